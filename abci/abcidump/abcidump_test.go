@@ -0,0 +1,88 @@
+package abcidump_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/abci/abcidump"
+	abciclient "github.com/tendermint/tendermint/abci/client"
+	"github.com/tendermint/tendermint/abci/example/kvstore"
+	"github.com/tendermint/tendermint/abci/server"
+	"github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+func TestProxyReplay(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logger := log.TestingLogger()
+
+	// some ports between 20k and 30k
+	port := 20000 + rand.Int31()%10000
+	appAddr := fmt.Sprintf("localhost:%d", port)
+	proxyAddr := fmt.Sprintf("localhost:%d", port+1)
+
+	s, err := server.NewServer(logger, appAddr, "socket", kvstore.NewApplication())
+	require.NoError(t, err)
+	require.NoError(t, s.Start(ctx))
+	t.Cleanup(s.Wait)
+
+	path := filepath.Join(t.TempDir(), "abci.dump")
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	proxy := abcidump.NewProxy(logger, proxyAddr, appAddr, file)
+	require.NoError(t, proxy.Start(ctx))
+
+	client := abciclient.NewSocketClient(logger, proxyAddr, true)
+	require.NoError(t, client.Start(ctx))
+
+	_, err = client.InfoSync(ctx, types.RequestInfo{})
+	require.NoError(t, err)
+	for height := int64(1); height <= 3; height++ {
+		tx := []byte(fmt.Sprintf("key%d=value", height))
+		_, err = client.CheckTxSync(ctx, types.RequestCheckTx{Tx: tx})
+		require.NoError(t, err)
+		_, err = client.FinalizeBlockSync(ctx, types.RequestFinalizeBlock{
+			Header: tmproto.Header{Height: height},
+			Txs:    [][]byte{tx},
+		})
+		require.NoError(t, err)
+		_, err = client.CommitSync(ctx)
+		require.NoError(t, err)
+	}
+	res, err := client.QuerySync(ctx, types.RequestQuery{Path: "/store", Data: []byte("key2")})
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), res.Value)
+
+	cancel()
+	client.Wait()
+	proxy.Wait()
+
+	// The dump replays against a new instance of the application.
+	file, err = os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	n, err := abcidump.Replay(file, abcidump.AppHandler(kvstore.NewApplication()))
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, n, 11)
+
+	// A different application responds differently.
+	_, err = file.Seek(0, 0)
+	require.NoError(t, err)
+	_, err = abcidump.Replay(file, abcidump.AppHandler(types.NewBaseApplication()))
+	var mismatch *abcidump.MismatchError
+	require.True(t, errors.As(err, &mismatch), "unexpected error %v", err)
+	assert.NotNil(t, mismatch.Record.Request)
+}
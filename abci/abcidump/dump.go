@@ -0,0 +1,91 @@
+// Package abcidump captures the ABCI traffic between Tendermint and an
+// application, and replays it against an application, so that failures can be
+// reproduced deterministically offline.
+//
+// A dump is a sequence of records, each made of the ID of the connection as a
+// uvarint, followed by the request and the response as varint
+// length-delimited protobuf messages.
+package abcidump
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/tendermint/tendermint/abci/types"
+)
+
+// Record is a request sent on an ABCI connection and its response.
+type Record struct {
+	Conn     uint64
+	Request  *types.Request
+	Response *types.Response
+}
+
+// Writer writes records to a dump. It is safe for concurrent use.
+type Writer struct {
+	mtx sync.Mutex
+	w   io.Writer
+}
+
+// NewWriter returns a writer of records to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// Write writes the record.
+func (w *Writer) Write(record Record) error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	buf := make([]byte, binary.MaxVarintLen64)
+	if _, err := w.w.Write(buf[:binary.PutUvarint(buf, record.Conn)]); err != nil {
+		return err
+	}
+	if err := types.WriteMessage(record.Request, w.w); err != nil {
+		return err
+	}
+	return types.WriteMessage(record.Response, w.w)
+}
+
+// Reader reads records from a dump.
+type Reader struct {
+	r *bufio.Reader
+}
+
+// NewReader returns a reader of records from r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: bufio.NewReader(r)}
+}
+
+// Read reads the next record. It returns io.EOF at the end of the dump.
+func (r *Reader) Read() (Record, error) {
+	conn, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		return Record{}, err
+	}
+	record := Record{
+		Conn:     conn,
+		Request:  &types.Request{},
+		Response: &types.Response{},
+	}
+	if err := types.ReadMessage(r.r, record.Request); err != nil {
+		return Record{}, fmt.Errorf("reading request: %w", unexpectedEOF(err))
+	}
+	if err := types.ReadMessage(r.r, record.Response); err != nil {
+		return Record{}, fmt.Errorf("reading response: %w", unexpectedEOF(err))
+	}
+	return record, nil
+}
+
+// unexpectedEOF converts io.EOF into io.ErrUnexpectedEOF, for records cut
+// short.
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
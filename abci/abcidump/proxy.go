@@ -0,0 +1,198 @@
+package abcidump
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	tmnet "github.com/tendermint/tendermint/libs/net"
+	"github.com/tendermint/tendermint/libs/service"
+)
+
+// Proxy is an ABCI socket server which forwards the connections it accepts to
+// the socket server of an application, recording the requests and responses
+// to a dump. Each connection is given an ID, in the order they are accepted.
+//
+// The records of all the connections are written in the order the responses
+// are received from the application.
+type Proxy struct {
+	service.BaseService
+	logger log.Logger
+
+	proto    string
+	addr     string
+	appAddr  string
+	listener net.Listener
+	writer   *Writer
+
+	connsMtx   sync.Mutex
+	conns      map[uint64]io.Closer
+	nextConnID uint64
+}
+
+// NewProxy returns a proxy listening on protoAddr, forwarding connections to
+// the application at appAddr, and writing records to w.
+func NewProxy(logger log.Logger, protoAddr, appAddr string, w io.Writer) *Proxy {
+	proto, addr := tmnet.ProtocolAndAddress(protoAddr)
+	p := &Proxy{
+		logger:  logger,
+		proto:   proto,
+		addr:    addr,
+		appAddr: appAddr,
+		writer:  NewWriter(w),
+		conns:   make(map[uint64]io.Closer),
+	}
+	p.BaseService = *service.NewBaseService(logger, "ABCIDumpProxy", p)
+	return p
+}
+
+func (p *Proxy) OnStart(ctx context.Context) error {
+	ln, err := net.Listen(p.proto, p.addr)
+	if err != nil {
+		return err
+	}
+
+	p.listener = ln
+	go p.acceptConnectionsRoutine(ctx)
+
+	return nil
+}
+
+func (p *Proxy) OnStop() {
+	if err := p.listener.Close(); err != nil {
+		p.logger.Error("error closing listener", "err", err)
+	}
+
+	p.connsMtx.Lock()
+	defer p.connsMtx.Unlock()
+
+	for id, conn := range p.conns {
+		delete(p.conns, id)
+		if err := conn.Close(); err != nil {
+			p.logger.Error("error closing connection", "conn", id, "err", err)
+		}
+	}
+}
+
+func (p *Proxy) acceptConnectionsRoutine(ctx context.Context) {
+	for {
+		conn, err := p.listener.Accept()
+		if err != nil {
+			if !p.IsRunning() || ctx.Err() != nil {
+				return // Ignore error from listener closing.
+			}
+			p.logger.Error("failed to accept connection", "err", err)
+			continue
+		}
+
+		appConn, err := tmnet.Connect(p.appAddr)
+		if err != nil {
+			p.logger.Error("failed to connect to the application", "addr", p.appAddr, "err", err)
+			conn.Close()
+			continue
+		}
+
+		go p.proxyConn(ctx, conn, appConn)
+	}
+}
+
+// connPair closes the connection to the client along with the one to the
+// application.
+type connPair struct {
+	conn, appConn net.Conn
+}
+
+func (c connPair) Close() error {
+	err := c.conn.Close()
+	if appErr := c.appConn.Close(); err == nil {
+		err = appErr
+	}
+	return err
+}
+
+// proxyConn forwards the requests read from conn to appConn, and the responses
+// read from appConn to conn, recording them, until either fails.
+func (p *Proxy) proxyConn(ctx context.Context, conn, appConn net.Conn) {
+	p.connsMtx.Lock()
+	connID := p.nextConnID
+	p.nextConnID++
+	p.conns[connID] = connPair{conn, appConn}
+	p.connsMtx.Unlock()
+
+	logger := p.logger.With("conn", connID)
+	logger.Info("proxying new connection")
+
+	// The application responds to the requests of a connection in order, so
+	// the pending requests are matched with the responses in that order.
+	pending := make(chan *types.Request, 1000)
+	errCh := make(chan error, 2)
+	go func() {
+		errCh <- p.forwardRequests(conn, appConn, pending)
+	}()
+	go func() {
+		errCh <- p.forwardResponses(connID, appConn, conn, pending)
+	}()
+
+	select {
+	case <-ctx.Done():
+	case err := <-errCh:
+		if err != io.EOF {
+			logger.Error("proxied connection failed", "err", err)
+		}
+	}
+
+	p.connsMtx.Lock()
+	defer p.connsMtx.Unlock()
+	if c, ok := p.conns[connID]; ok {
+		delete(p.conns, connID)
+		if err := c.Close(); err != nil {
+			logger.Error("error closing connection", "err", err)
+		}
+	}
+}
+
+func (p *Proxy) forwardRequests(conn io.Reader, appConn io.Writer, pending chan<- *types.Request) error {
+	r := bufio.NewReader(conn)
+	for {
+		req := &types.Request{}
+		if err := types.ReadMessage(r, req); err != nil {
+			return err
+		}
+		pending <- req
+		if err := types.WriteMessage(req, appConn); err != nil {
+			return fmt.Errorf("forwarding request: %w", err)
+		}
+	}
+}
+
+func (p *Proxy) forwardResponses(
+	connID uint64,
+	appConn io.Reader,
+	conn io.Writer,
+	pending <-chan *types.Request,
+) error {
+	r := bufio.NewReader(appConn)
+	for {
+		res := &types.Response{}
+		if err := types.ReadMessage(r, res); err != nil {
+			return err
+		}
+		var req *types.Request
+		select {
+		case req = <-pending:
+		default:
+			return fmt.Errorf("unexpected response %T without a request", res.Value)
+		}
+		if err := p.writer.Write(Record{Conn: connID, Request: req, Response: res}); err != nil {
+			return fmt.Errorf("recording response: %w", err)
+		}
+		if err := types.WriteMessage(res, conn); err != nil {
+			return fmt.Errorf("forwarding response: %w", err)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package abcidump
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/tendermint/tendermint/abci/server"
+	"github.com/tendermint/tendermint/abci/types"
+)
+
+// Handler passes a replayed request to an application and returns its
+// response.
+type Handler func(req *types.Request) (*types.Response, error)
+
+// AppHandler returns a handler passing requests to the application in the
+// same process, e.g. to debug it.
+func AppHandler(app types.Application) Handler {
+	return func(req *types.Request) (*types.Response, error) {
+		return server.HandleRequest(app, req), nil
+	}
+}
+
+// ConnHandler returns a handler sending requests on a connection to the
+// socket server of an application.
+func ConnHandler(conn io.ReadWriter) Handler {
+	r := bufio.NewReader(conn)
+	return func(req *types.Request) (*types.Response, error) {
+		if err := types.WriteMessage(req, conn); err != nil {
+			return nil, err
+		}
+		res := &types.Response{}
+		if err := types.ReadMessage(r, res); err != nil {
+			return nil, err
+		}
+		return res, nil
+	}
+}
+
+// MismatchError is returned by Replay when the application responds to a
+// request differently than in the dump.
+type MismatchError struct {
+	Index    int // the index of the record in the dump
+	Record   Record
+	Response *types.Response
+}
+
+func (e *MismatchError) Error() string {
+	return fmt.Sprintf("response to request %d (%T) on connection %d differs from the dump: expected %v, got %v",
+		e.Index, e.Record.Request.Value, e.Record.Conn, e.Record.Response, e.Response)
+}
+
+// Replay passes the requests of the dump read from r to the handler, in order,
+// and checks that the responses are the recorded ones. It stops at the first
+// response which differs, returning a *MismatchError, and returns the number
+// of requests replayed.
+func Replay(r io.Reader, handle Handler) (int, error) {
+	reader := NewReader(r)
+	for i := 0; ; i++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return i, nil
+		} else if err != nil {
+			return i, fmt.Errorf("reading record %d: %w", i, err)
+		}
+
+		res, err := handle(record.Request)
+		if err != nil {
+			return i, fmt.Errorf("replaying request %d (%T): %w", i, record.Request.Value, err)
+		}
+		if !proto.Equal(res, record.Response) {
+			return i + 1, &MismatchError{Index: i, Record: record, Response: res}
+		}
+	}
+}
@@ -26,4 +26,11 @@
 //
 // sync: waits for all Async calls to complete (essentially what Flush does in
 // the socket client) and calls Sync method.
+//
+// ## Interceptors
+//
+// Any client can be wrapped with NewInterceptedClient (or its clients created
+// with NewInterceptedCreator) to pass its Sync calls through a chain of
+// Interceptors, e.g. for logging, metrics, retries or request mutation. Async
+// calls are not intercepted.
 package abciclient
@@ -2,6 +2,7 @@ package abciclient
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"sync"
@@ -31,6 +32,17 @@ type grpcClient struct {
 	addr  string
 	err   error
 	resCb func(*types.Request, *types.Response) // listens to all callbacks
+
+	// In streaming mode, CheckTx requests are sent on a bidirectional stream
+	// instead of unary calls, and pending holds the requests sent on it that
+	// await responses, in order.
+	streaming  bool
+	sendMtx    sync.Mutex // serializes sends on the stream
+	streamMtx  sync.Mutex // guards pending
+	stream     types.ABCIApplication_CheckTxStreamClient
+	pending    []*ReqRes
+	stopStream context.CancelFunc
+	streamDone chan struct{}
 }
 
 var _ Client = (*grpcClient)(nil)
@@ -46,6 +58,18 @@ var _ Client = (*grpcClient)(nil)
 // protocol! maybe one day, if people really want it, we use grpc streams, but
 // hopefully not :D
 func NewGRPCClient(logger log.Logger, addr string, mustConnect bool) Client {
+	return newGRPCClient(logger, addr, mustConnect, false)
+}
+
+// NewGRPCStreamClient creates a gRPC client like NewGRPCClient, except that
+// CheckTx requests are pipelined on a bidirectional stream rather than sent
+// as unary calls, which avoids the per-call overhead for the mempool
+// connection. The server must support the CheckTxStream method.
+func NewGRPCStreamClient(logger log.Logger, addr string, mustConnect bool) Client {
+	return newGRPCClient(logger, addr, mustConnect, true)
+}
+
+func newGRPCClient(logger log.Logger, addr string, mustConnect, streaming bool) Client {
 	cli := &grpcClient{
 		logger:      logger,
 		addr:        addr,
@@ -56,7 +80,8 @@ func NewGRPCClient(logger log.Logger, addr string, mustConnect bool) Client {
 		// caller can make up to 64 async calls before a callback must be
 		// processed (otherwise it deadlocks). It also means that we can make 64
 		// gRPC calls while processing a slow callback at the channel head.
-		chReqRes: make(chan *ReqRes, 64),
+		chReqRes:  make(chan *ReqRes, 64),
+		streaming: streaming,
 	}
 	cli.BaseService = *service.NewBaseService(logger, "grpcClient", cli)
 	return cli
@@ -134,17 +159,120 @@ RETRY_LOOP:
 		}
 
 		cli.client = client
+		if cli.streaming {
+			return cli.startStream()
+		}
 		return nil
 	}
 }
 
 func (cli *grpcClient) OnStop() {
+	if cli.stopStream != nil {
+		cli.stopStream()
+		<-cli.streamDone
+	}
 	if cli.conn != nil {
 		cli.conn.Close()
 	}
 	close(cli.chReqRes)
 }
 
+// startStream opens the CheckTx stream, and starts dispatching its responses
+// to the pending requests.
+func (cli *grpcClient) startStream() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := cli.client.CheckTxStream(ctx, grpc.WaitForReady(true))
+	if err != nil {
+		cancel()
+		return err
+	}
+	cli.stream, cli.stopStream = stream, cancel
+	cli.streamDone = make(chan struct{})
+	go cli.recvStreamRoutine(ctx)
+	return nil
+}
+
+func (cli *grpcClient) recvStreamRoutine(ctx context.Context) {
+	defer close(cli.streamDone)
+	for {
+		res, err := cli.stream.Recv()
+		if err != nil {
+			cli.failStream(ctx, fmt.Errorf("CheckTx stream failed: %w", err))
+			return
+		}
+
+		cli.streamMtx.Lock()
+		if len(cli.pending) == 0 {
+			cli.streamMtx.Unlock()
+			cli.failStream(ctx, errors.New("unexpected response on the CheckTx stream"))
+			return
+		}
+		reqres := cli.pending[0]
+		cli.pending = cli.pending[1:]
+		cli.streamMtx.Unlock()
+
+		reqres.Response = types.ToResponseCheckTx(*res)
+		select {
+		case cli.chReqRes <- reqres:
+		case <-ctx.Done():
+			reqres.Done()
+			cli.failStream(ctx, ctx.Err())
+			return
+		}
+	}
+}
+
+// failStream marks the pending requests of the stream as done, so that
+// callers waiting for them return, and stops the client with err unless the
+// stream was closed by stopping it.
+func (cli *grpcClient) failStream(ctx context.Context, err error) {
+	stopped := ctx.Err() != nil
+	if !stopped {
+		cli.mtx.Lock()
+		if cli.err == nil {
+			cli.err = err
+		}
+		cli.mtx.Unlock()
+	}
+
+	cli.streamMtx.Lock()
+	for _, reqres := range cli.pending {
+		reqres.Done()
+	}
+	cli.pending = nil
+	cli.streamMtx.Unlock()
+
+	if !stopped {
+		go cli.StopForError(err)
+	}
+}
+
+// lastPending returns the last request sent on the stream which awaits a
+// response, if any.
+func (cli *grpcClient) lastPending() *ReqRes {
+	cli.streamMtx.Lock()
+	defer cli.streamMtx.Unlock()
+	if len(cli.pending) == 0 {
+		return nil
+	}
+	return cli.pending[len(cli.pending)-1]
+}
+
+// waitPending waits until the given request sent on the stream is done.
+func waitPending(ctx context.Context, reqres *ReqRes) error {
+	done := make(chan struct{})
+	go func() {
+		reqres.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 func (cli *grpcClient) StopForError(err error) {
 	if !cli.IsRunning() {
 		return
@@ -188,8 +316,14 @@ func (cli *grpcClient) EchoAsync(ctx context.Context, msg string) (*ReqRes, erro
 	return cli.finishAsyncCall(ctx, req, &types.Response{Value: &types.Response_Echo{Echo: res}})
 }
 
-// NOTE: call is synchronous, use ctx to break early if needed
+// NOTE: call is synchronous, use ctx to break early if needed. In streaming
+// mode, it waits for the pending CheckTx requests first.
 func (cli *grpcClient) FlushAsync(ctx context.Context) (*ReqRes, error) {
+	if reqres := cli.lastPending(); reqres != nil {
+		if err := waitPending(ctx, reqres); err != nil {
+			return nil, err
+		}
+	}
 	req := types.ToRequestFlush()
 	res, err := cli.client.Flush(ctx, req.GetFlush(), grpc.WaitForReady(true))
 	if err != nil {
@@ -208,19 +342,13 @@ func (cli *grpcClient) InfoAsync(ctx context.Context, params types.RequestInfo)
 	return cli.finishAsyncCall(ctx, req, &types.Response{Value: &types.Response_Info{Info: res}})
 }
 
-// NOTE: call is synchronous, use ctx to break early if needed
-func (cli *grpcClient) DeliverTxAsync(ctx context.Context, params types.RequestDeliverTx) (*ReqRes, error) {
-	req := types.ToRequestDeliverTx(params)
-	res, err := cli.client.DeliverTx(ctx, req.GetDeliverTx(), grpc.WaitForReady(true))
-	if err != nil {
-		return nil, err
-	}
-	return cli.finishAsyncCall(ctx, req, &types.Response{Value: &types.Response_DeliverTx{DeliverTx: res}})
-}
-
-// NOTE: call is synchronous, use ctx to break early if needed
+// NOTE: call is synchronous, use ctx to break early if needed, unless in
+// streaming mode, where the request is only sent.
 func (cli *grpcClient) CheckTxAsync(ctx context.Context, params types.RequestCheckTx) (*ReqRes, error) {
 	req := types.ToRequestCheckTx(params)
+	if cli.streaming {
+		return cli.sendCheckTx(req)
+	}
 	res, err := cli.client.CheckTx(ctx, req.GetCheckTx(), grpc.WaitForReady(true))
 	if err != nil {
 		return nil, err
@@ -258,6 +386,16 @@ func (cli *grpcClient) InitChainAsync(ctx context.Context, params types.RequestI
 	return cli.finishAsyncCall(ctx, req, &types.Response{Value: &types.Response_InitChain{InitChain: res}})
 }
 
+// NOTE: call is synchronous, use ctx to break early if needed
+func (cli *grpcClient) FinalizeBlockAsync(ctx context.Context, params types.RequestFinalizeBlock) (*ReqRes, error) {
+	req := types.ToRequestFinalizeBlock(params)
+	res, err := cli.client.FinalizeBlock(ctx, req.GetFinalizeBlock(), grpc.WaitForReady(true))
+	if err != nil {
+		return nil, err
+	}
+	return cli.finishAsyncCall(ctx, req, &types.Response{Value: &types.Response_FinalizeBlock{FinalizeBlock: res}})
+}
+
 // NOTE: call is synchronous, use ctx to break early if needed
 func (cli *grpcClient) BeginBlockAsync(ctx context.Context, params types.RequestBeginBlock) (*ReqRes, error) {
 	req := types.ToRequestBeginBlock(params)
@@ -268,6 +406,16 @@ func (cli *grpcClient) BeginBlockAsync(ctx context.Context, params types.Request
 	return cli.finishAsyncCall(ctx, req, &types.Response{Value: &types.Response_BeginBlock{BeginBlock: res}})
 }
 
+// NOTE: call is synchronous, use ctx to break early if needed
+func (cli *grpcClient) DeliverTxAsync(ctx context.Context, params types.RequestDeliverTx) (*ReqRes, error) {
+	req := types.ToRequestDeliverTx(params)
+	res, err := cli.client.DeliverTx(ctx, req.GetDeliverTx(), grpc.WaitForReady(true))
+	if err != nil {
+		return nil, err
+	}
+	return cli.finishAsyncCall(ctx, req, &types.Response{Value: &types.Response_DeliverTx{DeliverTx: res}})
+}
+
 // NOTE: call is synchronous, use ctx to break early if needed
 func (cli *grpcClient) EndBlockAsync(ctx context.Context, params types.RequestEndBlock) (*ReqRes, error) {
 	req := types.ToRequestEndBlock(params)
@@ -278,6 +426,26 @@ func (cli *grpcClient) EndBlockAsync(ctx context.Context, params types.RequestEn
 	return cli.finishAsyncCall(ctx, req, &types.Response{Value: &types.Response_EndBlock{EndBlock: res}})
 }
 
+// NOTE: call is synchronous, use ctx to break early if needed
+func (cli *grpcClient) PrepareProposalAsync(ctx context.Context, params types.RequestPrepareProposal) (*ReqRes, error) {
+	req := types.ToRequestPrepareProposal(params)
+	res, err := cli.client.PrepareProposal(ctx, req.GetPrepareProposal(), grpc.WaitForReady(true))
+	if err != nil {
+		return nil, err
+	}
+	return cli.finishAsyncCall(ctx, req, &types.Response{Value: &types.Response_PrepareProposal{PrepareProposal: res}})
+}
+
+// NOTE: call is synchronous, use ctx to break early if needed
+func (cli *grpcClient) ProcessProposalAsync(ctx context.Context, params types.RequestProcessProposal) (*ReqRes, error) {
+	req := types.ToRequestProcessProposal(params)
+	res, err := cli.client.ProcessProposal(ctx, req.GetProcessProposal(), grpc.WaitForReady(true))
+	if err != nil {
+		return nil, err
+	}
+	return cli.finishAsyncCall(ctx, req, &types.Response{Value: &types.Response_ProcessProposal{ProcessProposal: res}})
+}
+
 // NOTE: call is synchronous, use ctx to break early if needed
 func (cli *grpcClient) ListSnapshotsAsync(ctx context.Context, params types.RequestListSnapshots) (*ReqRes, error) {
 	req := types.ToRequestListSnapshots(params)
@@ -328,6 +496,31 @@ func (cli *grpcClient) ApplySnapshotChunkAsync(
 	)
 }
 
+// sendCheckTx sends a CheckTx request on the stream. The response is
+// dispatched to the ReqRes in order by recvStreamRoutine.
+func (cli *grpcClient) sendCheckTx(req *types.Request) (*ReqRes, error) {
+	reqres := NewReqRes(req)
+
+	// The request is queued before sending it, as its response may arrive
+	// before Send returns, but the queue is not locked while sending, as Send
+	// may block until responses are received.
+	cli.sendMtx.Lock()
+	defer cli.sendMtx.Unlock()
+	if err := cli.Error(); err != nil {
+		return nil, err
+	}
+	cli.streamMtx.Lock()
+	cli.pending = append(cli.pending, reqres)
+	cli.streamMtx.Unlock()
+
+	// If sending fails, the stream is broken and Recv fails too, releasing
+	// the pending requests.
+	if err := cli.stream.Send(req.GetCheckTx()); err != nil {
+		return nil, err
+	}
+	return reqres, nil
+}
+
 // finishAsyncCall creates a ReqRes for an async call, and immediately populates it
 // with the response. We don't complete it until it's been ordered via the channel.
 func (cli *grpcClient) finishAsyncCall(ctx context.Context, req *types.Request, res *types.Response) (*ReqRes, error) {
@@ -372,6 +565,12 @@ func (cli *grpcClient) finishSyncCall(reqres *ReqRes) *types.Response {
 //----------------------------------------
 
 func (cli *grpcClient) FlushSync(ctx context.Context) error {
+	if reqres := cli.lastPending(); reqres != nil {
+		if err := waitPending(ctx, reqres); err != nil {
+			return err
+		}
+		return cli.Error()
+	}
 	return nil
 }
 
@@ -394,18 +593,6 @@ func (cli *grpcClient) InfoSync(
 	return cli.finishSyncCall(reqres).GetInfo(), cli.Error()
 }
 
-func (cli *grpcClient) DeliverTxSync(
-	ctx context.Context,
-	params types.RequestDeliverTx,
-) (*types.ResponseDeliverTx, error) {
-
-	reqres, err := cli.DeliverTxAsync(ctx, params)
-	if err != nil {
-		return nil, err
-	}
-	return cli.finishSyncCall(reqres).GetDeliverTx(), cli.Error()
-}
-
 func (cli *grpcClient) CheckTxSync(
 	ctx context.Context,
 	params types.RequestCheckTx,
@@ -415,6 +602,19 @@ func (cli *grpcClient) CheckTxSync(
 	if err != nil {
 		return nil, err
 	}
+	if cli.streaming {
+		// the response may never be dispatched if the stream fails.
+		if err := waitPending(ctx, reqres); err != nil {
+			return nil, err
+		}
+		if reqres.Response == nil {
+			if err := cli.Error(); err != nil {
+				return nil, err
+			}
+			return nil, errors.New("CheckTx stream closed")
+		}
+		return reqres.Response.GetCheckTx(), cli.Error()
+	}
 	return cli.finishSyncCall(reqres).GetCheckTx(), cli.Error()
 }
 
@@ -449,6 +649,18 @@ func (cli *grpcClient) InitChainSync(
 	return cli.finishSyncCall(reqres).GetInitChain(), cli.Error()
 }
 
+func (cli *grpcClient) FinalizeBlockSync(
+	ctx context.Context,
+	params types.RequestFinalizeBlock,
+) (*types.ResponseFinalizeBlock, error) {
+
+	reqres, err := cli.FinalizeBlockAsync(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return cli.finishSyncCall(reqres).GetFinalizeBlock(), cli.Error()
+}
+
 func (cli *grpcClient) BeginBlockSync(
 	ctx context.Context,
 	params types.RequestBeginBlock,
@@ -473,6 +685,30 @@ func (cli *grpcClient) EndBlockSync(
 	return cli.finishSyncCall(reqres).GetEndBlock(), cli.Error()
 }
 
+func (cli *grpcClient) PrepareProposalSync(
+	ctx context.Context,
+	params types.RequestPrepareProposal,
+) (*types.ResponsePrepareProposal, error) {
+
+	reqres, err := cli.PrepareProposalAsync(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return cli.finishSyncCall(reqres).GetPrepareProposal(), cli.Error()
+}
+
+func (cli *grpcClient) ProcessProposalSync(
+	ctx context.Context,
+	params types.RequestProcessProposal,
+) (*types.ResponseProcessProposal, error) {
+
+	reqres, err := cli.ProcessProposalAsync(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return cli.finishSyncCall(reqres).GetProcessProposal(), cli.Error()
+}
+
 func (cli *grpcClient) ListSnapshotsSync(
 	ctx context.Context,
 	params types.RequestListSnapshots,
@@ -0,0 +1,341 @@
+package abciclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// Invoker sends an ABCI request to the application and returns its response.
+type Invoker func(ctx context.Context, req *types.Request) (*types.Response, error)
+
+// Interceptor intercepts an ABCI request, e.g. to log it, record metrics or
+// retry it. It may modify the request before calling invoke to send it, and
+// the response before returning it, or return without calling invoke at all.
+// The response must be of the type of the request.
+type Interceptor func(ctx context.Context, req *types.Request, invoke Invoker) (*types.Response, error)
+
+// ChainInterceptors chains the interceptors into one, the first being the
+// outermost.
+func ChainInterceptors(interceptors ...Interceptor) Interceptor {
+	return func(ctx context.Context, req *types.Request, invoke Invoker) (*types.Response, error) {
+		chained := invoke
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], chained
+			chained = func(ctx context.Context, req *types.Request) (*types.Response, error) {
+				return interceptor(ctx, req, next)
+			}
+		}
+		return chained(ctx, req)
+	}
+}
+
+// TimeoutInterceptor returns an interceptor bounding the duration of each
+// request by timeout, unless the caller's context has an earlier deadline, or
+// passing the requests through if timeout isn't positive. Requests which time
+// out fail with an error wrapping context.DeadlineExceeded, whatever the error
+// of the transport.
+//
+// The socket and gRPC clients give up on requests once their context is done,
+// but the requests of a local client run to completion, since it calls the
+// application directly.
+func TimeoutInterceptor(timeout time.Duration) Interceptor {
+	return func(ctx context.Context, req *types.Request, invoke Invoker) (*types.Response, error) {
+		if timeout <= 0 {
+			return invoke(ctx, req)
+		}
+		callCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		res, err := invoke(callCtx, req)
+		if err != nil && ctx.Err() == nil && errors.Is(callCtx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("ABCI request %T timed out after %v: %w", req.Value, timeout, context.DeadlineExceeded)
+		}
+		return res, err
+	}
+}
+
+// NewInterceptedCreator returns a Creator whose clients are created by the
+// given creator and intercepted by the interceptors.
+func NewInterceptedCreator(creator Creator, interceptors ...Interceptor) Creator {
+	return func(logger log.Logger) (Client, error) {
+		client, err := creator(logger)
+		if err != nil {
+			return nil, err
+		}
+		return NewInterceptedClient(client, interceptors...), nil
+	}
+}
+
+// interceptedClient wraps a client, passing its synchronous requests through
+// an interceptor.
+type interceptedClient struct {
+	Client
+	intercept Interceptor
+}
+
+var _ Client = (*interceptedClient)(nil)
+
+// NewInterceptedClient returns a client passing the synchronous requests of
+// the given client through the interceptors, the first being the outermost.
+//
+// Asynchronous requests are sent as is, since their responses are delivered to
+// callbacks in the order of the requests.
+func NewInterceptedClient(client Client, interceptors ...Interceptor) Client {
+	return &interceptedClient{
+		Client:    client,
+		intercept: ChainInterceptors(interceptors...),
+	}
+}
+
+// Stop stops the underlying client, if it can be stopped.
+func (cli *interceptedClient) Stop() error {
+	if c, ok := cli.Client.(interface{ Stop() error }); ok {
+		return c.Stop()
+	}
+	return nil
+}
+
+// invoke sends the request to the underlying client.
+func (cli *interceptedClient) invoke(ctx context.Context, req *types.Request) (*types.Response, error) {
+	switch r := req.Value.(type) {
+	case *types.Request_Echo:
+		res, err := cli.Client.EchoSync(ctx, r.Echo.Message)
+		if err != nil {
+			return nil, err
+		}
+		return types.ToResponseEcho(res.Message), nil
+	case *types.Request_Flush:
+		if err := cli.Client.FlushSync(ctx); err != nil {
+			return nil, err
+		}
+		return types.ToResponseFlush(), nil
+	case *types.Request_Info:
+		res, err := cli.Client.InfoSync(ctx, *r.Info)
+		if err != nil {
+			return nil, err
+		}
+		return types.ToResponseInfo(*res), nil
+	case *types.Request_CheckTx:
+		res, err := cli.Client.CheckTxSync(ctx, *r.CheckTx)
+		if err != nil {
+			return nil, err
+		}
+		return types.ToResponseCheckTx(*res), nil
+	case *types.Request_Query:
+		res, err := cli.Client.QuerySync(ctx, *r.Query)
+		if err != nil {
+			return nil, err
+		}
+		return types.ToResponseQuery(*res), nil
+	case *types.Request_Commit:
+		res, err := cli.Client.CommitSync(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return types.ToResponseCommit(*res), nil
+	case *types.Request_InitChain:
+		res, err := cli.Client.InitChainSync(ctx, *r.InitChain)
+		if err != nil {
+			return nil, err
+		}
+		return types.ToResponseInitChain(*res), nil
+	case *types.Request_FinalizeBlock:
+		res, err := cli.Client.FinalizeBlockSync(ctx, *r.FinalizeBlock)
+		if err != nil {
+			return nil, err
+		}
+		return types.ToResponseFinalizeBlock(*res), nil
+	case *types.Request_PrepareProposal:
+		res, err := cli.Client.PrepareProposalSync(ctx, *r.PrepareProposal)
+		if err != nil {
+			return nil, err
+		}
+		return types.ToResponsePrepareProposal(*res), nil
+	case *types.Request_ProcessProposal:
+		res, err := cli.Client.ProcessProposalSync(ctx, *r.ProcessProposal)
+		if err != nil {
+			return nil, err
+		}
+		return types.ToResponseProcessProposal(*res), nil
+	case *types.Request_ListSnapshots:
+		res, err := cli.Client.ListSnapshotsSync(ctx, *r.ListSnapshots)
+		if err != nil {
+			return nil, err
+		}
+		return types.ToResponseListSnapshots(*res), nil
+	case *types.Request_OfferSnapshot:
+		res, err := cli.Client.OfferSnapshotSync(ctx, *r.OfferSnapshot)
+		if err != nil {
+			return nil, err
+		}
+		return types.ToResponseOfferSnapshot(*res), nil
+	case *types.Request_LoadSnapshotChunk:
+		res, err := cli.Client.LoadSnapshotChunkSync(ctx, *r.LoadSnapshotChunk)
+		if err != nil {
+			return nil, err
+		}
+		return types.ToResponseLoadSnapshotChunk(*res), nil
+	case *types.Request_ApplySnapshotChunk:
+		res, err := cli.Client.ApplySnapshotChunkSync(ctx, *r.ApplySnapshotChunk)
+		if err != nil {
+			return nil, err
+		}
+		return types.ToResponseApplySnapshotChunk(*res), nil
+	default:
+		return nil, fmt.Errorf("unknown ABCI request type %T", req.Value)
+	}
+}
+
+// call passes the request through the interceptor, checking the type of the
+// response.
+func (cli *interceptedClient) call(ctx context.Context, req *types.Request) (*types.Response, error) {
+	res, err := cli.intercept(ctx, req, cli.invoke)
+	if err != nil {
+		return nil, err
+	}
+	if res == nil || !resMatchesReq(req, res) {
+		return nil, fmt.Errorf("unexpected ABCI response type %T for request %T", res.GetValue(), req.Value)
+	}
+	return res, nil
+}
+
+func (cli *interceptedClient) FlushSync(ctx context.Context) error {
+	_, err := cli.call(ctx, types.ToRequestFlush())
+	return err
+}
+
+func (cli *interceptedClient) EchoSync(ctx context.Context, msg string) (*types.ResponseEcho, error) {
+	res, err := cli.call(ctx, types.ToRequestEcho(msg))
+	if err != nil {
+		return nil, err
+	}
+	return res.GetEcho(), nil
+}
+
+func (cli *interceptedClient) InfoSync(ctx context.Context, req types.RequestInfo) (*types.ResponseInfo, error) {
+	res, err := cli.call(ctx, types.ToRequestInfo(req))
+	if err != nil {
+		return nil, err
+	}
+	return res.GetInfo(), nil
+}
+
+func (cli *interceptedClient) CheckTxSync(
+	ctx context.Context,
+	req types.RequestCheckTx,
+) (*types.ResponseCheckTx, error) {
+	res, err := cli.call(ctx, types.ToRequestCheckTx(req))
+	if err != nil {
+		return nil, err
+	}
+	return res.GetCheckTx(), nil
+}
+
+func (cli *interceptedClient) QuerySync(ctx context.Context, req types.RequestQuery) (*types.ResponseQuery, error) {
+	res, err := cli.call(ctx, types.ToRequestQuery(req))
+	if err != nil {
+		return nil, err
+	}
+	return res.GetQuery(), nil
+}
+
+func (cli *interceptedClient) CommitSync(ctx context.Context) (*types.ResponseCommit, error) {
+	res, err := cli.call(ctx, types.ToRequestCommit())
+	if err != nil {
+		return nil, err
+	}
+	return res.GetCommit(), nil
+}
+
+func (cli *interceptedClient) InitChainSync(
+	ctx context.Context,
+	req types.RequestInitChain,
+) (*types.ResponseInitChain, error) {
+	res, err := cli.call(ctx, types.ToRequestInitChain(req))
+	if err != nil {
+		return nil, err
+	}
+	return res.GetInitChain(), nil
+}
+
+func (cli *interceptedClient) FinalizeBlockSync(
+	ctx context.Context,
+	req types.RequestFinalizeBlock,
+) (*types.ResponseFinalizeBlock, error) {
+	res, err := cli.call(ctx, types.ToRequestFinalizeBlock(req))
+	if err != nil {
+		return nil, err
+	}
+	return res.GetFinalizeBlock(), nil
+}
+
+func (cli *interceptedClient) PrepareProposalSync(
+	ctx context.Context,
+	req types.RequestPrepareProposal,
+) (*types.ResponsePrepareProposal, error) {
+	res, err := cli.call(ctx, types.ToRequestPrepareProposal(req))
+	if err != nil {
+		return nil, err
+	}
+	return res.GetPrepareProposal(), nil
+}
+
+func (cli *interceptedClient) ProcessProposalSync(
+	ctx context.Context,
+	req types.RequestProcessProposal,
+) (*types.ResponseProcessProposal, error) {
+	res, err := cli.call(ctx, types.ToRequestProcessProposal(req))
+	if err != nil {
+		return nil, err
+	}
+	return res.GetProcessProposal(), nil
+}
+
+func (cli *interceptedClient) ListSnapshotsSync(
+	ctx context.Context,
+	req types.RequestListSnapshots,
+) (*types.ResponseListSnapshots, error) {
+	res, err := cli.call(ctx, types.ToRequestListSnapshots(req))
+	if err != nil {
+		return nil, err
+	}
+	return res.GetListSnapshots(), nil
+}
+
+func (cli *interceptedClient) OfferSnapshotSync(
+	ctx context.Context,
+	req types.RequestOfferSnapshot,
+) (*types.ResponseOfferSnapshot, error) {
+	res, err := cli.call(ctx, types.ToRequestOfferSnapshot(req))
+	if err != nil {
+		return nil, err
+	}
+	return res.GetOfferSnapshot(), nil
+}
+
+func (cli *interceptedClient) LoadSnapshotChunkSync(
+	ctx context.Context,
+	req types.RequestLoadSnapshotChunk,
+) (*types.ResponseLoadSnapshotChunk, error) {
+	res, err := cli.call(ctx, types.ToRequestLoadSnapshotChunk(req))
+	if err != nil {
+		return nil, err
+	}
+	return res.GetLoadSnapshotChunk(), nil
+}
+
+func (cli *interceptedClient) ApplySnapshotChunkSync(
+	ctx context.Context,
+	req types.RequestApplySnapshotChunk,
+) (*types.ResponseApplySnapshotChunk, error) {
+	res, err := cli.call(ctx, types.ToRequestApplySnapshotChunk(req))
+	if err != nil {
+		return nil, err
+	}
+	return res.GetApplySnapshotChunk(), nil
+}
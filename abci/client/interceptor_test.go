@@ -0,0 +1,134 @@
+package abciclient_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abciclient "github.com/tendermint/tendermint/abci/client"
+	"github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+type queryApp struct {
+	types.BaseApplication
+}
+
+func (queryApp) Query(req types.RequestQuery) types.ResponseQuery {
+	return types.ResponseQuery{Log: req.Path}
+}
+
+func TestInterceptedClient(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var calls []string
+	record := func(name string) abciclient.Interceptor {
+		return func(
+			ctx context.Context,
+			req *types.Request,
+			invoke abciclient.Invoker,
+		) (*types.Response, error) {
+			calls = append(calls, name+" before")
+			res, err := invoke(ctx, req)
+			calls = append(calls, name+" after")
+			return res, err
+		}
+	}
+	// rewrites the path of queries and the log of their responses
+	rewrite := func(ctx context.Context, req *types.Request, invoke abciclient.Invoker) (*types.Response, error) {
+		if query := req.GetQuery(); query != nil {
+			query.Path = "/rewritten"
+		}
+		res, err := invoke(ctx, req)
+		if query := res.GetQuery(); query != nil {
+			query.Log += "!"
+		}
+		return res, err
+	}
+
+	client := abciclient.NewInterceptedClient(
+		abciclient.NewLocalClient(log.NewNopLogger(), nil, queryApp{}),
+		record("outer"), record("inner"), rewrite,
+	)
+
+	res, err := client.QuerySync(ctx, types.RequestQuery{Path: "/store"})
+	require.NoError(t, err)
+	assert.Equal(t, "/rewritten!", res.Log)
+	assert.Equal(t, []string{"outer before", "inner before", "inner after", "outer after"}, calls)
+
+	// asynchronous requests are not intercepted
+	calls = nil
+	client.SetResponseCallback(func(*types.Request, *types.Response) {})
+	reqRes, err := client.QueryAsync(ctx, types.RequestQuery{Path: "/store"})
+	require.NoError(t, err)
+	assert.Equal(t, "/store", reqRes.Response.GetQuery().Log)
+	assert.Empty(t, calls)
+}
+
+func TestInterceptedClientShortCircuit(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var mtx sync.Mutex
+	invoked := 0
+	cache := func(ctx context.Context, req *types.Request, invoke abciclient.Invoker) (*types.Response, error) {
+		if req.GetInfo() != nil {
+			return types.ToResponseInfo(types.ResponseInfo{Data: "cached"}), nil
+		}
+		mtx.Lock()
+		invoked++
+		mtx.Unlock()
+		return invoke(ctx, req)
+	}
+	client := abciclient.NewInterceptedClient(
+		abciclient.NewLocalClient(log.NewNopLogger(), nil, types.NewBaseApplication()), cache)
+
+	res, err := client.InfoSync(ctx, types.RequestInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, "cached", res.Data)
+
+	_, err = client.CommitSync(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, invoked)
+
+	// responses of the wrong type are rejected
+	wrong := func(ctx context.Context, req *types.Request, invoke abciclient.Invoker) (*types.Response, error) {
+		return types.ToResponseFlush(), nil
+	}
+	client = abciclient.NewInterceptedClient(
+		abciclient.NewLocalClient(log.NewNopLogger(), nil, types.NewBaseApplication()), wrong)
+	_, err = client.InfoSync(ctx, types.RequestInfo{})
+	require.Error(t, err)
+	require.NoError(t, client.FlushSync(ctx))
+}
+
+func TestTimeoutInterceptor(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, c := setupClientServer(ctx, t, log.TestingLogger(), slowApp{})
+	client := abciclient.NewInterceptedClient(c, abciclient.TimeoutInterceptor(50*time.Millisecond))
+
+	start := time.Now()
+	_, err := client.FinalizeBlockSync(ctx, types.RequestFinalizeBlock{})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 200*time.Millisecond)
+
+	// the late response of the abandoned request is discarded, the next ones
+	// waiting for it on the connection
+	_, err = c.CommitSync(ctx)
+	require.NoError(t, err)
+	require.NoError(t, c.Error())
+
+	// requests not timing out are passed through
+	client = abciclient.NewInterceptedClient(c, abciclient.TimeoutInterceptor(time.Second))
+	res, err := client.FinalizeBlockSync(ctx, types.RequestFinalizeBlock{})
+	require.NoError(t, err)
+	assert.NotNil(t, res)
+}
@@ -0,0 +1,128 @@
+package abciclient
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tendermint/tendermint/abci/types"
+)
+
+// errLegacyAsync is returned for the asynchronous requests a legacy client
+// can't make, since it answers them locally or with several requests.
+var errLegacyAsync = errors.New("asynchronous request not supported by legacy applications")
+
+// LegacyClient is a client of a remote application which can make the
+// BeginBlock, DeliverTx and EndBlock calls of legacy applications, as the
+// socket and gRPC clients can.
+type LegacyClient interface {
+	Client
+
+	BeginBlockSync(context.Context, types.RequestBeginBlock) (*types.ResponseBeginBlock, error)
+	DeliverTxAsync(context.Context, types.RequestDeliverTx) (*ReqRes, error)
+	EndBlockSync(context.Context, types.RequestEndBlock) (*types.ResponseEndBlock, error)
+}
+
+// legacyClient wraps the client of a remote legacy application, translating
+// the calls it predates.
+type legacyClient struct {
+	LegacyClient
+}
+
+var _ Client = (*legacyClient)(nil)
+
+// NewLegacyClient returns a client of a remote application executing blocks
+// with BeginBlock, DeliverTx and EndBlock calls rather than FinalizeBlock (see
+// types.LegacyApplication), e.g. one built against a previous version of the
+// ABCI. FinalizeBlock is sent as a BeginBlock request, a DeliverTx request per
+// transaction and an EndBlock request, and PrepareProposal and
+// ProcessProposal are answered without calling the application, as
+// types.BaseApplication does.
+func NewLegacyClient(client LegacyClient) Client {
+	return &legacyClient{client}
+}
+
+// Stop stops the underlying client, if it can be stopped.
+func (cli *legacyClient) Stop() error {
+	if c, ok := cli.LegacyClient.(interface{ Stop() error }); ok {
+		return c.Stop()
+	}
+	return nil
+}
+
+func (cli *legacyClient) FinalizeBlockAsync(context.Context, types.RequestFinalizeBlock) (*ReqRes, error) {
+	return nil, errLegacyAsync
+}
+
+func (cli *legacyClient) PrepareProposalAsync(context.Context, types.RequestPrepareProposal) (*ReqRes, error) {
+	return nil, errLegacyAsync
+}
+
+func (cli *legacyClient) ProcessProposalAsync(context.Context, types.RequestProcessProposal) (*ReqRes, error) {
+	return nil, errLegacyAsync
+}
+
+// FinalizeBlockSync executes the block with a BeginBlock call, a DeliverTx
+// call for each transaction and an EndBlock call, pipelining the DeliverTx
+// requests. The events of BeginBlock are returned as the BeginBlockEvents of
+// the block, and those of EndBlock as its Events.
+func (cli *legacyClient) FinalizeBlockSync(
+	ctx context.Context,
+	req types.RequestFinalizeBlock,
+) (*types.ResponseFinalizeBlock, error) {
+
+	resBeginBlock, err := cli.BeginBlockSync(ctx, types.RequestBeginBlock{
+		Hash:                req.Hash,
+		Header:              req.Header,
+		LastCommitInfo:      req.LastCommitInfo,
+		ByzantineValidators: req.ByzantineValidators,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reqResTxs := make([]*ReqRes, len(req.Txs))
+	for i, tx := range req.Txs {
+		if reqResTxs[i], err = cli.DeliverTxAsync(ctx, types.RequestDeliverTx{Tx: tx}); err != nil {
+			return nil, err
+		}
+	}
+
+	// the responses are received in the order of the requests, so the
+	// DeliverTx requests are done once EndBlock is
+	resEndBlock, err := cli.EndBlockSync(ctx, types.RequestEndBlock{Height: req.Header.Height})
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]*types.ResponseDeliverTx, len(reqResTxs))
+	for i, reqRes := range reqResTxs {
+		reqRes.Wait()
+		if txs[i] = reqRes.Response.GetDeliverTx(); txs[i] == nil {
+			return nil, errors.New("unexpected response to DeliverTx")
+		}
+	}
+
+	return &types.ResponseFinalizeBlock{
+		BeginBlockEvents:      resBeginBlock.Events,
+		Events:                resEndBlock.Events,
+		Txs:                   txs,
+		ValidatorUpdates:      resEndBlock.ValidatorUpdates,
+		ConsensusParamUpdates: resEndBlock.ConsensusParamUpdates,
+	}, nil
+}
+
+func (cli *legacyClient) PrepareProposalSync(
+	ctx context.Context,
+	req types.RequestPrepareProposal,
+) (*types.ResponsePrepareProposal, error) {
+	res := types.BaseApplication{}.PrepareProposal(req)
+	return &res, nil
+}
+
+func (cli *legacyClient) ProcessProposalSync(
+	ctx context.Context,
+	req types.RequestProcessProposal,
+) (*types.ResponseProcessProposal, error) {
+	res := types.BaseApplication{}.ProcessProposal(req)
+	return &res, nil
+}
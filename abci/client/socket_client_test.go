@@ -16,6 +16,7 @@ import (
 	"github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/libs/service"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 )
 
 func TestProperSyncCalls(t *testing.T) {
@@ -29,12 +30,12 @@ func TestProperSyncCalls(t *testing.T) {
 
 	resp := make(chan error, 1)
 	go func() {
-		// This is BeginBlockSync unrolled....
-		reqres, err := c.BeginBlockAsync(ctx, types.RequestBeginBlock{})
+		// This is FinalizeBlockSync unrolled....
+		reqres, err := c.FinalizeBlockAsync(ctx, types.RequestFinalizeBlock{})
 		assert.NoError(t, err)
 		err = c.FlushSync(ctx)
 		assert.NoError(t, err)
-		res := reqres.Response.GetBeginBlock()
+		res := reqres.Response.GetFinalizeBlock()
 		assert.NotNil(t, res)
 		resp <- c.Error()
 	}()
@@ -48,6 +49,53 @@ func TestProperSyncCalls(t *testing.T) {
 	}
 }
 
+func TestLegacyClient(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	app := &legacyApp{}
+	logger := log.TestingLogger()
+
+	port := 20000 + rand.Int31()%10000
+	addr := fmt.Sprintf("localhost:%d", port)
+
+	s, err := server.NewServer(logger, addr, "socket", app)
+	require.NoError(t, err)
+	require.NoError(t, s.Start(ctx))
+	t.Cleanup(s.Wait)
+
+	c, err := abciclient.NewClient(logger, addr, "socket-legacy", true)
+	require.NoError(t, err)
+	require.NoError(t, c.Start(ctx))
+	t.Cleanup(c.Wait)
+
+	res, err := c.FinalizeBlockSync(ctx, types.RequestFinalizeBlock{
+		Txs:    [][]byte{[]byte("a"), []byte("b")},
+		Header: tmproto.Header{Height: 3},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"begin", "deliver a", "deliver b", "end"}, app.calls)
+	assert.Equal(t, []types.Event{{Type: "begin"}}, res.BeginBlockEvents)
+	assert.Equal(t, []types.Event{{Type: "end"}}, res.Events)
+	require.Len(t, res.Txs, 2)
+	assert.Equal(t, []byte("a"), res.Txs[0].Data)
+	assert.Equal(t, []byte("b"), res.Txs[1].Data)
+	assert.Equal(t, []types.ValidatorUpdate{{Power: 3}}, res.ValidatorUpdates)
+
+	// the calls legacy applications predate aren't sent to the application
+	resPrepare, err := c.PrepareProposalSync(ctx, types.RequestPrepareProposal{
+		Txs:        [][]byte{[]byte("a"), []byte("b")},
+		MaxTxBytes: 1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("a")}, resPrepare.Txs)
+	resProcess, err := c.ProcessProposalSync(ctx, types.RequestProcessProposal{})
+	require.NoError(t, err)
+	assert.Equal(t, types.ResponseProcessProposal_ACCEPT, resProcess.Status)
+	assert.Len(t, app.calls, 4)
+	require.NoError(t, c.Error())
+}
+
 func setupClientServer(
 	ctx context.Context,
 	t *testing.T,
@@ -79,7 +127,44 @@ type slowApp struct {
 	types.BaseApplication
 }
 
-func (slowApp) BeginBlock(req types.RequestBeginBlock) types.ResponseBeginBlock {
+func (slowApp) FinalizeBlock(req types.RequestFinalizeBlock) types.ResponseFinalizeBlock {
 	time.Sleep(200 * time.Millisecond)
-	return types.ResponseBeginBlock{}
+	return types.ResponseFinalizeBlock{}
+}
+
+// legacyApp is a legacy application, failing the calls it predates.
+type legacyApp struct {
+	types.BaseApplication
+
+	calls []string
+}
+
+func (app *legacyApp) BeginBlock(req types.RequestBeginBlock) types.ResponseBeginBlock {
+	app.calls = append(app.calls, "begin")
+	return types.ResponseBeginBlock{Events: []types.Event{{Type: "begin"}}}
+}
+
+func (app *legacyApp) DeliverTx(req types.RequestDeliverTx) types.ResponseDeliverTx {
+	app.calls = append(app.calls, "deliver "+string(req.Tx))
+	return types.ResponseDeliverTx{Code: types.CodeTypeOK, Data: req.Tx}
+}
+
+func (app *legacyApp) EndBlock(req types.RequestEndBlock) types.ResponseEndBlock {
+	app.calls = append(app.calls, "end")
+	return types.ResponseEndBlock{
+		ValidatorUpdates: []types.ValidatorUpdate{{Power: req.Height}},
+		Events:           []types.Event{{Type: "end"}},
+	}
+}
+
+func (app *legacyApp) FinalizeBlock(types.RequestFinalizeBlock) types.ResponseFinalizeBlock {
+	panic("FinalizeBlock called on a legacy application")
+}
+
+func (app *legacyApp) PrepareProposal(types.RequestPrepareProposal) types.ResponsePrepareProposal {
+	panic("PrepareProposal called on a legacy application")
+}
+
+func (app *legacyApp) ProcessProposal(types.RequestProcessProposal) types.ResponseProcessProposal {
+	panic("ProcessProposal called on a legacy application")
 }
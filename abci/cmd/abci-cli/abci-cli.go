@@ -15,6 +15,7 @@ import (
 
 	"github.com/tendermint/tendermint/libs/log"
 
+	"github.com/tendermint/tendermint/abci/abcidump"
 	abciclient "github.com/tendermint/tendermint/abci/client"
 	"github.com/tendermint/tendermint/abci/example/code"
 	"github.com/tendermint/tendermint/abci/example/kvstore"
@@ -22,6 +23,7 @@ import (
 	servertest "github.com/tendermint/tendermint/abci/tests/server"
 	"github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/abci/version"
+	tmnet "github.com/tendermint/tendermint/libs/net"
 	"github.com/tendermint/tendermint/proto/tendermint/crypto"
 )
 
@@ -46,6 +48,10 @@ var (
 
 	// kvstore
 	flagPersist string
+
+	// dump
+	flagListen string
+	flagOut    string
 )
 
 var RootCmd = &cobra.Command{
@@ -55,7 +61,7 @@ var RootCmd = &cobra.Command{
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 
 		switch cmd.Use {
-		case "kvstore", "version":
+		case "kvstore", "version", "dump", "replay":
 			return nil
 		}
 
@@ -108,7 +114,7 @@ func addGlobalFlags() {
 		"",
 		"tcp://0.0.0.0:26658",
 		"address of application socket")
-	RootCmd.PersistentFlags().StringVarP(&flagAbci, "abci", "", "socket", "either socket or grpc")
+	RootCmd.PersistentFlags().StringVarP(&flagAbci, "abci", "", "socket", "either socket, grpc or grpc-stream")
 	RootCmd.PersistentFlags().BoolVarP(&flagVerbose,
 		"verbose",
 		"v",
@@ -131,6 +137,15 @@ func addKVStoreFlags() {
 	kvstoreCmd.PersistentFlags().StringVarP(&flagPersist, "persist", "", "", "directory to use for a database")
 }
 
+func addDumpFlags() {
+	dumpCmd.PersistentFlags().StringVarP(&flagListen,
+		"listen",
+		"",
+		"tcp://0.0.0.0:26659",
+		"address to accept connections from Tendermint on")
+	dumpCmd.PersistentFlags().StringVarP(&flagOut, "out", "", "abci.dump", "file to write the dump to")
+}
+
 func addCommands() {
 	RootCmd.AddCommand(batchCmd)
 	RootCmd.AddCommand(consoleCmd)
@@ -143,6 +158,9 @@ func addCommands() {
 	RootCmd.AddCommand(testCmd)
 	addQueryFlags()
 	RootCmd.AddCommand(queryCmd)
+	addDumpFlags()
+	RootCmd.AddCommand(dumpCmd)
+	RootCmd.AddCommand(replayCmd)
 
 	// examples
 	addKVStoreFlags()
@@ -204,7 +222,7 @@ var infoCmd = &cobra.Command{
 var deliverTxCmd = &cobra.Command{
 	Use:   "deliver_tx",
 	Short: "deliver a new transaction to the application",
-	Long:  "deliver a new transaction to the application",
+	Long:  "deliver a new transaction to the application, as the only transaction of a block passed to FinalizeBlock",
 	Args:  cobra.ExactArgs(1),
 	RunE:  cmdDeliverTx,
 }
@@ -244,6 +262,35 @@ var queryCmd = &cobra.Command{
 	RunE:  cmdQuery,
 }
 
+var dumpCmd = &cobra.Command{
+	Use:   "dump",
+	Short: "record the ABCI traffic to an application",
+	Long: `record the ABCI traffic to an application
+
+This command accepts socket connections on the --listen address, forwards them
+to the application at --address, and writes the requests and responses to the
+--out file, e.g. with Tendermint configured with proxy-app = "tcp://0.0.0.0:26659":
+
+    abci-cli dump --address tcp://0.0.0.0:26658 --out abci.dump
+`,
+	Args: cobra.ExactArgs(0),
+	RunE: cmdDump,
+}
+
+var replayCmd = &cobra.Command{
+	Use:   "replay",
+	Short: "replay the ABCI traffic recorded by dump against an application",
+	Long: `replay the ABCI traffic recorded by dump against an application
+
+This command sends the requests of a dump to the application at --address, in
+order, and stops at the first response which differs from the recorded one:
+
+    abci-cli replay abci.dump
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: cmdReplay,
+}
+
 var kvstoreCmd = &cobra.Command{
 	Use:   "kvstore",
 	Short: "ABCI demo example",
@@ -485,10 +532,14 @@ func cmdDeliverTx(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	res, err := client.DeliverTxSync(cmd.Context(), types.RequestDeliverTx{Tx: txBytes})
+	resFinalizeBlock, err := client.FinalizeBlockSync(cmd.Context(), types.RequestFinalizeBlock{Txs: [][]byte{txBytes}})
 	if err != nil {
 		return err
 	}
+	if len(resFinalizeBlock.Txs) != 1 {
+		return fmt.Errorf("expected 1 tx result, got %d", len(resFinalizeBlock.Txs))
+	}
+	res := resFinalizeBlock.Txs[0]
 	printResponse(cmd, args, response{
 		Code: res.Code,
 		Data: res.Data,
@@ -604,6 +655,51 @@ func cmdKVStore(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func cmdDump(cmd *cobra.Command, args []string) error {
+	logger := log.MustNewDefaultLogger(log.LogFormatPlain, log.LogLevelInfo, false)
+
+	file, err := os.Create(flagOut)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	proxy := abcidump.NewProxy(logger.With("module", "abci-dump"), flagListen, flagAddress, file)
+
+	ctx, cancel := signal.NotifyContext(cmd.Context(), syscall.SIGTERM, syscall.SIGINT)
+	defer cancel()
+
+	if err := proxy.Start(ctx); err != nil {
+		return err
+	}
+
+	// Run until interrupted.
+	<-ctx.Done()
+	proxy.Wait()
+	return file.Sync()
+}
+
+func cmdReplay(cmd *cobra.Command, args []string) error {
+	file, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	conn, err := tmnet.Connect(flagAddress)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	n, err := abcidump.Replay(file, abcidump.ConnHandler(conn))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("-> replayed %d requests\n", n)
+	return nil
+}
+
 //--------------------------------------------------------------------------------
 
 func printResponse(cmd *cobra.Command, args []string, rsp response) {
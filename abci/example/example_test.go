@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"google.golang.org/grpc"
@@ -78,13 +79,15 @@ func testStream(ctx context.Context, t *testing.T, app types.Application) {
 	client.SetResponseCallback(func(req *types.Request, res *types.Response) {
 		// Process response
 		switch r := res.Value.(type) {
-		case *types.Response_DeliverTx:
+		case *types.Response_FinalizeBlock:
 			counter++
-			if r.DeliverTx.Code != code.CodeTypeOK {
-				t.Error("DeliverTx failed with ret_code", r.DeliverTx.Code)
+			for _, tx := range r.FinalizeBlock.Txs {
+				if tx.Code != code.CodeTypeOK {
+					t.Error("DeliverTx failed with ret_code", tx.Code)
+				}
 			}
 			if counter > numDeliverTxs {
-				t.Fatalf("Too many DeliverTx responses. Got %d, expected %d", counter, numDeliverTxs)
+				t.Fatalf("Too many FinalizeBlock responses. Got %d, expected %d", counter, numDeliverTxs)
 			}
 			if counter == numDeliverTxs {
 				go func() {
@@ -103,7 +106,7 @@ func testStream(ctx context.Context, t *testing.T, app types.Application) {
 	// Write requests
 	for counter := 0; counter < numDeliverTxs; counter++ {
 		// Send request
-		_, err = client.DeliverTxAsync(ctx, types.RequestDeliverTx{Tx: []byte("test")})
+		_, err = client.FinalizeBlockAsync(ctx, types.RequestFinalizeBlock{Txs: [][]byte{[]byte("test")}})
 		require.NoError(t, err)
 
 		// Sometimes send flush messages
@@ -162,16 +165,18 @@ func testGRPCSync(ctx context.Context, t *testing.T, app types.ABCIApplicationSe
 	// Write requests
 	for counter := 0; counter < numDeliverTxs; counter++ {
 		// Send request
-		response, err := client.DeliverTx(context.Background(), &types.RequestDeliverTx{Tx: []byte("test")})
+		response, err := client.FinalizeBlock(context.Background(), &types.RequestFinalizeBlock{Txs: [][]byte{[]byte("test")}})
 		if err != nil {
-			t.Fatalf("Error in GRPC DeliverTx: %v", err.Error())
+			t.Fatalf("Error in GRPC FinalizeBlock: %v", err.Error())
 		}
 		counter++
-		if response.Code != code.CodeTypeOK {
-			t.Error("DeliverTx failed with ret_code", response.Code)
+		for _, tx := range response.Txs {
+			if tx.Code != code.CodeTypeOK {
+				t.Error("DeliverTx failed with ret_code", tx.Code)
+			}
 		}
 		if counter > numDeliverTxs {
-			t.Fatal("Too many DeliverTx responses")
+			t.Fatal("Too many FinalizeBlock responses")
 		}
 		t.Log("response", counter)
 		if counter == numDeliverTxs {
@@ -182,3 +187,94 @@ func testGRPCSync(ctx context.Context, t *testing.T, app types.ABCIApplicationSe
 
 	}
 }
+
+// echoCheckTxApp returns the transactions in the CheckTx responses, so that
+// responses can be matched to requests.
+type echoCheckTxApp struct {
+	types.BaseApplication
+}
+
+func (echoCheckTxApp) CheckTx(req types.RequestCheckTx) types.ResponseCheckTx {
+	return types.ResponseCheckTx{Code: code.CodeTypeOK, Data: req.Tx}
+}
+
+func TestGRPCStream(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const numCheckTxs = 2000
+	socketFile := fmt.Sprintf("/tmp/test-%08x.sock", rand.Int31n(1<<30))
+	defer os.Remove(socketFile)
+	socket := fmt.Sprintf("unix://%v", socketFile)
+	logger := log.TestingLogger()
+
+	server, err := abciserver.NewServer(logger.With("module", "abci-server"), socket, "grpc-stream", echoCheckTxApp{})
+	require.NoError(t, err)
+	require.NoError(t, server.Start(ctx))
+	t.Cleanup(server.Wait)
+
+	client, err := abciclient.NewClient(logger.With("module", "abci-client"), socket, "grpc-stream", true)
+	require.NoError(t, err)
+	require.NoError(t, client.Start(ctx))
+	t.Cleanup(client.Wait)
+
+	// responses are delivered in the order of the requests
+	var responses [][]byte
+	client.SetResponseCallback(func(req *types.Request, res *types.Response) {
+		if r, ok := res.Value.(*types.Response_CheckTx); ok {
+			assert.Equal(t, req.GetCheckTx().Tx, r.CheckTx.Data)
+			responses = append(responses, r.CheckTx.Data)
+		}
+	})
+
+	for i := 0; i < numCheckTxs; i++ {
+		_, err := client.CheckTxAsync(ctx, types.RequestCheckTx{Tx: []byte(fmt.Sprint(i))})
+		require.NoError(t, err)
+	}
+	require.NoError(t, client.FlushSync(ctx))
+	require.Len(t, responses, numCheckTxs)
+	for i, tx := range responses {
+		require.Equal(t, fmt.Sprint(i), string(tx))
+	}
+
+	res, err := client.CheckTxSync(ctx, types.RequestCheckTx{Tx: []byte("sync")})
+	require.NoError(t, err)
+	require.Equal(t, []byte("sync"), res.Data)
+
+	// other requests are still unary calls
+	info, err := client.InfoSync(ctx, types.RequestInfo{})
+	require.NoError(t, err)
+	require.NotNil(t, info)
+}
+
+func BenchmarkGRPCCheckTx(b *testing.B) {
+	for _, transport := range []string{"grpc", "grpc-stream"} {
+		b.Run(transport, func(b *testing.B) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			socketFile := fmt.Sprintf("/tmp/test-%08x.sock", rand.Int31n(1<<30))
+			defer os.Remove(socketFile)
+			socket := fmt.Sprintf("unix://%v", socketFile)
+			logger := log.NewNopLogger()
+
+			server, err := abciserver.NewServer(logger, socket, transport, kvstore.NewApplication())
+			require.NoError(b, err)
+			require.NoError(b, server.Start(ctx))
+			b.Cleanup(server.Wait)
+
+			client, err := abciclient.NewClient(logger, socket, transport, true)
+			require.NoError(b, err)
+			require.NoError(b, client.Start(ctx))
+			b.Cleanup(client.Wait)
+
+			tx := []byte("key=value")
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_, err := client.CheckTxAsync(ctx, types.RequestCheckTx{Tx: tx})
+				require.NoError(b, err)
+			}
+			require.NoError(b, client.FlushSync(ctx))
+		})
+	}
+}
@@ -85,15 +85,25 @@ func (app *Application) Info(req types.RequestInfo) (resInfo types.ResponseInfo)
 	}
 }
 
+// FinalizeBlock executes each tx of the block with handleTx.
+func (app *Application) FinalizeBlock(req types.RequestFinalizeBlock) types.ResponseFinalizeBlock {
+	txs := make([]*types.ResponseDeliverTx, len(req.Txs))
+	for i, tx := range req.Txs {
+		res := app.handleTx(tx)
+		txs[i] = &res
+	}
+	return types.ResponseFinalizeBlock{Txs: txs}
+}
+
 // tx is either "key=value" or just arbitrary bytes
-func (app *Application) DeliverTx(req types.RequestDeliverTx) types.ResponseDeliverTx {
+func (app *Application) handleTx(tx []byte) types.ResponseDeliverTx {
 	var key, value string
 
-	parts := bytes.Split(req.Tx, []byte("="))
+	parts := bytes.Split(tx, []byte("="))
 	if len(parts) == 2 {
 		key, value = string(parts[0]), string(parts[1])
 	} else {
-		key, value = string(req.Tx), string(req.Tx)
+		key, value = string(tx), string(tx)
 	}
 
 	err := app.state.db.Set(prefixKey([]byte(key)), []byte(value))
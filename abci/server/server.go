@@ -22,10 +22,68 @@ func NewServer(logger log.Logger, protoAddr, transport string, app types.Applica
 	switch transport {
 	case "socket":
 		s = NewSocketServer(logger, protoAddr, app)
-	case "grpc":
+	case "grpc", "grpc-stream":
 		s = NewGRPCServer(logger, protoAddr, types.NewGRPCApplication(app))
 	default:
 		err = fmt.Errorf("unknown server type %s", transport)
 	}
 	return s, err
 }
+
+// HandleRequest passes the request to the application and returns its
+// response, as the socket server does.
+func HandleRequest(app types.Application, req *types.Request) *types.Response {
+	switch r := req.Value.(type) {
+	case *types.Request_Echo:
+		return types.ToResponseEcho(r.Echo.Message)
+	case *types.Request_Flush:
+		return types.ToResponseFlush()
+	case *types.Request_Info:
+		return types.ToResponseInfo(app.Info(*r.Info))
+	case *types.Request_CheckTx:
+		return types.ToResponseCheckTx(app.CheckTx(*r.CheckTx))
+	case *types.Request_Commit:
+		return types.ToResponseCommit(app.Commit())
+	case *types.Request_Query:
+		return types.ToResponseQuery(app.Query(*r.Query))
+	case *types.Request_InitChain:
+		return types.ToResponseInitChain(app.InitChain(*r.InitChain))
+	case *types.Request_FinalizeBlock:
+		return types.ToResponseFinalizeBlock(app.FinalizeBlock(*r.FinalizeBlock))
+	case *types.Request_PrepareProposal:
+		return types.ToResponsePrepareProposal(app.PrepareProposal(*r.PrepareProposal))
+	case *types.Request_ProcessProposal:
+		return types.ToResponseProcessProposal(app.ProcessProposal(*r.ProcessProposal))
+	case *types.Request_BeginBlock, *types.Request_DeliverTx, *types.Request_EndBlock:
+		return handleLegacyRequest(app, req)
+	case *types.Request_ListSnapshots:
+		return types.ToResponseListSnapshots(app.ListSnapshots(*r.ListSnapshots))
+	case *types.Request_OfferSnapshot:
+		return types.ToResponseOfferSnapshot(app.OfferSnapshot(*r.OfferSnapshot))
+	case *types.Request_LoadSnapshotChunk:
+		return types.ToResponseLoadSnapshotChunk(app.LoadSnapshotChunk(*r.LoadSnapshotChunk))
+	case *types.Request_ApplySnapshotChunk:
+		return types.ToResponseApplySnapshotChunk(app.ApplySnapshotChunk(*r.ApplySnapshotChunk))
+	default:
+		return types.ToResponseException("Unknown request")
+	}
+}
+
+// handleLegacyRequest serves the BeginBlock, DeliverTx and EndBlock requests of
+// the clients of legacy applications, see abciclient.NewLegacyClient.
+func handleLegacyRequest(app types.Application, req *types.Request) *types.Response {
+	legacy, ok := app.(types.LegacyApplication)
+	if !ok {
+		return types.ToResponseException(types.ErrNotLegacyApplication.Error())
+	}
+	switch r := req.Value.(type) {
+	case *types.Request_BeginBlock:
+		return types.ToResponseBeginBlock(legacy.BeginBlock(*r.BeginBlock))
+	case *types.Request_DeliverTx:
+		return types.ToResponseDeliverTx(legacy.DeliverTx(*r.DeliverTx))
+	case *types.Request_EndBlock:
+		return types.ToResponseEndBlock(legacy.EndBlock(*r.EndBlock))
+	default:
+		return types.ToResponseException("Unknown request")
+	}
+}
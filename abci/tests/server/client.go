@@ -50,8 +50,14 @@ func Commit(ctx context.Context, client abciclient.Client, hashExp []byte) error
 }
 
 func DeliverTx(ctx context.Context, client abciclient.Client, txBytes []byte, codeExp uint32, dataExp []byte) error {
-	res, _ := client.DeliverTxSync(ctx, types.RequestDeliverTx{Tx: txBytes})
-	code, data, log := res.Code, res.Data, res.Log
+	res, err := client.FinalizeBlockSync(ctx, types.RequestFinalizeBlock{Txs: [][]byte{txBytes}})
+	if err != nil {
+		return err
+	}
+	if len(res.Txs) != 1 {
+		return fmt.Errorf("expected 1 tx result, got %d", len(res.Txs))
+	}
+	code, data, log := res.Txs[0].Code, res.Txs[0].Data, res.Txs[0].Log
 	if code != codeExp {
 		fmt.Println("Failed test: DeliverTx")
 		fmt.Printf("DeliverTx response code was unexpected. Got %v expected %v. Log: %v\n",
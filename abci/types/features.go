@@ -0,0 +1,13 @@
+package types
+
+// Optional ABCI features, which applications declare support for in the
+// Features of their Info response, along with their AbciVersion.
+const (
+	// FeatureInitChainChunks is the reassembly of the genesis app state from
+	// InitChain requests carrying chunks of it.
+	FeatureInitChainChunks = "init-chain-chunks"
+
+	// FeatureMempoolLanes is the classification of transactions into mempool
+	// lanes by the Lane of CheckTx responses.
+	FeatureMempoolLanes = "mempool-lanes"
+)
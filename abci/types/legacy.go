@@ -0,0 +1,57 @@
+package types
+
+import "errors"
+
+// ErrNotLegacyApplication is returned for the BeginBlock, DeliverTx and
+// EndBlock calls to an application which isn't a LegacyApplication.
+var ErrNotLegacyApplication = errors.New("not a legacy application: BeginBlock, DeliverTx and EndBlock not supported")
+
+// LegacyApplication is an application executing blocks with a BeginBlock
+// call, a DeliverTx call for each transaction and an EndBlock call, rather
+// than a single FinalizeBlock call. Such applications can be run in process by
+// adapting them with NewLegacyApplication, and out of process with the legacy
+// ABCI transports of the client (see abciclient.NewLegacyClient).
+type LegacyApplication interface {
+	Application
+
+	BeginBlock(RequestBeginBlock) ResponseBeginBlock // Signals the beginning of a block
+	DeliverTx(RequestDeliverTx) ResponseDeliverTx    // Deliver a tx for full processing
+	EndBlock(RequestEndBlock) ResponseEndBlock       // Signals the end of a block, returns changes to the validator set
+}
+
+type legacyApplication struct {
+	LegacyApplication
+}
+
+// NewLegacyApplication returns an Application executing the blocks passed to
+// FinalizeBlock with the BeginBlock, DeliverTx and EndBlock methods of the
+// given legacy application. The events of BeginBlock are returned as the
+// BeginBlockEvents of the block, and those of EndBlock as its Events.
+func NewLegacyApplication(app LegacyApplication) Application {
+	return legacyApplication{app}
+}
+
+func (app legacyApplication) FinalizeBlock(req RequestFinalizeBlock) ResponseFinalizeBlock {
+	resBeginBlock := app.BeginBlock(RequestBeginBlock{
+		Hash:                req.Hash,
+		Header:              req.Header,
+		LastCommitInfo:      req.LastCommitInfo,
+		ByzantineValidators: req.ByzantineValidators,
+	})
+
+	txs := make([]*ResponseDeliverTx, len(req.Txs))
+	for i, tx := range req.Txs {
+		res := app.DeliverTx(RequestDeliverTx{Tx: tx})
+		txs[i] = &res
+	}
+
+	resEndBlock := app.EndBlock(RequestEndBlock{Height: req.Header.Height})
+
+	return ResponseFinalizeBlock{
+		BeginBlockEvents:      resBeginBlock.Events,
+		Events:                resEndBlock.Events,
+		Txs:                   txs,
+		ValidatorUpdates:      resEndBlock.ValidatorUpdates,
+		ConsensusParamUpdates: resEndBlock.ConsensusParamUpdates,
+	}
+}
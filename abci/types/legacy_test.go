@@ -0,0 +1,52 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+type legacyTestApp struct {
+	BaseApplication
+
+	calls []string
+}
+
+func (app *legacyTestApp) BeginBlock(req RequestBeginBlock) ResponseBeginBlock {
+	app.calls = append(app.calls, "begin")
+	return ResponseBeginBlock{Events: []Event{{Type: "begin"}}}
+}
+
+func (app *legacyTestApp) DeliverTx(req RequestDeliverTx) ResponseDeliverTx {
+	app.calls = append(app.calls, "deliver "+string(req.Tx))
+	return ResponseDeliverTx{Code: CodeTypeOK, Data: req.Tx}
+}
+
+func (app *legacyTestApp) EndBlock(req RequestEndBlock) ResponseEndBlock {
+	app.calls = append(app.calls, "end")
+	return ResponseEndBlock{
+		ValidatorUpdates: []ValidatorUpdate{{Power: req.Height}},
+		Events:           []Event{{Type: "end"}},
+	}
+}
+
+func TestLegacyApplicationFinalizeBlock(t *testing.T) {
+	legacy := &legacyTestApp{}
+	app := NewLegacyApplication(legacy)
+
+	res := app.FinalizeBlock(RequestFinalizeBlock{
+		Txs:    [][]byte{[]byte("a"), []byte("b")},
+		Header: tmproto.Header{Height: 7},
+	})
+
+	assert.Equal(t, []string{"begin", "deliver a", "deliver b", "end"}, legacy.calls)
+	require.Len(t, res.Txs, 2)
+	assert.Equal(t, []byte("a"), res.Txs[0].Data)
+	assert.Equal(t, []byte("b"), res.Txs[1].Data)
+	assert.Equal(t, []Event{{Type: "begin"}}, res.BeginBlockEvents)
+	assert.Equal(t, []Event{{Type: "end"}}, res.Events)
+	assert.Equal(t, []ValidatorUpdate{{Power: 7}}, res.ValidatorUpdates)
+}
@@ -51,3 +51,13 @@ func UpdateValidator(pk []byte, power int64, keyType string) ValidatorUpdate {
 		panic(fmt.Sprintf("key type %s not supported", keyType))
 	}
 }
+
+// RotateValidatorKey returns a validator update rotating the key of the
+// validator with public key pk, of the given power, to newPk. Both keys must be
+// of the given key type.
+func RotateValidatorKey(pk, newPk []byte, power int64, keyType string) ValidatorUpdate {
+	update := UpdateValidator(pk, power, keyType)
+	newPubKey := UpdateValidator(newPk, power, keyType).PubKey
+	update.NewPubKey = &newPubKey
+	return update
+}
@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+	rpcclient "github.com/tendermint/tendermint/rpc/jsonrpc/client"
+)
+
+var addressBookRPCAddr string
+
+// AddressBookCmd exports and imports the peer address book of a running node
+// via its RPC service, which must have the unsafe routes enabled.
+var AddressBookCmd = &cobra.Command{
+	Use:   "address-book",
+	Short: "Export or import the peer address book of a running node",
+	Long: `
+Export the peer addresses known to a running node, along with their source and
+dial history, as JSON, or import such a list into a running node. This lets
+operators migrate an address book to a new node or share a curated list of
+peers between nodes.
+
+These commands use the node's RPC service, which must be started with the
+unsafe routes enabled (rpc.unsafe = true).
+`,
+}
+
+var exportAddressBookCmd = &cobra.Command{
+	Use:   "export [file]",
+	Short: "Write the address book as JSON to a file, or to standard output",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := rpcclient.New(addressBookRPCAddr)
+		if err != nil {
+			return fmt.Errorf("failed to create RPC client: %w", err)
+		}
+
+		result := new(coretypes.ResultAddressBook)
+		if _, err := client.Call(cmd.Context(), "address_book", map[string]interface{}{}, result); err != nil {
+			return fmt.Errorf("failed to export address book: %w", err)
+		}
+
+		bz, err := tmjson.MarshalIndent(result.Addresses, "", "  ")
+		if err != nil {
+			return err
+		}
+		if len(args) == 0 {
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), string(bz))
+			return err
+		}
+		return os.WriteFile(args[0], bz, 0644)
+	},
+}
+
+var importAddressBookCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Add the addresses in a JSON file, as written by export, to the address book",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		bz, err := os.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+		var addresses []coretypes.AddressBookEntry
+		if err := tmjson.Unmarshal(bz, &addresses); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", args[0], err)
+		}
+
+		client, err := rpcclient.New(addressBookRPCAddr)
+		if err != nil {
+			return fmt.Errorf("failed to create RPC client: %w", err)
+		}
+
+		result := new(coretypes.ResultImportAddressBook)
+		params := map[string]interface{}{"addresses": addresses}
+		if _, err := client.Call(cmd.Context(), "import_address_book", params, result); err != nil {
+			return fmt.Errorf("failed to import address book: %w", err)
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "Imported %d of %d addresses\n", result.Added, len(addresses))
+		return nil
+	},
+}
+
+func init() {
+	AddressBookCmd.PersistentFlags().StringVar(
+		&addressBookRPCAddr,
+		"rpc-laddr",
+		"tcp://localhost:26657",
+		"the node's RPC address",
+	)
+
+	AddressBookCmd.AddCommand(exportAddressBookCmd)
+	AddressBookCmd.AddCommand(importAddressBookCmd)
+}
@@ -36,4 +36,6 @@ func init() {
 
 	DebugCmd.AddCommand(killCmd)
 	DebugCmd.AddCommand(dumpCmd)
+	DebugCmd.AddCommand(walCmd)
+	DebugCmd.AddCommand(flightRecorderCmd)
 }
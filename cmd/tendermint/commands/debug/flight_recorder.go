@@ -0,0 +1,48 @@
+package debug
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/flightrec"
+	"github.com/tendermint/tendermint/libs/cli"
+)
+
+var flightRecorderCmd = &cobra.Command{
+	Use:   "flight-recorder [output-file]",
+	Short: "Export the recordings of the flight recorder of a node to a zip file",
+	Long: `Export the recordings of the goroutines, consensus state, peers and p2p metrics
+kept by the flight recorder in the node's home directory to a zip file, which
+defaults to flight_recorder-<time>.zip in the current directory. The recordings
+are read from disk, so that the state of a node which crashed or hung can be
+inspected after the fact. The dumps of a running node are written to
+data/flight_recorder/dumps instead, e.g. with the unsafe_dump_flight_recorder
+RPC endpoint.
+
+Example:
+$ tendermint debug flight-recorder /tmp/incident.zip`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: flightRecorderCmdHandler,
+}
+
+func flightRecorderCmdHandler(_ *cobra.Command, args []string) error {
+	home := viper.GetString(cli.HomeFlag)
+	conf := config.DefaultConfig()
+	conf = conf.SetRoot(home)
+
+	outFile := fmt.Sprintf("flight_recorder-%s.zip", time.Now().UTC().Format("20060102T150405Z"))
+	if len(args) > 0 {
+		outFile = args[0]
+	}
+
+	if err := flightrec.Export(filepath.Join(conf.DBDir(), flightrec.DirName), outFile); err != nil {
+		return fmt.Errorf("failed to export the flight recorder: %w", err)
+	}
+	logger.Info("exported the flight recorder", "file", outFile)
+	return nil
+}
@@ -0,0 +1,157 @@
+package debug
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/consensus"
+	"github.com/tendermint/tendermint/libs/cli"
+)
+
+var (
+	walQuiet bool
+
+	flagQuiet = "quiet"
+)
+
+var walCmd = &cobra.Command{
+	Use:   "wal",
+	Short: "Inspect, truncate or repair the consensus WAL of a stopped node",
+	Long: `Inspect, truncate or repair the consensus write-ahead log (WAL) of a node.
+The commands operate on a single WAL file, which defaults to the head file of the
+WAL in the node's home directory; rotated files (e.g. cs.wal/wal.000) can be given
+explicitly. The node must not be running while its WAL is truncated or repaired.`,
+}
+
+var walInspectCmd = &cobra.Command{
+	Use:   "inspect [wal-file]",
+	Short: "Print a summary of every message in the WAL and check its consistency",
+	Long: `Print a summary of every message in the WAL, followed by the last consistent
+height and round, and the location of the first corrupted message, if any.
+
+Example:
+$ tendermint debug wal inspect --quiet`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: walInspectCmdHandler,
+}
+
+var walRepairCmd = &cobra.Command{
+	Use:   "repair [wal-file]",
+	Short: "Remove the corrupted data at the end of the WAL",
+	Long: `Remove the corrupted data from the WAL, keeping the valid messages preceding
+the first corrupted message. The original file is first backed up to
+<wal-file>.corrupted.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: walRepairCmdHandler,
+}
+
+var walTruncateCmd = &cobra.Command{
+	Use:   "truncate <height> [wal-file]",
+	Short: "Discard the WAL messages following the end of the given height",
+	Long: `Discard the WAL messages following the #ENDHEIGHT marker of the given height,
+so that the node resumes consensus from the next height when restarted.
+
+Example:
+$ tendermint debug wal truncate 1234`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: walTruncateCmdHandler,
+}
+
+func init() {
+	walInspectCmd.Flags().BoolVarP(
+		&walQuiet,
+		flagQuiet,
+		"q",
+		false,
+		"only print the summary of the WAL, not its messages",
+	)
+
+	walCmd.AddCommand(walInspectCmd)
+	walCmd.AddCommand(walRepairCmd)
+	walCmd.AddCommand(walTruncateCmd)
+}
+
+// walFile returns the WAL file given as an argument, or otherwise the WAL file
+// of the node.
+func walFile(args []string) string {
+	if len(args) > 0 {
+		return args[0]
+	}
+
+	conf := config.DefaultConfig()
+	conf = conf.SetRoot(viper.GetString(cli.HomeFlag))
+	return conf.Consensus.WalFile()
+}
+
+func walInspectCmdHandler(cmd *cobra.Command, args []string) error {
+	out := cmd.OutOrStdout()
+	path := walFile(args)
+
+	var visit func(consensus.WALEntry) error
+	if !walQuiet {
+		visit = func(e consensus.WALEntry) error {
+			_, err := fmt.Fprintf(out, "%d\t%s\t%s\n", e.Offset, e.Msg.Time.Format(time.RFC3339Nano),
+				consensus.DescribeWALMessage(e.Msg.Msg))
+			return err
+		}
+	}
+
+	wi, err := consensus.InspectWAL(path, visit)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "file: %s (%d bytes)\n", path, wi.Size)
+	fmt.Fprintf(out, "valid messages: %d (%d bytes)\n", wi.Messages, wi.ValidSize)
+	fmt.Fprintf(out, "last #ENDHEIGHT: %d\n", wi.LastEndHeight)
+	fmt.Fprintf(out, "last height/round: %d/%d\n", wi.LastHeight, wi.LastRound)
+	if wi.Corrupted() {
+		fmt.Fprintf(out, "corrupted at offset %d: %v\n", wi.ValidSize, wi.Corruption)
+		return fmt.Errorf("WAL %s is corrupted, see `tendermint debug wal repair`", path)
+	}
+	fmt.Fprintln(out, "no corruption found")
+	return nil
+}
+
+func walRepairCmdHandler(cmd *cobra.Command, args []string) error {
+	path := walFile(args)
+	backupPath := path + ".corrupted"
+
+	wi, err := consensus.RepairWAL(path, backupPath)
+	if err != nil {
+		return err
+	}
+	if !wi.Corrupted() {
+		logger.Info("no corruption found, the WAL was left unchanged", "file", path)
+		return nil
+	}
+
+	logger.Info("repaired the WAL",
+		"file", path,
+		"backup", backupPath,
+		"removed_bytes", wi.Size-wi.ValidSize,
+		"last_end_height", wi.LastEndHeight,
+		"corruption", wi.Corruption,
+	)
+	return nil
+}
+
+func walTruncateCmdHandler(cmd *cobra.Command, args []string) error {
+	height, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid height %q: %w", args[0], err)
+	}
+	path := walFile(args[1:])
+
+	if err := consensus.TruncateWAL(path, height); err != nil {
+		return err
+	}
+
+	logger.Info("truncated the WAL", "file", path, "height", height)
+	return nil
+}
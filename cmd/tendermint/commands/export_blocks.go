@@ -0,0 +1,141 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/internal/blocksync"
+	"github.com/tendermint/tendermint/internal/libs/progressbar"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+)
+
+var (
+	exportBlocksFrom      int64
+	exportBlocksTo        int64
+	exportBlocksChunkSize int64
+	exportBlocksNode      string
+)
+
+// ExportBlocksCmd exports blocks, with their commits and ABCI results, to a
+// block archive, from which other nodes can block sync without loading the
+// network, or which can be kept in cold storage.
+var ExportBlocksCmd = &cobra.Command{
+	Use:   "export-blocks <dir>",
+	Short: "Export blocks to a block archive directory",
+	Long: `
+Export blocks, with their commits and ABCI results, to a block archive directory,
+from which other nodes block sync before fetching blocks from peers when it is set
+as their block-archive. The directory can be published, e.g. in a public S3 bucket,
+or kept in cold storage. Blocks are added to an existing archive of the same chain.
+
+Blocks are read from the stores of a stopped node, or with --node over the RPC of
+a running node. The default --from height is 0, meaning the base height of the
+node, and the default --to height is 0, meaning the latest height of the node.
+`,
+	Example: `
+	tendermint export-blocks /var/archive
+	tendermint export-blocks --from 1000 --to 2000 /var/archive
+	tendermint export-blocks --node tcp://127.0.0.1:26657 --from 1000 /var/archive
+	`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var (
+			source       blocksync.ArchiveSource
+			base, height int64
+		)
+		if exportBlocksNode != "" {
+			client, err := rpchttp.New(exportBlocksNode)
+			if err != nil {
+				return err
+			}
+			status, err := client.Status(cmd.Context())
+			if err != nil {
+				return err
+			}
+			source = &rpcArchiveSource{client: client, chainID: status.NodeInfo.Network}
+			base, height = status.SyncInfo.EarliestBlockHeight, status.SyncInfo.LatestBlockHeight
+		} else {
+			bs, ss, err := loadStateAndBlockStore(config)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				_ = bs.Close()
+				_ = ss.Close()
+			}()
+			source = blocksync.NewStoreArchiveSource(bs, ss)
+			base, height = bs.Base(), bs.Height()
+		}
+
+		from, to := exportBlocksFrom, exportBlocksTo
+		if from == 0 {
+			from = base
+		}
+		if to == 0 {
+			to = height
+		}
+		if from < base || to > height || from > to {
+			return fmt.Errorf("%w: blocks %d to %d (node has %d to %d)",
+				coretypes.ErrHeightNotAvailable, from, to, base, height)
+		}
+
+		var bar progressbar.Bar
+		bar.NewOption(from-1, to)
+		defer bar.Finish()
+		return blocksync.ExportBlocks(cmd.Context(), source, args[0], from, to, exportBlocksChunkSize, bar.Play)
+	},
+}
+
+func init() {
+	flags := ExportBlocksCmd.Flags()
+	flags.Int64Var(&exportBlocksFrom, "from", 0, "the height of the first block to export")
+	flags.Int64Var(&exportBlocksTo, "to", 0, "the height of the last block to export")
+	flags.Int64Var(&exportBlocksChunkSize, "chunk-size", 1000, "the number of blocks per archive chunk")
+	flags.StringVar(&exportBlocksNode, "node", "",
+		"the RPC address of a running node to export blocks from, instead of the stores of a stopped node")
+}
+
+// rpcArchiveSource is a blocksync.ArchiveSource of the RPC of a running node.
+type rpcArchiveSource struct {
+	client  *rpchttp.HTTP
+	chainID string
+}
+
+// ChainID implements blocksync.ArchiveSource.
+func (s *rpcArchiveSource) ChainID(ctx context.Context) (string, error) {
+	return s.chainID, nil
+}
+
+// LoadBlock implements blocksync.ArchiveSource.
+func (s *rpcArchiveSource) LoadBlock(ctx context.Context, height int64) (*blocksync.ArchivedBlock, error) {
+	block, err := s.client.Block(ctx, &height)
+	if err != nil {
+		return nil, err
+	}
+	commit, err := s.client.Commit(ctx, &height)
+	if err != nil {
+		return nil, err
+	}
+	results, err := s.client.BlockResults(ctx, &height)
+	if err != nil {
+		return nil, err
+	}
+	return &blocksync.ArchivedBlock{
+		Block:  block.Block,
+		Commit: commit.Commit,
+		ABCIResponses: &tmstate.ABCIResponses{
+			DeliverTxs: results.TxsResults,
+			BeginBlock: &abci.ResponseBeginBlock{Events: results.BeginBlockEvents},
+			EndBlock: &abci.ResponseEndBlock{
+				ValidatorUpdates:      results.ValidatorUpdates,
+				ConsensusParamUpdates: results.ConsensusParamUpdates,
+				Events:                results.EndBlockEvents,
+			},
+		},
+	}, nil
+}
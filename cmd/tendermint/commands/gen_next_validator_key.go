@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	tmos "github.com/tendermint/tendermint/libs/os"
+	"github.com/tendermint/tendermint/privval"
+)
+
+// GenNextValidatorKeyCmd generates the next key of this node's validator, to
+// rotate its key to.
+var GenNextValidatorKeyCmd = &cobra.Command{
+	Use:   "gen-next-validator-key",
+	Short: "Generate the next key of this node's validator, and show its public key",
+	Long: `Generate the next key of this node's validator and store it in the
+private validator key file, replacing any previous next key. The node must not
+be running, as it only loads the key file on startup.
+
+The validator switches to the next key once the application rotated the key of
+the validator to the printed public key, with a validator update setting
+new_pub_key.`,
+	RunE: genNextValidatorKey,
+}
+
+func init() {
+	addKeyTypeFlag(GenNextValidatorKeyCmd, "Key type to generate the next key with")
+}
+
+func genNextValidatorKey(cmd *cobra.Command, args []string) error {
+	keyFilePath := config.PrivValidator.KeyFile()
+	if !tmos.FileExists(keyFilePath) {
+		return fmt.Errorf("private validator file %s does not exist", keyFilePath)
+	}
+
+	pv, err := privval.LoadFilePV(keyFilePath, config.PrivValidator.StateFile())
+	if err != nil {
+		return err
+	}
+
+	pubKey, err := pv.GenNextKey(keyType)
+	if err != nil {
+		return fmt.Errorf("failed to generate next key: %w", err)
+	}
+
+	bz, err := tmjson.Marshal(pubKey)
+	if err != nil {
+		return fmt.Errorf("failed to marshal next private validator pubkey: %w", err)
+	}
+
+	fmt.Println(string(bz))
+	return nil
+}
@@ -30,8 +30,25 @@ var (
 )
 
 func init() {
-	InitFilesCmd.Flags().StringVar(&keyType, "key", types.ABCIPubKeyTypeEd25519,
-		"Key type to generate privval file with. Options: ed25519, secp256k1")
+	addKeyTypeFlag(InitFilesCmd, "Key type to generate privval file with")
+}
+
+// addKeyTypeFlag adds the --key-type flag, and its deprecated --key alias, to
+// select the type of the validator key a command generates.
+func addKeyTypeFlag(cmd *cobra.Command, usage string) {
+	usage += ". Options: ed25519, secp256k1, sr25519"
+	cmd.Flags().StringVar(&keyType, "key-type", types.ABCIPubKeyTypeEd25519, usage)
+	cmd.Flags().StringVar(&keyType, "key", types.ABCIPubKeyTypeEd25519, usage)
+	_ = cmd.Flags().MarkDeprecated("key", "use --key-type instead")
+}
+
+// validatorParams returns the validator consensus params of a genesis whose
+// validators have keys of the given type.
+func validatorParams(keyType string) types.ValidatorParams {
+	if keyType == "" {
+		return types.DefaultValidatorParams()
+	}
+	return types.ValidatorParams{PubKeyTypes: []string{keyType}}
 }
 
 func initFiles(cmd *cobra.Command, args []string) error {
@@ -94,11 +111,7 @@ func initFilesWithConfig(ctx context.Context, config *cfg.Config) error {
 			GenesisTime:     tmtime.Now(),
 			ConsensusParams: types.DefaultConsensusParams(),
 		}
-		if keyType == "secp256k1" {
-			genDoc.ConsensusParams.Validator = types.ValidatorParams{
-				PubKeyTypes: []string{types.ABCIPubKeyTypeSecp256k1},
-			}
-		}
+		genDoc.ConsensusParams.Validator = validatorParams(keyType)
 
 		ctx, cancel := context.WithTimeout(ctx, ctxTimeout)
 		defer cancel()
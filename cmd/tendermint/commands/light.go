@@ -19,10 +19,13 @@ import (
 	tmmath "github.com/tendermint/tendermint/libs/math"
 	tmos "github.com/tendermint/tendermint/libs/os"
 	"github.com/tendermint/tendermint/light"
+	"github.com/tendermint/tendermint/light/provider"
+	lhttp "github.com/tendermint/tendermint/light/provider/http"
 	lproxy "github.com/tendermint/tendermint/light/proxy"
 	lrpc "github.com/tendermint/tendermint/light/rpc"
 	dbs "github.com/tendermint/tendermint/light/store/db"
 	rpcserver "github.com/tendermint/tendermint/rpc/jsonrpc/server"
+	"github.com/tendermint/tendermint/types"
 )
 
 // LightCmd represents the base command when called without any subcommands
@@ -54,12 +57,14 @@ for applications built w/ Cosmos SDK).
 }
 
 var (
-	listenAddr         string
-	primaryAddr        string
-	witnessAddrsJoined string
-	chainID            string
-	dir                string
-	maxOpenConnections int
+	listenAddr          string
+	primaryAddr         string
+	witnessAddrsJoined  string
+	evidenceAddrsJoined string
+	checkpointsJoined   string
+	chainID             string
+	dir                 string
+	maxOpenConnections  int
 
 	sequential     bool
 	trustingPeriod time.Duration
@@ -81,6 +86,10 @@ func init() {
 		"connect to a Tendermint node at this address")
 	LightCmd.Flags().StringVarP(&witnessAddrsJoined, "witnesses", "w", "",
 		"tendermint nodes to cross-check the primary node, comma-separated")
+	LightCmd.Flags().StringVar(&evidenceAddrsJoined, "evidence-receivers", "",
+		"tendermint nodes to also report evidence of light client attacks to, comma-separated")
+	LightCmd.Flags().StringVar(&checkpointsJoined, "checkpoints", "",
+		"height:hash checkpoints of the chain which verified headers must match, comma-separated")
 	LightCmd.Flags().StringVarP(&dir, "dir", "d", os.ExpandEnv(filepath.Join("$HOME", ".tendermint-light")),
 		"specify the directory")
 	LightCmd.Flags().IntVar(
@@ -153,6 +162,26 @@ func runProxy(cmd *cobra.Command, args []string) error {
 		options = append(options, light.SkippingVerification(trustLevel))
 	}
 
+	if evidenceAddrsJoined != "" {
+		receivers := []provider.Provider{}
+		for _, addr := range strings.Split(evidenceAddrsJoined, ",") {
+			p, err := lhttp.New(chainID, addr)
+			if err != nil {
+				return fmt.Errorf("can't create evidence receiver %s: %w", addr, err)
+			}
+			receivers = append(receivers, p)
+		}
+		options = append(options, light.EvidenceReceivers(receivers...))
+	}
+
+	if checkpointsJoined != "" {
+		checkpoints, err := types.ParseCheckpoints(strings.Split(checkpointsJoined, ","))
+		if err != nil {
+			return fmt.Errorf("can't parse checkpoints: %w", err)
+		}
+		options = append(options, light.Checkpoints(checkpoints))
+	}
+
 	// Initiate the light client. If the trusted store already has blocks in it, this
 	// will be used else we use the trusted options.
 	c, err := light.NewHTTPClient(
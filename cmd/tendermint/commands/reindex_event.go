@@ -9,40 +9,40 @@ import (
 	"github.com/spf13/cobra"
 	dbm "github.com/tendermint/tm-db"
 
-	abcitypes "github.com/tendermint/tendermint/abci/types"
 	tmcfg "github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/internal/libs/progressbar"
 	"github.com/tendermint/tendermint/internal/state"
 	"github.com/tendermint/tendermint/internal/state/indexer"
-	"github.com/tendermint/tendermint/internal/state/indexer/sink/kv"
-	"github.com/tendermint/tendermint/internal/state/indexer/sink/psql"
+	"github.com/tendermint/tendermint/internal/state/indexer/sink"
 	"github.com/tendermint/tendermint/internal/store"
 	"github.com/tendermint/tendermint/libs/os"
 	"github.com/tendermint/tendermint/rpc/coretypes"
-	"github.com/tendermint/tendermint/types"
 )
 
 const (
 	reindexFailed = "event re-index failed: "
 )
 
-// ReIndexEventCmd allows re-index the event by given block height interval
+// ReIndexEventCmd replays the events of a range of blocks from the block and
+// state stores through the event sinks.
 var ReIndexEventCmd = &cobra.Command{
-	Use:   "reindex-event",
-	Short: "reindex events to the event store backends",
+	Use:     "reindex",
+	Aliases: []string{"reindex-event"},
+	Short:   "reindex events to the event store backends",
 	Long: `
-reindex-event is an offline tooling to re-index block and tx events to the eventsinks,
-you can run this command when the event store backend dropped/disconnected or you want to 
-replace the backend. The default start-height is 0, meaning the tooling will start 
-reindex from the base block height(inclusive); and the default end-height is 0, meaning 
-the tooling will reindex until the latest block height(inclusive). User can omit
-either or both arguments.
+reindex is an offline tool which replays the blocks and ABCI results in the block and
+state stores through the event sinks, to populate a newly added sink or to recover a
+sink which lost data, without resyncing the chain. The default --from height is 0,
+meaning the tool starts from the base block height (inclusive), and the default --to
+height is 0, meaning the tool reindexes until the latest block height (inclusive).
+The sinks default to the indexers in the tx-index section of the config.toml, and
+are configured there.
 	`,
 	Example: `
-	tendermint reindex-event
-	tendermint reindex-event --start-height 2
-	tendermint reindex-event --end-height 10
-	tendermint reindex-event --start-height 2 --end-height 10
+	tendermint reindex
+	tendermint reindex --from 2
+	tendermint reindex --to 10
+	tendermint reindex --from 2 --to 10 --sink psql
 	`,
 	Run: func(cmd *cobra.Command, args []string) {
 		bs, ss, err := loadStateAndBlockStore(config)
@@ -56,7 +56,7 @@ either or both arguments.
 			return
 		}
 
-		es, err := loadEventSinks(config)
+		es, err := loadEventSinks(config, sinkTypes)
 		if err != nil {
 			fmt.Println(reindexFailed, err)
 			return
@@ -74,17 +74,32 @@ either or both arguments.
 var (
 	startHeight int64
 	endHeight   int64
+	sinkTypes   []string
 )
 
 func init() {
-	ReIndexEventCmd.Flags().Int64Var(&startHeight, "start-height", 0, "the block height would like to start for re-index")
-	ReIndexEventCmd.Flags().Int64Var(&endHeight, "end-height", 0, "the block height would like to finish for re-index")
+	flags := ReIndexEventCmd.Flags()
+	flags.Int64Var(&startHeight, "from", 0, "the block height to start re-indexing from")
+	flags.Int64Var(&endHeight, "to", 0, "the block height to finish re-indexing at")
+	flags.StringSliceVar(&sinkTypes, "sink", nil,
+		"the event sinks to re-index to, e.g. psql; defaults to the indexers in the config")
+
+	flags.Int64Var(&startHeight, "start-height", 0, "the block height to start re-indexing from")
+	flags.Int64Var(&endHeight, "end-height", 0, "the block height to finish re-indexing at")
+	_ = flags.MarkDeprecated("start-height", "use --from instead")
+	_ = flags.MarkDeprecated("end-height", "use --to instead")
 }
 
-func loadEventSinks(cfg *tmcfg.Config) ([]indexer.EventSink, error) {
+// loadEventSinks loads the event sinks of the given types, or of the types
+// configured in the config if none are given.
+func loadEventSinks(cfg *tmcfg.Config, sinkTypes []string) ([]indexer.EventSink, error) {
+	if len(sinkTypes) == 0 {
+		sinkTypes = cfg.TxIndex.Indexer
+	}
+
 	// Check duplicated sinks.
 	sinks := map[string]bool{}
-	for _, s := range cfg.TxIndex.Indexer {
+	for _, s := range sinkTypes {
 		sl := strings.ToLower(s)
 		if sinks[sl] {
 			return nil, errors.New("found duplicated sinks, please check the tx-index section in the config.toml")
@@ -98,24 +113,12 @@ func loadEventSinks(cfg *tmcfg.Config) ([]indexer.EventSink, error) {
 		switch k {
 		case string(indexer.NULL):
 			return nil, errors.New("found null event sink, please check the tx-index section in the config.toml")
-		case string(indexer.KV):
-			store, err := tmcfg.DefaultDBProvider(&tmcfg.DBContext{ID: "tx_index", Config: cfg})
-			if err != nil {
-				return nil, err
-			}
-			eventSinks = append(eventSinks, kv.NewEventSink(store))
-		case string(indexer.PSQL):
-			conn := cfg.TxIndex.PsqlConn
-			if conn == "" {
-				return nil, errors.New("the psql connection settings cannot be empty")
-			}
-			es, err := psql.NewEventSink(conn, chainID)
+		default:
+			es, err := sink.NewEventSink(indexer.EventSinkType(k), cfg, tmcfg.DefaultDBProvider, chainID)
 			if err != nil {
 				return nil, err
 			}
 			eventSinks = append(eventSinks, es)
-		default:
-			return nil, errors.New("unsupported event sink type")
 		}
 	}
 
@@ -166,61 +169,7 @@ func eventReIndex(cmd *cobra.Command, es []indexer.EventSink, bs state.BlockStor
 
 	fmt.Println("start re-indexing events:")
 	defer bar.Finish()
-	for i := startHeight; i <= endHeight; i++ {
-		select {
-		case <-cmd.Context().Done():
-			return fmt.Errorf("event re-index terminated at height %d: %w", i, cmd.Context().Err())
-		default:
-			b := bs.LoadBlock(i)
-			if b == nil {
-				return fmt.Errorf("not able to load block at height %d from the blockstore", i)
-			}
-
-			r, err := ss.LoadABCIResponses(i)
-			if err != nil {
-				return fmt.Errorf("not able to load ABCI Response at height %d from the statestore", i)
-			}
-
-			e := types.EventDataNewBlockHeader{
-				Header:           b.Header,
-				NumTxs:           int64(len(b.Txs)),
-				ResultBeginBlock: *r.BeginBlock,
-				ResultEndBlock:   *r.EndBlock,
-			}
-
-			var batch *indexer.Batch
-			if e.NumTxs > 0 {
-				batch = indexer.NewBatch(e.NumTxs)
-
-				for i, tx := range b.Data.Txs {
-					tr := abcitypes.TxResult{
-						Height: b.Height,
-						Index:  uint32(i),
-						Tx:     tx,
-						Result: *(r.DeliverTxs[i]),
-					}
-
-					_ = batch.Add(&tr)
-				}
-			}
-
-			for _, sink := range es {
-				if err := sink.IndexBlockEvents(e); err != nil {
-					return fmt.Errorf("block event re-index at height %d failed: %w", i, err)
-				}
-
-				if batch != nil {
-					if err := sink.IndexTxEvents(batch.Ops); err != nil {
-						return fmt.Errorf("tx event re-index at height %d failed: %w", i, err)
-					}
-				}
-			}
-		}
-
-		bar.Play(i)
-	}
-
-	return nil
+	return indexer.Reindex(cmd.Context(), es, bs, ss, startHeight, endHeight, bar.Play)
 }
 
 func checkValidHeight(bs state.BlockStore) error {
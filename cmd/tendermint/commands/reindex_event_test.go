@@ -83,24 +83,29 @@ func TestReIndexEventCheckHeight(t *testing.T) {
 func TestLoadEventSink(t *testing.T) {
 	testCases := []struct {
 		sinks   []string
+		types   []string
 		connURL string
 		loadErr bool
 	}{
-		{[]string{}, "", true},
-		{[]string{"NULL"}, "", true},
-		{[]string{"KV"}, "", false},
-		{[]string{"KV", "KV"}, "", true},
-		{[]string{"PSQL"}, "", true},         // true because empty connect url
-		{[]string{"PSQL"}, "wrongUrl", true}, // true because wrong connect url
+		{[]string{}, nil, "", true},
+		{[]string{"NULL"}, nil, "", true},
+		{[]string{"KV"}, nil, "", false},
+		{[]string{"KV", "KV"}, nil, "", true},
+		{[]string{"PSQL"}, nil, "", true},         // true because empty connect url
+		{[]string{"PSQL"}, nil, "wrongUrl", true}, // true because wrong connect url
 		// skip to test PSQL connect with correct url
-		{[]string{"UnsupportedSinkType"}, "wrongUrl", true},
+		{[]string{"UnsupportedSinkType"}, nil, "wrongUrl", true},
+		// the given sink types override the configured ones
+		{[]string{"NULL"}, []string{"kv"}, "", false},
+		{[]string{"KV"}, []string{"psql"}, "", true},
+		{[]string{"KV"}, []string{"kv", "KV"}, "", true},
 	}
 
 	for _, tc := range testCases {
 		cfg := tmcfg.TestConfig()
 		cfg.TxIndex.Indexer = tc.sinks
 		cfg.TxIndex.PsqlConn = tc.connURL
-		_, err := loadEventSinks(cfg)
+		_, err := loadEventSinks(cfg, tc.types)
 		if tc.loadErr {
 			require.Error(t, err)
 		} else {
@@ -1,11 +1,7 @@
 package commands
 
 import (
-	"bytes"
-	"crypto/sha256"
 	"fmt"
-	"io"
-	"os"
 	"os/signal"
 	"syscall"
 
@@ -14,10 +10,6 @@ import (
 	cfg "github.com/tendermint/tendermint/config"
 )
 
-var (
-	genesisHash []byte
-)
-
 // AddNodeFlags exposes some common configuration options on the command-line
 // These are exposed for convenience of commands embedding a tendermint node
 func AddNodeFlags(cmd *cobra.Command) {
@@ -35,11 +27,10 @@ func AddNodeFlags(cmd *cobra.Command) {
 
 	// node flags
 
-	cmd.Flags().BytesHexVar(
-		&genesisHash,
+	cmd.Flags().String(
 		"genesis-hash",
-		[]byte{},
-		"optional SHA-256 hash of the genesis file")
+		config.GenesisHash,
+		"optional hex encoded SHA-256 hash of the genesis doc the node must run")
 	cmd.Flags().Int64("consensus.double-sign-check-height", config.Consensus.DoubleSignCheckHeight,
 		"how many blocks to look back to check existence of the node's "+
 			"consensus votes before joining consensus")
@@ -50,7 +41,10 @@ func AddNodeFlags(cmd *cobra.Command) {
 		config.ProxyApp,
 		"proxy app address, or one of: 'kvstore',"+
 			" 'persistent_kvstore', 'e2e' or 'noop' for local testing.")
-	cmd.Flags().String("abci", config.ABCI, "specify abci transport (socket | grpc)")
+	cmd.Flags().String("abci", config.ABCI, "specify abci transport (socket | grpc | grpc-stream | socket-legacy | grpc-legacy)")
+	cmd.Flags().Int("mempool-connections", config.MempoolConnections,
+		"number of abci connections the mempool dispatches CheckTx requests to "+
+			"(0 for the CheckTx concurrency advertised by the app)")
 
 	// rpc flags
 	cmd.Flags().String("rpc.laddr", config.RPC.ListenAddress, "RPC listen address. Port required")
@@ -64,7 +58,9 @@ func AddNodeFlags(cmd *cobra.Command) {
 		"node listen address. (0.0.0.0:0 means any interface, any port)")
 	cmd.Flags().String("p2p.seeds", config.P2P.Seeds, "comma-delimited ID@host:port seed nodes")
 	cmd.Flags().String("p2p.persistent-peers", config.P2P.PersistentPeers, "comma-delimited ID@host:port persistent peers")
-	cmd.Flags().Bool("p2p.upnp", config.P2P.UPNP, "enable/disable UPNP port forwarding")
+	cmd.Flags().Bool("p2p.upnp", config.P2P.UPNP, "enable/disable UPnP/NAT-PMP port forwarding")
+	cmd.Flags().Bool("p2p.detect-external-address", config.P2P.DetectExternalAddress,
+		"advertise the IP address peers observe this node's connections from")
 	cmd.Flags().Bool("p2p.pex", config.P2P.PexReactor, "enable/disable Peer-Exchange")
 	cmd.Flags().String("p2p.private-peer-ids", config.P2P.PrivatePeerIDs, "comma-delimited private peer IDs")
 
@@ -100,10 +96,6 @@ func NewRunNodeCmd(nodeProvider cfg.ServiceProvider) *cobra.Command {
 		Aliases: []string{"node", "run"},
 		Short:   "Run the tendermint node",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := checkGenesisHash(config); err != nil {
-				return err
-			}
-
 			ctx, cancel := signal.NotifyContext(cmd.Context(), syscall.SIGTERM)
 			defer cancel()
 
@@ -118,6 +110,12 @@ func NewRunNodeCmd(nodeProvider cfg.ServiceProvider) *cobra.Command {
 
 			logger.Info("started node", "node", n.String())
 
+			// exit if the node stops by itself, e.g. when it halts
+			go func() {
+				n.Wait()
+				cancel()
+			}()
+
 			<-ctx.Done()
 			return nil
 		},
@@ -126,30 +124,3 @@ func NewRunNodeCmd(nodeProvider cfg.ServiceProvider) *cobra.Command {
 	AddNodeFlags(cmd)
 	return cmd
 }
-
-func checkGenesisHash(config *cfg.Config) error {
-	if len(genesisHash) == 0 || config.Genesis == "" {
-		return nil
-	}
-
-	// Calculate SHA-256 hash of the genesis file.
-	f, err := os.Open(config.GenesisFile())
-	if err != nil {
-		return fmt.Errorf("can't open genesis file: %w", err)
-	}
-	defer f.Close()
-	h := sha256.New()
-	if _, err := io.Copy(h, f); err != nil {
-		return fmt.Errorf("error when hashing genesis file: %w", err)
-	}
-	actualHash := h.Sum(nil)
-
-	// Compare with the flag.
-	if !bytes.Equal(genesisHash, actualHash) {
-		return fmt.Errorf(
-			"--genesis-hash=%X does not match %s hash: %X",
-			genesisHash, config.GenesisFile(), actualHash)
-	}
-
-	return nil
-}
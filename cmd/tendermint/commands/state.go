@@ -0,0 +1,120 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/internal/state"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/tendermint/tendermint/types"
+)
+
+var (
+	exportStateHeight  int64
+	exportStateChainID string
+)
+
+// StateCmd exports the state of a stopped node as a genesis document, and
+// initializes a node from such a document, to migrate a chain to a new one
+// (e.g. a hard fork) without ad-hoc scripts.
+var StateCmd = &cobra.Command{
+	Use:   "state",
+	Short: "Export or import the node state as a genesis document",
+	Long: `
+Export the validators, consensus parameters and app hash of a stopped node at a
+given height as a genesis document, from which a new chain can be started at the
+following height, or initialize a node from such a document.
+
+The application state is not exported: the application must export its own
+state and add it to the document's app_state before it is imported.
+`,
+}
+
+var exportStateCmd = &cobra.Command{
+	Use:   "export [file]",
+	Short: "Write the state at a height as a genesis document to a file, or to standard output",
+	Example: `
+	tendermint state export --height 1234 --chain-id test-chain-2 genesis.json
+	`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		bs, ss, err := loadStateAndBlockStore(config)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = bs.Close()
+			_ = ss.Close()
+		}()
+
+		height := exportStateHeight
+		if height == 0 {
+			height = bs.Height()
+		}
+		genDoc, err := state.ExportState(bs, ss, height)
+		if err != nil {
+			return fmt.Errorf("failed to export state: %w", err)
+		}
+		if exportStateChainID != "" {
+			genDoc.ChainID = exportStateChainID
+		}
+
+		if len(args) == 0 {
+			bz, err := tmjson.MarshalIndent(genDoc, "", "  ")
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), string(bz))
+			return err
+		}
+		return genDoc.SaveAs(args[0])
+	},
+}
+
+var importStateCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Initialize the node from a genesis document, as written by export",
+	Long: `
+Initialize the state of a node with no state from a genesis document, and make
+the document the node's genesis file, so that the node starts the new chain
+from the document's initial height.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		genDoc, err := types.GenesisDocFromFile(args[0])
+		if err != nil {
+			return err
+		}
+
+		stateDB, err := dbm.NewDB("state", dbm.BackendType(config.DBBackend), config.DBDir())
+		if err != nil {
+			return err
+		}
+		ss := state.NewStore(stateDB)
+		defer func() { _ = ss.Close() }()
+
+		st, err := state.ImportState(ss, genDoc)
+		if err != nil {
+			return fmt.Errorf("failed to import state: %w", err)
+		}
+		if err := genDoc.SaveAs(config.GenesisFile()); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "Initialized chain %s at height %d with app hash %X\n",
+			st.ChainID, st.InitialHeight, st.AppHash)
+		return nil
+	},
+}
+
+func init() {
+	exportStateCmd.Flags().Int64Var(&exportStateHeight, "height", 0,
+		"the height to export the state at, defaults to the latest height")
+	exportStateCmd.Flags().StringVar(&exportStateChainID, "chain-id", "",
+		"the chain ID of the new chain, defaults to the current one")
+
+	StateCmd.AddCommand(exportStateCmd)
+	StateCmd.AddCommand(importStateCmd)
+}
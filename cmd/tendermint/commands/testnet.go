@@ -74,8 +74,7 @@ func init() {
 		"P2P Port")
 	TestnetFilesCmd.Flags().BoolVar(&randomMonikers, "random-monikers", false,
 		"randomize the moniker for each generated node")
-	TestnetFilesCmd.Flags().StringVar(&keyType, "key", types.ABCIPubKeyTypeEd25519,
-		"Key type to generate privval file with. Options: ed25519, secp256k1")
+	addKeyTypeFlag(TestnetFilesCmd, "Key type to generate privval files with")
 }
 
 // TestnetFilesCmd allows initialisation of files for a Tendermint testnet.
@@ -194,11 +193,7 @@ func testnetFiles(cmd *cobra.Command, args []string) error {
 		Validators:      genVals,
 		ConsensusParams: types.DefaultConsensusParams(),
 	}
-	if keyType == "secp256k1" {
-		genDoc.ConsensusParams.Validator = types.ValidatorParams{
-			PubKeyTypes: []string{types.ABCIPubKeyTypeSecp256k1},
-		}
-	}
+	genDoc.ConsensusParams.Validator = validatorParams(keyType)
 
 	// Write genesis file.
 	for i := 0; i < nValidators+nNonValidators; i++ {
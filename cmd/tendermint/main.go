@@ -16,6 +16,7 @@ func main() {
 	rootCmd.AddCommand(
 		cmd.GenValidatorCmd,
 		cmd.ReIndexEventCmd,
+		cmd.ExportBlocksCmd,
 		cmd.InitFilesCmd,
 		cmd.LightCmd,
 		cmd.ReplayCmd,
@@ -23,12 +24,15 @@ func main() {
 		cmd.ResetAllCmd,
 		cmd.ResetPrivValidatorCmd,
 		cmd.ShowValidatorCmd,
+		cmd.GenNextValidatorKeyCmd,
 		cmd.TestnetFilesCmd,
 		cmd.ShowNodeIDCmd,
 		cmd.GenNodeKeyCmd,
 		cmd.VersionCmd,
 		cmd.InspectCmd,
 		cmd.RollbackStateCmd,
+		cmd.AddressBookCmd,
+		cmd.StateCmd,
 		cmd.MakeKeyMigrateCommand(),
 		debug.DebugCmd,
 		cli.NewCompletionCmd(rootCmd, true),
@@ -1,12 +1,17 @@
 package config
 
 import (
+	"crypto/sha256"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	tmjson "github.com/tendermint/tendermint/libs/json"
@@ -49,12 +54,16 @@ var (
 
 	defaultNodeKeyName = "node_key.json"
 
+	defaultLegacyAddrBookName = "addrbook.json"
+
 	defaultConfigFilePath   = filepath.Join(defaultConfigDir, defaultConfigFileName)
 	defaultGenesisJSONPath  = filepath.Join(defaultConfigDir, defaultGenesisJSONName)
 	defaultPrivValKeyPath   = filepath.Join(defaultConfigDir, defaultPrivValKeyName)
 	defaultPrivValStatePath = filepath.Join(defaultDataDir, defaultPrivValStateName)
 
 	defaultNodeKeyPath = filepath.Join(defaultConfigDir, defaultNodeKeyName)
+
+	defaultLegacyAddrBookPath = filepath.Join(defaultConfigDir, defaultLegacyAddrBookName)
 )
 
 // Config defines the top level configuration for a Tendermint node
@@ -118,6 +127,7 @@ func (cfg *Config) SetRoot(root string) *Config {
 	cfg.Mempool.RootDir = root
 	cfg.Consensus.RootDir = root
 	cfg.PrivValidator.RootDir = root
+	cfg.StateSync.RootDir = root
 	return cfg
 }
 
@@ -142,6 +152,12 @@ func (cfg *Config) ValidateBasic() error {
 	if err := cfg.Instrumentation.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [instrumentation] section: %w", err)
 	}
+	if err := cfg.PrivValidator.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [priv-validator] section: %w", err)
+	}
+	if err := cfg.TxIndex.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [tx-index] section: %w", err)
+	}
 	return nil
 }
 
@@ -161,6 +177,18 @@ type BaseConfig struct { //nolint: maligned
 	// or the name of an ABCI application compiled in with the Tendermint binary
 	ProxyApp string `mapstructure:"proxy-app"`
 
+	// Height of the first block to execute with the upgraded ABCI application
+	// at upgrade-proxy-app instead of the one at proxy-app, switching over once
+	// the previous block has been committed, so the application binary can be
+	// upgraded in place without a coordinated restart. If upgrade-proxy-app is
+	// empty, the node halts before executing the block at this height instead.
+	// 0 disables upgrades.
+	UpgradeHeight int64 `mapstructure:"upgrade-height"`
+
+	// TCP or UNIX socket address of the upgraded ABCI application, or the name
+	// of an ABCI application compiled in with the Tendermint binary
+	UpgradeProxyApp string `mapstructure:"upgrade-proxy-app"`
+
 	// A custom human readable name for this node
 	Moniker string `mapstructure:"moniker"`
 
@@ -200,6 +228,11 @@ type BaseConfig struct { //nolint: maligned
 	// Database directory
 	DBPath string `mapstructure:"db-dir"`
 
+	// If true, blocks are written to the block store without waiting for them
+	// to be fsynced to disk. The fsync is instead done concurrently with
+	// saving the results of executing the block, before its state is saved.
+	BlockStoreAsyncSync bool `mapstructure:"block-store-async-sync"`
+
 	// Output level for logging
 	LogLevel string `mapstructure:"log-level"`
 
@@ -209,16 +242,64 @@ type BaseConfig struct { //nolint: maligned
 	// Path to the JSON file containing the initial validator set and other meta data
 	Genesis string `mapstructure:"genesis-file"`
 
+	// If positive, the genesis file is parsed as a stream, leaving its
+	// app_state on disk, and the app state is delivered to the ABCI
+	// application in InitChain requests carrying chunks of at most this many
+	// bytes. Set it for genesis files too large to load into memory; 0 loads
+	// the whole genesis file and sends the app state in a single InitChain.
+	GenesisAppStateChunkSize int64 `mapstructure:"genesis-app-state-chunk-size"`
+
+	// GenesisHash is the hex encoded hash of the genesis doc (see
+	// types.GenesisDoc.Hash) the node must run, so that it refuses to start
+	// on a different genesis. Empty disables the check.
+	GenesisHash string `mapstructure:"genesis-hash"`
+
 	// A JSON file containing the private key to use for p2p authenticated encryption
 	NodeKey string `mapstructure:"node-key-file"`
 
-	// Mechanism to connect to the ABCI application: socket | grpc
+	// Mechanism to connect to the ABCI application: socket | grpc | grpc-stream,
+	// or socket-legacy | grpc-legacy for applications executing blocks with
+	// BeginBlock, DeliverTx and EndBlock rather than FinalizeBlock
 	ABCI string `mapstructure:"abci"`
 
+	// Number of connections to the ABCI application the mempool dispatches
+	// CheckTx requests to round-robin, so that applications serving
+	// connections concurrently check transactions in parallel. It's capped by
+	// the CheckTx concurrency advertised by the application in its Info
+	// response, and 0 uses the latter (or 1 if it advertises none). The
+	// consensus connection is always a single connection.
+	MempoolConnections int `mapstructure:"mempool-connections"`
+
+	// Maximum durations of the calls on the consensus, mempool and query
+	// connections to the ABCI application, after which they fail rather than
+	// blocking their callers forever on a hung application. The mempool
+	// timeout also bounds the flushes waiting for pending CheckTx responses,
+	// e.g. before Commit. 0 means no limit.
+	ABCIConsensusTimeout time.Duration `mapstructure:"abci-consensus-timeout"`
+	ABCIMempoolTimeout   time.Duration `mapstructure:"abci-mempool-timeout"`
+	ABCIQueryTimeout     time.Duration `mapstructure:"abci-query-timeout"`
+
 	// If true, query the ABCI app on connecting to a new peer
 	// so the app can decide if we should keep the connection or not
 	FilterPeers bool `mapstructure:"filter-peers"` // false
 
+	// Maximum amount of time to wait, while shutting down, for an in-flight
+	// block commit to finish and for services to drain before the node's
+	// stores are closed.
+	ShutdownGracePeriod time.Duration `mapstructure:"shutdown-grace-period"`
+
+	// Directory, or http(s) URL such as that of a public S3 bucket, of an
+	// archive of exported block files, which block sync applies before
+	// fetching blocks from peers. Relative paths are relative to the root
+	// directory.
+	BlockArchive string `mapstructure:"block-archive"`
+
+	// Checkpoints of the chain, as comma-separated "height:hash" pairs of the
+	// heights and hex-encoded hashes of blocks. Block sync and the light client
+	// of state sync halt if the block at a checkpoint height has another hash,
+	// protecting nodes syncing from genesis against long-range attacks.
+	Checkpoints []string `mapstructure:"checkpoints"`
+
 	Other map[string]interface{} `mapstructure:",remain"`
 }
 
@@ -236,6 +317,9 @@ func DefaultBaseConfig() BaseConfig {
 		FilterPeers: false,
 		DBBackend:   "goleveldb",
 		DBPath:      "data",
+
+		MempoolConnections:  0,
+		ShutdownGracePeriod: 10 * time.Second,
 	}
 }
 
@@ -258,6 +342,21 @@ func (cfg BaseConfig) GenesisFile() string {
 	return rootify(cfg.Genesis, cfg.RootDir)
 }
 
+// GenesisHashBytes decodes GenesisHash, returning nil if it isn't set.
+func (cfg BaseConfig) GenesisHashBytes() ([]byte, error) {
+	if cfg.GenesisHash == "" {
+		return nil, nil
+	}
+	hash, err := hex.DecodeString(cfg.GenesisHash)
+	if err != nil {
+		return nil, err
+	}
+	if len(hash) != sha256.Size {
+		return nil, fmt.Errorf("expected %d bytes, got %d", sha256.Size, len(hash))
+	}
+	return hash, nil
+}
+
 // NodeKeyFile returns the full path to the node_key.json file
 func (cfg BaseConfig) NodeKeyFile() string {
 	return rootify(cfg.NodeKey, cfg.RootDir)
@@ -298,6 +397,17 @@ func (cfg BaseConfig) LoadOrGenNodeKeyID() (types.NodeID, error) {
 	return nodeKey.ID, nil
 }
 
+// BlockArchiveLocation returns the location of the block archive, with paths
+// relative to the root directory, or "" if there is none.
+func (cfg BaseConfig) BlockArchiveLocation() string {
+	if cfg.BlockArchive == "" ||
+		strings.HasPrefix(cfg.BlockArchive, "http://") ||
+		strings.HasPrefix(cfg.BlockArchive, "https://") {
+		return cfg.BlockArchive
+	}
+	return rootify(cfg.BlockArchive, cfg.RootDir)
+}
+
 // DBDir returns the full path to the database directory
 func (cfg BaseConfig) DBDir() string {
 	return rootify(cfg.DBPath, cfg.RootDir)
@@ -321,6 +431,39 @@ func (cfg BaseConfig) ValidateBasic() error {
 		return fmt.Errorf("unknown mode: %v", cfg.Mode)
 	}
 
+	if cfg.ShutdownGracePeriod < 0 {
+		return errors.New("shutdown-grace-period can't be negative")
+	}
+
+	if cfg.MempoolConnections < 0 {
+		return errors.New("mempool-connections can't be negative")
+	}
+
+	if cfg.ABCIConsensusTimeout < 0 {
+		return errors.New("abci-consensus-timeout can't be negative")
+	}
+	if cfg.ABCIMempoolTimeout < 0 {
+		return errors.New("abci-mempool-timeout can't be negative")
+	}
+	if cfg.ABCIQueryTimeout < 0 {
+		return errors.New("abci-query-timeout can't be negative")
+	}
+
+	if _, err := cfg.GenesisHashBytes(); err != nil {
+		return fmt.Errorf("invalid genesis-hash: %w", err)
+	}
+
+	if cfg.GenesisAppStateChunkSize < 0 {
+		return errors.New("genesis-app-state-chunk-size can't be negative")
+	}
+
+	if cfg.UpgradeHeight < 0 {
+		return errors.New("upgrade-height can't be negative")
+	}
+	if cfg.UpgradeProxyApp != "" && cfg.UpgradeHeight == 0 {
+		return errors.New("upgrade-proxy-app requires an upgrade-height")
+	}
+
 	return nil
 }
 
@@ -338,7 +481,8 @@ type PrivValidatorConfig struct {
 	State string `mapstructure:"state-file"`
 
 	// TCP or UNIX socket address for Tendermint to listen on for
-	// connections from an external PrivValidator process
+	// connections from an external PrivValidator process, or a comma-separated
+	// list of them to fail over between
 	ListenAddr string `mapstructure:"laddr"`
 
 	// Client certificate generated while creating needed files for secure connection.
@@ -350,15 +494,56 @@ type PrivValidatorConfig struct {
 
 	// Path Root Certificate Authority used to sign both client and server certificates
 	RootCA string `mapstructure:"root-ca-file"`
+
+	// Path to a PKCS#11 module. If set, the validator key is held on a
+	// PKCS#11 token (e.g. an HSM) instead of in the key file. The last sign
+	// state is still kept in the state file.
+	PKCS11Module string `mapstructure:"pkcs11-module"`
+
+	// Label of the PKCS#11 token holding the validator key
+	PKCS11TokenLabel string `mapstructure:"pkcs11-token-label"`
+
+	// Label of the validator key pair on the PKCS#11 token
+	PKCS11KeyLabel string `mapstructure:"pkcs11-key-label"`
+
+	// User PIN of the PKCS#11 token
+	PKCS11PIN string `mapstructure:"pkcs11-pin"`
+
+	// Type of the validator key on the PKCS#11 token: ed25519 | secp256k1
+	PKCS11KeyType string `mapstructure:"pkcs11-key-type"`
 }
 
 // DefaultBaseConfig returns a default private validator configuration
 // for a Tendermint node.
 func DefaultPrivValidatorConfig() *PrivValidatorConfig {
 	return &PrivValidatorConfig{
-		Key:   defaultPrivValKeyPath,
-		State: defaultPrivValStatePath,
+		Key:           defaultPrivValKeyPath,
+		State:         defaultPrivValStatePath,
+		PKCS11KeyType: types.ABCIPubKeyTypeEd25519,
+	}
+}
+
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *PrivValidatorConfig) ValidateBasic() error {
+	if cfg.PKCS11Module == "" {
+		return nil
+	}
+	if cfg.ListenAddr != "" {
+		return errors.New("laddr and pkcs11-module cannot both be set")
+	}
+	if cfg.PKCS11TokenLabel == "" {
+		return errors.New("pkcs11-token-label is required when pkcs11-module is set")
 	}
+	if cfg.PKCS11KeyLabel == "" {
+		return errors.New("pkcs11-key-label is required when pkcs11-module is set")
+	}
+	switch cfg.PKCS11KeyType {
+	case types.ABCIPubKeyTypeEd25519, types.ABCIPubKeyTypeSecp256k1:
+	default:
+		return fmt.Errorf("unknown pkcs11-key-type %q", cfg.PKCS11KeyType)
+	}
+	return nil
 }
 
 // ClientKeyFile returns the full path to the priv_validator_key.json file
@@ -406,7 +591,8 @@ func (cfg *PrivValidatorConfig) AreSecurityOptionsPresent() bool {
 type RPCConfig struct {
 	RootDir string `mapstructure:"home"`
 
-	// TCP or UNIX socket address for the RPC server to listen on
+	// TCP or UNIX socket address for the RPC server to listen on, e.g.
+	// "unix:///var/run/tm.sock". The server also serves cleartext HTTP/2.
 	ListenAddress string `mapstructure:"laddr"`
 
 	// A list of origins a cross-domain request can be executed from.
@@ -454,6 +640,20 @@ type RPCConfig struct {
 	// Maximum size of request header, in bytes
 	MaxHeaderBytes int `mapstructure:"max-header-bytes"`
 
+	// Maximum number of calls of a JSON-RPC batch request executed
+	// concurrently. The responses are returned in the order of the calls.
+	// 0 executes the calls one at a time.
+	MaxBatchConcurrency int `mapstructure:"max-batch-concurrency"`
+
+	// Maximum number of blocks the node can be behind the highest block
+	// reported by its peers for /health/ready to report it ready.
+	HealthMaxBlocksBehind int64 `mapstructure:"health-max-blocks-behind"`
+
+	// How long /health/live and /health/ready wait for the consensus state
+	// machine and the ABCI application to respond before reporting the node
+	// unhealthy. 0 waits as long as the probe request lasts.
+	HealthTimeout time.Duration `mapstructure:"health-timeout"`
+
 	// The path to a file containing certificate that is used to create the HTTPS server.
 	// Might be either absolute path or path related to Tendermint's config directory.
 	//
@@ -474,6 +674,62 @@ type RPCConfig struct {
 
 	// pprof listen address (https://golang.org/pkg/net/http/pprof)
 	PprofListenAddress string `mapstructure:"pprof-laddr"`
+
+	// Profiles served by the pprof server, among heap, mutex, block and trace,
+	// which can be enabled and disabled at runtime with the unsafe_set_profile
+	// RPC method. The CPU and other profiles are always served.
+	PprofProfiles []string `mapstructure:"pprof-profiles"`
+
+	// Fraction of mutex contention events sampled by the mutex profile, as in
+	// runtime.SetMutexProfileFraction. 0 samples none.
+	PprofMutexProfileFraction int `mapstructure:"pprof-mutex-profile-fraction"`
+
+	// Rate of blocking events sampled by the block profile, as in
+	// runtime.SetBlockProfileRate. 0 samples none.
+	PprofBlockProfileRate int `mapstructure:"pprof-block-profile-rate"`
+
+	// Size of the heap, in bytes, past which a heap profile is written to
+	// heap-profile-dir, whether or not the pprof server is enabled. Another
+	// profile is only written once the heap shrank below the watermark and
+	// grew past it again. 0 disables heap profile capture.
+	HeapProfileWatermark int64 `mapstructure:"heap-profile-watermark"`
+
+	// Directory the heap profiles are written to, keeping the latest 10.
+	// Relative paths are relative to the root directory.
+	HeapProfileDir string `mapstructure:"heap-profile-dir"`
+
+	// The path to a JSON file of the API keys clients authenticate with, as a
+	// bearer token, and the methods and rate limit each key grants. Might be
+	// either absolute path or path related to Tendermint's config directory.
+	// If neither it nor auth-keys are set, the RPC server doesn't require
+	// authentication.
+	AuthKeysFile string `mapstructure:"auth-keys-file"`
+
+	// API keys in the same JSON format as auth-keys-file, in addition to the
+	// keys of the file, e.g. to pass them through the TM_RPC_AUTH_KEYS
+	// environment variable.
+	AuthKeys string `mapstructure:"auth-keys"`
+
+	// Additional listeners, by name, each serving only the RPC methods in its
+	// allowlist, e.g. to expose read-only methods publicly and unsafe
+	// methods on a separate admin address.
+	Listeners map[string]*RPCListenerConfig `mapstructure:"listeners"`
+}
+
+// RPCListenerAllMethods, in the methods of a listener, stands for all the
+// methods served on the main RPC address.
+const RPCListenerAllMethods = "*"
+
+// RPCListenerConfig defines an additional RPC listener and the methods it
+// serves.
+type RPCListenerConfig struct {
+	// TCP or UNIX socket address to listen on
+	ListenAddress string `mapstructure:"laddr"`
+
+	// The RPC methods served by the listener. "*" stands for all the methods
+	// served on the main address, which only include the unsafe methods if
+	// unsafe is enabled; unsafe methods can otherwise be listed by name.
+	Methods []string `mapstructure:"methods"`
 }
 
 // DefaultRPCConfig returns a default configuration for the RPC server
@@ -494,8 +750,16 @@ func DefaultRPCConfig() *RPCConfig {
 		MaxBodyBytes:   int64(1000000), // 1MB
 		MaxHeaderBytes: 1 << 20,        // same as the net/http default
 
+		MaxBatchConcurrency: 4,
+
+		HealthMaxBlocksBehind: 3,
+		HealthTimeout:         5 * time.Second,
+
 		TLSCertFile: "",
 		TLSKeyFile:  "",
+
+		PprofProfiles:  []string{"heap", "mutex", "block", "trace"},
+		HeapProfileDir: filepath.Join(defaultDataDir, "heap-profiles"),
 	}
 }
 
@@ -528,9 +792,47 @@ func (cfg *RPCConfig) ValidateBasic() error {
 	if cfg.MaxHeaderBytes < 0 {
 		return errors.New("max-header-bytes can't be negative")
 	}
+	if cfg.MaxBatchConcurrency < 0 {
+		return errors.New("max-batch-concurrency can't be negative")
+	}
+	if cfg.HealthMaxBlocksBehind < 0 {
+		return errors.New("health-max-blocks-behind can't be negative")
+	}
+	if cfg.HealthTimeout < 0 {
+		return errors.New("health-timeout can't be negative")
+	}
+	for _, profile := range cfg.PprofProfiles {
+		switch profile {
+		case "heap", "mutex", "block", "trace":
+		default:
+			return fmt.Errorf("unknown pprof profile %q, must be heap, mutex, block or trace", profile)
+		}
+	}
+	if cfg.PprofMutexProfileFraction < 0 {
+		return errors.New("pprof-mutex-profile-fraction can't be negative")
+	}
+	if cfg.PprofBlockProfileRate < 0 {
+		return errors.New("pprof-block-profile-rate can't be negative")
+	}
+	if cfg.HeapProfileWatermark < 0 {
+		return errors.New("heap-profile-watermark can't be negative")
+	}
+	for name, l := range cfg.Listeners {
+		if l == nil || l.ListenAddress == "" {
+			return fmt.Errorf("listeners.%s: laddr can't be empty", name)
+		}
+		if len(l.Methods) == 0 {
+			return fmt.Errorf("listeners.%s: methods can't be empty", name)
+		}
+	}
 	return nil
 }
 
+// HeapProfileDirectory returns the full path to the heap profile directory.
+func (cfg *RPCConfig) HeapProfileDirectory() string {
+	return rootify(cfg.HeapProfileDir, cfg.RootDir)
+}
+
 // IsCorsEnabled returns true if cross-origin resource sharing is enabled.
 func (cfg *RPCConfig) IsCorsEnabled() bool {
 	return len(cfg.CORSAllowedOrigins) != 0
@@ -552,6 +854,16 @@ func (cfg RPCConfig) CertFile() string {
 	return rootify(filepath.Join(defaultConfigDir, path), cfg.RootDir)
 }
 
+// AuthKeysFilePath returns the full path to the API keys file, empty if there
+// is none.
+func (cfg RPCConfig) AuthKeysFilePath() string {
+	path := cfg.AuthKeysFile
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return rootify(filepath.Join(defaultConfigDir, path), cfg.RootDir)
+}
+
 func (cfg RPCConfig) IsTLSEnabled() bool {
 	return cfg.TLSCertFile != "" && cfg.TLSKeyFile != ""
 }
@@ -584,13 +896,57 @@ type P2PConfig struct { //nolint: maligned
 	// Comma separated list of nodes to keep persistent connections to
 	PersistentPeers string `mapstructure:"persistent-peers"`
 
-	// UPNP port forwarding
+	// Interval at which the hostnames of persistent peers are resolved again.
+	// When they resolve to new IP addresses, e.g. when a peer is rescheduled,
+	// the peers are redialed without waiting out their retry backoff. 0
+	// disables it.
+	PersistentPeersResolveInterval time.Duration `mapstructure:"persistent-peers-resolve-interval"`
+
+	// Path to the address book of the legacy p2p stack. If it exists on
+	// start, its addresses are imported into the peer store, and it is
+	// renamed with a .migrated suffix.
+	LegacyAddrBook string `mapstructure:"legacy-addr-book-file"`
+
+	// UPNP maps the listen port on the NAT gateway with UPnP or NAT-PMP, and
+	// advertises the gateway's external address, unless ExternalAddress is set.
 	UPNP bool `mapstructure:"upnp"`
 
+	// DetectExternalAddress advertises the IP address that peers observe the
+	// node's connections from, unless ExternalAddress is set or the gateway
+	// reports a public address.
+	DetectExternalAddress bool `mapstructure:"detect-external-address"`
+
 	// MaxConnections defines the maximum number of connected peers (inbound and
 	// outbound).
 	MaxConnections uint16 `mapstructure:"max-connections"`
 
+	// MaxInboundConnections defines the maximum number of connected inbound
+	// peers. It must be lower than MaxConnections, the remaining connections
+	// being kept for the peers the node dials, so that peers dialing the node
+	// can't eclipse it. Validators and unconditional peers are accepted
+	// beyond it. 0 disables the limit.
+	MaxInboundConnections uint16 `mapstructure:"max-inbound-connections"`
+
+	// MaxOutboundConnections defines the maximum number of connected outbound
+	// peers. Unconditional peers are dialed beyond it. 0 disables the limit.
+	MaxOutboundConnections uint16 `mapstructure:"max-outbound-connections"`
+
+	// MaxConnectionsPerIP defines the maximum number of connected peers with
+	// the same IP address, so that a single host can't take the connections
+	// by running many nodes. Validators, unconditional peers and peers in
+	// ConnectionLimitAllowlist are connected beyond it. 0 disables the limit.
+	MaxConnectionsPerIP uint16 `mapstructure:"max-connections-per-ip"`
+
+	// MaxConnectionsPerSubnet defines the maximum number of connected peers
+	// in the same /24 IPv4 subnet, or /64 IPv6 subnet, with the same
+	// exceptions as MaxConnectionsPerIP. 0 disables the limit.
+	MaxConnectionsPerSubnet uint16 `mapstructure:"max-connections-per-subnet"`
+
+	// Comma separated list of IP addresses and CIDR networks of peers exempt
+	// from MaxConnectionsPerIP and MaxConnectionsPerSubnet, e.g. the private
+	// network of a validator and its sentries.
+	ConnectionLimitAllowlist string `mapstructure:"connection-limit-allowlist"`
+
 	// MaxIncomingConnectionAttempts rate limits the number of incoming connection
 	// attempts per IP address.
 	MaxIncomingConnectionAttempts uint `mapstructure:"max-incoming-connection-attempts"`
@@ -598,13 +954,57 @@ type P2PConfig struct { //nolint: maligned
 	// Set true to enable the peer-exchange reactor
 	PexReactor bool `mapstructure:"pex"`
 
+	// Set true to drop the addresses gossiped by peers without a signature of
+	// the node they point at. Nodes sign their own address and gossip the
+	// signatures of the others regardless, so that this can be enabled once
+	// the whole network is upgraded.
+	PexRequireSignedAddresses bool `mapstructure:"pex-require-signed-addresses"`
+
+	// Comma separated list of networks (chain IDs) other than the one of the
+	// genesis served by a seed node. Peers on any of these networks are
+	// accepted, and are only advertised the addresses of peers on their own
+	// network. Only used in seed mode.
+	SeedNetworks string `mapstructure:"seed-networks"`
+
 	// Comma separated list of peer IDs to keep private (will not be gossiped to
 	// other peers)
 	PrivatePeerIDs string `mapstructure:"private-peer-ids"`
 
+	// Comma separated list of peer IDs to always accept connections from and
+	// dial, regardless of max-connections
+	UnconditionalPeerIDs string `mapstructure:"unconditional-peer-ids"`
+
+	// Comma separated list of gossip policies of peers, of the form
+	// <ID>:<policy>, where an ID of "*" sets the policy of all other peers
+	GossipPolicies string `mapstructure:"gossip-policies"`
+
 	// Toggle to disable guard against peers connecting from the same ip.
 	AllowDuplicateIP bool `mapstructure:"allow-duplicate-ip"`
 
+	// NoiseHandshake dials peers with the Noise IK handshake instead of the
+	// secret connection handshake, once they are known to support it. It
+	// hides the node's identity from passive observers.
+	NoiseHandshake bool `mapstructure:"noise-handshake"`
+
+	// NetworkKey is the hex encoded 32 byte pre-shared key of a permissioned
+	// network. If set, only peers with the same key can connect, using the
+	// Noise handshake.
+	NetworkKey string `mapstructure:"network-key"`
+
+	// Comma separated list of the compression algorithms of large messages
+	// ("zstd", "snappy") in order of preference. The first one supported by a
+	// peer is used with it. Empty disables compression.
+	Compression string `mapstructure:"compression"`
+
+	// DialProxy is the URL of a SOCKS5 proxy to dial outbound peers through,
+	// e.g. "socks5://127.0.0.1:9050" for Tor. Onion addresses can only be
+	// dialed through it. Private and loopback addresses, which the proxy may
+	// not reach, are dialed directly unless DialProxyOnly is set.
+	DialProxy string `mapstructure:"dial-proxy"`
+
+	// DialProxyOnly refuses to dial peers other than through DialProxy.
+	DialProxyOnly bool `mapstructure:"dial-proxy-only"`
+
 	// Time to wait before flushing messages out on the connection
 	FlushThrottleTimeout time.Duration `mapstructure:"flush-throttle-timeout"`
 
@@ -637,7 +1037,9 @@ func DefaultP2PConfig() *P2PConfig {
 		ListenAddress:                 "tcp://0.0.0.0:26656",
 		ExternalAddress:               "",
 		UPNP:                          false,
+		DetectExternalAddress:         false,
 		MaxConnections:                64,
+		MaxInboundConnections:         48,
 		MaxIncomingConnectionAttempts: 100,
 		FlushThrottleTimeout:          100 * time.Millisecond,
 		// The MTU (Maximum Transmission Unit) for Ethernet is 1500 bytes.
@@ -654,9 +1056,18 @@ func DefaultP2PConfig() *P2PConfig {
 		DialTimeout:             3 * time.Second,
 		TestDialFail:            false,
 		QueueType:               "priority",
+		Compression:             "zstd,snappy",
+
+		PersistentPeersResolveInterval: time.Minute,
+		LegacyAddrBook:                 defaultLegacyAddrBookPath,
 	}
 }
 
+// LegacyAddrBookFile returns the full path to the legacy address book.
+func (cfg *P2PConfig) LegacyAddrBookFile() string {
+	return rootify(cfg.LegacyAddrBook, cfg.RootDir)
+}
+
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *P2PConfig) ValidateBasic() error {
@@ -672,9 +1083,162 @@ func (cfg *P2PConfig) ValidateBasic() error {
 	if cfg.RecvRate < 0 {
 		return errors.New("recv-rate can't be negative")
 	}
+	if cfg.PersistentPeersResolveInterval < 0 {
+		return errors.New("persistent-peers-resolve-interval can't be negative")
+	}
+	if cfg.MaxConnections > 0 && cfg.MaxInboundConnections >= cfg.MaxConnections {
+		return errors.New("max-inbound-connections must be lower than max-connections")
+	}
+	if cfg.MaxConnections > 0 && cfg.MaxOutboundConnections > cfg.MaxConnections {
+		return errors.New("max-outbound-connections can't exceed max-connections")
+	}
+	if cfg.MaxConnectionsPerSubnet > 0 && cfg.MaxConnectionsPerIP > cfg.MaxConnectionsPerSubnet {
+		return errors.New("max-connections-per-ip can't exceed max-connections-per-subnet")
+	}
+	if _, err := cfg.ParseConnectionLimitAllowlist(); err != nil {
+		return fmt.Errorf("invalid connection-limit-allowlist: %w", err)
+	}
+	if _, err := cfg.ParseGossipPolicies(); err != nil {
+		return fmt.Errorf("invalid gossip-policies: %w", err)
+	}
+	if _, err := cfg.NetworkKeyBytes(); err != nil {
+		return fmt.Errorf("invalid network-key: %w", err)
+	}
+	if _, err := cfg.Compressions(); err != nil {
+		return fmt.Errorf("invalid compression: %w", err)
+	}
+	if proxyURL, err := cfg.DialProxyURL(); err != nil {
+		return fmt.Errorf("invalid dial-proxy: %w", err)
+	} else if proxyURL == nil && cfg.DialProxyOnly {
+		return errors.New("dial-proxy-only requires a dial-proxy")
+	}
 	return nil
 }
 
+// DialProxyURL parses DialProxy, returning nil if it isn't set.
+func (cfg *P2PConfig) DialProxyURL() (*url.URL, error) {
+	if cfg.DialProxy == "" {
+		return nil, nil
+	}
+	proxyURL, err := url.Parse(cfg.DialProxy)
+	if err != nil {
+		return nil, err
+	}
+	if proxyURL.Scheme != "socks5" && proxyURL.Scheme != "socks5h" {
+		return nil, fmt.Errorf("unsupported proxy scheme %q, expected socks5", proxyURL.Scheme)
+	}
+	if proxyURL.Hostname() == "" {
+		return nil, errors.New("no proxy host")
+	}
+	return proxyURL, nil
+}
+
+// networkKeySize is the size of the network key, see P2PConfig.NetworkKey.
+const networkKeySize = 32
+
+// NetworkKeyBytes decodes NetworkKey, returning nil if it isn't set.
+func (cfg *P2PConfig) NetworkKeyBytes() ([]byte, error) {
+	if cfg.NetworkKey == "" {
+		return nil, nil
+	}
+	key, err := hex.DecodeString(cfg.NetworkKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(key) != networkKeySize {
+		return nil, fmt.Errorf("expected %d bytes, got %d", networkKeySize, len(key))
+	}
+	return key, nil
+}
+
+// Compressions parses the list of compression algorithms of Compression.
+func (cfg *P2PConfig) Compressions() ([]string, error) {
+	var compressions []string
+	for _, c := range strings.Split(cfg.Compression, ",") {
+		c = strings.TrimSpace(c)
+		switch c {
+		case "":
+		case "zstd", "snappy":
+			compressions = append(compressions, c)
+		default:
+			return nil, fmt.Errorf("unsupported compression %q", c)
+		}
+	}
+	return compressions, nil
+}
+
+// ParseConnectionLimitAllowlist parses ConnectionLimitAllowlist, where an IP
+// address without a prefix length is a network of a single address.
+func (cfg *P2PConfig) ParseConnectionLimitAllowlist() ([]*net.IPNet, error) {
+	var networks []*net.IPNet
+	for _, entry := range strings.Split(cfg.ConnectionLimitAllowlist, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid IP address %q", entry)
+			}
+			bits := 8 * net.IPv6len
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 8*net.IPv4len
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, err
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// Gossip policies of peers, see P2PConfig.GossipPolicies.
+const (
+	// GossipPolicyAll sends all messages to the peer.
+	GossipPolicyAll = "all"
+	// GossipPolicyNoAddresses sends no peer addresses to the peer.
+	GossipPolicyNoAddresses = "no-addresses"
+	// GossipPolicyBlocksOnly only sends consensus and block messages to the
+	// peer, and no transactions, evidence or peer addresses.
+	GossipPolicyBlocksOnly = "blocks-only"
+
+	// GossipPolicyDefaultPeer is the peer ID setting the policy of the peers
+	// without a policy of their own.
+	GossipPolicyDefaultPeer = "*"
+)
+
+// ParseGossipPolicies parses GossipPolicies, returning the gossip policies by
+// peer ID.
+func (cfg *P2PConfig) ParseGossipPolicies() (map[string]string, error) {
+	policies := make(map[string]string)
+	for _, entry := range strings.Split(cfg.GossipPolicies, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, ":", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("policy %q is not of the form <ID>:<policy>", entry)
+		}
+		id, policy := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		switch policy {
+		case GossipPolicyAll, GossipPolicyNoAddresses, GossipPolicyBlocksOnly:
+		default:
+			return nil, fmt.Errorf("unknown policy %q for peer %s", policy, id)
+		}
+		if _, ok := policies[id]; ok {
+			return nil, fmt.Errorf("duplicate policy for peer %s", id)
+		}
+		policies[id] = policy
+	}
+	return policies, nil
+}
+
 // TestP2PConfig returns a configuration for testing the peer-to-peer layer
 func TestP2PConfig() *P2PConfig {
 	cfg := DefaultP2PConfig()
@@ -702,6 +1266,21 @@ type MempoolConfig struct {
 	// max-txs-bytes=5MB, mempool will only accept 5 transactions).
 	MaxTxsBytes int64 `mapstructure:"max-txs-bytes"`
 
+	// Lanes of the mempool, as comma-separated "name:size:max-txs-bytes"
+	// triples, in the order their transactions are reaped into blocks. The
+	// application assigns transactions to lanes by name in CheckTx, and each
+	// lane has its own size limits and gossip channel, so that transactions of
+	// a lane are never evicted or delayed by those of another. Transactions of
+	// no configured lane are in the default lane, limited by size and
+	// max-txs-bytes and reaped last.
+	Lanes []string `mapstructure:"lanes"`
+
+	// Minimum priority increase, in percent, of a transaction replacing the
+	// one in the mempool of the same sender and sequence, as reported by the
+	// application in CheckTx. The replaced transaction is evicted, and the
+	// replacement gossiped. 0 accepts any higher priority.
+	ReplacementPriorityBump int64 `mapstructure:"replacement-priority-bump"`
+
 	// Size of the cache (used to filter transactions we saw earlier) in transactions
 	CacheSize int `mapstructure:"cache-size"`
 
@@ -710,6 +1289,36 @@ type MempoolConfig struct {
 	// valid again in the future.
 	KeepInvalidTxsInCache bool `mapstructure:"keep-invalid-txs-in-cache"`
 
+	// Size of the cache of the CheckTx responses of rejected transactions,
+	// keyed by transaction hash. A transaction with a cached response is
+	// refused with it without calling CheckTx again. 0 disables the cache.
+	CheckTxCacheSize int `mapstructure:"check-tx-cache-size"`
+
+	// Drop the cached CheckTx responses when a block is committed, for
+	// applications which reject transactions depending on the state.
+	CheckTxCacheResetOnCommit bool `mapstructure:"check-tx-cache-reset-on-commit"`
+
+	// CheckTx codes of rejections depending on the state, e.g. an insufficient
+	// balance or a wrong sequence number, which are never cached.
+	CheckTxCacheBypassCodes []uint32 `mapstructure:"check-tx-cache-bypass-codes"`
+
+	// File the transactions rejected by CheckTx are logged to, as JSON lines
+	// with their hash, code, codespace and log, so that application developers
+	// can find out why transactions never land. The file is rotated every
+	// 10MB, keeping at most 100MB of logs. Disabled if empty (default).
+	RejectedTxsLogPath string `mapstructure:"rejected-txs-log-file"`
+
+	// Archive the transactions broadcast by the node or seen in its mempool,
+	// with their status, in the tx_archive database, to be looked up by hash
+	// or by sender, as reported by the application in CheckTx unless set by
+	// the TxSender node option, with the archived_tx and archived_txs RPC
+	// endpoints.
+	TxArchive bool `mapstructure:"tx-archive"`
+
+	// How long the archived transactions are kept, since they were first
+	// seen. 0 keeps them forever.
+	TxArchiveRetention time.Duration `mapstructure:"tx-archive-retention"`
+
 	// Maximum size of a single transaction
 	// NOTE: the max size of a tx transmitted over the network is {max-tx-bytes}.
 	MaxTxBytes int `mapstructure:"max-tx-bytes"`
@@ -736,6 +1345,59 @@ type MempoolConfig struct {
 	TTLNumBlocks int64 `mapstructure:"ttl-num-blocks"`
 }
 
+// RejectedTxsLogFile returns the full path to the log of the rejected
+// transactions, or an empty string if it's disabled.
+func (cfg *MempoolConfig) RejectedTxsLogFile() string {
+	if cfg.RejectedTxsLogPath == "" {
+		return ""
+	}
+	return rootify(cfg.RejectedTxsLogPath, cfg.RootDir)
+}
+
+// MaxMempoolLanes is the maximum number of mempool lanes, each gossiped on a
+// channel of its own, which keeps the channels of a node within the 16 its
+// peers accept.
+const MaxMempoolLanes = 3
+
+// MempoolLane is a lane of the mempool, see MempoolConfig.Lanes.
+type MempoolLane struct {
+	Name        string
+	Size        int
+	MaxTxsBytes int64
+}
+
+// ParseLanes parses the lanes of the mempool, returning an error if any is
+// malformed or there are too many.
+func (cfg *MempoolConfig) ParseLanes() ([]MempoolLane, error) {
+	if len(cfg.Lanes) > MaxMempoolLanes {
+		return nil, fmt.Errorf("%d lanes configured, max is %d", len(cfg.Lanes), MaxMempoolLanes)
+	}
+
+	lanes := make([]MempoolLane, 0, len(cfg.Lanes))
+	names := make(map[string]bool, len(cfg.Lanes))
+	for _, lane := range cfg.Lanes {
+		parts := strings.Split(strings.TrimSpace(lane), ":")
+		if len(parts) != 3 || parts[0] == "" {
+			return nil, fmt.Errorf("lane %q is not of the form name:size:max-txs-bytes", lane)
+		}
+		if names[parts[0]] {
+			return nil, fmt.Errorf("duplicate lane %q", parts[0])
+		}
+		names[parts[0]] = true
+
+		size, err := strconv.Atoi(parts[1])
+		if err != nil || size <= 0 {
+			return nil, fmt.Errorf("lane %q has an invalid size %q", parts[0], parts[1])
+		}
+		maxTxsBytes, err := strconv.ParseInt(parts[2], 10, 64)
+		if err != nil || maxTxsBytes <= 0 {
+			return nil, fmt.Errorf("lane %q has an invalid max-txs-bytes %q", parts[0], parts[2])
+		}
+		lanes = append(lanes, MempoolLane{Name: parts[0], Size: size, MaxTxsBytes: maxTxsBytes})
+	}
+	return lanes, nil
+}
+
 // DefaultMempoolConfig returns a default configuration for the Tendermint mempool.
 func DefaultMempoolConfig() *MempoolConfig {
 	return &MempoolConfig{
@@ -743,12 +1405,16 @@ func DefaultMempoolConfig() *MempoolConfig {
 		Broadcast: true,
 		// Each signature verification takes .5ms, Size reduced until we implement
 		// ABCI Recheck
-		Size:         5000,
-		MaxTxsBytes:  1024 * 1024 * 1024, // 1GB
-		CacheSize:    10000,
-		MaxTxBytes:   1024 * 1024, // 1MB
-		TTLDuration:  0 * time.Second,
-		TTLNumBlocks: 0,
+		Size:                      5000,
+		MaxTxsBytes:               1024 * 1024 * 1024, // 1GB
+		ReplacementPriorityBump:   10,
+		CacheSize:                 10000,
+		CheckTxCacheSize:          0,
+		CheckTxCacheResetOnCommit: true,
+		TxArchiveRetention:        7 * 24 * time.Hour,
+		MaxTxBytes:                1024 * 1024, // 1MB
+		TTLDuration:               0 * time.Second,
+		TTLNumBlocks:              0,
 	}
 }
 
@@ -768,9 +1434,21 @@ func (cfg *MempoolConfig) ValidateBasic() error {
 	if cfg.MaxTxsBytes < 0 {
 		return errors.New("max-txs-bytes can't be negative")
 	}
+	if _, err := cfg.ParseLanes(); err != nil {
+		return fmt.Errorf("invalid lanes: %w", err)
+	}
+	if cfg.ReplacementPriorityBump < 0 {
+		return errors.New("replacement-priority-bump can't be negative")
+	}
 	if cfg.CacheSize < 0 {
 		return errors.New("cache-size can't be negative")
 	}
+	if cfg.CheckTxCacheSize < 0 {
+		return errors.New("check-tx-cache-size can't be negative")
+	}
+	if cfg.TxArchiveRetention < 0 {
+		return errors.New("tx-archive-retention can't be negative")
+	}
 	if cfg.MaxTxBytes < 0 {
 		return errors.New("max-tx-bytes can't be negative")
 	}
@@ -789,6 +1467,8 @@ func (cfg *MempoolConfig) ValidateBasic() error {
 
 // StateSyncConfig defines the configuration for the Tendermint state sync service
 type StateSyncConfig struct {
+	RootDir string `mapstructure:"home"`
+
 	// State sync rapidly bootstraps a new node by discovering, fetching, and restoring a
 	// state machine snapshot from peers instead of fetching and replaying historical
 	// blocks. Requires some peers in the network to take and serve state machine
@@ -829,6 +1509,22 @@ type StateSyncConfig struct {
 
 	// The number of concurrent chunk and block fetchers to run (default: 4).
 	Fetchers int32 `mapstructure:"fetchers"`
+
+	// File the progress of snapshot restoration is persisted to, so that a
+	// restoration interrupted by a restart resumes from the last applied chunk
+	// instead of starting over. Requires the application to keep the chunks it
+	// applied across restarts, as the snapshot is not offered to it again.
+	// Disabled if empty (default).
+	RestoreProgressPath string `mapstructure:"restore-progress-file"`
+}
+
+// RestoreProgressFile returns the full path to the restore progress file, or
+// an empty string if resuming restorations is disabled.
+func (cfg *StateSyncConfig) RestoreProgressFile() string {
+	if cfg.RestoreProgressPath == "" {
+		return ""
+	}
+	return rootify(cfg.RestoreProgressPath, cfg.RootDir)
 }
 
 func (cfg *StateSyncConfig) TrustHashBytes() []byte {
@@ -917,6 +1613,10 @@ type ConsensusConfig struct {
 	WalPath string `mapstructure:"wal-file"`
 	walFile string // overrides WalPath if set
 
+	// When the WAL is synced to disk: every-message, every-height or
+	// interval=<duration>. See WALFsync.
+	WALFsyncMode string `mapstructure:"wal-fsync-mode"`
+
 	// TODO: remove timeout configs, these should be global not local
 	// How long we wait for a proposal block before prevoting nil
 	TimeoutPropose time.Duration `mapstructure:"timeout-propose"`
@@ -938,6 +1638,16 @@ type ConsensusConfig struct {
 	// Make progress as soon as we have all the precommits (as if TimeoutCommit = 0)
 	SkipTimeoutCommit bool `mapstructure:"skip-timeout-commit"`
 
+	// Adapt timeout-propose and timeout-commit to the recent durations of
+	// rounds, within the bounds below: timeout-propose to the delay of
+	// proposals, and timeout-commit to the latency of votes. The configured
+	// timeouts are used until there are samples.
+	AdaptiveTimeouts  bool          `mapstructure:"adaptive-timeouts"`
+	TimeoutProposeMin time.Duration `mapstructure:"timeout-propose-min"`
+	TimeoutProposeMax time.Duration `mapstructure:"timeout-propose-max"`
+	TimeoutCommitMin  time.Duration `mapstructure:"timeout-commit-min"`
+	TimeoutCommitMax  time.Duration `mapstructure:"timeout-commit-max"`
+
 	// EmptyBlocks mode and possible interval between empty blocks
 	CreateEmptyBlocks         bool          `mapstructure:"create-empty-blocks"`
 	CreateEmptyBlocksInterval time.Duration `mapstructure:"create-empty-blocks-interval"`
@@ -947,12 +1657,55 @@ type ConsensusConfig struct {
 	PeerQueryMaj23SleepDuration time.Duration `mapstructure:"peer-query-maj23-sleep-duration"`
 
 	DoubleSignCheckHeight int64 `mapstructure:"double-sign-check-height"`
+
+	// Minimum interval between checkpoints of the consensus state of a height
+	// (round, step, proposal, locked and valid blocks) to the state DB, from
+	// which crash recovery replays only the WAL messages written after the
+	// checkpoint. 0 disables checkpoints.
+	CheckpointInterval time.Duration `mapstructure:"checkpoint-interval"`
+
+	// Send proposed blocks to peers as compact blocks, with the hashes of their
+	// transactions rather than the transactions, which peers reconstruct from
+	// their mempool, requesting the missing transactions.
+	CompactBlocks bool `mapstructure:"compact-blocks"`
+
+	// Send the votes a peer is missing for the same block ID as a batch, with
+	// the bit-array of their validators and their signatures, rather than one
+	// by one, to the peers supporting vote batches.
+	VoteBatches bool `mapstructure:"vote-batches"`
+
+	// Halt the node after committing the block at this height, or the first
+	// block with a time at or after halt-time (in seconds since the Unix epoch),
+	// for a coordinated upgrade. The node refuses to execute further blocks
+	// until restarted with a different halt configuration. 0 disables halting.
+	HaltHeight int64 `mapstructure:"halt-height"`
+	HaltTime   int64 `mapstructure:"halt-time"`
+
+	// File the height, time and app hash of the last block are written to when
+	// the node halts, for upgrade tooling to check
+	HaltMarkerPath string `mapstructure:"halt-marker-file"`
+
+	// Directory a forensic dump of the block, the ABCI requests and responses
+	// and the state is written to when the app hash of a committed block does
+	// not match the app hash of the application. Empty disables the dumps.
+	ForensicsPath string `mapstructure:"forensics-dir"`
+
+	// Development mode for single validator networks: do not write the
+	// consensus WAL, and use short timeouts, so blocks are committed in
+	// milliseconds. Refused if there is more than one validator.
+	SkipWAL bool `mapstructure:"skip-wal"`
+
+	// Publish the ConsensusStep, ConsensusVote and ConsensusBlockPart events
+	// for every step transition, vote and block part of the state machine,
+	// for external consensus monitors.
+	EventFirehose bool `mapstructure:"event-firehose"`
 }
 
 // DefaultConsensusConfig returns a default configuration for the consensus service
 func DefaultConsensusConfig() *ConsensusConfig {
 	return &ConsensusConfig{
 		WalPath:                     filepath.Join(defaultDataDir, "cs.wal", "wal"),
+		WALFsyncMode:                WALFsyncEveryMessage,
 		TimeoutPropose:              3000 * time.Millisecond,
 		TimeoutProposeDelta:         500 * time.Millisecond,
 		TimeoutPrevote:              1000 * time.Millisecond,
@@ -961,11 +1714,25 @@ func DefaultConsensusConfig() *ConsensusConfig {
 		TimeoutPrecommitDelta:       500 * time.Millisecond,
 		TimeoutCommit:               1000 * time.Millisecond,
 		SkipTimeoutCommit:           false,
+		AdaptiveTimeouts:            false,
+		TimeoutProposeMin:           500 * time.Millisecond,
+		TimeoutProposeMax:           10 * time.Second,
+		TimeoutCommitMin:            100 * time.Millisecond,
+		TimeoutCommitMax:            5 * time.Second,
 		CreateEmptyBlocks:           true,
 		CreateEmptyBlocksInterval:   0 * time.Second,
 		PeerGossipSleepDuration:     100 * time.Millisecond,
 		PeerQueryMaj23SleepDuration: 2000 * time.Millisecond,
 		DoubleSignCheckHeight:       int64(0),
+		CheckpointInterval:          0,
+		CompactBlocks:               false,
+		VoteBatches:                 false,
+		HaltHeight:                  0,
+		HaltTime:                    0,
+		HaltMarkerPath:              filepath.Join(defaultDataDir, "halt.json"),
+		ForensicsPath:               filepath.Join(defaultDataDir, "forensics"),
+		SkipWAL:                     false,
+		EventFirehose:               false,
 	}
 }
 
@@ -986,6 +1753,20 @@ func TestConsensusConfig() *ConsensusConfig {
 	return cfg
 }
 
+// SkipWALConsensusConfig returns a copy of the configuration with the short
+// timeouts used when the WAL is skipped on a single validator network.
+func (cfg *ConsensusConfig) SkipWALConsensusConfig() *ConsensusConfig {
+	skipCfg := *cfg
+	skipCfg.TimeoutPropose = 100 * time.Millisecond
+	skipCfg.TimeoutProposeDelta = 10 * time.Millisecond
+	skipCfg.TimeoutPrevote = 10 * time.Millisecond
+	skipCfg.TimeoutPrevoteDelta = 10 * time.Millisecond
+	skipCfg.TimeoutPrecommit = 10 * time.Millisecond
+	skipCfg.TimeoutPrecommitDelta = 10 * time.Millisecond
+	skipCfg.TimeoutCommit = 10 * time.Millisecond
+	return &skipCfg
+}
+
 // WaitForTxs returns true if the consensus should wait for transactions before entering the propose step
 func (cfg *ConsensusConfig) WaitForTxs() bool {
 	return !cfg.CreateEmptyBlocks || cfg.CreateEmptyBlocksInterval > 0
@@ -1026,6 +1807,52 @@ func (cfg *ConsensusConfig) WalFile() string {
 	return rootify(cfg.WalPath, cfg.RootDir)
 }
 
+// HaltMarkerFile returns the full path to the halt marker file
+func (cfg *ConsensusConfig) HaltMarkerFile() string {
+	return rootify(cfg.HaltMarkerPath, cfg.RootDir)
+}
+
+// ForensicsDir returns the full path to the forensic dumps directory
+func (cfg *ConsensusConfig) ForensicsDir() string {
+	return rootify(cfg.ForensicsPath, cfg.RootDir)
+}
+
+// WAL fsync modes, see ConsensusConfig.WALFsyncMode.
+const (
+	// WALFsyncEveryMessage syncs the WAL after every message the node writes
+	// synchronously, i.e. its own proposals, block parts and votes.
+	WALFsyncEveryMessage = "every-message"
+	// WALFsyncEveryHeight syncs the WAL at the end of every height.
+	WALFsyncEveryHeight = "every-height"
+	// WALFsyncInterval syncs the WAL at the end of every height and at the
+	// given interval, written interval=<duration>.
+	WALFsyncInterval = "interval"
+)
+
+// WALFsync parses WALFsyncMode, returning the mode and, for the interval mode,
+// the interval.
+func (cfg *ConsensusConfig) WALFsync() (string, time.Duration, error) {
+	switch mode := cfg.WALFsyncMode; mode {
+	case "", WALFsyncEveryMessage:
+		return WALFsyncEveryMessage, 0, nil
+	case WALFsyncEveryHeight:
+		return mode, 0, nil
+	default:
+		value := strings.TrimPrefix(mode, WALFsyncInterval+"=")
+		if value == mode {
+			return "", 0, fmt.Errorf("unknown wal-fsync-mode %q", mode)
+		}
+		interval, err := time.ParseDuration(value)
+		if err != nil {
+			return "", 0, fmt.Errorf("invalid wal-fsync-mode interval %q: %w", value, err)
+		}
+		if interval <= 0 {
+			return "", 0, fmt.Errorf("wal-fsync-mode interval must be positive, got %v", interval)
+		}
+		return WALFsyncInterval, interval, nil
+	}
+}
+
 // SetWalFile sets the path to the write-ahead log file
 func (cfg *ConsensusConfig) SetWalFile(walFile string) {
 	cfg.walFile = walFile
@@ -1055,6 +1882,18 @@ func (cfg *ConsensusConfig) ValidateBasic() error {
 	if cfg.TimeoutCommit < 0 {
 		return errors.New("timeout-commit can't be negative")
 	}
+	if cfg.TimeoutProposeMin < 0 {
+		return errors.New("timeout-propose-min can't be negative")
+	}
+	if cfg.TimeoutProposeMax < cfg.TimeoutProposeMin {
+		return errors.New("timeout-propose-max can't be less than timeout-propose-min")
+	}
+	if cfg.TimeoutCommitMin < 0 {
+		return errors.New("timeout-commit-min can't be negative")
+	}
+	if cfg.TimeoutCommitMax < cfg.TimeoutCommitMin {
+		return errors.New("timeout-commit-max can't be less than timeout-commit-min")
+	}
 	if cfg.CreateEmptyBlocksInterval < 0 {
 		return errors.New("create-empty-blocks-interval can't be negative")
 	}
@@ -1067,6 +1906,18 @@ func (cfg *ConsensusConfig) ValidateBasic() error {
 	if cfg.DoubleSignCheckHeight < 0 {
 		return errors.New("double-sign-check-height can't be negative")
 	}
+	if cfg.CheckpointInterval < 0 {
+		return errors.New("checkpoint-interval can't be negative")
+	}
+	if cfg.HaltHeight < 0 {
+		return errors.New("halt-height can't be negative")
+	}
+	if cfg.HaltTime < 0 {
+		return errors.New("halt-time can't be negative")
+	}
+	if _, _, err := cfg.WALFsync(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -1090,20 +1941,140 @@ type TxIndexConfig struct {
 	//   2) "kv" (default) - the simplest possible indexer,
 	//      backed by key-value storage (defaults to levelDB; see DBBackend).
 	//   3) "psql" - the indexer services backed by PostgreSQL.
+	//   4) "sqlite" - the indexer services backed by a SQLite database file.
+	//   5) "grpc" - the indexer services of an event sink running out of
+	//      process, served over gRPC at GRPCAddr.
+	// Event sinks compiled into the node binary are available under the name
+	// they are registered with.
 	Indexer []string `mapstructure:"indexer"`
 
 	// The PostgreSQL connection configuration, the connection format:
 	// postgresql://<user>:<password>@<host>:<port>/<db>?<opts>
 	PsqlConn string `mapstructure:"psql-conn"`
+
+	// The number of blocks between refreshes of the materialized views of the
+	// "psql" indexer, which must then be installed from
+	// state/indexer/sink/psql/views.sql. 0 disables the refreshes.
+	PsqlRefreshViews int64 `mapstructure:"psql-refresh-views"`
+
+	// The path to the database file of the "sqlite" indexer, relative to the
+	// home directory. If empty, tx_index.sqlite in the db-dir is used.
+	SqlitePath string `mapstructure:"sqlite-path"`
+
+	// The address of the "grpc" indexer, e.g. tcp://127.0.0.1:26670 or
+	// unix:///path/to/sink.sock.
+	GRPCAddr string `mapstructure:"grpc-addr"`
+
+	// The composite keys (<type>.<key>) of the event attributes to index,
+	// where "*" matches any sequence of characters, e.g. "transfer.*". If
+	// empty, all the attributes the application flags for indexing are
+	// indexed.
+	IndexEvents []string `mapstructure:"index-events"`
+
+	// The composite keys of the event attributes never to index, with the
+	// same syntax as IndexEvents.
+	ExcludeEvents []string `mapstructure:"exclude-events"`
+
+	// Overrides of IndexEvents and ExcludeEvents, by indexer.
+	Sinks map[string]*TxIndexSinkConfig `mapstructure:"sinks"`
+
+	// The number of most recent heights checked for blocks missing from the
+	// indexers, e.g. after the node crashed while indexing, which are then
+	// indexed from the block and state stores. 0 disables the checks.
+	GapCheckWindow int64 `mapstructure:"gap-check-window"`
+
+	// How often the heights are checked for gaps, in addition to at startup.
+	// 0 checks them at startup only.
+	GapCheckInterval time.Duration `mapstructure:"gap-check-interval"`
+}
+
+// TxIndexSinkConfig overrides the events indexed by an indexer.
+type TxIndexSinkConfig struct {
+	// If not empty, replaces TxIndexConfig.IndexEvents for the indexer. ["*"]
+	// indexes all the events.
+	IndexEvents []string `mapstructure:"index-events"`
+
+	// If not empty, replaces TxIndexConfig.ExcludeEvents for the indexer.
+	ExcludeEvents []string `mapstructure:"exclude-events"`
+}
+
+// EventFilter returns the patterns of the event attributes the given indexer
+// indexes and excludes, taking its overrides into account.
+func (cfg *TxIndexConfig) EventFilter(indexer string) (include, exclude []string) {
+	include, exclude = cfg.IndexEvents, cfg.ExcludeEvents
+	if sink := cfg.Sinks[indexer]; sink != nil {
+		if len(sink.IndexEvents) > 0 {
+			include = sink.IndexEvents
+		}
+		if len(sink.ExcludeEvents) > 0 {
+			exclude = sink.ExcludeEvents
+		}
+	}
+	return include, exclude
+}
+
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *TxIndexConfig) ValidateBasic() error {
+	validate := func(field string, patterns []string) error {
+		for _, pattern := range patterns {
+			if pattern == "" {
+				return fmt.Errorf("%s can't contain an empty pattern", field)
+			}
+		}
+		return nil
+	}
+	if err := validate("index-events", cfg.IndexEvents); err != nil {
+		return err
+	}
+	if err := validate("exclude-events", cfg.ExcludeEvents); err != nil {
+		return err
+	}
+	if cfg.PsqlRefreshViews < 0 {
+		return errors.New("psql-refresh-views can't be negative")
+	}
+	if cfg.GapCheckWindow < 0 {
+		return errors.New("gap-check-window can't be negative")
+	}
+	if cfg.GapCheckInterval < 0 {
+		return errors.New("gap-check-interval can't be negative")
+	}
+	for name, sink := range cfg.Sinks {
+		// the indexers registered by the node binary aren't known here
+		if name == "" || name == "null" {
+			return fmt.Errorf("sinks.%s: invalid indexer", name)
+		}
+		if sink == nil {
+			continue
+		}
+		if err := validate("sinks."+name+".index-events", sink.IndexEvents); err != nil {
+			return err
+		}
+		if err := validate("sinks."+name+".exclude-events", sink.ExcludeEvents); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // DefaultTxIndexConfig returns a default configuration for the transaction indexer.
 func DefaultTxIndexConfig() *TxIndexConfig {
 	return &TxIndexConfig{
-		Indexer: []string{"kv"},
+		Indexer:          []string{"kv"},
+		GapCheckWindow:   10000,
+		GapCheckInterval: 10 * time.Minute,
 	}
 }
 
+// SqliteIndexFile returns the full path to the database file of the "sqlite"
+// indexer.
+func (cfg *Config) SqliteIndexFile() string {
+	if cfg.TxIndex.SqlitePath == "" {
+		return filepath.Join(cfg.DBDir(), "tx_index.sqlite")
+	}
+	return rootify(cfg.TxIndex.SqlitePath, cfg.RootDir)
+}
+
 // TestTxIndexConfig returns a default configuration for the transaction indexer.
 func TestTxIndexConfig() *TxIndexConfig {
 	return DefaultTxIndexConfig()
@@ -1130,6 +2101,19 @@ type InstrumentationConfig struct {
 
 	// Instrumentation namespace.
 	Namespace string `mapstructure:"namespace"`
+
+	// Tracing configures the export of OpenTelemetry trace spans.
+	Tracing *TracingConfig `mapstructure:"tracing"`
+
+	// Downtime configures the tracking of the blocks missed by the validator.
+	Downtime *DowntimeConfig `mapstructure:"downtime"`
+
+	// Invariants configures the checking of the invariants of the stored state.
+	Invariants *InvariantsConfig `mapstructure:"invariants"`
+
+	// FlightRecorder configures the continuous recording of the state of the
+	// node, for diagnosing incidents after the fact.
+	FlightRecorder *FlightRecorderConfig `mapstructure:"flight-recorder"`
 }
 
 // DefaultInstrumentationConfig returns a default configuration for metrics
@@ -1140,6 +2124,10 @@ func DefaultInstrumentationConfig() *InstrumentationConfig {
 		PrometheusListenAddr: ":26660",
 		MaxOpenConnections:   3,
 		Namespace:            "tendermint",
+		Tracing:              DefaultTracingConfig(),
+		Downtime:             DefaultDowntimeConfig(),
+		Invariants:           DefaultInvariantsConfig(),
+		FlightRecorder:       DefaultFlightRecorderConfig(),
 	}
 }
 
@@ -1155,6 +2143,203 @@ func (cfg *InstrumentationConfig) ValidateBasic() error {
 	if cfg.MaxOpenConnections < 0 {
 		return errors.New("max-open-connections can't be negative")
 	}
+	if err := cfg.Tracing.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [instrumentation.tracing] section: %w", err)
+	}
+	if err := cfg.Downtime.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [instrumentation.downtime] section: %w", err)
+	}
+	if err := cfg.Invariants.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [instrumentation.invariants] section: %w", err)
+	}
+	if err := cfg.FlightRecorder.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [instrumentation.flight-recorder] section: %w", err)
+	}
+	return nil
+}
+
+// Tracing exporters.
+const (
+	TracingExporterNone = "none"
+	TracingExporterOTLP = "otlp"
+)
+
+// TracingConfig defines the configuration for exporting OpenTelemetry trace
+// spans.
+type TracingConfig struct {
+	// Exporter to which spans are sent:
+	//   1) "none" (default) - tracing is disabled.
+	//   2) "otlp" - spans are sent to an OpenTelemetry collector over gRPC.
+	Exporter string `mapstructure:"exporter"`
+
+	// Address of the OpenTelemetry collector, as host:port.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// When true, the connection to the collector is not secured with TLS.
+	Insecure bool `mapstructure:"insecure"`
+
+	// Fraction of traces to sample, between 0 and 1.
+	SampleRate float64 `mapstructure:"sample-rate"`
+}
+
+// DefaultTracingConfig returns a default configuration for tracing, which is
+// disabled.
+func DefaultTracingConfig() *TracingConfig {
+	return &TracingConfig{
+		Exporter:   TracingExporterNone,
+		Endpoint:   "localhost:4317",
+		Insecure:   false,
+		SampleRate: 1,
+	}
+}
+
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *TracingConfig) ValidateBasic() error {
+	switch cfg.Exporter {
+	case TracingExporterNone:
+	case TracingExporterOTLP:
+		if cfg.Endpoint == "" {
+			return errors.New("endpoint is required by the otlp exporter")
+		}
+	default:
+		return fmt.Errorf("unknown exporter %q, must be %q or %q",
+			cfg.Exporter, TracingExporterNone, TracingExporterOTLP)
+	}
+	if cfg.SampleRate < 0 || cfg.SampleRate > 1 {
+		return errors.New("sample-rate must be between 0 and 1")
+	}
+	return nil
+}
+
+// DowntimeConfig defines the configuration for tracking the blocks missed by
+// the validator of the node, in validator mode.
+type DowntimeConfig struct {
+	// Number of the last blocks the validator was expected to sign over which
+	// its missed blocks are counted. 0 disables the tracking.
+	Window int `mapstructure:"window"`
+
+	// Fraction of the blocks of the window, between 0 and 1, which the
+	// validator must miss for an alert to be raised. An alert is also raised
+	// when the miss rate falls back below it.
+	AlertThreshold float64 `mapstructure:"alert-threshold"`
+
+	// URL which the alerts are POSTed to as JSON, e.g. that of a paging
+	// service. If empty, the alerts are only logged and counted.
+	WebhookURL string `mapstructure:"webhook-url"`
+}
+
+// DefaultDowntimeConfig returns a default configuration for tracking the
+// missed blocks, over the last 100 blocks.
+func DefaultDowntimeConfig() *DowntimeConfig {
+	return &DowntimeConfig{
+		Window:         100,
+		AlertThreshold: 0.1,
+		WebhookURL:     "",
+	}
+}
+
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *DowntimeConfig) ValidateBasic() error {
+	if cfg.Window < 0 {
+		return errors.New("window can't be negative")
+	}
+	if cfg.AlertThreshold < 0 || cfg.AlertThreshold > 1 {
+		return errors.New("alert-threshold must be between 0 and 1")
+	}
+	if cfg.WebhookURL != "" {
+		u, err := url.Parse(cfg.WebhookURL)
+		if err != nil {
+			return fmt.Errorf("invalid webhook-url: %w", err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("webhook-url must be an http or https URL, got %q", cfg.WebhookURL)
+		}
+	}
+	return nil
+}
+
+// Actions taken on an invariant violation.
+const (
+	InvariantViolationAlert = "alert"
+	InvariantViolationHalt  = "halt"
+)
+
+// InvariantsConfig defines the configuration for checking the invariants of
+// the stored blocks and state in the background.
+type InvariantsConfig struct {
+	// Number of blocks between the checks of the invariants, which are checked
+	// after committing a block at a multiple of it. 0 disables the checks.
+	CheckInterval int64 `mapstructure:"check-interval"`
+
+	// Action taken when an invariant is violated:
+	//   1) "alert" (default) - the violation is logged and counted.
+	//   2) "halt" - the violation is logged and counted, and the node stops.
+	OnViolation string `mapstructure:"on-violation"`
+}
+
+// DefaultInvariantsConfig returns a default configuration for checking the
+// invariants, which is disabled.
+func DefaultInvariantsConfig() *InvariantsConfig {
+	return &InvariantsConfig{
+		CheckInterval: 0,
+		OnViolation:   InvariantViolationAlert,
+	}
+}
+
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *InvariantsConfig) ValidateBasic() error {
+	if cfg.CheckInterval < 0 {
+		return errors.New("check-interval can't be negative")
+	}
+	switch cfg.OnViolation {
+	case InvariantViolationAlert, InvariantViolationHalt:
+	default:
+		return fmt.Errorf("on-violation must be %q or %q, got %q",
+			InvariantViolationAlert, InvariantViolationHalt, cfg.OnViolation)
+	}
+	return nil
+}
+
+// FlightRecorderConfig defines the configuration of the flight recorder, which
+// continuously records the goroutines, consensus state and p2p metrics of the
+// node to a bounded ring on disk, dumped on demand or when the node fails.
+type FlightRecorderConfig struct {
+	// Interval between the recordings. 0 disables the flight recorder.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// Number of the last recordings kept in the ring, the oldest being
+	// removed.
+	MaxRecordings int `mapstructure:"max-recordings"`
+
+	// Number of the last dumps of the ring kept, the oldest being removed.
+	MaxDumps int `mapstructure:"max-dumps"`
+}
+
+// DefaultFlightRecorderConfig returns a default configuration of the flight
+// recorder, which is disabled.
+func DefaultFlightRecorderConfig() *FlightRecorderConfig {
+	return &FlightRecorderConfig{
+		Interval:      0,
+		MaxRecordings: 60,
+		MaxDumps:      10,
+	}
+}
+
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *FlightRecorderConfig) ValidateBasic() error {
+	if cfg.Interval < 0 {
+		return errors.New("interval can't be negative")
+	}
+	if cfg.MaxRecordings <= 0 {
+		return errors.New("max-recordings must be positive")
+	}
+	if cfg.MaxDumps <= 0 {
+		return errors.New("max-dumps must be positive")
+	}
 	return nil
 }
 
@@ -2,6 +2,7 @@ package config
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -25,6 +26,9 @@ func TestDefaultConfig(t *testing.T) {
 
 	assert.Equal("/foo/bar", cfg.GenesisFile())
 	assert.Equal("/opt/data", cfg.DBDir())
+	assert.Equal("/opt/data/tx_index.sqlite", cfg.SqliteIndexFile())
+	cfg.TxIndex.SqlitePath = "index/events.sqlite"
+	assert.Equal("/foo/index/events.sqlite", cfg.SqliteIndexFile())
 }
 
 func TestConfigValidateBasic(t *testing.T) {
@@ -59,6 +63,51 @@ func TestBaseConfigValidateBasic(t *testing.T) {
 	// tamper with log format
 	cfg.LogFormat = "invalid"
 	assert.Error(t, cfg.ValidateBasic())
+
+	// tamper with shutdown grace period
+	cfg = TestBaseConfig()
+	cfg.ShutdownGracePeriod = -1
+	assert.Error(t, cfg.ValidateBasic())
+
+	// tamper with the upgrade settings
+	cfg = TestBaseConfig()
+	cfg.UpgradeHeight = -1
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.UpgradeHeight = 0
+	cfg.UpgradeProxyApp = "tcp://127.0.0.1:26659"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.UpgradeHeight = 10
+	assert.NoError(t, cfg.ValidateBasic())
+
+	// tamper with the mempool connections
+	cfg = TestBaseConfig()
+	cfg.MempoolConnections = -1
+	assert.Error(t, cfg.ValidateBasic())
+
+	// tamper with the ABCI timeouts
+	cfg = TestBaseConfig()
+	cfg.ABCIConsensusTimeout = -time.Second
+	assert.Error(t, cfg.ValidateBasic())
+	cfg = TestBaseConfig()
+	cfg.ABCIMempoolTimeout = -time.Second
+	assert.Error(t, cfg.ValidateBasic())
+	cfg = TestBaseConfig()
+	cfg.ABCIQueryTimeout = -time.Second
+	assert.Error(t, cfg.ValidateBasic())
+
+	// tamper with the genesis app state chunk size
+	cfg = TestBaseConfig()
+	cfg.GenesisAppStateChunkSize = -1
+	assert.Error(t, cfg.ValidateBasic())
+
+	// tamper with the genesis hash
+	cfg = TestBaseConfig()
+	cfg.GenesisHash = strings.Repeat("ab", 32)
+	assert.NoError(t, cfg.ValidateBasic())
+	for _, hash := range []string{"xyz", strings.Repeat("ab", 20)} {
+		cfg.GenesisHash = hash
+		assert.Error(t, cfg.ValidateBasic(), hash)
+	}
 }
 
 func TestRPCConfigValidateBasic(t *testing.T) {
@@ -72,6 +121,12 @@ func TestRPCConfigValidateBasic(t *testing.T) {
 		"TimeoutBroadcastTxCommit",
 		"MaxBodyBytes",
 		"MaxHeaderBytes",
+		"MaxBatchConcurrency",
+		"HealthMaxBlocksBehind",
+		"HealthTimeout",
+		"PprofMutexProfileFraction",
+		"PprofBlockProfileRate",
+		"HeapProfileWatermark",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -79,6 +134,19 @@ func TestRPCConfigValidateBasic(t *testing.T) {
 		assert.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	cfg.PprofProfiles = []string{"cpu"}
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.PprofProfiles = nil
+
+	cfg.Listeners = map[string]*RPCListenerConfig{
+		"admin": {ListenAddress: "tcp://127.0.0.1:26659", Methods: []string{RPCListenerAllMethods}},
+	}
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.Listeners["admin"].Methods = nil
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.Listeners["admin"] = &RPCListenerConfig{Methods: []string{"status"}}
+	assert.Error(t, cfg.ValidateBasic())
 }
 
 func TestMempoolConfigValidateBasic(t *testing.T) {
@@ -88,8 +156,10 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 	fieldsToTest := []string{
 		"Size",
 		"MaxTxsBytes",
+		"ReplacementPriorityBump",
 		"CacheSize",
 		"MaxTxBytes",
+		"TxArchiveRetention",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -99,6 +169,34 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 	}
 }
 
+func TestMempoolConfigParseLanes(t *testing.T) {
+	cfg := TestMempoolConfig()
+	lanes, err := cfg.ParseLanes()
+	require.NoError(t, err)
+	assert.Empty(t, lanes)
+
+	cfg.Lanes = []string{"oracle:100:1024", " votes:10:512"}
+	lanes, err = cfg.ParseLanes()
+	require.NoError(t, err)
+	assert.Equal(t, []MempoolLane{
+		{Name: "oracle", Size: 100, MaxTxsBytes: 1024},
+		{Name: "votes", Size: 10, MaxTxsBytes: 512},
+	}, lanes)
+
+	for _, invalid := range [][]string{
+		{"oracle"},
+		{"oracle:100"},
+		{":100:1024"},
+		{"oracle:0:1024"},
+		{"oracle:100:-1"},
+		{"oracle:100:1024", "oracle:10:512"},
+		{"a:1:1", "b:1:1", "c:1:1", "d:1:1"},
+	} {
+		cfg.Lanes = invalid
+		assert.Error(t, cfg.ValidateBasic(), invalid)
+	}
+}
+
 func TestStateSyncConfigValidateBasic(t *testing.T) {
 	cfg := TestStateSyncConfig()
 	require.NoError(t, cfg.ValidateBasic())
@@ -123,11 +221,23 @@ func TestConsensusConfig_ValidateBasic(t *testing.T) {
 		"TimeoutPrecommitDelta negative":       {func(c *ConsensusConfig) { c.TimeoutPrecommitDelta = -1 }, true},
 		"TimeoutCommit":                        {func(c *ConsensusConfig) { c.TimeoutCommit = time.Second }, false},
 		"TimeoutCommit negative":               {func(c *ConsensusConfig) { c.TimeoutCommit = -1 }, true},
+		"TimeoutProposeMin negative":           {func(c *ConsensusConfig) { c.TimeoutProposeMin = -1 }, true},
+		"TimeoutProposeMax below min":          {func(c *ConsensusConfig) { c.TimeoutProposeMax = c.TimeoutProposeMin - 1 }, true},
+		"TimeoutCommitMin negative":            {func(c *ConsensusConfig) { c.TimeoutCommitMin = -1 }, true},
+		"TimeoutCommitMax below min":           {func(c *ConsensusConfig) { c.TimeoutCommitMax = c.TimeoutCommitMin - 1 }, true},
 		"PeerGossipSleepDuration":              {func(c *ConsensusConfig) { c.PeerGossipSleepDuration = time.Second }, false},
 		"PeerGossipSleepDuration negative":     {func(c *ConsensusConfig) { c.PeerGossipSleepDuration = -1 }, true},
 		"PeerQueryMaj23SleepDuration":          {func(c *ConsensusConfig) { c.PeerQueryMaj23SleepDuration = time.Second }, false},
 		"PeerQueryMaj23SleepDuration negative": {func(c *ConsensusConfig) { c.PeerQueryMaj23SleepDuration = -1 }, true},
 		"DoubleSignCheckHeight negative":       {func(c *ConsensusConfig) { c.DoubleSignCheckHeight = -1 }, true},
+		"HaltHeight":                           {func(c *ConsensusConfig) { c.HaltHeight = 10 }, false},
+		"HaltHeight negative":                  {func(c *ConsensusConfig) { c.HaltHeight = -1 }, true},
+		"HaltTime negative":                    {func(c *ConsensusConfig) { c.HaltTime = -1 }, true},
+		"WALFsyncMode every-height":            {func(c *ConsensusConfig) { c.WALFsyncMode = WALFsyncEveryHeight }, false},
+		"WALFsyncMode interval":                {func(c *ConsensusConfig) { c.WALFsyncMode = "interval=100ms" }, false},
+		"WALFsyncMode interval zero":           {func(c *ConsensusConfig) { c.WALFsyncMode = "interval=0s" }, true},
+		"WALFsyncMode interval malformed":      {func(c *ConsensusConfig) { c.WALFsyncMode = "interval=fast" }, true},
+		"WALFsyncMode unknown":                 {func(c *ConsensusConfig) { c.WALFsyncMode = "never" }, true},
 	}
 	for desc, tc := range testcases {
 		tc := tc // appease linter
@@ -154,6 +264,134 @@ func TestInstrumentationConfigValidateBasic(t *testing.T) {
 	assert.Error(t, cfg.ValidateBasic())
 }
 
+func TestTracingConfigValidateBasic(t *testing.T) {
+	cfg := DefaultTracingConfig()
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.Exporter = TracingExporterOTLP
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.Endpoint = ""
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = DefaultTracingConfig()
+	cfg.Exporter = "jaeger"
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = DefaultTracingConfig()
+	cfg.SampleRate = 1.5
+	assert.Error(t, cfg.ValidateBasic())
+}
+
+func TestDowntimeConfigValidateBasic(t *testing.T) {
+	cfg := DefaultDowntimeConfig()
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.WebhookURL = "https://alerts.example.com/hooks/validator"
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.WebhookURL = "tcp://127.0.0.1:8080"
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = DefaultDowntimeConfig()
+	cfg.Window = -1
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = DefaultDowntimeConfig()
+	cfg.AlertThreshold = 1.5
+	assert.Error(t, cfg.ValidateBasic())
+}
+
+func TestInvariantsConfigValidateBasic(t *testing.T) {
+	cfg := DefaultInvariantsConfig()
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.CheckInterval = 100
+	cfg.OnViolation = InvariantViolationHalt
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.OnViolation = "panic"
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = DefaultInvariantsConfig()
+	cfg.CheckInterval = -1
+	assert.Error(t, cfg.ValidateBasic())
+}
+
+func TestFlightRecorderConfigValidateBasic(t *testing.T) {
+	cfg := DefaultFlightRecorderConfig()
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.Interval = 10 * time.Second
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.Interval = -1
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = DefaultFlightRecorderConfig()
+	cfg.MaxRecordings = 0
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = DefaultFlightRecorderConfig()
+	cfg.MaxDumps = 0
+	assert.Error(t, cfg.ValidateBasic())
+}
+
+func TestPrivValidatorConfigValidateBasic(t *testing.T) {
+	cfg := DefaultPrivValidatorConfig()
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.PKCS11Module = "/usr/lib/softhsm/libsofthsm2.so"
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg.PKCS11TokenLabel = "validator"
+	cfg.PKCS11KeyLabel = "consensus"
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.PKCS11KeyType = "sr25519"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.PKCS11KeyType = "secp256k1"
+	assert.NoError(t, cfg.ValidateBasic())
+
+	// a PKCS#11 token cannot be combined with a remote signer
+	cfg.ListenAddr = "tcp://127.0.0.1:26659"
+	assert.Error(t, cfg.ValidateBasic())
+}
+
+func TestTxIndexConfigValidateBasic(t *testing.T) {
+	cfg := TestTxIndexConfig()
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.IndexEvents = []string{"transfer.*"}
+	cfg.ExcludeEvents = []string{"transfer.memo"}
+	cfg.Sinks = map[string]*TxIndexSinkConfig{"psql": {IndexEvents: []string{"*"}}}
+	assert.NoError(t, cfg.ValidateBasic())
+
+	include, exclude := cfg.EventFilter("kv")
+	assert.Equal(t, []string{"transfer.*"}, include)
+	assert.Equal(t, []string{"transfer.memo"}, exclude)
+	include, exclude = cfg.EventFilter("psql")
+	assert.Equal(t, []string{"*"}, include)
+	assert.Equal(t, []string{"transfer.memo"}, exclude)
+
+	cfg.Sinks["psql"].ExcludeEvents = []string{""}
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.Sinks = map[string]*TxIndexSinkConfig{"clickhouse": {}}
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.Sinks = map[string]*TxIndexSinkConfig{"null": {}}
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.Sinks = nil
+	cfg.IndexEvents = []string{""}
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.IndexEvents = nil
+
+	cfg.PsqlRefreshViews = -1
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.PsqlRefreshViews = 0
+	cfg.GapCheckWindow = -1
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.GapCheckWindow = 0
+	cfg.GapCheckInterval = -time.Second
+	assert.Error(t, cfg.ValidateBasic())
+}
+
 func TestP2PConfigValidateBasic(t *testing.T) {
 	cfg := TestP2PConfig()
 	assert.NoError(t, cfg.ValidateBasic())
@@ -163,6 +401,7 @@ func TestP2PConfigValidateBasic(t *testing.T) {
 		"MaxPacketMsgPayloadSize",
 		"SendRate",
 		"RecvRate",
+		"PersistentPeersResolveInterval",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -171,3 +410,104 @@ func TestP2PConfigValidateBasic(t *testing.T) {
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
 }
+
+func TestP2PConfigGossipPolicies(t *testing.T) {
+	cfg := TestP2PConfig()
+	cfg.GossipPolicies = " abcd:no-addresses, *:blocks-only,ef01:all "
+	policies, err := cfg.ParseGossipPolicies()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"abcd": GossipPolicyNoAddresses,
+		"*":    GossipPolicyBlocksOnly,
+		"ef01": GossipPolicyAll,
+	}, policies)
+	assert.NoError(t, cfg.ValidateBasic())
+
+	for _, policies := range []string{"abcd", ":all", "abcd:none", "abcd:all,abcd:no-addresses"} {
+		cfg.GossipPolicies = policies
+		assert.Error(t, cfg.ValidateBasic(), policies)
+	}
+}
+
+func TestP2PConfigConnectionLimits(t *testing.T) {
+	cfg := TestP2PConfig()
+	cfg.ConnectionLimitAllowlist = " 10.0.0.0/8, 192.0.2.1,2001:db8::/32 "
+	networks, err := cfg.ParseConnectionLimitAllowlist()
+	require.NoError(t, err)
+	require.Len(t, networks, 3)
+	assert.Equal(t, "10.0.0.0/8", networks[0].String())
+	assert.Equal(t, "192.0.2.1/32", networks[1].String())
+	assert.Equal(t, "2001:db8::/32", networks[2].String())
+	assert.NoError(t, cfg.ValidateBasic())
+
+	for _, allowlist := range []string{"10.0.0.0/33", "host", "10.0.0"} {
+		cfg.ConnectionLimitAllowlist = allowlist
+		assert.Error(t, cfg.ValidateBasic(), allowlist)
+	}
+
+	cfg.ConnectionLimitAllowlist = ""
+	cfg.MaxConnectionsPerIP = 4
+	cfg.MaxConnectionsPerSubnet = 2
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.MaxConnectionsPerSubnet = 0
+	assert.NoError(t, cfg.ValidateBasic())
+}
+
+func TestP2PConfigNetworkKey(t *testing.T) {
+	cfg := TestP2PConfig()
+	key, err := cfg.NetworkKeyBytes()
+	require.NoError(t, err)
+	assert.Nil(t, key)
+
+	cfg.NetworkKey = strings.Repeat("ab", 32)
+	key, err = cfg.NetworkKeyBytes()
+	require.NoError(t, err)
+	assert.Len(t, key, 32)
+	assert.NoError(t, cfg.ValidateBasic())
+
+	for _, networkKey := range []string{"xyz", strings.Repeat("ab", 31), strings.Repeat("ab", 33)} {
+		cfg.NetworkKey = networkKey
+		assert.Error(t, cfg.ValidateBasic(), networkKey)
+	}
+}
+
+func TestP2PConfigCompressions(t *testing.T) {
+	cfg := TestP2PConfig()
+	compressions, err := cfg.Compressions()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"zstd", "snappy"}, compressions)
+
+	cfg.Compression = ""
+	compressions, err = cfg.Compressions()
+	require.NoError(t, err)
+	assert.Empty(t, compressions)
+
+	cfg.Compression = "snappy, zstd"
+	compressions, err = cfg.Compressions()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"snappy", "zstd"}, compressions)
+
+	cfg.Compression = "zstd,gzip"
+	assert.Error(t, cfg.ValidateBasic())
+}
+
+func TestP2PConfigDialProxy(t *testing.T) {
+	cfg := TestP2PConfig()
+	proxyURL, err := cfg.DialProxyURL()
+	require.NoError(t, err)
+	assert.Nil(t, proxyURL)
+
+	cfg.DialProxyOnly = true
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg.DialProxy = "socks5://127.0.0.1:9050"
+	proxyURL, err = cfg.DialProxyURL()
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:9050", proxyURL.Host)
+	assert.NoError(t, cfg.ValidateBasic())
+
+	for _, dialProxy := range []string{"http://127.0.0.1:8080", "socks5://", "::"} {
+		cfg.DialProxy = dialProxy
+		assert.Error(t, cfg.ValidateBasic(), dialProxy)
+	}
+}
@@ -87,6 +87,18 @@ const defaultConfigTemplate = `# This is a TOML config file.
 # or the name of an ABCI application compiled in with the Tendermint binary
 proxy-app = "{{ .BaseConfig.ProxyApp }}"
 
+# Height of the first block to execute with the upgraded ABCI application at
+# upgrade-proxy-app instead of the one at proxy-app. The node switches over once
+# the previous block has been committed, waiting for the upgraded application
+# to become available, so the application can be upgraded in place without a
+# coordinated restart. If upgrade-proxy-app is empty, the node halts before
+# executing the block at this height instead. 0 disables upgrades.
+upgrade-height = {{ .BaseConfig.UpgradeHeight }}
+
+# TCP or UNIX socket address of the upgraded ABCI application,
+# or the name of an ABCI application compiled in with the Tendermint binary
+upgrade-proxy-app = "{{ .BaseConfig.UpgradeProxyApp }}"
+
 # A custom human readable name for this node
 moniker = "{{ .BaseConfig.Moniker }}"
 
@@ -126,6 +138,11 @@ db-backend = "{{ .BaseConfig.DBBackend }}"
 # Database directory
 db-dir = "{{ js .BaseConfig.DBPath }}"
 
+# If true, blocks are written to the block store without waiting for them
+# to be fsynced to disk. The fsync is instead done concurrently with
+# saving the results of executing the block, before its state is saved.
+block-store-async-sync = {{ .BaseConfig.BlockStoreAsyncSync }}
+
 # Output level for logging, including package level options
 log-level = "{{ .BaseConfig.LogLevel }}"
 
@@ -137,16 +154,65 @@ log-format = "{{ .BaseConfig.LogFormat }}"
 # Path to the JSON file containing the initial validator set and other meta data
 genesis-file = "{{ js .BaseConfig.Genesis }}"
 
+# If positive, the genesis file is parsed as a stream, leaving its app_state
+# on disk, and the app state is delivered to the ABCI application in InitChain
+# requests carrying chunks of at most this many bytes. Set it for genesis files
+# too large to load into memory; 0 loads the whole genesis file and sends the
+# app state in a single InitChain. The node refuses to start if the application
+# declares its ABCI features without the "init-chain-chunks" feature.
+genesis-app-state-chunk-size = {{ .BaseConfig.GenesisAppStateChunkSize }}
+
+# Hex encoded SHA-256 hash of the genesis doc the node must run, as reported in
+# the node_info of /status. The node refuses to start on a genesis doc with a
+# different hash. Empty disables the check.
+genesis-hash = "{{ .BaseConfig.GenesisHash }}"
+
 # Path to the JSON file containing the private key to use for node authentication in the p2p protocol
 node-key-file = "{{ js .BaseConfig.NodeKey }}"
 
-# Mechanism to connect to the ABCI application: socket | grpc
+# Mechanism to connect to the ABCI application: socket | grpc | grpc-stream,
+# or socket-legacy | grpc-legacy for applications executing blocks with
+# BeginBlock, DeliverTx and EndBlock rather than FinalizeBlock
 abci = "{{ .BaseConfig.ABCI }}"
 
+# Number of connections to the ABCI application the mempool dispatches CheckTx
+# requests to round-robin, so that applications serving connections
+# concurrently check transactions in parallel. It's capped by the CheckTx
+# concurrency advertised by the application in its Info response, and 0 uses
+# the latter (or 1 if it advertises none). The consensus connection is always
+# a single connection.
+mempool-connections = {{ .BaseConfig.MempoolConnections }}
+
+# Maximum durations of the calls on the consensus, mempool and query
+# connections to the ABCI application, after which they fail rather than
+# blocking their callers forever on a hung application. The mempool timeout
+# also bounds the flushes waiting for pending CheckTx responses, e.g. before
+# Commit. A timed out call fails like any ABCI client error, e.g. Commit halts
+# the block execution. 0 means no limit.
+abci-consensus-timeout = "{{ .BaseConfig.ABCIConsensusTimeout }}"
+abci-mempool-timeout = "{{ .BaseConfig.ABCIMempoolTimeout }}"
+abci-query-timeout = "{{ .BaseConfig.ABCIQueryTimeout }}"
+
 # If true, query the ABCI app on connecting to a new peer
 # so the app can decide if we should keep the connection or not
 filter-peers = {{ .BaseConfig.FilterPeers }}
 
+# Maximum amount of time to wait, while shutting down, for an in-flight
+# block commit to finish and for services to drain before the node's
+# stores are closed.
+shutdown-grace-period = "{{ .BaseConfig.ShutdownGracePeriod }}"
+
+# Directory, or http(s) URL such as that of a public S3 bucket, of an archive
+# of exported block files, which block sync applies before fetching blocks
+# from peers. Relative paths are relative to the root directory.
+block-archive = "{{ .BaseConfig.BlockArchive }}"
+
+# Checkpoints of the chain, as comma-separated "height:hash" pairs of the
+# heights and hex-encoded hashes of blocks. Block sync and the light client of
+# state sync halt if the block at a checkpoint height has another hash,
+# protecting nodes syncing from genesis against long-range attacks.
+checkpoints = "{{ StringsJoin .BaseConfig.Checkpoints "," }}"
+
 
 #######################################################
 ###       Priv Validator Configuration              ###
@@ -162,6 +228,9 @@ state-file = "{{ js .PrivValidator.State }}"
 # TCP or UNIX socket address for Tendermint to listen on for
 # connections from an external PrivValidator process
 # when the listenAddr is prefixed with grpc instead of tcp it will use the gRPC Client
+# A comma-separated list of addresses connects to several remote signers of
+# the same key, failing over to the next one when the one signing is
+# unreachable.
 laddr = "{{ .PrivValidator.ListenAddr }}"
 
 # Path to the client certificate generated while creating needed files for secure connection.
@@ -174,6 +243,24 @@ client-key-file = "{{ js .PrivValidator.ClientKey }}"
 # Path to the Root Certificate Authority used to sign both client and server certificates
 root-ca-file = "{{ js .PrivValidator.RootCA }}"
 
+# Path to a PKCS#11 module (shared library). If set, the validator key is held
+# on a PKCS#11 token, such as an HSM, instead of in key-file. The last sign
+# state used for double sign protection is still kept in state-file.
+# Requires a binary built with TENDERMINT_BUILD_OPTIONS=pkcs11.
+pkcs11-module = "{{ js .PrivValidator.PKCS11Module }}"
+
+# Label of the PKCS#11 token holding the validator key
+pkcs11-token-label = "{{ js .PrivValidator.PKCS11TokenLabel }}"
+
+# Label of the validator key pair on the PKCS#11 token
+pkcs11-key-label = "{{ js .PrivValidator.PKCS11KeyLabel }}"
+
+# User PIN of the PKCS#11 token
+pkcs11-pin = "{{ js .PrivValidator.PKCS11PIN }}"
+
+# Type of the validator key on the PKCS#11 token: ed25519 | secp256k1
+pkcs11-key-type = "{{ .PrivValidator.PKCS11KeyType }}"
+
 
 #######################################################################
 ###                 Advanced Configuration Options                  ###
@@ -184,7 +271,9 @@ root-ca-file = "{{ js .PrivValidator.RootCA }}"
 #######################################################
 [rpc]
 
-# TCP or UNIX socket address for the RPC server to listen on
+# TCP or UNIX socket address for the RPC server to listen on, e.g.
+# "unix:///var/run/tm.sock" for clients on the same host. Without TLS, the
+# server accepts cleartext HTTP/2 (h2c) besides HTTP/1.1.
 laddr = "{{ .RPC.ListenAddress }}"
 
 # A list of origins a cross-domain request can be executed from
@@ -231,6 +320,22 @@ max-body-bytes = {{ .RPC.MaxBodyBytes }}
 # Maximum size of request header, in bytes
 max-header-bytes = {{ .RPC.MaxHeaderBytes }}
 
+# Maximum number of calls of a JSON-RPC batch request executed concurrently.
+# The responses are returned in the order of the calls.
+# 0 executes the calls one at a time.
+max-batch-concurrency = {{ .RPC.MaxBatchConcurrency }}
+
+# Maximum number of blocks the node can be behind the highest block reported by
+# its peers for the /health/ready probe to report it ready.
+health-max-blocks-behind = {{ .RPC.HealthMaxBlocksBehind }}
+
+# How long the /health/live and /health/ready probes wait for the consensus
+# state machine and the ABCI application to respond before reporting the node
+# unhealthy. The timeout of the probes of the orchestrator, e.g. the
+# timeoutSeconds of Kubernetes probes, should be greater. 0 waits as long as the
+# probe request lasts.
+health-timeout = "{{ .RPC.HealthTimeout }}"
+
 # The path to a file containing certificate that is used to create the HTTPS server.
 # Might be either absolute path or path related to Tendermint's config directory.
 # If the certificate is signed by a certificate authority,
@@ -249,6 +354,69 @@ tls-key-file = "{{ .RPC.TLSKeyFile }}"
 # pprof listen address (https://golang.org/pkg/net/http/pprof)
 pprof-laddr = "{{ .RPC.PprofListenAddress }}"
 
+# Profiles served by the pprof server, among heap, mutex, block and trace,
+# which can be enabled and disabled at runtime with the unsafe_set_profile RPC
+# method. The CPU and other profiles are always served.
+pprof-profiles = "{{ StringsJoin .RPC.PprofProfiles "," }}"
+
+# Fraction of mutex contention events sampled by the mutex profile, as in
+# runtime.SetMutexProfileFraction. 0 samples none.
+pprof-mutex-profile-fraction = {{ .RPC.PprofMutexProfileFraction }}
+
+# Rate of blocking events sampled by the block profile, as in
+# runtime.SetBlockProfileRate. 0 samples none.
+pprof-block-profile-rate = {{ .RPC.PprofBlockProfileRate }}
+
+# Size of the heap, in bytes, past which a heap profile is written to
+# heap-profile-dir, whether or not the pprof server is enabled. Another profile
+# is only written once the heap shrank below the watermark and grew past it
+# again. 0 disables heap profile capture.
+heap-profile-watermark = {{ .RPC.HeapProfileWatermark }}
+
+# Directory the heap profiles are written to, keeping the latest 10. Relative
+# paths are relative to the root directory.
+heap-profile-dir = "{{ js .RPC.HeapProfileDir }}"
+
+# The path to a JSON file of the API keys clients authenticate with, sending
+# an "Authorization: Bearer <key>" header. Each key grants access to a list of
+# methods, where "*" stands for all of them, and may be rate limited, in calls
+# per second shared by all the clients using the key. A key with an empty
+# value applies to the clients which send no key; without one, they are
+# refused. For example:
+#
+# [
+#   {"name": "public", "key": "", "methods": ["health", "status"], "rate_limit": 10},
+#   {"name": "partner", "key": "<secret>", "methods": ["*"], "rate_limit": 100, "burst": 200}
+# ]
+#
+# Might be either absolute path or path related to Tendermint's config
+# directory. If neither it nor auth-keys are set, the RPC server doesn't
+# require authentication.
+auth-keys-file = "{{ js .RPC.AuthKeysFile }}"
+
+# API keys in the same JSON format as auth-keys-file, in addition to the keys
+# of the file, e.g. to pass them through the TM_RPC_AUTH_KEYS environment
+# variable.
+auth-keys = "{{ js .RPC.AuthKeys }}"
+
+# Additional listeners, each serving only the RPC methods in its allowlist,
+# e.g. to serve read-only methods publicly and unsafe methods on a separate
+# admin address. In methods, "*" stands for all the methods served on laddr
+# above, which only include the unsafe methods if unsafe is true; unsafe
+# methods can otherwise be listed by name. For example:
+#
+# [rpc.listeners.public]
+# laddr = "tcp://0.0.0.0:26667"
+# methods = ["health", "status", "block", "tx", "broadcast_tx_sync"]
+#
+# [rpc.listeners.admin]
+# laddr = "tcp://127.0.0.1:26659"
+# methods = ["*", "unsafe_flush_mempool", "remove_tx"]
+{{ range $name, $l := .RPC.Listeners }}
+[rpc.listeners.{{ $name }}]
+laddr = "{{ $l.ListenAddress }}"
+methods = [{{ range $l.Methods }}{{ printf "%q, " . }}{{end}}]
+{{ end }}
 #######################################################
 ###           P2P Configuration Options             ###
 #######################################################
@@ -282,25 +450,114 @@ bootstrap-peers = "{{ .P2P.BootstrapPeers }}"
 # Comma separated list of nodes to keep persistent connections to
 persistent-peers = "{{ .P2P.PersistentPeers }}"
 
-# UPNP port forwarding
+# Interval at which the hostnames of persistent peers are resolved again. When
+# they resolve to new IP addresses, e.g. when a peer is rescheduled, the peers
+# are redialed without waiting out their retry backoff. 0 disables it.
+persistent-peers-resolve-interval = "{{ .P2P.PersistentPeersResolveInterval }}"
+
+# Path to the address book of the legacy p2p stack (addrbook.json). If it
+# exists on start, its addresses are imported into the peer store, keeping
+# their sources and dial history, and it is renamed with a .migrated suffix.
+legacy-addr-book-file = "{{ js .P2P.LegacyAddrBook }}"
+
+# Map the listen port on the NAT gateway with UPnP or NAT-PMP, and advertise
+# the gateway's external address to peers, unless external-address is set.
 upnp = {{ .P2P.UPNP }}
 
+# Advertise the IP address that peers observe this node's connections from,
+# once enough peers agree on it, unless external-address is set or the NAT
+# gateway reports a public address. The port is the mapped port, or the port
+# of laddr.
+detect-external-address = {{ .P2P.DetectExternalAddress }}
+
 # Maximum number of connections (inbound and outbound).
 max-connections = {{ .P2P.MaxConnections }}
 
+# Maximum number of inbound connections, lower than max-connections. The
+# remaining connections are kept for the peers this node dials, so that peers
+# dialing it can't take all of them. Validators and unconditional peers are
+# accepted beyond it. 0 disables the limit.
+max-inbound-connections = {{ .P2P.MaxInboundConnections }}
+
+# Maximum number of outbound connections. Unconditional peers are dialed
+# beyond it. 0 disables the limit.
+max-outbound-connections = {{ .P2P.MaxOutboundConnections }}
+
+# Maximum number of connections with peers with the same IP address, so that
+# a single host can't take the connections by running many nodes. Validators,
+# unconditional peers and peers in connection-limit-allowlist are connected
+# beyond it. 0 disables the limit.
+max-connections-per-ip = {{ .P2P.MaxConnectionsPerIP }}
+
+# Maximum number of connections with peers in the same /24 IPv4 subnet, or /64
+# IPv6 subnet, with the same exceptions as max-connections-per-ip. 0 disables
+# the limit.
+max-connections-per-subnet = {{ .P2P.MaxConnectionsPerSubnet }}
+
+# Comma separated list of IP addresses and CIDR networks of peers exempt from
+# max-connections-per-ip and max-connections-per-subnet, e.g. the private
+# network of a validator and its sentries.
+connection-limit-allowlist = "{{ .P2P.ConnectionLimitAllowlist }}"
+
 # Rate limits the number of incoming connection attempts per IP address.
 max-incoming-connection-attempts = {{ .P2P.MaxIncomingConnectionAttempts }}
 
 # Set true to enable the peer-exchange reactor
 pex = {{ .P2P.PexReactor }}
 
+# Set true to drop the peer addresses gossiped without a signature of the
+# node they point at. Nodes sign their own address, and gossip the signatures
+# of the others, regardless, so this can be enabled once the whole network
+# signs its addresses.
+pex-require-signed-addresses = {{ .P2P.PexRequireSignedAddresses }}
+
+# Comma separated list of networks (chain IDs) other than the one of the
+# genesis served by a seed node, which keeps an address book per network
+# Only used in seed mode
+seed-networks = "{{ .P2P.SeedNetworks }}"
+
 # Comma separated list of peer IDs to keep private (will not be gossiped to other peers)
 # Warning: IPs will be exposed at /net_info, for more information https://github.com/tendermint/tendermint/issues/3055
 private-peer-ids = "{{ .P2P.PrivatePeerIDs }}"
 
+# Comma separated list of peer IDs to always accept connections from and dial,
+# even when max-connections is reached (e.g. the validator behind a sentry)
+unconditional-peer-ids = "{{ .P2P.UnconditionalPeerIDs }}"
+
+# Comma separated list of gossip policies of peers, of the form <ID>:<policy>.
+# An ID of "*" sets the policy of all other peers. Policies:
+#   1) "all" (default) - send all messages to the peer.
+#   2) "no-addresses" - never send peer addresses to the peer.
+#   3) "blocks-only" - only send consensus and block messages, and no
+#      transactions, evidence or peer addresses.
+gossip-policies = "{{ .P2P.GossipPolicies }}"
+
 # Toggle to disable guard against peers connecting from the same ip.
 allow-duplicate-ip = {{ .P2P.AllowDuplicateIP }}
 
+# Dial peers with the Noise IK handshake instead of the secret connection
+# handshake, once they are known to support it. It hides the node's identity
+# from passive observers.
+noise-handshake = {{ .P2P.NoiseHandshake }}
+
+# Hex encoded 32 byte pre-shared key of a permissioned network. If set, only
+# peers with the same key can connect, using the Noise handshake.
+network-key = "{{ .P2P.NetworkKey }}"
+
+# Comma separated list of the compression algorithms of large messages
+# ("zstd", "snappy") in order of preference. The first one supported by a peer
+# is used with it. Empty disables compression.
+compression = "{{ .P2P.Compression }}"
+
+# URL of a SOCKS5 proxy to dial outbound peers through, e.g.
+# "socks5://127.0.0.1:9050" for Tor. Onion addresses can only be dialed
+# through it. Private and loopback addresses, which the proxy may not reach,
+# are dialed directly unless dial-proxy-only is set.
+dial-proxy = "{{ .P2P.DialProxy }}"
+
+# Refuse to dial peers other than through the dial-proxy.
+dial-proxy-only = {{ .P2P.DialProxyOnly }}
+
 # Peer connection configuration.
 handshake-timeout = "{{ .P2P.HandshakeTimeout }}"
 dial-timeout = "{{ .P2P.DialTimeout }}"
@@ -338,6 +595,23 @@ size = {{ .Mempool.Size }}
 # max-txs-bytes=5MB, mempool will only accept 5 transactions).
 max-txs-bytes = {{ .Mempool.MaxTxsBytes }}
 
+# Lanes of the mempool, as comma-separated "name:size:max-txs-bytes" triples,
+# in the order their transactions are reaped into blocks, e.g.
+# "oracle:1000:1048576". The application assigns transactions to lanes by name
+# in CheckTx, and each lane has its own size limits and gossip channel, so that
+# transactions of a lane are never evicted or delayed by those of another.
+# Transactions of no configured lane are in the default lane, limited by size
+# and max-txs-bytes and reaped last. At most 3 lanes can be configured. Lanes
+# are disabled if the application declares its ABCI features without the
+# "mempool-lanes" feature.
+lanes = "{{ StringsJoin .Mempool.Lanes "," }}"
+
+# Minimum priority increase, in percent, of a transaction replacing the one in
+# the mempool of the same sender and sequence, as reported by the application
+# in CheckTx. The replaced transaction is evicted, and the replacement gossiped.
+# 0 accepts any higher priority.
+replacement-priority-bump = {{ .Mempool.ReplacementPriorityBump }}
+
 # Size of the cache (used to filter transactions we saw earlier) in transactions
 cache-size = {{ .Mempool.CacheSize }}
 
@@ -346,6 +620,39 @@ cache-size = {{ .Mempool.CacheSize }}
 # again in the future.
 keep-invalid-txs-in-cache = {{ .Mempool.KeepInvalidTxsInCache }}
 
+# Size of the cache of the CheckTx responses of rejected transactions, keyed by
+# transaction hash. A re-broadcast transaction with a cached response is refused
+# with it without calling CheckTx again, which spares the application from
+# re-checking transactions rejected for deterministic reasons, such as a bad
+# signature or a wrong chain ID. 0 disables the cache.
+check-tx-cache-size = {{ .Mempool.CheckTxCacheSize }}
+
+# Drop the cached CheckTx responses when a block is committed. Disable only if
+# the application's rejections never depend on the state.
+check-tx-cache-reset-on-commit = {{ .Mempool.CheckTxCacheResetOnCommit }}
+
+# CheckTx codes of rejections depending on the state, e.g. an insufficient
+# balance or a wrong sequence number, which are never cached.
+check-tx-cache-bypass-codes = [{{ range $i, $c := .Mempool.CheckTxCacheBypassCodes }}{{if $i}}, {{end}}{{ $c }}{{end}}]
+
+# File the transactions rejected by CheckTx are logged to, as JSON lines with
+# their hash, code, codespace and log, so that application developers can find
+# out why transactions never land without raising the log level. The file is
+# rotated every 10MB, keeping at most 100MB of logs. Disabled if empty.
+rejected-txs-log-file = "{{ js .Mempool.RejectedTxsLogPath }}"
+
+# Archive the transactions broadcast by the node or seen in its mempool, with
+# their status (pending, rejected, evicted, replaced, expired, removed or
+# committed), in the tx_archive database. The archive is looked up by hash or
+# by sender, as reported by the application in CheckTx, with the archived_tx
+# and archived_txs RPC endpoints, e.g. for wallets to list the pending
+# transactions of an account.
+tx-archive = {{ .Mempool.TxArchive }}
+
+# How long the archived transactions are kept, since they were first seen.
+# 0 keeps them forever.
+tx-archive-retention = "{{ .Mempool.TxArchiveRetention }}"
+
 # Maximum size of a single transaction.
 # NOTE: the max size of a tx transmitted over the network is {max-tx-bytes}.
 max-tx-bytes = {{ .Mempool.MaxTxBytes }}
@@ -415,6 +722,14 @@ chunk-request-timeout = "{{ .StateSync.ChunkRequestTimeout }}"
 # The number of concurrent chunk and block fetchers to run (default: 4).
 fetchers = "{{ .StateSync.Fetchers }}"
 
+# File the progress of snapshot restoration is persisted to, relative to the
+# home directory, e.g. "data/statesync-restore.json". A restoration
+# interrupted by a restart then resumes from the last applied chunk instead of
+# starting over. This requires the application to keep the chunks it applied
+# across restarts, as the snapshot is not offered to it again. Disabled if
+# empty.
+restore-progress-file = "{{ js .StateSync.RestoreProgressPath }}"
+
 #######################################################
 ###         Consensus Configuration Options         ###
 #######################################################
@@ -422,6 +737,18 @@ fetchers = "{{ .StateSync.Fetchers }}"
 
 wal-file = "{{ js .Consensus.WalPath }}"
 
+# When the WAL is synced to disk (fsync):
+#   - "every-message": after every message the node writes synchronously, i.e.
+#     its own proposals, block parts and votes.
+#   - "every-height": at the end of every height.
+#   - "interval=<duration>", e.g. "interval=100ms": at the end of every height
+#     and at the given interval.
+# The WAL is also synced before the node signs a proposal or a vote, and every
+# 2s in the first two modes. On reliable hardware, syncing less often trades a
+# window of messages that may not be replayed after a power loss for a higher
+# commit rate, notably on spinning disks.
+wal-fsync-mode = "{{ .Consensus.WALFsyncMode }}"
+
 # How long we wait for a proposal block before prevoting nil
 timeout-propose = "{{ .Consensus.TimeoutPropose }}"
 # How much timeout-propose increases with each round
@@ -448,6 +775,16 @@ double-sign-check-height = {{ .Consensus.DoubleSignCheckHeight }}
 # Make progress as soon as we have all the precommits (as if TimeoutCommit = 0)
 skip-timeout-commit = {{ .Consensus.SkipTimeoutCommit }}
 
+# Adapt timeout-propose and timeout-commit to the recent durations of rounds,
+# within the bounds below: timeout-propose to the delay of proposals, and
+# timeout-commit to the latency of votes. The timeouts above are used until
+# there are samples.
+adaptive-timeouts = {{ .Consensus.AdaptiveTimeouts }}
+timeout-propose-min = "{{ .Consensus.TimeoutProposeMin }}"
+timeout-propose-max = "{{ .Consensus.TimeoutProposeMax }}"
+timeout-commit-min = "{{ .Consensus.TimeoutCommitMin }}"
+timeout-commit-max = "{{ .Consensus.TimeoutCommitMax }}"
+
 # EmptyBlocks mode and possible interval between empty blocks
 create-empty-blocks = {{ .Consensus.CreateEmptyBlocks }}
 create-empty-blocks-interval = "{{ .Consensus.CreateEmptyBlocksInterval }}"
@@ -456,6 +793,63 @@ create-empty-blocks-interval = "{{ .Consensus.CreateEmptyBlocksInterval }}"
 peer-gossip-sleep-duration = "{{ .Consensus.PeerGossipSleepDuration }}"
 peer-query-maj23-sleep-duration = "{{ .Consensus.PeerQueryMaj23SleepDuration }}"
 
+# Minimum interval between checkpoints of the consensus state of a height
+# (round, step, proposal, locked and valid blocks) to the state DB. After a
+# crash, the node restores the last checkpoint and replays only the WAL messages
+# written after it, rather than all the messages of the height, which cuts the
+# restart time when blocks are large. 0 disables checkpoints.
+checkpoint-interval = "{{ .Consensus.CheckpointInterval }}"
+
+# Send proposed blocks to peers as compact blocks, carrying the hashes of their
+# transactions rather than the transactions, which peers reconstruct from their
+# mempool, requesting any missing transactions. This reduces the bandwidth used
+# by proposals when most transactions have already been gossiped. Peers fall
+# back to the block parts if a compact block can't be reconstructed, and are
+# sent the parts if they don't have the block after peer-gossip-sleep-duration.
+compact-blocks = {{ .Consensus.CompactBlocks }}
+
+# Send the votes a peer is missing for the same block, or for nil, as a batch
+# carrying the bit-array of their validators and their signatures, rather than
+# one by one, to the peers supporting vote batches. This reduces the number of
+# messages gossiped for the votes of large validator sets.
+vote-batches = {{ .Consensus.VoteBatches }}
+
+# Halt the node after committing the block at halt-height, or the first block
+# with a time at or after halt-time (in seconds since the Unix epoch), e.g. to
+# upgrade the binaries of all nodes at the same height. The height, time and app
+# hash of the last block are written to halt-marker-file, and the node refuses to
+# execute further blocks until restarted with a different halt configuration.
+# The status RPC reports a pending halt. 0 disables halting.
+halt-height = {{ .Consensus.HaltHeight }}
+halt-time = {{ .Consensus.HaltTime }}
+halt-marker-file = "{{ js .Consensus.HaltMarkerPath }}"
+
+# Directory to write a forensic dump to when the app hash of a committed block
+# does not match the app hash returned by the application, with the block, the
+# peer it was received from, the ABCI requests and responses executing the
+# previous block and the state before and after it, for the failure to be
+# diagnosed post-mortem. The dumps are served by the app_hash_mismatches RPC.
+# Empty disables the dumps.
+forensics-dir = "{{ js .Consensus.ForensicsPath }}"
+
+# Development mode for single validator networks: do not write the consensus
+# WAL, and use timeouts of a few milliseconds, so that blocks are committed in
+# milliseconds. The node refuses to start with this option if there is more
+# than one validator, and starts writing the WAL, with the configured timeouts,
+# if validators are added. Never use it in production: a validator restarting
+# after a crash may double sign without the WAL.
+skip-wal = {{ .Consensus.SkipWAL }}
+
+# Publish a ConsensusStep event for every step transition of the consensus state
+# machine, with the time spent in the previous step, and a ConsensusVote and a
+# ConsensusBlockPart event for every vote and block part received, with the peer
+# it was received from, for external consensus monitors such as timing
+# dashboards. The events carry the consensus.height, consensus.round and
+# consensus.step or consensus.peer keys, e.g. subscribe to
+# "consensus.height EXISTS" for all of them. They are frequent, so leave this
+# disabled unless they are consumed.
+event-firehose = {{ .Consensus.EventFirehose }}
+
 #######################################################
 ###   Transaction Indexer Configuration Options     ###
 #######################################################
@@ -471,13 +865,61 @@ peer-query-maj23-sleep-duration = "{{ .Consensus.PeerQueryMaj23SleepDuration }}"
 #   1) "null"
 #   2) "kv" (default) - the simplest possible indexer, backed by key-value storage (defaults to levelDB; see DBBackend).
 #   3) "psql" - the indexer services backed by PostgreSQL.
-# When "kv" or "psql" is chosen "tx.height" and "tx.hash" will always be indexed.
+#   4) "sqlite" - the indexer services backed by a SQLite database file.
+#   5) "grpc" - the indexer services of an event sink running out of process,
+#      served over gRPC at grpc-addr.
+# Event sinks compiled into the node binary are available under the name they
+# are registered with.
+# When "kv", "psql" or "sqlite" is chosen "tx.height" and "tx.hash" will always be indexed.
 indexer = [{{ range $i, $e := .TxIndex.Indexer }}{{if $i}}, {{end}}{{ printf "%q" $e}}{{end}}]
 
 # The PostgreSQL connection configuration, the connection format:
 #   postgresql://<user>:<password>@<host>:<port>/<db>?<opts>
 psql-conn = "{{ .TxIndex.PsqlConn }}"
 
+# The number of blocks between refreshes of the materialized views of the
+# "psql" indexer, which must then be installed from
+# state/indexer/sink/psql/views.sql. 0 disables the refreshes.
+psql-refresh-views = {{ .TxIndex.PsqlRefreshViews }}
+
+# The path to the database file of the "sqlite" indexer, relative to the home
+# directory. If empty, tx_index.sqlite in the db-dir is used.
+sqlite-path = "{{ .TxIndex.SqlitePath }}"
+
+# The address of the "grpc" indexer, e.g. "tcp://127.0.0.1:26670" or
+# "unix:///path/to/sink.sock".
+grpc-addr = "{{ .TxIndex.GRPCAddr }}"
+
+# The composite keys (<type>.<key>) of the event attributes to index, where
+# "*" matches any sequence of characters, e.g. ["transfer.*", "message.action"].
+# If empty, all the attributes the application flags for indexing are indexed.
+index-events = [{{ range $i, $e := .TxIndex.IndexEvents }}{{if $i}}, {{end}}{{ printf "%q" $e}}{{end}}]
+
+# The composite keys of the event attributes never to index, with the same
+# syntax as index-events.
+exclude-events = [{{ range $i, $e := .TxIndex.ExcludeEvents }}{{if $i}}, {{end}}{{ printf "%q" $e}}{{end}}]
+
+# The number of most recent heights checked for blocks missing from the
+# indexers, e.g. after the node crashed while indexing, which are then indexed
+# from the block and state stores. The "null" and "psql" indexers aren't
+# checked. 0 disables the checks.
+gap-check-window = {{ .TxIndex.GapCheckWindow }}
+
+# How often the heights are checked for gaps, in addition to at startup.
+# 0 checks them at startup only.
+gap-check-interval = "{{ .TxIndex.GapCheckInterval }}"
+
+# Overrides of index-events and exclude-events, by indexer. Non-empty lists
+# replace the lists above for the indexer, and index-events = ["*"] indexes all
+# the events. For example, to index all the events in PostgreSQL only:
+#
+# [tx-index.sinks.psql]
+# index-events = ["*"]
+{{ range $name, $s := .TxIndex.Sinks }}
+[tx-index.sinks.{{ $name }}]
+index-events = [{{ range $i, $e := $s.IndexEvents }}{{if $i}}, {{end}}{{ printf "%q" $e}}{{end}}]
+exclude-events = [{{ range $i, $e := $s.ExcludeEvents }}{{if $i}}, {{end}}{{ printf "%q" $e}}{{end}}]
+{{ end }}
 #######################################################
 ###       Instrumentation Configuration Options     ###
 #######################################################
@@ -499,6 +941,71 @@ max-open-connections = {{ .Instrumentation.MaxOpenConnections }}
 
 # Instrumentation namespace
 namespace = "{{ .Instrumentation.Namespace }}"
+
+[instrumentation.tracing]
+
+# Exporter to which OpenTelemetry trace spans are sent:
+#   1) "none" (default) - tracing is disabled.
+#   2) "otlp" - spans are sent to an OpenTelemetry collector over gRPC.
+exporter = "{{ .Instrumentation.Tracing.Exporter }}"
+
+# Address of the OpenTelemetry collector, as host:port.
+endpoint = "{{ .Instrumentation.Tracing.Endpoint }}"
+
+# When true, the connection to the collector is not secured with TLS.
+insecure = {{ .Instrumentation.Tracing.Insecure }}
+
+# Fraction of traces to sample, between 0 and 1.
+sample-rate = {{ .Instrumentation.Tracing.SampleRate }}
+
+[instrumentation.downtime]
+
+# Number of the last blocks the validator was expected to sign over which its
+# missed blocks are counted, in validator mode. 0 disables the tracking.
+window = {{ .Instrumentation.Downtime.Window }}
+
+# Fraction of the blocks of the window, between 0 and 1, which the validator
+# must miss for an alert to be raised, so that operators are paged before the
+# application jails the validator. An alert is also raised when the miss rate
+# falls back below it.
+alert-threshold = {{ .Instrumentation.Downtime.AlertThreshold }}
+
+# URL which the alerts are POSTed to as JSON, e.g. that of a paging service.
+# If empty, the alerts are only logged and counted in the metrics.
+webhook-url = "{{ .Instrumentation.Downtime.WebhookURL }}"
+
+[instrumentation.invariants]
+
+# Number of blocks between the checks of the invariants of the stored blocks
+# and state, checked in the background after committing a block at a multiple
+# of it: the heights of the block store and state store are consistent, the
+# validator set hashes of the state match the header of the last block, the
+# app hash of the state is the one of the next block, and the seen commit of
+# the last block is stored. 0 disables the checks.
+check-interval = {{ .Instrumentation.Invariants.CheckInterval }}
+
+# Action taken when an invariant is violated:
+#   1) "alert" (default) - the violation is logged and counted in the metrics.
+#   2) "halt" - the violation is logged and counted, and the node stops.
+on-violation = "{{ .Instrumentation.Invariants.OnViolation }}"
+
+[instrumentation.flight-recorder]
+
+# Interval between the recordings of the flight recorder, which continuously
+# records the goroutines, consensus state, peers and p2p metrics of the node to
+# a ring in data/flight_recorder/ring, e.g. "10s". The ring is dumped to a zip
+# file in data/flight_recorder/dumps on demand, with the
+# unsafe_dump_flight_recorder RPC endpoint, when consensus fails, and when the
+# node starts after it didn't stop cleanly, e.g. after a panic. It's exported
+# from a stopped node with "tendermint debug flight-recorder". 0 disables the
+# flight recorder.
+interval = "{{ .Instrumentation.FlightRecorder.Interval }}"
+
+# Number of the last recordings kept in the ring, the oldest being removed.
+max-recordings = {{ .Instrumentation.FlightRecorder.MaxRecordings }}
+
+# Number of the last dumps of the ring kept, the oldest being removed.
+max-dumps = {{ .Instrumentation.FlightRecorder.MaxDumps }}
 `
 
 /****** these are for test settings ***********/
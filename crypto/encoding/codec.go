@@ -15,6 +15,7 @@ func init() {
 	json.RegisterType((*cryptoproto.PublicKey)(nil), "tendermint.crypto.PublicKey")
 	json.RegisterType((*cryptoproto.PublicKey_Ed25519)(nil), "tendermint.crypto.PublicKey_Ed25519")
 	json.RegisterType((*cryptoproto.PublicKey_Secp256K1)(nil), "tendermint.crypto.PublicKey_Secp256K1")
+	json.RegisterType((*cryptoproto.PublicKey_Sr25519)(nil), "tendermint.crypto.PublicKey_Sr25519")
 }
 
 // PubKeyToProto takes crypto.PubKey and transforms it to a protobuf Pubkey
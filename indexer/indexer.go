@@ -0,0 +1,64 @@
+// Package indexer lets projects building their own node binary provide event
+// sinks, indexing the block and transaction events of the node in their own
+// data stores, e.g. ClickHouse or BigQuery.
+//
+// Sinks compiled into the binary are registered under a name with Register,
+// usually from the init function of their package, and enabled by listing
+// that name in the tx-index.indexer option of the config:
+//
+//	func init() {
+//		indexer.Register("clickhouse", func(cfg *config.Config, _ config.DBProvider, chainID string) (indexer.EventSink, error) {
+//			return clickhouse.NewEventSink(cfg.RootDir, chainID)
+//		})
+//	}
+//
+// Sinks running out of process are served with NewGRPCServer, or implement
+// the gRPC service defined in internal/state/indexer/sink/grpc/sink.proto,
+// and are enabled by the "grpc" indexer with the address of their server in
+// tx-index.grpc-addr.
+package indexer
+
+import (
+	"google.golang.org/grpc"
+
+	"github.com/tendermint/tendermint/internal/pubsub/query"
+	"github.com/tendermint/tendermint/internal/state/indexer"
+	"github.com/tendermint/tendermint/internal/state/indexer/sink"
+	grpcsink "github.com/tendermint/tendermint/internal/state/indexer/sink/grpc"
+)
+
+type (
+	// EventSink is the interface of the event sinks, which index the block
+	// and transaction events of the node and search them.
+	EventSink = indexer.EventSink
+
+	// EventSinkType is the type of an event sink, i.e. the name it's enabled
+	// with in tx-index.indexer.
+	EventSinkType = indexer.EventSinkType
+
+	// Query is a parsed query of the block_search and tx_search RPC methods,
+	// passed to the search methods of the event sinks.
+	Query = query.Query
+
+	// Constructor creates an event sink from the config of the node.
+	Constructor = sink.Constructor
+)
+
+// Register makes an event sink available to the tx-index.indexer option under
+// the given name. It panics if the name is already used by a built-in or
+// registered event sink.
+func Register(name string, constructor Constructor) {
+	sink.Register(name, constructor)
+}
+
+// Registered returns the names of the registered event sinks, sorted.
+func Registered() []string {
+	return sink.Registered()
+}
+
+// NewGRPCServer returns a gRPC server serving the given event sink to the
+// "grpc" indexer of a node. The server must be started by the caller, with
+// Serve.
+func NewGRPCServer(es EventSink, opts ...grpc.ServerOption) *grpc.Server {
+	return grpcsink.NewServer(es, opts...)
+}
@@ -0,0 +1,447 @@
+package blocksync
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/tendermint/tendermint/internal/libs/protoio"
+	"github.com/tendermint/tendermint/internal/libs/tempfile"
+	sm "github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/internal/store"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// A block archive is a directory holding a manifest and chunks of consecutive
+// blocks, as documented in docs/tendermint-core/block-sync/archive.md:
+//
+//   - ArchiveManifestFile, the JSON encoded ArchiveManifest listing the chunks
+//     and their SHA-256 checksums.
+//   - The gzip compressed chunks, each a sequence of three length-delimited
+//     protobuf messages per block, in height order: the tendermint.types.Block,
+//     the tendermint.types.Commit for the block, and the
+//     tendermint.state.ABCIResponses of the block, which is empty if the
+//     results were not available when exporting.
+const (
+	// ArchiveManifestFile is the name of the manifest of an archive.
+	ArchiveManifestFile = "manifest.json"
+
+	// ArchiveVersion is the version of the archive format.
+	ArchiveVersion = 1
+
+	// maxArchiveFileSize is the maximum size of a decompressed chunk, or of a
+	// manifest.
+	maxArchiveFileSize = 1 << 30
+
+	// maxArchivedMsgSize is the maximum size of an archived message.
+	maxArchivedMsgSize = types.MaxBlockSizeBytes + 1024*1024
+)
+
+// ErrBlockNotArchived is returned by an Archive which doesn't have the block at
+// the requested height.
+var ErrBlockNotArchived = errors.New("block not archived")
+
+// ArchiveManifest describes the chunks of an archive.
+type ArchiveManifest struct {
+	Version int            `json:"version"`
+	ChainID string         `json:"chain_id"`
+	Chunks  []ArchiveChunk `json:"chunks"` // ordered by height
+}
+
+// ArchiveChunk is a file of an archive holding consecutive blocks.
+type ArchiveChunk struct {
+	File   string           `json:"file"`
+	From   int64            `json:"from"`
+	To     int64            `json:"to"`
+	SHA256 tmbytes.HexBytes `json:"sha256"` // of the compressed file
+}
+
+// chunkFor returns the chunk holding the block at the given height.
+func (m *ArchiveManifest) chunkFor(height int64) (ArchiveChunk, bool) {
+	i := sort.Search(len(m.Chunks), func(i int) bool { return m.Chunks[i].To >= height })
+	if i == len(m.Chunks) || m.Chunks[i].From > height {
+		return ArchiveChunk{}, false
+	}
+	return m.Chunks[i], true
+}
+
+// ArchivedBlock is a block of an archive, with its commit and ABCI results.
+type ArchivedBlock struct {
+	Block         *types.Block
+	Commit        *types.Commit
+	ABCIResponses *tmstate.ABCIResponses
+}
+
+// Archive is a source of exported blocks, which block sync applies before
+// fetching blocks from peers, so that nodes bootstrapping from a published
+// archive don't load the live network.
+type Archive interface {
+	// LoadBlock returns the block at the given height, or ErrBlockNotArchived
+	// if the archive doesn't have it.
+	LoadBlock(ctx context.Context, height int64) (*ArchivedBlock, error)
+}
+
+// NewArchive returns the Archive at the given location, which is either a
+// local directory or an http(s) URL under which the archive files are served,
+// e.g. the URL of a public S3 bucket.
+func NewArchive(location string) (Archive, error) {
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		url := strings.TrimSuffix(location, "/")
+		return &archiveReader{fetch: func(ctx context.Context, name string) ([]byte, error) {
+			return fetchHTTP(ctx, url+"/"+name)
+		}}, nil
+	}
+
+	info, err := os.Stat(location)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("block archive %q is not a directory", location)
+	}
+	return &archiveReader{fetch: func(ctx context.Context, name string) ([]byte, error) {
+		return readFile(filepath.Join(location, name))
+	}}, nil
+}
+
+// archiveReader implements Archive, caching the manifest and the last chunk
+// read, as blocks are mostly loaded in order.
+type archiveReader struct {
+	fetch func(ctx context.Context, name string) ([]byte, error)
+
+	mtx      sync.Mutex
+	manifest *ArchiveManifest
+	chunk    ArchiveChunk
+	blocks   []*ArchivedBlock
+}
+
+// LoadBlock implements Archive.
+func (a *archiveReader) LoadBlock(ctx context.Context, height int64) (*ArchivedBlock, error) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	if a.manifest == nil {
+		bz, err := a.fetch(ctx, ArchiveManifestFile)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrBlockNotArchived
+		} else if err != nil {
+			return nil, err
+		}
+		manifest, err := decodeArchiveManifest(bz)
+		if err != nil {
+			return nil, err
+		}
+		a.manifest = manifest
+	}
+
+	chunk, ok := a.manifest.chunkFor(height)
+	if !ok {
+		return nil, ErrBlockNotArchived
+	}
+	if a.blocks == nil || a.chunk.File != chunk.File {
+		bz, err := a.fetch(ctx, chunk.File)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive chunk %s: %w", chunk.File, err)
+		}
+		blocks, err := decodeArchiveChunk(chunk, bz)
+		if err != nil {
+			return nil, fmt.Errorf("invalid archive chunk %s: %w", chunk.File, err)
+		}
+		a.chunk, a.blocks = chunk, blocks
+	}
+	return a.blocks[height-chunk.From], nil
+}
+
+// decodeArchiveManifest decodes and validates a manifest.
+func decodeArchiveManifest(bz []byte) (*ArchiveManifest, error) {
+	manifest := new(ArchiveManifest)
+	if err := json.Unmarshal(bz, manifest); err != nil {
+		return nil, fmt.Errorf("invalid archive manifest: %w", err)
+	}
+	if manifest.Version != ArchiveVersion {
+		return nil, fmt.Errorf("unsupported archive version %d", manifest.Version)
+	}
+	var prev int64
+	for _, chunk := range manifest.Chunks {
+		if chunk.From <= prev || chunk.To < chunk.From {
+			return nil, fmt.Errorf("invalid archive manifest: chunk %s out of order", chunk.File)
+		}
+		if strings.ContainsAny(chunk.File, `/\`) {
+			return nil, fmt.Errorf("invalid archive manifest: invalid chunk file %q", chunk.File)
+		}
+		prev = chunk.To
+	}
+	return manifest, nil
+}
+
+// decodeArchiveChunk verifies the checksum of a chunk and decodes its blocks.
+func decodeArchiveChunk(chunk ArchiveChunk, bz []byte) ([]*ArchivedBlock, error) {
+	if sum := sha256.Sum256(bz); !bytes.Equal(sum[:], chunk.SHA256) {
+		return nil, fmt.Errorf("checksum %X does not match %X", sum[:], chunk.SHA256)
+	}
+	gz, err := gzip.NewReader(bytes.NewReader(bz))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+	r := protoio.NewDelimitedReader(bufio.NewReader(io.LimitReader(gz, maxArchiveFileSize)), maxArchivedMsgSize)
+
+	blocks := make([]*ArchivedBlock, 0, chunk.To-chunk.From+1)
+	for height := chunk.From; height <= chunk.To; height++ {
+		var (
+			pbBlock  tmproto.Block
+			pbCommit tmproto.Commit
+			abciResp tmstate.ABCIResponses
+		)
+		for _, msg := range []proto.Message{&pbBlock, &pbCommit, &abciResp} {
+			if _, err := r.ReadMsg(msg); err != nil {
+				return nil, fmt.Errorf("block %d: %w", height, err)
+			}
+		}
+		block, err := types.BlockFromProto(&pbBlock)
+		if err != nil {
+			return nil, fmt.Errorf("block %d: %w", height, err)
+		}
+		commit, err := types.CommitFromProto(&pbCommit)
+		if err != nil {
+			return nil, fmt.Errorf("commit %d: %w", height, err)
+		}
+		if block.Height != height || commit.Height != height {
+			return nil, fmt.Errorf("block %d has height %d and commit height %d",
+				height, block.Height, commit.Height)
+		}
+		archived := &ArchivedBlock{Block: block, Commit: commit, ABCIResponses: &abciResp}
+		if abciResp.BeginBlock == nil && abciResp.EndBlock == nil {
+			archived.ABCIResponses = nil
+		}
+		blocks = append(blocks, archived)
+	}
+	return blocks, nil
+}
+
+// readFile reads a local archive file.
+func readFile(path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(io.LimitReader(file, maxArchiveFileSize))
+}
+
+// fetchHTTP fetches an archive file over http(s), returning os.ErrNotExist if
+// it doesn't exist.
+func fetchHTTP(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusForbidden:
+		// S3 responds with 403 for missing objects of buckets which can't be listed
+		return nil, os.ErrNotExist
+	default:
+		return nil, fmt.Errorf("failed to fetch %s: %s", url, resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxArchiveFileSize))
+}
+
+// ArchiveSource is a source of blocks to export to an archive.
+type ArchiveSource interface {
+	// ChainID returns the chain ID of the blocks.
+	ChainID(ctx context.Context) (string, error)
+
+	// LoadBlock returns the block at the given height, with its commit and
+	// ABCI results. The ABCI results may be nil if they aren't available.
+	LoadBlock(ctx context.Context, height int64) (*ArchivedBlock, error)
+}
+
+// storeArchiveSource is an ArchiveSource of the stores of a node.
+type storeArchiveSource struct {
+	blockStore *store.BlockStore
+	stateStore sm.Store
+}
+
+// NewStoreArchiveSource returns an ArchiveSource of the given stores.
+func NewStoreArchiveSource(blockStore *store.BlockStore, stateStore sm.Store) ArchiveSource {
+	return &storeArchiveSource{blockStore: blockStore, stateStore: stateStore}
+}
+
+// ChainID implements ArchiveSource.
+func (s *storeArchiveSource) ChainID(ctx context.Context) (string, error) {
+	state, err := s.stateStore.Load()
+	if err != nil {
+		return "", err
+	}
+	if state.IsEmpty() {
+		return "", errors.New("no state found")
+	}
+	return state.ChainID, nil
+}
+
+// LoadBlock implements ArchiveSource.
+func (s *storeArchiveSource) LoadBlock(ctx context.Context, height int64) (*ArchivedBlock, error) {
+	block := s.blockStore.LoadBlock(height)
+	if block == nil {
+		return nil, fmt.Errorf("block %d not found", height)
+	}
+	commit := s.blockStore.LoadBlockCommit(height)
+	if commit == nil {
+		// the commit of the latest block is only stored as the seen commit
+		if commit = s.blockStore.LoadSeenCommit(); commit == nil || commit.Height != height {
+			return nil, fmt.Errorf("commit %d not found", height)
+		}
+	}
+	abciResponses, err := s.stateStore.LoadABCIResponses(height)
+	if errors.As(err, &sm.ErrNoABCIResponsesForHeight{}) {
+		abciResponses = nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &ArchivedBlock{Block: block, Commit: commit, ABCIResponses: abciResponses}, nil
+}
+
+// ExportBlocks exports the blocks in the range [from, to] from the source to
+// the archive in the given directory, in chunks of up to chunkSize blocks,
+// adding them to the archive's manifest. The manifest is updated after each
+// chunk is written, so an interrupted export leaves a consistent archive.
+// progress, if not nil, is called with the height of each exported block.
+func ExportBlocks(
+	ctx context.Context,
+	source ArchiveSource,
+	dir string,
+	from, to, chunkSize int64,
+	progress func(height int64),
+) error {
+	if from < 1 || to < from || chunkSize < 1 {
+		return fmt.Errorf("invalid range of blocks %d to %d in chunks of %d", from, to, chunkSize)
+	}
+	chainID, err := source.ChainID(ctx)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	manifest := &ArchiveManifest{Version: ArchiveVersion, ChainID: chainID}
+	bz, err := readFile(filepath.Join(dir, ArchiveManifestFile))
+	switch {
+	case err == nil:
+		if manifest, err = decodeArchiveManifest(bz); err != nil {
+			return err
+		}
+		if manifest.ChainID != chainID {
+			return fmt.Errorf("archive is of chain %q, not %q", manifest.ChainID, chainID)
+		}
+		for _, chunk := range manifest.Chunks {
+			if chunk.From <= to && chunk.To >= from {
+				return fmt.Errorf("blocks %d to %d are already archived", chunk.From, chunk.To)
+			}
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		return err
+	}
+
+	for chunkFrom := from; chunkFrom <= to; chunkFrom += chunkSize {
+		chunkTo := chunkFrom + chunkSize - 1
+		if chunkTo > to {
+			chunkTo = to
+		}
+		chunk, err := exportChunk(ctx, source, dir, chunkFrom, chunkTo, progress)
+		if err != nil {
+			return err
+		}
+
+		manifest.Chunks = append(manifest.Chunks, chunk)
+		sort.Slice(manifest.Chunks, func(i, j int) bool {
+			return manifest.Chunks[i].From < manifest.Chunks[j].From
+		})
+		bz, err := json.MarshalIndent(manifest, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := tempfile.WriteFileAtomic(filepath.Join(dir, ArchiveManifestFile), bz, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportChunk writes the blocks in the range [from, to] to a chunk file.
+func exportChunk(
+	ctx context.Context,
+	source ArchiveSource,
+	dir string,
+	from, to int64,
+	progress func(height int64),
+) (ArchiveChunk, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	w := protoio.NewDelimitedWriter(gz)
+
+	for height := from; height <= to; height++ {
+		if err := ctx.Err(); err != nil {
+			return ArchiveChunk{}, err
+		}
+		archived, err := source.LoadBlock(ctx, height)
+		if err != nil {
+			return ArchiveChunk{}, fmt.Errorf("failed to load block %d: %w", height, err)
+		}
+		pbBlock, err := archived.Block.ToProto()
+		if err != nil {
+			return ArchiveChunk{}, err
+		}
+		abciResponses := archived.ABCIResponses
+		if abciResponses == nil {
+			abciResponses = new(tmstate.ABCIResponses)
+		}
+		for _, msg := range []proto.Message{pbBlock, archived.Commit.ToProto(), abciResponses} {
+			if _, err := w.WriteMsg(msg); err != nil {
+				return ArchiveChunk{}, err
+			}
+		}
+		if progress != nil {
+			progress(height)
+		}
+	}
+	if err := gz.Close(); err != nil {
+		return ArchiveChunk{}, err
+	}
+
+	sum := sha256.Sum256(buf.Bytes())
+	chunk := ArchiveChunk{
+		File:   fmt.Sprintf("%020d-%020d.pb.gz", from, to),
+		From:   from,
+		To:     to,
+		SHA256: sum[:],
+	}
+	if err := tempfile.WriteFileAtomic(filepath.Join(dir, chunk.File), buf.Bytes(), 0644); err != nil {
+		return ArchiveChunk{}, err
+	}
+	return chunk, nil
+}
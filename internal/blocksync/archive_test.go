@@ -0,0 +1,127 @@
+package blocksync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/config"
+	sm "github.com/tendermint/tendermint/internal/state"
+	sf "github.com/tendermint/tendermint/internal/state/test/factory"
+	"github.com/tendermint/tendermint/internal/test/factory"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+// testArchiveSource is an ArchiveSource of a map of blocks.
+type testArchiveSource map[int64]*ArchivedBlock
+
+func (s testArchiveSource) ChainID(ctx context.Context) (string, error) {
+	return s[1].Block.ChainID, nil
+}
+
+func (s testArchiveSource) LoadBlock(ctx context.Context, height int64) (*ArchivedBlock, error) {
+	if archived, ok := s[height]; ok {
+		return archived, nil
+	}
+	return nil, fmt.Errorf("block %d not found", height)
+}
+
+func makeTestArchiveSource(t *testing.T, n int64) testArchiveSource {
+	genDoc, _ := factory.RandGenesisDoc(config.TestConfig(), 1, false, 30)
+	state, err := sm.MakeGenesisState(genDoc)
+	require.NoError(t, err)
+
+	source := testArchiveSource{}
+	for height := int64(1); height <= n; height++ {
+		block := sf.MakeBlock(state, height, new(types.Commit))
+		archived := &ArchivedBlock{
+			Block: block,
+			Commit: types.NewCommit(height, 0, types.BlockID{Hash: block.Hash()},
+				[]types.CommitSig{types.NewCommitSigAbsent()}),
+		}
+		if height%2 == 0 {
+			archived.ABCIResponses = &tmstate.ABCIResponses{
+				BeginBlock: &abci.ResponseBeginBlock{},
+				EndBlock:   &abci.ResponseEndBlock{},
+			}
+		}
+		source[height] = archived
+	}
+	return source
+}
+
+func TestArchive(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	source := makeTestArchiveSource(t, 10)
+	dir := t.TempDir()
+
+	// blocks are exported in chunks, and can be added to an archive
+	require.NoError(t, ExportBlocks(ctx, source, dir, 1, 5, 2, nil))
+	var exported []int64
+	require.NoError(t, ExportBlocks(ctx, source, dir, 7, 9, 2,
+		func(height int64) { exported = append(exported, height) }))
+	assert.Equal(t, []int64{7, 8, 9}, exported)
+
+	// but not archived twice
+	require.Error(t, ExportBlocks(ctx, source, dir, 5, 6, 2, nil))
+
+	bz, err := os.ReadFile(filepath.Join(dir, ArchiveManifestFile))
+	require.NoError(t, err)
+	var manifest ArchiveManifest
+	require.NoError(t, json.Unmarshal(bz, &manifest))
+	require.Len(t, manifest.Chunks, 5)
+	assert.Equal(t, source[1].Block.ChainID, manifest.ChainID)
+
+	server := httptest.NewServer(http.FileServer(http.Dir(dir)))
+	defer server.Close()
+
+	for _, location := range []string{dir, server.URL} {
+		archive, err := NewArchive(location)
+		require.NoError(t, err, location)
+
+		for _, height := range []int64{1, 2, 3, 4, 5, 7, 8, 9} {
+			archived, err := archive.LoadBlock(ctx, height)
+			require.NoError(t, err, location)
+			assert.Equal(t, source[height].Block.Hash(), archived.Block.Hash(), location)
+			assert.Equal(t, source[height].Commit.BlockID, archived.Commit.BlockID, location)
+			assert.Equal(t, source[height].ABCIResponses, archived.ABCIResponses, location)
+		}
+		for _, height := range []int64{0, 6, 10} {
+			_, err = archive.LoadBlock(ctx, height)
+			assert.ErrorIs(t, err, ErrBlockNotArchived, location)
+		}
+	}
+
+	// corrupted chunks are rejected
+	chunk := filepath.Join(dir, manifest.Chunks[0].File)
+	bz, err = os.ReadFile(chunk)
+	require.NoError(t, err)
+	bz[len(bz)-1] ^= 0xff
+	require.NoError(t, os.WriteFile(chunk, bz, 0644))
+	archive, err := NewArchive(dir)
+	require.NoError(t, err)
+	_, err = archive.LoadBlock(ctx, 1)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrBlockNotArchived)
+
+	// directories without a manifest have no blocks
+	archive, err = NewArchive(t.TempDir())
+	require.NoError(t, err)
+	_, err = archive.LoadBlock(ctx, 1)
+	assert.ErrorIs(t, err, ErrBlockNotArchived)
+
+	_, err = NewArchive(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
@@ -187,6 +187,16 @@ func (pool *BlockPool) removeTimedoutPeers() {
 	}
 }
 
+// setHeight sets the height of the next block to request, before the pool is
+// started.
+func (pool *BlockPool) setHeight(height int64) {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	pool.height = height
+	pool.startHeight = height
+}
+
 // GetStatus returns pool's height, numPending requests and the number of
 // requesters.
 func (pool *BlockPool) GetStatus() (height int64, numPending int32, lenRequesters int) {
@@ -259,6 +269,18 @@ func (pool *BlockPool) PopRequest() {
 	}
 }
 
+// PeekPeer returns the ID of the peer the block at pool.height is requested
+// from, or an empty ID if there is none.
+func (pool *BlockPool) PeekPeer() types.NodeID {
+	pool.mtx.RLock()
+	defer pool.mtx.RUnlock()
+
+	if r := pool.requesters[pool.height]; r != nil {
+		return r.getPeerID()
+	}
+	return ""
+}
+
 // RedoRequest invalidates the block at pool.height,
 // Remove the peer and redo request from others.
 // Returns the ID of the removed peer.
@@ -19,7 +19,10 @@ import (
 	"github.com/tendermint/tendermint/types"
 )
 
-var _ service.Service = (*Reactor)(nil)
+var (
+	_ service.Service         = (*Reactor)(nil)
+	_ p2p.ZeroCopyUnmarshaler = (*bcproto.Message)(nil)
+)
 
 const (
 	// BlockSyncChannel is a channel for blocks and status updates
@@ -45,6 +48,7 @@ func GetChannelDescriptor() *p2p.ChannelDescriptor {
 		SendQueueCapacity:   1000,
 		RecvBufferCapacity:  1024,
 		RecvMessageCapacity: MaxMsgSize,
+		Compress:            true,
 	}
 }
 
@@ -54,6 +58,22 @@ type consensusReactor interface {
 	SwitchToConsensus(ctx context.Context, state sm.State, skipWAL bool)
 }
 
+// ReactorOption sets an optional parameter on the Reactor.
+type ReactorOption func(*Reactor)
+
+// ReactorArchive sets an Archive of blocks which are applied before fetching
+// blocks from peers.
+func ReactorArchive(archive Archive) ReactorOption {
+	return func(r *Reactor) { r.archive = archive }
+}
+
+// ReactorCheckpoints sets checkpoints of the chain, which the synced blocks
+// must match. Block sync halts on a block at a checkpoint height with another
+// hash.
+func ReactorCheckpoints(checkpoints types.Checkpoints) ReactorOption {
+	return func(r *Reactor) { r.checkpoints = checkpoints }
+}
+
 type peerError struct {
 	err    error
 	peerID types.NodeID
@@ -76,6 +96,11 @@ type Reactor struct {
 	pool        *BlockPool
 	consReactor consensusReactor
 	blockSync   *atomicBool
+	archive     Archive
+	checkpoints types.Checkpoints
+
+	// stopArchive cancels syncing from the archive when the reactor stops.
+	stopArchive context.CancelFunc
 
 	blockSyncCh *p2p.Channel
 	// blockSyncOutBridgeCh defines a channel that acts as a bridge between sending Envelope
@@ -112,6 +137,7 @@ func NewReactor(
 	peerUpdates *p2p.PeerUpdates,
 	blockSync bool,
 	metrics *consensus.Metrics,
+	options ...ReactorOption,
 ) (*Reactor, error) {
 	if state.LastBlockHeight != store.Height() {
 		return nil, fmt.Errorf("state (%v) and store (%v) height mismatch", state.LastBlockHeight, store.Height())
@@ -140,6 +166,10 @@ func NewReactor(
 		peerUpdates:          peerUpdates,
 		metrics:              metrics,
 		syncStartTime:        time.Time{},
+		stopArchive:          func() {},
+	}
+	for _, option := range options {
+		option(r)
 	}
 
 	r.BaseService = *service.NewBaseService(logger, "BlockSync", r)
@@ -152,17 +182,15 @@ func NewReactor(
 // OnStop to ensure the outbound p2p Channels are closed.
 //
 // If blockSync is enabled, we also start the pool and the pool processing
-// goroutine. If the pool fails to start, an error is returned.
+// goroutine, after applying the blocks of the archive if there is one. If the
+// pool fails to start, an error is returned.
 func (r *Reactor) OnStart(ctx context.Context) error {
 	if r.blockSync.IsSet() {
-		if err := r.pool.Start(ctx); err != nil {
+		if r.archive != nil {
+			r.startArchiveRoutine(ctx, false)
+		} else if err := r.startPool(ctx, false); err != nil {
 			return err
 		}
-		r.poolWG.Add(1)
-		go r.requestRoutine(ctx)
-
-		r.poolWG.Add(1)
-		go r.poolRoutine(ctx, false)
 	}
 
 	go r.processBlockSyncCh(ctx)
@@ -175,7 +203,8 @@ func (r *Reactor) OnStart(ctx context.Context) error {
 // OnStop stops the reactor by signaling to all spawned goroutines to exit and
 // blocking until they all exit.
 func (r *Reactor) OnStop() {
-	if r.blockSync.IsSet() {
+	r.stopArchive()
+	if r.blockSync.IsSet() && r.pool.IsRunning() {
 		if err := r.pool.Stop(); err != nil {
 			r.logger.Error("failed to stop pool", "err", err)
 		}
@@ -361,22 +390,110 @@ func (r *Reactor) SwitchToBlockSync(ctx context.Context, state sm.State) error {
 	r.blockSync.Set()
 	r.initialState = state
 	r.pool.height = state.LastBlockHeight + 1
+	r.syncStartTime = time.Now()
 
+	if r.archive != nil {
+		r.startArchiveRoutine(ctx, true)
+		return nil
+	}
+	return r.startPool(ctx, true)
+}
+
+// startPool starts the pool, and the goroutines requesting and processing its
+// blocks.
+func (r *Reactor) startPool(ctx context.Context, stateSynced bool) error {
 	if err := r.pool.Start(ctx); err != nil {
 		return err
 	}
 
-	r.syncStartTime = time.Now()
-
 	r.poolWG.Add(1)
 	go r.requestRoutine(ctx)
 
 	r.poolWG.Add(1)
-	go r.poolRoutine(ctx, true)
+	go r.poolRoutine(ctx, stateSynced)
 
 	return nil
 }
 
+// startArchiveRoutine starts applying the blocks of the archive, after which
+// the pool is started to fetch the remaining blocks from peers.
+func (r *Reactor) startArchiveRoutine(ctx context.Context, stateSynced bool) {
+	archiveCtx, cancel := context.WithCancel(ctx)
+	r.stopArchive = cancel
+
+	r.poolWG.Add(1)
+	go func() {
+		defer r.poolWG.Done()
+
+		state, blocksSynced, err := r.syncArchive(archiveCtx, r.initialState)
+		switch {
+		case errors.Is(err, sm.ErrHalted):
+			r.logger.Info("stopping block sync, the node halted", "height", state.LastBlockHeight+1)
+			return
+		case errors.As(err, &types.ErrCheckpointMismatch{}):
+			r.logger.Error("archived block does not match checkpoint, halting block sync", "err", err)
+			return
+		case archiveCtx.Err() != nil:
+			return
+		case err != nil:
+			r.logger.Error("failed to sync from the block archive, syncing from peers", "err", err)
+		}
+
+		if blocksSynced > 0 {
+			r.logger.Info("synced from the block archive", "blocks", blocksSynced, "height", state.LastBlockHeight)
+			r.initialState = state
+			r.pool.setHeight(state.LastBlockHeight + 1)
+		}
+		if err := r.startPool(ctx, stateSynced || blocksSynced > 0); err != nil {
+			r.logger.Error("failed to start pool", "err", err)
+		}
+	}()
+}
+
+// syncArchive applies the blocks of the archive following the given state,
+// until it runs out of blocks. It returns the resulting state and the number
+// of blocks applied. As with blocks from peers, each block is verified with
+// its commit before it is applied.
+func (r *Reactor) syncArchive(ctx context.Context, state sm.State) (sm.State, uint64, error) {
+	var blocksSynced uint64
+
+	height := state.LastBlockHeight + 1
+	if state.LastBlockHeight == 0 {
+		height = state.InitialHeight
+	}
+	for ; ; height++ {
+		archived, err := r.archive.LoadBlock(ctx, height)
+		if errors.Is(err, ErrBlockNotArchived) {
+			return state, blocksSynced, nil
+		} else if err != nil {
+			return state, blocksSynced, err
+		}
+
+		var (
+			block   = archived.Block
+			parts   = block.MakePartSetWithParams(state.ConsensusParams.Block)
+			blockID = types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+		)
+		err = types.VerifyCommitVotes(state.ChainID, state.Validators, blockID, block.Height, archived.Commit)
+		if err != nil {
+			return state, blocksSynced, fmt.Errorf("invalid commit of archived block %d: %w", height, err)
+		}
+		if err := r.checkpoints.Verify(block.Height, blockID.Hash); err != nil {
+			return state, blocksSynced, err
+		}
+
+		r.store.SaveBlock(block, parts, archived.Commit)
+		state, err = r.blockExec.ApplyBlock(ctx, state, blockID, block)
+		if errors.Is(err, sm.ErrHalted) {
+			return state, blocksSynced, err
+		} else if err != nil {
+			panic(fmt.Sprintf("failed to process committed block (%d:%X): %v", block.Height, block.Hash(), err))
+		}
+		r.metrics.RecordConsMetrics(block)
+		blocksSynced++
+	}
+}
+
 func (r *Reactor) requestRoutine(ctx context.Context) {
 	statusUpdateTicker := time.NewTicker(statusUpdateIntervalSeconds * time.Second)
 	defer statusUpdateTicker.Stop()
@@ -514,7 +631,7 @@ FOR_LOOP:
 			}
 
 			var (
-				firstParts         = first.MakePartSet(types.BlockPartSizeBytes)
+				firstParts         = first.MakePartSetWithParams(state.ConsensusParams.Block)
 				firstPartSetHeader = firstParts.Header()
 				firstID            = types.BlockID{Hash: first.Hash(), PartSetHeader: firstPartSetHeader}
 			)
@@ -524,7 +641,7 @@ FOR_LOOP:
 			// NOTE: We can probably make this more efficient, but note that calling
 			// first.Hash() doesn't verify the tx contents, so MakePartSet() is
 			// currently necessary.
-			err := state.Validators.VerifyCommitLight(chainID, firstID, first.Height, second.LastCommit)
+			err := types.VerifyCommitVotes(chainID, state.Validators, firstID, first.Height, second.LastCommit)
 			if err != nil {
 				err = fmt.Errorf("invalid last commit: %w", err)
 				r.logger.Error(
@@ -555,7 +672,25 @@ FOR_LOOP:
 				}
 
 				continue FOR_LOOP
+			} else if err := r.checkpoints.Verify(first.Height, firstID.Hash); err != nil {
+				// The block is committed by the validators, yet does not match the
+				// checkpoint, so the chain synced from the peer forked from the
+				// trusted one, e.g. by a long-range attack. Block sync halts rather
+				// than apply it.
+				peerID := r.pool.PeekPeer()
+				r.logger.Error("block does not match checkpoint, halting block sync", "err", err, "peer", peerID)
+				if serr := r.blockSyncCh.SendError(ctx, p2p.PeerError{
+					NodeID: peerID,
+					Err:    err,
+				}); serr != nil {
+					r.logger.Error("failed to report peer", "peer", peerID, "err", serr)
+				}
+				if err := r.pool.Stop(); err != nil {
+					r.logger.Error("failed to stop pool", "err", err)
+				}
+				return
 			} else {
+				peerID := r.pool.PeekPeer()
 				r.pool.PopRequest()
 
 				// TODO: batch saves so we do not persist to disk every block
@@ -565,7 +700,11 @@ FOR_LOOP:
 
 				// TODO: Same thing for app - but we would need a way to get the hash
 				// without persisting the state.
-				state, err = r.blockExec.ApplyBlock(ctx, state, firstID, first)
+				state, err = r.blockExec.ApplyBlockFrom(ctx, state, firstID, first, peerID)
+				if errors.Is(err, sm.ErrHalted) {
+					r.logger.Info("stopping block sync, the node halted", "height", first.Height)
+					return
+				}
 				if err != nil {
 					// TODO: This is bad, are we zombie?
 					panic(fmt.Sprintf("failed to process committed block (%d:%X): %v", first.Height, first.Hash(), err))
@@ -48,6 +48,7 @@ func setup(
 	privVal types.PrivValidator,
 	maxBlockHeights []int64,
 	chBuf uint,
+	options ...ReactorOption,
 ) *reactorTestSuite {
 	t.Helper()
 
@@ -75,7 +76,7 @@ func setup(
 
 	i := 0
 	for nodeID := range rts.network.Nodes {
-		rts.addNode(ctx, t, nodeID, genDoc, privVal, maxBlockHeights[i])
+		rts.addNode(ctx, t, nodeID, genDoc, privVal, maxBlockHeights[i], options...)
 		i++
 	}
 
@@ -101,6 +102,7 @@ func (rts *reactorTestSuite) addNode(
 	genDoc *types.GenesisDoc,
 	privVal types.PrivValidator,
 	maxBlockHeight int64,
+	options ...ReactorOption,
 ) {
 	t.Helper()
 
@@ -174,7 +176,8 @@ func (rts *reactorTestSuite) addNode(
 		rts.blockSyncChannels[nodeID],
 		rts.peerUpdates[nodeID],
 		rts.blockSync,
-		consensus.NopMetrics())
+		consensus.NopMetrics(),
+		options...)
 	require.NoError(t, err)
 
 	require.NoError(t, rts.reactors[nodeID].Start(ctx))
@@ -256,6 +259,104 @@ func TestReactor_SyncTime(t *testing.T) {
 	)
 }
 
+func TestReactor_SyncArchive(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg, err := config.ResetTestRoot("block_sync_reactor_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(cfg.RootDir)
+
+	genDoc, privVals := factory.RandGenesisDoc(cfg, 1, false, 30)
+	maxBlockHeight := int64(40)
+
+	// export the blocks of a node to the archive, all but the last have a
+	// canonical commit
+	dir := t.TempDir()
+	exporter := setup(ctx, t, genDoc, privVals[0], []int64{maxBlockHeight}, 0)
+	exportStore := exporter.reactors[exporter.nodes[0]].store
+	source := testArchiveSource{}
+	for height := int64(1); height < maxBlockHeight; height++ {
+		source[height] = &ArchivedBlock{
+			Block:  exportStore.LoadBlock(height),
+			Commit: exportStore.LoadBlockCommit(height),
+		}
+	}
+	require.NoError(t, ExportBlocks(ctx, source, dir, 1, maxBlockHeight-1, 16, nil))
+	archive, err := NewArchive(dir)
+	require.NoError(t, err)
+
+	// a node without peers syncs from the archive
+	rts := setup(ctx, t, genDoc, privVals[0], []int64{0}, 0, ReactorArchive(archive))
+	reactor := rts.reactors[rts.nodes[0]]
+	require.Eventually(
+		t,
+		func() bool { return reactor.store.Height() == maxBlockHeight-1 },
+		10*time.Second,
+		10*time.Millisecond,
+		"expected node to sync from the archive",
+	)
+	require.Eventually(
+		t,
+		func() bool { return reactor.pool.IsRunning() },
+		10*time.Second,
+		10*time.Millisecond,
+		"expected pool to be started after syncing from the archive",
+	)
+	height, _, _ := reactor.pool.GetStatus()
+	require.Equal(t, maxBlockHeight, height)
+}
+
+func TestReactor_CheckpointMismatchHalts(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg, err := config.ResetTestRoot("block_sync_reactor_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(cfg.RootDir)
+
+	genDoc, privVals := factory.RandGenesisDoc(cfg, 1, false, 30)
+	maxBlockHeight := int64(20)
+
+	dir := t.TempDir()
+	exporter := setup(ctx, t, genDoc, privVals[0], []int64{maxBlockHeight}, 0)
+	exportStore := exporter.reactors[exporter.nodes[0]].store
+	source := testArchiveSource{}
+	for height := int64(1); height < maxBlockHeight; height++ {
+		source[height] = &ArchivedBlock{
+			Block:  exportStore.LoadBlock(height),
+			Commit: exportStore.LoadBlockCommit(height),
+		}
+	}
+	require.NoError(t, ExportBlocks(ctx, source, dir, 1, maxBlockHeight-1, 16, nil))
+	archive, err := NewArchive(dir)
+	require.NoError(t, err)
+
+	// the checkpoint at height 10 matches the block at height 9, so a node
+	// syncing from the archive applies blocks up to height 9 and halts
+	checkpoints := types.Checkpoints{
+		5:  exportStore.LoadBlock(5).Hash(),
+		10: exportStore.LoadBlock(9).Hash(),
+	}
+	rts := setup(ctx, t, genDoc, privVals[0], []int64{0}, 0,
+		ReactorArchive(archive), ReactorCheckpoints(checkpoints))
+	reactor := rts.reactors[rts.nodes[0]]
+	require.Eventually(
+		t,
+		func() bool { return reactor.store.Height() == 9 },
+		10*time.Second,
+		10*time.Millisecond,
+		"expected node to sync from the archive up to the checkpoint",
+	)
+	require.Never(
+		t,
+		func() bool { return reactor.store.Height() > 9 || reactor.pool.IsRunning() },
+		200*time.Millisecond,
+		10*time.Millisecond,
+		"expected block sync to halt at the checkpoint",
+	)
+}
+
 func TestReactor_NoBlockResponse(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -0,0 +1,142 @@
+package consensus
+
+import (
+	"time"
+
+	"github.com/tendermint/tendermint/config"
+)
+
+const (
+	// adaptiveTimeoutSamples is the number of recent samples adaptive timeouts
+	// are derived from.
+	adaptiveTimeoutSamples = 20
+
+	// adaptiveTimeoutMargin is the factor applied to the slowest recent sample.
+	adaptiveTimeoutMargin = 2
+)
+
+// durationWindow holds the most recent durations.
+type durationWindow struct {
+	durations [adaptiveTimeoutSamples]time.Duration
+	next      int // index of the next duration in the ring buffer
+	count     int
+}
+
+func (w *durationWindow) add(d time.Duration) {
+	w.durations[w.next] = d
+	w.next = (w.next + 1) % adaptiveTimeoutSamples
+	if w.count < adaptiveTimeoutSamples {
+		w.count++
+	}
+}
+
+// max returns the longest duration, and false if there are none.
+func (w *durationWindow) max() (time.Duration, bool) {
+	var max time.Duration
+	for _, d := range w.durations[:w.count] {
+		if d > max {
+			max = d
+		}
+	}
+	return max, w.count > 0
+}
+
+// adaptiveTimeouts derives timeout-propose and timeout-commit from the recent
+// durations of rounds when adaptive timeouts are enabled, within the configured
+// bounds:
+//
+//   - timeout-propose follows the delay from entering the propose step until
+//     the proposal block is complete. A propose timeout counts as a delay of
+//     the whole timeout, so the timeout grows after missed proposals.
+//   - timeout-commit, which gives slower validators time to precommit, follows
+//     the network latency observed from entering the prevote step until +2/3
+//     prevotes are received.
+//
+// It is only accessed with the state's lock held.
+type adaptiveTimeouts struct {
+	proposalDelays durationWindow
+	voteLatencies  durationWindow
+
+	proposeStart time.Time // zero once the round's proposal delay is sampled
+	prevoteStart time.Time // zero once the round's vote latency is sampled
+}
+
+func (a *adaptiveTimeouts) enterPropose(now time.Time) { a.proposeStart = now }
+func (a *adaptiveTimeouts) enterPrevote(now time.Time) { a.prevoteStart = now }
+
+// proposalComplete samples the proposal delay of the round.
+func (a *adaptiveTimeouts) proposalComplete(now time.Time) {
+	if !a.proposeStart.IsZero() {
+		a.proposalDelays.add(now.Sub(a.proposeStart))
+		a.proposeStart = time.Time{}
+	}
+}
+
+// proposeTimedOut samples the timeout as the proposal delay of the round.
+func (a *adaptiveTimeouts) proposeTimedOut(timeout time.Duration) {
+	if !a.proposeStart.IsZero() {
+		a.proposalDelays.add(timeout)
+		a.proposeStart = time.Time{}
+	}
+}
+
+// twoThirdsPrevotes samples the vote latency of the round.
+func (a *adaptiveTimeouts) twoThirdsPrevotes(now time.Time) {
+	if !a.prevoteStart.IsZero() {
+		a.voteLatencies.add(now.Sub(a.prevoteStart))
+		a.prevoteStart = time.Time{}
+	}
+}
+
+// propose returns timeout-propose of round 0.
+func (a *adaptiveTimeouts) propose(cfg *config.ConsensusConfig) time.Duration {
+	if !cfg.AdaptiveTimeouts {
+		return cfg.TimeoutPropose
+	}
+	return adaptTimeout(&a.proposalDelays, cfg.TimeoutPropose, cfg.TimeoutProposeMin, cfg.TimeoutProposeMax)
+}
+
+// commit returns timeout-commit.
+func (a *adaptiveTimeouts) commit(cfg *config.ConsensusConfig) time.Duration {
+	if !cfg.AdaptiveTimeouts {
+		return cfg.TimeoutCommit
+	}
+	return adaptTimeout(&a.voteLatencies, cfg.TimeoutCommit, cfg.TimeoutCommitMin, cfg.TimeoutCommitMax)
+}
+
+// adaptTimeout returns the slowest of the samples with a margin, or the
+// configured timeout without samples, bounded by min and max.
+func adaptTimeout(samples *durationWindow, timeout, min, max time.Duration) time.Duration {
+	if slowest, ok := samples.max(); ok {
+		timeout = adaptiveTimeoutMargin * slowest
+	}
+	switch {
+	case timeout < min:
+		return min
+	case timeout > max:
+		return max
+	default:
+		return timeout
+	}
+}
+
+// stepTimeouts returns the configuration of the step timeouts, which are
+// shorter while the WAL is skipped.
+func (cs *State) stepTimeouts() *config.ConsensusConfig {
+	if cs.skipWALTimeouts != nil {
+		return cs.skipWALTimeouts
+	}
+	return cs.config
+}
+
+// proposeTimeout returns the timeout of the propose step of the round.
+func (cs *State) proposeTimeout(round int32) time.Duration {
+	cfg := cs.stepTimeouts()
+	return cs.adaptiveTimeouts.propose(cfg) + time.Duration(round)*cfg.TimeoutProposeDelta
+}
+
+// commitTimeout returns the time to wait after committing a block before
+// starting the next height.
+func (cs *State) commitTimeout() time.Duration {
+	return cs.adaptiveTimeouts.commit(cs.stepTimeouts())
+}
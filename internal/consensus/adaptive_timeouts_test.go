@@ -0,0 +1,72 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tendermint/tendermint/config"
+)
+
+func TestAdaptiveTimeouts(t *testing.T) {
+	cfg := config.DefaultConsensusConfig()
+	cfg.TimeoutPropose = 3 * time.Second
+	cfg.TimeoutProposeMin = 500 * time.Millisecond
+	cfg.TimeoutProposeMax = 10 * time.Second
+	cfg.TimeoutCommit = time.Second
+	cfg.TimeoutCommitMin = 100 * time.Millisecond
+	cfg.TimeoutCommitMax = 5 * time.Second
+
+	var a adaptiveTimeouts
+	start := time.Now()
+
+	// disabled, the configured timeouts are used
+	a.enterPropose(start)
+	a.proposalComplete(start.Add(time.Second))
+	assert.Equal(t, cfg.TimeoutPropose, a.propose(cfg))
+	assert.Equal(t, cfg.TimeoutCommit, a.commit(cfg))
+
+	cfg.AdaptiveTimeouts = true
+	a = adaptiveTimeouts{}
+
+	// without samples, the configured timeouts are used
+	assert.Equal(t, cfg.TimeoutPropose, a.propose(cfg))
+	assert.Equal(t, cfg.TimeoutCommit, a.commit(cfg))
+
+	// timeouts follow the slowest sample, with a margin
+	a.enterPropose(start)
+	a.proposalComplete(start.Add(time.Second))
+	a.enterPrevote(start)
+	a.twoThirdsPrevotes(start.Add(200 * time.Millisecond))
+	assert.Equal(t, 2*time.Second, a.propose(cfg))
+	assert.Equal(t, 400*time.Millisecond, a.commit(cfg))
+
+	// only the first sample of a round counts
+	a.proposalComplete(start.Add(4 * time.Second))
+	a.twoThirdsPrevotes(start.Add(4 * time.Second))
+	assert.Equal(t, 2*time.Second, a.propose(cfg))
+	assert.Equal(t, 400*time.Millisecond, a.commit(cfg))
+
+	// faster rounds shorten the timeouts once the slow samples are evicted,
+	// down to the minimum
+	for i := 0; i < adaptiveTimeoutSamples; i++ {
+		a.enterPropose(start)
+		a.proposalComplete(start.Add(10 * time.Millisecond))
+		a.enterPrevote(start)
+		a.twoThirdsPrevotes(start.Add(10 * time.Millisecond))
+	}
+	assert.Equal(t, cfg.TimeoutProposeMin, a.propose(cfg))
+	assert.Equal(t, cfg.TimeoutCommitMin, a.commit(cfg))
+
+	// a missed proposal counts as a delay of the whole timeout, up to the
+	// maximum
+	a.enterPropose(start)
+	a.proposeTimedOut(a.propose(cfg))
+	assert.Equal(t, 2*cfg.TimeoutProposeMin, a.propose(cfg))
+	for i := 0; i < 10; i++ {
+		a.enterPropose(start)
+		a.proposeTimedOut(a.propose(cfg))
+	}
+	assert.Equal(t, cfg.TimeoutProposeMax, a.propose(cfg))
+}
@@ -201,9 +201,13 @@ func TestByzantinePrevoteEquivocation(t *testing.T) {
 		}
 		proposerAddr := lazyNodeState.privValidatorPubKey.Address()
 
-		block, blockParts := lazyNodeState.blockExec.CreateProposalBlock(
-			lazyNodeState.Height, lazyNodeState.state, commit, proposerAddr,
+		block, blockParts, err := lazyNodeState.blockExec.CreateProposalBlock(
+			ctx, lazyNodeState.Height, lazyNodeState.state, commit, proposerAddr,
 		)
+		if err != nil {
+			lazyNodeState.logger.Error("enterPropose: failed to create proposal block", "err", err)
+			return
+		}
 
 		// Flush the WAL. Otherwise, we may not recompute the same proposal to sign,
 		// and the privValidator will refuse to sign anything.
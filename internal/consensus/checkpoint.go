@@ -0,0 +1,259 @@
+package consensus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/google/orderedcode"
+	dbm "github.com/tendermint/tm-db"
+
+	cstypes "github.com/tendermint/tendermint/internal/consensus/types"
+	tmmath "github.com/tendermint/tendermint/libs/math"
+	tmtime "github.com/tendermint/tendermint/libs/time"
+	tmcons "github.com/tendermint/tendermint/proto/tendermint/consensus"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// prefixCheckpoint is the prefix of the key of the consensus state checkpoint
+// in the state DB. Prefixes are unique across all tm db's.
+const prefixCheckpoint = int64(13)
+
+// checkpointKey should never change after being set in init()
+var checkpointKey []byte
+
+func init() {
+	var err error
+	checkpointKey, err = orderedcode.Append(nil, prefixCheckpoint)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// StateCheckpointDB sets the DB, usually the state DB, the consensus state is
+// checkpointed to every checkpoint-interval.
+func StateCheckpointDB(db dbm.DB) StateOption {
+	return func(cs *State) { cs.checkpointDB = db }
+}
+
+// ToProto converts the checkpoint marker to its protobuf representation.
+func (m CheckpointMessage) ToProto() *tmcons.Checkpoint {
+	return &tmcons.Checkpoint{
+		Height: m.Height,
+		Round:  m.Round,
+		Step:   uint32(m.Step),
+		Time:   m.Time,
+	}
+}
+
+// CheckpointMessageFromProto converts a protobuf checkpoint marker.
+func CheckpointMessageFromProto(pb *tmcons.Checkpoint) (CheckpointMessage, error) {
+	if pb == nil {
+		return CheckpointMessage{}, errors.New("nil checkpoint")
+	}
+	step, err := tmmath.SafeConvertUint8(int64(pb.Step))
+	if err != nil {
+		return CheckpointMessage{}, fmt.Errorf("denying message due to possible overflow: %w", err)
+	}
+	if !cstypes.RoundStepType(step).IsValid() {
+		return CheckpointMessage{}, fmt.Errorf("invalid checkpoint step %d", step)
+	}
+
+	return CheckpointMessage{
+		Height: pb.Height,
+		Round:  pb.Round,
+		Step:   cstypes.RoundStepType(step),
+		Time:   pb.Time,
+	}, nil
+}
+
+func (m CheckpointMessage) equal(other CheckpointMessage) bool {
+	return m.Height == other.Height && m.Round == other.Round && m.Step == other.Step &&
+		m.Time.Equal(other.Time)
+}
+
+// consensusCheckpoint is the consensus state of a height at a checkpoint.
+type consensusCheckpoint struct {
+	marker        CheckpointMessage
+	proposal      *types.Proposal
+	proposalBlock *types.Block
+	lockedRound   int32
+	lockedBlock   *types.Block
+	validRound    int32
+	validBlock    *types.Block
+}
+
+// checkpoint saves the consensus state to the checkpoint DB if the last
+// checkpoint is older than checkpoint-interval. Only the steps of a round
+// before the block is committed are checkpointed, as the commit step would be
+// resumed without its commit round.
+//
+// The checkpoint marker is written to the WAL before the checkpoint is saved,
+// so that a checkpoint is only restored along with the messages after it.
+func (cs *State) checkpoint() {
+	if cs.checkpointDB == nil || cs.config.CheckpointInterval <= 0 || cs.replayMode {
+		return
+	}
+	if cs.Step < cstypes.RoundStepPropose || cs.Step > cstypes.RoundStepPrecommit || cs.TriggeredTimeoutPrecommit {
+		return
+	}
+	now := tmtime.Now()
+	if now.Sub(cs.lastCheckpoint) < cs.config.CheckpointInterval {
+		return
+	}
+
+	marker := CheckpointMessage{Height: cs.Height, Round: cs.Round, Step: cs.Step, Time: now}
+	bz, err := cs.marshalCheckpoint(marker)
+	if err != nil {
+		cs.logger.Error("failed to marshal consensus checkpoint", "err", err)
+		return
+	}
+	if err := cs.wal.WriteSync(marker); err != nil {
+		cs.logger.Error("failed writing checkpoint to WAL", "err", err)
+		return
+	}
+	if err := cs.checkpointDB.SetSync(checkpointKey, bz); err != nil {
+		cs.logger.Error("failed to save consensus checkpoint", "err", err)
+		return
+	}
+	cs.lastCheckpoint = now
+}
+
+func (cs *State) marshalCheckpoint(marker CheckpointMessage) ([]byte, error) {
+	pb := &tmcons.ConsensusCheckpoint{
+		Checkpoint:  *marker.ToProto(),
+		LockedRound: cs.LockedRound,
+		ValidRound:  cs.ValidRound,
+	}
+	if cs.Proposal != nil {
+		pb.Proposal = cs.Proposal.ToProto()
+	}
+
+	var err error
+	if pb.ProposalBlock, err = blockToProto(cs.ProposalBlock); err != nil {
+		return nil, err
+	}
+	if pb.LockedBlock, err = blockToProto(cs.LockedBlock); err != nil {
+		return nil, err
+	}
+	if pb.ValidBlock, err = blockToProto(cs.ValidBlock); err != nil {
+		return nil, err
+	}
+	return proto.Marshal(pb)
+}
+
+// loadCheckpoint loads the consensus state checkpoint of the height, or
+// returns nil if there is none.
+func (cs *State) loadCheckpoint(height int64) (*consensusCheckpoint, error) {
+	if cs.checkpointDB == nil {
+		return nil, nil
+	}
+	bz, err := cs.checkpointDB.Get(checkpointKey)
+	if err != nil || len(bz) == 0 {
+		return nil, err
+	}
+
+	pb := new(tmcons.ConsensusCheckpoint)
+	if err := proto.Unmarshal(bz, pb); err != nil {
+		return nil, err
+	}
+	if pb.Checkpoint.Height != height {
+		return nil, nil
+	}
+
+	cp := &consensusCheckpoint{
+		lockedRound: pb.LockedRound,
+		validRound:  pb.ValidRound,
+	}
+	if cp.marker, err = CheckpointMessageFromProto(&pb.Checkpoint); err != nil {
+		return nil, err
+	}
+	if pb.Proposal != nil {
+		if cp.proposal, err = types.ProposalFromProto(pb.Proposal); err != nil {
+			return nil, err
+		}
+	}
+	if cp.proposalBlock, err = blockFromProto(pb.ProposalBlock); err != nil {
+		return nil, err
+	}
+	if cp.lockedBlock, err = blockFromProto(pb.LockedBlock); err != nil {
+		return nil, err
+	}
+	if cp.validBlock, err = blockFromProto(pb.ValidBlock); err != nil {
+		return nil, err
+	}
+	return cp, nil
+}
+
+// restoreCheckpoint restores the consensus state of the height at the
+// checkpoint, and schedules the timeout of its step if it has one.
+func (cs *State) restoreCheckpoint(ctx context.Context, cp *consensusCheckpoint) {
+	round := cp.marker.Round
+
+	// increment validators as enterNewRound does
+	validators := cs.Validators
+	if cs.Round < round {
+		validators = validators.Copy()
+		validators.IncrementProposerPriority(tmmath.SafeSubInt32(round, cs.Round))
+	}
+
+	cs.updateRoundStep(ctx, round, cp.marker.Step)
+	cs.Validators = validators
+	cs.Proposer = cs.selectProposer(cs.state, cs.Height, round)
+	cs.Votes.SetRound(tmmath.SafeAddInt32(round, 1))
+	cs.TriggeredTimeoutPrecommit = false
+
+	cs.Proposal = cp.proposal
+	cs.ProposalBlock, cs.ProposalBlockParts = cp.proposalBlock, makePartSet(cp.proposalBlock, cs.state.ConsensusParams.Block)
+	cs.LockedRound = cp.lockedRound
+	cs.LockedBlock, cs.LockedBlockParts = cp.lockedBlock, makePartSet(cp.lockedBlock, cs.state.ConsensusParams.Block)
+	cs.ValidRound = cp.validRound
+	cs.ValidBlock, cs.ValidBlockParts = cp.validBlock, makePartSet(cp.validBlock, cs.state.ConsensusParams.Block)
+
+	switch cp.marker.Step {
+	case cstypes.RoundStepPropose:
+		cs.scheduleTimeout(cs.proposeTimeout(round), cs.Height, round, cstypes.RoundStepPropose)
+	case cstypes.RoundStepPrevoteWait:
+		cs.scheduleTimeout(cs.stepTimeouts().Prevote(round), cs.Height, round, cstypes.RoundStepPrevoteWait)
+	}
+}
+
+// skipToCheckpoint reads the WAL up to the marker of the checkpoint, and
+// returns false if the marker is not found.
+func skipToCheckpoint(dec *WALDecoder, marker CheckpointMessage) (bool, error) {
+	for {
+		msg, err := dec.Decode()
+		if err == io.EOF {
+			return false, nil
+		} else if err != nil {
+			return false, err
+		}
+		if m, ok := msg.Msg.(CheckpointMessage); ok && m.equal(marker) {
+			return true, nil
+		}
+	}
+}
+
+func blockToProto(block *types.Block) (*tmproto.Block, error) {
+	if block == nil {
+		return nil, nil
+	}
+	return block.ToProto()
+}
+
+func blockFromProto(pb *tmproto.Block) (*types.Block, error) {
+	if pb == nil {
+		return nil, nil
+	}
+	return types.BlockFromProto(pb)
+}
+
+func makePartSet(block *types.Block, params types.BlockParams) *types.PartSet {
+	if block == nil {
+		return nil
+	}
+	return block.MakePartSetWithParams(params)
+}
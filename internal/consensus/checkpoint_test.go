@@ -0,0 +1,107 @@
+package consensus
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/abci/example/kvstore"
+	cstypes "github.com/tendermint/tendermint/internal/consensus/types"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestStateCheckpointReplay(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg := configSetup(t)
+	logger := log.TestingLogger()
+	walFile := filepath.Join(t.TempDir(), "wal")
+	checkpointDB := dbm.NewMemDB()
+
+	cs1, vss, err := randState(ctx, cfg, logger, 4)
+	require.NoError(t, err)
+	height, round := cs1.Height, cs1.Round
+
+	// sets up the state to checkpoint with the same WAL and checkpoint DB
+	setupState := func(cs *State) {
+		wal, err := NewWAL(logger, walFile)
+		require.NoError(t, err)
+		require.NoError(t, wal.Start(ctx))
+		t.Cleanup(wal.Wait)
+		t.Cleanup(func() { _ = wal.Stop() })
+		cs.wal = wal
+		cs.checkpointDB = checkpointDB
+		cs.config.CheckpointInterval = time.Nanosecond
+	}
+	setupState(cs1)
+
+	proposal, block := decideProposal(ctx, cs1, vss[1], height, round)
+	blockParts := block.MakePartSet(types.BlockPartSizeBytes)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: blockParts.Header()}
+
+	// a prevote before the checkpoint, which is not replayed after restoring it
+	prevote := signVote(ctx, vss[2], cfg, tmproto.PrevoteType, blockID.Hash, blockID.PartSetHeader)
+	require.NoError(t, cs1.wal.Write(msgInfo{&VoteMessage{prevote}, "peer"}))
+
+	// lock the block at the precommit step, which is checkpointed
+	cs1.Proposal = proposal
+	cs1.ProposalBlock, cs1.ProposalBlockParts = block, blockParts
+	cs1.LockedRound, cs1.LockedBlock, cs1.LockedBlockParts = round, block, blockParts
+	cs1.ValidRound, cs1.ValidBlock, cs1.ValidBlockParts = round, block, blockParts
+	cs1.updateRoundStep(ctx, round, cstypes.RoundStepPrecommit)
+	cs1.newStep(ctx)
+
+	// a precommit after the checkpoint, which is replayed
+	precommit := signVote(ctx, vss[1], cfg, tmproto.PrecommitType, blockID.Hash, blockID.PartSetHeader)
+	require.NoError(t, cs1.wal.Write(msgInfo{&VoteMessage{precommit}, "peer"}))
+	require.NoError(t, cs1.wal.FlushAndSync())
+	require.NoError(t, cs1.wal.Stop())
+
+	restart := func() *State {
+		cs, err := newState(ctx, logger, cs1.state, vss[0].PrivValidator, kvstore.NewApplication())
+		require.NoError(t, err)
+		setupState(cs)
+		require.NoError(t, cs.catchupReplay(ctx, height))
+		return cs
+	}
+
+	t.Run("restore checkpoint", func(t *testing.T) {
+		cs2 := restart()
+
+		assert.Equal(t, round, cs2.Round)
+		assert.Equal(t, cstypes.RoundStepPrecommit, cs2.Step)
+		assert.Equal(t, proposal, cs2.Proposal)
+		assert.Equal(t, block.Hash(), cs2.ProposalBlock.Hash())
+		assert.Equal(t, round, cs2.LockedRound)
+		assert.Equal(t, block.Hash(), cs2.LockedBlock.Hash())
+		assert.Equal(t, blockParts.Header(), cs2.LockedBlockParts.Header())
+		assert.Equal(t, round, cs2.ValidRound)
+		assert.Equal(t, block.Hash(), cs2.ValidBlock.Hash())
+
+		assert.Nil(t, cs2.Votes.Prevotes(round).GetByIndex(vss[2].Index))
+		assert.NotNil(t, cs2.Votes.Precommits(round).GetByIndex(vss[1].Index))
+	})
+
+	t.Run("replay the whole height without the checkpoint marker", func(t *testing.T) {
+		// a checkpoint saved without its marker
+		marker := CheckpointMessage{Height: height, Round: round, Step: cstypes.RoundStepPrecommit, Time: time.Now()}
+		bz, err := cs1.marshalCheckpoint(marker)
+		require.NoError(t, err)
+		require.NoError(t, checkpointDB.Set(checkpointKey, bz))
+
+		cs2 := restart()
+
+		assert.Equal(t, cstypes.RoundStepNewHeight, cs2.Step)
+		assert.Nil(t, cs2.LockedBlock)
+		assert.NotNil(t, cs2.Votes.Prevotes(round).GetByIndex(vss[2].Index))
+		assert.NotNil(t, cs2.Votes.Precommits(round).GetByIndex(vss[1].Index))
+	})
+}
@@ -0,0 +1,168 @@
+package consensus
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	tmcons "github.com/tendermint/tendermint/proto/tendermint/consensus"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// TxFetcher looks up transactions by key, e.g. in the mempool, to reconstruct
+// the proposed block from a compact block.
+type TxFetcher interface {
+	GetTxByKey(txKey types.TxKey) (types.Tx, bool)
+}
+
+// NewCompactBlockMessage returns the compact block of the given block, which
+// carries the keys of the block's transactions in place of the transactions.
+func NewCompactBlockMessage(height int64, round int32, block *types.Block) *CompactBlockMessage {
+	txKeys := make([]types.TxKey, len(block.Txs))
+	for i, tx := range block.Txs {
+		txKeys[i] = tx.Key()
+	}
+
+	return &CompactBlockMessage{
+		Height:     height,
+		Round:      round,
+		Header:     block.Header,
+		TxKeys:     txKeys,
+		Evidence:   block.Evidence,
+		LastCommit: block.LastCommit,
+	}
+}
+
+// ToProto converts the compact block to its protobuf representation.
+func (m *CompactBlockMessage) ToProto() (*tmcons.CompactBlock, error) {
+	evidence, err := m.Evidence.ToProto()
+	if err != nil {
+		return nil, err
+	}
+
+	txHashes := make([][]byte, len(m.TxKeys))
+	for i := range m.TxKeys {
+		txHashes[i] = m.TxKeys[i][:]
+	}
+
+	var lastCommit *tmproto.Commit
+	if m.LastCommit != nil {
+		lastCommit = m.LastCommit.ToProto()
+	}
+
+	return &tmcons.CompactBlock{
+		Height:     m.Height,
+		Round:      m.Round,
+		Header:     *m.Header.ToProto(),
+		TxHashes:   txHashes,
+		Evidence:   *evidence,
+		LastCommit: lastCommit,
+	}, nil
+}
+
+// CompactBlockFromProto converts a protobuf compact block to a
+// CompactBlockMessage.
+func CompactBlockFromProto(cb *tmcons.CompactBlock) (*CompactBlockMessage, error) {
+	if cb == nil {
+		return nil, errors.New("nil compact block")
+	}
+
+	header, err := types.HeaderFromProto(&cb.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	txKeys := make([]types.TxKey, len(cb.TxHashes))
+	for i, hash := range cb.TxHashes {
+		if len(hash) != sha256.Size {
+			return nil, fmt.Errorf("tx hash %d has length %d, expected %d", i, len(hash), sha256.Size)
+		}
+		copy(txKeys[i][:], hash)
+	}
+
+	msg := &CompactBlockMessage{
+		Height: cb.Height,
+		Round:  cb.Round,
+		Header: header,
+		TxKeys: txKeys,
+	}
+	if err := msg.Evidence.FromProto(&cb.Evidence); err != nil {
+		return nil, err
+	}
+	if cb.LastCommit != nil {
+		if msg.LastCommit, err = types.CommitFromProto(cb.LastCommit); err != nil {
+			return nil, err
+		}
+	}
+
+	return msg, msg.ValidateBasic()
+}
+
+// compactBlock is a compact block being reconstructed from the transactions
+// found locally and those requested from the peer which sent it.
+type compactBlock struct {
+	msg *CompactBlockMessage
+	txs types.Txs // nil for the transactions still missing
+}
+
+func newCompactBlock(msg *CompactBlockMessage, fetcher TxFetcher) *compactBlock {
+	cb := &compactBlock{
+		msg: msg,
+		txs: make(types.Txs, len(msg.TxKeys)),
+	}
+	if fetcher == nil {
+		return cb
+	}
+
+	for i, key := range msg.TxKeys {
+		if tx, ok := fetcher.GetTxByKey(key); ok {
+			cb.txs[i] = tx
+		}
+	}
+	return cb
+}
+
+// missing returns the indexes of the transactions still missing.
+func (cb *compactBlock) missing() []uint32 {
+	var indexes []uint32
+	for i, tx := range cb.txs {
+		if tx == nil {
+			indexes = append(indexes, uint32(i))
+		}
+	}
+	return indexes
+}
+
+// addTxs adds the transactions received from the peer. It returns false if a
+// transaction is not the one expected at its index, in which case the block
+// cannot be reconstructed.
+func (cb *compactBlock) addTxs(msg *CompactBlockTxsMessage) bool {
+	if msg.Height != cb.msg.Height || msg.Round != cb.msg.Round {
+		return false
+	}
+
+	for i, index := range msg.Indexes {
+		if int(index) >= len(cb.txs) || msg.Txs[i].Key() != cb.msg.TxKeys[index] {
+			return false
+		}
+		cb.txs[index] = msg.Txs[i]
+	}
+	return true
+}
+
+// block returns the reconstructed block, or nil if transactions are missing.
+func (cb *compactBlock) block() *types.Block {
+	for _, tx := range cb.txs {
+		if tx == nil {
+			return nil
+		}
+	}
+
+	return &types.Block{
+		Header:     cb.msg.Header,
+		Data:       types.Data{Txs: cb.txs},
+		Evidence:   cb.msg.Evidence,
+		LastCommit: cb.msg.LastCommit,
+	}
+}
@@ -0,0 +1,117 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/internal/test/factory"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
+)
+
+type mapTxFetcher map[types.TxKey]types.Tx
+
+func (f mapTxFetcher) GetTxByKey(key types.TxKey) (types.Tx, bool) {
+	tx, ok := f[key]
+	return tx, ok
+}
+
+func makeCompactBlockTestBlock(t *testing.T) *types.Block {
+	t.Helper()
+
+	txs := []types.Tx{types.Tx("tx0"), types.Tx("tx1"), types.Tx("tx2")}
+	block := types.MakeBlock(1, txs, nil, nil)
+	header := factory.MakeRandomHeader()
+	header.Height = 1
+	header.DataHash = block.Data.Hash()
+	block.Header = *header
+	return block
+}
+
+func TestCompactBlockMessageProto(t *testing.T) {
+	block := makeCompactBlockTestBlock(t)
+	msg := NewCompactBlockMessage(1, 2, block)
+
+	pb, err := MsgToProto(msg)
+	require.NoError(t, err)
+	got, err := MsgFromProto(pb)
+	require.NoError(t, err)
+
+	cb, ok := got.(*CompactBlockMessage)
+	require.True(t, ok)
+	assert.Equal(t, msg.Height, cb.Height)
+	assert.Equal(t, msg.Round, cb.Round)
+	assert.Equal(t, msg.Header.Hash(), cb.Header.Hash())
+	assert.Equal(t, msg.TxKeys, cb.TxKeys)
+	assert.Nil(t, cb.LastCommit)
+
+	pbcb, err := msg.ToProto()
+	require.NoError(t, err)
+	pbcb.TxHashes[0] = pbcb.TxHashes[0][1:]
+	_, err = CompactBlockFromProto(pbcb)
+	require.Error(t, err)
+}
+
+func TestCompactBlockReconstruct(t *testing.T) {
+	block := makeCompactBlockTestBlock(t)
+	msg := NewCompactBlockMessage(1, 0, block)
+	partSetHeader := block.MakePartSet(types.BlockPartSizeBytes).Header()
+
+	// all transactions in the mempool
+	fetcher := mapTxFetcher{}
+	for _, tx := range block.Txs {
+		fetcher[tx.Key()] = tx
+	}
+	cb := newCompactBlock(msg, fetcher)
+	require.Empty(t, cb.missing())
+	require.NotNil(t, cb.block())
+	assert.Equal(t, partSetHeader, cb.block().MakePartSet(types.BlockPartSizeBytes).Header())
+
+	// some transactions missing from the mempool
+	delete(fetcher, block.Txs[0].Key())
+	delete(fetcher, block.Txs[2].Key())
+	cb = newCompactBlock(msg, fetcher)
+	require.Equal(t, []uint32{0, 2}, cb.missing())
+	require.Nil(t, cb.block())
+
+	require.True(t, cb.addTxs(&CompactBlockTxsMessage{
+		Height:  1,
+		Indexes: []uint32{0, 2},
+		Txs:     types.Txs{block.Txs[0], block.Txs[2]},
+	}))
+	require.Empty(t, cb.missing())
+	assert.Equal(t, partSetHeader, cb.block().MakePartSet(types.BlockPartSizeBytes).Header())
+
+	// the peer sends the wrong transactions
+	cb = newCompactBlock(msg, nil)
+	require.Len(t, cb.missing(), 3)
+	require.False(t, cb.addTxs(&CompactBlockTxsMessage{
+		Height:  1,
+		Indexes: []uint32{0},
+		Txs:     types.Txs{types.Tx("other")},
+	}))
+	require.False(t, cb.addTxs(&CompactBlockTxsMessage{
+		Height:  1,
+		Indexes: []uint32{3},
+		Txs:     types.Txs{block.Txs[0]},
+	}))
+}
+
+func TestPeerStateCompactBlockPending(t *testing.T) {
+	header := types.PartSetHeader{Total: 1, Hash: []byte("parts")}
+	ps := NewPeerState(log.NewNopLogger(), "peer")
+	require.False(t, ps.CompactBlockPending(header, time.Minute))
+
+	// the parts are withheld until the compact block expires
+	ps.SetCompactBlockSent(header)
+	assert.True(t, ps.CompactBlockPending(header, time.Minute))
+	assert.False(t, ps.CompactBlockPending(header, 0))
+	assert.True(t, ps.CompactBlockSent(header))
+
+	// or the peer asks for them
+	ps.SetCompactBlockFallback()
+	assert.False(t, ps.CompactBlockPending(header, time.Minute))
+}
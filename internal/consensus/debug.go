@@ -0,0 +1,156 @@
+package consensus
+
+import (
+	"sync"
+	"time"
+
+	cstypes "github.com/tendermint/tendermint/internal/consensus/types"
+	"github.com/tendermint/tendermint/libs/bits"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+)
+
+// maxStepTransitions is the number of the most recent step transitions of the
+// state machine kept for debugging.
+const maxStepTransitions = 100
+
+// StepTransition is a transition of the state machine to a new step.
+type StepTransition struct {
+	Height int64     `json:"height"`
+	Round  int32     `json:"round"`
+	Step   string    `json:"step"`
+	Time   time.Time `json:"time"`
+}
+
+// ScheduledTimeout is the last timeout the state machine scheduled.
+type ScheduledTimeout struct {
+	Height      int64         `json:"height"`
+	Round       int32         `json:"round"`
+	Step        string        `json:"step"`
+	Duration    time.Duration `json:"duration"`
+	ScheduledAt time.Time     `json:"scheduled_at"`
+	FiresAt     time.Time     `json:"fires_at"`
+}
+
+// TimeoutSchedule holds the timeouts of the steps of the current round, which
+// grow with the round.
+type TimeoutSchedule struct {
+	Propose   time.Duration `json:"propose"`
+	Prevote   time.Duration `json:"prevote"`
+	Precommit time.Duration `json:"precommit"`
+	Commit    time.Duration `json:"commit"`
+}
+
+// BlockPartsProgress is the progress of receiving the parts of the proposal
+// block.
+type BlockPartsProgress struct {
+	Received uint32         `json:"received"`
+	Total    uint32         `json:"total"`
+	Complete bool           `json:"complete"`
+	Parts    *bits.BitArray `json:"parts"`
+}
+
+// DebugState is a snapshot of internals of the state machine, for diagnosing
+// rounds which don't make progress.
+type DebugState struct {
+	Height             int64               `json:"height"`
+	Round              int32               `json:"round"`
+	Step               string              `json:"step"`
+	ProposalBlockParts *BlockPartsProgress `json:"proposal_block_parts"`
+	Timeouts           TimeoutSchedule     `json:"timeouts"`
+	ScheduledTimeout   *ScheduledTimeout   `json:"scheduled_timeout"`
+	Transitions        []StepTransition    `json:"transitions"` // oldest first
+}
+
+// debugLog records the step transitions and scheduled timeouts of the state
+// machine. It has its own lock, as timeouts may be scheduled without holding
+// the state's.
+type debugLog struct {
+	mtx         sync.Mutex
+	transitions [maxStepTransitions]StepTransition
+	next        int // index of the next transition in the ring buffer
+	count       int
+	timeout     *ScheduledTimeout
+}
+
+func (l *debugLog) recordTransition(now time.Time, height int64, round int32, step cstypes.RoundStepType) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	l.transitions[l.next] = StepTransition{
+		Height: height,
+		Round:  round,
+		Step:   step.String(),
+		Time:   now,
+	}
+	l.next = (l.next + 1) % maxStepTransitions
+	if l.count < maxStepTransitions {
+		l.count++
+	}
+}
+
+func (l *debugLog) recordTimeout(now time.Time, ti timeoutInfo) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	l.timeout = &ScheduledTimeout{
+		Height:      ti.Height,
+		Round:       ti.Round,
+		Step:        ti.Step.String(),
+		Duration:    ti.Duration,
+		ScheduledAt: now,
+		FiresAt:     now.Add(ti.Duration),
+	}
+}
+
+// snapshot returns the recorded transitions, oldest first, and the last
+// scheduled timeout.
+func (l *debugLog) snapshot() ([]StepTransition, *ScheduledTimeout) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	transitions := make([]StepTransition, 0, l.count)
+	for i := 0; i < l.count; i++ {
+		transitions = append(transitions,
+			l.transitions[(l.next-l.count+i+maxStepTransitions)%maxStepTransitions])
+	}
+
+	var timeout *ScheduledTimeout
+	if l.timeout != nil {
+		t := *l.timeout
+		timeout = &t
+	}
+	return transitions, timeout
+}
+
+// GetDebugState returns a snapshot of internals of the state machine.
+func (cs *State) GetDebugState() *DebugState {
+	cs.mtx.RLock()
+	defer cs.mtx.RUnlock()
+
+	debug := &DebugState{
+		Height: cs.Height,
+		Round:  cs.Round,
+		Step:   cs.Step.String(),
+		Timeouts: TimeoutSchedule{
+			Propose:   cs.proposeTimeout(cs.Round),
+			Prevote:   cs.stepTimeouts().Prevote(cs.Round),
+			Precommit: cs.stepTimeouts().Precommit(cs.Round),
+			Commit:    cs.commitTimeout(),
+		},
+	}
+	if parts := cs.ProposalBlockParts; parts != nil {
+		debug.ProposalBlockParts = &BlockPartsProgress{
+			Received: parts.Count(),
+			Total:    parts.Total(),
+			Complete: parts.IsComplete(),
+			Parts:    parts.BitArray(),
+		}
+	}
+	debug.Transitions, debug.ScheduledTimeout = cs.debugLog.snapshot()
+	return debug
+}
+
+// GetDebugStateJSON returns a json of the DebugState.
+func (cs *State) GetDebugStateJSON() ([]byte, error) {
+	return tmjson.Marshal(cs.GetDebugState())
+}
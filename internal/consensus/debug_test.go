@@ -0,0 +1,107 @@
+package consensus
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cstypes "github.com/tendermint/tendermint/internal/consensus/types"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestDebugLog(t *testing.T) {
+	var l debugLog
+	transitions, timeout := l.snapshot()
+	assert.Empty(t, transitions)
+	assert.Nil(t, timeout)
+
+	// only the most recent transitions are kept, oldest first
+	for h := int64(1); h <= maxStepTransitions+10; h++ {
+		l.recordTransition(time.Now(), h, 0, cstypes.RoundStepPropose)
+	}
+	transitions, _ = l.snapshot()
+	require.Len(t, transitions, maxStepTransitions)
+	assert.EqualValues(t, 11, transitions[0].Height)
+	assert.EqualValues(t, maxStepTransitions+10, transitions[maxStepTransitions-1].Height)
+	assert.Equal(t, "RoundStepPropose", transitions[0].Step)
+
+	l.recordTimeout(time.Now(), timeoutInfo{time.Second, 3, 1, cstypes.RoundStepPrevoteWait})
+	_, timeout = l.snapshot()
+	require.NotNil(t, timeout)
+	assert.EqualValues(t, 3, timeout.Height)
+	assert.Equal(t, "RoundStepPrevoteWait", timeout.Step)
+	assert.Equal(t, time.Second, timeout.FiresAt.Sub(timeout.ScheduledAt))
+}
+
+func TestStateDebugState(t *testing.T) {
+	config := configSetup(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cs, _, err := randState(ctx, config, log.TestingLogger(), 1)
+	require.NoError(t, err)
+	// wait out a long commit timeout after the first block, so that the state
+	// machine stays at the next height while it's inspected
+	cs.config.SkipTimeoutCommit = false
+	cs.config.TimeoutCommit = time.Hour
+	height, round := cs.Height, cs.Round
+
+	newRoundCh := subscribe(ctx, t, cs.eventBus, types.EventQueryNewRound)
+	newBlockCh := subscribe(ctx, t, cs.eventBus, types.EventQueryNewBlock)
+	startTestRound(ctx, cs, height, round)
+	ensureNewRound(newRoundCh, height, round)
+	ensureNewBlock(newBlockCh, height)
+
+	debug := cs.GetDebugState()
+	assert.EqualValues(t, height+1, debug.Height)
+	assert.EqualValues(t, 0, debug.Round)
+	assert.Equal(t, "RoundStepNewHeight", debug.Step)
+	assert.Equal(t, TimeoutSchedule{
+		Propose:   cs.config.Propose(0),
+		Prevote:   cs.config.Prevote(0),
+		Precommit: cs.config.Precommit(0),
+		Commit:    time.Hour,
+	}, debug.Timeouts)
+
+	// the timeout scheduled last is that of the commit, starting the next
+	// height
+	require.NotNil(t, debug.ScheduledTimeout)
+	assert.EqualValues(t, height+1, debug.ScheduledTimeout.Height)
+	assert.EqualValues(t, 0, debug.ScheduledTimeout.Round)
+	assert.Equal(t, "RoundStepNewHeight", debug.ScheduledTimeout.Step)
+	assert.InDelta(t, time.Hour, debug.ScheduledTimeout.Duration, float64(time.Minute))
+	assert.Equal(t, debug.ScheduledTimeout.Duration,
+		debug.ScheduledTimeout.FiresAt.Sub(debug.ScheduledTimeout.ScheduledAt))
+
+	// the steps of the committed height were recorded in order, followed by
+	// the next height
+	var steps []string
+	for _, transition := range debug.Transitions {
+		if transition.Height == height {
+			steps = append(steps, transition.Step)
+		}
+	}
+	assert.Equal(t, []string{
+		"RoundStepNewHeight",
+		"RoundStepNewRound",
+		"RoundStepPropose",
+		"RoundStepPrevote",
+		"RoundStepPrecommit",
+		"RoundStepCommit",
+	}, steps)
+	last := debug.Transitions[len(debug.Transitions)-1]
+	assert.EqualValues(t, height+1, last.Height)
+	assert.Equal(t, "RoundStepNewHeight", last.Step)
+	for i := 1; i < len(debug.Transitions); i++ {
+		assert.False(t, debug.Transitions[i].Time.Before(debug.Transitions[i-1].Time))
+	}
+
+	bz, err := cs.GetDebugStateJSON()
+	require.NoError(t, err)
+	assert.True(t, json.Valid(bz))
+}
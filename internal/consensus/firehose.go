@@ -0,0 +1,112 @@
+package consensus
+
+import (
+	"context"
+	"time"
+
+	cstypes "github.com/tendermint/tendermint/internal/consensus/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// firehose holds the step the state machine last transitioned to, for the
+// consensus step events to report the time spent in each step.
+type firehose struct {
+	height int64
+	round  int32
+	step   cstypes.RoundStepType
+	time   time.Time
+}
+
+// firehoseEnabled returns whether the consensus firehose events are
+// published. They are not while replaying the WAL, as their times would not
+// be those of the original messages.
+func (cs *State) firehoseEnabled() bool {
+	return cs.config.EventFirehose && cs.eventBus != nil && !cs.replayMode
+}
+
+// publishStepEvent publishes a ConsensusStep event for the transition of the
+// state machine to its current step.
+func (cs *State) publishStepEvent(ctx context.Context) {
+	now := cs.now()
+	prev := cs.firehose
+	cs.firehose = firehose{height: cs.Height, round: cs.Round, step: cs.Step, time: now}
+	if !cs.firehoseEnabled() {
+		return
+	}
+
+	data := types.EventDataConsensusStep{
+		Height: cs.Height,
+		Round:  cs.Round,
+		Step:   cs.Step.String(),
+		Time:   now,
+	}
+	if !prev.time.IsZero() {
+		data.PrevHeight = prev.height
+		data.PrevRound = prev.round
+		data.PrevStep = prev.step.String()
+		data.PrevStepDuration = now.Sub(prev.time)
+	}
+	if err := cs.eventBus.PublishEventConsensusStep(ctx, data); err != nil {
+		cs.logger.Error("failed publishing consensus step", "err", err)
+	}
+}
+
+// publishVoteEvent publishes a ConsensusVote event for a vote received from
+// peerID, empty for the node's own votes.
+func (cs *State) publishVoteEvent(ctx context.Context, vote *types.Vote, peerID types.NodeID, added bool, err error) {
+	if !cs.firehoseEnabled() {
+		return
+	}
+
+	data := types.EventDataConsensusVote{
+		Height:           vote.Height,
+		Round:            vote.Round,
+		Type:             vote.Type.String(),
+		ValidatorAddress: vote.ValidatorAddress,
+		ValidatorIndex:   vote.ValidatorIndex,
+		BlockID:          vote.BlockID,
+		Timestamp:        vote.Timestamp,
+		PeerID:           peerID,
+		Time:             cs.now(),
+		Added:            added,
+	}
+	if err != nil {
+		data.Error = err.Error()
+	}
+	if err := cs.eventBus.PublishEventConsensusVote(ctx, data); err != nil {
+		cs.logger.Error("failed publishing consensus vote", "err", err)
+	}
+}
+
+// publishBlockPartEvent publishes a ConsensusBlockPart event for a block part
+// received from peerID, empty for the parts of the node's own proposals.
+func (cs *State) publishBlockPartEvent(
+	ctx context.Context,
+	msg *BlockPartMessage,
+	peerID types.NodeID,
+	added bool,
+	err error,
+) {
+	if !cs.firehoseEnabled() {
+		return
+	}
+
+	data := types.EventDataConsensusBlockPart{
+		Height: msg.Height,
+		Round:  msg.Round,
+		Index:  msg.Part.Index,
+		PeerID: peerID,
+		Time:   cs.now(),
+		Added:  added,
+	}
+	if cs.Height == msg.Height && cs.ProposalBlockParts != nil {
+		data.Total = cs.ProposalBlockParts.Total()
+		data.Complete = cs.ProposalBlockParts.IsComplete()
+	}
+	if err != nil {
+		data.Error = err.Error()
+	}
+	if err := cs.eventBus.PublishEventConsensusBlockPart(ctx, data); err != nil {
+		cs.logger.Error("failed publishing consensus block part", "err", err)
+	}
+}
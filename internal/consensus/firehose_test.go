@@ -0,0 +1,82 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tmpubsub "github.com/tendermint/tendermint/internal/pubsub"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestStateEventFirehose(t *testing.T) {
+	config := configSetup(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cs, _, err := randState(ctx, config, log.TestingLogger(), 1)
+	require.NoError(t, err)
+	cs.config.EventFirehose = true
+	height, round := cs.Height, cs.Round
+
+	sub, err := cs.eventBus.SubscribeWithArgs(ctx, tmpubsub.SubscribeArgs{
+		ClientID: testSubscriber,
+		Query:    types.EventQueryConsensusHeight(height),
+		Limit:    100,
+	})
+	require.NoError(t, err)
+
+	startTestRound(ctx, cs, height, round)
+
+	var (
+		steps      []string
+		votes      []types.EventDataConsensusVote
+		blockParts []types.EventDataConsensusBlockPart
+	)
+	// the precommit which commits the block is published after the commit step
+	for len(steps) == 0 || steps[len(steps)-1] != "RoundStepCommit" || len(votes) < 2 {
+		nextCtx, nextCancel := context.WithTimeout(ctx, ensureTimeout)
+		msg, err := sub.Next(nextCtx)
+		nextCancel()
+		require.NoError(t, err, "steps %v", steps)
+
+		switch data := msg.Data().(type) {
+		case types.EventDataConsensusStep:
+			assert.EqualValues(t, height, data.Height)
+			if len(steps) > 0 {
+				assert.Equal(t, steps[len(steps)-1], data.PrevStep)
+				assert.GreaterOrEqual(t, data.PrevStepDuration, time.Duration(0))
+			}
+			steps = append(steps, data.Step)
+		case types.EventDataConsensusVote:
+			votes = append(votes, data)
+		case types.EventDataConsensusBlockPart:
+			blockParts = append(blockParts, data)
+		default:
+			t.Fatalf("unexpected event %T", data)
+		}
+	}
+
+	assert.Equal(t, []string{
+		"RoundStepNewRound",
+		"RoundStepPropose",
+		"RoundStepPrevote",
+		"RoundStepPrecommit",
+		"RoundStepCommit",
+	}, steps)
+
+	// the node's own prevote and precommit
+	require.Len(t, votes, 2)
+	for _, vote := range votes {
+		assert.True(t, vote.Added)
+		assert.Empty(t, vote.PeerID)
+		assert.Equal(t, cs.privValidatorPubKey.Address(), vote.ValidatorAddress)
+	}
+
+	require.NotEmpty(t, blockParts)
+	assert.True(t, blockParts[len(blockParts)-1].Complete)
+}
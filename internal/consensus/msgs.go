@@ -3,6 +3,7 @@ package consensus
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	cstypes "github.com/tendermint/tendermint/internal/consensus/types"
 	"github.com/tendermint/tendermint/libs/bits"
@@ -30,6 +31,10 @@ func init() {
 	tmjson.RegisterType(&HasVoteMessage{}, "tendermint/HasVote")
 	tmjson.RegisterType(&VoteSetMaj23Message{}, "tendermint/VoteSetMaj23")
 	tmjson.RegisterType(&VoteSetBitsMessage{}, "tendermint/VoteSetBits")
+	tmjson.RegisterType(&CompactBlockMessage{}, "tendermint/CompactBlock")
+	tmjson.RegisterType(&CompactBlockTxsRequestMessage{}, "tendermint/CompactBlockTxsRequest")
+	tmjson.RegisterType(&CompactBlockTxsMessage{}, "tendermint/CompactBlockTxs")
+	tmjson.RegisterType(&VoteBatchMessage{}, "tendermint/VoteBatch")
 }
 
 // NewRoundStepMessage is sent for every step taken in the ConsensusState.
@@ -312,6 +317,153 @@ func (m *VoteSetBitsMessage) String() string {
 	return fmt.Sprintf("[VSB %v/%02d/%v %v %v]", m.Height, m.Round, m.Type, m.BlockID, m.Votes)
 }
 
+// CompactBlockMessage is sent in place of the parts of a proposed block, with
+// the keys of its transactions rather than the transactions, for the receiver
+// to reconstruct the block from its mempool.
+type CompactBlockMessage struct {
+	Height     int64
+	Round      int32
+	Header     types.Header
+	TxKeys     []types.TxKey
+	Evidence   types.EvidenceData
+	LastCommit *types.Commit
+}
+
+// ValidateBasic performs basic validation.
+func (m *CompactBlockMessage) ValidateBasic() error {
+	if m.Height < 0 {
+		return errors.New("negative Height")
+	}
+	if m.Round < 0 {
+		return errors.New("negative Round")
+	}
+	if err := m.Header.ValidateBasic(); err != nil {
+		return fmt.Errorf("wrong Header: %v", err)
+	}
+	if m.Header.Height != m.Height {
+		return fmt.Errorf("header height %d does not match height %d", m.Header.Height, m.Height)
+	}
+	if m.LastCommit != nil {
+		if err := m.LastCommit.ValidateBasic(); err != nil {
+			return fmt.Errorf("wrong LastCommit: %v", err)
+		}
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *CompactBlockMessage) String() string {
+	return fmt.Sprintf("[CompactBlock H:%v R:%v T:%v B:%v]", m.Height, m.Round, len(m.TxKeys), m.Header.Hash())
+}
+
+// CompactBlockTxsRequestMessage is sent to request the transactions of a
+// compact block, by index, that are missing from the mempool. If Fallback is
+// set, the compact block could not be reconstructed and the block parts are
+// requested instead.
+type CompactBlockTxsRequestMessage struct {
+	Height   int64
+	Round    int32
+	Indexes  []uint32
+	Fallback bool
+}
+
+// ValidateBasic performs basic validation.
+func (m *CompactBlockTxsRequestMessage) ValidateBasic() error {
+	if m.Height < 0 {
+		return errors.New("negative Height")
+	}
+	if m.Round < 0 {
+		return errors.New("negative Round")
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *CompactBlockTxsRequestMessage) String() string {
+	return fmt.Sprintf("[CompactBlockTxsRequest H:%v R:%v T:%v F:%v]", m.Height, m.Round, len(m.Indexes), m.Fallback)
+}
+
+// CompactBlockTxsMessage is sent in response to a
+// CompactBlockTxsRequestMessage.
+type CompactBlockTxsMessage struct {
+	Height  int64
+	Round   int32
+	Indexes []uint32
+	Txs     types.Txs
+}
+
+// ValidateBasic performs basic validation.
+func (m *CompactBlockTxsMessage) ValidateBasic() error {
+	if m.Height < 0 {
+		return errors.New("negative Height")
+	}
+	if m.Round < 0 {
+		return errors.New("negative Round")
+	}
+	if len(m.Indexes) != len(m.Txs) {
+		return fmt.Errorf("got %d indexes for %d txs", len(m.Indexes), len(m.Txs))
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *CompactBlockTxsMessage) String() string {
+	return fmt.Sprintf("[CompactBlockTxs H:%v R:%v T:%v]", m.Height, m.Round, len(m.Txs))
+}
+
+// VoteBatchMessage is sent to peers supporting it in place of the votes of
+// validators for the same block ID, see NewVoteBatchMessage.
+type VoteBatchMessage struct {
+	Height     int64
+	Round      int32
+	Type       tmproto.SignedMsgType
+	BlockID    types.BlockID
+	Votes      *bits.BitArray // the validator indexes of the votes
+	Timestamps []time.Time
+	Signatures [][]byte
+}
+
+// ValidateBasic performs basic validation.
+func (m *VoteBatchMessage) ValidateBasic() error {
+	if m.Height < 0 {
+		return errors.New("negative Height")
+	}
+	if m.Round < 0 {
+		return errors.New("negative Round")
+	}
+	if !types.IsVoteTypeValid(m.Type) {
+		return errors.New("invalid Type")
+	}
+	if err := m.BlockID.ValidateBasic(); err != nil {
+		return fmt.Errorf("wrong BlockID: %v", err)
+	}
+	if m.Votes.Size() > types.MaxVotesCount {
+		return fmt.Errorf("votes bit array is too big: %d, max: %d", m.Votes.Size(), types.MaxVotesCount)
+	}
+	numVotes := len(voteBatchIndexes(m.Votes))
+	if numVotes == 0 {
+		return errors.New("no votes")
+	}
+	if len(m.Timestamps) != numVotes || len(m.Signatures) != numVotes {
+		return fmt.Errorf("got %d timestamps and %d signatures for %d votes",
+			len(m.Timestamps), len(m.Signatures), numVotes)
+	}
+	for i, sig := range m.Signatures {
+		if len(sig) == 0 {
+			return fmt.Errorf("signature %d is missing", i)
+		}
+		if len(sig) > types.MaxSignatureSize {
+			return fmt.Errorf("signature %d is too big (max: %d)", i, types.MaxSignatureSize)
+		}
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *VoteBatchMessage) String() string {
+	return fmt.Sprintf("[VoteBatch %v/%02d/%v %v %v]", m.Height, m.Round, m.Type, m.BlockID, m.Votes)
+}
+
 // MsgToProto takes a consensus message type and returns the proto defined
 // consensus message.
 //
@@ -435,6 +587,59 @@ func MsgToProto(msg Message) (*tmcons.Message, error) {
 		pb = tmcons.Message{
 			Sum: vsb,
 		}
+	case *CompactBlockMessage:
+		cb, err := msg.ToProto()
+		if err != nil {
+			return nil, fmt.Errorf("msg to proto error: %w", err)
+		}
+		pb = tmcons.Message{
+			Sum: &tmcons.Message_CompactBlock{
+				CompactBlock: cb,
+			},
+		}
+	case *CompactBlockTxsRequestMessage:
+		pb = tmcons.Message{
+			Sum: &tmcons.Message_CompactBlockTxsRequest{
+				CompactBlockTxsRequest: &tmcons.CompactBlockTxsRequest{
+					Height:   msg.Height,
+					Round:    msg.Round,
+					Indexes:  msg.Indexes,
+					Fallback: msg.Fallback,
+				},
+			},
+		}
+	case *CompactBlockTxsMessage:
+		txs := make([][]byte, len(msg.Txs))
+		for i, tx := range msg.Txs {
+			txs[i] = tx
+		}
+		pb = tmcons.Message{
+			Sum: &tmcons.Message_CompactBlockTxs{
+				CompactBlockTxs: &tmcons.CompactBlockTxs{
+					Height:  msg.Height,
+					Round:   msg.Round,
+					Indexes: msg.Indexes,
+					Txs:     txs,
+				},
+			},
+		}
+	case *VoteBatchMessage:
+		vb := &tmcons.VoteBatch{
+			Height:     msg.Height,
+			Round:      msg.Round,
+			Type:       msg.Type,
+			BlockID:    msg.BlockID.ToProto(),
+			Timestamps: msg.Timestamps,
+			Signatures: msg.Signatures,
+		}
+		if bits := msg.Votes.ToProto(); bits != nil {
+			vb.Votes = *bits
+		}
+		pb = tmcons.Message{
+			Sum: &tmcons.Message_VoteBatch{
+				VoteBatch: vb,
+			},
+		}
 
 	default:
 		return nil, fmt.Errorf("consensus: message not recognized: %T", msg)
@@ -558,6 +763,48 @@ func MsgFromProto(msg *tmcons.Message) (Message, error) {
 			BlockID: *bi,
 			Votes:   bits,
 		}
+	case *tmcons.Message_CompactBlock:
+		cb, err := CompactBlockFromProto(msg.CompactBlock)
+		if err != nil {
+			return nil, fmt.Errorf("compact block msg to proto error: %w", err)
+		}
+		pb = cb
+	case *tmcons.Message_CompactBlockTxsRequest:
+		pb = &CompactBlockTxsRequestMessage{
+			Height:   msg.CompactBlockTxsRequest.Height,
+			Round:    msg.CompactBlockTxsRequest.Round,
+			Indexes:  msg.CompactBlockTxsRequest.Indexes,
+			Fallback: msg.CompactBlockTxsRequest.Fallback,
+		}
+	case *tmcons.Message_CompactBlockTxs:
+		txs := make(types.Txs, len(msg.CompactBlockTxs.Txs))
+		for i, tx := range msg.CompactBlockTxs.Txs {
+			txs[i] = tx
+		}
+		pb = &CompactBlockTxsMessage{
+			Height:  msg.CompactBlockTxs.Height,
+			Round:   msg.CompactBlockTxs.Round,
+			Indexes: msg.CompactBlockTxs.Indexes,
+			Txs:     txs,
+		}
+	case *tmcons.Message_VoteBatch:
+		bi, err := types.BlockIDFromProto(&msg.VoteBatch.BlockID)
+		if err != nil {
+			return nil, fmt.Errorf("block ID to proto error: %w", err)
+		}
+		bits := new(bits.BitArray)
+		if err := bits.FromProto(&msg.VoteBatch.Votes); err != nil {
+			return nil, fmt.Errorf("votes to proto error: %w", err)
+		}
+		pb = &VoteBatchMessage{
+			Height:     msg.VoteBatch.Height,
+			Round:      msg.VoteBatch.Round,
+			Type:       msg.VoteBatch.Type,
+			BlockID:    *bi,
+			Votes:      bits,
+			Timestamps: msg.VoteBatch.Timestamps,
+			Signatures: msg.VoteBatch.Signatures,
+		}
 	default:
 		return nil, fmt.Errorf("consensus: message not recognized: %T", msg)
 	}
@@ -619,6 +866,13 @@ func WALToProto(msg WALMessage) (*tmcons.WALMessage, error) {
 			},
 		}
 
+	case CheckpointMessage:
+		pb = tmcons.WALMessage{
+			Sum: &tmcons.WALMessage_Checkpoint{
+				Checkpoint: msg.ToProto(),
+			},
+		}
+
 	default:
 		return nil, fmt.Errorf("to proto: wal message not recognized: %T", msg)
 	}
@@ -676,6 +930,9 @@ func WALFromProto(msg *tmcons.WALMessage) (WALMessage, error) {
 
 		return pb, nil
 
+	case *tmcons.WALMessage_Checkpoint:
+		return CheckpointMessageFromProto(msg.Checkpoint)
+
 	default:
 		return nil, fmt.Errorf("from proto: wal message not recognized: %T", msg)
 	}
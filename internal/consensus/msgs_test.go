@@ -183,6 +183,34 @@ func TestMsgToProto(t *testing.T) {
 				},
 			},
 		}, false},
+		{"successful CompactBlockTxsRequest", &CompactBlockTxsRequestMessage{
+			Height:  1,
+			Round:   1,
+			Indexes: []uint32{0, 2},
+		}, &tmcons.Message{
+			Sum: &tmcons.Message_CompactBlockTxsRequest{
+				CompactBlockTxsRequest: &tmcons.CompactBlockTxsRequest{
+					Height:  1,
+					Round:   1,
+					Indexes: []uint32{0, 2},
+				},
+			},
+		}, false},
+		{"successful CompactBlockTxs", &CompactBlockTxsMessage{
+			Height:  1,
+			Round:   1,
+			Indexes: []uint32{0, 2},
+			Txs:     types.Txs{types.Tx("foo"), types.Tx("bar")},
+		}, &tmcons.Message{
+			Sum: &tmcons.Message_CompactBlockTxs{
+				CompactBlockTxs: &tmcons.CompactBlockTxs{
+					Height:  1,
+					Round:   1,
+					Indexes: []uint32{0, 2},
+					Txs:     [][]byte{[]byte("foo"), []byte("bar")},
+				},
+			},
+		}, false},
 		{"failure", nil, &tmcons.Message{}, true},
 	}
 	for _, tt := range testsCases {
@@ -289,6 +317,21 @@ func TestWALMsgProto(t *testing.T) {
 				},
 			},
 		}, false},
+		{"successful CheckpointMessage", CheckpointMessage{
+			Height: 1,
+			Round:  2,
+			Step:   cstypes.RoundStepPrecommit,
+			Time:   time.Date(2018, 8, 30, 12, 0, 0, 0, time.UTC),
+		}, &tmcons.WALMessage{
+			Sum: &tmcons.WALMessage_Checkpoint{
+				Checkpoint: &tmcons.Checkpoint{
+					Height: 1,
+					Round:  2,
+					Step:   uint32(cstypes.RoundStepPrecommit),
+					Time:   time.Date(2018, 8, 30, 12, 0, 0, 0, time.UTC),
+				},
+			},
+		}, false},
 		{"failure", nil, &tmcons.WALMessage{}, true},
 	}
 	for _, tt := range testsCases {
@@ -604,7 +647,7 @@ func TestNewValidBlockMessageValidateBasic(t *testing.T) {
 		},
 		{
 			func(msg *NewValidBlockMessage) { msg.BlockParts = bits.NewBitArray(int(types.MaxBlockPartsCount) + 1) },
-			"blockParts bit array size 1602 not equal to BlockPartSetHeader.Total 1",
+			"blockParts bit array size 25602 not equal to BlockPartSetHeader.Total 1",
 		},
 	}
 
@@ -39,12 +39,26 @@ type PeerState struct {
 	peerID types.NodeID
 	logger log.Logger
 
+	// wake up the gossip routines when there is new data for the peer
+	dataWaker  *tmsync.Waker
+	votesWaker *tmsync.Waker
+
 	// NOTE: Modify below using setters, never directly.
 	mtx     sync.RWMutex
 	running bool
+	valAddr types.Address          // validator operating the peer, if proven
+	caps    types.NodeCapabilities // advertised by the peer in the handshake
 	PRS     cstypes.PeerRoundState `json:"round_state"`
 	Stats   *peerStateStats        `json:"stats"`
 
+	// the proposal block a compact block was sent to the peer for, when, and
+	// whether the peer asked for its parts instead
+	compactBlockSent     types.PartSetHeader
+	compactBlockSentAt   time.Time
+	compactBlockFallback bool
+	// the compact block received from the peer, waiting for transactions
+	compactBlock *compactBlock
+
 	broadcastWG sync.WaitGroup
 	closer      *tmsync.Closer
 }
@@ -52,9 +66,11 @@ type PeerState struct {
 // NewPeerState returns a new PeerState for the given node ID.
 func NewPeerState(logger log.Logger, peerID types.NodeID) *PeerState {
 	return &PeerState{
-		peerID: peerID,
-		logger: logger,
-		closer: tmsync.NewCloser(),
+		peerID:     peerID,
+		logger:     logger,
+		closer:     tmsync.NewCloser(),
+		dataWaker:  tmsync.NewWaker(),
+		votesWaker: tmsync.NewWaker(),
 		PRS: cstypes.PeerRoundState{
 			Round:              -1,
 			ProposalPOLRound:   -1,
@@ -65,6 +81,46 @@ func NewPeerState(logger log.Logger, peerID types.NodeID) *PeerState {
 	}
 }
 
+// SetValidatorAddress sets the address of the validator which proved that it
+// operates the peer.
+func (ps *PeerState) SetValidatorAddress(addr types.Address) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	ps.valAddr = addr
+}
+
+// ValidatorAddress returns the address of the validator which proved that it
+// operates the peer, or nil.
+func (ps *PeerState) ValidatorAddress() types.Address {
+	ps.mtx.RLock()
+	defer ps.mtx.RUnlock()
+
+	return ps.valAddr
+}
+
+// SetCapabilities sets the capabilities the peer advertised in the handshake.
+func (ps *PeerState) SetCapabilities(caps types.NodeCapabilities) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	ps.caps = caps
+}
+
+// HasCapability returns whether the peer advertised the given capabilities.
+func (ps *PeerState) HasCapability(caps types.NodeCapabilities) bool {
+	ps.mtx.RLock()
+	defer ps.mtx.RUnlock()
+
+	return ps.caps.Has(caps)
+}
+
+// wake wakes up the peer's gossip routines if they are sleeping.
+func (ps *PeerState) wake() {
+	ps.dataWaker.Wake()
+	ps.votesWaker.Wake()
+}
+
 // SetRunning sets the running state of the peer.
 func (ps *PeerState) SetRunning(v bool) {
 	ps.mtx.Lock()
@@ -161,6 +217,76 @@ func (ps *PeerState) SetHasProposalBlockPart(height int64, round int32, index in
 	ps.PRS.ProposalBlockParts.SetIndex(index, true)
 }
 
+// SetCompactBlockSent records that a compact block of the proposal block with
+// the given part set header was sent to the peer.
+func (ps *PeerState) SetCompactBlockSent(header types.PartSetHeader) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	ps.compactBlockSent = header
+	ps.compactBlockSentAt = time.Now()
+	ps.compactBlockFallback = false
+}
+
+// SetCompactBlockFallback records that the peer could not reconstruct the
+// compact block sent to it, and needs the block parts.
+func (ps *PeerState) SetCompactBlockFallback() {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	ps.compactBlockFallback = true
+}
+
+// CompactBlockSent returns whether a compact block of the proposal block with
+// the given part set header was sent to the peer, and the peer has not asked
+// for the block parts instead.
+func (ps *PeerState) CompactBlockSent(header types.PartSetHeader) bool {
+	ps.mtx.RLock()
+	defer ps.mtx.RUnlock()
+
+	return !header.IsZero() && ps.compactBlockSent.Equals(header) && !ps.compactBlockFallback
+}
+
+// CompactBlockPending returns whether a compact block of the proposal block
+// with the given part set header was sent to the peer less than timeout ago,
+// and the peer has not asked for the block parts instead. The block parts are
+// withheld while the compact block is pending, and sent once it expires in
+// case the compact block or the peer's request for the parts was lost.
+func (ps *PeerState) CompactBlockPending(header types.PartSetHeader, timeout time.Duration) bool {
+	ps.mtx.RLock()
+	defer ps.mtx.RUnlock()
+
+	return !header.IsZero() && ps.compactBlockSent.Equals(header) && !ps.compactBlockFallback &&
+		time.Since(ps.compactBlockSentAt) < timeout
+}
+
+// CompactBlockFallback returns whether the peer asked for the parts of the
+// proposal block with the given part set header in place of a compact block.
+func (ps *PeerState) CompactBlockFallback(header types.PartSetHeader) bool {
+	ps.mtx.RLock()
+	defer ps.mtx.RUnlock()
+
+	return ps.compactBlockSent.Equals(header) && ps.compactBlockFallback
+}
+
+// setCompactBlock sets the compact block received from the peer which is
+// waiting for transactions, or clears it if cb is nil.
+func (ps *PeerState) setCompactBlock(cb *compactBlock) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	ps.compactBlock = cb
+}
+
+// getCompactBlock returns the compact block received from the peer which is
+// waiting for transactions, if any.
+func (ps *PeerState) getCompactBlock() *compactBlock {
+	ps.mtx.RLock()
+	defer ps.mtx.RUnlock()
+
+	return ps.compactBlock
+}
+
 // PickVoteToSend picks a vote to send to the peer. It will return true if a
 // vote was picked.
 //
@@ -202,6 +328,58 @@ func (ps *PeerState) PickVoteToSend(votes types.VoteSetReader) (*types.Vote, boo
 	return nil, false
 }
 
+// PickVoteBatchToSend picks a vote to send to the peer, like PickVoteToSend,
+// along with all the other votes the peer is missing for the same block ID,
+// to be sent as a batch. It will return true if votes were picked.
+func (ps *PeerState) PickVoteBatchToSend(votes types.VoteSetReader) ([]*types.Vote, bool) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	if votes.Size() == 0 {
+		return nil, false
+	}
+
+	var (
+		height    = votes.GetHeight()
+		round     = votes.GetRound()
+		votesType = tmproto.SignedMsgType(votes.Type())
+		size      = votes.Size()
+	)
+
+	// lazily set data using 'votes'
+	if votes.IsCommit() {
+		ps.ensureCatchupCommitRound(height, round, size)
+	}
+
+	ps.ensureVoteBitArrays(height, size)
+
+	psVotes := ps.getVoteBitArray(height, round, votesType)
+	if psVotes == nil {
+		return nil, false // not something worth sending
+	}
+
+	missing := votes.BitArray().Sub(psVotes)
+	index, ok := missing.PickRandom()
+	if !ok {
+		return nil, false
+	}
+	picked := votes.GetByIndex(int32(index))
+	if picked == nil {
+		return nil, false
+	}
+
+	batch := []*types.Vote{picked}
+	for i := 0; i < missing.Size(); i++ {
+		if i == index || !missing.GetIndex(i) {
+			continue
+		}
+		if vote := votes.GetByIndex(int32(i)); vote != nil && vote.BlockID.Equals(picked.BlockID) {
+			batch = append(batch, vote)
+		}
+	}
+	return batch, true
+}
+
 func (ps *PeerState) getVoteBitArray(height int64, round int32, votesType tmproto.SignedMsgType) *bits.BitArray {
 	if !types.IsVoteTypeValid(votesType) {
 		return nil
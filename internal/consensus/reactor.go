@@ -8,8 +8,13 @@ import (
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
 	cstypes "github.com/tendermint/tendermint/internal/consensus/types"
 	"github.com/tendermint/tendermint/internal/eventbus"
+	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
+	"github.com/tendermint/tendermint/internal/libs/tracing"
 	"github.com/tendermint/tendermint/internal/p2p"
 	sm "github.com/tendermint/tendermint/internal/state"
 	"github.com/tendermint/tendermint/libs/bits"
@@ -22,8 +27,9 @@ import (
 )
 
 var (
-	_ service.Service = (*Reactor)(nil)
-	_ p2p.Wrapper     = (*tmcons.Message)(nil)
+	_ service.Service         = (*Reactor)(nil)
+	_ p2p.Wrapper             = (*tmcons.Message)(nil)
+	_ p2p.ZeroCopyUnmarshaler = (*tmcons.Message)(nil)
 )
 
 // GetChannelDescriptor produces an instance of a descriptor for this
@@ -37,6 +43,7 @@ func GetChannelDescriptors() []*p2p.ChannelDescriptor {
 			SendQueueCapacity:   64,
 			RecvMessageCapacity: maxMsgSize,
 			RecvBufferCapacity:  128,
+			MessageTTL:          gossipMessageTTL,
 		},
 		{
 			// TODO: Consider a split between gossiping current block and catchup
@@ -56,6 +63,7 @@ func GetChannelDescriptors() []*p2p.ChannelDescriptor {
 			SendQueueCapacity:   64,
 			RecvBufferCapacity:  128,
 			RecvMessageCapacity: maxMsgSize,
+			MessageTTL:          gossipMessageTTL,
 		},
 		{
 			ID:                  VoteSetBitsChannel,
@@ -76,6 +84,10 @@ const (
 
 	maxMsgSize = 1048576 // 1MB; NOTE: keep in sync with types.PartSet sizes.
 
+	// gossipMessageTTL is how long state and vote messages may wait to be
+	// sent to a peer; they are stale by then, and gossiped again if needed.
+	gossipMessageTTL = 10 * time.Second
+
 	blocksToContributeToBecomeGoodPeer = 10000
 	votesToContributeToBecomeGoodPeer  = 10000
 
@@ -84,6 +96,12 @@ const (
 
 type ReactorOption func(*Reactor)
 
+// ValidatorTracker is notified whenever the validator set changes, so that the
+// p2p layer can prioritize connections to peers operated by validators.
+type ValidatorTracker interface {
+	SetValidators(vals *types.ValidatorSet) error
+}
+
 // NOTE: Temporary interface for switching to block sync, we should get rid of v0.
 // See: https://github.com/tendermint/tendermint/issues/4595
 type BlockSyncReactor interface {
@@ -121,6 +139,16 @@ type Reactor struct {
 	peers    map[types.NodeID]*PeerState
 	waitSync bool
 
+	// active validators, used to prioritize gossip to peers operated by them
+	validatorTracker ValidatorTracker
+	validators       *types.ValidatorSet
+	validatorAddrs   map[string]bool
+
+	// looks up the transactions of compact blocks received from peers
+	txFetcher TxFetcher
+
+	timeStats timeStats
+
 	stateCh       *p2p.Channel
 	dataCh        *p2p.Channel
 	voteCh        *p2p.Channel
@@ -241,6 +269,18 @@ func ReactorMetrics(metrics *Metrics) ReactorOption {
 	return func(r *Reactor) { r.Metrics = metrics }
 }
 
+// ReactorValidatorTracker sets a ValidatorTracker which is notified whenever
+// the validator set changes.
+func ReactorValidatorTracker(tracker ValidatorTracker) ReactorOption {
+	return func(r *Reactor) { r.validatorTracker = tracker }
+}
+
+// ReactorTxFetcher sets the TxFetcher, typically the mempool, used to
+// reconstruct the compact blocks received from peers.
+func ReactorTxFetcher(fetcher TxFetcher) ReactorOption {
+	return func(r *Reactor) { r.txFetcher = fetcher }
+}
+
 // SwitchToConsensus switches from block-sync mode to consensus mode. It resets
 // the state, turns off block-sync, and starts the consensus state-machine.
 func (r *Reactor) SwitchToConsensus(ctx context.Context, state sm.State, skipWAL bool) {
@@ -358,9 +398,16 @@ func (r *Reactor) subscribeToBroadcastEvents() {
 		listenerIDConsensus,
 		types.EventNewRoundStepValue,
 		func(ctx context.Context, data tmevents.EventData) error {
-			if err := r.broadcastNewRoundStepMessage(ctx, data.(*cstypes.RoundState)); err != nil {
+			rs := data.(*cstypes.RoundState)
+			r.updateValidators(rs.Validators)
+			// listeners are called by the state machine with its lock held
+			if rs.Step == cstypes.RoundStepNewHeight && !r.state.replayMode {
+				r.timeStats.newHeight(rs)
+			}
+			if err := r.broadcastNewRoundStepMessage(ctx, rs); err != nil {
 				return err
 			}
+			r.wakeValidatorPeers()
 			select {
 			case r.state.onStopCh <- data.(*cstypes.RoundState):
 				return nil
@@ -379,18 +426,42 @@ func (r *Reactor) subscribeToBroadcastEvents() {
 		listenerIDConsensus,
 		types.EventValidBlockValue,
 		func(ctx context.Context, data tmevents.EventData) error {
-			return r.broadcastNewValidBlockMessage(ctx, data.(*cstypes.RoundState))
+			if err := r.broadcastNewValidBlockMessage(ctx, data.(*cstypes.RoundState)); err != nil {
+				return err
+			}
+			r.wakeValidatorPeers()
+			return nil
 		},
 	)
 	if err != nil {
 		r.logger.Error("failed to add listener for events", "err", err)
 	}
 
+	for _, event := range []string{types.EventTimeoutProposeValue, types.EventTimeoutWaitValue} {
+		err = r.state.evsw.AddListenerForEvent(
+			listenerIDConsensus,
+			event,
+			func(ctx context.Context, data tmevents.EventData) error {
+				if !r.state.replayMode {
+					r.timeStats.timeout(data.(timeoutInfo))
+				}
+				return nil
+			},
+		)
+		if err != nil {
+			r.logger.Error("failed to add listener for events", "err", err)
+		}
+	}
+
 	err = r.state.evsw.AddListenerForEvent(
 		listenerIDConsensus,
 		types.EventVoteValue,
 		func(ctx context.Context, data tmevents.EventData) error {
-			return r.broadcastHasVoteMessage(ctx, data.(*types.Vote))
+			if err := r.broadcastHasVoteMessage(ctx, data.(*types.Vote)); err != nil {
+				return err
+			}
+			r.wakeValidatorPeers()
+			return nil
 		},
 	)
 	if err != nil {
@@ -398,6 +469,80 @@ func (r *Reactor) subscribeToBroadcastEvents() {
 	}
 }
 
+// TimeStats returns the timing of the latest heights committed by the node:
+// the average time between them, the number of rounds they took and the
+// number of times the timeouts of the steps fired.
+func (r *Reactor) TimeStats() TimeStats {
+	return r.timeStats.stats()
+}
+
+// updateValidators records the active validator set, notifying the validator
+// tracker if it changed.
+func (r *Reactor) updateValidators(vals *types.ValidatorSet) {
+	if vals == nil {
+		return
+	}
+
+	r.mtx.Lock()
+	if r.validators == vals {
+		r.mtx.Unlock()
+		return
+	}
+	r.validators = vals
+	r.validatorAddrs = make(map[string]bool, vals.Size())
+	for _, val := range vals.Validators {
+		r.validatorAddrs[string(val.Address)] = true
+	}
+	r.mtx.Unlock()
+
+	if r.validatorTracker != nil {
+		if err := r.validatorTracker.SetValidators(vals); err != nil {
+			r.logger.Error("failed to update validator tracker", "err", err)
+		}
+	}
+}
+
+// isValidatorPeer returns whether the peer is operated by an active validator.
+// The caller must hold the mutex lock.
+func (r *Reactor) isValidatorPeer(ps *PeerState) bool {
+	addr := ps.ValidatorAddress()
+	return len(addr) > 0 && r.validatorAddrs[string(addr)]
+}
+
+// wakeValidatorPeers wakes up the gossip routines of peers operated by active
+// validators, so that new consensus data is sent to them right away instead
+// of on the next gossip tick. Other peers receive it on the next tick.
+func (r *Reactor) wakeValidatorPeers() {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	for _, ps := range r.peers {
+		if r.isValidatorPeer(ps) {
+			ps.wake()
+		}
+	}
+}
+
+// sleepGossip sleeps for the gossip interval, or until the waker is woken. It
+// returns false if the context is canceled.
+func (r *Reactor) sleepGossip(ctx context.Context, timer *time.Timer, waker *tmsync.Waker) bool {
+	timer.Reset(r.state.config.PeerGossipSleepDuration)
+	select {
+	case <-timer.C:
+		return true
+	case <-waker.Sleep():
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
 func (r *Reactor) unsubscribeFromBroadcastEvents() {
 	r.state.evsw.RemoveListener(listenerIDConsensus)
 }
@@ -513,7 +658,18 @@ OUTER_LOOP:
 
 		// Send proposal Block parts?
 		if rs.ProposalBlockParts.HasHeader(prs.ProposalBlockPartSetHeader) {
-			if index, ok := rs.ProposalBlockParts.BitArray().Sub(prs.ProposalBlockParts.Copy()).PickRandom(); ok {
+			// Send a compact block in place of the parts? Parts are withheld
+			// while the peer reconstructs the block, unless it asks for them
+			// or has not done so within a gossip sleep.
+			if r.shouldSendCompactBlock(rs, prs, ps) {
+				if err := r.sendCompactBlock(ctx, rs, ps); err != nil {
+					return
+				}
+				continue OUTER_LOOP
+			}
+
+			index, ok := rs.ProposalBlockParts.BitArray().Sub(prs.ProposalBlockParts.Copy()).PickRandom()
+			if ok && !ps.CompactBlockPending(prs.ProposalBlockPartSetHeader, r.state.config.PeerGossipSleepDuration) {
 				part := rs.ProposalBlockParts.GetPart(index)
 				partProto, err := part.ToProto()
 				if err != nil {
@@ -522,14 +678,20 @@ OUTER_LOOP:
 				}
 
 				logger.Debug("sending block part", "height", prs.Height, "round", prs.Round)
-				if err := r.dataCh.Send(ctx, p2p.Envelope{
+				sendCtx, span := tracer.Start(ctx, "consensus.GossipBlockPart",
+					tracing.HeightRound(rs.Height, rs.Round),
+					trace.WithAttributes(tracing.PeerKey.String(string(ps.peerID)), attribute.Int("index", index)))
+				err = r.dataCh.Send(sendCtx, p2p.Envelope{
 					To: ps.peerID,
 					Message: &tmcons.BlockPart{
 						Height: rs.Height, // this tells peer that this part applies to us
 						Round:  rs.Round,  // this tells peer that this part applies to us
 						Part:   *partProto,
 					},
-				}); err != nil {
+				})
+				tracing.RecordError(span, err)
+				span.End()
+				if err != nil {
 					return
 				}
 
@@ -575,10 +737,7 @@ OUTER_LOOP:
 
 		// if height and round don't match, sleep
 		if (rs.Height != prs.Height) || (rs.Round != prs.Round) {
-			timer.Reset(r.state.config.PeerGossipSleepDuration)
-			select {
-			case <-timer.C:
-			case <-ctx.Done():
+			if !r.sleepGossip(ctx, timer, ps.dataWaker) {
 				return
 			}
 			continue OUTER_LOOP
@@ -635,31 +794,260 @@ OUTER_LOOP:
 		}
 
 		// nothing to do -- sleep
-		timer.Reset(r.state.config.PeerGossipSleepDuration)
-		select {
-		case <-timer.C:
-		case <-ctx.Done():
+		if !r.sleepGossip(ctx, timer, ps.dataWaker) {
 			return
 		}
 		continue OUTER_LOOP
 	}
 }
 
+// shouldSendCompactBlock returns whether to send the peer a compact block of
+// the proposal block, which it has none of the parts of, instead of the parts.
+// Peers which do not support compact blocks are sent the parts.
+func (r *Reactor) shouldSendCompactBlock(rs *cstypes.RoundState, prs *cstypes.PeerRoundState, ps *PeerState) bool {
+	if !r.state.config.CompactBlocks || rs.ProposalBlock == nil || !rs.ProposalBlockParts.IsComplete() {
+		return false
+	}
+	if !ps.HasCapability(types.CapabilityCompactBlocks) {
+		return false
+	}
+	if rs.Height != prs.Height || !prs.ProposalBlockParts.IsEmpty() {
+		return false
+	}
+
+	header := rs.ProposalBlockParts.Header()
+	return !ps.CompactBlockSent(header) && !ps.CompactBlockFallback(header)
+}
+
+// sendCompactBlock sends the peer a compact block of the proposal block. If
+// the compact block is too large to be sent, the parts are sent instead.
+func (r *Reactor) sendCompactBlock(ctx context.Context, rs *cstypes.RoundState, ps *PeerState) error {
+	header := rs.ProposalBlockParts.Header()
+	ps.SetCompactBlockSent(header)
+
+	cb, err := NewCompactBlockMessage(rs.Height, rs.Round, rs.ProposalBlock).ToProto()
+	if err != nil {
+		r.logger.Error("failed to convert compact block to proto", "err", err)
+		ps.SetCompactBlockFallback()
+		return nil
+	}
+	if (&tmcons.Message{Sum: &tmcons.Message_CompactBlock{CompactBlock: cb}}).Size() > maxMsgSize {
+		ps.SetCompactBlockFallback()
+		return nil
+	}
+
+	r.logger.Debug("sending compact block", "peer", ps.peerID, "height", rs.Height, "round", rs.Round)
+	return r.dataCh.Send(ctx, p2p.Envelope{
+		To:      ps.peerID,
+		Message: cb,
+	})
+}
+
+// handleCompactBlock reconstructs the compact block received from the peer
+// from the mempool, or requests the transactions missing from the mempool.
+func (r *Reactor) handleCompactBlock(ctx context.Context, ps *PeerState, msg *CompactBlockMessage) error {
+	rs := r.state.GetRoundState()
+	if rs.Height != msg.Height || (rs.ProposalBlockParts != nil && rs.ProposalBlockParts.IsComplete()) {
+		// we have moved on, or already have the block
+		return nil
+	}
+
+	cb := newCompactBlock(msg, r.txFetcher)
+	missing := cb.missing()
+	if len(missing) == 0 {
+		return r.addCompactBlock(ctx, ps, cb)
+	}
+
+	ps.setCompactBlock(cb)
+	return r.dataCh.Send(ctx, p2p.Envelope{
+		To: ps.peerID,
+		Message: &tmcons.CompactBlockTxsRequest{
+			Height:  msg.Height,
+			Round:   msg.Round,
+			Indexes: missing,
+		},
+	})
+}
+
+// handleCompactBlockTxs adds the transactions received from the peer to the
+// compact block it sent, and reconstructs the block.
+func (r *Reactor) handleCompactBlockTxs(ctx context.Context, ps *PeerState, msg *CompactBlockTxsMessage) error {
+	cb := ps.getCompactBlock()
+	if cb == nil {
+		return nil
+	}
+
+	if !cb.addTxs(msg) || cb.block() == nil {
+		ps.setCompactBlock(nil)
+		return r.requestCompactBlockFallback(ctx, ps, cb.msg.Height, cb.msg.Round)
+	}
+	return r.addCompactBlock(ctx, ps, cb)
+}
+
+// handleCompactBlockTxsRequest sends the peer the transactions it is missing
+// from the compact block sent to it, or the parts of the block if it asks for
+// them or the transactions cannot be sent.
+func (r *Reactor) handleCompactBlockTxsRequest(ctx context.Context, ps *PeerState, msg *CompactBlockTxsRequestMessage) error {
+	rs := r.state.GetRoundState()
+	if rs.Height != msg.Height || rs.ProposalBlock == nil {
+		return nil
+	}
+
+	fallback := func() error {
+		ps.SetCompactBlockFallback()
+		ps.dataWaker.Wake()
+		return nil
+	}
+
+	if msg.Fallback || !ps.CompactBlockSent(rs.ProposalBlockParts.Header()) {
+		return fallback()
+	}
+
+	txs := make([][]byte, len(msg.Indexes))
+	for i, index := range msg.Indexes {
+		if int(index) >= len(rs.ProposalBlock.Txs) {
+			return fallback()
+		}
+		txs[i] = rs.ProposalBlock.Txs[index]
+	}
+
+	cbTxs := &tmcons.CompactBlockTxs{
+		Height:  msg.Height,
+		Round:   msg.Round,
+		Indexes: msg.Indexes,
+		Txs:     txs,
+	}
+	if (&tmcons.Message{Sum: &tmcons.Message_CompactBlockTxs{CompactBlockTxs: cbTxs}}).Size() > maxMsgSize {
+		return fallback()
+	}
+
+	return r.dataCh.Send(ctx, p2p.Envelope{
+		To:      ps.peerID,
+		Message: cbTxs,
+	})
+}
+
+// addCompactBlock splits the block reconstructed from a compact block into
+// parts, which are passed to the consensus state as if received from the peer.
+// The parts are requested from the peer instead if the block does not match
+// the proposal.
+func (r *Reactor) addCompactBlock(ctx context.Context, ps *PeerState, cb *compactBlock) error {
+	ps.setCompactBlock(nil)
+
+	height, round := cb.msg.Height, cb.msg.Round
+	r.state.mtx.RLock()
+	blockParams := r.state.state.ConsensusParams.Block
+	r.state.mtx.RUnlock()
+	parts := cb.block().MakePartSetWithParams(blockParams)
+
+	rs := r.state.GetRoundState()
+	if rs.ProposalBlockParts == nil || !rs.ProposalBlockParts.HasHeader(parts.Header()) {
+		r.logger.Debug("compact block does not match the proposal", "peer", ps.peerID, "height", height, "round", round)
+		return r.requestCompactBlockFallback(ctx, ps, height, round)
+	}
+
+	for i := 0; i < int(parts.Total()); i++ {
+		ps.SetHasProposalBlockPart(height, round, i)
+		msg := &BlockPartMessage{
+			Height: height,
+			Round:  round,
+			Part:   parts.GetPart(i),
+		}
+
+		select {
+		case r.state.peerMsgQueue <- msgInfo{msg, ps.peerID}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// requestCompactBlockFallback asks the peer for the parts of the block it sent
+// a compact block of.
+func (r *Reactor) requestCompactBlockFallback(ctx context.Context, ps *PeerState, height int64, round int32) error {
+	return r.dataCh.Send(ctx, p2p.Envelope{
+		To: ps.peerID,
+		Message: &tmcons.CompactBlockTxsRequest{
+			Height:   height,
+			Round:    round,
+			Fallback: true,
+		},
+	})
+}
+
 // pickSendVote picks a vote and sends it to the peer. It will return true if
-// there is a vote to send and false otherwise.
+// there is a vote to send and false otherwise. Peers supporting vote batches
+// are sent all the votes they are missing for the block ID of the vote.
 func (r *Reactor) pickSendVote(ctx context.Context, ps *PeerState, votes types.VoteSetReader) (bool, error) {
+	if r.state.config.VoteBatches && ps.HasCapability(types.CapabilityVoteBatches) {
+		return r.pickSendVoteBatch(ctx, ps, votes)
+	}
+
 	vote, ok := ps.PickVoteToSend(votes)
 	if !ok {
 		return false, nil
 	}
+	return r.sendVote(ctx, ps, vote)
+}
+
+// pickSendVoteBatch picks the votes the peer is missing for the block ID of a
+// vote, and sends them to the peer as a batch, or as a vote if there is only
+// one. It will return true if there are votes to send and false otherwise.
+func (r *Reactor) pickSendVoteBatch(ctx context.Context, ps *PeerState, votes types.VoteSetReader) (bool, error) {
+	batch, ok := ps.PickVoteBatchToSend(votes)
+	if !ok {
+		return false, nil
+	}
+	if len(batch) == 1 {
+		return r.sendVote(ctx, ps, batch[0])
+	}
+
+	msg, err := NewVoteBatchMessage(batch, votes.Size())
+	if err != nil {
+		return false, err
+	}
+	pb, err := MsgToProto(msg)
+	if err != nil {
+		return false, err
+	}
 
+	r.logger.Debug("sending vote batch message", "ps", ps, "batch", msg)
+	ctx, span := tracer.Start(ctx, "consensus.GossipVoteBatch",
+		tracing.HeightRound(msg.Height, msg.Round),
+		trace.WithAttributes(tracing.PeerKey.String(string(ps.peerID)), attribute.String("type", msg.Type.String()),
+			attribute.Int("votes", len(batch))))
+	defer span.End()
+
+	if err := r.voteCh.Send(ctx, p2p.Envelope{
+		To:      ps.peerID,
+		Message: pb.GetVoteBatch(),
+	}); err != nil {
+		tracing.RecordError(span, err)
+		return false, err
+	}
+
+	for _, vote := range batch {
+		ps.SetHasVote(vote)
+	}
+	return true, nil
+}
+
+// sendVote sends a vote to the peer.
+func (r *Reactor) sendVote(ctx context.Context, ps *PeerState, vote *types.Vote) (bool, error) {
 	r.logger.Debug("sending vote message", "ps", ps, "vote", vote)
+	ctx, span := tracer.Start(ctx, "consensus.GossipVote",
+		tracing.HeightRound(vote.Height, vote.Round),
+		trace.WithAttributes(tracing.PeerKey.String(string(ps.peerID)), attribute.String("type", vote.Type.String())))
+	defer span.End()
+
 	if err := r.voteCh.Send(ctx, p2p.Envelope{
 		To: ps.peerID,
 		Message: &tmcons.Vote{
 			Vote: vote.ToProto(),
 		},
 	}); err != nil {
+		tracing.RecordError(span, err)
 		return false, err
 	}
 
@@ -829,11 +1217,8 @@ OUTER_LOOP:
 			logThrottle = 1
 		}
 
-		timer.Reset(r.state.config.PeerGossipSleepDuration)
-		select {
-		case <-ctx.Done():
+		if !r.sleepGossip(ctx, timer, ps.votesWaker) {
 			return
-		case <-timer.C:
 		}
 		continue OUTER_LOOP
 	}
@@ -1023,6 +1408,8 @@ func (r *Reactor) processPeerUpdate(ctx context.Context, peerUpdate p2p.PeerUpda
 			ps = NewPeerState(r.logger, peerUpdate.NodeID)
 			r.peers[peerUpdate.NodeID] = ps
 		}
+		ps.SetValidatorAddress(peerUpdate.ValidatorAddress)
+		ps.SetCapabilities(peerUpdate.Capabilities)
 
 		if !ps.IsRunning() {
 			// Set the peer state's closer to signal to all spawned goroutines to exit
@@ -1194,6 +1581,13 @@ func (r *Reactor) handleDataMessage(ctx context.Context, envelope *p2p.Envelope,
 			return ctx.Err()
 		}
 
+	case *tmcons.CompactBlock:
+		return r.handleCompactBlock(ctx, ps, msgI.(*CompactBlockMessage))
+	case *tmcons.CompactBlockTxsRequest:
+		return r.handleCompactBlockTxsRequest(ctx, ps, msgI.(*CompactBlockTxsRequestMessage))
+	case *tmcons.CompactBlockTxs:
+		return r.handleCompactBlockTxs(ctx, ps, msgI.(*CompactBlockTxsMessage))
+
 	default:
 		return fmt.Errorf("received unknown message on DataChannel: %T", msg)
 	}
@@ -1237,6 +1631,46 @@ func (r *Reactor) handleVoteMessage(ctx context.Context, envelope *p2p.Envelope,
 		case <-ctx.Done():
 			return ctx.Err()
 		}
+	case *tmcons.VoteBatch:
+		r.state.mtx.RLock()
+		height, valSize, lastCommitSize := r.state.Height, r.state.Validators.Size(), r.state.LastCommit.Size()
+		vals, lastVals := r.state.Validators, r.state.LastValidators
+		r.state.mtx.RUnlock()
+
+		bMsg := msgI.(*VoteBatchMessage)
+
+		// the validators of the votes are looked up by index, which is only
+		// possible for the heights whose validator sets are at hand
+		var batchVals *types.ValidatorSet
+		switch bMsg.Height {
+		case height:
+			batchVals = vals
+		case height - 1:
+			batchVals = lastVals
+		}
+		if batchVals == nil {
+			logger.Debug("ignoring vote batch for another height", "height", bMsg.Height)
+			return nil
+		}
+		votes, err := bMsg.ToVotes(batchVals)
+		if err != nil {
+			return err
+		}
+
+		ps.EnsureVoteBitArrays(height, valSize)
+		ps.EnsureVoteBitArrays(height-1, lastCommitSize)
+		for _, vote := range votes {
+			ps.SetHasVote(vote)
+		}
+
+		// the votes are added to the state at once, for their signatures to
+		// be verified in a batch
+		select {
+		case r.state.peerMsgQueue <- msgInfo{bMsg, envelope.From}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		return nil
 	default:
 		return fmt.Errorf("received unknown message on VoteChannel: %T", msg)
 	}
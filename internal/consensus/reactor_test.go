@@ -96,6 +96,7 @@ func setup(
 			rts.voteSetBitsChannels[nodeID],
 			node.MakePeerUpdates(ctx, t),
 			true,
+			ReactorTxFetcher(assertMempool(state.txNotifier)),
 		)
 
 		reactor.SetEventBus(state.eventBus)
@@ -519,6 +520,116 @@ func TestReactorCreatesBlockWhenEmptyBlocksFalse(t *testing.T) {
 	wg.Wait()
 }
 
+func TestReactorCompactBlocks(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg := configSetup(t)
+
+	n := 4
+	states, cleanup := randConsensusState(
+		ctx,
+		t,
+		cfg,
+		n,
+		"consensus_reactor_test",
+		newMockTickerFunc(true),
+		newKVStore,
+		func(c *config.Config) {
+			c.Consensus.CreateEmptyBlocks = false
+			c.Consensus.CompactBlocks = true
+		},
+	)
+
+	t.Cleanup(cleanup)
+
+	rts := setup(ctx, t, n, states, 100) // buffer must be large enough to not deadlock
+
+	for _, reactor := range rts.reactors {
+		state := reactor.state.GetState()
+		reactor.SwitchToConsensus(ctx, state, false)
+	}
+
+	// one tx is in every mempool, the other one has to be requested from the
+	// proposer if it is the last node
+	for _, state := range states {
+		require.NoError(t, assertMempool(state.txNotifier).CheckTx(ctx, []byte{1, 2, 3}, nil, mempool.TxInfo{}))
+	}
+	require.NoError(t, assertMempool(states[3].txNotifier).CheckTx(ctx, []byte{4, 5, 6}, nil, mempool.TxInfo{}))
+
+	var wg sync.WaitGroup
+	for _, sub := range rts.subs {
+		wg.Add(1)
+
+		// wait till everyone commits the first block with txs
+		go func(s eventbus.Subscription) {
+			defer wg.Done()
+			for {
+				msg, err := s.Next(ctx)
+				if !assert.NoError(t, err) {
+					cancel()
+					return
+				}
+				block := msg.Data().(types.EventDataNewBlock).Block
+				if len(block.Txs) > 0 {
+					assert.Contains(t, block.Txs, types.Tx{1, 2, 3})
+					return
+				}
+			}
+		}(sub)
+	}
+
+	wg.Wait()
+}
+
+func TestReactorVoteBatches(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg := configSetup(t)
+
+	n := 4
+	states, cleanup := randConsensusState(
+		ctx,
+		t,
+		cfg,
+		n,
+		"consensus_reactor_test",
+		newMockTickerFunc(true),
+		newKVStore,
+		func(c *config.Config) {
+			c.Consensus.VoteBatches = true
+		},
+	)
+
+	t.Cleanup(cleanup)
+
+	rts := setup(ctx, t, n, states, 100) // buffer must be large enough to not deadlock
+
+	for _, reactor := range rts.reactors {
+		state := reactor.state.GetState()
+		reactor.SwitchToConsensus(ctx, state, false)
+	}
+
+	var wg sync.WaitGroup
+	for _, sub := range rts.subs {
+		wg.Add(1)
+
+		// wait till everyone commits a few blocks, voting with batches
+		go func(s eventbus.Subscription) {
+			defer wg.Done()
+			for i := 0; i < 3; i++ {
+				if _, err := s.Next(ctx); !assert.NoError(t, err) {
+					cancel()
+					return
+				}
+			}
+		}(sub)
+	}
+
+	wg.Wait()
+}
+
 func TestReactorRecordsVotesAndBlockParts(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"hash/crc32"
 	"io"
+	"math"
 	"reflect"
 	"time"
 
@@ -40,7 +41,8 @@ var crc32c = crc32.MakeTable(crc32.Castagnoli)
 // NOTE: receiveRoutine should not be running.
 func (cs *State) readReplayMessage(ctx context.Context, msg *TimedWALMessage, newStepSub eventbus.Subscription) error {
 	// Skip meta messages which exist for demarcating boundaries.
-	if _, ok := msg.Msg.(EndHeightMessage); ok {
+	switch msg.Msg.(type) {
+	case EndHeightMessage, CheckpointMessage:
 		return nil
 	}
 
@@ -79,6 +81,9 @@ func (cs *State) readReplayMessage(ctx context.Context, msg *TimedWALMessage, ne
 			v := msg.Vote
 			cs.logger.Info("Replay: Vote", "height", v.Height, "round", v.Round, "type", v.Type,
 				"blockID", v.BlockID, "peer", peerID)
+		case *VoteBatchMessage:
+			cs.logger.Info("Replay: VoteBatch", "height", msg.Height, "round", msg.Round, "type", msg.Type,
+				"blockID", msg.BlockID, "peer", peerID)
 		}
 
 		cs.handleMsg(ctx, m)
@@ -137,13 +142,46 @@ func (cs *State) catchupReplay(ctx context.Context, csHeight int64) error {
 	if !found {
 		return fmt.Errorf("cannot replay height %d. WAL does not contain #ENDHEIGHT for %d", csHeight, endHeight)
 	}
-	defer gr.Close()
+	defer func() { gr.Close() }()
 
 	cs.logger.Info("Catchup by replaying consensus messages", "height", csHeight)
 
 	var msg *TimedWALMessage
 	dec := WALDecoder{gr}
 
+	// Restore the checkpoint of the height, if any, and only replay the
+	// messages after its marker.
+	cp, err := cs.loadCheckpoint(csHeight)
+	if err != nil {
+		cs.logger.Error("failed to load consensus checkpoint; replaying the whole height", "err", err)
+	} else if cp != nil {
+		found, err := skipToCheckpoint(&dec, cp.marker)
+		switch {
+		case IsDataCorruptionError(err):
+			cs.logger.Error("data has been corrupted in last height of consensus WAL", "err", err, "height", csHeight)
+			return err
+		case err != nil:
+			return err
+		case found:
+			cs.restoreCheckpoint(ctx, cp)
+			cs.logger.Info("Replay: restored checkpoint", "height", csHeight, "round", cs.Round, "step", cs.Step)
+		default:
+			// the WAL was read to the end, so search it again
+			cs.logger.Error("consensus checkpoint not found in WAL; replaying the whole height", "height", csHeight)
+			if err := gr.Close(); err != nil {
+				return err
+			}
+			gr, found, err = cs.wal.SearchForEndHeight(endHeight, &WALSearchOptions{IgnoreDataCorruptionErrors: true})
+			if err != nil {
+				return err
+			}
+			if !found {
+				return fmt.Errorf("cannot replay height %d. WAL does not contain #ENDHEIGHT for %d", csHeight, endHeight)
+			}
+			dec = WALDecoder{gr}
+		}
+	}
+
 LOOP:
 	for {
 		msg, err = dec.Decode()
@@ -208,9 +246,23 @@ type Handshaker struct {
 	genDoc       *types.GenesisDoc
 	logger       log.Logger
 
+	// if positive, the genesis app state is sent in InitChain chunks of at
+	// most this many bytes
+	appStateChunkSize int64
+
 	nBlocks int // number of blocks applied to the state
 }
 
+// HandshakerOption sets an optional parameter on the Handshaker.
+type HandshakerOption func(*Handshaker)
+
+// HandshakerAppStateChunkSize makes the handshaker send the genesis app state
+// to the application in InitChain requests carrying chunks of at most size
+// bytes, rather than in a single request.
+func HandshakerAppStateChunkSize(size int64) HandshakerOption {
+	return func(h *Handshaker) { h.appStateChunkSize = size }
+}
+
 func NewHandshaker(
 	logger log.Logger,
 	stateStore sm.Store,
@@ -218,9 +270,10 @@ func NewHandshaker(
 	store sm.BlockStore,
 	eventBus types.BlockEventPublisher,
 	genDoc *types.GenesisDoc,
+	options ...HandshakerOption,
 ) *Handshaker {
 
-	return &Handshaker{
+	h := &Handshaker{
 		stateStore:   stateStore,
 		initialState: state,
 		store:        store,
@@ -229,6 +282,10 @@ func NewHandshaker(
 		logger:       logger,
 		nBlocks:      0,
 	}
+	for _, option := range options {
+		option(h)
+	}
+	return h
 }
 
 // NBlocks returns the number of blocks applied to the state.
@@ -314,9 +371,8 @@ func (h *Handshaker) ReplayBlocks(
 			InitialHeight:   h.genDoc.InitialHeight,
 			ConsensusParams: &pbParams,
 			Validators:      nextVals,
-			AppStateBytes:   h.genDoc.AppState,
 		}
-		res, err := proxyApp.Consensus().InitChainSync(ctx, req)
+		res, err := h.initChain(ctx, proxyApp.Consensus(), req)
 		if err != nil {
 			return nil, err
 		}
@@ -434,6 +490,58 @@ func (h *Handshaker) ReplayBlocks(
 		appBlockHeight, storeBlockHeight, stateBlockHeight))
 }
 
+// initChain sends the genesis app state to the application with InitChain,
+// in chunks if appStateChunkSize is set, and returns the response to the last
+// request.
+func (h *Handshaker) initChain(
+	ctx context.Context,
+	appClient proxy.AppConnConsensus,
+	req abci.RequestInitChain,
+) (*abci.ResponseInitChain, error) {
+	r, size, err := h.genDoc.AppStateReader()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	if h.appStateChunkSize <= 0 {
+		if req.AppStateBytes, err = io.ReadAll(r); err != nil {
+			return nil, fmt.Errorf("error reading genesis app state: %w", err)
+		}
+		return appClient.InitChainSync(ctx, req)
+	}
+
+	chunks := (size + h.appStateChunkSize - 1) / h.appStateChunkSize
+	if chunks == 0 {
+		chunks = 1
+	}
+	if chunks > math.MaxUint32 {
+		return nil, fmt.Errorf("genesis app state of %d bytes has too many chunks of %d bytes",
+			size, h.appStateChunkSize)
+	}
+	h.logger.Info("Sending genesis app state in chunks", "size", size, "chunks", chunks)
+
+	var res *abci.ResponseInitChain
+	for i := int64(0); i < chunks; i++ {
+		chunkSize := size - i*h.appStateChunkSize
+		if chunkSize > h.appStateChunkSize {
+			chunkSize = h.appStateChunkSize
+		}
+		// The application may retain the chunk, so each is read into its own buffer.
+		chunk := make([]byte, chunkSize)
+		if _, err := io.ReadFull(r, chunk); err != nil {
+			return nil, fmt.Errorf("error reading genesis app state: %w", err)
+		}
+		req.AppStateBytes = chunk
+		req.AppStateChunk = uint32(i)
+		req.AppStateChunks = uint32(chunks)
+		if res, err = appClient.InitChainSync(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
 func (h *Handshaker) replayBlocks(
 	ctx context.Context,
 	state sm.State,
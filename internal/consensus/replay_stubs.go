@@ -2,6 +2,7 @@ package consensus
 
 import (
 	"context"
+	"time"
 
 	abciclient "github.com/tendermint/tendermint/abci/client"
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -26,7 +27,8 @@ func (emptyMempool) CheckTx(_ context.Context, _ types.Tx, _ func(*abci.Response
 	return nil
 }
 func (emptyMempool) RemoveTxByKey(txKey types.TxKey) error   { return nil }
-func (emptyMempool) ReapMaxBytesMaxGas(_, _ int64) types.Txs { return types.Txs{} }
+func (emptyMempool) GetTxByKey(types.TxKey) (types.Tx, bool) { return nil, false }
+func (emptyMempool) ReapMaxBytesMaxGas(_, _, _ int64) types.Txs { return types.Txs{} }
 func (emptyMempool) ReapMaxTxs(n int) types.Txs              { return types.Txs{} }
 func (emptyMempool) Update(
 	_ context.Context,
@@ -44,6 +46,11 @@ func (emptyMempool) TxsAvailable() <-chan struct{}          { return make(chan s
 func (emptyMempool) EnableTxsAvailable()                    {}
 func (emptyMempool) SizeBytes() int64                       { return 0 }
 
+func (emptyMempool) RemoveTxBySender(string) (types.TxKey, error) {
+	return types.TxKey{}, nil
+}
+func (emptyMempool) RejectTxs(time.Duration, ...types.TxKey) {}
+
 func (emptyMempool) TxsFront() *clist.CElement    { return nil }
 func (emptyMempool) TxsWaitChan() <-chan struct{} { return nil }
 
@@ -78,22 +85,25 @@ type mockProxyApp struct {
 	abci.BaseApplication
 
 	appHash       []byte
-	txCount       int
 	abciResponses *tmstate.ABCIResponses
 }
 
-func (mock *mockProxyApp) DeliverTx(req abci.RequestDeliverTx) abci.ResponseDeliverTx {
-	r := mock.abciResponses.DeliverTxs[mock.txCount]
-	mock.txCount++
-	if r == nil {
-		return abci.ResponseDeliverTx{}
+func (mock *mockProxyApp) FinalizeBlock(req abci.RequestFinalizeBlock) abci.ResponseFinalizeBlock {
+	txs := make([]*abci.ResponseDeliverTx, len(req.Txs))
+	for i := range req.Txs {
+		r := mock.abciResponses.DeliverTxs[i]
+		if r == nil {
+			r = &abci.ResponseDeliverTx{}
+		}
+		txs[i] = r
 	}
-	return *r
-}
-
-func (mock *mockProxyApp) EndBlock(req abci.RequestEndBlock) abci.ResponseEndBlock {
-	mock.txCount = 0
-	return *mock.abciResponses.EndBlock
+	res := abci.ResponseFinalizeBlock{Txs: txs}
+	if endBlock := mock.abciResponses.EndBlock; endBlock != nil {
+		res.Events = endBlock.Events
+		res.ValidatorUpdates = endBlock.ValidatorUpdates
+		res.ConsensusParamUpdates = endBlock.ConsensusParamUpdates
+	}
+	return res
 }
 
 func (mock *mockProxyApp) Commit() abci.ResponseCommit {
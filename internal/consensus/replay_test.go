@@ -381,7 +381,7 @@ func setupSimulator(ctx context.Context, t *testing.T) *simulatorTestSuite {
 	newValidatorTx1 := kvstore.MakeValSetChangeTx(valPubKey1ABCI, testMinPower)
 	err = assertMempool(css[0].txNotifier).CheckTx(ctx, newValidatorTx1, nil, mempool.TxInfo{})
 	assert.Nil(t, err)
-	propBlock, _ := css[0].createProposalBlock() // changeProposer(t, cs1, vs2)
+	propBlock, _ := css[0].createProposalBlock(ctx) // changeProposer(t, cs1, vs2)
 	propBlockParts := propBlock.MakePartSet(partSize)
 	blockID := types.BlockID{Hash: propBlock.Hash(), PartSetHeader: propBlockParts.Header()}
 
@@ -413,7 +413,7 @@ func setupSimulator(ctx context.Context, t *testing.T) *simulatorTestSuite {
 	updateValidatorTx1 := kvstore.MakeValSetChangeTx(updatePubKey1ABCI, 25)
 	err = assertMempool(css[0].txNotifier).CheckTx(ctx, updateValidatorTx1, nil, mempool.TxInfo{})
 	assert.Nil(t, err)
-	propBlock, _ = css[0].createProposalBlock() // changeProposer(t, cs1, vs2)
+	propBlock, _ = css[0].createProposalBlock(ctx) // changeProposer(t, cs1, vs2)
 	propBlockParts = propBlock.MakePartSet(partSize)
 	blockID = types.BlockID{Hash: propBlock.Hash(), PartSetHeader: propBlockParts.Header()}
 
@@ -452,7 +452,7 @@ func setupSimulator(ctx context.Context, t *testing.T) *simulatorTestSuite {
 	newValidatorTx3 := kvstore.MakeValSetChangeTx(newVal3ABCI, testMinPower)
 	err = assertMempool(css[0].txNotifier).CheckTx(ctx, newValidatorTx3, nil, mempool.TxInfo{})
 	assert.Nil(t, err)
-	propBlock, _ = css[0].createProposalBlock() // changeProposer(t, cs1, vs2)
+	propBlock, _ = css[0].createProposalBlock(ctx) // changeProposer(t, cs1, vs2)
 	propBlockParts = propBlock.MakePartSet(partSize)
 	blockID = types.BlockID{Hash: propBlock.Hash(), PartSetHeader: propBlockParts.Header()}
 	newVss := make([]*validatorStub, nVals+1)
@@ -531,7 +531,7 @@ func setupSimulator(ctx context.Context, t *testing.T) *simulatorTestSuite {
 	removeValidatorTx3 := kvstore.MakeValSetChangeTx(newVal3ABCI, 0)
 	err = assertMempool(css[0].txNotifier).CheckTx(ctx, removeValidatorTx3, nil, mempool.TxInfo{})
 	assert.Nil(t, err)
-	propBlock, _ = css[0].createProposalBlock() // changeProposer(t, cs1, vs2)
+	propBlock, _ = css[0].createProposalBlock(ctx) // changeProposer(t, cs1, vs2)
 	propBlockParts = propBlock.MakePartSet(partSize)
 	blockID = types.BlockID{Hash: propBlock.Hash(), PartSetHeader: propBlockParts.Header()}
 	newVss = make([]*validatorStub, nVals+3)
@@ -646,7 +646,6 @@ func TestMockProxyApp(t *testing.T) {
 
 	logger := log.TestingLogger()
 	var validTxs, invalidTxs = 0, 0
-	txIndex := 0
 
 	assert.NotPanics(t, func() {
 		abciResWithEmptyDeliverTx := new(tmstate.ABCIResponses)
@@ -664,30 +663,20 @@ func TestMockProxyApp(t *testing.T) {
 
 		mock := newMockProxyApp(ctx, logger, []byte("mock_hash"), loadedAbciRes)
 
-		abciRes := new(tmstate.ABCIResponses)
-		abciRes.DeliverTxs = make([]*abci.ResponseDeliverTx, len(loadedAbciRes.DeliverTxs))
-		// Execute transactions and get hash.
-		proxyCb := func(req *abci.Request, res *abci.Response) {
-			if r, ok := res.Value.(*abci.Response_DeliverTx); ok {
-				// TODO: make use of res.Log
-				// TODO: make use of this info
-				// Blocks may include invalid txs.
-				txRes := r.DeliverTx
-				if txRes.Code == abci.CodeTypeOK {
-					validTxs++
-				} else {
-					logger.Debug("Invalid tx", "code", txRes.Code, "log", txRes.Log)
-					invalidTxs++
-				}
-				abciRes.DeliverTxs[txIndex] = txRes
-				txIndex++
+		someTx := []byte("tx")
+		res, err := mock.FinalizeBlockSync(ctx, abci.RequestFinalizeBlock{Txs: [][]byte{someTx}})
+		require.NoError(t, err)
+		// TODO: make use of res.Log
+		// TODO: make use of this info
+		// Blocks may include invalid txs.
+		for _, txRes := range res.Txs {
+			if txRes.Code == abci.CodeTypeOK {
+				validTxs++
+			} else {
+				logger.Debug("Invalid tx", "code", txRes.Code, "log", txRes.Log)
+				invalidTxs++
 			}
 		}
-		mock.SetResponseCallback(proxyCb)
-
-		someTx := []byte("tx")
-		_, err = mock.DeliverTxAsync(ctx, abci.RequestDeliverTx{Tx: someTx})
-		assert.NoError(t, err)
 	})
 	assert.True(t, validTxs == 1)
 	assert.True(t, invalidTxs == 0)
@@ -1253,6 +1242,7 @@ func (bs *mockBlockStore) LoadBlockMeta(height int64) *types.BlockMeta {
 func (bs *mockBlockStore) LoadBlockPart(height int64, index int) *types.Part { return nil }
 func (bs *mockBlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, seenCommit *types.Commit) {
 }
+func (bs *mockBlockStore) Sync() error { return nil }
 func (bs *mockBlockStore) LoadBlockCommit(height int64) *types.Commit {
 	return bs.commits[height-1]
 }
@@ -1320,6 +1310,75 @@ func TestHandshakeUpdatesValidators(t *testing.T) {
 	assert.Equal(t, newValAddr, expectValAddr)
 }
 
+func TestHandshakeSendsAppStateInChunks(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	val, _ := factory.RandValidator(true, 10)
+	vals := types.NewValidatorSet([]*types.Validator{val})
+	app := &chunkedInitChainApp{vals: types.TM2PB.ValidatorUpdates(vals)}
+	clientCreator := abciclient.NewLocalCreator(app)
+
+	cfg, err := ResetConfig("handshake_test_")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(cfg.RootDir) })
+
+	privVal, err := privval.LoadFilePV(cfg.PrivValidator.KeyFile(), cfg.PrivValidator.StateFile())
+	require.NoError(t, err)
+	pubKey, err := privVal.GetPubKey(ctx)
+	require.NoError(t, err)
+	stateDB, state, store := stateAndStore(cfg, pubKey, 0x0)
+	stateStore := sm.NewStore(stateDB)
+
+	// stream a genesis doc with an app state of several chunks
+	genDoc, err := sm.MakeGenesisDocFromFile(cfg.GenesisFile())
+	require.NoError(t, err)
+	appState := []byte(`{"accounts":["alice","bob","carol","dave"]}`)
+	genDoc.AppState = appState
+	require.NoError(t, genDoc.SaveAs(cfg.GenesisFile()))
+	genDoc, err = types.StreamGenesisDocFromFile(cfg.GenesisFile())
+	require.NoError(t, err)
+
+	logger := log.TestingLogger()
+	handshaker := NewHandshaker(logger, stateStore, state, store, eventbus.NopEventBus{}, genDoc,
+		HandshakerAppStateChunkSize(10))
+	proxyApp := proxy.NewAppConns(clientCreator, logger, proxy.NopMetrics())
+	require.NoError(t, proxyApp.Start(ctx))
+	require.NoError(t, handshaker.Handshake(ctx, proxyApp))
+
+	assert.Equal(t, (len(app.appState)+9)/10, app.chunks)
+	assert.Greater(t, app.chunks, 1)
+	assert.JSONEq(t, string(appState), string(app.appState))
+
+	// the response to the last chunk is used
+	state, err = stateStore.Load()
+	require.NoError(t, err)
+	assert.Equal(t, val.Address, state.Validators.Validators[0].Address)
+}
+
+// assembles the app state sent in InitChain chunks, returning the vals on the
+// last chunk
+type chunkedInitChainApp struct {
+	abci.BaseApplication
+	vals     []abci.ValidatorUpdate
+	chunks   int
+	appState []byte
+}
+
+func (app *chunkedInitChainApp) InitChain(req abci.RequestInitChain) abci.ResponseInitChain {
+	if int(req.AppStateChunk) != app.chunks {
+		panic(fmt.Sprintf("expected chunk %d, got %d", app.chunks, req.AppStateChunk))
+	}
+	app.chunks++
+	app.appState = append(app.appState, req.AppStateBytes...)
+	if req.AppStateChunk < req.AppStateChunks-1 {
+		return abci.ResponseInitChain{}
+	}
+	return abci.ResponseInitChain{
+		Validators: app.vals,
+	}
+}
+
 // returns the vals on InitChain
 type initChainApp struct {
 	abci.BaseApplication
@@ -0,0 +1,144 @@
+package consensus
+
+import (
+	"context"
+	"time"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+// SimulatedNode drives a State synchronously, without its receive and timeout
+// routines, for deterministic simulations: the State only processes the
+// messages and timeouts the simulation hands it, on the simulation's
+// goroutine, and its timeouts fire on the simulation's virtual clock.
+type SimulatedNode struct {
+	cs     *State
+	ticker *virtualTicker
+}
+
+// NewSimulatedNode returns a SimulatedNode driving cs, which must use the
+// virtual clock of the simulation (see StateClock) and must not be started.
+func NewSimulatedNode(cs *State) *SimulatedNode {
+	ticker := &virtualTicker{now: cs.now}
+	cs.SetTimeoutTicker(ticker)
+	return &SimulatedNode{cs: cs, ticker: ticker}
+}
+
+// State returns the driven State.
+func (n *SimulatedNode) State() *State {
+	return n.cs
+}
+
+// Start schedules the first round of the node, as State.Start does.
+func (n *SimulatedNode) Start() {
+	n.cs.scheduleRound0(n.cs.GetRoundState())
+}
+
+// NextTimeout returns the time the pending timeout of the node fires at, and
+// false if there is none.
+func (n *SimulatedNode) NextTimeout() (time.Time, bool) {
+	if !n.ticker.pending {
+		return time.Time{}, false
+	}
+	return n.ticker.firesAt, true
+}
+
+// FireTimeout processes the pending timeout of the node, and returns the
+// proposals, block parts and votes the node sent as a result.
+func (n *SimulatedNode) FireTimeout(ctx context.Context) []Message {
+	if !n.ticker.pending {
+		return nil
+	}
+	n.ticker.pending = false
+	n.cs.handleTimeout(ctx, n.ticker.ti, *n.cs.GetRoundState())
+	return n.process(ctx)
+}
+
+// Receive processes msg received from peerID, and returns the proposals, block
+// parts and votes the node sent as a result.
+func (n *SimulatedNode) Receive(ctx context.Context, msg Message, peerID types.NodeID) []Message {
+	n.cs.handleMsg(ctx, msgInfo{msg, peerID})
+	return n.process(ctx)
+}
+
+// CommitMessages returns the precommits and block parts of the block
+// committed at height, for a node which missed them to commit it, or nil if
+// the block isn't committed.
+func (n *SimulatedNode) CommitMessages(height int64) []Message {
+	meta := n.cs.blockStore.LoadBlockMeta(height)
+	commit := n.cs.LoadCommit(height)
+	if meta == nil || commit == nil {
+		return nil
+	}
+
+	var msgs []Message
+	for idx, sig := range commit.Signatures {
+		if sig.Absent() {
+			continue
+		}
+		msgs = append(msgs, &VoteMessage{commit.GetVote(int32(idx))})
+	}
+	for idx := 0; idx < int(meta.BlockID.PartSetHeader.Total); idx++ {
+		part := n.cs.blockStore.LoadBlockPart(height, idx)
+		if part == nil {
+			return nil
+		}
+		msgs = append(msgs, &BlockPartMessage{Height: height, Round: commit.Round, Part: part})
+	}
+	return msgs
+}
+
+// process processes the messages the node sent itself until there are none
+// left, and returns them.
+func (n *SimulatedNode) process(ctx context.Context) []Message {
+	var sent []Message
+	for {
+		// the reactor isn't running to read the statistics
+		for len(n.cs.statsMsgQueue) > 0 {
+			<-n.cs.statsMsgQueue
+		}
+
+		select {
+		case mi := <-n.cs.internalMsgQueue:
+			n.cs.handleMsg(ctx, mi)
+			sent = append(sent, mi.Msg)
+		case <-n.cs.txNotifier.TxsAvailable():
+			n.cs.handleTxsAvailable(ctx)
+		default:
+			return sent
+		}
+	}
+}
+
+// virtualTicker is a TimeoutTicker on a virtual clock, firing its timeouts
+// when the simulation tells it to. Like timeoutTicker, it only schedules
+// timeouts for a later height, round or step than the last one.
+type virtualTicker struct {
+	now     func() time.Time
+	ti      timeoutInfo
+	pending bool
+	firesAt time.Time
+}
+
+var _ TimeoutTicker = (*virtualTicker)(nil)
+
+func (t *virtualTicker) Start(context.Context) error { return nil }
+func (t *virtualTicker) Stop() error                 { return nil }
+func (t *virtualTicker) IsRunning() bool             { return true }
+func (t *virtualTicker) Chan() <-chan timeoutInfo    { return nil }
+
+func (t *virtualTicker) ScheduleTimeout(ti timeoutInfo) {
+	if ti.Height < t.ti.Height {
+		return
+	} else if ti.Height == t.ti.Height {
+		if ti.Round < t.ti.Round {
+			return
+		} else if ti.Round == t.ti.Round && t.ti.Step > 0 && ti.Step <= t.ti.Step {
+			return
+		}
+	}
+
+	t.ti = ti
+	t.pending = true
+	t.firesAt = t.now().Add(ti.Duration)
+}
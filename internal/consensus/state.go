@@ -12,12 +12,16 @@ import (
 	"time"
 
 	"github.com/gogo/protobuf/proto"
+	dbm "github.com/tendermint/tm-db"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/crypto"
 	cstypes "github.com/tendermint/tendermint/internal/consensus/types"
 	"github.com/tendermint/tendermint/internal/eventbus"
 	"github.com/tendermint/tendermint/internal/libs/fail"
+	"github.com/tendermint/tendermint/internal/libs/tracing"
 	sm "github.com/tendermint/tendermint/internal/state"
 	tmevents "github.com/tendermint/tendermint/libs/events"
 	tmjson "github.com/tendermint/tendermint/libs/json"
@@ -44,6 +48,8 @@ var (
 
 var msgQueueSize = 1000
 
+var tracer = tracing.Tracer("consensus")
+
 // msgs from the reactor which may update the state
 type msgInfo struct {
 	Msg    Message      `json:"msg"`
@@ -107,12 +113,22 @@ type State struct {
 	// to avoid extra requests to HSM
 	privValidatorPubKey crypto.PubKey
 
+	// closed once the block being committed is finalized, from the commit
+	// step until finalizeCommit completes; nil if no block is being
+	// committed
+	commitMtx  sync.Mutex
+	commitDone chan struct{}
+
 	// state changes may be triggered by: msgs from peers,
 	// msgs from ourself, or by timeouts
 	peerMsgQueue     chan msgInfo
 	internalMsgQueue chan msgInfo
 	timeoutTicker    TimeoutTicker
 
+	// pings of the receive routine, each answered by closing the channel
+	// received, showing the routine is responsive
+	pingQueue chan chan struct{}
+
 	// information about about added votes and block parts are written on this channel
 	// so statistics can be computed by reactor
 	statsMsgQueue chan msgInfo
@@ -121,15 +137,42 @@ type State struct {
 	// and to notify external subscribers, eg. through a websocket
 	eventBus *eventbus.EventBus
 
+	// spans the current height, from its first step until its block is
+	// committed, recording each step as an event
+	heightSpan trace.Span
+
 	// a Write-Ahead Log ensures we can recover from any kind of crash
 	// and helps us avoid signing conflicting votes
 	wal          WAL
 	replayMode   bool // so we don't log signing errors during replay
 	doWALCatchup bool // determines if we even try to do the catchup
 
+	// the consensus state of the height is checkpointed to checkpointDB, if
+	// set, so crash recovery only replays the WAL after the last checkpoint
+	checkpointDB   dbm.DB
+	lastCheckpoint time.Time
+
+	// the short step timeouts used while skipping the WAL on a single
+	// validator network, only read by the step timeouts under mtx; nil if
+	// the WAL is written
+	skipWALTimeouts *config.ConsensusConfig
+
 	// for tests where we want to limit the number of transitions the state makes
 	nSteps int
 
+	// the recent step transitions and the last scheduled timeout, for debugging
+	debugLog debugLog
+
+	// the step last transitioned to, for the consensus firehose events
+	firehose firehose
+
+	// the recent round durations timeouts adapt to, if enabled
+	adaptiveTimeouts adaptiveTimeouts
+
+	// the clock of the state machine, which simulations replace by a virtual
+	// clock
+	now func() time.Time
+
 	// some functions can be overwritten for testing
 	decideProposal func(ctx context.Context, height int64, round int32)
 	doPrevote      func(ctx context.Context, height int64, round int32)
@@ -147,6 +190,9 @@ type State struct {
 
 	// wait the channel event happening for shutting down the state gracefully
 	onStopCh chan *cstypes.RoundState
+
+	// called with the recovered panic when consensus fails; nil if not set
+	onFailure func(interface{})
 }
 
 // StateOption sets an optional parameter on the State.
@@ -172,6 +218,7 @@ func NewState(
 		txNotifier:       txNotifier,
 		peerMsgQueue:     make(chan msgInfo, msgQueueSize),
 		internalMsgQueue: make(chan msgInfo, msgQueueSize),
+		pingQueue:        make(chan chan struct{}),
 		timeoutTicker:    NewTimeoutTicker(logger),
 		statsMsgQueue:    make(chan msgInfo, msgQueueSize),
 		done:             make(chan struct{}),
@@ -181,6 +228,8 @@ func NewState(
 		evsw:             tmevents.NewEventSwitch(logger),
 		metrics:          NopMetrics(),
 		onStopCh:         make(chan *cstypes.RoundState),
+		heightSpan:       trace.SpanFromContext(context.Background()),
+		now:              tmtime.Now,
 	}
 
 	// set function defaults (may be overwritten before calling Start)
@@ -188,6 +237,11 @@ func NewState(
 	cs.doPrevote = cs.defaultDoPrevote
 	cs.setProposal = cs.defaultSetProposal
 
+	cs.BaseService = *service.NewBaseService(logger, "State", cs)
+	for _, option := range options {
+		option(cs)
+	}
+
 	// We have no votes, so reconstruct LastCommit from SeenCommit.
 	if state.LastBlockHeight > 0 {
 		cs.reconstructLastCommit(state)
@@ -197,11 +251,6 @@ func NewState(
 
 	// NOTE: we do not call scheduleRound0 yet, we do that upon Start()
 
-	cs.BaseService = *service.NewBaseService(logger, "State", cs)
-	for _, option := range options {
-		option(cs)
-	}
-
 	return cs
 }
 
@@ -211,11 +260,24 @@ func (cs *State) SetEventBus(b *eventbus.EventBus) {
 	cs.blockExec.SetEventBus(b)
 }
 
+// SetFailureHandler sets the function called with the recovered panic when
+// consensus fails, before the state machine halts. It must not block, and is
+// set before the state is started.
+func (cs *State) SetFailureHandler(onFailure func(interface{})) {
+	cs.onFailure = onFailure
+}
+
 // StateMetrics sets the metrics.
 func StateMetrics(metrics *Metrics) StateOption {
 	return func(cs *State) { cs.metrics = metrics }
 }
 
+// StateClock sets the clock of the state machine, which times the steps and
+// timestamps the votes. It defaults to tmtime.Now.
+func StateClock(now func() time.Time) StateOption {
+	return func(cs *State) { cs.now = now }
+}
+
 // String returns a string.
 func (cs *State) String() string {
 	// better not to access shared variables
@@ -237,6 +299,27 @@ func (cs *State) GetLastHeight() int64 {
 	return cs.RoundState.Height - 1
 }
 
+// Ping waits for the receive routine to answer a ping, in between handling
+// messages and timeouts, to check that the state machine is responsive. It
+// returns an error if ctx is done first, or if the routine has exited.
+func (cs *State) Ping(ctx context.Context) error {
+	done := make(chan struct{})
+	select {
+	case cs.pingQueue <- done:
+	case <-cs.done:
+		return errors.New("consensus state machine has stopped")
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // GetRoundState returns a shallow copy of the internal consensus state.
 func (cs *State) GetRoundState() *cstypes.RoundState {
 	cs.mtx.RLock()
@@ -333,9 +416,16 @@ func (cs *State) LoadCommit(height int64) *types.Commit {
 // OnStart loads the latest state via the WAL, and starts the timeout and
 // receive routines.
 func (cs *State) OnStart(ctx context.Context) error {
-	// We may set the WAL in testing before calling Start, so only OpenWAL if its
-	// still the nilWAL.
-	if _, ok := cs.wal.(nilWAL); ok {
+	if cs.config.SkipWAL {
+		if n := cs.Validators.Size(); n > 1 {
+			return fmt.Errorf("skip-wal is only allowed with a single validator, but there are %d validators", n)
+		}
+		cs.logger.Info("skipping the consensus WAL on a single validator network; use for development only")
+		cs.skipWALTimeouts = cs.config.SkipWALConsensusConfig()
+		cs.doWALCatchup = false
+	} else if _, ok := cs.wal.(nilWAL); ok {
+		// We may set the WAL in testing before calling Start, so only OpenWAL if its
+		// still the nilWAL.
 		if err := cs.loadWalFile(ctx); err != nil {
 			return err
 		}
@@ -479,6 +569,46 @@ func (cs *State) OnStop() {
 	// WAL is stopped in receiveRoutine.
 }
 
+// WaitForCommit waits for the block being committed, if any, to be finalized.
+// It reports false if ctx is done first.
+func (cs *State) WaitForCommit(ctx context.Context) bool {
+	cs.commitMtx.Lock()
+	done := cs.commitDone
+	cs.commitMtx.Unlock()
+
+	if done == nil {
+		return true
+	}
+	select {
+	case <-done:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// beginCommit records that a block is being committed, until endCommit.
+func (cs *State) beginCommit() {
+	cs.commitMtx.Lock()
+	defer cs.commitMtx.Unlock()
+
+	if cs.commitDone == nil {
+		cs.commitDone = make(chan struct{})
+	}
+}
+
+// endCommit records that the block being committed is finalized, releasing
+// WaitForCommit.
+func (cs *State) endCommit() {
+	cs.commitMtx.Lock()
+	defer cs.commitMtx.Unlock()
+
+	if cs.commitDone != nil {
+		close(cs.commitDone)
+		cs.commitDone = nil
+	}
+}
+
 // Wait waits for the the main routine to return.
 // NOTE: be sure to Stop() the event switch and drain
 // any event channels or this may deadlock
@@ -495,6 +625,12 @@ func (cs *State) OpenWAL(ctx context.Context, walFile string) (WAL, error) {
 		return nil, err
 	}
 
+	mode, interval, err := cs.config.WALFsync()
+	if err != nil {
+		return nil, err
+	}
+	wal.SetFsyncMode(mode, interval)
+
 	if err := wal.Start(ctx); err != nil {
 		cs.logger.Error("failed to start WAL", "err", err)
 		return nil, err
@@ -605,21 +741,25 @@ func (cs *State) updateHeight(height int64) {
 	cs.Height = height
 }
 
-func (cs *State) updateRoundStep(round int32, step cstypes.RoundStepType) {
+func (cs *State) updateRoundStep(ctx context.Context, round int32, step cstypes.RoundStepType) {
 	cs.Round = round
 	cs.Step = step
+	cs.debugLog.recordTransition(cs.now(), cs.Height, round, step)
+	cs.publishStepEvent(ctx)
 }
 
 // enterNewRound(height, 0) at cs.StartTime.
 func (cs *State) scheduleRound0(rs *cstypes.RoundState) {
-	// cs.logger.Info("scheduleRound0", "now", tmtime.Now(), "startTime", cs.StartTime)
-	sleepDuration := rs.StartTime.Sub(tmtime.Now())
+	// cs.logger.Info("scheduleRound0", "now", cs.now(), "startTime", cs.StartTime)
+	sleepDuration := rs.StartTime.Sub(cs.now())
 	cs.scheduleTimeout(sleepDuration, rs.Height, 0, cstypes.RoundStepNewHeight)
 }
 
 // Attempt to schedule a timeout (by sending timeoutInfo on the tickChan)
 func (cs *State) scheduleTimeout(duration time.Duration, height int64, round int32, step cstypes.RoundStepType) {
-	cs.timeoutTicker.ScheduleTimeout(timeoutInfo{duration, height, round, step})
+	ti := timeoutInfo{duration, height, round, step}
+	cs.debugLog.recordTimeout(cs.now(), ti)
+	cs.timeoutTicker.ScheduleTimeout(ti)
 }
 
 // send a msg into the receiveRoutine regarding our own proposal, block part, or vote
@@ -740,7 +880,7 @@ func (cs *State) updateToState(ctx context.Context, state sm.State) {
 
 	// RoundState fields
 	cs.updateHeight(height)
-	cs.updateRoundStep(0, cstypes.RoundStepNewHeight)
+	cs.updateRoundStep(ctx, 0, cstypes.RoundStepNewHeight)
 
 	if cs.CommitTime.IsZero() {
 		// "Now" makes it easier to sync up dev nodes.
@@ -748,12 +888,13 @@ func (cs *State) updateToState(ctx context.Context, state sm.State) {
 		// to be gathered for the first block.
 		// And alternative solution that relies on clocks:
 		// cs.StartTime = state.LastBlockTime.Add(timeoutCommit)
-		cs.StartTime = cs.config.Commit(tmtime.Now())
+		cs.StartTime = cs.now().Add(cs.commitTimeout())
 	} else {
-		cs.StartTime = cs.config.Commit(cs.CommitTime)
+		cs.StartTime = cs.CommitTime.Add(cs.commitTimeout())
 	}
 
 	cs.Validators = validators
+	cs.Proposer = cs.selectProposer(state, height, 0)
 	cs.Proposal = nil
 	cs.ProposalBlock = nil
 	cs.ProposalBlockParts = nil
@@ -770,6 +911,10 @@ func (cs *State) updateToState(ctx context.Context, state sm.State) {
 
 	cs.state = state
 
+	// Trace the new height. Its span ends once the block is committed.
+	cs.heightSpan.End()
+	_, cs.heightSpan = tracer.Start(ctx, "consensus.Height", tracing.Height(height), trace.WithNewRoot())
+
 	// Finally, broadcast RoundState
 	cs.newStep(ctx)
 }
@@ -779,9 +924,12 @@ func (cs *State) newStep(ctx context.Context) {
 	if err := cs.wal.Write(rs); err != nil {
 		cs.logger.Error("failed writing to WAL", "err", err)
 	}
+	cs.checkpoint()
 
 	cs.nSteps++
 
+	cs.heightSpan.AddEvent(cs.Step.String(), trace.WithAttributes(tracing.RoundKey.Int64(int64(cs.Round))))
+
 	// newStep is called by updateToState in NewState before the eventBus is set!
 	if cs.eventBus != nil {
 		if err := cs.eventBus.PublishEventNewRoundStep(ctx, rs); err != nil {
@@ -820,6 +968,9 @@ func (cs *State) receiveRoutine(ctx context.Context, maxSteps int) {
 	defer func() {
 		if r := recover(); r != nil {
 			cs.logger.Error("CONSENSUS FAILURE!!!", "err", r, "stack", string(debug.Stack()))
+			if cs.onFailure != nil {
+				cs.onFailure(r)
+			}
 			// stop gracefully
 			//
 			// NOTE: We most probably shouldn't be running any further when there is
@@ -848,6 +999,9 @@ func (cs *State) receiveRoutine(ctx context.Context, maxSteps int) {
 		case <-cs.txNotifier.TxsAvailable():
 			cs.handleTxsAvailable(ctx)
 
+		case done := <-cs.pingQueue:
+			close(done)
+
 		case mi = <-cs.peerMsgQueue:
 			if err := cs.wal.Write(mi); err != nil {
 				cs.logger.Error("failed writing to WAL", "err", err)
@@ -916,6 +1070,7 @@ func (cs *State) handleMsg(ctx context.Context, mi msgInfo) {
 	case *BlockPartMessage:
 		// if the proposal is complete, we'll enterPrevote or tryFinalizeCommit
 		added, err = cs.addProposalBlockPart(ctx, msg, peerID)
+		cs.publishBlockPartEvent(ctx, msg, peerID, added, err)
 		if added {
 			select {
 			case cs.statsMsgQueue <- mi:
@@ -938,6 +1093,7 @@ func (cs *State) handleMsg(ctx context.Context, mi msgInfo) {
 		// attempt to add the vote and dupeout the validator if its a duplicate signature
 		// if the vote gives us a 2/3-any or 2/3-one, we transition
 		added, err = cs.tryAddVote(ctx, msg.Vote, peerID)
+		cs.publishVoteEvent(ctx, msg.Vote, peerID, added, err)
 		if added {
 			select {
 			case cs.statsMsgQueue <- mi:
@@ -961,6 +1117,42 @@ func (cs *State) handleMsg(ctx context.Context, mi msgInfo) {
 		// the peer is sending us CatchupCommit precommits.
 		// We could make note of this and help filter in broadcastHasVoteMessage().
 
+	case *VoteBatchMessage:
+		// the votes of the batch are added at once, verifying their
+		// signatures in a batch, and each transitions as a single vote would
+		var vals *types.ValidatorSet
+		switch msg.Height {
+		case cs.Height:
+			vals = cs.Validators
+		case cs.Height - 1:
+			vals = cs.LastValidators
+		}
+		if vals == nil {
+			cs.logger.Debug("vote batch ignored", "batch_height", msg.Height, "cs_height", cs.Height, "peer", peerID)
+			return
+		}
+
+		var votes []*types.Vote
+		votes, err = msg.ToVotes(vals)
+		if err != nil {
+			break
+		}
+
+		addedVotes, errs := cs.tryAddVotes(ctx, votes, peerID)
+		for i, vote := range votes {
+			cs.publishVoteEvent(ctx, vote, peerID, addedVotes[i], errs[i])
+			if errs[i] != nil && err == nil {
+				err = errs[i]
+			}
+			if addedVotes[i] {
+				select {
+				case cs.statsMsgQueue <- msgInfo{&VoteMessage{vote}, peerID}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+
 	default:
 		cs.logger.Error("unknown msg type", "type", fmt.Sprintf("%T", msg))
 		return
@@ -1009,12 +1201,15 @@ func (cs *State) handleTimeout(
 			cs.logger.Error("failed publishing timeout propose", "err", err)
 		}
 
+		cs.evsw.FireEvent(ctx, types.EventTimeoutProposeValue, ti)
+		cs.adaptiveTimeouts.proposeTimedOut(ti.Duration)
 		cs.enterPrevote(ctx, ti.Height, ti.Round)
 
 	case cstypes.RoundStepPrevoteWait:
 		if err := cs.eventBus.PublishEventTimeoutWait(ctx, cs.RoundStateEvent()); err != nil {
 			cs.logger.Error("failed publishing timeout wait", "err", err)
 		}
+		cs.evsw.FireEvent(ctx, types.EventTimeoutWaitValue, ti)
 
 		cs.enterPrecommit(ctx, ti.Height, ti.Round)
 
@@ -1022,6 +1217,7 @@ func (cs *State) handleTimeout(
 		if err := cs.eventBus.PublishEventTimeoutWait(ctx, cs.RoundStateEvent()); err != nil {
 			cs.logger.Error("failed publishing timeout wait", "err", err)
 		}
+		cs.evsw.FireEvent(ctx, types.EventTimeoutWaitValue, ti)
 
 		cs.enterPrecommit(ctx, ti.Height, ti.Round)
 		cs.enterNewRound(ctx, ti.Height, ti.Round+1)
@@ -1049,7 +1245,7 @@ func (cs *State) handleTxsAvailable(ctx context.Context) {
 		}
 
 		// +1ms to ensure RoundStepNewRound timeout always happens after RoundStepNewHeight
-		timeoutCommit := cs.StartTime.Sub(tmtime.Now()) + 1*time.Millisecond
+		timeoutCommit := cs.StartTime.Sub(cs.now()) + 1*time.Millisecond
 		cs.scheduleTimeout(timeoutCommit, cs.Height, 0, cstypes.RoundStepNewRound)
 
 	case cstypes.RoundStepNewRound: // after timeoutCommit
@@ -1078,7 +1274,7 @@ func (cs *State) enterNewRound(ctx context.Context, height int64, round int32) {
 		return
 	}
 
-	if now := tmtime.Now(); cs.StartTime.After(now) {
+	if now := cs.now(); cs.StartTime.After(now) {
 		logger.Debug("need to set a buffer and log message here for sanity", "start_time", cs.StartTime, "now", now)
 	}
 
@@ -1094,8 +1290,9 @@ func (cs *State) enterNewRound(ctx context.Context, height int64, round int32) {
 	// Setup new round
 	// we don't fire newStep for this step,
 	// but we fire an event, so update the round step first
-	cs.updateRoundStep(round, cstypes.RoundStepNewRound)
+	cs.updateRoundStep(ctx, round, cstypes.RoundStepNewRound)
 	cs.Validators = validators
+	cs.Proposer = cs.selectProposer(cs.state, height, round)
 	if round == 0 {
 		// We've already reset these upon new height,
 		// and meanwhile we might have received a proposal
@@ -1164,7 +1361,7 @@ func (cs *State) enterPropose(ctx context.Context, height int64, round int32) {
 
 	defer func() {
 		// Done enterPropose:
-		cs.updateRoundStep(round, cstypes.RoundStepPropose)
+		cs.updateRoundStep(ctx, round, cstypes.RoundStepPropose)
 		cs.newStep(ctx)
 
 		// If we have the whole proposal + POL, then goto Prevote now.
@@ -1176,7 +1373,8 @@ func (cs *State) enterPropose(ctx context.Context, height int64, round int32) {
 	}()
 
 	// If we don't get the proposal and all block parts quick enough, enterPrevote
-	cs.scheduleTimeout(cs.config.Propose(round), height, round, cstypes.RoundStepPropose)
+	cs.adaptiveTimeouts.enterPropose(cs.now())
+	cs.scheduleTimeout(cs.proposeTimeout(round), height, round, cstypes.RoundStepPropose)
 
 	// Nothing more to do if we're not a validator
 	if cs.privValidator == nil {
@@ -1211,16 +1409,27 @@ func (cs *State) enterPropose(ctx context.Context, height int64, round int32) {
 	} else {
 		logger.Debug(
 			"propose step; not our turn to propose",
-			"proposer", cs.Validators.GetProposer().Address,
+			"proposer", cs.Proposer.Address,
 		)
 	}
 }
 
 func (cs *State) isProposer(address []byte) bool {
-	return bytes.Equal(cs.Validators.GetProposer().Address, address)
+	return bytes.Equal(cs.Proposer.Address, address)
+}
+
+// selectProposer returns the proposer of the round among cs.Validators, as
+// selected by the algorithm of the consensus params of the state.
+func (cs *State) selectProposer(state sm.State, height int64, round int32) *types.Validator {
+	selector := state.ConsensusParams.Validator.ProposerSelector()
+	return selector.Proposer(cs.Validators, height, round, state.LastBlockID.Hash)
 }
 
 func (cs *State) defaultDecideProposal(ctx context.Context, height int64, round int32) {
+	ctx, span := tracer.Start(trace.ContextWithSpan(ctx, cs.heightSpan), "consensus.DecideProposal",
+		tracing.HeightRound(height, round))
+	defer span.End()
+
 	var block *types.Block
 	var blockParts *types.PartSet
 
@@ -1230,7 +1439,7 @@ func (cs *State) defaultDecideProposal(ctx context.Context, height int64, round
 		block, blockParts = cs.ValidBlock, cs.ValidBlockParts
 	} else {
 		// Create a new proposal block from state/txs from the mempool.
-		block, blockParts = cs.createProposalBlock()
+		block, blockParts = cs.createProposalBlock(ctx)
 		if block == nil {
 			return
 		}
@@ -1245,6 +1454,7 @@ func (cs *State) defaultDecideProposal(ctx context.Context, height int64, round
 	// Make proposal
 	propBlockID := types.BlockID{Hash: block.Hash(), PartSetHeader: blockParts.Header()}
 	proposal := types.NewProposal(height, round, cs.ValidRound, propBlockID)
+	proposal.Timestamp = cs.now()
 	p := proposal.ToProto()
 
 	// wait the max amount we would wait for a proposal
@@ -1290,7 +1500,7 @@ func (cs *State) isProposalComplete() bool {
 //
 // NOTE: keep it side-effect free for clarity.
 // CONTRACT: cs.privValidator is not nil.
-func (cs *State) createProposalBlock() (block *types.Block, blockParts *types.PartSet) {
+func (cs *State) createProposalBlock(ctx context.Context) (block *types.Block, blockParts *types.PartSet) {
 	if cs.privValidator == nil {
 		panic("entered createProposalBlock with privValidator being nil")
 	}
@@ -1320,7 +1530,12 @@ func (cs *State) createProposalBlock() (block *types.Block, blockParts *types.Pa
 
 	proposerAddr := cs.privValidatorPubKey.Address()
 
-	return cs.blockExec.CreateProposalBlock(cs.Height, cs.state, commit, proposerAddr)
+	block, blockParts, err := cs.blockExec.CreateProposalBlock(ctx, cs.Height, cs.state, commit, proposerAddr)
+	if err != nil {
+		cs.logger.Error("propose step; failed to create proposal block", "err", err)
+		return nil, nil
+	}
+	return block, blockParts
 }
 
 // Enter: `timeoutPropose` after entering Propose.
@@ -1340,11 +1555,12 @@ func (cs *State) enterPrevote(ctx context.Context, height int64, round int32) {
 
 	defer func() {
 		// Done enterPrevote:
-		cs.updateRoundStep(round, cstypes.RoundStepPrevote)
+		cs.updateRoundStep(ctx, round, cstypes.RoundStepPrevote)
 		cs.newStep(ctx)
 	}()
 
 	logger.Debug("entering prevote step", "current", fmt.Sprintf("%v/%v/%v", cs.Height, cs.Round, cs.Step))
+	cs.adaptiveTimeouts.enterPrevote(cs.now())
 
 	// Sign and broadcast vote as necessary
 	cs.doPrevote(ctx, height, round)
@@ -1379,6 +1595,18 @@ func (cs *State) defaultDoPrevote(ctx context.Context, height int64, round int32
 		return
 	}
 
+	// Let the application validate the proposal block, prevote nil if it
+	// rejects it.
+	accepted, err := cs.blockExec.ProcessProposal(ctx, cs.state, cs.ProposalBlock)
+	if err != nil {
+		panic(fmt.Sprintf("ProcessProposal: %v", err))
+	}
+	if !accepted {
+		logger.Error("prevote step: ProposalBlock was rejected by the application")
+		cs.signAddVote(ctx, tmproto.PrevoteType, nil, types.PartSetHeader{})
+		return
+	}
+
 	// Prevote cs.ProposalBlock
 	// NOTE: the proposal signature is validated when it is received,
 	// and the proposal block parts are validated as they are received (against the merkle hash in the proposal)
@@ -1409,12 +1637,12 @@ func (cs *State) enterPrevoteWait(ctx context.Context, height int64, round int32
 
 	defer func() {
 		// Done enterPrevoteWait:
-		cs.updateRoundStep(round, cstypes.RoundStepPrevoteWait)
+		cs.updateRoundStep(ctx, round, cstypes.RoundStepPrevoteWait)
 		cs.newStep(ctx)
 	}()
 
 	// Wait for some more prevotes; enterPrecommit
-	cs.scheduleTimeout(cs.config.Prevote(round), height, round, cstypes.RoundStepPrevoteWait)
+	cs.scheduleTimeout(cs.stepTimeouts().Prevote(round), height, round, cstypes.RoundStepPrevoteWait)
 }
 
 // Enter: `timeoutPrevote` after any +2/3 prevotes.
@@ -1438,7 +1666,7 @@ func (cs *State) enterPrecommit(ctx context.Context, height int64, round int32)
 
 	defer func() {
 		// Done enterPrecommit:
-		cs.updateRoundStep(round, cstypes.RoundStepPrecommit)
+		cs.updateRoundStep(ctx, round, cstypes.RoundStepPrecommit)
 		cs.newStep(ctx)
 	}()
 
@@ -1534,7 +1762,7 @@ func (cs *State) enterPrecommit(ctx context.Context, height int64, round int32)
 
 	if !cs.ProposalBlockParts.HasHeader(blockID.PartSetHeader) {
 		cs.ProposalBlock = nil
-		cs.ProposalBlockParts = types.NewPartSetFromHeader(blockID.PartSetHeader)
+		cs.ProposalBlockParts = types.NewPartSetFromHeaderWithParams(blockID.PartSetHeader, cs.state.ConsensusParams.Block)
 	}
 
 	if err := cs.eventBus.PublishEventUnlock(ctx, cs.RoundStateEvent()); err != nil {
@@ -1573,7 +1801,7 @@ func (cs *State) enterPrecommitWait(ctx context.Context, height int64, round int
 	}()
 
 	// wait for some more precommits; enterNewRound
-	cs.scheduleTimeout(cs.config.Precommit(round), height, round, cstypes.RoundStepPrecommitWait)
+	cs.scheduleTimeout(cs.stepTimeouts().Precommit(round), height, round, cstypes.RoundStepPrecommitWait)
 }
 
 // Enter: +2/3 precommits for block
@@ -1593,9 +1821,10 @@ func (cs *State) enterCommit(ctx context.Context, height int64, commitRound int3
 	defer func() {
 		// Done enterCommit:
 		// keep cs.Round the same, commitRound points to the right Precommits set.
-		cs.updateRoundStep(cs.Round, cstypes.RoundStepCommit)
+		cs.updateRoundStep(ctx, cs.Round, cstypes.RoundStepCommit)
+		cs.beginCommit()
 		cs.CommitRound = commitRound
-		cs.CommitTime = tmtime.Now()
+		cs.CommitTime = cs.now()
 		cs.newStep(ctx)
 
 		// Maybe finalize immediately.
@@ -1628,7 +1857,7 @@ func (cs *State) enterCommit(ctx context.Context, height int64, commitRound int3
 			// We're getting the wrong block.
 			// Set up ProposalBlockParts and keep waiting.
 			cs.ProposalBlock = nil
-			cs.ProposalBlockParts = types.NewPartSetFromHeader(blockID.PartSetHeader)
+			cs.ProposalBlockParts = types.NewPartSetFromHeaderWithParams(blockID.PartSetHeader, cs.state.ConsensusParams.Block)
 
 			if err := cs.eventBus.PublishEventValidBlock(ctx, cs.RoundStateEvent()); err != nil {
 				logger.Error("failed publishing valid block", "err", err)
@@ -1693,9 +1922,14 @@ func (cs *State) finalizeCommit(ctx context.Context, height int64) {
 	}
 
 	if err := cs.blockExec.ValidateBlock(cs.state, block); err != nil {
+		cs.blockExec.ReportInvalidBlock(cs.state, block, "", err)
 		panic(fmt.Errorf("+2/3 committed an invalid block: %w", err))
 	}
 
+	ctx, span := tracer.Start(trace.ContextWithSpan(ctx, cs.heightSpan), "consensus.FinalizeCommit",
+		tracing.HeightRound(height, cs.CommitRound))
+	defer span.End()
+
 	logger.Info(
 		"finalizing commit of block",
 		"hash", block.Hash(),
@@ -1758,6 +1992,7 @@ func (cs *State) finalizeCommit(ctx context.Context, height int64) {
 	)
 	if err != nil {
 		logger.Error("failed to apply block", "err", err)
+		tracing.RecordError(span, err)
 		return
 	}
 
@@ -1765,6 +2000,7 @@ func (cs *State) finalizeCommit(ctx context.Context, height int64) {
 
 	// must be called before we update state
 	cs.RecordMetrics(height, block)
+	cs.heightSpan.End()
 
 	// NewHeightStep!
 	cs.updateToState(ctx, stateCopy)
@@ -1776,9 +2012,20 @@ func (cs *State) finalizeCommit(ctx context.Context, height int64) {
 		logger.Error("failed to get private validator pubkey", "err", err)
 	}
 
+	// Write the WAL, with the configured timeouts, once validators are added
+	// to a single validator network skipping the WAL.
+	if cs.skipWALTimeouts != nil && cs.Validators.Size() > 1 {
+		logger.Info("validators were added; writing the consensus WAL")
+		cs.skipWALTimeouts = nil
+		if err := cs.loadWalFile(ctx); err != nil {
+			logger.Error("failed to open the consensus WAL", "err", err)
+		}
+	}
+
 	// cs.StartTime is already set.
 	// Schedule Round0 to start soon.
 	cs.scheduleRound0(&cs.RoundState)
+	cs.endCommit()
 
 	// By here,
 	// * cs.Height has been increment to height+1
@@ -1898,7 +2145,7 @@ func (cs *State) defaultSetProposal(proposal *types.Proposal) error {
 
 	p := proposal.ToProto()
 	// Verify signature
-	if !cs.Validators.GetProposer().PubKey.VerifySignature(
+	if !cs.Proposer.PubKey.VerifySignature(
 		types.ProposalSignBytes(cs.state.ChainID, p), proposal.Signature,
 	) {
 		return ErrInvalidProposalSignature
@@ -1910,7 +2157,7 @@ func (cs *State) defaultSetProposal(proposal *types.Proposal) error {
 	// This happens if we're already in cstypes.RoundStepCommit or if there is a valid block in the current round.
 	// TODO: We can check if Proposal is for a different block as this is a sign of misbehavior!
 	if cs.ProposalBlockParts == nil {
-		cs.ProposalBlockParts = types.NewPartSetFromHeader(proposal.BlockID.PartSetHeader)
+		cs.ProposalBlockParts = types.NewPartSetFromHeaderWithParams(proposal.BlockID.PartSetHeader, cs.state.ConsensusParams.Block)
 	}
 
 	cs.logger.Info("received proposal", "proposal", proposal)
@@ -2005,6 +2252,8 @@ func (cs *State) addProposalBlockPart(
 		}
 
 		if cs.Step <= cstypes.RoundStepPropose && cs.isProposalComplete() {
+			cs.adaptiveTimeouts.proposalComplete(cs.now())
+
 			// Move onto the next step
 			cs.enterPrevote(ctx, height, cs.Round)
 			if hasTwoThirds { // this is optimisation as this will be triggered when prevote is added
@@ -2024,6 +2273,21 @@ func (cs *State) addProposalBlockPart(
 // Attempt to add the vote. if its a duplicate signature, dupeout the validator
 func (cs *State) tryAddVote(ctx context.Context, vote *types.Vote, peerID types.NodeID) (bool, error) {
 	added, err := cs.addVote(ctx, vote, peerID)
+	return cs.handleAddVoteError(vote, added, err)
+}
+
+// tryAddVotes attempts to add several votes at once, as tryAddVote does.
+func (cs *State) tryAddVotes(ctx context.Context, votes []*types.Vote, peerID types.NodeID) ([]bool, []error) {
+	added, errs := cs.addVotes(ctx, votes, peerID)
+	for i, vote := range votes {
+		added[i], errs[i] = cs.handleAddVoteError(vote, added[i], errs[i])
+	}
+	return added, errs
+}
+
+// handleAddVoteError reports the conflicting votes among the errors of adding
+// a vote to the evidence pool, and returns the error to report for it.
+func (cs *State) handleAddVoteError(vote *types.Vote, added bool, err error) (bool, error) {
 	if err != nil {
 		// If the vote height is off, we'll just ignore it,
 		// But if it's a conflicting sig, add it to the cs.evpool.
@@ -2097,20 +2361,9 @@ func (cs *State) addVote(
 			return
 		}
 
-		cs.logger.Debug("added vote to last precommits", "last_commit", cs.LastCommit.StringShort())
-		if err := cs.eventBus.PublishEventVote(ctx, types.EventDataVote{Vote: vote}); err != nil {
+		if err := cs.handleAddedLastCommitVote(ctx, vote); err != nil {
 			return added, err
 		}
-
-		cs.evsw.FireEvent(ctx, types.EventVoteValue, vote)
-
-		// if we can skip timeoutCommit and have all the votes now,
-		if cs.config.SkipTimeoutCommit && cs.LastCommit.HasAll() {
-			// go straight to new round (skip timeout commit)
-			// cs.scheduleTimeout(time.Duration(0), cs.Height, 0, cstypes.RoundStepNewHeight)
-			cs.enterNewRound(ctx, cs.Height, 0)
-		}
-
 		return
 	}
 
@@ -2121,18 +2374,121 @@ func (cs *State) addVote(
 		return
 	}
 
-	height := cs.Height
 	added, err = cs.Votes.AddVote(vote, peerID)
 	if !added {
 		// Either duplicate, or error upon cs.Votes.AddByIndex()
 		return
 	}
 
-	if err := cs.eventBus.PublishEventVote(ctx, types.EventDataVote{Vote: vote}); err != nil {
+	if err := cs.handleAddedVote(ctx, cs.Height, vote); err != nil {
 		return added, err
 	}
+	return added, err
+}
+
+// addVotes adds several votes at once, as addVote does, verifying the
+// signatures of the votes added to the same vote set in a batch.
+func (cs *State) addVotes(
+	ctx context.Context,
+	votes []*types.Vote,
+	peerID types.NodeID,
+) (added []bool, errs []error) {
+	cs.logger.Debug("adding votes", "num_votes", len(votes), "cs_height", cs.Height)
+
+	added = make([]bool, len(votes))
+	errs = make([]error, len(votes))
+
+	var lastCommitIdxs, idxs []int
+	for i, vote := range votes {
+		switch {
+		case vote.Height+1 == cs.Height && vote.Type == tmproto.PrecommitType:
+			if cs.Step != cstypes.RoundStepNewHeight {
+				cs.logger.Debug("precommit vote came in after commit timeout and has been ignored", "vote", vote)
+				continue
+			}
+			lastCommitIdxs = append(lastCommitIdxs, i)
+
+		case vote.Height == cs.Height:
+			idxs = append(idxs, i)
+
+		default:
+			cs.logger.Debug("vote ignored and not added", "vote_height", vote.Height, "cs_height", cs.Height, "peer", peerID)
+		}
+	}
+
+	if len(lastCommitIdxs) > 0 {
+		setAdded, setErrs := cs.LastCommit.AddVotes(selectVotes(votes, lastCommitIdxs))
+		for j, i := range lastCommitIdxs {
+			added[i], errs[i] = setAdded[j], setErrs[j]
+			if !added[i] {
+				continue
+			}
+			if err := cs.handleAddedLastCommitVote(ctx, votes[i]); err != nil {
+				errs[i] = err
+			}
+		}
+	}
+
+	if len(idxs) > 0 {
+		height := cs.Height
+		setAdded, setErrs := cs.Votes.AddVotes(selectVotes(votes, idxs), peerID)
+		for j, i := range idxs {
+			added[i], errs[i] = setAdded[j], setErrs[j]
+			if !added[i] {
+				continue
+			}
+			if err := cs.handleAddedVote(ctx, height, votes[i]); err != nil {
+				errs[i] = err
+			}
+		}
+	}
+
+	return added, errs
+}
+
+// selectVotes returns the votes at the given indexes.
+func selectVotes(votes []*types.Vote, idxs []int) []*types.Vote {
+	selected := make([]*types.Vote, len(idxs))
+	for j, i := range idxs {
+		selected[j] = votes[i]
+	}
+	return selected
+}
+
+// handleAddedLastCommitVote publishes a precommit for the previous height
+// added to the last commit, and skips the commit timeout once all of them are
+// in, if configured to.
+func (cs *State) handleAddedLastCommitVote(ctx context.Context, vote *types.Vote) error {
+	cs.logger.Debug("added vote to last precommits", "last_commit", cs.LastCommit.StringShort())
+	if err := cs.eventBus.PublishEventVote(ctx, types.EventDataVote{Vote: vote}); err != nil {
+		return err
+	}
+
+	cs.evsw.FireEvent(ctx, types.EventVoteValue, vote)
+
+	// if we can skip timeoutCommit and have all the votes now,
+	if cs.config.SkipTimeoutCommit && cs.LastCommit.HasAll() {
+		// go straight to new round (skip timeout commit)
+		// cs.scheduleTimeout(time.Duration(0), cs.Height, 0, cstypes.RoundStepNewHeight)
+		cs.enterNewRound(ctx, cs.Height, 0)
+	}
+	return nil
+}
+
+// handleAddedVote publishes a vote added to the votes of the given height, and
+// makes the transitions it causes. Once the height is committed, which may
+// happen while adding a batch of votes, the following votes of the batch only
+// are published.
+func (cs *State) handleAddedVote(ctx context.Context, height int64, vote *types.Vote) error {
+	if err := cs.eventBus.PublishEventVote(ctx, types.EventDataVote{Vote: vote}); err != nil {
+		return err
+	}
 	cs.evsw.FireEvent(ctx, types.EventVoteValue, vote)
 
+	if height != cs.Height {
+		return nil
+	}
+
 	switch vote.Type {
 	case tmproto.PrevoteType:
 		prevotes := cs.Votes.Prevotes(vote.Round)
@@ -2158,7 +2514,7 @@ func (cs *State) addVote(
 				cs.LockedBlockParts = nil
 
 				if err := cs.eventBus.PublishEventUnlock(ctx, cs.RoundStateEvent()); err != nil {
-					return added, err
+					return err
 				}
 			}
 
@@ -2182,16 +2538,20 @@ func (cs *State) addVote(
 				}
 
 				if !cs.ProposalBlockParts.HasHeader(blockID.PartSetHeader) {
-					cs.ProposalBlockParts = types.NewPartSetFromHeader(blockID.PartSetHeader)
+					cs.ProposalBlockParts = types.NewPartSetFromHeaderWithParams(blockID.PartSetHeader, cs.state.ConsensusParams.Block)
 				}
 
 				cs.evsw.FireEvent(ctx, types.EventValidBlockValue, &cs.RoundState)
 				if err := cs.eventBus.PublishEventValidBlock(ctx, cs.RoundStateEvent()); err != nil {
-					return added, err
+					return err
 				}
 			}
 		}
 
+		if cs.Round == vote.Round && prevotes.HasTwoThirdsAny() {
+			cs.adaptiveTimeouts.twoThirdsPrevotes(cs.now())
+		}
+
 		// If +2/3 prevotes for *anything* for future round:
 		switch {
 		case cs.Round < vote.Round && prevotes.HasTwoThirdsAny():
@@ -2245,7 +2605,7 @@ func (cs *State) addVote(
 		panic(fmt.Sprintf("unexpected vote type %v", vote.Type))
 	}
 
-	return added, err
+	return nil
 }
 
 // CONTRACT: cs.privValidator is not nil.
@@ -2308,7 +2668,7 @@ func (cs *State) signVote(
 // any vote from this validator will have time at least time T + 1ms.
 // This is needed, as monotonicity of time is a guarantee that BFT time provides.
 func (cs *State) voteTime() time.Time {
-	now := tmtime.Now()
+	now := cs.now()
 	minVoteTime := now
 	// Minimum time increment between blocks
 	const timeIota = time.Millisecond
@@ -2344,6 +2704,10 @@ func (cs *State) signAddVote(ctx context.Context, msgType tmproto.SignedMsgType,
 		return nil
 	}
 
+	ctx, span := tracer.Start(trace.ContextWithSpan(ctx, cs.heightSpan), "consensus.SignVote",
+		tracing.HeightRound(cs.Height, cs.Round), trace.WithAttributes(attribute.String("type", msgType.String())))
+	defer span.End()
+
 	// TODO: pass pubKey to signVote
 	vote, err := cs.signVote(ctx, msgType, hash, header)
 	if err == nil {
@@ -2352,6 +2716,7 @@ func (cs *State) signAddVote(ctx context.Context, msgType tmproto.SignedMsgType,
 		return vote
 	}
 
+	tracing.RecordError(span, err)
 	cs.logger.Error("failed signing vote", "height", cs.Height, "round", cs.Round, "vote", vote, "err", err)
 	return nil
 }
@@ -2384,6 +2749,25 @@ func (cs *State) updatePrivValidatorPubKey(rctx context.Context) error {
 	if err != nil {
 		return err
 	}
+
+	// Switch to the next key of the validator once the application rotated
+	// the key of the validator in the current validator set.
+	if rotator, ok := cs.privValidator.(types.KeyRotator); ok &&
+		cs.Validators != nil && !cs.Validators.HasAddress(pubKey.Address()) {
+		nextPubKey, err := rotator.GetNextPubKey(ctxto)
+		if err != nil {
+			return err
+		}
+		if nextPubKey != nil && cs.Validators.HasAddress(nextPubKey.Address()) {
+			if err := rotator.RotateKey(ctxto); err != nil {
+				return fmt.Errorf("failed to rotate private validator key: %w", err)
+			}
+			cs.logger.Info("rotated private validator key",
+				"height", cs.Height, "old", pubKey.Address(), "new", nextPubKey.Address())
+			pubKey = nextPubKey
+		}
+	}
+
 	cs.privValidatorPubKey = pubKey
 	return nil
 }
@@ -227,7 +227,7 @@ func TestStateBadProposal(t *testing.T) {
 	proposalCh := subscribe(ctx, t, cs1.eventBus, types.EventQueryCompleteProposal)
 	voteCh := subscribe(ctx, t, cs1.eventBus, types.EventQueryVote)
 
-	propBlock, _ := cs1.createProposalBlock() // changeProposer(t, cs1, vs2)
+	propBlock, _ := cs1.createProposalBlock(ctx) // changeProposer(t, cs1, vs2)
 
 	// make the second validator the proposer by incrementing round
 	round++
@@ -291,7 +291,7 @@ func TestStateOversizedBlock(t *testing.T) {
 	timeoutProposeCh := subscribe(ctx, t, cs1.eventBus, types.EventQueryTimeoutPropose)
 	voteCh := subscribe(ctx, t, cs1.eventBus, types.EventQueryVote)
 
-	propBlock, _ := cs1.createProposalBlock()
+	propBlock, _ := cs1.createProposalBlock(ctx)
 	propBlock.Data.Txs = []types.Tx{tmrand.Bytes(2001)}
 	propBlock.Header.DataHash = propBlock.Data.Hash()
 
@@ -2116,3 +2116,100 @@ func subscribe(
 	}()
 	return ch
 }
+
+func TestStateSkipWAL(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg := configSetup(t)
+	cfg.Consensus.SkipWAL = true
+	logger := log.TestingLogger()
+
+	// more than one validator is refused
+	state, privVals := randGenesisState(cfg, 2, false, 10)
+	cs := newStateWithConfig(ctx, logger, cfg, state, privVals[0], kvstore.NewApplication())
+	require.Error(t, cs.Start(ctx))
+
+	// a single validator commits blocks without opening a WAL
+	state, privVals = randGenesisState(cfg, 1, false, 10)
+	cs = newStateWithConfig(ctx, logger, cfg, state, privVals[0], kvstore.NewApplication())
+	newBlockCh := subscribe(ctx, t, cs.eventBus, types.EventQueryNewBlock)
+	require.NoError(t, cs.Start(ctx))
+	t.Cleanup(cs.Wait)
+
+	ensureNewBlock(newBlockCh, 1)
+	ensureNewBlock(newBlockCh, 2)
+
+	cs.mtx.RLock()
+	defer cs.mtx.RUnlock()
+	_, ok := cs.wal.(nilWAL)
+	assert.True(t, ok)
+	assert.Equal(t, cfg.Consensus.SkipWALConsensusConfig().TimeoutCommit, cs.commitTimeout())
+	assert.Equal(t, cfg.Consensus.TimeoutCommit, cs.config.TimeoutCommit)
+}
+
+func TestStateWaitForCommit(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	config := configSetup(t)
+
+	cs1, _, err := randState(ctx, config, log.TestingLogger(), 1)
+	require.NoError(t, err)
+
+	// no block is being committed
+	require.True(t, cs1.WaitForCommit(ctx))
+
+	// the commit of a block is waited for until it is finalized
+	cs1.beginCommit()
+	waitCtx, waitCancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer waitCancel()
+	require.False(t, cs1.WaitForCommit(waitCtx))
+
+	go cs1.endCommit()
+	require.True(t, cs1.WaitForCommit(ctx))
+}
+
+func TestStatePing(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	config := configSetup(t)
+
+	cs1, _, err := randState(ctx, config, log.TestingLogger(), 1)
+	require.NoError(t, err)
+
+	// the receive routine isn't running yet
+	pingCtx, pingCancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer pingCancel()
+	require.ErrorIs(t, cs1.Ping(pingCtx), context.DeadlineExceeded)
+
+	routineCtx, routineCancel := context.WithCancel(ctx)
+	startTestRound(routineCtx, cs1, cs1.Height, cs1.Round)
+	require.NoError(t, cs1.Ping(ctx))
+
+	// nor once it has exited
+	routineCancel()
+	<-cs1.done
+	require.Error(t, cs1.Ping(ctx))
+}
+
+func TestStateFailureHandler(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	config := configSetup(t)
+
+	cs1, _, err := randState(ctx, config, log.TestingLogger(), 1)
+	require.NoError(t, err)
+
+	failures := make(chan interface{}, 1)
+	cs1.SetFailureHandler(func(r interface{}) { failures <- r })
+	cs1.doPrevote = func(context.Context, int64, int32) { panic("prevote failed") }
+
+	startTestRound(ctx, cs1, cs1.Height, cs1.Round)
+	select {
+	case r := <-failures:
+		require.Equal(t, "prevote failed", r)
+	case <-time.After(10 * ensureTimeout):
+		t.Fatal("consensus didn't fail")
+	}
+	<-cs1.done
+}
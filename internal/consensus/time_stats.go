@@ -0,0 +1,146 @@
+package consensus
+
+import (
+	"sync"
+	"time"
+
+	cstypes "github.com/tendermint/tendermint/internal/consensus/types"
+)
+
+// timeStatsWindow is the number of latest committed heights the time
+// statistics are computed from.
+const timeStatsWindow = 100
+
+// TimeStats summarizes the timing of the latest heights committed by the node.
+type TimeStats struct {
+	FirstHeight int64
+	LastHeight  int64
+	// AvgBlockTime is the average time between the commits of consecutive
+	// heights, zero if there are none.
+	AvgBlockTime time.Duration
+	// Rounds is the number of heights committed in each number of rounds.
+	Rounds map[int32]int
+	// Timeouts is, by step, the number of heights at which its timeout fired
+	// a given number of times.
+	Timeouts map[cstypes.RoundStepType]map[int]int
+}
+
+// TimeoutSteps are the steps whose timeouts are tracked by the time
+// statistics, in order.
+var TimeoutSteps = []cstypes.RoundStepType{
+	cstypes.RoundStepPropose,
+	cstypes.RoundStepPrevoteWait,
+	cstypes.RoundStepPrecommitWait,
+}
+
+// heightTimes holds the timing of a committed height.
+type heightTimes struct {
+	height     int64
+	rounds     int32
+	commitTime time.Time
+	timeouts   map[cstypes.RoundStepType]int // number of timeouts fired by step
+}
+
+// timeStats records the timing of the latest committed heights in a ring
+// buffer, from the steps and timeouts of the state machine.
+type timeStats struct {
+	mtx     sync.Mutex
+	heights [timeStatsWindow]heightTimes
+	next    int // index of the next height in the ring buffer
+	count   int
+
+	// timeouts fired at the height being decided
+	height   int64
+	timeouts map[cstypes.RoundStepType]int
+}
+
+// timeout records a timeout fired by the state machine.
+func (s *timeStats) timeout(ti timeoutInfo) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if ti.Height != s.height {
+		s.height = ti.Height
+		s.timeouts = make(map[cstypes.RoundStepType]int)
+	}
+	s.timeouts[ti.Step]++
+}
+
+// newHeight records the height committed before the new height of the round
+// state. The heights committed before the node started, or replayed from the
+// WAL, are not, as their commit times are unknown.
+func (s *timeStats) newHeight(rs *cstypes.RoundState) {
+	if rs.LastCommit == nil || rs.CommitTime.IsZero() {
+		return
+	}
+	height := rs.Height - 1
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.count > 0 && s.last().height >= height {
+		return
+	}
+	h := heightTimes{
+		height:     height,
+		rounds:     rs.LastCommit.GetRound() + 1,
+		commitTime: rs.CommitTime,
+	}
+	if s.height == height {
+		h.timeouts = s.timeouts
+	}
+
+	s.heights[s.next] = h
+	s.next = (s.next + 1) % timeStatsWindow
+	if s.count < timeStatsWindow {
+		s.count++
+	}
+}
+
+// last returns the latest committed height, if any.
+func (s *timeStats) last() heightTimes {
+	return s.heights[(s.next+timeStatsWindow-1)%timeStatsWindow]
+}
+
+// stats returns the statistics of the heights in the ring buffer.
+func (s *timeStats) stats() TimeStats {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	stats := TimeStats{
+		Rounds:   make(map[int32]int),
+		Timeouts: make(map[cstypes.RoundStepType]map[int]int),
+	}
+	for _, step := range TimeoutSteps {
+		stats.Timeouts[step] = make(map[int]int)
+	}
+	if s.count == 0 {
+		return stats
+	}
+
+	var (
+		total     time.Duration
+		intervals int
+		prev      heightTimes
+	)
+	for i := 0; i < s.count; i++ {
+		h := s.heights[(s.next+timeStatsWindow-s.count+i)%timeStatsWindow]
+		if i == 0 {
+			stats.FirstHeight = h.height
+		} else if h.height == prev.height+1 {
+			// the interval from a height which was not recorded is unknown
+			total += h.commitTime.Sub(prev.commitTime)
+			intervals++
+		}
+		stats.LastHeight = h.height
+		stats.Rounds[h.rounds]++
+		for _, step := range TimeoutSteps {
+			stats.Timeouts[step][h.timeouts[step]]++
+		}
+		prev = h
+	}
+	if intervals > 0 {
+		stats.AvgBlockTime = total / time.Duration(intervals)
+	}
+	return stats
+}
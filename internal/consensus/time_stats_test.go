@@ -0,0 +1,67 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	cstypes "github.com/tendermint/tendermint/internal/consensus/types"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestTimeStats(t *testing.T) {
+	var s timeStats
+	start := time.Now()
+	valSet := types.NewValidatorSet(nil)
+
+	newHeight := func(height int64, round int32, commitTime time.Time) {
+		s.newHeight(&cstypes.RoundState{
+			Height:     height + 1,
+			CommitTime: commitTime,
+			LastCommit: types.NewVoteSet("test", height, round, tmproto.PrecommitType, valSet),
+		})
+	}
+
+	// without heights, the histograms are empty
+	stats := s.stats()
+	assert.Zero(t, stats.LastHeight)
+	assert.Zero(t, stats.AvgBlockTime)
+	assert.Empty(t, stats.Rounds)
+	assert.Len(t, stats.Timeouts, len(TimeoutSteps))
+
+	// the height committed before the node started is not recorded
+	s.newHeight(&cstypes.RoundState{Height: 2})
+	assert.Zero(t, s.stats().LastHeight)
+
+	newHeight(2, 0, start)
+	s.timeout(timeoutInfo{Height: 3, Round: 0, Step: cstypes.RoundStepPropose})
+	s.timeout(timeoutInfo{Height: 3, Round: 1, Step: cstypes.RoundStepPropose})
+	s.timeout(timeoutInfo{Height: 3, Round: 1, Step: cstypes.RoundStepPrecommitWait})
+	newHeight(3, 2, start.Add(5*time.Second))
+	newHeight(4, 0, start.Add(6*time.Second))
+	// a height is recorded once
+	newHeight(4, 0, start.Add(7*time.Second))
+
+	stats = s.stats()
+	assert.EqualValues(t, 2, stats.FirstHeight)
+	assert.EqualValues(t, 4, stats.LastHeight)
+	assert.Equal(t, 3*time.Second, stats.AvgBlockTime)
+	assert.Equal(t, map[int32]int{1: 2, 3: 1}, stats.Rounds)
+	assert.Equal(t, map[cstypes.RoundStepType]map[int]int{
+		cstypes.RoundStepPropose:       {0: 2, 2: 1},
+		cstypes.RoundStepPrevoteWait:   {0: 3},
+		cstypes.RoundStepPrecommitWait: {0: 2, 1: 1},
+	}, stats.Timeouts)
+
+	// the oldest heights leave the window
+	for height := int64(5); height < 5+timeStatsWindow; height++ {
+		newHeight(height, 0, start.Add(time.Duration(height)*time.Second))
+	}
+	stats = s.stats()
+	assert.EqualValues(t, 5, stats.FirstHeight)
+	assert.EqualValues(t, 4+timeStatsWindow, stats.LastHeight)
+	assert.Equal(t, time.Second, stats.AvgBlockTime)
+	assert.Equal(t, map[int32]int{1: timeStatsWindow}, stats.Rounds)
+}
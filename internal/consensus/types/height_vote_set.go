@@ -135,6 +135,48 @@ func (hvs *HeightVoteSet) AddVote(vote *types.Vote, peerID types.NodeID) (added
 	return
 }
 
+// AddVotes adds several votes at once, as AddVote does, verifying the
+// signatures of the votes for the same round and type in a batch. The results
+// for each vote are those AddVote would have returned.
+func (hvs *HeightVoteSet) AddVotes(votes []*types.Vote, peerID types.NodeID) (added []bool, errs []error) {
+	hvs.mtx.Lock()
+	defer hvs.mtx.Unlock()
+
+	added = make([]bool, len(votes))
+	errs = make([]error, len(votes))
+	for start := 0; start < len(votes); {
+		// Group the following votes of the same round and type.
+		round, typ := votes[start].Round, votes[start].Type
+		end := start + 1
+		for end < len(votes) && votes[end].Round == round && votes[end].Type == typ {
+			end++
+		}
+
+		if types.IsVoteTypeValid(typ) {
+			voteSet := hvs.getVoteSet(round, typ)
+			if voteSet == nil {
+				if rndz := hvs.peerCatchupRounds[peerID]; len(rndz) < 2 {
+					hvs.addRound(round)
+					voteSet = hvs.getVoteSet(round, typ)
+					hvs.peerCatchupRounds[peerID] = append(rndz, round)
+				}
+			}
+			if voteSet == nil {
+				// punish peer
+				for i := start; i < end; i++ {
+					errs[i] = ErrGotVoteFromUnwantedRound
+				}
+			} else {
+				groupAdded, groupErrs := voteSet.AddVotes(votes[start:end])
+				copy(added[start:end], groupAdded)
+				copy(errs[start:end], groupErrs)
+			}
+		}
+		start = end
+	}
+	return added, errs
+}
+
 func (hvs *HeightVoteSet) Prevotes(round int32) *types.VoteSet {
 	hvs.mtx.Lock()
 	defer hvs.mtx.Unlock()
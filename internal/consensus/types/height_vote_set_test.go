@@ -64,6 +64,39 @@ func TestPeerCatchupRounds(t *testing.T) {
 
 }
 
+func TestHeightVoteSetAddVotes(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	valSet, privVals := factory.RandValidatorSet(10, 1)
+
+	hvs := NewHeightVoteSet(cfg.ChainID(), 1, valSet)
+
+	// the votes of each round are added to its own vote set, and the votes of
+	// the third catchup round of the peer are rejected
+	votes := []*types.Vote{
+		makeVoteHR(ctx, t, 1, 0, 999, privVals),
+		makeVoteHR(ctx, t, 1, 1, 999, privVals),
+		makeVoteHR(ctx, t, 1, 0, 1000, privVals),
+		makeVoteHR(ctx, t, 1, 0, 1001, privVals),
+		makeVoteHR(ctx, t, 1, 1, 1001, privVals),
+	}
+	added, errs := hvs.AddVotes(votes, "peer1")
+	for i := 0; i < 3; i++ {
+		if !added[i] || errs[i] != nil {
+			t.Errorf("Expected to successfully add vote %d from peer: %v", i, errs[i])
+		}
+	}
+	for i := 3; i < len(votes); i++ {
+		if added[i] || errs[i] != ErrGotVoteFromUnwantedRound {
+			t.Errorf("expected GotVoteFromUnwantedRoundError for vote %d, but got %v", i, errs[i])
+		}
+	}
+	if bits := hvs.Precommits(999).BitArray(); !bits.GetIndex(0) || !bits.GetIndex(1) {
+		t.Errorf("Expected the votes of round 999 to be added to its vote set")
+	}
+}
+
 func makeVoteHR(
 	ctx context.Context,
 	t *testing.T,
@@ -73,6 +73,7 @@ type RoundState struct {
 	// Subjective time when +2/3 precommits for Block at Round were found
 	CommitTime         time.Time           `json:"commit_time"`
 	Validators         *types.ValidatorSet `json:"validators"`
+	Proposer           *types.Validator    `json:"proposer"` // selected among Validators by the consensus params
 	Proposal           *types.Proposal     `json:"proposal"`
 	ProposalBlock      *types.Block        `json:"proposal_block"`
 	ProposalBlockParts *types.PartSet      `json:"proposal_block_parts"`
@@ -111,7 +112,7 @@ func (rs *RoundState) RoundStateSimple() RoundStateSimple {
 		panic(err)
 	}
 
-	addr := rs.Validators.GetProposer().Address
+	addr := rs.proposer().Address
 	idx, _ := rs.Validators.GetByAddress(addr)
 
 	return RoundStateSimple{
@@ -128,9 +129,18 @@ func (rs *RoundState) RoundStateSimple() RoundStateSimple {
 	}
 }
 
+// proposer returns the proposer of the round, the validator of the highest
+// proposer priority if it wasn't selected.
+func (rs *RoundState) proposer() *types.Validator {
+	if rs.Proposer == nil {
+		return rs.Validators.GetProposer()
+	}
+	return rs.Proposer
+}
+
 // NewRoundEvent returns the RoundState with proposer information as an event.
 func (rs *RoundState) NewRoundEvent() types.EventDataNewRound {
-	addr := rs.Validators.GetProposer().Address
+	addr := rs.proposer().Address
 	idx, _ := rs.Validators.GetByAddress(addr)
 
 	return types.EventDataNewRound{
@@ -0,0 +1,89 @@
+package consensus
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/bits"
+	"github.com/tendermint/tendermint/types"
+)
+
+// NewVoteBatchMessage returns a batch of the given votes, which must be for
+// the same height, round, type and block ID, out of a validator set of the
+// given size.
+func NewVoteBatchMessage(votes []*types.Vote, valSetSize int) (*VoteBatchMessage, error) {
+	if len(votes) == 0 {
+		return nil, errors.New("no votes")
+	}
+
+	first := votes[0]
+	indexes := bits.NewBitArray(valSetSize)
+	byIndex := make(map[int32]*types.Vote, len(votes))
+	for _, vote := range votes {
+		if vote.Height != first.Height || vote.Round != first.Round || vote.Type != first.Type {
+			return nil, fmt.Errorf("vote %v does not have the height, round and type of %v", vote, first)
+		}
+		if !vote.BlockID.Equals(first.BlockID) {
+			return nil, fmt.Errorf("vote %v does not have the block ID of %v", vote, first)
+		}
+		if !indexes.SetIndex(int(vote.ValidatorIndex), true) {
+			return nil, fmt.Errorf("vote %v has an index out of a validator set of %d", vote, valSetSize)
+		}
+		byIndex[vote.ValidatorIndex] = vote
+	}
+
+	// the timestamps and signatures are in the order of the indexes
+	msg := &VoteBatchMessage{
+		Height:     first.Height,
+		Round:      first.Round,
+		Type:       first.Type,
+		BlockID:    first.BlockID,
+		Votes:      indexes,
+		Timestamps: make([]time.Time, 0, len(byIndex)),
+		Signatures: make([][]byte, 0, len(byIndex)),
+	}
+	for _, index := range voteBatchIndexes(indexes) {
+		vote := byIndex[index]
+		msg.Timestamps = append(msg.Timestamps, vote.Timestamp)
+		msg.Signatures = append(msg.Signatures, vote.Signature)
+	}
+	return msg, nil
+}
+
+// ToVotes returns the votes of the batch, the addresses of their validators
+// being looked up in vals, the validator set of the height of the batch.
+func (m *VoteBatchMessage) ToVotes(vals *types.ValidatorSet) ([]*types.Vote, error) {
+	if m.Votes.Size() != vals.Size() {
+		return nil, fmt.Errorf("got votes of %d validators, expected %d", m.Votes.Size(), vals.Size())
+	}
+
+	indexes := voteBatchIndexes(m.Votes)
+	votes := make([]*types.Vote, len(indexes))
+	for i, index := range indexes {
+		address, _ := vals.GetByIndex(index)
+		votes[i] = &types.Vote{
+			Type:             m.Type,
+			Height:           m.Height,
+			Round:            m.Round,
+			BlockID:          m.BlockID,
+			Timestamp:        m.Timestamps[i],
+			ValidatorAddress: address,
+			ValidatorIndex:   index,
+			Signature:        m.Signatures[i],
+		}
+	}
+	return votes, nil
+}
+
+// voteBatchIndexes returns the validator indexes set in votes, in ascending
+// order.
+func voteBatchIndexes(votes *bits.BitArray) []int32 {
+	var indexes []int32
+	for i := 0; i < votes.Size(); i++ {
+		if votes.GetIndex(i) {
+			indexes = append(indexes, int32(i))
+		}
+	}
+	return indexes
+}
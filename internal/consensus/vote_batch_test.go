@@ -0,0 +1,104 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/internal/test/factory"
+	"github.com/tendermint/tendermint/libs/log"
+	tmtime "github.com/tendermint/tendermint/libs/time"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestVoteBatchMessage(t *testing.T) {
+	vals, privVals := factory.RandValidatorSet(4, 10)
+	blockID := factory.MakeBlockID()
+
+	makeVote := func(index int32, blockID types.BlockID) *types.Vote {
+		vote, err := factory.MakeVote(privVals[index], factory.DefaultTestChainID,
+			index, 3, 1, int(tmproto.PrecommitType), blockID, tmtime.Now())
+		require.NoError(t, err)
+		return vote
+	}
+	// the votes are batched in the order of their indexes
+	votes := []*types.Vote{makeVote(3, blockID), makeVote(0, blockID), makeVote(2, blockID)}
+
+	msg, err := NewVoteBatchMessage(votes, vals.Size())
+	require.NoError(t, err)
+	require.NoError(t, msg.ValidateBasic())
+
+	pb, err := MsgToProto(msg)
+	require.NoError(t, err)
+	got, err := MsgFromProto(pb)
+	require.NoError(t, err)
+	batch, ok := got.(*VoteBatchMessage)
+	require.True(t, ok)
+
+	gotVotes, err := batch.ToVotes(vals)
+	require.NoError(t, err)
+	require.Equal(t, []*types.Vote{votes[1], votes[2], votes[0]}, gotVotes)
+	for _, vote := range gotVotes {
+		pubKey, err := privVals[vote.ValidatorIndex].GetPubKey(context.Background())
+		require.NoError(t, err)
+		assert.NoError(t, vote.Verify(factory.DefaultTestChainID, pubKey))
+	}
+
+	// the votes of a batch are looked up in a validator set of its size
+	otherVals, _ := factory.RandValidatorSet(5, 10)
+	_, err = batch.ToVotes(otherVals)
+	assert.Error(t, err)
+
+	// the votes of a batch are for the same block ID
+	_, err = NewVoteBatchMessage([]*types.Vote{votes[0], makeVote(1, types.BlockID{})}, vals.Size())
+	assert.Error(t, err)
+	_, err = NewVoteBatchMessage(nil, vals.Size())
+	assert.Error(t, err)
+
+	// each vote of a batch has a signature
+	batch.Signatures = batch.Signatures[1:]
+	assert.Error(t, batch.ValidateBasic())
+}
+
+func TestPeerStatePickVoteBatchToSend(t *testing.T) {
+	vals, privVals := factory.RandValidatorSet(4, 10)
+	blockID := factory.MakeBlockID()
+
+	voteSet := types.NewVoteSet(factory.DefaultTestChainID, 1, 0, tmproto.PrevoteType, vals)
+	for i, privVal := range privVals {
+		// the last validator votes nil
+		voteBlockID := blockID
+		if i == len(privVals)-1 {
+			voteBlockID = types.BlockID{}
+		}
+		vote, err := factory.MakeVote(privVal, factory.DefaultTestChainID,
+			int32(i), 1, 0, int(tmproto.PrevoteType), voteBlockID, tmtime.Now())
+		require.NoError(t, err)
+		added, err := voteSet.AddVote(vote)
+		require.NoError(t, err)
+		require.True(t, added)
+	}
+
+	ps := NewPeerState(log.NewNopLogger(), "peer")
+	ps.PRS.Height = 1
+	ps.PRS.Round = 0
+
+	// the votes for the block and for nil are sent in a batch each
+	var sent []*types.Vote
+	for {
+		batch, ok := ps.PickVoteBatchToSend(voteSet)
+		if !ok {
+			break
+		}
+		require.Contains(t, []int{1, 3}, len(batch))
+		for _, vote := range batch {
+			assert.True(t, vote.BlockID.Equals(batch[0].BlockID))
+			ps.SetHasVote(vote)
+		}
+		sent = append(sent, batch...)
+	}
+	assert.Len(t, sent, 4)
+}
@@ -12,6 +12,8 @@ import (
 
 	"github.com/gogo/protobuf/proto"
 
+	"github.com/tendermint/tendermint/config"
+	cstypes "github.com/tendermint/tendermint/internal/consensus/types"
 	auto "github.com/tendermint/tendermint/internal/libs/autofile"
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	"github.com/tendermint/tendermint/libs/log"
@@ -44,12 +46,22 @@ type EndHeightMessage struct {
 	Height int64 `json:"height"`
 }
 
+// CheckpointMessage marks the point inside WAL after which messages are
+// replayed on top of the consensus state checkpoint saved at the same time.
+type CheckpointMessage struct {
+	Height int64                 `json:"height"`
+	Round  int32                 `json:"round"`
+	Step   cstypes.RoundStepType `json:"step"`
+	Time   time.Time             `json:"time"`
+}
+
 type WALMessage interface{}
 
 func init() {
 	tmjson.RegisterType(msgInfo{}, "tendermint/wal/MsgInfo")
 	tmjson.RegisterType(timeoutInfo{}, "tendermint/wal/TimeoutInfo")
 	tmjson.RegisterType(EndHeightMessage{}, "tendermint/wal/EndHeightMessage")
+	tmjson.RegisterType(CheckpointMessage{}, "tendermint/wal/CheckpointMessage")
 }
 
 //--------------------------------------------------------
@@ -84,6 +96,9 @@ type BaseWAL struct {
 
 	flushTicker   *time.Ticker
 	flushInterval time.Duration
+
+	// when WriteSync syncs the WAL to disk, see config.WALFsyncMode
+	fsyncMode string
 }
 
 var _ WAL = &BaseWAL{}
@@ -105,6 +120,7 @@ func NewWAL(logger log.Logger, walFile string, groupOptions ...func(*auto.Group)
 		group:         group,
 		enc:           NewWALEncoder(group),
 		flushInterval: walDefaultFlushInterval,
+		fsyncMode:     config.WALFsyncEveryMessage,
 	}
 	wal.BaseService = *service.NewBaseService(logger, "baseWAL", wal)
 	return wal, nil
@@ -115,6 +131,17 @@ func (wal *BaseWAL) SetFlushInterval(i time.Duration) {
 	wal.flushInterval = i
 }
 
+// SetFsyncMode sets when the WAL is synced to disk, one of the config.WALFsync
+// modes. Outside of the every-message mode, WriteSync only syncs the messages
+// ending a height or checkpointing the consensus state, and the WAL is
+// otherwise synced periodically, at the given interval in the interval mode.
+func (wal *BaseWAL) SetFsyncMode(mode string, interval time.Duration) {
+	wal.fsyncMode = mode
+	if mode == config.WALFsyncInterval {
+		wal.flushInterval = interval
+	}
+}
+
 func (wal *BaseWAL) Group() *auto.Group {
 	return wal.group
 }
@@ -204,7 +231,7 @@ func (wal *BaseWAL) Write(msg WALMessage) error {
 
 // WriteSync is called when we receive a msg from ourselves
 // so that we write to disk before sending signed messages.
-// NOTE: calls fsync()
+// NOTE: calls fsync(), unless the fsync mode defers it. See SetFsyncMode.
 func (wal *BaseWAL) WriteSync(msg WALMessage) error {
 	if wal == nil {
 		return nil
@@ -214,6 +241,10 @@ func (wal *BaseWAL) WriteSync(msg WALMessage) error {
 		return err
 	}
 
+	if !wal.syncOnWrite(msg) {
+		return wal.group.Flush()
+	}
+
 	if err := wal.FlushAndSync(); err != nil {
 		wal.logger.Error(`WriteSync failed to flush consensus wal.
 		WARNING: may result in creating alternative proposals / votes for the current height iff the node restarted`,
@@ -224,6 +255,17 @@ func (wal *BaseWAL) WriteSync(msg WALMessage) error {
 	return nil
 }
 
+// syncOnWrite returns whether WriteSync syncs the message to disk. Messages
+// ending a height or checkpointing the consensus state are always synced, as
+// crash recovery relies on them.
+func (wal *BaseWAL) syncOnWrite(msg WALMessage) bool {
+	switch msg.(type) {
+	case EndHeightMessage, CheckpointMessage:
+		return true
+	}
+	return wal.fsyncMode == config.WALFsyncEveryMessage
+}
+
 // WALSearchOptions are optional arguments to SearchForEndHeight.
 type WALSearchOptions struct {
 	// IgnoreDataCorruptionErrors set to true will result in skipping data corruption errors.
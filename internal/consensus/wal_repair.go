@@ -0,0 +1,217 @@
+package consensus
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+// WALInspection summarizes the contents of a WAL file, as read up to the end
+// of the file or the first corrupted message.
+type WALInspection struct {
+	// Size is the size of the file in bytes, and ValidSize the size of the
+	// prefix consisting of valid messages.
+	Size      int64
+	ValidSize int64
+	// Messages is the number of valid messages.
+	Messages int
+
+	// LastEndHeight is the height of the last #ENDHEIGHT marker, or -1 if
+	// there is none.
+	LastEndHeight int64
+
+	// LastHeight and LastRound are the height and round of the last valid
+	// message referring to one, i.e. where consensus was when the WAL was
+	// last written.
+	LastHeight int64
+	LastRound  int32
+
+	// Corruption is the error decoding the first corrupted message, or nil if
+	// the file is consistent.
+	Corruption error
+}
+
+// Corrupted reports whether the inspected file contains corrupted data.
+func (wi *WALInspection) Corrupted() bool {
+	return wi.Corruption != nil
+}
+
+// countingReader counts the bytes read from the underlying reader.
+type countingReader struct {
+	rd io.Reader
+	n  int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.rd.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
+
+// WALEntry is a valid message read from a WAL file.
+type WALEntry struct {
+	// Offset and Size locate the encoded message in the file.
+	Offset int64
+	Size   int64
+	Msg    *TimedWALMessage
+}
+
+// InspectWAL reads the WAL file at path and summarizes its contents. If visit
+// is not nil, it is called with every valid message, and an error from it
+// aborts the inspection. Reading stops at the first corrupted message, which
+// is reported in the returned inspection rather than as an error.
+func InspectWAL(path string, visit func(WALEntry) error) (*WALInspection, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open WAL file: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	wi := &WALInspection{Size: info.Size(), LastEndHeight: -1}
+	cr := &countingReader{rd: f}
+	dec := NewWALDecoder(cr)
+	for {
+		offset := cr.n
+		msg, err := dec.Decode()
+		if errors.Is(err, io.EOF) && offset == wi.Size {
+			break
+		} else if IsDataCorruptionError(err) {
+			wi.Corruption = err
+			break
+		} else if err != nil {
+			// A partially written checksum is reported as EOF.
+			wi.Corruption = DataCorruptionError{fmt.Errorf("unexpected end of file at offset %d", offset)}
+			break
+		}
+
+		if visit != nil {
+			if err := visit(WALEntry{Offset: offset, Size: cr.n - offset, Msg: msg}); err != nil {
+				return nil, err
+			}
+		}
+		wi.Messages++
+		wi.ValidSize = cr.n
+
+		if m, ok := msg.Msg.(EndHeightMessage); ok {
+			wi.LastEndHeight = m.Height
+		}
+		if height, round, ok := walMessageHeightRound(msg.Msg); ok {
+			wi.LastHeight, wi.LastRound = height, round
+		}
+	}
+
+	return wi, nil
+}
+
+// walMessageHeightRound returns the height and round a WAL message refers to,
+// if any.
+func walMessageHeightRound(msg WALMessage) (int64, int32, bool) {
+	switch m := msg.(type) {
+	case types.EventDataRoundState:
+		return m.Height, m.Round, true
+	case timeoutInfo:
+		return m.Height, m.Round, true
+	case msgInfo:
+		switch mi := m.Msg.(type) {
+		case *ProposalMessage:
+			return mi.Proposal.Height, mi.Proposal.Round, true
+		case *BlockPartMessage:
+			return mi.Height, mi.Round, true
+		case *VoteMessage:
+			return mi.Vote.Height, mi.Vote.Round, true
+		}
+	}
+	return 0, 0, false
+}
+
+// DescribeWALMessage returns a one-line summary of a WAL message.
+func DescribeWALMessage(msg WALMessage) string {
+	switch m := msg.(type) {
+	case EndHeightMessage:
+		return fmt.Sprintf("#ENDHEIGHT %d", m.Height)
+	case types.EventDataRoundState:
+		return fmt.Sprintf("RoundState %d/%d/%s", m.Height, m.Round, m.Step)
+	case timeoutInfo:
+		return fmt.Sprintf("Timeout %v", &m)
+	case msgInfo:
+		if m.PeerID == "" {
+			return fmt.Sprintf("Msg %v (internal)", m.Msg)
+		}
+		return fmt.Sprintf("Msg %v (from %v)", m.Msg, m.PeerID)
+	default:
+		return fmt.Sprintf("%T %v", msg, msg)
+	}
+}
+
+// TruncateWAL truncates the WAL file at path right after the #ENDHEIGHT marker
+// of the given height, discarding the messages of any later heights. It fails
+// if no valid marker for height is found.
+func TruncateWAL(path string, height int64) error {
+	size := int64(-1)
+	_, err := InspectWAL(path, func(e WALEntry) error {
+		if m, ok := e.Msg.Msg.(EndHeightMessage); ok && m.Height == height {
+			size = e.Offset + e.Size
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if size < 0 {
+		return fmt.Errorf("WAL does not contain a valid #ENDHEIGHT %d", height)
+	}
+	return os.Truncate(path, size)
+}
+
+// RepairWAL removes the corrupted data from the WAL file at path, keeping the
+// valid messages preceding it. The original file is first copied to backupPath.
+// It returns the inspection of the file before repairing it, and does nothing
+// if the file is not corrupted.
+func RepairWAL(path, backupPath string) (*WALInspection, error) {
+	wi, err := InspectWAL(path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !wi.Corrupted() {
+		return wi, nil
+	}
+
+	if err := copyFile(path, backupPath); err != nil {
+		return nil, fmt.Errorf("failed to back up WAL file: %w", err)
+	}
+	if err := os.Truncate(path, wi.ValidSize); err != nil {
+		return nil, fmt.Errorf("failed to truncate WAL file: %w", err)
+	}
+	return wi, nil
+}
+
+// copyFile copies the file at src to a new file at dst.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
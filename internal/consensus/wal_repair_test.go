@@ -0,0 +1,138 @@
+package consensus
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/internal/consensus/types"
+	tmtime "github.com/tendermint/tendermint/libs/time"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// writeTestWAL writes a WAL file containing three heights, the last of which
+// is incomplete, and returns its path and the offsets following each message.
+func writeTestWAL(t *testing.T) (string, []int64) {
+	t.Helper()
+
+	now := tmtime.Now()
+	msgs := []WALMessage{
+		EndHeightMessage{0},
+		tmtypes.EventDataRoundState{Height: 1, Round: 0, Step: "RoundStepNewHeight"},
+		timeoutInfo{Duration: time.Second, Height: 1, Round: 1, Step: types.RoundStepPropose},
+		EndHeightMessage{1},
+		tmtypes.EventDataRoundState{Height: 2, Round: 0, Step: "RoundStepNewHeight"},
+		EndHeightMessage{2},
+		tmtypes.EventDataRoundState{Height: 3, Round: 2, Step: "RoundStepPrevote"},
+	}
+
+	path := filepath.Join(t.TempDir(), "wal")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var ends []int64
+	enc := NewWALEncoder(f)
+	for _, msg := range msgs {
+		require.NoError(t, enc.Encode(&TimedWALMessage{Time: now, Msg: msg}))
+		end, err := f.Seek(0, io.SeekCurrent)
+		require.NoError(t, err)
+		ends = append(ends, end)
+	}
+	return path, ends
+}
+
+func appendToFile(t *testing.T, path string, data []byte) {
+	t.Helper()
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = f.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+}
+
+func TestInspectWAL(t *testing.T) {
+	path, ends := writeTestWAL(t)
+
+	var entries []WALEntry
+	wi, err := InspectWAL(path, func(e WALEntry) error {
+		entries = append(entries, e)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.False(t, wi.Corrupted())
+	assert.Equal(t, len(ends), wi.Messages)
+	assert.Equal(t, ends[len(ends)-1], wi.Size)
+	assert.Equal(t, wi.Size, wi.ValidSize)
+	assert.EqualValues(t, 2, wi.LastEndHeight)
+	assert.EqualValues(t, 3, wi.LastHeight)
+	assert.EqualValues(t, 2, wi.LastRound)
+
+	require.Len(t, entries, len(ends))
+	assert.EqualValues(t, 0, entries[0].Offset)
+	assert.Equal(t, ends[3], entries[4].Offset)
+	assert.Equal(t, "#ENDHEIGHT 1", DescribeWALMessage(entries[3].Msg.Msg))
+
+	// A truncated message is reported as corruption following the valid ones.
+	appendToFile(t, path, []byte{0x01, 0x02, 0x03, 0x04, 0x00, 0x00})
+	wi, err = InspectWAL(path, nil)
+	require.NoError(t, err)
+	assert.True(t, wi.Corrupted())
+	assert.True(t, IsDataCorruptionError(wi.Corruption))
+	assert.Equal(t, len(ends), wi.Messages)
+	assert.Equal(t, ends[len(ends)-1], wi.ValidSize)
+	assert.Equal(t, wi.ValidSize+6, wi.Size)
+}
+
+func TestRepairWAL(t *testing.T) {
+	path, ends := writeTestWAL(t)
+	backupPath := path + ".corrupted"
+
+	// A consistent WAL is left unchanged.
+	wi, err := RepairWAL(path, backupPath)
+	require.NoError(t, err)
+	assert.False(t, wi.Corrupted())
+	assert.NoFileExists(t, backupPath)
+
+	// Corrupt the data of the last message.
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	data[len(data)-1] ^= 0xff
+	require.NoError(t, os.WriteFile(path, data, 0600))
+
+	wi, err = RepairWAL(path, backupPath)
+	require.NoError(t, err)
+	assert.True(t, wi.Corrupted())
+	assert.Equal(t, ends[len(ends)-2], wi.ValidSize)
+
+	backup, err := os.ReadFile(backupPath)
+	require.NoError(t, err)
+	assert.Equal(t, data, backup)
+
+	wi, err = InspectWAL(path, nil)
+	require.NoError(t, err)
+	assert.False(t, wi.Corrupted())
+	assert.Equal(t, ends[len(ends)-2], wi.Size)
+	assert.EqualValues(t, 2, wi.LastEndHeight)
+}
+
+func TestTruncateWAL(t *testing.T) {
+	path, ends := writeTestWAL(t)
+
+	require.NoError(t, TruncateWAL(path, 1))
+	wi, err := InspectWAL(path, nil)
+	require.NoError(t, err)
+	assert.False(t, wi.Corrupted())
+	assert.Equal(t, ends[3], wi.Size)
+	assert.EqualValues(t, 1, wi.LastEndHeight)
+	assert.EqualValues(t, 1, wi.LastHeight)
+	assert.EqualValues(t, 1, wi.LastRound)
+
+	assert.Error(t, TruncateWAL(path, 2))
+}
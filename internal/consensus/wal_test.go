@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/crypto/merkle"
 	"github.com/tendermint/tendermint/internal/consensus/types"
 	"github.com/tendermint/tendermint/internal/libs/autofile"
@@ -201,3 +202,43 @@ func TestWALPeriodicSync(t *testing.T) {
 		gr.Close()
 	}
 }
+
+func TestWALFsyncMode(t *testing.T) {
+	wal, err := NewWAL(log.TestingLogger(), filepath.Join(t.TempDir(), "wal"))
+	require.NoError(t, err)
+
+	timeout := timeoutInfo{Duration: time.Second, Height: 1, Round: 0, Step: types.RoundStepPropose}
+	endHeight := EndHeightMessage{Height: 1}
+	checkpoint := CheckpointMessage{Height: 1, Step: types.RoundStepPrecommit, Time: tmtime.Now()}
+
+	// by default, every message is synced
+	assert.True(t, wal.syncOnWrite(timeout))
+	assert.True(t, wal.syncOnWrite(endHeight))
+
+	// otherwise, only the markers crash recovery relies on are
+	wal.SetFsyncMode(config.WALFsyncEveryHeight, 0)
+	assert.False(t, wal.syncOnWrite(timeout))
+	assert.True(t, wal.syncOnWrite(endHeight))
+	assert.True(t, wal.syncOnWrite(checkpoint))
+	assert.Equal(t, walDefaultFlushInterval, wal.flushInterval)
+
+	wal.SetFsyncMode(config.WALFsyncInterval, walTestFlushInterval)
+	assert.False(t, wal.syncOnWrite(timeout))
+	assert.True(t, wal.syncOnWrite(endHeight))
+	assert.Equal(t, walTestFlushInterval, wal.flushInterval)
+
+	// messages that are not synced are still written to the file
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, wal.Start(ctx))
+	t.Cleanup(wal.Wait)
+
+	require.NoError(t, wal.WriteSync(timeout))
+	assert.Zero(t, wal.Group().Buffered())
+	require.NoError(t, wal.WriteSync(endHeight))
+
+	gr, found, err := wal.SearchForEndHeight(1, &WALSearchOptions{})
+	require.NoError(t, err)
+	require.True(t, found)
+	require.NoError(t, gr.Close())
+}
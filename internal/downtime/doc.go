@@ -0,0 +1,13 @@
+/*
+Package downtime tracks the blocks missed by the validator of the node, so that
+operators are alerted before the application punishes the validator for its
+downtime, e.g. by jailing it.
+
+The tracker observes the committed blocks, and counts the blocks whose last
+commit lacks the precommit of the validator over a sliding window of the last
+blocks the validator was expected to sign. The number and the rate of missed
+blocks are exported as metrics, and alerts are raised when the miss rate
+exceeds a threshold, and again when it falls back below it, e.g. to the
+webhook configured in the [instrumentation.downtime] section.
+*/
+package downtime
@@ -0,0 +1,63 @@
+package downtime
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "downtime"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Number of blocks missed by the validator in the window.
+	MissedBlocks metrics.Gauge
+	// Fraction of the blocks of the window missed by the validator.
+	MissRate metrics.Gauge
+	// Number of alerts raised for a miss rate exceeding the threshold.
+	Alerts metrics.Counter
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		MissedBlocks: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "missed_blocks",
+			Help:      "Number of blocks missed by the validator in the window.",
+		}, labels).With(labelsAndValues...),
+		MissRate: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "miss_rate",
+			Help:      "Fraction of the blocks of the window missed by the validator.",
+		}, labels).With(labelsAndValues...),
+		Alerts: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "alerts",
+			Help:      "Number of alerts raised for a miss rate exceeding the threshold.",
+		}, labels).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		MissedBlocks: discard.NewGauge(),
+		MissRate:     discard.NewGauge(),
+		Alerts:       discard.NewCounter(),
+	}
+}
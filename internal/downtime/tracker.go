@@ -0,0 +1,228 @@
+package downtime
+
+import (
+	"context"
+	"sync"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/internal/eventbus"
+	"github.com/tendermint/tendermint/internal/pubsub"
+	sm "github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/service"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	subscriber = "DowntimeTracker"
+
+	// capacity of the new block subscription
+	subscriptionLimit = 100
+)
+
+// Alert reports that the miss rate of the validator exceeded the threshold, or
+// fell back below it.
+type Alert struct {
+	ValidatorAddress crypto.Address `json:"validator_address"`
+	// Height is the height of the last block checked for the precommit of the
+	// validator.
+	Height int64 `json:"height"`
+	// Missed is the number of blocks missed by the validator in the window.
+	Missed    int     `json:"missed"`
+	Window    int     `json:"window"`
+	MissRate  float64 `json:"miss_rate"`
+	Threshold float64 `json:"threshold"`
+	// Firing is true when the miss rate exceeded the threshold, and false when
+	// it fell back below it.
+	Firing bool `json:"firing"`
+}
+
+// AlertHandler is called with the alerts raised by a Tracker. It's called
+// synchronously, as blocks are committed, so it must not block.
+type AlertHandler func(Alert)
+
+// Tracker tracks the blocks missed by a validator over a sliding window of the
+// blocks it was expected to sign, i.e. those committed while it was in the
+// validator set.
+type Tracker struct {
+	service.BaseService
+	logger log.Logger
+
+	address    crypto.Address
+	threshold  float64
+	eventBus   *eventbus.EventBus
+	stateStore sm.Store
+	blockStore sm.BlockStore
+	metrics    *Metrics
+	handlers   []AlertHandler
+
+	mtx        sync.Mutex
+	missed     []bool // ring buffer of the window, by height
+	next       int    // index of the next block in missed
+	missCount  int
+	lastHeight int64 // height of the last block checked
+	firing     bool
+}
+
+// NewTracker returns a tracker of the blocks missed by the validator with the
+// given address, configured by cfg, which calls the handlers with its alerts.
+// The blocks already in the block store fill the window when it starts.
+func NewTracker(
+	logger log.Logger,
+	cfg *config.DowntimeConfig,
+	address crypto.Address,
+	eventBus *eventbus.EventBus,
+	stateStore sm.Store,
+	blockStore sm.BlockStore,
+	metrics *Metrics,
+	handlers ...AlertHandler,
+) *Tracker {
+	t := &Tracker{
+		logger:     logger,
+		address:    address,
+		threshold:  cfg.AlertThreshold,
+		eventBus:   eventBus,
+		stateStore: stateStore,
+		blockStore: blockStore,
+		metrics:    metrics,
+		handlers:   handlers,
+		missed:     make([]bool, cfg.Window),
+	}
+	t.BaseService = *service.NewBaseService(logger, "DowntimeTracker", t)
+	return t
+}
+
+// OnStart implements service.Service by filling the window with the blocks in
+// the block store, and subscribing to the blocks committed from then on.
+func (t *Tracker) OnStart(ctx context.Context) error {
+	from := t.blockStore.Height() - int64(len(t.missed))
+	if base := t.blockStore.Base(); from < base {
+		from = base
+	}
+	// the commit of the latest block is only canonical once the next block is
+	// committed
+	for height := from; height > 0 && height < t.blockStore.Height(); height++ {
+		if commit := t.blockStore.LoadBlockCommit(height); commit != nil {
+			t.checkCommit(commit)
+		}
+	}
+
+	// the tracking is auxiliary, so a failure to subscribe is logged
+	// rather than failing the node
+	go t.processBlocks(ctx)
+	return nil
+}
+
+func (t *Tracker) subscribe(ctx context.Context) (eventbus.Subscription, error) {
+	return t.eventBus.SubscribeWithArgs(ctx, pubsub.SubscribeArgs{
+		ClientID: subscriber,
+		Query:    types.EventQueryNewBlock,
+		Limit:    subscriptionLimit,
+	})
+}
+
+// processBlocks checks the last commit of the new blocks, until ctx is done.
+// The subscription is renewed if the tracker falls so far behind that it's
+// terminated.
+func (t *Tracker) processBlocks(ctx context.Context) {
+	sub, err := t.subscribe(ctx)
+	if err != nil {
+		t.logger.Error("failed to subscribe to new blocks", "err", err)
+		return
+	}
+	for {
+		msg, err := sub.Next(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			t.logger.Error("new block subscription failed, resubscribing", "err", err)
+			if sub, err = t.subscribe(ctx); err != nil {
+				t.logger.Error("failed to subscribe to new blocks", "err", err)
+				return
+			}
+			continue
+		}
+		block := msg.Data().(types.EventDataNewBlock).Block
+		if block != nil && block.LastCommit != nil {
+			t.checkCommit(block.LastCommit)
+		}
+	}
+}
+
+// OnStop implements service.Service.
+func (t *Tracker) OnStop() {}
+
+// checkCommit records whether the validator signed the given commit, if it was
+// in the validator set at its height.
+func (t *Tracker) checkCommit(commit *types.Commit) {
+	if commit.Height <= 0 {
+		return // the last commit of the initial block is empty
+	}
+	vals, err := t.stateStore.LoadValidators(commit.Height)
+	if err != nil {
+		t.logger.Error("failed to load validators", "height", commit.Height, "err", err)
+		return
+	}
+	idx, val := vals.GetByAddress(t.address)
+	if val == nil || int(idx) >= len(commit.Signatures) {
+		return
+	}
+	t.record(commit.Height, commit.Signatures[idx].Absent())
+}
+
+// record records whether the validator missed the block at the given height,
+// and raises an alert if the miss rate crossed the threshold.
+func (t *Tracker) record(height int64, missed bool) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	if height <= t.lastHeight {
+		return
+	}
+	t.lastHeight = height
+
+	if t.missed[t.next] {
+		t.missCount--
+	}
+	t.missed[t.next] = missed
+	if missed {
+		t.missCount++
+		t.logger.Debug("validator missed block", "height", height, "missed", t.missCount)
+	}
+	t.next = (t.next + 1) % len(t.missed)
+
+	missRate := float64(t.missCount) / float64(len(t.missed))
+	t.metrics.MissedBlocks.Set(float64(t.missCount))
+	t.metrics.MissRate.Set(missRate)
+
+	firing := missRate > t.threshold
+	if firing == t.firing {
+		return
+	}
+	t.firing = firing
+
+	alert := Alert{
+		ValidatorAddress: t.address,
+		Height:           height,
+		Missed:           t.missCount,
+		Window:           len(t.missed),
+		MissRate:         missRate,
+		Threshold:        t.threshold,
+		Firing:           firing,
+	}
+	if firing {
+		t.metrics.Alerts.Add(1)
+		t.logger.Error("validator miss rate exceeds the threshold",
+			"height", height, "missed", t.missCount, "window", len(t.missed),
+			"miss_rate", missRate, "threshold", t.threshold)
+	} else {
+		t.logger.Info("validator miss rate is back below the threshold",
+			"height", height, "missed", t.missCount, "window", len(t.missed),
+			"miss_rate", missRate, "threshold", t.threshold)
+	}
+	for _, handler := range t.handlers {
+		handler(alert)
+	}
+}
@@ -0,0 +1,132 @@
+package downtime
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/internal/eventbus"
+	"github.com/tendermint/tendermint/internal/state/mocks"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestTracker(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	logger := log.TestingLogger()
+
+	ours := types.NewValidator(ed25519.GenPrivKey().PubKey(), 10)
+	other := types.NewValidator(ed25519.GenPrivKey().PubKey(), 10)
+	vals := types.NewValidatorSet([]*types.Validator{ours, other})
+	idx, _ := vals.GetByAddress(ours.Address)
+
+	// makeCommit returns the commit at the given height, signed by us or not
+	makeCommit := func(height int64, signed bool) *types.Commit {
+		sigs := make([]types.CommitSig, vals.Size())
+		for i, val := range vals.Validators {
+			sigs[i] = types.NewCommitSigForBlock([]byte("signature"), val.Address, time.Now())
+		}
+		if !signed {
+			sigs[idx] = types.NewCommitSigAbsent()
+		}
+		return types.NewCommit(height, 0, types.BlockID{}, sigs)
+	}
+
+	stateStore := &mocks.Store{}
+	stateStore.On("LoadValidators", mock.Anything).Return(vals, nil)
+	// the blocks 1 to 3 are in the block store, and we missed the block 2
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("Base").Return(int64(1))
+	blockStore.On("Height").Return(int64(3))
+	blockStore.On("LoadBlockCommit", int64(1)).Return(makeCommit(1, true))
+	blockStore.On("LoadBlockCommit", int64(2)).Return(makeCommit(2, false))
+
+	eventBus := eventbus.NewDefault(logger)
+	require.NoError(t, eventBus.Start(ctx))
+
+	alerts := make(chan Alert, 10)
+	webhookAlerts := make(chan Alert, 10)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var alert Alert
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&alert))
+		webhookAlerts <- alert
+	}))
+	defer webhook.Close()
+
+	cfg := &config.DowntimeConfig{Window: 4, AlertThreshold: 0.5}
+	tracker := NewTracker(logger, cfg, ours.Address, eventBus, stateStore, blockStore, NopMetrics(),
+		func(alert Alert) { alerts <- alert },
+		WebhookAlertHandler(logger, webhook.URL))
+	require.NoError(t, tracker.Start(ctx))
+	require.Eventually(t, func() bool { return eventBus.NumClientSubscriptions(subscriber) == 1 },
+		time.Second, 10*time.Millisecond)
+
+	lastHeight := func() int64 {
+		tracker.mtx.Lock()
+		defer tracker.mtx.Unlock()
+		return tracker.lastHeight
+	}
+	publish := func(height int64, signed bool) {
+		require.NoError(t, eventBus.PublishEventNewBlock(ctx, types.EventDataNewBlock{
+			Block: &types.Block{LastCommit: makeCommit(height, signed)},
+		}))
+	}
+	// commit publishes the block and waits for the tracker to check it
+	commit := func(height int64, signed bool) {
+		publish(height, signed)
+		require.Eventually(t, func() bool { return lastHeight() == height }, time.Second, 10*time.Millisecond)
+	}
+
+	// missing 3 of the last 4 blocks exceeds the threshold
+	commit(3, false)
+	require.Empty(t, alerts)
+	publish(3, false) // already counted
+	commit(4, false)
+	select {
+	case alert := <-alerts:
+		require.True(t, alert.Firing)
+		require.Equal(t, int64(4), alert.Height)
+		require.Equal(t, 3, alert.Missed)
+		require.Equal(t, 4, alert.Window)
+		require.Equal(t, 0.75, alert.MissRate)
+		require.Equal(t, ours.Address, alert.ValidatorAddress)
+	case <-time.After(time.Second):
+		t.Fatal("no alert raised")
+	}
+	select {
+	case alert := <-webhookAlerts:
+		require.True(t, alert.Firing)
+		require.Equal(t, 3, alert.Missed)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no alert sent to the webhook")
+	}
+
+	// the alert is raised once, and resolved when the missed blocks slide out
+	// of the window
+	commit(5, true)
+	require.Empty(t, alerts)
+	commit(6, true)
+	select {
+	case alert := <-alerts:
+		require.False(t, alert.Firing)
+		require.Equal(t, int64(6), alert.Height)
+		require.Equal(t, 2, alert.Missed)
+	case <-time.After(time.Second):
+		t.Fatal("no alert raised")
+	}
+
+	// the blocks we aren't expected to sign aren't counted
+	stateStore.ExpectedCalls = nil
+	stateStore.On("LoadValidators", mock.Anything).Return(types.NewValidatorSet([]*types.Validator{other}), nil)
+	tracker.checkCommit(makeCommit(7, false))
+	require.Equal(t, int64(6), lastHeight())
+}
@@ -0,0 +1,51 @@
+package downtime
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// webhookTimeout bounds the POST of an alert to a webhook.
+const webhookTimeout = 10 * time.Second
+
+// WebhookAlertHandler returns an alert handler POSTing the alerts as JSON to
+// the given URL, e.g. that of a paging service. The requests are sent in the
+// background, and their failures logged.
+func WebhookAlertHandler(logger log.Logger, url string) AlertHandler {
+	client := &http.Client{Timeout: webhookTimeout}
+	return func(alert Alert) {
+		go func() {
+			if err := postAlert(client, url, alert); err != nil {
+				logger.Error("failed to send downtime alert to webhook", "url", url, "err", err)
+			}
+		}()
+	}
+}
+
+func postAlert(client *http.Client, url string, alert Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", res.Status)
+	}
+	return nil
+}
@@ -131,7 +131,15 @@ func (b *EventBus) PublishEventStateSyncStatus(ctx context.Context, data types.E
 // predefined keys (EventTypeKey, TxHashKey). Existing events with the same keys
 // will be overwritten.
 func (b *EventBus) PublishEventTx(ctx context.Context, data types.EventDataTx) error {
-	events := data.Result.Events
+	return b.pubsub.PublishWithEvents(ctx, data, TxEvents(data))
+}
+
+// TxEvents returns the events a transaction result is published with: those
+// of the result, and the Tendermint-reserved ones of its type, hash and
+// height.
+func TxEvents(data types.EventDataTx) []abci.Event {
+	events := make([]abci.Event, 0, len(data.Result.Events)+3)
+	events = append(events, data.Result.Events...)
 
 	// add Tendermint-reserved events
 	events = append(events, types.EventTx)
@@ -148,7 +156,7 @@ func (b *EventBus) PublishEventTx(ctx context.Context, data types.EventDataTx) e
 	})
 
 	tokens = strings.Split(types.TxHeightKey, ".")
-	events = append(events, abci.Event{
+	return append(events, abci.Event{
 		Type: tokens[0],
 		Attributes: []abci.EventAttribute{
 			{
@@ -157,8 +165,6 @@ func (b *EventBus) PublishEventTx(ctx context.Context, data types.EventDataTx) e
 			},
 		},
 	})
-
-	return b.pubsub.PublishWithEvents(ctx, data, events)
 }
 
 func (b *EventBus) PublishEventNewRoundStep(ctx context.Context, data types.EventDataRoundState) error {
@@ -201,6 +207,92 @@ func (b *EventBus) PublishEventValidatorSetUpdates(ctx context.Context, data typ
 	return b.Publish(ctx, types.EventValidatorSetUpdatesValue, data)
 }
 
+// PublishEventValidatorSetChange publishes a validator set change event with
+// the predefined keys EventTypeKey and ValidatorAddressKey, the latter set to
+// the address of each changed validator.
+func (b *EventBus) PublishEventValidatorSetChange(ctx context.Context, data types.EventDataValidatorSetChange) error {
+	events := []abci.Event{types.EventValidatorSetChange}
+
+	tokens := strings.Split(types.ValidatorAddressKey, ".")
+	for _, changes := range [][]types.ValidatorPowerChange{
+		data.ValidatorSetChange.Added,
+		data.ValidatorSetChange.Updated,
+		data.ValidatorSetChange.Removed,
+	} {
+		for _, change := range changes {
+			events = append(events, abci.Event{
+				Type: tokens[0],
+				Attributes: []abci.EventAttribute{
+					{
+						Key:   tokens[1],
+						Value: change.Address.String(),
+					},
+				},
+			})
+		}
+	}
+
+	return b.pubsub.PublishWithEvents(ctx, data, events)
+}
+
+// PublishEventConsensusStep publishes a consensus step event with the
+// predefined keys EventTypeKey, ConsensusHeightKey, ConsensusRoundKey and
+// ConsensusStepKey.
+func (b *EventBus) PublishEventConsensusStep(ctx context.Context, data types.EventDataConsensusStep) error {
+	events := consensusEvents(types.EventConsensusStepValue, data.Height, data.Round)
+	events = append(events, reservedEvent(types.ConsensusStepKey, data.Step))
+
+	return b.pubsub.PublishWithEvents(ctx, data, events)
+}
+
+// PublishEventConsensusVote publishes a consensus vote event with the
+// predefined keys EventTypeKey, ConsensusHeightKey, ConsensusRoundKey and,
+// for votes received from a peer, ConsensusPeerKey.
+func (b *EventBus) PublishEventConsensusVote(ctx context.Context, data types.EventDataConsensusVote) error {
+	events := consensusEvents(types.EventConsensusVoteValue, data.Height, data.Round)
+	if data.PeerID != "" {
+		events = append(events, reservedEvent(types.ConsensusPeerKey, string(data.PeerID)))
+	}
+
+	return b.pubsub.PublishWithEvents(ctx, data, events)
+}
+
+// PublishEventConsensusBlockPart publishes a consensus block part event with
+// the predefined keys EventTypeKey, ConsensusHeightKey, ConsensusRoundKey and,
+// for block parts received from a peer, ConsensusPeerKey.
+func (b *EventBus) PublishEventConsensusBlockPart(ctx context.Context, data types.EventDataConsensusBlockPart) error {
+	events := consensusEvents(types.EventConsensusBlockPartValue, data.Height, data.Round)
+	if data.PeerID != "" {
+		events = append(events, reservedEvent(types.ConsensusPeerKey, string(data.PeerID)))
+	}
+
+	return b.pubsub.PublishWithEvents(ctx, data, events)
+}
+
+// consensusEvents returns the reserved events shared by the consensus
+// firehose events.
+func consensusEvents(eventValue string, height int64, round int32) []abci.Event {
+	return []abci.Event{
+		reservedEvent(types.EventTypeKey, eventValue),
+		reservedEvent(types.ConsensusHeightKey, fmt.Sprintf("%d", height)),
+		reservedEvent(types.ConsensusRoundKey, fmt.Sprintf("%d", round)),
+	}
+}
+
+// reservedEvent returns an event setting the reserved composite key to value.
+func reservedEvent(compositeKey, value string) abci.Event {
+	tokens := strings.Split(compositeKey, ".")
+	return abci.Event{
+		Type: tokens[0],
+		Attributes: []abci.EventAttribute{
+			{
+				Key:   tokens[1],
+				Value: value,
+			},
+		},
+	}
+}
+
 //-----------------------------------------------------------------------------
 
 // NopEventBus implements a types.BlockEventPublisher that discards all events.
@@ -225,3 +317,7 @@ func (NopEventBus) PublishEventTx(context.Context, types.EventDataTx) error {
 func (NopEventBus) PublishEventValidatorSetUpdates(context.Context, types.EventDataValidatorSetUpdates) error {
 	return nil
 }
+
+func (NopEventBus) PublishEventValidatorSetChange(context.Context, types.EventDataValidatorSetChange) error {
+	return nil
+}
@@ -340,6 +340,51 @@ func TestEventBusPublishEventNewEvidence(t *testing.T) {
 	}
 }
 
+func TestEventBusPublishEventConsensusFirehose(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	eventBus := eventbus.NewDefault(log.TestingLogger())
+	err := eventBus.Start(ctx)
+	require.NoError(t, err)
+
+	const query = `consensus.height = 3 AND consensus.peer = 'peer'`
+	sub, err := eventBus.SubscribeWithArgs(ctx, tmpubsub.SubscribeArgs{
+		ClientID: "test",
+		Query:    tmquery.MustCompile(query),
+		Limit:    2,
+	})
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		msg, err := sub.Next(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, types.EventDataConsensusVote{Height: 3, Round: 1, PeerID: "peer"}, msg.Data())
+
+		msg, err = sub.Next(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, types.EventDataConsensusBlockPart{Height: 3, Index: 2, PeerID: "peer"}, msg.Data())
+	}()
+
+	// neither of another height nor from another peer
+	require.NoError(t, eventBus.PublishEventConsensusStep(ctx, types.EventDataConsensusStep{Height: 3}))
+	require.NoError(t, eventBus.PublishEventConsensusVote(ctx, types.EventDataConsensusVote{Height: 2, PeerID: "peer"}))
+	require.NoError(t, eventBus.PublishEventConsensusVote(ctx, types.EventDataConsensusVote{Height: 3}))
+
+	require.NoError(t, eventBus.PublishEventConsensusVote(ctx,
+		types.EventDataConsensusVote{Height: 3, Round: 1, PeerID: "peer"}))
+	require.NoError(t, eventBus.PublishEventConsensusBlockPart(ctx,
+		types.EventDataConsensusBlockPart{Height: 3, Index: 2, PeerID: "peer"}))
+
+	select {
+	case <-done:
+	case <-time.After(1 * time.Second):
+		t.Fatal("did not receive the consensus events after 1 sec.")
+	}
+}
+
 func TestEventBusPublish(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -348,7 +393,7 @@ func TestEventBusPublish(t *testing.T) {
 	err := eventBus.Start(ctx)
 	require.NoError(t, err)
 
-	const numEventsExpected = 14
+	const numEventsExpected = 15
 
 	sub, err := eventBus.SubscribeWithArgs(ctx, tmpubsub.SubscribeArgs{
 		ClientID: "test",
@@ -386,6 +431,7 @@ func TestEventBusPublish(t *testing.T) {
 	require.NoError(t, eventBus.PublishEventRelock(ctx, types.EventDataRoundState{}))
 	require.NoError(t, eventBus.PublishEventLock(ctx, types.EventDataRoundState{}))
 	require.NoError(t, eventBus.PublishEventValidatorSetUpdates(ctx, types.EventDataValidatorSetUpdates{}))
+	require.NoError(t, eventBus.PublishEventValidatorSetChange(ctx, types.EventDataValidatorSetChange{}))
 	require.NoError(t, eventBus.PublishEventBlockSyncStatus(ctx, types.EventDataBlockSyncStatus{}))
 	require.NoError(t, eventBus.PublishEventStateSyncStatus(ctx, types.EventDataStateSyncStatus{}))
 
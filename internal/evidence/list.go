@@ -0,0 +1,161 @@
+package evidence
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/gogo/protobuf/proto"
+	gogotypes "github.com/gogo/protobuf/types"
+	"github.com/google/orderedcode"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+// Evidence types, see Filter.Type.
+const (
+	TypeDuplicateVote     = "duplicate_vote"
+	TypeLightClientAttack = "light_client_attack"
+)
+
+// Filter selects the evidence listed by ListEvidence. Zero values select any
+// evidence.
+type Filter struct {
+	// Committed lists committed rather than pending evidence.
+	Committed bool
+	// Type is TypeDuplicateVote or TypeLightClientAttack.
+	Type string
+	// MinHeight and MaxHeight bound the height of the misbehavior.
+	MinHeight int64
+	MaxHeight int64
+	// ValidatorAddress is the address of a misbehaving validator.
+	ValidatorAddress types.Address
+}
+
+// ListedEvidence is evidence listed by ListEvidence.
+type ListedEvidence struct {
+	Evidence types.Evidence
+	// CommitHeight is the height of the block which committed the evidence,
+	// or 0 if it is pending.
+	CommitHeight int64
+}
+
+// ListEvidence lists the pending or committed evidence selected by the filter,
+// in ascending order of height. Committed evidence is loaded from the blocks
+// which committed it, and skipped once they are pruned.
+func (evpool *Pool) ListEvidence(filter Filter) ([]ListedEvidence, error) {
+	if filter.Type != "" && filter.Type != TypeDuplicateVote && filter.Type != TypeLightClientAttack {
+		return nil, fmt.Errorf("unknown evidence type %q", filter.Type)
+	}
+
+	prefix := prefixPending
+	if filter.Committed {
+		prefix = prefixCommitted
+	}
+	start, err := orderedcode.Append(nil, prefix, filter.MinHeight)
+	if err != nil {
+		return nil, err
+	}
+	end := prefixToBytes(prefix + 1)
+	if filter.MaxHeight > 0 {
+		if end, err = orderedcode.Append(nil, prefix, filter.MaxHeight+1); err != nil {
+			return nil, err
+		}
+	}
+
+	iter, err := evpool.evidenceStore.Iterator(start, end)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer iter.Close()
+
+	var (
+		listed []ListedEvidence
+		block  *types.Block // the last block loaded for committed evidence
+	)
+	for ; iter.Valid(); iter.Next() {
+		item := ListedEvidence{}
+		if filter.Committed {
+			var height gogotypes.Int64Value
+			if err := proto.Unmarshal(iter.Value(), &height); err != nil {
+				return nil, err
+			}
+			item.CommitHeight = height.Value
+			if block == nil || block.Height != height.Value {
+				block = evpool.blockStore.LoadBlock(height.Value)
+			}
+			item.Evidence, err = committedEvidence(block, iter.Key())
+			if err != nil {
+				return nil, err
+			}
+			if item.Evidence == nil {
+				continue
+			}
+		} else {
+			item.Evidence, err = bytesToEv(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if filter.Type != "" && evidenceType(item.Evidence) != filter.Type {
+			continue
+		}
+		if len(filter.ValidatorAddress) > 0 && !hasValidator(item.Evidence, filter.ValidatorAddress) {
+			continue
+		}
+		listed = append(listed, item)
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+
+	return listed, nil
+}
+
+// committedEvidence returns the evidence of the committed key from the block
+// which committed it, or nil if the block is pruned.
+func committedEvidence(block *types.Block, key []byte) (types.Evidence, error) {
+	var (
+		prefix, height int64
+		hash           string
+	)
+	if _, err := orderedcode.Parse(string(key), &prefix, &height, &hash); err != nil {
+		return nil, fmt.Errorf("invalid committed evidence key: %w", err)
+	}
+	if block == nil {
+		return nil, nil
+	}
+	for _, ev := range block.Evidence.Evidence {
+		if bytes.Equal(ev.Hash(), []byte(hash)) {
+			return ev, nil
+		}
+	}
+	return nil, fmt.Errorf("committed evidence %X is missing from block %d", hash, block.Height)
+}
+
+// evidenceType returns the type of the evidence, see Filter.Type.
+func evidenceType(ev types.Evidence) string {
+	switch ev.(type) {
+	case *types.DuplicateVoteEvidence:
+		return TypeDuplicateVote
+	case *types.LightClientAttackEvidence:
+		return TypeLightClientAttack
+	default:
+		return fmt.Sprintf("%T", ev)
+	}
+}
+
+// hasValidator returns whether the validator with the address misbehaved.
+func hasValidator(ev types.Evidence, address types.Address) bool {
+	switch ev := ev.(type) {
+	case *types.DuplicateVoteEvidence:
+		return bytes.Equal(ev.VoteA.ValidatorAddress, address)
+	case *types.LightClientAttackEvidence:
+		for _, val := range ev.ByzantineValidators {
+			if bytes.Equal(val.Address, address) {
+				return true
+			}
+		}
+	}
+	return false
+}
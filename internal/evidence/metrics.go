@@ -0,0 +1,56 @@
+package evidence
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "evidence"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Number of pending evidence in the pool.
+	NumEvidence metrics.Gauge
+
+	// Number of evidence which failed verification, by evidence type.
+	VerificationFailures metrics.Counter
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		NumEvidence: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "num_evidence",
+			Help:      "Number of pending evidence in the pool.",
+		}, labels).With(labelsAndValues...),
+
+		VerificationFailures: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "verification_failures",
+			Help:      "Number of evidence which failed verification, by evidence type.",
+		}, append(labels, "type")).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		NumEvidence:          discard.NewGauge(),
+		VerificationFailures: discard.NewCounter(),
+	}
+}
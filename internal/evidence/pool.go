@@ -49,11 +49,27 @@ type Pool struct {
 
 	pruningHeight int64
 	pruningTime   time.Time
+
+	metrics *Metrics
+}
+
+// PoolOption sets an optional parameter on the Pool.
+type PoolOption func(*Pool)
+
+// WithMetrics sets the pool's metrics collector.
+func WithMetrics(metrics *Metrics) PoolOption {
+	return func(evpool *Pool) { evpool.metrics = metrics }
 }
 
 // NewPool creates an evidence pool. If using an existing evidence store,
 // it will add all pending evidence to the concurrent list.
-func NewPool(logger log.Logger, evidenceDB dbm.DB, stateDB sm.Store, blockStore BlockStore) (*Pool, error) {
+func NewPool(
+	logger log.Logger,
+	evidenceDB dbm.DB,
+	stateDB sm.Store,
+	blockStore BlockStore,
+	options ...PoolOption,
+) (*Pool, error) {
 	state, err := stateDB.Load()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load state: %w", err)
@@ -67,6 +83,10 @@ func NewPool(logger log.Logger, evidenceDB dbm.DB, stateDB sm.Store, blockStore
 		evidenceStore:   evidenceDB,
 		evidenceList:    clist.New(),
 		consensusBuffer: make([]duplicateVoteSet, 0),
+		metrics:         NopMetrics(),
+	}
+	for _, option := range options {
+		option(pool)
 	}
 
 	// If pending evidence already in db, in event of prior failure, then check
@@ -78,6 +98,7 @@ func NewPool(logger log.Logger, evidenceDB dbm.DB, stateDB sm.Store, blockStore
 	}
 
 	atomic.StoreUint32(&pool.evidenceSize, uint32(len(evList)))
+	pool.metrics.NumEvidence.Set(float64(len(evList)))
 
 	for _, ev := range evList {
 		pool.evidenceList.PushBack(ev)
@@ -161,6 +182,7 @@ func (evpool *Pool) AddEvidence(ev types.Evidence) error {
 
 	// 1) Verify against state.
 	if err := evpool.verify(ev); err != nil {
+		evpool.metrics.VerificationFailures.With("type", evidenceType(ev)).Add(1)
 		return err
 	}
 
@@ -214,6 +236,7 @@ func (evpool *Pool) CheckEvidence(evList types.EvidenceList) error {
 
 			err := evpool.verify(ev)
 			if err != nil {
+				evpool.metrics.VerificationFailures.With("type", evidenceType(ev)).Add(1)
 				return err
 			}
 
@@ -316,6 +339,7 @@ func (evpool *Pool) addPendingEvidence(ev types.Evidence) error {
 	}
 
 	atomic.AddUint32(&evpool.evidenceSize, 1)
+	evpool.metrics.NumEvidence.Set(float64(evpool.Size()))
 	return nil
 }
 
@@ -368,6 +392,7 @@ func (evpool *Pool) markEvidenceAsCommitted(evidence types.EvidenceList, height
 
 	// update the evidence size
 	atomic.AddUint32(&evpool.evidenceSize, ^uint32(len(blockEvidenceMap)-1))
+	evpool.metrics.NumEvidence.Set(float64(evpool.Size()))
 }
 
 // listEvidence retrieves lists evidence from oldest to newest within maxBytes.
@@ -448,6 +473,7 @@ func (evpool *Pool) removeExpiredPendingEvidence() (int64, time.Time) {
 
 	// update the evidence size
 	atomic.AddUint32(&evpool.evidenceSize, ^uint32(len(blockEvidenceMap)-1))
+	evpool.metrics.NumEvidence.Set(float64(evpool.Size()))
 
 	return height, time
 }
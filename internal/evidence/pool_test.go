@@ -86,6 +86,69 @@ func TestEvidencePoolBasic(t *testing.T) {
 	require.Equal(t, 1, len(evs))
 }
 
+func TestEvidencePoolListEvidence(t *testing.T) {
+	var (
+		height     = int64(10)
+		stateStore = &smmocks.Store{}
+		blockStore = &mocks.BlockStore{}
+	)
+
+	valSet, privVals := factory.RandValidatorSet(1, 10)
+	valAddr := valSet.Validators[0].Address
+
+	blockStore.On("LoadBlockMeta", mock.AnythingOfType("int64")).Return(
+		&types.BlockMeta{Header: types.Header{Time: defaultEvidenceTime}},
+	)
+	stateStore.On("LoadValidators", mock.AnythingOfType("int64")).Return(valSet, nil)
+	stateStore.On("Load").Return(createState(height, valSet), nil)
+
+	pool, err := evidence.NewPool(log.TestingLogger(), dbm.NewMemDB(), stateStore, blockStore)
+	require.NoError(t, err)
+
+	evs := make([]types.Evidence, 3)
+	for i := range evs {
+		evs[i] = types.NewMockDuplicateVoteEvidenceWithValidator(
+			int64(i+1), defaultEvidenceTime, privVals[0], evidenceChainID)
+		require.NoError(t, pool.AddEvidence(evs[i]))
+	}
+
+	listEvidence := func(filter evidence.Filter) []types.Evidence {
+		listed, err := pool.ListEvidence(filter)
+		require.NoError(t, err)
+		var listedEvs []types.Evidence
+		for _, item := range listed {
+			listedEvs = append(listedEvs, item.Evidence)
+		}
+		return listedEvs
+	}
+
+	assert.Equal(t, evs, listEvidence(evidence.Filter{}))
+	assert.Equal(t, evs[1:], listEvidence(evidence.Filter{MinHeight: 2}))
+	assert.Equal(t, evs[1:2], listEvidence(evidence.Filter{MinHeight: 2, MaxHeight: 2}))
+	assert.Equal(t, evs, listEvidence(evidence.Filter{Type: evidence.TypeDuplicateVote}))
+	assert.Empty(t, listEvidence(evidence.Filter{Type: evidence.TypeLightClientAttack}))
+	assert.Equal(t, evs, listEvidence(evidence.Filter{ValidatorAddress: valAddr}))
+	assert.Empty(t, listEvidence(evidence.Filter{ValidatorAddress: types.NewMockPV().PrivKey.PubKey().Address()}))
+	assert.Empty(t, listEvidence(evidence.Filter{Committed: true}))
+
+	_, err = pool.ListEvidence(evidence.Filter{Type: "unknown"})
+	require.Error(t, err)
+
+	// commit the second evidence in the next block
+	block := types.MakeBlock(height+1, []types.Tx{}, makeCommit(height, valAddr), []types.Evidence{evs[1]})
+	blockStore.On("LoadBlock", height+1).Return(block)
+	state := pool.State()
+	state.LastBlockHeight = height + 1
+	pool.Update(state, block.Evidence.Evidence)
+
+	assert.Equal(t, []types.Evidence{evs[0], evs[2]}, listEvidence(evidence.Filter{}))
+	listed, err := pool.ListEvidence(evidence.Filter{Committed: true})
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, evs[1], listed[0].Evidence)
+	assert.Equal(t, height+1, listed[0].CommitHeight)
+}
+
 // Tests inbound evidence for the right time and height
 func TestAddExpiredEvidence(t *testing.T) {
 	var (
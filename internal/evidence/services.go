@@ -8,6 +8,7 @@ import (
 
 type BlockStore interface {
 	LoadBlockMeta(height int64) *types.BlockMeta
+	LoadBlock(height int64) *types.Block
 	LoadBlockCommit(height int64) *types.Commit
 	Height() int64
 }
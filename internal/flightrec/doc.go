@@ -0,0 +1,17 @@
+/*
+Package flightrec implements the flight recorder of the node, which
+continuously records the state of the node, so that transient incidents, e.g.
+a stall of consensus or a burst of peer disconnections, can be diagnosed after
+the fact.
+
+Every interval, the recorder writes a recording of the goroutines of the node
+and of its sources, e.g. the consensus state and the p2p metrics, as a zip file
+to a ring on disk keeping the last recordings. A source blocked for too long,
+e.g. by a deadlock, is recorded as such rather than stalling the recorder.
+
+The ring is dumped to a zip file of its own, kept apart from the ring, on
+demand, when consensus fails, and when the node starts after it didn't stop
+cleanly, e.g. after a panic, in which case the last recordings before the
+crash are dumped. The ring of a stopped node can also be exported with Export.
+*/
+package flightrec
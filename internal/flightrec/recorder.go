@@ -0,0 +1,359 @@
+package flightrec
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/service"
+)
+
+const (
+	// DirName is the name of the directory of the flight recorder, in the
+	// data directory of the node.
+	DirName = "flight_recorder"
+
+	// GoroutineFile is the file of the goroutine dump in the recordings.
+	GoroutineFile = "goroutine.txt"
+
+	// sourceTimeout is how long a source is waited for, before it's recorded
+	// as blocked.
+	sourceTimeout = 5 * time.Second
+
+	ringDir     = "ring"
+	dumpsDir    = "dumps"
+	runningFile = "running"
+	zipSuffix   = ".zip"
+	errorSuffix = ".error"
+	timeFormat  = "20060102T150405.000000000Z"
+)
+
+// The reasons of the dumps of the ring, suffixing the names of their files.
+const (
+	DumpOnDemand         = "on-demand"
+	DumpConsensusFailure = "consensus-failure"
+	DumpUncleanShutdown  = "unclean-shutdown"
+)
+
+// Source writes a view of the node to w, e.g. its consensus state as JSON,
+// which is recorded as a file of the recordings.
+type Source func(ctx context.Context, w io.Writer) error
+
+type source struct {
+	name string
+	fn   Source
+	busy uint32 // 1 while a call of fn is in progress
+}
+
+// Recorder records the goroutines of the node and its sources every interval
+// to a ring of the last recordings on disk, which it dumps on demand and when
+// the node failed.
+type Recorder struct {
+	service.BaseService
+	logger log.Logger
+
+	dir           string
+	interval      time.Duration
+	maxRecordings int
+	maxDumps      int
+	timeout       time.Duration
+	sources       []*source
+
+	mtx sync.Mutex // serializes the recordings and dumps
+}
+
+// NewRecorder returns a flight recorder configured by cfg, recording the
+// goroutines and the given sources, by file name, to the ring in dir.
+func NewRecorder(
+	logger log.Logger,
+	cfg *config.FlightRecorderConfig,
+	dir string,
+	sources map[string]Source,
+) *Recorder {
+	r := &Recorder{
+		logger:        logger,
+		dir:           dir,
+		interval:      cfg.Interval,
+		maxRecordings: cfg.MaxRecordings,
+		maxDumps:      cfg.MaxDumps,
+		timeout:       sourceTimeout,
+		sources:       []*source{{name: GoroutineFile, fn: writeGoroutines}},
+	}
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		r.sources = append(r.sources, &source{name: name, fn: sources[name]})
+	}
+	r.BaseService = *service.NewBaseService(logger, "FlightRecorder", r)
+	return r
+}
+
+// OnStart implements service.Service by dumping the ring if the node didn't
+// stop cleanly since it was last started, and recording every interval from
+// then on.
+func (r *Recorder) OnStart(ctx context.Context) error {
+	for _, dir := range []string{ringDir, dumpsDir} {
+		if err := os.MkdirAll(filepath.Join(r.dir, dir), 0755); err != nil {
+			return err
+		}
+	}
+
+	// the running file is only removed once the recorder is stopped
+	running := filepath.Join(r.dir, runningFile)
+	if _, err := os.Stat(running); err == nil {
+		r.logger.Error("node didn't stop cleanly, dumping the flight recorder")
+		if _, err := r.dump(DumpUncleanShutdown, time.Now()); err != nil {
+			r.logger.Error("failed to dump the flight recorder", "err", err)
+		}
+	}
+	if err := os.WriteFile(running, []byte(time.Now().UTC().Format(time.RFC3339)), 0644); err != nil {
+		return err
+	}
+
+	go r.recordRoutine(ctx)
+	return nil
+}
+
+// OnStop implements service.Service by marking the node as stopped cleanly.
+func (r *Recorder) OnStop() {
+	if err := os.Remove(filepath.Join(r.dir, runningFile)); err != nil {
+		r.logger.Error("failed to remove the running file", "err", err)
+	}
+}
+
+func (r *Recorder) recordRoutine(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Record(ctx); err != nil {
+				r.logger.Error("failed to record", "err", err)
+			}
+		}
+	}
+}
+
+// Record writes a recording to the ring, removing the oldest recordings
+// beyond max-recordings.
+func (r *Recorder) Record(ctx context.Context) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	return r.record(ctx, time.Now())
+}
+
+// Dump writes a recording to the ring, and dumps the ring to a zip file for
+// the given reason, returning its path. The oldest dumps beyond max-dumps are
+// removed.
+func (r *Recorder) Dump(ctx context.Context, reason string) (string, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	now := time.Now()
+	if err := r.record(ctx, now); err != nil {
+		r.logger.Error("failed to record before dumping", "err", err)
+	}
+	return r.dump(reason, now)
+}
+
+func (r *Recorder) record(ctx context.Context, now time.Time) error {
+	name := now.UTC().Format(timeFormat) + zipSuffix
+	path := filepath.Join(r.dir, ringDir, name)
+	tmpPath := path + ".tmp"
+	defer os.Remove(tmpPath)
+
+	if err := r.writeRecording(ctx, tmpPath); err != nil {
+		return err
+	}
+	// a recording is only in the ring once it's complete
+	if err := os.Rename(tmpPath, path); err != nil {
+		return err
+	}
+	return prune(filepath.Join(r.dir, ringDir), r.maxRecordings)
+}
+
+// writeRecording writes a file of each source to a zip file. A source which
+// fails is recorded as a file of its error instead.
+func (r *Recorder) writeRecording(ctx context.Context, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	for _, src := range r.sources {
+		name := src.name
+		data, err := r.collect(ctx, src)
+		if err != nil {
+			name += errorSuffix
+			data = []byte(err.Error())
+		}
+		w, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// collect returns the output of a source, unless it takes longer than the
+// timeout, or a previous call still hasn't returned, e.g. because the source
+// is deadlocked, in which case the call is abandoned.
+func (r *Recorder) collect(ctx context.Context, src *source) ([]byte, error) {
+	if !atomic.CompareAndSwapUint32(&src.busy, 0, 1) {
+		return nil, errors.New("blocked by a previous recording")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	type result struct {
+		data []byte
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		var buf bytes.Buffer
+		err := src.fn(ctx, &buf)
+		atomic.StoreUint32(&src.busy, 0)
+		done <- result{buf.Bytes(), err}
+	}()
+
+	select {
+	case res := <-done:
+		return res.data, res.err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("blocked for %v: %w", r.timeout, ctx.Err())
+	}
+}
+
+// dump exports the ring to a zip file in the dumps directory, and removes the
+// oldest dumps beyond max-dumps.
+func (r *Recorder) dump(reason string, now time.Time) (string, error) {
+	path := filepath.Join(r.dir, dumpsDir, fmt.Sprintf("%s-%s%s", now.UTC().Format(timeFormat), reason, zipSuffix))
+	if err := Export(r.dir, path); err != nil {
+		return "", err
+	}
+	r.logger.Info("dumped the flight recorder", "reason", reason, "path", path)
+	return path, prune(filepath.Join(r.dir, dumpsDir), r.maxDumps)
+}
+
+// Export writes the recordings of the ring of the flight recorder in dir to a
+// zip file at path, the files of each recording being in a directory named
+// after the time of the recording.
+func Export(dir, path string) error {
+	recordings, err := listZips(filepath.Join(dir, ringDir))
+	if err != nil {
+		return err
+	}
+	if len(recordings) == 0 {
+		return fmt.Errorf("no recordings in %s", filepath.Join(dir, ringDir))
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	for _, name := range recordings {
+		if err := copyRecording(zw, filepath.Join(dir, ringDir, name), strings.TrimSuffix(name, zipSuffix)); err != nil {
+			return fmt.Errorf("failed to export recording %s: %w", name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// copyRecording copies the files of the recording at path, as they're
+// compressed, to the directory dir of zw.
+func copyRecording(zw *zip.Writer, path, dir string) error {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+
+	for _, file := range zr.File {
+		header := file.FileHeader
+		header.Name = dir + "/" + file.Name
+		w, err := zw.CreateRaw(&header)
+		if err != nil {
+			return err
+		}
+		raw, err := file.OpenRaw()
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(w, raw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listZips returns the names of the zip files in dir, which sort by the time
+// they were written.
+func listZips(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, entry := range entries {
+		if name := entry.Name(); !entry.IsDir() && strings.HasSuffix(name, zipSuffix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// prune removes the oldest zip files in dir beyond max.
+func prune(dir string, max int) error {
+	names, err := listZips(dir)
+	if err != nil {
+		return err
+	}
+	for len(names) > max {
+		if err := os.Remove(filepath.Join(dir, names[0])); err != nil {
+			return err
+		}
+		names = names[1:]
+	}
+	return nil
+}
+
+func writeGoroutines(_ context.Context, w io.Writer) error {
+	return pprof.Lookup("goroutine").WriteTo(w, 2)
+}
@@ -0,0 +1,128 @@
+package flightrec
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestRecorder(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir := t.TempDir()
+	cfg := &config.FlightRecorderConfig{Interval: time.Hour, MaxRecordings: 2, MaxDumps: 1}
+
+	height := 0
+	unblock := make(chan struct{})
+	defer close(unblock)
+	sources := map[string]Source{
+		"state.json": func(_ context.Context, w io.Writer) error {
+			height++
+			_, err := fmt.Fprintf(w, `{"height":%d}`, height)
+			return err
+		},
+		"stuck.txt": func(_ context.Context, w io.Writer) error {
+			<-unblock
+			return nil
+		},
+	}
+	r := NewRecorder(log.TestingLogger(), cfg, dir, sources)
+	r.timeout = 10 * time.Millisecond
+	require.NoError(t, r.Start(ctx))
+
+	// a blocked source is recorded as such, and not called again while blocked
+	require.NoError(t, r.Record(ctx))
+	recordings, err := listZips(filepath.Join(dir, ringDir))
+	require.NoError(t, err)
+	require.Len(t, recordings, 1)
+	files := readZip(t, filepath.Join(dir, ringDir, recordings[0]))
+	require.Contains(t, files[GoroutineFile], "goroutine")
+	require.Contains(t, files["stuck.txt"+errorSuffix], "blocked for")
+
+	// the oldest recordings are removed
+	for i := 0; i < 2; i++ {
+		require.NoError(t, r.Record(ctx))
+	}
+	recordings, err = listZips(filepath.Join(dir, ringDir))
+	require.NoError(t, err)
+	require.Len(t, recordings, 2)
+	files = readZip(t, filepath.Join(dir, ringDir, recordings[1]))
+	require.Equal(t, `{"height":3}`, files["state.json"])
+	require.Contains(t, files["stuck.txt"+errorSuffix], "blocked by a previous recording")
+
+	path, err := r.Dump(ctx, DumpOnDemand)
+	require.NoError(t, err)
+	require.True(t, strings.HasSuffix(path, DumpOnDemand+zipSuffix))
+	files = readZip(t, path)
+	require.Len(t, files, 6)
+	recordings, err = listZips(filepath.Join(dir, ringDir))
+	require.NoError(t, err)
+	require.Equal(t, `{"height":4}`, files[strings.TrimSuffix(recordings[1], zipSuffix)+"/state.json"])
+
+	// the oldest dumps are removed
+	_, err = r.Dump(ctx, DumpOnDemand)
+	require.NoError(t, err)
+	dumps, err := listZips(filepath.Join(dir, dumpsDir))
+	require.NoError(t, err)
+	require.Len(t, dumps, 1)
+
+	require.NoError(t, r.Stop())
+	_, err = os.Stat(filepath.Join(dir, runningFile))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestRecorder_UncleanShutdown(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir := t.TempDir()
+	cfg := &config.FlightRecorderConfig{Interval: time.Hour, MaxRecordings: 2, MaxDumps: 2}
+
+	r := NewRecorder(log.TestingLogger(), cfg, dir, nil)
+	require.NoError(t, r.Start(ctx))
+	require.NoError(t, r.Record(ctx))
+
+	// the node crashes, leaving the running file behind
+	r = NewRecorder(log.TestingLogger(), cfg, dir, nil)
+	require.NoError(t, r.Start(ctx))
+
+	dumps, err := listZips(filepath.Join(dir, dumpsDir))
+	require.NoError(t, err)
+	require.Len(t, dumps, 1)
+	require.True(t, strings.HasSuffix(dumps[0], DumpUncleanShutdown+zipSuffix))
+	require.Len(t, readZip(t, filepath.Join(dir, dumpsDir, dumps[0])), 1)
+
+	require.Error(t, Export(t.TempDir(), filepath.Join(t.TempDir(), "export.zip")))
+}
+
+// readZip returns the contents of the files of a zip file, by name.
+func readZip(t *testing.T, path string) map[string]string {
+	t.Helper()
+
+	zr, err := zip.OpenReader(path)
+	require.NoError(t, err)
+	defer zr.Close()
+
+	files := make(map[string]string, len(zr.File))
+	for _, file := range zr.File {
+		rc, err := file.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		files[file.Name] = string(data)
+	}
+	return files
+}
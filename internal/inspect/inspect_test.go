@@ -477,7 +477,11 @@ func TestValidators(t *testing.T) {
 		},
 	}
 	stateStoreMock := &statemocks.Store{}
-	stateStoreMock.On("LoadValidators", testHeight).Return(&testValidators, nil)
+	stateStoreMock.On("IterateValidators", testHeight, testHeight, mock.Anything).Return(
+		func(from, to int64, fn func(int64, *types.ValidatorSet) bool) error {
+			fn(testHeight, &testValidators)
+			return nil
+		})
 
 	blockStoreMock := &statemocks.BlockStore{}
 	blockStoreMock.On("Height").Return(testHeight)
@@ -543,8 +547,10 @@ func TestBlockSearch(t *testing.T) {
 		},
 	})
 	eventSinkMock.On("SearchBlockEvents", mock.Anything,
-		mock.MatchedBy(func(q *query.Query) bool { return testQuery == q.String() })).
+		mock.MatchedBy(func(q *query.Query) bool { return testQuery == q.String() }), false).
 		Return([]int64{testHeight}, nil)
+	blockStoreMock.On("Base").Return(int64(1))
+	blockStoreMock.On("Height").Return(testHeight)
 	rpcConfig := config.TestRPCConfig()
 	l := log.TestingLogger()
 	d := inspect.New(rpcConfig, blockStoreMock, stateStoreMock, []indexer.EventSink{eventSinkMock}, l)
@@ -570,7 +576,7 @@ func TestBlockSearch(t *testing.T) {
 	testPage := 1
 	testPerPage := 100
 	testOrderBy := "desc"
-	res, err := cli.BlockSearch(ctx, testQuery, &testPage, &testPerPage, testOrderBy)
+	res, err := cli.BlockSearch(ctx, testQuery, &testPage, &testPerPage, testOrderBy, false)
 	require.NoError(t, err)
 	require.NotNil(t, res)
 	require.Equal(t, testBlockHash, []byte(res.Blocks[0].BlockID.Hash))
@@ -45,12 +45,12 @@ func Routes(cfg config.RPCConfig, s state.Store, bs state.BlockStore, es []index
 		"consensus_params": server.NewRPCFunc(env.ConsensusParams, "height", true),
 		"block":            server.NewRPCFunc(env.Block, "height", true),
 		"block_by_hash":    server.NewRPCFunc(env.BlockByHash, "hash", true),
-		"block_results":    server.NewRPCFunc(env.BlockResults, "height", true),
+		"block_results":    server.NewRPCFunc(env.BlockResults, "height,prove_events", true),
 		"commit":           server.NewRPCFunc(env.Commit, "height", true),
-		"validators":       server.NewRPCFunc(env.Validators, "height,page,per_page", true),
+		"validators":       server.NewRPCFunc(env.Validators, "height,page,per_page,cursor,window", true),
 		"tx":               server.NewRPCFunc(env.Tx, "hash,prove", true),
 		"tx_search":        server.NewRPCFunc(env.TxSearch, "query,prove,page,per_page,order_by", false),
-		"block_search":     server.NewRPCFunc(env.BlockSearch, "query,page,per_page,order_by", false),
+		"block_search":     server.NewRPCFunc(env.BlockSearch, "query,page,per_page,order_by,match_events", false),
 	}
 }
 
@@ -103,6 +103,10 @@ func (waitSyncCheckerImpl) GetPeerState(peerID types.NodeID) (*consensus.PeerSta
 	return nil, false
 }
 
+func (waitSyncCheckerImpl) TimeStats() consensus.TimeStats {
+	return consensus.TimeStats{}
+}
+
 // ListenAndServe listens on the address specified in srv.Addr and handles any
 // incoming requests over HTTP using the Inspector rpc handler specified on the server.
 func (srv *Server) ListenAndServe(ctx context.Context) error {
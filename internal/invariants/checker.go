@@ -0,0 +1,240 @@
+package invariants
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/eventbus"
+	"github.com/tendermint/tendermint/internal/pubsub"
+	sm "github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/service"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	subscriber = "InvariantChecker"
+
+	// capacity of the new block subscription
+	subscriptionLimit = 100
+)
+
+// The invariants checked.
+const (
+	StoreHeights     = "store-heights"
+	ValidatorSetHash = "validator-set-hash"
+	AppHash          = "app-hash"
+	SeenCommit       = "seen-commit"
+)
+
+// Violation is a violation of an invariant by the state at a height.
+type Violation struct {
+	Invariant string
+	Height    int64
+	Err       error
+}
+
+func (v Violation) Error() string {
+	return fmt.Sprintf("invariant %s violated at height %d: %v", v.Invariant, v.Height, v.Err)
+}
+
+// Checker checks the invariants of the stored blocks and state every interval
+// blocks, halting the node on a violation if configured to.
+type Checker struct {
+	service.BaseService
+	logger log.Logger
+
+	interval   int64
+	halt       bool
+	eventBus   *eventbus.EventBus
+	stateStore sm.Store
+	blockStore sm.BlockStore
+	metrics    *Metrics
+
+	// height and app hash of the state last checked, whose app hash is checked
+	// against the header of the next block. Only accessed by the goroutine
+	// checking the invariants.
+	lastHeight  int64
+	lastAppHash []byte
+
+	haltOnce sync.Once
+	halted   chan struct{}
+}
+
+// NewChecker returns a checker of the invariants of the blocks and state of
+// the given stores, configured by cfg.
+func NewChecker(
+	logger log.Logger,
+	cfg *config.InvariantsConfig,
+	eventBus *eventbus.EventBus,
+	stateStore sm.Store,
+	blockStore sm.BlockStore,
+	metrics *Metrics,
+) *Checker {
+	c := &Checker{
+		logger:     logger,
+		interval:   cfg.CheckInterval,
+		halt:       cfg.OnViolation == config.InvariantViolationHalt,
+		eventBus:   eventBus,
+		stateStore: stateStore,
+		blockStore: blockStore,
+		metrics:    metrics,
+		halted:     make(chan struct{}),
+	}
+	c.BaseService = *service.NewBaseService(logger, "InvariantChecker", c)
+	return c
+}
+
+// Halted returns a channel which is closed once an invariant is violated, if
+// the checker halts the node on violations.
+func (c *Checker) Halted() <-chan struct{} { return c.halted }
+
+// OnStart implements service.Service by checking the invariants of the
+// stored state, and subscribing to the blocks committed from then on.
+func (c *Checker) OnStart(ctx context.Context) error {
+	c.checkInvariants()
+
+	// the checks are auxiliary, so a failure to subscribe is logged rather
+	// than failing the node
+	go c.processBlocks(ctx)
+	return nil
+}
+
+// OnStop implements service.Service.
+func (c *Checker) OnStop() {}
+
+func (c *Checker) subscribe(ctx context.Context) (eventbus.Subscription, error) {
+	return c.eventBus.SubscribeWithArgs(ctx, pubsub.SubscribeArgs{
+		ClientID: subscriber,
+		Query:    types.EventQueryNewBlock,
+		Limit:    subscriptionLimit,
+	})
+}
+
+// processBlocks checks the invariants after the blocks at a multiple of the
+// interval are committed, until ctx is done. The subscription is renewed if
+// the checker falls so far behind that it's terminated.
+func (c *Checker) processBlocks(ctx context.Context) {
+	sub, err := c.subscribe(ctx)
+	if err != nil {
+		c.logger.Error("failed to subscribe to new blocks", "err", err)
+		return
+	}
+	for {
+		msg, err := sub.Next(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			c.logger.Error("new block subscription failed, resubscribing", "err", err)
+			if sub, err = c.subscribe(ctx); err != nil {
+				c.logger.Error("failed to subscribe to new blocks", "err", err)
+				return
+			}
+			continue
+		}
+		block := msg.Data().(types.EventDataNewBlock).Block
+		if block != nil && block.Height%c.interval == 0 {
+			c.checkInvariants()
+		}
+	}
+}
+
+// checkInvariants checks the invariants of the latest stored state, reporting
+// and returning the violations.
+func (c *Checker) checkInvariants() []Violation {
+	violations, err := c.check()
+	if err != nil {
+		c.logger.Error("failed to check the invariants", "err", err)
+		return nil
+	}
+	c.metrics.Checks.Add(1)
+
+	for _, v := range violations {
+		c.metrics.Violations.With("invariant", v.Invariant).Add(1)
+		c.logger.Error("invariant violated", "invariant", v.Invariant, "height", v.Height, "err", v.Err)
+	}
+	if len(violations) > 0 && c.halt {
+		c.haltOnce.Do(func() {
+			c.logger.Error("halting on the invariant violation")
+			close(c.halted)
+		})
+	}
+	return violations
+}
+
+func (c *Checker) check() ([]Violation, error) {
+	// the block store is read around the state, which may be committed in the
+	// meantime
+	heightBefore := c.blockStore.Height()
+	state, err := c.stateStore.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load state: %w", err)
+	}
+	heightAfter := c.blockStore.Height()
+
+	height := state.LastBlockHeight
+	if height == 0 || heightAfter == 0 {
+		// no block was committed, or the node state synced and did not
+		// store a block yet
+		return nil, nil
+	}
+
+	var violations []Violation
+	violate := func(invariant string, format string, args ...interface{}) {
+		violations = append(violations, Violation{
+			Invariant: invariant,
+			Height:    height,
+			Err:       fmt.Errorf(format, args...),
+		})
+	}
+
+	// the block store is one block ahead while the block is executed
+	if height < heightBefore-1 || height > heightAfter {
+		violate(StoreHeights, "the block store is at height %d", heightAfter)
+	}
+
+	if meta := c.blockStore.LoadBlockMeta(height); meta == nil {
+		violate(StoreHeights, "the last block of the state is not in the block store")
+	} else {
+		if !state.LastBlockID.Equals(meta.BlockID) {
+			violate(StoreHeights, "the last block ID of the state is %v, the stored block's is %v",
+				state.LastBlockID, meta.BlockID)
+		}
+		if hash := state.LastValidators.Hash(); !bytes.Equal(hash, meta.Header.ValidatorsHash) {
+			violate(ValidatorSetHash, "the hash of the last validators of the state is %X, the header's is %X",
+				hash, meta.Header.ValidatorsHash)
+		}
+		if hash := state.Validators.Hash(); !bytes.Equal(hash, meta.Header.NextValidatorsHash) {
+			violate(ValidatorSetHash, "the hash of the validators of the state is %X, the header's next is %X",
+				hash, meta.Header.NextValidatorsHash)
+		}
+	}
+
+	// the next block of the state last checked has been committed since,
+	// unless the blocks were pruned
+	if c.lastHeight > 0 && c.lastHeight < height {
+		if meta := c.blockStore.LoadBlockMeta(c.lastHeight + 1); meta != nil &&
+			!bytes.Equal(c.lastAppHash, meta.Header.AppHash) {
+			violate(AppHash, "the app hash of the state at height %d is %X, the next header's is %X",
+				c.lastHeight, c.lastAppHash, meta.Header.AppHash)
+		}
+	}
+	if meta := c.blockStore.LoadBlockMeta(height + 1); meta != nil &&
+		!bytes.Equal(state.AppHash, meta.Header.AppHash) {
+		violate(AppHash, "the app hash of the state is %X, the next header's is %X",
+			state.AppHash, meta.Header.AppHash)
+	}
+	c.lastHeight, c.lastAppHash = height, state.AppHash
+
+	if seen := c.blockStore.LoadSeenCommit(); seen == nil {
+		violate(SeenCommit, "no seen commit is stored")
+	} else if seen.Height < height {
+		violate(SeenCommit, "the seen commit is at height %d", seen.Height)
+	}
+
+	return violations, nil
+}
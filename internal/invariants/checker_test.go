@@ -0,0 +1,167 @@
+package invariants
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/internal/eventbus"
+	sm "github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/internal/state/mocks"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
+)
+
+// chain is the stored blocks and state checked.
+type chain struct {
+	state  sm.State
+	height int64 // of the block store
+	metas  map[int64]*types.BlockMeta
+	seen   *types.Commit
+}
+
+// newChain returns a consistent chain at the given height, with the given app
+// hashes by height.
+func newChain(height int64, appHashes map[int64][]byte) *chain {
+	vals := types.NewValidatorSet([]*types.Validator{types.NewValidator(ed25519.GenPrivKey().PubKey(), 10)})
+	c := &chain{height: height, metas: make(map[int64]*types.BlockMeta)}
+	for h := int64(1); h <= height; h++ {
+		blockID := types.BlockID{Hash: []byte{byte(h)}}
+		c.metas[h] = &types.BlockMeta{
+			BlockID: blockID,
+			Header: types.Header{
+				Height:             h,
+				ValidatorsHash:     vals.Hash(),
+				NextValidatorsHash: vals.Hash(),
+				AppHash:            appHashes[h-1],
+			},
+		}
+	}
+	c.state = sm.State{
+		LastBlockHeight: height,
+		LastBlockID:     c.metas[height].BlockID,
+		Validators:      vals,
+		LastValidators:  vals,
+		AppHash:         appHashes[height],
+	}
+	c.seen = &types.Commit{Height: height, BlockID: c.state.LastBlockID}
+	return c
+}
+
+// use makes the checker check the chain.
+func (c *chain) use(checker *Checker) {
+	stateStore := &mocks.Store{}
+	stateStore.On("Load").Return(c.state, nil)
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("Height").Return(c.height)
+	blockStore.On("LoadBlockMeta", mock.Anything).Return(func(height int64) *types.BlockMeta {
+		return c.metas[height]
+	})
+	blockStore.On("LoadSeenCommit").Return(c.seen)
+	checker.stateStore, checker.blockStore = stateStore, blockStore
+}
+
+func invariantsOf(violations []Violation) []string {
+	invariants := []string{}
+	for _, v := range violations {
+		invariants = append(invariants, v.Invariant)
+	}
+	return invariants
+}
+
+func TestChecker(t *testing.T) {
+	cfg := &config.InvariantsConfig{CheckInterval: 1, OnViolation: config.InvariantViolationAlert}
+	checker := NewChecker(log.TestingLogger(), cfg, nil, nil, nil, NopMetrics())
+	appHashes := map[int64][]byte{1: []byte("one"), 2: []byte("two"), 3: []byte("three")}
+
+	// a consistent chain
+	c := newChain(2, appHashes)
+	c.use(checker)
+	assert.Empty(t, checker.checkInvariants())
+
+	// the block store is one block ahead while it's executed
+	c = newChain(3, appHashes)
+	c.state.LastBlockHeight = 2
+	c.state.LastBlockID = c.metas[2].BlockID
+	c.state.AppHash = appHashes[2]
+	c.use(checker)
+	assert.Empty(t, checker.checkInvariants())
+
+	// the block store is behind the state
+	c = newChain(3, appHashes)
+	c.height = 2
+	c.use(checker)
+	assert.Equal(t, []string{StoreHeights}, invariantsOf(checker.checkInvariants()))
+
+	// the validators differ from the header's
+	c = newChain(3, appHashes)
+	c.state.Validators = newChain(1, nil).state.Validators
+	c.use(checker)
+	assert.Equal(t, []string{ValidatorSetHash}, invariantsOf(checker.checkInvariants()))
+
+	// the seen commit is missing
+	c = newChain(3, appHashes)
+	c.seen = nil
+	c.use(checker)
+	assert.Equal(t, []string{SeenCommit}, invariantsOf(checker.checkInvariants()))
+
+	// the app hash of the state checked last is not the one of the next block
+	checker.lastHeight, checker.lastAppHash = 1, []byte("other")
+	c = newChain(3, appHashes)
+	c.use(checker)
+	assert.Equal(t, []string{AppHash}, invariantsOf(checker.checkInvariants()))
+	assert.EqualValues(t, 3, checker.lastHeight)
+	assert.Equal(t, []byte("three"), checker.lastAppHash)
+
+	// alerting, the checker does not halt
+	select {
+	case <-checker.Halted():
+		t.Fatal("checker halted")
+	default:
+	}
+}
+
+func TestCheckerHalts(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	logger := log.TestingLogger()
+
+	eventBus := eventbus.NewDefault(logger)
+	require.NoError(t, eventBus.Start(ctx))
+
+	cfg := &config.InvariantsConfig{CheckInterval: 2, OnViolation: config.InvariantViolationHalt}
+	checker := NewChecker(logger, cfg, eventBus, nil, nil, NopMetrics())
+	c := newChain(3, nil)
+	c.use(checker)
+	require.NoError(t, checker.Start(ctx))
+	require.Eventually(t, func() bool { return eventBus.NumClientSubscriptions(subscriber) == 1 },
+		time.Second, 10*time.Millisecond)
+
+	// the seen commit goes missing, which is only checked every 2 blocks
+	c.seen = nil
+	c.use(checker)
+	publish := func(height int64) {
+		require.NoError(t, eventBus.PublishEventNewBlock(ctx, types.EventDataNewBlock{
+			Block: &types.Block{Header: types.Header{Height: height}},
+		}))
+	}
+	publish(3)
+	select {
+	case <-checker.Halted():
+		t.Fatal("checker halted between checks")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	publish(4)
+	select {
+	case <-checker.Halted():
+	case <-time.After(time.Second):
+		t.Fatal("checker did not halt")
+	}
+}
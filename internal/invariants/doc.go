@@ -0,0 +1,18 @@
+/*
+Package invariants checks, in the background, invariants of the blocks and
+state stored by the node which the state machine relies on, to detect the
+corruption of its databases, or bugs, before they make the node diverge from
+the network or fail to restart:
+
+  - the block store is at the height of the state store, or one block ahead
+    while that block is committed, and stores the last block of the state;
+  - the validator set hashes of the state match those of the header of its
+    last block;
+  - the app hash of the state is the one of the header of the next block;
+  - the seen commit of the last block is stored.
+
+The invariants are checked after committing the blocks at a multiple of the
+check interval of the [instrumentation.invariants] section. Violations are
+logged and counted in the metrics, and may halt the node.
+*/
+package invariants
@@ -0,0 +1,54 @@
+package invariants
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "invariants"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Number of times the invariants were checked.
+	Checks metrics.Counter
+	// Number of violations of the invariants, by invariant.
+	Violations metrics.Counter
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		Checks: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "checks",
+			Help:      "Number of times the invariants were checked.",
+		}, labels).With(labelsAndValues...),
+		Violations: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "violations",
+			Help:      "Number of violations of the invariants, by invariant.",
+		}, append(labels, "invariant")).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		Checks:     discard.NewCounter(),
+		Violations: discard.NewCounter(),
+	}
+}
@@ -228,6 +228,14 @@ func (g *Group) Buffered() int {
 	return g.headBuf.Buffered()
 }
 
+// Flush writes any buffered data to the underlying file, without committing it
+// to stable storage.
+func (g *Group) Flush() error {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	return g.headBuf.Flush()
+}
+
 // FlushAndSync writes any buffered data to the underlying file and commits the
 // current content of the file to stable storage (fsync).
 func (g *Group) FlushAndSync() error {
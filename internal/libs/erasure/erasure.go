@@ -0,0 +1,170 @@
+// Package erasure implements a systematic Reed-Solomon erasure code over
+// GF(2^8), which extends k data shards with parity shards so that the data
+// can be reconstructed from any k of the shards.
+//
+// The parity shards are computed with a Cauchy matrix, any square submatrix
+// of which is invertible, so that the code is maximum distance separable.
+package erasure
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MaxShards is the maximum total number of data and parity shards.
+const MaxShards = 256
+
+// Encode returns the given number of parity shards for the data shards, which
+// must all have the same length.
+func Encode(data [][]byte, parity int) ([][]byte, error) {
+	if err := checkShards(len(data), parity); err != nil {
+		return nil, err
+	}
+	size := len(data[0])
+	for i, shard := range data {
+		if len(shard) != size {
+			return nil, fmt.Errorf("data shard %d has %d bytes, expected %d", i, len(shard), size)
+		}
+	}
+
+	shards := make([][]byte, parity)
+	for i := range shards {
+		shards[i] = make([]byte, size)
+		for j, shard := range data {
+			mulAdd(shards[i], shard, cauchy(len(data), i, j))
+		}
+	}
+	return shards, nil
+}
+
+// Reconstruct fills in the missing (nil) shards among the given data shards
+// followed by parity shards, as produced by Encode. At least dataShards
+// shards must be present, all with the same length.
+func Reconstruct(shards [][]byte, dataShards int) error {
+	parity := len(shards) - dataShards
+	if err := checkShards(dataShards, parity); err != nil {
+		return err
+	}
+
+	// Pick the first dataShards present shards, and build the rows of the
+	// encoding matrix which produced them.
+	var (
+		present = make([]int, 0, dataShards)
+		size    = -1
+	)
+	for i, shard := range shards {
+		if shard == nil {
+			continue
+		}
+		if size >= 0 && len(shard) != size {
+			return fmt.Errorf("shard %d has %d bytes, expected %d", i, len(shard), size)
+		}
+		size = len(shard)
+		if len(present) < dataShards {
+			present = append(present, i)
+		}
+	}
+	if len(present) < dataShards {
+		return fmt.Errorf("%d shards present, at least %d are needed", len(present), dataShards)
+	}
+
+	matrix := make([][]byte, dataShards)
+	for r, i := range present {
+		matrix[r] = make([]byte, dataShards)
+		if i < dataShards {
+			matrix[r][i] = 1
+		} else {
+			for j := range matrix[r] {
+				matrix[r][j] = cauchy(dataShards, i-dataShards, j)
+			}
+		}
+	}
+	inverse, err := invert(matrix)
+	if err != nil {
+		return err
+	}
+
+	// Data shard j is the j-th row of the inverse applied to the present shards.
+	for j := 0; j < dataShards; j++ {
+		if shards[j] != nil {
+			continue
+		}
+		shard := make([]byte, size)
+		for r, i := range present {
+			mulAdd(shard, shards[i], inverse[j][r])
+		}
+		shards[j] = shard
+	}
+
+	// Recompute the missing parity shards from the data.
+	for i := 0; i < parity; i++ {
+		if shards[dataShards+i] != nil {
+			continue
+		}
+		shard := make([]byte, size)
+		for j := 0; j < dataShards; j++ {
+			mulAdd(shard, shards[j], cauchy(dataShards, i, j))
+		}
+		shards[dataShards+i] = shard
+	}
+	return nil
+}
+
+func checkShards(data, parity int) error {
+	switch {
+	case data <= 0:
+		return errors.New("no data shards")
+	case parity < 0:
+		return errors.New("negative number of parity shards")
+	case data+parity > MaxShards:
+		return fmt.Errorf("%d shards exceed the maximum of %d", data+parity, MaxShards)
+	}
+	return nil
+}
+
+// cauchy returns the element of the parity encoding matrix for the given
+// parity shard and data shard: 1 / (x_i + y_j), with the distinct elements
+// x_i = dataShards + i and y_j = j.
+func cauchy(dataShards, i, j int) byte {
+	return inv(byte(dataShards+i) ^ byte(j))
+}
+
+// invert returns the inverse of the square matrix, by Gauss-Jordan
+// elimination. The matrix is modified.
+func invert(matrix [][]byte) ([][]byte, error) {
+	n := len(matrix)
+	inverse := make([][]byte, n)
+	for i := range inverse {
+		inverse[i] = make([]byte, n)
+		inverse[i][i] = 1
+	}
+
+	for col := 0; col < n; col++ {
+		pivot := col
+		for pivot < n && matrix[pivot][col] == 0 {
+			pivot++
+		}
+		if pivot == n {
+			return nil, errors.New("singular matrix")
+		}
+		matrix[col], matrix[pivot] = matrix[pivot], matrix[col]
+		inverse[col], inverse[pivot] = inverse[pivot], inverse[col]
+
+		if c := matrix[col][col]; c != 1 {
+			f := inv(c)
+			for k := 0; k < n; k++ {
+				matrix[col][k] = mul(matrix[col][k], f)
+				inverse[col][k] = mul(inverse[col][k], f)
+			}
+		}
+		for row := 0; row < n; row++ {
+			if row == col || matrix[row][col] == 0 {
+				continue
+			}
+			f := matrix[row][col]
+			mulAdd(matrix[row], matrix[col], f)
+			mulAdd(inverse[row], inverse[col], f)
+		}
+	}
+	return inverse, nil
+}
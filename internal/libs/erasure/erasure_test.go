@@ -0,0 +1,65 @@
+package erasure
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGalois(t *testing.T) {
+	for a := 1; a < 256; a++ {
+		require.Equal(t, byte(1), mul(byte(a), inv(byte(a))), "%d", a)
+	}
+	require.Equal(t, byte(0), mul(0, 7))
+}
+
+func TestEncodeReconstruct(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	testCases := []struct {
+		data, parity int
+	}{
+		{1, 1},
+		{2, 1},
+		{4, 4},
+		{10, 3},
+		{128, 128},
+	}
+	for _, tc := range testCases {
+		data := make([][]byte, tc.data)
+		for i := range data {
+			data[i] = make([]byte, 64)
+			r.Read(data[i])
+		}
+		parity, err := Encode(data, tc.parity)
+		require.NoError(t, err)
+		require.Len(t, parity, tc.parity)
+		all := append(append([][]byte{}, data...), parity...)
+
+		// Drop random shards, keeping exactly as many as the data shards.
+		for attempt := 0; attempt < 5; attempt++ {
+			shards := append([][]byte{}, all...)
+			for _, i := range r.Perm(len(shards))[:tc.parity] {
+				shards[i] = nil
+			}
+			require.NoError(t, Reconstruct(shards, tc.data))
+			require.Equal(t, all, shards)
+		}
+
+		// One shard too few can't be reconstructed.
+		shards := append([][]byte{}, all...)
+		for _, i := range r.Perm(len(shards))[:tc.parity+1] {
+			shards[i] = nil
+		}
+		require.Error(t, Reconstruct(shards, tc.data))
+	}
+}
+
+func TestEncodeErrors(t *testing.T) {
+	_, err := Encode(nil, 1)
+	require.Error(t, err)
+	_, err = Encode([][]byte{{1}, {1, 2}}, 1)
+	require.Error(t, err)
+	_, err = Encode(make([][]byte, 200), 57)
+	require.Error(t, err)
+}
@@ -0,0 +1,50 @@
+package erasure
+
+// Arithmetic in GF(2^8), with the reducing polynomial x^8+x^4+x^3+x^2+1.
+
+var (
+	expTable [510]byte
+	logTable [256]byte
+
+	// mulTable[a][b] is a*b, so that multiplying a shard by a constant is a
+	// table lookup per byte.
+	mulTable [256][256]byte
+)
+
+func init() {
+	x := 1
+	for i := 0; i < 255; i++ {
+		expTable[i] = byte(x)
+		expTable[i+255] = byte(x)
+		logTable[x] = byte(i)
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= 0x11d
+		}
+	}
+	for a := 1; a < 256; a++ {
+		for b := 1; b < 256; b++ {
+			mulTable[a][b] = expTable[int(logTable[a])+int(logTable[b])]
+		}
+	}
+}
+
+func mul(a, b byte) byte {
+	return mulTable[a][b]
+}
+
+// inv returns the multiplicative inverse of a, which must not be zero.
+func inv(a byte) byte {
+	return expTable[255-int(logTable[a])]
+}
+
+// mulAdd adds c*src to dst, element-wise.
+func mulAdd(dst, src []byte, c byte) {
+	if c == 0 {
+		return
+	}
+	table := &mulTable[c]
+	for i, b := range src {
+		dst[i] ^= table[b]
+	}
+}
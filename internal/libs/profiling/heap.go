@@ -0,0 +1,128 @@
+package profiling
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+const (
+	// heapCheckInterval is how often the heap size is checked.
+	heapCheckInterval = 10 * time.Second
+
+	// maxHeapProfiles is the number of heap profiles kept in the directory,
+	// the oldest being removed.
+	maxHeapProfiles = 10
+
+	heapProfilePrefix = "heap-"
+	heapProfileSuffix = ".pprof"
+)
+
+// HeapMonitor writes a heap profile to a directory each time the heap grows
+// past a watermark, so that the allocations behind high memory use can be
+// inspected after the fact. Another profile is only written once the heap has
+// shrunk back below the watermark and grown past it again.
+type HeapMonitor struct {
+	logger    log.Logger
+	watermark uint64
+	dir       string
+	interval  time.Duration
+
+	above bool // whether the heap was above the watermark at the last check
+}
+
+// NewHeapMonitor returns a HeapMonitor writing profiles to dir when the heap
+// grows past watermark bytes.
+func NewHeapMonitor(logger log.Logger, watermark uint64, dir string) *HeapMonitor {
+	return &HeapMonitor{
+		logger:    logger,
+		watermark: watermark,
+		dir:       dir,
+		interval:  heapCheckInterval,
+	}
+}
+
+// Run checks the heap size until the context is canceled.
+func (m *HeapMonitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			var stats runtime.MemStats
+			runtime.ReadMemStats(&stats)
+			if err := m.check(stats.HeapAlloc, time.Now()); err != nil {
+				m.logger.Error("failed to write heap profile", "err", err)
+			}
+		}
+	}
+}
+
+// check writes a heap profile if the heap of the given size has grown past
+// the watermark since the last check.
+func (m *HeapMonitor) check(heapSize uint64, now time.Time) error {
+	above := heapSize >= m.watermark
+	crossed := above && !m.above
+	m.above = above
+	if !crossed {
+		return nil
+	}
+
+	m.logger.Info("heap grew past the watermark, writing heap profile",
+		"heap", heapSize, "watermark", m.watermark, "dir", m.dir)
+	if err := os.MkdirAll(m.dir, 0755); err != nil {
+		return err
+	}
+	name := heapProfilePrefix + now.UTC().Format("20060102T150405.000000000Z") + heapProfileSuffix
+	if err := writeHeapProfile(filepath.Join(m.dir, name)); err != nil {
+		return err
+	}
+	return m.prune()
+}
+
+// prune removes the oldest heap profiles beyond maxHeapProfiles.
+func (m *HeapMonitor) prune() error {
+	entries, err := os.ReadDir(m.dir)
+	if err != nil {
+		return err
+	}
+	var profiles []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, heapProfilePrefix) && strings.HasSuffix(name, heapProfileSuffix) {
+			profiles = append(profiles, name)
+		}
+	}
+	// The names sort by the time the profiles were written.
+	sort.Strings(profiles)
+	for len(profiles) > maxHeapProfiles {
+		if err := os.Remove(filepath.Join(m.dir, profiles[0])); err != nil {
+			return err
+		}
+		profiles = profiles[1:]
+	}
+	return nil
+}
+
+func writeHeapProfile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := pprof.Lookup("heap").WriteTo(f, 0); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write heap profile: %w", err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,174 @@
+// Package profiling controls the runtime profiles served by the pprof server,
+// which can be enabled, disabled and resampled at runtime, and captures heap
+// profiles to disk when the heap grows past a watermark.
+package profiling
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// Profiles which can be enabled and disabled at runtime. The CPU profile and
+// the other profiles served by pprof are always enabled.
+const (
+	ProfileHeap  = "heap"
+	ProfileMutex = "mutex"
+	ProfileBlock = "block"
+	ProfileTrace = "trace"
+)
+
+// Profiles lists the profiles which can be enabled and disabled.
+var Profiles = []string{ProfileHeap, ProfileMutex, ProfileBlock, ProfileTrace}
+
+// ProfileStatus is the state of a profile.
+type ProfileStatus struct {
+	Name    string
+	Enabled bool
+	// Rate is the sampling rate of the profile, as set with
+	// runtime.MemProfileRate, runtime.SetMutexProfileFraction or
+	// runtime.SetBlockProfileRate. It is 0 for the trace.
+	Rate int
+}
+
+// Profiler serves the pprof profiles, gating each of Profiles on whether it
+// is enabled. It is safe for concurrent use.
+type Profiler struct {
+	mtx     sync.Mutex
+	enabled map[string]bool
+	rates   map[string]int
+}
+
+// NewProfiler returns a Profiler with the given profiles enabled, sampling
+// mutex contention and blocking events at the given rates. An unknown profile
+// is an error.
+func NewProfiler(enabled []string, mutexProfileFraction, blockProfileRate int) (*Profiler, error) {
+	p := &Profiler{
+		enabled: make(map[string]bool, len(Profiles)),
+		rates: map[string]int{
+			ProfileHeap:  runtime.MemProfileRate,
+			ProfileMutex: mutexProfileFraction,
+			ProfileBlock: blockProfileRate,
+		},
+	}
+	for _, name := range enabled {
+		if !isProfile(name) {
+			return nil, fmt.Errorf("unknown profile %q, must be one of %s", name, strings.Join(Profiles, ", "))
+		}
+		p.enabled[name] = true
+	}
+	for _, name := range Profiles {
+		p.apply(name)
+	}
+	return p, nil
+}
+
+// Set enables or disables a profile. A positive rate sets the sampling rate of
+// the heap, mutex or block profile, while 0 keeps the current rate. Sampling of
+// a disabled mutex or block profile is turned off.
+func (p *Profiler) Set(name string, enabled bool, rate int) (ProfileStatus, error) {
+	if !isProfile(name) {
+		return ProfileStatus{}, fmt.Errorf("unknown profile %q, must be one of %s", name, strings.Join(Profiles, ", "))
+	}
+	if rate < 0 {
+		return ProfileStatus{}, fmt.Errorf("rate can't be negative, got %d", rate)
+	}
+	if rate > 0 && name == ProfileTrace {
+		return ProfileStatus{}, fmt.Errorf("the %s profile has no sampling rate", name)
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.enabled[name] = enabled
+	if rate > 0 {
+		p.rates[name] = rate
+	}
+	p.apply(name)
+	return p.status(name), nil
+}
+
+// Status returns the state of each of Profiles.
+func (p *Profiler) Status() []ProfileStatus {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	statuses := make([]ProfileStatus, 0, len(Profiles))
+	for _, name := range Profiles {
+		statuses = append(statuses, p.status(name))
+	}
+	return statuses
+}
+
+// Enabled returns whether the profile is enabled. Profiles other than
+// Profiles are always enabled.
+func (p *Profiler) Enabled(name string) bool {
+	if name == "allocs" { // a view of the heap profile
+		name = ProfileHeap
+	}
+	if !isProfile(name) {
+		return true
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.enabled[name]
+}
+
+// Handler returns a handler serving the pprof endpoints under /debug/pprof/,
+// which responds with 403 Forbidden to requests for disabled profiles.
+func (p *Profiler) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/debug/pprof/", p.gate(http.HandlerFunc(pprof.Index)))
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.Handle("/debug/pprof/trace", p.gate(http.HandlerFunc(pprof.Trace)))
+	return mux
+}
+
+// gate responds with 403 Forbidden to requests for disabled profiles.
+func (p *Profiler) gate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path, "/debug/pprof/")
+		if !p.Enabled(name) {
+			http.Error(w, fmt.Sprintf("the %s profile is disabled", name), http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// apply sets the sampling rate of the runtime for the profile.
+func (p *Profiler) apply(name string) {
+	rate := 0
+	if p.enabled[name] {
+		rate = p.rates[name]
+	}
+	switch name {
+	case ProfileHeap:
+		// Heap allocations are sampled even while the profile is disabled, so
+		// that it is complete once enabled again.
+		runtime.MemProfileRate = p.rates[name]
+	case ProfileMutex:
+		runtime.SetMutexProfileFraction(rate)
+	case ProfileBlock:
+		runtime.SetBlockProfileRate(rate)
+	}
+}
+
+func (p *Profiler) status(name string) ProfileStatus {
+	return ProfileStatus{Name: name, Enabled: p.enabled[name], Rate: p.rates[name]}
+}
+
+func isProfile(name string) bool {
+	for _, profile := range Profiles {
+		if name == profile {
+			return true
+		}
+	}
+	return false
+}
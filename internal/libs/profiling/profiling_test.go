@@ -0,0 +1,110 @@
+package profiling
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestProfiler(t *testing.T) {
+	defer runtime.SetMutexProfileFraction(0)
+	defer runtime.SetBlockProfileRate(0)
+
+	_, err := NewProfiler([]string{"cpu"}, 0, 0)
+	require.Error(t, err)
+
+	p, err := NewProfiler([]string{ProfileHeap, ProfileTrace}, 5, 100)
+	require.NoError(t, err)
+	assert.Equal(t, 0, runtime.SetMutexProfileFraction(-1), "disabled mutex profile should not be sampled")
+
+	srv := httptest.NewServer(p.Handler())
+	defer srv.Close()
+	get := func(path string) int {
+		res, err := http.Get(srv.URL + "/debug/pprof/" + path)
+		require.NoError(t, err)
+		res.Body.Close()
+		return res.StatusCode
+	}
+	assert.Equal(t, http.StatusOK, get(""))
+	assert.Equal(t, http.StatusOK, get("heap"))
+	assert.Equal(t, http.StatusOK, get("allocs"))
+	assert.Equal(t, http.StatusOK, get("goroutine"))
+	assert.Equal(t, http.StatusForbidden, get("mutex"))
+	assert.Equal(t, http.StatusForbidden, get("block"))
+
+	// enabling the mutex profile samples at the configured rate
+	status, err := p.Set(ProfileMutex, true, 0)
+	require.NoError(t, err)
+	assert.Equal(t, ProfileStatus{Name: ProfileMutex, Enabled: true, Rate: 5}, status)
+	assert.Equal(t, 5, runtime.SetMutexProfileFraction(-1))
+	assert.Equal(t, http.StatusOK, get("mutex"))
+
+	// the rate can be changed
+	status, err = p.Set(ProfileMutex, true, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 10, status.Rate)
+	assert.Equal(t, 10, runtime.SetMutexProfileFraction(-1))
+
+	// disabling the heap and trace profiles
+	_, err = p.Set(ProfileHeap, false, 0)
+	require.NoError(t, err)
+	_, err = p.Set(ProfileTrace, false, 0)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, get("heap"))
+	assert.Equal(t, http.StatusForbidden, get("allocs"))
+	assert.Equal(t, http.StatusForbidden, get("trace"))
+
+	_, err = p.Set("cpu", true, 0)
+	assert.Error(t, err)
+	_, err = p.Set(ProfileBlock, true, -1)
+	assert.Error(t, err)
+	_, err = p.Set(ProfileTrace, true, 1)
+	assert.Error(t, err)
+
+	assert.Equal(t, []ProfileStatus{
+		{Name: ProfileHeap, Enabled: false, Rate: runtime.MemProfileRate},
+		{Name: ProfileMutex, Enabled: true, Rate: 10},
+		{Name: ProfileBlock, Enabled: false, Rate: 100},
+		{Name: ProfileTrace, Enabled: false, Rate: 0},
+	}, p.Status())
+}
+
+func TestHeapMonitor(t *testing.T) {
+	dir := t.TempDir()
+	m := NewHeapMonitor(log.TestingLogger(), 1000, dir)
+	now := time.Now()
+
+	countProfiles := func() int {
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		return len(entries)
+	}
+
+	// a profile is written when the heap grows past the watermark
+	require.NoError(t, m.check(500, now))
+	assert.Equal(t, 0, countProfiles())
+	require.NoError(t, m.check(1500, now))
+	assert.Equal(t, 1, countProfiles())
+
+	// but not again until it shrank below it
+	require.NoError(t, m.check(2000, now.Add(time.Second)))
+	assert.Equal(t, 1, countProfiles())
+	require.NoError(t, m.check(900, now.Add(2*time.Second)))
+	require.NoError(t, m.check(1000, now.Add(3*time.Second)))
+	assert.Equal(t, 2, countProfiles())
+
+	// only the latest profiles are kept
+	for i := 0; i < 2*maxHeapProfiles; i++ {
+		require.NoError(t, m.check(0, now))
+		require.NoError(t, m.check(1000, now.Add(time.Duration(i+4)*time.Second)))
+	}
+	assert.Equal(t, maxHeapProfiles, countProfiles())
+}
@@ -0,0 +1,69 @@
+package protoio
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Protobuf wire types read by ReadWireFields.
+const (
+	WireVarint = 0
+	WireBytes  = 2
+)
+
+// ErrWireType is returned by ReadWireFields for a field of a wire type other
+// than varint or length-delimited.
+var ErrWireType = errors.New("unsupported wire type")
+
+// WireField is a field of an encoded Protobuf message.
+type WireField struct {
+	Num      int32
+	WireType int
+	// Varint is the value of a varint field.
+	Varint uint64
+	// Bytes is the value of a length-delimited field, aliasing the message.
+	Bytes []byte
+}
+
+// ReadWireFields calls fn with each field of an encoded Protobuf message, for
+// hand-written decoders which alias the bytes of the message rather than
+// copying them. It returns an error if the message is malformed, has a field
+// of a wire type other than varint or length-delimited, or fn returns one.
+func ReadWireFields(b []byte, fn func(WireField) error) error {
+	for len(b) > 0 {
+		tag, n := binary.Uvarint(b)
+		if n <= 0 {
+			return errors.New("invalid field tag")
+		}
+		b = b[n:]
+
+		field := WireField{Num: int32(tag >> 3), WireType: int(tag & 0x7)}
+		if field.Num <= 0 {
+			return fmt.Errorf("invalid field number %d", field.Num)
+		}
+		switch field.WireType {
+		case WireVarint:
+			if field.Varint, n = binary.Uvarint(b); n <= 0 {
+				return fmt.Errorf("invalid varint of field %d", field.Num)
+			}
+			b = b[n:]
+
+		case WireBytes:
+			length, n := binary.Uvarint(b)
+			if n <= 0 || length > uint64(len(b)-n) {
+				return fmt.Errorf("invalid length of field %d", field.Num)
+			}
+			field.Bytes = b[n : n+int(length) : n+int(length)]
+			b = b[n+int(length):]
+
+		default:
+			return fmt.Errorf("%w %d of field %d", ErrWireType, field.WireType, field.Num)
+		}
+
+		if err := fn(field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package protoio_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/internal/libs/protoio"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+func TestReadWireFields(t *testing.T) {
+	bz, err := proto.Marshal(&tmproto.PartSetHeader{Total: 300, Hash: []byte("hash")})
+	require.NoError(t, err)
+
+	var fields []protoio.WireField
+	require.NoError(t, protoio.ReadWireFields(bz, func(f protoio.WireField) error {
+		fields = append(fields, f)
+		return nil
+	}))
+	require.Equal(t, []protoio.WireField{
+		{Num: 1, WireType: protoio.WireVarint, Varint: 300},
+		{Num: 2, WireType: protoio.WireBytes, Bytes: []byte("hash")},
+	}, fields)
+
+	// the bytes alias the message, without room to append over it
+	require.Equal(t, len(fields[1].Bytes), cap(fields[1].Bytes))
+	bz[len(bz)-1] = 'H'
+	require.Equal(t, []byte("hasH"), fields[1].Bytes)
+
+	nop := func(protoio.WireField) error { return nil }
+	for _, malformed := range [][]byte{
+		{0x08},            // missing varint
+		{0x12, 0x05, 'a'}, // truncated bytes
+		{0x00, 0x01},      // field number 0
+		{0x80},            // truncated tag
+	} {
+		require.Error(t, protoio.ReadWireFields(malformed, nop), malformed)
+	}
+	err = protoio.ReadWireFields([]byte{0x0d, 0, 0, 0, 0}, nop) // fixed32
+	require.True(t, errors.Is(err, protoio.ErrWireType))
+
+	// errors of the callback are returned
+	errStop := errors.New("stop")
+	require.Equal(t, errStop, protoio.ReadWireFields(bz, func(protoio.WireField) error { return errStop }))
+}
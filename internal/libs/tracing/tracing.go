@@ -0,0 +1,46 @@
+// Package tracing provides the OpenTelemetry tracers used to instrument
+// Tendermint, along with helpers for the span attributes they share.
+//
+// Spans are recorded by the globally registered tracer provider, which the
+// node configures from the [instrumentation.tracing] configuration section.
+// Until a provider is registered, all spans are no-ops.
+package tracing
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/tendermint/tendermint"
+
+// Keys of the attributes shared by spans across components.
+const (
+	HeightKey = attribute.Key("height")
+	RoundKey  = attribute.Key("round")
+	PeerKey   = attribute.Key("peer")
+)
+
+// Tracer returns the tracer for the named component, such as "consensus".
+func Tracer(component string) trace.Tracer {
+	return otel.Tracer(instrumentationName + "/" + component)
+}
+
+// HeightRound returns a span option setting the height and round attributes.
+func HeightRound(height int64, round int32) trace.SpanStartEventOption {
+	return trace.WithAttributes(HeightKey.Int64(height), RoundKey.Int64(int64(round)))
+}
+
+// Height returns a span option setting the height attribute.
+func Height(height int64) trace.SpanStartEventOption {
+	return trace.WithAttributes(HeightKey.Int64(height))
+}
+
+// RecordError records err, if any, on span and marks the span as failed.
+func RecordError(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
@@ -0,0 +1,430 @@
+package mempool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/google/orderedcode"
+	dbm "github.com/tendermint/tm-db"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/service"
+	"github.com/tendermint/tendermint/types"
+)
+
+// ArchivedTxStatus is the status of an archived transaction.
+type ArchivedTxStatus string
+
+const (
+	// ArchivedTxPending is the status of a transaction in the mempool.
+	ArchivedTxPending ArchivedTxStatus = "pending"
+	// ArchivedTxRejected is the status of a transaction rejected by CheckTx
+	// or by the mempool, when checked or rechecked.
+	ArchivedTxRejected ArchivedTxStatus = "rejected"
+	// ArchivedTxEvicted is the status of a transaction evicted from the full
+	// mempool by a transaction of a higher priority.
+	ArchivedTxEvicted ArchivedTxStatus = "evicted"
+	// ArchivedTxReplaced is the status of a transaction replaced in the
+	// mempool by one of the same sender and sequence with a higher priority.
+	ArchivedTxReplaced ArchivedTxStatus = "replaced"
+	// ArchivedTxExpired is the status of a transaction removed from the
+	// mempool once its TTL was exceeded.
+	ArchivedTxExpired ArchivedTxStatus = "expired"
+	// ArchivedTxRemoved is the status of a transaction removed from the
+	// mempool over RPC, or by flushing it.
+	ArchivedTxRemoved ArchivedTxStatus = "removed"
+	// ArchivedTxCommitted is the status of a transaction committed in a block.
+	ArchivedTxCommitted ArchivedTxStatus = "committed"
+)
+
+// ArchivedTx is a transaction checked by the mempool, with its last status.
+type ArchivedTx struct {
+	Hash tmbytes.HexBytes `json:"hash"`
+	Tx   types.Tx         `json:"tx"`
+	// Sender is the sender of the transaction, as extracted by the
+	// TxSenderFunc of the mempool, if any.
+	Sender string `json:"sender,omitempty"`
+	// Local is true if the transaction was submitted to this node, rather
+	// than received from a peer.
+	Local bool `json:"local,omitempty"`
+	// Time is the time the transaction was first checked, and Height the last
+	// block height the mempool was updated to then.
+	Time   time.Time `json:"time"`
+	Height int64     `json:"height"`
+
+	Status     ArchivedTxStatus `json:"status"`
+	StatusTime time.Time        `json:"status_time"`
+	// Code is the code of the CheckTx of a rejected transaction, or of the
+	// DeliverTx of a committed one.
+	Code uint32 `json:"code,omitempty"`
+	// Error is the reason a transaction was rejected by the mempool, rather
+	// than by the application.
+	Error string `json:"error,omitempty"`
+	// CommitHeight is the height of the block a committed transaction is in.
+	CommitHeight int64 `json:"commit_height,omitempty"`
+}
+
+// TxSenderFunc returns the sender of a checked transaction the archive indexes
+// it by, or an empty string if it has none.
+type TxSenderFunc func(tx types.Tx, res *abci.ResponseCheckTx) string
+
+// DefaultTxSender returns the sender reported by the application in CheckTx.
+func DefaultTxSender(tx types.Tx, res *abci.ResponseCheckTx) string {
+	return res.Sender
+}
+
+// TxArchiveSink records the transactions checked by the mempool, and their
+// status as it changes. It's called synchronously by the mempool, so it must
+// not block for long.
+type TxArchiveSink interface {
+	// ArchiveTx records a transaction, or updates the status of an archived
+	// one.
+	ArchiveTx(ArchivedTx)
+	// CommitTxs updates the status of the archived transactions of a block.
+	CommitTxs(height int64, txs types.Txs, deliverTxResponses []*abci.ResponseDeliverTx)
+}
+
+// WithTxArchive sets the sink the mempool records the transactions it checks
+// in, indexed by the sender returned by senderFn, or by DefaultTxSender if
+// nil.
+func WithTxArchive(sink TxArchiveSink, senderFn TxSenderFunc) TxMempoolOption {
+	return func(txmp *TxMempool) {
+		if senderFn == nil {
+			senderFn = DefaultTxSender
+		}
+		txmp.txArchive = sink
+		txmp.txSender = senderFn
+	}
+}
+
+// archiveCheckedTx records a transaction just checked in the archive of the
+// mempool, if any, with the given status, err being the error of the mempool
+// if it rejected it.
+func (txmp *TxMempool) archiveCheckedTx(
+	wtx *WrappedTx,
+	res *abci.ResponseCheckTx,
+	txInfo TxInfo,
+	status ArchivedTxStatus,
+	err error,
+) {
+	if txmp.txArchive == nil {
+		return
+	}
+	atx := ArchivedTx{
+		Hash:       wtx.tx.Hash(),
+		Tx:         wtx.tx,
+		Sender:     txmp.txSender(wtx.tx, res),
+		Local:      txInfo.SenderID == UnknownPeerID,
+		Time:       wtx.timestamp,
+		Height:     wtx.height,
+		Status:     status,
+		StatusTime: time.Now().UTC(),
+	}
+	if status == ArchivedTxRejected {
+		atx.Code = res.Code
+	}
+	if err != nil {
+		atx.Error = err.Error()
+	}
+	txmp.txArchive.ArchiveTx(atx)
+}
+
+// archiveTxStatus updates the status of a transaction of the mempool in its
+// archive, if any.
+func (txmp *TxMempool) archiveTxStatus(wtx *WrappedTx, status ArchivedTxStatus, code uint32, err error) {
+	if txmp.txArchive == nil {
+		return
+	}
+	atx := ArchivedTx{
+		Hash:       wtx.tx.Hash(),
+		Tx:         wtx.tx,
+		Sender:     wtx.sender,
+		Time:       wtx.timestamp,
+		Height:     wtx.height,
+		Status:     status,
+		StatusTime: time.Now().UTC(),
+		Code:       code,
+	}
+	if err != nil {
+		atx.Error = err.Error()
+	}
+	txmp.txArchive.ArchiveTx(atx)
+}
+
+const (
+	prefixArchivedTx       = int64(1)
+	prefixArchivedBySender = int64(2)
+	prefixArchivedByTime   = int64(3)
+
+	// txArchivePruneInterval is the interval at which a TxArchive prunes the
+	// transactions older than its retention.
+	txArchivePruneInterval = time.Hour
+)
+
+// TxArchive is a TxArchiveSink writing the transactions through to a
+// database, indexed by hash and by sender, so that e.g. wallets can list the
+// pending transactions of an account without an external indexer. The
+// transactions first checked longer than the retention ago are pruned.
+type TxArchive struct {
+	service.BaseService
+	logger log.Logger
+
+	db        dbm.DB
+	retention time.Duration
+
+	// mtx serializes the updates of the archived transactions
+	mtx sync.Mutex
+}
+
+var _ TxArchiveSink = (*TxArchive)(nil)
+
+// NewTxArchive returns an archive of the transactions in db, which must be
+// started to prune the transactions older than retention, if not zero.
+func NewTxArchive(logger log.Logger, db dbm.DB, retention time.Duration) *TxArchive {
+	ta := &TxArchive{
+		logger:    logger,
+		db:        db,
+		retention: retention,
+	}
+	ta.BaseService = *service.NewBaseService(logger, "TxArchive", ta)
+	return ta
+}
+
+// OnStart implements service.Service by starting to prune the archive.
+func (ta *TxArchive) OnStart(ctx context.Context) error {
+	if ta.retention > 0 {
+		go ta.pruneRoutine(ctx)
+	}
+	return nil
+}
+
+// OnStop implements service.Service.
+func (ta *TxArchive) OnStop() {}
+
+func (ta *TxArchive) pruneRoutine(ctx context.Context) {
+	ticker := time.NewTicker(txArchivePruneInterval)
+	defer ticker.Stop()
+
+	for {
+		pruned, err := ta.Prune(time.Now().Add(-ta.retention))
+		if err != nil {
+			ta.logger.Error("failed to prune tx archive", "err", err)
+		} else if pruned > 0 {
+			ta.logger.Debug("pruned tx archive", "num_txs", pruned)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ArchiveTx implements TxArchiveSink. The sender, time and height of a
+// transaction already archived are those of the first time it was checked.
+func (ta *TxArchive) ArchiveTx(atx ArchivedTx) {
+	ta.mtx.Lock()
+	defer ta.mtx.Unlock()
+
+	if err := ta.archiveTx(atx); err != nil {
+		ta.logger.Error("failed to archive transaction", "tx", atx.Hash, "err", err)
+	}
+}
+
+func (ta *TxArchive) archiveTx(atx ArchivedTx) error {
+	prev, err := ta.getTx(atx.Hash)
+	if err != nil {
+		return err
+	}
+
+	batch := ta.db.NewBatch()
+	defer batch.Close()
+
+	if prev != nil {
+		atx.Sender, atx.Time, atx.Height = prev.Sender, prev.Time, prev.Height
+		atx.Local = atx.Local || prev.Local
+	} else {
+		if atx.Sender != "" {
+			if err := batch.Set(archivedBySenderKey(atx.Sender, atx.Time, atx.Hash), atx.Hash); err != nil {
+				return err
+			}
+		}
+		if err := batch.Set(archivedByTimeKey(atx.Time, atx.Hash), atx.Hash); err != nil {
+			return err
+		}
+	}
+
+	bz, err := json.Marshal(atx)
+	if err != nil {
+		return err
+	}
+	if err := batch.Set(archivedTxKey(atx.Hash), bz); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// CommitTxs implements TxArchiveSink. The transactions of the block which
+// aren't archived are ignored.
+func (ta *TxArchive) CommitTxs(height int64, txs types.Txs, deliverTxResponses []*abci.ResponseDeliverTx) {
+	ta.mtx.Lock()
+	defer ta.mtx.Unlock()
+
+	now := time.Now().UTC()
+	for i, tx := range txs {
+		atx, err := ta.getTx(tx.Hash())
+		if err == nil && atx != nil {
+			atx.Status = ArchivedTxCommitted
+			atx.StatusTime = now
+			atx.Code = deliverTxResponses[i].Code
+			atx.Error = ""
+			atx.CommitHeight = height
+			err = ta.archiveTx(*atx)
+		}
+		if err != nil {
+			ta.logger.Error("failed to archive committed transaction", "tx", tmbytes.HexBytes(tx.Hash()), "err", err)
+		}
+	}
+}
+
+// GetTx returns the archived transaction of the given hash, or nil if it isn't
+// archived.
+func (ta *TxArchive) GetTx(hash []byte) (*ArchivedTx, error) {
+	ta.mtx.Lock()
+	defer ta.mtx.Unlock()
+
+	return ta.getTx(hash)
+}
+
+func (ta *TxArchive) getTx(hash []byte) (*ArchivedTx, error) {
+	bz, err := ta.db.Get(archivedTxKey(hash))
+	if err != nil || bz == nil {
+		return nil, err
+	}
+	atx := new(ArchivedTx)
+	if err := json.Unmarshal(bz, atx); err != nil {
+		return nil, fmt.Errorf("failed to decode archived transaction %X: %w", hash, err)
+	}
+	return atx, nil
+}
+
+// TxsBySender returns the archived transactions of a sender, newest first, of
+// the given status only if not empty.
+func (ta *TxArchive) TxsBySender(sender string, status ArchivedTxStatus) ([]*ArchivedTx, error) {
+	ta.mtx.Lock()
+	defer ta.mtx.Unlock()
+
+	start, err := orderedcode.Append(nil, prefixArchivedBySender, sender)
+	if err != nil {
+		return nil, err
+	}
+	end, err := orderedcode.Append(nil, prefixArchivedBySender, sender, int64(math.MaxInt64))
+	if err != nil {
+		return nil, err
+	}
+	iter, err := ta.db.ReverseIterator(start, end)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	var atxs []*ArchivedTx
+	for ; iter.Valid(); iter.Next() {
+		atx, err := ta.getTx(iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		if atx != nil && (status == "" || atx.Status == status) {
+			atxs = append(atxs, atx)
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+	return atxs, nil
+}
+
+// Prune removes the transactions first checked before the given time from the
+// archive, and returns how many it removed.
+func (ta *TxArchive) Prune(before time.Time) (int, error) {
+	ta.mtx.Lock()
+	defer ta.mtx.Unlock()
+
+	start, err := orderedcode.Append(nil, prefixArchivedByTime)
+	if err != nil {
+		return 0, err
+	}
+	end, err := orderedcode.Append(nil, prefixArchivedByTime, before.UnixNano())
+	if err != nil {
+		return 0, err
+	}
+	iter, err := ta.db.Iterator(start, end)
+	if err != nil {
+		return 0, err
+	}
+	defer iter.Close()
+
+	batch := ta.db.NewBatch()
+	defer batch.Close()
+
+	pruned := 0
+	for ; iter.Valid(); iter.Next() {
+		atx, err := ta.getTx(iter.Value())
+		if err != nil {
+			return 0, err
+		}
+		if err := batch.Delete(iter.Key()); err != nil {
+			return 0, err
+		}
+		if atx == nil {
+			continue
+		}
+		if err := batch.Delete(archivedTxKey(atx.Hash)); err != nil {
+			return 0, err
+		}
+		if atx.Sender != "" {
+			if err := batch.Delete(archivedBySenderKey(atx.Sender, atx.Time, atx.Hash)); err != nil {
+				return 0, err
+			}
+		}
+		pruned++
+	}
+	if err := iter.Error(); err != nil {
+		return 0, err
+	}
+	if err := batch.Write(); err != nil {
+		return 0, err
+	}
+	return pruned, nil
+}
+
+func archivedTxKey(hash []byte) []byte {
+	key, err := orderedcode.Append(nil, prefixArchivedTx, string(hash))
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+func archivedBySenderKey(sender string, t time.Time, hash []byte) []byte {
+	key, err := orderedcode.Append(nil, prefixArchivedBySender, sender, t.UnixNano(), string(hash))
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+func archivedByTimeKey(t time.Time, hash []byte) []byte {
+	key, err := orderedcode.Append(nil, prefixArchivedByTime, t.UnixNano(), string(hash))
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
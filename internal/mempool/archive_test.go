@@ -0,0 +1,126 @@
+package mempool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestTxArchive(t *testing.T) {
+	ta := NewTxArchive(log.TestingLogger(), dbm.NewMemDB(), 0)
+
+	start := time.Now().UTC()
+	txs := types.Txs{types.Tx("a=1=1"), types.Tx("a=2=1"), types.Tx("b=1=1")}
+	for i, tx := range txs {
+		ta.ArchiveTx(ArchivedTx{
+			Hash:   tx.Hash(),
+			Tx:     tx,
+			Sender: string(tx[:1]),
+			Time:   start.Add(time.Duration(i) * time.Second),
+			Height: 1,
+			Status: ArchivedTxPending,
+		})
+	}
+
+	// a status update keeps the first time the transaction was checked
+	ta.ArchiveTx(ArchivedTx{
+		Hash:   txs[0].Hash(),
+		Tx:     txs[0],
+		Time:   start.Add(time.Minute),
+		Height: 2,
+		Status: ArchivedTxRejected,
+		Code:   101,
+	})
+	atx, err := ta.GetTx(txs[0].Hash())
+	require.NoError(t, err)
+	require.Equal(t, ArchivedTxRejected, atx.Status)
+	require.Equal(t, uint32(101), atx.Code)
+	require.Equal(t, "a", atx.Sender)
+	require.True(t, start.Equal(atx.Time))
+	require.Equal(t, int64(1), atx.Height)
+
+	// the transactions of a block not archived are ignored
+	ta.CommitTxs(3, types.Txs{txs[1], types.Tx("c=1=1")}, []*abci.ResponseDeliverTx{{}, {}})
+	atx, err = ta.GetTx(txs[1].Hash())
+	require.NoError(t, err)
+	require.Equal(t, ArchivedTxCommitted, atx.Status)
+	require.Equal(t, int64(3), atx.CommitHeight)
+	atx, err = ta.GetTx(types.Tx("c=1=1").Hash())
+	require.NoError(t, err)
+	require.Nil(t, atx)
+
+	atxs, err := ta.TxsBySender("a", "")
+	require.NoError(t, err)
+	require.Len(t, atxs, 2)
+	require.Equal(t, txs[1], atxs[0].Tx)
+	require.Equal(t, txs[0], atxs[1].Tx)
+
+	atxs, err = ta.TxsBySender("a", ArchivedTxRejected)
+	require.NoError(t, err)
+	require.Len(t, atxs, 1)
+	require.Equal(t, txs[0], atxs[0].Tx)
+
+	atxs, err = ta.TxsBySender("", "")
+	require.NoError(t, err)
+	require.Empty(t, atxs)
+
+	pruned, err := ta.Prune(start.Add(2 * time.Second))
+	require.NoError(t, err)
+	require.Equal(t, 2, pruned)
+	atxs, err = ta.TxsBySender("a", "")
+	require.NoError(t, err)
+	require.Empty(t, atxs)
+	atxs, err = ta.TxsBySender("b", "")
+	require.NoError(t, err)
+	require.Len(t, atxs, 1)
+}
+
+func TestTxMempool_TxArchive(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ta := NewTxArchive(log.TestingLogger(), dbm.NewMemDB(), 0)
+	senderFn := func(tx types.Tx, res *abci.ResponseCheckTx) string {
+		return "account:" + res.Sender
+	}
+	txmp := setup(ctx, t, 0, WithTxArchive(ta, senderFn))
+
+	committed, removed, rejected := types.Tx("a=1=1"), types.Tx("b=1=1"), types.Tx("malformed")
+	require.NoError(t, txmp.CheckTx(ctx, committed, nil, TxInfo{}))
+	require.NoError(t, txmp.CheckTx(ctx, removed, nil, TxInfo{SenderID: 1}))
+	require.NoError(t, txmp.CheckTx(ctx, rejected, nil, TxInfo{}))
+
+	atxs, err := ta.TxsBySender("account:a", ArchivedTxPending)
+	require.NoError(t, err)
+	require.Len(t, atxs, 1)
+	require.Equal(t, committed, atxs[0].Tx)
+	require.True(t, atxs[0].Local)
+
+	atx, err := ta.GetTx(rejected.Hash())
+	require.NoError(t, err)
+	require.Equal(t, ArchivedTxRejected, atx.Status)
+	require.Equal(t, uint32(101), atx.Code)
+
+	txmp.Lock()
+	require.NoError(t, txmp.Update(ctx, 1, types.Txs{committed}, []*abci.ResponseDeliverTx{{}}, nil, nil))
+	txmp.Unlock()
+	require.NoError(t, txmp.RemoveTxByKey(removed.Key()))
+
+	atx, err = ta.GetTx(committed.Hash())
+	require.NoError(t, err)
+	require.Equal(t, ArchivedTxCommitted, atx.Status)
+	require.Equal(t, int64(1), atx.CommitHeight)
+
+	atxs, err = ta.TxsBySender("account:b", "")
+	require.NoError(t, err)
+	require.Len(t, atxs, 1)
+	require.Equal(t, ArchivedTxRemoved, atxs[0].Status)
+	require.False(t, atxs[0].Local)
+}
@@ -4,6 +4,7 @@ import (
 	"container/list"
 	"sync"
 
+	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -22,6 +23,9 @@ type TxCache interface {
 
 	// Remove removes the given raw transaction from the cache.
 	Remove(tx types.Tx)
+
+	// Has returns true if the transaction with the given key is in the cache.
+	Has(key types.TxKey) bool
 }
 
 var _ TxCache = (*LRUTxCache)(nil)
@@ -97,11 +101,94 @@ func (c *LRUTxCache) Remove(tx types.Tx) {
 	}
 }
 
+func (c *LRUTxCache) Has(key types.TxKey) bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	_, ok := c.cacheMap[key]
+	return ok
+}
+
 // NopTxCache defines a no-op raw transaction cache.
 type NopTxCache struct{}
 
 var _ TxCache = (*NopTxCache)(nil)
 
-func (NopTxCache) Reset()             {}
-func (NopTxCache) Push(types.Tx) bool { return true }
-func (NopTxCache) Remove(types.Tx)    {}
+func (NopTxCache) Reset()               {}
+func (NopTxCache) Push(types.Tx) bool   { return true }
+func (NopTxCache) Remove(types.Tx)      {}
+func (NopTxCache) Has(types.TxKey) bool { return false }
+
+// CheckTxCache maintains a thread-safe LRU cache of the CheckTx responses of
+// rejected transactions, keyed by the hash of the raw transaction.
+type CheckTxCache struct {
+	mtx      sync.Mutex
+	size     int
+	cacheMap map[types.TxKey]*list.Element
+	list     *list.List
+}
+
+type checkTxCacheEntry struct {
+	key types.TxKey
+	res abci.ResponseCheckTx
+}
+
+func NewCheckTxCache(cacheSize int) *CheckTxCache {
+	return &CheckTxCache{
+		size:     cacheSize,
+		cacheMap: make(map[types.TxKey]*list.Element, cacheSize),
+		list:     list.New(),
+	}
+}
+
+// Reset removes all the cached responses.
+func (c *CheckTxCache) Reset() {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.cacheMap = make(map[types.TxKey]*list.Element, c.size)
+	c.list.Init()
+}
+
+// Get returns the cached CheckTx response of the transaction with the given
+// key, if any.
+func (c *CheckTxCache) Get(key types.TxKey) (abci.ResponseCheckTx, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	e, ok := c.cacheMap[key]
+	if !ok {
+		return abci.ResponseCheckTx{}, false
+	}
+	c.list.MoveToBack(e)
+	return e.Value.(*checkTxCacheEntry).res, true
+}
+
+// Put caches the CheckTx response of the transaction with the given key,
+// evicting the least recently used response if the cache is full.
+func (c *CheckTxCache) Put(key types.TxKey, res abci.ResponseCheckTx) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if e, ok := c.cacheMap[key]; ok {
+		e.Value.(*checkTxCacheEntry).res = res
+		c.list.MoveToBack(e)
+		return
+	}
+
+	if c.list.Len() >= c.size {
+		if front := c.list.Front(); front != nil {
+			delete(c.cacheMap, front.Value.(*checkTxCacheEntry).key)
+			c.list.Remove(front)
+		}
+	}
+	c.cacheMap[key] = c.list.PushBack(&checkTxCacheEntry{key: key, res: res})
+}
+
+// Len returns the number of cached responses.
+func (c *CheckTxCache) Len() int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return c.list.Len()
+}
@@ -5,6 +5,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/types"
 )
 
 func TestCacheRemove(t *testing.T) {
@@ -33,3 +36,29 @@ func TestCacheRemove(t *testing.T) {
 		require.Equal(t, numTxs-(i+1), cache.list.Len())
 	}
 }
+
+func TestCheckTxCacheEviction(t *testing.T) {
+	cache := NewCheckTxCache(2)
+	txs := []types.Tx{types.Tx("a"), types.Tx("b"), types.Tx("c")}
+
+	cache.Put(txs[0].Key(), abci.ResponseCheckTx{Code: 1})
+	cache.Put(txs[1].Key(), abci.ResponseCheckTx{Code: 2})
+
+	// getting the first response makes the second the least recently used
+	res, ok := cache.Get(txs[0].Key())
+	require.True(t, ok)
+	require.Equal(t, uint32(1), res.Code)
+
+	cache.Put(txs[2].Key(), abci.ResponseCheckTx{Code: 3})
+	require.Equal(t, 2, cache.Len())
+	_, ok = cache.Get(txs[1].Key())
+	require.False(t, ok)
+	res, ok = cache.Get(txs[2].Key())
+	require.True(t, ok)
+	require.Equal(t, uint32(3), res.Code)
+
+	cache.Reset()
+	require.Equal(t, 0, cache.Len())
+	_, ok = cache.Get(txs[0].Key())
+	require.False(t, ok)
+}
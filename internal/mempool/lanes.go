@@ -0,0 +1,68 @@
+package mempool
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/p2p"
+)
+
+// lane is a lane of the mempool, whose transactions are limited in number and
+// size independently of those of the other lanes.
+type lane struct {
+	name        string
+	maxTxs      int
+	maxTxsBytes int64
+
+	// numTxs and sizeBytes are accessed atomically
+	numTxs    int64
+	sizeBytes int64
+}
+
+// newLanes returns the lanes configured, in reaping order, followed by the
+// default lane.
+func newLanes(cfg *config.MempoolConfig) []*lane {
+	configured, err := cfg.ParseLanes()
+	if err != nil {
+		// the lanes are validated with the rest of the config
+		panic(fmt.Sprintf("invalid mempool lanes: %v", err))
+	}
+
+	lanes := make([]*lane, 0, len(configured)+1)
+	for _, l := range configured {
+		lanes = append(lanes, &lane{name: l.Name, maxTxs: l.Size, maxTxsBytes: l.MaxTxsBytes})
+	}
+	return append(lanes, &lane{maxTxs: cfg.Size, maxTxsBytes: cfg.MaxTxsBytes})
+}
+
+// LaneChannel returns the channel the transactions of the i-th configured
+// lane are gossiped on.
+func LaneChannel(i int) p2p.ChannelID {
+	return MempoolChannel + 1 + p2p.ChannelID(i)
+}
+
+// laneOf returns the index of the lane of the given name, the default lane if
+// no lane of that name is configured.
+func (txmp *TxMempool) laneOf(name string) int {
+	if name != "" {
+		for i, l := range txmp.lanes[:len(txmp.lanes)-1] {
+			if l.name == name {
+				return i
+			}
+		}
+	}
+	return txmp.defaultLane()
+}
+
+// defaultLane returns the index of the default lane.
+func (txmp *TxMempool) defaultLane() int {
+	return len(txmp.lanes) - 1
+}
+
+// LaneSize returns the number of valid transactions in the lane of the given
+// name, or the default lane if no lane of that name is configured. It is
+// thread-safe.
+func (txmp *TxMempool) LaneSize(name string) int {
+	return int(atomic.LoadInt64(&txmp.lanes[txmp.laneOf(name)].numTxs))
+}
@@ -1,18 +1,20 @@
 package mempool
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
-	"reflect"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/internal/libs/clist"
+	"github.com/tendermint/tendermint/internal/libs/tracing"
 	"github.com/tendermint/tendermint/internal/proxy"
 	"github.com/tendermint/tendermint/libs/log"
 	tmmath "github.com/tendermint/tendermint/libs/math"
@@ -21,6 +23,12 @@ import (
 
 var _ Mempool = (*TxMempool)(nil)
 
+var tracer = tracing.Tracer("mempool")
+
+// errTxSenderExists is the error of a transaction rejected as the mempool
+// already has a transaction of its sender.
+var errTxSenderExists = errors.New("transaction already exists for sender")
+
 // TxMempoolOption sets an optional parameter on the TxMempool.
 type TxMempoolOption func(*TxMempool)
 
@@ -44,31 +52,42 @@ type TxMempool struct {
 	// sizeBytes defines the total size of the mempool (sum of all tx bytes)
 	sizeBytes int64
 
+	// lanes defines the lanes of the mempool, in reaping order, the default
+	// lane being the last one.
+	lanes []*lane
+
 	// cache defines a fixed-size cache of already seen transactions as this
 	// reduces pressure on the proxyApp.
 	cache TxCache
 
+	// checkTxCache caches the CheckTx responses of rejected transactions, to
+	// refuse them again without calling CheckTx. It is nil if disabled.
+	checkTxCache *CheckTxCache
+
+	// rejectedTxs holds the transactions rejected by the node operator, until
+	// the time they are refused.
+	rejectedMtx sync.Mutex
+	rejectedTxs map[types.TxKey]time.Time
+
+	// rejectedTxSink records the transactions rejected by CheckTx, if not nil.
+	rejectedTxSink RejectedTxSink
+
+	// txArchive records the transactions checked, and their status, if not
+	// nil, indexed by the sender txSender returns.
+	txArchive TxArchiveSink
+	txSender  TxSenderFunc
+
 	// txStore defines the main storage of valid transactions. Indexes are built
 	// on top of this store.
 	txStore *TxStore
 
 	// gossipIndex defines the gossiping index of valid transactions via a
-	// thread-safe linked-list. We also use the gossip index as a cursor for
-	// rechecking transactions already in the mempool.
+	// thread-safe linked-list.
 	gossipIndex *clist.CList
 
-	// recheckCursor and recheckEnd are used as cursors based on the gossip index
-	// to recheck transactions that are already in the mempool. Iteration is not
-	// thread-safe and transaction may be mutated in serial order.
-	//
-	// XXX/TODO: It might be somewhat of a codesmell to use the gossip index for
-	// iterator and cursor management when rechecking transactions. If the gossip
-	// index changes or is removed in a future refactor, this will have to be
-	// refactored. Instead, we should consider just keeping a slice of a snapshot
-	// of the mempool's current transactions during Update and an integer cursor
-	// into that slice. This, however, requires additional O(n) space complexity.
-	recheckCursor *clist.CElement // next expected response
-	recheckEnd    *clist.CElement // re-checking stops here
+	// recheck tracks the recheck of the transactions already in the mempool,
+	// done in the background after each block is committed.
+	recheck *recheck
 
 	// priorityIndex defines the priority index of valid transactions via a
 	// thread-safe priority queue.
@@ -107,7 +126,10 @@ func NewTxMempool(
 		cache:         NopTxCache{},
 		metrics:       NopMetrics(),
 		txStore:       NewTxStore(),
+		lanes:         newLanes(cfg),
+		rejectedTxs:   make(map[types.TxKey]time.Time),
 		gossipIndex:   clist.New(),
+		recheck:       newRecheck(),
 		priorityIndex: NewTxPriorityQueue(),
 		heightIndex: NewWrappedTxList(func(wtx1, wtx2 *WrappedTx) bool {
 			return wtx1.height >= wtx2.height
@@ -120,6 +142,9 @@ func NewTxMempool(
 	if cfg.CacheSize > 0 {
 		txmp.cache = NewLRUTxCache(cfg.CacheSize)
 	}
+	if cfg.CheckTxCacheSize > 0 {
+		txmp.checkTxCache = NewCheckTxCache(cfg.CheckTxCacheSize)
+	}
 
 	proxyAppConn.SetResponseCallback(txmp.defaultTxCallback)
 
@@ -220,6 +245,10 @@ func (txmp *TxMempool) TxsAvailable() <-chan struct{} {
 // - The transaction fails Pre-Check (if it is defined).
 // - The proxyAppConn fails, e.g. the buffer is full.
 //
+// If the transaction was rejected by CheckTx and its response is cached, it is
+// refused with the cached response, passed to the callback, without calling
+// CheckTx again.
+//
 // If the mempool is full, we still execute CheckTx and attempt to find a lower
 // priority transaction to evict. If such a transaction exists, we remove the
 // lower priority transaction and add the new one with higher priority.
@@ -232,7 +261,22 @@ func (txmp *TxMempool) CheckTx(
 	tx types.Tx,
 	cb func(*abci.Response),
 	txInfo TxInfo,
-) error {
+) (err error) {
+	ctx, span := tracer.Start(ctx, "mempool.CheckTx", trace.WithAttributes(attribute.Int("size", len(tx))))
+	if span.IsRecording() {
+		span.SetAttributes(attribute.String("hash", fmt.Sprintf("%X", tx.Hash())))
+	}
+
+	// The span is ended by the CheckTx callback once the transaction has been
+	// submitted to the application, and here otherwise.
+	var submitted bool
+	defer func() {
+		if !submitted {
+			tracing.RecordError(span, err)
+			span.End()
+		}
+	}()
+
 	txmp.mtx.RLock()
 	defer txmp.mtx.RUnlock()
 
@@ -255,6 +299,24 @@ func (txmp *TxMempool) CheckTx(
 
 	txHash := tx.Key()
 
+	if txmp.isRejected(txHash) {
+		txmp.metrics.RejectedTxs.Add(1)
+		return types.ErrTxRejected
+	}
+
+	if txmp.checkTxCache != nil {
+		if res, ok := txmp.checkTxCache.Get(txHash); ok {
+			txmp.logger.Debug("refused transaction with a cached CheckTx response",
+				"tx", fmt.Sprintf("%X", tx.Hash()), "code", res.Code)
+			txmp.metrics.CachedCheckTxs.Add(1)
+			span.SetAttributes(attribute.Int64("code", int64(res.Code)))
+			if cb != nil {
+				cb(abci.ToResponseCheckTx(res))
+			}
+			return nil
+		}
+	}
+
 	// We add the transaction to the mempool's cache and if the transaction already
 	// exists, i.e. false is returned, then we check if we've seen this transaction
 	// from the same sender and error if we have. Otherwise, we return nil.
@@ -277,10 +339,6 @@ func (txmp *TxMempool) CheckTx(
 	}
 
 	reqRes.SetCallback(func(res *abci.Response) {
-		if txmp.recheckCursor != nil {
-			panic("recheck cursor is non-nil in CheckTx callback")
-		}
-
 		wtx := &WrappedTx{
 			tx:        tx,
 			hash:      txHash,
@@ -292,7 +350,13 @@ func (txmp *TxMempool) CheckTx(
 		if cb != nil {
 			cb(res)
 		}
+
+		if checkTxRes := res.GetCheckTx(); checkTxRes != nil {
+			span.SetAttributes(attribute.Int64("code", int64(checkTxRes.Code)))
+		}
+		span.End()
 	})
+	submitted = true
 
 	return nil
 }
@@ -304,12 +368,69 @@ func (txmp *TxMempool) RemoveTxByKey(txKey types.TxKey) error {
 	// remove the committed transaction from the transaction store and indexes
 	if wtx := txmp.txStore.GetTxByHash(txKey); wtx != nil {
 		txmp.removeTx(wtx, false)
+		txmp.archiveTxStatus(wtx, ArchivedTxRemoved, 0, nil)
 		return nil
 	}
 
 	return errors.New("transaction not found")
 }
 
+// RemoveTxBySender removes the transaction of a sender, as reported by the
+// application in CheckTx, from the mempool, and returns its key.
+func (txmp *TxMempool) RemoveTxBySender(sender string) (types.TxKey, error) {
+	txmp.Lock()
+	defer txmp.Unlock()
+
+	if wtx := txmp.txStore.GetTxBySender(sender); wtx != nil {
+		txmp.removeTx(wtx, false)
+		txmp.archiveTxStatus(wtx, ArchivedTxRemoved, 0, nil)
+		return wtx.hash, nil
+	}
+
+	return types.TxKey{}, errors.New("transaction not found")
+}
+
+// RejectTxs refuses the transactions, identified by their keys, without
+// calling CheckTx for the given duration, e.g. for transactions which crash
+// the application. The rejected transactions are kept in memory only.
+func (txmp *TxMempool) RejectTxs(duration time.Duration, txKeys ...types.TxKey) {
+	txmp.rejectedMtx.Lock()
+	defer txmp.rejectedMtx.Unlock()
+
+	now := time.Now()
+	for txKey, until := range txmp.rejectedTxs {
+		if !now.Before(until) {
+			delete(txmp.rejectedTxs, txKey)
+		}
+	}
+	for _, txKey := range txKeys {
+		txmp.rejectedTxs[txKey] = now.Add(duration)
+	}
+}
+
+// isRejected returns whether the transaction was rejected by the node operator
+// until a time not reached yet.
+func (txmp *TxMempool) isRejected(txKey types.TxKey) bool {
+	txmp.rejectedMtx.Lock()
+	defer txmp.rejectedMtx.Unlock()
+
+	until, ok := txmp.rejectedTxs[txKey]
+	if ok && !time.Now().Before(until) {
+		delete(txmp.rejectedTxs, txKey)
+		return false
+	}
+	return ok
+}
+
+// GetTxByKey returns the transaction identified by its key, if it is in the
+// mempool.
+func (txmp *TxMempool) GetTxByKey(txKey types.TxKey) (types.Tx, bool) {
+	if wtx := txmp.txStore.GetTxByHash(txKey); wtx != nil {
+		return wtx.tx, true
+	}
+	return nil, false
+}
+
 // Flush empties the mempool. It acquires a read-lock, fetches all the
 // transactions currently in the transaction store and removes each transaction
 // from the store and all indexes and finally resets the cache.
@@ -325,22 +446,49 @@ func (txmp *TxMempool) Flush() {
 
 	for _, wtx := range txmp.txStore.GetAllTxs() {
 		txmp.removeTx(wtx, false)
+		txmp.archiveTxStatus(wtx, ArchivedTxRemoved, 0, nil)
 	}
 
 	atomic.SwapInt64(&txmp.sizeBytes, 0)
 	txmp.cache.Reset()
+	if txmp.checkTxCache != nil {
+		txmp.checkTxCache.Reset()
+	}
 }
 
 // ReapMaxBytesMaxGas returns a list of transactions within the provided size
-// and gas constraints. Transaction are retrieved in priority order.
+// and gas constraints, the total gas wanted by the transactions being bounded
+// by both maxGas and maxGasWanted. Transaction are retrieved in priority order.
+// If any of them is still to be rechecked since the last block was committed,
+// it waits for their recheck, which may change the transactions returned.
 //
 // NOTE:
 // - Transactions returned are not removed from the mempool transaction
 //   store or indexes.
-func (txmp *TxMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
-	txmp.mtx.RLock()
-	defer txmp.mtx.RUnlock()
+func (txmp *TxMempool) ReapMaxBytesMaxGas(maxBytes, maxGas, maxGasWanted int64) types.Txs {
+	var cutoff bool
+	wTxs := txmp.waitForRecheck(func() []*WrappedTx {
+		var wTxs []*WrappedTx
+		wTxs, cutoff = txmp.reapMaxBytesMaxGas(maxBytes, maxGas, maxGasWanted)
+		return wTxs
+	})
+	if cutoff {
+		txmp.observeReapCutoff(wTxs)
+	}
 
+	txs := make([]types.Tx, len(wTxs))
+	for i, wtx := range wTxs {
+		txs[i] = wtx.tx
+	}
+	return txs
+}
+
+// reapMaxBytesMaxGas returns the transactions of ReapMaxBytesMaxGas, and
+// whether the constraints left some transactions out.
+//
+// NOTE:
+// - The caller must have a read-lock when executing reapMaxBytesMaxGas.
+func (txmp *TxMempool) reapMaxBytesMaxGas(maxBytes, maxGas, maxGasWanted int64) ([]*WrappedTx, bool) {
 	var (
 		totalGas  int64
 		totalSize int64
@@ -355,61 +503,75 @@ func (txmp *TxMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
 		}
 	}()
 
-	txs := make([]types.Tx, 0, txmp.priorityIndex.NumTxs())
 	for txmp.priorityIndex.NumTxs() > 0 {
 		wtx := txmp.priorityIndex.PopTx()
-		txs = append(txs, wtx.tx)
 		wTxs = append(wTxs, wtx)
 		size := types.ComputeProtoSizeForTxs([]types.Tx{wtx.tx})
 
 		// Ensure we have capacity for the transaction with respect to the
 		// transaction size.
 		if maxBytes > -1 && totalSize+size > maxBytes {
-			return txs[:len(txs)-1]
+			return wTxs[:len(wTxs)-1], true
 		}
 
 		totalSize += size
 
 		// ensure we have capacity for the transaction with respect to total gas
 		gas := totalGas + wtx.gasWanted
-		if maxGas > -1 && gas > maxGas {
-			return txs[:len(txs)-1]
+		if (maxGas > -1 && gas > maxGas) || (maxGasWanted > -1 && gas > maxGasWanted) {
+			return wTxs[:len(wTxs)-1], true
 		}
 
 		totalGas = gas
 	}
 
-	return txs
+	return wTxs, false
+}
+
+// observeReapCutoff records the lowest priority of the transactions reaped,
+// in priority order, for a block which could not fit all the transactions in
+// the mempool, if the application sets priorities.
+func (txmp *TxMempool) observeReapCutoff(reaped []*WrappedTx) {
+	if len(reaped) == 0 {
+		return
+	}
+	if cutoff := reaped[len(reaped)-1].priority; cutoff != 0 {
+		txmp.metrics.ReapCutoffPriority.Observe(float64(cutoff))
+	}
 }
 
 // ReapMaxTxs returns a list of transactions within the provided number of
-// transactions bound. Transaction are retrieved in priority order.
+// transactions bound. Transaction are retrieved in priority order. If any of
+// them is still to be rechecked since the last block was committed, it waits
+// for their recheck, as ReapMaxBytesMaxGas does.
 //
 // NOTE:
 // - Transactions returned are not removed from the mempool transaction
 //   store or indexes.
 func (txmp *TxMempool) ReapMaxTxs(max int) types.Txs {
-	txmp.mtx.RLock()
-	defer txmp.mtx.RUnlock()
+	wTxs := txmp.waitForRecheck(func() []*WrappedTx {
+		numTxs := txmp.priorityIndex.NumTxs()
+		if max < 0 {
+			max = numTxs
+		}
 
-	numTxs := txmp.priorityIndex.NumTxs()
-	if max < 0 {
-		max = numTxs
-	}
+		cap := tmmath.MinInt(numTxs, max)
 
-	cap := tmmath.MinInt(numTxs, max)
+		// wTxs contains a list of *WrappedTx retrieved from the priority queue that
+		// need to be re-enqueued prior to returning.
+		wTxs := make([]*WrappedTx, 0, cap)
+		for txmp.priorityIndex.NumTxs() > 0 && len(wTxs) < max {
+			wTxs = append(wTxs, txmp.priorityIndex.PopTx())
+		}
+		for _, wtx := range wTxs {
+			txmp.priorityIndex.PushTx(wtx)
+		}
+		return wTxs
+	})
 
-	// wTxs contains a list of *WrappedTx retrieved from the priority queue that
-	// need to be re-enqueued prior to returning.
-	wTxs := make([]*WrappedTx, 0, cap)
-	txs := make([]types.Tx, 0, cap)
-	for txmp.priorityIndex.NumTxs() > 0 && len(txs) < max {
-		wtx := txmp.priorityIndex.PopTx()
-		txs = append(txs, wtx.tx)
-		wTxs = append(wTxs, wtx)
-	}
-	for _, wtx := range wTxs {
-		txmp.priorityIndex.PushTx(wtx)
+	txs := make([]types.Tx, len(wTxs))
+	for i, wtx := range wTxs {
+		txs[i] = wtx.tx
 	}
 	return txs
 }
@@ -417,9 +579,9 @@ func (txmp *TxMempool) ReapMaxTxs(max int) types.Txs {
 // Update iterates over all the transactions provided by the block producer,
 // removes them from the cache (if applicable), and removes
 // the transactions from the main transaction store and associated indexes.
-// If there are transactions remaining in the mempool, we initiate a
-// re-CheckTx for them (if applicable), otherwise, we notify the caller more
-// transactions are available.
+// If there are transactions remaining in the mempool, we schedule a
+// re-CheckTx for them in the background (if applicable), otherwise, we notify
+// the caller more transactions are available.
 //
 // NOTE:
 // - The caller must explicitly acquire a write-lock.
@@ -453,32 +615,53 @@ func (txmp *TxMempool) Update(
 
 		// remove the committed transaction from the transaction store and indexes
 		if wtx := txmp.txStore.GetTxByHash(tx.Key()); wtx != nil {
+			txmp.metrics.TxTimeInMempoolSeconds.Observe(time.Since(wtx.timestamp).Seconds())
 			txmp.removeTx(wtx, false)
 		}
 	}
 
+	if txmp.txArchive != nil {
+		txmp.txArchive.CommitTxs(blockHeight, blockTxs, deliverTxResponses)
+	}
+
+	if txmp.checkTxCache != nil && txmp.config.CheckTxCacheResetOnCommit {
+		txmp.checkTxCache.Reset()
+	}
+
 	txmp.purgeExpiredTxs(blockHeight)
 
 	// If there any uncommitted transactions left in the mempool, we either
-	// initiate re-CheckTx per remaining transaction or notify that remaining
-	// transactions are left.
-	if txmp.Size() > 0 {
-		if txmp.config.Recheck {
+	// schedule re-CheckTx per remaining transaction or notify that remaining
+	// transactions are left. Scheduling supersedes the recheck in progress,
+	// even if no transaction is left.
+	if txmp.config.Recheck {
+		if txmp.Size() > 0 {
 			txmp.logger.Debug(
-				"executing re-CheckTx for all remaining transactions",
+				"scheduling re-CheckTx for all remaining transactions",
 				"num_txs", txmp.Size(),
 				"height", blockHeight,
 			)
-			txmp.updateReCheckTxs(ctx)
-		} else {
-			txmp.notifyTxsAvailable()
 		}
+		txmp.scheduleRecheck()
+	} else if txmp.Size() > 0 {
+		txmp.notifyTxsAvailable()
 	}
 
 	txmp.metrics.Size.Set(float64(txmp.Size()))
 	return nil
 }
 
+// bypassCheckTxCache returns whether rejections with the given CheckTx code
+// depend on the state, and are never cached.
+func (txmp *TxMempool) bypassCheckTxCache(code uint32) bool {
+	for _, c := range txmp.config.CheckTxCacheBypassCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
 // initTxCallback is the callback invoked for a new unique transaction after CheckTx
 // has been executed by the ABCI application for the first time on that transaction.
 // CheckTx can be called again for the same transaction later when re-checking;
@@ -490,6 +673,10 @@ func (txmp *TxMempool) Update(
 // reports an error, the transaction is rejected. Otherwise, we attempt to insert
 // the transaction into the mempool.
 //
+// A transaction of a sender, as reported by the application, which already has
+// a transaction in the mempool, is rejected, unless it has the same sequence and
+// a higher priority, in which case it replaces the existing transaction.
+//
 // When inserting a transaction, we first check if there is sufficient capacity.
 // If there is, the transaction is added to the txStore and all indexes.
 // Otherwise, if the mempool is full, we attempt to find a lower priority transaction
@@ -521,6 +708,14 @@ func (txmp *TxMempool) initTxCallback(wtx *WrappedTx, res *abci.Response, txInfo
 		)
 
 		txmp.metrics.FailedTxs.Add(1)
+		txmp.recordRejectedTx(wtx.tx, checkTxRes.CheckTx, err, txInfo.SenderNodeID, false)
+		txmp.archiveCheckedTx(wtx, checkTxRes.CheckTx, txInfo, ArchivedTxRejected, err)
+
+		// Cache the rejection by the application, unless it depends on the
+		// state, to refuse the transaction again without calling CheckTx.
+		if err == nil && txmp.checkTxCache != nil && !txmp.bypassCheckTxCache(checkTxRes.CheckTx.Code) {
+			txmp.checkTxCache.Put(wtx.hash, *checkTxRes.CheckTx)
+		}
 
 		if !txmp.config.KeepInvalidTxsInCache {
 			txmp.cache.Remove(wtx.tx)
@@ -533,25 +728,39 @@ func (txmp *TxMempool) initTxCallback(wtx *WrappedTx, res *abci.Response, txInfo
 
 	sender := checkTxRes.CheckTx.Sender
 	priority := checkTxRes.CheckTx.Priority
+	wtx.lane = txmp.laneOf(checkTxRes.CheckTx.Lane)
 
+	// A transaction of the same sender and sequence as the existing one of its
+	// sender, with a high enough priority, replaces it. The existing one is
+	// removed first to make room for its replacement, and restored if the
+	// replacement can't be added to the mempool.
+	var replaced *WrappedTx
 	if len(sender) > 0 {
-		if wtx := txmp.txStore.GetTxBySender(sender); wtx != nil {
-			txmp.logger.Error(
-				"rejected incoming good transaction; tx already exists for sender",
-				"tx", fmt.Sprintf("%X", wtx.tx.Hash()),
-				"sender", sender,
-			)
-			txmp.metrics.RejectedTxs.Add(1)
-			return
+		if existing := txmp.txStore.GetTxBySender(sender); existing != nil {
+			if !txmp.canReplaceTx(existing, checkTxRes.CheckTx.Sequence, priority) {
+				txmp.logger.Error(
+					"rejected incoming good transaction; tx already exists for sender",
+					"tx", fmt.Sprintf("%X", existing.tx.Hash()),
+					"sender", sender,
+				)
+				txmp.metrics.RejectedTxs.Add(1)
+				txmp.archiveCheckedTx(wtx, checkTxRes.CheckTx, txInfo, ArchivedTxRejected, errTxSenderExists)
+				return
+			}
+
+			replaced = existing
+			txmp.removeTx(replaced, false)
 		}
 	}
 
 	if err := txmp.canAddTx(wtx); err != nil {
+		lane := txmp.lanes[wtx.lane]
 		evictTxs := txmp.priorityIndex.GetEvictableTxs(
+			wtx.lane,
 			priority,
 			int64(wtx.Size()),
-			txmp.SizeBytes(),
-			txmp.config.MaxTxsBytes,
+			atomic.LoadInt64(&lane.sizeBytes),
+			lane.maxTxsBytes,
 		)
 		if len(evictTxs) == 0 {
 			// No room for the new incoming transaction so we just remove it from
@@ -560,9 +769,15 @@ func (txmp *TxMempool) initTxCallback(wtx *WrappedTx, res *abci.Response, txInfo
 			txmp.logger.Error(
 				"rejected incoming good transaction; mempool full",
 				"tx", fmt.Sprintf("%X", wtx.tx.Hash()),
+				"lane", lane.name,
 				"err", err.Error(),
 			)
 			txmp.metrics.RejectedTxs.Add(1)
+			txmp.archiveCheckedTx(wtx, checkTxRes.CheckTx, txInfo, ArchivedTxRejected, err)
+			if replaced != nil {
+				replaced.removed = false
+				txmp.insertTx(replaced)
+			}
 			return
 		}
 
@@ -579,22 +794,43 @@ func (txmp *TxMempool) initTxCallback(wtx *WrappedTx, res *abci.Response, txInfo
 				"old_priority", toEvict.priority,
 				"new_tx", fmt.Sprintf("%X", wtx.tx.Hash()),
 				"new_priority", wtx.priority,
+				"lane", lane.name,
 			)
 			txmp.metrics.EvictedTxs.Add(1)
+			txmp.archiveTxStatus(toEvict, ArchivedTxEvicted, 0, nil)
 		}
 	}
 
 	wtx.gasWanted = checkTxRes.CheckTx.GasWanted
 	wtx.priority = priority
 	wtx.sender = sender
+	wtx.sequence = checkTxRes.CheckTx.Sequence
 	wtx.peers = map[uint16]struct{}{
 		txInfo.SenderID: {},
 	}
 
 	txmp.metrics.TxSizeBytes.Observe(float64(wtx.Size()))
+	if priority != 0 {
+		txmp.metrics.TxPriority.Observe(float64(priority))
+	}
 	txmp.metrics.Size.Set(float64(txmp.Size()))
 
+	if replaced != nil {
+		txmp.logger.Debug(
+			"replaced existing good transaction of the same sender and sequence",
+			"old_tx", fmt.Sprintf("%X", replaced.tx.Hash()),
+			"old_priority", replaced.priority,
+			"new_tx", fmt.Sprintf("%X", wtx.tx.Hash()),
+			"new_priority", wtx.priority,
+			"sender", sender,
+			"sequence", wtx.sequence,
+		)
+		txmp.metrics.ReplacedTxs.Add(1)
+		txmp.archiveTxStatus(replaced, ArchivedTxReplaced, 0, nil)
+	}
+
 	txmp.insertTx(wtx)
+	txmp.archiveCheckedTx(wtx, checkTxRes.CheckTx, txInfo, ArchivedTxPending, nil)
 	txmp.logger.Debug(
 		"inserted good transaction",
 		"priority", wtx.priority,
@@ -605,156 +841,47 @@ func (txmp *TxMempool) initTxCallback(wtx *WrappedTx, res *abci.Response, txInfo
 	txmp.notifyTxsAvailable()
 }
 
-// defaultTxCallback is the CheckTx application callback used when a transaction
-// is being re-checked (if re-checking is enabled). The caller must hold a mempool
-// write-lock (via Lock()) and when executing Update(), if the mempool is non-empty
-// and Recheck is enabled, then all remaining transactions will be rechecked via
-// CheckTxAsync. The order transactions are rechecked must be the same as the
-// order in which this callback is called.
+// defaultTxCallback is the global CheckTx application callback, executed for
+// all the CheckTx responses. It only counts rechecks, whose responses are
+// handled by the recheck worker.
 func (txmp *TxMempool) defaultTxCallback(req *abci.Request, res *abci.Response) {
-	if txmp.recheckCursor == nil {
-		return
-	}
-
-	txmp.metrics.RecheckTimes.Add(1)
-
-	checkTxRes, ok := res.Value.(*abci.Response_CheckTx)
-	if !ok {
-		txmp.logger.Error("received incorrect type in mempool callback",
-			"expected", reflect.TypeOf(&abci.Response_CheckTx{}).Name(),
-			"got", reflect.TypeOf(res.Value).Name(),
-		)
-		return
+	if req.GetCheckTx().GetType() == abci.CheckTxType_Recheck {
+		txmp.metrics.RecheckTimes.Add(1)
 	}
-	tx := req.GetCheckTx().Tx
-	wtx := txmp.recheckCursor.Value.(*WrappedTx)
-
-	// Search through the remaining list of tx to recheck for a transaction that matches
-	// the one we received from the ABCI application.
-	for {
-		if bytes.Equal(tx, wtx.tx) {
-			// We've found a tx in the recheck list that matches the tx that we
-			// received from the ABCI application.
-			// Break, and use this transaction for further checks.
-			break
-		}
-
-		txmp.logger.Error(
-			"re-CheckTx transaction mismatch",
-			"got", wtx.tx.Hash(),
-			"expected", types.Tx(tx).Key(),
-		)
-
-		if txmp.recheckCursor == txmp.recheckEnd {
-			// we reached the end of the recheckTx list without finding a tx
-			// matching the one we received from the ABCI application.
-			// Return without processing any tx.
-			txmp.recheckCursor = nil
-			return
-		}
-
-		txmp.recheckCursor = txmp.recheckCursor.Next()
-		wtx = txmp.recheckCursor.Value.(*WrappedTx)
-	}
-
-	// Only evaluate transactions that have not been removed. This can happen
-	// if an existing transaction is evicted during CheckTx and while this
-	// callback is being executed for the same evicted transaction.
-	if !txmp.txStore.IsTxRemoved(wtx.hash) {
-		var err error
-		if txmp.postCheck != nil {
-			err = txmp.postCheck(tx, checkTxRes.CheckTx)
-		}
-
-		if checkTxRes.CheckTx.Code == abci.CodeTypeOK && err == nil {
-			wtx.priority = checkTxRes.CheckTx.Priority
-		} else {
-			txmp.logger.Debug(
-				"existing transaction no longer valid; failed re-CheckTx callback",
-				"priority", wtx.priority,
-				"tx", fmt.Sprintf("%X", wtx.tx.Hash()),
-				"err", err,
-				"code", checkTxRes.CheckTx.Code,
-			)
-
-			if wtx.gossipEl != txmp.recheckCursor {
-				panic("corrupted reCheckTx cursor")
-			}
-
-			txmp.removeTx(wtx, !txmp.config.KeepInvalidTxsInCache)
-		}
-	}
-
-	// move reCheckTx cursor to next element
-	if txmp.recheckCursor == txmp.recheckEnd {
-		txmp.recheckCursor = nil
-	} else {
-		txmp.recheckCursor = txmp.recheckCursor.Next()
-	}
-
-	if txmp.recheckCursor == nil {
-		txmp.logger.Debug("finished rechecking transactions")
-
-		if txmp.Size() > 0 {
-			txmp.notifyTxsAvailable()
-		}
-	}
-
-	txmp.metrics.Size.Set(float64(txmp.Size()))
 }
 
-// updateReCheckTxs updates the recheck cursors using the gossipIndex. For
-// each transaction, it executes CheckTxAsync. The global callback defined on
-// the proxyAppConn will be executed for each transaction after CheckTx is
-// executed.
-//
-// NOTE:
-// - The caller must have a write-lock when executing updateReCheckTxs.
-func (txmp *TxMempool) updateReCheckTxs(ctx context.Context) {
-	if txmp.Size() == 0 {
-		panic("attempted to update re-CheckTx txs when mempool is empty")
+// canReplaceTx returns true if a transaction of the given sequence and priority
+// can replace the existing transaction of its sender, i.e. if they have the
+// same sequence, and its priority is higher by at least replacement-priority-bump
+// percent of the existing one's, or just higher if the latter isn't positive.
+func (txmp *TxMempool) canReplaceTx(existing *WrappedTx, sequence uint64, priority int64) bool {
+	if sequence != existing.sequence || priority <= existing.priority {
+		return false
 	}
-
-	txmp.recheckCursor = txmp.gossipIndex.Front()
-	txmp.recheckEnd = txmp.gossipIndex.Back()
-
-	for e := txmp.gossipIndex.Front(); e != nil; e = e.Next() {
-		wtx := e.Value.(*WrappedTx)
-
-		// Only execute CheckTx if the transaction is not marked as removed which
-		// could happen if the transaction was evicted.
-		if !txmp.txStore.IsTxRemoved(wtx.hash) {
-			_, err := txmp.proxyAppConn.CheckTxAsync(ctx, abci.RequestCheckTx{
-				Tx:   wtx.tx,
-				Type: abci.CheckTxType_Recheck,
-			})
-			if err != nil {
-				// no need in retrying since the tx will be rechecked after the next block
-				txmp.logger.Error("failed to execute CheckTx during rechecking", "err", err)
-			}
-		}
+	if existing.priority <= 0 {
+		return true
 	}
 
-	if _, err := txmp.proxyAppConn.FlushAsync(ctx); err != nil {
-		txmp.logger.Error("failed to flush transactions during rechecking", "err", err)
-	}
+	bump := float64(existing.priority) * float64(txmp.config.ReplacementPriorityBump) / 100
+	return float64(priority-existing.priority) >= bump
 }
 
 // canAddTx returns an error if we cannot insert the provided *WrappedTx into
-// the mempool due to mempool configured constraints. If it returns nil,
-// the transaction can be inserted into the mempool.
+// its lane of the mempool due to the lane's configured constraints. If it
+// returns nil, the transaction can be inserted into the mempool.
 func (txmp *TxMempool) canAddTx(wtx *WrappedTx) error {
 	var (
-		numTxs    = txmp.Size()
-		sizeBytes = txmp.SizeBytes()
+		lane      = txmp.lanes[wtx.lane]
+		numTxs    = int(atomic.LoadInt64(&lane.numTxs))
+		sizeBytes = atomic.LoadInt64(&lane.sizeBytes)
 	)
 
-	if numTxs >= txmp.config.Size || int64(wtx.Size())+sizeBytes > txmp.config.MaxTxsBytes {
+	if numTxs >= lane.maxTxs || int64(wtx.Size())+sizeBytes > lane.maxTxsBytes {
 		return types.ErrMempoolIsFull{
 			NumTxs:      numTxs,
-			MaxTxs:      txmp.config.Size,
+			MaxTxs:      lane.maxTxs,
 			TxsBytes:    sizeBytes,
-			MaxTxsBytes: txmp.config.MaxTxsBytes,
+			MaxTxsBytes: lane.maxTxsBytes,
 		}
 	}
 
@@ -774,6 +901,8 @@ func (txmp *TxMempool) insertTx(wtx *WrappedTx) {
 	wtx.gossipEl = gossipEl
 
 	atomic.AddInt64(&txmp.sizeBytes, int64(wtx.Size()))
+	atomic.AddInt64(&txmp.lanes[wtx.lane].numTxs, 1)
+	atomic.AddInt64(&txmp.lanes[wtx.lane].sizeBytes, int64(wtx.Size()))
 }
 
 func (txmp *TxMempool) removeTx(wtx *WrappedTx, removeFromCache bool) {
@@ -792,6 +921,8 @@ func (txmp *TxMempool) removeTx(wtx *WrappedTx, removeFromCache bool) {
 	wtx.gossipEl.DetachPrev()
 
 	atomic.AddInt64(&txmp.sizeBytes, int64(-wtx.Size()))
+	atomic.AddInt64(&txmp.lanes[wtx.lane].numTxs, -1)
+	atomic.AddInt64(&txmp.lanes[wtx.lane].sizeBytes, int64(-wtx.Size()))
 
 	if removeFromCache {
 		txmp.cache.Remove(wtx.tx)
@@ -845,6 +976,7 @@ func (txmp *TxMempool) purgeExpiredTxs(blockHeight int64) {
 
 	for _, wtx := range expiredTxs {
 		txmp.removeTx(wtx, false)
+		txmp.archiveTxStatus(wtx, ArchivedTxExpired, 0, nil)
 	}
 }
 
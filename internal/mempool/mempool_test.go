@@ -14,7 +14,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/go-kit/kit/metrics/generic"
 	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
 
 	abciclient "github.com/tendermint/tendermint/abci/client"
 	"github.com/tendermint/tendermint/abci/example/code"
@@ -36,10 +38,14 @@ type testTx struct {
 	priority int64
 }
 
+// CheckTx classifies the transactions of senders prefixed by a lane name and a
+// slash into the lane, and reports the sequence of senders suffixed by a colon
+// and a number.
 func (app *application) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
 	var (
 		priority int64
 		sender   string
+		sequence uint64
 	)
 
 	// infer the priority from the raw transaction value (sender=key=value)
@@ -64,9 +70,20 @@ func (app *application) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
 		}
 	}
 
+	var lane string
+	if i := strings.Index(sender, "/"); i > 0 {
+		lane = sender[:i]
+	}
+	if i := strings.Index(sender, ":"); i > 0 {
+		sequence, _ = strconv.ParseUint(sender[i+1:], 10, 64)
+		sender = sender[:i]
+	}
+
 	return abci.ResponseCheckTx{
 		Priority:  priority,
 		Sender:    sender,
+		Sequence:  sequence,
+		Lane:      lane,
 		Code:      code.CodeTypeOK,
 		GasWanted: 1,
 	}
@@ -75,16 +92,44 @@ func (app *application) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
 func setup(ctx context.Context, t testing.TB, cacheSize int, options ...TxMempoolOption) *TxMempool {
 	t.Helper()
 
+	return setupWithConfig(ctx, t, func(cfg *config.MempoolConfig) {
+		cfg.CacheSize = cacheSize
+	}, options...)
+}
+
+// setupWithConfig returns a mempool with the test configuration modified by
+// configure.
+func setupWithConfig(
+	ctx context.Context,
+	t testing.TB,
+	configure func(*config.MempoolConfig),
+	options ...TxMempoolOption,
+) *TxMempool {
+	t.Helper()
+
+	return setupWithApp(ctx, t, &application{kvstore.NewApplication()}, configure, options...)
+}
+
+// setupWithApp returns a mempool of the given application, with the test
+// configuration modified by configure.
+func setupWithApp(
+	ctx context.Context,
+	t testing.TB,
+	app abci.Application,
+	configure func(*config.MempoolConfig),
+	options ...TxMempoolOption,
+) *TxMempool {
+	t.Helper()
+
 	var cancel context.CancelFunc
 	ctx, cancel = context.WithCancel(ctx)
 
-	app := &application{kvstore.NewApplication()}
 	cc := abciclient.NewLocalCreator(app)
 	logger := log.TestingLogger()
 
 	cfg, err := config.ResetTestRoot(strings.ReplaceAll(t.Name(), "/", "|"))
 	require.NoError(t, err)
-	cfg.Mempool.CacheSize = cacheSize
+	configure(cfg.Mempool)
 	appConnMem, err := cc(logger)
 	require.NoError(t, err)
 	require.NoError(t, appConnMem.Start(ctx))
@@ -217,6 +262,66 @@ func TestTxMempool_Size(t *testing.T) {
 	require.Equal(t, int64(2850), txmp.SizeBytes())
 }
 
+// recheckApplication blocks rechecks until released, and invalidates the
+// transactions of the senders prefixed by "invalid" on recheck.
+type recheckApplication struct {
+	*application
+	release chan struct{}
+}
+
+func (app *recheckApplication) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	if req.Type == abci.CheckTxType_Recheck {
+		<-app.release
+		if bytes.HasPrefix(req.Tx, []byte("invalid")) {
+			return abci.ResponseCheckTx{Code: 102}
+		}
+	}
+	return app.application.CheckTx(req)
+}
+
+func TestTxMempool_AsyncRecheck(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	app := &recheckApplication{
+		application: &application{kvstore.NewApplication()},
+		release:     make(chan struct{}),
+	}
+	txmp := setupWithApp(ctx, t, app, func(*config.MempoolConfig) {})
+
+	for i := 0; i < 5; i++ {
+		tx := types.Tx(fmt.Sprintf("sender-%d=key=%d", i, i+1))
+		require.NoError(t, txmp.CheckTx(ctx, tx, nil, TxInfo{SenderID: 0}))
+	}
+	require.NoError(t, txmp.CheckTx(ctx, types.Tx("invalid-0=key=10"), nil, TxInfo{SenderID: 0}))
+	require.Equal(t, 6, txmp.Size())
+
+	// committing a block doesn't wait for the recheck of the mempool
+	txmp.Lock()
+	require.NoError(t, txmp.Update(ctx, 1, nil, nil, nil, nil))
+	txmp.Unlock()
+	require.Equal(t, 6, txmp.Size())
+
+	// but reaping waits for the recheck of the transactions reaped
+	reaped := make(chan types.Txs, 1)
+	go func() { reaped <- txmp.ReapMaxTxs(1) }()
+	select {
+	case txs := <-reaped:
+		t.Fatalf("reaped %v before the recheck", txs)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(app.release)
+	select {
+	case txs := <-reaped:
+		require.Equal(t, types.Txs{types.Tx("sender-4=key=5")}, txs)
+	case <-time.After(5 * time.Second):
+		t.Fatal("reaping did not complete after the recheck")
+	}
+	require.Equal(t, 5, txmp.Size())
+	require.Len(t, txmp.ReapMaxTxs(-1), 5)
+}
+
 func TestTxMempool_Flush(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -276,14 +381,14 @@ func TestTxMempool_ReapMaxBytesMaxGas(t *testing.T) {
 	}
 
 	// reap by gas capacity only
-	reapedTxs := txmp.ReapMaxBytesMaxGas(-1, 50)
+	reapedTxs := txmp.ReapMaxBytesMaxGas(-1, 50, -1)
 	ensurePrioritized(reapedTxs)
 	require.Equal(t, len(tTxs), txmp.Size())
 	require.Equal(t, int64(5690), txmp.SizeBytes())
 	require.Len(t, reapedTxs, 50)
 
 	// reap by transaction bytes only
-	reapedTxs = txmp.ReapMaxBytesMaxGas(1000, -1)
+	reapedTxs = txmp.ReapMaxBytesMaxGas(1000, -1, -1)
 	ensurePrioritized(reapedTxs)
 	require.Equal(t, len(tTxs), txmp.Size())
 	require.Equal(t, int64(5690), txmp.SizeBytes())
@@ -291,11 +396,20 @@ func TestTxMempool_ReapMaxBytesMaxGas(t *testing.T) {
 
 	// Reap by both transaction bytes and gas, where the size yields 31 reaped
 	// transactions and the gas limit reaps 25 transactions.
-	reapedTxs = txmp.ReapMaxBytesMaxGas(1500, 30)
+	reapedTxs = txmp.ReapMaxBytesMaxGas(1500, 30, -1)
 	ensurePrioritized(reapedTxs)
 	require.Equal(t, len(tTxs), txmp.Size())
 	require.Equal(t, int64(5690), txmp.SizeBytes())
 	require.Len(t, reapedTxs, 25)
+
+	// reap by gas wanted, which bounds the total gas wanted below the gas
+	// capacity
+	reapedTxs = txmp.ReapMaxBytesMaxGas(-1, 50, 20)
+	ensurePrioritized(reapedTxs)
+	require.Len(t, reapedTxs, 20)
+	reapedTxs = txmp.ReapMaxBytesMaxGas(-1, -1, 40)
+	ensurePrioritized(reapedTxs)
+	require.Len(t, reapedTxs, 40)
 }
 
 func TestTxMempool_ReapMaxTxs(t *testing.T) {
@@ -413,6 +527,81 @@ func TestTxMempool_CheckTxSameSender(t *testing.T) {
 	require.Equal(t, 1, txmp.Size())
 }
 
+func TestTxMempool_ReplaceTx(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ta := NewTxArchive(log.TestingLogger(), dbm.NewMemDB(), 0)
+	txmp := setupWithConfig(ctx, t, func(cfg *config.MempoolConfig) {
+		cfg.MaxTxsBytes = 48
+	}, WithTxArchive(ta, nil))
+
+	txs := []types.Tx{types.Tx("alice:1=a=100"), types.Tx("bob=b=1000")}
+	for _, tx := range txs {
+		require.NoError(t, txmp.CheckTx(ctx, tx, nil, TxInfo{}))
+	}
+
+	// a transaction of another sequence, or of a priority not higher by at
+	// least replacement-priority-bump percent, doesn't replace the existing one
+	for _, tx := range []types.Tx{types.Tx("alice:2=c=200"), types.Tx("alice:1=d=109")} {
+		require.NoError(t, txmp.CheckTx(ctx, tx, nil, TxInfo{}))
+		require.Nil(t, txmp.txStore.GetTxByHash(tx.Key()))
+	}
+
+	// the existing transaction is kept if its replacement doesn't fit in the
+	// mempool
+	tooBig := types.Tx("alice:1=eeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee=500")
+	require.NoError(t, txmp.CheckTx(ctx, tooBig, nil, TxInfo{}))
+	require.Nil(t, txmp.txStore.GetTxByHash(tooBig.Key()))
+	require.Equal(t, txs[0], txmp.txStore.GetTxBySender("alice").tx)
+	require.Equal(t, 2, txmp.Size())
+
+	replacement := types.Tx("alice:1=f=110")
+	require.NoError(t, txmp.CheckTx(ctx, replacement, nil, TxInfo{}))
+	require.Equal(t, 2, txmp.Size())
+	require.Nil(t, txmp.txStore.GetTxByHash(txs[0].Key()))
+	require.Equal(t, replacement, txmp.txStore.GetTxBySender("alice").tx)
+	require.Equal(t, replacement, types.Tx(txmp.ReapMaxTxs(-1)[1]))
+
+	// the replacement is gossiped after the transactions already gossiped
+	el := txmp.gossipIndex.Back()
+	require.Equal(t, replacement, el.Value.(*WrappedTx).tx)
+
+	atx, err := ta.GetTx(txs[0].Hash())
+	require.NoError(t, err)
+	require.Equal(t, ArchivedTxReplaced, atx.Status)
+}
+
+func TestTxMempool_RemoveTxBySenderRejectTxs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	txmp := setup(ctx, t, 0)
+	tx1 := types.Tx("sender-1=key=10")
+	tx2 := types.Tx("sender-2=key=10")
+	require.NoError(t, txmp.CheckTx(ctx, tx1, nil, TxInfo{}))
+	require.NoError(t, txmp.CheckTx(ctx, tx2, nil, TxInfo{}))
+	require.Equal(t, 2, txmp.Size())
+
+	txKey, err := txmp.RemoveTxBySender("sender-1")
+	require.NoError(t, err)
+	require.Equal(t, tx1.Key(), txKey)
+	require.Equal(t, 1, txmp.Size())
+	_, err = txmp.RemoveTxBySender("sender-1")
+	require.Error(t, err)
+
+	// a rejected transaction is refused until the rejection expires
+	txmp.RejectTxs(time.Hour, tx1.Key())
+	txmp.RejectTxs(time.Millisecond, tx2.Key())
+	require.ErrorIs(t, txmp.CheckTx(ctx, tx1, nil, TxInfo{}), types.ErrTxRejected)
+	require.Equal(t, 1, txmp.Size())
+
+	require.NoError(t, txmp.RemoveTxByKey(tx2.Key()))
+	time.Sleep(2 * time.Millisecond)
+	require.NoError(t, txmp.CheckTx(ctx, tx2, nil, TxInfo{}))
+	require.Equal(t, 1, txmp.Size())
+}
+
 func TestTxMempool_ConcurrentTxs(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -578,3 +767,186 @@ func TestTxMempool_CheckTxPostCheckError(t *testing.T) {
 		})
 	}
 }
+
+func TestTxMempool_CheckTxCache(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cachedCheckTxs := generic.NewCounter("cached_check_txs")
+	metrics := NopMetrics()
+	metrics.CachedCheckTxs = cachedCheckTxs
+	txmp := setup(ctx, t, 0, WithMetrics(metrics))
+	txmp.checkTxCache = NewCheckTxCache(10)
+	txmp.config.CheckTxCacheBypassCodes = []uint32{100}
+
+	checkTx := func(tx types.Tx) uint32 {
+		var code uint32
+		callback := func(res *abci.Response) {
+			code = res.GetCheckTx().Code
+		}
+		require.NoError(t, txmp.CheckTx(ctx, tx, callback, TxInfo{SenderID: 0}))
+		return code
+	}
+	update := func(height int64) {
+		txmp.Lock()
+		defer txmp.Unlock()
+		require.NoError(t, txmp.Update(ctx, height, nil, nil, nil, nil))
+	}
+
+	// the rejection of a malformed transaction is cached, and the transaction
+	// refused with it without calling CheckTx
+	malformed := types.Tx("malformed")
+	require.Equal(t, uint32(101), checkTx(malformed))
+	require.Equal(t, 1, txmp.checkTxCache.Len())
+	require.Equal(t, uint32(101), checkTx(malformed))
+	require.Equal(t, float64(1), cachedCheckTxs.Value())
+
+	// a rejection with a bypassed code is never cached
+	badPriority := types.Tx("sender=key=priority")
+	require.Equal(t, uint32(100), checkTx(badPriority))
+	require.Equal(t, uint32(100), checkTx(badPriority))
+	require.Equal(t, 1, txmp.checkTxCache.Len())
+	require.Equal(t, float64(1), cachedCheckTxs.Value())
+
+	// the cache is reset when a block is committed
+	update(1)
+	require.Equal(t, 0, txmp.checkTxCache.Len())
+
+	// unless configured otherwise
+	txmp.config.CheckTxCacheResetOnCommit = false
+	require.Equal(t, uint32(101), checkTx(malformed))
+	update(2)
+	require.Equal(t, 1, txmp.checkTxCache.Len())
+	require.Equal(t, uint32(101), checkTx(malformed))
+	require.Equal(t, float64(2), cachedCheckTxs.Value())
+
+	// accepted transactions are not cached
+	require.Equal(t, code.CodeTypeOK, checkTx(types.Tx("sender=key=1")))
+	require.Equal(t, 1, txmp.checkTxCache.Len())
+}
+
+type rejectedTxRecorder struct {
+	rejected []RejectedTx
+}
+
+func (r *rejectedTxRecorder) RecordRejectedTx(rejected RejectedTx) {
+	r.rejected = append(r.rejected, rejected)
+}
+
+func TestTxMempool_RejectedTxSink(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	postCheckErr := errors.New("post-check error")
+	postCheckFn := func(tx types.Tx, _ *abci.ResponseCheckTx) error {
+		if bytes.HasPrefix(tx, []byte("spam")) {
+			return postCheckErr
+		}
+		return nil
+	}
+	sink := &rejectedTxRecorder{}
+	txmp := setup(ctx, t, 0, WithPostCheck(postCheckFn), WithRejectedTxSink(sink))
+
+	peerID := types.NodeID("0123456789abcdef0123456789abcdef01234567")
+	malformed := types.Tx("malformed")
+	require.NoError(t, txmp.CheckTx(ctx, malformed, nil, TxInfo{SenderID: 1, SenderNodeID: peerID}))
+	require.NoError(t, txmp.CheckTx(ctx, types.Tx("sender=key=1"), nil, TxInfo{SenderID: 0}))
+	spam := types.Tx("spam=key=1")
+	require.NoError(t, txmp.CheckTx(ctx, spam, nil, TxInfo{SenderID: 0}))
+
+	// accepted transactions are not recorded
+	require.Len(t, sink.rejected, 2)
+	require.Equal(t, malformed.Hash(), []byte(sink.rejected[0].Hash))
+	require.Equal(t, uint32(101), sink.rejected[0].Code)
+	require.Equal(t, peerID, sink.rejected[0].Peer)
+	require.Empty(t, sink.rejected[0].MempoolError)
+	require.False(t, sink.rejected[0].Recheck)
+
+	require.Equal(t, spam.Hash(), []byte(sink.rejected[1].Hash))
+	require.Equal(t, code.CodeTypeOK, sink.rejected[1].Code)
+	require.Equal(t, postCheckErr.Error(), sink.rejected[1].MempoolError)
+	require.Empty(t, sink.rejected[1].Peer)
+}
+
+func TestTxMempool_PriorityMetrics(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	metrics := NopMetrics()
+	txPriority := generic.NewHistogram("tx_priority", 10)
+	reapCutoff := generic.NewHistogram("reap_cutoff_priority", 10)
+	timeInMempool := generic.NewHistogram("tx_time_in_mempool_seconds", 10)
+	metrics.TxPriority = txPriority
+	metrics.ReapCutoffPriority = reapCutoff
+	metrics.TxTimeInMempoolSeconds = timeInMempool
+	txmp := setup(ctx, t, 0, WithMetrics(metrics))
+
+	txs := types.Txs{
+		types.Tx("sender-1=key=10"),
+		types.Tx("sender-2=key=20"),
+		types.Tx("sender-3=key=30"),
+	}
+	for _, tx := range txs {
+		require.NoError(t, txmp.CheckTx(ctx, tx, nil, TxInfo{SenderID: 0}))
+	}
+	require.Equal(t, 20.0, txPriority.Quantile(0.5))
+
+	// a block with room for all transactions has no cut-off
+	require.Len(t, txmp.ReapMaxBytesMaxGas(-1, -1, -1), 3)
+	require.Equal(t, -1.0, reapCutoff.Quantile(0.5)) // nothing observed
+
+	// the gas limit leaves room for the two highest priority transactions
+	reaped := txmp.ReapMaxBytesMaxGas(-1, 2, -1)
+	require.Len(t, reaped, 2)
+	require.Equal(t, 20.0, reapCutoff.Quantile(0.5))
+
+	txmp.Lock()
+	responses := []*abci.ResponseDeliverTx{{Code: abci.CodeTypeOK}, {Code: abci.CodeTypeOK}}
+	require.NoError(t, txmp.Update(ctx, 1, reaped, responses, nil, nil))
+	txmp.Unlock()
+	require.Equal(t, 1, txmp.Size())
+	require.Greater(t, timeInMempool.Quantile(0.99), 0.0)
+}
+
+func TestTxMempool_Lanes(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	txmp := setupWithConfig(ctx, t, func(cfg *config.MempoolConfig) {
+		cfg.Size = 3
+		cfg.Lanes = []string{"oracle:2:1000"}
+	})
+
+	checkTx := func(tx string) {
+		require.NoError(t, txmp.CheckTx(ctx, types.Tx(tx), nil, TxInfo{SenderID: 0}))
+	}
+	for i := 0; i < 3; i++ {
+		checkTx(fmt.Sprintf("user-%d=key=%d", i, 100+i))
+	}
+	require.Equal(t, 3, txmp.LaneSize(""))
+
+	// the default lane is full, but the transactions of the oracle lane are
+	// limited on their own
+	checkTx("user-3=key=1")
+	checkTx("oracle/1=key=10")
+	checkTx("oracle/2=key=15")
+	require.Equal(t, 3, txmp.LaneSize("user"))
+	require.Equal(t, 2, txmp.LaneSize("oracle"))
+	require.Equal(t, 5, txmp.Size())
+
+	// a transaction only evicts those of its lane
+	checkTx("oracle/3=key=5")
+	require.Equal(t, 2, txmp.LaneSize("oracle"))
+	checkTx("oracle/4=key=20")
+	require.Equal(t, 2, txmp.LaneSize("oracle"))
+	require.Equal(t, 3, txmp.LaneSize(""))
+
+	// the transactions of the oracle lane are reaped first
+	require.Equal(t, types.Txs{
+		types.Tx("oracle/4=key=20"),
+		types.Tx("oracle/2=key=15"),
+		types.Tx("user-2=key=102"),
+		types.Tx("user-1=key=101"),
+		types.Tx("user-0=key=100"),
+	}, txmp.ReapMaxTxs(-1))
+}
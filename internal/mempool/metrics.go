@@ -37,8 +37,28 @@ type Metrics struct {
 	// CheckTx.
 	EvictedTxs metrics.Counter
 
+	// ReplacedTxs defines the number of transactions replaced by transactions
+	// of the same sender and sequence with a higher priority.
+	ReplacedTxs metrics.Counter
+
 	// Number of times transactions are rechecked in the mempool.
 	RecheckTimes metrics.Counter
+
+	// Number of transactions refused with a cached CheckTx response.
+	CachedCheckTxs metrics.Counter
+
+	// Histogram of the priorities of transactions added to the mempool, for
+	// applications setting priorities.
+	TxPriority metrics.Histogram
+
+	// Histogram of the lowest priority of the transactions reaped for a block
+	// which could not fit all the transactions in the mempool, i.e. the
+	// priority a transaction needed to be included in the block.
+	ReapCutoffPriority metrics.Histogram
+
+	// Histogram of the time transactions spent in the mempool before being
+	// included in a block, in seconds.
+	TxTimeInMempoolSeconds metrics.Histogram
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -86,23 +106,66 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Help:      "Number of evicted transactions.",
 		}, labels).With(labelsAndValues...),
 
+		ReplacedTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "replaced_txs",
+			Help:      "Number of transactions replaced by ones of a higher priority.",
+		}, labels).With(labelsAndValues...),
+
 		RecheckTimes: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
 			Name:      "recheck_times",
 			Help:      "Number of times transactions are rechecked in the mempool.",
 		}, labels).With(labelsAndValues...),
+
+		CachedCheckTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "cached_check_txs",
+			Help:      "Number of transactions refused with a cached CheckTx response.",
+		}, labels).With(labelsAndValues...),
+
+		TxPriority: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "tx_priority",
+			Help:      "Priorities of transactions added to the mempool.",
+			Buckets:   stdprometheus.ExponentialBuckets(1, 4, 16),
+		}, labels).With(labelsAndValues...),
+
+		ReapCutoffPriority: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "reap_cutoff_priority",
+			Help:      "Lowest priority of the transactions reaped for a full block.",
+			Buckets:   stdprometheus.ExponentialBuckets(1, 4, 16),
+		}, labels).With(labelsAndValues...),
+
+		TxTimeInMempoolSeconds: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "tx_time_in_mempool_seconds",
+			Help:      "Time transactions spent in the mempool before being included in a block.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.5, 2, 14),
+		}, labels).With(labelsAndValues...),
 	}
 }
 
 // NopMetrics returns no-op Metrics.
 func NopMetrics() *Metrics {
 	return &Metrics{
-		Size:         discard.NewGauge(),
-		TxSizeBytes:  discard.NewHistogram(),
-		FailedTxs:    discard.NewCounter(),
-		RejectedTxs:  discard.NewCounter(),
-		EvictedTxs:   discard.NewCounter(),
-		RecheckTimes: discard.NewCounter(),
+		Size:                   discard.NewGauge(),
+		TxSizeBytes:            discard.NewHistogram(),
+		FailedTxs:              discard.NewCounter(),
+		RejectedTxs:            discard.NewCounter(),
+		EvictedTxs:             discard.NewCounter(),
+		ReplacedTxs:            discard.NewCounter(),
+		RecheckTimes:           discard.NewCounter(),
+		CachedCheckTxs:         discard.NewCounter(),
+		TxPriority:             discard.NewHistogram(),
+		ReapCutoffPriority:     discard.NewHistogram(),
+		TxTimeInMempoolSeconds: discard.NewHistogram(),
 	}
 }
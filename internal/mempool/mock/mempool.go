@@ -2,6 +2,7 @@ package mock
 
 import (
 	"context"
+	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/internal/libs/clist"
@@ -21,7 +22,8 @@ func (Mempool) CheckTx(_ context.Context, _ types.Tx, _ func(*abci.Response), _
 	return nil
 }
 func (Mempool) RemoveTxByKey(txKey types.TxKey) error   { return nil }
-func (Mempool) ReapMaxBytesMaxGas(_, _ int64) types.Txs { return types.Txs{} }
+func (Mempool) GetTxByKey(types.TxKey) (types.Tx, bool) { return nil, false }
+func (Mempool) ReapMaxBytesMaxGas(_, _, _ int64) types.Txs { return types.Txs{} }
 func (Mempool) ReapMaxTxs(n int) types.Txs              { return types.Txs{} }
 func (Mempool) Update(
 	_ context.Context,
@@ -39,6 +41,11 @@ func (Mempool) TxsAvailable() <-chan struct{}          { return make(chan struct
 func (Mempool) EnableTxsAvailable()                    {}
 func (Mempool) SizeBytes() int64                       { return 0 }
 
+func (Mempool) RemoveTxBySender(string) (types.TxKey, error) {
+	return types.TxKey{}, nil
+}
+func (Mempool) RejectTxs(time.Duration, ...types.TxKey) {}
+
 func (Mempool) TxsFront() *clist.CElement    { return nil }
 func (Mempool) TxsWaitChan() <-chan struct{} { return nil }
 
@@ -24,18 +24,22 @@ func NewTxPriorityQueue() *TxPriorityQueue {
 	return pq
 }
 
-// GetEvictableTxs attempts to find and return a list of *WrappedTx than can be
-// evicted to make room for another *WrappedTx with higher priority. If no such
-// list of *WrappedTx exists, nil will be returned. The returned list of *WrappedTx
-// indicate that these transactions can be removed due to them being of lower
-// priority and that their total sum in size allows room for the incoming
-// transaction according to the mempool's configured limits.
-func (pq *TxPriorityQueue) GetEvictableTxs(priority, txSize, totalSize, cap int64) []*WrappedTx {
+// GetEvictableTxs attempts to find and return a list of *WrappedTx of the given
+// lane than can be evicted to make room for another *WrappedTx with higher
+// priority. If no such list of *WrappedTx exists, nil will be returned. The
+// returned list of *WrappedTx indicate that these transactions can be removed
+// due to them being of lower priority and that their total sum in size allows
+// room for the incoming transaction according to the lane's configured limits.
+func (pq *TxPriorityQueue) GetEvictableTxs(lane int, priority, txSize, totalSize, cap int64) []*WrappedTx {
 	pq.mtx.RLock()
 	defer pq.mtx.RUnlock()
 
-	txs := make([]*WrappedTx, len(pq.txs))
-	copy(txs, pq.txs)
+	txs := make([]*WrappedTx, 0, len(pq.txs))
+	for _, tx := range pq.txs {
+		if tx.lane == lane {
+			txs = append(txs, tx)
+		}
+	}
 
 	sort.Slice(txs, func(i, j int) bool {
 		return txs[i].priority < txs[j].priority
@@ -106,6 +110,21 @@ func (pq *TxPriorityQueue) PopTx() *WrappedTx {
 	return nil
 }
 
+// SortedTxs returns the transactions in the priority queue in the order they
+// are popped, highest priority first. It is thread safe.
+func (pq *TxPriorityQueue) SortedTxs() []*WrappedTx {
+	pq.mtx.RLock()
+	defer pq.mtx.RUnlock()
+
+	txs := make([]*WrappedTx, len(pq.txs))
+	copy(txs, pq.txs)
+	sort.Slice(txs, func(i, j int) bool {
+		return higherPriority(txs[i], txs[j])
+	})
+
+	return txs
+}
+
 // Push implements the Heap interface.
 //
 // NOTE: A caller should never call Push. Use PushTx instead.
@@ -138,16 +157,28 @@ func (pq *TxPriorityQueue) Len() int {
 
 // Less implements the Heap interface. It returns true if the transaction at
 // position i in the queue is of less priority than the transaction at position j.
+// The transactions of a lane reaped earlier are of higher priority than those
+// of a lane reaped later, regardless of their priorities.
 func (pq *TxPriorityQueue) Less(i, j int) bool {
+	return higherPriority(pq.txs[i], pq.txs[j])
+}
+
+// higherPriority returns true if wtx1 is popped before wtx2 from the priority
+// queue.
+func higherPriority(wtx1, wtx2 *WrappedTx) bool {
+	if wtx1.lane != wtx2.lane {
+		return wtx1.lane < wtx2.lane
+	}
+
 	// If there exists two transactions with the same priority, consider the one
 	// that we saw the earliest as the higher priority transaction.
-	if pq.txs[i].priority == pq.txs[j].priority {
-		return pq.txs[i].timestamp.Before(pq.txs[j].timestamp)
+	if wtx1.priority == wtx2.priority {
+		return wtx1.timestamp.Before(wtx2.timestamp)
 	}
 
 	// We want Pop to give us the highest, not lowest, priority so we use greater
 	// than here.
-	return pq.txs[i].priority > pq.txs[j].priority
+	return wtx1.priority > wtx2.priority
 }
 
 // Swap implements the Heap interface. It swaps two transactions in the queue.
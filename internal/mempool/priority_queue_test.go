@@ -135,7 +135,7 @@ func TestTxPriorityQueue_GetEvictableTxs(t *testing.T) {
 		tc := tc
 
 		t.Run(tc.name, func(t *testing.T) {
-			evictTxs := pq.GetEvictableTxs(tc.priority, tc.txSize, tc.totalSize, tc.cap)
+			evictTxs := pq.GetEvictableTxs(0, tc.priority, tc.txSize, tc.totalSize, tc.cap)
 			require.Len(t, evictTxs, tc.expectedLen)
 		})
 	}
@@ -174,3 +174,26 @@ func TestTxPriorityQueue_RemoveTx(t *testing.T) {
 	})
 	require.Equal(t, numTxs-2, pq.NumTxs())
 }
+
+func TestTxPriorityQueue_Lanes(t *testing.T) {
+	pq := NewTxPriorityQueue()
+	now := time.Now()
+
+	pq.PushTx(&WrappedTx{lane: 1, priority: 100, timestamp: now, tx: []byte{1}})
+	pq.PushTx(&WrappedTx{lane: 0, priority: 1, timestamp: now, tx: []byte{2}})
+	pq.PushTx(&WrappedTx{lane: 1, priority: 10, timestamp: now, tx: []byte{3}})
+	pq.PushTx(&WrappedTx{lane: 0, priority: 5, timestamp: now, tx: []byte{4}})
+
+	// only the transactions of the lane are evicted
+	evictTxs := pq.GetEvictableTxs(1, 50, 1, 2, 2)
+	require.Len(t, evictTxs, 1)
+	require.Equal(t, int64(10), evictTxs[0].priority)
+	require.Nil(t, pq.GetEvictableTxs(0, 1, 1, 2, 2))
+
+	// the transactions of the earlier lane are popped first
+	var got []byte
+	for pq.NumTxs() > 0 {
+		got = append(got, pq.PopTx().tx[0])
+	}
+	require.Equal(t, []byte{4, 2, 1, 3}, got)
+}
@@ -1,7 +1,9 @@
 package mempool
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"runtime/debug"
@@ -28,18 +30,40 @@ var (
 // approach utilizing the p2p stack.
 type PeerManager interface {
 	GetHeight(types.NodeID) int64
+	NodeInfo(types.NodeID) (types.NodeInfo, bool)
 }
 
+const (
+	// maxAnnouncedTxs is the maximum number of transaction keys in a HaveTxs or
+	// WantTxs message.
+	maxAnnouncedTxs = 1000
+
+	// txRequestTimeout is how long to wait for a requested transaction before
+	// requesting it from another peer announcing it.
+	txRequestTimeout = 2 * time.Second
+)
+
 // Reactor implements a service that contains mempool of txs that are broadcasted
 // amongst peers. It maintains a map from peer ID to counter, to prevent gossiping
 // txs to the peers you received it from.
+//
+// Transactions are not flooded to peers: the reactor announces the keys of its
+// transactions with HaveTxs messages, and peers request the transactions they
+// do not have yet with WantTxs messages, requesting each transaction from a
+// single peer at a time. Peers which do not advertise the tx announcements
+// capability are sent the transactions instead.
+//
+// The transactions of each lane of the mempool are gossiped by a routine of
+// their own, on the channel of the lane if the peer has it, so that those of a
+// lane are not delayed by those of another.
 type Reactor struct {
 	service.BaseService
 	logger log.Logger
 
-	cfg     *config.MempoolConfig
-	mempool *TxMempool
-	ids     *IDs
+	cfg      *config.MempoolConfig
+	mempool  *TxMempool
+	ids      *IDs
+	requests *txRequests
 
 	// XXX: Currently, this is the only way to get information about a peer. Ideally,
 	// we rely on message-oriented communication to get necessary peer data.
@@ -47,6 +71,7 @@ type Reactor struct {
 	peerMgr PeerManager
 
 	mempoolCh   *p2p.Channel
+	laneChs     []*p2p.Channel // of the configured lanes, in order
 	peerUpdates *p2p.PeerUpdates
 
 	// peerWG is used to coordinate graceful termination of all peer broadcasting
@@ -68,6 +93,7 @@ func NewReactor(
 	peerMgr PeerManager,
 	txmp *TxMempool,
 	mempoolCh *p2p.Channel,
+	laneChs []*p2p.Channel,
 	peerUpdates *p2p.PeerUpdates,
 ) *Reactor {
 
@@ -77,7 +103,9 @@ func NewReactor(
 		peerMgr:      peerMgr,
 		mempool:      txmp,
 		ids:          NewMempoolIDs(),
+		requests:     newTxRequests(txRequestTimeout),
 		mempoolCh:    mempoolCh,
+		laneChs:      laneChs,
 		peerUpdates:  peerUpdates,
 		peerRoutines: make(map[types.NodeID]*tmsync.Closer),
 		observePanic: defaultObservePanic,
@@ -92,6 +120,26 @@ func defaultObservePanic(r interface{}) {}
 // GetChannelDescriptor produces an instance of a descriptor for this
 // package's required channels.
 func GetChannelDescriptor(cfg *config.MempoolConfig) *p2p.ChannelDescriptor {
+	return channelDescriptor(cfg, MempoolChannel, 5)
+}
+
+// GetLaneChannelDescriptors returns the descriptors of the channels of the
+// configured lanes, in order. The lane channels have a higher priority than
+// the channel of the default lane.
+func GetLaneChannelDescriptors(cfg *config.MempoolConfig) ([]*p2p.ChannelDescriptor, error) {
+	lanes, err := cfg.ParseLanes()
+	if err != nil {
+		return nil, err
+	}
+
+	descs := make([]*p2p.ChannelDescriptor, len(lanes))
+	for i := range lanes {
+		descs[i] = channelDescriptor(cfg, LaneChannel(i), 6)
+	}
+	return descs, nil
+}
+
+func channelDescriptor(cfg *config.MempoolConfig, id p2p.ChannelID, priority int) *p2p.ChannelDescriptor {
 	largestTx := make([]byte, cfg.MaxTxBytes)
 	batchMsg := protomem.Message{
 		Sum: &protomem.Message_Txs{
@@ -99,12 +147,28 @@ func GetChannelDescriptor(cfg *config.MempoolConfig) *p2p.ChannelDescriptor {
 		},
 	}
 
+	txKeys := make([][]byte, maxAnnouncedTxs)
+	for i := range txKeys {
+		txKeys[i] = make([]byte, sha256.Size)
+	}
+	announceMsg := protomem.Message{
+		Sum: &protomem.Message_HaveTxs{
+			HaveTxs: &protomem.HaveTxs{TxKeys: txKeys},
+		},
+	}
+
+	recvMessageCapacity := batchMsg.Size()
+	if announceMsg.Size() > recvMessageCapacity {
+		recvMessageCapacity = announceMsg.Size()
+	}
+
 	return &p2p.ChannelDescriptor{
-		ID:                  MempoolChannel,
+		ID:                  id,
 		MessageType:         new(protomem.Message),
-		Priority:            5,
-		RecvMessageCapacity: batchMsg.Size(),
+		Priority:            priority,
+		RecvMessageCapacity: recvMessageCapacity,
 		RecvBufferCapacity:  128,
+		Compress:            true,
 	}
 }
 
@@ -117,8 +181,12 @@ func (r *Reactor) OnStart(ctx context.Context) error {
 		r.logger.Info("tx broadcasting is disabled")
 	}
 
-	go r.processMempoolCh(ctx)
+	go r.processMempoolCh(ctx, r.mempoolCh)
+	for _, ch := range r.laneChs {
+		go r.processMempoolCh(ctx, ch)
+	}
 	go r.processPeerUpdates(ctx)
+	go r.retryTxRequestsRoutine(ctx)
 
 	return nil
 }
@@ -136,13 +204,49 @@ func (r *Reactor) OnStop() {
 	r.peerWG.Wait()
 }
 
-// handleMempoolMessage handles envelopes sent from peers on the MempoolChannel.
-// For every tx in the message, we execute CheckTx. It returns an error if an
-// empty set of txs are sent in an envelope or if we receive an unexpected
-// message type.
+// channel returns the channel of the given ID, the MempoolChannel or that of a
+// lane, or nil if there is none.
+func (r *Reactor) channel(chID p2p.ChannelID) *p2p.Channel {
+	if chID == r.mempoolCh.ID {
+		return r.mempoolCh
+	}
+	for _, ch := range r.laneChs {
+		if ch.ID == chID {
+			return ch
+		}
+	}
+	return nil
+}
+
+// peerChannel returns the channel to gossip the transactions of the lane to
+// the peer on: the channel of the lane if the peer has it, and the
+// MempoolChannel otherwise.
+func (r *Reactor) peerChannel(peerID types.NodeID, lane int) *p2p.Channel {
+	if lane >= len(r.laneChs) || r.peerMgr == nil {
+		return r.mempoolCh
+	}
+
+	ch := r.laneChs[lane]
+	if nodeInfo, ok := r.peerMgr.NodeInfo(peerID); ok && bytes.Contains(nodeInfo.Channels, []byte{byte(ch.ID)}) {
+		return ch
+	}
+	return r.mempoolCh
+}
+
+// handleMempoolMessage handles envelopes sent from peers on the MempoolChannel
+// or the channel of a lane. For every tx in the message, we execute CheckTx.
+// For announced txs, we request those we do not have yet, and for requested
+// txs, we send those we have, on the channel of the envelope. It returns an
+// error if an empty set of txs or keys are sent in an envelope or if we receive
+// an unexpected message type.
 func (r *Reactor) handleMempoolMessage(ctx context.Context, envelope *p2p.Envelope) error {
 	logger := r.logger.With("peer", envelope.From)
 
+	ch := r.channel(envelope.ChannelID)
+	if ch == nil {
+		ch = r.mempoolCh
+	}
+
 	switch msg := envelope.Message.(type) {
 	case *protomem.Txs:
 		protoTxs := msg.GetTxs()
@@ -159,8 +263,23 @@ func (r *Reactor) handleMempoolMessage(ctx context.Context, envelope *p2p.Envelo
 			if err := r.mempool.CheckTx(ctx, types.Tx(tx), nil, txInfo); err != nil {
 				logger.Error("checktx failed for tx", "tx", fmt.Sprintf("%X", types.Tx(tx).Hash()), "err", err)
 			}
+			r.requests.remove(types.Tx(tx).Key())
 		}
 
+	case *protomem.HaveTxs:
+		txKeys, err := txKeysFromProto(msg.TxKeys)
+		if err != nil {
+			return err
+		}
+		return r.requestTxs(ctx, ch, envelope.From, txKeys)
+
+	case *protomem.WantTxs:
+		txKeys, err := txKeysFromProto(msg.TxKeys)
+		if err != nil {
+			return err
+		}
+		return r.sendTxs(ctx, ch, envelope.From, txKeys)
+
 	default:
 		return fmt.Errorf("received unknown message: %T", msg)
 	}
@@ -168,6 +287,142 @@ func (r *Reactor) handleMempoolMessage(ctx context.Context, envelope *p2p.Envelo
 	return nil
 }
 
+// requestTxs requests the transactions announced by the peer which are neither
+// in the mempool or cache, nor already requested from another peer.
+func (r *Reactor) requestTxs(ctx context.Context, ch *p2p.Channel, peerID types.NodeID, txKeys []types.TxKey) error {
+	peerMempoolID := r.ids.GetForPeer(peerID)
+	now := time.Now()
+
+	var wanted [][]byte
+	for i := range txKeys {
+		// The peer has the transaction, so we need not send it back.
+		if wtx, _ := r.mempool.txStore.GetOrSetPeerByTxHash(txKeys[i], peerMempoolID); wtx != nil {
+			continue
+		}
+		if r.mempool.cache.Has(txKeys[i]) || !r.requests.announce(txKeys[i], peerID, ch.ID, now) {
+			continue
+		}
+		wanted = append(wanted, txKeys[i][:])
+	}
+
+	if len(wanted) == 0 {
+		return nil
+	}
+	return ch.Send(ctx, p2p.Envelope{
+		To:      peerID,
+		Message: &protomem.WantTxs{TxKeys: wanted},
+	})
+}
+
+// retryTxRequests requests the transactions whose requests timed out at the
+// given time from the next peers which announced them.
+func (r *Reactor) retryTxRequests(ctx context.Context, now time.Time) error {
+	for announcer, txKeys := range r.requests.expire(now) {
+		var wanted [][]byte
+		for i := range txKeys {
+			if r.mempool.cache.Has(txKeys[i]) {
+				r.requests.remove(txKeys[i])
+				continue
+			}
+			wanted = append(wanted, txKeys[i][:])
+		}
+
+		ch := r.channel(announcer.channelID)
+		if ch == nil {
+			ch = r.mempoolCh
+		}
+		for len(wanted) > 0 {
+			n := len(wanted)
+			if n > maxAnnouncedTxs {
+				n = maxAnnouncedTxs
+			}
+			r.logger.Debug("requesting txs from another peer", "num_txs", n, "peer", announcer.peerID)
+			if err := ch.Send(ctx, p2p.Envelope{
+				To:      announcer.peerID,
+				Message: &protomem.WantTxs{TxKeys: wanted[:n]},
+			}); err != nil {
+				return err
+			}
+			wanted = wanted[n:]
+		}
+	}
+	return nil
+}
+
+// retryTxRequestsRoutine requests the transactions whose requests time out
+// from other peers, until the reactor is stopped.
+func (r *Reactor) retryTxRequestsRoutine(ctx context.Context) {
+	ticker := time.NewTicker(txRequestTimeout / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := r.retryTxRequests(ctx, now); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// sendTxs sends the transactions requested by the peer which are in the
+// mempool, batched into messages of at most MaxTxBytes unless a single
+// transaction is larger.
+func (r *Reactor) sendTxs(ctx context.Context, ch *p2p.Channel, peerID types.NodeID, txKeys []types.TxKey) error {
+	var (
+		txs  [][]byte
+		size int
+	)
+	send := func() error {
+		if len(txs) == 0 {
+			return nil
+		}
+		err := ch.Send(ctx, p2p.Envelope{
+			To:      peerID,
+			Message: &protomem.Txs{Txs: txs},
+		})
+		txs, size = nil, 0
+		return err
+	}
+
+	for _, txKey := range txKeys {
+		tx, ok := r.mempool.GetTxByKey(txKey)
+		if !ok {
+			continue
+		}
+		if size+len(tx) > r.cfg.MaxTxBytes {
+			if err := send(); err != nil {
+				return err
+			}
+		}
+		txs = append(txs, tx)
+		size += len(tx)
+	}
+	return send()
+}
+
+// txKeysFromProto converts the transaction keys of a HaveTxs or WantTxs
+// message, returning an error if there are none, too many, or any is invalid.
+func txKeysFromProto(keys [][]byte) ([]types.TxKey, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("empty tx keys received from peer")
+	}
+	if len(keys) > maxAnnouncedTxs {
+		return nil, fmt.Errorf("received %d tx keys from peer, max is %d", len(keys), maxAnnouncedTxs)
+	}
+
+	txKeys := make([]types.TxKey, len(keys))
+	for i, key := range keys {
+		if len(key) != sha256.Size {
+			return nil, fmt.Errorf("tx key %d has length %d, expected %d", i, len(key), sha256.Size)
+		}
+		copy(txKeys[i][:], key)
+	}
+	return txKeys, nil
+}
+
 // handleMessage handles an Envelope sent from a peer on a specific p2p Channel.
 // It will handle errors and any possible panics gracefully. A caller can handle
 // any error returned by sending a PeerError on the respective channel.
@@ -186,8 +441,8 @@ func (r *Reactor) handleMessage(ctx context.Context, chID p2p.ChannelID, envelop
 
 	r.logger.Debug("received message", "peer", envelope.From)
 
-	switch chID {
-	case MempoolChannel:
+	switch {
+	case r.channel(chID) != nil:
 		err = r.handleMempoolMessage(ctx, envelope)
 
 	default:
@@ -198,14 +453,14 @@ func (r *Reactor) handleMessage(ctx context.Context, chID p2p.ChannelID, envelop
 }
 
 // processMempoolCh implements a blocking event loop where we listen for p2p
-// Envelope messages from the mempoolCh.
-func (r *Reactor) processMempoolCh(ctx context.Context) {
-	iter := r.mempoolCh.Receive(ctx)
+// Envelope messages from the mempoolCh or the channel of a lane.
+func (r *Reactor) processMempoolCh(ctx context.Context, ch *p2p.Channel) {
+	iter := ch.Receive(ctx)
 	for iter.Next(ctx) {
 		envelope := iter.Envelope()
-		if err := r.handleMessage(ctx, r.mempoolCh.ID, envelope); err != nil {
-			r.logger.Error("failed to process message", "ch_id", r.mempoolCh.ID, "envelope", envelope, "err", err)
-			if serr := r.mempoolCh.SendError(ctx, p2p.PeerError{
+		if err := r.handleMessage(ctx, ch.ID, envelope); err != nil {
+			r.logger.Error("failed to process message", "ch_id", ch.ID, "envelope", envelope, "err", err)
+			if serr := ch.SendError(ctx, p2p.PeerError{
 				NodeID: envelope.From,
 				Err:    err,
 			}); serr != nil {
@@ -236,26 +491,32 @@ func (r *Reactor) processPeerUpdate(ctx context.Context, peerUpdate p2p.PeerUpda
 		}
 
 		if r.cfg.Broadcast {
-			// Check if we've already started a goroutine for this peer, if not we create
-			// a new done channel so we can explicitly close the goroutine if the peer
-			// is later removed, we increment the waitgroup so the reactor can stop
-			// safely, and finally start the goroutine to broadcast txs to that peer.
+			// Check if we've already started the goroutines for this peer, if not we
+			// create a new done channel so we can explicitly close the goroutines if
+			// the peer is later removed, we increment the waitgroup so the reactor can
+			// stop safely, and finally start a goroutine per lane to broadcast txs to
+			// that peer.
 			_, ok := r.peerRoutines[peerUpdate.NodeID]
 			if !ok {
 				closer := tmsync.NewCloser()
 
 				r.peerRoutines[peerUpdate.NodeID] = closer
-				r.peerWG.Add(1)
+				r.peerWG.Add(len(r.mempool.lanes))
 
 				r.ids.ReserveForPeer(peerUpdate.NodeID)
 
-				// start a broadcast routine ensuring all txs are forwarded to the peer
-				go r.broadcastTxRoutine(ctx, peerUpdate.NodeID, closer)
+				// start the broadcast routines ensuring all txs are forwarded to the peer
+				announceTxs := peerUpdate.Capabilities.Has(types.CapabilityTxAnnouncements)
+				for lane := range r.mempool.lanes {
+					ch := r.peerChannel(peerUpdate.NodeID, lane)
+					go r.broadcastTxRoutine(ctx, peerUpdate.NodeID, lane, ch, announceTxs, closer)
+				}
 			}
 		}
 
 	case p2p.PeerStatusDown:
 		r.ids.Reclaim(peerUpdate.NodeID)
+		r.requests.removePeer(peerUpdate.NodeID)
 
 		// Check if we've started a tx broadcasting goroutine for this peer.
 		// If we have, we signal to terminate the goroutine via the channel's closure.
@@ -283,14 +544,58 @@ func (r *Reactor) processPeerUpdates(ctx context.Context) {
 	}
 }
 
-func (r *Reactor) broadcastTxRoutine(ctx context.Context, peerID types.NodeID, closer *tmsync.Closer) {
+// broadcastTxRoutine announces the transactions of the lane in the mempool to
+// the peer on the given channel, except those received from it, in batches of
+// up to maxAnnouncedTxs keys. If the peer does not support tx announcements,
+// the transactions are sent instead.
+func (r *Reactor) broadcastTxRoutine(
+	ctx context.Context,
+	peerID types.NodeID,
+	lane int,
+	ch *p2p.Channel,
+	announceTxs bool,
+	closer *tmsync.Closer,
+) {
 	peerMempoolID := r.ids.GetForPeer(peerID)
 	var nextGossipTx *clist.CElement
 
-	// remove the peer ID from the map of routines and mark the waitgroup as done
+	var announced []types.TxKey
+	announce := func() error {
+		if len(announced) == 0 {
+			return nil
+		}
+		if !announceTxs {
+			if err := r.sendTxs(ctx, ch, peerID, announced); err != nil {
+				return err
+			}
+			r.logger.Debug("sent txs to peer", "num_txs", len(announced), "peer", peerID)
+			announced = nil
+			return nil
+		}
+
+		txKeys := make([][]byte, len(announced))
+		for i := range announced {
+			txKeys[i] = announced[i][:]
+		}
+		if err := ch.Send(ctx, p2p.Envelope{
+			To:      peerID,
+			Message: &protomem.HaveTxs{TxKeys: txKeys},
+		}); err != nil {
+			return err
+		}
+
+		r.logger.Debug("announced txs to peer", "num_txs", len(announced), "peer", peerID)
+		announced = nil
+		return nil
+	}
+
+	// remove the peer ID from the map of routines, unless the peer was added
+	// again since, and mark the waitgroup as done
 	defer func() {
 		r.mtx.Lock()
-		delete(r.peerRoutines, peerID)
+		if r.peerRoutines[peerID] == closer {
+			delete(r.peerRoutines, peerID)
+		}
 		r.mtx.Unlock()
 
 		r.peerWG.Done()
@@ -331,34 +636,31 @@ func (r *Reactor) broadcastTxRoutine(ctx context.Context, peerID types.NodeID, c
 
 		memTx := nextGossipTx.Value.(*WrappedTx)
 
-		if r.peerMgr != nil {
+		if memTx.lane == lane && r.peerMgr != nil {
 			height := r.peerMgr.GetHeight(peerID)
 			if height > 0 && height < memTx.height-1 {
+				if err := announce(); err != nil {
+					return
+				}
+
 				// allow for a lag of one block
 				time.Sleep(PeerCatchupSleepIntervalMS * time.Millisecond)
 				continue
 			}
 		}
 
-		// NOTE: Transaction batching was disabled due to:
-		// https://github.com/tendermint/tendermint/issues/5796
-		if ok := r.mempool.txStore.TxHasPeer(memTx.hash, peerMempoolID); !ok {
-			// Send the mempool tx to the corresponding peer. Note, the peer may be
-			// behind and thus would not be able to process the mempool tx correctly.
-			if err := r.mempoolCh.Send(ctx, p2p.Envelope{
-				To: peerID,
-				Message: &protomem.Txs{
-					Txs: [][]byte{memTx.tx},
-				},
-			}); err != nil {
+		// the transactions of other lanes are gossiped by their routines
+		if memTx.lane == lane && !r.mempool.txStore.TxHasPeer(memTx.hash, peerMempoolID) {
+			announced = append(announced, memTx.hash)
+		}
+
+		// Announce the batch once it is full or no further tx is available yet.
+		// Note, the peer may be behind and thus would not be able to process
+		// the mempool txs correctly.
+		if len(announced) >= maxAnnouncedTxs || nextGossipTx.Next() == nil {
+			if err := announce(); err != nil {
 				return
 			}
-
-			r.logger.Debug(
-				"gossiped tx to peer",
-				"tx", fmt.Sprintf("%X", memTx.tx.Hash()),
-				"peer", peerID,
-			)
 		}
 
 		select {
@@ -74,6 +74,7 @@ func setupReactors(ctx context.Context, t *testing.T, numNodes int, chBuf uint)
 			rts.network.Nodes[nodeID].PeerManager,
 			mempool,
 			rts.mempoolChannels[nodeID],
+			nil,
 			rts.peerUpdates[nodeID],
 		)
 
@@ -164,7 +165,7 @@ func TestReactorBroadcastDoesNotPanic(t *testing.T) {
 
 	closer := tmsync.NewCloser()
 	primaryReactor.peerWG.Add(1)
-	go primaryReactor.broadcastTxRoutine(ctx, secondary, closer)
+	go primaryReactor.broadcastTxRoutine(ctx, secondary, 0, primaryReactor.mempoolCh, true, closer)
 
 	wg := &sync.WaitGroup{}
 	for i := 0; i < 50; i++ {
@@ -403,3 +404,233 @@ func TestBroadcastTxForPeerStopsWhenPeerStops(t *testing.T) {
 	require.Equal(t, 4, rts.mempools[primary].Size())
 	require.Equal(t, 0, rts.mempools[secondary].Size())
 }
+
+func TestReactorAnnounceTxs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	txmp := setup(ctx, t, 100)
+	outCh := make(chan p2p.Envelope, 10)
+	mempoolCh := p2p.NewChannel(MempoolChannel, new(protomem.Message), make(chan p2p.Envelope), outCh, make(chan p2p.PeerError))
+	reactor := NewReactor(log.TestingLogger(), config.TestMempoolConfig(), nil, txmp, mempoolCh, nil, nil)
+
+	peerA := types.NodeID(strings.Repeat("a", 40))
+	peerB := types.NodeID(strings.Repeat("b", 40))
+	reactor.ids.ReserveForPeer(peerA)
+	reactor.ids.ReserveForPeer(peerB)
+
+	have := checkTxs(ctx, t, txmp, 1, UnknownPeerID)[0].tx
+	missing := types.Tx("sender-missing=ABCD=1000")
+	haveKey, missingKey := have.Key(), missing.Key()
+
+	// only the transaction not in the mempool is requested
+	require.NoError(t, reactor.handleMempoolMessage(ctx, &p2p.Envelope{
+		From:    peerA,
+		Message: &protomem.HaveTxs{TxKeys: [][]byte{haveKey[:], missingKey[:]}},
+	}))
+	envelope := <-outCh
+	require.Equal(t, peerA, envelope.To)
+	require.Equal(t, &protomem.WantTxs{TxKeys: [][]byte{missingKey[:]}}, envelope.Message)
+	require.True(t, txmp.txStore.TxHasPeer(haveKey, reactor.ids.GetForPeer(peerA)))
+
+	// the transaction is not requested again from another peer
+	require.NoError(t, reactor.handleMempoolMessage(ctx, &p2p.Envelope{
+		From:    peerB,
+		Message: &protomem.HaveTxs{TxKeys: [][]byte{missingKey[:]}},
+	}))
+	require.Empty(t, outCh)
+
+	// only the transactions in the mempool are sent
+	require.NoError(t, reactor.handleMempoolMessage(ctx, &p2p.Envelope{
+		From:    peerB,
+		Message: &protomem.WantTxs{TxKeys: [][]byte{haveKey[:], missingKey[:]}},
+	}))
+	envelope = <-outCh
+	require.Equal(t, peerB, envelope.To)
+	require.Equal(t, &protomem.Txs{Txs: [][]byte{have}}, envelope.Message)
+
+	// the request is complete once the transaction is received
+	require.NoError(t, reactor.handleMempoolMessage(ctx, &p2p.Envelope{
+		From:    peerA,
+		Message: &protomem.Txs{Txs: [][]byte{missing}},
+	}))
+	require.Equal(t, 0, reactor.requests.size())
+	_, ok := txmp.GetTxByKey(missingKey)
+	require.True(t, ok)
+
+	// invalid keys are rejected
+	require.Error(t, reactor.handleMempoolMessage(ctx, &p2p.Envelope{
+		From:    peerA,
+		Message: &protomem.HaveTxs{},
+	}))
+	require.Error(t, reactor.handleMempoolMessage(ctx, &p2p.Envelope{
+		From:    peerA,
+		Message: &protomem.WantTxs{TxKeys: [][]byte{missingKey[1:]}},
+	}))
+}
+
+func TestReactorRequestTxsFromNextAnnouncer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	txmp := setup(ctx, t, 100)
+	outCh := make(chan p2p.Envelope, 10)
+	mempoolCh := p2p.NewChannel(MempoolChannel, new(protomem.Message), make(chan p2p.Envelope), outCh, make(chan p2p.PeerError))
+	reactor := NewReactor(log.TestingLogger(), config.TestMempoolConfig(), nil, txmp, mempoolCh, nil, nil)
+
+	peerA := types.NodeID(strings.Repeat("a", 40))
+	peerB := types.NodeID(strings.Repeat("b", 40))
+	reactor.ids.ReserveForPeer(peerA)
+	reactor.ids.ReserveForPeer(peerB)
+
+	tx := types.Tx("sender-missing=ABCD=1000")
+	key := tx.Key()
+	announce := &protomem.HaveTxs{TxKeys: [][]byte{key[:]}}
+
+	require.NoError(t, reactor.handleMempoolMessage(ctx, &p2p.Envelope{From: peerA, Message: announce}))
+	envelope := <-outCh
+	require.Equal(t, peerA, envelope.To)
+
+	// peerB announces the transaction while it is requested from peerA
+	require.NoError(t, reactor.handleMempoolMessage(ctx, &p2p.Envelope{From: peerB, Message: announce}))
+	require.Empty(t, outCh)
+
+	// peerA never answers, so the transaction is requested from peerB
+	now := time.Now()
+	require.NoError(t, reactor.retryTxRequests(ctx, now))
+	require.Empty(t, outCh)
+	require.NoError(t, reactor.retryTxRequests(ctx, now.Add(txRequestTimeout)))
+	envelope = <-outCh
+	require.Equal(t, peerB, envelope.To)
+	require.Equal(t, &protomem.WantTxs{TxKeys: [][]byte{key[:]}}, envelope.Message)
+
+	require.NoError(t, reactor.handleMempoolMessage(ctx, &p2p.Envelope{
+		From:    peerB,
+		Message: &protomem.Txs{Txs: [][]byte{tx}},
+	}))
+	require.Equal(t, 0, reactor.requests.size())
+	_, ok := txmp.GetTxByKey(key)
+	require.True(t, ok)
+}
+
+func TestReactorSendTxsToPeersWithoutAnnouncements(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	txmp := setup(ctx, t, 100)
+	outCh := make(chan p2p.Envelope, 10)
+	mempoolCh := p2p.NewChannel(MempoolChannel, new(protomem.Message), make(chan p2p.Envelope), outCh, make(chan p2p.PeerError))
+	peerCh := make(chan p2p.PeerUpdate, 2)
+	reactor := NewReactor(log.TestingLogger(), config.TestMempoolConfig(), nil, txmp, mempoolCh, nil,
+		p2p.NewPeerUpdates(peerCh, 2))
+	require.NoError(t, reactor.Start(ctx))
+	t.Cleanup(reactor.Wait)
+
+	tx := checkTxs(ctx, t, txmp, 1, UnknownPeerID)[0].tx
+	txKey := tx.Key()
+
+	// a peer supporting tx announcements is announced the transaction
+	peerA := types.NodeID(strings.Repeat("a", 40))
+	peerCh <- p2p.PeerUpdate{
+		NodeID:       peerA,
+		Status:       p2p.PeerStatusUp,
+		Capabilities: types.CapabilityTxAnnouncements,
+	}
+	envelope := <-outCh
+	require.Equal(t, peerA, envelope.To)
+	require.Equal(t, &protomem.HaveTxs{TxKeys: [][]byte{txKey[:]}}, envelope.Message)
+
+	// an older peer is sent the transaction itself
+	peerB := types.NodeID(strings.Repeat("b", 40))
+	peerCh <- p2p.PeerUpdate{
+		NodeID: peerB,
+		Status: p2p.PeerStatusUp,
+	}
+	envelope = <-outCh
+	require.Equal(t, peerB, envelope.To)
+	require.Equal(t, &protomem.Txs{Txs: [][]byte{tx}}, envelope.Message)
+
+	cancel()
+}
+
+// lanePeerManager reports the peers as having the given channels.
+type lanePeerManager map[types.NodeID][]byte
+
+func (m lanePeerManager) GetHeight(types.NodeID) int64 { return 0 }
+
+func (m lanePeerManager) NodeInfo(peerID types.NodeID) (types.NodeInfo, bool) {
+	return types.NodeInfo{Channels: m[peerID]}, true
+}
+
+func TestReactorGossipsLanesOnTheirChannels(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	txmp := setupWithConfig(ctx, t, func(cfg *config.MempoolConfig) {
+		cfg.Lanes = []string{"oracle:10:1000"}
+	})
+	cfg := config.TestMempoolConfig()
+	cfg.Lanes = txmp.config.Lanes
+	chDescs, err := GetLaneChannelDescriptors(cfg)
+	require.NoError(t, err)
+	require.Len(t, chDescs, 1)
+	require.Equal(t, LaneChannel(0), chDescs[0].ID)
+
+	outCh := make(chan p2p.Envelope, 10)
+	laneOutCh := make(chan p2p.Envelope, 10)
+	mempoolCh := p2p.NewChannel(MempoolChannel, new(protomem.Message), make(chan p2p.Envelope), outCh, make(chan p2p.PeerError))
+	laneCh := p2p.NewChannel(LaneChannel(0), new(protomem.Message), make(chan p2p.Envelope), laneOutCh, make(chan p2p.PeerError))
+
+	peerA := types.NodeID(strings.Repeat("a", 40))
+	peerB := types.NodeID(strings.Repeat("b", 40))
+	peerMgr := lanePeerManager{
+		peerA: {byte(MempoolChannel), byte(LaneChannel(0))},
+		peerB: {byte(MempoolChannel)},
+	}
+	peerCh := make(chan p2p.PeerUpdate, 2)
+	reactor := NewReactor(log.TestingLogger(), cfg, peerMgr, txmp, mempoolCh, []*p2p.Channel{laneCh},
+		p2p.NewPeerUpdates(peerCh, 2))
+	require.NoError(t, reactor.Start(ctx))
+	t.Cleanup(reactor.Wait)
+
+	userTx := types.Tx("user=key=1")
+	oracleTx := types.Tx("oracle/1=key=1")
+	require.NoError(t, txmp.CheckTx(ctx, userTx, nil, TxInfo{SenderID: UnknownPeerID}))
+	require.NoError(t, txmp.CheckTx(ctx, oracleTx, nil, TxInfo{SenderID: UnknownPeerID}))
+	userKey, oracleKey := userTx.Key(), oracleTx.Key()
+
+	// the peer with the lane channel is announced the oracle transaction on it
+	peerCh <- p2p.PeerUpdate{NodeID: peerA, Status: p2p.PeerStatusUp, Capabilities: types.CapabilityTxAnnouncements}
+	envelope := <-laneOutCh
+	require.Equal(t, peerA, envelope.To)
+	require.Equal(t, &protomem.HaveTxs{TxKeys: [][]byte{oracleKey[:]}}, envelope.Message)
+	envelope = <-outCh
+	require.Equal(t, peerA, envelope.To)
+	require.Equal(t, &protomem.HaveTxs{TxKeys: [][]byte{userKey[:]}}, envelope.Message)
+
+	// requests are answered on the channel they are received on
+	require.NoError(t, reactor.handleMempoolMessage(ctx, &p2p.Envelope{
+		From:      peerA,
+		ChannelID: LaneChannel(0),
+		Message:   &protomem.WantTxs{TxKeys: [][]byte{oracleKey[:]}},
+	}))
+	envelope = <-laneOutCh
+	require.Equal(t, &protomem.Txs{Txs: [][]byte{oracleTx}}, envelope.Message)
+
+	// the peer without it is announced both on the mempool channel
+	peerCh <- p2p.PeerUpdate{NodeID: peerB, Status: p2p.PeerStatusUp, Capabilities: types.CapabilityTxAnnouncements}
+	announced := make(map[types.TxKey]bool)
+	for i := 0; i < 2; i++ {
+		envelope = <-outCh
+		require.Equal(t, peerB, envelope.To)
+		for _, key := range envelope.Message.(*protomem.HaveTxs).TxKeys {
+			var txKey types.TxKey
+			copy(txKey[:], key)
+			announced[txKey] = true
+		}
+	}
+	require.Equal(t, map[types.TxKey]bool{userKey: true, oracleKey: true}, announced)
+	require.Empty(t, laneOutCh)
+
+	cancel()
+}
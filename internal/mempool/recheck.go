@@ -0,0 +1,272 @@
+package mempool
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// recheckBatchSize is the number of transactions the recheck worker rechecks
+// at once. The worker holds a read-lock on the mempool while the application
+// rechecks a batch, so it bounds how long committing a block waits for the
+// recheck in progress.
+const recheckBatchSize = 100
+
+// recheck tracks the recheck of the transactions left in the mempool after a
+// block is committed. The transactions are rechecked in the background, in
+// priority order, by a single worker, so that committing a block doesn't wait
+// for the application to recheck the whole mempool. Each Update supersedes the
+// recheck in progress, as the transactions must be rechecked against the state
+// of the last block committed.
+type recheck struct {
+	mtx sync.Mutex
+
+	// gen is incremented by each Update, to discard the batches of the
+	// recheck it supersedes.
+	gen uint64
+
+	// queue holds the transactions left to recheck, in priority order, and
+	// pending those not rechecked yet, including the batch in progress.
+	queue   []*WrappedTx
+	pending map[types.TxKey]struct{}
+
+	// running is true while the worker is running.
+	running bool
+
+	// progress is closed, and replaced, whenever transactions are no longer
+	// pending.
+	progress chan struct{}
+}
+
+func newRecheck() *recheck {
+	return &recheck{
+		pending:  make(map[types.TxKey]struct{}),
+		progress: make(chan struct{}),
+	}
+}
+
+// reset replaces the transactions to recheck, and returns whether the worker
+// must be started.
+func (r *recheck) reset(wtxs []*WrappedTx) bool {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	r.gen++
+	r.queue = wtxs
+	r.pending = make(map[types.TxKey]struct{}, len(wtxs))
+	for _, wtx := range wtxs {
+		r.pending[wtx.hash] = struct{}{}
+	}
+	r.notifyProgress()
+
+	if len(wtxs) == 0 || r.running {
+		return false
+	}
+	r.running = true
+	return true
+}
+
+// nextBatch returns the next batch of transactions to recheck, and the
+// generation it belongs to, or nil once all the transactions have been
+// rechecked, in which case the worker must return.
+func (r *recheck) nextBatch() (uint64, []*WrappedTx) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if len(r.queue) == 0 {
+		r.running = false
+		return r.gen, nil
+	}
+
+	n := recheckBatchSize
+	if n > len(r.queue) {
+		n = len(r.queue)
+	}
+	batch := r.queue[:n]
+	r.queue = r.queue[n:]
+	return r.gen, batch
+}
+
+// isCurrent returns whether gen is the generation of the recheck in progress.
+func (r *recheck) isCurrent(gen uint64) bool {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.gen == gen
+}
+
+// done marks a batch of the given generation as rechecked, and returns
+// whether all the transactions of the recheck have been.
+func (r *recheck) done(gen uint64, batch []*WrappedTx) bool {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if r.gen != gen {
+		return false
+	}
+	for _, wtx := range batch {
+		delete(r.pending, wtx.hash)
+	}
+	r.notifyProgress()
+	return len(r.pending) == 0
+}
+
+// wait returns a channel closed when more transactions have been rechecked if
+// any of wtxs is pending recheck, or nil otherwise.
+func (r *recheck) wait(wtxs []*WrappedTx) <-chan struct{} {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	for _, wtx := range wtxs {
+		if _, ok := r.pending[wtx.hash]; ok {
+			return r.progress
+		}
+	}
+	return nil
+}
+
+func (r *recheck) notifyProgress() {
+	close(r.progress)
+	r.progress = make(chan struct{})
+}
+
+// scheduleRecheck schedules the recheck of all the transactions in the
+// mempool, superseding the recheck in progress, and starts the recheck worker
+// if it isn't running.
+//
+// NOTE:
+// - The caller must have a write-lock when executing scheduleRecheck.
+func (txmp *TxMempool) scheduleRecheck() {
+	if txmp.recheck.reset(txmp.priorityIndex.SortedTxs()) {
+		go txmp.recheckRoutine()
+	}
+}
+
+// recheckRoutine rechecks the transactions scheduled by scheduleRecheck in
+// batches, until none is left.
+func (txmp *TxMempool) recheckRoutine() {
+	for {
+		gen, batch := txmp.recheck.nextBatch()
+		if batch == nil {
+			return
+		}
+		txmp.recheckBatch(gen, batch)
+	}
+}
+
+// recheckBatch executes CheckTx for a batch of transactions, holding a
+// read-lock, then processes the responses holding a write-lock, unless a block
+// has been committed in between.
+func (txmp *TxMempool) recheckBatch(gen uint64, batch []*WrappedTx) {
+	ctx := context.Background()
+	responses := make([]*abci.ResponseCheckTx, len(batch))
+
+	txmp.mtx.RLock()
+	if !txmp.recheck.isCurrent(gen) {
+		txmp.mtx.RUnlock()
+		return
+	}
+	for i, wtx := range batch {
+		// Only execute CheckTx if the transaction is not marked as removed which
+		// could happen if the transaction was evicted.
+		if txmp.txStore.IsTxRemoved(wtx.hash) {
+			continue
+		}
+
+		reqRes, err := txmp.proxyAppConn.CheckTxAsync(ctx, abci.RequestCheckTx{
+			Tx:   wtx.tx,
+			Type: abci.CheckTxType_Recheck,
+		})
+		if err != nil {
+			// no need in retrying since the tx will be rechecked after the next block
+			txmp.logger.Error("failed to execute CheckTx during rechecking", "err", err)
+			continue
+		}
+
+		i := i
+		reqRes.SetCallback(func(res *abci.Response) {
+			responses[i] = res.GetCheckTx()
+		})
+	}
+	if err := txmp.proxyAppConn.FlushSync(ctx); err != nil {
+		txmp.logger.Error("failed to flush transactions during rechecking", "err", err)
+	}
+	txmp.mtx.RUnlock()
+
+	txmp.mtx.Lock()
+	defer txmp.mtx.Unlock()
+
+	if !txmp.recheck.isCurrent(gen) {
+		return
+	}
+	for i, wtx := range batch {
+		// Only evaluate transactions that have not been removed since.
+		if responses[i] != nil && !txmp.txStore.IsTxRemoved(wtx.hash) {
+			txmp.recheckTxCallback(wtx, responses[i])
+		}
+	}
+	if txmp.recheck.done(gen, batch) {
+		txmp.logger.Debug("finished rechecking transactions")
+
+		if txmp.Size() > 0 {
+			txmp.notifyTxsAvailable()
+		}
+	}
+
+	txmp.metrics.Size.Set(float64(txmp.Size()))
+}
+
+// recheckTxCallback processes the response of the application to the recheck
+// of a transaction, updating its priority if it's still valid, and removing it
+// from the mempool otherwise.
+//
+// NOTE:
+// - The caller must have a write-lock when executing recheckTxCallback.
+func (txmp *TxMempool) recheckTxCallback(wtx *WrappedTx, res *abci.ResponseCheckTx) {
+	var err error
+	if txmp.postCheck != nil {
+		err = txmp.postCheck(wtx.tx, res)
+	}
+
+	if res.Code == abci.CodeTypeOK && err == nil {
+		if wtx.priority != res.Priority {
+			// reinsert the transaction to keep the priority index ordered
+			txmp.priorityIndex.RemoveTx(wtx)
+			wtx.priority = res.Priority
+			txmp.priorityIndex.PushTx(wtx)
+		}
+		return
+	}
+
+	txmp.logger.Debug(
+		"existing transaction no longer valid; failed re-CheckTx callback",
+		"priority", wtx.priority,
+		"tx", fmt.Sprintf("%X", wtx.tx.Hash()),
+		"err", err,
+		"code", res.Code,
+	)
+	txmp.recordRejectedTx(wtx.tx, res, err, "", true)
+	txmp.removeTx(wtx, !txmp.config.KeepInvalidTxsInCache)
+	txmp.archiveTxStatus(wtx, ArchivedTxRejected, res.Code, err)
+}
+
+// waitForRecheck calls reap, holding a read-lock, until none of the
+// transactions it returns is pending recheck, waiting for more transactions
+// to be rechecked in between, and returns them.
+func (txmp *TxMempool) waitForRecheck(reap func() []*WrappedTx) []*WrappedTx {
+	for {
+		txmp.mtx.RLock()
+		wtxs := reap()
+		// Transactions are only rechecked holding a write-lock, so no progress
+		// is missed between reaping and waiting.
+		wait := txmp.recheck.wait(wtxs)
+		txmp.mtx.RUnlock()
+
+		if wait == nil {
+			return wtxs
+		}
+		<-wait
+	}
+}
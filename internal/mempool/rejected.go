@@ -0,0 +1,144 @@
+package mempool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	auto "github.com/tendermint/tendermint/internal/libs/autofile"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/libs/log"
+	tmos "github.com/tendermint/tendermint/libs/os"
+	"github.com/tendermint/tendermint/libs/service"
+	"github.com/tendermint/tendermint/types"
+)
+
+// RejectedTx describes a transaction rejected by the CheckTx of the
+// application, or by the post-check of the mempool.
+type RejectedTx struct {
+	Time time.Time `json:"time"`
+	// Height is the last block height the mempool was updated to.
+	Height    int64            `json:"height"`
+	Hash      tmbytes.HexBytes `json:"hash"`
+	Code      uint32           `json:"code"`
+	Codespace string           `json:"codespace,omitempty"`
+	Log       string           `json:"log,omitempty"`
+	// MempoolError is the error of the post-check, if it rejected the
+	// transaction.
+	MempoolError string `json:"mempool_error,omitempty"`
+	// Peer is the peer the transaction was received from, if any.
+	Peer types.NodeID `json:"peer,omitempty"`
+	// Recheck is true if the transaction was in the mempool, and was evicted
+	// when rechecked after a block.
+	Recheck bool `json:"recheck,omitempty"`
+}
+
+// RejectedTxSink records the transactions rejected by CheckTx, so that
+// application developers can find out why transactions never land without
+// raising the log level. It's called synchronously by the mempool, so it must
+// not block.
+type RejectedTxSink interface {
+	RecordRejectedTx(RejectedTx)
+}
+
+// WithRejectedTxSink sets the sink the mempool records the transactions
+// rejected by CheckTx in.
+func WithRejectedTxSink(sink RejectedTxSink) TxMempoolOption {
+	return func(txmp *TxMempool) { txmp.rejectedTxSink = sink }
+}
+
+// recordRejectedTx records a transaction rejected by CheckTx, or by the
+// post-check with err, in the sink of the mempool, if any.
+func (txmp *TxMempool) recordRejectedTx(
+	tx types.Tx,
+	res *abci.ResponseCheckTx,
+	err error,
+	peer types.NodeID,
+	recheck bool,
+) {
+	if txmp.rejectedTxSink == nil {
+		return
+	}
+	rejected := RejectedTx{
+		Time:      time.Now().UTC(),
+		Height:    txmp.height,
+		Hash:      tx.Hash(),
+		Code:      res.Code,
+		Codespace: res.Codespace,
+		Log:       res.Log,
+		Peer:      peer,
+		Recheck:   recheck,
+	}
+	if err != nil {
+		rejected.MempoolError = err.Error()
+	}
+	txmp.rejectedTxSink.RecordRejectedTx(rejected)
+}
+
+// RejectedTxLog is a RejectedTxSink writing the rejected transactions as JSON
+// lines to a log file, rotated by size as the consensus WAL is.
+type RejectedTxLog struct {
+	service.BaseService
+	logger log.Logger
+
+	group *auto.Group
+}
+
+var _ RejectedTxSink = (*RejectedTxLog)(nil)
+
+// NewRejectedTxLog returns a log of the rejected transactions writing to the
+// given file, which must be started to rotate it.
+func NewRejectedTxLog(logger log.Logger, path string, groupOptions ...func(*auto.Group)) (*RejectedTxLog, error) {
+	if err := tmos.EnsureDir(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to ensure rejected transactions log directory: %w", err)
+	}
+	group, err := auto.OpenGroup(logger, path, groupOptions...)
+	if err != nil {
+		return nil, err
+	}
+	rtl := &RejectedTxLog{
+		logger: logger,
+		group:  group,
+	}
+	rtl.BaseService = *service.NewBaseService(logger, "RejectedTxLog", rtl)
+	return rtl, nil
+}
+
+// OnStart implements service.Service by starting the rotation of the log.
+func (rtl *RejectedTxLog) OnStart(ctx context.Context) error {
+	return rtl.group.Start(ctx)
+}
+
+// OnStop implements service.Service by flushing and closing the log.
+func (rtl *RejectedTxLog) OnStop() {
+	if err := rtl.group.Stop(); err != nil && !errors.Is(err, service.ErrAlreadyStopped) {
+		rtl.logger.Error("failed to stop rejected transactions log", "err", err)
+	}
+	rtl.group.Close()
+}
+
+// FlushAndSync flushes the log and syncs it to disk.
+func (rtl *RejectedTxLog) FlushAndSync() error {
+	return rtl.group.FlushAndSync()
+}
+
+// RecordRejectedTx implements RejectedTxSink. The entries are flushed to the
+// file as they are written, for the log to be followed, but not synced.
+func (rtl *RejectedTxLog) RecordRejectedTx(rejected RejectedTx) {
+	bz, err := json.Marshal(rejected)
+	if err != nil {
+		rtl.logger.Error("failed to encode rejected transaction", "err", err)
+		return
+	}
+	if err := rtl.group.WriteLine(string(bz)); err != nil {
+		rtl.logger.Error("failed to write rejected transaction", "err", err)
+		return
+	}
+	if err := rtl.group.Flush(); err != nil {
+		rtl.logger.Error("failed to flush rejected transactions log", "err", err)
+	}
+}
@@ -0,0 +1,53 @@
+package mempool
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestRejectedTxLog(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "mempool", "rejected_txs.log")
+	rtl, err := NewRejectedTxLog(log.TestingLogger(), path)
+	require.NoError(t, err)
+	require.NoError(t, rtl.Start(ctx))
+
+	tx := types.Tx("malformed")
+	rtl.RecordRejectedTx(RejectedTx{Height: 3, Hash: tx.Hash(), Code: 101, Log: "malformed"})
+	rtl.RecordRejectedTx(RejectedTx{Height: 4, Hash: tx.Hash(), Code: 0, MempoolError: "too big", Recheck: true})
+
+	// the entries can be followed before the log is closed
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var rejected []RejectedTx
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry RejectedTx
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		rejected = append(rejected, entry)
+	}
+	require.NoError(t, scanner.Err())
+
+	require.Len(t, rejected, 2)
+	require.Equal(t, int64(3), rejected[0].Height)
+	require.Equal(t, tx.Hash(), []byte(rejected[0].Hash))
+	require.Equal(t, uint32(101), rejected[0].Code)
+	require.Equal(t, "malformed", rejected[0].Log)
+	require.Equal(t, "too big", rejected[1].MempoolError)
+	require.True(t, rejected[1].Recheck)
+
+	require.NoError(t, rtl.Stop())
+}
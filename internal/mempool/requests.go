@@ -0,0 +1,143 @@
+package mempool
+
+import (
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/internal/p2p"
+	"github.com/tendermint/tendermint/types"
+)
+
+// txAnnouncer is a peer which announced a transaction, on the channel the
+// transaction is to be requested on.
+type txAnnouncer struct {
+	peerID    types.NodeID
+	channelID p2p.ChannelID
+}
+
+// txRequest is the request of a transaction from a peer, with the other peers
+// which announced the transaction in the meantime, in order.
+type txRequest struct {
+	peerID      types.NodeID
+	requestedAt time.Time
+	announcers  []txAnnouncer
+}
+
+// txRequests tracks the transactions requested from peers after they were
+// announced, so that a transaction announced by several peers is only
+// requested from one of them. The peers announcing a transaction while it is
+// requested are recorded, and a request which is not answered within the
+// timeout is made again to the next of them.
+type txRequests struct {
+	timeout time.Duration
+
+	mtx       sync.Mutex
+	requested map[types.TxKey]*txRequest
+}
+
+func newTxRequests(timeout time.Duration) *txRequests {
+	return &txRequests{
+		timeout:   timeout,
+		requested: make(map[types.TxKey]*txRequest),
+	}
+}
+
+// announce records that the peer announced the transaction with the given key
+// on the given channel at the given time. It returns true if the transaction
+// is to be requested from the peer, i.e. if it was not requested yet, or the
+// request timed out.
+func (r *txRequests) announce(key types.TxKey, peerID types.NodeID, channelID p2p.ChannelID, now time.Time) bool {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	req, ok := r.requested[key]
+	if !ok {
+		r.requested[key] = &txRequest{peerID: peerID, requestedAt: now}
+		return true
+	}
+	if now.Sub(req.requestedAt) >= r.timeout {
+		req.peerID, req.requestedAt = peerID, now
+		req.removeAnnouncer(peerID)
+		return true
+	}
+
+	if req.peerID == peerID {
+		return false
+	}
+	for _, a := range req.announcers {
+		if a.peerID == peerID {
+			return false
+		}
+	}
+	req.announcers = append(req.announcers, txAnnouncer{peerID: peerID, channelID: channelID})
+	return false
+}
+
+// expire makes the requests which timed out at the given time again, to the
+// next peers which announced their transactions. It returns the keys of the
+// transactions to request from each peer. The requests of transactions no
+// other peer announced are forgotten.
+func (r *txRequests) expire(now time.Time) map[txAnnouncer][]types.TxKey {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	var retries map[txAnnouncer][]types.TxKey
+	for key, req := range r.requested {
+		if now.Sub(req.requestedAt) < r.timeout {
+			continue
+		}
+		if len(req.announcers) == 0 {
+			delete(r.requested, key)
+			continue
+		}
+
+		next := req.announcers[0]
+		req.peerID, req.requestedAt, req.announcers = next.peerID, now, req.announcers[1:]
+		if retries == nil {
+			retries = make(map[txAnnouncer][]types.TxKey)
+		}
+		retries[next] = append(retries[next], key)
+	}
+	return retries
+}
+
+// removePeer forgets the announcements of the peer, and expires the requests
+// made to it, once it disconnected.
+func (r *txRequests) removePeer(peerID types.NodeID) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	for _, req := range r.requested {
+		if req.peerID == peerID {
+			req.requestedAt = time.Time{}
+		}
+		req.removeAnnouncer(peerID)
+	}
+}
+
+// removeAnnouncer removes the peer from the announcers of the transaction.
+func (req *txRequest) removeAnnouncer(peerID types.NodeID) {
+	for i, a := range req.announcers {
+		if a.peerID == peerID {
+			req.announcers = append(req.announcers[:i:i], req.announcers[i+1:]...)
+			return
+		}
+	}
+}
+
+// remove forgets the request for the transaction with the given key, once the
+// transaction was received.
+func (r *txRequests) remove(key types.TxKey) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	delete(r.requested, key)
+}
+
+// size returns the number of pending requests.
+func (r *txRequests) size() int {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	return len(r.requested)
+}
@@ -0,0 +1,48 @@
+package mempool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestTxRequests(t *testing.T) {
+	requests := newTxRequests(time.Second)
+	now := time.Now()
+	key1, key2 := types.Tx("tx1").Key(), types.Tx("tx2").Key()
+	peerA, peerB, peerC := types.NodeID("a"), types.NodeID("b"), types.NodeID("c")
+
+	require.True(t, requests.announce(key1, peerA, MempoolChannel, now))
+	require.False(t, requests.announce(key1, peerA, MempoolChannel, now))
+	require.False(t, requests.announce(key1, peerB, MempoolChannel, now.Add(500*time.Millisecond)))
+	require.False(t, requests.announce(key1, peerC, MempoolChannel, now.Add(500*time.Millisecond)))
+	require.True(t, requests.announce(key2, peerB, MempoolChannel, now.Add(500*time.Millisecond)))
+	require.Empty(t, requests.expire(now.Add(500*time.Millisecond)))
+
+	// the request for key1 timed out, and is made again to the next announcer
+	require.Equal(t, map[txAnnouncer][]types.TxKey{
+		{peerID: peerB, channelID: MempoolChannel}: {key1},
+	}, requests.expire(now.Add(time.Second)))
+	require.Equal(t, 2, requests.size())
+
+	// the request for key2 has no other announcer, and is forgotten
+	require.Empty(t, requests.expire(now.Add(1500*time.Millisecond)))
+	require.Equal(t, 1, requests.size())
+
+	// the disconnected peer is not requested, and its request expires
+	requests.removePeer(peerB)
+	require.Equal(t, map[txAnnouncer][]types.TxKey{
+		{peerID: peerC, channelID: MempoolChannel}: {key1},
+	}, requests.expire(now.Add(1500*time.Millisecond)))
+
+	// a peer announcing a timed out request is requested right away
+	require.True(t, requests.announce(key1, peerA, MempoolChannel, now.Add(3*time.Second)))
+	require.Empty(t, requests.expire(now.Add(3*time.Second)))
+
+	requests.remove(key1)
+	require.Equal(t, 0, requests.size())
+	require.True(t, requests.announce(key1, peerB, MempoolChannel, now.Add(3*time.Second)))
+}
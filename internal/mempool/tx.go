@@ -44,6 +44,14 @@ type WrappedTx struct {
 	// the ResponseCheckTx response.
 	sender string
 
+	// sequence defines the transaction's sequence among those of its sender, as
+	// specified by the application in the ResponseCheckTx response.
+	sequence uint64
+
+	// lane defines the index of the transaction's mempool lane, as classified
+	// by the application in the ResponseCheckTx response.
+	lane int
+
 	// timestamp is the time at which the node first received the transaction from
 	// a peer. It is used as a second dimension is prioritizing transactions when
 	// two transactions have the same priority.
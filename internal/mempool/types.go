@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/internal/p2p"
@@ -36,13 +37,25 @@ type Mempool interface {
 	// from the mempool.
 	RemoveTxByKey(txKey types.TxKey) error
 
+	// RemoveTxBySender removes the transaction of a sender, as reported by the
+	// application in CheckTx, from the mempool, and returns its key.
+	RemoveTxBySender(sender string) (types.TxKey, error)
+
+	// RejectTxs refuses the transactions, identified by their keys, without
+	// calling CheckTx for the given duration.
+	RejectTxs(duration time.Duration, txKeys ...types.TxKey)
+
+	// GetTxByKey returns the transaction identified by its key, if it is in the
+	// mempool.
+	GetTxByKey(txKey types.TxKey) (types.Tx, bool)
+
 	// ReapMaxBytesMaxGas reaps transactions from the mempool up to maxBytes
 	// bytes total with the condition that the total gasWanted must be less than
-	// maxGas.
+	// both maxGas and maxGasWanted.
 	//
-	// If both maxes are negative, there is no cap on the size of all returned
+	// If all maxes are negative, there is no cap on the size of all returned
 	// transactions (~ all available transactions).
-	ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs
+	ReapMaxBytesMaxGas(maxBytes, maxGas, maxGasWanted int64) types.Txs
 
 	// ReapMaxTxs reaps up to max transactions from the mempool. If max is
 	// negative, there is no cap on the size of all returned transactions
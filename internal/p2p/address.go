@@ -22,8 +22,21 @@ var (
 	// reSchemeIsHost tries to detect URLs where the scheme part is instead a
 	// hostname, i.e. of the form "host:80/path" where host: is a hostname.
 	reSchemeIsHost = regexp.MustCompile(`^[^/:]+:\d+(/|$)`)
+
+	// reOnionHostname matches the hostnames of Tor v3 onion services, which
+	// are the base32 encoded 35 byte address followed by ".onion".
+	reOnionHostname = regexp.MustCompile(`^[a-z2-7]{56}\.onion$`)
 )
 
+// onionSuffix is the suffix of the hostnames of Tor onion services, which can
+// only be resolved by a Tor proxy.
+const onionSuffix = ".onion"
+
+// isOnionHostname returns true if the hostname is an onion service hostname.
+func isOnionHostname(hostname string) bool {
+	return strings.HasSuffix(hostname, onionSuffix)
+}
+
 // NodeAddress is a node address URL. It differs from a transport Endpoint in
 // that it contains the node's ID, and that the address hostname may be resolved
 // into multiple IP addresses (and thus multiple endpoints).
@@ -96,7 +109,8 @@ func ParseNodeAddress(urlString string) (NodeAddress, error) {
 }
 
 // Resolve resolves a NodeAddress into a set of Endpoints, by expanding
-// out a DNS hostname to IP addresses.
+// out a DNS hostname to IP addresses. Onion hostnames are not resolved, and
+// are left for the dial proxy to resolve, so that they aren't leaked to DNS.
 func (a NodeAddress) Resolve(ctx context.Context) ([]Endpoint, error) {
 	if a.Protocol == "" {
 		return nil, errors.New("address has no protocol")
@@ -115,6 +129,15 @@ func (a NodeAddress) Resolve(ctx context.Context) ([]Endpoint, error) {
 		}}, nil
 	}
 
+	if isOnionHostname(a.Hostname) {
+		return []Endpoint{{
+			Protocol: a.Protocol,
+			Hostname: a.Hostname,
+			Port:     a.Port,
+			Path:     a.Path,
+		}}, nil
+	}
+
 	ips, err := net.DefaultResolver.LookupIP(ctx, "ip", a.Hostname)
 	if err != nil {
 		return nil, err
@@ -172,5 +195,8 @@ func (a NodeAddress) Validate() error {
 	if a.Port > 0 && a.Hostname == "" {
 		return errors.New("cannot specify port without hostname")
 	}
+	if isOnionHostname(a.Hostname) && !reOnionHostname.MatchString(a.Hostname) {
+		return fmt.Errorf("invalid onion hostname %q", a.Hostname)
+	}
 	return nil
 }
@@ -174,6 +174,11 @@ func TestParseNodeAddress(t *testing.T) {
 			p2p.NodeAddress{Protocol: "mconn", NodeID: id, Hostname: "fd80:b10c::2", Port: 26657},
 			true,
 		},
+		{
+			user + "@DUCKDUCKGOGG42XJOC72X3SJASOWOARFBGCMVFIMAFTT6TWAGSWZCZAD.onion:26656",
+			p2p.NodeAddress{Protocol: "mconn", NodeID: id, Hostname: "duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzczad.onion", Port: 26656},
+			true,
+		},
 
 		// Invalid addresses.
 		{"", p2p.NodeAddress{}, false},
@@ -187,6 +192,8 @@ func TestParseNodeAddress(t *testing.T) {
 		{"mconn://foo@127.0.0.1", p2p.NodeAddress{}, false},
 		{"mconn://" + user + "@127.0.0.1:65536", p2p.NodeAddress{}, false},
 		{"mconn://" + user + "@:80", p2p.NodeAddress{}, false},
+		{user + "@expyuzz4wqqyqhjn.onion:26656", p2p.NodeAddress{}, false},
+		{user + "@duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzcza1.onion", p2p.NodeAddress{}, false},
 	}
 	for _, tc := range testcases {
 		tc := tc
@@ -255,6 +262,11 @@ func TestNodeAddress_Resolve(t *testing.T) {
 			p2p.Endpoint{},
 			false,
 		},
+		{
+			p2p.NodeAddress{Protocol: "tcp", Hostname: "duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzczad.onion", Port: 80},
+			p2p.Endpoint{Protocol: "tcp", Hostname: "duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzczad.onion", Port: 80},
+			true,
+		},
 
 		// Valid non-networked addresses.
 		{
@@ -0,0 +1,184 @@
+package p2p
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"sort"
+	"time"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+// Sources of addresses in the peer store, other than peers advertising them
+// via PEX (whose node ID is recorded as the source instead).
+const (
+	AddressSourceConfig = "config" // persistent peers and bootstrap peers
+	AddressSourceImport = "import" // imported without a source of their own
+)
+
+// AddressBookEntry is a peer address in the peer store along with its
+// metadata. It is used to export the address book of a node and import it
+// into another one.
+type AddressBookEntry struct {
+	Address         NodeAddress
+	Source          string
+	LastConnected   time.Time // of the peer, via any address
+	LastDialSuccess time.Time
+	LastDialFailure time.Time
+	DialFailures    uint32
+}
+
+// ExportAddressBook returns all addresses in the peer store, ordered by node
+// ID and address.
+func (m *PeerManager) ExportAddressBook() []AddressBookEntry {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	entries := []AddressBookEntry{}
+	for _, peer := range m.store.List() {
+		for _, addressInfo := range peer.AddressInfo {
+			entries = append(entries, AddressBookEntry{
+				Address:         addressInfo.Address,
+				Source:          addressInfo.Source,
+				LastConnected:   peer.LastConnected,
+				LastDialSuccess: addressInfo.LastDialSuccess,
+				LastDialFailure: addressInfo.LastDialFailure,
+				DialFailures:    addressInfo.DialFailures,
+			})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Address.String() < entries[j].Address.String()
+	})
+	return entries
+}
+
+// ImportAddressBook adds the given addresses, along with their metadata, to
+// the peer store, and returns the number of addresses that were not already
+// known. Addresses already in the peer store keep their own metadata, and the
+// address book is pruned to its maximum size as with Add. If any entry is
+// invalid, no addresses are imported.
+func (m *PeerManager) ImportAddressBook(entries []AddressBookEntry) (int, error) {
+	for _, entry := range entries {
+		if err := entry.Address.Validate(); err != nil {
+			return 0, fmt.Errorf("invalid address %q: %w", entry.Address, err)
+		}
+		if entry.Address.NodeID == m.selfID {
+			return 0, fmt.Errorf("can't add self (%v) to peer store", m.selfID)
+		}
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	added := 0
+	for _, entry := range entries {
+		peer, ok := m.store.Get(entry.Address.NodeID)
+		if !ok {
+			peer = m.newPeerInfo(entry.Address.NodeID)
+		}
+		if _, ok := peer.AddressInfo[entry.Address]; ok {
+			continue
+		}
+
+		source := entry.Source
+		if source == "" {
+			source = AddressSourceImport
+		}
+		peer.AddressInfo[entry.Address] = &peerAddressInfo{
+			Address:         entry.Address,
+			LastDialSuccess: entry.LastDialSuccess,
+			LastDialFailure: entry.LastDialFailure,
+			DialFailures:    entry.DialFailures,
+			Source:          source,
+		}
+		if entry.LastConnected.After(peer.LastConnected) {
+			peer.LastConnected = entry.LastConnected
+		}
+		if err := m.store.Set(peer); err != nil {
+			return added, err
+		}
+		added++
+	}
+
+	if added == 0 {
+		return 0, nil
+	}
+	if err := m.prunePeers(); err != nil {
+		return added, err
+	}
+	m.dialWaker.Wake()
+	return added, nil
+}
+
+// legacyAddrBook is the JSON format of the address book of the legacy p2p
+// stack, addrbook.json.
+type legacyAddrBook struct {
+	Addrs []struct {
+		Addr        *legacyNetAddress `json:"addr"`
+		Src         *legacyNetAddress `json:"src"`
+		Attempts    int32             `json:"attempts"`
+		BucketType  byte              `json:"bucket_type"`
+		LastAttempt time.Time         `json:"last_attempt"`
+		LastSuccess time.Time         `json:"last_success"`
+	} `json:"addrs"`
+}
+
+type legacyNetAddress struct {
+	ID   types.NodeID `json:"id"`
+	IP   net.IP       `json:"ip"`
+	Port uint16       `json:"port"`
+}
+
+// legacyBucketTypeOld is the type of the buckets of the legacy address book
+// holding the addresses which were successfully connected to.
+const legacyBucketTypeOld = 0x02
+
+// ParseLegacyAddressBook parses an address book of the legacy p2p stack
+// (addrbook.json) into entries to import with ImportAddressBook. The address
+// advertising each address becomes its source, and its dial attempts its dial
+// history. The last successful dial of addresses in old buckets, which were
+// connected to, becomes the last connection to their peer. Invalid addresses
+// are skipped, and returned as the number of skipped entries.
+func ParseLegacyAddressBook(bz []byte) ([]AddressBookEntry, int, error) {
+	var book legacyAddrBook
+	if err := json.Unmarshal(bz, &book); err != nil {
+		return nil, 0, fmt.Errorf("invalid legacy address book: %w", err)
+	}
+
+	entries := make([]AddressBookEntry, 0, len(book.Addrs))
+	skipped := 0
+	for _, known := range book.Addrs {
+		if known.Addr == nil || known.Addr.IP == nil {
+			skipped++
+			continue
+		}
+		entry := AddressBookEntry{
+			Address: NodeAddress{
+				NodeID:   known.Addr.ID,
+				Protocol: MConnProtocol,
+				Hostname: known.Addr.IP.String(),
+				Port:     known.Addr.Port,
+			},
+			Source:          AddressSourceImport,
+			LastDialSuccess: known.LastSuccess,
+		}
+		if entry.Address.Validate() != nil {
+			skipped++
+			continue
+		}
+		if known.Src != nil && known.Src.ID != known.Addr.ID && known.Src.ID.Validate() == nil {
+			entry.Source = string(known.Src.ID)
+		}
+		if known.BucketType == legacyBucketTypeOld {
+			entry.LastConnected = known.LastSuccess
+		}
+		if known.Attempts > 0 {
+			entry.DialFailures = uint32(known.Attempts)
+			entry.LastDialFailure = known.LastAttempt
+		}
+		entries = append(entries, entry)
+	}
+	return entries, skipped, nil
+}
@@ -0,0 +1,146 @@
+package p2p_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/internal/p2p"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestPeerManager_ExportImportAddressBook(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	aAddress := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("a", 40))}
+	bAddress := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("b", 40))}
+	cAddress := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("c", 40))}
+
+	db := dbm.NewMemDB()
+	source, err := p2p.NewPeerManager(selfID, db, p2p.PeerManagerOptions{})
+	require.NoError(t, err)
+
+	added, err := source.AddFrom(aAddress, p2p.AddressSourceConfig)
+	require.NoError(t, err)
+	require.True(t, added)
+	added, err = source.AddFrom(bAddress, string(aAddress.NodeID))
+	require.NoError(t, err)
+	require.True(t, added)
+
+	// Record a failed dial of b, which should be exported.
+	dial, err := source.TryDialNext()
+	require.NoError(t, err)
+	require.NoError(t, source.DialFailed(ctx, dial))
+
+	entries := source.ExportAddressBook()
+	require.Len(t, entries, 2)
+	require.Equal(t, aAddress, entries[0].Address)
+	require.Equal(t, p2p.AddressSourceConfig, entries[0].Source)
+	require.Equal(t, bAddress, entries[1].Address)
+	require.Equal(t, string(aAddress.NodeID), entries[1].Source)
+	failed := entries[0]
+	if dial == bAddress {
+		failed = entries[1]
+	}
+	require.EqualValues(t, 1, failed.DialFailures)
+	require.False(t, failed.LastDialFailure.IsZero())
+
+	// The metadata is persisted along with the addresses.
+	reloaded, err := p2p.NewPeerManager(selfID, db, p2p.PeerManagerOptions{})
+	require.NoError(t, err)
+	require.Equal(t, entries, reloaded.ExportAddressBook())
+
+	// Importing into another node adds the addresses with their metadata,
+	// defaulting the source, and skips known addresses.
+	target, err := p2p.NewPeerManager(selfID, dbm.NewMemDB(), p2p.PeerManagerOptions{})
+	require.NoError(t, err)
+	added, err = target.Add(aAddress)
+	require.NoError(t, err)
+	require.True(t, added)
+
+	imported := append(entries, p2p.AddressBookEntry{
+		Address:       cAddress,
+		LastConnected: time.Now().UTC().Truncate(time.Second),
+	})
+	n, err := target.ImportAddressBook(imported)
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+
+	exported := target.ExportAddressBook()
+	require.Len(t, exported, 3)
+	require.Equal(t, "", exported[0].Source)
+	require.Equal(t, entries[1], exported[1])
+	require.Equal(t, p2p.AddressSourceImport, exported[2].Source)
+	require.Equal(t, imported[2].LastConnected, exported[2].LastConnected)
+
+	// Importing an invalid address or self should fail without adding anything.
+	selfAddress := p2p.NodeAddress{Protocol: "memory", NodeID: selfID}
+	dAddress := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("d", 40))}
+	_, err = target.ImportAddressBook([]p2p.AddressBookEntry{{Address: dAddress}, {Address: selfAddress}})
+	require.Error(t, err)
+	_, err = target.ImportAddressBook([]p2p.AddressBookEntry{{Address: p2p.NodeAddress{Path: "foo"}}})
+	require.Error(t, err)
+	require.Len(t, target.ExportAddressBook(), 3)
+}
+
+func TestParseLegacyAddressBook(t *testing.T) {
+	aID := types.NodeID(strings.Repeat("a", 40))
+	bID := types.NodeID(strings.Repeat("b", 40))
+	book := `{
+		"key": "0123456789abcdef01234567",
+		"addrs": [
+			{
+				"addr": {"id": "` + string(aID) + `", "ip": "1.2.3.4", "port": 26656},
+				"src": {"id": "` + string(aID) + `", "ip": "1.2.3.4", "port": 26656},
+				"buckets": [12],
+				"attempts": 0,
+				"bucket_type": 2,
+				"last_attempt": "2021-06-01T10:00:00Z",
+				"last_success": "2021-06-01T10:00:00Z",
+				"last_ban_time": "0001-01-01T00:00:00Z"
+			},
+			{
+				"addr": {"id": "` + string(bID) + `", "ip": "5.6.7.8", "port": 26656},
+				"src": {"id": "` + string(aID) + `", "ip": "1.2.3.4", "port": 26656},
+				"buckets": [3, 40],
+				"attempts": 3,
+				"bucket_type": 1,
+				"last_attempt": "2021-06-02T10:00:00Z",
+				"last_success": "0001-01-01T00:00:00Z",
+				"last_ban_time": "0001-01-01T00:00:00Z"
+			},
+			{
+				"addr": {"id": "invalid", "ip": "5.6.7.8", "port": 26656},
+				"src": {"id": "` + string(aID) + `", "ip": "1.2.3.4", "port": 26656},
+				"bucket_type": 1
+			}
+		]
+	}`
+
+	entries, skipped, err := p2p.ParseLegacyAddressBook([]byte(book))
+	require.NoError(t, err)
+	require.Equal(t, 1, skipped)
+	lastSuccess := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+	require.Equal(t, []p2p.AddressBookEntry{
+		{
+			Address:         p2p.NodeAddress{NodeID: aID, Protocol: "mconn", Hostname: "1.2.3.4", Port: 26656},
+			Source:          p2p.AddressSourceImport,
+			LastConnected:   lastSuccess,
+			LastDialSuccess: lastSuccess,
+		},
+		{
+			Address:         p2p.NodeAddress{NodeID: bID, Protocol: "mconn", Hostname: "5.6.7.8", Port: 26656},
+			Source:          string(aID),
+			LastDialFailure: time.Date(2021, 6, 2, 10, 0, 0, 0, time.UTC),
+			DialFailures:    3,
+		},
+	}, entries)
+
+	_, _, err = p2p.ParseLegacyAddressBook([]byte("{"))
+	require.Error(t, err)
+}
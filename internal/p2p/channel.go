@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/tendermint/tendermint/types"
@@ -16,6 +17,9 @@ type Envelope struct {
 	Broadcast bool          // send to all connected peers (ignores To)
 	Message   proto.Message // message payload
 	ChannelID ChannelID
+
+	// TTL overrides the channel's MessageTTL for this message, if non-zero.
+	TTL time.Duration
 }
 
 // Wrapper is a Protobuf message that can contain a variety of inner messages
@@ -32,6 +36,18 @@ type Wrapper interface {
 	Unwrap() (proto.Message, error)
 }
 
+// ZeroCopyUnmarshaler is a Protobuf message which can be unmarshaled with its
+// byte fields aliasing the encoded message rather than copying it. If a
+// Channel's message type implements ZeroCopyUnmarshaler, the Router unmarshals
+// inbound messages with it, as it owns their encoding.
+type ZeroCopyUnmarshaler interface {
+	proto.Message
+
+	// UnmarshalZeroCopy unmarshals the message like proto.Unmarshal, with its
+	// byte fields possibly aliasing the given bytes.
+	UnmarshalZeroCopy([]byte) error
+}
+
 // PeerError is a peer error reported via Channel.Error.
 //
 // FIXME: This currently just disconnects the peer, which is too simplistic.
@@ -0,0 +1,157 @@
+package p2p
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// CompressionZstd compresses messages with zstd.
+	CompressionZstd = "zstd"
+
+	// CompressionSnappy compresses messages with snappy, which is faster but
+	// compresses less than zstd.
+	CompressionSnappy = "snappy"
+
+	// compressionMinSize is the size below which messages are not worth
+	// compressing.
+	compressionMinSize = 256
+
+	// Once a compression algorithm is negotiated with a peer, each message is
+	// prefixed with one of these flags.
+	messageUncompressed byte = 0
+	messageCompressed   byte = 1
+
+	// zstdWindowSize bounds the memory used to compress and decompress zstd
+	// messages, whatever their size.
+	zstdWindowSize = 4 << 20
+)
+
+// compressor compresses the messages of a connection with the compression
+// algorithm negotiated with the peer.
+type compressor interface {
+	compress(msg []byte) []byte
+	// decompress fails if the decompressed message exceeds maxSize bytes.
+	decompress(msg []byte, maxSize int) ([]byte, error)
+}
+
+// negotiateCompression returns the compressor for the first of our
+// compression algorithms that the peer supports, or nil if there is none.
+func negotiateCompression(ours, theirs []string) compressor {
+	for _, c := range ours {
+		for _, t := range theirs {
+			if c != t {
+				continue
+			}
+			switch c {
+			case CompressionZstd:
+				return zstdCompressor{}
+			case CompressionSnappy:
+				return snappyCompressor{}
+			}
+		}
+	}
+	return nil
+}
+
+// encodeMessage prefixes msg with its compression flag, compressing it with
+// c if it is worth it. It returns the encoded message and whether it was
+// compressed.
+func encodeMessage(c compressor, msg []byte, compress bool) ([]byte, bool) {
+	if compress && len(msg) >= compressionMinSize {
+		compressed := c.compress(msg)
+		if len(compressed) < len(msg) {
+			return append([]byte{messageCompressed}, compressed...), true
+		}
+	}
+	return append([]byte{messageUncompressed}, msg...), false
+}
+
+// decodeMessage reverses encodeMessage.
+func decodeMessage(c compressor, msg []byte, maxSize int) ([]byte, error) {
+	if len(msg) == 0 {
+		return nil, errors.New("empty message")
+	}
+	switch msg[0] {
+	case messageUncompressed:
+		return msg[1:], nil
+	case messageCompressed:
+		return c.decompress(msg[1:], maxSize)
+	default:
+		return nil, fmt.Errorf("invalid message compression flag %d", msg[0])
+	}
+}
+
+var (
+	zstdEncoderOnce sync.Once
+	zstdEncoder     *zstd.Encoder
+
+	zstdDecoders = sync.Pool{
+		New: func() interface{} {
+			dec, err := zstd.NewReader(nil,
+				zstd.WithDecoderConcurrency(1), zstd.WithDecoderMaxWindow(zstdWindowSize))
+			if err != nil {
+				panic(err)
+			}
+			return dec
+		},
+	}
+)
+
+// zstdCompressor compresses messages with zstd, sharing the encoder, which is
+// safe for concurrent use, between connections.
+type zstdCompressor struct{}
+
+func (zstdCompressor) compress(msg []byte) []byte {
+	zstdEncoderOnce.Do(func() {
+		var err error
+		zstdEncoder, err = zstd.NewWriter(nil,
+			zstd.WithEncoderLevel(zstd.SpeedFastest), zstd.WithWindowSize(zstdWindowSize))
+		if err != nil {
+			panic(err)
+		}
+	})
+	return zstdEncoder.EncodeAll(msg, nil)
+}
+
+func (zstdCompressor) decompress(msg []byte, maxSize int) ([]byte, error) {
+	dec := zstdDecoders.Get().(*zstd.Decoder)
+	defer zstdDecoders.Put(dec)
+	if err := dec.Reset(bytes.NewReader(msg)); err != nil {
+		return nil, err
+	}
+	// Stream the message to stop at maxSize, rather than trusting the sizes
+	// declared in the frame headers.
+	decompressed, err := io.ReadAll(io.LimitReader(dec, int64(maxSize)+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(decompressed) > maxSize {
+		return nil, fmt.Errorf("compressed message is too large (max %d bytes)", maxSize)
+	}
+	return decompressed, nil
+}
+
+// snappyCompressor compresses messages with snappy.
+type snappyCompressor struct{}
+
+func (snappyCompressor) compress(msg []byte) []byte {
+	return snappy.Encode(nil, msg)
+}
+
+func (snappyCompressor) decompress(msg []byte, maxSize int) ([]byte, error) {
+	size, err := snappy.DecodedLen(msg)
+	if err != nil {
+		return nil, err
+	}
+	if size > maxSize {
+		return nil, fmt.Errorf("compressed message is too large (max %d bytes)", maxSize)
+	}
+	return snappy.Decode(nil, msg)
+}
@@ -0,0 +1,73 @@
+package p2p
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+)
+
+func TestNegotiateCompression(t *testing.T) {
+	testcases := map[string]struct {
+		ours, theirs []string
+		expect       compressor
+	}{
+		"none":            {nil, nil, nil},
+		"ours only":       {[]string{CompressionZstd}, nil, nil},
+		"theirs only":     {nil, []string{CompressionZstd}, nil},
+		"no common":       {[]string{CompressionZstd}, []string{CompressionSnappy}, nil},
+		"our preference":  {[]string{CompressionSnappy, CompressionZstd}, []string{CompressionZstd, CompressionSnappy}, snappyCompressor{}},
+		"common":          {[]string{CompressionZstd, CompressionSnappy}, []string{CompressionSnappy}, snappyCompressor{}},
+		"unknown ignored": {[]string{"lz4", CompressionZstd}, []string{"lz4", CompressionZstd}, zstdCompressor{}},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expect, negotiateCompression(tc.ours, tc.theirs))
+		})
+	}
+}
+
+func TestEncodeDecodeMessage(t *testing.T) {
+	compressible := bytes.Repeat([]byte("compressible "), 1000)
+	for name, c := range map[string]compressor{
+		CompressionZstd:   zstdCompressor{},
+		CompressionSnappy: snappyCompressor{},
+	} {
+		c := c
+		t.Run(name, func(t *testing.T) {
+			// compressible messages are compressed, if asked to
+			encoded, compressed := encodeMessage(c, compressible, true)
+			require.True(t, compressed)
+			require.Less(t, len(encoded), len(compressible))
+			decoded, err := decodeMessage(c, encoded, len(compressible))
+			require.NoError(t, err)
+			require.Equal(t, compressible, decoded)
+
+			// but not when they would exceed the max size
+			_, err = decodeMessage(c, encoded, len(compressible)-1)
+			require.Error(t, err)
+
+			// small, incompressible or uncompressed messages are sent as is
+			for _, msg := range [][]byte{{}, []byte("small"), tmrand.Bytes(1000)} {
+				encoded, compressed = encodeMessage(c, msg, true)
+				require.False(t, compressed)
+				require.Equal(t, append([]byte{messageUncompressed}, msg...), encoded)
+			}
+			encoded, compressed = encodeMessage(c, compressible, false)
+			require.False(t, compressed)
+			decoded, err = decodeMessage(c, encoded, len(compressible))
+			require.NoError(t, err)
+			require.Equal(t, compressible, decoded)
+
+			// invalid messages are rejected
+			for _, msg := range [][]byte{{}, {2, 1, 2}, {messageCompressed, 1, 2, 3}} {
+				_, err = decodeMessage(c, msg, len(compressible))
+				require.Error(t, err)
+			}
+		})
+	}
+}
@@ -34,6 +34,11 @@ const (
 	minWriteBufferSize = 65536
 	updateStats        = 2 * time.Second
 
+	// maxRetainedRecvBufferSize is the capacity up to which the buffer a
+	// channel assembles the messages received in is reused, which holds a
+	// block part with its proof.
+	maxRetainedRecvBufferSize = 1 << 17
+
 	// some of these defaults are written in the user config
 	// flushThrottle, sendRate, recvRate
 	// TODO: remove values present in config
@@ -111,6 +116,11 @@ type MConnection struct {
 	pongTimer     *time.Timer
 	pongTimeoutCh chan bool // true - timeout, false - peer sent pong
 
+	// time the ping awaiting a pong was sent, zero if none. Only accessed by
+	// the sendRoutine.
+	pingSent time.Time
+	rtt      int64 // atomic, round-trip time of the last ping answered
+
 	chStatsTimer *time.Ticker // update channel stats periodically
 
 	created time.Time // time of creation
@@ -333,6 +343,12 @@ func (c *MConnection) Send(chID ChannelID, msgBytes []byte) bool {
 	return success
 }
 
+// RTT returns the round-trip time of the last ping answered by the peer, or
+// zero if none was answered yet.
+func (c *MConnection) RTT() time.Duration {
+	return time.Duration(atomic.LoadInt64(&c.rtt))
+}
+
 // sendRoutine polls for packets to send from channels.
 func (c *MConnection) sendRoutine(ctx context.Context) {
 	defer c._recover(ctx)
@@ -360,6 +376,7 @@ FOR_LOOP:
 				break SELECTION
 			}
 			c.sendMonitor.Update(_n)
+			c.pingSent = time.Now()
 			c.logger.Debug("Starting pong timer", "dur", c.config.PongTimeout)
 			c.pongTimer = time.AfterFunc(c.config.PongTimeout, func() {
 				select {
@@ -374,6 +391,10 @@ FOR_LOOP:
 				err = errors.New("pong timeout")
 			} else {
 				c.stopPongTimer()
+				if !c.pingSent.IsZero() {
+					atomic.StoreInt64(&c.rtt, int64(time.Since(c.pingSent)))
+					c.pingSent = time.Time{}
+				}
 			}
 		case <-c.pong:
 			c.logger.Debug("Send Pong")
@@ -625,6 +646,15 @@ type ChannelDescriptor struct {
 	// RecvBufferCapacity defines the max buffer size of inbound messages for a
 	// given p2p Channel queue.
 	RecvBufferCapacity int
+
+	// MessageTTL is how long messages on the channel may wait in the Router's
+	// priority queues before they are dropped, e.g. because they are stale
+	// by then. Zero means they never expire.
+	MessageTTL time.Duration
+
+	// Compress marks channels of large, compressible messages, which are
+	// compressed if a compression algorithm is negotiated with the peer.
+	Compress bool
 }
 
 func (chDesc ChannelDescriptor) FillDefaults() (filled ChannelDescriptor) {
@@ -653,7 +683,7 @@ type channel struct {
 	desc          ChannelDescriptor
 	sendQueue     chan []byte
 	sendQueueSize int32 // atomic.
-	recving       []byte
+	recving       []byte // reused across messages
 	sending       []byte
 
 	maxPacketMsgPayloadSize int
@@ -729,7 +759,10 @@ func (ch *channel) writePacketMsgTo(w io.Writer) (n int, err error) {
 }
 
 // Handles incoming PacketMsgs. It returns a message bytes if message is
-// complete, which is owned by the caller and will not be modified.
+// complete, which is owned by the caller and will not be modified. The message
+// is copied out of the receive buffer at its exact size, rather than handing
+// over the buffer grown while receiving it, so that the buffer is reused and
+// decoded messages aliasing the bytes retain no more memory than needed.
 // Not goroutine-safe
 func (ch *channel) recvPacketMsg(packet tmp2p.PacketMsg) ([]byte, error) {
 	ch.logger.Debug("Read PacketMsg", "conn", ch.conn, "packet", packet)
@@ -739,8 +772,13 @@ func (ch *channel) recvPacketMsg(packet tmp2p.PacketMsg) ([]byte, error) {
 	}
 	ch.recving = append(ch.recving, packet.Data...)
 	if packet.EOF {
-		msgBytes := ch.recving
-		ch.recving = make([]byte, 0, ch.desc.RecvBufferCapacity)
+		msgBytes := make([]byte, len(ch.recving))
+		copy(msgBytes, ch.recving)
+		if cap(ch.recving) > maxRetainedRecvBufferSize {
+			ch.recving = make([]byte, 0, ch.desc.RecvBufferCapacity)
+		} else {
+			ch.recving = ch.recving[:0]
+		}
 		return msgBytes, nil
 	}
 	return nil, nil
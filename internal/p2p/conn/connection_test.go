@@ -393,6 +393,10 @@ func TestMConnectionPingPongs(t *testing.T) {
 	case <-time.After(2 * pongTimerExpired):
 		assert.True(t, mconn.IsRunning())
 	}
+	// the pongs answered the pings
+	rtt := mconn.RTT()
+	assert.Greater(t, int64(rtt), int64(0))
+	assert.Less(t, int64(rtt), int64(mconn.config.PongTimeout))
 }
 
 func TestMConnectionStopsAndReturnsError(t *testing.T) {
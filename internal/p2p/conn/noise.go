@@ -0,0 +1,507 @@
+package conn
+
+import (
+	"crypto/cipher"
+	"crypto/hmac"
+	crand "crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/oasisprotocol/curve25519-voi/primitives/x25519"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/curve25519"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+)
+
+// NoiseHandshake identifies the Noise handshake pattern an initiator uses. It
+// is the first byte the initiator sends, which lets the responder tell Noise
+// handshakes apart from the STS handshake of MakeSecretConnection, whose first
+// byte is the length of the ephemeral key message.
+type NoiseHandshake byte
+
+const (
+	// NoiseHandshakeIK is the Noise IK pattern, used when the initiator knows
+	// the responder's static key. The responder never sends its static key, so
+	// it is hidden from anyone not knowing it already.
+	NoiseHandshakeIK NoiseHandshake = 0x01
+	// NoiseHandshakeXX is the Noise XX pattern, used when the initiator
+	// doesn't know the responder's static key. Both static keys are sent
+	// encrypted, hiding them from passive observers.
+	NoiseHandshakeXX NoiseHandshake = 0x02
+
+	// NetworkKeySize is the size of a pre-shared network key.
+	NetworkKeySize = 32
+
+	noisePrologue         = "TENDERMINT_NOISE_HANDSHAKE"
+	noiseMaxMessageSize   = 65535
+	noiseHashSize         = sha256.Size
+	noiseDHSize           = curve25519.PointSize
+	noiseStaticPayloadLen = ed25519.PubKeySize
+)
+
+var (
+	errNoiseHandshakeFailed = errors.New("noise handshake failed")
+
+	// the message patterns of the handshakes, after any pre-messages
+	noisePatterns = map[NoiseHandshake][][]string{
+		NoiseHandshakeIK: {{"e", "es", "s", "ss"}, {"e", "ee", "se"}},
+		NoiseHandshakeXX: {{"e"}, {"e", "ee", "s", "es"}, {"s", "se"}},
+	}
+	// the message the psk token is appended to, in the psk2 and psk3
+	// variants of the handshakes
+	noisePSKMessage = map[NoiseHandshake]int{
+		NoiseHandshakeIK: 1,
+		NoiseHandshakeXX: 2,
+	}
+)
+
+// IsNoiseHandshake returns whether the first byte received on a connection
+// starts a Noise handshake.
+func IsNoiseHandshake(b byte) bool {
+	_, ok := noisePatterns[NoiseHandshake(b)]
+	return ok
+}
+
+// MakeNoiseConnection performs a Noise handshake as the initiator, and returns
+// an authenticated SecretConnection. If remPubKey is set, the responder must
+// have this key, and the IK handshake is used, otherwise the XX handshake.
+// If networkKey is set, it is mixed into the handshake as a pre-shared key,
+// and the handshake only succeeds with responders having the same key.
+//
+// The static keys of the handshake are the X25519 equivalents of the ed25519
+// node keys, and the transport frames are those of the STS handshake.
+func MakeNoiseConnection(
+	conn io.ReadWriteCloser,
+	locPrivKey crypto.PrivKey,
+	remPubKey crypto.PubKey,
+	networkKey []byte,
+) (*SecretConnection, error) {
+	pattern := NoiseHandshakeXX
+	if remPubKey != nil {
+		pattern = NoiseHandshakeIK
+	}
+	hs, err := newNoiseHandshake(pattern, true, locPrivKey, remPubKey, networkKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := conn.Write([]byte{byte(pattern)}); err != nil {
+		return nil, err
+	}
+	return hs.run(conn)
+}
+
+// AcceptNoiseConnection performs a Noise handshake as the responder, once the
+// first byte of the connection identified the handshake pattern, and returns
+// an authenticated SecretConnection. If networkKey is set, the handshake only
+// succeeds with initiators having the same key.
+func AcceptNoiseConnection(
+	conn io.ReadWriteCloser,
+	pattern NoiseHandshake,
+	locPrivKey crypto.PrivKey,
+	networkKey []byte,
+) (*SecretConnection, error) {
+	hs, err := newNoiseHandshake(pattern, false, locPrivKey, nil, networkKey)
+	if err != nil {
+		return nil, err
+	}
+	return hs.run(conn)
+}
+
+// noiseHandshake is the state of a Noise handshake, as specified in
+// https://noiseprotocol.org/noise.html with the 25519, ChaChaPoly and SHA256
+// functions.
+type noiseHandshake struct {
+	pattern    NoiseHandshake
+	initiator  bool
+	networkKey []byte
+
+	// local static and ephemeral keys, and remote ones once known
+	s, e        *noiseKeyPair
+	rs, re      []byte
+	locPubKey   ed25519.PubKey
+	remPubKey   ed25519.PubKey
+	chainingKey [noiseHashSize]byte
+	hash        [noiseHashSize]byte
+	cipher      cipher.AEAD
+	nonce       uint64
+}
+
+type noiseKeyPair struct {
+	priv []byte
+	pub  []byte
+}
+
+func newNoiseHandshake(
+	pattern NoiseHandshake,
+	initiator bool,
+	locPrivKey crypto.PrivKey,
+	remPubKey crypto.PubKey,
+	networkKey []byte,
+) (*noiseHandshake, error) {
+	if _, ok := noisePatterns[pattern]; !ok {
+		return nil, fmt.Errorf("unknown noise handshake %#x", byte(pattern))
+	}
+	if len(networkKey) != 0 && len(networkKey) != NetworkKeySize {
+		return nil, fmt.Errorf("network key must be %d bytes, got %d", NetworkKeySize, len(networkKey))
+	}
+	privKey, ok := locPrivKey.(ed25519.PrivKey)
+	if !ok {
+		return nil, fmt.Errorf("expected ed25519 private key, got %T", locPrivKey)
+	}
+
+	hs := &noiseHandshake{
+		pattern:    pattern,
+		initiator:  initiator,
+		networkKey: networkKey,
+		locPubKey:  privKey.PubKey().(ed25519.PubKey),
+	}
+	hs.s = &noiseKeyPair{priv: x25519.EdPrivateKeyToX25519([]byte(privKey))}
+	hs.s.pub, ok = x25519.EdPublicKeyToX25519([]byte(hs.locPubKey))
+	if !ok {
+		return nil, errors.New("invalid ed25519 public key")
+	}
+	if remPubKey != nil {
+		pubKey, ok := remPubKey.(ed25519.PubKey)
+		if !ok {
+			return nil, fmt.Errorf("expected ed25519 pubkey, got %T", remPubKey)
+		}
+		if hs.rs, ok = x25519.EdPublicKeyToX25519([]byte(pubKey)); !ok {
+			return nil, errors.New("invalid remote ed25519 public key")
+		}
+		hs.remPubKey = pubKey
+	}
+
+	hs.initialize()
+	return hs, nil
+}
+
+// protocolName returns the Noise protocol name of the handshake.
+func (hs *noiseHandshake) protocolName() string {
+	name, psk := "IK", "psk2"
+	if hs.pattern == NoiseHandshakeXX {
+		name, psk = "XX", "psk3"
+	}
+	if len(hs.networkKey) > 0 {
+		name += psk
+	}
+	return "Noise_" + name + "_25519_ChaChaPoly_SHA256"
+}
+
+func (hs *noiseHandshake) initialize() {
+	name := hs.protocolName()
+	if len(name) <= noiseHashSize {
+		copy(hs.hash[:], name)
+	} else {
+		hs.hash = sha256.Sum256([]byte(name))
+	}
+	hs.chainingKey = hs.hash
+	hs.mixHash([]byte(noisePrologue))
+
+	// IK has the pre-message <- s
+	if hs.pattern == NoiseHandshakeIK {
+		if hs.initiator {
+			hs.mixHash(hs.rs)
+		} else {
+			hs.mixHash(hs.s.pub)
+		}
+	}
+}
+
+// run runs the handshake on the connection, and returns the SecretConnection
+// using the keys it established.
+func (hs *noiseHandshake) run(conn io.ReadWriteCloser) (*SecretConnection, error) {
+	messages := noisePatterns[hs.pattern]
+	for i := range messages {
+		// the initiator writes the even messages, the responder the odd ones
+		if (i%2 == 0) == hs.initiator {
+			if err := hs.writeMessage(conn, i); err != nil {
+				return nil, err
+			}
+		} else if err := hs.readMessage(conn, i); err != nil {
+			return nil, err
+		}
+	}
+
+	initKey, respKey := hs.split()
+	sendKey, recvKey := initKey, respKey
+	if !hs.initiator {
+		sendKey, recvKey = respKey, initKey
+	}
+	sendAead, err := chacha20poly1305.New(sendKey[:])
+	if err != nil {
+		return nil, errors.New("invalid send SecretConnection Key")
+	}
+	recvAead, err := chacha20poly1305.New(recvKey[:])
+	if err != nil {
+		return nil, errors.New("invalid receive SecretConnection Key")
+	}
+
+	// Noise nonces are 64-bit little endian counters after 4 zero bytes,
+	// like the SecretConnection ones, which start at zero too.
+	return &SecretConnection{
+		conn:      conn,
+		recvNonce: new([aeadNonceSize]byte),
+		sendNonce: new([aeadNonceSize]byte),
+		recvAead:  recvAead,
+		sendAead:  sendAead,
+		remPubKey: hs.remPubKey,
+	}, nil
+}
+
+// payload returns the payload of the message, which is the local ed25519
+// public key in the message which sends the local static key.
+func (hs *noiseHandshake) payload(tokens []string) []byte {
+	for _, token := range tokens {
+		if token == "s" {
+			return hs.locPubKey
+		}
+	}
+	return nil
+}
+
+func (hs *noiseHandshake) writeMessage(w io.Writer, i int) error {
+	tokens := noisePatterns[hs.pattern][i]
+	msg := make([]byte, 2, 2+3*noiseDHSize+noiseStaticPayloadLen+2*aeadSizeOverhead)
+
+	for _, token := range tokens {
+		switch token {
+		case "e":
+			e, err := genNoiseKeyPair()
+			if err != nil {
+				return err
+			}
+			hs.e = e
+			msg = append(msg, e.pub...)
+			hs.mixHash(e.pub)
+			if len(hs.networkKey) > 0 {
+				hs.mixKey(e.pub)
+			}
+		case "s":
+			ciphertext, err := hs.encryptAndHash(hs.s.pub)
+			if err != nil {
+				return err
+			}
+			msg = append(msg, ciphertext...)
+		default:
+			if err := hs.mixDH(token); err != nil {
+				return err
+			}
+		}
+	}
+	if len(hs.networkKey) > 0 && noisePSKMessage[hs.pattern] == i {
+		hs.mixKeyAndHash(hs.networkKey)
+	}
+
+	ciphertext, err := hs.encryptAndHash(hs.payload(tokens))
+	if err != nil {
+		return err
+	}
+	msg = append(msg, ciphertext...)
+
+	binary.BigEndian.PutUint16(msg, uint16(len(msg)-2))
+	_, err = w.Write(msg)
+	return err
+}
+
+func (hs *noiseHandshake) readMessage(r io.Reader, i int) error {
+	var length [2]byte
+	if _, err := io.ReadFull(r, length[:]); err != nil {
+		return err
+	}
+	msg := make([]byte, binary.BigEndian.Uint16(length[:]))
+	if _, err := io.ReadFull(r, msg); err != nil {
+		return err
+	}
+
+	tokens := noisePatterns[hs.pattern][i]
+	for _, token := range tokens {
+		switch token {
+		case "e":
+			if len(msg) < noiseDHSize {
+				return errNoiseHandshakeFailed
+			}
+			hs.re, msg = msg[:noiseDHSize], msg[noiseDHSize:]
+			hs.mixHash(hs.re)
+			if len(hs.networkKey) > 0 {
+				hs.mixKey(hs.re)
+			}
+		case "s":
+			size := noiseDHSize
+			if hs.cipher != nil {
+				size += aeadSizeOverhead
+			}
+			if len(msg) < size {
+				return errNoiseHandshakeFailed
+			}
+			rs, err := hs.decryptAndHash(msg[:size])
+			if err != nil {
+				return err
+			}
+			hs.rs, msg = rs, msg[size:]
+		default:
+			if err := hs.mixDH(token); err != nil {
+				return err
+			}
+		}
+	}
+	if len(hs.networkKey) > 0 && noisePSKMessage[hs.pattern] == i {
+		hs.mixKeyAndHash(hs.networkKey)
+	}
+
+	payload, err := hs.decryptAndHash(msg)
+	if err != nil {
+		return err
+	}
+	for _, token := range tokens {
+		if token == "s" {
+			return hs.authenticate(payload)
+		}
+	}
+	return nil
+}
+
+// authenticate checks that the remote ed25519 public key in the payload is the
+// one of the remote static key of the handshake.
+func (hs *noiseHandshake) authenticate(payload []byte) error {
+	if len(payload) != ed25519.PubKeySize {
+		return fmt.Errorf("expected ed25519 pubkey, got %d bytes", len(payload))
+	}
+	pubKey := ed25519.PubKey(append([]byte{}, payload...))
+	rs, ok := x25519.EdPublicKeyToX25519([]byte(pubKey))
+	if !ok || !hmac.Equal(rs, hs.rs) {
+		return errors.New("remote ed25519 pubkey doesn't match its static key")
+	}
+	hs.remPubKey = pubKey
+	return nil
+}
+
+// mixDH mixes the Diffie-Hellman of the keys of a DH token into the chaining
+// key. The first letter of the token is the initiator's key, the second the
+// responder's.
+func (hs *noiseHandshake) mixDH(token string) error {
+	local, remote := token[0], token[1]
+	if !hs.initiator {
+		local, remote = remote, local
+	}
+
+	locKey := hs.e
+	if local == 's' {
+		locKey = hs.s
+	}
+	remKey := hs.re
+	if remote == 's' {
+		remKey = hs.rs
+	}
+	if locKey == nil || remKey == nil {
+		return errNoiseHandshakeFailed
+	}
+
+	dh, err := curve25519.X25519(locKey.priv, remKey)
+	if err != nil {
+		return ErrSmallOrderRemotePubKey
+	}
+	hs.mixKey(dh)
+	return nil
+}
+
+func (hs *noiseHandshake) mixHash(data []byte) {
+	h := sha256.New()
+	h.Write(hs.hash[:])
+	h.Write(data)
+	h.Sum(hs.hash[:0])
+}
+
+func (hs *noiseHandshake) mixKey(ikm []byte) {
+	var key [noiseHashSize]byte
+	noiseHKDF(hs.chainingKey[:], ikm, &hs.chainingKey, &key)
+	hs.setKey(key[:])
+}
+
+func (hs *noiseHandshake) mixKeyAndHash(ikm []byte) {
+	var hash, key [noiseHashSize]byte
+	noiseHKDF(hs.chainingKey[:], ikm, &hs.chainingKey, &hash, &key)
+	hs.mixHash(hash[:])
+	hs.setKey(key[:])
+}
+
+func (hs *noiseHandshake) setKey(key []byte) {
+	aead, err := chacha20poly1305.New(key)
+	if err != nil {
+		panic(err) // the key size is always valid
+	}
+	hs.cipher = aead
+	hs.nonce = 0
+}
+
+func (hs *noiseHandshake) nextNonce() []byte {
+	nonce := make([]byte, aeadNonceSize)
+	binary.LittleEndian.PutUint64(nonce[4:], hs.nonce)
+	hs.nonce++
+	return nonce
+}
+
+func (hs *noiseHandshake) encryptAndHash(plaintext []byte) ([]byte, error) {
+	ciphertext := plaintext
+	if hs.cipher != nil {
+		ciphertext = hs.cipher.Seal(nil, hs.nextNonce(), plaintext, hs.hash[:])
+	}
+	if len(ciphertext) > noiseMaxMessageSize {
+		return nil, errors.New("noise message too large")
+	}
+	hs.mixHash(ciphertext)
+	return ciphertext, nil
+}
+
+func (hs *noiseHandshake) decryptAndHash(ciphertext []byte) ([]byte, error) {
+	plaintext := ciphertext
+	if hs.cipher != nil {
+		var err error
+		plaintext, err = hs.cipher.Open(nil, hs.nextNonce(), ciphertext, hs.hash[:])
+		if err != nil {
+			return nil, errNoiseHandshakeFailed
+		}
+	}
+	hs.mixHash(ciphertext)
+	return plaintext, nil
+}
+
+// split returns the keys of the transport ciphers of the initiator and the
+// responder.
+func (hs *noiseHandshake) split() (initKey, respKey [aeadKeySize]byte) {
+	noiseHKDF(hs.chainingKey[:], nil, &initKey, &respKey)
+	return initKey, respKey
+}
+
+// noiseHKDF is the HKDF function of the Noise specification, with as many
+// outputs as given.
+func noiseHKDF(chainingKey, ikm []byte, outputs ...*[noiseHashSize]byte) {
+	mac := hmac.New(sha256.New, chainingKey)
+	mac.Write(ikm)
+	tempKey := mac.Sum(nil)
+
+	var prev []byte
+	for i, output := range outputs {
+		mac = hmac.New(sha256.New, tempKey)
+		mac.Write(prev)
+		mac.Write([]byte{byte(i + 1)})
+		prev = mac.Sum(output[:0])
+	}
+}
+
+func genNoiseKeyPair() (*noiseKeyPair, error) {
+	priv := make([]byte, curve25519.ScalarSize)
+	if _, err := crand.Read(priv); err != nil {
+		return nil, err
+	}
+	pub, err := curve25519.X25519(priv, curve25519.Basepoint)
+	if err != nil {
+		return nil, err
+	}
+	return &noiseKeyPair{priv: priv, pub: pub}, nil
+}
@@ -0,0 +1,113 @@
+package conn
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+)
+
+// makeNoiseConnPair performs a Noise handshake between an initiator and a
+// responder with the given keys, and returns the resulting connections or the
+// first handshake error.
+func makeNoiseConnPair(
+	t *testing.T,
+	initKey, respKey crypto.PrivKey,
+	remPubKey crypto.PubKey,
+	initNetworkKey, respNetworkKey []byte,
+) (initConn, respConn *SecretConnection, err error) {
+	fooConn, barConn := makeKVStoreConnPair()
+	t.Cleanup(func() {
+		_ = fooConn.Close()
+		_ = barConn.Close()
+	})
+
+	errCh := make(chan error, 1)
+	go func() {
+		var err error
+		defer func() { errCh <- err }()
+
+		var first [1]byte
+		if _, err = io.ReadFull(barConn, first[:]); err != nil {
+			return
+		}
+		if !IsNoiseHandshake(first[0]) {
+			err = io.ErrUnexpectedEOF
+			return
+		}
+		respConn, err = AcceptNoiseConnection(barConn, NoiseHandshake(first[0]), respKey, respNetworkKey)
+		if err != nil {
+			// unblock the initiator
+			_ = barConn.Close()
+		}
+	}()
+
+	initConn, err = MakeNoiseConnection(fooConn, initKey, remPubKey, initNetworkKey)
+	if err != nil {
+		_ = fooConn.Close()
+	}
+	if respErr := <-errCh; err == nil {
+		err = respErr
+	}
+	return initConn, respConn, err
+}
+
+func TestNoiseConnection(t *testing.T) {
+	initKey, respKey := ed25519.GenPrivKey(), ed25519.GenPrivKey()
+	networkKey := tmrand.Bytes(NetworkKeySize)
+
+	testcases := map[string]struct {
+		remPubKey      crypto.PubKey
+		initNetworkKey []byte
+		respNetworkKey []byte
+		ok             bool
+	}{
+		"XX":                       {nil, nil, nil, true},
+		"IK":                       {respKey.PubKey(), nil, nil, true},
+		"XX network key":           {nil, networkKey, networkKey, true},
+		"IK network key":           {respKey.PubKey(), networkKey, networkKey, true},
+		"IK wrong responder key":   {ed25519.GenPrivKey().PubKey(), nil, nil, false},
+		"XX missing network key":   {nil, nil, networkKey, false},
+		"IK missing network key":   {respKey.PubKey(), networkKey, nil, false},
+		"XX different network key": {nil, networkKey, tmrand.Bytes(NetworkKeySize), false},
+		"IK different network key": {respKey.PubKey(), networkKey, tmrand.Bytes(NetworkKeySize), false},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			initConn, respConn, err := makeNoiseConnPair(
+				t, initKey, respKey, tc.remPubKey, tc.initNetworkKey, tc.respNetworkKey)
+			if !tc.ok {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			// both sides authenticated each other
+			assert.Equal(t, respKey.PubKey(), initConn.RemotePubKey())
+			assert.Equal(t, initKey.PubKey(), respConn.RemotePubKey())
+
+			// and can exchange data both ways
+			msg := tmrand.Bytes(3 * dataMaxSize)
+			go func() {
+				_, _ = initConn.Write(msg)
+			}()
+			received := make([]byte, len(msg))
+			_, err = io.ReadFull(respConn, received)
+			require.NoError(t, err)
+			assert.Equal(t, msg, received)
+
+			go func() {
+				_, _ = respConn.Write(msg[:10])
+			}()
+			_, err = io.ReadFull(initConn, received[:10])
+			require.NoError(t, err)
+			assert.Equal(t, msg[:10], received[:10])
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package p2p
+
+import "github.com/tendermint/tendermint/types"
+
+// GossipPolicy restricts the messages that are routed to a peer, e.g. to not
+// send peer addresses to a validator behind sentries, or to only relay blocks
+// to a peer. The zero value allows all messages.
+type GossipPolicy struct {
+	// BlockedChannels are the channels on which no messages are routed to the
+	// peer. Messages sent to the peer on these channels are dropped.
+	BlockedChannels map[ChannelID]bool
+}
+
+// Allows returns whether the policy allows routing messages on the given
+// channel.
+func (p GossipPolicy) Allows(chID ChannelID) bool {
+	return !p.BlockedChannels[chID]
+}
+
+// GossipPolicy returns the gossip policy of the given peer, as configured by
+// PeerManagerOptions.GossipPolicies and DefaultGossipPolicy.
+func (m *PeerManager) GossipPolicy(peerID types.NodeID) GossipPolicy {
+	if policy, ok := m.options.GossipPolicies[peerID]; ok {
+		return policy
+	}
+	return m.options.DefaultGossipPolicy
+}
@@ -4,18 +4,30 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"sort"
 	"sync"
 
 	"github.com/go-kit/kit/metrics"
 	"github.com/go-kit/kit/metrics/discard"
 	"github.com/go-kit/kit/metrics/prometheus"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
+
+	"github.com/tendermint/tendermint/types"
 )
 
 const (
 	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
 	// package.
 	MetricsSubsystem = "p2p"
+
+	// maxPeerMetricLabels is the number of peers, with the most traffic, whose
+	// metrics are labeled with their ID. The metrics of other peers are
+	// aggregated under otherPeersMetricLabel, to bound the label cardinality.
+	maxPeerMetricLabels = 10
+
+	// otherPeersMetricLabel is the peer_id label of the aggregated metrics of
+	// the peers not among the top maxPeerMetricLabels.
+	otherPeersMetricLabel = "other"
 )
 
 var (
@@ -29,6 +41,8 @@ var (
 type Metrics struct {
 	// Number of peers.
 	Peers metrics.Gauge
+	// Number of peers by software and application version.
+	PeersByVersion metrics.Gauge
 	// Number of bytes received from a given peer.
 	PeerReceiveBytesTotal metrics.Counter
 	// Number of bytes sent to a given peer.
@@ -52,12 +66,45 @@ type Metrics struct {
 	// queue for a specific flow (i.e. Channel).
 	PeerQueueDroppedMsgs metrics.Counter
 
+	// PeerQueueExpiredMsgs defines the number of messages dropped from the
+	// priority queues for a specific flow (i.e. Channel) because their TTL
+	// expired before they were dequeued.
+	PeerQueueExpiredMsgs metrics.Counter
+
 	// PeerQueueMsgSize defines the average size of messages sent over a peer's
 	// queue for a specific flow (i.e. Channel).
 	PeerQueueMsgSize metrics.Gauge
 
+	// RouterQueueLatency defines the time messages for a specific flow (i.e.
+	// Channel) spend in the priority queues before they are dequeued.
+	RouterQueueLatency metrics.Histogram
+
+	// Number of messages received from a given peer on a channel.
+	PeerReceiveMsgsTotal metrics.Counter
+	// Number of messages sent to a given peer on a channel.
+	PeerSendMsgsTotal metrics.Counter
+	// Number of messages to a given peer on a channel which could not be
+	// queued or sent.
+	PeerSendFailuresTotal metrics.Counter
+	// Number of messages queued to be sent to a given peer.
+	PeerQueueDepth metrics.Gauge
+	// Number of messages received on a channel, queued to be processed by its
+	// reactor.
+	ChannelQueueDepth metrics.Gauge
+
+	// Ratio of the uncompressed to the compressed size of the messages sent
+	// compressed on a channel.
+	MessageCompressionRatio metrics.Histogram
+	// Number of bytes saved by compressing the messages sent on a channel.
+	CompressionSavedBytesTotal metrics.Counter
+
+	// Number of connections dialed, by IP family and result.
+	DialsTotal metrics.Counter
+
 	mtx               *sync.RWMutex
 	messageLabelNames map[reflect.Type]string
+
+	peerLabels *peerMetricLabels
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -76,6 +123,13 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Help:      "Number of peers.",
 		}, labels).With(labelsAndValues...),
 
+		PeersByVersion: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peers_by_version",
+			Help:      "Number of peers by software and application version.",
+		}, append(labels, "version", "app_version")).With(labelsAndValues...),
+
 		PeerReceiveBytesTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
@@ -125,6 +179,13 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Help:      "The number of messages dropped from a peer's queue for a specific p2p Channel.",
 		}, append(labels, "ch_id")).With(labelsAndValues...),
 
+		PeerQueueExpiredMsgs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "router_channel_queue_expired_msgs",
+			Help:      "The number of messages dropped from the priority queues for a specific p2p Channel because their TTL expired.",
+		}, append(labels, "ch_id")).With(labelsAndValues...),
+
 		PeerQueueMsgSize: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
@@ -132,8 +193,74 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Help:      "The size of messages sent over a peer's queue for a specific p2p Channel.",
 		}, append(labels, "ch_id")).With(labelsAndValues...),
 
+		RouterQueueLatency: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "router_channel_queue_latency",
+			Help:      "The time in seconds messages for a specific p2p Channel spend in the priority queues before they are dequeued.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.0001, 4, 10),
+		}, append(labels, "ch_id")).With(labelsAndValues...),
+
+		PeerReceiveMsgsTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_receive_msgs_total",
+			Help:      "Number of messages received from a given peer on a channel.",
+		}, append(labels, "peer_id", "ch_id")).With(labelsAndValues...),
+
+		PeerSendMsgsTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_send_msgs_total",
+			Help:      "Number of messages sent to a given peer on a channel.",
+		}, append(labels, "peer_id", "ch_id")).With(labelsAndValues...),
+
+		PeerSendFailuresTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_send_failures_total",
+			Help:      "Number of messages to a given peer on a channel which could not be queued or sent.",
+		}, append(labels, "peer_id", "ch_id")).With(labelsAndValues...),
+
+		PeerQueueDepth: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_queue_depth",
+			Help:      "Number of messages queued to be sent to a given peer.",
+		}, append(labels, "peer_id")).With(labelsAndValues...),
+
+		ChannelQueueDepth: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "channel_queue_depth",
+			Help:      "Number of messages received on a channel, queued to be processed by its reactor.",
+		}, append(labels, "ch_id")).With(labelsAndValues...),
+
+		MessageCompressionRatio: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "message_compression_ratio",
+			Help:      "Ratio of the uncompressed to the compressed size of the messages sent compressed on a channel.",
+			Buckets:   []float64{1.25, 1.5, 2, 3, 4, 6, 8, 12, 16},
+		}, append(labels, "ch_id")).With(labelsAndValues...),
+
+		CompressionSavedBytesTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "compression_saved_bytes_total",
+			Help:      "Number of bytes saved by compressing the messages sent on a channel.",
+		}, append(labels, "ch_id")).With(labelsAndValues...),
+
+		DialsTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "dials_total",
+			Help:      "Number of connections dialed, by IP family and result.",
+		}, append(labels, "family", "result")).With(labelsAndValues...),
+
 		mtx:               &sync.RWMutex{},
 		messageLabelNames: map[reflect.Type]string{},
+		peerLabels:        newPeerMetricLabels(maxPeerMetricLabels),
 	}
 }
 
@@ -141,6 +268,7 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 func NopMetrics() *Metrics {
 	return &Metrics{
 		Peers:                  discard.NewGauge(),
+		PeersByVersion:         discard.NewGauge(),
 		PeerReceiveBytesTotal:  discard.NewCounter(),
 		PeerSendBytesTotal:     discard.NewCounter(),
 		PeerPendingSendBytes:   discard.NewGauge(),
@@ -148,9 +276,99 @@ func NopMetrics() *Metrics {
 		RouterPeerQueueSend:    discard.NewHistogram(),
 		RouterChannelQueueSend: discard.NewHistogram(),
 		PeerQueueDroppedMsgs:   discard.NewCounter(),
+		PeerQueueExpiredMsgs:   discard.NewCounter(),
 		PeerQueueMsgSize:       discard.NewGauge(),
-		mtx:                    &sync.RWMutex{},
-		messageLabelNames:      map[reflect.Type]string{},
+		RouterQueueLatency:     discard.NewHistogram(),
+		PeerReceiveMsgsTotal:   discard.NewCounter(),
+		PeerSendMsgsTotal:      discard.NewCounter(),
+		PeerSendFailuresTotal:  discard.NewCounter(),
+		PeerQueueDepth:         discard.NewGauge(),
+		ChannelQueueDepth:      discard.NewGauge(),
+
+		MessageCompressionRatio:    discard.NewHistogram(),
+		CompressionSavedBytesTotal: discard.NewCounter(),
+		DialsTotal:                 discard.NewCounter(),
+
+		mtx:               &sync.RWMutex{},
+		messageLabelNames: map[reflect.Type]string{},
+		peerLabels:        newPeerMetricLabels(maxPeerMetricLabels),
+	}
+}
+
+// PeerLabel returns the peer_id label of the metrics of the given peer: its
+// ID if it is among the peers with the most traffic, or otherPeersMetricLabel.
+func (m *Metrics) PeerLabel(peerID types.NodeID) string {
+	return m.peerLabels.label(peerID)
+}
+
+// peerMetricLabels tracks the traffic of each connected peer, to label the
+// metrics of the peers with the most traffic with their ID, and bound the
+// number of label values.
+type peerMetricLabels struct {
+	max int
+
+	mtx     sync.RWMutex
+	traffic map[types.NodeID]uint64
+	top     map[types.NodeID]bool
+}
+
+func newPeerMetricLabels(max int) *peerMetricLabels {
+	return &peerMetricLabels{
+		max:     max,
+		traffic: make(map[types.NodeID]uint64),
+		top:     make(map[types.NodeID]bool),
+	}
+}
+
+func (l *peerMetricLabels) label(peerID types.NodeID) string {
+	l.mtx.RLock()
+	defer l.mtx.RUnlock()
+
+	if l.top[peerID] {
+		return string(peerID)
+	}
+	return otherPeersMetricLabel
+}
+
+// addTraffic records bytes sent to or received from the peer.
+func (l *peerMetricLabels) addTraffic(peerID types.NodeID, bytes int) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	l.traffic[peerID] += uint64(bytes)
+}
+
+// removePeer forgets a disconnected peer.
+func (l *peerMetricLabels) removePeer(peerID types.NodeID) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	delete(l.traffic, peerID)
+	delete(l.top, peerID)
+}
+
+// refresh recomputes the peers with the most traffic.
+func (l *peerMetricLabels) refresh() {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	peers := make([]types.NodeID, 0, len(l.traffic))
+	for peerID := range l.traffic {
+		peers = append(peers, peerID)
+	}
+	sort.Slice(peers, func(i, j int) bool {
+		if l.traffic[peers[i]] != l.traffic[peers[j]] {
+			return l.traffic[peers[i]] > l.traffic[peers[j]]
+		}
+		return peers[i] < peers[j]
+	})
+	if len(peers) > l.max {
+		peers = peers[:l.max]
+	}
+
+	l.top = make(map[types.NodeID]bool, len(peers))
+	for _, peerID := range peers {
+		l.top[peerID] = true
 	}
 }
 
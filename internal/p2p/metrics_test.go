@@ -1,10 +1,13 @@
 package p2p
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
 	"github.com/tendermint/tendermint/proto/tendermint/p2p"
+	"github.com/tendermint/tendermint/types"
 )
 
 func TestValueToMetricsLabel(t *testing.T) {
@@ -17,3 +20,30 @@ func TestValueToMetricsLabel(t *testing.T) {
 	str = m.ValueToMetricLabel(r)
 	assert.Equal(t, "p2p_PexResponse", str)
 }
+
+func TestPeerMetricLabels(t *testing.T) {
+	l := newPeerMetricLabels(2)
+	a, b, c := types.NodeID(strings.Repeat("a", 40)), types.NodeID(strings.Repeat("b", 40)), types.NodeID(strings.Repeat("c", 40))
+
+	// peers are aggregated until the top peers are computed
+	l.addTraffic(a, 10)
+	l.addTraffic(b, 30)
+	l.addTraffic(c, 20)
+	assert.Equal(t, otherPeersMetricLabel, l.label(a))
+
+	l.refresh()
+	assert.Equal(t, otherPeersMetricLabel, l.label(a))
+	assert.Equal(t, string(b), l.label(b))
+	assert.Equal(t, string(c), l.label(c))
+
+	l.addTraffic(a, 100)
+	l.refresh()
+	assert.Equal(t, string(a), l.label(a))
+	assert.Equal(t, string(b), l.label(b))
+	assert.Equal(t, otherPeersMetricLabel, l.label(c))
+
+	l.removePeer(a)
+	assert.Equal(t, otherPeersMetricLabel, l.label(a))
+	l.refresh()
+	assert.Equal(t, string(c), l.label(c))
+}
@@ -0,0 +1,106 @@
+// Package nat makes nodes behind a NAT dialable, by mapping their p2p port on
+// the NAT gateway with UPnP or NAT-PMP, and by detecting their external
+// address from the gateway and from the addresses their peers observe.
+package nat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+// Interface is a NAT gateway which can report its external IP address and map
+// external ports to ports on the local host.
+type Interface interface {
+	// ExternalIP returns the external IP address of the gateway.
+	ExternalIP(ctx context.Context) (net.IP, error)
+
+	// AddMapping maps the given external port of the gateway to the internal
+	// port of the local host for the given lifetime, and returns the external
+	// port actually mapped, which the gateway may choose differently.
+	// Protocol is either "tcp" or "udp".
+	AddMapping(ctx context.Context, protocol string, extPort, intPort int,
+		desc string, lifetime time.Duration) (int, error)
+
+	// DeleteMapping removes a mapping added by AddMapping.
+	DeleteMapping(ctx context.Context, protocol string, extPort, intPort int) error
+
+	// String describes the gateway, e.g. in logs.
+	String() string
+}
+
+// Discover searches the local network for a gateway supporting UPnP or
+// NAT-PMP, and returns the first one found. The search is bounded by the
+// context, which should have a timeout.
+func Discover(ctx context.Context) (Interface, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		nat Interface
+		err error
+	}
+	discoverers := []func(context.Context) (Interface, error){discoverUPnP, discoverNATPMP}
+	results := make(chan result, len(discoverers))
+	for _, discover := range discoverers {
+		go func(discover func(context.Context) (Interface, error)) {
+			nat, err := discover(ctx)
+			results <- result{nat, err}
+		}(discover)
+	}
+
+	var errs []string
+	for range discoverers {
+		res := <-results
+		if res.err == nil {
+			return res.nat, nil
+		}
+		errs = append(errs, res.err.Error())
+	}
+	return nil, fmt.Errorf("no NAT gateway found: %v", errs)
+}
+
+// potentialGateways guesses the addresses of the gateways on the local
+// networks the host is on, i.e. the first address of each private IPv4
+// network of its interfaces, as routers conventionally use it.
+func potentialGateways() ([]net.IP, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+
+	var gateways []net.IP
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		addrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, addr := range addrs {
+			ipNet, ok := addr.(*net.IPNet)
+			if !ok {
+				continue
+			}
+			ip := ipNet.IP.To4()
+			if ip == nil || !ip.IsPrivate() {
+				continue
+			}
+			gateway := ip.Mask(ipNet.Mask).To4()
+			if gateway == nil {
+				continue
+			}
+			gateway[3] |= 1
+			if !gateway.Equal(ip) {
+				gateways = append(gateways, gateway)
+			}
+		}
+	}
+	if len(gateways) == 0 {
+		return nil, errors.New("no private IPv4 networks")
+	}
+	return gateways, nil
+}
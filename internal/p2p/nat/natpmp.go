@@ -0,0 +1,164 @@
+package nat
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+const (
+	natPMPPort    = 5351
+	natPMPVersion = 0
+
+	natPMPOpExternalAddress = 0
+	natPMPOpMapUDP          = 1
+	natPMPOpMapTCP          = 2
+
+	// natPMPRetries is the number of times a request is sent, starting with a
+	// timeout of natPMPInitialTimeout and doubling it each time, as described
+	// in RFC 6886.
+	natPMPRetries        = 4
+	natPMPInitialTimeout = 250 * time.Millisecond
+)
+
+// natPMP is a gateway supporting NAT-PMP, as specified in RFC 6886.
+type natPMP struct {
+	gateway *net.UDPAddr
+}
+
+var _ Interface = (*natPMP)(nil)
+
+func newNATPMP(gateway net.IP) *natPMP {
+	return &natPMP{gateway: &net.UDPAddr{IP: gateway, Port: natPMPPort}}
+}
+
+// discoverNATPMP asks each potential gateway for its external address, and
+// returns the first one which answers.
+func discoverNATPMP(ctx context.Context) (Interface, error) {
+	gateways, err := potentialGateways()
+	if err != nil {
+		return nil, fmt.Errorf("NAT-PMP: %w", err)
+	}
+
+	found := make(chan *natPMP, len(gateways))
+	for _, gateway := range gateways {
+		go func(nat *natPMP) {
+			if _, err := nat.ExternalIP(ctx); err != nil {
+				nat = nil
+			}
+			found <- nat
+		}(newNATPMP(gateway))
+	}
+	for range gateways {
+		if nat := <-found; nat != nil {
+			return nat, nil
+		}
+	}
+	return nil, fmt.Errorf("NAT-PMP: no gateway answered at %v", gateways)
+}
+
+func (n *natPMP) String() string {
+	return "NAT-PMP(" + n.gateway.IP.String() + ")"
+}
+
+func (n *natPMP) ExternalIP(ctx context.Context) (net.IP, error) {
+	res, err := n.request(ctx, []byte{natPMPVersion, natPMPOpExternalAddress}, 12)
+	if err != nil {
+		return nil, err
+	}
+	return net.IPv4(res[8], res[9], res[10], res[11]), nil
+}
+
+func (n *natPMP) AddMapping(
+	ctx context.Context,
+	protocol string,
+	extPort, intPort int,
+	desc string,
+	lifetime time.Duration,
+) (int, error) {
+	op, err := natPMPMapOp(protocol)
+	if err != nil {
+		return 0, err
+	}
+
+	req := make([]byte, 12)
+	req[0] = natPMPVersion
+	req[1] = op
+	binary.BigEndian.PutUint16(req[4:], uint16(intPort))
+	binary.BigEndian.PutUint16(req[6:], uint16(extPort))
+	binary.BigEndian.PutUint32(req[8:], uint32(lifetime/time.Second))
+
+	res, err := n.request(ctx, req, 16)
+	if err != nil {
+		return 0, err
+	}
+	return int(binary.BigEndian.Uint16(res[10:])), nil
+}
+
+func (n *natPMP) DeleteMapping(ctx context.Context, protocol string, extPort, intPort int) error {
+	// a mapping is deleted by requesting it with a lifetime of 0.
+	_, err := n.AddMapping(ctx, protocol, 0, intPort, "", 0)
+	return err
+}
+
+// request sends a request to the gateway, retrying until a response of the
+// given size for the same operation is received, and checks its result code.
+func (n *natPMP) request(ctx context.Context, req []byte, size int) ([]byte, error) {
+	conn, err := net.DialUDP("udp4", nil, n.gateway)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	res := make([]byte, 16)
+	timeout := natPMPInitialTimeout
+	for i := 0; i < natPMPRetries; i++ {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		deadline := time.Now().Add(timeout)
+		if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+			deadline = d
+		}
+		if err := conn.SetDeadline(deadline); err != nil {
+			return nil, err
+		}
+		if _, err := conn.Write(req); err != nil {
+			return nil, err
+		}
+
+		for {
+			m, err := conn.Read(res)
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				break
+			} else if err != nil {
+				return nil, err
+			}
+			if m != size || res[0] != natPMPVersion || res[1] != req[1]|0x80 {
+				continue
+			}
+			if code := binary.BigEndian.Uint16(res[2:]); code != 0 {
+				return nil, fmt.Errorf("NAT-PMP gateway returned result code %d", code)
+			}
+			return res[:m], nil
+		}
+		timeout *= 2
+	}
+	return nil, errors.New("NAT-PMP gateway did not respond")
+}
+
+func natPMPMapOp(protocol string) (byte, error) {
+	switch protocol {
+	case "tcp":
+		return natPMPOpMapTCP, nil
+	case "udp":
+		return natPMPOpMapUDP, nil
+	default:
+		return 0, fmt.Errorf("unsupported protocol %q", protocol)
+	}
+}
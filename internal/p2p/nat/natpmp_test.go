@@ -0,0 +1,102 @@
+package nat
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNATPMP answers NAT-PMP requests on a local UDP port, mapping ports to
+// themselves plus one, and returns the last mapping request received.
+func fakeNATPMP(t *testing.T, resultCode uint16) (*natPMP, <-chan []byte) {
+	conn, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	mappings := make(chan []byte, 10)
+	go func() {
+		buf := make([]byte, 64)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			req := append([]byte{}, buf[:n]...)
+			var res []byte
+			switch req[1] {
+			case natPMPOpExternalAddress:
+				res = make([]byte, 12)
+				copy(res[8:], []byte{203, 0, 113, 7})
+			case natPMPOpMapTCP, natPMPOpMapUDP:
+				mappings <- req
+				res = make([]byte, 16)
+				copy(res[8:], req[4:6])
+				binary.BigEndian.PutUint16(res[10:], binary.BigEndian.Uint16(req[6:])+1)
+				copy(res[12:], req[8:12])
+			default:
+				continue
+			}
+			res[1] = req[1] | 0x80
+			binary.BigEndian.PutUint16(res[2:], resultCode)
+			_, _ = conn.WriteTo(res, addr)
+		}
+	}()
+
+	nat := newNATPMP(net.IPv4(127, 0, 0, 1))
+	nat.gateway.Port = conn.LocalAddr().(*net.UDPAddr).Port
+	return nat, mappings
+}
+
+func TestNATPMP(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	nat, mappings := fakeNATPMP(t, 0)
+
+	ip, err := nat.ExternalIP(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "203.0.113.7", ip.String())
+
+	port, err := nat.AddMapping(ctx, "tcp", 26656, 26657, "test", 2*time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 26657, port)
+	req := <-mappings
+	assert.EqualValues(t, natPMPOpMapTCP, req[1])
+	assert.EqualValues(t, 26657, binary.BigEndian.Uint16(req[4:]))
+	assert.EqualValues(t, 26656, binary.BigEndian.Uint16(req[6:]))
+	assert.EqualValues(t, 120, binary.BigEndian.Uint32(req[8:]))
+
+	require.NoError(t, nat.DeleteMapping(ctx, "tcp", 26657, 26657))
+	req = <-mappings
+	assert.EqualValues(t, 26657, binary.BigEndian.Uint16(req[4:]))
+	assert.EqualValues(t, 0, binary.BigEndian.Uint32(req[8:]))
+
+	_, err = nat.AddMapping(ctx, "sctp", 1, 1, "test", time.Minute)
+	require.Error(t, err)
+}
+
+func TestNATPMPErrors(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	nat, _ := fakeNATPMP(t, 2)
+	_, err := nat.ExternalIP(ctx)
+	require.Error(t, err)
+
+	// a gateway which doesn't answer times out
+	conn, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+	nat = newNATPMP(net.IPv4(127, 0, 0, 1))
+	nat.gateway.Port = conn.LocalAddr().(*net.UDPAddr).Port
+
+	ctx, cancel = context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	_, err = nat.ExternalIP(ctx)
+	require.Error(t, err)
+}
@@ -0,0 +1,244 @@
+package nat
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/service"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	// discoverTimeout bounds the search for a gateway on the local network.
+	discoverTimeout = 5 * time.Second
+
+	// mappingLifetime is the lifetime requested for the port mapping, which
+	// is renewed after half of it, and mappingRetry the delay before retrying
+	// a failed mapping.
+	mappingLifetime = 20 * time.Minute
+	mappingRetry    = time.Minute
+
+	// minObservers is the number of distinct peers which must observe the
+	// same IP address before it is used as the external address, so that a
+	// single peer can not redirect the node's inbound connections.
+	minObservers = 3
+
+	// maxObservations is the number of most recent peer observations kept.
+	maxObservations = 64
+
+	mappingDescription = "tendermint p2p"
+)
+
+// Options configures the NAT service.
+type Options struct {
+	// ListenPort is the local p2p port, which is mapped on the gateway.
+	ListenPort int
+
+	// MapPort enables mapping the listen port with UPnP or NAT-PMP, and using
+	// the external IP address of the gateway.
+	MapPort bool
+
+	// DetectAddress enables using the IP address that peers observe the
+	// node's connections from.
+	DetectAddress bool
+}
+
+type observation struct {
+	peerID types.NodeID
+	ip     net.IP
+}
+
+// Service maintains the external address of a node behind a NAT. It maps the
+// listen port on the gateway, and detects the external IP address from the
+// gateway and from the addresses peers observe, calling setListenAddr with
+// the resulting address whenever it changes.
+type Service struct {
+	*service.BaseService
+	logger log.Logger
+
+	opts          Options
+	setListenAddr func(string)
+	discover      func(context.Context) (Interface, error)
+
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mtx          sync.Mutex
+	gatewayIP    net.IP
+	mappedPort   int
+	observations []observation
+	listenAddr   string
+}
+
+// NewService creates a new NAT service.
+func NewService(logger log.Logger, opts Options, setListenAddr func(string)) *Service {
+	s := &Service{
+		logger:        logger,
+		opts:          opts,
+		setListenAddr: setListenAddr,
+		discover:      Discover,
+	}
+	s.BaseService = service.NewBaseService(logger, "NAT", s)
+	return s
+}
+
+// OnStart implements service.Service, and starts mapping the listen port if
+// enabled.
+func (s *Service) OnStart(ctx context.Context) error {
+	if s.opts.MapPort {
+		ctx, s.cancel = context.WithCancel(ctx)
+		s.done = make(chan struct{})
+		go s.mapPort(ctx)
+	}
+	return nil
+}
+
+// OnStop implements service.Service, and removes the port mapping.
+func (s *Service) OnStop() {
+	if s.cancel != nil {
+		s.cancel()
+		<-s.done
+	}
+}
+
+// ListenAddr returns the detected external address, if any.
+func (s *Service) ListenAddr() string {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.listenAddr
+}
+
+// Observe records the IP address that a peer reports it observed the node's
+// connection from.
+func (s *Service) Observe(peerID types.NodeID, ip net.IP) {
+	if !s.opts.DetectAddress || ip == nil || ip.IsLoopback() || ip.IsUnspecified() {
+		return
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	for i, o := range s.observations {
+		if o.peerID == peerID {
+			s.observations = append(s.observations[:i], s.observations[i+1:]...)
+			break
+		}
+	}
+	s.observations = append(s.observations, observation{peerID: peerID, ip: ip})
+	if len(s.observations) > maxObservations {
+		s.observations = s.observations[len(s.observations)-maxObservations:]
+	}
+	s.updateListenAddr()
+}
+
+// mapPort discovers the gateway, and maps the listen port on it until the
+// context is canceled, renewing the mapping before it expires.
+func (s *Service) mapPort(ctx context.Context) {
+	defer close(s.done)
+
+	discoverCtx, cancel := context.WithTimeout(ctx, discoverTimeout)
+	gateway, err := s.discover(discoverCtx)
+	cancel()
+	if err != nil {
+		s.logger.Info("not mapping the p2p port", "err", err)
+		return
+	}
+	s.logger.Info("found NAT gateway", "gateway", gateway)
+
+	extPort := s.opts.ListenPort
+	mapped := false
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if mapped {
+				deleteCtx, cancel := context.WithTimeout(context.Background(), discoverTimeout)
+				if err := gateway.DeleteMapping(deleteCtx, "tcp", extPort, s.opts.ListenPort); err != nil {
+					s.logger.Error("failed to delete port mapping", "gateway", gateway, "err", err)
+				}
+				cancel()
+			}
+			return
+		case <-timer.C:
+		}
+
+		port, err := gateway.AddMapping(ctx, "tcp", extPort, s.opts.ListenPort, mappingDescription, mappingLifetime)
+		if err != nil {
+			s.logger.Error("failed to map p2p port", "gateway", gateway, "port", extPort, "err", err)
+			timer.Reset(mappingRetry)
+			continue
+		}
+		if !mapped || port != extPort {
+			s.logger.Info("mapped p2p port", "gateway", gateway, "external_port", port, "port", s.opts.ListenPort)
+		}
+		extPort, mapped = port, true
+
+		ip, err := gateway.ExternalIP(ctx)
+		if err != nil {
+			s.logger.Error("failed to get external IP", "gateway", gateway, "err", err)
+		}
+		s.mtx.Lock()
+		s.gatewayIP, s.mappedPort = ip, port
+		s.updateListenAddr()
+		s.mtx.Unlock()
+
+		timer.Reset(mappingLifetime / 2)
+	}
+}
+
+// updateListenAddr derives the external address from the gateway and the
+// peer observations, and reports it if it changed. The gateway's IP address
+// is preferred unless it is private, e.g. behind a second NAT. It must be
+// called with the mutex held.
+func (s *Service) updateListenAddr() {
+	ip := s.gatewayIP
+	if ip == nil || ip.IsPrivate() {
+		if observed := s.observedIP(); observed != nil {
+			ip = observed
+		}
+	}
+	if ip == nil {
+		return
+	}
+
+	port := s.opts.ListenPort
+	if s.mappedPort != 0 {
+		port = s.mappedPort
+	}
+	addr := net.JoinHostPort(ip.String(), strconv.Itoa(port))
+	if addr == s.listenAddr {
+		return
+	}
+
+	s.logger.Info("detected external address", "addr", addr)
+	s.listenAddr = addr
+	s.setListenAddr(addr)
+}
+
+// observedIP returns the IP address observed by the most peers, if at least
+// minObservers observed it. Ties go to the most recently observed address.
+func (s *Service) observedIP() net.IP {
+	var (
+		counts = map[string]int{}
+		best   net.IP
+		max    int
+	)
+	for _, o := range s.observations {
+		counts[o.ip.String()]++
+	}
+	for i := len(s.observations) - 1; i >= 0; i-- {
+		ip := s.observations[i].ip
+		if count := counts[ip.String()]; count > max {
+			best, max = ip, count
+		}
+	}
+	if max < minObservers {
+		return nil
+	}
+	return best
+}
@@ -0,0 +1,149 @@
+package nat
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
+)
+
+type testGateway struct {
+	mtx      sync.Mutex
+	ip       net.IP
+	mappings map[int]int // external to internal port
+}
+
+func (g *testGateway) ExternalIP(context.Context) (net.IP, error) {
+	return g.ip, nil
+}
+
+func (g *testGateway) AddMapping(_ context.Context, _ string, extPort, intPort int, _ string, _ time.Duration) (int, error) {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	g.mappings[extPort+1] = intPort
+	return extPort + 1, nil
+}
+
+func (g *testGateway) DeleteMapping(_ context.Context, _ string, extPort, _ int) error {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	if _, ok := g.mappings[extPort]; !ok {
+		return errors.New("no such mapping")
+	}
+	delete(g.mappings, extPort)
+	return nil
+}
+
+func (g *testGateway) String() string { return "test" }
+
+func testNodeID() types.NodeID {
+	return types.NodeIDFromPubKey(ed25519.GenPrivKey().PubKey())
+}
+
+// listenAddrs collects the addresses reported by a service.
+type listenAddrs struct {
+	mtx   sync.Mutex
+	addrs []string
+}
+
+func (l *listenAddrs) set(addr string) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.addrs = append(l.addrs, addr)
+}
+
+func (l *listenAddrs) get() []string {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	return append([]string{}, l.addrs...)
+}
+
+func TestServiceObserve(t *testing.T) {
+	addrs := &listenAddrs{}
+	s := NewService(log.TestingLogger(), Options{ListenPort: 26656, DetectAddress: true}, addrs.set)
+
+	peers := make([]types.NodeID, minObservers+1)
+	for i := range peers {
+		peers[i] = testNodeID()
+	}
+	ipA, ipB := net.IPv4(203, 0, 113, 1), net.IPv4(203, 0, 113, 2)
+
+	// loopback and unspecified addresses are ignored, and a single peer
+	// observing an address repeatedly counts once.
+	s.Observe(peers[0], net.IPv4(127, 0, 0, 1))
+	s.Observe(peers[0], net.IPv4zero)
+	for i := 0; i < minObservers; i++ {
+		s.Observe(peers[0], ipA)
+	}
+	assert.Empty(t, s.ListenAddr())
+
+	for i := 1; i < minObservers; i++ {
+		s.Observe(peers[i], ipA)
+	}
+	assert.Equal(t, "203.0.113.1:26656", s.ListenAddr())
+
+	// the address changes once the peers observe another one
+	for _, peer := range peers {
+		s.Observe(peer, ipB)
+	}
+	assert.Equal(t, "203.0.113.2:26656", s.ListenAddr())
+	assert.Equal(t, []string{"203.0.113.1:26656", "203.0.113.2:26656"}, addrs.get())
+
+	// observations are ignored unless enabled
+	s = NewService(log.TestingLogger(), Options{ListenPort: 26656}, addrs.set)
+	for _, peer := range peers {
+		s.Observe(peer, ipA)
+	}
+	assert.Empty(t, s.ListenAddr())
+}
+
+func TestServiceMapPort(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	gateway := &testGateway{ip: net.IPv4(198, 51, 100, 4), mappings: map[int]int{}}
+	addrs := &listenAddrs{}
+	s := NewService(log.TestingLogger(), Options{ListenPort: 26656, MapPort: true}, addrs.set)
+	s.discover = func(context.Context) (Interface, error) { return gateway, nil }
+
+	require.NoError(t, s.Start(ctx))
+	require.Eventually(t, func() bool { return s.ListenAddr() != "" }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, "198.51.100.4:26657", s.ListenAddr())
+	gateway.mtx.Lock()
+	assert.Equal(t, map[int]int{26657: 26656}, gateway.mappings)
+	gateway.mtx.Unlock()
+
+	// the mapping is removed when stopping
+	require.NoError(t, s.Stop())
+	assert.Empty(t, gateway.mappings)
+
+	// a private gateway address, e.g. behind a second NAT, is superseded by
+	// the address peers observe
+	gateway = &testGateway{ip: net.IPv4(10, 0, 0, 1), mappings: map[int]int{}}
+	s = NewService(log.TestingLogger(), Options{ListenPort: 26656, MapPort: true, DetectAddress: true}, addrs.set)
+	s.discover = func(context.Context) (Interface, error) { return gateway, nil }
+	require.NoError(t, s.Start(ctx))
+	require.Eventually(t, func() bool { return s.ListenAddr() != "" }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, "10.0.0.1:26657", s.ListenAddr())
+	for i := 0; i < minObservers; i++ {
+		s.Observe(testNodeID(), net.IPv4(203, 0, 113, 1))
+	}
+	assert.Equal(t, "203.0.113.1:26657", s.ListenAddr())
+	require.NoError(t, s.Stop())
+
+	// without a gateway, nothing is mapped
+	s = NewService(log.TestingLogger(), Options{ListenPort: 26656, MapPort: true}, addrs.set)
+	s.discover = func(context.Context) (Interface, error) { return nil, errors.New("no gateway") }
+	require.NoError(t, s.Start(ctx))
+	require.NoError(t, s.Stop())
+	assert.Empty(t, s.ListenAddr())
+}
@@ -0,0 +1,289 @@
+package nat
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ssdpAddress = "239.255.255.250:1900"
+	ssdpSearch  = "M-SEARCH * HTTP/1.1\r\n" +
+		"HOST: 239.255.255.250:1900\r\n" +
+		"ST: urn:schemas-upnp-org:device:InternetGatewayDevice:1\r\n" +
+		"MAN: \"ssdp:discover\"\r\n" +
+		"MX: 2\r\n\r\n"
+
+	// upnpMaxResponseSize bounds the size of device descriptions and SOAP
+	// responses read from the gateway.
+	upnpMaxResponseSize = 1 << 20
+)
+
+// upnpServiceTypes are the UPnP services that can map ports, in order of
+// preference.
+var upnpServiceTypes = []string{
+	"urn:schemas-upnp-org:service:WANIPConnection:2",
+	"urn:schemas-upnp-org:service:WANIPConnection:1",
+	"urn:schemas-upnp-org:service:WANPPPConnection:1",
+}
+
+// upnp is a gateway supporting the UPnP Internet Gateway Device protocol.
+type upnp struct {
+	serviceType string
+	controlURL  string
+	localIP     net.IP
+	client      *http.Client
+}
+
+var _ Interface = (*upnp)(nil)
+
+// discoverUPnP searches for an Internet Gateway Device with SSDP, and returns
+// the first one responding with a description of a port mapping service.
+func discoverUPnP(ctx context.Context) (Interface, error) {
+	conn, err := net.ListenPacket("udp4", ":0")
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	ssdp, err := net.ResolveUDPAddr("udp4", ssdpAddress)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := conn.WriteTo([]byte(ssdpSearch), ssdp); err != nil {
+		return nil, fmt.Errorf("UPnP: %w", err)
+	}
+	go func() {
+		<-ctx.Done()
+		_ = conn.SetReadDeadline(time.Now())
+	}()
+
+	seen := map[string]bool{}
+	buf := make([]byte, 2048)
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, errors.New("UPnP: no gateway found")
+			}
+			return nil, fmt.Errorf("UPnP: %w", err)
+		}
+		res, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(buf[:n])), nil)
+		if err != nil {
+			continue
+		}
+		location := res.Header.Get("Location")
+		if location == "" || seen[location] {
+			continue
+		}
+		seen[location] = true
+		if nat, err := newUPnP(ctx, location); err == nil {
+			return nat, nil
+		}
+	}
+}
+
+// upnpDevice is the part of a UPnP device description that is needed to find
+// port mapping services.
+type upnpDevice struct {
+	Services []struct {
+		ServiceType string `xml:"serviceType"`
+		ControlURL  string `xml:"controlURL"`
+	} `xml:"serviceList>service"`
+	Devices []upnpDevice `xml:"deviceList>device"`
+}
+
+// findService returns the control URL of the first port mapping service of
+// the given type on the device or its embedded devices.
+func (d upnpDevice) findService(serviceType string) (string, bool) {
+	for _, service := range d.Services {
+		if service.ServiceType == serviceType {
+			return service.ControlURL, true
+		}
+	}
+	for _, device := range d.Devices {
+		if controlURL, ok := device.findService(serviceType); ok {
+			return controlURL, true
+		}
+	}
+	return "", false
+}
+
+// newUPnP fetches the device description at the given location, and returns
+// a gateway for its preferred port mapping service.
+func newUPnP(ctx context.Context, location string) (*upnp, error) {
+	locationURL, err := url.Parse(location)
+	if err != nil {
+		return nil, err
+	}
+	client := &http.Client{}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("UPnP device description: %s", res.Status)
+	}
+
+	var root struct {
+		URLBase string     `xml:"URLBase"`
+		Device  upnpDevice `xml:"device"`
+	}
+	if err := xml.NewDecoder(io.LimitReader(res.Body, upnpMaxResponseSize)).Decode(&root); err != nil {
+		return nil, fmt.Errorf("UPnP device description: %w", err)
+	}
+	baseURL := locationURL
+	if root.URLBase != "" {
+		if baseURL, err = url.Parse(root.URLBase); err != nil {
+			return nil, err
+		}
+	}
+
+	// the gateway maps ports to the address the host reaches it from.
+	localIP, err := localIPTo(locationURL.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, serviceType := range upnpServiceTypes {
+		controlURL, ok := root.Device.findService(serviceType)
+		if !ok {
+			continue
+		}
+		ref, err := url.Parse(controlURL)
+		if err != nil {
+			return nil, err
+		}
+		return &upnp{
+			serviceType: serviceType,
+			controlURL:  baseURL.ResolveReference(ref).String(),
+			localIP:     localIP,
+			client:      client,
+		}, nil
+	}
+	return nil, fmt.Errorf("UPnP device at %s has no port mapping service", location)
+}
+
+func (u *upnp) String() string {
+	return "UPnP(" + u.controlURL + ")"
+}
+
+func (u *upnp) ExternalIP(ctx context.Context) (net.IP, error) {
+	var res struct {
+		IP string `xml:"Body>GetExternalIPAddressResponse>NewExternalIPAddress"`
+	}
+	if err := u.call(ctx, "GetExternalIPAddress", nil, &res); err != nil {
+		return nil, err
+	}
+	ip := net.ParseIP(strings.TrimSpace(res.IP))
+	if ip == nil {
+		return nil, fmt.Errorf("UPnP gateway returned invalid external IP %q", res.IP)
+	}
+	return ip, nil
+}
+
+func (u *upnp) AddMapping(
+	ctx context.Context,
+	protocol string,
+	extPort, intPort int,
+	desc string,
+	lifetime time.Duration,
+) (int, error) {
+	err := u.call(ctx, "AddPortMapping", []upnpArg{
+		{"NewRemoteHost", ""},
+		{"NewExternalPort", strconv.Itoa(extPort)},
+		{"NewProtocol", strings.ToUpper(protocol)},
+		{"NewInternalPort", strconv.Itoa(intPort)},
+		{"NewInternalClient", u.localIP.String()},
+		{"NewEnabled", "1"},
+		{"NewPortMappingDescription", desc},
+		{"NewLeaseDuration", strconv.Itoa(int(lifetime / time.Second))},
+	}, nil)
+	if err != nil {
+		return 0, err
+	}
+	return extPort, nil
+}
+
+func (u *upnp) DeleteMapping(ctx context.Context, protocol string, extPort, intPort int) error {
+	return u.call(ctx, "DeletePortMapping", []upnpArg{
+		{"NewRemoteHost", ""},
+		{"NewExternalPort", strconv.Itoa(extPort)},
+		{"NewProtocol", strings.ToUpper(protocol)},
+	}, nil)
+}
+
+type upnpArg struct {
+	name, value string
+}
+
+// call invokes a SOAP action of the port mapping service, and decodes the
+// response envelope into res, if given.
+func (u *upnp) call(ctx context.Context, action string, args []upnpArg, res interface{}) error {
+	var body bytes.Buffer
+	body.WriteString(`<?xml version="1.0"?>` +
+		`<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" ` +
+		`s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/"><s:Body>`)
+	fmt.Fprintf(&body, `<u:%s xmlns:u="%s">`, action, u.serviceType)
+	for _, arg := range args {
+		fmt.Fprintf(&body, "<%s>", arg.name)
+		if err := xml.EscapeText(&body, []byte(arg.value)); err != nil {
+			return err
+		}
+		fmt.Fprintf(&body, "</%s>", arg.name)
+	}
+	fmt.Fprintf(&body, `</u:%s></s:Body></s:Envelope>`, action)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.controlURL, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", `text/xml; charset="utf-8"`)
+	req.Header.Set("SOAPAction", fmt.Sprintf(`"%s#%s"`, u.serviceType, action))
+
+	httpRes, err := u.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer httpRes.Body.Close()
+	if httpRes.StatusCode != http.StatusOK {
+		return fmt.Errorf("UPnP %s: %s", action, httpRes.Status)
+	}
+	if res == nil {
+		return nil
+	}
+	if err := xml.NewDecoder(io.LimitReader(httpRes.Body, upnpMaxResponseSize)).Decode(res); err != nil {
+		return fmt.Errorf("UPnP %s: %w", action, err)
+	}
+	return nil
+}
+
+// localIPTo returns the local IP address the host uses to reach the given
+// host:port.
+func localIPTo(hostPort string) (net.IP, error) {
+	if _, _, err := net.SplitHostPort(hostPort); err != nil {
+		hostPort = net.JoinHostPort(hostPort, "80")
+	}
+	conn, err := net.Dial("udp", hostPort)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	return conn.LocalAddr().(*net.UDPAddr).IP, nil
+}
@@ -0,0 +1,134 @@
+package nat
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDeviceDescription = `<?xml version="1.0"?>
+<root xmlns="urn:schemas-upnp-org:device-1-0">
+  <device>
+    <deviceType>urn:schemas-upnp-org:device:InternetGatewayDevice:1</deviceType>
+    <deviceList>
+      <device>
+        <deviceType>urn:schemas-upnp-org:device:WANDevice:1</deviceType>
+        <deviceList>
+          <device>
+            <deviceType>urn:schemas-upnp-org:device:WANConnectionDevice:1</deviceType>
+            <serviceList>
+              <service>
+                <serviceType>urn:schemas-upnp-org:service:WANPPPConnection:1</serviceType>
+                <controlURL>/ppp</controlURL>
+              </service>
+              <service>
+                <serviceType>urn:schemas-upnp-org:service:WANIPConnection:1</serviceType>
+                <controlURL>/ctl/IPConn</controlURL>
+              </service>
+            </serviceList>
+          </device>
+        </deviceList>
+      </device>
+    </deviceList>
+  </device>
+</root>`
+
+type soapRequest struct {
+	action string
+	args   map[string]string
+}
+
+// fakeUPnP serves a device description and answers SOAP requests, returning
+// the requests received.
+func fakeUPnP(t *testing.T) (*httptest.Server, <-chan soapRequest) {
+	requests := make(chan soapRequest, 10)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/desc.xml", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, testDeviceDescription)
+	})
+	mux.HandleFunc("/ctl/IPConn", func(w http.ResponseWriter, r *http.Request) {
+		var env struct {
+			Body struct {
+				Action struct {
+					XMLName xml.Name
+					Args    []struct {
+						XMLName xml.Name
+						Value   string `xml:",chardata"`
+					} `xml:",any"`
+				} `xml:",any"`
+			}
+		}
+		if err := xml.NewDecoder(r.Body).Decode(&env); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		req := soapRequest{action: env.Body.Action.XMLName.Local, args: map[string]string{}}
+		for _, arg := range env.Body.Action.Args {
+			req.args[arg.XMLName.Local] = arg.Value
+		}
+		requests <- req
+
+		if r.Header.Get("SOAPAction") != fmt.Sprintf(`"urn:schemas-upnp-org:service:WANIPConnection:1#%s"`, req.action) {
+			http.Error(w, "bad SOAPAction", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(w, `<?xml version="1.0"?>
+<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/"><s:Body>
+<u:%[1]sResponse xmlns:u="urn:schemas-upnp-org:service:WANIPConnection:1">
+<NewExternalIPAddress>198.51.100.4</NewExternalIPAddress>
+</u:%[1]sResponse></s:Body></s:Envelope>`, req.action)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server, requests
+}
+
+func TestUPnP(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	server, requests := fakeUPnP(t)
+	nat, err := newUPnP(ctx, server.URL+"/desc.xml")
+	require.NoError(t, err)
+	assert.Equal(t, "urn:schemas-upnp-org:service:WANIPConnection:1", nat.serviceType)
+	assert.Equal(t, server.URL+"/ctl/IPConn", nat.controlURL)
+	assert.Equal(t, "127.0.0.1", nat.localIP.String())
+
+	ip, err := nat.ExternalIP(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "198.51.100.4", ip.String())
+	assert.Equal(t, "GetExternalIPAddress", (<-requests).action)
+
+	port, err := nat.AddMapping(ctx, "tcp", 26656, 26657, "test <p2p>", time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 26656, port)
+	assert.Equal(t, soapRequest{action: "AddPortMapping", args: map[string]string{
+		"NewRemoteHost":             "",
+		"NewExternalPort":           "26656",
+		"NewProtocol":               "TCP",
+		"NewInternalPort":           "26657",
+		"NewInternalClient":         "127.0.0.1",
+		"NewEnabled":                "1",
+		"NewPortMappingDescription": "test <p2p>",
+		"NewLeaseDuration":          "3600",
+	}}, <-requests)
+
+	require.NoError(t, nat.DeleteMapping(ctx, "tcp", 26656, 26657))
+	assert.Equal(t, soapRequest{action: "DeletePortMapping", args: map[string]string{
+		"NewRemoteHost":   "",
+		"NewExternalPort": "26656",
+		"NewProtocol":     "TCP",
+	}}, <-requests)
+
+	// devices without a port mapping service are rejected
+	_, err = newUPnP(ctx, server.URL+"/missing.xml")
+	require.Error(t, err)
+}
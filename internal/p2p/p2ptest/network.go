@@ -27,6 +27,10 @@ type Network struct {
 	cancel        context.CancelFunc
 }
 
+// nodeCapabilities are the capabilities the nodes of the network advertise.
+const nodeCapabilities = types.CapabilityCompactBlocks | types.CapabilityTxAnnouncements |
+	types.CapabilityVoteBatches
+
 // NetworkOptions is an argument structure to parameterize the
 // MakeNetwork function.
 type NetworkOptions struct {
@@ -38,6 +42,9 @@ type NetworkOptions struct {
 type NodeOptions struct {
 	MaxPeers     uint16
 	MaxConnected uint16
+
+	// DefaultGossipPolicy is the gossip policy of the node's peers.
+	DefaultGossipPolicy p2p.GossipPolicy
 }
 
 func (opts *NetworkOptions) setDefaults() {
@@ -102,8 +109,9 @@ func (n *Network) Start(ctx context.Context, t *testing.T) {
 				require.Fail(t, "operation canceled")
 			case peerUpdate := <-sourceSub.Updates():
 				require.Equal(t, p2p.PeerUpdate{
-					NodeID: targetNode.NodeID,
-					Status: p2p.PeerStatusUp,
+					NodeID:       targetNode.NodeID,
+					Status:       p2p.PeerStatusUp,
+					Capabilities: nodeCapabilities,
 				}, peerUpdate)
 			case <-time.After(3 * time.Second):
 				require.Fail(t, "timed out waiting for peer", "%v dialing %v",
@@ -115,8 +123,9 @@ func (n *Network) Start(ctx context.Context, t *testing.T) {
 				require.Fail(t, "operation canceled")
 			case peerUpdate := <-targetSub.Updates():
 				require.Equal(t, p2p.PeerUpdate{
-					NodeID: sourceNode.NodeID,
-					Status: p2p.PeerStatusUp,
+					NodeID:       sourceNode.NodeID,
+					Status:       p2p.PeerStatusUp,
+					Capabilities: nodeCapabilities,
 				}, peerUpdate)
 			case <-time.After(3 * time.Second):
 				require.Fail(t, "timed out waiting for peer", "%v accepting %v",
@@ -241,9 +250,10 @@ func (n *Network) MakeNode(ctx context.Context, t *testing.T, opts NodeOptions)
 	privKey := ed25519.GenPrivKey()
 	nodeID := types.NodeIDFromPubKey(privKey.PubKey())
 	nodeInfo := types.NodeInfo{
-		NodeID:     nodeID,
-		ListenAddr: "0.0.0.0:0", // FIXME: We have to fake this for now.
-		Moniker:    string(nodeID),
+		NodeID:       nodeID,
+		ListenAddr:   "0.0.0.0:0", // FIXME: We have to fake this for now.
+		Moniker:      string(nodeID),
+		Capabilities: nodeCapabilities,
 	}
 
 	transport := n.memoryNetwork.CreateTransport(nodeID)
@@ -255,6 +265,8 @@ func (n *Network) MakeNode(ctx context.Context, t *testing.T, opts NodeOptions)
 		RetryTimeJitter: time.Millisecond,
 		MaxPeers:        opts.MaxPeers,
 		MaxConnected:    opts.MaxConnected,
+
+		DefaultGossipPolicy: opts.DefaultGossipPolicy,
 	})
 	require.NoError(t, err)
 
@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"net"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -42,13 +44,22 @@ const (
 type PeerScore uint8
 
 const (
-	PeerScorePersistent PeerScore = math.MaxUint8 // persistent peers
+	PeerScorePersistent PeerScore = math.MaxUint8           // persistent peers
+	PeerScoreValidator  PeerScore = PeerScorePersistent - 1 // peers operated by active validators
 )
 
 // PeerUpdate is a peer update event sent via PeerUpdates.
 type PeerUpdate struct {
 	NodeID types.NodeID
 	Status PeerStatus
+
+	// ValidatorAddress is the address of the validator operating the peer, as
+	// proven during the handshake. It is only set for PeerStatusUp updates.
+	ValidatorAddress types.Address
+
+	// Capabilities are the optional protocol extensions the peer advertised
+	// during the handshake. It is only set for PeerStatusUp updates.
+	Capabilities types.NodeCapabilities
 }
 
 // PeerUpdates is a peer update subscription with notifications about peer
@@ -112,6 +123,35 @@ type PeerManagerOptions struct {
 	// the connection and evict a lower-scored peer.
 	MaxConnectedUpgrade uint16
 
+	// MaxConnectedInbound is the maximum number of connected inbound peers,
+	// i.e. peers that dialed us. When lower than MaxConnected, the remaining
+	// connection slots are kept for outbound connections, so that peers
+	// dialing us can't take all of them and eclipse the node. Validators and
+	// unconditional peers are accepted beyond it. 0 means no limit other than
+	// MaxConnected.
+	MaxConnectedInbound uint16
+
+	// MaxConnectedOutbound is the maximum number of connected outbound peers,
+	// i.e. peers we dialed, including peers being dialed. Unconditional peers
+	// are dialed beyond it. 0 means no limit other than MaxConnected.
+	MaxConnectedOutbound uint16
+
+	// MaxConnectedPerIP is the maximum number of connected peers with the
+	// same IP address, so that a single host can't take the connection slots
+	// by running many nodes. Validators, unconditional peers and peers in
+	// ConnectionLimitAllowlist are connected beyond it. 0 means no limit.
+	MaxConnectedPerIP uint16
+
+	// MaxConnectedPerSubnet is the maximum number of connected peers in the
+	// same /24 IPv4 subnet, or /64 IPv6 subnet, with the same exceptions as
+	// MaxConnectedPerIP. 0 means no limit.
+	MaxConnectedPerSubnet uint16
+
+	// ConnectionLimitAllowlist are the networks of the peers exempt from
+	// MaxConnectedPerIP and MaxConnectedPerSubnet, e.g. the private network
+	// of a validator and its sentries.
+	ConnectionLimitAllowlist []*net.IPNet
+
 	// MinRetryTime is the minimum time to wait between retries. Retry times
 	// double for each retry, up to MaxRetryTime. 0 disables retries.
 	MinRetryTime time.Duration
@@ -128,6 +168,17 @@ type PeerManagerOptions struct {
 	// retry times, to avoid thundering herds. 0 disables jitter.
 	RetryTimeJitter time.Duration
 
+	// PersistentPeerResolveInterval is the interval at which the hostnames in
+	// the addresses of persistent peers are resolved again. When a hostname
+	// resolves to different IP addresses than before, the dial failures of
+	// the address are reset so that it is dialed right away rather than after
+	// its retry time. 0 disables it.
+	PersistentPeerResolveInterval time.Duration
+
+	// Resolver resolves addresses for PersistentPeerResolveInterval. It is
+	// mainly used for testing, and defaults to NodeAddress.Resolve.
+	Resolver func(context.Context, NodeAddress) ([]Endpoint, error)
+
 	// PeerScores sets fixed scores for specific peers. It is mainly used
 	// for testing. A score of 0 is ignored.
 	PeerScores map[types.NodeID]PeerScore
@@ -136,6 +187,19 @@ type PeerManagerOptions struct {
 	// consider private and never gossip.
 	PrivatePeers map[types.NodeID]struct{}
 
+	// UnconditionalPeers are peers that are always accepted and dialed, even
+	// when MaxConnected is reached. They don't count towards MaxConnected,
+	// and are never evicted to make room for other peers.
+	UnconditionalPeers map[types.NodeID]struct{}
+
+	// GossipPolicies sets the gossip policies of specific peers. Peers that
+	// are not listed use DefaultGossipPolicy.
+	GossipPolicies map[types.NodeID]GossipPolicy
+
+	// DefaultGossipPolicy is the gossip policy of peers not listed in
+	// GossipPolicies. The zero value allows all messages.
+	DefaultGossipPolicy GossipPolicy
+
 	// persistentPeers provides fast PersistentPeers lookups. It is built
 	// by optimize().
 	persistentPeers map[types.NodeID]bool
@@ -155,11 +219,44 @@ func (o *PeerManagerOptions) Validate() error {
 		}
 	}
 
+	for id := range o.UnconditionalPeers {
+		if err := id.Validate(); err != nil {
+			return fmt.Errorf("invalid unconditional peer ID %q: %w", id, err)
+		}
+	}
+
+	for id := range o.GossipPolicies {
+		if err := id.Validate(); err != nil {
+			return fmt.Errorf("invalid gossip policy peer ID %q: %w", id, err)
+		}
+	}
+
 	if o.MaxConnected > 0 && len(o.PersistentPeers) > int(o.MaxConnected) {
 		return fmt.Errorf("number of persistent peers %v can't exceed MaxConnected %v",
 			len(o.PersistentPeers), o.MaxConnected)
 	}
 
+	if o.MaxConnected > 0 && o.MaxConnectedInbound > o.MaxConnected {
+		return fmt.Errorf("MaxConnectedInbound %v can't exceed MaxConnected %v",
+			o.MaxConnectedInbound, o.MaxConnected)
+	}
+
+	if o.MaxConnected > 0 && o.MaxConnectedOutbound > o.MaxConnected {
+		return fmt.Errorf("MaxConnectedOutbound %v can't exceed MaxConnected %v",
+			o.MaxConnectedOutbound, o.MaxConnected)
+	}
+
+	if o.MaxConnectedOutbound > 0 && len(o.PersistentPeers) > int(o.MaxConnectedOutbound) {
+		return fmt.Errorf("number of persistent peers %v can't exceed MaxConnectedOutbound %v",
+			len(o.PersistentPeers), o.MaxConnectedOutbound)
+	}
+
+	if o.MaxConnectedPerIP > 0 && o.MaxConnectedPerSubnet > 0 &&
+		o.MaxConnectedPerIP > o.MaxConnectedPerSubnet {
+		return fmt.Errorf("MaxConnectedPerIP %v can't exceed MaxConnectedPerSubnet %v",
+			o.MaxConnectedPerIP, o.MaxConnectedPerSubnet)
+	}
+
 	if o.MaxPeers > 0 {
 		if o.MaxConnected == 0 || o.MaxConnected+o.MaxConnectedUpgrade > o.MaxPeers {
 			return fmt.Errorf("MaxConnected %v and MaxConnectedUpgrade %v can't exceed MaxPeers %v",
@@ -199,6 +296,17 @@ func (o *PeerManagerOptions) isPersistent(id types.NodeID) bool {
 	return o.persistentPeers[id]
 }
 
+// isConnectionLimitExempt checks if an IP address is in
+// ConnectionLimitAllowlist.
+func (o *PeerManagerOptions) isConnectionLimitExempt(ip net.IP) bool {
+	for _, network := range o.ConnectionLimitAllowlist {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
 // optimize optimizes operations by pregenerating lookup structures. It's a
 // separate method instead of memoizing during calls to avoid dealing with
 // concurrency and mutex overhead.
@@ -252,6 +360,14 @@ func (o *PeerManagerOptions) optimize() {
 //   lower-scored to evict.
 // - EvictNext: pick peer from evict, mark as evicting.
 // - Disconnected: unmark connected, upgrading[from]=to, evict, evicting.
+//
+// Inbound and outbound connections can further be limited separately, by
+// MaxConnectedInbound and MaxConnectedOutbound, in which case Accepted and
+// DialNext check them before the checks above. The number of connected peers
+// per IP address and per subnet can be limited too, by MaxConnectedPerIP and
+// MaxConnectedPerSubnet, in which case the router reports the IP address of
+// each connection with SetRemoteIP before Accepted or Dialed, which check
+// them.
 type PeerManager struct {
 	selfID     types.NodeID
 	options    PeerManagerOptions
@@ -265,9 +381,13 @@ type PeerManager struct {
 	dialing       map[types.NodeID]bool         // peers being dialed (DialNext → Dialed/DialFail)
 	upgrading     map[types.NodeID]types.NodeID // peers claimed for upgrade (DialNext → Dialed/DialFail)
 	connected     map[types.NodeID]bool         // connected peers (Dialed/Accepted → Disconnected)
+	inbound       map[types.NodeID]bool         // peers connected inbound (Accepted → Disconnected)
+	remoteIPs     map[types.NodeID]net.IP       // IPs of connected peers (SetRemoteIP → Disconnected)
 	ready         map[types.NodeID]bool         // ready peers (Ready → Disconnected)
 	evict         map[types.NodeID]bool         // peers scheduled for eviction (Connected → EvictNext)
 	evicting      map[types.NodeID]bool         // peers being evicted (EvictNext → Disconnected)
+	validators    map[string]bool               // addresses of the active validators (SetValidators)
+	resolved      map[NodeAddress]string        // IPs persistent peer hostnames last resolved to
 }
 
 // NewPeerManager creates a new peer manager.
@@ -297,9 +417,13 @@ func NewPeerManager(selfID types.NodeID, peerDB dbm.DB, options PeerManagerOptio
 		dialing:       map[types.NodeID]bool{},
 		upgrading:     map[types.NodeID]types.NodeID{},
 		connected:     map[types.NodeID]bool{},
+		inbound:       map[types.NodeID]bool{},
+		remoteIPs:     map[types.NodeID]net.IP{},
 		ready:         map[types.NodeID]bool{},
 		evict:         map[types.NodeID]bool{},
 		evicting:      map[types.NodeID]bool{},
+		validators:    map[string]bool{},
+		resolved:      map[NodeAddress]string{},
 		subscriptions: map[*PeerUpdates]*PeerUpdates{},
 	}
 	if err = peerManager.configurePeers(); err != nil {
@@ -339,7 +463,9 @@ func (m *PeerManager) configurePeers() error {
 // configurePeer configures a peer with ephemeral runtime configuration.
 func (m *PeerManager) configurePeer(peer peerInfo) peerInfo {
 	peer.Persistent = m.options.isPersistent(peer.ID)
+	_, peer.Unconditional = m.options.UnconditionalPeers[peer.ID]
 	peer.FixedScore = m.options.PeerScores[peer.ID]
+	peer.Validator = len(peer.ValidatorAddress) > 0 && m.validators[string(peer.ValidatorAddress)]
 	return peer
 }
 
@@ -380,6 +506,15 @@ func (m *PeerManager) prunePeers() error {
 // exists, the address is added to it if it isn't already present. This will push
 // low scoring peers out of the address book if it exceeds the maximum size.
 func (m *PeerManager) Add(address NodeAddress) (bool, error) {
+	return m.AddFrom(address, "")
+}
+
+// AddFrom is like Add, but records where the address was learned from: either
+// the ID of the peer that advertised it, or one of the AddressSource
+// constants. The source of an address already in the peer store is kept. A
+// peer learned from another one is assumed to be on the same network until it
+// presents its own in a handshake.
+func (m *PeerManager) AddFrom(address NodeAddress, source string) (bool, error) {
 	if err := address.Validate(); err != nil {
 		return false, err
 	}
@@ -401,7 +536,12 @@ func (m *PeerManager) Add(address NodeAddress) (bool, error) {
 	}
 
 	// else add the new address
-	peer.AddressInfo[address] = &peerAddressInfo{Address: address}
+	peer.AddressInfo[address] = &peerAddressInfo{Address: address, Source: source}
+	if peer.Network == "" {
+		if sourcePeer, ok := m.store.Get(types.NodeID(source)); ok {
+			peer.Network = sourcePeer.Network
+		}
+	}
 	if err := m.store.Set(peer); err != nil {
 		return false, err
 	}
@@ -451,13 +591,16 @@ func (m *PeerManager) TryDialNext() (NodeAddress, error) {
 	// We allow dialing MaxConnected+MaxConnectedUpgrade peers. Including
 	// MaxConnectedUpgrade allows us to probe additional peers that have a
 	// higher score than any other peers, and if successful evict it.
-	if m.options.MaxConnected > 0 && len(m.connected)+len(m.dialing) >=
-		int(m.options.MaxConnected)+int(m.options.MaxConnectedUpgrade) {
-		return NodeAddress{}, nil
+	// Unconditional peers are dialed regardless.
+	full := m.options.MaxConnected > 0 && m.numLimited(m.connected)+m.numLimited(m.dialing) >=
+		int(m.options.MaxConnected)+int(m.options.MaxConnectedUpgrade)
+	if m.options.MaxConnectedOutbound > 0 && m.numOutbound()+m.numLimited(m.dialing) >=
+		int(m.options.MaxConnectedOutbound) {
+		full = true
 	}
 
 	for _, peer := range m.store.Ranked() {
-		if m.dialing[peer.ID] || m.connected[peer.ID] {
+		if m.dialing[peer.ID] || m.connected[peer.ID] || (full && !peer.Unconditional) {
 			continue
 		}
 
@@ -473,7 +616,8 @@ func (m *PeerManager) TryDialNext() (NodeAddress, error) {
 			// If we don't find one, there is no point in trying additional
 			// peers, since they will all have the same or lower score than this
 			// peer (since they're ordered by score via peerStore.Ranked).
-			if m.options.MaxConnected > 0 && len(m.connected) >= int(m.options.MaxConnected) {
+			if m.options.MaxConnected > 0 && !peer.Unconditional &&
+				m.numLimited(m.connected) >= int(m.options.MaxConnected) {
 				upgradeFromPeer := m.findUpgradeCandidate(peer.ID, peer.Score())
 				if upgradeFromPeer == "" {
 					return NodeAddress{}, nil
@@ -541,6 +685,84 @@ func (m *PeerManager) DialFailed(ctx context.Context, address NodeAddress) error
 	return nil
 }
 
+// ResolvePersistentPeers resolves the hostnames in the addresses of persistent
+// peers. If a hostname resolves to different IP addresses than the last time,
+// the dial failures of the address are reset, so that it is dialed right away
+// instead of after its retry time, which can be long after the peer moved.
+func (m *PeerManager) ResolvePersistentPeers(ctx context.Context) error {
+	resolve := m.options.Resolver
+	if resolve == nil {
+		resolve = func(ctx context.Context, address NodeAddress) ([]Endpoint, error) {
+			return address.Resolve(ctx)
+		}
+	}
+
+	m.mtx.Lock()
+	addresses := []NodeAddress{}
+	for _, id := range m.options.PersistentPeers {
+		peer, ok := m.store.Get(id)
+		if !ok {
+			continue
+		}
+		for address := range peer.AddressInfo {
+			if address.Hostname != "" && net.ParseIP(address.Hostname) == nil &&
+				!isOnionHostname(address.Hostname) {
+				addresses = append(addresses, address)
+			}
+		}
+	}
+	m.mtx.Unlock()
+
+	changed := false
+	for _, address := range addresses {
+		// Resolution failures are left for dials to report.
+		endpoints, err := resolve(ctx, address)
+		if err != nil || len(endpoints) == 0 {
+			continue
+		}
+		ips := make([]string, 0, len(endpoints))
+		for _, endpoint := range endpoints {
+			ips = append(ips, endpoint.IP.String())
+		}
+		sort.Strings(ips)
+		resolved := strings.Join(ips, ",")
+
+		m.mtx.Lock()
+		previous, ok := m.resolved[address]
+		m.resolved[address] = resolved
+		if ok && previous != resolved {
+			reset, err := m.resetDialFailures(address)
+			if err != nil {
+				m.mtx.Unlock()
+				return err
+			}
+			changed = changed || reset
+		}
+		m.mtx.Unlock()
+	}
+
+	if changed {
+		m.dialWaker.Wake()
+	}
+	return nil
+}
+
+// resetDialFailures resets the dial failures of an address, returning whether
+// it had any. The caller must hold the mutex lock.
+func (m *PeerManager) resetDialFailures(address NodeAddress) (bool, error) {
+	peer, ok := m.store.Get(address.NodeID)
+	if !ok {
+		return false, nil
+	}
+	addressInfo, ok := peer.AddressInfo[address]
+	if !ok || addressInfo.DialFailures == 0 {
+		return false, nil
+	}
+	addressInfo.DialFailures = 0
+	addressInfo.LastDialFailure = time.Time{}
+	return true, m.store.Set(peer)
+}
+
 // Dialed marks a peer as successfully dialed. Any further connections will be
 // rejected, and once disconnected the peer may be dialed again.
 func (m *PeerManager) Dialed(address NodeAddress) error {
@@ -564,17 +786,28 @@ func (m *PeerManager) Dialed(address NodeAddress) error {
 	if m.connected[address.NodeID] {
 		return fmt.Errorf("peer %v is already connected", address.NodeID)
 	}
-	if m.options.MaxConnected > 0 && len(m.connected) >= int(m.options.MaxConnected) {
-		if upgradeFromPeer == "" || len(m.connected) >=
-			int(m.options.MaxConnected)+int(m.options.MaxConnectedUpgrade) {
-			return fmt.Errorf("already connected to maximum number of peers")
-		}
-	}
+	defer m.forgetRemoteIP(address.NodeID)
 
 	peer, ok := m.store.Get(address.NodeID)
 	if !ok {
 		return fmt.Errorf("peer %q was removed while dialing", address.NodeID)
 	}
+
+	if m.options.MaxConnected > 0 && !peer.Unconditional &&
+		m.numLimited(m.connected) >= int(m.options.MaxConnected) {
+		// Validators may always replace a lower-scored peer, even when there
+		// is no upgrade capacity left, so that links between validators are kept.
+		if peer.Validator && upgradeFromPeer == "" {
+			upgradeFromPeer = m.findUpgradeCandidate(peer.ID, peer.Score())
+		}
+		if upgradeFromPeer == "" || (!peer.Validator && m.numLimited(m.connected) >=
+			int(m.options.MaxConnected)+int(m.options.MaxConnectedUpgrade)) {
+			return fmt.Errorf("already connected to maximum number of peers")
+		}
+	}
+	if err := m.checkIPLimits(peer); err != nil {
+		return err
+	}
 	now := time.Now().UTC()
 	peer.LastConnected = now
 	if addressInfo, ok := peer.AddressInfo[address]; ok {
@@ -587,8 +820,8 @@ func (m *PeerManager) Dialed(address NodeAddress) error {
 		return err
 	}
 
-	if upgradeFromPeer != "" && m.options.MaxConnected > 0 &&
-		len(m.connected) >= int(m.options.MaxConnected) {
+	if upgradeFromPeer != "" && m.options.MaxConnected > 0 && !peer.Unconditional &&
+		m.numLimited(m.connected) >= int(m.options.MaxConnected) {
 		// Look for an even lower-scored peer that may have appeared since we
 		// started the upgrade.
 		if p, ok := m.store.Get(upgradeFromPeer); ok {
@@ -631,16 +864,31 @@ func (m *PeerManager) Accepted(peerID types.NodeID) error {
 	if m.connected[peerID] {
 		return fmt.Errorf("peer %q is already connected", peerID)
 	}
-	if m.options.MaxConnected > 0 &&
-		len(m.connected) >= int(m.options.MaxConnected)+int(m.options.MaxConnectedUpgrade) {
-		return fmt.Errorf("already connected to maximum number of peers")
-	}
+	defer m.forgetRemoteIP(peerID)
 
 	peer, ok := m.store.Get(peerID)
 	if !ok {
 		peer = m.newPeerInfo(peerID)
 	}
 
+	// Validators may always replace a lower-scored peer, even when there is
+	// no upgrade capacity left, so that links between validators are kept.
+	if m.options.MaxConnected > 0 && !peer.Validator && !peer.Unconditional &&
+		m.numLimited(m.connected) >= int(m.options.MaxConnected)+int(m.options.MaxConnectedUpgrade) {
+		return fmt.Errorf("already connected to maximum number of peers")
+	}
+
+	// The connection slots beyond MaxConnectedInbound are kept for the peers
+	// we dial.
+	if m.options.MaxConnectedInbound > 0 && !peer.Validator && !peer.Unconditional &&
+		m.numLimited(m.inbound) >= int(m.options.MaxConnectedInbound) {
+		return fmt.Errorf("already connected to maximum number of inbound peers")
+	}
+
+	if err := m.checkIPLimits(peer); err != nil {
+		return err
+	}
+
 	// reset this to avoid penalizing peers for their past transgressions
 	for _, addr := range peer.AddressInfo {
 		addr.DialFailures = 0
@@ -650,7 +898,8 @@ func (m *PeerManager) Accepted(peerID types.NodeID) error {
 	// above that we have upgrade capacity), then we can look for a lower-scored
 	// peer to replace and if found accept the connection anyway and evict it.
 	var upgradeFromPeer types.NodeID
-	if m.options.MaxConnected > 0 && len(m.connected) >= int(m.options.MaxConnected) {
+	if m.options.MaxConnected > 0 && !peer.Unconditional &&
+		m.numLimited(m.connected) >= int(m.options.MaxConnected) {
 		upgradeFromPeer = m.findUpgradeCandidate(peer.ID, peer.Score())
 		if upgradeFromPeer == "" {
 			return fmt.Errorf("already connected to maximum number of peers")
@@ -663,6 +912,7 @@ func (m *PeerManager) Accepted(peerID types.NodeID) error {
 	}
 
 	m.connected[peerID] = true
+	m.inbound[peerID] = true
 	if upgradeFromPeer != "" {
 		m.evict[upgradeFromPeer] = true
 	}
@@ -680,10 +930,15 @@ func (m *PeerManager) Ready(ctx context.Context, peerID types.NodeID) {
 
 	if m.connected[peerID] {
 		m.ready[peerID] = true
-		m.broadcast(ctx, PeerUpdate{
+		update := PeerUpdate{
 			NodeID: peerID,
 			Status: PeerStatusUp,
-		})
+		}
+		if peer, ok := m.store.Get(peerID); ok {
+			update.ValidatorAddress = peer.ValidatorAddress
+			update.Capabilities = peer.Capabilities
+		}
+		m.broadcast(ctx, update)
 	}
 }
 
@@ -721,7 +976,7 @@ func (m *PeerManager) TryEvictNext() (types.NodeID, error) {
 
 	// If we're below capacity, we don't need to evict anything.
 	if m.options.MaxConnected == 0 ||
-		len(m.connected)-len(m.evicting) <= int(m.options.MaxConnected) {
+		m.numLimited(m.connected)-m.numLimited(m.evicting) <= int(m.options.MaxConnected) {
 		return "", nil
 	}
 
@@ -730,7 +985,7 @@ func (m *PeerManager) TryEvictNext() (types.NodeID, error) {
 	ranked := m.store.Ranked()
 	for i := len(ranked) - 1; i >= 0; i-- {
 		peer := ranked[i]
-		if m.connected[peer.ID] && !m.evicting[peer.ID] {
+		if m.connected[peer.ID] && !m.evicting[peer.ID] && !peer.Unconditional {
 			m.evicting[peer.ID] = true
 			return peer.ID, nil
 		}
@@ -748,6 +1003,8 @@ func (m *PeerManager) Disconnected(ctx context.Context, peerID types.NodeID) {
 	ready := m.ready[peerID]
 
 	delete(m.connected, peerID)
+	delete(m.inbound, peerID)
+	delete(m.remoteIPs, peerID)
 	delete(m.upgrading, peerID)
 	delete(m.evict, peerID)
 	delete(m.evicting, peerID)
@@ -790,11 +1047,21 @@ func (m *PeerManager) Advertise(peerID types.NodeID, limit uint16) []NodeAddress
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
 
+	// Only peers on the network of the given peer are advertised to it, so
+	// that a node serving several networks keeps an address book per network.
+	var network string
+	if peer, ok := m.store.Get(peerID); ok {
+		network = peer.Network
+	}
+
 	addresses := make([]NodeAddress, 0, limit)
 	for _, peer := range m.store.Ranked() {
 		if peer.ID == peerID {
 			continue
 		}
+		if network != "" && peer.Network != "" && peer.Network != network {
+			continue
+		}
 
 		for nodeAddr, addressInfo := range peer.AddressInfo {
 			if len(addresses) >= int(limit) {
@@ -949,6 +1216,139 @@ func (m *PeerManager) Status(id types.NodeID) PeerStatus {
 	}
 }
 
+// SetValidatorAddress records the address of the validator which proved, during
+// the handshake, that it operates the given peer. An empty address clears it.
+// Peers operated by active validators (see SetValidators) are scored above all
+// other peers except persistent ones, so they are preferred for connection
+// slots and are evicted last. It must be called before Accepted or Dialed.
+func (m *PeerManager) SetValidatorAddress(peerID types.NodeID, address types.Address) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if peerID == m.selfID {
+		return nil
+	}
+
+	peer, ok := m.store.Get(peerID)
+	if !ok {
+		if len(address) == 0 {
+			return nil
+		}
+		peer = m.newPeerInfo(peerID)
+	}
+	peer.ValidatorAddress = address
+	return m.store.Set(m.configurePeer(peer))
+}
+
+// SetCapabilities records the capabilities the peer advertised during the
+// handshake, which are sent to subscribers when the peer is ready. It must be
+// called before Accepted or Dialed.
+func (m *PeerManager) SetCapabilities(peerID types.NodeID, capabilities types.NodeCapabilities) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if peerID == m.selfID {
+		return nil
+	}
+
+	peer, ok := m.store.Get(peerID)
+	if !ok {
+		if capabilities == 0 {
+			return nil
+		}
+		peer = m.newPeerInfo(peerID)
+	}
+	peer.Capabilities = capabilities
+	return m.store.Set(m.configurePeer(peer))
+}
+
+// SetNetwork records the network the peer presented during the handshake. It
+// must be called before Accepted or Dialed.
+func (m *PeerManager) SetNetwork(peerID types.NodeID, network string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if peerID == m.selfID {
+		return nil
+	}
+
+	peer, ok := m.store.Get(peerID)
+	if !ok {
+		peer = m.newPeerInfo(peerID)
+	} else if peer.Network == network {
+		return nil
+	}
+	peer.Network = network
+	return m.store.Set(peer)
+}
+
+// SetRemoteIP records the IP address a peer connects from or was dialed at,
+// for MaxConnectedPerIP and MaxConnectedPerSubnet. It must be called before
+// Accepted or Dialed, and is ignored if the peer is already connected.
+func (m *PeerManager) SetRemoteIP(peerID types.NodeID, ip net.IP) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if m.connected[peerID] || ip == nil {
+		return
+	}
+	m.remoteIPs[peerID] = ip
+}
+
+// SetNodeInfo records the node info the peer presented during the handshake,
+// e.g. to track the versions run across the network (see PeerVersions). It
+// must be called before Accepted or Dialed.
+func (m *PeerManager) SetNodeInfo(peerID types.NodeID, nodeInfo types.NodeInfo) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if peerID == m.selfID {
+		return nil
+	}
+
+	peer, ok := m.store.Get(peerID)
+	if !ok {
+		peer = m.newPeerInfo(peerID)
+	}
+	// The observed address describes this node rather than the peer, and the
+	// validator proof is only meaningful within the handshake.
+	nodeInfo.ObservedAddr = ""
+	nodeInfo.ValidatorProof = nil
+	peer.NodeInfo = &nodeInfo
+	return m.store.Set(peer)
+}
+
+// SetValidators sets the active validator set, used to prioritize peers which
+// are operated by validators.
+func (m *PeerManager) SetValidators(vals *types.ValidatorSet) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	validators := make(map[string]bool, vals.Size())
+	for _, val := range vals.Validators {
+		validators[string(val.Address)] = true
+	}
+	m.validators = validators
+
+	changed := false
+	for _, peer := range m.store.List() {
+		if len(peer.ValidatorAddress) == 0 {
+			continue
+		}
+		if configured := m.configurePeer(peer); configured.Validator != peer.Validator {
+			if err := m.store.Set(configured); err != nil {
+				return err
+			}
+			changed = true
+		}
+	}
+	if changed {
+		m.dialWaker.Wake()
+		m.evictWaker.Wake()
+	}
+	return nil
+}
+
 // findUpgradeCandidate looks for a lower-scored peer that we could evict
 // to make room for the given peer. Returns an empty ID if none is found.
 // If the peer is already being upgraded to, we return that same upgrade.
@@ -967,6 +1367,7 @@ func (m *PeerManager) findUpgradeCandidate(id types.NodeID, score PeerScore) typ
 		case candidate.Score() >= score:
 			return "" // no further peers can be scored lower, due to sorting
 		case !m.connected[candidate.ID]:
+		case candidate.Unconditional:
 		case m.evict[candidate.ID]:
 		case m.evicting[candidate.ID]:
 		case m.upgrading[candidate.ID] != "":
@@ -977,6 +1378,79 @@ func (m *PeerManager) findUpgradeCandidate(id types.NodeID, score PeerScore) typ
 	return ""
 }
 
+// numLimited returns the number of peers in the given set that count towards
+// MaxConnected, i.e. that are not unconditional peers. The caller must hold
+// the mutex lock.
+func (m *PeerManager) numLimited(peers map[types.NodeID]bool) int {
+	n := 0
+	for id, ok := range peers {
+		if _, unconditional := m.options.UnconditionalPeers[id]; ok && !unconditional {
+			n++
+		}
+	}
+	return n
+}
+
+// checkIPLimits returns an error if connecting to the given peer would exceed
+// MaxConnectedPerIP or MaxConnectedPerSubnet. The caller must hold the mutex
+// lock.
+func (m *PeerManager) checkIPLimits(peer peerInfo) error {
+	if m.options.MaxConnectedPerIP == 0 && m.options.MaxConnectedPerSubnet == 0 {
+		return nil
+	}
+	ip, ok := m.remoteIPs[peer.ID]
+	if !ok || peer.Validator || peer.Unconditional || m.options.isConnectionLimitExempt(ip) {
+		return nil
+	}
+
+	subnet := ipSubnet(ip)
+	sameIP, sameSubnet := 0, 0
+	for id := range m.connected {
+		other, ok := m.remoteIPs[id]
+		if !ok {
+			continue
+		}
+		if other.Equal(ip) {
+			sameIP++
+		}
+		if subnet.Contains(other) {
+			sameSubnet++
+		}
+	}
+	if m.options.MaxConnectedPerIP > 0 && sameIP >= int(m.options.MaxConnectedPerIP) {
+		return fmt.Errorf("already connected to maximum number of peers with IP %v", ip)
+	}
+	if m.options.MaxConnectedPerSubnet > 0 && sameSubnet >= int(m.options.MaxConnectedPerSubnet) {
+		return fmt.Errorf("already connected to maximum number of peers in subnet %v", subnet)
+	}
+	return nil
+}
+
+// forgetRemoteIP removes the IP address recorded by SetRemoteIP for a peer
+// which failed to connect. The caller must hold the mutex lock.
+func (m *PeerManager) forgetRemoteIP(peerID types.NodeID) {
+	if !m.connected[peerID] {
+		delete(m.remoteIPs, peerID)
+	}
+}
+
+// ipSubnet returns the subnet of an IP address for MaxConnectedPerSubnet, i.e.
+// its /24 IPv4 subnet or /64 IPv6 subnet.
+func ipSubnet(ip net.IP) *net.IPNet {
+	mask := net.CIDRMask(64, 8*net.IPv6len)
+	if ip4 := ip.To4(); ip4 != nil {
+		ip, mask = ip4, net.CIDRMask(24, 8*net.IPv4len)
+	}
+	return &net.IPNet{IP: ip.Mask(mask), Mask: mask}
+}
+
+// numOutbound returns the number of connected outbound peers that count
+// towards MaxConnectedOutbound, i.e. that are not unconditional peers. The
+// caller must hold the mutex lock.
+func (m *PeerManager) numOutbound() int {
+	return m.numLimited(m.connected) - m.numLimited(m.inbound)
+}
+
 // retryDelay calculates a dial retry delay using exponential backoff, based on
 // retry settings in PeerManagerOptions. If retries are disabled (i.e.
 // MinRetryTime is 0), this returns retryNever (i.e. an infinite retry delay).
@@ -1034,6 +1508,21 @@ func (m *PeerManager) SetHeight(peerID types.NodeID, height int64) error {
 	return m.store.Set(peer)
 }
 
+// MaxPeerHeight returns the greatest height reported via SetHeight by the
+// peers that are currently connected, or 0 if none is known.
+func (m *PeerManager) MaxPeerHeight() int64 {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	var maxHeight int64
+	for peerID := range m.ready {
+		if peer, ok := m.store.Get(peerID); ok && peer.Height > maxHeight {
+			maxHeight = peer.Height
+		}
+	}
+	return maxHeight
+}
+
 // peerStore stores information about peers. It is not thread-safe, assuming it
 // is only used by PeerManager which handles concurrency control. This allows
 // the manager to execute multiple operations atomically via its own mutex.
@@ -1192,11 +1681,17 @@ type peerInfo struct {
 	ID            types.NodeID
 	AddressInfo   map[NodeAddress]*peerAddressInfo
 	LastConnected time.Time
+	Network       string          // presented in the last handshake, or of the peer advertising it
+	NodeInfo      *types.NodeInfo // presented in the last handshake
 
 	// These fields are ephemeral, i.e. not persisted to the database.
-	Persistent bool
-	Height     int64
-	FixedScore PeerScore // mainly for tests
+	Persistent       bool
+	Unconditional    bool
+	Height           int64
+	FixedScore       PeerScore              // mainly for tests
+	ValidatorAddress types.Address          // validator proven in the last handshake
+	Validator        bool                   // ValidatorAddress is an active validator
+	Capabilities     types.NodeCapabilities // advertised in the last handshake
 
 	MutableScore int64 // updated by router
 }
@@ -1207,10 +1702,18 @@ func peerInfoFromProto(msg *p2pproto.PeerInfo) (*peerInfo, error) {
 	p := &peerInfo{
 		ID:          types.NodeID(msg.ID),
 		AddressInfo: map[NodeAddress]*peerAddressInfo{},
+		Network:     msg.Network,
 	}
 	if msg.LastConnected != nil {
 		p.LastConnected = *msg.LastConnected
 	}
+	if msg.NodeInfo != nil {
+		nodeInfo, err := types.NodeInfoFromProto(msg.NodeInfo)
+		if err != nil {
+			return nil, err
+		}
+		p.NodeInfo = &nodeInfo
+	}
 	for _, a := range msg.AddressInfo {
 		addressInfo, err := peerAddressInfoFromProto(a)
 		if err != nil {
@@ -1229,10 +1732,14 @@ func (p *peerInfo) ToProto() *p2pproto.PeerInfo {
 	msg := &p2pproto.PeerInfo{
 		ID:            string(p.ID),
 		LastConnected: &p.LastConnected,
+		Network:       p.Network,
 	}
 	for _, addressInfo := range p.AddressInfo {
 		msg.AddressInfo = append(msg.AddressInfo, addressInfo.ToProto())
 	}
+	if p.NodeInfo != nil {
+		msg.NodeInfo = p.NodeInfo.ToProto()
+	}
 	if msg.LastConnected.IsZero() {
 		msg.LastConnected = nil
 	}
@@ -1261,6 +1768,9 @@ func (p *peerInfo) Score() PeerScore {
 	if p.Persistent {
 		return PeerScorePersistent
 	}
+	if p.Validator {
+		return PeerScoreValidator
+	}
 
 	score := p.MutableScore
 
@@ -1274,8 +1784,8 @@ func (p *peerInfo) Score() PeerScore {
 		return 0
 	}
 
-	if score >= math.MaxUint8 {
-		return PeerScore(math.MaxUint8)
+	if score >= int64(PeerScoreValidator) {
+		return PeerScoreValidator - 1
 	}
 
 	return PeerScore(score)
@@ -1295,6 +1805,7 @@ type peerAddressInfo struct {
 	LastDialSuccess time.Time
 	LastDialFailure time.Time
 	DialFailures    uint32 // since last successful dial
+	Source          string // where the address was learned from
 }
 
 // peerAddressInfoFromProto converts a Protobuf PeerAddressInfo message
@@ -1307,6 +1818,7 @@ func peerAddressInfoFromProto(msg *p2pproto.PeerAddressInfo) (*peerAddressInfo,
 	addressInfo := &peerAddressInfo{
 		Address:      address,
 		DialFailures: msg.DialFailures,
+		Source:       msg.Source,
 	}
 	if msg.LastDialSuccess != nil {
 		addressInfo.LastDialSuccess = *msg.LastDialSuccess
@@ -1324,6 +1836,7 @@ func (a *peerAddressInfo) ToProto() *p2pproto.PeerAddressInfo {
 		LastDialSuccess: &a.LastDialSuccess,
 		LastDialFailure: &a.LastDialFailure,
 		DialFailures:    a.DialFailures,
+		Source:          a.Source,
 	}
 	if msg.LastDialSuccess.IsZero() {
 		msg.LastDialSuccess = nil
@@ -3,6 +3,7 @@ package p2p_test
 import (
 	"context"
 	"errors"
+	"net"
 	"strings"
 	"testing"
 	"time"
@@ -11,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 	dbm "github.com/tendermint/tm-db"
 
+	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/internal/p2p"
 	"github.com/tendermint/tendermint/types"
 )
@@ -69,6 +71,35 @@ func TestPeerManagerOptions_Validate(t *testing.T) {
 			MaxConnectedUpgrade: 1,
 		}, true},
 
+		// MaxConnectedInbound and MaxConnectedOutbound
+		"MaxConnectedInbound above MaxConnected": {p2p.PeerManagerOptions{
+			MaxConnected:        2,
+			MaxConnectedInbound: 3,
+		}, false},
+		"MaxConnectedOutbound above MaxConnected": {p2p.PeerManagerOptions{
+			MaxConnected:         2,
+			MaxConnectedOutbound: 3,
+		}, false},
+		"MaxConnectedInbound and MaxConnectedOutbound below MaxConnected": {p2p.PeerManagerOptions{
+			MaxConnected:         3,
+			MaxConnectedInbound:  2,
+			MaxConnectedOutbound: 2,
+		}, true},
+		"PersistentPeers above MaxConnectedOutbound": {p2p.PeerManagerOptions{
+			PersistentPeers:      []types.NodeID{nodeID, nodeID},
+			MaxConnected:         3,
+			MaxConnectedOutbound: 1,
+		}, false},
+
+		// MaxConnectedPerIP and MaxConnectedPerSubnet
+		"MaxConnectedPerIP above MaxConnectedPerSubnet": {p2p.PeerManagerOptions{
+			MaxConnectedPerIP:     3,
+			MaxConnectedPerSubnet: 2,
+		}, false},
+		"MaxConnectedPerIP without MaxConnectedPerSubnet": {p2p.PeerManagerOptions{
+			MaxConnectedPerIP: 3,
+		}, true},
+
 		// MaxRetryTime
 		"MaxRetryTime below MinRetryTime": {p2p.PeerManagerOptions{
 			MinRetryTime: 7 * time.Second,
@@ -511,6 +542,55 @@ func TestPeerManager_TryDialNext_MaxConnected(t *testing.T) {
 	require.Zero(t, dial)
 }
 
+func TestPeerManager_TryDialNext_MaxConnectedOutbound(t *testing.T) {
+	a := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("a", 40))}
+	b := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("b", 40))}
+	c := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("c", 40))}
+	d := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("d", 40))}
+
+	peerManager, err := p2p.NewPeerManager(selfID, dbm.NewMemDB(), p2p.PeerManagerOptions{
+		MaxConnected:         3,
+		MaxConnectedOutbound: 1,
+		UnconditionalPeers:   map[types.NodeID]struct{}{d.NodeID: {}},
+	})
+	require.NoError(t, err)
+
+	// Accept a, which doesn't count towards the outbound limit.
+	added, err := peerManager.Add(a)
+	require.NoError(t, err)
+	require.True(t, added)
+	require.NoError(t, peerManager.Accepted(a.NodeID))
+
+	// Add b and start dialing it.
+	added, err = peerManager.Add(b)
+	require.NoError(t, err)
+	require.True(t, added)
+	dial, err := peerManager.TryDialNext()
+	require.NoError(t, err)
+	require.Equal(t, b, dial)
+
+	// Adding c will not allow dialing it, even when b is connected.
+	added, err = peerManager.Add(c)
+	require.NoError(t, err)
+	require.True(t, added)
+	dial, err = peerManager.TryDialNext()
+	require.NoError(t, err)
+	require.Zero(t, dial)
+
+	require.NoError(t, peerManager.Dialed(b))
+	dial, err = peerManager.TryDialNext()
+	require.NoError(t, err)
+	require.Zero(t, dial)
+
+	// The unconditional peer d is dialed regardless.
+	added, err = peerManager.Add(d)
+	require.NoError(t, err)
+	require.True(t, added)
+	dial, err = peerManager.TryDialNext()
+	require.NoError(t, err)
+	require.Equal(t, d, dial)
+}
+
 func TestPeerManager_TryDialNext_MaxConnectedUpgrade(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -761,6 +841,59 @@ func TestPeerManager_DialFailed(t *testing.T) {
 	require.Equal(t, []types.NodeID{aID}, peerManager.Peers())
 }
 
+func TestPeerManager_ResolvePersistentPeers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	a := p2p.NodeAddress{Protocol: "tcp", NodeID: types.NodeID(strings.Repeat("a", 40)), Hostname: "a.local", Port: 26656}
+	b := p2p.NodeAddress{Protocol: "tcp", NodeID: types.NodeID(strings.Repeat("b", 40)), Hostname: "b.local", Port: 26656}
+
+	ip := net.IPv4(10, 0, 0, 1)
+	resolved := []p2p.NodeAddress{}
+	peerManager, err := p2p.NewPeerManager(selfID, dbm.NewMemDB(), p2p.PeerManagerOptions{
+		PersistentPeers: []types.NodeID{a.NodeID},
+		MinRetryTime:    time.Hour,
+		Resolver: func(_ context.Context, address p2p.NodeAddress) ([]p2p.Endpoint, error) {
+			resolved = append(resolved, address)
+			return []p2p.Endpoint{{Protocol: address.Protocol, IP: ip, Port: address.Port}}, nil
+		},
+	})
+	require.NoError(t, err)
+
+	for _, address := range []p2p.NodeAddress{a, b} {
+		added, err := peerManager.Add(address)
+		require.NoError(t, err)
+		require.True(t, added)
+	}
+
+	// Only the hostnames of persistent peers are resolved.
+	require.NoError(t, peerManager.ResolvePersistentPeers(ctx))
+	require.Equal(t, []p2p.NodeAddress{a}, resolved)
+
+	// A failed dial makes the peer wait out its retry time.
+	dial, err := peerManager.TryDialNext()
+	require.NoError(t, err)
+	require.Equal(t, a, dial)
+	require.NoError(t, peerManager.DialFailed(ctx, a))
+	dial, err = peerManager.TryDialNext()
+	require.NoError(t, err)
+	require.Equal(t, b, dial)
+	require.NoError(t, peerManager.DialFailed(ctx, b))
+
+	// Resolving to the same IP addresses changes nothing.
+	require.NoError(t, peerManager.ResolvePersistentPeers(ctx))
+	dial, err = peerManager.TryDialNext()
+	require.NoError(t, err)
+	require.Zero(t, dial)
+
+	// Resolving to new IP addresses makes the peer dialable right away.
+	ip = net.IPv4(10, 0, 0, 2)
+	require.NoError(t, peerManager.ResolvePersistentPeers(ctx))
+	dial, err = peerManager.TryDialNext()
+	require.NoError(t, err)
+	require.Equal(t, a, dial)
+}
+
 func TestPeerManager_DialFailed_UnreservePeer(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -1152,6 +1285,91 @@ func TestPeerManager_Accepted_MaxConnected(t *testing.T) {
 	require.Error(t, peerManager.Accepted(c.NodeID))
 }
 
+func TestPeerManager_Accepted_MaxConnectedInbound(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	a := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("a", 40))}
+	b := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("b", 40))}
+	c := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("c", 40))}
+
+	peerManager, err := p2p.NewPeerManager(selfID, dbm.NewMemDB(), p2p.PeerManagerOptions{
+		MaxConnected:        2,
+		MaxConnectedInbound: 1,
+	})
+	require.NoError(t, err)
+
+	// Accepting a takes the only inbound slot, so b is rejected.
+	require.NoError(t, peerManager.Accepted(a.NodeID))
+	require.Error(t, peerManager.Accepted(b.NodeID))
+
+	// The remaining slot is kept for dialing c.
+	added, err := peerManager.Add(c)
+	require.NoError(t, err)
+	require.True(t, added)
+	dial, err := peerManager.TryDialNext()
+	require.NoError(t, err)
+	require.Equal(t, c, dial)
+	require.NoError(t, peerManager.Dialed(c))
+
+	// Once a disconnects, b can be accepted.
+	peerManager.Disconnected(ctx, a.NodeID)
+	require.NoError(t, peerManager.Accepted(b.NodeID))
+}
+
+func TestPeerManager_Accepted_MaxConnectedPerIP(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	a := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("a", 40))}
+	b := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("b", 40))}
+	c := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("c", 40))}
+	d := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("d", 40))}
+	e := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("e", 40))}
+
+	_, sentries, err := net.ParseCIDR("10.0.0.0/8")
+	require.NoError(t, err)
+	peerManager, err := p2p.NewPeerManager(selfID, dbm.NewMemDB(), p2p.PeerManagerOptions{
+		MaxConnectedPerIP:        1,
+		MaxConnectedPerSubnet:    2,
+		ConnectionLimitAllowlist: []*net.IPNet{sentries},
+	})
+	require.NoError(t, err)
+
+	// a takes the only slot of its IP, so b is rejected from it.
+	peerManager.SetRemoteIP(a.NodeID, net.IPv4(192, 0, 2, 1))
+	require.NoError(t, peerManager.Accepted(a.NodeID))
+	peerManager.SetRemoteIP(b.NodeID, net.IPv4(192, 0, 2, 1))
+	require.Error(t, peerManager.Accepted(b.NodeID))
+
+	// c takes the last slot of the subnet, so d is rejected from it, and
+	// dialing it too.
+	peerManager.SetRemoteIP(c.NodeID, net.IPv4(192, 0, 2, 2))
+	require.NoError(t, peerManager.Accepted(c.NodeID))
+	peerManager.SetRemoteIP(d.NodeID, net.IPv4(192, 0, 2, 3))
+	require.Error(t, peerManager.Accepted(d.NodeID))
+
+	added, err := peerManager.Add(d)
+	require.NoError(t, err)
+	require.True(t, added)
+	dial, err := peerManager.TryDialNext()
+	require.NoError(t, err)
+	require.Equal(t, d, dial)
+	peerManager.SetRemoteIP(d.NodeID, net.IPv4(192, 0, 2, 3))
+	require.Error(t, peerManager.Dialed(d))
+
+	// Peers in the allowlist aren't limited.
+	peerManager.SetRemoteIP(b.NodeID, net.IPv4(10, 0, 0, 1))
+	require.NoError(t, peerManager.Accepted(b.NodeID))
+	peerManager.SetRemoteIP(e.NodeID, net.IPv4(10, 0, 0, 1))
+	require.NoError(t, peerManager.Accepted(e.NodeID))
+
+	// Once a disconnects, d can be accepted.
+	peerManager.Disconnected(ctx, a.NodeID)
+	peerManager.SetRemoteIP(d.NodeID, net.IPv4(192, 0, 2, 3))
+	require.NoError(t, peerManager.Accepted(d.NodeID))
+}
+
 func TestPeerManager_Accepted_MaxConnectedUpgrade(t *testing.T) {
 	a := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("a", 40))}
 	b := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("b", 40))}
@@ -1328,7 +1546,179 @@ func TestPeerManager_Ready(t *testing.T) {
 	require.Empty(t, sub.Updates())
 }
 
+func TestPeerManager_SetValidators(t *testing.T) {
+	a := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("a", 40))}
+	b := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("b", 40))}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	peerManager, err := p2p.NewPeerManager(selfID, dbm.NewMemDB(), p2p.PeerManagerOptions{})
+	require.NoError(t, err)
+
+	val := types.NewValidator(ed25519.GenPrivKey().PubKey(), 10)
+
+	// a proved that it is operated by val, b did not.
+	for _, addr := range []p2p.NodeAddress{a, b} {
+		added, err := peerManager.Add(addr)
+		require.NoError(t, err)
+		require.True(t, added)
+	}
+	require.NoError(t, peerManager.SetValidatorAddress(a.NodeID, val.Address))
+	require.EqualValues(t, 0, peerManager.Scores()[a.NodeID])
+
+	// Once val is an active validator, a is preferred over b.
+	require.NoError(t, peerManager.SetValidators(types.NewValidatorSet([]*types.Validator{val})))
+	require.Equal(t, p2p.PeerScoreValidator, peerManager.Scores()[a.NodeID])
+	require.EqualValues(t, 0, peerManager.Scores()[b.NodeID])
+
+	// The validator address is included in the peer's status update.
+	sub := peerManager.Subscribe(ctx)
+	require.NoError(t, peerManager.Accepted(a.NodeID))
+	peerManager.Ready(ctx, a.NodeID)
+	require.Equal(t, p2p.PeerUpdate{
+		NodeID:           a.NodeID,
+		Status:           p2p.PeerStatusUp,
+		ValidatorAddress: val.Address,
+	}, <-sub.Updates())
+
+	// When val leaves the validator set, a is no longer preferred.
+	other := types.NewValidator(ed25519.GenPrivKey().PubKey(), 10)
+	require.NoError(t, peerManager.SetValidators(types.NewValidatorSet([]*types.Validator{other})))
+	require.EqualValues(t, 0, peerManager.Scores()[a.NodeID])
+}
+
+func TestPeerManager_SetCapabilities(t *testing.T) {
+	a := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("a", 40))}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	peerManager, err := p2p.NewPeerManager(selfID, dbm.NewMemDB(), p2p.PeerManagerOptions{})
+	require.NoError(t, err)
+
+	// The capabilities advertised in the handshake are included in the
+	// peer's status update.
+	capabilities := types.CapabilityCompactBlocks | types.CapabilityTxAnnouncements
+	require.NoError(t, peerManager.SetCapabilities(a.NodeID, capabilities))
+	sub := peerManager.Subscribe(ctx)
+	require.NoError(t, peerManager.Accepted(a.NodeID))
+	peerManager.Ready(ctx, a.NodeID)
+	require.Equal(t, p2p.PeerUpdate{
+		NodeID:       a.NodeID,
+		Status:       p2p.PeerStatusUp,
+		Capabilities: capabilities,
+	}, <-sub.Updates())
+}
+
+func TestPeerManager_Accepted_Validator(t *testing.T) {
+	a := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("a", 40))}
+	b := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("b", 40))}
+	c := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("c", 40))}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// No upgrade capacity: only validators can replace connected peers.
+	peerManager, err := p2p.NewPeerManager(selfID, dbm.NewMemDB(), p2p.PeerManagerOptions{
+		MaxConnected: 1,
+	})
+	require.NoError(t, err)
+
+	val := types.NewValidator(ed25519.GenPrivKey().PubKey(), 10)
+	require.NoError(t, peerManager.SetValidators(types.NewValidatorSet([]*types.Validator{val})))
+
+	// Accept full node a.
+	require.NoError(t, peerManager.Accepted(a.NodeID))
+
+	// Validator b replaces a, even without upgrade capacity.
+	require.NoError(t, peerManager.SetValidatorAddress(b.NodeID, val.Address))
+	require.NoError(t, peerManager.Accepted(b.NodeID))
+	evict, err := peerManager.TryEvictNext()
+	require.NoError(t, err)
+	require.Equal(t, a.NodeID, evict)
+	peerManager.Disconnected(ctx, a.NodeID)
+
+	// Full node c cannot replace validator b.
+	require.Error(t, peerManager.Accepted(c.NodeID))
+	evict, err = peerManager.TryEvictNext()
+	require.NoError(t, err)
+	require.Zero(t, evict)
+}
+
 // See TryEvictNext for most tests, this just tests blocking behavior.
+func TestPeerManager_Unconditional(t *testing.T) {
+	a := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("a", 40))}
+	b := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("b", 40))}
+	c := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("c", 40))}
+	d := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("d", 40))}
+
+	peerManager, err := p2p.NewPeerManager(selfID, dbm.NewMemDB(), p2p.PeerManagerOptions{
+		UnconditionalPeers: map[types.NodeID]struct{}{
+			b.NodeID: {},
+			c.NodeID: {},
+		},
+		PeerScores:          map[types.NodeID]p2p.PeerScore{d.NodeID: 10},
+		MaxConnected:        1,
+		MaxConnectedUpgrade: 1,
+	})
+	require.NoError(t, err)
+
+	for _, address := range []p2p.NodeAddress{a, b, c, d} {
+		added, err := peerManager.Add(address)
+		require.NoError(t, err)
+		require.True(t, added)
+	}
+
+	// Fill the connection slot with a.
+	require.NoError(t, peerManager.Accepted(a.NodeID))
+
+	// The unconditional peers are accepted and dialed regardless.
+	require.NoError(t, peerManager.Accepted(b.NodeID))
+	dial, err := peerManager.TryDialNext()
+	require.NoError(t, err)
+	require.Equal(t, d, dial) // d upgrades a
+	dial, err = peerManager.TryDialNext()
+	require.NoError(t, err)
+	require.Equal(t, c, dial)
+	require.NoError(t, peerManager.Dialed(c))
+
+	// The unconditional peers are never evicted for the upgrade.
+	require.NoError(t, peerManager.Dialed(d))
+	evict, err := peerManager.TryEvictNext()
+	require.NoError(t, err)
+	require.Equal(t, a.NodeID, evict)
+	peerManager.Disconnected(context.Background(), a.NodeID)
+
+	evict, err = peerManager.TryEvictNext()
+	require.NoError(t, err)
+	require.Empty(t, evict)
+}
+
+func TestPeerManager_GossipPolicy(t *testing.T) {
+	a := types.NodeID(strings.Repeat("a", 40))
+	b := types.NodeID(strings.Repeat("b", 40))
+
+	noAddresses := p2p.GossipPolicy{BlockedChannels: map[p2p.ChannelID]bool{0x00: true}}
+	peerManager, err := p2p.NewPeerManager(selfID, dbm.NewMemDB(), p2p.PeerManagerOptions{
+		GossipPolicies: map[types.NodeID]p2p.GossipPolicy{a: noAddresses},
+	})
+	require.NoError(t, err)
+
+	require.False(t, peerManager.GossipPolicy(a).Allows(0x00))
+	require.True(t, peerManager.GossipPolicy(a).Allows(0x20))
+	require.True(t, peerManager.GossipPolicy(b).Allows(0x00))
+
+	peerManager, err = p2p.NewPeerManager(selfID, dbm.NewMemDB(), p2p.PeerManagerOptions{
+		GossipPolicies:      map[types.NodeID]p2p.GossipPolicy{a: {}},
+		DefaultGossipPolicy: noAddresses,
+	})
+	require.NoError(t, err)
+
+	require.True(t, peerManager.GossipPolicy(a).Allows(0x00))
+	require.False(t, peerManager.GossipPolicy(b).Allows(0x00))
+}
+
 func TestPeerManager_EvictNext(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -1828,6 +2218,103 @@ func TestPeerManager_Advertise(t *testing.T) {
 	}, peerManager.Advertise(dID, 2))
 }
 
+func TestPeerManager_Advertise_Networks(t *testing.T) {
+	a := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("a", 40))}
+	b := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("b", 40))}
+	c := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("c", 40))}
+	d := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("d", 40))}
+
+	db := dbm.NewMemDB()
+	peerManager, err := p2p.NewPeerManager(selfID, db, p2p.PeerManagerOptions{})
+	require.NoError(t, err)
+
+	// a and b are on different networks, and each advertises a peer which is
+	// assumed to be on its own network.
+	for _, address := range []p2p.NodeAddress{a, b} {
+		added, err := peerManager.Add(address)
+		require.NoError(t, err)
+		require.True(t, added)
+	}
+	require.NoError(t, peerManager.SetNetwork(a.NodeID, "chain-a"))
+	require.NoError(t, peerManager.SetNetwork(b.NodeID, "chain-b"))
+	added, err := peerManager.AddFrom(c, string(a.NodeID))
+	require.NoError(t, err)
+	require.True(t, added)
+	added, err = peerManager.AddFrom(d, string(b.NodeID))
+	require.NoError(t, err)
+	require.True(t, added)
+
+	require.ElementsMatch(t, []p2p.NodeAddress{c}, peerManager.Advertise(a.NodeID, 100))
+	require.ElementsMatch(t, []p2p.NodeAddress{a}, peerManager.Advertise(c.NodeID, 100))
+	require.ElementsMatch(t, []p2p.NodeAddress{b}, peerManager.Advertise(d.NodeID, 100))
+
+	// a peer of unknown network gets all addresses
+	unknownID := types.NodeID(strings.Repeat("e", 40))
+	require.ElementsMatch(t, []p2p.NodeAddress{a, b, c, d}, peerManager.Advertise(unknownID, 100))
+
+	// the networks are persisted, and a handshake updates them
+	peerManager, err = p2p.NewPeerManager(selfID, db, p2p.PeerManagerOptions{})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []p2p.NodeAddress{c}, peerManager.Advertise(a.NodeID, 100))
+	require.NoError(t, peerManager.SetNetwork(c.NodeID, "chain-b"))
+	require.Empty(t, peerManager.Advertise(a.NodeID, 100))
+	require.ElementsMatch(t, []p2p.NodeAddress{b, c}, peerManager.Advertise(d.NodeID, 100))
+}
+
+func TestPeerManager_PeerVersions(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	a := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("a", 40))}
+	b := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("b", 40))}
+	c := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("c", 40))}
+
+	nodeInfo := func(id types.NodeID, version string, app uint64) types.NodeInfo {
+		return types.NodeInfo{
+			NodeID:          id,
+			Network:         "test",
+			Version:         version,
+			Moniker:         string(id[:1]),
+			Channels:        []byte{0x01},
+			ProtocolVersion: types.ProtocolVersion{P2P: 8, Block: 11, App: app},
+			ObservedAddr:    "10.0.0.1",
+		}
+	}
+
+	db := dbm.NewMemDB()
+	peerManager, err := p2p.NewPeerManager(selfID, db, p2p.PeerManagerOptions{})
+	require.NoError(t, err)
+	require.Equal(t, p2p.PeerVersions{
+		Software: []p2p.VersionCount{}, Block: []p2p.VersionCount{}, App: []p2p.VersionCount{},
+	}, peerManager.PeerVersions())
+
+	require.NoError(t, peerManager.SetNodeInfo(a.NodeID, nodeInfo(a.NodeID, "0.35.1", 1)))
+	require.NoError(t, peerManager.SetNodeInfo(b.NodeID, nodeInfo(b.NodeID, "0.35.0", 1)))
+	require.NoError(t, peerManager.SetNodeInfo(c.NodeID, nodeInfo(c.NodeID, "0.35.1", 2)))
+	for _, address := range []p2p.NodeAddress{a, b, c} {
+		require.NoError(t, peerManager.Accepted(address.NodeID))
+	}
+
+	require.Equal(t, p2p.PeerVersions{
+		Software: []p2p.VersionCount{{Version: "0.35.1", Peers: 2}, {Version: "0.35.0", Peers: 1}},
+		Block:    []p2p.VersionCount{{Version: "11", Peers: 3}},
+		App:      []p2p.VersionCount{{Version: "1", Peers: 2}, {Version: "2", Peers: 1}},
+	}, peerManager.PeerVersions())
+
+	// Disconnected peers are not counted.
+	peerManager.Disconnected(ctx, c.NodeID)
+	require.Equal(t, []p2p.VersionCount{{Version: "1", Peers: 2}}, peerManager.PeerVersions().App)
+
+	// The node info is persisted, without the address observed by the peer.
+	peerManager, err = p2p.NewPeerManager(selfID, db, p2p.PeerManagerOptions{})
+	require.NoError(t, err)
+	info, ok := peerManager.NodeInfo(c.NodeID)
+	require.True(t, ok)
+	expect := nodeInfo(c.NodeID, "0.35.1", 2)
+	expect.ObservedAddr = ""
+	require.Equal(t, expect, info)
+}
+
 func TestPeerManager_SetHeight_GetHeight(t *testing.T) {
 	a := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("a", 40))}
 	b := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("b", 40))}
@@ -1862,3 +2349,32 @@ func TestPeerManager_SetHeight_GetHeight(t *testing.T) {
 	require.Zero(t, peerManager.GetHeight(a.NodeID))
 	require.Zero(t, peerManager.GetHeight(b.NodeID))
 }
+
+func TestPeerManager_MaxPeerHeight(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	a := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("a", 40))}
+	b := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("b", 40))}
+	c := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("c", 40))}
+
+	peerManager, err := p2p.NewPeerManager(selfID, dbm.NewMemDB(), p2p.PeerManagerOptions{})
+	require.NoError(t, err)
+	require.Zero(t, peerManager.MaxPeerHeight())
+
+	// Only the heights of connected peers are taken into account.
+	for _, addr := range []p2p.NodeAddress{a, b} {
+		added, err := peerManager.Add(addr)
+		require.NoError(t, err)
+		require.True(t, added)
+		require.NoError(t, peerManager.Accepted(addr.NodeID))
+		peerManager.Ready(ctx, addr.NodeID)
+	}
+	require.NoError(t, peerManager.SetHeight(a.NodeID, 3))
+	require.NoError(t, peerManager.SetHeight(b.NodeID, 5))
+	require.NoError(t, peerManager.SetHeight(c.NodeID, 9))
+	require.EqualValues(t, 5, peerManager.MaxPeerHeight())
+
+	peerManager.Disconnected(ctx, b.NodeID)
+	require.EqualValues(t, 3, peerManager.MaxPeerHeight())
+}
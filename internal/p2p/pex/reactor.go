@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/internal/p2p"
 	"github.com/tendermint/tendermint/internal/p2p/conn"
 	"github.com/tendermint/tendermint/libs/log"
@@ -26,7 +27,7 @@ const (
 	PexChannel = 0x00
 
 	// over-estimate of max NetAddress size
-	// hexID (40) + IP (16) + Port (2) + Name (100) ...
+	// hexID (40) + IP (16) + Port (2) + Name (100) + PubKey (32) + Signature (64) ...
 	// NOTE: dont use massive DNS name ..
 	maxAddressSize = 256
 
@@ -68,6 +69,22 @@ func ChannelDescriptor() *conn.ChannelDescriptor {
 	}
 }
 
+// ReactorOptions specifies options for a Reactor.
+type ReactorOptions struct {
+	// PrivKey is the node key, signing the address of the node advertised to
+	// peers. If nil, the node doesn't advertise its own address.
+	PrivKey crypto.PrivKey
+
+	// SelfAddress returns the address URL of the node advertised to peers,
+	// if it's dialable.
+	SelfAddress func() string
+
+	// RequireSignedAddresses drops the addresses gossiped by peers without a
+	// signature of the node they point at. Otherwise, they're accepted, so
+	// that the nodes of a network can be upgraded gradually.
+	RequireSignedAddresses bool
+}
+
 // The peer exchange or PEX reactor supports the peer manager by sending
 // requests to other peers for addresses that can be given to the peer manager
 // and at the same time advertises addresses to peers that need more.
@@ -76,9 +93,16 @@ func ChannelDescriptor() *conn.ChannelDescriptor {
 // increasing the interval between each request. It tracks connected peers via
 // a linked list, sending a request to the node at the front of the list and
 // adding it to the back of the list once a response is received.
+//
+// Nodes sign their own address with their node key, and the signed addresses
+// are gossiped along with their signatures, so that a peer can't advertise
+// the address of a node pointing at another host. Addresses with an invalid
+// signature are rejected, and unsigned ones are dropped if required by
+// ReactorOptions.
 type Reactor struct {
 	service.BaseService
 	logger log.Logger
+	opts   ReactorOptions
 
 	peerManager *p2p.PeerManager
 	pexCh       *p2p.Channel
@@ -100,6 +124,14 @@ type Reactor struct {
 	// minReceiveRequestInterval).
 	lastReceivedRequests map[types.NodeID]time.Time
 
+	// signedAddresses keeps the signed addresses received from peers, by
+	// address, to be advertised along with their signatures.
+	signedAddresses map[string]protop2p.PexAddress
+
+	// reportedPeers keeps the nodes in the last PEX response of each peer,
+	// for the node's view of the network topology.
+	reportedPeers map[types.NodeID][]types.NodeID
+
 	// keep track of how many new peers to existing peers we have received to
 	// extrapolate the size of the network
 	newPeers   uint32
@@ -117,16 +149,20 @@ func NewReactor(
 	peerManager *p2p.PeerManager,
 	pexCh *p2p.Channel,
 	peerUpdates *p2p.PeerUpdates,
+	opts ReactorOptions,
 ) *Reactor {
 
 	r := &Reactor{
 		logger:               logger,
+		opts:                 opts,
 		peerManager:          peerManager,
 		pexCh:                pexCh,
 		peerUpdates:          peerUpdates,
 		availablePeers:       make(map[types.NodeID]struct{}),
 		requestsSent:         make(map[types.NodeID]struct{}),
 		lastReceivedRequests: make(map[types.NodeID]time.Time),
+		signedAddresses:      make(map[string]protop2p.PexAddress),
+		reportedPeers:        make(map[types.NodeID][]types.NodeID),
 	}
 
 	r.BaseService = *service.NewBaseService(logger, "PEX", r)
@@ -230,13 +266,16 @@ func (r *Reactor) handlePexMessage(ctx context.Context, envelope *p2p.Envelope)
 		}
 
 		// request peers from the peer manager and parse the NodeAddresses into
-		// URL strings
-		nodeAddresses := r.peerManager.Advertise(envelope.From, maxAddresses)
-		pexAddresses := make([]protop2p.PexAddress, len(nodeAddresses))
-		for idx, addr := range nodeAddresses {
-			pexAddresses[idx] = protop2p.PexAddress{
-				URL: addr.String(),
+		// URL strings, after the signed address of the node itself
+		var pexAddresses []protop2p.PexAddress
+		if self, ok := r.selfAddress(); ok {
+			pexAddresses = append(pexAddresses, self)
+		}
+		for _, addr := range r.peerManager.Advertise(envelope.From, maxAddresses) {
+			if len(pexAddresses) >= int(maxAddresses) {
+				break
 			}
+			pexAddresses = append(pexAddresses, r.pexAddress(addr))
 		}
 		if err := r.pexCh.Send(ctx, p2p.Envelope{
 			To:      envelope.From,
@@ -260,14 +299,34 @@ func (r *Reactor) handlePexMessage(ctx context.Context, envelope *p2p.Envelope)
 			)
 		}
 
+		// the whole response is rejected if any address has an invalid
+		// signature, which honest peers never forward
+		peerAddresses := make([]p2p.NodeAddress, 0, len(msg.Addresses))
+		pexAddresses := make([]protop2p.PexAddress, 0, len(msg.Addresses))
 		for _, pexAddress := range msg.Addresses {
 			peerAddress, err := p2p.ParseNodeAddress(pexAddress.URL)
 			if err != nil {
 				continue
 			}
-			added, err := r.peerManager.Add(peerAddress)
+			if isSigned(pexAddress) {
+				if err := verifyAddress(peerAddress, pexAddress); err != nil {
+					return 10 * time.Minute, fmt.Errorf("peer sent an invalid signed address %v: %w", peerAddress, err)
+				}
+			} else if r.opts.RequireSignedAddresses {
+				logger.Debug("dropped unsigned PEX address", "address", peerAddress)
+				continue
+			}
+			peerAddresses = append(peerAddresses, peerAddress)
+			pexAddresses = append(pexAddresses, pexAddress)
+		}
+		r.setReportedPeers(envelope.From, peerAddresses)
+
+		for idx, peerAddress := range peerAddresses {
+			added, err := r.peerManager.AddFrom(peerAddress, string(envelope.From))
 			if err != nil {
 				logger.Error("failed to add PEX address", "address", peerAddress, "err", err)
+			} else if isSigned(pexAddresses[idx]) {
+				r.addSignedAddress(peerAddress, pexAddresses[idx])
 			}
 			if added {
 				r.newPeers++
@@ -324,6 +383,7 @@ func (r *Reactor) processPeerUpdate(peerUpdate p2p.PeerUpdate) {
 		delete(r.availablePeers, peerUpdate.NodeID)
 		delete(r.requestsSent, peerUpdate.NodeID)
 		delete(r.lastReceivedRequests, peerUpdate.NodeID)
+		delete(r.reportedPeers, peerUpdate.NodeID)
 	default:
 	}
 }
@@ -409,6 +469,76 @@ func (r *Reactor) calculateNextRequestTime() time.Duration {
 	return baseTime * time.Duration(r.discoveryRatio)
 }
 
+// selfAddress returns the signed address of the node, unless it doesn't
+// advertise its address.
+func (r *Reactor) selfAddress() (protop2p.PexAddress, bool) {
+	if r.opts.PrivKey == nil || r.opts.SelfAddress == nil {
+		return protop2p.PexAddress{}, false
+	}
+	address, err := p2p.ParseNodeAddress(r.opts.SelfAddress())
+	if err != nil || !isDialable(address) {
+		return protop2p.PexAddress{}, false
+	}
+	pexAddress, err := signAddress(r.opts.PrivKey, address.String())
+	if err != nil {
+		r.logger.Error("failed to sign the node address", "address", address, "err", err)
+		return protop2p.PexAddress{}, false
+	}
+	return pexAddress, true
+}
+
+// pexAddress returns the PEX address of the given address, along with its
+// signature if it was received signed.
+func (r *Reactor) pexAddress(address p2p.NodeAddress) protop2p.PexAddress {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	if pexAddress, ok := r.signedAddresses[address.String()]; ok {
+		return pexAddress
+	}
+	return protop2p.PexAddress{URL: address.String()}
+}
+
+// addSignedAddress keeps the signed address to advertise it along with its
+// signature, evicting an arbitrary one if too many are kept.
+func (r *Reactor) addSignedAddress(address p2p.NodeAddress, pexAddress protop2p.PexAddress) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	key := address.String()
+	if _, ok := r.signedAddresses[key]; !ok && len(r.signedAddresses) >= maxSignedAddresses {
+		for k := range r.signedAddresses {
+			delete(r.signedAddresses, k)
+			break
+		}
+	}
+	r.signedAddresses[key] = pexAddress
+}
+
+// setReportedPeers records the nodes of the addresses in a PEX response of a
+// peer, other than the peer itself.
+func (r *Reactor) setReportedPeers(peer types.NodeID, addresses []p2p.NodeAddress) {
+	nodes := make([]types.NodeID, 0, len(addresses))
+	seen := make(map[types.NodeID]bool, len(addresses))
+	for _, address := range addresses {
+		if address.NodeID != peer && !seen[address.NodeID] {
+			seen[address.NodeID] = true
+			nodes = append(nodes, address.NodeID)
+		}
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.reportedPeers[peer] = nodes
+}
+
+// ReportedPeers returns the nodes in the last PEX response of a connected
+// peer, false if it did not respond to a request yet.
+func (r *Reactor) ReportedPeers(peer types.NodeID) ([]types.NodeID, bool) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	nodes, ok := r.reportedPeers[peer]
+	return nodes, ok
+}
+
 func (r *Reactor) markPeerRequest(peer types.NodeID) error {
 	r.mtx.Lock()
 	defer r.mtx.Unlock()
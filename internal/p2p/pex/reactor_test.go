@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 	dbm "github.com/tendermint/tm-db"
 
+	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/internal/p2p"
 	"github.com/tendermint/tendermint/internal/p2p/p2ptest"
@@ -73,7 +74,7 @@ func TestReactorSendsRequestsTooOften(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	r := setupSingle(ctx, t)
+	r := setupSingle(ctx, t, pex.ReactorOptions{})
 
 	badNode := newNodeID(t, "b")
 
@@ -147,7 +148,7 @@ func TestReactorErrorsOnReceivingTooManyPeers(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	r := setupSingle(ctx, t)
+	r := setupSingle(ctx, t, pex.ReactorOptions{})
 	peer := p2p.NodeAddress{Protocol: p2p.MemoryProtocol, NodeID: randomNodeID(t)}
 	added, err := r.manager.Add(peer)
 	require.NoError(t, err)
@@ -190,6 +191,148 @@ func TestReactorErrorsOnReceivingTooManyPeers(t *testing.T) {
 	require.Equal(t, peer.NodeID, peerErr.NodeID)
 }
 
+func TestReactorSignsAddresses(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	privKey := ed25519.GenPrivKey()
+	selfAddress := types.NodeIDFromPubKey(privKey.PubKey()).AddressString("tcp://1.2.3.4:26656")
+	r := setupSingle(ctx, t, pex.ReactorOptions{
+		PrivKey:     privKey,
+		SelfAddress: func() string { return selfAddress },
+	})
+
+	// a peer's signed address is gossiped along with its signature
+	peer := p2p.NodeAddress{Protocol: p2p.MemoryProtocol, NodeID: randomNodeID(t)}
+	signer := ed25519.GenPrivKey()
+	signed := signedAddress(t, signer)
+	r.respond(t, peer, []p2pproto.PexAddress{signed})
+	require.Eventually(t, func() bool {
+		return len(r.manager.Addresses(types.NodeIDFromPubKey(signer.PubKey()))) > 0
+	}, shortWait, 10*time.Millisecond)
+
+	r.pexInCh <- p2p.Envelope{
+		From:    newNodeID(t, "b"),
+		Message: &p2pproto.PexRequest{},
+	}
+	msg := r.receiveResponse(t)
+	require.Len(t, msg.Addresses, 3)
+
+	// the node's own address comes first, signed with its key
+	self := msg.Addresses[0]
+	require.Equal(t, "mconn://"+selfAddress, self.URL)
+	require.Equal(t, privKey.PubKey().Bytes(), self.PubKey)
+	require.True(t, privKey.PubKey().VerifySignature(
+		[]byte("tendermint/pex-address:"+self.URL), self.Signature))
+
+	require.Contains(t, msg.Addresses, signed)
+	require.Contains(t, msg.Addresses, p2pproto.PexAddress{URL: peer.String()})
+}
+
+func TestReactorVerifiesSignedAddresses(t *testing.T) {
+	testcases := map[string]struct {
+		address       func(t *testing.T) p2pproto.PexAddress
+		requireSigned bool
+		added         bool
+		err           string
+	}{
+		"signed": {
+			address: func(t *testing.T) p2pproto.PexAddress {
+				return signedAddress(t, ed25519.GenPrivKey())
+			},
+			added: true,
+		},
+		"signed by another node": {
+			address: func(t *testing.T) p2pproto.PexAddress {
+				address := signedAddress(t, ed25519.GenPrivKey())
+				address.PubKey = ed25519.GenPrivKey().PubKey().Bytes()
+				return address
+			},
+			err: "peer sent an invalid signed address",
+		},
+		"invalid signature": {
+			address: func(t *testing.T) p2pproto.PexAddress {
+				address := signedAddress(t, ed25519.GenPrivKey())
+				address.Signature[0] ^= 0xff
+				return address
+			},
+			err: "invalid signature",
+		},
+		"unsigned": {
+			address: func(t *testing.T) p2pproto.PexAddress {
+				nodeAddress := p2p.NodeAddress{Protocol: p2p.MemoryProtocol, NodeID: randomNodeID(t)}
+				return p2pproto.PexAddress{URL: nodeAddress.String()}
+			},
+			added: true,
+		},
+		"unsigned when required": {
+			address: func(t *testing.T) p2pproto.PexAddress {
+				nodeAddress := p2p.NodeAddress{Protocol: p2p.MemoryProtocol, NodeID: randomNodeID(t)}
+				return p2pproto.PexAddress{URL: nodeAddress.String()}
+			},
+			requireSigned: true,
+		},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			r := setupSingle(ctx, t, pex.ReactorOptions{RequireSignedAddresses: tc.requireSigned})
+			peer := p2p.NodeAddress{Protocol: p2p.MemoryProtocol, NodeID: randomNodeID(t)}
+			address := tc.address(t)
+			// the signed control address is added once the response is processed
+			control := ed25519.GenPrivKey()
+			r.respond(t, peer, []p2pproto.PexAddress{address, signedAddress(t, control)})
+
+			if tc.err != "" {
+				peerErr := <-r.pexErrCh
+				require.Contains(t, peerErr.Err.Error(), tc.err)
+				require.Equal(t, peer.NodeID, peerErr.NodeID)
+				require.Empty(t, r.manager.Addresses(types.NodeIDFromPubKey(control.PubKey())))
+			} else {
+				require.Eventually(t, func() bool {
+					return len(r.manager.Addresses(types.NodeIDFromPubKey(control.PubKey()))) > 0
+				}, shortWait, 10*time.Millisecond)
+			}
+
+			nodeAddress, err := p2p.ParseNodeAddress(address.URL)
+			require.NoError(t, err)
+			require.Equal(t, tc.added, len(r.manager.Addresses(nodeAddress.NodeID)) > 0)
+		})
+	}
+}
+
+func TestReactorReportedPeers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r := setupSingle(ctx, t, pex.ReactorOptions{})
+	peer := p2p.NodeAddress{Protocol: p2p.MemoryProtocol, NodeID: randomNodeID(t)}
+	_, ok := r.reactor.ReportedPeers(peer.NodeID)
+	require.False(t, ok)
+
+	// the peer reports itself, and another node twice
+	other := p2p.NodeAddress{Protocol: p2p.MemoryProtocol, NodeID: randomNodeID(t)}
+	r.respond(t, peer, []p2pproto.PexAddress{
+		{URL: peer.String()},
+		{URL: other.String()},
+		{URL: other.String()},
+	})
+	require.Eventually(t, func() bool {
+		nodes, ok := r.reactor.ReportedPeers(peer.NodeID)
+		return ok && len(nodes) == 1 && nodes[0] == other.NodeID
+	}, shortWait, 10*time.Millisecond)
+
+	// the reported peers are dropped once the peer disconnects
+	r.peerCh <- p2p.PeerUpdate{NodeID: peer.NodeID, Status: p2p.PeerStatusDown}
+	require.Eventually(t, func() bool {
+		_, ok := r.reactor.ReportedPeers(peer.NodeID)
+		return !ok
+	}, shortWait, 10*time.Millisecond)
+}
+
 func TestReactorSmallPeerStoreInALargeNetwork(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -275,7 +418,7 @@ type singleTestReactor struct {
 	manager  *p2p.PeerManager
 }
 
-func setupSingle(ctx context.Context, t *testing.T) *singleTestReactor {
+func setupSingle(ctx context.Context, t *testing.T, opts pex.ReactorOptions) *singleTestReactor {
 	t.Helper()
 	nodeID := newNodeID(t, "a")
 	chBuf := 2
@@ -295,7 +438,7 @@ func setupSingle(ctx context.Context, t *testing.T) *singleTestReactor {
 	peerManager, err := p2p.NewPeerManager(nodeID, dbm.NewMemDB(), p2p.PeerManagerOptions{})
 	require.NoError(t, err)
 
-	reactor := pex.NewReactor(log.TestingLogger(), peerManager, pexCh, peerUpdates)
+	reactor := pex.NewReactor(log.TestingLogger(), peerManager, pexCh, peerUpdates, opts)
 	require.NoError(t, reactor.Start(ctx))
 	t.Cleanup(reactor.Wait)
 
@@ -310,6 +453,64 @@ func setupSingle(ctx context.Context, t *testing.T) *singleTestReactor {
 	}
 }
 
+// respond sends the reactor the given addresses in response to its request to
+// the given peer.
+func (r *singleTestReactor) respond(t *testing.T, peer p2p.NodeAddress, addresses []p2pproto.PexAddress) {
+	t.Helper()
+
+	added, err := r.manager.Add(peer)
+	require.NoError(t, err)
+	require.True(t, added)
+	r.peerCh <- p2p.PeerUpdate{
+		NodeID: peer.NodeID,
+		Status: p2p.PeerStatusUp,
+	}
+
+	select {
+	case req := <-r.pexOutCh:
+		_, ok := req.Message.(*p2pproto.PexRequest)
+		require.True(t, ok)
+		r.pexInCh <- p2p.Envelope{
+			From:    peer.NodeID,
+			Message: &p2pproto.PexResponse{Addresses: addresses},
+		}
+	case <-time.After(shortWait):
+		t.Fatal("pex failed to send a request")
+	}
+}
+
+// receiveResponse returns the next response sent by the reactor.
+func (r *singleTestReactor) receiveResponse(t *testing.T) *p2pproto.PexResponse {
+	t.Helper()
+
+	timeout := time.After(shortWait)
+	for {
+		select {
+		case envelope := <-r.pexOutCh:
+			if msg, ok := envelope.Message.(*p2pproto.PexResponse); ok {
+				return msg
+			}
+		case <-timeout:
+			t.Fatal("pex failed to send a response")
+		}
+	}
+}
+
+// signedAddress returns the address of the node with the given key, signed by
+// it.
+func signedAddress(t *testing.T, privKey crypto.PrivKey) p2pproto.PexAddress {
+	t.Helper()
+
+	address := p2p.NodeAddress{Protocol: p2p.MemoryProtocol, NodeID: types.NodeIDFromPubKey(privKey.PubKey())}
+	sig, err := privKey.Sign([]byte("tendermint/pex-address:" + address.String()))
+	require.NoError(t, err)
+	return p2pproto.PexAddress{
+		URL:       address.String(),
+		PubKey:    privKey.PubKey().Bytes(),
+		Signature: sig,
+	}
+}
+
 type reactorTestSuite struct {
 	network *p2ptest.Network
 	logger  log.Logger
@@ -384,6 +585,7 @@ func setupNetwork(ctx context.Context, t *testing.T, opts testOptions) *reactorT
 				rts.network.Nodes[nodeID].PeerManager,
 				rts.pexChannels[nodeID],
 				rts.peerUpdates[nodeID],
+				pex.ReactorOptions{},
 			)
 		}
 		rts.nodes = append(rts.nodes, nodeID)
@@ -434,6 +636,7 @@ func (r *reactorTestSuite) addNodes(ctx context.Context, t *testing.T, nodes int
 			r.network.Nodes[nodeID].PeerManager,
 			r.pexChannels[nodeID],
 			r.peerUpdates[nodeID],
+			pex.ReactorOptions{},
 		)
 		r.nodes = append(r.nodes, nodeID)
 		r.total++
@@ -670,10 +873,8 @@ func (r *reactorTestSuite) connectPeers(ctx context.Context, t *testing.T, sourc
 
 	select {
 	case peerUpdate := <-targetSub.Updates():
-		require.Equal(t, p2p.PeerUpdate{
-			NodeID: node1,
-			Status: p2p.PeerStatusUp,
-		}, peerUpdate)
+		require.Equal(t, node1, peerUpdate.NodeID)
+		require.Equal(t, p2p.PeerStatusUp, peerUpdate.Status)
 		r.logger.Debug("target connected with source")
 	case <-time.After(2 * time.Second):
 		require.Fail(t, "timed out waiting for peer", "%v accepting %v",
@@ -682,10 +883,8 @@ func (r *reactorTestSuite) connectPeers(ctx context.Context, t *testing.T, sourc
 
 	select {
 	case peerUpdate := <-sourceSub.Updates():
-		require.Equal(t, p2p.PeerUpdate{
-			NodeID: node2,
-			Status: p2p.PeerStatusUp,
-		}, peerUpdate)
+		require.Equal(t, node2, peerUpdate.NodeID)
+		require.Equal(t, p2p.PeerStatusUp, peerUpdate.Status)
 		r.logger.Debug("source connected with target")
 	case <-time.After(2 * time.Second):
 		require.Fail(t, "timed out waiting for peer", "%v dialing %v",
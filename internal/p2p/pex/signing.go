@@ -0,0 +1,67 @@
+package pex
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/internal/p2p"
+	protop2p "github.com/tendermint/tendermint/proto/tendermint/p2p"
+	"github.com/tendermint/tendermint/types"
+)
+
+// maxSignedAddresses is the maximum number of signed addresses of other nodes
+// kept to be gossiped along with their signatures.
+const maxSignedAddresses = 1000
+
+// addressSignBytes returns the bytes signed by a node to vouch for its address
+// URL.
+func addressSignBytes(url string) []byte {
+	return []byte("tendermint/pex-address:" + url)
+}
+
+// signAddress returns the PEX address of the given URL, signed with privKey.
+func signAddress(privKey crypto.PrivKey, url string) (protop2p.PexAddress, error) {
+	sig, err := privKey.Sign(addressSignBytes(url))
+	if err != nil {
+		return protop2p.PexAddress{}, err
+	}
+	return protop2p.PexAddress{
+		URL:       url,
+		PubKey:    privKey.PubKey().Bytes(),
+		Signature: sig,
+	}, nil
+}
+
+// isSigned returns true if the PEX address carries a signature, valid or not.
+func isSigned(pexAddress protop2p.PexAddress) bool {
+	return len(pexAddress.PubKey) > 0 || len(pexAddress.Signature) > 0
+}
+
+// verifyAddress verifies that the signed PEX address, parsed as address, was
+// signed by the node it points at.
+func verifyAddress(address p2p.NodeAddress, pexAddress protop2p.PexAddress) error {
+	if len(pexAddress.PubKey) != ed25519.PubKeySize {
+		return fmt.Errorf("invalid public key size %d", len(pexAddress.PubKey))
+	}
+	pubKey := ed25519.PubKey(pexAddress.PubKey)
+	if id := types.NodeIDFromPubKey(pubKey); id != address.NodeID {
+		return fmt.Errorf("address of node %v signed by node %v", address.NodeID, id)
+	}
+	if !pubKey.VerifySignature(addressSignBytes(pexAddress.URL), pexAddress.Signature) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+// isDialable returns false if the address can't be dialed by peers, i.e. if it
+// has no hostname or an unspecified IP such as 0.0.0.0.
+func isDialable(address p2p.NodeAddress) bool {
+	if address.Hostname == "" {
+		return false
+	}
+	ip := net.ParseIP(address.Hostname)
+	return ip == nil || !ip.IsUnspecified()
+}
@@ -3,8 +3,8 @@ package p2p
 import (
 	"container/heap"
 	"context"
-	"sort"
 	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
@@ -18,7 +18,10 @@ type pqEnvelope struct {
 	envelope  Envelope
 	priority  uint
 	size      uint
-	timestamp time.Time
+	seq       uint64    // enqueue order, to dequeue in order within a priority
+	timestamp time.Time // when the envelope was enqueued
+	deadline  time.Time // when the envelope expires, zero if it doesn't
+	peerLabel string    // peer_id metric label of the recipient when enqueued
 
 	index int
 }
@@ -30,9 +33,10 @@ func (pq priorityQueue) get(i int) *pqEnvelope { return pq[i] }
 func (pq priorityQueue) Len() int              { return len(pq) }
 
 func (pq priorityQueue) Less(i, j int) bool {
-	// if both elements have the same priority, prioritize based on most recent
+	// if both elements have the same priority, keep the order they were
+	// enqueued in, as reactors may rely on it
 	if pq[i].priority == pq[j].priority {
-		return pq[i].timestamp.After(pq[j].timestamp)
+		return pq[i].seq < pq[j].seq
 	}
 
 	// otherwise, pick the pqEnvelope with the higher priority
@@ -70,11 +74,13 @@ type pqScheduler struct {
 	logger       log.Logger
 	metrics      *Metrics
 	size         uint
-	sizes        map[uint]uint // cumulative priority sizes
+	sizes        map[uint]uint // queued sizes per priority
 	pq           *priorityQueue
-	chDescs      []*ChannelDescriptor
 	capacity     uint
 	chPriorities map[ChannelID]uint
+	chTTLs       map[ChannelID]time.Duration
+	seq          uint64
+	pqLen        int64 // length of pq, for len()
 
 	enqueueCh chan Envelope
 	dequeueCh chan Envelope
@@ -89,19 +95,16 @@ func newPQScheduler(
 	enqueueBuf, dequeueBuf, capacity uint,
 ) *pqScheduler {
 
-	// copy each ChannelDescriptor and sort them by ascending channel priority
-	chDescsCopy := make([]*ChannelDescriptor, len(chDescs))
-	copy(chDescsCopy, chDescs)
-	sort.Slice(chDescsCopy, func(i, j int) bool { return chDescsCopy[i].Priority < chDescsCopy[j].Priority })
-
 	var (
 		chPriorities = make(map[ChannelID]uint)
+		chTTLs       = make(map[ChannelID]time.Duration)
 		sizes        = make(map[uint]uint)
 	)
 
-	for _, chDesc := range chDescsCopy {
+	for _, chDesc := range chDescs {
 		chID := chDesc.ID
 		chPriorities[chID] = uint(chDesc.Priority)
+		chTTLs[chID] = chDesc.MessageTTL
 		sizes[uint(chDesc.Priority)] = 0
 	}
 
@@ -111,9 +114,9 @@ func newPQScheduler(
 	return &pqScheduler{
 		logger:       logger.With("router", "scheduler"),
 		metrics:      m,
-		chDescs:      chDescsCopy,
 		capacity:     capacity,
 		chPriorities: chPriorities,
+		chTTLs:       chTTLs,
 		pq:           &pq,
 		sizes:        sizes,
 		enqueueCh:    make(chan Envelope, enqueueBuf),
@@ -140,140 +143,177 @@ func (s *pqScheduler) closed() <-chan struct{} {
 	return s.closer.Done()
 }
 
+func (s *pqScheduler) len() int {
+	return len(s.enqueueCh) + int(atomic.LoadInt64(&s.pqLen)) + len(s.dequeueCh)
+}
+
 // start starts non-blocking process that starts the priority queue scheduler.
 func (s *pqScheduler) start(ctx context.Context) {
 	go s.process(ctx)
 }
 
-// process starts a block process where we listen for Envelopes to enqueue. If
-// there is sufficient capacity, it will be enqueued into the priority queue,
-// otherwise, we attempt to dequeue enough elements from the priority queue to
-// make room for the incoming Envelope by dropping lower priority elements. If
-// there isn't sufficient capacity at lower priorities for the incoming Envelope,
-// it is dropped.
-//
-// After we attempt to enqueue the incoming Envelope, if the priority queue is
-// non-empty, we pop the top Envelope and send it on the dequeueCh.
+// process starts a block process where we listen for Envelopes to enqueue,
+// and offer the highest priority queued Envelope on the dequeueCh. Envelopes
+// keep being enqueued while waiting for the top Envelope to be dequeued, so
+// that higher priority Envelopes overtake lower priority ones queued before
+// them. Envelopes whose TTL expires before they are dequeued are dropped.
 func (s *pqScheduler) process(ctx context.Context) {
 	defer s.done.Close()
 
+	expireTimer := time.NewTimer(0)
+	defer expireTimer.Stop()
+	<-expireTimer.C
+
 	for {
+		var (
+			dequeueCh chan Envelope
+			next      *pqEnvelope
+			expireCh  <-chan time.Time
+		)
+
+		now := time.Now()
+		for s.pq.Len() > 0 && s.expired(s.pq.get(0), now) {
+			s.expire(s.pq.get(0))
+		}
+		if s.pq.Len() > 0 {
+			next = s.pq.get(0)
+			dequeueCh = s.dequeueCh
+			if !next.deadline.IsZero() {
+				expireTimer.Reset(next.deadline.Sub(now))
+				expireCh = expireTimer.C
+			}
+		}
+
+		var envelope Envelope
+		if next != nil {
+			envelope = next.envelope
+		}
+
 		select {
 		case e := <-s.enqueueCh:
-			chIDStr := strconv.Itoa(int(e.ChannelID))
-			pqEnv := &pqEnvelope{
-				envelope:  e,
-				size:      uint(proto.Size(e.Message)),
-				priority:  s.chPriorities[e.ChannelID],
-				timestamp: time.Now().UTC(),
-			}
+			s.enqueueEnvelope(e, time.Now())
 
-			// enqueue
-
-			// Check if we have sufficient capacity to simply enqueue the incoming
-			// Envelope.
-			if s.size+pqEnv.size <= s.capacity {
-				s.metrics.PeerPendingSendBytes.With("peer_id", string(pqEnv.envelope.To)).Add(float64(pqEnv.size))
-				// enqueue the incoming Envelope
-				s.push(pqEnv)
-			} else {
-				// There is not sufficient capacity to simply enqueue the incoming
-				// Envelope. So we have to attempt to make room for it by dropping lower
-				// priority Envelopes or drop the incoming Envelope otherwise.
-
-				// The cumulative size of all enqueue envelopes at the incoming envelope's
-				// priority or lower.
-				total := s.sizes[pqEnv.priority]
-
-				if total >= pqEnv.size {
-					// There is room for the incoming Envelope, so we drop as many lower
-					// priority Envelopes as we need to.
-					var (
-						canEnqueue bool
-						tmpSize    = s.size
-						i          = s.pq.Len() - 1
-					)
-
-					// Drop lower priority Envelopes until sufficient capacity exists for
-					// the incoming Envelope
-					for i >= 0 && !canEnqueue {
-						pqEnvTmp := s.pq.get(i)
-
-						if pqEnvTmp.priority < pqEnv.priority {
-							if tmpSize+pqEnv.size <= s.capacity {
-								canEnqueue = true
-							} else {
-								pqEnvTmpChIDStr := strconv.Itoa(int(pqEnvTmp.envelope.ChannelID))
-								s.metrics.PeerQueueDroppedMsgs.With("ch_id", pqEnvTmpChIDStr).Add(1)
-								s.logger.Debug(
-									"dropped envelope",
-									"ch_id", pqEnvTmpChIDStr,
-									"priority", pqEnvTmp.priority,
-									"msg_size", pqEnvTmp.size,
-									"capacity", s.capacity,
-								)
-
-								s.metrics.PeerPendingSendBytes.With("peer_id", string(pqEnvTmp.envelope.To)).Add(float64(-pqEnvTmp.size))
-
-								// dequeue/drop from the priority queue
-								heap.Remove(s.pq, pqEnvTmp.index)
-
-								// update the size tracker
-								tmpSize -= pqEnvTmp.size
-
-								// start from the end again
-								i = s.pq.Len() - 1
-							}
-						} else {
-							i--
-						}
-					}
-
-					// enqueue the incoming Envelope
-					s.push(pqEnv)
-				} else {
-					// There is not sufficient capacity to drop lower priority Envelopes,
-					// so we drop the incoming Envelope.
-					s.metrics.PeerQueueDroppedMsgs.With("ch_id", chIDStr).Add(1)
-					s.logger.Debug(
-						"dropped envelope",
-						"ch_id", chIDStr,
-						"priority", pqEnv.priority,
-						"msg_size", pqEnv.size,
-						"capacity", s.capacity,
-					)
-				}
-			}
+		case dequeueCh <- envelope:
+			s.remove(next)
+
+			chIDStr := strconv.Itoa(int(next.envelope.ChannelID))
+			s.metrics.RouterQueueLatency.With("ch_id", chIDStr).Observe(time.Since(next.timestamp).Seconds())
+			s.metrics.PeerSendBytesTotal.With(
+				"chID", chIDStr,
+				"peer_id", next.peerLabel,
+				"message_type", s.metrics.ValueToMetricLabel(next.envelope.Message)).Add(float64(next.size))
+
+		case <-expireCh:
+			// the top Envelope expired, it is dropped in the next iteration
+			continue
 
-			// dequeue
-
-			for s.pq.Len() > 0 {
-				pqEnv = heap.Pop(s.pq).(*pqEnvelope)
-				s.size -= pqEnv.size
-
-				// deduct the Envelope size from all the relevant cumulative sizes
-				for i := 0; i < len(s.chDescs) && pqEnv.priority <= uint(s.chDescs[i].Priority); i++ {
-					s.sizes[uint(s.chDescs[i].Priority)] -= pqEnv.size
-				}
-
-				s.metrics.PeerSendBytesTotal.With(
-					"chID", chIDStr,
-					"peer_id", string(pqEnv.envelope.To),
-					"message_type", s.metrics.ValueToMetricLabel(pqEnv.envelope.Message)).Add(float64(pqEnv.size))
-				s.metrics.PeerPendingSendBytes.With(
-					"peer_id", string(pqEnv.envelope.To)).Add(float64(-pqEnv.size))
-				select {
-				case s.dequeueCh <- pqEnv.envelope:
-				case <-s.closer.Done():
-					return
-				}
-			}
 		case <-ctx.Done():
 			return
 		case <-s.closer.Done():
 			return
 		}
+
+		// stop the timer if it didn't fire, so that it can be reset
+		if expireCh != nil && !expireTimer.Stop() {
+			<-expireTimer.C
+		}
+	}
+}
+
+// enqueueEnvelope enqueues the incoming Envelope if there is sufficient
+// capacity, otherwise it attempts to make room for it by dropping lower
+// priority Envelopes. If there isn't sufficient capacity at lower priorities
+// for the incoming Envelope, it is dropped.
+func (s *pqScheduler) enqueueEnvelope(e Envelope, now time.Time) {
+	chIDStr := strconv.Itoa(int(e.ChannelID))
+	s.seq++
+	pqEnv := &pqEnvelope{
+		envelope:  e,
+		size:      uint(proto.Size(e.Message)),
+		priority:  s.chPriorities[e.ChannelID],
+		seq:       s.seq,
+		timestamp: now,
+		peerLabel: s.metrics.PeerLabel(e.To),
+	}
+	ttl := e.TTL
+	if ttl == 0 {
+		ttl = s.chTTLs[e.ChannelID]
+	}
+	if ttl > 0 {
+		pqEnv.deadline = now.Add(ttl)
+	}
+
+	// The cumulative size of all enqueued envelopes at a lower priority than
+	// the incoming envelope's, which can be dropped to make room for it.
+	var droppable uint
+	for priority, size := range s.sizes {
+		if priority < pqEnv.priority {
+			droppable += size
+		}
+	}
+
+	if s.size+pqEnv.size > s.capacity+droppable {
+		// There is not sufficient capacity to drop lower priority Envelopes,
+		// so we drop the incoming Envelope.
+		s.metrics.PeerQueueDroppedMsgs.With("ch_id", chIDStr).Add(1)
+		s.metrics.PeerSendFailuresTotal.With("peer_id", pqEnv.peerLabel, "ch_id", chIDStr).Add(1)
+		s.logger.Debug(
+			"dropped envelope",
+			"ch_id", chIDStr,
+			"priority", pqEnv.priority,
+			"msg_size", pqEnv.size,
+			"capacity", s.capacity,
+		)
+		return
 	}
+
+	// Drop the lowest priority Envelopes, most recently enqueued first, until
+	// sufficient capacity exists for the incoming Envelope.
+	for s.size+pqEnv.size > s.capacity {
+		var drop *pqEnvelope
+		for _, queued := range *s.pq {
+			if queued.priority >= pqEnv.priority {
+				continue
+			}
+			if drop == nil || queued.priority < drop.priority ||
+				(queued.priority == drop.priority && queued.seq > drop.seq) {
+				drop = queued
+			}
+		}
+
+		dropChIDStr := strconv.Itoa(int(drop.envelope.ChannelID))
+		s.metrics.PeerQueueDroppedMsgs.With("ch_id", dropChIDStr).Add(1)
+		s.metrics.PeerSendFailuresTotal.With("peer_id", drop.peerLabel, "ch_id", dropChIDStr).Add(1)
+		s.logger.Debug(
+			"dropped envelope",
+			"ch_id", dropChIDStr,
+			"priority", drop.priority,
+			"msg_size", drop.size,
+			"capacity", s.capacity,
+		)
+		s.remove(drop)
+	}
+
+	s.push(pqEnv)
+}
+
+// expired returns whether the Envelope's TTL expired.
+func (s *pqScheduler) expired(pqEnv *pqEnvelope, now time.Time) bool {
+	return !pqEnv.deadline.IsZero() && !now.Before(pqEnv.deadline)
+}
+
+// expire drops an Envelope whose TTL expired.
+func (s *pqScheduler) expire(pqEnv *pqEnvelope) {
+	chIDStr := strconv.Itoa(int(pqEnv.envelope.ChannelID))
+	s.metrics.PeerQueueExpiredMsgs.With("ch_id", chIDStr).Add(1)
+	s.metrics.PeerSendFailuresTotal.With("peer_id", pqEnv.peerLabel, "ch_id", chIDStr).Add(1)
+	s.logger.Debug(
+		"dropped expired envelope",
+		"ch_id", chIDStr,
+		"priority", pqEnv.priority,
+		"queued", time.Since(pqEnv.timestamp),
+	)
+	s.remove(pqEnv)
 }
 
 func (s *pqScheduler) push(pqEnv *pqEnvelope) {
@@ -281,12 +321,19 @@ func (s *pqScheduler) push(pqEnv *pqEnvelope) {
 
 	// enqueue the incoming Envelope
 	heap.Push(s.pq, pqEnv)
+	atomic.AddInt64(&s.pqLen, 1)
 	s.size += pqEnv.size
+	s.sizes[pqEnv.priority] += pqEnv.size
 	s.metrics.PeerQueueMsgSize.With("ch_id", chIDStr).Add(float64(pqEnv.size))
+	s.metrics.PeerPendingSendBytes.With("peer_id", pqEnv.peerLabel).Add(float64(pqEnv.size))
+}
 
-	// Update the cumulative sizes by adding the Envelope's size to every
-	// priority less than or equal to it.
-	for i := 0; i < len(s.chDescs) && pqEnv.priority <= uint(s.chDescs[i].Priority); i++ {
-		s.sizes[uint(s.chDescs[i].Priority)] += pqEnv.size
-	}
+// remove removes an Envelope from the priority queue, when it is dequeued or
+// dropped.
+func (s *pqScheduler) remove(pqEnv *pqEnvelope) {
+	heap.Remove(s.pq, pqEnv.index)
+	atomic.AddInt64(&s.pqLen, -1)
+	s.size -= pqEnv.size
+	s.sizes[pqEnv.priority] -= pqEnv.size
+	s.metrics.PeerPendingSendBytes.With("peer_id", pqEnv.peerLabel).Add(float64(-pqEnv.size))
 }
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	gogotypes "github.com/gogo/protobuf/types"
+	"github.com/stretchr/testify/require"
+
 	"github.com/tendermint/tendermint/libs/log"
 )
 
@@ -44,3 +46,103 @@ func TestCloseWhileDequeueFull(t *testing.T) {
 		t.Fatal("pqueue failed to close")
 	}
 }
+
+// dequeueAll dequeues the envelopes available from the queue, until none
+// becomes available for a while.
+func dequeueAll(t *testing.T, q queue) []string {
+	t.Helper()
+	var values []string
+	for {
+		select {
+		case e := <-q.dequeue():
+			values = append(values, e.Message.(*testMessage).Value)
+		case <-time.After(50 * time.Millisecond):
+			return values
+		}
+	}
+}
+
+func TestPQSchedulerPriority(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	chDescs := []*ChannelDescriptor{
+		{ID: 0x01, Priority: 1},
+		{ID: 0x02, Priority: 2},
+	}
+	pqueue := newPQScheduler(log.NewNopLogger(), NopMetrics(), chDescs, 1, 0, 1000)
+	pqueue.start(ctx)
+	defer pqueue.close()
+
+	// higher priority envelopes overtake lower priority ones queued before
+	// them, and envelopes with the same priority keep their order
+	for _, e := range []Envelope{
+		{ChannelID: 0x01, Message: &testMessage{Value: "low1"}},
+		{ChannelID: 0x01, Message: &testMessage{Value: "low2"}},
+		{ChannelID: 0x02, Message: &testMessage{Value: "high1"}},
+		{ChannelID: 0x01, Message: &testMessage{Value: "low3"}},
+		{ChannelID: 0x02, Message: &testMessage{Value: "high2"}},
+	} {
+		pqueue.enqueue() <- e
+	}
+	require.Eventually(t, func() bool { return pqueue.len() == 5 }, time.Second, 10*time.Millisecond)
+	require.Equal(t, []string{"high1", "high2", "low1", "low2", "low3"}, dequeueAll(t, pqueue))
+	require.Zero(t, pqueue.len())
+}
+
+func TestPQSchedulerTTL(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	chDescs := []*ChannelDescriptor{
+		{ID: 0x01, Priority: 1},
+		{ID: 0x02, Priority: 2, MessageTTL: 50 * time.Millisecond},
+	}
+	pqueue := newPQScheduler(log.NewNopLogger(), NopMetrics(), chDescs, 1, 0, 1000)
+	pqueue.start(ctx)
+	defer pqueue.close()
+
+	// the channel's TTL applies unless the envelope overrides it
+	for _, e := range []Envelope{
+		{ChannelID: 0x01, Message: &testMessage{Value: "forever"}},
+		{ChannelID: 0x01, Message: &testMessage{Value: "short"}, TTL: 50 * time.Millisecond},
+		{ChannelID: 0x02, Message: &testMessage{Value: "channel"}},
+		{ChannelID: 0x02, Message: &testMessage{Value: "long"}, TTL: time.Hour},
+	} {
+		pqueue.enqueue() <- e
+	}
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, []string{"long", "forever"}, dequeueAll(t, pqueue))
+}
+
+func TestPQSchedulerCapacity(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	chDescs := []*ChannelDescriptor{
+		{ID: 0x01, Priority: 1},
+		{ID: 0x02, Priority: 2},
+	}
+	// each message is 6 bytes, so 3 fit
+	pqueue := newPQScheduler(log.NewNopLogger(), NopMetrics(), chDescs, 1, 0, 18)
+	pqueue.start(ctx)
+	defer pqueue.close()
+
+	for _, e := range []Envelope{
+		{ChannelID: 0x01, Message: &testMessage{Value: "lo_1"}},
+		{ChannelID: 0x01, Message: &testMessage{Value: "lo_2"}},
+		{ChannelID: 0x02, Message: &testMessage{Value: "hi_1"}},
+		{ChannelID: 0x02, Message: &testMessage{Value: "hi_2"}}, // drops lo_2
+		{ChannelID: 0x02, Message: &testMessage{Value: "hi_3"}}, // drops lo_1
+		{ChannelID: 0x02, Message: &testMessage{Value: "hi_4"}}, // is dropped
+		{ChannelID: 0x01, Message: &testMessage{Value: "lo_3"}}, // is dropped
+	} {
+		pqueue.enqueue() <- e
+	}
+	require.Eventually(t, func() bool { return pqueue.len() == 3 }, time.Second, 10*time.Millisecond)
+	require.Equal(t, []string{"hi_1", "hi_2", "hi_3"}, dequeueAll(t, pqueue))
+
+	// the sizes of dequeued and dropped envelopes are deducted
+	pqueue.close()
+	require.Zero(t, pqueue.size)
+}
@@ -26,6 +26,9 @@ type queue interface {
 
 	// closed returns a channel that's closed when the scheduler is closed.
 	closed() <-chan struct{}
+
+	// len returns the number of queued envelopes.
+	len() int
 }
 
 // fifoQueue is a simple unbuffered lossless queue that passes messages through
@@ -57,3 +60,7 @@ func (q *fifoQueue) close() {
 func (q *fifoQueue) closed() <-chan struct{} {
 	return q.closer.Done()
 }
+
+func (q *fifoQueue) len() int {
+	return len(q.queueCh)
+}
@@ -9,6 +9,7 @@ import (
 	"net"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
@@ -21,6 +22,10 @@ import (
 
 const queueBufferDefault = 32
 
+// queueMetricsInterval is how often the peer and channel queue depth metrics
+// are updated.
+const queueMetricsInterval = 10 * time.Second
+
 // RouterOptions specifies options for a Router.
 type RouterOptions struct {
 	// ResolveTimeout is the timeout for resolving NodeAddress URLs.
@@ -72,6 +77,18 @@ type RouterOptions struct {
 	// are used to dial peers. This defaults to the value of
 	// runtime.NumCPU.
 	NumConcurrentDials func() int
+
+	// ObservedAddress is called with the IP address that a peer reports it
+	// observed our connection from during the handshake, e.g. to detect the
+	// external address of a node behind a NAT.
+	ObservedAddress func(types.NodeID, net.IP)
+
+	// Networks are networks other than the one of the node info whose peers
+	// are accepted, e.g. by a seed node serving several networks. The router
+	// then waits for the node info of a peer during the handshake, and
+	// presents its network to it. Two such routers can't handshake with each
+	// other.
+	Networks []string
 }
 
 const (
@@ -150,6 +167,7 @@ type Router struct {
 
 	metrics            *Metrics
 	options            RouterOptions
+	nodeInfoMtx        sync.RWMutex
 	nodeInfo           types.NodeInfo
 	privKey            crypto.PrivKey
 	peerManager        *PeerManager
@@ -158,11 +176,14 @@ type Router struct {
 	endpoints          []Endpoint
 	connTracker        connectionTracker
 	protocolTransports map[Protocol]Transport
+	draining           uint32 // atomic
 
 	peerMtx    sync.RWMutex
 	peerQueues map[types.NodeID]queue // outbound messages per peer for all channels
 	// the channels that the peer queue has open
 	peerChannels map[types.NodeID]channelIDs
+	// the connections of the peers, measuring their round-trip time
+	peerRTTs     map[types.NodeID]rttReporter
 	queueFactory func(int) queue
 
 	// FIXME: We don't strictly need to use a mutex for this if we seal the
@@ -211,6 +232,7 @@ func NewRouter(
 		channelMessages:    map[ChannelID]proto.Message{},
 		peerQueues:         map[types.NodeID]queue{},
 		peerChannels:       make(map[types.NodeID]channelIDs),
+		peerRTTs:           make(map[types.NodeID]rttReporter),
 	}
 
 	router.BaseService = service.NewBaseService(logger, "router", router)
@@ -240,11 +262,9 @@ func (r *Router) createQueueFactory(ctx context.Context) (func(int) queue, error
 
 	case queueTypePriority:
 		return func(size int) queue {
-			if size%2 != 0 {
-				size++
-			}
-
-			q := newPQScheduler(r.logger, r.metrics, r.chDescs, uint(size)/2, uint(size)/2, defaultCapacity)
+			// Envelopes are not buffered once dequeued, so that they are
+			// reordered and expire until they are consumed.
+			q := newPQScheduler(r.logger, r.metrics, r.chDescs, uint(size), 0, defaultCapacity)
 			q.start(ctx)
 			return q
 		}, nil
@@ -286,7 +306,9 @@ func (r *Router) OpenChannel(ctx context.Context, chDesc *ChannelDescriptor) (*C
 	r.channelMessages[id] = messageType
 
 	// add the channel to the nodeInfo if it's not already there.
+	r.nodeInfoMtx.Lock()
 	r.nodeInfo.AddChannel(uint16(chDesc.ID))
+	r.nodeInfoMtx.Unlock()
 
 	for _, t := range r.transports {
 		t.AddChannelDescriptors([]*ChannelDescriptor{chDesc})
@@ -350,8 +372,9 @@ func (r *Router) routeChannel(
 				for nodeID, q := range r.peerQueues {
 					peerChs := r.peerChannels[nodeID]
 
-					// check whether the peer is receiving on that channel
-					if _, ok := peerChs[chID]; ok {
+					// check whether the peer is receiving on that channel, and
+					// whether its gossip policy allows sending on it
+					if _, ok := peerChs[chID]; ok && r.peerManager.GossipPolicy(nodeID).Allows(chID) {
 						queues = append(queues, q)
 					}
 				}
@@ -372,6 +395,9 @@ func (r *Router) routeChannel(
 
 				if !ok {
 					r.logger.Debug("dropping message for unconnected peer", "peer", envelope.To, "channel", chID)
+					r.metrics.PeerSendFailuresTotal.With(
+						"peer_id", r.metrics.PeerLabel(envelope.To),
+						"ch_id", fmt.Sprint(chID)).Add(1)
 					continue
 				}
 
@@ -383,6 +409,11 @@ func (r *Router) routeChannel(
 					continue
 				}
 
+				if !r.peerManager.GossipPolicy(envelope.To).Allows(chID) {
+					r.logger.Debug("dropping message blocked by gossip policy", "peer", envelope.To, "channel", chID)
+					continue
+				}
+
 				queues = []queue{q}
 			}
 
@@ -396,6 +427,11 @@ func (r *Router) routeChannel(
 
 				case <-q.closed():
 					r.logger.Debug("dropping message for unconnected peer", "peer", envelope.To, "channel", chID)
+					if !envelope.Broadcast {
+						r.metrics.PeerSendFailuresTotal.With(
+							"peer_id", r.metrics.PeerLabel(envelope.To),
+							"ch_id", fmt.Sprint(chID)).Add(1)
+					}
 
 				case <-ctx.Done():
 					return
@@ -481,6 +517,12 @@ func (r *Router) acceptPeers(ctx context.Context, transport Transport) {
 			return
 		}
 
+		if r.IsDraining() {
+			r.logger.Debug("rejecting incoming connection while draining", "remote", conn.RemoteEndpoint())
+			conn.Close()
+			continue
+		}
+
 		incomingIP := conn.RemoteEndpoint().IP
 		if err := r.connTracker.AddConn(incomingIP); err != nil {
 			closeErr := conn.Close()
@@ -539,13 +581,28 @@ func (r *Router) openConnection(ctx context.Context, conn Connection) {
 		return
 	}
 
-	if err := r.runWithPeerMutex(func() error { return r.peerManager.Accepted(peerInfo.NodeID) }); err != nil {
+	if err := r.runWithPeerMutex(func() error {
+		if err := r.peerManager.SetValidatorAddress(peerInfo.NodeID, validatorAddress(peerInfo)); err != nil {
+			return err
+		}
+		if err := r.peerManager.SetCapabilities(peerInfo.NodeID, peerInfo.Capabilities); err != nil {
+			return err
+		}
+		if err := r.peerManager.SetNetwork(peerInfo.NodeID, peerInfo.Network); err != nil {
+			return err
+		}
+		if err := r.peerManager.SetNodeInfo(peerInfo.NodeID, peerInfo); err != nil {
+			return err
+		}
+		r.peerManager.SetRemoteIP(peerInfo.NodeID, incomingIP)
+		return r.peerManager.Accepted(peerInfo.NodeID)
+	}); err != nil {
 		r.logger.Error("failed to accept connection",
 			"op", "incoming/accepted", "peer", peerInfo.NodeID, "err", err)
 		return
 	}
 
-	r.routePeer(ctx, peerInfo.NodeID, conn, toChannelIDs(peerInfo.Channels))
+	r.routePeer(ctx, peerInfo.NodeID, conn, peerInfo)
 }
 
 // dialPeers maintains outbound connections to peers by dialing them.
@@ -607,6 +664,13 @@ LOOP:
 }
 
 func (r *Router) connectPeer(ctx context.Context, address NodeAddress) {
+	if r.IsDraining() {
+		// The peer remains marked as dialing in the peer manager, which keeps
+		// it from being dialed again until the router stops.
+		r.logger.Debug("not dialing peer while draining", "peer", address)
+		return
+	}
+
 	conn, err := r.dialPeer(ctx, address)
 	switch {
 	case errors.Is(err, context.Canceled):
@@ -633,7 +697,22 @@ func (r *Router) connectPeer(ctx context.Context, address NodeAddress) {
 		return
 	}
 
-	if err := r.runWithPeerMutex(func() error { return r.peerManager.Dialed(address) }); err != nil {
+	if err := r.runWithPeerMutex(func() error {
+		if err := r.peerManager.SetValidatorAddress(address.NodeID, validatorAddress(peerInfo)); err != nil {
+			return err
+		}
+		if err := r.peerManager.SetCapabilities(address.NodeID, peerInfo.Capabilities); err != nil {
+			return err
+		}
+		if err := r.peerManager.SetNetwork(address.NodeID, peerInfo.Network); err != nil {
+			return err
+		}
+		if err := r.peerManager.SetNodeInfo(address.NodeID, peerInfo); err != nil {
+			return err
+		}
+		r.peerManager.SetRemoteIP(address.NodeID, conn.RemoteEndpoint().IP)
+		return r.peerManager.Dialed(address)
+	}); err != nil {
 		r.logger.Error("failed to dial peer",
 			"op", "outgoing/dialing", "peer", address.NodeID, "err", err)
 		conn.Close()
@@ -641,7 +720,7 @@ func (r *Router) connectPeer(ctx context.Context, address NodeAddress) {
 	}
 
 	// routePeer (also) calls connection close
-	go r.routePeer(ctx, address.NodeID, conn, toChannelIDs(peerInfo.Channels))
+	go r.routePeer(ctx, address.NodeID, conn, peerInfo)
 }
 
 func (r *Router) getOrMakeQueue(peerID types.NodeID, channels channelIDs) queue {
@@ -676,6 +755,23 @@ func (r *Router) dialPeer(ctx context.Context, address NodeAddress) (Connection,
 		return nil, fmt.Errorf("address %q did not resolve to any endpoints", address)
 	}
 
+	// the endpoints resolved share the address' protocol, so a transport
+	// dialing several endpoints at once gets them all
+	if transport, ok := r.protocolTransports[address.Protocol].(multiDialer); ok && len(endpoints) > 1 {
+		dialCtx := ctx
+		if r.options.DialTimeout > 0 {
+			var cancel context.CancelFunc
+			dialCtx, cancel = context.WithTimeout(dialCtx, r.options.DialTimeout)
+			defer cancel()
+		}
+		conn, err := transport.DialAny(dialCtx, endpoints)
+		if err != nil {
+			return nil, err
+		}
+		r.logger.Debug("dialed peer", "peer", address.NodeID, "endpoint", conn.RemoteEndpoint())
+		return conn, nil
+	}
+
 	for _, endpoint := range endpoints {
 		transport, ok := r.protocolTransports[endpoint.Protocol]
 		if !ok {
@@ -722,7 +818,21 @@ func (r *Router) handshakePeer(
 		defer cancel()
 	}
 
-	peerInfo, peerKey, err := conn.Handshake(ctx, r.nodeInfo, r.privKey)
+	nodeInfo := r.NodeInfo()
+	if ip := conn.RemoteEndpoint().IP; ip != nil {
+		nodeInfo.ObservedAddr = ip.String()
+	}
+
+	var (
+		peerInfo types.NodeInfo
+		peerKey  crypto.PubKey
+		err      error
+	)
+	if len(r.options.Networks) == 0 {
+		peerInfo, peerKey, err = conn.Handshake(ctx, nodeInfo, r.privKey)
+	} else {
+		peerInfo, peerKey, err = r.handshakeNetworks(ctx, conn, &nodeInfo)
+	}
 	if err != nil {
 		return peerInfo, err
 	}
@@ -737,16 +847,66 @@ func (r *Router) handshakePeer(
 		return peerInfo, fmt.Errorf("expected to connect with peer %q, got %q",
 			expectID, peerInfo.NodeID)
 	}
-	if err := r.nodeInfo.CompatibleWith(peerInfo); err != nil {
+	if err := nodeInfo.CompatibleWith(peerInfo); err != nil {
 		return peerInfo, ErrRejected{
 			err:            err,
 			id:             peerInfo.ID(),
 			isIncompatible: true,
 		}
 	}
+	if peerInfo.ObservedAddr != "" && r.options.ObservedAddress != nil {
+		r.options.ObservedAddress(peerInfo.NodeID, net.ParseIP(peerInfo.ObservedAddr))
+	}
 	return peerInfo, nil
 }
 
+// handshakeNetworks handshakes with a peer on any of the networks served by
+// the router, setting the network of nodeInfo to the one of the peer.
+func (r *Router) handshakeNetworks(
+	ctx context.Context,
+	conn Connection,
+	nodeInfo *types.NodeInfo,
+) (types.NodeInfo, crypto.PubKey, error) {
+	handshaker, ok := conn.(respondingHandshaker)
+	if !ok {
+		return types.NodeInfo{}, nil, fmt.Errorf("connection %v can't serve several networks", conn)
+	}
+	return handshaker.HandshakeResponding(ctx, func(peerInfo types.NodeInfo) (types.NodeInfo, error) {
+		if !r.servesNetwork(peerInfo.Network) {
+			return types.NodeInfo{}, ErrRejected{
+				err:            fmt.Errorf("peer is on network %v, which is not served", peerInfo.Network),
+				id:             peerInfo.ID(),
+				isIncompatible: true,
+			}
+		}
+		nodeInfo.Network = peerInfo.Network
+		return *nodeInfo, nil
+	}, r.privKey)
+}
+
+// servesNetwork returns whether peers on the network are accepted.
+func (r *Router) servesNetwork(network string) bool {
+	if network == r.NodeInfo().Network {
+		return true
+	}
+	for _, n := range r.options.Networks {
+		if network == n {
+			return true
+		}
+	}
+	return false
+}
+
+// validatorAddress returns the address of the validator which proved that it
+// operates the peer, if any. The proof itself is checked by NodeInfo.Validate
+// during the handshake.
+func validatorAddress(info types.NodeInfo) types.Address {
+	if info.ValidatorProof == nil {
+		return nil
+	}
+	return info.ValidatorProof.PubKey.Address()
+}
+
 func (r *Router) runWithPeerMutex(fn func() error) error {
 	r.peerMtx.Lock()
 	defer r.peerMtx.Unlock()
@@ -756,21 +916,31 @@ func (r *Router) runWithPeerMutex(fn func() error) error {
 // routePeer routes inbound and outbound messages between a peer and the reactor
 // channels. It will close the given connection and send queue when done, or if
 // they are closed elsewhere it will cause this method to shut down and return.
-func (r *Router) routePeer(ctx context.Context, peerID types.NodeID, conn Connection, channels channelIDs) {
+func (r *Router) routePeer(ctx context.Context, peerID types.NodeID, conn Connection, nodeInfo types.NodeInfo) {
 	r.metrics.Peers.Add(1)
+	versionLabels := peerVersionLabels(nodeInfo)
+	r.metrics.PeersByVersion.With(versionLabels...).Add(1)
 	r.peerManager.Ready(ctx, peerID)
 
-	sendQueue := r.getOrMakeQueue(peerID, channels)
+	sendQueue := r.getOrMakeQueue(peerID, toChannelIDs(nodeInfo.Channels))
+	if reporter, ok := conn.(rttReporter); ok {
+		r.peerMtx.Lock()
+		r.peerRTTs[peerID] = reporter
+		r.peerMtx.Unlock()
+	}
 	defer func() {
 		r.peerMtx.Lock()
 		delete(r.peerQueues, peerID)
 		delete(r.peerChannels, peerID)
+		delete(r.peerRTTs, peerID)
 		r.peerMtx.Unlock()
 
 		sendQueue.close()
 
 		r.peerManager.Disconnected(ctx, peerID)
 		r.metrics.Peers.Add(-1)
+		r.metrics.PeersByVersion.With(versionLabels...).Add(-1)
+		r.metrics.peerLabels.removePeer(peerID)
 	}()
 
 	r.logger.Info("peer connected", "peer", peerID, "endpoint", conn)
@@ -823,6 +993,15 @@ func (r *Router) routePeer(ctx context.Context, peerID types.NodeID, conn Connec
 	}
 }
 
+// unmarshalMessage unmarshals an inbound message, without copying its bytes if
+// supported: the bytes received are owned by the router and never modified.
+func unmarshalMessage(bz []byte, msg proto.Message) error {
+	if u, ok := msg.(ZeroCopyUnmarshaler); ok {
+		return u.UnmarshalZeroCopy(bz)
+	}
+	return proto.Unmarshal(bz, msg)
+}
+
 // receivePeer receives inbound messages from a peer, deserializes them and
 // passes them on to the appropriate channel.
 func (r *Router) receivePeer(ctx context.Context, peerID types.NodeID, conn Connection) error {
@@ -843,7 +1022,7 @@ func (r *Router) receivePeer(ctx context.Context, peerID types.NodeID, conn Conn
 		}
 
 		msg := proto.Clone(messageType)
-		if err := proto.Unmarshal(bz, msg); err != nil {
+		if err := unmarshalMessage(bz, msg); err != nil {
 			r.logger.Error("message decoding failed, dropping message", "peer", peerID, "err", err)
 			continue
 		}
@@ -860,10 +1039,13 @@ func (r *Router) receivePeer(ctx context.Context, peerID types.NodeID, conn Conn
 
 		select {
 		case queue.enqueue() <- Envelope{From: peerID, Message: msg, ChannelID: chID}:
+			r.metrics.peerLabels.addTraffic(peerID, len(bz))
+			peerLabel := r.metrics.PeerLabel(peerID)
 			r.metrics.PeerReceiveBytesTotal.With(
 				"chID", fmt.Sprint(chID),
-				"peer_id", string(peerID),
+				"peer_id", peerLabel,
 				"message_type", r.metrics.ValueToMetricLabel(msg)).Add(float64(proto.Size(msg)))
+			r.metrics.PeerReceiveMsgsTotal.With("peer_id", peerLabel, "ch_id", fmt.Sprint(chID)).Add(1)
 			r.metrics.RouterChannelQueueSend.Observe(time.Since(start).Seconds())
 			r.logger.Debug("received message", "peer", peerID, "message", msg)
 
@@ -892,12 +1074,22 @@ func (r *Router) sendPeer(ctx context.Context, peerID types.NodeID, conn Connect
 			bz, err := proto.Marshal(envelope.Message)
 			if err != nil {
 				r.logger.Error("failed to marshal message", "peer", peerID, "err", err)
+				r.metrics.PeerSendFailuresTotal.With(
+					"peer_id", r.metrics.PeerLabel(peerID),
+					"ch_id", fmt.Sprint(envelope.ChannelID)).Add(1)
 				continue
 			}
 
 			if err = conn.SendMessage(ctx, envelope.ChannelID, bz); err != nil {
+				r.metrics.PeerSendFailuresTotal.With(
+					"peer_id", r.metrics.PeerLabel(peerID),
+					"ch_id", fmt.Sprint(envelope.ChannelID)).Add(1)
 				return err
 			}
+			r.metrics.peerLabels.addTraffic(peerID, len(bz))
+			r.metrics.PeerSendMsgsTotal.With(
+				"peer_id", r.metrics.PeerLabel(peerID),
+				"ch_id", fmt.Sprint(envelope.ChannelID)).Add(1)
 
 			r.logger.Debug("sent message", "peer", envelope.To, "message", envelope.Message)
 
@@ -939,11 +1131,115 @@ func (r *Router) evictPeers(ctx context.Context) {
 	}
 }
 
-// NodeInfo returns a copy of the current NodeInfo. Used for testing.
+// resolvePeers resolves the hostnames of persistent peers again every
+// PersistentPeerResolveInterval, so that peers which moved are redialed.
+func (r *Router) resolvePeers(ctx context.Context) {
+	interval := r.peerManager.options.PersistentPeerResolveInterval
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+		if err := r.peerManager.ResolvePersistentPeers(ctx); err != nil {
+			r.logger.Error("failed to resolve persistent peers", "err", err)
+		}
+	}
+}
+
+// PeerRTT returns the round-trip time to a connected peer, if its connection
+// measures it and a ping was answered yet.
+func (r *Router) PeerRTT(peerID types.NodeID) (time.Duration, bool) {
+	r.peerMtx.RLock()
+	reporter, ok := r.peerRTTs[peerID]
+	r.peerMtx.RUnlock()
+	if !ok {
+		return 0, false
+	}
+	rtt := reporter.RTT()
+	return rtt, rtt > 0
+}
+
+// NodeInfo returns a copy of the current NodeInfo.
 func (r *Router) NodeInfo() types.NodeInfo {
+	r.nodeInfoMtx.RLock()
+	defer r.nodeInfoMtx.RUnlock()
 	return r.nodeInfo.Copy()
 }
 
+// SetListenAddr updates the address advertised to peers in the NodeInfo of
+// subsequent handshakes, e.g. once the external address of a node behind a
+// NAT is known.
+func (r *Router) SetListenAddr(addr string) {
+	r.nodeInfoMtx.Lock()
+	defer r.nodeInfoMtx.Unlock()
+	r.nodeInfo.ListenAddr = addr
+}
+
+// Drain stops the router from accepting or dialing new peer connections, while
+// leaving established connections open so that reactors can finish in-flight
+// work. The router must still be stopped as usual once draining is done.
+func (r *Router) Drain() {
+	if atomic.CompareAndSwapUint32(&r.draining, 0, 1) {
+		r.logger.Info("draining router; no longer accepting new peer connections")
+	}
+}
+
+// IsDraining reports whether Drain has been called on the router.
+func (r *Router) IsDraining() bool {
+	return atomic.LoadUint32(&r.draining) == 1
+}
+
+// updateQueueMetrics periodically samples the depth of the peer and channel
+// queues, and recomputes the peers whose metrics are labeled with their ID.
+func (r *Router) updateQueueMetrics(ctx context.Context) {
+	ticker := time.NewTicker(queueMetricsInterval)
+	defer ticker.Stop()
+
+	// peer labels set during the previous update, to reset those no longer in use
+	prevPeerLabels := map[string]bool{}
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		r.metrics.peerLabels.refresh()
+
+		peerDepths := map[string]int{}
+		r.peerMtx.RLock()
+		for peerID, q := range r.peerQueues {
+			peerDepths[r.metrics.PeerLabel(peerID)] += q.len()
+		}
+		r.peerMtx.RUnlock()
+
+		for label := range prevPeerLabels {
+			if _, ok := peerDepths[label]; !ok {
+				r.metrics.PeerQueueDepth.With("peer_id", label).Set(0)
+			}
+		}
+		prevPeerLabels = make(map[string]bool, len(peerDepths))
+		for label, depth := range peerDepths {
+			r.metrics.PeerQueueDepth.With("peer_id", label).Set(float64(depth))
+			prevPeerLabels[label] = true
+		}
+
+		r.channelMtx.RLock()
+		for chID, q := range r.channelQueues {
+			r.metrics.ChannelQueueDepth.With("ch_id", fmt.Sprint(chID)).Set(float64(q.len()))
+		}
+		r.channelMtx.RUnlock()
+	}
+}
+
 // OnStart implements service.Service.
 func (r *Router) OnStart(ctx context.Context) error {
 	for _, transport := range r.transports {
@@ -954,16 +1250,19 @@ func (r *Router) OnStart(ctx context.Context) error {
 		}
 	}
 
+	nodeInfo := r.NodeInfo()
 	r.logger.Info(
 		"starting router",
-		"node_id", r.nodeInfo.NodeID,
-		"channels", r.nodeInfo.Channels,
-		"listen_addr", r.nodeInfo.ListenAddr,
+		"node_id", nodeInfo.NodeID,
+		"channels", nodeInfo.Channels,
+		"listen_addr", nodeInfo.ListenAddr,
 		"transports", len(r.transports),
 	)
 
 	go r.dialPeers(ctx)
 	go r.evictPeers(ctx)
+	go r.resolvePeers(ctx)
+	go r.updateQueueMetrics(ctx)
 
 	for _, transport := range r.transports {
 		go r.acceptPeers(ctx, transport)
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"runtime"
 	"strings"
 	"sync"
@@ -19,6 +20,7 @@ import (
 	dbm "github.com/tendermint/tm-db"
 
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
 	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
 	"github.com/tendermint/tendermint/internal/p2p"
 	"github.com/tendermint/tendermint/internal/p2p/mocks"
@@ -92,7 +94,7 @@ func TestRouter_Network(t *testing.T) {
 	}))
 	p2ptest.RequireUpdates(t, peerUpdates, []p2p.PeerUpdate{
 		{NodeID: peers[0].NodeID, Status: p2p.PeerStatusDown},
-		{NodeID: peers[0].NodeID, Status: p2p.PeerStatusUp},
+		{NodeID: peers[0].NodeID, Status: p2p.PeerStatusUp, Capabilities: peers[0].NodeInfo.Capabilities},
 	})
 }
 
@@ -257,6 +259,33 @@ func TestRouter_Channel_Broadcast(t *testing.T) {
 	p2ptest.RequireEmpty(ctx, t, a, b, c, d)
 }
 
+func TestRouter_Channel_GossipPolicy(t *testing.T) {
+	t.Cleanup(leaktest.Check(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Create a test network whose nodes don't send messages on chID.
+	network := p2ptest.MakeNetwork(ctx, t, p2ptest.NetworkOptions{
+		NumNodes: 2,
+		NodeOpts: p2ptest.NodeOptions{
+			DefaultGossipPolicy: p2p.GossipPolicy{BlockedChannels: map[p2p.ChannelID]bool{chID: true}},
+		},
+	})
+
+	ids := network.NodeIDs()
+	aID, bID := ids[0], ids[1]
+	channels := network.MakeChannels(ctx, t, chDesc)
+	a, b := channels[aID], channels[bID]
+
+	network.Start(ctx, t)
+
+	// Neither direct nor broadcast messages should be sent.
+	p2ptest.RequireSend(ctx, t, a, p2p.Envelope{To: bID, Message: &p2ptest.Message{Value: "foo"}})
+	p2ptest.RequireSend(ctx, t, a, p2p.Envelope{Broadcast: true, Message: &p2ptest.Message{Value: "bar"}})
+	p2ptest.RequireEmpty(ctx, t, a, b)
+}
+
 func TestRouter_Channel_Wrapper(t *testing.T) {
 	t.Cleanup(leaktest.Check(t))
 
@@ -347,7 +376,7 @@ func TestRouter_Channel_Error(t *testing.T) {
 	p2ptest.RequireError(ctx, t, a, p2p.PeerError{NodeID: bID, Err: errors.New("boom")})
 	p2ptest.RequireUpdates(t, sub, []p2p.PeerUpdate{
 		{NodeID: bID, Status: p2p.PeerStatusDown},
-		{NodeID: bID, Status: p2p.PeerStatusUp},
+		{NodeID: bID, Status: p2p.PeerStatusUp, Capabilities: network.Nodes[bID].NodeInfo.Capabilities},
 	})
 }
 
@@ -522,6 +551,56 @@ func TestRouter_AcceptPeers_ErrorEOF(t *testing.T) {
 	mockTransport.AssertExpectations(t)
 }
 
+func TestRouter_AcceptPeers_Draining(t *testing.T) {
+	t.Cleanup(leaktest.Check(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Set up a mock connection that must be closed without a handshake, since
+	// the router is draining when it is accepted.
+	mockConnection := &mocks.Connection{}
+	mockConnection.On("String").Maybe().Return("mock")
+	mockConnection.On("RemoteEndpoint").Maybe().Return(p2p.Endpoint{})
+	mockConnection.On("Close").Return(nil)
+
+	mockTransport := &mocks.Transport{}
+	mockTransport.On("String").Maybe().Return("mock")
+	mockTransport.On("Protocols").Return([]p2p.Protocol{"mock"})
+	mockTransport.On("Accept", mock.Anything).Once().Return(mockConnection, nil)
+	mockTransport.On("Accept", mock.Anything).Maybe().Return(nil, io.EOF)
+	mockTransport.On("Close").Return(nil)
+
+	// Set up, drain, and start the router.
+	peerManager, err := p2p.NewPeerManager(selfID, dbm.NewMemDB(), p2p.PeerManagerOptions{})
+	require.NoError(t, err)
+
+	router, err := p2p.NewRouter(
+		ctx,
+		log.TestingLogger(),
+		p2p.NopMetrics(),
+		selfInfo,
+		selfKey,
+		peerManager,
+		[]p2p.Transport{mockTransport},
+		nil,
+		p2p.RouterOptions{},
+	)
+	require.NoError(t, err)
+
+	require.False(t, router.IsDraining())
+	router.Drain()
+	require.True(t, router.IsDraining())
+
+	require.NoError(t, router.Start(ctx))
+	time.Sleep(time.Second)
+	require.NoError(t, router.Stop())
+
+	mockConnection.AssertExpectations(t)
+	mockConnection.AssertNotCalled(t, "Handshake", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockTransport.AssertExpectations(t)
+}
+
 func TestRouter_AcceptPeers_HeadOfLineBlocking(t *testing.T) {
 	t.Cleanup(leaktest.Check(t))
 
@@ -579,6 +658,72 @@ func TestRouter_AcceptPeers_HeadOfLineBlocking(t *testing.T) {
 	mockConnection.AssertExpectations(t)
 }
 
+func TestRouter_ObservedAddress(t *testing.T) {
+	t.Cleanup(leaktest.Check(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The handshake reports the address we observe the peer from, and
+	// advertises the listen address set on the router.
+	expectInfo := selfInfo.Copy()
+	expectInfo.ListenAddr = "198.51.100.1:26656"
+	expectInfo.ObservedAddr = "203.0.113.9"
+	remoteInfo := peerInfo.Copy()
+	remoteInfo.ObservedAddr = "198.51.100.1"
+
+	mockConnection := &mocks.Connection{}
+	mockConnection.On("String").Maybe().Return("mock")
+	mockConnection.On("Handshake", mock.Anything, expectInfo, selfKey).
+		Return(remoteInfo, peerKey.PubKey(), nil)
+	mockConnection.On("Close").Return(nil).Maybe()
+	mockConnection.On("RemoteEndpoint").Return(p2p.Endpoint{IP: net.IPv4(203, 0, 113, 9)})
+	mockConnection.On("ReceiveMessage", mock.Anything).Return(chID, nil, io.EOF).Maybe()
+
+	mockTransport := &mocks.Transport{}
+	mockTransport.On("String").Maybe().Return("mock")
+	mockTransport.On("Protocols").Return([]p2p.Protocol{"mock"})
+	mockTransport.On("Close").Return(nil).Maybe()
+	mockTransport.On("Accept", mock.Anything).Once().Return(mockConnection, nil)
+	mockTransport.On("Accept", mock.Anything).Maybe().Return(nil, io.EOF)
+
+	peerManager, err := p2p.NewPeerManager(selfID, dbm.NewMemDB(), p2p.PeerManagerOptions{})
+	require.NoError(t, err)
+
+	observed := make(chan net.IP, 1)
+	router, err := p2p.NewRouter(
+		ctx,
+		log.TestingLogger(),
+		p2p.NopMetrics(),
+		selfInfo,
+		selfKey,
+		peerManager,
+		[]p2p.Transport{mockTransport},
+		nil,
+		p2p.RouterOptions{
+			ObservedAddress: func(id types.NodeID, ip net.IP) {
+				require.Equal(t, peerInfo.NodeID, id)
+				observed <- ip
+			},
+		},
+	)
+	require.NoError(t, err)
+	router.SetListenAddr(expectInfo.ListenAddr)
+	require.Equal(t, expectInfo.ListenAddr, router.NodeInfo().ListenAddr)
+	require.NoError(t, router.Start(ctx))
+
+	select {
+	case ip := <-observed:
+		require.Equal(t, "198.51.100.1", ip.String())
+	case <-time.After(time.Second):
+		require.Fail(t, "observed address not reported")
+	}
+
+	require.NoError(t, router.Stop())
+	mockTransport.AssertExpectations(t)
+	mockConnection.AssertExpectations(t)
+}
+
 func TestRouter_DialPeers(t *testing.T) {
 	testcases := map[string]struct {
 		dialID   types.NodeID
@@ -627,6 +772,7 @@ func TestRouter_DialPeers(t *testing.T) {
 				mockConnection.On("Handshake", mock.Anything, selfInfo, selfKey).
 					Return(tc.peerInfo, tc.peerKey, nil)
 				mockConnection.On("Close").Run(func(_ mock.Arguments) { closer.Close() }).Return(nil).Maybe()
+				mockConnection.On("RemoteEndpoint").Return(p2p.Endpoint{}).Maybe()
 			}
 			if tc.ok {
 				mockConnection.On("ReceiveMessage", mock.Anything).Return(chID, nil, io.EOF).Maybe()
@@ -714,6 +860,7 @@ func TestRouter_DialPeers_Parallel(t *testing.T) {
 	mockConnection.On("Handshake", mock.Anything, selfInfo, selfKey).
 		WaitUntil(closeCh).Return(types.NodeInfo{}, nil, io.EOF)
 	mockConnection.On("Close").Return(nil)
+	mockConnection.On("RemoteEndpoint").Return(p2p.Endpoint{}).Maybe()
 
 	mockTransport := &mocks.Transport{}
 	mockTransport.On("String").Maybe().Return("mock")
@@ -902,6 +1049,71 @@ func TestRouter_ChannelCompatability(t *testing.T) {
 	mockTransport.AssertExpectations(t)
 }
 
+func TestRouter_Networks(t *testing.T) {
+	t.Cleanup(leaktest.Check(t))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	network := p2p.NewMemoryNetwork(log.TestingLogger(), 1)
+	seedTransport := network.CreateTransport(selfID)
+
+	peerManager, err := p2p.NewPeerManager(selfID, dbm.NewMemDB(), p2p.PeerManagerOptions{})
+	require.NoError(t, err)
+
+	router, err := p2p.NewRouter(
+		ctx,
+		log.TestingLogger(),
+		p2p.NopMetrics(),
+		selfInfo,
+		selfKey,
+		peerManager,
+		[]p2p.Transport{seedTransport},
+		nil,
+		p2p.RouterOptions{Networks: []string{"other"}},
+	)
+	require.NoError(t, err)
+	require.NoError(t, router.Start(ctx))
+
+	// handshake connects a new peer on the network to the router, returning
+	// the node info presented by the router.
+	handshake := func(networkName string) (types.NodeID, types.NodeInfo, error) {
+		key := ed25519.GenPrivKey()
+		id := types.NodeIDFromPubKey(key.PubKey())
+		transport := network.CreateTransport(id)
+		t.Cleanup(func() { _ = transport.Close() })
+
+		conn, err := transport.Dial(ctx, seedTransport.Endpoints()[0])
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = conn.Close() })
+
+		info, _, err := conn.Handshake(ctx, types.NodeInfo{
+			NodeID:     id,
+			ListenAddr: "0.0.0.0:0",
+			Network:    networkName,
+			Moniker:    string(id),
+			Channels:   []byte{0x01},
+		}, key)
+		return id, info, err
+	}
+
+	// peers on any of the served networks are accepted, and the router
+	// presents their own network to them
+	for _, networkName := range []string{selfInfo.Network, "other"} {
+		id, info, err := handshake(networkName)
+		require.NoError(t, err)
+		require.Equal(t, networkName, info.Network)
+		require.Eventually(t, func() bool {
+			return peerManager.Status(id) == p2p.PeerStatusUp
+		}, time.Second, 10*time.Millisecond)
+	}
+
+	// peers on other networks are rejected
+	_, _, err = handshake("unknown")
+	require.Error(t, err)
+
+	require.NoError(t, router.Stop())
+}
+
 func TestRouter_DontSendOnInvalidChannel(t *testing.T) {
 	t.Cleanup(leaktest.Check(t))
 	ctx, cancel := context.WithCancel(context.Background())
@@ -967,3 +1179,88 @@ func TestRouter_DontSendOnInvalidChannel(t *testing.T) {
 	require.NoError(t, router.Stop())
 	mockTransport.AssertExpectations(t)
 }
+
+func TestRouter_Channel_MessageTTL(t *testing.T) {
+	t.Cleanup(leaktest.Check(t))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The first message sent blocks the connection until released, so that
+	// the messages sent after it wait in the peer's queue.
+	closer := tmsync.NewCloser()
+	release := make(chan struct{})
+	sentCh := make(chan string, 3)
+	mockConnection := &mocks.Connection{}
+	mockConnection.On("String").Maybe().Return("mock")
+	mockConnection.On("Handshake", mock.Anything, selfInfo, selfKey).
+		Return(peerInfo, peerKey.PubKey(), nil)
+	mockConnection.On("RemoteEndpoint").Return(p2p.Endpoint{})
+	mockConnection.On("Close").Run(func(_ mock.Arguments) { closer.Close() }).Return(nil).Maybe()
+	mockConnection.On("ReceiveMessage", mock.Anything).
+		Run(func(args mock.Arguments) {
+			select {
+			case <-args.Get(0).(context.Context).Done():
+			case <-closer.Done():
+			}
+		}).Return(chID, nil, io.EOF).Maybe()
+	mockConnection.On("SendMessage", mock.Anything, chID, mock.Anything).
+		Run(func(args mock.Arguments) {
+			msg := &p2ptest.Message{}
+			require.NoError(t, proto.Unmarshal(args.Get(2).([]byte), msg))
+			sentCh <- msg.Value
+			<-release
+		}).Return(nil)
+
+	mockTransport := &mocks.Transport{}
+	mockTransport.On("AddChannelDescriptors", mock.Anything).Return()
+	mockTransport.On("String").Maybe().Return("mock")
+	mockTransport.On("Protocols").Return([]p2p.Protocol{"mock"})
+	mockTransport.On("Close").Return(nil)
+	mockTransport.On("Accept", mock.Anything).Once().Return(mockConnection, nil)
+	mockTransport.On("Accept", mock.Anything).Maybe().Return(nil, io.EOF)
+
+	peerManager, err := p2p.NewPeerManager(selfID, dbm.NewMemDB(), p2p.PeerManagerOptions{})
+	require.NoError(t, err)
+
+	sub := peerManager.Subscribe(ctx)
+
+	router, err := p2p.NewRouter(
+		ctx,
+		log.TestingLogger(),
+		p2p.NopMetrics(),
+		selfInfo,
+		selfKey,
+		peerManager,
+		[]p2p.Transport{mockTransport},
+		nil,
+		p2p.RouterOptions{QueueType: "priority"},
+	)
+	require.NoError(t, err)
+
+	// The channel is opened before the peer connects, so that the peer's
+	// queue knows the channel's TTL.
+	ttlDesc := *chDesc
+	ttlDesc.MessageTTL = 50 * time.Millisecond
+	channel, err := router.OpenChannel(ctx, &ttlDesc)
+	require.NoError(t, err)
+
+	require.NoError(t, router.Start(ctx))
+	p2ptest.RequireUpdate(t, sub, p2p.PeerUpdate{
+		NodeID: peerInfo.NodeID,
+		Status: p2p.PeerStatusUp,
+	})
+
+	require.NoError(t, channel.Send(ctx, p2p.Envelope{To: peerID, Message: &p2ptest.Message{Value: "first"}}))
+	require.Equal(t, "first", <-sentCh)
+
+	// The message expires while the connection is blocked, and is dropped.
+	require.NoError(t, channel.Send(ctx, p2p.Envelope{To: peerID, Message: &p2ptest.Message{Value: "stale"}}))
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+
+	require.NoError(t, channel.Send(ctx, p2p.Envelope{To: peerID, Message: &p2ptest.Message{Value: "fresh"}}))
+	require.Equal(t, "fresh", <-sentCh)
+
+	require.NoError(t, router.Stop())
+	mockTransport.AssertExpectations(t)
+}
@@ -0,0 +1,11 @@
+/*
+Package status exchanges the status of the node, the height of its latest
+committed block, with its peers over a lightweight channel.
+
+Each node sends its height to a peer when the peer connects, and to all its
+peers periodically. The heights reported by the peers are stored in the peer
+manager, which is used to tell how far the node is behind the network (see
+the node_height_lag metric and the /status RPC endpoint), so that operators
+can detect a stalled node without probing other nodes.
+*/
+package status
@@ -0,0 +1,198 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/tendermint/tendermint/internal/p2p"
+	"github.com/tendermint/tendermint/internal/p2p/conn"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/service"
+	protop2p "github.com/tendermint/tendermint/proto/tendermint/p2p"
+)
+
+var _ service.Service = (*Reactor)(nil)
+
+const (
+	// StatusChannel is a channel for the status messages peers exchange
+	StatusChannel = 0x01
+
+	// a status message only holds a varint-encoded height
+	maxMsgSize = 16
+
+	// how often the node advertises its height to its peers
+	broadcastInterval = 10 * time.Second
+)
+
+// ChannelDescriptor returns the channel descriptor of the status channel.
+func ChannelDescriptor() *conn.ChannelDescriptor {
+	return &conn.ChannelDescriptor{
+		ID:                  StatusChannel,
+		MessageType:         new(protop2p.NodeStatus),
+		Priority:            1,
+		SendQueueCapacity:   10,
+		RecvMessageCapacity: maxMsgSize,
+		RecvBufferCapacity:  32,
+	}
+}
+
+// The status reactor exchanges the height of the latest committed block with
+// peers, both when a peer connects and periodically. The heights of the peers
+// are stored in the peer manager, which aggregates them, and the reactor
+// exports how far the node is behind its peers.
+type Reactor struct {
+	service.BaseService
+	logger log.Logger
+
+	peerManager *p2p.PeerManager
+	statusCh    *p2p.Channel
+	peerUpdates *p2p.PeerUpdates
+	metrics     *Metrics
+
+	// height returns the height of the latest committed block
+	height func() int64
+}
+
+// NewReactor returns a reference to a new reactor.
+func NewReactor(
+	logger log.Logger,
+	peerManager *p2p.PeerManager,
+	statusCh *p2p.Channel,
+	peerUpdates *p2p.PeerUpdates,
+	height func() int64,
+	metrics *Metrics,
+) *Reactor {
+	r := &Reactor{
+		logger:      logger,
+		peerManager: peerManager,
+		statusCh:    statusCh,
+		peerUpdates: peerUpdates,
+		height:      height,
+		metrics:     metrics,
+	}
+
+	r.BaseService = *service.NewBaseService(logger, "Status", r)
+	return r
+}
+
+// OnStart starts separate go routines for receiving the status messages of
+// peers, for the peer updates and for advertising the height of the node.
+func (r *Reactor) OnStart(ctx context.Context) error {
+	go r.processStatusCh(ctx)
+	go r.processPeerUpdates(ctx)
+	go r.broadcastRoutine(ctx)
+	return nil
+}
+
+// OnStop stops the reactor by signaling to all spawned goroutines to exit and
+// blocking until they all exit.
+func (r *Reactor) OnStop() {}
+
+// processStatusCh implements a blocking event loop where we listen for p2p
+// Envelope messages from the statusCh.
+func (r *Reactor) processStatusCh(ctx context.Context) {
+	iter := r.statusCh.Receive(ctx)
+	for iter.Next(ctx) {
+		envelope := iter.Envelope()
+		if err := r.handleMessage(envelope); err != nil {
+			r.logger.Error("failed to process message", "ch_id", r.statusCh.ID, "envelope", envelope, "err", err)
+			if serr := r.statusCh.SendError(ctx, p2p.PeerError{
+				NodeID: envelope.From,
+				Err:    err,
+			}); serr != nil {
+				return
+			}
+		}
+	}
+}
+
+// handleMessage handles an Envelope sent from a peer on the status channel.
+// It will handle errors and any possible panics gracefully.
+func (r *Reactor) handleMessage(envelope *p2p.Envelope) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("panic in processing message: %v", e)
+			r.logger.Error(
+				"recovering from processing message panic",
+				"err", err,
+				"stack", string(debug.Stack()),
+			)
+		}
+	}()
+
+	switch msg := envelope.Message.(type) {
+	case *protop2p.NodeStatus:
+		if msg.Height < 0 {
+			return errors.New("negative height")
+		}
+		if err := r.peerManager.SetHeight(envelope.From, msg.Height); err != nil {
+			return err
+		}
+		r.updateHeightLag()
+		return nil
+	default:
+		return fmt.Errorf("received unknown message: %T", msg)
+	}
+}
+
+// processPeerUpdates initiates a blocking process where we listen for and handle
+// PeerUpdate messages. A peer that connects is sent the height of the node.
+func (r *Reactor) processPeerUpdates(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case peerUpdate := <-r.peerUpdates.Updates():
+			switch peerUpdate.Status {
+			case p2p.PeerStatusUp:
+				if err := r.statusCh.Send(ctx, p2p.Envelope{
+					To:      peerUpdate.NodeID,
+					Message: &protop2p.NodeStatus{Height: r.height()},
+				}); err != nil {
+					return
+				}
+			case p2p.PeerStatusDown:
+				r.updateHeightLag()
+			}
+		}
+	}
+}
+
+// broadcastRoutine periodically advertises the height of the node to all
+// peers.
+func (r *Reactor) broadcastRoutine(ctx context.Context) {
+	ticker := time.NewTicker(broadcastInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.statusCh.Send(ctx, p2p.Envelope{
+				Broadcast: true,
+				Message:   &protop2p.NodeStatus{Height: r.height()},
+			}); err != nil {
+				return
+			}
+			r.updateHeightLag()
+		}
+	}
+}
+
+// HeightLag returns the number of blocks the node is behind the highest
+// height reported by its peers, or 0 if it is not behind.
+func (r *Reactor) HeightLag() int64 {
+	lag := r.peerManager.MaxPeerHeight() - r.height()
+	if lag < 0 {
+		return 0
+	}
+	return lag
+}
+
+func (r *Reactor) updateHeightLag() {
+	r.metrics.HeightLag.Set(float64(r.HeightLag()))
+}
@@ -0,0 +1,108 @@
+package status_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/internal/p2p"
+	"github.com/tendermint/tendermint/internal/p2p/p2ptest"
+	"github.com/tendermint/tendermint/internal/p2p/status"
+	"github.com/tendermint/tendermint/libs/log"
+	p2pproto "github.com/tendermint/tendermint/proto/tendermint/p2p"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestReactor_ExchangeHeightsOnConnect(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	network := p2ptest.MakeNetwork(ctx, t, p2ptest.NetworkOptions{NumNodes: 3})
+	channels := network.MakeChannels(ctx, t, status.ChannelDescriptor())
+
+	heights := map[types.NodeID]int64{}
+	reactors := map[types.NodeID]*status.Reactor{}
+	for i, nodeID := range network.NodeIDs() {
+		height := int64(10 * (i + 1))
+		heights[nodeID] = height
+
+		node := network.Nodes[nodeID]
+		reactors[nodeID] = status.NewReactor(
+			log.TestingLogger().With("node", nodeID),
+			node.PeerManager,
+			channels[nodeID],
+			node.PeerManager.Subscribe(ctx),
+			func() int64 { return height },
+			status.NopMetrics(),
+		)
+		require.NoError(t, reactors[nodeID].Start(ctx))
+	}
+
+	network.Start(ctx, t)
+
+	// every node learns the heights of its peers, and how far it is behind
+	var maxHeight int64 = 30
+	for nodeID, reactor := range reactors {
+		peerManager := network.Nodes[nodeID].PeerManager
+		for peerID, height := range heights {
+			if peerID == nodeID {
+				continue
+			}
+			peerID, height := peerID, height
+			require.Eventually(t, func() bool {
+				return peerManager.GetHeight(peerID) == height
+			}, 5*time.Second, 50*time.Millisecond)
+		}
+		require.Equal(t, maxHeight-heights[nodeID], reactor.HeightLag())
+	}
+}
+
+func TestReactor_ReceiveStatus(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	network := p2ptest.MakeNetwork(ctx, t, p2ptest.NetworkOptions{NumNodes: 2})
+	channels := network.MakeChannels(ctx, t, status.ChannelDescriptor())
+	nodeIDs := network.NodeIDs()
+	local, remote := network.Nodes[nodeIDs[0]], network.Nodes[nodeIDs[1]]
+
+	reactor := status.NewReactor(
+		log.TestingLogger(),
+		local.PeerManager,
+		channels[local.NodeID],
+		local.PeerManager.Subscribe(ctx),
+		func() int64 { return 5 },
+		status.NopMetrics(),
+	)
+	require.NoError(t, reactor.Start(ctx))
+
+	network.Start(ctx, t)
+
+	// the reactor sends its height to the peer that connected
+	p2ptest.RequireReceive(ctx, t, channels[remote.NodeID], p2p.Envelope{
+		From:    local.NodeID,
+		Message: &p2pproto.NodeStatus{Height: 5},
+	})
+
+	// and stores the height of the peer
+	p2ptest.RequireSend(ctx, t, channels[remote.NodeID], p2p.Envelope{
+		To:      local.NodeID,
+		Message: &p2pproto.NodeStatus{Height: 12},
+	})
+	require.Eventually(t, func() bool {
+		return local.PeerManager.MaxPeerHeight() == 12
+	}, 5*time.Second, 50*time.Millisecond)
+	require.EqualValues(t, 7, reactor.HeightLag())
+
+	// a peer that is behind does not count as lag
+	p2ptest.RequireSend(ctx, t, channels[remote.NodeID], p2p.Envelope{
+		To:      local.NodeID,
+		Message: &p2pproto.NodeStatus{Height: 3},
+	})
+	require.Eventually(t, func() bool {
+		return local.PeerManager.MaxPeerHeight() == 3
+	}, 5*time.Second, 50*time.Millisecond)
+	require.Zero(t, reactor.HeightLag())
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/types"
@@ -84,7 +85,8 @@ type Connection interface {
 	Handshake(context.Context, types.NodeInfo, crypto.PrivKey) (types.NodeInfo, crypto.PubKey, error)
 
 	// ReceiveMessage returns the next message received on the connection,
-	// blocking until one is available. Returns io.EOF if closed.
+	// blocking until one is available. Returns io.EOF if closed. The message
+	// is owned by the caller, and never modified by the connection.
 	ReceiveMessage(context.Context) (ChannelID, []byte, error)
 
 	// SendMessage sends a message on the connection. Returns io.EOF if closed.
@@ -107,6 +109,32 @@ type Connection interface {
 	fmt.Stringer
 }
 
+// respondingHandshaker is implemented by connections which can execute the
+// node handshake by first receiving the node info of the remote peer, and
+// then sending the node info returned by respond for it, e.g. to present the
+// network of the peer when serving several networks. The handshake is aborted
+// if respond errors.
+type respondingHandshaker interface {
+	HandshakeResponding(
+		ctx context.Context,
+		respond func(peerInfo types.NodeInfo) (types.NodeInfo, error),
+		privKey crypto.PrivKey,
+	) (types.NodeInfo, crypto.PubKey, error)
+}
+
+// rttReporter is implemented by connections which measure the round-trip time
+// to the remote peer, returning zero until it's known.
+type rttReporter interface {
+	RTT() time.Duration
+}
+
+// multiDialer is implemented by transports which dial several endpoints of a
+// peer at once, e.g. the IPv4 and IPv6 addresses of its hostname, returning
+// the first connection established.
+type multiDialer interface {
+	DialAny(ctx context.Context, endpoints []Endpoint) (Connection, error)
+}
+
 // Endpoint represents a transport connection endpoint, either local or remote.
 //
 // Endpoints are not necessarily networked (see e.g. MemoryTransport) but all
@@ -120,6 +148,11 @@ type Endpoint struct {
 	// endpoint as a networked endpoint.
 	IP net.IP
 
+	// Hostname is a hostname to connect to in place of IP, which is resolved
+	// by the dial proxy, e.g. an onion hostname. If set, this defines the
+	// endpoint as a networked endpoint.
+	Hostname string
+
 	// Port is a network port (either TCP or UDP). If 0, a default port may be
 	// used depending on the protocol.
 	Port uint16
@@ -149,9 +182,13 @@ func (e Endpoint) NodeAddress(nodeID types.NodeID) NodeAddress {
 		Protocol: e.Protocol,
 		Path:     e.Path,
 	}
-	if len(e.IP) > 0 {
+	switch {
+	case len(e.IP) > 0:
 		address.Hostname = e.IP.String()
 		address.Port = e.Port
+	case e.Hostname != "":
+		address.Hostname = e.Hostname
+		address.Port = e.Port
 	}
 	return address
 }
@@ -161,7 +198,7 @@ func (e Endpoint) String() string {
 	// If this is a non-networked endpoint with a valid node ID as a path,
 	// assume that path is a node ID (to handle opaque URLs of the form
 	// scheme:id).
-	if e.IP == nil {
+	if e.IP == nil && e.Hostname == "" {
 		if nodeID, err := types.NewNodeID(e.Path); err == nil {
 			return e.NodeAddress(nodeID).String()
 		}
@@ -178,10 +215,13 @@ func (e Endpoint) Validate() error {
 	case len(e.IP) > 0 && e.IP.To16() == nil:
 		return fmt.Errorf("invalid IP address %v", e.IP)
 
-	case e.Port > 0 && len(e.IP) == 0:
+	case len(e.IP) > 0 && e.Hostname != "":
+		return errors.New("endpoint has both IP and hostname")
+
+	case e.Port > 0 && len(e.IP) == 0 && e.Hostname == "":
 		return fmt.Errorf("endpoint has port %v but no IP", e.Port)
 
-	case len(e.IP) == 0 && e.Path == "":
+	case len(e.IP) == 0 && e.Hostname == "" && e.Path == "":
 		return errors.New("endpoint has neither path nor IP")
 
 	default:
@@ -7,10 +7,13 @@ import (
 	"io"
 	"math"
 	"net"
+	"net/url"
 	"strconv"
 	"sync"
+	"time"
 
 	"golang.org/x/net/netutil"
+	"golang.org/x/net/proxy"
 
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/internal/libs/protoio"
@@ -23,6 +26,14 @@ import (
 const (
 	MConnProtocol Protocol = "mconn"
 	TCPProtocol   Protocol = "tcp"
+
+	// noiseP2PProtocol is the first P2P protocol version whose nodes accept
+	// the Noise handshake.
+	noiseP2PProtocol uint64 = 9
+
+	// defaultDialAttemptDelay is the connection attempt delay recommended by
+	// RFC 8305.
+	defaultDialAttemptDelay = 250 * time.Millisecond
 )
 
 // MConnTransportOptions sets options for MConnTransport.
@@ -35,6 +46,55 @@ type MConnTransportOptions struct {
 	// Router, since it will need to do e.g. rate limiting and such as well.
 	// But it might also make sense to have per-transport limits.
 	MaxAcceptedConnections uint32
+
+	// NoiseHandshake dials peers known to support it with the Noise IK
+	// handshake, which hides our identity from passive observers, instead of
+	// the secret connection handshake. Peers are learned from a previous
+	// connection to the same endpoint. Inbound connections are accepted with
+	// either handshake regardless.
+	NoiseHandshake bool
+
+	// NetworkKey is a pre-shared key of a permissioned network, which is mixed
+	// into Noise handshakes. If set, all peers are dialed and accepted with the
+	// Noise handshake, and must have the same key.
+	NetworkKey []byte
+
+	// DialProxy dials outbound connections through a proxy, e.g. a SOCKS5
+	// proxy of Tor. Endpoints with a hostname, such as onion services, can only
+	// be dialed through it. Private and loopback IP addresses are dialed
+	// directly, unless DialProxyOnly is set.
+	DialProxy proxy.ContextDialer
+
+	// DialProxyOnly refuses to dial endpoints other than through DialProxy.
+	DialProxyOnly bool
+
+	// DialAttemptDelay is how long DialAny waits on a connection attempt
+	// before dialing the next endpoint in parallel, as in RFC 8305 ("Happy
+	// Eyeballs"). Defaults to 250ms.
+	DialAttemptDelay time.Duration
+
+	// Metrics records the compression of messages. Defaults to NopMetrics.
+	Metrics *Metrics
+}
+
+// NewProxyDialer returns the dialer of the proxy at the given URL, e.g.
+// "socks5://127.0.0.1:9050", for MConnTransportOptions.DialProxy.
+func NewProxyDialer(proxyURL *url.URL) (proxy.ContextDialer, error) {
+	dialer, err := proxy.FromURL(proxyURL, proxy.Direct)
+	if err != nil {
+		return nil, err
+	}
+	contextDialer, ok := dialer.(proxy.ContextDialer)
+	if !ok {
+		return nil, fmt.Errorf("proxy %v does not support dialing with a context", proxyURL.Redacted())
+	}
+	return contextDialer, nil
+}
+
+// noisePeer is what the transport learned about the peer at an endpoint.
+type noisePeer struct {
+	pubKey crypto.PubKey
+	noise  bool // whether the peer accepts the Noise handshake
 }
 
 // MConnTransport is a Transport implementation using the current multiplexed
@@ -48,6 +108,9 @@ type MConnTransport struct {
 	closeOnce sync.Once
 	doneCh    chan struct{}
 	listener  net.Listener
+
+	mtx   sync.Mutex
+	peers map[string]noisePeer // by dialed endpoint
 }
 
 // NewMConnTransport sets up a new MConnection transport. This uses the
@@ -59,12 +122,19 @@ func NewMConnTransport(
 	channelDescs []*ChannelDescriptor,
 	options MConnTransportOptions,
 ) *MConnTransport {
+	if options.Metrics == nil {
+		options.Metrics = NopMetrics()
+	}
+	if options.DialAttemptDelay == 0 {
+		options.DialAttemptDelay = defaultDialAttemptDelay
+	}
 	return &MConnTransport{
 		logger:       logger,
 		options:      options,
 		mConnConfig:  mConnConfig,
 		doneCh:       make(chan struct{}),
 		channelDescs: channelDescs,
+		peers:        make(map[string]noisePeer),
 	}
 }
 
@@ -113,6 +183,9 @@ func (m *MConnTransport) Listen(endpoint Endpoint) error {
 	if err := m.validateEndpoint(endpoint); err != nil {
 		return err
 	}
+	if endpoint.Hostname != "" {
+		return fmt.Errorf("cannot listen on hostname %q", endpoint.Hostname)
+	}
 
 	listener, err := net.Listen("tcp", net.JoinHostPort(
 		endpoint.IP.String(), strconv.Itoa(int(endpoint.Port))))
@@ -150,7 +223,10 @@ func (m *MConnTransport) Accept(ctx context.Context) (Connection, error) {
 		}
 	}
 
-	return newMConnConnection(m.logger, tcpConn, m.mConnConfig, m.channelDescs), nil
+	c := newMConnConnection(m.logger, tcpConn, m.mConnConfig, m.channelDescs)
+	c.metrics = m.options.Metrics
+	c.secretConnFn = m.acceptSecretConnection
+	return c, nil
 }
 
 // Dial implements Transport.
@@ -162,19 +238,236 @@ func (m *MConnTransport) Dial(ctx context.Context, endpoint Endpoint) (Connectio
 		endpoint.Port = 26657
 	}
 
-	dialer := net.Dialer{}
-	tcpConn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(
-		endpoint.IP.String(), strconv.Itoa(int(endpoint.Port))))
+	dialer, proxied, err := m.dialer(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	host := endpoint.Hostname
+	if host == "" {
+		host = endpoint.IP.String()
+	}
+	tcpConn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, strconv.Itoa(int(endpoint.Port))))
 	if err != nil {
 		select {
 		case <-ctx.Done():
 			return nil, ctx.Err()
 		default:
+			m.options.Metrics.DialsTotal.With("family", ipFamily(endpoint), "result", "failure").Add(1)
 			return nil, err
 		}
 	}
+	m.options.Metrics.DialsTotal.With("family", ipFamily(endpoint), "result", "success").Add(1)
+
+	c := newMConnConnection(m.logger, tcpConn, m.mConnConfig, m.channelDescs)
+	c.metrics = m.options.Metrics
+	if proxied {
+		c.proxiedEndpoint = &endpoint
+	}
+	c.secretConnFn = func(tcpConn net.Conn, privKey crypto.PrivKey) (*conn.SecretConnection, error) {
+		return m.dialSecretConnection(endpoint, tcpConn, privKey)
+	}
+	c.onHandshake = func(peerInfo types.NodeInfo, peerKey crypto.PubKey) {
+		m.mtx.Lock()
+		defer m.mtx.Unlock()
+		m.peers[endpoint.String()] = noisePeer{
+			pubKey: peerKey,
+			noise:  len(m.options.NetworkKey) > 0 || peerInfo.ProtocolVersion.P2P >= noiseP2PProtocol,
+		}
+	}
+	return c, nil
+}
 
-	return newMConnConnection(m.logger, tcpConn, m.mConnConfig, m.channelDescs), nil
+// DialAny implements multiDialer, dialing the endpoints as in RFC 8305 ("Happy
+// Eyeballs"): IPv6 and IPv4 endpoints are interleaved, starting with IPv6, and
+// the next endpoint is dialed whenever an attempt fails or is still pending
+// after the dial attempt delay. The first connection established is returned,
+// and the others are closed.
+func (m *MConnTransport) DialAny(ctx context.Context, endpoints []Endpoint) (Connection, error) {
+	switch len(endpoints) {
+	case 0:
+		return nil, errors.New("no endpoints to dial")
+	case 1:
+		return m.Dial(ctx, endpoints[0])
+	}
+	endpoints = interleaveIPFamilies(endpoints)
+
+	dialCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		conn Connection
+		err  error
+	}
+	results := make(chan result, len(endpoints))
+	next, pending := 0, 0
+	var delay <-chan time.Time
+	dialNext := func() {
+		endpoint := endpoints[next]
+		next++
+		pending++
+		go func() {
+			conn, err := m.Dial(dialCtx, endpoint)
+			if err != nil {
+				err = fmt.Errorf("%v: %w", endpoint, err)
+			}
+			results <- result{conn: conn, err: err}
+		}()
+		if next < len(endpoints) {
+			delay = time.After(m.options.DialAttemptDelay)
+		} else {
+			delay = nil
+		}
+	}
+
+	dialNext()
+	var lastErr error
+	for pending > 0 {
+		select {
+		case <-delay:
+			dialNext()
+
+		case res := <-results:
+			pending--
+			if res.err == nil {
+				// the attempts still pending are canceled, and the connections
+				// they may have established in the meantime closed
+				cancel()
+				go func(pending int) {
+					for ; pending > 0; pending-- {
+						if res := <-results; res.err == nil {
+							res.conn.Close()
+						}
+					}
+				}(pending)
+				return res.conn, nil
+			}
+			lastErr = res.err
+			if next < len(endpoints) {
+				dialNext()
+			}
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("all endpoints failed, the last with: %w", lastErr)
+}
+
+// interleaveIPFamilies orders the endpoints as RFC 8305 recommends, starting
+// with an IPv6 one and alternating between IPv6 and IPv4, keeping the order of
+// the endpoints of each family. Endpoints without an IP are dialed last.
+func interleaveIPFamilies(endpoints []Endpoint) []Endpoint {
+	var ipv6, ipv4, other []Endpoint
+	for _, endpoint := range endpoints {
+		switch ipFamily(endpoint) {
+		case "ipv6":
+			ipv6 = append(ipv6, endpoint)
+		case "ipv4":
+			ipv4 = append(ipv4, endpoint)
+		default:
+			other = append(other, endpoint)
+		}
+	}
+
+	ordered := make([]Endpoint, 0, len(endpoints))
+	for i := 0; i < len(ipv6) || i < len(ipv4); i++ {
+		if i < len(ipv6) {
+			ordered = append(ordered, ipv6[i])
+		}
+		if i < len(ipv4) {
+			ordered = append(ordered, ipv4[i])
+		}
+	}
+	return append(ordered, other...)
+}
+
+// ipFamily returns the IP family of the endpoint, for the metrics label.
+func ipFamily(endpoint Endpoint) string {
+	switch {
+	case endpoint.IP == nil:
+		return "none"
+	case endpoint.IP.To4() != nil:
+		return "ipv4"
+	default:
+		return "ipv6"
+	}
+}
+
+// dialer returns the dialer of the endpoint, and whether it is the dial proxy.
+// Private and loopback IP addresses, which the proxy may not reach, are dialed
+// directly unless only dialing through the proxy.
+func (m *MConnTransport) dialer(endpoint Endpoint) (proxy.ContextDialer, bool, error) {
+	switch {
+	case m.options.DialProxy != nil && (endpoint.Hostname != "" || m.options.DialProxyOnly ||
+		!(endpoint.IP.IsPrivate() || endpoint.IP.IsLoopback())):
+		return m.options.DialProxy, true, nil
+	case endpoint.Hostname != "":
+		return nil, false, fmt.Errorf("cannot dial hostname %q without a dial proxy", endpoint.Hostname)
+	case m.options.DialProxyOnly:
+		return nil, false, fmt.Errorf("refusing to dial %v other than through a dial proxy", endpoint.IP)
+	default:
+		return &net.Dialer{}, false, nil
+	}
+}
+
+// dialSecretConnection performs the handshake of an outbound connection. The
+// Noise handshake is used if a network key is set, or if it is enabled and the
+// peer at the endpoint is known to support it. Otherwise, the secret
+// connection handshake is used.
+func (m *MConnTransport) dialSecretConnection(
+	endpoint Endpoint,
+	tcpConn net.Conn,
+	privKey crypto.PrivKey,
+) (*conn.SecretConnection, error) {
+	m.mtx.Lock()
+	peer, ok := m.peers[endpoint.String()]
+	m.mtx.Unlock()
+
+	switch {
+	case len(m.options.NetworkKey) > 0:
+		// without a known key this falls back to the Noise XX handshake
+		return conn.MakeNoiseConnection(tcpConn, privKey, peer.pubKey, m.options.NetworkKey)
+	case m.options.NoiseHandshake && ok && peer.noise:
+		return conn.MakeNoiseConnection(tcpConn, privKey, peer.pubKey, nil)
+	default:
+		return conn.MakeSecretConnection(tcpConn, privKey)
+	}
+}
+
+// acceptSecretConnection performs the handshake of an inbound connection,
+// which is told apart by the first byte sent by the peer.
+func (m *MConnTransport) acceptSecretConnection(
+	tcpConn net.Conn,
+	privKey crypto.PrivKey,
+) (*conn.SecretConnection, error) {
+	var first [1]byte
+	if _, err := io.ReadFull(tcpConn, first[:]); err != nil {
+		return nil, err
+	}
+	if conn.IsNoiseHandshake(first[0]) {
+		return conn.AcceptNoiseConnection(tcpConn, conn.NoiseHandshake(first[0]), privKey, m.options.NetworkKey)
+	}
+	if len(m.options.NetworkKey) > 0 {
+		return nil, errors.New("peer did not use the Noise handshake required by the network key")
+	}
+	return conn.MakeSecretConnection(&peekedConn{Conn: tcpConn, peeked: first[:]}, privKey)
+}
+
+// peekedConn is a net.Conn which returns the bytes already read from the
+// connection before reading any further.
+type peekedConn struct {
+	net.Conn
+	peeked []byte
+}
+
+// Read implements net.Conn.
+func (c *peekedConn) Read(b []byte) (int, error) {
+	if len(c.peeked) > 0 {
+		n := copy(b, c.peeked)
+		c.peeked = c.peeked[n:]
+		return n, nil
+	}
+	return c.Conn.Read(b)
 }
 
 // Close implements Transport.
@@ -208,7 +501,7 @@ func (m *MConnTransport) validateEndpoint(endpoint Endpoint) error {
 	if endpoint.Protocol != MConnProtocol && endpoint.Protocol != TCPProtocol {
 		return fmt.Errorf("unsupported protocol %q", endpoint.Protocol)
 	}
-	if len(endpoint.IP) == 0 {
+	if len(endpoint.IP) == 0 && endpoint.Hostname == "" {
 		return errors.New("endpoint has no IP address")
 	}
 	if endpoint.Path != "" {
@@ -228,6 +521,23 @@ type mConnConnection struct {
 	doneCh       chan struct{}
 	closeOnce    sync.Once
 
+	// secretConnFn performs the handshake of the secret connection, and
+	// onHandshake is called with the peer's info after a successful handshake.
+	// Both are optional.
+	secretConnFn func(net.Conn, crypto.PrivKey) (*conn.SecretConnection, error)
+	onHandshake  func(types.NodeInfo, crypto.PubKey)
+
+	metrics *Metrics
+
+	// proxiedEndpoint is the dialed endpoint, if dialed through a proxy.
+	proxiedEndpoint *Endpoint
+
+	// compressor is the compression negotiated with the peer, if any, and
+	// channels holds the descriptors of the channels by ID. Both are set
+	// during Handshake().
+	compressor compressor
+	channels   map[ChannelID]*ChannelDescriptor
+
 	mconn *conn.MConnection // set during Handshake()
 }
 
@@ -252,6 +562,7 @@ func newMConnConnection(
 		receiveCh:    make(chan mConnMessage),
 		errorCh:      make(chan error, 1), // buffered to avoid onError leak
 		doneCh:       make(chan struct{}),
+		metrics:      NopMetrics(),
 	}
 }
 
@@ -260,6 +571,91 @@ func (c *mConnConnection) Handshake(
 	ctx context.Context,
 	nodeInfo types.NodeInfo,
 	privKey crypto.PrivKey,
+) (types.NodeInfo, crypto.PubKey, error) {
+	return c.handshakeWith(ctx, privKey, exchangeNodeInfo(nodeInfo))
+}
+
+// HandshakeResponding implements respondingHandshaker.
+func (c *mConnConnection) HandshakeResponding(
+	ctx context.Context,
+	respond func(peerInfo types.NodeInfo) (types.NodeInfo, error),
+	privKey crypto.PrivKey,
+) (types.NodeInfo, crypto.PubKey, error) {
+	return c.handshakeWith(ctx, privKey, respondNodeInfo(respond))
+}
+
+// nodeInfoExchange exchanges node infos with the peer over the secret
+// connection, returning the node info sent and the one received.
+type nodeInfoExchange func(
+	ctx context.Context,
+	secretConn *conn.SecretConnection,
+) (types.NodeInfo, *p2pproto.NodeInfo, error)
+
+// exchangeNodeInfo sends the node info to the peer while receiving its own.
+func exchangeNodeInfo(nodeInfo types.NodeInfo) nodeInfoExchange {
+	return func(ctx context.Context, secretConn *conn.SecretConnection) (types.NodeInfo, *p2pproto.NodeInfo, error) {
+		wg := &sync.WaitGroup{}
+		var pbPeerInfo p2pproto.NodeInfo
+		errCh := make(chan error, 2)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := protoio.NewDelimitedWriter(secretConn).WriteMsg(nodeInfo.ToProto())
+			select {
+			case errCh <- err:
+			case <-ctx.Done():
+			}
+
+		}()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := protoio.NewDelimitedReader(secretConn, types.MaxNodeInfoSize()).ReadMsg(&pbPeerInfo)
+			select {
+			case errCh <- err:
+			case <-ctx.Done():
+			}
+		}()
+
+		wg.Wait()
+
+		if err, ok := <-errCh; ok && err != nil {
+			return types.NodeInfo{}, nil, err
+		}
+		return nodeInfo, &pbPeerInfo, nil
+	}
+}
+
+// respondNodeInfo receives the node info of the peer before sending the one
+// returned by respond for it.
+func respondNodeInfo(respond func(types.NodeInfo) (types.NodeInfo, error)) nodeInfoExchange {
+	return func(ctx context.Context, secretConn *conn.SecretConnection) (types.NodeInfo, *p2pproto.NodeInfo, error) {
+		var pbPeerInfo p2pproto.NodeInfo
+		_, err := protoio.NewDelimitedReader(secretConn, types.MaxNodeInfoSize()).ReadMsg(&pbPeerInfo)
+		if err != nil {
+			return types.NodeInfo{}, nil, err
+		}
+		peerInfo, err := types.NodeInfoFromProto(&pbPeerInfo)
+		if err != nil {
+			return types.NodeInfo{}, nil, err
+		}
+		nodeInfo, err := respond(peerInfo)
+		if err != nil {
+			return types.NodeInfo{}, nil, err
+		}
+		if _, err := protoio.NewDelimitedWriter(secretConn).WriteMsg(nodeInfo.ToProto()); err != nil {
+			return types.NodeInfo{}, nil, err
+		}
+		return nodeInfo, &pbPeerInfo, nil
+	}
+}
+
+// handshakeWith executes the handshake, exchanging node infos with the peer
+// using exchange.
+func (c *mConnConnection) handshakeWith(
+	ctx context.Context,
+	privKey crypto.PrivKey,
+	exchange nodeInfoExchange,
 ) (types.NodeInfo, crypto.PubKey, error) {
 	var (
 		mconn    *conn.MConnection
@@ -279,7 +675,7 @@ func (c *mConnConnection) Handshake(
 			}
 		}()
 		var err error
-		mconn, peerInfo, peerKey, err = c.handshake(ctx, nodeInfo, privKey)
+		mconn, peerInfo, peerKey, err = c.handshake(ctx, privKey, exchange)
 
 		select {
 		case errCh <- err:
@@ -310,44 +706,26 @@ func (c *mConnConnection) Handshake(
 // unstarted but handshaked MConnection, to avoid concurrent field writes.
 func (c *mConnConnection) handshake(
 	ctx context.Context,
-	nodeInfo types.NodeInfo,
 	privKey crypto.PrivKey,
+	exchange nodeInfoExchange,
 ) (*conn.MConnection, types.NodeInfo, crypto.PubKey, error) {
 	if c.mconn != nil {
 		return nil, types.NodeInfo{}, nil, errors.New("connection is already handshaked")
 	}
 
-	secretConn, err := conn.MakeSecretConnection(c.conn, privKey)
+	secretConnFn := c.secretConnFn
+	if secretConnFn == nil {
+		secretConnFn = func(tcpConn net.Conn, privKey crypto.PrivKey) (*conn.SecretConnection, error) {
+			return conn.MakeSecretConnection(tcpConn, privKey)
+		}
+	}
+	secretConn, err := secretConnFn(c.conn, privKey)
 	if err != nil {
 		return nil, types.NodeInfo{}, nil, err
 	}
 
-	wg := &sync.WaitGroup{}
-	var pbPeerInfo p2pproto.NodeInfo
-	errCh := make(chan error, 2)
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		_, err := protoio.NewDelimitedWriter(secretConn).WriteMsg(nodeInfo.ToProto())
-		select {
-		case errCh <- err:
-		case <-ctx.Done():
-		}
-
-	}()
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		_, err := protoio.NewDelimitedReader(secretConn, types.MaxNodeInfoSize()).ReadMsg(&pbPeerInfo)
-		select {
-		case errCh <- err:
-		case <-ctx.Done():
-		}
-	}()
-
-	wg.Wait()
-
-	if err, ok := <-errCh; ok && err != nil {
+	nodeInfo, pbPeerInfo, err := exchange(ctx, secretConn)
+	if err != nil {
 		return nil, types.NodeInfo{}, nil, err
 	}
 
@@ -355,15 +733,37 @@ func (c *mConnConnection) handshake(
 		return nil, types.NodeInfo{}, nil, err
 	}
 
-	peerInfo, err := types.NodeInfoFromProto(&pbPeerInfo)
+	peerInfo, err := types.NodeInfoFromProto(pbPeerInfo)
 	if err != nil {
 		return nil, types.NodeInfo{}, nil, err
 	}
+	if c.onHandshake != nil {
+		c.onHandshake(peerInfo, secretConn.RemotePubKey())
+	}
+
+	// Once compression is negotiated, every message is prefixed with its
+	// compression flag, so each side decides which of its channels to compress.
+	// The decompressed size of messages is bounded by the channel capacity.
+	// The compressor is only read once the handshake is complete.
+	c.compressor = negotiateCompression(nodeInfo.Compression, peerInfo.Compression)
+	c.channels = make(map[ChannelID]*ChannelDescriptor, len(c.channelDescs))
+	channelDescs := make([]*ChannelDescriptor, 0, len(c.channelDescs))
+	for _, desc := range c.channelDescs {
+		filled := desc.FillDefaults()
+		c.channels[desc.ID] = &filled
+		if c.compressor != nil {
+			// leave room for the compression flag
+			desc = &ChannelDescriptor{}
+			*desc = filled
+			desc.RecvMessageCapacity++
+		}
+		channelDescs = append(channelDescs, desc)
+	}
 
 	mconn := conn.NewMConnectionWithConfig(
 		c.logger.With("peer", c.RemoteEndpoint().NodeAddress(peerInfo.NodeID)),
 		secretConn,
-		c.channelDescs,
+		channelDescs,
 		c.onReceive,
 		c.onError,
 		c.mConnConfig,
@@ -412,6 +812,16 @@ func (c *mConnConnection) SendMessage(ctx context.Context, chID ChannelID, msg [
 	case <-ctx.Done():
 		return io.EOF
 	default:
+		if c.compressor != nil {
+			var compressed bool
+			size := len(msg)
+			msg, compressed = encodeMessage(c.compressor, msg, c.channels[chID] != nil && c.channels[chID].Compress)
+			if compressed {
+				chIDStr := fmt.Sprint(chID)
+				c.metrics.MessageCompressionRatio.With("ch_id", chIDStr).Observe(float64(size) / float64(len(msg)-1))
+				c.metrics.CompressionSavedBytesTotal.With("ch_id", chIDStr).Add(float64(size - len(msg) + 1))
+			}
+		}
 		if ok := c.mconn.Send(chID, msg); !ok {
 			return errors.New("sending message timed out")
 		}
@@ -430,7 +840,18 @@ func (c *mConnConnection) ReceiveMessage(ctx context.Context) (ChannelID, []byte
 	case <-ctx.Done():
 		return 0, nil, io.EOF
 	case msg := <-c.receiveCh:
-		return msg.channelID, msg.payload, nil
+		if c.compressor == nil {
+			return msg.channelID, msg.payload, nil
+		}
+		var maxSize int
+		if desc, ok := c.channels[msg.channelID]; ok {
+			maxSize = desc.RecvMessageCapacity
+		}
+		payload, err := decodeMessage(c.compressor, msg.payload, maxSize)
+		if err != nil {
+			return 0, nil, fmt.Errorf("invalid message on channel %v: %w", msg.channelID, err)
+		}
+		return msg.channelID, payload, nil
 	}
 }
 
@@ -446,8 +867,12 @@ func (c *mConnConnection) LocalEndpoint() Endpoint {
 	return endpoint
 }
 
-// RemoteEndpoint implements Connection.
+// RemoteEndpoint implements Connection. The remote endpoint of a connection
+// dialed through a proxy is the dialed endpoint, rather than the proxy.
 func (c *mConnConnection) RemoteEndpoint() Endpoint {
+	if c.proxiedEndpoint != nil {
+		return *c.proxiedEndpoint
+	}
 	endpoint := Endpoint{
 		Protocol: MConnProtocol,
 	}
@@ -458,6 +883,15 @@ func (c *mConnConnection) RemoteEndpoint() Endpoint {
 	return endpoint
 }
 
+// RTT implements rttReporter, with the round-trip time of the pings of the
+// MConnection.
+func (c *mConnConnection) RTT() time.Duration {
+	if c.mconn == nil {
+		return 0
+	}
+	return c.mconn.RTT()
+}
+
 // Close implements Connection.
 func (c *mConnConnection) Close() error {
 	var err error
@@ -1,18 +1,27 @@
 package p2p_test
 
 import (
+	"bytes"
 	"context"
 	"io"
 	"net"
+	"net/url"
+	"strconv"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/fortytw2/leaktest"
 	"github.com/stretchr/testify/require"
 
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/internal/p2p"
 	"github.com/tendermint/tendermint/internal/p2p/conn"
 	"github.com/tendermint/tendermint/libs/log"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+	"github.com/tendermint/tendermint/types"
+	"github.com/tendermint/tendermint/version"
 )
 
 // Transports are mainly tested by common tests in transport_test.go, we
@@ -230,3 +239,335 @@ func TestMConnTransport_Listen(t *testing.T) {
 		})
 	}
 }
+
+func TestMConnTransport_NoiseHandshake(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	networkKey := tmrand.Bytes(32)
+	testcases := map[string]struct {
+		dialOptions   p2p.MConnTransportOptions
+		acceptOptions p2p.MConnTransportOptions
+		ok            bool
+	}{
+		"secret connection": {
+			p2p.MConnTransportOptions{}, p2p.MConnTransportOptions{}, true},
+		"noise dialer": {
+			p2p.MConnTransportOptions{NoiseHandshake: true}, p2p.MConnTransportOptions{}, true},
+		"noise both": {
+			p2p.MConnTransportOptions{NoiseHandshake: true},
+			p2p.MConnTransportOptions{NoiseHandshake: true}, true},
+		"network key": {
+			p2p.MConnTransportOptions{NetworkKey: networkKey},
+			p2p.MConnTransportOptions{NetworkKey: networkKey}, true},
+		"network key missing on dialer": {
+			p2p.MConnTransportOptions{NoiseHandshake: true},
+			p2p.MConnTransportOptions{NetworkKey: networkKey}, false},
+		"network key missing on acceptor": {
+			p2p.MConnTransportOptions{NetworkKey: networkKey},
+			p2p.MConnTransportOptions{NoiseHandshake: true}, false},
+		"different network keys": {
+			p2p.MConnTransportOptions{NetworkKey: networkKey},
+			p2p.MConnTransportOptions{NetworkKey: tmrand.Bytes(32)}, false},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			a := makeMConnTransport(t, tc.dialOptions)
+			b := makeMConnTransport(t, tc.acceptOptions)
+			aKey, bKey := ed25519.GenPrivKey(), ed25519.GenPrivKey()
+
+			// the second handshake uses the peer key and protocol version
+			// learned in the first one
+			for i := 0; i < 2; i++ {
+				ab, ba := dialAccept(ctx, t, a, b)
+
+				errCh := make(chan error, 1)
+				go func() {
+					_, _, err := ba.Handshake(ctx, makeNodeInfo(bKey), bKey)
+					if err != nil {
+						_ = ba.Close()
+					}
+					errCh <- err
+				}()
+				_, peerKey, err := ab.Handshake(ctx, makeNodeInfo(aKey), aKey)
+				if err != nil {
+					_ = ab.Close()
+				}
+				if bErr := <-errCh; err == nil {
+					err = bErr
+				}
+				if !tc.ok {
+					require.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+				require.Equal(t, bKey.PubKey(), peerKey)
+			}
+		})
+	}
+}
+
+func TestMConnTransport_Compression(t *testing.T) {
+	const compressedChID = p2p.ChannelID(2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	testcases := map[string]struct {
+		dialCompressions   []string
+		acceptCompressions []string
+	}{
+		"none":          {nil, nil},
+		"dialer only":   {[]string{"zstd", "snappy"}, nil},
+		"acceptor only": {nil, []string{"zstd"}},
+		"zstd":          {[]string{"zstd", "snappy"}, []string{"zstd", "snappy"}},
+		"snappy":        {[]string{"zstd", "snappy"}, []string{"snappy"}},
+		"unknown":       {[]string{"lz4", "zstd"}, []string{"lz4", "zstd"}},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			a := makeMConnTransport(t, p2p.MConnTransportOptions{})
+			b := makeMConnTransport(t, p2p.MConnTransportOptions{})
+			a.AddChannelDescriptors([]*p2p.ChannelDescriptor{{ID: compressedChID, Priority: 1, Compress: true}})
+			b.AddChannelDescriptors([]*p2p.ChannelDescriptor{{ID: compressedChID, Priority: 1, Compress: true}})
+			aKey, bKey := ed25519.GenPrivKey(), ed25519.GenPrivKey()
+			aInfo, bInfo := makeNodeInfo(aKey), makeNodeInfo(bKey)
+			aInfo.Compression, bInfo.Compression = tc.dialCompressions, tc.acceptCompressions
+
+			ab, ba := dialAccept(ctx, t, a, b)
+			errCh := make(chan error, 1)
+			go func() {
+				_, _, err := ba.Handshake(ctx, bInfo, bKey)
+				errCh <- err
+			}()
+			_, _, err := ab.Handshake(ctx, aInfo, aKey)
+			require.NoError(t, err)
+			require.NoError(t, <-errCh)
+
+			// large compressible, small and incompressible messages arrive
+			// intact on compressed and uncompressed channels, both ways
+			msgs := [][]byte{
+				bytes.Repeat([]byte("compressible "), 10000),
+				[]byte("small"),
+				tmrand.Bytes(10000),
+				{},
+			}
+			for _, id := range []p2p.ChannelID{chID, compressedChID} {
+				for _, msg := range msgs {
+					require.NoError(t, ab.SendMessage(ctx, id, msg))
+					ch, received, err := ba.ReceiveMessage(ctx)
+					require.NoError(t, err)
+					require.Equal(t, id, ch)
+					require.Equal(t, msg, received)
+
+					require.NoError(t, ba.SendMessage(ctx, id, msg))
+					ch, received, err = ab.ReceiveMessage(ctx)
+					require.NoError(t, err)
+					require.Equal(t, id, ch)
+					require.Equal(t, msg, received)
+				}
+			}
+		})
+	}
+}
+
+// makeMConnTransport creates a listening MConnTransport with the given options.
+func TestMConnTransport_DialProxy(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	b := makeMConnTransport(t, p2p.MConnTransportOptions{})
+	private := b.Endpoints()[0]
+	onion := p2p.Endpoint{
+		Protocol: p2p.MConnProtocol,
+		Hostname: "duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzczad.onion",
+		Port:     26656,
+	}
+	public := p2p.Endpoint{Protocol: p2p.MConnProtocol, IP: net.IPv4(8, 8, 8, 8), Port: 26656}
+
+	testcases := map[string]struct {
+		proxy     bool
+		proxyOnly bool
+		endpoint  p2p.Endpoint
+		proxied   bool
+		ok        bool
+	}{
+		"onion through proxy":       {proxy: true, endpoint: onion, proxied: true, ok: true},
+		"public through proxy":      {proxy: true, endpoint: public, proxied: true, ok: true},
+		"private directly":          {proxy: true, endpoint: private, ok: true},
+		"private through proxy":     {proxy: true, proxyOnly: true, endpoint: private, proxied: true, ok: true},
+		"private without proxy":     {endpoint: private, ok: true},
+		"onion without proxy":       {endpoint: onion},
+		"proxy only without proxy":  {proxyOnly: true, endpoint: private},
+		"hostname without protocol": {proxy: true, endpoint: p2p.Endpoint{Hostname: onion.Hostname}},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			// the proxy connects all dialed addresses to b
+			dialer := &recordingDialer{target: net.JoinHostPort(private.IP.String(), strconv.Itoa(int(private.Port)))}
+			options := p2p.MConnTransportOptions{DialProxyOnly: tc.proxyOnly}
+			if tc.proxy {
+				options.DialProxy = dialer
+			}
+			a := makeMConnTransport(t, options)
+
+			ab, err := a.Dial(ctx, tc.endpoint)
+			if !tc.ok {
+				require.Error(t, err)
+				require.Empty(t, dialer.addresses)
+				return
+			}
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = ab.Close() })
+
+			ba, err := b.Accept(ctx)
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = ba.Close() })
+
+			if tc.proxied {
+				host := tc.endpoint.Hostname
+				if host == "" {
+					host = tc.endpoint.IP.String()
+				}
+				require.Equal(t, []string{net.JoinHostPort(host, strconv.Itoa(int(tc.endpoint.Port)))}, dialer.addresses)
+				require.Equal(t, tc.endpoint, ab.RemoteEndpoint())
+			} else {
+				require.Empty(t, dialer.addresses)
+				require.Equal(t, private.IP.String(), ab.RemoteEndpoint().IP.String())
+			}
+		})
+	}
+}
+
+func TestMConnTransport_DialAny(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	b := makeMConnTransport(t, p2p.MConnTransportOptions{})
+	private := b.Endpoints()[0]
+	ipv4a := p2p.Endpoint{Protocol: p2p.MConnProtocol, IP: net.IPv4(8, 8, 8, 8), Port: 26656}
+	ipv4b := p2p.Endpoint{Protocol: p2p.MConnProtocol, IP: net.IPv4(1, 1, 1, 1), Port: 26656}
+	ipv6 := p2p.Endpoint{Protocol: p2p.MConnProtocol, IP: net.ParseIP("2001:db8::1"), Port: 26656}
+
+	testcases := map[string]struct {
+		blackholed []p2p.Endpoint
+		expect     p2p.Endpoint
+		dialed     []p2p.Endpoint
+	}{
+		"ipv6 first":           {expect: ipv6, dialed: []p2p.Endpoint{ipv6}},
+		"ipv4 after the delay": {blackholed: []p2p.Endpoint{ipv6}, expect: ipv4a, dialed: []p2p.Endpoint{ipv6, ipv4a}},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			// the proxy connects the addresses not blackholed to b
+			dialer := &blackholingDialer{target: net.JoinHostPort(private.IP.String(), strconv.Itoa(int(private.Port)))}
+			for _, endpoint := range tc.blackholed {
+				dialer.blackholed = append(dialer.blackholed, net.JoinHostPort(endpoint.IP.String(), "26656"))
+			}
+			a := makeMConnTransport(t, p2p.MConnTransportOptions{
+				DialProxy:        dialer,
+				DialProxyOnly:    true,
+				DialAttemptDelay: 100 * time.Millisecond,
+			})
+
+			ab, err := a.DialAny(ctx, []p2p.Endpoint{ipv4a, ipv4b, ipv6})
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = ab.Close() })
+			require.Equal(t, tc.expect, ab.RemoteEndpoint())
+
+			ba, err := b.Accept(ctx)
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = ba.Close() })
+
+			var dialed []string
+			for _, endpoint := range tc.dialed {
+				dialed = append(dialed, net.JoinHostPort(endpoint.IP.String(), "26656"))
+			}
+			require.Equal(t, dialed, dialer.dialed())
+		})
+	}
+}
+
+func TestNewProxyDialer(t *testing.T) {
+	dialer, err := p2p.NewProxyDialer(&url.URL{Scheme: "socks5", Host: "127.0.0.1:9050"})
+	require.NoError(t, err)
+	require.NotNil(t, dialer)
+
+	_, err = p2p.NewProxyDialer(&url.URL{Scheme: "http", Host: "127.0.0.1:8080"})
+	require.Error(t, err)
+}
+
+// recordingDialer is a dial proxy connecting all addresses to the target
+// address, which records the dialed addresses.
+type recordingDialer struct {
+	target    string
+	addresses []string
+}
+
+func (d *recordingDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	d.addresses = append(d.addresses, address)
+	var dialer net.Dialer
+	return dialer.DialContext(ctx, network, d.target)
+}
+
+// blackholingDialer is a dial proxy connecting all addresses but the
+// blackholed ones to the target address, which records the dialed addresses.
+// Dialing a blackholed address blocks until the context is canceled.
+type blackholingDialer struct {
+	target     string
+	blackholed []string
+
+	mtx       sync.Mutex
+	addresses []string
+}
+
+func (d *blackholingDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	d.mtx.Lock()
+	d.addresses = append(d.addresses, address)
+	d.mtx.Unlock()
+	for _, blackholed := range d.blackholed {
+		if address == blackholed {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+	}
+	var dialer net.Dialer
+	return dialer.DialContext(ctx, network, d.target)
+}
+
+func (d *blackholingDialer) dialed() []string {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	return append([]string(nil), d.addresses...)
+}
+
+func makeMConnTransport(t *testing.T, options p2p.MConnTransportOptions) *p2p.MConnTransport {
+	transport := p2p.NewMConnTransport(
+		log.TestingLogger(),
+		conn.DefaultMConnConfig(),
+		[]*p2p.ChannelDescriptor{{ID: chID, Priority: 1}},
+		options,
+	)
+	err := transport.Listen(p2p.Endpoint{
+		Protocol: p2p.MConnProtocol,
+		IP:       net.IPv4(127, 0, 0, 1),
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = transport.Close()
+	})
+	return transport
+}
+
+// makeNodeInfo returns the NodeInfo of a node with the given key, running the
+// current P2P protocol version.
+func makeNodeInfo(privKey crypto.PrivKey) types.NodeInfo {
+	return types.NodeInfo{
+		NodeID:          types.NodeIDFromPubKey(privKey.PubKey()),
+		ProtocolVersion: types.ProtocolVersion{P2P: version.P2PProtocol},
+	}
+}
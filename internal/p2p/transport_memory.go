@@ -284,6 +284,44 @@ func (c *MemoryConnection) Handshake(
 	}
 }
 
+// HandshakeResponding implements respondingHandshaker.
+func (c *MemoryConnection) HandshakeResponding(
+	ctx context.Context,
+	respond func(peerInfo types.NodeInfo) (types.NodeInfo, error),
+	privKey crypto.PrivKey,
+) (types.NodeInfo, crypto.PubKey, error) {
+	var (
+		peerInfo types.NodeInfo
+		peerKey  crypto.PubKey
+	)
+	select {
+	case msg := <-c.receiveCh:
+		if msg.nodeInfo == nil {
+			return types.NodeInfo{}, nil, errors.New("no NodeInfo in handshake")
+		}
+		c.logger.Debug("received handshake", "peerInfo", msg.nodeInfo)
+		peerInfo, peerKey = *msg.nodeInfo, msg.pubKey
+	case <-c.closer.Done():
+		return types.NodeInfo{}, nil, io.EOF
+	case <-ctx.Done():
+		return types.NodeInfo{}, nil, ctx.Err()
+	}
+
+	nodeInfo, err := respond(peerInfo)
+	if err != nil {
+		return types.NodeInfo{}, nil, err
+	}
+	select {
+	case c.sendCh <- memoryMessage{nodeInfo: &nodeInfo, pubKey: privKey.PubKey()}:
+		c.logger.Debug("sent handshake", "nodeInfo", nodeInfo)
+	case <-c.closer.Done():
+		return types.NodeInfo{}, nil, io.EOF
+	case <-ctx.Done():
+		return types.NodeInfo{}, nil, ctx.Err()
+	}
+	return peerInfo, peerKey, nil
+}
+
 // ReceiveMessage implements Connection.
 func (c *MemoryConnection) ReceiveMessage(ctx context.Context) (ChannelID, []byte, error) {
 	// Check close first, since channels are buffered. Otherwise, below select
@@ -0,0 +1,83 @@
+package p2p
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+// VersionCount is the number of connected peers running a version.
+type VersionCount struct {
+	Version string
+	Peers   int
+}
+
+// PeerVersions summarizes the versions run by the connected peers, as
+// presented in their handshakes, so that upgrades can be tracked across the
+// network. Each list is ordered by decreasing number of peers.
+type PeerVersions struct {
+	Software []VersionCount // NodeInfo.Version
+	Block    []VersionCount // block protocol version
+	App      []VersionCount // application version
+}
+
+// NodeInfo returns the node info the peer presented in its last handshake,
+// if any.
+func (m *PeerManager) NodeInfo(peerID types.NodeID) (types.NodeInfo, bool) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	peer, ok := m.store.Get(peerID)
+	if !ok || peer.NodeInfo == nil {
+		return types.NodeInfo{}, false
+	}
+	return *peer.NodeInfo, true
+}
+
+// PeerVersions returns the versions run by the connected peers.
+func (m *PeerManager) PeerVersions() PeerVersions {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	software := map[string]int{}
+	block := map[string]int{}
+	app := map[string]int{}
+	for peerID := range m.connected {
+		peer, ok := m.store.Get(peerID)
+		if !ok || peer.NodeInfo == nil {
+			continue
+		}
+		software[peer.NodeInfo.Version]++
+		block[strconv.FormatUint(peer.NodeInfo.ProtocolVersion.Block, 10)]++
+		app[strconv.FormatUint(peer.NodeInfo.ProtocolVersion.App, 10)]++
+	}
+	return PeerVersions{
+		Software: sortVersionCounts(software),
+		Block:    sortVersionCounts(block),
+		App:      sortVersionCounts(app),
+	}
+}
+
+// peerVersionLabels returns the labels of the PeersByVersion metric for a
+// peer with the given node info.
+func peerVersionLabels(nodeInfo types.NodeInfo) []string {
+	return []string{
+		"version", nodeInfo.Version,
+		"app_version", strconv.FormatUint(nodeInfo.ProtocolVersion.App, 10),
+	}
+}
+
+func sortVersionCounts(counts map[string]int) []VersionCount {
+	res := make([]VersionCount, 0, len(counts))
+	for version, peers := range counts {
+		res = append(res, VersionCount{Version: version, Peers: peers})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Peers != res[j].Peers {
+			return res[i].Peers > res[j].Peers
+		}
+		return res[i].Version < res[j].Version
+	})
+	return res
+}
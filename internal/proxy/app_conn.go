@@ -2,13 +2,21 @@ package proxy
 
 import (
 	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync/atomic"
 	"time"
+	"unicode"
 
 	"github.com/go-kit/kit/metrics"
 	abciclient "github.com/tendermint/tendermint/abci/client"
 	"github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/internal/libs/tracing"
 )
 
+var tracer = tracing.Tracer("proxy")
+
 //go:generate ../../scripts/mockery_generate.sh AppConnConsensus|AppConnMempool|AppConnQuery|AppConnSnapshot
 
 //----------------------------------------------------------------------------------------
@@ -20,9 +28,9 @@ type AppConnConsensus interface {
 
 	InitChainSync(context.Context, types.RequestInitChain) (*types.ResponseInitChain, error)
 
-	BeginBlockSync(context.Context, types.RequestBeginBlock) (*types.ResponseBeginBlock, error)
-	DeliverTxAsync(context.Context, types.RequestDeliverTx) (*abciclient.ReqRes, error)
-	EndBlockSync(context.Context, types.RequestEndBlock) (*types.ResponseEndBlock, error)
+	PrepareProposalSync(context.Context, types.RequestPrepareProposal) (*types.ResponsePrepareProposal, error)
+	ProcessProposalSync(context.Context, types.RequestProcessProposal) (*types.ResponseProcessProposal, error)
+	FinalizeBlockSync(context.Context, types.RequestFinalizeBlock) (*types.ResponseFinalizeBlock, error)
 	CommitSync(context.Context) (*types.ResponseCommit, error)
 }
 
@@ -82,35 +90,45 @@ func (app *appConnConsensus) InitChainSync(
 	req types.RequestInitChain,
 ) (*types.ResponseInitChain, error) {
 	defer addTimeSample(app.metrics.MethodTiming.With("method", "init_chain", "type", "sync"))()
+	ctx, span := tracer.Start(ctx, "abci.InitChain")
+	defer span.End()
 	return app.appConn.InitChainSync(ctx, req)
 }
 
-func (app *appConnConsensus) BeginBlockSync(
+func (app *appConnConsensus) PrepareProposalSync(
 	ctx context.Context,
-	req types.RequestBeginBlock,
-) (*types.ResponseBeginBlock, error) {
-	defer addTimeSample(app.metrics.MethodTiming.With("method", "begin_block", "type", "sync"))()
-	return app.appConn.BeginBlockSync(ctx, req)
+	req types.RequestPrepareProposal,
+) (*types.ResponsePrepareProposal, error) {
+	defer addTimeSample(app.metrics.MethodTiming.With("method", "prepare_proposal", "type", "sync"))()
+	ctx, span := tracer.Start(ctx, "abci.PrepareProposal")
+	defer span.End()
+	return app.appConn.PrepareProposalSync(ctx, req)
 }
 
-func (app *appConnConsensus) DeliverTxAsync(
+func (app *appConnConsensus) ProcessProposalSync(
 	ctx context.Context,
-	req types.RequestDeliverTx,
-) (*abciclient.ReqRes, error) {
-	defer addTimeSample(app.metrics.MethodTiming.With("method", "deliver_tx", "type", "async"))()
-	return app.appConn.DeliverTxAsync(ctx, req)
+	req types.RequestProcessProposal,
+) (*types.ResponseProcessProposal, error) {
+	defer addTimeSample(app.metrics.MethodTiming.With("method", "process_proposal", "type", "sync"))()
+	ctx, span := tracer.Start(ctx, "abci.ProcessProposal")
+	defer span.End()
+	return app.appConn.ProcessProposalSync(ctx, req)
 }
 
-func (app *appConnConsensus) EndBlockSync(
+func (app *appConnConsensus) FinalizeBlockSync(
 	ctx context.Context,
-	req types.RequestEndBlock,
-) (*types.ResponseEndBlock, error) {
-	defer addTimeSample(app.metrics.MethodTiming.With("method", "deliver_tx", "type", "sync"))()
-	return app.appConn.EndBlockSync(ctx, req)
+	req types.RequestFinalizeBlock,
+) (*types.ResponseFinalizeBlock, error) {
+	defer addTimeSample(app.metrics.MethodTiming.With("method", "finalize_block", "type", "sync"))()
+	ctx, span := tracer.Start(ctx, "abci.FinalizeBlock")
+	defer span.End()
+	return app.appConn.FinalizeBlockSync(ctx, req)
 }
 
 func (app *appConnConsensus) CommitSync(ctx context.Context) (*types.ResponseCommit, error) {
 	defer addTimeSample(app.metrics.MethodTiming.With("method", "commit", "type", "sync"))()
+	ctx, span := tracer.Start(ctx, "abci.Commit")
+	defer span.End()
 	return app.appConn.CommitSync(ctx)
 }
 
@@ -157,6 +175,108 @@ func (app *appConnMempool) CheckTxSync(ctx context.Context, req types.RequestChe
 	return app.appConn.CheckTxSync(ctx, req)
 }
 
+//------------------------------------------------
+// Implements AppConnMempool over a pool of connections
+
+// appConnMempoolPool dispatches CheckTx requests round-robin to a pool of
+// connections, so that an application serving connections concurrently
+// checks transactions in parallel.
+//
+// Rechecks are only sent on the first connection, since the mempool relies on
+// their responses arriving in the order they were sent. Until they're flushed,
+// the other CheckTx requests are sent on the first connection too, so that
+// their responses don't overtake those of the rechecks.
+type appConnMempoolPool struct {
+	metrics    *Metrics
+	appConns   []abciclient.Client
+	next       uint32
+	rechecking uint32 // 1 while rechecks are in flight on the first connection
+}
+
+// NewAppConnMempoolPool returns an AppConnMempool using the given connections.
+func NewAppConnMempoolPool(appConns []abciclient.Client, metrics *Metrics) AppConnMempool {
+	return &appConnMempoolPool{
+		metrics:  metrics,
+		appConns: appConns,
+	}
+}
+
+// pick returns the connection to send a CheckTx request of the given type on.
+func (app *appConnMempoolPool) pick(typ types.CheckTxType) abciclient.Client {
+	if typ == types.CheckTxType_Recheck {
+		atomic.StoreUint32(&app.rechecking, 1)
+		return app.appConns[0]
+	}
+	if atomic.LoadUint32(&app.rechecking) == 1 {
+		return app.appConns[0]
+	}
+	i := atomic.AddUint32(&app.next, 1)
+	return app.appConns[int(i)%len(app.appConns)]
+}
+
+func (app *appConnMempoolPool) SetResponseCallback(cb abciclient.Callback) {
+	for _, appConn := range app.appConns {
+		appConn.SetResponseCallback(cb)
+	}
+}
+
+func (app *appConnMempoolPool) Error() error {
+	for _, appConn := range app.appConns {
+		if err := appConn.Error(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FlushAsync flushes all the connections, returning the ReqRes of the flush
+// of the first one, which rechecks are sent on.
+func (app *appConnMempoolPool) FlushAsync(ctx context.Context) (*abciclient.ReqRes, error) {
+	defer addTimeSample(app.metrics.MethodTiming.With("method", "flush", "type", "async"))()
+	for _, appConn := range app.appConns[1:] {
+		if _, err := appConn.FlushAsync(ctx); err != nil {
+			return nil, err
+		}
+	}
+	rechecking := atomic.LoadUint32(&app.rechecking) == 1
+	reqRes, err := app.appConns[0].FlushAsync(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if rechecking {
+		// the responses to the rechecks sent before the flush have been
+		// handled once it's done
+		reqRes.SetCallback(func(*types.Response) {
+			atomic.StoreUint32(&app.rechecking, 0)
+		})
+	}
+	return reqRes, nil
+}
+
+func (app *appConnMempoolPool) FlushSync(ctx context.Context) error {
+	defer addTimeSample(app.metrics.MethodTiming.With("method", "flush", "type", "sync"))()
+	rechecking := atomic.LoadUint32(&app.rechecking) == 1
+	for _, appConn := range app.appConns {
+		if err := appConn.FlushSync(ctx); err != nil {
+			return err
+		}
+	}
+	if rechecking {
+		atomic.StoreUint32(&app.rechecking, 0)
+	}
+	return nil
+}
+
+func (app *appConnMempoolPool) CheckTxAsync(ctx context.Context, req types.RequestCheckTx) (*abciclient.ReqRes, error) {
+	defer addTimeSample(app.metrics.MethodTiming.With("method", "check_tx", "type", "async"))()
+	return app.pick(req.Type).CheckTxAsync(ctx, req)
+}
+
+func (app *appConnMempoolPool) CheckTxSync(ctx context.Context, req types.RequestCheckTx) (*types.ResponseCheckTx, error) {
+	defer addTimeSample(app.metrics.MethodTiming.With("method", "check_tx", "type", "sync"))()
+	return app.pick(req.Type).CheckTxSync(ctx, req)
+}
+
 //------------------------------------------------
 // Implements AppConnQuery (subset of abciclient.Client)
 
@@ -248,3 +368,36 @@ func addTimeSample(m metrics.Histogram) func() {
 	start := time.Now()
 	return func() { m.Observe(time.Since(start).Seconds()) }
 }
+
+// timeoutInterceptor bounds the duration of the requests by timeout, counting
+// those which time out in m.
+func timeoutInterceptor(timeout time.Duration, m metrics.Counter) abciclient.Interceptor {
+	withTimeout := abciclient.TimeoutInterceptor(timeout)
+	return func(ctx context.Context, req *types.Request, invoke abciclient.Invoker) (*types.Response, error) {
+		res, err := withTimeout(ctx, req, invoke)
+		if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+			m.With("method", methodName(req)).Add(1)
+		}
+		return res, err
+	}
+}
+
+// methodName returns the name of the ABCI method of req, as in the method
+// labels of the metrics, e.g. finalize_block.
+func methodName(req *types.Request) string {
+	if req.Value == nil {
+		return "unknown"
+	}
+	name := strings.TrimPrefix(reflect.TypeOf(req.Value).Elem().Name(), "Request_")
+	var sb strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				sb.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
@@ -6,7 +6,11 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
 	abciclient "github.com/tendermint/tendermint/abci/client"
+	abcimocks "github.com/tendermint/tendermint/abci/client/mocks"
 	"github.com/tendermint/tendermint/abci/example/kvstore"
 	"github.com/tendermint/tendermint/abci/server"
 	"github.com/tendermint/tendermint/abci/types"
@@ -179,3 +183,68 @@ func TestInfo(t *testing.T) {
 		t.Error("Expected ResponseInfo with one element '{\"size\":0}' but got something else")
 	}
 }
+
+func TestAppConnMempoolPool(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clients := make([]*abcimocks.Client, 3)
+	appConns := make([]abciclient.Client, len(clients))
+	for i := range clients {
+		clients[i] = &abcimocks.Client{}
+		clients[i].On("FlushSync", mock.Anything).Return(nil).Once()
+		appConns[i] = clients[i]
+	}
+	pool := NewAppConnMempoolPool(appConns, NopMetrics())
+
+	// new transactions are dispatched round-robin
+	for i := 0; i < 2*len(clients); i++ {
+		req := types.RequestCheckTx{Tx: []byte{byte(i)}}
+		clients[(i+1)%len(clients)].On("CheckTxAsync", mock.Anything, req).Return(&abciclient.ReqRes{}, nil).Once()
+		_, err := pool.CheckTxAsync(ctx, req)
+		require.NoError(t, err)
+	}
+
+	// rechecks are all sent on the first connection, to keep their order
+	for i := 0; i < len(clients); i++ {
+		req := types.RequestCheckTx{Tx: []byte{byte(i)}, Type: types.CheckTxType_Recheck}
+		clients[0].On("CheckTxAsync", mock.Anything, req).Return(&abciclient.ReqRes{}, nil).Once()
+		_, err := pool.CheckTxAsync(ctx, req)
+		require.NoError(t, err)
+	}
+
+	// until the rechecks are flushed, new transactions are sent on the first
+	// connection too, so that their responses don't overtake those of the
+	// rechecks
+	checkTx := func(tx byte, client *abcimocks.Client) {
+		req := types.RequestCheckTx{Tx: []byte{tx}}
+		client.On("CheckTxAsync", mock.Anything, req).Return(&abciclient.ReqRes{}, nil).Once()
+		_, err := pool.CheckTxAsync(ctx, req)
+		require.NoError(t, err)
+	}
+	checkTx(10, clients[0])
+
+	flush := abciclient.NewReqRes(types.ToRequestFlush())
+	clients[0].On("FlushAsync", mock.Anything).Return(flush, nil).Once()
+	for _, client := range clients[1:] {
+		client.On("FlushAsync", mock.Anything).Return(&abciclient.ReqRes{}, nil).Once()
+	}
+	reqRes, err := pool.FlushAsync(ctx)
+	require.NoError(t, err)
+	require.Equal(t, flush, reqRes)
+	checkTx(11, clients[0])
+
+	// once the flush is done, they're dispatched round-robin again
+	flush.Response = types.ToResponseFlush()
+	flush.SetDone()
+	flush.InvokeCallback()
+	checkTx(12, clients[1])
+	checkTx(13, clients[2])
+
+	// all connections are flushed
+	require.NoError(t, pool.FlushSync(ctx))
+
+	for _, client := range clients {
+		client.AssertExpectations(t)
+	}
+}
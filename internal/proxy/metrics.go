@@ -16,6 +16,8 @@ const (
 // Metrics contains the prometheus metrics exposed by the proxy package.
 type Metrics struct {
 	MethodTiming metrics.Histogram
+	// Number of ABCI calls which timed out, by method.
+	MethodTimeouts metrics.Counter
 }
 
 // PrometheusMetrics constructs a Metrics instance that collects metrics samples.
@@ -35,6 +37,12 @@ func PrometheusMetrics(namespace string, defaultLabelsAndValues ...string) *Metr
 			Help:      "ABCI Method Timing",
 			Buckets:   []float64{.0001, .0004, .002, .009, .02, .1, .65, 2, 6, 25},
 		}, append(defaultLabels, []string{"method", "type"}...)).With(defaultLabelsAndValues...),
+		MethodTimeouts: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "method_timeouts",
+			Help:      "Number of ABCI calls which timed out, by method.",
+		}, append(defaultLabels, "method")).With(defaultLabelsAndValues...),
 	}
 }
 
@@ -42,6 +50,7 @@ func PrometheusMetrics(namespace string, defaultLabelsAndValues ...string) *Metr
 // for testing.
 func NopMetrics() *Metrics {
 	return &Metrics{
-		MethodTiming: discard.NewHistogram(),
+		MethodTiming:   discard.NewHistogram(),
+		MethodTimeouts: discard.NewCounter(),
 	}
 }
@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"os"
 	"syscall"
+	"time"
 
 	abciclient "github.com/tendermint/tendermint/abci/client"
+	"github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/libs/service"
 )
@@ -17,6 +19,10 @@ const (
 	connMempool   = "mempool"
 	connQuery     = "query"
 	connSnapshot  = "snapshot"
+
+	// maxMempoolConnections bounds the CheckTx concurrency advertised by the
+	// application.
+	maxMempoolConnections = 64
 )
 
 // AppConns is the Tendermint's interface to the application that consists of
@@ -32,11 +38,64 @@ type AppConns interface {
 	Query() AppConnQuery
 	// Snapshot connection
 	Snapshot() AppConnSnapshot
+
+	// SupportsFeature returns whether the application supports an optional
+	// ABCI feature, once started. Applications which don't declare their ABCI
+	// version are assumed to support all features.
+	SupportsFeature(feature string) bool
 }
 
 // NewAppConns calls NewMultiAppConn.
-func NewAppConns(clientCreator abciclient.Creator, logger log.Logger, metrics *Metrics) AppConns {
-	return NewMultiAppConn(clientCreator, logger, metrics)
+func NewAppConns(
+	clientCreator abciclient.Creator,
+	logger log.Logger,
+	metrics *Metrics,
+	options ...AppConnsOption,
+) AppConns {
+	return NewMultiAppConn(clientCreator, logger, metrics, options...)
+}
+
+// AppConnsOption sets an optional parameter on the AppConns.
+type AppConnsOption func(*multiAppConn)
+
+// WithMempoolConnections sets the number of connections the mempool sends
+// CheckTx requests on, which defaults to 1. It's capped by the CheckTx
+// concurrency advertised by the application in its Info response, if any. 0
+// uses the latter, or 1 if the application doesn't advertise one. Negative
+// values are ignored. The consensus connection is always a single connection,
+// as blocks must be executed serially.
+func WithMempoolConnections(n int) AppConnsOption {
+	return func(app *multiAppConn) {
+		if n >= 0 {
+			app.mempoolConns = n
+		}
+	}
+}
+
+// WithRequiredFeatures sets the optional ABCI features the node is configured
+// to use. The connections fail to start if the application declares its ABCI
+// version without declaring support for all of them, rather than failing at
+// the first unsupported call.
+func WithRequiredFeatures(features ...string) AppConnsOption {
+	return func(app *multiAppConn) {
+		app.requiredFeatures = features
+	}
+}
+
+// WithTimeouts sets the maximum durations of the synchronous calls on the
+// consensus, mempool and query connections, after which they fail with an
+// error wrapping context.DeadlineExceeded, so that a hung application doesn't
+// block their callers forever. On the mempool connection, they include the
+// flushes waiting for the responses of the pending CheckTx requests. Values
+// below 1 mean no limit, the default.
+func WithTimeouts(consensus, mempool, query time.Duration) AppConnsOption {
+	return func(app *multiAppConn) {
+		app.timeouts = map[string]time.Duration{
+			connConsensus: consensus,
+			connMempool:   mempool,
+			connQuery:     query,
+		}
+	}
 }
 
 // multiAppConn implements AppConns.
@@ -54,8 +113,12 @@ type multiAppConn struct {
 	queryConn     AppConnQuery
 	snapshotConn  AppConnSnapshot
 
+	mempoolConns        int
+	timeouts            map[string]time.Duration // by connection
+	requiredFeatures    []string
+	features            map[string]bool // nil if the app doesn't declare its ABCI version
 	consensusConnClient stoppableClient
-	mempoolConnClient   stoppableClient
+	mempoolConnClients  []stoppableClient
 	queryConnClient     stoppableClient
 	snapshotConnClient  stoppableClient
 
@@ -71,12 +134,21 @@ type stoppableClient interface {
 }
 
 // NewMultiAppConn makes all necessary abci connections to the application.
-func NewMultiAppConn(clientCreator abciclient.Creator, logger log.Logger, metrics *Metrics) AppConns {
+func NewMultiAppConn(
+	clientCreator abciclient.Creator,
+	logger log.Logger,
+	metrics *Metrics,
+	options ...AppConnsOption,
+) AppConns {
 	multiAppConn := &multiAppConn{
 		logger:        logger,
 		metrics:       metrics,
+		mempoolConns:  1,
 		clientCreator: clientCreator,
 	}
+	for _, option := range options {
+		option(multiAppConn)
+	}
 	multiAppConn.BaseService = *service.NewBaseService(logger, "multiAppConn", multiAppConn)
 	return multiAppConn
 }
@@ -97,15 +169,19 @@ func (app *multiAppConn) Snapshot() AppConnSnapshot {
 	return app.snapshotConn
 }
 
+func (app *multiAppConn) SupportsFeature(feature string) bool {
+	return app.features == nil || app.features[feature]
+}
+
 func (app *multiAppConn) OnStart(ctx context.Context) error {
-	c, err := app.abciClientFor(ctx, connQuery)
+	c, err := app.abciClientFor(ctx, connQuery, connQuery)
 	if err != nil {
 		return err
 	}
 	app.queryConnClient = c.(stoppableClient)
 	app.queryConn = NewAppConnQuery(c, app.metrics)
 
-	c, err = app.abciClientFor(ctx, connSnapshot)
+	c, err = app.abciClientFor(ctx, connSnapshot, connSnapshot)
 	if err != nil {
 		app.stopAllClients()
 		return err
@@ -113,15 +189,38 @@ func (app *multiAppConn) OnStart(ctx context.Context) error {
 	app.snapshotConnClient = c.(stoppableClient)
 	app.snapshotConn = NewAppConnSnapshot(c, app.metrics)
 
-	c, err = app.abciClientFor(ctx, connMempool)
+	info, err := app.queryConn.InfoSync(ctx, RequestInfo)
 	if err != nil {
+		app.stopAllClients()
+		return fmt.Errorf("error calling Info: %w", err)
+	}
+	if err := app.negotiateFeatures(info); err != nil {
 		app.stopAllClients()
 		return err
 	}
-	app.mempoolConnClient = c.(stoppableClient)
-	app.mempoolConn = NewAppConnMempool(c, app.metrics)
 
-	c, err = app.abciClientFor(ctx, connConsensus)
+	mempoolConns := app.mempoolConnections(info)
+	mempoolClients := make([]abciclient.Client, 0, mempoolConns)
+	for i := 0; i < mempoolConns; i++ {
+		conn := connMempool
+		if mempoolConns > 1 {
+			conn = fmt.Sprintf("%s-%d", connMempool, i)
+		}
+		c, err = app.abciClientFor(ctx, conn, connMempool)
+		if err != nil {
+			app.stopAllClients()
+			return err
+		}
+		app.mempoolConnClients = append(app.mempoolConnClients, c.(stoppableClient))
+		mempoolClients = append(mempoolClients, c)
+	}
+	if len(mempoolClients) == 1 {
+		app.mempoolConn = NewAppConnMempool(mempoolClients[0], app.metrics)
+	} else {
+		app.mempoolConn = NewAppConnMempoolPool(mempoolClients, app.metrics)
+	}
+
+	c, err = app.abciClientFor(ctx, connConsensus, connConsensus)
 	if err != nil {
 		app.stopAllClients()
 		return err
@@ -135,6 +234,60 @@ func (app *multiAppConn) OnStart(ctx context.Context) error {
 	return nil
 }
 
+// negotiateFeatures checks the ABCI version declared by the application in its
+// Info response against the node's, and that it supports the required
+// features. Applications which don't declare their ABCI version are not
+// checked, as they predate the negotiation.
+func (app *multiAppConn) negotiateFeatures(res *types.ResponseInfo) error {
+	if res.AbciVersion == "" {
+		app.logger.Info("the application doesn't declare its ABCI version, assuming it supports all features")
+		return nil
+	}
+	if err := CheckABCIVersion(res.AbciVersion); err != nil {
+		return err
+	}
+
+	features := make(map[string]bool, len(res.Features))
+	for _, feature := range res.Features {
+		features[feature] = true
+	}
+	for _, feature := range app.requiredFeatures {
+		if !features[feature] {
+			return fmt.Errorf("the application doesn't support the %q ABCI feature the node is configured to use",
+				feature)
+		}
+	}
+	app.features = features
+	app.logger.Info("negotiated ABCI features with the application",
+		"abci_version", res.AbciVersion, "features", res.Features)
+	return nil
+}
+
+// mempoolConnections returns the number of mempool connections to open, i.e.
+// the configured number capped by the CheckTx concurrency advertised by the
+// application, or the latter if the number isn't configured.
+func (app *multiAppConn) mempoolConnections(res *types.ResponseInfo) int {
+	if app.mempoolConns == 1 {
+		return 1
+	}
+
+	n := app.mempoolConns
+	if concurrency := int(res.CheckTxConcurrency); concurrency > 0 {
+		if concurrency > maxMempoolConnections {
+			concurrency = maxMempoolConnections
+		}
+		if n == 0 || concurrency < n {
+			n = concurrency
+		}
+	}
+	if n == 0 {
+		n = 1
+	}
+	app.logger.Info("connecting the mempool to the application",
+		"connections", n, "check_tx_concurrency", res.CheckTxConcurrency)
+	return n
+}
+
 func (app *multiAppConn) OnStop() {
 	app.stopAllClients()
 }
@@ -159,15 +312,11 @@ func (app *multiAppConn) startWatchersForClientErrorToKillTendermint(ctx context
 		name       string
 	}
 
-	for _, client := range []op{
+	clients := []op{
 		{
 			connClient: app.consensusConnClient,
 			name:       connConsensus,
 		},
-		{
-			connClient: app.mempoolConnClient,
-			name:       connMempool,
-		},
 		{
 			connClient: app.queryConnClient,
 			name:       connQuery,
@@ -176,7 +325,15 @@ func (app *multiAppConn) startWatchersForClientErrorToKillTendermint(ctx context
 			connClient: app.snapshotConnClient,
 			name:       connSnapshot,
 		},
-	} {
+	}
+	for _, client := range app.mempoolConnClients {
+		clients = append(clients, op{
+			connClient: client,
+			name:       connMempool,
+		})
+	}
+
+	for _, client := range clients {
 		go func(name string, client stoppableClient) {
 			client.Wait()
 			if ctx.Err() != nil {
@@ -197,8 +354,8 @@ func (app *multiAppConn) stopAllClients() {
 			}
 		}
 	}
-	if app.mempoolConnClient != nil {
-		if err := app.mempoolConnClient.Stop(); err != nil {
+	for _, client := range app.mempoolConnClients {
+		if err := client.Stop(); err != nil {
 			if !errors.Is(err, service.ErrAlreadyStopped) {
 				app.logger.Error("error while stopping mempool client", "error", err)
 			}
@@ -220,7 +377,9 @@ func (app *multiAppConn) stopAllClients() {
 	}
 }
 
-func (app *multiAppConn) abciClientFor(ctx context.Context, conn string) (abciclient.Client, error) {
+// abciClientFor creates and starts the client of the given connection, of the
+// given kind, which determines the timeout of its calls.
+func (app *multiAppConn) abciClientFor(ctx context.Context, conn, kind string) (abciclient.Client, error) {
 	c, err := app.clientCreator(app.logger.With(
 		"module", "abci-client",
 		"connection", conn))
@@ -230,6 +389,9 @@ func (app *multiAppConn) abciClientFor(ctx context.Context, conn string) (abcicl
 	if err := c.Start(ctx); err != nil {
 		return nil, fmt.Errorf("error starting ABCI client (%s connection): %w", conn, err)
 	}
+	if timeout := app.timeouts[kind]; timeout > 0 {
+		c = abciclient.NewInterceptedClient(c, timeoutInterceptor(timeout, app.metrics.MethodTimeouts))
+	}
 	return c, nil
 }
 
@@ -3,19 +3,24 @@ package proxy
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
 	"testing"
 	"time"
 
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	abciclient "github.com/tendermint/tendermint/abci/client"
 	abcimocks "github.com/tendermint/tendermint/abci/client/mocks"
+	"github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/version"
 )
 
 type noopStoppableClientImpl struct {
@@ -33,6 +38,7 @@ func TestAppConns_Start_Stop(t *testing.T) {
 	clientMock.On("Start", mock.Anything).Return(nil).Times(4)
 	clientMock.On("Error").Return(nil)
 	clientMock.On("Wait").Return(nil).Times(4)
+	clientMock.On("InfoSync", mock.Anything, RequestInfo).Return(&types.ResponseInfo{}, nil).Once()
 	cl := &noopStoppableClientImpl{Client: clientMock}
 
 	creatorCallCount := 0
@@ -77,6 +83,7 @@ func TestAppConns_Failure(t *testing.T) {
 
 	clientMock.On("Wait").Return(nil)
 	clientMock.On("Error").Return(errors.New("EOF"))
+	clientMock.On("InfoSync", mock.Anything, RequestInfo).Return(&types.ResponseInfo{}, nil)
 	cl := &noopStoppableClientImpl{Client: clientMock}
 
 	creator := func(log.Logger) (abciclient.Client, error) {
@@ -96,3 +103,221 @@ func TestAppConns_Failure(t *testing.T) {
 		t.Fatal("expected process to receive SIGTERM signal")
 	}
 }
+
+func TestAppConns_MempoolConnections(t *testing.T) {
+	testcases := map[string]struct {
+		configured  int
+		concurrency uint32
+		expected    int
+	}{
+		"configured":                       {3, 0, 3},
+		"capped by the app concurrency":    {3, 2, 2},
+		"below the app concurrency":        {3, 8, 3},
+		"app concurrency":                  {0, 4, 4},
+		"app concurrency beyond the limit": {0, 1000, maxMempoolConnections},
+		"no app concurrency":               {0, 0, 1},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			clients := 3 + tc.expected
+			clientMock := &abcimocks.Client{}
+			clientMock.On("Start", mock.Anything).Return(nil).Times(clients)
+			clientMock.On("Error").Return(nil).Maybe()
+			clientMock.On("Wait").Return(nil).Maybe()
+			clientMock.On("InfoSync", mock.Anything, RequestInfo).
+				Return(&types.ResponseInfo{CheckTxConcurrency: tc.concurrency}, nil).Once()
+			cl := &noopStoppableClientImpl{Client: clientMock}
+
+			creatorCallCount := 0
+			creator := func(logger log.Logger) (abciclient.Client, error) {
+				creatorCallCount++
+				return cl, nil
+			}
+
+			appConns := NewAppConns(creator, log.TestingLogger(), NopMetrics(), WithMempoolConnections(tc.configured))
+			require.NoError(t, appConns.Start(ctx))
+			if tc.expected == 1 {
+				assert.IsType(t, &appConnMempool{}, appConns.Mempool())
+			} else {
+				assert.IsType(t, &appConnMempoolPool{}, appConns.Mempool())
+				assert.Len(t, appConns.Mempool().(*appConnMempoolPool).appConns, tc.expected)
+			}
+
+			cancel()
+			appConns.Wait()
+
+			clientMock.AssertExpectations(t)
+			assert.Equal(t, clients, cl.count)
+			assert.Equal(t, clients, creatorCallCount)
+		})
+	}
+}
+
+func TestAppConns_InterceptedCreator(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clientMock := &abcimocks.Client{}
+	clientMock.On("Start", mock.Anything).Return(nil).Times(4)
+	clientMock.On("Error").Return(nil).Maybe()
+	clientMock.On("Wait").Return(nil).Maybe()
+	clientMock.On("InfoSync", mock.Anything, RequestInfo).Return(&types.ResponseInfo{Data: "app"}, nil).Twice()
+	cl := &noopStoppableClientImpl{Client: clientMock}
+
+	var methods []string
+	interceptor := func(
+		ctx context.Context,
+		req *types.Request,
+		invoke abciclient.Invoker,
+	) (*types.Response, error) {
+		methods = append(methods, fmt.Sprintf("%T", req.Value))
+		return invoke(ctx, req)
+	}
+	creator := abciclient.NewInterceptedCreator(func(logger log.Logger) (abciclient.Client, error) {
+		return cl, nil
+	}, interceptor)
+
+	appConns := NewAppConns(creator, log.TestingLogger(), NopMetrics())
+	require.NoError(t, appConns.Start(ctx))
+
+	res, err := appConns.Query().InfoSync(ctx, RequestInfo)
+	require.NoError(t, err)
+	assert.Equal(t, "app", res.Data)
+	// Info is called when starting too
+	assert.Equal(t, []string{"*types.Request_Info", "*types.Request_Info"}, methods)
+
+	// the intercepted clients are stopped with the connections
+	cancel()
+	appConns.Wait()
+
+	clientMock.AssertExpectations(t)
+	assert.Equal(t, 4, cl.count)
+}
+
+// recordingCounter records the label values of the samples added to it.
+type recordingCounter struct {
+	labelValues []string
+	samples     *[][]string
+}
+
+func (c recordingCounter) With(labelValues ...string) metrics.Counter {
+	return recordingCounter{labelValues: append(c.labelValues, labelValues...), samples: c.samples}
+}
+
+func (c recordingCounter) Add(float64) { *c.samples = append(*c.samples, c.labelValues) }
+
+func TestAppConns_Timeouts(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clientMock := &abcimocks.Client{}
+	clientMock.On("Start", mock.Anything).Return(nil).Times(4)
+	clientMock.On("Error").Return(nil).Maybe()
+	clientMock.On("Wait").Return(nil).Maybe()
+	// a hung application, only returning once the call is abandoned
+	clientMock.On("FinalizeBlockSync", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { <-args.Get(0).(context.Context).Done() }).
+		Return(nil, errors.New("transport error")).Once()
+	clientMock.On("InfoSync", mock.Anything, RequestInfo).Return(&types.ResponseInfo{Data: "app"}, nil).Twice()
+	cl := &noopStoppableClientImpl{Client: clientMock}
+
+	var timeouts [][]string
+	appMetrics := &Metrics{
+		MethodTiming:   discard.NewHistogram(),
+		MethodTimeouts: recordingCounter{samples: &timeouts},
+	}
+	creator := func(logger log.Logger) (abciclient.Client, error) {
+		return cl, nil
+	}
+	appConns := NewAppConns(creator, log.TestingLogger(), appMetrics, WithTimeouts(10*time.Millisecond, 0, time.Minute))
+	require.NoError(t, appConns.Start(ctx))
+
+	_, err := appConns.Consensus().FinalizeBlockSync(ctx, types.RequestFinalizeBlock{})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, [][]string{{"method", "finalize_block"}}, timeouts)
+
+	res, err := appConns.Query().InfoSync(ctx, RequestInfo)
+	require.NoError(t, err)
+	assert.Equal(t, "app", res.Data)
+	assert.Len(t, timeouts, 1)
+
+	cancel()
+	appConns.Wait()
+
+	clientMock.AssertExpectations(t)
+	assert.Equal(t, 4, cl.count)
+}
+
+func TestAppConns_NegotiateFeatures(t *testing.T) {
+	testcases := map[string]struct {
+		info      types.ResponseInfo
+		required  []string
+		err       string
+		supported map[string]bool
+	}{
+		"undeclared version": {
+			info:      types.ResponseInfo{},
+			required:  []string{types.FeatureInitChainChunks},
+			supported: map[string]bool{types.FeatureInitChainChunks: true, types.FeatureMempoolLanes: true},
+		},
+		"declared features": {
+			info:      types.ResponseInfo{AbciVersion: version.ABCIVersion, Features: []string{types.FeatureMempoolLanes}},
+			supported: map[string]bool{types.FeatureInitChainChunks: false, types.FeatureMempoolLanes: true},
+		},
+		"required features": {
+			info: types.ResponseInfo{AbciVersion: version.ABCIVersion, Features: []string{
+				types.FeatureInitChainChunks, "unknown",
+			}},
+			required:  []string{types.FeatureInitChainChunks},
+			supported: map[string]bool{types.FeatureInitChainChunks: true, types.FeatureMempoolLanes: false},
+		},
+		"missing required feature": {
+			info:     types.ResponseInfo{AbciVersion: version.ABCIVersion},
+			required: []string{types.FeatureInitChainChunks},
+			err:      `doesn't support the "init-chain-chunks" ABCI feature`,
+		},
+		"incompatible version": {
+			info: types.ResponseInfo{AbciVersion: "99.0.0"},
+			err:  "incompatible with the node's ABCI",
+		},
+	}
+	for name, tc := range testcases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			clientMock := &abcimocks.Client{}
+			clientMock.On("Start", mock.Anything).Return(nil)
+			clientMock.On("Error").Return(nil).Maybe()
+			clientMock.On("Wait").Return(nil).Maybe()
+			clientMock.On("InfoSync", mock.Anything, RequestInfo).Return(&tc.info, nil).Once()
+			cl := &noopStoppableClientImpl{Client: clientMock}
+			creator := func(logger log.Logger) (abciclient.Client, error) {
+				return cl, nil
+			}
+
+			appConns := NewAppConns(creator, log.TestingLogger(), NopMetrics(), WithRequiredFeatures(tc.required...))
+			err := appConns.Start(ctx)
+			if tc.err != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.err)
+				// the query and snapshot clients are stopped
+				assert.Equal(t, 2, cl.count)
+				return
+			}
+			require.NoError(t, err)
+			for feature, supported := range tc.supported {
+				assert.Equal(t, supported, appConns.SupportsFeature(feature), feature)
+			}
+
+			cancel()
+			appConns.Wait()
+		})
+	}
+}
@@ -0,0 +1,350 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	abciclient "github.com/tendermint/tendermint/abci/client"
+	"github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/service"
+)
+
+// ErrUpgradeHalt is returned when asked to execute a block at the upgrade
+// height without an upgraded application to switch to.
+var ErrUpgradeHalt = errors.New("reached the upgrade height, restart the node with the upgraded application")
+
+// upgradeAppConns implements AppConns by multiplexing between the connections
+// to an application and to its upgraded version, switching over once the
+// block before the upgrade height has been committed by the former.
+//
+// The connections to the upgraded application are only established when
+// switching over, so it need not be running before the upgrade height, and
+// the connections to the original application are closed afterwards. Without
+// an upgraded application, the consensus connection refuses to execute blocks
+// from the upgrade height onward, halting the node.
+type upgradeAppConns struct {
+	service.BaseService
+	logger log.Logger
+
+	upgradeHeight int64
+	lastHeight    int64
+	pre           AppConns
+	post          AppConns // nil to halt at the upgrade height
+
+	mtx         sync.RWMutex
+	ctx         context.Context // for starting post
+	upgraded    bool            // the upgrade height has been reached
+	height      int64           // height of the block being executed
+	consensusCb abciclient.Callback
+	mempoolCb   abciclient.Callback
+}
+
+// NewUpgradeAppConns returns AppConns using pre for the blocks below
+// upgradeHeight, and post from there on. If post is nil, blocks are not
+// executed from upgradeHeight onward. lastHeight is the height of the last
+// block committed by the node, and determines which of pre and post is used
+// when starting.
+func NewUpgradeAppConns(pre, post AppConns, upgradeHeight, lastHeight int64, logger log.Logger) AppConns {
+	app := &upgradeAppConns{
+		logger:        logger,
+		upgradeHeight: upgradeHeight,
+		lastHeight:    lastHeight,
+		pre:           pre,
+		post:          post,
+	}
+	app.BaseService = *service.NewBaseService(logger, "upgradeAppConns", app)
+	return app
+}
+
+func (app *upgradeAppConns) Mempool() AppConnMempool {
+	return &upgradeAppConnMempool{app: app}
+}
+
+func (app *upgradeAppConns) Consensus() AppConnConsensus {
+	return &upgradeAppConnConsensus{app: app}
+}
+
+func (app *upgradeAppConns) Query() AppConnQuery {
+	return &upgradeAppConnQuery{app: app}
+}
+
+func (app *upgradeAppConns) Snapshot() AppConnSnapshot {
+	return &upgradeAppConnSnapshot{app: app}
+}
+
+// SupportsFeature implements AppConns for the application in use, so the
+// features of the upgraded application only count from the upgrade height.
+func (app *upgradeAppConns) SupportsFeature(feature string) bool {
+	return app.active().SupportsFeature(feature)
+}
+
+func (app *upgradeAppConns) OnStart(ctx context.Context) error {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+
+	app.ctx = ctx
+	if app.lastHeight >= app.upgradeHeight-1 {
+		app.upgraded = true
+		if app.post == nil {
+			app.logger.Error("upgrade height was reached, but no upgraded application is configured",
+				"upgrade_height", app.upgradeHeight)
+		}
+	}
+	return app.activeLocked().Start(ctx)
+}
+
+func (app *upgradeAppConns) OnStop() {
+	app.mtx.RLock()
+	defer app.mtx.RUnlock()
+
+	if err := stopAppConns(app.activeLocked()); err != nil {
+		app.logger.Error("error while stopping application connections", "err", err)
+	}
+}
+
+// active returns the connections currently in use.
+func (app *upgradeAppConns) active() AppConns {
+	app.mtx.RLock()
+	defer app.mtx.RUnlock()
+	return app.activeLocked()
+}
+
+func (app *upgradeAppConns) activeLocked() AppConns {
+	if app.upgraded && app.post != nil {
+		return app.post
+	}
+	return app.pre
+}
+
+// upgrade switches over to the upgraded application, if there is one. The
+// caller must hold the lock.
+func (app *upgradeAppConns) upgrade() error {
+	if app.upgraded {
+		return nil
+	}
+	if app.post == nil {
+		app.upgraded = true
+		app.logger.Error("reached the upgrade height, halting", "upgrade_height", app.upgradeHeight)
+		return nil
+	}
+
+	app.logger.Info("reached the upgrade height, switching to the upgraded application",
+		"upgrade_height", app.upgradeHeight)
+	if err := app.post.Start(app.ctx); err != nil {
+		return fmt.Errorf("failed to connect to the upgraded application: %w", err)
+	}
+	if app.consensusCb != nil {
+		app.post.Consensus().SetResponseCallback(app.consensusCb)
+	}
+	if app.mempoolCb != nil {
+		app.post.Mempool().SetResponseCallback(app.mempoolCb)
+	}
+	app.upgraded = true
+
+	if err := stopAppConns(app.pre); err != nil {
+		app.logger.Error("error while stopping connections to the original application", "err", err)
+	}
+	return nil
+}
+
+// stopAppConns stops conns, if it can be stopped. See stoppableClient.
+func stopAppConns(conns AppConns) error {
+	s, ok := conns.(interface{ Stop() error })
+	if !ok {
+		return nil
+	}
+	if err := s.Stop(); err != nil && !errors.Is(err, service.ErrAlreadyStopped) {
+		return err
+	}
+	return nil
+}
+
+//----------------------------------------------------------------------------------------
+// Implements AppConnConsensus, switching applications at the upgrade height
+
+type upgradeAppConnConsensus struct {
+	app *upgradeAppConns
+}
+
+func (c *upgradeAppConnConsensus) SetResponseCallback(cb abciclient.Callback) {
+	c.app.mtx.Lock()
+	defer c.app.mtx.Unlock()
+
+	c.app.consensusCb = cb
+	c.app.activeLocked().Consensus().SetResponseCallback(cb)
+}
+
+func (c *upgradeAppConnConsensus) Error() error {
+	return c.app.active().Consensus().Error()
+}
+
+func (c *upgradeAppConnConsensus) InitChainSync(
+	ctx context.Context,
+	req types.RequestInitChain,
+) (*types.ResponseInitChain, error) {
+	return c.app.active().Consensus().InitChainSync(ctx, req)
+}
+
+func (c *upgradeAppConnConsensus) PrepareProposalSync(
+	ctx context.Context,
+	req types.RequestPrepareProposal,
+) (*types.ResponsePrepareProposal, error) {
+	return c.app.active().Consensus().PrepareProposalSync(ctx, req)
+}
+
+func (c *upgradeAppConnConsensus) ProcessProposalSync(
+	ctx context.Context,
+	req types.RequestProcessProposal,
+) (*types.ResponseProcessProposal, error) {
+	return c.app.active().Consensus().ProcessProposalSync(ctx, req)
+}
+
+func (c *upgradeAppConnConsensus) FinalizeBlockSync(
+	ctx context.Context,
+	req types.RequestFinalizeBlock,
+) (*types.ResponseFinalizeBlock, error) {
+	c.app.mtx.Lock()
+	c.app.height = req.Header.Height
+	if c.app.height >= c.app.upgradeHeight {
+		// Normally we have already switched over after committing the block
+		// before the upgrade height, but retry if that failed.
+		if err := c.app.upgrade(); err != nil {
+			c.app.mtx.Unlock()
+			return nil, err
+		}
+		if c.app.post == nil {
+			c.app.mtx.Unlock()
+			return nil, ErrUpgradeHalt
+		}
+	}
+	conn := c.app.activeLocked().Consensus()
+	c.app.mtx.Unlock()
+
+	return conn.FinalizeBlockSync(ctx, req)
+}
+
+func (c *upgradeAppConnConsensus) CommitSync(ctx context.Context) (*types.ResponseCommit, error) {
+	res, err := c.app.active().Consensus().CommitSync(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Switch over right after the commit, while the mempool is still locked,
+	// so that its transactions are rechecked by the upgraded application.
+	c.app.mtx.Lock()
+	defer c.app.mtx.Unlock()
+	if c.app.height == c.app.upgradeHeight-1 {
+		if err := c.app.upgrade(); err != nil {
+			// The block was committed, so only log the error. Executing the
+			// next block will retry and halt if the upgrade still fails.
+			c.app.logger.Error("failed to switch to the upgraded application", "err", err)
+		}
+	}
+	return res, nil
+}
+
+//----------------------------------------------------------------------------------------
+// Implements AppConnMempool, using the current application
+
+type upgradeAppConnMempool struct {
+	app *upgradeAppConns
+}
+
+func (c *upgradeAppConnMempool) SetResponseCallback(cb abciclient.Callback) {
+	c.app.mtx.Lock()
+	defer c.app.mtx.Unlock()
+
+	c.app.mempoolCb = cb
+	c.app.activeLocked().Mempool().SetResponseCallback(cb)
+}
+
+func (c *upgradeAppConnMempool) Error() error {
+	return c.app.active().Mempool().Error()
+}
+
+func (c *upgradeAppConnMempool) CheckTxAsync(
+	ctx context.Context,
+	req types.RequestCheckTx,
+) (*abciclient.ReqRes, error) {
+	return c.app.active().Mempool().CheckTxAsync(ctx, req)
+}
+
+func (c *upgradeAppConnMempool) CheckTxSync(
+	ctx context.Context,
+	req types.RequestCheckTx,
+) (*types.ResponseCheckTx, error) {
+	return c.app.active().Mempool().CheckTxSync(ctx, req)
+}
+
+func (c *upgradeAppConnMempool) FlushAsync(ctx context.Context) (*abciclient.ReqRes, error) {
+	return c.app.active().Mempool().FlushAsync(ctx)
+}
+
+func (c *upgradeAppConnMempool) FlushSync(ctx context.Context) error {
+	return c.app.active().Mempool().FlushSync(ctx)
+}
+
+//----------------------------------------------------------------------------------------
+// Implements AppConnQuery, using the current application
+
+type upgradeAppConnQuery struct {
+	app *upgradeAppConns
+}
+
+func (c *upgradeAppConnQuery) Error() error {
+	return c.app.active().Query().Error()
+}
+
+func (c *upgradeAppConnQuery) EchoSync(ctx context.Context, msg string) (*types.ResponseEcho, error) {
+	return c.app.active().Query().EchoSync(ctx, msg)
+}
+
+func (c *upgradeAppConnQuery) InfoSync(ctx context.Context, req types.RequestInfo) (*types.ResponseInfo, error) {
+	return c.app.active().Query().InfoSync(ctx, req)
+}
+
+func (c *upgradeAppConnQuery) QuerySync(ctx context.Context, req types.RequestQuery) (*types.ResponseQuery, error) {
+	return c.app.active().Query().QuerySync(ctx, req)
+}
+
+//----------------------------------------------------------------------------------------
+// Implements AppConnSnapshot, using the current application
+
+type upgradeAppConnSnapshot struct {
+	app *upgradeAppConns
+}
+
+func (c *upgradeAppConnSnapshot) Error() error {
+	return c.app.active().Snapshot().Error()
+}
+
+func (c *upgradeAppConnSnapshot) ListSnapshotsSync(
+	ctx context.Context,
+	req types.RequestListSnapshots,
+) (*types.ResponseListSnapshots, error) {
+	return c.app.active().Snapshot().ListSnapshotsSync(ctx, req)
+}
+
+func (c *upgradeAppConnSnapshot) OfferSnapshotSync(
+	ctx context.Context,
+	req types.RequestOfferSnapshot,
+) (*types.ResponseOfferSnapshot, error) {
+	return c.app.active().Snapshot().OfferSnapshotSync(ctx, req)
+}
+
+func (c *upgradeAppConnSnapshot) LoadSnapshotChunkSync(
+	ctx context.Context,
+	req types.RequestLoadSnapshotChunk,
+) (*types.ResponseLoadSnapshotChunk, error) {
+	return c.app.active().Snapshot().LoadSnapshotChunkSync(ctx, req)
+}
+
+func (c *upgradeAppConnSnapshot) ApplySnapshotChunkSync(
+	ctx context.Context,
+	req types.RequestApplySnapshotChunk,
+) (*types.ResponseApplySnapshotChunk, error) {
+	return c.app.active().Snapshot().ApplySnapshotChunkSync(ctx, req)
+}
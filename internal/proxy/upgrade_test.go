@@ -0,0 +1,119 @@
+package proxy
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abciclient "github.com/tendermint/tendermint/abci/client"
+	"github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+// heightsApp records the heights of the blocks it executes.
+type heightsApp struct {
+	types.BaseApplication
+	name string
+
+	mtx     sync.Mutex
+	heights []int64
+}
+
+func (app *heightsApp) Info(types.RequestInfo) types.ResponseInfo {
+	return types.ResponseInfo{Data: app.name}
+}
+
+func (app *heightsApp) FinalizeBlock(req types.RequestFinalizeBlock) types.ResponseFinalizeBlock {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	app.heights = append(app.heights, req.Header.Height)
+	return types.ResponseFinalizeBlock{}
+}
+
+func (app *heightsApp) Heights() []int64 {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	return app.heights
+}
+
+func newHeightsAppConns(name string) (*heightsApp, AppConns) {
+	app := &heightsApp{name: name}
+	return app, NewAppConns(abciclient.NewLocalCreator(app), log.TestingLogger(), NopMetrics())
+}
+
+func executeBlock(ctx context.Context, conn AppConnConsensus, height int64) error {
+	_, err := conn.FinalizeBlockSync(ctx, types.RequestFinalizeBlock{Header: tmproto.Header{Height: height}})
+	if err != nil {
+		return err
+	}
+	_, err = conn.CommitSync(ctx)
+	return err
+}
+
+func TestUpgradeAppConns_Switch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	preApp, pre := newHeightsAppConns("pre")
+	postApp, post := newHeightsAppConns("post")
+	appConns := NewUpgradeAppConns(pre, post, 3, 0, log.TestingLogger())
+	require.NoError(t, appConns.Start(ctx))
+
+	conn := appConns.Consensus()
+	for height := int64(1); height <= 4; height++ {
+		require.NoError(t, executeBlock(ctx, conn, height))
+
+		// The upgraded application is used as soon as the block before the
+		// upgrade height has been committed.
+		info, err := appConns.Query().InfoSync(ctx, types.RequestInfo{})
+		require.NoError(t, err)
+		if height < 2 {
+			require.Equal(t, "pre", info.Data)
+		} else {
+			require.Equal(t, "post", info.Data)
+		}
+	}
+
+	require.Equal(t, []int64{1, 2}, preApp.Heights())
+	require.Equal(t, []int64{3, 4}, postApp.Heights())
+	require.False(t, pre.IsRunning())
+	require.True(t, post.IsRunning())
+}
+
+func TestUpgradeAppConns_Restart(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	preApp, pre := newHeightsAppConns("pre")
+	postApp, post := newHeightsAppConns("post")
+	appConns := NewUpgradeAppConns(pre, post, 3, 2, log.TestingLogger())
+	require.NoError(t, appConns.Start(ctx))
+
+	require.NoError(t, executeBlock(ctx, appConns.Consensus(), 3))
+	require.Empty(t, preApp.Heights())
+	require.Equal(t, []int64{3}, postApp.Heights())
+	require.False(t, pre.IsRunning())
+}
+
+func TestUpgradeAppConns_Halt(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	preApp, pre := newHeightsAppConns("pre")
+	appConns := NewUpgradeAppConns(pre, nil, 3, 0, log.TestingLogger())
+	require.NoError(t, appConns.Start(ctx))
+
+	conn := appConns.Consensus()
+	require.NoError(t, executeBlock(ctx, conn, 1))
+	require.NoError(t, executeBlock(ctx, conn, 2))
+	require.ErrorIs(t, executeBlock(ctx, conn, 3), ErrUpgradeHalt)
+	require.Equal(t, []int64{1, 2}, preApp.Heights())
+
+	// The original application can still be queried.
+	info, err := appConns.Query().InfoSync(ctx, types.RequestInfo{})
+	require.NoError(t, err)
+	require.Equal(t, "pre", info.Data)
+}
@@ -1,6 +1,10 @@
 package proxy
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/version"
 )
@@ -14,3 +18,44 @@ var RequestInfo = abci.RequestInfo{
 	P2PVersion:   version.P2PProtocol,
 	AbciVersion:  version.ABCIVersion,
 }
+
+// SupportedFeatures are the optional ABCI features supported by the node.
+var SupportedFeatures = []string{
+	abci.FeatureInitChainChunks,
+	abci.FeatureMempoolLanes,
+}
+
+// CheckABCIVersion returns an error if the application implements a version
+// of ABCI incompatible with the node's: one of another major version, or of a
+// later minor version, which the application may rely on. Before 1.0, minor
+// versions are incompatible with each other.
+func CheckABCIVersion(appVersion string) error {
+	appMajor, appMinor, err := parseMajorMinor(appVersion)
+	if err != nil {
+		return fmt.Errorf("invalid ABCI version %q declared by the application: %w", appVersion, err)
+	}
+	major, minor, err := parseMajorMinor(version.ABCIVersion)
+	if err != nil {
+		return fmt.Errorf("invalid ABCI version %q of the node: %w", version.ABCIVersion, err)
+	}
+	if appMajor != major || appMinor > minor || (major == 0 && appMinor != minor) {
+		return fmt.Errorf("the application implements ABCI %s, incompatible with the node's ABCI %s",
+			appVersion, version.ABCIVersion)
+	}
+	return nil
+}
+
+// parseMajorMinor returns the major and minor versions of a semantic version.
+func parseMajorMinor(v string) (major, minor uint64, err error) {
+	parts := strings.SplitN(strings.TrimPrefix(v, "v"), ".", 3)
+	if len(parts) < 2 {
+		return 0, 0, fmt.Errorf("expected a semantic version")
+	}
+	if major, err = strconv.ParseUint(parts[0], 10, 64); err != nil {
+		return 0, 0, err
+	}
+	if minor, err = strconv.ParseUint(parts[1], 10, 64); err != nil {
+		return 0, 0, err
+	}
+	return major, minor, nil
+}
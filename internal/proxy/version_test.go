@@ -0,0 +1,39 @@
+package proxy
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/version"
+)
+
+func TestCheckABCIVersion(t *testing.T) {
+	major, minor, err := parseMajorMinor(version.ABCIVersion)
+	require.NoError(t, err)
+	// before 1.0, minor versions are incompatible with each other
+	require.EqualValues(t, 0, major)
+
+	testcases := map[string]bool{
+		version.ABCIVersion:             true,
+		"v" + version.ABCIVersion:       true,
+		fmt.Sprintf("0.%d.99", minor):   true,
+		fmt.Sprintf("0.%d", minor):      true,
+		fmt.Sprintf("0.%d.0", minor+1):  false,
+		fmt.Sprintf("0.%d.0", minor-1):  false,
+		fmt.Sprintf("1.%d.0", minor):    false,
+		"0":                             false,
+		fmt.Sprintf("zero.%d.0", minor): false,
+		"":                              false,
+	}
+	for v, compatible := range testcases {
+		err := CheckABCIVersion(v)
+		if compatible {
+			assert.NoError(t, err, v)
+		} else {
+			assert.Error(t, err, v)
+		}
+	}
+}
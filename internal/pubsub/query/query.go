@@ -5,6 +5,7 @@
 // strings like:
 //
 //    abci.invoice.number = 22 AND abci.invoice.owner = 'Ivan'
+//    abci.invoice.owner LIKE 'Iv%' OR NOT abci.invoice.paid EXISTS
 //
 // Query expressions can handle attribute values encoding numbers, strings,
 // dates, and timestamps.  The complete query grammar is described in the
@@ -28,8 +29,8 @@ var All *Query
 
 // A Query is the compiled form of a query.
 type Query struct {
-	ast   syntax.Query
-	conds []condition
+	ast     syntax.Query
+	clauses [][]condition
 }
 
 // New parses and compiles the query expression into an executable query.
@@ -56,15 +57,19 @@ func MustCompile(query string) *Query {
 
 // Compile compiles the given query AST so it can be used to match events.
 func Compile(ast syntax.Query) (*Query, error) {
-	conds := make([]condition, len(ast))
-	for i, q := range ast {
-		cond, err := compileCondition(q)
-		if err != nil {
-			return nil, fmt.Errorf("compile %s: %w", q, err)
+	clauses := make([][]condition, len(ast))
+	for i, clause := range ast {
+		conds := make([]condition, len(clause))
+		for j, q := range clause {
+			cond, err := compileCondition(q)
+			if err != nil {
+				return nil, fmt.Errorf("compile %s: %w", q, err)
+			}
+			conds[j] = cond
 		}
-		conds[i] = cond
+		clauses[i] = conds
 	}
-	return &Query{ast: ast, conds: conds}, nil
+	return &Query{ast: ast, clauses: clauses}, nil
 }
 
 // Matches satisfies part of the pubsub.Query interface.  This implementation
@@ -92,21 +97,37 @@ func (q *Query) Syntax() syntax.Query {
 	return q.ast
 }
 
-// matchesEvents reports whether all the conditions match the given events.
+// matchesEvents reports whether all the conditions of at least one clause
+// match the given events.
 func (q *Query) matchesEvents(events []types.Event) bool {
-	for _, cond := range q.conds {
-		if !cond.matchesAny(events) {
+	if len(events) == 0 {
+		return false
+	}
+	for _, conds := range q.clauses {
+		if matchesAll(conds, events) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAll reports whether all the conditions match the given events.
+func matchesAll(conds []condition, events []types.Event) bool {
+	for _, cond := range conds {
+		if cond.matchesAny(events) == cond.not {
 			return false
 		}
 	}
-	return len(events) != 0
+	return true
 }
 
 // A condition is a compiled match condition.  A condition matches an event if
 // the event has the designated type, contains an attribute with the given
-// name, and the match function returns true for the attribute value.
+// name, and the match function returns true for the attribute value. A negated
+// condition is satisfied by a set of events if it matches none of them.
 type condition struct {
 	tag   string // e.g., "tx.hash"
+	not   bool
 	match func(s string) bool
 }
 
@@ -162,7 +183,7 @@ func (c condition) matchesEvent(event types.Event) bool {
 }
 
 func compileCondition(cond syntax.Condition) (condition, error) {
-	out := condition{tag: cond.Tag}
+	out := condition{tag: cond.Tag, not: cond.Not}
 
 	// Handle existence checks separately to simplify the logic below for
 	// comparisons that take arguments.
@@ -221,6 +242,13 @@ var opTypeMap = map[syntax.Token]map[syntax.Token]func(interface{}) func(string)
 			}
 		},
 	},
+	syntax.TLike: {
+		syntax.TString: func(v interface{}) func(string) bool {
+			return func(s string) bool {
+				return syntax.MatchLike(v.(string), s)
+			}
+		},
+	},
 	syntax.TEq: {
 		syntax.TString: func(v interface{}) func(string) bool {
 			return func(s string) bool { return s == v.(string) }
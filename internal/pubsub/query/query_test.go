@@ -186,6 +186,30 @@ func TestCompiledMatches(t *testing.T) {
 			apiEvents, false},
 		{`tm.event = 'Tx' AND rewards.withdraw.source = 'W'`,
 			apiEvents, false},
+
+		// Disjunction, negation, and pattern matching.
+		{`transfer.sender = 'AddrZ' OR transfer.sender = 'AddrC'`,
+			apiEvents, true},
+		{`transfer.sender = 'AddrZ' OR rewards.withdraw.source = 'W'`,
+			apiEvents, false},
+		{`tm.event = 'Tx' AND transfer.sender = 'AddrZ' OR tm.height = 5`,
+			apiEvents, true},
+		{`tm.event = 'Tx' AND NOT transfer.sender = 'AddrZ'`,
+			apiEvents, true},
+		{`tm.event = 'Tx' AND NOT transfer.sender = 'AddrC'`,
+			apiEvents, false},
+		{`NOT slash EXISTS`,
+			apiEvents, true},
+		{`NOT rewards.withdraw.amount > 50`,
+			apiEvents, false},
+		{`rewards.withdraw.address LIKE 'Addr%'`,
+			apiEvents, true},
+		{`rewards.withdraw.address LIKE 'Addr_'`,
+			apiEvents, true},
+		{`rewards.withdraw.address LIKE 'Addr'`,
+			apiEvents, false},
+		{`NOT transfer.recipient LIKE '%D'`,
+			apiEvents, false},
 	}
 
 	// NOTE: The original implementation allowed arbitrary prefix matches on
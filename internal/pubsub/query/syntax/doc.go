@@ -5,15 +5,23 @@
 //
 // The grammar of the query language is defined by the following EBNF:
 //
-//   query      = conditions EOF
-//   conditions = condition {"AND" condition}
-//   condition  = tag comparison
-//   comparison = equal / order / contains / "EXISTS"
+//   query      = clause {"OR" clause} EOF
+//   clause     = condition {"AND" condition}
+//   condition  = ["NOT"] tag comparison
+//   comparison = equal / order / contains / like / "EXISTS"
 //   equal      = "=" (date / number / time / value)
 //   order      = cmp (date / number / time)
 //   contains   = "CONTAINS" value
+//   like       = "LIKE" value
 //   cmp        = "<" / "<=" / ">" / ">="
 //
+// AND binds more tightly than OR, so a query is a disjunction of clauses, each
+// of which is a conjunction of conditions. NOT applies only to the condition
+// immediately following it.
+//
+// The argument of LIKE is a pattern in which "%" matches any sequence of
+// characters and "_" matches any single character.
+//
 // The lexical terms are defined here using RE2 regular expression notation:
 //
 //   // The name of an event attribute (type.value)
@@ -15,13 +15,24 @@ func Parse(s string) (Query, error) {
 	return NewParser(strings.NewReader(s)).Parse()
 }
 
-// Query is the root of the parse tree for a query.  A query is the conjunction
-// of one or more conditions.
-type Query []Condition
+// Query is the root of the parse tree for a query.  A query is the disjunction
+// of one or more clauses.
+type Query []Clause
 
 func (q Query) String() string {
 	ss := make([]string, len(q))
-	for i, cond := range q {
+	for i, clause := range q {
+		ss[i] = clause.String()
+	}
+	return strings.Join(ss, " OR ")
+}
+
+// A Clause is the conjunction of one or more conditions.
+type Clause []Condition
+
+func (c Clause) String() string {
+	ss := make([]string, len(c))
+	for i, cond := range c {
 		ss[i] = cond.String()
 	}
 	return strings.Join(ss, " AND ")
@@ -29,17 +40,22 @@ func (q Query) String() string {
 
 // A Condition is a single conditional expression, consisting of a tag, a
 // comparison operator, and an optional argument. The type of the argument
-// depends on the operator.
+// depends on the operator. If Not is true, the sense of the condition is
+// inverted.
 type Condition struct {
 	Tag string
 	Op  Token
 	Arg *Arg
+	Not bool
 
 	opText string
 }
 
 func (c Condition) String() string {
 	s := c.Tag + " " + c.opText
+	if c.Not {
+		s = "NOT " + s
+	}
 	if c.Arg != nil {
 		return s + " " + c.Arg.String()
 	}
@@ -122,32 +138,44 @@ func NewParser(r io.Reader) *Parser {
 
 // Parse parses the complete input and returns the resulting query.
 func (p *Parser) Parse() (Query, error) {
-	cond, err := p.parseCond()
-	if err != nil {
-		return nil, err
-	}
-	conds := []Condition{cond}
-	for p.scanner.Next() != io.EOF {
-		if tok := p.scanner.Token(); tok != TAnd {
-			return nil, fmt.Errorf("offset %d: got %v, want %v", p.scanner.Pos(), tok, TAnd)
-		}
+	var query Query
+	var clause Clause
+	for {
 		cond, err := p.parseCond()
 		if err != nil {
 			return nil, err
 		}
-		conds = append(conds, cond)
+		clause = append(clause, cond)
+		if p.scanner.Next() == io.EOF {
+			break
+		}
+		switch tok := p.scanner.Token(); tok {
+		case TAnd:
+			// continue the current clause
+		case TOr:
+			query = append(query, clause)
+			clause = nil
+		default:
+			return nil, fmt.Errorf("offset %d: got %v, wanted %s", p.scanner.Pos(), tok, tokLabel([]Token{TAnd, TOr}))
+		}
 	}
-	return conds, nil
+	return append(query, clause), nil
 }
 
-// parseCond parses a conditional expression: tag OP value.
+// parseCond parses a conditional expression: [NOT] tag OP value.
 func (p *Parser) parseCond() (Condition, error) {
 	var cond Condition
-	if err := p.require(TTag); err != nil {
+	if err := p.require(TTag, TNot); err != nil {
 		return cond, err
 	}
+	if p.scanner.Token() == TNot {
+		cond.Not = true
+		if err := p.require(TTag); err != nil {
+			return cond, err
+		}
+	}
 	cond.Tag = p.scanner.Text()
-	if err := p.require(TLeq, TGeq, TLt, TGt, TEq, TContains, TExists); err != nil {
+	if err := p.require(TLeq, TGeq, TLt, TGt, TEq, TContains, TLike, TExists); err != nil {
 		return cond, err
 	}
 	cond.Op = p.scanner.Token()
@@ -159,7 +187,7 @@ func (p *Parser) parseCond() (Condition, error) {
 		err = p.require(TNumber, TTime, TDate)
 	case TEq:
 		err = p.require(TNumber, TTime, TDate, TString)
-	case TContains:
+	case TContains, TLike:
 		err = p.require(TString)
 	case TExists:
 		// no argument
@@ -211,3 +239,43 @@ func ParseDate(s string) (time.Time, error) {
 func ParseTime(s string) (time.Time, error) {
 	return time.Parse(time.RFC3339, s)
 }
+
+// MatchLike reports whether s matches the LIKE pattern. In a pattern, the
+// character "%" matches any sequence of zero or more characters, and "_"
+// matches any single character. All other characters match themselves.
+func MatchLike(pattern, s string) bool {
+	p, v := []rune(pattern), []rune(s)
+	var pi, vi int
+	star, mark := -1, 0
+	for vi < len(v) {
+		switch {
+		case pi < len(p) && p[pi] == '%':
+			star, mark = pi, vi
+			pi++
+		case pi < len(p) && (p[pi] == '_' || p[pi] == v[vi]):
+			pi++
+			vi++
+		case star >= 0:
+			// Backtrack: let the last "%" absorb one more character.
+			pi = star + 1
+			mark++
+			vi = mark
+		default:
+			return false
+		}
+	}
+	for pi < len(p) && p[pi] == '%' {
+		pi++
+	}
+	return pi == len(p)
+}
+
+// LikePrefix returns the literal prefix of the LIKE pattern, that is, the
+// portion of the pattern preceding its first wildcard. Every string matching
+// the pattern begins with this prefix.
+func LikePrefix(pattern string) string {
+	if i := strings.IndexAny(pattern, "%_"); i >= 0 {
+		return pattern[:i]
+	}
+	return pattern
+}
@@ -28,6 +28,9 @@ const (
 	TLeq             // operator: <=
 	TGt              // operator: >
 	TGeq             // operator: >=
+	TOr              // operator: OR
+	TNot             // operator: NOT
+	TLike            // operator: LIKE
 
 	// Do not reorder these values without updating the scanner code.
 )
@@ -47,6 +50,9 @@ var tString = [...]string{
 	TLeq:      "<= operator",
 	TGt:       "> operator",
 	TGeq:      ">= operator",
+	TOr:       "OR operator",
+	TNot:      "NOT operator",
+	TLike:     "LIKE operator",
 }
 
 func (t Token) String() string {
@@ -224,6 +230,12 @@ func (s *Scanner) scanTagLike(first rune) error {
 		s.tok = TTag
 	case "AND":
 		s.tok = TAnd
+	case "OR":
+		s.tok = TOr
+	case "NOT":
+		s.tok = TNot
+	case "LIKE":
+		s.tok = TLike
 	case "EXISTS":
 		s.tok = TExists
 	case "CONTAINS":
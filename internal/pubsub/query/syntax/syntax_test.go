@@ -40,6 +40,8 @@ func TestScanner(t *testing.T) {
 		{`x.y CONTAINS 'z'`, []syntax.Token{syntax.TTag, syntax.TContains, syntax.TString}},
 		{`foo EXISTS`, []syntax.Token{syntax.TTag, syntax.TExists}},
 		{`and AND`, []syntax.Token{syntax.TTag, syntax.TAnd}},
+		{`x OR NOT y`, []syntax.Token{syntax.TTag, syntax.TOr, syntax.TNot, syntax.TTag}},
+		{`x.y LIKE 'z%'`, []syntax.Token{syntax.TTag, syntax.TLike, syntax.TString}},
 
 		// Timestamp
 		{`TIME 2021-11-23T15:16:17Z`, []syntax.Token{syntax.TTime}},
@@ -167,6 +169,20 @@ func TestParseValid(t *testing.T) {
 
 		{"hash='136E18F7E4C348B780CF873A0BF43922E5BAFA63'", true},
 		{"hash=136E18F7E4C348B780CF873A0BF43922E5BAFA63", false},
+
+		{"account.owner = 'Ivan' OR account.owner = 'Igor'", true},
+		{"a.b = 1 AND a.c = 2 OR a.d = 3 AND a.e EXISTS", true},
+		{"a.b = 1 OR", false},
+		{"OR a.b = 1", false},
+		{"a.b = 1 OR OR a.c = 2", false},
+		{"NOT account.owner = 'Ivan'", true},
+		{"account.number > 1 AND NOT account.owner EXISTS", true},
+		{"NOT NOT account.owner EXISTS", false},
+		{"account.owner NOT = 'Ivan'", false},
+		{"NOT", false},
+		{"account.owner LIKE 'Iv%'", true},
+		{"account.owner LIKE 5", false},
+		{"account.owner LIKE", false},
 	}
 
 	for _, test := range tests {
@@ -188,3 +204,69 @@ func TestParseValid(t *testing.T) {
 		}
 	}
 }
+
+func TestParseClauses(t *testing.T) {
+	q, err := syntax.Parse("a.b = 1 AND NOT a.c EXISTS OR a.d LIKE 'x_%'")
+	if err != nil {
+		t.Fatalf("Parse: unexpected error: %v", err)
+	}
+	if len(q) != 2 {
+		t.Fatalf("Wrong number of clauses: got %d, want 2", len(q))
+	}
+	if len(q[0]) != 2 || len(q[1]) != 1 {
+		t.Errorf("Wrong clause sizes: got %d, %d; want 2, 1", len(q[0]), len(q[1]))
+	}
+	if c := q[0][1]; !c.Not || c.Tag != "a.c" || c.Op != syntax.TExists {
+		t.Errorf("Wrong negated condition: %+v", c)
+	}
+	if c := q[1][0]; c.Not || c.Op != syntax.TLike || c.Arg.Value() != "x_%" {
+		t.Errorf("Wrong LIKE condition: %+v", c)
+	}
+}
+
+func TestMatchLike(t *testing.T) {
+	tests := []struct {
+		pattern, input string
+		want           bool
+	}{
+		{"", "", true},
+		{"", "a", false},
+		{"%", "", true},
+		{"%", "anything", true},
+		{"abc", "abc", true},
+		{"abc", "abcd", false},
+		{"ab%", "abcd", true},
+		{"ab%", "xabcd", false},
+		{"%cd", "abcd", true},
+		{"%b%", "abcd", true},
+		{"%x%", "abcd", false},
+		{"a_c", "abc", true},
+		{"a_c", "ac", false},
+		{"a%c%e", "abcde", true},
+		{"a%c%e", "abcdf", false},
+		{"%%a", "ba", true},
+		{"_", "é", true},
+	}
+	for _, test := range tests {
+		if got := syntax.MatchLike(test.pattern, test.input); got != test.want {
+			t.Errorf("MatchLike(%q, %q): got %v, want %v", test.pattern, test.input, got, test.want)
+		}
+	}
+}
+
+func TestLikePrefix(t *testing.T) {
+	tests := []struct {
+		pattern, want string
+	}{
+		{"", ""},
+		{"abc", "abc"},
+		{"ab%", "ab"},
+		{"a_c%", "a"},
+		{"%abc", ""},
+	}
+	for _, test := range tests {
+		if got := syntax.LikePrefix(test.pattern); got != test.want {
+			t.Errorf("LikePrefix(%q): got %q, want %q", test.pattern, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+// Package auth authorizes the calls of RPC clients by API key.
+//
+// Clients send their key as a bearer token, in an "Authorization: Bearer <key>"
+// header. Each key grants access to a list of methods and may be rate limited.
+// A key with an empty value applies to the clients which send no key at all;
+// without one, such clients are refused.
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	rpcserver "github.com/tendermint/tendermint/rpc/jsonrpc/server"
+)
+
+// AllMethods, in the methods of a key, stands for all the RPC methods.
+const AllMethods = "*"
+
+// Key is an API key and the access it grants.
+type Key struct {
+	// Name of the key, identifying it in logs and errors.
+	Name string `json:"name"`
+	// Value sent by the clients as bearer token. Empty for the clients which
+	// send no key.
+	Key string `json:"key"`
+	// RPC methods the key grants access to. "*" stands for all the methods.
+	Methods []string `json:"methods"`
+	// Calls per second allowed on average, across all the clients using the
+	// key. 0 is unlimited.
+	RateLimit float64 `json:"rate_limit"`
+	// Calls allowed in a burst. Defaults to the rate limit, rounded up.
+	Burst int `json:"burst"`
+}
+
+// ValidateBasic performs basic validation of the key.
+func (k Key) ValidateBasic() error {
+	if k.Name == "" {
+		return errors.New("name can't be empty")
+	}
+	if len(k.Methods) == 0 {
+		return fmt.Errorf("key %s: methods can't be empty", k.Name)
+	}
+	if k.RateLimit < 0 || math.IsNaN(k.RateLimit) || math.IsInf(k.RateLimit, 0) {
+		return fmt.Errorf("key %s: invalid rate_limit %v", k.Name, k.RateLimit)
+	}
+	if k.Burst < 0 {
+		return fmt.Errorf("key %s: burst can't be negative", k.Name)
+	}
+	return nil
+}
+
+// ParseKeys parses a JSON list of keys.
+func ParseKeys(bz []byte) ([]Key, error) {
+	var keys []Key
+	if err := json.Unmarshal(bz, &keys); err != nil {
+		return nil, fmt.Errorf("parsing API keys: %w", err)
+	}
+	return keys, nil
+}
+
+// Load returns an Authenticator for the keys in file and in keys, a JSON list
+// of keys. Either may be empty; if both are, it returns nil as there is
+// nothing to authorize.
+func Load(file, keys string) (*Authenticator, error) {
+	var all []Key
+	if file != "" {
+		bz, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("reading API keys: %w", err)
+		}
+		fileKeys, err := ParseKeys(bz)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		all = append(all, fileKeys...)
+	}
+	if keys != "" {
+		inlineKeys, err := ParseKeys([]byte(keys))
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, inlineKeys...)
+	}
+	if len(all) == 0 {
+		return nil, nil
+	}
+	return NewAuthenticator(all)
+}
+
+// Authenticator authorizes RPC calls by API key.
+type Authenticator struct {
+	// keys by the SHA256 hash of their value, which does not leak the keys
+	// through the timing of the lookups
+	keys map[[sha256.Size]byte]*grant
+}
+
+// grant is the access granted by a key.
+type grant struct {
+	name    string
+	all     bool
+	methods map[string]bool
+	limiter *limiter
+}
+
+// NewAuthenticator returns an Authenticator for the given keys.
+func NewAuthenticator(keys []Key) (*Authenticator, error) {
+	a := &Authenticator{keys: make(map[[sha256.Size]byte]*grant, len(keys))}
+	names := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		if err := k.ValidateBasic(); err != nil {
+			return nil, err
+		}
+		if names[k.Name] {
+			return nil, fmt.Errorf("duplicate key name %s", k.Name)
+		}
+		names[k.Name] = true
+
+		hash := sha256.Sum256([]byte(k.Key))
+		if _, ok := a.keys[hash]; ok {
+			return nil, fmt.Errorf("key %s: duplicate key", k.Name)
+		}
+
+		g := &grant{name: k.Name, methods: make(map[string]bool, len(k.Methods))}
+		for _, method := range k.Methods {
+			if method == AllMethods {
+				g.all = true
+			}
+			g.methods[method] = true
+		}
+		if k.RateLimit > 0 {
+			burst := k.Burst
+			if burst == 0 {
+				burst = int(math.Ceil(k.RateLimit))
+			}
+			g.limiter = newLimiter(k.RateLimit, burst)
+		}
+		a.keys[hash] = g
+	}
+	return a, nil
+}
+
+// Authorize authorizes the call of method by the client which sent r. It
+// implements rpcserver.AuthorizeFunc.
+func (a *Authenticator) Authorize(r *http.Request, method string) error {
+	key, ok := bearerToken(r)
+	if !ok {
+		return fmt.Errorf("%w: malformed Authorization header", rpcserver.ErrUnauthorized)
+	}
+	g, ok := a.keys[sha256.Sum256([]byte(key))]
+	switch {
+	case !ok && key == "":
+		return fmt.Errorf("%w: API key required", rpcserver.ErrUnauthorized)
+	case !ok:
+		return fmt.Errorf("%w: unknown API key", rpcserver.ErrUnauthorized)
+	}
+
+	if !g.all && !g.methods[method] {
+		return fmt.Errorf("%w: key %s can't call %s", rpcserver.ErrForbidden, g.name, method)
+	}
+	if g.limiter != nil && !g.limiter.allow(time.Now()) {
+		return fmt.Errorf("%w for key %s", rpcserver.ErrRateLimited, g.name)
+	}
+	return nil
+}
+
+// bearerToken returns the bearer token of r, empty if it has none, and false
+// if its Authorization header is not a bearer token.
+func bearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	if header == "" {
+		return "", true
+	}
+	const prefix = "bearer "
+	if len(header) <= len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return "", false
+	}
+	return strings.TrimSpace(header[len(prefix):]), true
+}
+
+// limiter is a token bucket allowing rate calls per second on average, and
+// up to burst calls at once.
+type limiter struct {
+	mtx    sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newLimiter(rate float64, burst int) *limiter {
+	return &limiter{rate: rate, burst: float64(burst), tokens: float64(burst)}
+}
+
+// allow takes a token from the bucket, refilled up to now, and returns
+// whether there was one.
+func (l *limiter) allow(now time.Time) bool {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	if !l.last.IsZero() && now.After(l.last) {
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	if l.last.IsZero() || now.After(l.last) {
+		l.last = now
+	}
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	return true
+}
@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	rpcserver "github.com/tendermint/tendermint/rpc/jsonrpc/server"
+)
+
+func request(key string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	if key != "" {
+		r.Header.Set("Authorization", "Bearer "+key)
+	}
+	return r
+}
+
+func TestAuthenticator(t *testing.T) {
+	a, err := NewAuthenticator([]Key{
+		{Name: "public", Methods: []string{"health", "status"}},
+		{Name: "admin", Key: "secret", Methods: []string{AllMethods}},
+		{Name: "limited", Key: "limited", Methods: []string{"status"}, RateLimit: 1, Burst: 2},
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		key    string
+		method string
+		err    error
+	}{
+		{"", "health", nil},
+		{"", "broadcast_tx_sync", rpcserver.ErrForbidden},
+		{"secret", "broadcast_tx_sync", nil},
+		{"secret", "unsafe_flush_mempool", nil},
+		{"unknown", "health", rpcserver.ErrUnauthorized},
+		{"limited", "status", nil},
+		{"limited", "status", nil},
+		{"limited", "status", rpcserver.ErrRateLimited},
+		{"limited", "health", rpcserver.ErrForbidden},
+	}
+	for _, tc := range testCases {
+		err := a.Authorize(request(tc.key), tc.method)
+		if tc.err == nil {
+			assert.NoError(t, err, "key %q method %s", tc.key, tc.method)
+		} else {
+			assert.True(t, errors.Is(err, tc.err), "key %q method %s: %v", tc.key, tc.method, err)
+		}
+	}
+
+	r := request("")
+	r.Header.Set("Authorization", "Basic dXNlcjpwYXNz")
+	assert.True(t, errors.Is(a.Authorize(r, "health"), rpcserver.ErrUnauthorized))
+}
+
+func TestAuthenticatorNoAnonymousKey(t *testing.T) {
+	a, err := NewAuthenticator([]Key{{Name: "admin", Key: "secret", Methods: []string{AllMethods}}})
+	require.NoError(t, err)
+
+	assert.True(t, errors.Is(a.Authorize(request(""), "health"), rpcserver.ErrUnauthorized))
+	assert.NoError(t, a.Authorize(request("secret"), "health"))
+}
+
+func TestNewAuthenticatorInvalid(t *testing.T) {
+	testCases := map[string][]Key{
+		"no name":        {{Key: "a", Methods: []string{"health"}}},
+		"no methods":     {{Name: "a", Key: "a"}},
+		"negative rate":  {{Name: "a", Key: "a", Methods: []string{"health"}, RateLimit: -1}},
+		"negative burst": {{Name: "a", Key: "a", Methods: []string{"health"}, Burst: -1}},
+		"duplicate name": {{Name: "a", Key: "a", Methods: []string{"health"}}, {Name: "a", Key: "b", Methods: []string{"health"}}},
+		"duplicate key":  {{Name: "a", Key: "a", Methods: []string{"health"}}, {Name: "b", Key: "a", Methods: []string{"health"}}},
+		"two anonymous":  {{Name: "a", Methods: []string{"health"}}, {Name: "b", Methods: []string{"status"}}},
+	}
+	for name, keys := range testCases {
+		_, err := NewAuthenticator(keys)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	a, err := Load("", "")
+	require.NoError(t, err)
+	assert.Nil(t, a)
+
+	file := filepath.Join(t.TempDir(), "keys.json")
+	require.NoError(t, os.WriteFile(file,
+		[]byte(`[{"name": "admin", "key": "secret", "methods": ["*"]}]`), 0600))
+
+	a, err = Load(file, `[{"name": "public", "methods": ["health"], "rate_limit": 10}]`)
+	require.NoError(t, err)
+	require.NotNil(t, a)
+	assert.NoError(t, a.Authorize(request("secret"), "status"))
+	assert.NoError(t, a.Authorize(request(""), "health"))
+	assert.Error(t, a.Authorize(request(""), "status"))
+
+	_, err = Load(file, `{"name": "public"}`)
+	assert.Error(t, err)
+	_, err = Load(filepath.Join(t.TempDir(), "missing.json"), "")
+	assert.Error(t, err)
+}
+
+func TestLimiter(t *testing.T) {
+	now := time.Now()
+	l := newLimiter(2, 2)
+
+	assert.True(t, l.allow(now))
+	assert.True(t, l.allow(now))
+	assert.False(t, l.allow(now))
+
+	// refills at 2 calls per second, up to the burst
+	assert.True(t, l.allow(now.Add(500*time.Millisecond)))
+	assert.False(t, l.allow(now.Add(500*time.Millisecond)))
+	assert.True(t, l.allow(now.Add(time.Hour)))
+	assert.True(t, l.allow(now.Add(time.Hour)))
+	assert.False(t, l.allow(now.Add(time.Hour)))
+}
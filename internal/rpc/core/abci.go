@@ -1,33 +1,118 @@
 package core
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
 	"github.com/tendermint/tendermint/internal/proxy"
-	"github.com/tendermint/tendermint/libs/bytes"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 	"github.com/tendermint/tendermint/rpc/coretypes"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 )
 
-// ABCIQuery queries the application for some information.
+// ABCIQuery queries the application for some information. If verify is set,
+// the proof of the response is requested and verified against the app hash
+// of its height.
 // More: https://docs.tendermint.com/master/rpc/#/ABCI/abci_query
 func (env *Environment) ABCIQuery(
 	ctx *rpctypes.Context,
 	path string,
-	data bytes.HexBytes,
+	data tmbytes.HexBytes,
 	height int64,
 	prove bool,
+	verify bool,
 ) (*coretypes.ResultABCIQuery, error) {
 	resQuery, err := env.ProxyAppQuery.QuerySync(ctx.Context(), abci.RequestQuery{
 		Path:   path,
 		Data:   data,
 		Height: height,
-		Prove:  prove,
+		Prove:  prove || verify,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &coretypes.ResultABCIQuery{Response: *resQuery}, nil
+	result := &coretypes.ResultABCIQuery{Response: *resQuery}
+	if verify {
+		result.Verification = env.verifyQueryProof(*resQuery)
+	}
+	return result, nil
+}
+
+// verifyQueryProof verifies the proof of a query response against the app
+// hash of its height, i.e. the app hash of the block after it. Only the proof
+// operators of merkle.DefaultProofRuntime are supported.
+func (env *Environment) verifyQueryProof(res abci.ResponseQuery) *coretypes.ProofVerification {
+	appHash, err := env.queryAppHash(res)
+	if err != nil {
+		return &coretypes.ProofVerification{Error: err.Error()}
+	}
+	verification := &coretypes.ProofVerification{AppHash: appHash}
+	if err := verifyProofOps(res, appHash); err != nil {
+		verification.Error = err.Error()
+		return verification
+	}
+	verification.Verified = true
+	return verification
+}
+
+// queryAppHash returns the app hash the proof of a query response is verified
+// against.
+func (env *Environment) queryAppHash(res abci.ResponseQuery) (tmbytes.HexBytes, error) {
+	if res.IsErr() {
+		return nil, fmt.Errorf("query failed with code %d", res.Code)
+	}
+	if res.Height <= 0 {
+		return nil, coretypes.ErrZeroOrNegativeHeight
+	}
+
+	// The app hash of the latest height is only in the header of the next
+	// block, which isn't committed yet, but it's in the state already.
+	state, err := env.StateStore.Load()
+	if err != nil {
+		return nil, err
+	}
+	if state.LastBlockHeight == res.Height {
+		return state.AppHash, nil
+	}
+	blockMeta := env.BlockStore.LoadBlockMeta(res.Height + 1)
+	if blockMeta == nil {
+		return nil, fmt.Errorf("app hash of height %d is not available", res.Height)
+	}
+	return blockMeta.Header.AppHash, nil
+}
+
+// verifyProofOps verifies the proof operators of a query response, proving
+// its value, or its absence if it has none, at its key.
+func verifyProofOps(res abci.ResponseQuery, appHash []byte) error {
+	if res.ProofOps == nil || len(res.ProofOps.Ops) == 0 {
+		return errors.New("no proof ops")
+	}
+
+	// The operators go from the value up to the root, each with the key of
+	// the value in its own tree, and the key path from the root down.
+	var keys [][]byte
+	for _, op := range res.ProofOps.Ops {
+		if len(op.Key) > 0 {
+			keys = append(keys, op.Key)
+		}
+	}
+	if len(keys) == 0 || !bytes.Equal(keys[0], res.Key) {
+		return fmt.Errorf("proof is not for key %X", res.Key)
+	}
+	kp := merkle.KeyPath{}
+	for i := len(keys) - 1; i >= 0; i-- {
+		kp = kp.AppendKey(keys[i], merkle.KeyEncodingURL)
+	}
+
+	prt := merkle.DefaultProofRuntime()
+	if res.Value != nil {
+		return prt.VerifyValue(res.ProofOps, appHash, kp.String(), res.Value)
+	}
+	return prt.VerifyAbsence(res.ProofOps, appHash, kp.String())
 }
 
 // ABCIInfo gets some info about the application.
@@ -0,0 +1,88 @@
+package core
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	proxymocks "github.com/tendermint/tendermint/internal/proxy/mocks"
+	sm "github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/internal/state/mocks"
+	"github.com/tendermint/tendermint/proto/tendermint/crypto"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// valueProof returns the proof of a value in a simple merkle tree, along with
+// the root of the tree.
+func valueProof(key, value []byte) (*crypto.ProofOps, []byte) {
+	encode := func(bz []byte) []byte {
+		buf := make([]byte, binary.MaxVarintLen64)
+		n := binary.PutUvarint(buf, uint64(len(bz)))
+		return append(buf[:n], bz...)
+	}
+	leaf := append(encode(key), encode(tmhash.Sum(value))...)
+	root, proofs := merkle.ProofsFromByteSlices([][]byte{leaf, []byte("other")})
+	op := merkle.NewValueOp(key, proofs[0]).ProofOp()
+	return &crypto.ProofOps{Ops: []crypto.ProofOp{op}}, root
+}
+
+func TestABCIQueryVerify(t *testing.T) {
+	key, value := []byte("key"), []byte("value")
+	proof, root := valueProof(key, value)
+
+	stateStore := &mocks.Store{}
+	stateStore.On("Load").Return(sm.State{LastBlockHeight: 10, AppHash: root}, nil)
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("LoadBlockMeta", int64(9)).Return(&types.BlockMeta{
+		Header: types.Header{AppHash: root},
+	})
+	blockStore.On("LoadBlockMeta", mock.Anything).Return(nil)
+
+	testCases := []struct {
+		name     string
+		res      abci.ResponseQuery
+		verified bool
+	}{
+		{"latest height", abci.ResponseQuery{Key: key, Value: value, ProofOps: proof, Height: 10}, true},
+		{"past height", abci.ResponseQuery{Key: key, Value: value, ProofOps: proof, Height: 8}, true},
+		{"unavailable height", abci.ResponseQuery{Key: key, Value: value, ProofOps: proof, Height: 5}, false},
+		{"wrong value", abci.ResponseQuery{Key: key, Value: []byte("other"), ProofOps: proof, Height: 10}, false},
+		{"wrong key", abci.ResponseQuery{Key: []byte("other"), Value: value, ProofOps: proof, Height: 10}, false},
+		{"no proof", abci.ResponseQuery{Key: key, Value: value, Height: 10}, false},
+		{"error", abci.ResponseQuery{Code: 1, Height: 10}, false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			app := &proxymocks.AppConnQuery{}
+			app.On("QuerySync", mock.Anything, abci.RequestQuery{Path: "/key", Data: key, Prove: true}).
+				Return(&tc.res, nil)
+			env := &Environment{ProxyAppQuery: app, StateStore: stateStore, BlockStore: blockStore}
+
+			res, err := env.ABCIQuery(&rpctypes.Context{}, "/key", key, 0, false, true)
+			require.NoError(t, err)
+			require.NotNil(t, res.Verification)
+			assert.Equal(t, tc.verified, res.Verification.Verified)
+			if tc.verified {
+				assert.EqualValues(t, root, res.Verification.AppHash)
+				assert.Empty(t, res.Verification.Error)
+			} else {
+				assert.NotEmpty(t, res.Verification.Error)
+			}
+		})
+	}
+
+	app := &proxymocks.AppConnQuery{}
+	app.On("QuerySync", mock.Anything, abci.RequestQuery{Path: "/key", Data: key}).
+		Return(&abci.ResponseQuery{Key: key, Value: value, Height: 10}, nil)
+	env := &Environment{ProxyAppQuery: app}
+	res, err := env.ABCIQuery(&rpctypes.Context{}, "/key", key, 0, false, false)
+	require.NoError(t, err)
+	assert.Nil(t, res.Verification)
+}
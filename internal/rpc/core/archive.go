@@ -0,0 +1,93 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tendermint/tendermint/internal/mempool"
+	"github.com/tendermint/tendermint/libs/bytes"
+	tmmath "github.com/tendermint/tendermint/libs/math"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+// ArchivedTx returns the transaction of the given hash from the archive of the
+// transactions checked by the mempool of the node, with its last status.
+// More: https://docs.tendermint.com/master/rpc/#/Tx/archived_tx
+func (env *Environment) ArchivedTx(ctx *rpctypes.Context, hash bytes.HexBytes) (*coretypes.ResultArchivedTx, error) {
+	if env.TxArchive == nil {
+		return nil, errors.New("tx archive is disabled")
+	}
+
+	atx, err := env.TxArchive.GetTx(hash)
+	if err != nil {
+		return nil, err
+	}
+	if atx == nil {
+		return nil, fmt.Errorf("tx %X is not archived", []byte(hash))
+	}
+	return toResultArchivedTx(atx), nil
+}
+
+// ArchivedTxs returns a paginated set of the archived transactions of a
+// sender, newest first, of the given status only if not empty.
+// More: https://docs.tendermint.com/master/rpc/#/Tx/archived_txs
+func (env *Environment) ArchivedTxs(
+	ctx *rpctypes.Context,
+	sender string,
+	status string,
+	pagePtr, perPagePtr *int,
+) (*coretypes.ResultArchivedTxs, error) {
+	if env.TxArchive == nil {
+		return nil, errors.New("tx archive is disabled")
+	}
+	if sender == "" {
+		return nil, fmt.Errorf("sender cannot be empty: %w", coretypes.ErrInvalidRequest)
+	}
+	switch mempool.ArchivedTxStatus(status) {
+	case "", mempool.ArchivedTxPending, mempool.ArchivedTxRejected, mempool.ArchivedTxEvicted,
+		mempool.ArchivedTxReplaced, mempool.ArchivedTxExpired, mempool.ArchivedTxRemoved,
+		mempool.ArchivedTxCommitted:
+	default:
+		return nil, fmt.Errorf("unknown tx status %q: %w", status, coretypes.ErrInvalidRequest)
+	}
+
+	atxs, err := env.TxArchive.TxsBySender(sender, mempool.ArchivedTxStatus(status))
+	if err != nil {
+		return nil, err
+	}
+
+	// paginate results
+	totalCount := len(atxs)
+	perPage := env.validatePerPage(perPagePtr)
+
+	page, err := validatePage(pagePtr, perPage, totalCount)
+	if err != nil {
+		return nil, err
+	}
+
+	skipCount := validateSkipCount(page, perPage)
+	pageSize := tmmath.MinInt(perPage, totalCount-skipCount)
+
+	results := make([]*coretypes.ResultArchivedTx, 0, pageSize)
+	for _, atx := range atxs[skipCount : skipCount+pageSize] {
+		results = append(results, toResultArchivedTx(atx))
+	}
+	return &coretypes.ResultArchivedTxs{Txs: results, TotalCount: totalCount}, nil
+}
+
+func toResultArchivedTx(atx *mempool.ArchivedTx) *coretypes.ResultArchivedTx {
+	return &coretypes.ResultArchivedTx{
+		Hash:         atx.Hash,
+		Tx:           atx.Tx,
+		Sender:       atx.Sender,
+		Local:        atx.Local,
+		Time:         atx.Time,
+		Height:       atx.Height,
+		Status:       string(atx.Status),
+		StatusTime:   atx.StatusTime,
+		Code:         atx.Code,
+		Error:        atx.Error,
+		CommitHeight: atx.CommitHeight,
+	}
+}
@@ -0,0 +1,64 @@
+package core
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/internal/mempool"
+	"github.com/tendermint/tendermint/libs/log"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestArchivedTxs(t *testing.T) {
+	env := &Environment{}
+	_, err := env.ArchivedTxs(&rpctypes.Context{}, "alice", "", nil, nil)
+	require.Error(t, err, "the tx archive is disabled")
+
+	env.TxArchive = mempool.NewTxArchive(log.TestingLogger(), dbm.NewMemDB(), 0)
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		tx := types.Tx(fmt.Sprintf("alice=%d", i))
+		status := mempool.ArchivedTxPending
+		if i%2 == 0 {
+			status = mempool.ArchivedTxCommitted
+		}
+		env.TxArchive.ArchiveTx(mempool.ArchivedTx{
+			Hash:   tx.Hash(),
+			Tx:     tx,
+			Sender: "alice",
+			Time:   start.Add(time.Duration(i) * time.Second),
+			Status: status,
+		})
+	}
+
+	perPage := 2
+	page := 2
+	res, err := env.ArchivedTxs(&rpctypes.Context{}, "alice", "", &page, &perPage)
+	require.NoError(t, err)
+	require.Equal(t, 5, res.TotalCount)
+	require.Len(t, res.Txs, 2)
+	require.Equal(t, types.Tx("alice=2"), res.Txs[0].Tx)
+	require.Equal(t, "committed", res.Txs[0].Status)
+
+	res, err = env.ArchivedTxs(&rpctypes.Context{}, "alice", "pending", nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, res.TotalCount)
+	require.Equal(t, types.Tx("alice=3"), res.Txs[0].Tx)
+
+	_, err = env.ArchivedTxs(&rpctypes.Context{}, "alice", "unknown", nil, nil)
+	require.Error(t, err)
+	_, err = env.ArchivedTxs(&rpctypes.Context{}, "", "", nil, nil)
+	require.Error(t, err)
+
+	tx := types.Tx("alice=4")
+	atx, err := env.ArchivedTx(&rpctypes.Context{}, tx.Hash())
+	require.NoError(t, err)
+	require.Equal(t, tx, atx.Tx)
+	_, err = env.ArchivedTx(&rpctypes.Context{}, types.Tx("bob=0").Hash())
+	require.Error(t, err)
+}
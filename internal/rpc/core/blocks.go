@@ -5,6 +5,7 @@ import (
 	"sort"
 
 	tmquery "github.com/tendermint/tendermint/internal/pubsub/query"
+	sm "github.com/tendermint/tendermint/internal/state"
 	"github.com/tendermint/tendermint/internal/state/indexer"
 	"github.com/tendermint/tendermint/libs/bytes"
 	tmmath "github.com/tendermint/tendermint/libs/math"
@@ -195,8 +196,17 @@ func (env *Environment) Commit(ctx *rpctypes.Context, heightPtr *int64) (*corety
 // Results are for the height of the block containing the txs.
 // Thus response.results.deliver_tx[5] is the results of executing
 // getBlock(h).Txs[5]
+//
+// If proveEvents is true, the events of the block are also returned along
+// with Merkle proofs against the events hash, which is committed to by the
+// LastEventsHash of the next header. This requires the ABCI.HashEvents
+// consensus parameter to have been enabled at the given height.
 // More: https://docs.tendermint.com/master/rpc/#/Info/block_results
-func (env *Environment) BlockResults(ctx *rpctypes.Context, heightPtr *int64) (*coretypes.ResultBlockResults, error) {
+func (env *Environment) BlockResults(
+	ctx *rpctypes.Context,
+	heightPtr *int64,
+	proveEvents bool,
+) (*coretypes.ResultBlockResults, error) {
 	height, err := env.getHeight(env.BlockStore.Height(), heightPtr)
 	if err != nil {
 		return nil, err
@@ -212,7 +222,7 @@ func (env *Environment) BlockResults(ctx *rpctypes.Context, heightPtr *int64) (*
 		totalGasUsed += tx.GetGasUsed()
 	}
 
-	return &coretypes.ResultBlockResults{
+	res := &coretypes.ResultBlockResults{
 		Height:                height,
 		TxsResults:            results.DeliverTxs,
 		TotalGasUsed:          totalGasUsed,
@@ -220,20 +230,47 @@ func (env *Environment) BlockResults(ctx *rpctypes.Context, heightPtr *int64) (*
 		EndBlockEvents:        results.EndBlock.Events,
 		ValidatorUpdates:      results.EndBlock.ValidatorUpdates,
 		ConsensusParamUpdates: results.EndBlock.ConsensusParamUpdates,
-	}, nil
+	}
+
+	if proveEvents {
+		params, err := env.StateStore.LoadConsensusParams(height)
+		if err != nil {
+			return nil, err
+		}
+		if !params.ABCI.HashEvents {
+			return nil, fmt.Errorf("events of block %d are not committed to: "+
+				"the abci.hash_events consensus parameter was disabled", height)
+		}
+		events := sm.ABCIResponsesEvents(results)
+		res.EventsHash = events.Hash()
+		res.EventProofs = events.Prove()
+	}
+
+	return res, nil
 }
 
 // BlockSearch searches for a paginated set of blocks matching BeginBlock and
-// EndBlock event search criteria.
+// EndBlock event search criteria. If matchEvents is true, the event conditions
+// of each query clause must be satisfied by a single event. Only blocks
+// available in the block store are counted and returned, so that every page of
+// results is complete.
 func (env *Environment) BlockSearch(
 	ctx *rpctypes.Context,
 	query string,
 	pagePtr, perPagePtr *int,
 	orderBy string,
+	matchEvents bool,
 ) (*coretypes.ResultBlockSearch, error) {
 
-	if !indexer.KVSinkEnabled(env.EventSinks) {
-		return nil, fmt.Errorf("block searching is disabled due to no kvEventSink")
+	var searchSink indexer.EventSink
+	for _, sink := range env.EventSinks {
+		if sink.Type() == indexer.KV || sink.Type() == indexer.PSQL || sink.Type() == indexer.SQLITE {
+			searchSink = sink
+			break
+		}
+	}
+	if searchSink == nil {
+		return nil, fmt.Errorf("block searching is disabled due to no kv, psql or sqlite event sink")
 	}
 
 	q, err := tmquery.New(query)
@@ -241,18 +278,21 @@ func (env *Environment) BlockSearch(
 		return nil, err
 	}
 
-	var kvsink indexer.EventSink
-	for _, sink := range env.EventSinks {
-		if sink.Type() == indexer.KV {
-			kvsink = sink
-		}
-	}
-
-	results, err := kvsink.SearchBlockEvents(ctx.Context(), q)
+	heights, err := searchSink.SearchBlockEvents(ctx.Context(), q, matchEvents)
 	if err != nil {
 		return nil, err
 	}
 
+	// Drop heights the block store cannot serve, such as pruned blocks or
+	// blocks indexed ahead of the store, so that pages are stable.
+	base, latest := env.BlockStore.Base(), env.BlockStore.Height()
+	results := make([]int64, 0, len(heights))
+	for _, h := range heights {
+		if h >= base && h <= latest {
+			results = append(results, h)
+		}
+	}
+
 	// sort results (must be done before pagination)
 	switch orderBy {
 	case "desc", "":
@@ -15,6 +15,7 @@ import (
 	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
 	"github.com/tendermint/tendermint/rpc/coretypes"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
 )
 
 func TestBlockchainInfo(t *testing.T) {
@@ -109,7 +110,7 @@ func TestBlockResults(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		res, err := env.BlockResults(&rpctypes.Context{}, &tc.height)
+		res, err := env.BlockResults(&rpctypes.Context{}, &tc.height, false)
 		if tc.wantErr {
 			assert.Error(t, err)
 		} else {
@@ -118,3 +119,44 @@ func TestBlockResults(t *testing.T) {
 		}
 	}
 }
+
+func TestBlockResultsProveEvents(t *testing.T) {
+	results := &tmstate.ABCIResponses{
+		DeliverTxs: []*abci.ResponseDeliverTx{
+			{Code: 0, Events: []abci.Event{{Type: "tx", Attributes: []abci.EventAttribute{{Key: "a", Value: "1"}}}}},
+		},
+		EndBlock:   &abci.ResponseEndBlock{Events: []abci.Event{{Type: "end"}}},
+		BeginBlock: &abci.ResponseBeginBlock{Events: []abci.Event{{Type: "begin"}}},
+	}
+
+	params := types.DefaultConsensusParams()
+	stateStore := &mocks.Store{}
+	stateStore.On("LoadABCIResponses", int64(100)).Return(results, nil)
+	stateStore.On("LoadABCIResponses", int64(99)).Return(results, nil)
+	stateStore.On("LoadConsensusParams", int64(99)).Return(*params, nil)
+	hashParams := *params
+	hashParams.ABCI.HashEvents = true
+	stateStore.On("LoadConsensusParams", int64(100)).Return(hashParams, nil)
+
+	mockstore := &mocks.BlockStore{}
+	mockstore.On("Height").Return(int64(100))
+	mockstore.On("Base").Return(int64(1))
+	env := &Environment{StateStore: stateStore, BlockStore: mockstore}
+
+	// The events of blocks executed without the parameter are not committed to.
+	height := int64(99)
+	_, err := env.BlockResults(&rpctypes.Context{}, &height, true)
+	assert.Error(t, err)
+
+	height = 100
+	res, err := env.BlockResults(&rpctypes.Context{}, &height, true)
+	require.NoError(t, err)
+	assert.Equal(t, sm.ABCIResponsesEventsHash(results), res.EventsHash.Bytes())
+	require.Len(t, res.EventProofs, 3)
+	assert.Equal(t, types.EventSourceBeginBlock, res.EventProofs[0].Source)
+	assert.EqualValues(t, 0, res.EventProofs[1].Source)
+	assert.Equal(t, types.EventSourceEndBlock, res.EventProofs[2].Source)
+	for _, proof := range res.EventProofs {
+		assert.NoError(t, proof.Verify(res.EventsHash))
+	}
+}
@@ -1,9 +1,16 @@
 package core
 
 import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tendermint/tendermint/internal/consensus"
 	tmmath "github.com/tendermint/tendermint/libs/math"
 	"github.com/tendermint/tendermint/rpc/coretypes"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
 )
 
 // Validators gets the validator set at the given block height.
@@ -12,39 +19,199 @@ import (
 // validators are sorted by their voting power - this is the canonical order
 // for the validators in the set as used in computing their Merkle root.
 //
+// If a window is provided, it also fetches the validator sets at the
+// following heights, up to window heights in total, returning at most
+// per_page validators in total. If there are more validators, the next ones
+// are returned when requesting the returned next cursor, in place of the
+// height, page and window.
+//
 // More: https://docs.tendermint.com/master/rpc/#/Info/validators
 func (env *Environment) Validators(
 	ctx *rpctypes.Context,
 	heightPtr *int64,
-	pagePtr, perPagePtr *int) (*coretypes.ResultValidators, error) {
+	pagePtr, perPagePtr *int,
+	cursor string,
+	windowPtr *int) (*coretypes.ResultValidators, error) {
 
 	// The latest validator that we know is the NextValidator of the last block.
-	height, err := env.getHeight(env.latestUncommittedHeight(), heightPtr)
+	latestHeight := env.latestUncommittedHeight()
+
+	var (
+		from validatorsCursor
+		err  error
+	)
+	if cursor != "" {
+		from, err = parseValidatorsCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := env.getHeight(latestHeight, &from.height); err != nil {
+			return nil, err
+		}
+		if from.lastHeight < from.height || from.lastHeight > latestHeight ||
+			from.lastHeight-from.height >= maxValidatorsWindow {
+			return nil, fmt.Errorf("%w: invalid cursor %q", coretypes.ErrInvalidRequest, cursor)
+		}
+	} else {
+		height, err := env.getHeight(latestHeight, heightPtr)
+		if err != nil {
+			return nil, err
+		}
+		window := 1
+		if windowPtr != nil {
+			window = *windowPtr
+			if window < 1 || window > maxValidatorsWindow {
+				return nil, fmt.Errorf("%w: window must be in range [1, %d], given %d",
+					coretypes.ErrInvalidRequest, maxValidatorsWindow, window)
+			}
+		}
+		from = validatorsCursor{
+			height:     height,
+			lastHeight: tmmath.MinInt64(height+int64(window)-1, latestHeight),
+		}
+	}
+
+	var (
+		result    *coretypes.ResultValidators
+		next      *validatorsCursor
+		remaining = env.validatePerPage(perPagePtr)
+		fnErr     error
+	)
+	err = env.StateStore.IterateValidators(from.height, from.lastHeight,
+		func(height int64, validators *types.ValidatorSet) bool {
+			totalCount := len(validators.Validators)
+
+			skipCount := 0
+			if height == from.height {
+				if cursor == "" {
+					page, err := validatePage(pagePtr, remaining, totalCount)
+					if err != nil {
+						fnErr = err
+						return false
+					}
+					skipCount = validateSkipCount(page, remaining)
+				} else if skipCount = int(from.offset); skipCount > totalCount {
+					fnErr = fmt.Errorf("%w: invalid cursor %q", coretypes.ErrInvalidRequest, cursor)
+					return false
+				}
+			}
+
+			count := tmmath.MinInt(remaining, totalCount-skipCount)
+			res := &coretypes.ResultValidators{
+				BlockHeight: height,
+				Validators:  validators.Validators[skipCount : skipCount+count],
+				Count:       count,
+				Total:       totalCount,
+			}
+			if result == nil {
+				result = res
+			} else {
+				result.Window = append(result.Window, res)
+			}
+
+			remaining -= count
+			switch {
+			case skipCount+count < totalCount:
+				next = &validatorsCursor{height: height, offset: int64(skipCount + count), lastHeight: from.lastHeight}
+				return false
+			case remaining == 0 && height < from.lastHeight:
+				next = &validatorsCursor{height: height + 1, lastHeight: from.lastHeight}
+				return false
+			}
+			return true
+		})
 	if err != nil {
 		return nil, err
 	}
+	if fnErr != nil {
+		return nil, fnErr
+	}
+
+	if next != nil {
+		result.NextCursor = next.String()
+	}
+	return result, nil
+}
 
-	validators, err := env.StateStore.LoadValidators(height)
+// ValidatorUpdates gets the changes of the validator set made by the
+// validator updates of the blocks minHeight <= height <= maxHeight, skipping
+// the blocks not changing it.
+//
+// If maxHeight does not yet exist, changes up to the current height will be
+// returned. If minHeight does not exist (due to pruning), earliest existing
+// height will be used. At most 100 heights are looked up. Changes are returned
+// in ascending order of height. The validator set changed at a height takes
+// effect at height+2.
+//
+// More: https://docs.tendermint.com/master/rpc/#/Info/validator_updates
+func (env *Environment) ValidatorUpdates(
+	ctx *rpctypes.Context,
+	minHeight, maxHeight int64) (*coretypes.ResultValidatorUpdates, error) {
+
+	lastHeight := env.BlockStore.Height()
+	minHeight, maxHeight, err := filterMinMax(
+		env.BlockStore.Base(),
+		lastHeight,
+		minHeight,
+		maxHeight,
+		maxValidatorsWindow)
 	if err != nil {
 		return nil, err
 	}
 
-	totalCount := len(validators.Validators)
-	perPage := env.validatePerPage(perPagePtr)
-	page, err := validatePage(pagePtr, perPage, totalCount)
+	// the validator updates returned at a height change the validator set
+	// from the one at height+1 to the one at height+2
+	var (
+		changes    = []types.ValidatorSetChange{}
+		validators *types.ValidatorSet
+	)
+	err = env.StateStore.IterateValidators(minHeight+1, maxHeight+2,
+		func(height int64, vals *types.ValidatorSet) bool {
+			if validators != nil {
+				change := types.NewValidatorSetChange(height-2, validators, vals)
+				if !change.IsEmpty() {
+					changes = append(changes, change)
+				}
+			}
+			validators = vals
+			return true
+		})
 	if err != nil {
 		return nil, err
 	}
 
-	skipCount := validateSkipCount(page, perPage)
+	return &coretypes.ResultValidatorUpdates{
+		LastHeight:          lastHeight,
+		ValidatorSetChanges: changes,
+	}, nil
+}
+
+// validatorsCursor is the position of the next validator to return when
+// listing the validators at a window of heights.
+type validatorsCursor struct {
+	height     int64
+	offset     int64 // index of the validator in the validator set at height
+	lastHeight int64 // last height of the window
+}
 
-	v := validators.Validators[skipCount : skipCount+tmmath.MinInt(perPage, totalCount-skipCount)]
+func (c validatorsCursor) String() string {
+	return fmt.Sprintf("%d-%d-%d", c.height, c.offset, c.lastHeight)
+}
 
-	return &coretypes.ResultValidators{
-		BlockHeight: height,
-		Validators:  v,
-		Count:       len(v),
-		Total:       totalCount}, nil
+func parseValidatorsCursor(s string) (validatorsCursor, error) {
+	var c validatorsCursor
+	parts := strings.Split(s, "-")
+	if len(parts) != 3 {
+		return c, fmt.Errorf("%w: invalid cursor %q", coretypes.ErrInvalidRequest, s)
+	}
+	for i, field := range []*int64{&c.height, &c.offset, &c.lastHeight} {
+		v, err := strconv.ParseInt(parts[i], 10, 64)
+		if err != nil || v < 0 {
+			return c, fmt.Errorf("%w: invalid cursor %q", coretypes.ErrInvalidRequest, s)
+		}
+		*field = v
+	}
+	return c, nil
 }
 
 // DumpConsensusState dumps consensus state.
@@ -83,9 +250,15 @@ func (env *Environment) DumpConsensusState(ctx *rpctypes.Context) (*coretypes.Re
 	if err != nil {
 		return nil, err
 	}
+	stateMachine, err := env.ConsensusState.GetDebugStateJSON()
+	if err != nil {
+		return nil, err
+	}
 	return &coretypes.ResultDumpConsensusState{
-		RoundState: roundState,
-		Peers:      peerStates}, nil
+		RoundState:   roundState,
+		Peers:        peerStates,
+		StateMachine: stateMachine,
+	}, nil
 }
 
 // ConsensusState returns a concise summary of the consensus state.
@@ -97,6 +270,43 @@ func (env *Environment) GetConsensusState(ctx *rpctypes.Context) (*coretypes.Res
 	return &coretypes.ResultConsensusState{RoundState: bz}, err
 }
 
+// ConsensusTimeStats returns the timing of the latest heights committed by the
+// node: the average time between them, the number of rounds they took and how
+// often the timeouts of the propose, prevote wait and precommit wait steps
+// fired.
+// UNSTABLE
+// More: https://docs.tendermint.com/master/rpc/#/Info/consensus_time_stats
+func (env *Environment) ConsensusTimeStats(ctx *rpctypes.Context) (*coretypes.ResultConsensusTimeStats, error) {
+	stats := env.ConsensusReactor.TimeStats()
+
+	result := &coretypes.ResultConsensusTimeStats{
+		FirstHeight:  stats.FirstHeight,
+		LastHeight:   stats.LastHeight,
+		AvgBlockTime: stats.AvgBlockTime,
+		Rounds:       []coretypes.HeightCount{},
+		Timeouts:     make([]coretypes.TimeoutUsage, 0, len(consensus.TimeoutSteps)),
+	}
+	for rounds, heights := range stats.Rounds {
+		result.Rounds = append(result.Rounds, coretypes.HeightCount{Count: int(rounds), Heights: heights})
+	}
+	sortHeightCounts(result.Rounds)
+
+	for _, step := range consensus.TimeoutSteps {
+		usage := coretypes.TimeoutUsage{Step: step.String(), Histogram: []coretypes.HeightCount{}}
+		for fired, heights := range stats.Timeouts[step] {
+			usage.Fired += fired * heights
+			usage.Histogram = append(usage.Histogram, coretypes.HeightCount{Count: fired, Heights: heights})
+		}
+		sortHeightCounts(usage.Histogram)
+		result.Timeouts = append(result.Timeouts, usage)
+	}
+	return result, nil
+}
+
+func sortHeightCounts(counts []coretypes.HeightCount) {
+	sort.Slice(counts, func(i, j int) bool { return counts[i].Count < counts[j].Count })
+}
+
 // ConsensusParams gets the consensus parameters at the given block height.
 // If no height is provided, it will fetch the latest consensus params.
 // More: https://docs.tendermint.com/master/rpc/#/Info/consensus_params
@@ -0,0 +1,93 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/internal/consensus"
+	sm "github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/internal/state/mocks"
+	"github.com/tendermint/tendermint/internal/test/factory"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+type syncedConsensusReactor struct{}
+
+func (syncedConsensusReactor) WaitSync() bool { return false }
+
+func (syncedConsensusReactor) GetPeerState(types.NodeID) (*consensus.PeerState, bool) {
+	return nil, false
+}
+
+func (syncedConsensusReactor) TimeStats() consensus.TimeStats { return consensus.TimeStats{} }
+
+func TestValidatorsWindow(t *testing.T) {
+	env := &Environment{ConsensusReactor: syncedConsensusReactor{}}
+	env.StateStore = sm.NewStore(dbm.NewMemDB())
+	valSet, _ := factory.RandValidatorSet(3, 10)
+	require.NoError(t, env.StateStore.SaveValidatorSets(1, 5, valSet))
+	mockstore := &mocks.BlockStore{}
+	mockstore.On("Height").Return(int64(4))
+	mockstore.On("Base").Return(int64(1))
+	env.BlockStore = mockstore
+
+	ctx := &rpctypes.Context{}
+	height, perPage := int64(2), 2
+
+	// a single height is paginated with a cursor
+	res, err := env.Validators(ctx, &height, nil, &perPage, "", nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, res.BlockHeight)
+	assert.Equal(t, 2, res.Count)
+	assert.Equal(t, 3, res.Total)
+	assert.Empty(t, res.Window)
+	require.NotEmpty(t, res.NextCursor)
+
+	res, err = env.Validators(ctx, nil, nil, &perPage, res.NextCursor, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, res.BlockHeight)
+	assert.Equal(t, 1, res.Count)
+	assert.Empty(t, res.NextCursor)
+
+	// a window of heights is paginated across heights, and clipped to the
+	// latest height
+	window, perPage := 10, 4
+	var (
+		cursor  string
+		heights = map[int64]int{}
+	)
+	for i := 0; ; i++ {
+		require.Less(t, i, 10, "too many pages")
+		if cursor == "" && i > 0 {
+			break
+		}
+		res, err = env.Validators(ctx, &height, nil, &perPage, cursor, &window)
+		require.NoError(t, err)
+		count := 0
+		for _, r := range append([]*coretypes.ResultValidators{res}, res.Window...) {
+			assert.Equal(t, 3, r.Total)
+			assert.Len(t, r.Validators, r.Count)
+			heights[r.BlockHeight] += r.Count
+			count += r.Count
+		}
+		assert.LessOrEqual(t, count, perPage)
+		cursor = res.NextCursor
+	}
+	assert.Equal(t, map[int64]int{2: 3, 3: 3, 4: 3, 5: 3}, heights)
+
+	// invalid requests
+	for _, window := range []int{0, -1, maxValidatorsWindow + 1} {
+		window := window
+		_, err = env.Validators(ctx, &height, nil, &perPage, "", &window)
+		assert.Error(t, err, "window %d", window)
+	}
+	for _, cursor := range []string{"x", "1-2", "2-0-1", "2-4-2", "2-0-6", "0-0-1"} {
+		_, err = env.Validators(ctx, nil, nil, &perPage, cursor, nil)
+		assert.Error(t, err, "cursor %q", cursor)
+	}
+}
@@ -1,6 +1,10 @@
 package core
 
 import (
+	"errors"
+
+	"github.com/tendermint/tendermint/internal/flightrec"
+	"github.com/tendermint/tendermint/internal/libs/profiling"
 	"github.com/tendermint/tendermint/rpc/coretypes"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 )
@@ -10,3 +14,56 @@ func (env *Environment) UnsafeFlushMempool(ctx *rpctypes.Context) (*coretypes.Re
 	env.Mempool.Flush()
 	return &coretypes.ResultUnsafeFlushMempool{}, nil
 }
+
+// UnsafeProfiles returns the state of the runtime profiles served by the
+// pprof server.
+func (env *Environment) UnsafeProfiles(ctx *rpctypes.Context) (*coretypes.ResultProfiles, error) {
+	if env.Profiler == nil {
+		return nil, errors.New("the pprof server is disabled")
+	}
+	return makeResultProfiles(env.Profiler.Status()), nil
+}
+
+// UnsafeSetProfile enables or disables a runtime profile served by the pprof
+// server: heap, mutex, block or trace. A positive rate sets its sampling rate,
+// while 0 keeps the current one.
+func (env *Environment) UnsafeSetProfile(
+	ctx *rpctypes.Context,
+	profile string,
+	enabled bool,
+	rate int,
+) (*coretypes.ResultProfiles, error) {
+	if env.Profiler == nil {
+		return nil, errors.New("the pprof server is disabled")
+	}
+	if _, err := env.Profiler.Set(profile, enabled, rate); err != nil {
+		return nil, err
+	}
+	env.Logger.Info("set profile", "profile", profile, "enabled", enabled, "rate", rate)
+	return makeResultProfiles(env.Profiler.Status()), nil
+}
+
+// UnsafeDumpFlightRecorder records the node, and dumps the ring of the flight
+// recorder to a zip file on the node.
+func (env *Environment) UnsafeDumpFlightRecorder(ctx *rpctypes.Context) (*coretypes.ResultDumpFlightRecorder, error) {
+	if env.FlightRecorder == nil {
+		return nil, errors.New("the flight recorder is disabled")
+	}
+	path, err := env.FlightRecorder.Dump(ctx.Context(), flightrec.DumpOnDemand)
+	if err != nil {
+		return nil, err
+	}
+	return &coretypes.ResultDumpFlightRecorder{Path: path}, nil
+}
+
+func makeResultProfiles(statuses []profiling.ProfileStatus) *coretypes.ResultProfiles {
+	profiles := make([]coretypes.ProfileStatus, len(statuses))
+	for i, status := range statuses {
+		profiles[i] = coretypes.ProfileStatus{
+			Name:    status.Name,
+			Enabled: status.Enabled,
+			Rate:    status.Rate,
+		}
+	}
+	return &coretypes.ResultProfiles{Profiles: profiles}
+}
@@ -1,14 +1,19 @@
 package core
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/internal/consensus"
 	"github.com/tendermint/tendermint/internal/eventbus"
+	"github.com/tendermint/tendermint/internal/flightrec"
+	"github.com/tendermint/tendermint/internal/evidence"
+	"github.com/tendermint/tendermint/internal/libs/profiling"
 	"github.com/tendermint/tendermint/internal/mempool"
 	"github.com/tendermint/tendermint/internal/p2p"
 	"github.com/tendermint/tendermint/internal/proxy"
@@ -26,6 +31,10 @@ const (
 	defaultPerPage = 30
 	maxPerPage     = 100
 
+	// maxValidatorsWindow is the maximum number of heights of a window of
+	// validator sets
+	maxValidatorsWindow = 100
+
 	// SubscribeTimeout is the maximum time we wait to subscribe for an event.
 	// must be less than the server's write timeout (see rpcserver.DefaultConfig)
 	SubscribeTimeout = 5 * time.Second
@@ -44,22 +53,44 @@ type consensusState interface {
 	GetLastHeight() int64
 	GetRoundStateJSON() ([]byte, error)
 	GetRoundStateSimpleJSON() ([]byte, error)
+	GetDebugStateJSON() ([]byte, error)
+	Ping(context.Context) error
 }
 
 type transport interface {
 	Listeners() []string
 	IsListening() bool
 	NodeInfo() types.NodeInfo
+	PeerRTT(types.NodeID) (time.Duration, bool)
 }
 
 type consensusReactor interface {
 	WaitSync() bool
 	GetPeerState(peerID types.NodeID) (*consensus.PeerState, bool)
+	TimeStats() consensus.TimeStats
+}
+
+type evidencePool interface {
+	sm.EvidencePool
+	ListEvidence(evidence.Filter) ([]evidence.ListedEvidence, error)
 }
 
 type peerManager interface {
 	Peers() []types.NodeID
 	Addresses(types.NodeID) []p2p.NodeAddress
+	ExportAddressBook() []p2p.AddressBookEntry
+	ImportAddressBook([]p2p.AddressBookEntry) (int, error)
+	MaxPeerHeight() int64
+	NodeInfo(types.NodeID) (types.NodeInfo, bool)
+	PeerVersions() p2p.PeerVersions
+}
+
+type pexReactor interface {
+	ReportedPeers(types.NodeID) ([]types.NodeID, bool)
+}
+
+type indexerService interface {
+	IndexedHeight() int64
 }
 
 //----------------------------------------------
@@ -73,7 +104,7 @@ type Environment struct {
 	// interfaces defined in types and above
 	StateStore       sm.Store
 	BlockStore       sm.BlockStore
-	EvidencePool     sm.EvidencePool
+	EvidencePool     evidencePool
 	ConsensusState   consensusState
 	ConsensusReactor consensusReactor
 
@@ -82,15 +113,22 @@ type Environment struct {
 
 	// interfaces for new p2p interfaces
 	PeerManager peerManager
+	PexReactor  pexReactor // nil if the PEX reactor is disabled
 
 	// objects
 	PubKey            crypto.PubKey
 	GenDoc            *types.GenesisDoc // cache the genesis structure
 	EventSinks        []indexer.EventSink
+	IndexerService    indexerService     // nil if the events aren't indexed
 	EventBus          *eventbus.EventBus // thread safe
 	Mempool           mempool.Mempool
 	BlockSyncReactor  consensus.BlockSyncReactor
 	StateSyncMetricer statesync.Metricer
+	Halt              *sm.Halt            // nil if no halt is configured
+	Forensics         *sm.Forensics       // nil if forensic dumps are disabled
+	TxArchive         *mempool.TxArchive  // nil if the tx archive is disabled
+	Profiler          *profiling.Profiler // nil if the pprof server is disabled
+	FlightRecorder    *flightrec.Recorder // nil if the flight recorder is disabled
 
 	Logger log.Logger
 
@@ -98,6 +136,11 @@ type Environment struct {
 
 	// cache of chunked genesis data.
 	genChunks []string
+	// the genesis file and its number of chunks, if the genesis doc was
+	// streamed from it, in which case chunks are read from the file on demand
+	// rather than cached.
+	genFile       string
+	genFileChunks int
 }
 
 //----------------------------------------------
@@ -143,7 +186,7 @@ func (env *Environment) validatePerPage(perPagePtr *int) int {
 // InitGenesisChunks configures the environment and should be called on service
 // startup.
 func (env *Environment) InitGenesisChunks() error {
-	if env.genChunks != nil {
+	if env.genChunks != nil || env.genFile != "" {
 		return nil
 	}
 
@@ -151,6 +194,16 @@ func (env *Environment) InitGenesisChunks() error {
 		return nil
 	}
 
+	if file := env.GenDoc.SourceFile(); file != "" {
+		info, err := os.Stat(file)
+		if err != nil {
+			return err
+		}
+		env.genFile = file
+		env.genFileChunks = int((info.Size() + genesisChunkSize - 1) / genesisChunkSize)
+		return nil
+	}
+
 	data, err := tmjson.Marshal(env.GenDoc)
 	if err != nil {
 		return err
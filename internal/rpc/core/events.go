@@ -4,12 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/tendermint/tendermint/internal/eventbus"
 	tmpubsub "github.com/tendermint/tendermint/internal/pubsub"
 	tmquery "github.com/tendermint/tendermint/internal/pubsub/query"
+	"github.com/tendermint/tendermint/internal/state/indexer"
 	"github.com/tendermint/tendermint/rpc/coretypes"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
 )
 
 const (
@@ -19,11 +24,22 @@ const (
 	// maxQueryLength is the maximum length of a query string that will be
 	// accepted. This is just a safety check to avoid outlandish queries.
 	maxQueryLength = 512
+
+	// maxHistoryHeights is the maximum number of past heights whose
+	// transactions a subscription may replay.
+	maxHistoryHeights = 10000
+
+	// historyIndexTimeout is how long a subscription replaying past heights
+	// waits for the last block stored to be indexed.
+	historyIndexTimeout = 10 * time.Second
 )
 
-// Subscribe for events via WebSocket.
+// Subscribe for events via WebSocket. If fromHeight is set, the transaction
+// events matching the query from that height on are first replayed from the
+// event sinks, up to the last block stored, and the events of the following
+// blocks delivered as they happen.
 // More: https://docs.tendermint.com/master/rpc/#/Websocket/subscribe
-func (env *Environment) Subscribe(ctx *rpctypes.Context, query string) (*coretypes.ResultSubscribe, error) {
+func (env *Environment) Subscribe(ctx *rpctypes.Context, query string, fromHeight int64) (*coretypes.ResultSubscribe, error) {
 	addr := ctx.RemoteAddr()
 
 	if env.EventBus.NumClients() >= env.Config.MaxSubscriptionClients {
@@ -41,6 +57,18 @@ func (env *Environment) Subscribe(ctx *rpctypes.Context, query string) (*coretyp
 		return nil, fmt.Errorf("failed to parse query: %w", err)
 	}
 
+	var historySink indexer.EventSink
+	if fromHeight > 0 {
+		historySink = indexer.HistorySink(env.EventSinks)
+		if historySink == nil || env.IndexerService == nil {
+			return nil, errors.New("from_height requires an event sink supporting transaction search")
+		}
+		if height := env.BlockStore.Height(); height-fromHeight >= maxHistoryHeights {
+			return nil, fmt.Errorf("from_height can be at most %d heights back, the height is %d",
+				maxHistoryHeights, height)
+		}
+	}
+
 	subCtx, cancel := context.WithTimeout(ctx.Context(), SubscribeTimeout)
 	defer cancel()
 
@@ -53,12 +81,42 @@ func (env *Environment) Subscribe(ctx *rpctypes.Context, query string) (*coretyp
 		return nil, err
 	}
 
+	// The transactions of the blocks up to the last one stored are replayed,
+	// and the events of the blocks stored from now on, which are published
+	// after subscribing, delivered live.
+	var toHeight int64
+	if fromHeight > 0 {
+		toHeight = env.BlockStore.Height()
+	}
+
 	// Capture the current ID, since it can change in the future.
 	subscriptionID := ctx.JSONReq.ID
 	go func() {
 		opctx, opcancel := context.WithCancel(context.Background())
 		defer opcancel()
 
+		if fromHeight > 0 && fromHeight <= toHeight {
+			events, err := env.txHistory(opctx, historySink, query, q, fromHeight, toHeight)
+			if err != nil {
+				env.Logger.Error("failed to replay the transactions of the subscription",
+					"to", addr, "subscriptionID", subscriptionID, "err", err)
+				resp := rpctypes.RPCInternalError(subscriptionID, err)
+				_ = ctx.WSConn.TryWriteRPCResponse(opctx, resp)
+				_ = env.EventBus.Unsubscribe(opctx, tmpubsub.UnsubscribeArgs{Subscriber: addr, Query: q})
+				return
+			}
+			for _, event := range events {
+				resp := rpctypes.NewRPCSuccessResponse(subscriptionID, event)
+				wctx, cancel := context.WithTimeout(opctx, 10*time.Second)
+				err = ctx.WSConn.WriteRPCResponse(wctx, resp)
+				cancel()
+				if err != nil {
+					env.Logger.Info("Unable to write response (slow client)",
+						"to", addr, "subscriptionID", subscriptionID, "err", err)
+				}
+			}
+		}
+
 		for {
 			msg, err := sub.Next(opctx)
 			if errors.Is(err, tmpubsub.ErrUnsubscribed) {
@@ -75,6 +133,11 @@ func (env *Environment) Subscribe(ctx *rpctypes.Context, query string) (*coretyp
 				return
 			}
 
+			// The transactions replayed are not delivered again.
+			if data, ok := msg.Data().(types.EventDataTx); ok && data.Height <= toHeight {
+				continue
+			}
+
 			// We have a message to deliver to the client.
 			resp := rpctypes.NewRPCSuccessResponse(subscriptionID, &coretypes.ResultEvent{
 				Query:  query,
@@ -94,6 +157,83 @@ func (env *Environment) Subscribe(ctx *rpctypes.Context, query string) (*coretyp
 	return &coretypes.ResultSubscribe{}, nil
 }
 
+// txHistory returns the events of the transactions from height from to to,
+// inclusive, matching the query, searched in the given sink once the block at
+// height to is indexed, or historyIndexTimeout has passed.
+func (env *Environment) txHistory(
+	ctx context.Context,
+	sink indexer.EventSink,
+	query string,
+	q *tmquery.Query,
+	from, to int64,
+) ([]*coretypes.ResultEvent, error) {
+	waitCtx, cancel := context.WithTimeout(ctx, historyIndexTimeout)
+	defer cancel()
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+WAIT:
+	for env.IndexerService.IndexedHeight() < to {
+		select {
+		case <-ticker.C:
+		case <-waitCtx.Done():
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			env.Logger.Info("replaying the transactions of a subscription before the last block is indexed",
+				"height", to, "indexed", env.IndexerService.IndexedHeight())
+			break WAIT
+		}
+	}
+
+	// The conditions of the query are searched in the sink in the range of
+	// heights, but for those on the event type, which isn't indexed. The
+	// results are then matched with the query as the events published are.
+	heights := fmt.Sprintf("%s >= %d AND %s <= %d", types.TxHeightKey, from, types.TxHeightKey, to)
+	clauses := make([]string, 0, len(q.Syntax()))
+	for _, clause := range q.Syntax() {
+		conditions := []string{heights}
+		for _, c := range clause {
+			if c.Tag != types.EventTypeKey {
+				conditions = append(conditions, c.String())
+			}
+		}
+		clauses = append(clauses, strings.Join(conditions, " AND "))
+	}
+	if len(clauses) == 0 {
+		clauses = append(clauses, heights)
+	}
+	searchQuery, err := tmquery.New(strings.Join(clauses, " OR "))
+	if err != nil {
+		return nil, fmt.Errorf("failed to make the search query: %w", err)
+	}
+
+	results, err := sink.SearchTxEvents(ctx, searchQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search transactions: %w", err)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Height != results[j].Height {
+			return results[i].Height < results[j].Height
+		}
+		return results[i].Index < results[j].Index
+	})
+
+	events := make([]*coretypes.ResultEvent, 0, len(results))
+	for _, result := range results {
+		data := types.EventDataTx{TxResult: *result}
+		txEvents := eventbus.TxEvents(data)
+		if ok, err := q.Matches(txEvents); err != nil || !ok {
+			continue
+		}
+		events = append(events, &coretypes.ResultEvent{
+			Query:  query,
+			Data:   data,
+			Events: txEvents,
+		})
+	}
+	return events, nil
+}
+
 // Unsubscribe from events via WebSocket.
 // More: https://docs.tendermint.com/master/rpc/#/Websocket/unsubscribe
 func (env *Environment) Unsubscribe(ctx *rpctypes.Context, query string) (*coretypes.ResultUnsubscribe, error) {
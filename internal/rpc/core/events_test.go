@@ -0,0 +1,69 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmquery "github.com/tendermint/tendermint/internal/pubsub/query"
+	"github.com/tendermint/tendermint/internal/state/indexer/sink/kv"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
+)
+
+// indexedHeight fakes an indexer service which indexed up to its height.
+type indexedHeight int64
+
+func (h indexedHeight) IndexedHeight() int64 { return int64(h) }
+
+func TestTxHistory(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the transactions of heights 1 to 3 are sent by a and b in turn
+	sink, err := kv.NewEventSink(dbm.NewMemDB())
+	require.NoError(t, err)
+	senders := []string{"a", "b"}
+	for height := int64(1); height <= 3; height++ {
+		require.NoError(t, sink.IndexBlockEvents(types.EventDataNewBlockHeader{Header: types.Header{Height: height}}))
+		var txs []*abci.TxResult
+		for i, sender := range senders {
+			txs = append(txs, &abci.TxResult{
+				Height: height,
+				Index:  uint32(i),
+				Tx:     types.Tx(fmt.Sprintf("%d/%s", height, sender)),
+				Result: abci.ResponseDeliverTx{Events: []abci.Event{{
+					Type:       "transfer",
+					Attributes: []abci.EventAttribute{{Key: "sender", Value: sender, Index: true}},
+				}}},
+			})
+		}
+		require.NoError(t, sink.IndexTxEvents(txs))
+	}
+
+	env := &Environment{IndexerService: indexedHeight(3), Logger: log.TestingLogger()}
+	history := func(query string, from, to int64) []string {
+		q, err := tmquery.New(query)
+		require.NoError(t, err)
+		events, err := env.txHistory(ctx, sink, query, q, from, to)
+		require.NoError(t, err)
+		var txs []string
+		for _, event := range events {
+			require.Equal(t, query, event.Query)
+			txs = append(txs, string(event.Data.(types.EventDataTx).Tx))
+		}
+		return txs
+	}
+
+	require.Equal(t, []string{"2/a", "3/a"}, history(`tm.event = 'Tx' AND transfer.sender = 'a'`, 2, 3))
+	require.Equal(t, []string{"1/a", "1/b", "2/a", "2/b"}, history(`tm.event = 'Tx'`, 1, 2))
+	require.Equal(t, []string{"1/b", "3/a"},
+		history(`transfer.sender = 'b' AND tx.height = 1 OR transfer.sender = 'a' AND tx.height > 2`, 1, 3))
+
+	// the event type is matched by the node
+	require.Empty(t, history(`tm.event = 'NewBlock'`, 1, 3))
+}
@@ -3,6 +3,9 @@ package core
 import (
 	"fmt"
 
+	"github.com/tendermint/tendermint/internal/evidence"
+	"github.com/tendermint/tendermint/libs/bytes"
+	tmmath "github.com/tendermint/tendermint/libs/math"
 	"github.com/tendermint/tendermint/rpc/coretypes"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 	"github.com/tendermint/tendermint/types"
@@ -27,3 +30,58 @@ func (env *Environment) BroadcastEvidence(
 	}
 	return &coretypes.ResultBroadcastEvidence{Hash: ev.Hash()}, nil
 }
+
+// Evidence lists a paginated set of pending or committed evidence, optionally
+// filtered by type ("duplicate_vote" or "light_client_attack"), by the range of
+// heights of the misbehavior, and by the address of a misbehaving validator.
+// More: https://docs.tendermint.com/master/rpc/#/Evidence/evidence
+func (env *Environment) Evidence(
+	ctx *rpctypes.Context,
+	committed bool,
+	evType string,
+	minHeight, maxHeight int64,
+	validatorAddress bytes.HexBytes,
+	pagePtr, perPagePtr *int,
+) (*coretypes.ResultEvidenceList, error) {
+	if minHeight < 0 || maxHeight < 0 {
+		return nil, fmt.Errorf("%w: heights must be non-negative", coretypes.ErrInvalidRequest)
+	}
+	if maxHeight > 0 && minHeight > maxHeight {
+		return nil, fmt.Errorf("%w: min_height %d is greater than max_height %d",
+			coretypes.ErrInvalidRequest, minHeight, maxHeight)
+	}
+
+	listed, err := env.EvidencePool.ListEvidence(evidence.Filter{
+		Committed:        committed,
+		Type:             evType,
+		MinHeight:        minHeight,
+		MaxHeight:        maxHeight,
+		ValidatorAddress: types.Address(validatorAddress),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// paginate results
+	totalCount := len(listed)
+	perPage := env.validatePerPage(perPagePtr)
+
+	page, err := validatePage(pagePtr, perPage, totalCount)
+	if err != nil {
+		return nil, err
+	}
+
+	skipCount := validateSkipCount(page, perPage)
+	pageSize := tmmath.MinInt(perPage, totalCount-skipCount)
+
+	results := make([]*coretypes.ResultEvidence, 0, pageSize)
+	for _, item := range listed[skipCount : skipCount+pageSize] {
+		results = append(results, &coretypes.ResultEvidence{
+			Evidence:     item.Evidence,
+			Hash:         item.Evidence.Hash(),
+			CommitHeight: item.CommitHeight,
+		})
+	}
+
+	return &coretypes.ResultEvidenceList{Evidence: results, TotalCount: totalCount}, nil
+}
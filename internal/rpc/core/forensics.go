@@ -0,0 +1,43 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tendermint/tendermint/rpc/coretypes"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+// AppHashMismatches returns the forensic dumps written when the app hash of
+// the committed block at the given height did not match the app hash returned
+// by the application, oldest first. If no height is provided, the dumps at the
+// greatest height with dumps are returned.
+// More: https://docs.tendermint.com/master/rpc/#/Info/app_hash_mismatches
+func (env *Environment) AppHashMismatches(
+	ctx *rpctypes.Context,
+	heightPtr *int64,
+) (*coretypes.ResultAppHashMismatches, error) {
+	if env.Forensics == nil {
+		return nil, errors.New("forensic dumps are disabled")
+	}
+
+	var height int64
+	if heightPtr != nil {
+		height = *heightPtr
+		if height <= 0 {
+			return nil, fmt.Errorf("%w (requested height: %d)", coretypes.ErrZeroOrNegativeHeight, height)
+		}
+	}
+
+	height, dumps, err := env.Forensics.Load(height)
+	if err != nil {
+		return nil, err
+	}
+	if len(dumps) == 0 {
+		if height == 0 {
+			return nil, errors.New("no app hash mismatch has been dumped")
+		}
+		return nil, fmt.Errorf("no app hash mismatch has been dumped at height %d", height)
+	}
+	return &coretypes.ResultAppHashMismatches{Height: height, Dumps: dumps}, nil
+}
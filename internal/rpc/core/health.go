@@ -1,6 +1,11 @@
 package core
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
 	"github.com/tendermint/tendermint/rpc/coretypes"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 )
@@ -11,3 +16,98 @@ import (
 func (env *Environment) Health(ctx *rpctypes.Context) (*coretypes.ResultHealth, error) {
 	return &coretypes.ResultHealth{}, nil
 }
+
+// The checks of the health probes
+const (
+	HealthCheckConsensus = "consensus"
+	HealthCheckRPC       = "rpc"
+	HealthCheckSync      = "sync"
+	HealthCheckHeight    = "height"
+	HealthCheckABCI      = "abci"
+)
+
+// LiveProbe checks that the node is live, i.e. that its consensus state
+// machine is responsive. While the node is block or state syncing, the state
+// machine isn't running yet, and the node is live.
+func (env *Environment) LiveProbe(ctx context.Context) *coretypes.ResultHealthProbe {
+	ctx, cancel := env.healthContext(ctx)
+	defer cancel()
+
+	var err error
+	if env.ConsensusState != nil && !env.ConsensusReactor.WaitSync() {
+		err = env.ConsensusState.Ping(ctx)
+	}
+	return newResultHealthProbe(healthCheck(HealthCheckConsensus, err))
+}
+
+// ReadyProbe checks that the node is ready to serve requests, i.e. that the
+// RPC serves its status, that it's done block and state syncing and is at
+// most health-max-blocks-behind blocks behind the highest block reported by
+// its peers, and that the ABCI application is connected.
+func (env *Environment) ReadyProbe(ctx context.Context) *coretypes.ResultHealthProbe {
+	ctx, cancel := env.healthContext(ctx)
+	defer cancel()
+
+	var syncErr, heightErr error
+	status, err := env.Status(&rpctypes.Context{})
+	if err == nil {
+		if status.SyncInfo.CatchingUp {
+			syncErr = fmt.Errorf("block or state syncing, at height %d", status.SyncInfo.LatestBlockHeight)
+		}
+		if lag := status.SyncInfo.HeightLag; lag > env.Config.HealthMaxBlocksBehind {
+			heightErr = fmt.Errorf("%d blocks behind the highest block of the peers, %d",
+				lag, status.SyncInfo.MaxPeerBlockHeight)
+		}
+	} else {
+		syncErr, heightErr = err, err
+	}
+
+	_, abciErr := env.ProxyAppQuery.EchoSync(ctx, "ready")
+
+	return newResultHealthProbe(
+		healthCheck(HealthCheckRPC, err),
+		healthCheck(HealthCheckSync, syncErr),
+		healthCheck(HealthCheckHeight, heightErr),
+		healthCheck(HealthCheckABCI, abciErr),
+	)
+}
+
+// healthContext bounds ctx by health-timeout, if any.
+func (env *Environment) healthContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if env.Config.HealthTimeout > 0 {
+		return context.WithTimeout(ctx, env.Config.HealthTimeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// HealthProbeHandler returns the HTTP handler of a probe, e.g. for a
+// Kubernetes liveness or readiness probe, responding with the checks of the
+// probe as JSON, with the status 200 if the node is healthy and 503 otherwise.
+func HealthProbeHandler(probe func(context.Context) *coretypes.ResultHealthProbe) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res := probe(r.Context())
+		w.Header().Set("Content-Type", "application/json")
+		if res.Healthy {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(res)
+	})
+}
+
+func healthCheck(name string, err error) coretypes.HealthCheck {
+	check := coretypes.HealthCheck{Name: name, Healthy: err == nil}
+	if err != nil {
+		check.Error = err.Error()
+	}
+	return check
+}
+
+func newResultHealthProbe(checks ...coretypes.HealthCheck) *coretypes.ResultHealthProbe {
+	res := &coretypes.ResultHealthProbe{Healthy: true, Checks: checks}
+	for _, check := range checks {
+		res.Healthy = res.Healthy && check.Healthy
+	}
+	return res
+}
@@ -0,0 +1,57 @@
+package core
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/config"
+)
+
+// stuckConsensusState is a consensus state machine whose receive routine
+// never answers pings.
+type stuckConsensusState struct {
+	consensusState
+}
+
+func (stuckConsensusState) Ping(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestLiveProbe(t *testing.T) {
+	cfg := config.DefaultRPCConfig()
+	cfg.HealthTimeout = 10 * time.Millisecond
+	env := &Environment{
+		ConsensusState:   stuckConsensusState{},
+		ConsensusReactor: syncedConsensusReactor{},
+		Config:           *cfg,
+	}
+
+	res := env.LiveProbe(context.Background())
+	assert.False(t, res.Healthy)
+	require.Len(t, res.Checks, 1)
+	assert.Equal(t, HealthCheckConsensus, res.Checks[0].Name)
+	assert.NotEmpty(t, res.Checks[0].Error)
+
+	rec := httptest.NewRecorder()
+	HealthProbeHandler(env.LiveProbe).ServeHTTP(rec, httptest.NewRequest("GET", "/health/live", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	// the state machine doesn't run while syncing
+	env.ConsensusReactor = syncingConsensusReactor{}
+	rec = httptest.NewRecorder()
+	HealthProbeHandler(env.LiveProbe).ServeHTTP(rec, httptest.NewRequest("GET", "/health/live", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+type syncingConsensusReactor struct {
+	syncedConsensusReactor
+}
+
+func (syncingConsensusReactor) WaitSync() bool { return true }
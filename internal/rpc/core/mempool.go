@@ -1,6 +1,7 @@
 package core
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/rand"
@@ -9,6 +10,7 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/internal/mempool"
 	"github.com/tendermint/tendermint/internal/state/indexer"
+	"github.com/tendermint/tendermint/libs/bytes"
 	"github.com/tendermint/tendermint/rpc/coretypes"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 	"github.com/tendermint/tendermint/types"
@@ -73,50 +75,166 @@ func (env *Environment) BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*
 
 	r := (<-resCh).GetCheckTx()
 
-	if !indexer.KVSinkEnabled(env.EventSinks) {
+	if indexer.TxSearchSink(env.EventSinks) == nil {
 		return &coretypes.ResultBroadcastTxCommit{
 				CheckTx: *r,
 				Hash:    tx.Hash(),
 			},
-			errors.New("cannot confirm transaction because kvEventSink is not enabled")
+			errors.New("cannot confirm transaction because no kv or sqlite event sink is enabled")
 	}
 
 	startAt := time.Now()
+	txres, err := env.waitForTx(ctx.Context(), tx.Hash(), false)
+	if err != nil {
+		env.Logger.Error("Error on broadcastTxCommit",
+			"duration", time.Since(startAt),
+			"err", err)
+		return &coretypes.ResultBroadcastTxCommit{
+				CheckTx: *r,
+				Hash:    tx.Hash(),
+			}, fmt.Errorf("timeout waiting for commit of tx %s (%s)",
+				tx.Hash(), time.Since(startAt))
+	}
+
+	return &coretypes.ResultBroadcastTxCommit{
+		CheckTx:   *r,
+		DeliverTx: txres.TxResult,
+		Hash:      tx.Hash(),
+		Height:    txres.Height,
+	}, nil
+}
+
+// BroadcastTxCommitProof returns with the responses from CheckTx and
+// DeliverTx, and a Merkle proof of the inclusion of the tx in its block, once
+// the commit of the block is canonical. It waits for at most the given
+// timeout, which defaults to and is capped by the timeout-broadcast-tx-commit
+// config. Over WebSocket, the status transitions of the tx are streamed before
+// the result, as responses with the id of the request.
+// More: https://docs.tendermint.com/master/rpc/#/Tx/broadcast_tx_commit_proof
+func (env *Environment) BroadcastTxCommitProof(
+	ctx *rpctypes.Context,
+	tx types.Tx,
+	timeout time.Duration,
+) (*coretypes.ResultBroadcastTxCommitProof, error) {
+	if timeout < 0 {
+		return nil, fmt.Errorf("timeout cannot be negative (got %v)", timeout)
+	}
+	if limit := env.Config.TimeoutBroadcastTxCommit; limit > 0 && (timeout == 0 || timeout > limit) {
+		timeout = limit
+	}
+
+	resCh := make(chan *abci.Response, 1)
+	err := env.Mempool.CheckTx(
+		ctx.Context(),
+		tx,
+		func(res *abci.Response) { resCh <- res },
+		mempool.TxInfo{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &coretypes.ResultBroadcastTxCommitProof{
+		CheckTx: *(<-resCh).GetCheckTx(),
+		Hash:    tx.Hash(),
+	}
+	if result.CheckTx.Code != abci.CodeTypeOK {
+		return result, nil
+	}
+
+	if indexer.TxSearchSink(env.EventSinks) == nil {
+		return result, errors.New("cannot confirm transaction because no kv or sqlite event sink is enabled")
+	}
+	env.sendTxStatus(ctx, result.Hash, coretypes.TxStatusAccepted, 0)
+
+	waitCtx := ctx.Context()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(waitCtx, timeout)
+		defer cancel()
+	}
+
+	txres, err := env.waitForTx(waitCtx, tx.Hash(), false)
+	if err != nil {
+		return result, fmt.Errorf("timeout waiting for commit of tx %s: %w", tx.Hash(), err)
+	}
+	result.DeliverTx = txres.TxResult
+	result.Height = txres.Height
+	env.sendTxStatus(ctx, result.Hash, coretypes.TxStatusIncluded, result.Height)
+
+	// the commit of the block is canonical once the next block is stored
+	err = poll(waitCtx, func() bool { return env.BlockStore.Height() > txres.Height })
+	if err != nil {
+		return result, fmt.Errorf("timeout waiting for finalization of tx %s: %w", tx.Hash(), err)
+	}
+	block := env.BlockStore.LoadBlock(txres.Height)
+	if block == nil {
+		return result, fmt.Errorf("block at height %d containing tx %s not found", txres.Height, tx.Hash())
+	}
+	result.Proof = block.Data.Txs.Proof(int(txres.Index))
+	env.sendTxStatus(ctx, result.Hash, coretypes.TxStatusFinalized, result.Height)
+
+	return result, nil
+}
+
+// waitForTx waits until the tx with the given hash is indexed, or the context
+// is done.
+func (env *Environment) waitForTx(ctx context.Context, hash bytes.HexBytes, prove bool) (*coretypes.ResultTx, error) {
+	var txres *coretypes.ResultTx
+	err := poll(ctx, func() bool {
+		var err error
+		txres, err = env.Tx(&rpctypes.Context{}, hash, prove)
+		return err == nil
+	})
+	return txres, err
+}
+
+// poll calls done, with a jittered backoff, until it returns true or the
+// context is done.
+func poll(ctx context.Context, done func() bool) error {
 	timer := time.NewTimer(0)
 	defer timer.Stop()
 
-	count := 0
-	for {
-		count++
+	for count := 1; ; count++ {
 		select {
-		case <-ctx.Context().Done():
-			env.Logger.Error("Error on broadcastTxCommit",
-				"duration", time.Since(startAt),
-				"err", err)
-			return &coretypes.ResultBroadcastTxCommit{
-					CheckTx: *r,
-					Hash:    tx.Hash(),
-				}, fmt.Errorf("timeout waiting for commit of tx %s (%s)",
-					tx.Hash(), time.Since(startAt))
+		case <-ctx.Done():
+			return ctx.Err()
 		case <-timer.C:
-			txres, err := env.Tx(ctx, tx.Hash(), false)
-			if err != nil {
-				jitter := 100*time.Millisecond + time.Duration(rand.Int63n(int64(time.Second))) // nolint: gosec
-				backoff := 100 * time.Duration(count) * time.Millisecond
-				timer.Reset(jitter + backoff)
-				continue
+			if done() {
+				return nil
 			}
-
-			return &coretypes.ResultBroadcastTxCommit{
-				CheckTx:   *r,
-				DeliverTx: txres.TxResult,
-				Hash:      tx.Hash(),
-				Height:    txres.Height,
-			}, nil
+			jitter := 100*time.Millisecond + time.Duration(rand.Int63n(int64(time.Second))) // nolint: gosec
+			backoff := 100 * time.Duration(count) * time.Millisecond
+			timer.Reset(jitter + backoff)
 		}
 	}
 }
 
+// sendTxStatus streams a status transition of a broadcast tx to the client, if
+// it is connected via WebSocket.
+func (env *Environment) sendTxStatus(
+	ctx *rpctypes.Context,
+	hash bytes.HexBytes,
+	status coretypes.TxStatus,
+	height int64,
+) {
+	if ctx.WSConn == nil || ctx.JSONReq == nil {
+		return
+	}
+
+	resp := rpctypes.NewRPCSuccessResponse(ctx.JSONReq.ID, &coretypes.ResultBroadcastTxStatus{
+		Hash:   hash,
+		Status: status,
+		Height: height,
+	})
+	wctx, cancel := context.WithTimeout(ctx.Context(), 10*time.Second)
+	defer cancel()
+	if err := ctx.WSConn.WriteRPCResponse(wctx, resp); err != nil {
+		env.Logger.Info("Unable to write tx status (slow client)",
+			"to", ctx.RemoteAddr(), "hash", hash, "status", status, "err", err)
+	}
+}
+
 // UnconfirmedTxs gets unconfirmed transactions (maximum ?limit entries)
 // including their number.
 // More: https://docs.tendermint.com/master/rpc/#/Info/unconfirmed_txs
@@ -155,3 +273,62 @@ func (env *Environment) CheckTx(ctx *rpctypes.Context, tx types.Tx) (*coretypes.
 func (env *Environment) RemoveTx(ctx *rpctypes.Context, txkey types.TxKey) error {
 	return env.Mempool.RemoveTxByKey(txkey)
 }
+
+// UnconfirmedTxRemove removes the transaction with the given hash from the
+// mempool, e.g. a transaction which crashes the application in CheckTx. If
+// rejectFor is positive, the transaction is refused without calling CheckTx
+// for that long, including when received from peers, even if it is not in
+// the mempool.
+func (env *Environment) UnconfirmedTxRemove(
+	ctx *rpctypes.Context,
+	hash bytes.HexBytes,
+	rejectFor time.Duration,
+) (*coretypes.ResultUnconfirmedTxsRemove, error) {
+	if len(hash) != len(types.TxKey{}) {
+		return nil, fmt.Errorf("invalid tx hash length %d, expected %d", len(hash), len(types.TxKey{}))
+	}
+	if rejectFor < 0 {
+		return nil, fmt.Errorf("reject_for cannot be negative (got %v)", rejectFor)
+	}
+
+	var txKey types.TxKey
+	copy(txKey[:], hash)
+	if rejectFor > 0 {
+		env.Mempool.RejectTxs(rejectFor, txKey)
+	}
+	if err := env.Mempool.RemoveTxByKey(txKey); err != nil {
+		return nil, err
+	}
+	return &coretypes.ResultUnconfirmedTxsRemove{Hashes: []bytes.HexBytes{hash}}, nil
+}
+
+// UnconfirmedTxsRemoveBySender removes the transactions of the given senders,
+// as reported by the application in CheckTx, from the mempool. If rejectFor
+// is positive, the removed transactions are refused without calling CheckTx
+// for that long.
+func (env *Environment) UnconfirmedTxsRemoveBySender(
+	ctx *rpctypes.Context,
+	senders []string,
+	rejectFor time.Duration,
+) (*coretypes.ResultUnconfirmedTxsRemove, error) {
+	if rejectFor < 0 {
+		return nil, fmt.Errorf("reject_for cannot be negative (got %v)", rejectFor)
+	}
+
+	var (
+		txKeys = make([]types.TxKey, 0, len(senders))
+		hashes = make([]bytes.HexBytes, 0, len(senders))
+	)
+	for _, sender := range senders {
+		txKey, err := env.Mempool.RemoveTxBySender(sender)
+		if err != nil {
+			continue // the sender has no transaction in the mempool
+		}
+		txKeys = append(txKeys, txKey)
+		hashes = append(hashes, txKey[:])
+	}
+	if rejectFor > 0 {
+		env.Mempool.RejectTxs(rejectFor, txKeys...)
+	}
+	return &coretypes.ResultUnconfirmedTxsRemove{Hashes: hashes}, nil
+}
@@ -1,11 +1,16 @@
 package core
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"io"
+	"os"
 
+	"github.com/tendermint/tendermint/internal/p2p"
 	"github.com/tendermint/tendermint/rpc/coretypes"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
 )
 
 // NetInfo returns network info.
@@ -20,24 +25,143 @@ func (env *Environment) NetInfo(ctx *rpctypes.Context) (*coretypes.ResultNetInfo
 			continue
 		}
 
-		peers = append(peers, coretypes.Peer{
+		p := coretypes.Peer{
 			ID:  peer,
 			URL: addrs[0].String(),
-		})
+		}
+		if nodeInfo, ok := env.PeerManager.NodeInfo(peer); ok {
+			p.NodeInfo = &nodeInfo
+		}
+		peers = append(peers, p)
 	}
 
+	versions := env.PeerManager.PeerVersions()
 	return &coretypes.ResultNetInfo{
 		Listening: env.P2PTransport.IsListening(),
 		Listeners: env.P2PTransport.Listeners(),
 		NPeers:    len(peers),
 		Peers:     peers,
+		Versions: coretypes.PeerVersions{
+			Software: versionCounts(versions.Software),
+			Block:    versionCounts(versions.Block),
+			App:      versionCounts(versions.App),
+		},
 	}, nil
 }
 
+// NetTopology returns the network graph as seen by the node: its connected
+// peers, with the round-trip times and channels of their connections, and the
+// nodes each peer reported in its last PEX response.
+// UNSTABLE
+// More: https://docs.tendermint.com/master/rpc/#/Info/net_topology
+func (env *Environment) NetTopology(ctx *rpctypes.Context) (*coretypes.ResultNetTopology, error) {
+	self := env.P2PTransport.NodeInfo()
+	result := &coretypes.ResultNetTopology{
+		NodeID: self.NodeID,
+		Nodes: []coretypes.TopologyNode{{
+			ID:       self.NodeID,
+			Moniker:  self.Moniker,
+			Channels: self.Channels,
+		}},
+		Edges: []coretypes.TopologyEdge{},
+	}
+	known := map[types.NodeID]bool{self.NodeID: true}
+
+	peers := env.PeerManager.Peers()
+	for _, peer := range peers {
+		node := coretypes.TopologyNode{ID: peer, Connected: true}
+		if nodeInfo, ok := env.PeerManager.NodeInfo(peer); ok {
+			node.Moniker = nodeInfo.Moniker
+			node.Channels = nodeInfo.Channels
+		}
+		result.Nodes = append(result.Nodes, node)
+		known[peer] = true
+
+		edge := coretypes.TopologyEdge{From: self.NodeID, To: peer}
+		if rtt, ok := env.P2PTransport.PeerRTT(peer); ok {
+			edge.Latency = rtt
+		}
+		result.Edges = append(result.Edges, edge)
+	}
+
+	if env.PexReactor == nil {
+		return result, nil
+	}
+	for _, peer := range peers {
+		reported, _ := env.PexReactor.ReportedPeers(peer)
+		for _, node := range reported {
+			if !known[node] {
+				result.Nodes = append(result.Nodes, coretypes.TopologyNode{ID: node})
+				known[node] = true
+			}
+			result.Edges = append(result.Edges, coretypes.TopologyEdge{From: peer, To: node, Reported: true})
+		}
+	}
+	return result, nil
+}
+
+func versionCounts(counts []p2p.VersionCount) []coretypes.VersionCount {
+	res := make([]coretypes.VersionCount, 0, len(counts))
+	for _, count := range counts {
+		res = append(res, coretypes.VersionCount{Version: count.Version, NPeers: count.Peers})
+	}
+	return res
+}
+
+// AddressBook returns all peer addresses known to the node, along with their
+// dial history.
+func (env *Environment) AddressBook(ctx *rpctypes.Context) (*coretypes.ResultAddressBook, error) {
+	entries := env.PeerManager.ExportAddressBook()
+
+	addresses := make([]coretypes.AddressBookEntry, 0, len(entries))
+	for _, entry := range entries {
+		addresses = append(addresses, coretypes.AddressBookEntry{
+			Address:         entry.Address.String(),
+			Source:          entry.Source,
+			LastConnected:   entry.LastConnected,
+			LastDialSuccess: entry.LastDialSuccess,
+			LastDialFailure: entry.LastDialFailure,
+			DialFailures:    entry.DialFailures,
+		})
+	}
+
+	return &coretypes.ResultAddressBook{Addresses: addresses}, nil
+}
+
+// ImportAddressBook adds the given peer addresses, such as those returned by
+// AddressBook on another node, to the address book.
+func (env *Environment) ImportAddressBook(
+	ctx *rpctypes.Context,
+	addresses []coretypes.AddressBookEntry,
+) (*coretypes.ResultImportAddressBook, error) {
+	entries := make([]p2p.AddressBookEntry, 0, len(addresses))
+	for _, addr := range addresses {
+		address, err := p2p.ParseNodeAddress(addr.Address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address %q: %w", addr.Address, err)
+		}
+		entries = append(entries, p2p.AddressBookEntry{
+			Address:         address,
+			Source:          addr.Source,
+			LastConnected:   addr.LastConnected,
+			LastDialSuccess: addr.LastDialSuccess,
+			LastDialFailure: addr.LastDialFailure,
+			DialFailures:    addr.DialFailures,
+		})
+	}
+
+	added, err := env.PeerManager.ImportAddressBook(entries)
+	if err != nil {
+		return nil, err
+	}
+	return &coretypes.ResultImportAddressBook{Added: added}, nil
+}
+
 // Genesis returns genesis file.
 // More: https://docs.tendermint.com/master/rpc/#/Info/genesis
 func (env *Environment) Genesis(ctx *rpctypes.Context) (*coretypes.ResultGenesis, error) {
-	if len(env.genChunks) > 1 {
+	// A streamed genesis doc does not hold its app state.
+	if len(env.genChunks) > 1 || env.genFile != "" {
 		return nil, errors.New("genesis response is large, please use the genesis_chunked API instead")
 	}
 
@@ -45,6 +169,10 @@ func (env *Environment) Genesis(ctx *rpctypes.Context) (*coretypes.ResultGenesis
 }
 
 func (env *Environment) GenesisChunked(ctx *rpctypes.Context, chunk uint) (*coretypes.ResultGenesisChunk, error) {
+	if env.genFile != "" {
+		return env.genesisFileChunk(chunk)
+	}
+
 	if env.genChunks == nil {
 		return nil, fmt.Errorf("service configuration error, genesis chunks are not initialized")
 	}
@@ -65,3 +193,31 @@ func (env *Environment) GenesisChunked(ctx *rpctypes.Context, chunk uint) (*core
 		Data:        env.genChunks[id],
 	}, nil
 }
+
+// genesisFileChunk reads a chunk of the genesis file a streamed genesis doc
+// was loaded from.
+func (env *Environment) genesisFileChunk(chunk uint) (*coretypes.ResultGenesisChunk, error) {
+	id := int(chunk)
+
+	if id > env.genFileChunks-1 {
+		return nil, fmt.Errorf("there are %d chunks, %d is invalid", env.genFileChunks-1, id)
+	}
+
+	f, err := os.Open(env.genFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	data := make([]byte, genesisChunkSize)
+	n, err := f.ReadAt(data, int64(id)*genesisChunkSize)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	return &coretypes.ResultGenesisChunk{
+		TotalChunks: env.genFileChunks,
+		ChunkNumber: id,
+		Data:        base64.StdEncoding.EncodeToString(data[:n]),
+	}, nil
+}
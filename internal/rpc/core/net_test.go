@@ -0,0 +1,94 @@
+package core
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/rpc/coretypes"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// topologyPeers fakes the peer manager, transport and PEX reactor of a node
+// with the given peers, their round-trip times and reported peers.
+type topologyPeers struct {
+	peerManager
+	transport
+
+	peers    []types.NodeID
+	rtts     map[types.NodeID]time.Duration
+	reported map[types.NodeID][]types.NodeID
+}
+
+func (p topologyPeers) Peers() []types.NodeID { return p.peers }
+
+func (p topologyPeers) NodeInfo(peer types.NodeID) (types.NodeInfo, bool) {
+	return types.NodeInfo{NodeID: peer, Moniker: string(peer)[:2], Channels: []byte{0x20}}, true
+}
+
+func (p topologyPeers) PeerRTT(peer types.NodeID) (time.Duration, bool) {
+	rtt, ok := p.rtts[peer]
+	return rtt, ok
+}
+
+func (p topologyPeers) ReportedPeers(peer types.NodeID) ([]types.NodeID, bool) {
+	nodes, ok := p.reported[peer]
+	return nodes, ok
+}
+
+// selfTransport fakes the transport of the node itself.
+type selfTransport struct {
+	topologyPeers
+	self types.NodeInfo
+}
+
+func (t selfTransport) NodeInfo() types.NodeInfo { return t.self }
+
+func TestNetTopology(t *testing.T) {
+	self := types.NodeID(strings.Repeat("00", 20))
+	a := types.NodeID(strings.Repeat("aa", 20))
+	b := types.NodeID(strings.Repeat("bb", 20))
+	c := types.NodeID(strings.Repeat("cc", 20))
+	peers := topologyPeers{
+		peers: []types.NodeID{a, b},
+		rtts:  map[types.NodeID]time.Duration{a: time.Millisecond},
+		reported: map[types.NodeID][]types.NodeID{
+			a: {self, b, c},
+		},
+	}
+	env := &Environment{
+		PeerManager:  peers,
+		P2PTransport: selfTransport{topologyPeers: peers, self: types.NodeInfo{NodeID: self, Moniker: "self"}},
+	}
+
+	// without PEX, only the connections of the node are known
+	result, err := env.NetTopology(&rpctypes.Context{})
+	require.NoError(t, err)
+	assert.Equal(t, self, result.NodeID)
+	require.Len(t, result.Nodes, 3)
+	assert.Equal(t, coretypes.TopologyNode{ID: self, Moniker: "self"}, result.Nodes[0])
+	assert.Equal(t, coretypes.TopologyNode{ID: a, Moniker: "aa", Connected: true, Channels: []byte{0x20}},
+		result.Nodes[1])
+	assert.Equal(t, []coretypes.TopologyEdge{
+		{From: self, To: a, Latency: time.Millisecond},
+		{From: self, To: b},
+	}, result.Edges)
+
+	// with PEX, the nodes reported by the peers are added
+	env.PexReactor = peers
+	result, err = env.NetTopology(&rpctypes.Context{})
+	require.NoError(t, err)
+	require.Len(t, result.Nodes, 4)
+	assert.Equal(t, coretypes.TopologyNode{ID: c}, result.Nodes[3])
+	assert.Equal(t, []coretypes.TopologyEdge{
+		{From: self, To: a, Latency: time.Millisecond},
+		{From: self, To: b},
+		{From: a, To: self, Reported: true},
+		{From: a, To: b, Reported: true},
+		{From: a, To: c, Reported: true},
+	}, result.Edges)
+}
@@ -12,7 +12,7 @@ type RoutesMap map[string]*rpc.RPCFunc
 func (env *Environment) GetRoutes() RoutesMap {
 	return RoutesMap{
 		// subscribe/unsubscribe are reserved for websocket events.
-		"subscribe":       rpc.NewWSRPCFunc(env.Subscribe, "query"),
+		"subscribe":       rpc.NewWSRPCFunc(env.Subscribe, "query,from_height"),
 		"unsubscribe":     rpc.NewWSRPCFunc(env.Unsubscribe, "query"),
 		"unsubscribe_all": rpc.NewWSRPCFunc(env.UnsubscribeAll, ""),
 
@@ -20,6 +20,7 @@ func (env *Environment) GetRoutes() RoutesMap {
 		"health":               rpc.NewRPCFunc(env.Health, "", false),
 		"status":               rpc.NewRPCFunc(env.Status, "", false),
 		"net_info":             rpc.NewRPCFunc(env.NetInfo, "", false),
+		"net_topology":         rpc.NewRPCFunc(env.NetTopology, "", false),
 		"blockchain":           rpc.NewRPCFunc(env.BlockchainInfo, "minHeight,maxHeight", true),
 		"genesis":              rpc.NewRPCFunc(env.Genesis, "", true),
 		"genesis_chunked":      rpc.NewRPCFunc(env.GenesisChunked, "chunk", true),
@@ -27,31 +28,39 @@ func (env *Environment) GetRoutes() RoutesMap {
 		"header_by_hash":       rpc.NewRPCFunc(env.HeaderByHash, "hash", true),
 		"block":                rpc.NewRPCFunc(env.Block, "height", true),
 		"block_by_hash":        rpc.NewRPCFunc(env.BlockByHash, "hash", true),
-		"block_results":        rpc.NewRPCFunc(env.BlockResults, "height", true),
+		"block_results":        rpc.NewRPCFunc(env.BlockResults, "height,prove_events", true),
 		"commit":               rpc.NewRPCFunc(env.Commit, "height", true),
 		"check_tx":             rpc.NewRPCFunc(env.CheckTx, "tx", true),
 		"remove_tx":            rpc.NewRPCFunc(env.RemoveTx, "txkey", false),
 		"tx":                   rpc.NewRPCFunc(env.Tx, "hash,prove", true),
 		"tx_search":            rpc.NewRPCFunc(env.TxSearch, "query,prove,page,per_page,order_by", false),
-		"block_search":         rpc.NewRPCFunc(env.BlockSearch, "query,page,per_page,order_by", false),
-		"validators":           rpc.NewRPCFunc(env.Validators, "height,page,per_page", true),
+		"block_search":         rpc.NewRPCFunc(env.BlockSearch, "query,page,per_page,order_by,match_events", false),
+		"validators":           rpc.NewRPCFunc(env.Validators, "height,page,per_page,cursor,window", true),
+		"validator_updates":    rpc.NewRPCFunc(env.ValidatorUpdates, "min_height,max_height", true),
 		"dump_consensus_state": rpc.NewRPCFunc(env.DumpConsensusState, "", false),
+		"app_hash_mismatches":  rpc.NewRPCFunc(env.AppHashMismatches, "height", false),
 		"consensus_state":      rpc.NewRPCFunc(env.GetConsensusState, "", false),
 		"consensus_params":     rpc.NewRPCFunc(env.ConsensusParams, "height", true),
+		"consensus_time_stats": rpc.NewRPCFunc(env.ConsensusTimeStats, "", false),
 		"unconfirmed_txs":      rpc.NewRPCFunc(env.UnconfirmedTxs, "limit", false),
 		"num_unconfirmed_txs":  rpc.NewRPCFunc(env.NumUnconfirmedTxs, "", false),
+		"archived_tx":          rpc.NewRPCFunc(env.ArchivedTx, "hash", false),
+		"archived_txs":         rpc.NewRPCFunc(env.ArchivedTxs, "sender,status,page,per_page", false),
 
 		// tx broadcast API
-		"broadcast_tx_commit": rpc.NewRPCFunc(env.BroadcastTxCommit, "tx", false),
-		"broadcast_tx_sync":   rpc.NewRPCFunc(env.BroadcastTxSync, "tx", false),
-		"broadcast_tx_async":  rpc.NewRPCFunc(env.BroadcastTxAsync, "tx", false),
+		"broadcast_tx_commit":       rpc.NewRPCFunc(env.BroadcastTxCommit, "tx", false),
+		"broadcast_tx_commit_proof": rpc.NewRPCFunc(env.BroadcastTxCommitProof, "tx,timeout", false),
+		"broadcast_tx_sync":         rpc.NewRPCFunc(env.BroadcastTxSync, "tx", false),
+		"broadcast_tx_async":        rpc.NewRPCFunc(env.BroadcastTxAsync, "tx", false),
 
 		// abci API
-		"abci_query": rpc.NewRPCFunc(env.ABCIQuery, "path,data,height,prove", false),
+		"abci_query": rpc.NewRPCFunc(env.ABCIQuery, "path,data,height,prove,verify", false),
 		"abci_info":  rpc.NewRPCFunc(env.ABCIInfo, "", true),
 
 		// evidence API
 		"broadcast_evidence": rpc.NewRPCFunc(env.BroadcastEvidence, "evidence", false),
+		"evidence": rpc.NewRPCFunc(env.Evidence,
+			"committed,type,min_height,max_height,validator_address,page,per_page", false),
 	}
 }
 
@@ -59,4 +68,14 @@ func (env *Environment) GetRoutes() RoutesMap {
 func (env *Environment) AddUnsafe(routes RoutesMap) {
 	// control API
 	routes["unsafe_flush_mempool"] = rpc.NewRPCFunc(env.UnsafeFlushMempool, "", false)
+	routes["unconfirmed_tx_remove"] = rpc.NewRPCFunc(env.UnconfirmedTxRemove, "hash,reject_for", false)
+	routes["unconfirmed_txs_remove_by_sender"] = rpc.NewRPCFunc(env.UnconfirmedTxsRemoveBySender,
+		"senders,reject_for", false)
+	routes["unsafe_profiles"] = rpc.NewRPCFunc(env.UnsafeProfiles, "", false)
+	routes["unsafe_set_profile"] = rpc.NewRPCFunc(env.UnsafeSetProfile, "profile,enabled,rate", false)
+	routes["unsafe_dump_flight_recorder"] = rpc.NewRPCFunc(env.UnsafeDumpFlightRecorder, "", false)
+
+	// address book API
+	routes["address_book"] = rpc.NewRPCFunc(env.AddressBook, "", false)
+	routes["import_address_book"] = rpc.NewRPCFunc(env.ImportAddressBook, "addresses", false)
 }
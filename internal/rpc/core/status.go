@@ -59,6 +59,19 @@ func (env *Environment) Status(ctx *rpctypes.Context) (*coretypes.ResultStatus,
 		}
 	}
 
+	// The peers report their height over the status channel, and to the block
+	// sync reactor while it is syncing.
+	maxPeerHeight := env.BlockSyncReactor.GetMaxPeerBlockHeight()
+	if env.PeerManager != nil {
+		if h := env.PeerManager.MaxPeerHeight(); h > maxPeerHeight {
+			maxPeerHeight = h
+		}
+	}
+	var heightLag int64
+	if maxPeerHeight > latestHeight {
+		heightLag = maxPeerHeight - latestHeight
+	}
+
 	result := &coretypes.ResultStatus{
 		NodeInfo: env.P2PTransport.NodeInfo(),
 		SyncInfo: coretypes.SyncInfo{
@@ -70,7 +83,8 @@ func (env *Environment) Status(ctx *rpctypes.Context) (*coretypes.ResultStatus,
 			EarliestAppHash:     earliestAppHash,
 			EarliestBlockHeight: earliestBlockHeight,
 			EarliestBlockTime:   time.Unix(0, earliestBlockTimeNano),
-			MaxPeerBlockHeight:  env.BlockSyncReactor.GetMaxPeerBlockHeight(),
+			MaxPeerBlockHeight:  maxPeerHeight,
+			HeightLag:           heightLag,
 			CatchingUp:          env.ConsensusReactor.WaitSync(),
 			TotalSyncedTime:     env.BlockSyncReactor.GetTotalSyncedTime(),
 			RemainingTime:       env.BlockSyncReactor.GetRemainingSyncTime(),
@@ -78,6 +92,14 @@ func (env *Environment) Status(ctx *rpctypes.Context) (*coretypes.ResultStatus,
 		ValidatorInfo: validatorInfo,
 	}
 
+	if env.Halt != nil {
+		result.HaltInfo = &coretypes.HaltInfo{
+			HaltHeight: env.Halt.Height(),
+			HaltTime:   env.Halt.Time(),
+			Halted:     env.Halt.Marker() != nil,
+		}
+	}
+
 	if env.StateSyncMetricer != nil {
 		result.SyncInfo.TotalSnapshots = env.StateSyncMetricer.TotalSnapshots()
 		result.SyncInfo.ChunkProcessAvgTime = env.StateSyncMetricer.ChunkProcessAvgTime()
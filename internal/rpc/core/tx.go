@@ -25,38 +25,33 @@ func (env *Environment) Tx(ctx *rpctypes.Context, hash bytes.HexBytes, prove boo
 	// decoding logic in the HTTP service will correctly translate from JSON.
 	// See https://github.com/tendermint/tendermint/issues/6802 for context.
 
-	if !indexer.KVSinkEnabled(env.EventSinks) {
-		return nil, errors.New("transaction querying is disabled due to no kvEventSink")
+	sink := indexer.TxSearchSink(env.EventSinks)
+	if sink == nil {
+		return nil, errors.New("transaction querying is disabled due to no kv or sqlite event sink")
 	}
 
-	for _, sink := range env.EventSinks {
-		if sink.Type() == indexer.KV {
-			r, err := sink.GetTxByHash(hash)
-			if r == nil {
-				return nil, fmt.Errorf("tx (%X) not found, err: %w", hash, err)
-			}
+	r, err := sink.GetTxByHash(hash)
+	if r == nil {
+		return nil, fmt.Errorf("tx (%X) not found, err: %w", hash, err)
+	}
 
-			height := r.Height
-			index := r.Index
+	height := r.Height
+	index := r.Index
 
-			var proof types.TxProof
-			if prove {
-				block := env.BlockStore.LoadBlock(height)
-				proof = block.Data.Txs.Proof(int(index)) // XXX: overflow on 32-bit machines
-			}
-
-			return &coretypes.ResultTx{
-				Hash:     hash,
-				Height:   height,
-				Index:    index,
-				TxResult: r.Result,
-				Tx:       r.Tx,
-				Proof:    proof,
-			}, nil
-		}
+	var proof types.TxProof
+	if prove {
+		block := env.BlockStore.LoadBlock(height)
+		proof = block.Data.Txs.Proof(int(index)) // XXX: overflow on 32-bit machines
 	}
 
-	return nil, fmt.Errorf("transaction querying is disabled on this node due to the KV event sink being disabled")
+	return &coretypes.ResultTx{
+		Hash:     hash,
+		Height:   height,
+		Index:    index,
+		TxResult: r.Result,
+		Tx:       r.Tx,
+		Proof:    proof,
+	}, nil
 }
 
 // TxSearch allows you to query for multiple transactions results. It returns a
@@ -70,8 +65,9 @@ func (env *Environment) TxSearch(
 	orderBy string,
 ) (*coretypes.ResultTxSearch, error) {
 
-	if !indexer.KVSinkEnabled(env.EventSinks) {
-		return nil, fmt.Errorf("transaction searching is disabled due to no kvEventSink")
+	sink := indexer.TxSearchSink(env.EventSinks)
+	if sink == nil {
+		return nil, fmt.Errorf("transaction searching is disabled due to no kv or sqlite event sink")
 	} else if len(query) > maxQueryLength {
 		return nil, errors.New("maximum query length exceeded")
 	}
@@ -81,68 +77,62 @@ func (env *Environment) TxSearch(
 		return nil, err
 	}
 
-	for _, sink := range env.EventSinks {
-		if sink.Type() == indexer.KV {
-			results, err := sink.SearchTxEvents(ctx.Context(), q)
-			if err != nil {
-				return nil, err
-			}
+	results, err := sink.SearchTxEvents(ctx.Context(), q)
+	if err != nil {
+		return nil, err
+	}
 
-			// sort results (must be done before pagination)
-			switch orderBy {
-			case "desc", "":
-				sort.Slice(results, func(i, j int) bool {
-					if results[i].Height == results[j].Height {
-						return results[i].Index > results[j].Index
-					}
-					return results[i].Height > results[j].Height
-				})
-			case "asc":
-				sort.Slice(results, func(i, j int) bool {
-					if results[i].Height == results[j].Height {
-						return results[i].Index < results[j].Index
-					}
-					return results[i].Height < results[j].Height
-				})
-			default:
-				return nil, fmt.Errorf("expected order_by to be either `asc` or `desc` or empty: %w", coretypes.ErrInvalidRequest)
+	// sort results (must be done before pagination)
+	switch orderBy {
+	case "desc", "":
+		sort.Slice(results, func(i, j int) bool {
+			if results[i].Height == results[j].Height {
+				return results[i].Index > results[j].Index
 			}
+			return results[i].Height > results[j].Height
+		})
+	case "asc":
+		sort.Slice(results, func(i, j int) bool {
+			if results[i].Height == results[j].Height {
+				return results[i].Index < results[j].Index
+			}
+			return results[i].Height < results[j].Height
+		})
+	default:
+		return nil, fmt.Errorf("expected order_by to be either `asc` or `desc` or empty: %w", coretypes.ErrInvalidRequest)
+	}
 
-			// paginate results
-			totalCount := len(results)
-			perPage := env.validatePerPage(perPagePtr)
+	// paginate results
+	totalCount := len(results)
+	perPage := env.validatePerPage(perPagePtr)
 
-			page, err := validatePage(pagePtr, perPage, totalCount)
-			if err != nil {
-				return nil, err
-			}
+	page, err := validatePage(pagePtr, perPage, totalCount)
+	if err != nil {
+		return nil, err
+	}
 
-			skipCount := validateSkipCount(page, perPage)
-			pageSize := tmmath.MinInt(perPage, totalCount-skipCount)
-
-			apiResults := make([]*coretypes.ResultTx, 0, pageSize)
-			for i := skipCount; i < skipCount+pageSize; i++ {
-				r := results[i]
-
-				var proof types.TxProof
-				if prove {
-					block := env.BlockStore.LoadBlock(r.Height)
-					proof = block.Data.Txs.Proof(int(r.Index)) // XXX: overflow on 32-bit machines
-				}
-
-				apiResults = append(apiResults, &coretypes.ResultTx{
-					Hash:     types.Tx(r.Tx).Hash(),
-					Height:   r.Height,
-					Index:    r.Index,
-					TxResult: r.Result,
-					Tx:       r.Tx,
-					Proof:    proof,
-				})
-			}
+	skipCount := validateSkipCount(page, perPage)
+	pageSize := tmmath.MinInt(perPage, totalCount-skipCount)
 
-			return &coretypes.ResultTxSearch{Txs: apiResults, TotalCount: totalCount}, nil
+	apiResults := make([]*coretypes.ResultTx, 0, pageSize)
+	for i := skipCount; i < skipCount+pageSize; i++ {
+		r := results[i]
+
+		var proof types.TxProof
+		if prove {
+			block := env.BlockStore.LoadBlock(r.Height)
+			proof = block.Data.Txs.Proof(int(r.Index)) // XXX: overflow on 32-bit machines
 		}
+
+		apiResults = append(apiResults, &coretypes.ResultTx{
+			Hash:     types.Tx(r.Tx).Hash(),
+			Height:   r.Height,
+			Index:    r.Index,
+			TxResult: r.Result,
+			Tx:       r.Tx,
+			Proof:    proof,
+		})
 	}
 
-	return nil, fmt.Errorf("transaction searching is disabled on this node due to the KV event sink being disabled")
+	return &coretypes.ResultTxSearch{Txs: apiResults, TotalCount: totalCount}, nil
 }
@@ -16,6 +16,12 @@ type (
 		Height   int64
 	}
 
+	ErrAppHashMismatch struct {
+		Height   int64
+		Expected []byte
+		Got      []byte
+	}
+
 	ErrAppBlockHeightTooHigh struct {
 		CoreHeight int64
 		AppHeight  int64
@@ -63,6 +69,10 @@ func (e ErrBlockHashMismatch) Error() string {
 	)
 }
 
+func (e ErrAppHashMismatch) Error() string {
+	return fmt.Sprintf("wrong Block.Header.AppHash.  Expected %X, got %X", e.Expected, e.Got)
+}
+
 func (e ErrAppBlockHeightTooHigh) Error() string {
 	return fmt.Sprintf("app block height (%d) is higher than core (%d)", e.AppHeight, e.CoreHeight)
 }
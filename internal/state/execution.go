@@ -6,17 +6,24 @@ import (
 	"fmt"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto/encoding"
 	"github.com/tendermint/tendermint/internal/eventbus"
 	"github.com/tendermint/tendermint/internal/libs/fail"
+	"github.com/tendermint/tendermint/internal/libs/tracing"
 	"github.com/tendermint/tendermint/internal/mempool"
 	"github.com/tendermint/tendermint/internal/proxy"
 	"github.com/tendermint/tendermint/libs/log"
+	tmtime "github.com/tendermint/tendermint/libs/time"
 	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
 	"github.com/tendermint/tendermint/types"
 )
 
+var tracer = tracing.Tracer("state")
+
 //-----------------------------------------------------------------------------
 // BlockExecutor handles block execution and state updates.
 // It exposes ApplyBlock(), which validates & executes the block, updates state w/ ABCI responses,
@@ -36,6 +43,9 @@ type BlockExecutor struct {
 	// events
 	eventBus types.BlockEventPublisher
 
+	// notified of the snapshot hints of the application's Commit responses
+	snapshotNotifier SnapshotNotifier
+
 	// manage the mempool lock during commit
 	// and update both with block results after commit.
 	mempool mempool.Mempool
@@ -44,18 +54,49 @@ type BlockExecutor struct {
 	logger  log.Logger
 	metrics *Metrics
 
+	// stops block execution at the halt height or time, if set
+	halt *Halt
+
+	// writes forensic dumps of app hash mismatches, if set, with the
+	// execution of the last block applied
+	forensics     *Forensics
+	lastExecution *ExecutionTranscript
+
 	// cache the verification results over a single height
 	cache map[string]struct{}
 }
 
 type BlockExecutorOption func(executor *BlockExecutor)
 
+// SnapshotNotifier is notified of the snapshots the application reports in
+// its Commit responses: the height of a snapshot it has just created, if any,
+// and the height of the oldest snapshot it retains, if any.
+type SnapshotNotifier interface {
+	NotifySnapshots(height, retainHeight uint64)
+}
+
 func BlockExecutorWithMetrics(metrics *Metrics) BlockExecutorOption {
 	return func(blockExec *BlockExecutor) {
 		blockExec.metrics = metrics
 	}
 }
 
+// BlockExecutorWithHalt stops block execution once a block reaching the halt
+// height or time has been committed.
+func BlockExecutorWithHalt(halt *Halt) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.halt = halt
+	}
+}
+
+// BlockExecutorWithForensics writes a forensic dump when the app hash of a
+// committed block does not match the app hash returned by the application.
+func BlockExecutorWithForensics(forensics *Forensics) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.forensics = forensics
+	}
+}
+
 // NewBlockExecutor returns a new BlockExecutor with a NopEventBus.
 // Call SetEventBus to provide one.
 func NewBlockExecutor(
@@ -96,27 +137,103 @@ func (blockExec *BlockExecutor) SetEventBus(eventBus types.BlockEventPublisher)
 	blockExec.eventBus = eventBus
 }
 
+// SetSnapshotNotifier sets the notifier of the snapshot hints of the
+// application's Commit responses, e.g. the state sync reactor.
+func (blockExec *BlockExecutor) SetSnapshotNotifier(notifier SnapshotNotifier) {
+	blockExec.snapshotNotifier = notifier
+}
+
 // CreateProposalBlock calls state.MakeBlock with evidence from the evpool
 // and txs from the mempool. The max bytes must be big enough to fit the commit.
 // Up to 1/10th of the block space is allcoated for maximum sized evidence.
 // The rest is given to txs, up to the max gas.
+//
+// The txs reaped from the mempool are only candidates: the application
+// returns the txs of the proposal from PrepareProposal, and may reorder,
+// replace or add txs, as long as they fit in the block.
 func (blockExec *BlockExecutor) CreateProposalBlock(
+	ctx context.Context,
 	height int64,
 	state State, commit *types.Commit,
 	proposerAddr []byte,
-) (*types.Block, *types.PartSet) {
+) (*types.Block, *types.PartSet, error) {
 
 	maxBytes := state.ConsensusParams.Block.MaxBytes
 	maxGas := state.ConsensusParams.Block.MaxGas
+	maxGasWanted := state.ConsensusParams.Block.GasWantedLimit()
 
 	evidence, evSize := blockExec.evpool.PendingEvidence(state.ConsensusParams.Evidence.MaxBytes)
 
 	// Fetch a limited amount of valid txs
 	maxDataBytes := types.MaxDataBytes(maxBytes, evSize, state.Validators.Size())
 
-	txs := blockExec.mempool.ReapMaxBytesMaxGas(maxDataBytes, maxGas)
+	candidates := blockExec.mempool.ReapMaxBytesMaxGas(maxDataBytes, maxGas, maxGasWanted)
+	candidateTxs := make([][]byte, len(candidates))
+	for i, tx := range candidates {
+		candidateTxs[i] = tx
+	}
+
+	res, err := blockExec.proxyApp.PrepareProposalSync(
+		ctx,
+		abci.RequestPrepareProposal{
+			Txs:                 candidateTxs,
+			MaxTxBytes:          maxDataBytes,
+			Height:              height,
+			Time:                state.blockTime(height, commit),
+			ProposerAddress:     proposerAddr,
+			LastCommitInfo:      buildLastCommitInfo(height, commit, state.LastValidators, state.InitialHeight),
+			ByzantineValidators: getByzantineValidators(evidence),
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	txs := make([]types.Tx, len(res.Txs))
+	for i, tx := range res.Txs {
+		txs[i] = tx
+	}
+	if size := types.ComputeProtoSizeForTxs(txs); size > maxDataBytes {
+		return nil, nil, fmt.Errorf("txs returned by PrepareProposal take %d bytes, more than the maximum of %d",
+			size, maxDataBytes)
+	}
 
-	return state.MakeBlock(height, txs, commit, evidence, proposerAddr)
+	block, blockParts := state.MakeBlock(height, txs, commit, evidence, proposerAddr)
+	return block, blockParts, nil
+}
+
+// ProcessProposal asks the application whether a proposed block, which must
+// already be valid according to ValidateBlock, is acceptable. Validators
+// prevote nil for blocks rejected by the application.
+func (blockExec *BlockExecutor) ProcessProposal(ctx context.Context, state State, block *types.Block) (bool, error) {
+	pbh := block.Header.ToProto()
+	if pbh == nil {
+		return false, errors.New("nil header")
+	}
+
+	txs := make([][]byte, len(block.Txs))
+	for i, tx := range block.Txs {
+		txs[i] = tx
+	}
+
+	res, err := blockExec.proxyApp.ProcessProposalSync(
+		ctx,
+		abci.RequestProcessProposal{
+			Txs:    txs,
+			Hash:   block.Hash(),
+			Header: *pbh,
+			LastCommitInfo: buildLastCommitInfo(
+				block.Height, block.LastCommit, state.LastValidators, state.InitialHeight),
+			ByzantineValidators: getByzantineValidators(block.Evidence.Evidence),
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+	if res.IsStatusUnknown() {
+		return false, errors.New("ProcessProposal responded with an unknown status")
+	}
+	return res.IsAccepted(), nil
 }
 
 // ValidateBlock validates the given block against the given state.
@@ -155,15 +272,51 @@ func (blockExec *BlockExecutor) ApplyBlock(
 	blockID types.BlockID,
 	block *types.Block,
 ) (State, error) {
+	return blockExec.ApplyBlockFrom(ctx, state, blockID, block, "")
+}
+
+// ApplyBlockFrom is like ApplyBlock, for a block received from the given
+// peer, which is recorded in the forensic dump of an app hash mismatch.
+func (blockExec *BlockExecutor) ApplyBlockFrom(
+	ctx context.Context,
+	state State,
+	blockID types.BlockID,
+	block *types.Block,
+	peerID types.NodeID,
+) (_ State, err error) {
+	ctx, span := tracer.Start(ctx, "state.ApplyBlock", tracing.Height(block.Height))
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
+	// refuse to execute blocks past the halt height or time
+	if blockExec.halt != nil {
+		if halted, err := blockExec.halt.Check(state); halted {
+			if err != nil {
+				blockExec.logger.Error("failed to halt", "err", err)
+			}
+			return state, ErrHalted
+		}
+	}
 
 	// validate the block if we haven't already
 	if err := blockExec.ValidateBlock(state, block); err != nil {
+		blockExec.ReportInvalidBlock(state, block, peerID, err)
 		return state, ErrInvalidBlock(err)
 	}
 
+	// record the execution for the forensic dump of an app hash mismatch of
+	// the next block
+	var transcript *ExecutionTranscript
+	if blockExec.forensics != nil {
+		stateBefore := state.Copy()
+		transcript = &ExecutionTranscript{Height: block.Height, StateBefore: &stateBefore}
+	}
+
 	startTime := time.Now().UnixNano()
 	abciResponses, err := execBlockOnProxyApp(ctx,
-		blockExec.logger, blockExec.proxyApp, block, blockExec.store, state.InitialHeight,
+		blockExec.logger, blockExec.proxyApp, block, blockExec.store, state.InitialHeight, transcript,
 	)
 	endTime := time.Now().UnixNano()
 	blockExec.metrics.BlockProcessingTime.Observe(float64(endTime-startTime) / 1000000)
@@ -173,10 +326,17 @@ func (blockExec *BlockExecutor) ApplyBlock(
 
 	fail.Fail() // XXX
 
-	// Save the results before we commit.
+	// Save the results before we commit. If the block store syncs
+	// asynchronously, the block must be made durable before any state
+	// referring to it is, so sync it alongside saving the results.
+	syncErr := make(chan error, 1)
+	go func() { syncErr <- blockExec.blockStore.Sync() }()
 	if err := blockExec.store.SaveABCIResponses(block.Height, abciResponses); err != nil {
 		return state, err
 	}
+	if err := <-syncErr; err != nil {
+		return state, err
+	}
 
 	fail.Fail() // XXX
 
@@ -196,10 +356,12 @@ func (blockExec *BlockExecutor) ApplyBlock(
 	}
 
 	// Update the state with the block and responses.
+	prevValidators := state.NextValidators
 	state, err = updateState(state, blockID, &block.Header, abciResponses, validatorUpdates)
 	if err != nil {
 		return state, fmt.Errorf("commit failed for application: %v", err)
 	}
+	valSetChange := types.NewValidatorSetChange(block.Height, prevValidators, state.NextValidators)
 
 	// Lock mempool, commit app state, update mempoool.
 	appHash, retainHeight, err := blockExec.Commit(ctx, state, block, abciResponses.DeliverTxs)
@@ -218,6 +380,13 @@ func (blockExec *BlockExecutor) ApplyBlock(
 		return state, err
 	}
 
+	if transcript != nil {
+		transcript.CommitResponse = &abci.ResponseCommit{Data: appHash, RetainHeight: retainHeight}
+		stateAfter := state.Copy()
+		transcript.StateAfter = &stateAfter
+		blockExec.lastExecution = transcript
+	}
+
 	fail.Fail() // XXX
 
 	// Prune old heights, if requested by ABCI app.
@@ -235,11 +404,69 @@ func (blockExec *BlockExecutor) ApplyBlock(
 
 	// Events are fired after everything else.
 	// NOTE: if we crash between Commit and Save, events wont be fired during replay
-	fireEvents(ctx, blockExec.logger, blockExec.eventBus, block, blockID, abciResponses, validatorUpdates)
+	fireEvents(ctx, blockExec.logger, blockExec.eventBus, block, blockID, abciResponses, validatorUpdates, valSetChange)
+
+	if blockExec.halt != nil {
+		halted, err := blockExec.halt.Check(state)
+		if err != nil {
+			blockExec.logger.Error("failed to halt", "err", err)
+		}
+		if halted {
+			blockExec.logger.Info("reached the halt height or time, halting",
+				"height", block.Height, "app_hash", fmt.Sprintf("%X", state.AppHash))
+		}
+	}
 
 	return state, nil
 }
 
+// ReportInvalidBlock writes a forensic dump of the app hash mismatch if err,
+// returned by ValidateBlock for a committed block received from the given
+// peer, if known, is an ErrAppHashMismatch. Other errors are ignored.
+func (blockExec *BlockExecutor) ReportInvalidBlock(state State, block *types.Block, peerID types.NodeID, err error) {
+	var mismatch ErrAppHashMismatch
+	if blockExec.forensics == nil || !errors.As(err, &mismatch) {
+		return
+	}
+
+	dump := &AppHashMismatch{
+		Height:          block.Height,
+		Time:            tmtime.Now(),
+		Peer:            peerID,
+		ExpectedAppHash: mismatch.Expected,
+		BlockAppHash:    mismatch.Got,
+		Block:           block,
+	}
+	if state.LastBlockHeight > 0 {
+		dump.LastBlock = blockExec.blockStore.LoadBlock(state.LastBlockHeight)
+	}
+	switch {
+	case blockExec.lastExecution != nil && blockExec.lastExecution.Height == state.LastBlockHeight:
+		dump.LastExecution = blockExec.lastExecution
+	case dump.LastBlock != nil:
+		// the last block was executed before the node started, so its
+		// execution is rebuilt from the stored responses
+		dump.LastExecution, err = loadExecutionTranscript(blockExec.store, state, dump.LastBlock)
+		if err != nil {
+			blockExec.logger.Error("failed to load the execution of the last block",
+				"height", state.LastBlockHeight, "err", err)
+		}
+	}
+
+	path, err := blockExec.forensics.Write(dump)
+	if err != nil {
+		blockExec.logger.Error("failed to write app hash mismatch dump", "height", block.Height, "err", err)
+		return
+	}
+	blockExec.logger.Error("app hash mismatch, wrote forensic dump",
+		"height", block.Height,
+		"expected_app_hash", fmt.Sprintf("%X", mismatch.Expected),
+		"block_app_hash", fmt.Sprintf("%X", mismatch.Got),
+		"peer", peerID,
+		"path", path,
+	)
+}
+
 // Commit locks the mempool, runs the ABCI Commit message, and updates the
 // mempool.
 // It returns the result of calling abci.Commit (the AppHash) and the height to retain (if any).
@@ -251,13 +478,19 @@ func (blockExec *BlockExecutor) Commit(
 	state State,
 	block *types.Block,
 	deliverTxResponses []*abci.ResponseDeliverTx,
-) ([]byte, int64, error) {
+) (_ []byte, _ int64, err error) {
+	ctx, span := tracer.Start(ctx, "state.Commit", tracing.Height(block.Height))
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
 	blockExec.mempool.Lock()
 	defer blockExec.mempool.Unlock()
 
 	// while mempool is Locked, flush to ensure all async requests have completed
 	// in the ABCI app before Commit.
-	err := blockExec.mempool.FlushAppConn(ctx)
+	err = blockExec.mempool.FlushAppConn(ctx)
 	if err != nil {
 		blockExec.logger.Error("client error during mempool.FlushAppConn", "err", err)
 		return nil, 0, err
@@ -278,6 +511,10 @@ func (blockExec *BlockExecutor) Commit(
 		"app_hash", fmt.Sprintf("%X", res.Data),
 	)
 
+	if blockExec.snapshotNotifier != nil && (res.SnapshotHeight > 0 || res.SnapshotRetainHeight > 0) {
+		blockExec.snapshotNotifier.NotifySnapshots(res.SnapshotHeight, res.SnapshotRetainHeight)
+	}
+
 	// Update mempool.
 	err = blockExec.mempool.Update(
 		ctx,
@@ -295,7 +532,8 @@ func (blockExec *BlockExecutor) Commit(
 // Helper functions for executing blocks and updating state
 
 // Executes block's transactions on proxyAppConn.
-// Returns a list of transaction results and updates to the validator set
+// Returns a list of transaction results and updates to the validator set.
+// The request and response are recorded in the transcript, if not nil.
 func execBlockOnProxyApp(
 	ctx context.Context,
 	logger log.Logger,
@@ -303,107 +541,147 @@ func execBlockOnProxyApp(
 	block *types.Block,
 	store Store,
 	initialHeight int64,
-) (*tmstate.ABCIResponses, error) {
-	var validTxs, invalidTxs = 0, 0
+	transcript *ExecutionTranscript,
+) (_ *tmstate.ABCIResponses, err error) {
+	ctx, span := tracer.Start(ctx, "state.ExecBlock",
+		tracing.Height(block.Height), trace.WithAttributes(attribute.Int("num_txs", len(block.Txs))))
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
+	req, err := finalizeBlockRequest(block, store, initialHeight)
+	if err != nil {
+		return nil, err
+	}
 
-	txIndex := 0
-	abciResponses := new(tmstate.ABCIResponses)
-	dtxs := make([]*abci.ResponseDeliverTx, len(block.Txs))
-	abciResponses.DeliverTxs = dtxs
-
-	// Execute transactions and get hash.
-	proxyCb := func(req *abci.Request, res *abci.Response) {
-		if r, ok := res.Value.(*abci.Response_DeliverTx); ok {
-			// TODO: make use of res.Log
-			// TODO: make use of this info
-			// Blocks may include invalid txs.
-			txRes := r.DeliverTx
-			if txRes.Code == abci.CodeTypeOK {
-				validTxs++
-			} else {
-				logger.Debug("invalid tx", "code", txRes.Code, "log", txRes.Log)
-				invalidTxs++
-			}
+	res, err := proxyAppConn.FinalizeBlockSync(ctx, *req)
+	if err != nil {
+		logger.Error("error in proxyAppConn.FinalizeBlock", "err", err)
+		return nil, err
+	}
+	if transcript != nil {
+		transcript.FinalizeBlockRequest = req
+		transcript.FinalizeBlockResponse = res
+	}
+	if len(res.Txs) != len(block.Txs) {
+		return nil, fmt.Errorf("expected %d tx results from FinalizeBlock, got %d", len(block.Txs), len(res.Txs))
+	}
 
-			abciResponses.DeliverTxs[txIndex] = txRes
-			txIndex++
+	var validTxs, invalidTxs = 0, 0
+	for _, txRes := range res.Txs {
+		// Blocks may include invalid txs.
+		if txRes.Code == abci.CodeTypeOK {
+			validTxs++
+		} else {
+			logger.Debug("invalid tx", "code", txRes.Code, "log", txRes.Log)
+			invalidTxs++
 		}
 	}
-	proxyAppConn.SetResponseCallback(proxyCb)
-
-	commitInfo := getBeginBlockValidatorInfo(block, store, initialHeight)
 
-	byzVals := make([]abci.Evidence, 0)
-	for _, evidence := range block.Evidence.Evidence {
-		byzVals = append(byzVals, evidence.ABCI()...)
+	// The responses are stored as those of BeginBlock, DeliverTx and EndBlock.
+	abciResponses := &tmstate.ABCIResponses{
+		DeliverTxs: res.Txs,
+		BeginBlock: &abci.ResponseBeginBlock{Events: res.BeginBlockEvents},
+		EndBlock: &abci.ResponseEndBlock{
+			ValidatorUpdates:      res.ValidatorUpdates,
+			ConsensusParamUpdates: res.ConsensusParamUpdates,
+			Events:                res.Events,
+		},
 	}
 
-	// Begin block
-	var err error
+	logger.Info("executed block", "height", block.Height, "num_valid_txs", validTxs, "num_invalid_txs", invalidTxs)
+	return abciResponses, nil
+}
+
+// finalizeBlockRequest returns the FinalizeBlock request executing the block.
+func finalizeBlockRequest(block *types.Block, store Store, initialHeight int64) (*abci.RequestFinalizeBlock, error) {
 	pbh := block.Header.ToProto()
 	if pbh == nil {
 		return nil, errors.New("nil header")
 	}
 
-	abciResponses.BeginBlock, err = proxyAppConn.BeginBlockSync(
-		ctx,
-		abci.RequestBeginBlock{
-			Hash:                block.Hash(),
-			Header:              *pbh,
-			LastCommitInfo:      commitInfo,
-			ByzantineValidators: byzVals,
-		},
-	)
-	if err != nil {
-		logger.Error("error in proxyAppConn.BeginBlock", "err", err)
-		return nil, err
+	txs := make([][]byte, len(block.Txs))
+	for i, tx := range block.Txs {
+		txs[i] = tx
 	}
 
-	// run txs of block
-	for _, tx := range block.Txs {
-		_, err = proxyAppConn.DeliverTxAsync(ctx, abci.RequestDeliverTx{Tx: tx})
-		if err != nil {
-			return nil, err
-		}
-	}
+	return &abci.RequestFinalizeBlock{
+		Txs:                 txs,
+		Hash:                block.Hash(),
+		Header:              *pbh,
+		LastCommitInfo:      getBeginBlockValidatorInfo(block, store, initialHeight),
+		ByzantineValidators: getByzantineValidators(block.Evidence.Evidence),
+	}, nil
+}
 
-	abciResponses.EndBlock, err = proxyAppConn.EndBlockSync(ctx, abci.RequestEndBlock{Height: block.Height})
+// loadExecutionTranscript rebuilds the execution of the last block of the
+// state from its stored responses. The state before the block is unknown.
+func loadExecutionTranscript(store Store, state State, block *types.Block) (*ExecutionTranscript, error) {
+	req, err := finalizeBlockRequest(block, store, state.InitialHeight)
+	if err != nil {
+		return nil, err
+	}
+	abciResponses, err := store.LoadABCIResponses(block.Height)
 	if err != nil {
-		logger.Error("error in proxyAppConn.EndBlock", "err", err)
 		return nil, err
 	}
 
-	logger.Info("executed block", "height", block.Height, "num_valid_txs", validTxs, "num_invalid_txs", invalidTxs)
-	return abciResponses, nil
+	res := &abci.ResponseFinalizeBlock{Txs: abciResponses.DeliverTxs}
+	if abciResponses.BeginBlock != nil {
+		res.BeginBlockEvents = abciResponses.BeginBlock.Events
+	}
+	if abciResponses.EndBlock != nil {
+		res.Events = abciResponses.EndBlock.Events
+		res.ValidatorUpdates = abciResponses.EndBlock.ValidatorUpdates
+		res.ConsensusParamUpdates = abciResponses.EndBlock.ConsensusParamUpdates
+	}
+	return &ExecutionTranscript{
+		Height:                block.Height,
+		FinalizeBlockRequest:  req,
+		FinalizeBlockResponse: res,
+		CommitResponse:        &abci.ResponseCommit{Data: state.AppHash},
+		StateAfter:            &state,
+	}, nil
 }
 
 func getBeginBlockValidatorInfo(block *types.Block, store Store,
 	initialHeight int64) abci.LastCommitInfo {
-	voteInfos := make([]abci.VoteInfo, block.LastCommit.Size())
-	// Initial block -> LastCommitInfo.Votes are empty.
-	// Remember that the first LastCommit is intentionally empty, so it makes
-	// sense for LastCommitInfo.Votes to also be empty.
+	var lastValSet *types.ValidatorSet
 	if block.Height > initialHeight {
-		lastValSet, err := store.LoadValidators(block.Height - 1)
+		var err error
+		lastValSet, err = store.LoadValidators(block.Height - 1)
 		if err != nil {
 			panic(err)
 		}
+	}
+	return buildLastCommitInfo(block.Height, block.LastCommit, lastValSet, initialHeight)
+}
 
+// buildLastCommitInfo returns which validators of lastValSet signed the last
+// commit of the block at height.
+func buildLastCommitInfo(height int64, lastCommit *types.Commit, lastValSet *types.ValidatorSet,
+	initialHeight int64) abci.LastCommitInfo {
+	voteInfos := make([]abci.VoteInfo, lastCommit.Size())
+	// Initial block -> LastCommitInfo.Votes are empty.
+	// Remember that the first LastCommit is intentionally empty, so it makes
+	// sense for LastCommitInfo.Votes to also be empty.
+	if height > initialHeight {
 		// Sanity check that commit size matches validator set size - only applies
 		// after first block.
 		var (
-			commitSize = block.LastCommit.Size()
+			commitSize = lastCommit.Size()
 			valSetLen  = len(lastValSet.Validators)
 		)
 		if commitSize != valSetLen {
 			panic(fmt.Sprintf(
 				"commit size (%d) doesn't match valset length (%d) at height %d\n\n%v\n\n%v",
-				commitSize, valSetLen, block.Height, block.LastCommit.Signatures, lastValSet.Validators,
+				commitSize, valSetLen, height, lastCommit.Signatures, lastValSet.Validators,
 			))
 		}
 
 		for i, val := range lastValSet.Validators {
-			commitSig := block.LastCommit.Signatures[i]
+			commitSig := lastCommit.Signatures[i]
 			voteInfos[i] = abci.VoteInfo{
 				Validator:       types.TM2PB.Validator(val),
 				SignedLastBlock: !commitSig.Absent(),
@@ -412,11 +690,21 @@ func getBeginBlockValidatorInfo(block *types.Block, store Store,
 	}
 
 	return abci.LastCommitInfo{
-		Round: block.LastCommit.Round,
+		Round: lastCommit.Round,
 		Votes: voteInfos,
 	}
 }
 
+// getByzantineValidators returns the misbehaviour of validators proven by
+// the evidence.
+func getByzantineValidators(evidence []types.Evidence) []abci.Evidence {
+	byzVals := make([]abci.Evidence, 0)
+	for _, ev := range evidence {
+		byzVals = append(byzVals, ev.ABCI()...)
+	}
+	return byzVals
+}
+
 func validateValidatorUpdates(abciUpdates []abci.ValidatorUpdate,
 	params types.ValidatorParams) error {
 	for _, valUpdate := range abciUpdates {
@@ -438,6 +726,57 @@ func validateValidatorUpdates(abciUpdates []abci.ValidatorUpdate,
 			return fmt.Errorf("validator %v is using pubkey %s, which is unsupported for consensus",
 				valUpdate, pk.Type())
 		}
+
+		if valUpdate.NewPubKey == nil {
+			continue
+		}
+		newPk, err := encoding.PubKeyFromProto(*valUpdate.NewPubKey)
+		if err != nil {
+			return err
+		}
+		if !params.IsValidPubkeyType(newPk.Type()) {
+			return fmt.Errorf("validator %v is rotating to pubkey %s, which is unsupported for consensus",
+				valUpdate, newPk.Type())
+		}
+	}
+	return nil
+}
+
+// validateKeyRotations checks that the validator updates rotating a key keep
+// the power of the validator, and that the rotated validators are not
+// otherwise updated.
+func validateKeyRotations(abciUpdates []abci.ValidatorUpdate, vals *types.ValidatorSet) error {
+	updated := make(map[string]bool, len(abciUpdates))
+	for _, valUpdate := range abciUpdates {
+		if valUpdate.NewPubKey != nil {
+			continue
+		}
+		pk, err := encoding.PubKeyFromProto(valUpdate.PubKey)
+		if err != nil {
+			return err
+		}
+		updated[string(pk.Address())] = true
+	}
+
+	for _, valUpdate := range abciUpdates {
+		if valUpdate.NewPubKey == nil {
+			continue
+		}
+		pk, err := encoding.PubKeyFromProto(valUpdate.PubKey)
+		if err != nil {
+			return err
+		}
+		newPk, err := encoding.PubKeyFromProto(*valUpdate.NewPubKey)
+		if err != nil {
+			return err
+		}
+		if updated[string(pk.Address())] || updated[string(newPk.Address())] {
+			return fmt.Errorf("validator %v is both updated and rotating its key", valUpdate)
+		}
+		if _, val := vals.GetByAddress(pk.Address()); val != nil && val.VotingPower != valUpdate.Power {
+			return fmt.Errorf("validator %v is rotating its key with power %d, but has power %d",
+				valUpdate, valUpdate.Power, val.VotingPower)
+		}
 	}
 	return nil
 }
@@ -457,6 +796,22 @@ func updateState(
 
 	// Update the validator set with the latest abciResponses.
 	lastHeightValsChanged := state.LastHeightValidatorsChanged
+	abciValUpdates := abciResponses.EndBlock.ValidatorUpdates
+	keyRotations, err := types.PB2TM.KeyRotations(abciValUpdates)
+	if err != nil {
+		return state, err
+	}
+	if len(keyRotations) > 0 {
+		if err := validateKeyRotations(abciValUpdates, nValSet); err != nil {
+			return state, fmt.Errorf("error rotating validator keys: %w", err)
+		}
+		// Like other changes, key rotations apply from the next next height,
+		// when the validator set changes.
+		if err := nValSet.RotateKeys(keyRotations); err != nil {
+			return state, fmt.Errorf("error rotating validator keys: %w", err)
+		}
+		lastHeightValsChanged = header.Height + 1 + 1
+	}
 	if len(validatorUpdates) > 0 {
 		err := nValSet.UpdateWithChangeSet(validatorUpdates)
 		if err != nil {
@@ -488,6 +843,13 @@ func updateState(
 
 	nextVersion := state.Version
 
+	// The events are only committed to if the parameters the block was
+	// executed with say so.
+	var lastEventsHash []byte
+	if state.ConsensusParams.ABCI.HashEvents {
+		lastEventsHash = ABCIResponsesEventsHash(abciResponses)
+	}
+
 	// NOTE: the AppHash has not been populated.
 	// It will be filled on state.Save.
 	return State{
@@ -504,12 +866,14 @@ func updateState(
 		ConsensusParams:                  nextParams,
 		LastHeightConsensusParamsChanged: lastHeightParamsChanged,
 		LastResultsHash:                  ABCIResponsesResultsHash(abciResponses),
+		LastEventsHash:                   lastEventsHash,
 		AppHash:                          nil,
 	}, nil
 }
 
 // Fire NewBlock, NewBlockHeader.
 // Fire TxEvent for every tx.
+// Fire ValidatorSetUpdates and ValidatorSetChange if the validator set changes.
 // NOTE: if Tendermint crashes before commit, some or all of these events may be published again.
 func fireEvents(
 	ctx context.Context,
@@ -519,6 +883,7 @@ func fireEvents(
 	blockID types.BlockID,
 	abciResponses *tmstate.ABCIResponses,
 	validatorUpdates []*types.Validator,
+	valSetChange types.ValidatorSetChange,
 ) {
 	if err := eventBus.PublishEventNewBlock(ctx, types.EventDataNewBlock{
 		Block:            block,
@@ -566,6 +931,13 @@ func fireEvents(
 			logger.Error("failed publishing event", "err", err)
 		}
 	}
+
+	if !valSetChange.IsEmpty() {
+		if err := eventBus.PublishEventValidatorSetChange(ctx,
+			types.EventDataValidatorSetChange{ValidatorSetChange: valSetChange}); err != nil {
+			logger.Error("failed publishing validator set change", "err", err)
+		}
+	}
 }
 
 //----------------------------------------------------------------------------------------------------
@@ -583,7 +955,7 @@ func ExecCommitBlock(
 	initialHeight int64,
 	s State,
 ) ([]byte, error) {
-	abciResponses, err := execBlockOnProxyApp(ctx, logger, appConnConsensus, block, store, initialHeight)
+	abciResponses, err := execBlockOnProxyApp(ctx, logger, appConnConsensus, block, store, initialHeight, nil)
 	if err != nil {
 		logger.Error("failed executing block on proxy app", "height", block.Height, "err", err)
 		return nil, err
@@ -603,8 +975,15 @@ func ExecCommitBlock(
 			return nil, err
 		}
 
-		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(types.BlockPartSizeBytes).Header()}
-		fireEvents(ctx, be.logger, be.eventBus, block, blockID, abciResponses, validatorUpdates)
+		// the state of the block is saved, so the changed validator set is
+		// loaded rather than updated again
+		valSetChange, err := loadValidatorSetChange(store, block.Height)
+		if err != nil {
+			logger.Error("failed to load validator set change", "height", block.Height, "err", err)
+		}
+
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSetWithParams(s.ConsensusParams.Block).Header()}
+		fireEvents(ctx, be.logger, be.eventBus, block, blockID, abciResponses, validatorUpdates, valSetChange)
 	}
 
 	// Commit block, get hash back
@@ -618,6 +997,20 @@ func ExecCommitBlock(
 	return res.Data, nil
 }
 
+// loadValidatorSetChange returns the change of the validator set made by the
+// validator updates returned at height, which is saved in the store.
+func loadValidatorSetChange(store Store, height int64) (types.ValidatorSetChange, error) {
+	prevValidators, err := store.LoadValidators(height + 1)
+	if err != nil {
+		return types.ValidatorSetChange{}, err
+	}
+	nextValidators, err := store.LoadValidators(height + 2)
+	if err != nil {
+		return types.ValidatorSetChange{}, err
+	}
+	return types.NewValidatorSetChange(height, prevValidators, nextValidators), nil
+}
+
 func (blockExec *BlockExecutor) pruneBlocks(retainHeight int64) (uint64, error) {
 	base := blockExec.blockStore.Base()
 	if retainHeight <= base {
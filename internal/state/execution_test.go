@@ -1,7 +1,10 @@
 package state_test
 
 import (
+	"bytes"
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -24,6 +27,7 @@ import (
 	"github.com/tendermint/tendermint/internal/state/mocks"
 	sf "github.com/tendermint/tendermint/internal/state/test/factory"
 	"github.com/tendermint/tendermint/internal/store"
+	tmjson "github.com/tendermint/tendermint/libs/json"
 	"github.com/tendermint/tendermint/libs/log"
 	tmtime "github.com/tendermint/tendermint/libs/time"
 	"github.com/tendermint/tendermint/types"
@@ -63,6 +67,193 @@ func TestApplyBlock(t *testing.T) {
 	assert.EqualValues(t, 1, state.Version.Consensus.App, "App version wasn't updated")
 }
 
+type blockEventsApp struct {
+	*testApp
+}
+
+func (app blockEventsApp) FinalizeBlock(req abci.RequestFinalizeBlock) abci.ResponseFinalizeBlock {
+	res := app.testApp.FinalizeBlock(req)
+	res.BeginBlockEvents = []abci.Event{{Type: "begin"}}
+	res.Events = []abci.Event{{Type: "end"}}
+	return res
+}
+
+// TestApplyBlockEvents ensures the events of the block emitted before and
+// after its transactions are stored separately.
+func TestApplyBlockEvents(t *testing.T) {
+	cc := abciclient.NewLocalCreator(blockEventsApp{&testApp{}})
+	logger := log.TestingLogger()
+	proxyApp := proxy.NewAppConns(cc, logger, proxy.NopMetrics())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, proxyApp.Start(ctx))
+
+	state, stateDB, _ := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB)
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	blockExec := sm.NewBlockExecutor(stateStore, logger, proxyApp.Consensus(),
+		mmock.Mempool{}, sm.EmptyEvidencePool{}, blockStore)
+
+	block := sf.MakeBlock(state, 1, new(types.Commit))
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+
+	_, err := blockExec.ApplyBlock(ctx, state, blockID, block)
+	require.NoError(t, err)
+
+	abciResponses, err := stateStore.LoadABCIResponses(block.Height)
+	require.NoError(t, err)
+	assert.Equal(t, []abci.Event{{Type: "begin"}}, abciResponses.BeginBlock.Events)
+	assert.Equal(t, []abci.Event{{Type: "end"}}, abciResponses.EndBlock.Events)
+}
+
+func TestApplyBlockHalt(t *testing.T) {
+	app := &testApp{}
+	cc := abciclient.NewLocalCreator(app)
+	logger := log.TestingLogger()
+	proxyApp := proxy.NewAppConns(cc, logger, proxy.NopMetrics())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, proxyApp.Start(ctx))
+
+	state, stateDB, _ := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB)
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	markerFile := filepath.Join(t.TempDir(), "halt.json")
+	halt := sm.NewHalt(1, time.Time{}, markerFile)
+	blockExec := sm.NewBlockExecutor(stateStore, logger, proxyApp.Consensus(),
+		mmock.Mempool{}, sm.EmptyEvidencePool{}, blockStore, sm.BlockExecutorWithHalt(halt))
+
+	block := sf.MakeBlock(state, 1, new(types.Commit))
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+
+	state, err := blockExec.ApplyBlock(ctx, state, blockID, block)
+	require.NoError(t, err)
+
+	// the block at the halt height is committed, and the node halts
+	select {
+	case <-halt.Done():
+	default:
+		t.Fatal("expected the node to halt")
+	}
+	bz, err := os.ReadFile(markerFile)
+	require.NoError(t, err)
+	var marker sm.HaltMarker
+	require.NoError(t, tmjson.Unmarshal(bz, &marker))
+	assert.Equal(t, int64(1), marker.Height)
+	assert.True(t, bytes.Equal(state.AppHash, marker.AppHash))
+	require.NotNil(t, halt.Marker())
+	assert.Equal(t, marker.Height, halt.Marker().Height)
+
+	// the next block is not executed
+	block = sf.MakeBlock(state, 2, new(types.Commit))
+	blockID = types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+	_, err = blockExec.ApplyBlock(ctx, state, blockID, block)
+	require.ErrorIs(t, err, sm.ErrHalted)
+}
+
+type snapshotNotifierFunc func(height, retainHeight uint64)
+
+func (f snapshotNotifierFunc) NotifySnapshots(height, retainHeight uint64) { f(height, retainHeight) }
+
+func TestApplyBlockSnapshotNotifier(t *testing.T) {
+	app := &testApp{SnapshotHeight: 1, SnapshotRetainHeight: 1}
+	cc := abciclient.NewLocalCreator(app)
+	logger := log.TestingLogger()
+	proxyApp := proxy.NewAppConns(cc, logger, proxy.NopMetrics())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, proxyApp.Start(ctx))
+
+	state, stateDB, _ := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB)
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	blockExec := sm.NewBlockExecutor(stateStore, logger, proxyApp.Consensus(),
+		mmock.Mempool{}, sm.EmptyEvidencePool{}, blockStore)
+
+	var notified [][2]uint64
+	blockExec.SetSnapshotNotifier(snapshotNotifierFunc(func(height, retainHeight uint64) {
+		notified = append(notified, [2]uint64{height, retainHeight})
+	}))
+
+	block := sf.MakeBlock(state, 1, new(types.Commit))
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+	_, err := blockExec.ApplyBlock(ctx, state, blockID, block)
+	require.NoError(t, err)
+	assert.Equal(t, [][2]uint64{{1, 1}}, notified)
+}
+
+func TestApplyBlockAppHashMismatch(t *testing.T) {
+	app := &testApp{}
+	cc := abciclient.NewLocalCreator(app)
+	logger := log.TestingLogger()
+	proxyApp := proxy.NewAppConns(cc, logger, proxy.NopMetrics())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, proxyApp.Start(ctx))
+
+	state, stateDB, _ := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB)
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	forensics := sm.NewForensics(filepath.Join(t.TempDir(), "forensics"))
+	blockExec := sm.NewBlockExecutor(stateStore, logger, proxyApp.Consensus(),
+		mmock.Mempool{}, sm.EmptyEvidencePool{}, blockStore, sm.BlockExecutorWithForensics(forensics))
+
+	block := sf.MakeBlock(state, 1, new(types.Commit))
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+	state, err := blockExec.ApplyBlock(ctx, state, blockID, block)
+	require.NoError(t, err)
+
+	// nothing is dumped until a block has an app hash mismatch
+	_, dumps, err := forensics.Load(0)
+	require.NoError(t, err)
+	require.Empty(t, dumps)
+
+	block = sf.MakeBlock(state, 2, new(types.Commit))
+	block.AppHash = []byte("wrong app hash")
+	blockID = types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+	peerID := types.NodeID("aa")
+	_, err = blockExec.ApplyBlockFrom(ctx, state, blockID, block, peerID)
+	var mismatch sm.ErrAppHashMismatch
+	require.ErrorAs(t, err, &mismatch)
+
+	height, dumps, err := forensics.Load(0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), height)
+	require.Len(t, dumps, 1)
+
+	var dump sm.AppHashMismatch
+	require.NoError(t, tmjson.Unmarshal(dumps[0], &dump))
+	assert.Equal(t, int64(2), dump.Height)
+	assert.Equal(t, peerID, dump.Peer)
+	assert.True(t, bytes.Equal(state.AppHash, dump.ExpectedAppHash))
+	assert.Equal(t, []byte("wrong app hash"), []byte(dump.BlockAppHash))
+	assert.Equal(t, block.Hash(), dump.Block.Hash())
+
+	// the execution of the previous block is recorded
+	require.NotNil(t, dump.LastExecution)
+	assert.Equal(t, int64(1), dump.LastExecution.Height)
+	require.NotNil(t, dump.LastExecution.FinalizeBlockRequest)
+	assert.Equal(t, int64(1), dump.LastExecution.FinalizeBlockRequest.Header.Height)
+	require.NotNil(t, dump.LastExecution.CommitResponse)
+	assert.True(t, bytes.Equal(state.AppHash, dump.LastExecution.CommitResponse.Data))
+	require.NotNil(t, dump.LastExecution.StateBefore)
+	assert.Equal(t, int64(0), dump.LastExecution.StateBefore.LastBlockHeight)
+	require.NotNil(t, dump.LastExecution.StateAfter)
+	assert.Equal(t, int64(1), dump.LastExecution.StateAfter.LastBlockHeight)
+
+	_, dumps, err = forensics.Load(1)
+	require.NoError(t, err)
+	require.Empty(t, dumps)
+}
+
 // TestBeginBlockValidators ensures we send absent validators list.
 func TestBeginBlockValidators(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
@@ -398,6 +589,11 @@ func TestEndBlockValidatorUpdates(t *testing.T) {
 	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
 
 	pubkey := ed25519.GenPrivKey().PubKey()
+	changeSub, err := eventBus.SubscribeWithArgs(context.Background(), pubsub.SubscribeArgs{
+		ClientID: "TestEndBlockValidatorUpdates",
+		Query:    types.EventQueryValidatorSetChangeFor(pubkey.Address()),
+	})
+	require.NoError(t, err)
 	pk, err := encoding.PubKeyToProto(pubkey)
 	require.NoError(t, err)
 	app.ValidatorUpdates = []abci.ValidatorUpdate{
@@ -425,6 +621,19 @@ func TestEndBlockValidatorUpdates(t *testing.T) {
 		assert.Equal(t, pubkey, event.ValidatorUpdates[0].PubKey)
 		assert.EqualValues(t, 10, event.ValidatorUpdates[0].VotingPower)
 	}
+
+	msg, err = changeSub.Next(ctx)
+	require.NoError(t, err)
+	change, ok := msg.Data().(types.EventDataValidatorSetChange)
+	require.True(t, ok, "Expected event of type EventDataValidatorSetChange, got %T", msg.Data())
+	assert.Equal(t, block.Height, change.ValidatorSetChange.Height)
+	assert.Equal(t, []types.ValidatorPowerChange{{
+		Address:     pubkey.Address(),
+		PubKey:      pubkey,
+		VotingPower: 10,
+	}}, change.ValidatorSetChange.Added)
+	assert.Empty(t, change.ValidatorSetChange.Updated)
+	assert.Empty(t, change.ValidatorSetChange.Removed)
 }
 
 // TestEndBlockValidatorUpdatesResultingInEmptySet checks that processing validator updates that
@@ -467,6 +676,99 @@ func TestEndBlockValidatorUpdatesResultingInEmptySet(t *testing.T) {
 	assert.NotEmpty(t, state.NextValidators.Validators)
 }
 
+func TestCreateProposalBlock(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	app := &proposalApp{}
+	cc := abciclient.NewLocalCreator(app)
+	proxyApp := proxy.NewAppConns(cc, log.TestingLogger(), proxy.NopMetrics())
+	require.NoError(t, proxyApp.Start(ctx))
+
+	state, stateDB, _ := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB)
+	mp := reapMempool{txs: types.Txs{types.Tx("a"), types.Tx("b"), types.Tx("c")}}
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		mp, sm.EmptyEvidencePool{}, store.NewBlockStore(dbm.NewMemDB()))
+
+	commit := types.NewCommit(0, 0, types.BlockID{}, nil)
+	proposerAddr := state.Validators.GetProposer().Address
+
+	// the application reorders the candidate txs and adds one
+	block, _, err := blockExec.CreateProposalBlock(ctx, 1, state, commit, proposerAddr)
+	require.NoError(t, err)
+	assert.Equal(t, types.Txs{types.Tx("c"), types.Tx("b"), types.Tx("a"), types.Tx("injected")}, block.Txs)
+	assert.Equal(t, [][]byte{[]byte("a"), []byte("b"), []byte("c")}, app.candidates)
+
+	// txs which do not fit in the block are refused
+	app.oversized = true
+	_, _, err = blockExec.CreateProposalBlock(ctx, 1, state, commit, proposerAddr)
+	require.Error(t, err)
+}
+
+func TestProcessProposal(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	app := &proposalApp{}
+	cc := abciclient.NewLocalCreator(app)
+	proxyApp := proxy.NewAppConns(cc, log.TestingLogger(), proxy.NopMetrics())
+	require.NoError(t, proxyApp.Start(ctx))
+
+	state, stateDB, _ := makeState(1, 1)
+	stateStore := sm.NewStore(stateDB)
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		mmock.Mempool{}, sm.EmptyEvidencePool{}, store.NewBlockStore(dbm.NewMemDB()))
+
+	block := sf.MakeBlock(state, 1, new(types.Commit))
+	accepted, err := blockExec.ProcessProposal(ctx, state, block)
+	require.NoError(t, err)
+	assert.True(t, accepted)
+
+	block.Txs = types.Txs{types.Tx("reject")}
+	accepted, err = blockExec.ProcessProposal(ctx, state, block)
+	require.NoError(t, err)
+	assert.False(t, accepted)
+}
+
+// proposalApp proposes the candidate txs in reverse order followed by an
+// injected tx, and rejects proposals with a "reject" tx.
+type proposalApp struct {
+	testApp
+
+	candidates [][]byte
+	oversized  bool
+}
+
+func (app *proposalApp) PrepareProposal(req abci.RequestPrepareProposal) abci.ResponsePrepareProposal {
+	app.candidates = req.Txs
+	if app.oversized {
+		return abci.ResponsePrepareProposal{Txs: [][]byte{make([]byte, req.MaxTxBytes+1)}}
+	}
+	txs := make([][]byte, 0, len(req.Txs)+1)
+	for i := len(req.Txs) - 1; i >= 0; i-- {
+		txs = append(txs, req.Txs[i])
+	}
+	return abci.ResponsePrepareProposal{Txs: append(txs, []byte("injected"))}
+}
+
+func (app *proposalApp) ProcessProposal(req abci.RequestProcessProposal) abci.ResponseProcessProposal {
+	for _, tx := range req.Txs {
+		if string(tx) == "reject" {
+			return abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_REJECT}
+		}
+	}
+	return abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_ACCEPT}
+}
+
+// reapMempool is a mempool reaping the given txs.
+type reapMempool struct {
+	mmock.Mempool
+	txs types.Txs
+}
+
+func (mp reapMempool) ReapMaxBytesMaxGas(_, _, _ int64) types.Txs { return mp.txs }
+
 func makeBlockID(hash []byte, partSetSize uint32, partSetHash []byte) types.BlockID {
 	var (
 		h   = make([]byte, tmhash.Size)
@@ -0,0 +1,97 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+// ExportState returns a genesis document for a new chain continuing from the
+// given height, e.g. to migrate a chain through a hard fork. The new chain
+// starts at height + 1 with the validators, consensus parameters and app hash
+// the chain had after committing height, and the time of the block at height.
+//
+// The application state is not included: the application is responsible for
+// exporting its own state into the document's app_state.
+func ExportState(bs BlockStore, ss Store, height int64) (*types.GenesisDoc, error) {
+	state, err := ss.Load()
+	if err != nil {
+		return nil, err
+	}
+	if state.IsEmpty() {
+		return nil, errors.New("no state found")
+	}
+	if height < state.InitialHeight || height > state.LastBlockHeight {
+		return nil, fmt.Errorf("height %d is not within the committed heights %d to %d",
+			height, state.InitialHeight, state.LastBlockHeight)
+	}
+
+	blockMeta := bs.LoadBlockMeta(height)
+	if blockMeta == nil {
+		return nil, fmt.Errorf("block at height %d not found", height)
+	}
+
+	// The app hash resulting from height is only recorded in the following
+	// block, or in the state if height is the last one.
+	var appHash []byte
+	if height == state.LastBlockHeight {
+		appHash = state.AppHash
+	} else {
+		nextMeta := bs.LoadBlockMeta(height + 1)
+		if nextMeta == nil {
+			return nil, fmt.Errorf("block at height %d not found", height+1)
+		}
+		appHash = nextMeta.Header.AppHash
+	}
+
+	vals, err := ss.LoadValidators(height + 1)
+	if err != nil {
+		return nil, err
+	}
+	params, err := ss.LoadConsensusParams(height + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	genVals := make([]types.GenesisValidator, len(vals.Validators))
+	for i, val := range vals.Validators {
+		genVals[i] = types.GenesisValidator{
+			Address: val.Address,
+			PubKey:  val.PubKey,
+			Power:   val.VotingPower,
+		}
+	}
+
+	return &types.GenesisDoc{
+		GenesisTime:     blockMeta.Header.Time,
+		ChainID:         state.ChainID,
+		InitialHeight:   height + 1,
+		ConsensusParams: &params,
+		Validators:      genVals,
+		AppHash:         appHash,
+	}, nil
+}
+
+// ImportState initializes an empty state store with the genesis state of
+// genDoc, e.g. as produced by ExportState, so that a node starts the new chain
+// from the document's initial height.
+func ImportState(ss Store, genDoc *types.GenesisDoc) (State, error) {
+	existing, err := ss.Load()
+	if err != nil {
+		return State{}, err
+	}
+	if !existing.IsEmpty() {
+		return State{}, fmt.Errorf("state store already contains state for height %d",
+			existing.LastBlockHeight)
+	}
+
+	state, err := MakeGenesisState(genDoc)
+	if err != nil {
+		return State{}, err
+	}
+	if err := ss.Save(state); err != nil {
+		return State{}, err
+	}
+	return state, nil
+}
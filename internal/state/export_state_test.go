@@ -0,0 +1,59 @@
+package state_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/internal/state/mocks"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestExportImportState(t *testing.T) {
+	const height int64 = 100
+	stateStore := setupStateStore(t, height)
+	initialState, err := stateStore.Load()
+	require.NoError(t, err)
+
+	blockTime := time.Now().UTC().Truncate(time.Second)
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("LoadBlockMeta", height).Return(&types.BlockMeta{
+		Header: types.Header{Height: height, Time: blockTime},
+	})
+
+	_, err = state.ExportState(blockStore, stateStore, height+1)
+	require.Error(t, err)
+
+	genDoc, err := state.ExportState(blockStore, stateStore, height)
+	require.NoError(t, err)
+	require.Equal(t, initialState.ChainID, genDoc.ChainID)
+	require.Equal(t, height+1, genDoc.InitialHeight)
+	require.Equal(t, blockTime, genDoc.GenesisTime)
+	require.EqualValues(t, initialState.AppHash, genDoc.AppHash)
+	require.Equal(t, initialState.ConsensusParams, *genDoc.ConsensusParams)
+	require.Equal(t, initialState.Validators.Hash(), genDoc.ValidatorHash())
+
+	// a new chain is initialized from the exported document
+	newStore := state.NewStore(dbm.NewMemDB())
+	newState, err := state.ImportState(newStore, genDoc)
+	require.NoError(t, err)
+	require.Equal(t, height+1, newState.InitialHeight)
+	require.EqualValues(t, initialState.AppHash, newState.AppHash)
+
+	loaded, err := newStore.Load()
+	require.NoError(t, err)
+	require.Equal(t, newState.InitialHeight, loaded.InitialHeight)
+	vals, err := newStore.LoadValidators(height + 1)
+	require.NoError(t, err)
+	require.Equal(t, initialState.Validators.Hash(), vals.Hash())
+
+	// the store can't be initialized twice
+	_, err = state.ImportState(newStore, genDoc)
+	require.Error(t, err)
+
+	_, err = state.ImportState(stateStore, genDoc)
+	require.Error(t, err)
+}
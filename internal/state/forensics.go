@@ -0,0 +1,151 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/internal/libs/tempfile"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	tmos "github.com/tendermint/tendermint/libs/os"
+	"github.com/tendermint/tendermint/types"
+)
+
+// the forensic dumps of app hash mismatches are written to files named
+// app-hash-mismatch-<height>-<unix nanoseconds>.json
+const (
+	appHashMismatchPrefix = "app-hash-mismatch-"
+	appHashMismatchSuffix = ".json"
+)
+
+// ExecutionTranscript records the execution of a block by the application:
+// the ABCI requests and responses, and the state before and after the block.
+type ExecutionTranscript struct {
+	Height                int64                       `json:"height"`
+	FinalizeBlockRequest  *abci.RequestFinalizeBlock  `json:"finalize_block_request"`
+	FinalizeBlockResponse *abci.ResponseFinalizeBlock `json:"finalize_block_response"`
+	CommitResponse        *abci.ResponseCommit        `json:"commit_response"`
+
+	// The state before the block is only known if the block was executed
+	// since the node started.
+	StateBefore *State `json:"state_before,omitempty"`
+	StateAfter  *State `json:"state_after"`
+}
+
+// AppHashMismatch is the forensic dump written when the app hash of a block
+// does not match the app hash the application returned for the previous block,
+// for the failure to be diagnosed post-mortem.
+type AppHashMismatch struct {
+	Height          int64            `json:"height"`
+	Time            time.Time        `json:"time"`           // when the mismatch was detected
+	Peer            types.NodeID     `json:"peer,omitempty"` // peer the block was received from, if known
+	ExpectedAppHash tmbytes.HexBytes `json:"expected_app_hash"`
+	BlockAppHash    tmbytes.HexBytes `json:"block_app_hash"`
+	Block           *types.Block     `json:"block"`
+
+	// The previous block, whose execution returned the expected app hash, and
+	// its execution, if they can be loaded.
+	LastBlock     *types.Block         `json:"last_block,omitempty"`
+	LastExecution *ExecutionTranscript `json:"last_execution,omitempty"`
+}
+
+// Forensics writes forensic dumps of app hash mismatches to a directory.
+type Forensics struct {
+	dir string
+}
+
+// NewForensics returns a Forensics writing dumps to the given directory, which
+// is created when the first dump is written.
+func NewForensics(dir string) *Forensics {
+	return &Forensics{dir: dir}
+}
+
+// Dir returns the directory the dumps are written to.
+func (f *Forensics) Dir() string { return f.dir }
+
+// Write writes the dump of an app hash mismatch, and returns the path of the
+// file it was written to.
+func (f *Forensics) Write(dump *AppHashMismatch) (string, error) {
+	if err := tmos.EnsureDir(f.dir, 0700); err != nil {
+		return "", err
+	}
+	bz, err := tmjson.MarshalIndent(dump, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(f.dir, fmt.Sprintf("%s%d-%d%s",
+		appHashMismatchPrefix, dump.Height, dump.Time.UnixNano(), appHashMismatchSuffix))
+	if err := tempfile.WriteFileAtomic(path, bz, 0600); err != nil {
+		return "", fmt.Errorf("failed to write app hash mismatch dump: %w", err)
+	}
+	return path, nil
+}
+
+// Load returns the dumps of the app hash mismatches at the given height, or at
+// the greatest height with dumps if height is 0, oldest first, and the height.
+// It returns no dumps if there are none at the height.
+func (f *Forensics) Load(height int64) (int64, []json.RawMessage, error) {
+	entries, err := os.ReadDir(f.dir)
+	if os.IsNotExist(err) {
+		return height, nil, nil
+	} else if err != nil {
+		return 0, nil, err
+	}
+
+	type dumpFile struct {
+		name   string
+		height int64
+		time   int64
+	}
+	var files []dumpFile
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, appHashMismatchPrefix) ||
+			!strings.HasSuffix(name, appHashMismatchSuffix) {
+			continue
+		}
+		parts := strings.Split(strings.TrimSuffix(
+			strings.TrimPrefix(name, appHashMismatchPrefix), appHashMismatchSuffix), "-")
+		if len(parts) != 2 {
+			continue
+		}
+		h, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		t, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		files = append(files, dumpFile{name: name, height: h, time: t})
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].height != files[j].height {
+			return files[i].height < files[j].height
+		}
+		return files[i].time < files[j].time
+	})
+
+	if height == 0 && len(files) > 0 {
+		height = files[len(files)-1].height
+	}
+	var dumps []json.RawMessage
+	for _, file := range files {
+		if file.height != height {
+			continue
+		}
+		bz, err := os.ReadFile(filepath.Join(f.dir, file.name))
+		if err != nil {
+			return 0, nil, err
+		}
+		dumps = append(dumps, json.RawMessage(bz))
+	}
+	return height, dumps, nil
+}
@@ -0,0 +1,108 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/internal/libs/tempfile"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+)
+
+// ErrHalted is returned when asked to execute a block after the node halted at
+// its halt height or time.
+var ErrHalted = errors.New("the node halted at the configured halt height or time")
+
+// HaltMarker is written to the halt marker file when the node halts, so that
+// upgrade tooling can check the state the node halted at.
+type HaltMarker struct {
+	ChainID string           `json:"chain_id"`
+	Height  int64            `json:"height"`
+	Time    time.Time        `json:"time"`
+	AppHash tmbytes.HexBytes `json:"app_hash"`
+}
+
+// Halt stops block execution once the block at the halt height, or the first
+// block with a time at or after the halt time, has been committed. Blocks are
+// not executed past that point for as long as the halt is configured, so that
+// all nodes of a chain can be upgraded at the same height.
+type Halt struct {
+	height     int64     // 0 if unset
+	time       time.Time // zero if unset
+	markerFile string
+
+	mtx    sync.Mutex
+	marker *HaltMarker // the state the node halted at, nil until halted
+	done   chan struct{}
+}
+
+// NewHalt returns a Halt at the given height and time, either of which may be
+// unset, writing the halt marker to markerFile.
+func NewHalt(height int64, haltTime time.Time, markerFile string) *Halt {
+	return &Halt{
+		height:     height,
+		time:       haltTime,
+		markerFile: markerFile,
+		done:       make(chan struct{}),
+	}
+}
+
+// Height returns the halt height, or 0 if unset.
+func (h *Halt) Height() int64 { return h.height }
+
+// Time returns the halt time, or the zero time if unset.
+func (h *Halt) Time() time.Time { return h.time }
+
+// Done returns a channel which is closed once the node has halted.
+func (h *Halt) Done() <-chan struct{} { return h.done }
+
+// Marker returns the state the node halted at, or nil if it has not halted.
+func (h *Halt) Marker() *HaltMarker {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	return h.marker
+}
+
+// reached returns whether the block at the given height and time reaches the
+// halt height or time.
+func (h *Halt) reached(height int64, blockTime time.Time) bool {
+	if height <= 0 {
+		return false
+	}
+	return (h.height > 0 && height >= h.height) || (!h.time.IsZero() && !blockTime.Before(h.time))
+}
+
+// Check halts the node if the last block of the given state reaches the halt
+// height or time, and returns whether the node halted.
+func (h *Halt) Check(state State) (bool, error) {
+	if !h.reached(state.LastBlockHeight, state.LastBlockTime) {
+		return false, nil
+	}
+
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	if h.marker != nil {
+		return true, nil
+	}
+
+	h.marker = &HaltMarker{
+		ChainID: state.ChainID,
+		Height:  state.LastBlockHeight,
+		Time:    state.LastBlockTime,
+		AppHash: state.AppHash,
+	}
+	close(h.done)
+
+	// The node halts even if the marker can't be written.
+	bz, err := tmjson.MarshalIndent(h.marker, "", "  ")
+	if err != nil {
+		return true, err
+	}
+	if err := tempfile.WriteFileAtomic(h.markerFile, bz, 0644); err != nil {
+		return true, fmt.Errorf("failed to write halt marker file: %w", err)
+	}
+	return true, nil
+}
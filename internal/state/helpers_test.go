@@ -278,6 +278,9 @@ type testApp struct {
 	CommitVotes         []abci.VoteInfo
 	ByzantineValidators []abci.Evidence
 	ValidatorUpdates    []abci.ValidatorUpdate
+
+	SnapshotHeight       uint64
+	SnapshotRetainHeight uint64
 }
 
 var _ abci.Application = (*testApp)(nil)
@@ -286,30 +289,32 @@ func (app *testApp) Info(req abci.RequestInfo) (resInfo abci.ResponseInfo) {
 	return abci.ResponseInfo{}
 }
 
-func (app *testApp) BeginBlock(req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+func (app *testApp) FinalizeBlock(req abci.RequestFinalizeBlock) abci.ResponseFinalizeBlock {
 	app.CommitVotes = req.LastCommitInfo.Votes
 	app.ByzantineValidators = req.ByzantineValidators
-	return abci.ResponseBeginBlock{}
-}
 
-func (app *testApp) EndBlock(req abci.RequestEndBlock) abci.ResponseEndBlock {
-	return abci.ResponseEndBlock{
+	txs := make([]*abci.ResponseDeliverTx, len(req.Txs))
+	for i := range req.Txs {
+		txs[i] = &abci.ResponseDeliverTx{Events: []abci.Event{}}
+	}
+	return abci.ResponseFinalizeBlock{
+		Txs:              txs,
 		ValidatorUpdates: app.ValidatorUpdates,
 		ConsensusParamUpdates: &tmproto.ConsensusParams{
 			Version: &tmproto.VersionParams{
 				AppVersion: 1}}}
 }
 
-func (app *testApp) DeliverTx(req abci.RequestDeliverTx) abci.ResponseDeliverTx {
-	return abci.ResponseDeliverTx{Events: []abci.Event{}}
-}
-
 func (app *testApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
 	return abci.ResponseCheckTx{}
 }
 
 func (app *testApp) Commit() abci.ResponseCommit {
-	return abci.ResponseCommit{RetainHeight: 1}
+	return abci.ResponseCommit{
+		RetainHeight:         1,
+		SnapshotHeight:       app.SnapshotHeight,
+		SnapshotRetainHeight: app.SnapshotRetainHeight,
+	}
 }
 
 func (app *testApp) Query(reqQuery abci.RequestQuery) (resQuery abci.ResponseQuery) {
@@ -5,10 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"sort"
-	"strconv"
 	"strings"
 
-	"github.com/google/orderedcode"
 	dbm "github.com/tendermint/tm-db"
 
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -48,8 +46,13 @@ func (idx *BlockerIndexer) Has(height int64) (bool, error) {
 // The following is indexed:
 //
 // primary key: encode(block.height | height) => encode(height)
-// BeginBlock events: encode(eventType.eventAttr|eventValue|height|begin_block) => encode(height)
-// EndBlock events: encode(eventType.eventAttr|eventValue|height|end_block) => encode(height)
+// BeginBlock events: encode(block_events|eventType.eventAttr|eventValue|height|begin_block|eventSeq) => encode(height)
+// EndBlock events: encode(block_events|eventType.eventAttr|eventValue|height|end_block|eventSeq) => encode(height)
+//
+// where eventSeq is the position of the event within the BeginBlock or EndBlock
+// events, so that attributes of the same event can be matched together. The
+// events of FinalizeBlock are recorded as those of EndBlock. Event keys are
+// scoped under block_events, apart from the event keys of transactions.
 func (idx *BlockerIndexer) Index(bh types.EventDataNewBlockHeader) error {
 	batch := idx.store.NewBatch()
 	defer batch.Close()
@@ -80,10 +83,14 @@ func (idx *BlockerIndexer) Index(bh types.EventDataNewBlockHeader) error {
 
 // Search performs a query for block heights that match a given BeginBlock
 // and Endblock event search criteria. The given query can match against zero,
-// one or more block heights. In the case of height queries, i.e. block.height=H,
-// if the height is indexed, that height alone will be returned. An error and
-// nil slice is returned. Otherwise, a non-nil slice and nil error is returned.
-func (idx *BlockerIndexer) Search(ctx context.Context, q *query.Query) ([]int64, error) {
+// one or more block heights. Each clause of the query is evaluated separately
+// and the union of the matching heights is returned. In the case of height
+// queries, i.e. block.height=H, if the height is indexed, that height alone
+// will be returned for the clause. If matchEvents is true, the event
+// conditions of a clause must all be satisfied by attributes of a single
+// event, rather than by any events of the block. An error and nil slice is
+// returned. Otherwise, a non-nil slice and nil error is returned.
+func (idx *BlockerIndexer) Search(ctx context.Context, q *query.Query, matchEvents bool) ([]int64, error) {
 	results := make([]int64, 0)
 	select {
 	case <-ctx.Done():
@@ -92,7 +99,53 @@ func (idx *BlockerIndexer) Search(ctx context.Context, q *query.Query) ([]int64,
 	default:
 	}
 
-	conditions := q.Syntax()
+	matchedHeights := make(map[string][]byte)
+	for _, clause := range q.Syntax() {
+		search := idx.searchClause
+		if matchEvents {
+			search = idx.searchClauseByEvent
+		}
+		heights, err := search(ctx, clause)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range heights {
+			matchedHeights[k] = v
+		}
+	}
+
+	// fetch matching heights
+	results = make([]int64, 0, len(matchedHeights))
+heights:
+	for _, hBz := range matchedHeights {
+		h := int64FromBytes(hBz)
+
+		ok, err := idx.Has(h)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			results = append(results, h)
+		}
+
+		select {
+		case <-ctx.Done():
+			break heights
+
+		default:
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool { return results[i] < results[j] })
+
+	return results, nil
+}
+
+// searchClause returns the encoded heights of all blocks matching every
+// condition of the given clause.
+func (idx *BlockerIndexer) searchClause(ctx context.Context, conditions syntax.Clause) (map[string][]byte, error) {
+	var heightsInitialized bool
+	filteredHeights := make(map[string][]byte)
 
 	// If there is an exact height query, return the result immediately
 	// (if it exists).
@@ -104,15 +157,12 @@ func (idx *BlockerIndexer) Search(ctx context.Context, q *query.Query) ([]int64,
 		}
 
 		if ok {
-			return []int64{height}, nil
+			filteredHeights[string(int64ToBytes(height))] = int64ToBytes(height)
 		}
 
-		return results, nil
+		return filteredHeights, nil
 	}
 
-	var heightsInitialized bool
-	filteredHeights := make(map[string][]byte)
-
 	// conditions to skip because they're handled before "everything else"
 	skipIndexes := make([]int, 0)
 
@@ -123,13 +173,13 @@ func (idx *BlockerIndexer) Search(ctx context.Context, q *query.Query) ([]int64,
 		skipIndexes = append(skipIndexes, rangeIndexes...)
 
 		for _, qr := range ranges {
-			prefix, err := orderedcode.Append(nil, qr.Key)
+			prefix, err := tagPrefix(qr.Key)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create prefix key: %w", err)
 			}
 
 			if !heightsInitialized {
-				filteredHeights, err = idx.matchRange(ctx, qr, prefix, filteredHeights, true)
+				filteredHeights, err = idx.matchRange(ctx, qr, prefix, filteredHeights, true, false)
 				if err != nil {
 					return nil, err
 				}
@@ -142,7 +192,7 @@ func (idx *BlockerIndexer) Search(ctx context.Context, q *query.Query) ([]int64,
 					break
 				}
 			} else {
-				filteredHeights, err = idx.matchRange(ctx, qr, prefix, filteredHeights, false)
+				filteredHeights, err = idx.matchRange(ctx, qr, prefix, filteredHeights, false, false)
 				if err != nil {
 					return nil, err
 				}
@@ -151,18 +201,23 @@ func (idx *BlockerIndexer) Search(ctx context.Context, q *query.Query) ([]int64,
 	}
 
 	// for all other conditions
+	var negated []syntax.Condition
 	for i, c := range conditions {
 		if intInSlice(i, skipIndexes) {
 			continue
 		}
+		if c.Not {
+			negated = append(negated, c)
+			continue
+		}
 
-		startKey, err := orderedcode.Append(nil, c.Tag, c.Arg.Value())
+		startKey, err := tagPrefix(c.Tag, c.Arg.Value())
 		if err != nil {
 			return nil, err
 		}
 
 		if !heightsInitialized {
-			filteredHeights, err = idx.match(ctx, c, startKey, filteredHeights, true)
+			filteredHeights, err = idx.match(ctx, c, startKey, filteredHeights, true, false)
 			if err != nil {
 				return nil, err
 			}
@@ -175,43 +230,140 @@ func (idx *BlockerIndexer) Search(ctx context.Context, q *query.Query) ([]int64,
 				break
 			}
 		} else {
-			filteredHeights, err = idx.match(ctx, c, startKey, filteredHeights, false)
+			filteredHeights, err = idx.match(ctx, c, startKey, filteredHeights, false, false)
 			if err != nil {
 				return nil, err
 			}
 		}
 	}
 
-	// fetch matching heights
-	results = make([]int64, 0, len(filteredHeights))
-heights:
-	for _, hBz := range filteredHeights {
-		h := int64FromBytes(hBz)
+	if len(negated) == 0 {
+		return filteredHeights, nil
+	}
 
-		ok, err := idx.Has(h)
+	// A clause consisting only of negated conditions selects from the set of
+	// all indexed blocks.
+	if !heightsInitialized {
+		var err error
+		filteredHeights, err = idx.match(ctx, syntax.Condition{Tag: types.BlockHeightKey, Op: syntax.TExists},
+			nil, filteredHeights, true, false)
 		if err != nil {
 			return nil, err
 		}
-		if ok {
-			results = append(results, h)
+	}
+	for _, c := range negated {
+		if len(filteredHeights) == 0 {
+			break
+		}
+		matched, err := idx.matchCondition(ctx, c, false)
+		if err != nil {
+			return nil, err
 		}
+		for k := range matched {
+			delete(filteredHeights, k)
+		}
+	}
 
-		select {
-		case <-ctx.Done():
-			break heights
+	return filteredHeights, nil
+}
 
-		default:
+// searchClauseByEvent returns the encoded heights of all blocks matching every
+// condition of the given clause, where the event conditions of the clause must
+// all be satisfied by the same event. Conditions on the block height and
+// negated conditions apply to the block as a whole.
+func (idx *BlockerIndexer) searchClauseByEvent(ctx context.Context, conditions syntax.Clause) (map[string][]byte, error) {
+	var blockConditions, eventConditions syntax.Clause
+	for _, c := range conditions {
+		if c.Tag == types.BlockHeightKey || c.Not {
+			blockConditions = append(blockConditions, c)
+		} else {
+			eventConditions = append(eventConditions, c)
 		}
 	}
 
-	sort.Slice(results, func(i, j int) bool { return results[i] < results[j] })
+	// A single event condition is trivially satisfied by a single event.
+	if len(eventConditions) < 2 {
+		return idx.searchClause(ctx, conditions)
+	}
 
-	return results, nil
+	var matchedEvents map[string][]byte
+	for i, c := range eventConditions {
+		matched, err := idx.matchCondition(ctx, c, true)
+		if err != nil {
+			return nil, err
+		}
+
+		if i == 0 {
+			matchedEvents = matched
+		} else {
+			for k := range matchedEvents {
+				if matched[k] == nil {
+					delete(matchedEvents, k)
+				}
+			}
+		}
+
+		if len(matchedEvents) == 0 {
+			break
+		}
+	}
+
+	filteredHeights := make(map[string][]byte, len(matchedEvents))
+	for _, hBz := range matchedEvents {
+		filteredHeights[string(hBz)] = hBz
+	}
+	if len(blockConditions) == 0 || len(filteredHeights) == 0 {
+		return filteredHeights, nil
+	}
+
+	matchedHeights, err := idx.searchClause(ctx, blockConditions)
+	if err != nil {
+		return nil, err
+	}
+	for k := range filteredHeights {
+		if matchedHeights[k] == nil {
+			delete(filteredHeights, k)
+		}
+	}
+
+	return filteredHeights, nil
+}
+
+// matchCondition returns all block heights that satisfy c without regard to
+// its negation. It is used to evaluate negated conditions by subtraction. If
+// byEvent is true, the matches are keyed by event rather than by height.
+func (idx *BlockerIndexer) matchCondition(ctx context.Context, c syntax.Condition, byEvent bool) (map[string][]byte, error) {
+	c.Not = false
+	if indexer.IsRangeOperation(c.Op) {
+		ranges, _ := indexer.LookForRanges([]syntax.Condition{c})
+		qr := ranges[c.Tag]
+		prefix, err := tagPrefix(qr.Key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create prefix key: %w", err)
+		}
+		return idx.matchRange(ctx, qr, prefix, make(map[string][]byte), true, byEvent)
+	}
+
+	if c.Tag == types.BlockHeightKey && c.Op == syntax.TEq {
+		height := int64(c.Arg.Number())
+		ok, err := idx.Has(height)
+		if err != nil || !ok {
+			return nil, err
+		}
+		return map[string][]byte{string(int64ToBytes(height)): int64ToBytes(height)}, nil
+	}
+
+	startKey, err := tagPrefix(c.Tag, c.Arg.Value())
+	if err != nil {
+		return nil, err
+	}
+	return idx.match(ctx, c, startKey, make(map[string][]byte), true, byEvent)
 }
 
 // matchRange returns all matching block heights that match a given QueryRange
 // and start key. An already filtered result (filteredHeights) is provided such
-// that any non-intersecting matches are removed.
+// that any non-intersecting matches are removed. If byEvent is true, the
+// matches are keyed by event rather than by height.
 //
 // NOTE: The provided filteredHeights may be empty if no previous condition has
 // matched.
@@ -221,6 +373,7 @@ func (idx *BlockerIndexer) matchRange(
 	startKey []byte,
 	filteredHeights map[string][]byte,
 	firstRun bool,
+	byEvent bool,
 ) (map[string][]byte, error) {
 
 	// A previous match was attempted but resulted in no matches, so we return
@@ -230,8 +383,6 @@ func (idx *BlockerIndexer) matchRange(
 	}
 
 	tmpHeights := make(map[string][]byte)
-	lowerBound := qr.LowerBoundValue()
-	upperBound := qr.UpperBoundValue()
 
 	it, err := dbm.IteratePrefix(idx.store, startKey)
 	if err != nil {
@@ -256,24 +407,12 @@ iter:
 			continue
 		}
 
-		if _, ok := qr.AnyBound().(int64); ok {
-			v, err := strconv.ParseInt(eventValue, 10, 64)
+		if qr.IsNumeric() && qr.MatchNumber(eventValue) {
+			k, err := matchKey(it, byEvent)
 			if err != nil {
-				continue iter
-			}
-
-			include := true
-			if lowerBound != nil && v < lowerBound.(int64) {
-				include = false
-			}
-
-			if upperBound != nil && v > upperBound.(int64) {
-				include = false
-			}
-
-			if include {
-				tmpHeights[string(it.Value())] = it.Value()
+				continue
 			}
+			tmpHeights[k] = it.Value()
 		}
 
 		select {
@@ -319,7 +458,8 @@ iter:
 
 // match returns all matching heights that meet a given query condition and start
 // key. An already filtered result (filteredHeights) is provided such that any
-// non-intersecting matches are removed.
+// non-intersecting matches are removed. If byEvent is true, the matches are
+// keyed by event rather than by height.
 //
 // NOTE: The provided filteredHeights may be empty if no previous condition has
 // matched.
@@ -329,6 +469,7 @@ func (idx *BlockerIndexer) match(
 	startKeyBz []byte,
 	filteredHeights map[string][]byte,
 	firstRun bool,
+	byEvent bool,
 ) (map[string][]byte, error) {
 
 	// A previous match was attempted but resulted in no matches, so we return
@@ -348,7 +489,11 @@ func (idx *BlockerIndexer) match(
 		defer it.Close()
 
 		for ; it.Valid(); it.Next() {
-			tmpHeights[string(it.Value())] = it.Value()
+			k, err := matchKey(it, byEvent)
+			if err != nil {
+				continue
+			}
+			tmpHeights[k] = it.Value()
 
 			if err := ctx.Err(); err != nil {
 				break
@@ -360,7 +505,7 @@ func (idx *BlockerIndexer) match(
 		}
 
 	case c.Op == syntax.TExists:
-		prefix, err := orderedcode.Append(nil, c.Tag)
+		prefix, err := tagPrefix(c.Tag)
 		if err != nil {
 			return nil, err
 		}
@@ -373,7 +518,11 @@ func (idx *BlockerIndexer) match(
 
 	iterExists:
 		for ; it.Valid(); it.Next() {
-			tmpHeights[string(it.Value())] = it.Value()
+			k, err := matchKey(it, byEvent)
+			if err != nil {
+				continue
+			}
+			tmpHeights[k] = it.Value()
 
 			select {
 			case <-ctx.Done():
@@ -388,7 +537,7 @@ func (idx *BlockerIndexer) match(
 		}
 
 	case c.Op == syntax.TContains:
-		prefix, err := orderedcode.Append(nil, c.Tag)
+		prefix, err := tagPrefix(c.Tag)
 		if err != nil {
 			return nil, err
 		}
@@ -407,7 +556,11 @@ func (idx *BlockerIndexer) match(
 			}
 
 			if strings.Contains(eventValue, c.Arg.Value()) {
-				tmpHeights[string(it.Value())] = it.Value()
+				k, err := matchKey(it, byEvent)
+				if err != nil {
+					continue
+				}
+				tmpHeights[k] = it.Value()
 			}
 
 			select {
@@ -421,6 +574,49 @@ func (idx *BlockerIndexer) match(
 			return nil, err
 		}
 
+	case c.Op == syntax.TLike:
+		// Only keys whose value begins with the literal prefix of the pattern
+		// can match, so restrict the scan to that portion of the index.
+		pattern := c.Arg.Value()
+		prefix, err := tagPrefix(c.Tag, syntax.LikePrefix(pattern))
+		if err != nil {
+			return nil, err
+		}
+
+		// Drop the string terminator so that the prefix matches any value
+		// beginning with the literal prefix.
+		it, err := dbm.IteratePrefix(idx.store, prefix[:len(prefix)-2])
+		if err != nil {
+			return nil, fmt.Errorf("failed to create prefix iterator: %w", err)
+		}
+		defer it.Close()
+
+	iterLike:
+		for ; it.Valid(); it.Next() {
+			eventValue, err := parseValueFromEventKey(it.Key())
+			if err != nil {
+				continue
+			}
+
+			if syntax.MatchLike(pattern, eventValue) {
+				k, err := matchKey(it, byEvent)
+				if err != nil {
+					continue
+				}
+				tmpHeights[k] = it.Value()
+			}
+
+			select {
+			case <-ctx.Done():
+				break iterLike
+
+			default:
+			}
+		}
+		if err := it.Error(); err != nil {
+			return nil, err
+		}
+
 	default:
 		return nil, errors.New("other operators should be handled already")
 	}
@@ -457,7 +653,7 @@ func (idx *BlockerIndexer) match(
 func (idx *BlockerIndexer) indexEvents(batch dbm.Batch, events []abci.Event, typ string, height int64) error {
 	heightBz := int64ToBytes(height)
 
-	for _, event := range events {
+	for i, event := range events {
 		// only index events with a non-empty type
 		if len(event.Type) == 0 {
 			continue
@@ -475,7 +671,7 @@ func (idx *BlockerIndexer) indexEvents(batch dbm.Batch, events []abci.Event, typ
 			}
 
 			if attr.GetIndex() {
-				key, err := eventKey(compositeKey, typ, attr.Value, height)
+				key, err := eventKey(compositeKey, typ, attr.Value, height, int64(i))
 				if err != nil {
 					return fmt.Errorf("failed to create block index key: %w", err)
 				}
@@ -489,3 +685,15 @@ func (idx *BlockerIndexer) indexEvents(batch dbm.Batch, events []abci.Event, typ
 
 	return nil
 }
+
+// matchKey returns the key under which a match of the index entry at the
+// iterator's position is recorded: the encoded block height, or the event the
+// entry belongs to if byEvent is true.
+func matchKey(it dbm.Iterator, byEvent bool) (string, error) {
+	if !byEvent {
+		return string(it.Value()), nil
+	}
+
+	_, eventID, err := parseEventKey(it.Key())
+	return eventID, err
+}
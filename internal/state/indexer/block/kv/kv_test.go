@@ -2,9 +2,11 @@ package kv_test
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
 	"testing"
 
+	"github.com/google/orderedcode"
 	"github.com/stretchr/testify/require"
 	dbm "github.com/tendermint/tm-db"
 
@@ -129,14 +131,166 @@ func TestBlockIndexer(t *testing.T) {
 			q:       query.MustCompile(`begin_event.proposer CONTAINS 'FCAA001'`),
 			results: []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11},
 		},
+		"block.height = 3 OR end_event.foo >= 100": {
+			q:       query.MustCompile(`block.height = 3 OR end_event.foo >= 100`),
+			results: []int64{1, 3},
+		},
+		"block.height < 5 AND NOT end_event.foo EXISTS": {
+			q:       query.MustCompile(`block.height < 5 AND NOT end_event.foo EXISTS`),
+			results: []int64{3},
+		},
+		"NOT block.height <= 9": {
+			q:       query.MustCompile(`NOT block.height <= 9`),
+			results: []int64{10, 11},
+		},
+		"begin_event.proposer LIKE 'FC%01'": {
+			q:       query.MustCompile(`begin_event.proposer LIKE 'FC%01'`),
+			results: []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11},
+		},
+		"begin_event.proposer LIKE 'FF%'": {
+			q:       query.MustCompile(`begin_event.proposer LIKE 'FF%'`),
+			results: []int64{},
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			results, err := indexer.Search(context.Background(), tc.q, false)
+			require.NoError(t, err)
+			require.Equal(t, tc.results, results)
+		})
+	}
+}
+
+func TestBlockIndexerMatchEvents(t *testing.T) {
+	store := dbm.NewPrefixDB(dbm.NewMemDB(), []byte("block_events"))
+	indexer := blockidxkv.New(store)
+
+	transfer := func(sender, amount string) abci.Event {
+		return abci.Event{
+			Type: "transfer",
+			Attributes: []abci.EventAttribute{
+				{Key: "sender", Value: sender, Index: true},
+				{Key: "amount", Value: amount, Index: true},
+			},
+		}
+	}
+
+	for i := 1; i <= 4; i++ {
+		require.NoError(t, indexer.Index(types.EventDataNewBlockHeader{
+			Header: types.Header{Height: int64(i)},
+			ResultBeginBlock: abci.ResponseBeginBlock{
+				Events: []abci.Event{
+					transfer(fmt.Sprintf("addr%d", i), fmt.Sprintf("%d", i*10)),
+					transfer("fee", "5"),
+				},
+			},
+			ResultEndBlock: abci.ResponseEndBlock{
+				Events: []abci.Event{
+					transfer("fee", fmt.Sprintf("%d", i)),
+				},
+			},
+		}))
+	}
+
+	testCases := map[string]struct {
+		q           *query.Query
+		matchEvents bool
+		results     []int64
+	}{
+		"attributes of different events": {
+			q:       query.MustCompile(`transfer.sender = 'fee' AND transfer.amount = 30`),
+			results: []int64{3},
+		},
+		"attributes of different events, matching events": {
+			q:           query.MustCompile(`transfer.sender = 'fee' AND transfer.amount = 30`),
+			matchEvents: true,
+			results:     []int64{},
+		},
+		"attributes of the same event": {
+			q:           query.MustCompile(`transfer.sender = 'fee' AND transfer.amount = 3`),
+			matchEvents: true,
+			results:     []int64{3},
+		},
+		"ranges within the same event": {
+			q:           query.MustCompile(`transfer.sender = 'fee' AND transfer.amount > 2 AND transfer.amount < 10`),
+			matchEvents: true,
+			results:     []int64{1, 2, 3, 4},
+		},
+		"same event with block conditions": {
+			q:           query.MustCompile(`transfer.sender LIKE 'addr%' AND transfer.amount >= 20 AND block.height < 4`),
+			matchEvents: true,
+			results:     []int64{2, 3},
+		},
+		"same event with negated conditions": {
+			q:           query.MustCompile(`transfer.sender = 'fee' AND transfer.amount = 5 AND NOT transfer.sender = 'addr2'`),
+			matchEvents: true,
+			results:     []int64{1, 3, 4},
+		},
+		"same event in either clause": {
+			q: query.MustCompile(
+				`transfer.sender = 'addr1' AND transfer.amount = 10 OR transfer.sender = 'fee' AND transfer.amount = 4`),
+			matchEvents: true,
+			results:     []int64{1, 4},
+		},
 	}
 
 	for name, tc := range testCases {
 		tc := tc
 		t.Run(name, func(t *testing.T) {
-			results, err := indexer.Search(context.Background(), tc.q)
+			results, err := indexer.Search(context.Background(), tc.q, tc.matchEvents)
 			require.NoError(t, err)
 			require.Equal(t, tc.results, results)
 		})
 	}
 }
+
+func TestBlockIndexerMigrate(t *testing.T) {
+	store := dbm.NewMemDB()
+
+	// keys of a former version, whose event keys were not scoped, and a key of
+	// the transaction indexer sharing the store
+	setKey := func(value []byte, components ...interface{}) {
+		key, err := orderedcode.Append(nil, components...)
+		require.NoError(t, err)
+		require.NoError(t, store.Set(key, value))
+	}
+	for h := int64(1); h <= 3; h++ {
+		height := make([]byte, binary.MaxVarintLen64)
+		height = height[:binary.PutVarint(height, h)]
+		setKey(height, types.BlockHeightKey, h)
+		setKey(height, "slash.validator", fmt.Sprintf("val%d", h), h, "begin_block", int64(0))
+		setKey(height, "reward.amount", "100", h, "end_block")
+	}
+	setKey([]byte("txhash"), "slash.validator", "val1", int64(1), int64(0))
+
+	indexer := blockidxkv.New(store)
+	search := func(q string) []int64 {
+		results, err := indexer.Search(context.Background(), query.MustCompile(q), false)
+		require.NoError(t, err)
+		return results
+	}
+	require.Empty(t, search(`slash.validator = 'val2'`))
+
+	require.NoError(t, indexer.Migrate())
+	require.Equal(t, []int64{2}, search(`slash.validator = 'val2'`))
+	require.Equal(t, []int64{1, 2, 3}, search(`reward.amount = 100`))
+	require.Equal(t, []int64{1, 3}, search(`slash.validator EXISTS AND NOT slash.validator = 'val2'`))
+
+	// the unscoped keys were moved, but not the transaction key
+	txKey, err := orderedcode.Append(nil, "slash.validator", "val1", int64(1), int64(0))
+	require.NoError(t, err)
+	value, err := store.Get(txKey)
+	require.NoError(t, err)
+	require.Equal(t, []byte("txhash"), value)
+	unscopedKey, err := orderedcode.Append(nil, "reward.amount", "100", int64(1), "end_block")
+	require.NoError(t, err)
+	ok, err := store.Has(unscopedKey)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// migrating again is a no-op
+	require.NoError(t, indexer.Migrate())
+	require.Equal(t, []int64{1, 2, 3}, search(`reward.amount = 100`))
+}
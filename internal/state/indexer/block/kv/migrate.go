@@ -0,0 +1,114 @@
+package kv
+
+import (
+	"fmt"
+
+	"github.com/google/orderedcode"
+)
+
+const (
+	// schemaVersion is the version of the keys of the index. Version 0 event
+	// keys are not scoped under blockEventsKey.
+	schemaVersion = 1
+
+	// migrateBatchSize is the number of keys migrated per batch.
+	migrateBatchSize = 1000
+)
+
+// schemaVersionKey records the schema version of the index.
+var schemaVersionKey = []byte("block_events_schema_version")
+
+// Migrate moves the event keys indexed by former versions of the indexer to
+// their current keys, in batches, such that it can resume after a failure. It
+// is a no-op once the index is migrated.
+func (idx *BlockerIndexer) Migrate() error {
+	version, err := idx.store.Get(schemaVersionKey)
+	if err != nil {
+		return err
+	}
+	if version != nil && int64FromBytes(version) >= schemaVersion {
+		return nil
+	}
+
+	prefix, err := orderedcode.Append(nil, blockEventsKey)
+	if err != nil {
+		return err
+	}
+
+	var start []byte
+	for {
+		keys, values, next, err := idx.unscopedEventKeys(start, migrateBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to find unscoped event keys: %w", err)
+		}
+
+		batch := idx.store.NewBatch()
+		for i, key := range keys {
+			scopedKey := append(append([]byte{}, prefix...), key...)
+			if err := batch.Set(scopedKey, values[i]); err != nil {
+				batch.Close()
+				return err
+			}
+			if err := batch.Delete(key); err != nil {
+				batch.Close()
+				return err
+			}
+		}
+		if next == nil {
+			if err := batch.Set(schemaVersionKey, int64ToBytes(schemaVersion)); err != nil {
+				batch.Close()
+				return err
+			}
+		}
+		err = batch.WriteSync()
+		batch.Close()
+		if err != nil {
+			return fmt.Errorf("failed to migrate event keys: %w", err)
+		}
+
+		if next == nil {
+			return nil
+		}
+		start = next
+	}
+}
+
+// unscopedEventKeys returns up to limit event keys of version 0 from the start
+// key onwards, with their values, and the key to continue from, which is nil
+// once the store is exhausted. The keys are collected before migrating them,
+// since the store can't be written to while iterating over it.
+func (idx *BlockerIndexer) unscopedEventKeys(start []byte, limit int) (keys, values [][]byte, next []byte, err error) {
+	it, err := idx.store.Iterator(start, nil)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	defer it.Close()
+
+	for ; it.Valid(); it.Next() {
+		if len(keys) == limit {
+			return keys, values, append([]byte{}, it.Key()...), nil
+		}
+		if isUnscopedEventKey(it.Key()) {
+			keys = append(keys, append([]byte{}, it.Key()...))
+			values = append(values, append([]byte{}, it.Value()...))
+		}
+	}
+	return keys, values, nil, it.Error()
+}
+
+// isUnscopedEventKey returns whether key is an event key of version 0, rather
+// than e.g. a key of the transaction indexer sharing the store.
+func isUnscopedEventKey(key []byte) bool {
+	var (
+		compositeKey, eventValue, typ string
+		height                        int64
+	)
+	if _, err := orderedcode.Parse(string(key), &compositeKey, &eventValue, &height, &typ); err != nil {
+		return false
+	}
+	if typ != "begin_block" && typ != "end_block" {
+		return false
+	}
+	_, _, err := parseEventKeyComponents(string(key))
+	return err == nil
+}
@@ -39,13 +39,30 @@ func heightKey(height int64) ([]byte, error) {
 	)
 }
 
-func eventKey(compositeKey, typ, eventValue string, height int64) ([]byte, error) {
+// blockEventsKey scopes the event keys of blocks, which would otherwise share
+// the key space of the event keys of transactions in the same store. It has no
+// dot, unlike the composite keys of events.
+const blockEventsKey = "block_events"
+
+// tagPrefix returns the prefix of the keys indexing tag, followed by the given
+// key components. Block heights are indexed by their primary keys, and other
+// tags by the event keys of blocks.
+func tagPrefix(tag string, components ...interface{}) ([]byte, error) {
+	if tag == types.BlockHeightKey {
+		return orderedcode.Append(nil, append([]interface{}{tag}, components...)...)
+	}
+	return orderedcode.Append(nil, append([]interface{}{blockEventsKey, tag}, components...)...)
+}
+
+func eventKey(compositeKey, typ, eventValue string, height, eventSeq int64) ([]byte, error) {
 	return orderedcode.Append(
 		nil,
+		blockEventsKey,
 		compositeKey,
 		eventValue,
 		height,
 		typ,
+		eventSeq,
 	)
 }
 
@@ -68,26 +85,57 @@ func parseValueFromPrimaryKey(key []byte) (string, error) {
 }
 
 func parseValueFromEventKey(key []byte) (string, error) {
+	eventValue, _, err := parseEventKey(key)
+	return eventValue, err
+}
+
+// parseEventKey returns the event value stored in an event key, along with a
+// string identifying the event the key belongs to. Keys indexed before event
+// sequence numbers were recorded identify only the block and the BeginBlock or
+// EndBlock phase of the event.
+func parseEventKey(key []byte) (string, string, error) {
+	var namespace string
+	remaining, err := orderedcode.Parse(string(key), &namespace)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to parse event key: %w", err)
+	}
+	if namespace != blockEventsKey {
+		return "", "", fmt.Errorf("unexpected event key namespace %q", namespace)
+	}
+	return parseEventKeyComponents(remaining)
+}
+
+// parseEventKeyComponents parses the components of an event key following its
+// namespace, which unscoped event keys of former versions lack.
+func parseEventKeyComponents(key string) (string, string, error) {
 	var (
 		compositeKey, typ, eventValue string
 		height                        int64
 	)
 
-	remaining, err := orderedcode.Parse(string(key), &compositeKey, &eventValue, &height, &typ)
+	remaining, err := orderedcode.Parse(key, &compositeKey, &eventValue, &height, &typ)
 	if err != nil {
-		return "", fmt.Errorf("failed to parse event key: %w", err)
+		return "", "", fmt.Errorf("failed to parse event key: %w", err)
 	}
 
 	if len(remaining) != 0 {
-		return "", fmt.Errorf("unexpected remainder in key: %s", remaining)
+		var eventSeq int64
+		remaining, err = orderedcode.Parse(remaining, &eventSeq)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to parse event key: %w", err)
+		}
+		if len(remaining) != 0 {
+			return "", "", fmt.Errorf("unexpected remainder in key: %s", remaining)
+		}
+		return eventValue, fmt.Sprintf("%d/%s/%d", height, typ, eventSeq), nil
 	}
 
-	return eventValue, nil
+	return eventValue, fmt.Sprintf("%d/%s", height, typ), nil
 }
 
 func lookForHeight(conditions []syntax.Condition) (int64, bool) {
 	for _, c := range conditions {
-		if c.Tag == types.BlockHeightKey && c.Op == syntax.TEq {
+		if c.Tag == types.BlockHeightKey && c.Op == syntax.TEq && !c.Not {
 			return int64(c.Arg.Number()), true
 		}
 	}
@@ -1,10 +1,11 @@
 /*
 Package indexer defines Tendermint's block and transaction event indexing logic.
 
-Tendermint supports two primary means of block and transaction event indexing:
+Tendermint supports three primary means of block and transaction event indexing:
 
 1. A key-value sink via an embedded database with a proprietary query language.
 2. A Postgres-based sink.
+3. A SQLite-based sink, storing events in a single database file.
 
 An ABCI application can emit events during block and transaction execution in the form
 
@@ -68,5 +69,45 @@ The following are some example SQL queries against the database schema:
 
 Note that if a complete abci.TxResult is needed, you will need to join "tx_events" with
 "tx_results" via a foreign key, to obtain contains the raw protobuf-encoded abci.TxResult.
+
+Besides the events, the schema records the header of each block, the result
+code and gas of each transaction, and the validator updates of each height,
+which are joined with their blocks by the "block_txs" and
+"block_validator_updates" views:
+
+	SELECT tx_hash, code, gas_used FROM block_txs WHERE height = 25;
+
+The materialized views of state/indexer/sink/psql/views.sql, for common block
+explorer queries, e.g. the blocks with the number of their failed transactions,
+the current validators and the blocks proposed by each validator, can be
+installed on top of the schema. They are refreshed by the sink every
+'tx-index.psql-refresh-views' blocks:
+
+	$ psql <flags> -f state/indexer/sink/psql/views.sql
+
+The "sqlite" indexing sink stores the same relational schema in a SQLite
+database file, which it creates along with the schema as needed, so it requires
+no external service. The file is located by the 'tx-index.sqlite-path' value,
+and defaults to tx_index.sqlite in the database directory. Unlike the "psql"
+sink, it supports block and transaction queries via RPC, as well as direct SQL
+queries against the file:
+
+	$ sqlite3 data/tx_index.sqlite "SELECT * FROM tx_events WHERE height = 25;"
+
+Third parties can provide their own sinks, e.g. for ClickHouse or BigQuery,
+without patching Tendermint, in two ways:
+
+1. Sinks compiled into a custom node binary register a constructor under a
+name with sink.Register, from the init function of their package, and are
+enabled by listing that name in 'tx-index.indexer'. The public package
+github.com/tendermint/tendermint/indexer exposes the registry.
+
+2. Sinks running out of process serve the EventSink gRPC service defined in
+state/indexer/sink/grpc/sink.proto, and are enabled by the "grpc" indexing sink
+with the address of their server in 'tx-index.grpc-addr'. Sinks written in Go
+can be served with indexer.NewGRPCServer.
+
+Custom sinks only receive the events to index: block and transaction queries
+via RPC are served by the "kv", "psql" and "sqlite" sinks.
 */
 package indexer
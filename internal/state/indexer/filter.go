@@ -0,0 +1,134 @@
+package indexer
+
+import (
+	"strings"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// EventFilter selects the event attributes to index by their composite keys,
+// of the form <type>.<key>. Patterns are matched against the whole composite
+// key, where "*" matches any sequence of characters, e.g. "transfer.*" matches
+// all the attributes of the transfer events.
+type EventFilter struct {
+	include []string
+	exclude []string
+}
+
+// NewEventFilter returns a filter allowing the composite keys which match one
+// of the include patterns, or any if there are none, and none of the exclude
+// patterns. It returns nil, which allows all keys, if there are no patterns.
+func NewEventFilter(include, exclude []string) *EventFilter {
+	if len(include) == 0 && len(exclude) == 0 {
+		return nil
+	}
+	return &EventFilter{include: include, exclude: exclude}
+}
+
+// Allows returns true if the attributes with the given composite key are
+// indexed.
+func (f *EventFilter) Allows(compositeKey string) bool {
+	if f == nil {
+		return true
+	}
+	for _, pattern := range f.exclude {
+		if matchPattern(pattern, compositeKey) {
+			return false
+		}
+	}
+	if len(f.include) == 0 {
+		return true
+	}
+	for _, pattern := range f.include {
+		if matchPattern(pattern, compositeKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterEvents returns a copy of events, with the attributes the filter
+// doesn't allow no longer flagged for indexing. It returns events itself if no
+// attribute is affected.
+func (f *EventFilter) filterEvents(events []abci.Event) []abci.Event {
+	var filtered []abci.Event
+	for i, event := range events {
+		var attrs []abci.EventAttribute
+		for j, attr := range event.Attributes {
+			if !attr.Index || f.Allows(event.Type+"."+attr.Key) {
+				continue
+			}
+			if attrs == nil {
+				attrs = append([]abci.EventAttribute(nil), event.Attributes...)
+			}
+			attrs[j].Index = false
+		}
+		if attrs == nil {
+			continue
+		}
+		if filtered == nil {
+			filtered = append([]abci.Event(nil), events...)
+		}
+		filtered[i].Attributes = attrs
+	}
+	if filtered == nil {
+		return events
+	}
+	return filtered
+}
+
+// matchPattern reports whether key matches pattern, where "*" matches any
+// sequence of characters.
+func matchPattern(pattern, key string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == key
+	}
+	if !strings.HasPrefix(key, parts[0]) {
+		return false
+	}
+	key = key[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(key, part)
+		if i < 0 {
+			return false
+		}
+		key = key[i+len(part):]
+	}
+	last := parts[len(parts)-1]
+	return len(key) >= len(last) && strings.HasSuffix(key, last)
+}
+
+// FilterEventSink returns an EventSink indexing only the event attributes
+// filter allows into sink, or sink itself if filter is nil. The attributes
+// it doesn't allow are still stored with the transaction results, but are no
+// longer flagged for indexing.
+func FilterEventSink(sink EventSink, filter *EventFilter) EventSink {
+	if filter == nil {
+		return sink
+	}
+	return &filteredEventSink{EventSink: sink, filter: filter}
+}
+
+type filteredEventSink struct {
+	EventSink
+	filter *EventFilter
+}
+
+func (es *filteredEventSink) IndexBlockEvents(h types.EventDataNewBlockHeader) error {
+	h.ResultBeginBlock.Events = es.filter.filterEvents(h.ResultBeginBlock.Events)
+	h.ResultEndBlock.Events = es.filter.filterEvents(h.ResultEndBlock.Events)
+	return es.EventSink.IndexBlockEvents(h)
+}
+
+func (es *filteredEventSink) IndexTxEvents(txrs []*abci.TxResult) error {
+	filtered := make([]*abci.TxResult, len(txrs))
+	for i, txr := range txrs {
+		// the results are shared with the other sinks
+		txr := *txr
+		txr.Result.Events = es.filter.filterEvents(txr.Result.Events)
+		filtered[i] = &txr
+	}
+	return es.EventSink.IndexTxEvents(filtered)
+}
@@ -0,0 +1,90 @@
+package indexer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/internal/state/indexer"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestEventFilterAllows(t *testing.T) {
+	testCases := []struct {
+		include, exclude []string
+		key              string
+		allowed          bool
+	}{
+		{nil, nil, "transfer.sender", true},
+		{[]string{"transfer.*"}, nil, "transfer.sender", true},
+		{[]string{"transfer.*"}, nil, "transfers.sender", false},
+		{[]string{"transfer.*"}, nil, "message.action", false},
+		{[]string{"*.sender"}, nil, "transfer.sender", true},
+		{[]string{"*.sender"}, nil, "transfer.recipient", false},
+		{[]string{"t*r.s*r"}, nil, "transfer.sender", true},
+		{[]string{"transfer.sender"}, nil, "transfer.sender", true},
+		{[]string{"transfer.sender"}, nil, "transfer.senders", false},
+		{[]string{"*"}, []string{"transfer.*"}, "message.action", true},
+		{[]string{"*"}, []string{"transfer.*"}, "transfer.sender", false},
+		{nil, []string{"*.amount"}, "transfer.amount", false},
+		{nil, []string{"*.amount"}, "transfer.sender", true},
+	}
+	for _, tc := range testCases {
+		filter := indexer.NewEventFilter(tc.include, tc.exclude)
+		assert.Equal(t, tc.allowed, filter.Allows(tc.key),
+			"include %v exclude %v key %v", tc.include, tc.exclude, tc.key)
+	}
+}
+
+type recordingEventSink struct {
+	indexer.EventSink
+	header types.EventDataNewBlockHeader
+	txrs   []*abci.TxResult
+}
+
+func (es *recordingEventSink) IndexBlockEvents(h types.EventDataNewBlockHeader) error {
+	es.header = h
+	return nil
+}
+
+func (es *recordingEventSink) IndexTxEvents(txrs []*abci.TxResult) error {
+	es.txrs = txrs
+	return nil
+}
+
+func TestFilterEventSink(t *testing.T) {
+	sink := &recordingEventSink{}
+	require.Equal(t, indexer.EventSink(sink), indexer.FilterEventSink(sink, nil))
+
+	filtered := indexer.FilterEventSink(sink, indexer.NewEventFilter([]string{"transfer.*"}, nil))
+
+	events := []abci.Event{
+		{Type: "transfer", Attributes: []abci.EventAttribute{
+			{Key: "sender", Value: "foo", Index: true},
+		}},
+		{Type: "message", Attributes: []abci.EventAttribute{
+			{Key: "action", Value: "send", Index: true},
+			{Key: "module", Value: "bank", Index: false},
+		}},
+	}
+	txr := &abci.TxResult{Height: 1, Result: abci.ResponseDeliverTx{Events: events}}
+	require.NoError(t, filtered.IndexTxEvents([]*abci.TxResult{txr}))
+
+	require.Len(t, sink.txrs, 1)
+	indexed := sink.txrs[0].Result.Events
+	assert.True(t, indexed[0].Attributes[0].Index)
+	assert.False(t, indexed[1].Attributes[0].Index)
+	assert.False(t, indexed[1].Attributes[1].Index)
+	// the events are otherwise unchanged, and the original result too
+	assert.Equal(t, "send", indexed[1].Attributes[0].Value)
+	assert.True(t, txr.Result.Events[1].Attributes[0].Index)
+
+	require.NoError(t, filtered.IndexBlockEvents(types.EventDataNewBlockHeader{
+		ResultEndBlock: abci.ResponseEndBlock{Events: events},
+	}))
+	assert.True(t, sink.header.ResultEndBlock.Events[0].Attributes[0].Index)
+	assert.False(t, sink.header.ResultEndBlock.Events[1].Attributes[0].Index)
+	assert.True(t, events[1].Attributes[0].Index)
+}
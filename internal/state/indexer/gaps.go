@@ -0,0 +1,132 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// checkGapsPeriodically checks the sinks for gaps, and repairs them, right
+// away and then every gap check interval until ctx is done.
+func (is *Service) checkGapsPeriodically(ctx context.Context) {
+	is.checkGaps(ctx)
+	if is.gapCheckInterval == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(is.gapCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			is.checkGaps(ctx)
+		}
+	}
+}
+
+// checkGaps finds the heights in the gap check window missing from each sink
+// which can report the blocks it indexed, and indexes them from the stores.
+// The heights left missing, e.g. because their results were pruned, are
+// reported by the gaps metric.
+func (is *Service) checkGaps(ctx context.Context) {
+	from, to, err := is.gapCheckRange()
+	if err != nil {
+		is.logger.Error("failed to check the indexed heights for gaps", "err", err)
+		return
+	}
+	if from > to {
+		return
+	}
+
+	for _, sink := range is.eventSinks {
+		// the null sink indexes nothing, and the postgres one can't tell
+		if sink.Type() == NULL || sink.Type() == PSQL {
+			continue
+		}
+
+		missing, err := findGaps(ctx, sink, from, to)
+		if err != nil {
+			is.logger.Error("failed to check the indexed heights for gaps",
+				"sink", sink.Type(), "err", err)
+			continue
+		}
+		if len(missing) == 0 {
+			is.metrics.Gaps.With("sink", string(sink.Type())).Set(0)
+			continue
+		}
+		is.logger.Info("found heights missing from the index, indexing them",
+			"sink", sink.Type(), "count", len(missing), "first", missing[0], "last", missing[len(missing)-1])
+
+		var left int
+		for _, height := range missing {
+			if ctx.Err() != nil {
+				return
+			}
+			if err := is.repairGap(sink, height); err != nil {
+				is.logger.Error("failed to index missing height", "sink", sink.Type(), "height", height, "err", err)
+				left++
+				continue
+			}
+			is.metrics.GapsRepaired.With("sink", string(sink.Type())).Add(1)
+		}
+		is.metrics.Gaps.With("sink", string(sink.Type())).Set(float64(left))
+	}
+}
+
+// gapCheckRange returns the heights checked for gaps. The last block executed
+// is left out, for it may be being indexed.
+func (is *Service) gapCheckRange() (from, to int64, err error) {
+	st, err := is.stateStore.Load()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to load state: %w", err)
+	}
+
+	to = st.LastBlockHeight - 1
+	from = to - is.gapCheckWindow + 1
+	if base := is.blockStore.Base(); from < base {
+		from = base
+	}
+	if from < st.InitialHeight {
+		from = st.InitialHeight
+	}
+	return from, to, nil
+}
+
+// findGaps returns the heights from from to to, inclusive, which are missing
+// from the sink, in increasing order.
+func findGaps(ctx context.Context, sink EventSink, from, to int64) ([]int64, error) {
+	var missing []int64
+	for height := from; height <= to; height++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		ok, err := sink.HasBlock(height)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			missing = append(missing, height)
+		}
+	}
+	return missing, nil
+}
+
+// repairGap indexes the block at the given height from the stores into the
+// sink.
+func (is *Service) repairGap(sink EventSink, height int64) error {
+	header, batch, err := loadBlockEvents(is.blockStore, is.stateStore, height)
+	if err != nil {
+		return err
+	}
+	if err := sink.IndexBlockEvents(header); err != nil {
+		return fmt.Errorf("failed to index block events: %w", err)
+	}
+	if batch.Size() != 0 {
+		if err := sink.IndexTxEvents(batch.Ops); err != nil {
+			return fmt.Errorf("failed to index tx events: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package indexer_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/internal/eventbus"
+	sm "github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/internal/state/indexer"
+	"github.com/tendermint/tendermint/internal/state/indexer/sink/kv"
+	"github.com/tendermint/tendermint/internal/state/mocks"
+	"github.com/tendermint/tendermint/libs/log"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestIndexerServiceRepairsGaps(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	logger := log.TestingLogger()
+
+	eventBus := eventbus.NewDefault(logger)
+	require.NoError(t, eventBus.Start(ctx))
+	t.Cleanup(eventBus.Wait)
+
+	// the blocks 1 to 4 are executed, of which 2 was indexed
+	tx := types.Tx("foo")
+	stateStore := &mocks.Store{}
+	stateStore.On("Load").Return(sm.State{InitialHeight: 1, LastBlockHeight: 4}, nil)
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("Base").Return(int64(1))
+	for _, height := range []int64{1, 3} {
+		blockStore.On("LoadBlock", height).Return(&types.Block{
+			Header: types.Header{Height: height},
+			Data:   types.Data{Txs: types.Txs{tx}},
+		})
+		stateStore.On("LoadABCIResponses", height).Return(&tmstate.ABCIResponses{
+			BeginBlock: &abci.ResponseBeginBlock{},
+			DeliverTxs: []*abci.ResponseDeliverTx{{Code: abci.CodeTypeOK}},
+			EndBlock:   &abci.ResponseEndBlock{},
+		}, nil)
+	}
+
+	sink, err := kv.NewEventSink(dbm.NewMemDB())
+	require.NoError(t, err)
+	require.NoError(t, sink.IndexBlockEvents(types.EventDataNewBlockHeader{Header: types.Header{Height: 2}}))
+
+	service := indexer.NewService(indexer.ServiceArgs{
+		Logger:         logger,
+		Sinks:          []indexer.EventSink{sink},
+		EventBus:       eventBus,
+		BlockStore:     blockStore,
+		StateStore:     stateStore,
+		GapCheckWindow: 10,
+	})
+	require.NoError(t, service.Start(ctx))
+	t.Cleanup(service.Wait)
+
+	// the gaps are indexed, but not the last block, which may be being indexed
+	require.Eventually(t, func() bool {
+		ok, err := sink.HasBlock(3)
+		return err == nil && ok
+	}, time.Second, 10*time.Millisecond)
+	ok, err := sink.HasBlock(1)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = sink.HasBlock(4)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	res, err := sink.GetTxByHash(tx.Hash())
+	require.NoError(t, err)
+	require.NotNil(t, res)
+}
@@ -34,8 +34,9 @@ type BlockIndexer interface {
 	Index(types.EventDataNewBlockHeader) error
 
 	// Search performs a query for block heights that match a given BeginBlock
-	// and Endblock event search criteria.
-	Search(ctx context.Context, q *query.Query) ([]int64, error)
+	// and Endblock event search criteria. If matchEvents is true, the event
+	// conditions of each query clause must be satisfied by a single event.
+	Search(ctx context.Context, q *query.Query, matchEvents bool) ([]int64, error)
 }
 
 // Batch groups together multiple Index operations to be performed at the same time.
@@ -2,10 +2,12 @@ package indexer
 
 import (
 	"context"
+	"sync/atomic"
 	"time"
 
 	"github.com/tendermint/tendermint/internal/eventbus"
 	"github.com/tendermint/tendermint/internal/pubsub"
+	"github.com/tendermint/tendermint/internal/state"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/libs/service"
 	"github.com/tendermint/tendermint/types"
@@ -21,6 +23,15 @@ type Service struct {
 	eventBus   *eventbus.EventBus
 	metrics    *Metrics
 
+	// the stores the heights missing from the sinks are indexed from
+	blockStore       state.BlockStore
+	stateStore       state.Store
+	gapCheckWindow   int64
+	gapCheckInterval time.Duration
+
+	// the height of the last block indexed, accessed atomically
+	indexedHeight int64
+
 	currentBlock struct {
 		header types.EventDataNewBlockHeader
 		height int64
@@ -35,6 +46,11 @@ func NewService(args ServiceArgs) *Service {
 		eventSinks: args.Sinks,
 		eventBus:   args.EventBus,
 		metrics:    args.Metrics,
+
+		blockStore:       args.BlockStore,
+		stateStore:       args.StateStore,
+		gapCheckWindow:   args.GapCheckWindow,
+		gapCheckInterval: args.GapCheckInterval,
 	}
 	if is.metrics == nil {
 		is.metrics = NopMetrics()
@@ -108,6 +124,7 @@ func (is *Service) publish(msg pubsub.Message) error {
 				}
 			}
 		}
+		atomic.StoreInt64(&is.indexedHeight, is.currentBlock.height)
 		is.currentBlock.batch = nil // return to the WAIT state for the next block
 	}
 
@@ -115,22 +132,41 @@ func (is *Service) publish(msg pubsub.Message) error {
 }
 
 // OnStart implements part of service.Service. It registers an observer for the
-// indexer if the underlying event sinks support indexing.
+// indexer if the underlying event sinks support indexing, and starts checking
+// the sinks for gaps if configured to.
 //
 // TODO(creachadair): Can we get rid of the "enabled" check?
 func (is *Service) OnStart(ctx context.Context) error {
 	// If the event sinks support indexing, register an observer to capture
 	// block header data for the indexer.
 	if IndexingEnabled(is.eventSinks) {
+		// the blocks executed before starting are taken as indexed
+		if is.stateStore != nil {
+			st, err := is.stateStore.Load()
+			if err != nil {
+				return err
+			}
+			atomic.StoreInt64(&is.indexedHeight, st.LastBlockHeight)
+		}
+
 		err := is.eventBus.Observe(ctx, is.publish,
 			types.EventQueryNewBlockHeader, types.EventQueryTx)
 		if err != nil {
 			return err
 		}
+		if is.blockStore != nil && is.stateStore != nil && is.gapCheckWindow > 0 {
+			go is.checkGapsPeriodically(ctx)
+		}
 	}
 	return nil
 }
 
+// IndexedHeight returns the height of the last block indexed. It is
+// thread-safe.
+func (is *Service) IndexedHeight() int64 {
+	return atomic.LoadInt64(&is.indexedHeight)
+}
+
 // OnStop implements service.Service by closing the event sinks.
 func (is *Service) OnStop() {
 	for _, sink := range is.eventSinks {
@@ -146,6 +182,14 @@ type ServiceArgs struct {
 	EventBus *eventbus.EventBus
 	Metrics  *Metrics
 	Logger   log.Logger
+
+	// If set, the GapCheckWindow most recent heights are checked for blocks
+	// missing from the sinks at startup and every GapCheckInterval, and those
+	// are indexed from the stores.
+	BlockStore       state.BlockStore
+	StateStore       state.Store
+	GapCheckWindow   int64
+	GapCheckInterval time.Duration
 }
 
 // KVSinkEnabled returns the given eventSinks is containing KVEventSink.
@@ -159,10 +203,37 @@ func KVSinkEnabled(sinks []EventSink) bool {
 	return false
 }
 
-// IndexingEnabled returns the given eventSinks is supporting the indexing services.
+// TxSearchSink returns the first of the given eventSinks supporting the
+// transaction queries, or nil if there is none.
+func TxSearchSink(sinks []EventSink) EventSink {
+	for _, sink := range sinks {
+		if sink.Type() == KV || sink.Type() == SQLITE {
+			return sink
+		}
+	}
+
+	return nil
+}
+
+// HistorySink returns the first of the given eventSinks the transactions
+// events of a subscription's past heights are searched in, or nil if there is
+// none: the postgres sink, which evaluates the queries in the database, or
+// else the first sink supporting the transaction queries.
+func HistorySink(sinks []EventSink) EventSink {
+	for _, sink := range sinks {
+		if sink.Type() == PSQL {
+			return sink
+		}
+	}
+
+	return TxSearchSink(sinks)
+}
+
+// IndexingEnabled returns the given eventSinks is supporting the indexing services,
+// i.e. contains any sink other than the null one.
 func IndexingEnabled(sinks []EventSink) bool {
 	for _, sink := range sinks {
-		if sink.Type() == KV || sink.Type() == PSQL {
+		if sink.Type() != NULL {
 			return true
 		}
 	}
@@ -58,7 +58,9 @@ func TestIndexerServiceIndexesBlocks(t *testing.T) {
 	assert.Nil(t, err)
 
 	store := dbm.NewMemDB()
-	eventSinks := []indexer.EventSink{kv.NewEventSink(store), pSink}
+	kvSink, err := kv.NewEventSink(store)
+	require.NoError(t, err)
+	eventSinks := []indexer.EventSink{kvSink, pSink}
 	assert.True(t, indexer.KVSinkEnabled(eventSinks))
 	assert.True(t, indexer.IndexingEnabled(eventSinks))
 
@@ -165,7 +167,7 @@ func setupDB(t *testing.T) (*dockertest.Pool, error) {
 	conn := fmt.Sprintf(dsn, user, password, resource.GetPort(port+"/tcp"), dbName)
 
 	assert.NoError(t, pool.Retry(func() error {
-		sink, err := psql.NewEventSink(conn, "test-chainID")
+		sink, err := psql.NewEventSink(conn, "test-chainID", 0)
 		if err != nil {
 			return err
 		}
@@ -24,6 +24,12 @@ type Metrics struct {
 
 	// Number of transactions indexed.
 	TransactionsIndexed metrics.Counter
+
+	// Number of heights missing from an event sink, as of its last check.
+	Gaps metrics.Gauge
+
+	// Number of missing heights indexed by the gap checks.
+	GapsRepaired metrics.Counter
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -59,6 +65,18 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "transactions_indexed",
 			Help:      "Number of transactions indexed.",
 		}, labels).With(labelsAndValues...),
+		Gaps: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "gaps",
+			Help:      "Number of heights missing from an event sink, as of its last check.",
+		}, append(labels, "sink")).With(labelsAndValues...),
+		GapsRepaired: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "gaps_repaired",
+			Help:      "Number of missing heights indexed by the gap checks.",
+		}, append(labels, "sink")).With(labelsAndValues...),
 	}
 }
 
@@ -69,5 +87,7 @@ func NopMetrics() *Metrics {
 		TxEventsSeconds:     discard.NewHistogram(),
 		BlocksIndexed:       discard.NewCounter(),
 		TransactionsIndexed: discard.NewCounter(),
+		Gaps:                discard.NewGauge(),
+		GapsRepaired:        discard.NewCounter(),
 	}
 }
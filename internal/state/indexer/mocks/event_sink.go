@@ -92,13 +92,13 @@ func (_m *EventSink) IndexTxEvents(_a0 []*types.TxResult) error {
 	return r0
 }
 
-// SearchBlockEvents provides a mock function with given fields: _a0, _a1
-func (_m *EventSink) SearchBlockEvents(_a0 context.Context, _a1 *query.Query) ([]int64, error) {
-	ret := _m.Called(_a0, _a1)
+// SearchBlockEvents provides a mock function with given fields: ctx, q, matchEvents
+func (_m *EventSink) SearchBlockEvents(ctx context.Context, q *query.Query, matchEvents bool) ([]int64, error) {
+	ret := _m.Called(ctx, q, matchEvents)
 
 	var r0 []int64
-	if rf, ok := ret.Get(0).(func(context.Context, *query.Query) []int64); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, *query.Query, bool) []int64); ok {
+		r0 = rf(ctx, q, matchEvents)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]int64)
@@ -106,8 +106,8 @@ func (_m *EventSink) SearchBlockEvents(_a0 context.Context, _a1 *query.Query) ([
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, *query.Query) error); ok {
-		r1 = rf(_a0, _a1)
+	if rf, ok := ret.Get(1).(func(context.Context, *query.Query, bool) error); ok {
+		r1 = rf(ctx, q, matchEvents)
 	} else {
 		r1 = ret.Error(1)
 	}
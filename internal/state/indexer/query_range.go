@@ -1,6 +1,8 @@
 package indexer
 
 import (
+	"math"
+	"strconv"
 	"time"
 
 	"github.com/tendermint/tendermint/internal/pubsub/query/syntax"
@@ -13,8 +15,8 @@ type QueryRanges map[string]QueryRange
 
 // QueryRange defines a range within a query condition.
 type QueryRange struct {
-	LowerBound        interface{} // int || time.Time
-	UpperBound        interface{} // int || time.Time
+	LowerBound        interface{} // int64 || float64 || time.Time
+	UpperBound        interface{} // int64 || float64 || time.Time
 	Key               string
 	IncludeLowerBound bool
 	IncludeUpperBound bool
@@ -44,6 +46,9 @@ func (qr QueryRange) LowerBoundValue() interface{} {
 	case int64:
 		return t + 1
 
+	case float64:
+		return math.Nextafter(t, math.Inf(1))
+
 	case time.Time:
 		return t.Unix() + 1
 
@@ -67,6 +72,9 @@ func (qr QueryRange) UpperBoundValue() interface{} {
 	case int64:
 		return t - 1
 
+	case float64:
+		return math.Nextafter(t, math.Inf(-1))
+
 	case time.Time:
 		return t.Unix() - 1
 
@@ -75,12 +83,74 @@ func (qr QueryRange) UpperBoundValue() interface{} {
 	}
 }
 
+// IsNumeric reports whether the bounds of qr are numbers.
+func (qr QueryRange) IsNumeric() bool {
+	switch qr.AnyBound().(type) {
+	case int64, float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// MatchNumber reports whether s encodes a number that lies within the bounds
+// of qr. Values are compared as integers when both s and every bound are
+// integers, so that large values do not lose precision; otherwise they are
+// compared as floating-point values.
+func (qr QueryRange) MatchNumber(s string) bool {
+	if v, err := strconv.ParseInt(s, 10, 64); err == nil && qr.integerBounds() {
+		lower, upper := qr.LowerBoundValue(), qr.UpperBoundValue()
+		if lower != nil && v < lower.(int64) {
+			return false
+		}
+		return upper == nil || v <= upper.(int64)
+	}
+
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(v) {
+		return false
+	}
+	if qr.LowerBound != nil {
+		lo := toFloat(qr.LowerBound)
+		if v < lo || (v == lo && !qr.IncludeLowerBound) {
+			return false
+		}
+	}
+	if qr.UpperBound != nil {
+		hi := toFloat(qr.UpperBound)
+		if v > hi || (v == hi && !qr.IncludeUpperBound) {
+			return false
+		}
+	}
+	return true
+}
+
+func (qr QueryRange) integerBounds() bool {
+	for _, b := range []interface{}{qr.LowerBound, qr.UpperBound} {
+		if _, ok := b.(int64); b != nil && !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func toFloat(v interface{}) float64 {
+	switch t := v.(type) {
+	case int64:
+		return float64(t)
+	case float64:
+		return t
+	default:
+		return math.NaN()
+	}
+}
+
 // LookForRanges returns a mapping of QueryRanges and the matching indexes in
-// the provided query conditions.
+// the provided query conditions. Negated conditions are not treated as ranges.
 func LookForRanges(conditions []syntax.Condition) (ranges QueryRanges, indexes []int) {
 	ranges = make(QueryRanges)
 	for i, c := range conditions {
-		if IsRangeOperation(c.Op) {
+		if IsRangeOperation(c.Op) && !c.Not {
 			r, ok := ranges[c.Tag]
 			if !ok {
 				r = QueryRange{Key: c.Tag}
@@ -128,7 +198,11 @@ func conditionArg(c syntax.Condition) interface{} {
 	}
 	switch c.Arg.Type {
 	case syntax.TNumber:
-		return int64(c.Arg.Number())
+		v := c.Arg.Number()
+		if v == math.Trunc(v) {
+			return int64(v)
+		}
+		return v
 	case syntax.TTime, syntax.TDate:
 		return c.Arg.Time()
 	default:
@@ -0,0 +1,42 @@
+package indexer_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/internal/pubsub/query/syntax"
+	"github.com/tendermint/tendermint/internal/state/indexer"
+)
+
+func TestQueryRangeFloatBounds(t *testing.T) {
+	q, err := syntax.Parse("tx.fee > 1.5 AND tx.fee <= 2.5")
+	require.NoError(t, err)
+
+	ranges, indexes := indexer.LookForRanges(q[0])
+	require.Len(t, indexes, 2)
+	r, ok := ranges["tx.fee"]
+	require.True(t, ok)
+
+	assert.Equal(t, math.Nextafter(1.5, math.Inf(1)), r.LowerBoundValue())
+	assert.Equal(t, 2.5, r.UpperBoundValue())
+
+	r.IncludeUpperBound = false
+	assert.Equal(t, math.Nextafter(2.5, math.Inf(-1)), r.UpperBoundValue())
+
+	for _, tc := range []struct {
+		value string
+		match bool
+	}{
+		{"1", false},
+		{"1.5", false},
+		{"1.50001", true},
+		{"2", true},
+		{"2.5", false},
+		{"3", false},
+	} {
+		assert.Equal(t, tc.match, r.MatchNumber(tc.value), tc.value)
+	}
+}
@@ -0,0 +1,101 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Reindex replays the blocks from height from to height to, inclusive, and
+// the results of executing them, from the block and state stores through the
+// given sinks, indexing them as the indexer service does when the blocks are
+// committed. It is used to populate a newly added sink, or to recover a sink
+// which lost data, without resyncing the chain.
+//
+// Reindex stops at the first error, or when the context is canceled. If set,
+// progress is called after each height is indexed.
+func Reindex(
+	ctx context.Context,
+	sinks []EventSink,
+	blockStore state.BlockStore,
+	stateStore state.Store,
+	from, to int64,
+	progress func(height int64),
+) error {
+	for height := from; height <= to; height++ {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("event re-index terminated at height %d: %w", height, err)
+		}
+
+		header, batch, err := loadBlockEvents(blockStore, stateStore, height)
+		if err != nil {
+			return err
+		}
+
+		for _, sink := range sinks {
+			if err := sink.IndexBlockEvents(header); err != nil {
+				return fmt.Errorf("block event re-index at height %d failed: %w", height, err)
+			}
+
+			if batch.Size() != 0 {
+				if err := sink.IndexTxEvents(batch.Ops); err != nil {
+					return fmt.Errorf("tx event re-index at height %d failed: %w", height, err)
+				}
+			}
+		}
+
+		if progress != nil {
+			progress(height)
+		}
+	}
+
+	return nil
+}
+
+// loadBlockEvents loads the block header event and the transaction results of
+// the given height, as published on the event bus when it was committed.
+func loadBlockEvents(
+	blockStore state.BlockStore,
+	stateStore state.Store,
+	height int64,
+) (types.EventDataNewBlockHeader, *Batch, error) {
+	block := blockStore.LoadBlock(height)
+	if block == nil {
+		return types.EventDataNewBlockHeader{}, nil,
+			fmt.Errorf("not able to load block at height %d from the blockstore", height)
+	}
+
+	res, err := stateStore.LoadABCIResponses(height)
+	if err != nil {
+		return types.EventDataNewBlockHeader{}, nil,
+			fmt.Errorf("not able to load ABCI Response at height %d from the statestore", height)
+	}
+	if res.BeginBlock == nil || res.EndBlock == nil || len(res.DeliverTxs) != len(block.Txs) {
+		return types.EventDataNewBlockHeader{}, nil,
+			fmt.Errorf("incomplete ABCI Response at height %d in the statestore", height)
+	}
+
+	header := types.EventDataNewBlockHeader{
+		Header:           block.Header,
+		NumTxs:           int64(len(block.Txs)),
+		ResultBeginBlock: *res.BeginBlock,
+		ResultEndBlock:   *res.EndBlock,
+	}
+
+	batch := NewBatch(header.NumTxs)
+	for i, tx := range block.Txs {
+		if err := batch.Add(&abci.TxResult{
+			Height: block.Height,
+			Index:  uint32(i),
+			Tx:     tx,
+			Result: *res.DeliverTxs[i],
+		}); err != nil {
+			return types.EventDataNewBlockHeader{}, nil, err
+		}
+	}
+
+	return header, batch, nil
+}
@@ -0,0 +1,72 @@
+package indexer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/internal/state/indexer"
+	"github.com/tendermint/tendermint/internal/state/indexer/sink/kv"
+	"github.com/tendermint/tendermint/internal/state/mocks"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestReindex(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tx := types.Tx("foo")
+	blockStore := &mocks.BlockStore{}
+	blockStore.
+		On("LoadBlock", int64(1)).Return(&types.Block{Header: types.Header{Height: 1}}).
+		On("LoadBlock", int64(2)).Return(&types.Block{
+		Header: types.Header{Height: 2},
+		Data:   types.Data{Txs: types.Txs{tx}},
+	}).
+		On("LoadBlock", int64(3)).Return(nil)
+
+	stateStore := &mocks.Store{}
+	stateStore.
+		On("LoadABCIResponses", int64(1)).Return(&tmstate.ABCIResponses{
+		BeginBlock: &abci.ResponseBeginBlock{},
+		EndBlock:   &abci.ResponseEndBlock{},
+	}, nil).
+		On("LoadABCIResponses", int64(2)).Return(&tmstate.ABCIResponses{
+		BeginBlock: &abci.ResponseBeginBlock{},
+		DeliverTxs: []*abci.ResponseDeliverTx{{Code: abci.CodeTypeOK, Data: []byte("bar")}},
+		EndBlock:   &abci.ResponseEndBlock{},
+	}, nil)
+
+	sink, err := kv.NewEventSink(dbm.NewMemDB())
+	require.NoError(t, err)
+	var heights []int64
+	err = indexer.Reindex(ctx, []indexer.EventSink{sink}, blockStore, stateStore, 1, 2,
+		func(height int64) { heights = append(heights, height) })
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, heights)
+
+	for _, height := range []int64{1, 2} {
+		ok, err := sink.HasBlock(height)
+		require.NoError(t, err)
+		assert.True(t, ok, "block %d not indexed", height)
+	}
+	res, err := sink.GetTxByHash(tx.Hash())
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	assert.EqualValues(t, 2, res.Height)
+	assert.Equal(t, []byte("bar"), res.Result.Data)
+
+	// missing blocks fail the reindex
+	err = indexer.Reindex(ctx, []indexer.EventSink{sink}, blockStore, stateStore, 2, 3, nil)
+	require.Error(t, err)
+
+	// so does canceling it
+	cancel()
+	err = indexer.Reindex(ctx, []indexer.EventSink{sink}, blockStore, stateStore, 1, 2, nil)
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,100 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/tendermint/tendermint/internal/pubsub/query"
+	"github.com/tendermint/tendermint/internal/state/indexer"
+	"github.com/tendermint/tendermint/types"
+)
+
+// NewServer returns a gRPC server serving the given event sink to the "grpc"
+// indexer of a node, for sinks running out of process. The server must be
+// started by the caller, with Serve.
+func NewServer(sink indexer.EventSink, opts ...grpc.ServerOption) *grpc.Server {
+	s := grpc.NewServer(append(opts, grpc.ForceServerCodec(codec{}))...)
+	s.RegisterService(&serviceDesc, &server{sink: sink})
+	return s
+}
+
+var _ eventSinkServer = (*server)(nil)
+
+// server implements the EventSink service with an indexer.EventSink.
+type server struct {
+	sink indexer.EventSink
+}
+
+func (s *server) IndexBlockEvents(ctx context.Context, req *IndexBlockEventsRequest) (*IndexBlockEventsResponse, error) {
+	if req.Header == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing header")
+	}
+	header, err := types.HeaderFromProto(req.Header)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid header: %v", err)
+	}
+	bh := types.EventDataNewBlockHeader{
+		Header: header,
+		NumTxs: req.NumTxs,
+	}
+	if req.ResultBeginBlock != nil {
+		bh.ResultBeginBlock = *req.ResultBeginBlock
+	}
+	if req.ResultEndBlock != nil {
+		bh.ResultEndBlock = *req.ResultEndBlock
+	}
+	if err := s.sink.IndexBlockEvents(bh); err != nil {
+		return nil, err
+	}
+	return &IndexBlockEventsResponse{}, nil
+}
+
+func (s *server) IndexTxEvents(ctx context.Context, req *IndexTxEventsRequest) (*IndexTxEventsResponse, error) {
+	if err := s.sink.IndexTxEvents(req.TxResults); err != nil {
+		return nil, err
+	}
+	return &IndexTxEventsResponse{}, nil
+}
+
+func (s *server) SearchBlockEvents(ctx context.Context, req *SearchBlockEventsRequest) (*SearchBlockEventsResponse, error) {
+	q, err := query.New(req.Query)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid query: %v", err)
+	}
+	heights, err := s.sink.SearchBlockEvents(ctx, q, req.MatchEvents)
+	if err != nil {
+		return nil, err
+	}
+	return &SearchBlockEventsResponse{Heights: heights}, nil
+}
+
+func (s *server) SearchTxEvents(ctx context.Context, req *SearchTxEventsRequest) (*SearchTxEventsResponse, error) {
+	q, err := query.New(req.Query)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid query: %v", err)
+	}
+	results, err := s.sink.SearchTxEvents(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	return &SearchTxEventsResponse{TxResults: results}, nil
+}
+
+func (s *server) GetTxByHash(ctx context.Context, req *GetTxByHashRequest) (*GetTxByHashResponse, error) {
+	result, err := s.sink.GetTxByHash(req.Hash)
+	if err != nil {
+		return nil, err
+	}
+	return &GetTxByHashResponse{TxResult: result}, nil
+}
+
+func (s *server) HasBlock(ctx context.Context, req *HasBlockRequest) (*HasBlockResponse, error) {
+	has, err := s.sink.HasBlock(req.Height)
+	if err != nil {
+		return nil, err
+	}
+	return &HasBlockResponse{HasBlock: has}, nil
+}
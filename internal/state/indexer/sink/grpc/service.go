@@ -0,0 +1,116 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gogo/protobuf/proto"
+	"google.golang.org/grpc"
+)
+
+const serviceName = "tendermint.indexer.EventSink"
+
+// eventSinkServer is the server API of the EventSink service.
+type eventSinkServer interface {
+	IndexBlockEvents(context.Context, *IndexBlockEventsRequest) (*IndexBlockEventsResponse, error)
+	IndexTxEvents(context.Context, *IndexTxEventsRequest) (*IndexTxEventsResponse, error)
+	SearchBlockEvents(context.Context, *SearchBlockEventsRequest) (*SearchBlockEventsResponse, error)
+	SearchTxEvents(context.Context, *SearchTxEventsRequest) (*SearchTxEventsResponse, error)
+	GetTxByHash(context.Context, *GetTxByHashRequest) (*GetTxByHashResponse, error)
+	HasBlock(context.Context, *HasBlockRequest) (*HasBlockResponse, error)
+}
+
+// codec encodes the messages with gogoproto, which the default codec of gRPC
+// can't do for the gogoproto types they embed, e.g. the timestamps of the
+// headers. It keeps the name of the default codec, so that the wire format is
+// standard protobuf.
+type codec struct{}
+
+func (codec) Marshal(v interface{}) ([]byte, error) {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a protobuf message", v)
+	}
+	return proto.Marshal(msg)
+}
+
+func (codec) Unmarshal(data []byte, v interface{}) error {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("%T is not a protobuf message", v)
+	}
+	return proto.Unmarshal(data, msg)
+}
+
+func (codec) Name() string { return "proto" }
+
+// handler returns the handler of a unary method of the service, decoding its
+// request in the message returned by newReq.
+func handler(
+	method string,
+	newReq func() proto.Message,
+	call func(eventSinkServer, context.Context, proto.Message) (proto.Message, error),
+) grpc.MethodDesc {
+	return grpc.MethodDesc{
+		MethodName: method,
+		Handler: func(
+			srv interface{},
+			ctx context.Context,
+			dec func(interface{}) error,
+			interceptor grpc.UnaryServerInterceptor,
+		) (interface{}, error) {
+			req := newReq()
+			if err := dec(req); err != nil {
+				return nil, err
+			}
+			if interceptor == nil {
+				return call(srv.(eventSinkServer), ctx, req)
+			}
+			info := &grpc.UnaryServerInfo{
+				Server:     srv,
+				FullMethod: "/" + serviceName + "/" + method,
+			}
+			return interceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return call(srv.(eventSinkServer), ctx, req.(proto.Message))
+			})
+		},
+	}
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*eventSinkServer)(nil),
+	Methods: []grpc.MethodDesc{
+		handler("IndexBlockEvents",
+			func() proto.Message { return new(IndexBlockEventsRequest) },
+			func(s eventSinkServer, ctx context.Context, req proto.Message) (proto.Message, error) {
+				return s.IndexBlockEvents(ctx, req.(*IndexBlockEventsRequest))
+			}),
+		handler("IndexTxEvents",
+			func() proto.Message { return new(IndexTxEventsRequest) },
+			func(s eventSinkServer, ctx context.Context, req proto.Message) (proto.Message, error) {
+				return s.IndexTxEvents(ctx, req.(*IndexTxEventsRequest))
+			}),
+		handler("SearchBlockEvents",
+			func() proto.Message { return new(SearchBlockEventsRequest) },
+			func(s eventSinkServer, ctx context.Context, req proto.Message) (proto.Message, error) {
+				return s.SearchBlockEvents(ctx, req.(*SearchBlockEventsRequest))
+			}),
+		handler("SearchTxEvents",
+			func() proto.Message { return new(SearchTxEventsRequest) },
+			func(s eventSinkServer, ctx context.Context, req proto.Message) (proto.Message, error) {
+				return s.SearchTxEvents(ctx, req.(*SearchTxEventsRequest))
+			}),
+		handler("GetTxByHash",
+			func() proto.Message { return new(GetTxByHashRequest) },
+			func(s eventSinkServer, ctx context.Context, req proto.Message) (proto.Message, error) {
+				return s.GetTxByHash(ctx, req.(*GetTxByHashRequest))
+			}),
+		handler("HasBlock",
+			func() proto.Message { return new(HasBlockRequest) },
+			func(s eventSinkServer, ctx context.Context, req proto.Message) (proto.Message, error) {
+				return s.HasBlock(ctx, req.(*HasBlockRequest))
+			}),
+	},
+	Metadata: "sink.proto",
+}
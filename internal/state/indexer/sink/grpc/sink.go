@@ -0,0 +1,111 @@
+// Package grpc implements an event sink forwarding the events to an event sink
+// running out of process, over gRPC, and the server serving an event sink to
+// it. The service is defined in sink.proto.
+package grpc
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/internal/pubsub/query"
+	"github.com/tendermint/tendermint/internal/state/indexer"
+	tmnet "github.com/tendermint/tendermint/libs/net"
+	"github.com/tendermint/tendermint/types"
+)
+
+var _ indexer.EventSink = (*EventSink)(nil)
+
+// EventSink is an event sink calling the EventSink service of a gRPC server,
+// e.g. one returned by NewServer.
+type EventSink struct {
+	conn    *grpc.ClientConn
+	chainID string
+}
+
+// NewEventSink returns an event sink connected to the server at addr, e.g.
+// tcp://127.0.0.1:26670 or unix:///path/to/sink.sock. Events written to the
+// sink are attributed to the specified chainID. The connection is established
+// lazily, so that the server can be started after the node.
+func NewEventSink(addr, chainID string) (*EventSink, error) {
+	conn, err := grpc.Dial(addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(_ context.Context, addr string) (net.Conn, error) {
+			return tmnet.Connect(addr)
+		}),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(codec{})),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &EventSink{
+		conn:    conn,
+		chainID: chainID,
+	}, nil
+}
+
+func (es *EventSink) invoke(ctx context.Context, method string, req, res interface{}) error {
+	return es.conn.Invoke(ctx, "/"+serviceName+"/"+method, req, res)
+}
+
+// Type returns the structure type for this sink, which is gRPC.
+func (es *EventSink) Type() indexer.EventSinkType { return indexer.GRPC }
+
+// IndexBlockEvents indexes the specified block header, part of the
+// indexer.EventSink interface.
+func (es *EventSink) IndexBlockEvents(h types.EventDataNewBlockHeader) error {
+	return es.invoke(context.Background(), "IndexBlockEvents", &IndexBlockEventsRequest{
+		ChainID:          es.chainID,
+		Header:           h.Header.ToProto(),
+		NumTxs:           h.NumTxs,
+		ResultBeginBlock: &h.ResultBeginBlock,
+		ResultEndBlock:   &h.ResultEndBlock,
+	}, new(IndexBlockEventsResponse))
+}
+
+// IndexTxEvents indexes the specified transaction results, part of the
+// indexer.EventSink interface.
+func (es *EventSink) IndexTxEvents(txrs []*abci.TxResult) error {
+	return es.invoke(context.Background(), "IndexTxEvents", &IndexTxEventsRequest{
+		ChainID:   es.chainID,
+		TxResults: txrs,
+	}, new(IndexTxEventsResponse))
+}
+
+// SearchBlockEvents is part of the indexer.EventSink interface.
+func (es *EventSink) SearchBlockEvents(ctx context.Context, q *query.Query, matchEvents bool) ([]int64, error) {
+	res := new(SearchBlockEventsResponse)
+	err := es.invoke(ctx, "SearchBlockEvents", &SearchBlockEventsRequest{
+		Query:       q.String(),
+		MatchEvents: matchEvents,
+	}, res)
+	return res.Heights, err
+}
+
+// SearchTxEvents is part of the indexer.EventSink interface.
+func (es *EventSink) SearchTxEvents(ctx context.Context, q *query.Query) ([]*abci.TxResult, error) {
+	res := new(SearchTxEventsResponse)
+	err := es.invoke(ctx, "SearchTxEvents", &SearchTxEventsRequest{Query: q.String()}, res)
+	return res.TxResults, err
+}
+
+// GetTxByHash is part of the indexer.EventSink interface. It returns nil if
+// the transaction isn't indexed.
+func (es *EventSink) GetTxByHash(hash []byte) (*abci.TxResult, error) {
+	res := new(GetTxByHashResponse)
+	err := es.invoke(context.Background(), "GetTxByHash", &GetTxByHashRequest{Hash: hash}, res)
+	return res.TxResult, err
+}
+
+// HasBlock is part of the indexer.EventSink interface.
+func (es *EventSink) HasBlock(h int64) (bool, error) {
+	res := new(HasBlockResponse)
+	err := es.invoke(context.Background(), "HasBlock", &HasBlockRequest{Height: h}, res)
+	return res.HasBlock, err
+}
+
+// Stop closes the connection to the server.
+func (es *EventSink) Stop() error { return es.conn.Close() }
@@ -0,0 +1,92 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/internal/pubsub/query"
+	"github.com/tendermint/tendermint/internal/state/indexer"
+	"github.com/tendermint/tendermint/internal/state/indexer/sink/kv"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestEventSink(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the events are forwarded to a kv sink behind the server
+	kvSink, err := kv.NewEventSink(dbm.NewMemDB())
+	require.NoError(t, err)
+	socket := filepath.Join(t.TempDir(), "sink.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	server := NewServer(kvSink)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	es, err := NewEventSink("unix://"+socket, "test-chain")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, es.Stop()) })
+	assert.Equal(t, indexer.GRPC, es.Type())
+
+	header := types.MakeBlock(1, nil, &types.Commit{}, nil).Header
+	header.ChainID = "test-chain"
+	header.ProposerAddress = make([]byte, crypto.AddressSize)
+	require.NoError(t, es.IndexBlockEvents(types.EventDataNewBlockHeader{
+		Header: header,
+		ResultEndBlock: abci.ResponseEndBlock{
+			Events: []abci.Event{{
+				Type:       "end_event",
+				Attributes: []abci.EventAttribute{{Key: "foo", Value: "100", Index: true}},
+			}},
+		},
+	}))
+	has, err := es.HasBlock(1)
+	require.NoError(t, err)
+	assert.True(t, has)
+	has, err = es.HasBlock(2)
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	heights, err := es.SearchBlockEvents(ctx, query.MustCompile(`end_event.foo = 100`), false)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1}, heights)
+
+	txr := &abci.TxResult{
+		Height: 1,
+		Tx:     types.Tx("HELLO WORLD"),
+		Result: abci.ResponseDeliverTx{
+			Events: []abci.Event{{
+				Type:       "account",
+				Attributes: []abci.EventAttribute{{Key: "number", Value: "1", Index: true}},
+			}},
+		},
+	}
+	require.NoError(t, es.IndexTxEvents([]*abci.TxResult{txr}))
+
+	got, err := es.GetTxByHash(types.Tx("HELLO WORLD").Hash())
+	require.NoError(t, err)
+	assert.Equal(t, txr.Tx, got.Tx)
+	assert.Equal(t, txr.Height, got.Height)
+	got, err = es.GetTxByHash(types.Tx("unknown").Hash())
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	results, err := es.SearchTxEvents(ctx, query.MustCompile(`account.number = 1`))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, txr.Tx, results[0].Tx)
+
+	// the errors of the sink are returned
+	_, err = es.GetTxByHash(nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), indexer.ErrorEmptyHash.Error())
+}
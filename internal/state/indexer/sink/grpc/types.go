@@ -0,0 +1,110 @@
+package grpc
+
+import (
+	"github.com/gogo/protobuf/proto"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+// The messages of the EventSink service defined in sink.proto. They are
+// written by hand rather than generated, and encoded by reflection on their
+// protobuf tags.
+
+type IndexBlockEventsRequest struct {
+	ChainID          string                   `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Header           *tmproto.Header          `protobuf:"bytes,2,opt,name=header,proto3" json:"header,omitempty"`
+	NumTxs           int64                    `protobuf:"varint,3,opt,name=num_txs,json=numTxs,proto3" json:"num_txs,omitempty"`
+	ResultBeginBlock *abci.ResponseBeginBlock `protobuf:"bytes,4,opt,name=result_begin_block,json=resultBeginBlock,proto3" json:"result_begin_block,omitempty"`
+	ResultEndBlock   *abci.ResponseEndBlock   `protobuf:"bytes,5,opt,name=result_end_block,json=resultEndBlock,proto3" json:"result_end_block,omitempty"`
+}
+
+func (m *IndexBlockEventsRequest) Reset()         { *m = IndexBlockEventsRequest{} }
+func (m *IndexBlockEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*IndexBlockEventsRequest) ProtoMessage()    {}
+
+type IndexBlockEventsResponse struct{}
+
+func (m *IndexBlockEventsResponse) Reset()         { *m = IndexBlockEventsResponse{} }
+func (m *IndexBlockEventsResponse) String() string { return proto.CompactTextString(m) }
+func (*IndexBlockEventsResponse) ProtoMessage()    {}
+
+type IndexTxEventsRequest struct {
+	ChainID   string           `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	TxResults []*abci.TxResult `protobuf:"bytes,2,rep,name=tx_results,json=txResults,proto3" json:"tx_results,omitempty"`
+}
+
+func (m *IndexTxEventsRequest) Reset()         { *m = IndexTxEventsRequest{} }
+func (m *IndexTxEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*IndexTxEventsRequest) ProtoMessage()    {}
+
+type IndexTxEventsResponse struct{}
+
+func (m *IndexTxEventsResponse) Reset()         { *m = IndexTxEventsResponse{} }
+func (m *IndexTxEventsResponse) String() string { return proto.CompactTextString(m) }
+func (*IndexTxEventsResponse) ProtoMessage()    {}
+
+type SearchBlockEventsRequest struct {
+	Query       string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	MatchEvents bool   `protobuf:"varint,2,opt,name=match_events,json=matchEvents,proto3" json:"match_events,omitempty"`
+}
+
+func (m *SearchBlockEventsRequest) Reset()         { *m = SearchBlockEventsRequest{} }
+func (m *SearchBlockEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*SearchBlockEventsRequest) ProtoMessage()    {}
+
+type SearchBlockEventsResponse struct {
+	Heights []int64 `protobuf:"varint,1,rep,packed,name=heights,proto3" json:"heights,omitempty"`
+}
+
+func (m *SearchBlockEventsResponse) Reset()         { *m = SearchBlockEventsResponse{} }
+func (m *SearchBlockEventsResponse) String() string { return proto.CompactTextString(m) }
+func (*SearchBlockEventsResponse) ProtoMessage()    {}
+
+type SearchTxEventsRequest struct {
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+}
+
+func (m *SearchTxEventsRequest) Reset()         { *m = SearchTxEventsRequest{} }
+func (m *SearchTxEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*SearchTxEventsRequest) ProtoMessage()    {}
+
+type SearchTxEventsResponse struct {
+	TxResults []*abci.TxResult `protobuf:"bytes,1,rep,name=tx_results,json=txResults,proto3" json:"tx_results,omitempty"`
+}
+
+func (m *SearchTxEventsResponse) Reset()         { *m = SearchTxEventsResponse{} }
+func (m *SearchTxEventsResponse) String() string { return proto.CompactTextString(m) }
+func (*SearchTxEventsResponse) ProtoMessage()    {}
+
+type GetTxByHashRequest struct {
+	Hash []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+}
+
+func (m *GetTxByHashRequest) Reset()         { *m = GetTxByHashRequest{} }
+func (m *GetTxByHashRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTxByHashRequest) ProtoMessage()    {}
+
+type GetTxByHashResponse struct {
+	TxResult *abci.TxResult `protobuf:"bytes,1,opt,name=tx_result,json=txResult,proto3" json:"tx_result,omitempty"`
+}
+
+func (m *GetTxByHashResponse) Reset()         { *m = GetTxByHashResponse{} }
+func (m *GetTxByHashResponse) String() string { return proto.CompactTextString(m) }
+func (*GetTxByHashResponse) ProtoMessage()    {}
+
+type HasBlockRequest struct {
+	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (m *HasBlockRequest) Reset()         { *m = HasBlockRequest{} }
+func (m *HasBlockRequest) String() string { return proto.CompactTextString(m) }
+func (*HasBlockRequest) ProtoMessage()    {}
+
+type HasBlockResponse struct {
+	HasBlock bool `protobuf:"varint,1,opt,name=has_block,json=hasBlock,proto3" json:"has_block,omitempty"`
+}
+
+func (m *HasBlockResponse) Reset()         { *m = HasBlockResponse{} }
+func (m *HasBlockResponse) String() string { return proto.CompactTextString(m) }
+func (*HasBlockResponse) ProtoMessage()    {}
@@ -2,6 +2,7 @@ package kv
 
 import (
 	"context"
+	"fmt"
 
 	dbm "github.com/tendermint/tm-db"
 
@@ -23,12 +24,18 @@ type EventSink struct {
 	store dbm.DB
 }
 
-func NewEventSink(store dbm.DB) indexer.EventSink {
+// NewEventSink returns an EventSink of the store, migrating the keys of block
+// events indexed by former versions on first start.
+func NewEventSink(store dbm.DB) (indexer.EventSink, error) {
+	bi := kvb.New(store)
+	if err := bi.Migrate(); err != nil {
+		return nil, fmt.Errorf("failed to migrate block event index: %w", err)
+	}
 	return &EventSink{
 		txi:   kvt.NewTxIndex(store),
-		bi:    kvb.New(store),
+		bi:    bi,
 		store: store,
-	}
+	}, nil
 }
 
 func (kves *EventSink) Type() indexer.EventSinkType {
@@ -43,8 +50,8 @@ func (kves *EventSink) IndexTxEvents(results []*abci.TxResult) error {
 	return kves.txi.Index(results)
 }
 
-func (kves *EventSink) SearchBlockEvents(ctx context.Context, q *query.Query) ([]int64, error) {
-	return kves.bi.Search(ctx, q)
+func (kves *EventSink) SearchBlockEvents(ctx context.Context, q *query.Query, matchEvents bool) ([]int64, error) {
+	return kves.bi.Search(ctx, q, matchEvents)
 }
 
 func (kves *EventSink) SearchTxEvents(ctx context.Context, q *query.Query) ([]*abci.TxResult, error) {
@@ -18,18 +18,21 @@ import (
 )
 
 func TestType(t *testing.T) {
-	kvSink := NewEventSink(dbm.NewMemDB())
+	kvSink, err := NewEventSink(dbm.NewMemDB())
+	require.NoError(t, err)
 	assert.Equal(t, indexer.KV, kvSink.Type())
 }
 
 func TestStop(t *testing.T) {
-	kvSink := NewEventSink(dbm.NewMemDB())
+	kvSink, err := NewEventSink(dbm.NewMemDB())
+	require.NoError(t, err)
 	assert.Nil(t, kvSink.Stop())
 }
 
 func TestBlockFuncs(t *testing.T) {
 	store := dbm.NewPrefixDB(dbm.NewMemDB(), []byte("block_events"))
-	indexer := NewEventSink(store)
+	indexer, err := NewEventSink(store)
+	require.NoError(t, err)
 
 	require.NoError(t, indexer.IndexBlockEvents(types.EventDataNewBlockHeader{
 		Header: types.Header{Height: 1},
@@ -151,22 +154,49 @@ func TestBlockFuncs(t *testing.T) {
 	for name, tc := range testCases {
 		tc := tc
 		t.Run(name, func(t *testing.T) {
-			results, err := indexer.SearchBlockEvents(context.Background(), tc.q)
+			results, err := indexer.SearchBlockEvents(context.Background(), tc.q, false)
 			require.NoError(t, err)
 			require.Equal(t, tc.results, results)
 		})
 	}
 }
 
+func TestBlockAndTxEventsWithSameKeys(t *testing.T) {
+	indexer, err := NewEventSink(dbm.NewMemDB())
+	require.NoError(t, err)
+
+	events := []abci.Event{
+		{Type: "account", Attributes: []abci.EventAttribute{{Key: "number", Value: "1", Index: true}}},
+	}
+	txResult := txResultWithEvents(events)
+	require.NoError(t, indexer.IndexTxEvents([]*abci.TxResult{txResult}))
+	require.NoError(t, indexer.IndexBlockEvents(types.EventDataNewBlockHeader{
+		Header:           types.Header{Height: 1},
+		ResultBeginBlock: abci.ResponseBeginBlock{Events: events},
+		ResultEndBlock:   abci.ResponseEndBlock{Events: events},
+	}))
+
+	for _, q := range []string{"account.number = 1", "account.number EXISTS", "account.number >= 1"} {
+		txResults, err := indexer.SearchTxEvents(context.Background(), query.MustCompile(q))
+		require.NoError(t, err, q)
+		assert.Equal(t, []*abci.TxResult{txResult}, txResults, q)
+
+		heights, err := indexer.SearchBlockEvents(context.Background(), query.MustCompile(q), false)
+		require.NoError(t, err, q)
+		assert.Equal(t, []int64{1}, heights, q)
+	}
+}
+
 func TestTxSearchWithCancelation(t *testing.T) {
-	indexer := NewEventSink(dbm.NewMemDB())
+	indexer, err := NewEventSink(dbm.NewMemDB())
+	require.NoError(t, err)
 
 	txResult := txResultWithEvents([]abci.Event{
 		{Type: "account", Attributes: []abci.EventAttribute{{Key: "number", Value: "1", Index: true}}},
 		{Type: "account", Attributes: []abci.EventAttribute{{Key: "owner", Value: "Ivan", Index: true}}},
 		{Type: "", Attributes: []abci.EventAttribute{{Key: "not_allowed", Value: "Vlad", Index: true}}},
 	})
-	err := indexer.IndexTxEvents([]*abci.TxResult{txResult})
+	err = indexer.IndexTxEvents([]*abci.TxResult{txResult})
 	require.NoError(t, err)
 
 	r, e := indexer.GetTxByHash(types.Tx("HELLO WORLD").Hash())
@@ -182,7 +212,8 @@ func TestTxSearchWithCancelation(t *testing.T) {
 
 func TestTxSearchDeprecatedIndexing(t *testing.T) {
 	esdb := dbm.NewMemDB()
-	indexer := NewEventSink(esdb)
+	indexer, err := NewEventSink(esdb)
+	require.NoError(t, err)
 
 	// index tx using events indexing (composite key)
 	txResult1 := txResultWithEvents([]abci.Event{
@@ -190,7 +221,7 @@ func TestTxSearchDeprecatedIndexing(t *testing.T) {
 	})
 	hash1 := types.Tx(txResult1.Tx).Hash()
 
-	err := indexer.IndexTxEvents([]*abci.TxResult{txResult1})
+	err = indexer.IndexTxEvents([]*abci.TxResult{txResult1})
 	require.NoError(t, err)
 
 	// index tx also using deprecated indexing (event as key)
@@ -261,14 +292,15 @@ func TestTxSearchDeprecatedIndexing(t *testing.T) {
 }
 
 func TestTxSearchOneTxWithMultipleSameTagsButDifferentValues(t *testing.T) {
-	indexer := NewEventSink(dbm.NewMemDB())
+	indexer, err := NewEventSink(dbm.NewMemDB())
+	require.NoError(t, err)
 
 	txResult := txResultWithEvents([]abci.Event{
 		{Type: "account", Attributes: []abci.EventAttribute{{Key: "number", Value: "1", Index: true}}},
 		{Type: "account", Attributes: []abci.EventAttribute{{Key: "number", Value: "2", Index: true}}},
 	})
 
-	err := indexer.IndexTxEvents([]*abci.TxResult{txResult})
+	err = indexer.IndexTxEvents([]*abci.TxResult{txResult})
 	require.NoError(t, err)
 
 	ctx := context.Background()
@@ -283,7 +315,8 @@ func TestTxSearchOneTxWithMultipleSameTagsButDifferentValues(t *testing.T) {
 }
 
 func TestTxSearchMultipleTxs(t *testing.T) {
-	indexer := NewEventSink(dbm.NewMemDB())
+	indexer, err := NewEventSink(dbm.NewMemDB())
+	require.NoError(t, err)
 
 	// indexed first, but bigger height (to test the order of transactions)
 	txResult := txResultWithEvents([]abci.Event{
@@ -293,7 +326,7 @@ func TestTxSearchMultipleTxs(t *testing.T) {
 	txResult.Tx = types.Tx("Bob's account")
 	txResult.Height = 2
 	txResult.Index = 1
-	err := indexer.IndexTxEvents([]*abci.TxResult{txResult})
+	err = indexer.IndexTxEvents([]*abci.TxResult{txResult})
 	require.NoError(t, err)
 
 	// indexed second, but smaller height (to test the order of transactions)
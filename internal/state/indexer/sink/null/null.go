@@ -30,7 +30,7 @@ func (nes *EventSink) IndexTxEvents(results []*abci.TxResult) error {
 	return nil
 }
 
-func (nes *EventSink) SearchBlockEvents(ctx context.Context, q *query.Query) ([]int64, error) {
+func (nes *EventSink) SearchBlockEvents(ctx context.Context, q *query.Query, matchEvents bool) ([]int64, error) {
 	return nil, nil
 }
 
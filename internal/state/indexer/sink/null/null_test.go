@@ -17,7 +17,7 @@ func TestNullEventSink(t *testing.T) {
 
 	assert.Nil(t, nullIndexer.IndexTxEvents(nil))
 	assert.Nil(t, nullIndexer.IndexBlockEvents(types.EventDataNewBlockHeader{}))
-	val1, err1 := nullIndexer.SearchBlockEvents(ctx, nil)
+	val1, err1 := nullIndexer.SearchBlockEvents(ctx, nil, false)
 	assert.Nil(t, val1)
 	assert.Nil(t, err1)
 	val2, err2 := nullIndexer.SearchTxEvents(ctx, nil)
@@ -11,8 +11,10 @@ import (
 
 	"github.com/gogo/protobuf/proto"
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/encoding"
 	"github.com/tendermint/tendermint/internal/pubsub/query"
 	"github.com/tendermint/tendermint/internal/state/indexer"
+	"github.com/tendermint/tendermint/internal/state/indexer/sink/sqlquery"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -21,21 +23,29 @@ const (
 	tableTxResults  = "tx_results"
 	tableEvents     = "events"
 	tableAttributes = "attributes"
+	tableValidators = "validator_updates"
 	driverName      = "postgres"
 )
 
+// materializedViews are the materialized views defined in
+// state/indexer/sink/psql/views.sql, which are refreshed by the sink.
+var materializedViews = []string{"explorer_blocks", "explorer_validators", "explorer_proposers"}
+
 // EventSink is an indexer backend providing the tx/block index services.  This
 // implementation stores records in a PostgreSQL database using the schema
 // defined in state/indexer/sink/psql/schema.sql.
 type EventSink struct {
-	store   *sql.DB
-	chainID string
+	store        *sql.DB
+	chainID      string
+	refreshViews int64
 }
 
 // NewEventSink constructs an event sink associated with the PostgreSQL
 // database specified by connStr. Events written to the sink are attributed to
-// the specified chainID.
-func NewEventSink(connStr, chainID string) (*EventSink, error) {
+// the specified chainID. If refreshViews > 0, the materialized views of
+// state/indexer/sink/psql/views.sql, which must then be installed, are
+// refreshed every refreshViews blocks.
+func NewEventSink(connStr, chainID string, refreshViews int64) (*EventSink, error) {
 	db, err := sql.Open(driverName, connStr)
 	if err != nil {
 		return nil, err
@@ -44,8 +54,9 @@ func NewEventSink(connStr, chainID string) (*EventSink, error) {
 	}
 
 	return &EventSink{
-		store:   db,
-		chainID: chainID,
+		store:        db,
+		chainID:      chainID,
+		refreshViews: refreshViews,
 	}, nil
 }
 
@@ -142,20 +153,53 @@ func makeIndexedEvent(compositeKey, value string) abci.Event {
 	}}
 }
 
+// insertValidatorUpdates inserts the validator updates of the block with the
+// given ID into the database associated with dbtx.
+func insertValidatorUpdates(dbtx *sql.Tx, blockID uint32, updates []abci.ValidatorUpdate) error {
+	for _, update := range updates {
+		pubKey, err := encoding.PubKeyFromProto(update.PubKey)
+		if err != nil {
+			return err
+		}
+		if _, err := dbtx.Exec(`
+INSERT INTO `+tableValidators+` (block_id, address, pub_key_type, pub_key, power)
+  VALUES ($1, $2, $3, $4, $5)
+  ON CONFLICT DO NOTHING;
+`, blockID, pubKey.Address().String(), pubKey.Type(), fmt.Sprintf("%X", pubKey.Bytes()), update.Power); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // IndexBlockEvents indexes the specified block header, part of the
 // indexer.EventSink interface.
 func (es *EventSink) IndexBlockEvents(h types.EventDataNewBlockHeader) error {
+	if err := es.indexBlock(h); err != nil {
+		return err
+	}
+	if es.refreshViews > 0 && h.Header.Height%es.refreshViews == 0 {
+		if err := es.RefreshViews(context.Background()); err != nil {
+			return fmt.Errorf("refreshing materialized views: %w", err)
+		}
+	}
+	return nil
+}
+
+func (es *EventSink) indexBlock(h types.EventDataNewBlockHeader) error {
 	ts := time.Now().UTC()
 
 	return runInTransaction(es.store, func(dbtx *sql.Tx) error {
 		// Add the block to the blocks table and report back its row ID for use
 		// in indexing the events for the block.
 		blockID, err := queryWithID(dbtx, `
-INSERT INTO `+tableBlocks+` (height, chain_id, created_at)
-  VALUES ($1, $2, $3)
+INSERT INTO `+tableBlocks+` (height, chain_id, created_at, hash, time, num_txs,
+    proposer_address, validators_hash, app_hash)
+  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
   ON CONFLICT DO NOTHING
   RETURNING rowid;
-`, h.Header.Height, es.chainID, ts)
+`, h.Header.Height, es.chainID, ts, h.Header.Hash().String(), h.Header.Time.UTC(), h.NumTxs,
+			h.Header.ProposerAddress.String(), h.Header.ValidatorsHash.String(), h.Header.AppHash.String())
 		if err == sql.ErrNoRows {
 			return nil // we already saw this block; quietly succeed
 		} else if err != nil {
@@ -175,10 +219,25 @@ INSERT INTO `+tableBlocks+` (height, chain_id, created_at)
 		if err := insertEvents(dbtx, blockID, 0, h.ResultEndBlock.Events); err != nil {
 			return fmt.Errorf("end-block events: %w", err)
 		}
+		if err := insertValidatorUpdates(dbtx, blockID, h.ResultEndBlock.ValidatorUpdates); err != nil {
+			return fmt.Errorf("validator updates: %w", err)
+		}
 		return nil
 	})
 }
 
+// RefreshViews refreshes the materialized views of
+// state/indexer/sink/psql/views.sql, without blocking the queries of the views
+// in the meantime.
+func (es *EventSink) RefreshViews(ctx context.Context) error {
+	for _, view := range materializedViews {
+		if _, err := es.store.ExecContext(ctx, `REFRESH MATERIALIZED VIEW CONCURRENTLY `+view+`;`); err != nil {
+			return fmt.Errorf("refreshing %s: %w", view, err)
+		}
+	}
+	return nil
+}
+
 func (es *EventSink) IndexTxEvents(txrs []*abci.TxResult) error {
 	ts := time.Now().UTC()
 
@@ -204,11 +263,13 @@ SELECT rowid FROM `+tableBlocks+` WHERE height = $1 AND chain_id = $2;
 
 			// Insert a record for this tx_result and capture its ID for indexing events.
 			txID, err := queryWithID(dbtx, `
-INSERT INTO `+tableTxResults+` (block_id, index, created_at, tx_hash, tx_result)
-  VALUES ($1, $2, $3, $4, $5)
+INSERT INTO `+tableTxResults+` (block_id, index, created_at, tx_hash, tx_result,
+    code, codespace, gas_wanted, gas_used)
+  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
   ON CONFLICT DO NOTHING
   RETURNING rowid;
-`, blockID, txr.Index, ts, txHash, resultData)
+`, blockID, txr.Index, ts, txHash, resultData,
+				txr.Result.Code, txr.Result.Codespace, txr.Result.GasWanted, txr.Result.GasUsed)
 			if err == sql.ErrNoRows {
 				return nil // we already saw this transaction; quietly succeed
 			} else if err != nil {
@@ -235,14 +296,75 @@ INSERT INTO `+tableTxResults+` (block_id, index, created_at, tx_hash, tx_result)
 	return nil
 }
 
-// SearchBlockEvents is not implemented by this sink, and reports an error for all queries.
-func (es *EventSink) SearchBlockEvents(ctx context.Context, q *query.Query) ([]int64, error) {
-	return nil, errors.New("block search is not supported via the postgres event sink")
+// SearchBlockEvents returns the heights of the blocks whose BeginBlock and
+// EndBlock events match q, in ascending order. If matchEvents is true, the
+// event conditions of each query clause must be satisfied by a single event.
+// Queries comparing timestamps are not supported.
+func (es *EventSink) SearchBlockEvents(ctx context.Context, q *query.Query, matchEvents bool) ([]int64, error) {
+	if q == nil {
+		return nil, errors.New("block search requires a query")
+	}
+
+	stmt, args, err := sqlquery.BlockQuery(dialect, es.chainID, q.Syntax(), matchEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := es.store.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, fmt.Errorf("searching blocks: %w", err)
+	}
+	defer rows.Close()
+
+	heights := make([]int64, 0)
+	for rows.Next() {
+		var height int64
+		if err := rows.Scan(&height); err != nil {
+			return nil, fmt.Errorf("searching blocks: %w", err)
+		}
+		heights = append(heights, height)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("searching blocks: %w", err)
+	}
+	return heights, nil
 }
 
-// SearchTxEvents is not implemented by this sink, and reports an error for all queries.
+// SearchTxEvents returns the results of the transactions whose events match
+// q, ordered by height and index. The query is evaluated by the database.
+// Queries comparing timestamps are not supported.
 func (es *EventSink) SearchTxEvents(ctx context.Context, q *query.Query) ([]*abci.TxResult, error) {
-	return nil, errors.New("tx search is not supported via the postgres event sink")
+	if q == nil {
+		return nil, errors.New("tx search requires a query")
+	}
+
+	stmt, args, err := sqlquery.TxQuery(dialect, es.chainID, q.Syntax())
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := es.store.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, fmt.Errorf("searching transactions: %w", err)
+	}
+	defer rows.Close()
+
+	results := make([]*abci.TxResult, 0)
+	for rows.Next() {
+		var resultData []byte
+		if err := rows.Scan(&resultData); err != nil {
+			return nil, fmt.Errorf("searching transactions: %w", err)
+		}
+		txr := new(abci.TxResult)
+		if err := proto.Unmarshal(resultData, txr); err != nil {
+			return nil, fmt.Errorf("reading tx_result: %w", err)
+		}
+		results = append(results, txr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("searching transactions: %w", err)
+	}
+	return results, nil
 }
 
 // GetTxByHash is not implemented by this sink, and reports an error for all queries.
@@ -18,6 +18,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/encoding"
+	"github.com/tendermint/tendermint/internal/pubsub/query"
 	"github.com/tendermint/tendermint/internal/state/indexer"
 	"github.com/tendermint/tendermint/types"
 
@@ -93,7 +96,7 @@ func TestMain(m *testing.M) {
 	var db *sql.DB
 
 	if err := pool.Retry(func() error {
-		sink, err := NewEventSink(conn, chainID)
+		sink, err := NewEventSink(conn, chainID, 0)
 		if err != nil {
 			return err
 		}
@@ -158,10 +161,9 @@ func TestIndexing(t *testing.T) {
 		verifyNotImplemented(t, "hasBlock", func() (bool, error) { return indexer.HasBlock(1) })
 		verifyNotImplemented(t, "hasBlock", func() (bool, error) { return indexer.HasBlock(2) })
 
-		verifyNotImplemented(t, "block search", func() (bool, error) {
-			v, err := indexer.SearchBlockEvents(ctx, nil)
-			return v != nil, err
-		})
+		heights, err := indexer.SearchBlockEvents(ctx, query.MustCompile(`end_event.foo >= 100`), false)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1}, heights)
 
 		require.NoError(t, verifyTimeStamp(tableBlocks))
 
@@ -192,10 +194,12 @@ func TestIndexing(t *testing.T) {
 			txr, err := indexer.GetTxByHash(types.Tx(txResult.Tx).Hash())
 			return txr != nil, err
 		})
-		verifyNotImplemented(t, "tx search", func() (bool, error) {
-			txr, err := indexer.SearchTxEvents(ctx, nil)
-			return txr != nil, err
-		})
+		results, err := indexer.SearchTxEvents(ctx, query.MustCompile(`account.owner = 'Ivan' AND tx.height >= 1`))
+		require.NoError(t, err)
+		assert.Equal(t, []*abci.TxResult{txResult}, results)
+		results, err = indexer.SearchTxEvents(ctx, query.MustCompile(`account.number > 1`))
+		require.NoError(t, err)
+		assert.Empty(t, results)
 
 		// try to insert the duplicate tx events.
 		err = indexer.IndexTxEvents([]*abci.TxResult{txResult})
@@ -203,6 +207,137 @@ func TestIndexing(t *testing.T) {
 	})
 }
 
+func TestSearchBlockEvents(t *testing.T) {
+	ctx := context.Background()
+	indexer := &EventSink{store: testDB(), chainID: "search-" + chainID}
+
+	transfer := func(sender, amount string) abci.Event {
+		return abci.Event{Type: "transfer", Attributes: []abci.EventAttribute{
+			{Key: "sender", Value: sender, Index: true},
+			{Key: "amount", Value: amount, Index: true},
+		}}
+	}
+	for h := int64(1); h <= 4; h++ {
+		require.NoError(t, indexer.IndexBlockEvents(types.EventDataNewBlockHeader{
+			Header: types.Header{Height: h},
+			ResultBeginBlock: abci.ResponseBeginBlock{
+				Events: []abci.Event{transfer(fmt.Sprintf("addr%d", h), fmt.Sprint(h*10))},
+			},
+			ResultEndBlock: abci.ResponseEndBlock{
+				Events: []abci.Event{transfer("fee", "5")},
+			},
+		}))
+	}
+
+	testCases := []struct {
+		q           string
+		matchEvents bool
+		want        []int64
+	}{
+		{`block.height = 2`, false, []int64{2}},
+		{`block.height > 2`, false, []int64{3, 4}},
+		{`transfer.amount > 20`, false, []int64{3, 4}},
+		{`transfer.sender = 'addr1' OR transfer.sender = 'addr4'`, false, []int64{1, 4}},
+		{`transfer.sender LIKE 'addr%' AND NOT block.height <= 3`, false, []int64{4}},
+		{`NOT transfer.sender CONTAINS '2'`, false, []int64{1, 3, 4}},
+		{`transfer.sender = 'fee' AND transfer.amount = 30`, false, []int64{3}},
+		{`transfer.sender = 'fee' AND transfer.amount = 30`, true, []int64{}},
+		{`transfer.sender = 'fee' AND transfer.amount = 5`, true, []int64{1, 2, 3, 4}},
+		{`transfer.sender = 'addr3' AND transfer.amount = 30 AND block.height = 3`, true, []int64{3}},
+	}
+	for _, tc := range testCases {
+		heights, err := indexer.SearchBlockEvents(ctx, query.MustCompile(tc.q), tc.matchEvents)
+		require.NoError(t, err, tc.q)
+		assert.Equal(t, tc.want, heights, "query %q (match events: %v)", tc.q, tc.matchEvents)
+	}
+
+	_, err := indexer.SearchBlockEvents(ctx, query.MustCompile(`transfer.time > TIME 2021-01-01T00:00:00Z`), false)
+	assert.Error(t, err)
+}
+
+func TestExplorerViews(t *testing.T) {
+	ctx := context.Background()
+	indexer := &EventSink{store: testDB(), chainID: "explorer-" + chainID, refreshViews: 2}
+
+	pubKey := ed25519.GenPrivKey().PubKey()
+	update := func(power int64) []abci.ValidatorUpdate {
+		pk, err := encoding.PubKeyToProto(pubKey)
+		require.NoError(t, err)
+		return []abci.ValidatorUpdate{{PubKey: pk, Power: power}}
+	}
+	proposer := pubKey.Address()
+	for h, power := range []int64{10, 20} {
+		height := int64(h + 1)
+		require.NoError(t, indexer.IndexBlockEvents(types.EventDataNewBlockHeader{
+			Header: types.Header{
+				Height:          height,
+				Time:            time.Date(2021, 1, 1, 0, 0, int(height), 0, time.UTC),
+				ProposerAddress: proposer,
+			},
+			NumTxs:         2,
+			ResultEndBlock: abci.ResponseEndBlock{ValidatorUpdates: update(power)},
+		}))
+		txrs := make([]*abci.TxResult, 0, 2)
+		for i := uint32(0); i < 2; i++ {
+			txrs = append(txrs, &abci.TxResult{
+				Height: height,
+				Index:  i,
+				Tx:     types.Tx(fmt.Sprintf("tx%d-%d", height, i)),
+				Result: abci.ResponseDeliverTx{Code: i, GasWanted: 10, GasUsed: 5},
+			})
+		}
+		require.NoError(t, indexer.IndexTxEvents(txrs))
+	}
+
+	var code uint32
+	var gasUsed int64
+	require.NoError(t, testDB().QueryRow(`
+SELECT code, gas_used FROM block_txs WHERE chain_id = $1 AND tx_hash = $2;
+`, indexer.chainID, fmt.Sprintf("%X", types.Tx("tx2-1").Hash())).Scan(&code, &gasUsed))
+	assert.EqualValues(t, 1, code)
+	assert.EqualValues(t, 5, gasUsed)
+
+	// the views were refreshed at height 2, before its transactions were
+	// indexed
+	var numTxs, numFailedTxs, gasWanted int64
+	require.NoError(t, testDB().QueryRow(`
+SELECT num_txs, num_failed_txs, gas_wanted FROM explorer_blocks WHERE chain_id = $1 AND height = 1;
+`, indexer.chainID).Scan(&numTxs, &numFailedTxs, &gasWanted))
+	assert.EqualValues(t, 2, numTxs)
+	assert.EqualValues(t, 1, numFailedTxs)
+	assert.EqualValues(t, 20, gasWanted)
+
+	require.NoError(t, indexer.RefreshViews(ctx))
+	require.NoError(t, testDB().QueryRow(`
+SELECT gas_wanted FROM explorer_blocks WHERE chain_id = $1 AND height = 2;
+`, indexer.chainID).Scan(&gasWanted))
+	assert.EqualValues(t, 20, gasWanted)
+
+	var power, updatedAt int64
+	require.NoError(t, testDB().QueryRow(`
+SELECT power, updated_at FROM explorer_validators WHERE chain_id = $1 AND address = $2;
+`, indexer.chainID, proposer.String()).Scan(&power, &updatedAt))
+	assert.EqualValues(t, 20, power)
+	assert.EqualValues(t, 2, updatedAt)
+
+	var numBlocks int64
+	require.NoError(t, testDB().QueryRow(`
+SELECT num_blocks FROM explorer_proposers WHERE chain_id = $1 AND proposer_address = $2;
+`, indexer.chainID, proposer.String()).Scan(&numBlocks))
+	assert.EqualValues(t, 2, numBlocks)
+
+	// a validator removed from the set isn't a validator anymore
+	require.NoError(t, indexer.IndexBlockEvents(types.EventDataNewBlockHeader{
+		Header:         types.Header{Height: 3},
+		ResultEndBlock: abci.ResponseEndBlock{ValidatorUpdates: update(0)},
+	}))
+	require.NoError(t, indexer.RefreshViews(ctx))
+	err := testDB().QueryRow(`
+SELECT power FROM explorer_validators WHERE chain_id = $1;
+`, indexer.chainID).Scan(&power)
+	assert.Equal(t, sql.ErrNoRows, err)
+}
+
 func TestStop(t *testing.T) {
 	indexer := &EventSink{store: testDB()}
 	require.NoError(t, indexer.Stop())
@@ -228,27 +363,34 @@ func newTestBlockHeader() types.EventDataNewBlockHeader {
 	}
 }
 
-// readSchema loads the indexing database schema file
+// readSchema loads the indexing database schema file, and the file of the
+// materialized views installed on top of it.
 func readSchema() ([]*schema.Migration, error) {
-	const filename = "schema.sql"
-	contents, err := os.ReadFile(filename)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read sql file from '%s': %w", filename, err)
+	var migrations []*schema.Migration
+	for _, filename := range []string{"schema.sql", "views.sql"} {
+		contents, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read sql file from '%s': %w", filename, err)
+		}
+		migrations = append(migrations, &schema.Migration{
+			ID:     time.Now().Local().String() + " " + filename,
+			Script: string(contents),
+		})
 	}
-
-	return []*schema.Migration{{
-		ID:     time.Now().Local().String() + " db schema",
-		Script: string(contents),
-	}}, nil
+	return migrations, nil
 }
 
 // resetDB drops all the data from the test database.
 func resetDatabase(db *sql.DB) error {
-	_, err := db.Exec(`DROP TABLE IF EXISTS blocks,tx_results,events,attributes CASCADE;`)
+	_, err := db.Exec(`DROP MATERIALIZED VIEW IF EXISTS explorer_blocks,explorer_validators,explorer_proposers CASCADE;`)
+	if err != nil {
+		return fmt.Errorf("dropping materialized views: %v", err)
+	}
+	_, err = db.Exec(`DROP TABLE IF EXISTS blocks,tx_results,events,attributes,validator_updates CASCADE;`)
 	if err != nil {
 		return fmt.Errorf("dropping tables: %v", err)
 	}
-	_, err = db.Exec(`DROP VIEW IF EXISTS event_attributes,block_events,tx_events CASCADE;`)
+	_, err = db.Exec(`DROP VIEW IF EXISTS event_attributes,block_events,tx_events,block_txs,block_validator_updates CASCADE;`)
 	if err != nil {
 		return fmt.Errorf("dropping views: %v", err)
 	}
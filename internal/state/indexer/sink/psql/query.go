@@ -0,0 +1,24 @@
+package psql
+
+import (
+	"fmt"
+
+	"github.com/tendermint/tendermint/internal/state/indexer/sink/sqlquery"
+)
+
+// dialect describes the SQL of PostgreSQL to the query builder.
+var dialect = sqlquery.Dialect{
+	Name: "postgres",
+
+	Placeholder: func(n int) string { return fmt.Sprintf("$%d", n) },
+
+	NumericValue: `(CASE WHEN a.value ~ '^-?[0-9]+(\.[0-9]+)?$' THEN a.value::numeric END)`,
+
+	Text:    func(expr string) string { return expr + "::text" },
+	Numeric: func(expr string) string { return expr + "::numeric" },
+
+	Contains: func(s, substr string) string { return "strpos(" + s + ", " + substr + ") > 0" },
+
+	// The query language has no escape character in LIKE patterns.
+	Like: func(s, pattern string) string { return s + " LIKE " + pattern + ` ESCAPE ''` },
+}
@@ -4,7 +4,12 @@
   this schema before using the database to index events.
  */
 
--- The blocks table records metadata about each block.
+/*
+  The optional materialized views of views.sql, for common block explorer
+  queries, can be installed on top of this schema.
+ */
+
+-- The blocks table records the header of each block.
 -- The block record does not include its events or transactions (see tx_results).
 CREATE TABLE blocks (
   rowid      BIGSERIAL PRIMARY KEY,
@@ -15,12 +20,27 @@ CREATE TABLE blocks (
   -- When this block header was logged into the sink, in UTC.
   created_at TIMESTAMPTZ NOT NULL,
 
+  -- The hex-encoded hash of the block.
+  hash             VARCHAR NOT NULL,
+  -- The time of the block, from its header.
+  time             TIMESTAMPTZ NOT NULL,
+  -- The number of transactions in the block.
+  num_txs          BIGINT NOT NULL,
+  -- The hex-encoded address of the validator which proposed the block.
+  proposer_address VARCHAR NOT NULL,
+  -- The hex-encoded hashes of the validators of the block, and of the state of
+  -- the application after the previous block.
+  validators_hash  VARCHAR NOT NULL,
+  app_hash         VARCHAR NOT NULL,
+
   UNIQUE (height, chain_id)
 );
 
 -- Index blocks by height and chain, since we need to resolve block IDs when
 -- indexing transaction records and transaction events.
 CREATE INDEX idx_blocks_height_chain ON blocks(height, chain_id);
+CREATE INDEX idx_blocks_hash ON blocks(hash);
+CREATE INDEX idx_blocks_proposer_address ON blocks(proposer_address);
 
 -- The tx_results table records metadata about transaction results.  Note that
 -- the events from a transaction are stored separately.
@@ -38,9 +58,39 @@ CREATE TABLE tx_results (
   -- The protobuf wire encoding of the TxResult message.
   tx_result BYTEA NOT NULL,
 
+  -- The result of executing the transaction, from its TxResult message.
+  code       INTEGER NOT NULL,
+  codespace  VARCHAR NOT NULL,
+  gas_wanted BIGINT NOT NULL,
+  gas_used   BIGINT NOT NULL,
+
   UNIQUE (block_id, index)
 );
 
+-- Index transactions by hash, to look them up as explorers do.
+CREATE INDEX idx_tx_results_tx_hash ON tx_results(tx_hash);
+
+-- The validator_updates table records the changes of the validator set
+-- returned by the application at the end of each block. The validators of the
+-- genesis, set by InitChain, are not recorded.
+CREATE TABLE validator_updates (
+  rowid BIGSERIAL PRIMARY KEY,
+
+  -- The block at the end of which the validator was updated.
+  block_id BIGINT NOT NULL REFERENCES blocks(rowid),
+  -- The hex-encoded address and public key of the validator, and the type of
+  -- its key, e.g. "ed25519".
+  address      VARCHAR NOT NULL,
+  pub_key_type VARCHAR NOT NULL,
+  pub_key      VARCHAR NOT NULL,
+  -- The new voting power of the validator, 0 if it was removed.
+  power        BIGINT NOT NULL,
+
+  UNIQUE (block_id, pub_key)
+);
+
+CREATE INDEX idx_validator_updates_address ON validator_updates(address);
+
 -- The events table records events. All events (both block and transaction) are
 -- associated with a block ID; transaction events also have a transaction ID.
 CREATE TABLE events (
@@ -65,6 +115,12 @@ CREATE TABLE attributes (
    UNIQUE (event_id, key)
 );
 
+-- Index block events by block, and attributes by key and value, to support
+-- searching for blocks by their events.
+CREATE INDEX idx_events_block_id ON events(block_id) WHERE tx_id IS NULL;
+CREATE INDEX idx_events_tx_id ON events(tx_id) WHERE tx_id IS NOT NULL;
+CREATE INDEX idx_attributes_composite_key_value ON attributes(composite_key, value);
+
 -- A joined view of events and their attributes. Events that do not have any
 -- attributes are represented as a single row with empty key and value fields.
 CREATE VIEW event_attributes AS
@@ -83,3 +139,14 @@ CREATE VIEW tx_events AS
   FROM blocks JOIN tx_results ON (blocks.rowid = tx_results.block_id)
   JOIN event_attributes ON (tx_results.rowid = event_attributes.tx_id)
   WHERE event_attributes.tx_id IS NOT NULL;
+
+-- A joined view of all transactions and the blocks they belong to.
+CREATE VIEW block_txs AS
+  SELECT tx_results.rowid AS tx_id, height, chain_id, time, index, tx_hash,
+    code, codespace, gas_wanted, gas_used, tx_results.created_at
+  FROM blocks JOIN tx_results ON (blocks.rowid = tx_results.block_id);
+
+-- A joined view of all validator updates and the heights they were made at.
+CREATE VIEW block_validator_updates AS
+  SELECT height, chain_id, address, pub_key_type, pub_key, power
+  FROM blocks JOIN validator_updates ON (blocks.rowid = validator_updates.block_id);
@@ -0,0 +1,49 @@
+/*
+  This file defines the optional materialized views of the PostgreSQL ("psql")
+  event sink implementation in Tendermint, for common block explorer queries.
+  They are installed on top of the schema of schema.sql, and refreshed by the
+  sink every tx-index.psql-refresh-views blocks.
+
+  The views are refreshed concurrently, so that they can be queried while being
+  refreshed, which requires each of them to have a unique index.
+ */
+
+-- The explorer_blocks view summarizes each block with the results of its
+-- transactions.
+CREATE MATERIALIZED VIEW explorer_blocks AS
+  SELECT blocks.rowid AS block_id, blocks.height, blocks.chain_id, blocks.hash,
+    blocks.time, blocks.num_txs, blocks.proposer_address,
+    COUNT(tx_results.rowid) FILTER (WHERE tx_results.code <> 0) AS num_failed_txs,
+    COALESCE(SUM(tx_results.gas_wanted), 0) AS gas_wanted,
+    COALESCE(SUM(tx_results.gas_used), 0) AS gas_used
+  FROM blocks LEFT JOIN tx_results ON (blocks.rowid = tx_results.block_id)
+  GROUP BY blocks.rowid;
+
+CREATE UNIQUE INDEX idx_explorer_blocks_block_id ON explorer_blocks(block_id);
+CREATE INDEX idx_explorer_blocks_chain_height ON explorer_blocks(chain_id, height);
+
+-- The explorer_validators view records the validators of the latest indexed
+-- height, from the validator updates: the last update of each validator, if
+-- it didn't remove it. Validators of the genesis which were never updated are
+-- not included.
+CREATE MATERIALIZED VIEW explorer_validators AS
+  SELECT chain_id, address, pub_key_type, pub_key, power, height AS updated_at
+  FROM (
+    SELECT DISTINCT ON (chain_id, pub_key) chain_id, address, pub_key_type,
+      pub_key, power, height
+    FROM block_validator_updates
+    ORDER BY chain_id, pub_key, height DESC
+  ) AS latest
+  WHERE power > 0;
+
+CREATE UNIQUE INDEX idx_explorer_validators_chain_pub_key ON explorer_validators(chain_id, pub_key);
+CREATE INDEX idx_explorer_validators_address ON explorer_validators(address);
+
+-- The explorer_proposers view counts the blocks proposed by each validator.
+CREATE MATERIALIZED VIEW explorer_proposers AS
+  SELECT chain_id, proposer_address, COUNT(*) AS num_blocks,
+    MAX(height) AS last_height
+  FROM blocks
+  GROUP BY chain_id, proposer_address;
+
+CREATE UNIQUE INDEX idx_explorer_proposers_chain_address ON explorer_proposers(chain_id, proposer_address);
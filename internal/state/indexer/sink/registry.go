@@ -0,0 +1,65 @@
+package sink
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/state/indexer"
+)
+
+// Constructor creates an event sink from the config of the node, for the
+// event sinks registered with Register.
+type Constructor func(cfg *config.Config, dbProvider config.DBProvider, chainID string) (indexer.EventSink, error)
+
+var (
+	registryMtx sync.RWMutex
+	registry    = map[indexer.EventSinkType]Constructor{}
+)
+
+// Register makes an event sink available to the tx-index.indexer option under
+// the given name, for event sinks compiled into the node binary. Its events
+// are filtered as configured in tx-index.sinks.<name>. It's meant to be called
+// from the init function of the package implementing the sink, and panics if
+// the name is already used by a built-in or registered event sink.
+func Register(name string, constructor Constructor) {
+	typ := indexer.EventSinkType(name)
+	if constructor == nil {
+		panic(fmt.Sprintf("event sink %q registered with a nil constructor", name))
+	}
+
+	switch typ {
+	case indexer.NULL, indexer.KV, indexer.PSQL, indexer.SQLITE, indexer.GRPC:
+		panic(fmt.Sprintf("event sink %q is built in", name))
+	}
+
+	registryMtx.Lock()
+	defer registryMtx.Unlock()
+
+	if _, ok := registry[typ]; ok {
+		panic(fmt.Sprintf("event sink %q registered twice", name))
+	}
+	registry[typ] = constructor
+}
+
+// Registered returns the names of the registered event sinks, sorted.
+func Registered() []string {
+	registryMtx.RLock()
+	defer registryMtx.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for typ := range registry {
+		names = append(names, string(typ))
+	}
+	sort.Strings(names)
+	return names
+}
+
+func registered(typ indexer.EventSinkType) (Constructor, bool) {
+	registryMtx.RLock()
+	defer registryMtx.RUnlock()
+
+	constructor, ok := registry[typ]
+	return constructor, ok
+}
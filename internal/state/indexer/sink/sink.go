@@ -2,17 +2,21 @@ package sink
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/internal/state/indexer"
+	grpcsink "github.com/tendermint/tendermint/internal/state/indexer/sink/grpc"
 	"github.com/tendermint/tendermint/internal/state/indexer/sink/kv"
 	"github.com/tendermint/tendermint/internal/state/indexer/sink/null"
 	"github.com/tendermint/tendermint/internal/state/indexer/sink/psql"
+	"github.com/tendermint/tendermint/internal/state/indexer/sink/sqlite"
 )
 
 // EventSinksFromConfig constructs a slice of indexer.EventSink using the provided
-// configuration.
+// configuration. The indexers which aren't built in are created by the
+// constructor registered with Register.
 func EventSinksFromConfig(cfg *config.Config, dbProvider config.DBProvider, chainID string) ([]indexer.EventSink, error) {
 	if len(cfg.TxIndex.Indexer) == 0 {
 		return []indexer.EventSink{null.NewEventSink()}, nil
@@ -29,35 +33,65 @@ func EventSinksFromConfig(cfg *config.Config, dbProvider config.DBProvider, chai
 	}
 	eventSinks := []indexer.EventSink{}
 	for k := range sinks {
-		switch indexer.EventSinkType(k) {
-		case indexer.NULL:
-			// When we see null in the config, the eventsinks will be reset with the
-			// nullEventSink.
+		// When we see null in the config, the eventsinks will be reset with the
+		// nullEventSink.
+		if indexer.EventSinkType(k) == indexer.NULL {
 			return []indexer.EventSink{null.NewEventSink()}, nil
+		}
 
-		case indexer.KV:
-			store, err := dbProvider(&config.DBContext{ID: "tx_index", Config: cfg})
-			if err != nil {
-				return nil, err
-			}
-
-			eventSinks = append(eventSinks, kv.NewEventSink(store))
-
-		case indexer.PSQL:
-			conn := cfg.TxIndex.PsqlConn
-			if conn == "" {
-				return nil, errors.New("the psql connection settings cannot be empty")
-			}
-
-			es, err := psql.NewEventSink(conn, chainID)
-			if err != nil {
-				return nil, err
-			}
-			eventSinks = append(eventSinks, es)
-		default:
-			return nil, errors.New("unsupported event sink type")
+		es, err := NewEventSink(indexer.EventSinkType(k), cfg, dbProvider, chainID)
+		if err != nil {
+			return nil, err
 		}
+
+		include, exclude := cfg.TxIndex.EventFilter(k)
+		eventSinks = append(eventSinks, indexer.FilterEventSink(es, indexer.NewEventFilter(include, exclude)))
 	}
 	return eventSinks, nil
 
 }
+
+// NewEventSink constructs the event sink of the given type, built in or
+// registered with Register, other than the null one.
+func NewEventSink(
+	typ indexer.EventSinkType,
+	cfg *config.Config,
+	dbProvider config.DBProvider,
+	chainID string,
+) (indexer.EventSink, error) {
+	switch typ {
+	case indexer.KV:
+		store, err := dbProvider(&config.DBContext{ID: "tx_index", Config: cfg})
+		if err != nil {
+			return nil, err
+		}
+		return kv.NewEventSink(store)
+
+	case indexer.PSQL:
+		conn := cfg.TxIndex.PsqlConn
+		if conn == "" {
+			return nil, errors.New("the psql connection settings cannot be empty")
+		}
+		return psql.NewEventSink(conn, chainID, cfg.TxIndex.PsqlRefreshViews)
+
+	case indexer.SQLITE:
+		return sqlite.NewEventSink(cfg.SqliteIndexFile(), chainID)
+
+	case indexer.GRPC:
+		addr := cfg.TxIndex.GRPCAddr
+		if addr == "" {
+			return nil, errors.New("the grpc event sink address cannot be empty")
+		}
+		return grpcsink.NewEventSink(addr, chainID)
+	}
+
+	constructor, ok := registered(typ)
+	if !ok {
+		return nil, fmt.Errorf("unsupported event sink type %q", typ)
+	}
+	es, err := constructor(cfg, dbProvider, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the %s event sink: %w", typ, err)
+	}
+	return es, nil
+}
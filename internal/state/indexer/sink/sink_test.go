@@ -0,0 +1,53 @@
+package sink
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/state/indexer"
+	"github.com/tendermint/tendermint/internal/state/indexer/mocks"
+)
+
+func TestEventSinksFromConfig_Registered(t *testing.T) {
+	es := &mocks.EventSink{}
+	es.On("Type").Return(indexer.EventSinkType("custom"))
+	var gotChainID string
+	Register("custom", func(cfg *config.Config, _ config.DBProvider, chainID string) (indexer.EventSink, error) {
+		gotChainID = chainID
+		return es, nil
+	})
+	assert.Contains(t, Registered(), "custom")
+
+	// built-in and registered names can't be registered again
+	assert.Panics(t, func() { Register("kv", func(*config.Config, config.DBProvider, string) (indexer.EventSink, error) { return nil, nil }) })
+	assert.Panics(t, func() { Register("custom", func(*config.Config, config.DBProvider, string) (indexer.EventSink, error) { return nil, nil }) })
+	assert.Panics(t, func() { Register("other", nil) })
+
+	cfg := config.TestConfig()
+	cfg.TxIndex.Indexer = []string{"custom"}
+	sinks, err := EventSinksFromConfig(cfg, config.DefaultDBProvider, "test-chain")
+	require.NoError(t, err)
+	require.Len(t, sinks, 1)
+	assert.Equal(t, indexer.EventSinkType("custom"), sinks[0].Type())
+	assert.Equal(t, "test-chain", gotChainID)
+	assert.True(t, indexer.IndexingEnabled(sinks))
+
+	cfg.TxIndex.Indexer = []string{"unknown"}
+	_, err = EventSinksFromConfig(cfg, config.DefaultDBProvider, "test-chain")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported event sink type")
+
+	// the grpc sink needs an address
+	cfg.TxIndex.Indexer = []string{"grpc"}
+	_, err = EventSinksFromConfig(cfg, config.DefaultDBProvider, "test-chain")
+	require.Error(t, err)
+	cfg.TxIndex.GRPCAddr = "tcp://127.0.0.1:26670"
+	sinks, err = EventSinksFromConfig(cfg, config.DefaultDBProvider, "test-chain")
+	require.NoError(t, err)
+	require.Len(t, sinks, 1)
+	assert.Equal(t, indexer.GRPC, sinks[0].Type())
+	require.NoError(t, sinks[0].Stop())
+}
@@ -0,0 +1,31 @@
+package sqlite
+
+import (
+	"fmt"
+
+	"github.com/tendermint/tendermint/internal/state/indexer/sink/sqlquery"
+)
+
+// dialect describes the SQL of SQLite to the query builder.
+var dialect = sqlquery.Dialect{
+	Name: "sqlite",
+
+	Placeholder: func(n int) string { return fmt.Sprintf("?%d", n) },
+
+	// SQLite has no regular expressions, so the value is matched with GLOB
+	// patterns for an optional sign followed by digits with at most one
+	// decimal point.
+	NumericValue: `(CASE WHEN a.value GLOB '*[0-9]' AND a.value NOT GLOB '*.*.*' AND (
+    (a.value GLOB '[0-9]*' AND a.value NOT GLOB '*[^0-9.]*') OR
+    (a.value GLOB '-[0-9]*' AND substr(a.value, 2) NOT GLOB '*[^0-9.]*'))
+  THEN CAST(a.value AS NUMERIC) END)`,
+
+	Text:    func(expr string) string { return "CAST(" + expr + " AS TEXT)" },
+	Numeric: func(expr string) string { return "CAST(" + expr + " AS NUMERIC)" },
+
+	Contains: func(s, substr string) string { return "instr(" + s + ", " + substr + ") > 0" },
+
+	// LIKE is made case sensitive when opening the database, to match the
+	// other sinks. The query language has no escape character in patterns.
+	Like: func(s, pattern string) string { return s + " LIKE " + pattern },
+}
@@ -0,0 +1,365 @@
+//go:build cgo
+// +build cgo
+
+// Package sqlite implements an event sink backed by a SQLite database file.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	_ "embed" // for the schema
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	_ "github.com/mattn/go-sqlite3" // register the driver
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/internal/pubsub/query"
+	"github.com/tendermint/tendermint/internal/state/indexer"
+	"github.com/tendermint/tendermint/internal/state/indexer/sink/sqlquery"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	tableBlocks     = "blocks"
+	tableTxResults  = "tx_results"
+	tableEvents     = "events"
+	tableAttributes = "attributes"
+	driverName      = "sqlite3"
+)
+
+// schema is the database schema of the sink, which is installed when opening
+// a database.
+//
+//go:embed schema.sql
+var schema string
+
+// EventSink is an indexer backend providing the tx/block index services. This
+// implementation stores records in a single SQLite database file using the
+// schema defined in state/indexer/sink/sqlite/schema.sql, which mirrors the
+// event tables of the PostgreSQL sink. Unlike the PostgreSQL sink, it supports
+// searching for transactions as well as blocks.
+type EventSink struct {
+	store   *sql.DB
+	chainID string
+}
+
+// NewEventSink constructs an event sink associated with the SQLite database
+// file at path, which is created along with its schema if needed. Events
+// written to the sink are attributed to the specified chainID.
+func NewEventSink(path, chainID string) (*EventSink, error) {
+	// The LIKE operator of the query language is case sensitive.
+	dsn := "file:" + path + "?_busy_timeout=5000&_journal_mode=WAL&_foreign_keys=on&_cslike=on"
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(schema); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("installing schema: %w", err)
+	}
+
+	return &EventSink{
+		store:   db,
+		chainID: chainID,
+	}, nil
+}
+
+// DB returns the underlying SQLite connection used by the sink.
+// This is exported to support testing.
+func (es *EventSink) DB() *sql.DB { return es.store }
+
+// Type returns the structure type for this sink, which is SQLite.
+func (es *EventSink) Type() indexer.EventSinkType { return indexer.SQLITE }
+
+// runInTransaction executes query in a fresh database transaction.
+// If query reports an error, the transaction is rolled back and the
+// error from query is reported to the caller.
+// Otherwise, the result of committing the transaction is returned.
+func runInTransaction(db *sql.DB, query func(*sql.Tx) error) error {
+	dbtx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := query(dbtx); err != nil {
+		_ = dbtx.Rollback() // report the initial error, not the rollback
+		return err
+	}
+	return dbtx.Commit()
+}
+
+// queryWithID executes the specified SQL query with the given arguments,
+// expecting a single-row, single-column result containing an ID. If the query
+// succeeds, the ID from the result is returned.
+func queryWithID(tx *sql.Tx, query string, args ...interface{}) (int64, error) {
+	var id int64
+	if err := tx.QueryRow(query, args...).Scan(&id); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// insertEvents inserts a slice of events and any indexed attributes of those
+// events into the database associated with dbtx.
+//
+// If txID > 0, the event is attributed to the Tendermint transaction with that
+// ID; otherwise it is recorded as a block event.
+func insertEvents(dbtx *sql.Tx, blockID, txID int64, evts []abci.Event) error {
+	// Populate the transaction ID field iff one is defined (> 0).
+	var txIDArg interface{}
+	if txID > 0 {
+		txIDArg = txID
+	}
+
+	// Add each event to the events table, and retrieve its row ID to use when
+	// adding any attributes the event provides.
+	for _, evt := range evts {
+		// Skip events with an empty type.
+		if evt.Type == "" {
+			continue
+		}
+
+		eid, err := queryWithID(dbtx, `
+INSERT INTO `+tableEvents+` (block_id, tx_id, type) VALUES (?, ?, ?)
+  RETURNING rowid;
+`, blockID, txIDArg, evt.Type)
+		if err != nil {
+			return err
+		}
+
+		// Add any attributes flagged for indexing.
+		for _, attr := range evt.Attributes {
+			if !attr.Index {
+				continue
+			}
+			compositeKey := evt.Type + "." + attr.Key
+			if _, err := dbtx.Exec(`
+INSERT INTO `+tableAttributes+` (event_id, key, composite_key, value)
+  VALUES (?, ?, ?, ?);
+`, eid, attr.Key, compositeKey, attr.Value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// makeIndexedEvent constructs an event from the specified composite key and
+// value. If the key has the form "type.name", the event will have a single
+// attribute with that name and the value; otherwise the event will have only
+// a type and no attributes.
+func makeIndexedEvent(compositeKey, value string) abci.Event {
+	i := strings.Index(compositeKey, ".")
+	if i < 0 {
+		return abci.Event{Type: compositeKey}
+	}
+	return abci.Event{Type: compositeKey[:i], Attributes: []abci.EventAttribute{
+		{Key: compositeKey[i+1:], Value: value, Index: true},
+	}}
+}
+
+// IndexBlockEvents indexes the specified block header, part of the
+// indexer.EventSink interface.
+func (es *EventSink) IndexBlockEvents(h types.EventDataNewBlockHeader) error {
+	ts := time.Now().UTC()
+
+	return runInTransaction(es.store, func(dbtx *sql.Tx) error {
+		// Add the block to the blocks table and report back its row ID for use
+		// in indexing the events for the block.
+		blockID, err := queryWithID(dbtx, `
+INSERT INTO `+tableBlocks+` (height, chain_id, created_at)
+  VALUES (?, ?, ?)
+  ON CONFLICT DO NOTHING
+  RETURNING rowid;
+`, h.Header.Height, es.chainID, ts)
+		if err == sql.ErrNoRows {
+			return nil // we already saw this block; quietly succeed
+		} else if err != nil {
+			return fmt.Errorf("indexing block header: %w", err)
+		}
+
+		// Insert the special block meta-event for height.
+		if err := insertEvents(dbtx, blockID, 0, []abci.Event{
+			makeIndexedEvent(types.BlockHeightKey, fmt.Sprint(h.Header.Height)),
+		}); err != nil {
+			return fmt.Errorf("block meta-events: %w", err)
+		}
+		// Insert all the block events. Order is important here,
+		if err := insertEvents(dbtx, blockID, 0, h.ResultBeginBlock.Events); err != nil {
+			return fmt.Errorf("begin-block events: %w", err)
+		}
+		if err := insertEvents(dbtx, blockID, 0, h.ResultEndBlock.Events); err != nil {
+			return fmt.Errorf("end-block events: %w", err)
+		}
+		return nil
+	})
+}
+
+func (es *EventSink) IndexTxEvents(txrs []*abci.TxResult) error {
+	ts := time.Now().UTC()
+
+	for _, txr := range txrs {
+		// Encode the result message in protobuf wire format for indexing.
+		resultData, err := proto.Marshal(txr)
+		if err != nil {
+			return fmt.Errorf("marshaling tx_result: %w", err)
+		}
+
+		// Index the hash of the underlying transaction as a hex string.
+		txHash := fmt.Sprintf("%X", types.Tx(txr.Tx).Hash())
+
+		if err := runInTransaction(es.store, func(dbtx *sql.Tx) error {
+			// Find the block associated with this transaction. The block header
+			// must have been indexed prior to the transactions belonging to it.
+			blockID, err := queryWithID(dbtx, `
+SELECT rowid FROM `+tableBlocks+` WHERE height = ? AND chain_id = ?;
+`, txr.Height, es.chainID)
+			if err != nil {
+				return fmt.Errorf("finding block ID: %w", err)
+			}
+
+			// Insert a record for this tx_result and capture its ID for indexing events.
+			txID, err := queryWithID(dbtx, `
+INSERT INTO `+tableTxResults+` (block_id, "index", created_at, tx_hash, tx_result)
+  VALUES (?, ?, ?, ?, ?)
+  ON CONFLICT DO NOTHING
+  RETURNING rowid;
+`, blockID, txr.Index, ts, txHash, resultData)
+			if err == sql.ErrNoRows {
+				return nil // we already saw this transaction; quietly succeed
+			} else if err != nil {
+				return fmt.Errorf("indexing tx_result: %w", err)
+			}
+
+			// Insert the special transaction meta-events for hash and height.
+			if err := insertEvents(dbtx, blockID, txID, []abci.Event{
+				makeIndexedEvent(types.TxHashKey, txHash),
+				makeIndexedEvent(types.TxHeightKey, fmt.Sprint(txr.Height)),
+			}); err != nil {
+				return fmt.Errorf("indexing transaction meta-events: %w", err)
+			}
+			// Index any events packaged with the transaction.
+			if err := insertEvents(dbtx, blockID, txID, txr.Result.Events); err != nil {
+				return fmt.Errorf("indexing transaction events: %w", err)
+			}
+			return nil
+
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SearchBlockEvents returns the heights of the blocks whose BeginBlock and
+// EndBlock events match q, in ascending order. If matchEvents is true, the
+// event conditions of each query clause must be satisfied by a single event.
+// Queries comparing timestamps are not supported.
+func (es *EventSink) SearchBlockEvents(ctx context.Context, q *query.Query, matchEvents bool) ([]int64, error) {
+	if q == nil {
+		return nil, errors.New("block search requires a query")
+	}
+
+	stmt, args, err := sqlquery.BlockQuery(dialect, es.chainID, q.Syntax(), matchEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := es.store.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, fmt.Errorf("searching blocks: %w", err)
+	}
+	defer rows.Close()
+
+	heights := make([]int64, 0)
+	for rows.Next() {
+		var height int64
+		if err := rows.Scan(&height); err != nil {
+			return nil, fmt.Errorf("searching blocks: %w", err)
+		}
+		heights = append(heights, height)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("searching blocks: %w", err)
+	}
+	return heights, nil
+}
+
+// SearchTxEvents returns the results of the transactions whose events match
+// q, ordered by height and index. Queries comparing timestamps are not
+// supported.
+func (es *EventSink) SearchTxEvents(ctx context.Context, q *query.Query) ([]*abci.TxResult, error) {
+	if q == nil {
+		return nil, errors.New("tx search requires a query")
+	}
+
+	stmt, args, err := sqlquery.TxQuery(dialect, es.chainID, q.Syntax())
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := es.store.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, fmt.Errorf("searching transactions: %w", err)
+	}
+	defer rows.Close()
+
+	results := make([]*abci.TxResult, 0)
+	for rows.Next() {
+		var resultData []byte
+		if err := rows.Scan(&resultData); err != nil {
+			return nil, fmt.Errorf("searching transactions: %w", err)
+		}
+		txr := new(abci.TxResult)
+		if err := proto.Unmarshal(resultData, txr); err != nil {
+			return nil, fmt.Errorf("reading tx_result: %w", err)
+		}
+		results = append(results, txr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("searching transactions: %w", err)
+	}
+	return results, nil
+}
+
+// GetTxByHash returns the result of the transaction with the given hash, or
+// nil if it has not been indexed.
+func (es *EventSink) GetTxByHash(hash []byte) (*abci.TxResult, error) {
+	if len(hash) == 0 {
+		return nil, indexer.ErrorEmptyHash
+	}
+
+	var resultData []byte
+	err := es.store.QueryRow(`
+SELECT r.tx_result FROM `+tableTxResults+` r JOIN `+tableBlocks+` b ON r.block_id = b.rowid
+  WHERE r.tx_hash = ? AND b.chain_id = ?;
+`, fmt.Sprintf("%X", hash), es.chainID).Scan(&resultData)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("finding transaction: %w", err)
+	}
+
+	txr := new(abci.TxResult)
+	if err := proto.Unmarshal(resultData, txr); err != nil {
+		return nil, fmt.Errorf("reading tx_result: %w", err)
+	}
+	return txr, nil
+}
+
+// HasBlock reports whether the block at height h has been indexed.
+func (es *EventSink) HasBlock(h int64) (bool, error) {
+	var found bool
+	if err := es.store.QueryRow(`
+SELECT EXISTS (SELECT 1 FROM `+tableBlocks+` WHERE height = ? AND chain_id = ?);
+`, h, es.chainID).Scan(&found); err != nil {
+		return false, fmt.Errorf("finding block: %w", err)
+	}
+	return found, nil
+}
+
+// Stop closes the underlying SQLite database.
+func (es *EventSink) Stop() error { return es.store.Close() }
@@ -0,0 +1,15 @@
+//go:build !cgo
+// +build !cgo
+
+package sqlite
+
+import (
+	"errors"
+
+	"github.com/tendermint/tendermint/internal/state/indexer"
+)
+
+// NewEventSink returns an error, as the SQLite driver requires cgo.
+func NewEventSink(path, chainID string) (indexer.EventSink, error) {
+	return nil, errors.New("the sqlite event sink is not available; rebuild with CGO_ENABLED=1")
+}
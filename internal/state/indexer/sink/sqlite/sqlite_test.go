@@ -0,0 +1,200 @@
+//go:build cgo
+// +build cgo
+
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/internal/pubsub/query"
+	"github.com/tendermint/tendermint/internal/state/indexer"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Verify that the type satisfies the EventSink interface.
+var _ indexer.EventSink = (*EventSink)(nil)
+
+const chainID = "test-chainID"
+
+func newTestSink(t *testing.T) *EventSink {
+	t.Helper()
+
+	es, err := NewEventSink(filepath.Join(t.TempDir(), "index.sqlite"), chainID)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = es.Stop() })
+	return es
+}
+
+func transfer(sender, amount string) abci.Event {
+	return abci.Event{Type: "transfer", Attributes: []abci.EventAttribute{
+		{Key: "sender", Value: sender, Index: true},
+		{Key: "amount", Value: amount, Index: true},
+	}}
+}
+
+func TestType(t *testing.T) {
+	assert.Equal(t, indexer.SQLITE, newTestSink(t).Type())
+}
+
+func TestIndexing(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "index.sqlite")
+	es, err := NewEventSink(path, chainID)
+	require.NoError(t, err)
+
+	header := types.EventDataNewBlockHeader{
+		Header: types.Header{Height: 1},
+		ResultBeginBlock: abci.ResponseBeginBlock{
+			Events: []abci.Event{transfer("addr1", "10")},
+		},
+	}
+	require.NoError(t, es.IndexBlockEvents(header))
+	// Attempting to reindex the same events should gracefully succeed.
+	require.NoError(t, es.IndexBlockEvents(header))
+
+	txResult := &abci.TxResult{
+		Height: 1,
+		Index:  0,
+		Tx:     types.Tx("HELLO WORLD"),
+		Result: abci.ResponseDeliverTx{
+			Data: []byte{0},
+			Code: abci.CodeTypeOK,
+			Log:  "",
+			Events: []abci.Event{
+				transfer("addr1", "10"),
+				{Type: "", Attributes: []abci.EventAttribute{{Key: "not_allowed", Value: "Vlad", Index: true}}},
+			},
+		},
+	}
+	require.NoError(t, es.IndexTxEvents([]*abci.TxResult{txResult}))
+	// try to insert the duplicate tx events.
+	require.NoError(t, es.IndexTxEvents([]*abci.TxResult{txResult}))
+
+	hash := types.Tx(txResult.Tx).Hash()
+	txr, err := es.GetTxByHash(hash)
+	require.NoError(t, err)
+	assert.Equal(t, txResult, txr)
+
+	txr, err = es.GetTxByHash(types.Tx("missing").Hash())
+	require.NoError(t, err)
+	assert.Nil(t, txr)
+
+	found, err := es.HasBlock(1)
+	require.NoError(t, err)
+	assert.True(t, found)
+	found, err = es.HasBlock(2)
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	// Indexing a transaction of an unknown block fails.
+	assert.Error(t, es.IndexTxEvents([]*abci.TxResult{{Height: 2, Tx: types.Tx("foo")}}))
+
+	// The index is persisted in the database file.
+	require.NoError(t, es.Stop())
+	es, err = NewEventSink(path, chainID)
+	require.NoError(t, err)
+	defer es.Stop()
+
+	results, err := es.SearchTxEvents(ctx, query.MustCompile(`transfer.sender = 'addr1'`))
+	require.NoError(t, err)
+	assert.Equal(t, []*abci.TxResult{txResult}, results)
+
+	heights, err := es.SearchBlockEvents(ctx, query.MustCompile(`transfer.sender = 'addr1'`), false)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1}, heights)
+}
+
+func TestSearchBlockEvents(t *testing.T) {
+	ctx := context.Background()
+	es := newTestSink(t)
+
+	for h := int64(1); h <= 4; h++ {
+		require.NoError(t, es.IndexBlockEvents(types.EventDataNewBlockHeader{
+			Header: types.Header{Height: h},
+			ResultBeginBlock: abci.ResponseBeginBlock{
+				Events: []abci.Event{transfer(fmt.Sprintf("addr%d", h), fmt.Sprint(h*10))},
+			},
+			ResultEndBlock: abci.ResponseEndBlock{
+				Events: []abci.Event{transfer("fee", "5")},
+			},
+		}))
+	}
+
+	testCases := []struct {
+		q           string
+		matchEvents bool
+		want        []int64
+	}{
+		{`block.height = 2`, false, []int64{2}},
+		{`block.height > 2`, false, []int64{3, 4}},
+		{`transfer.amount > 20`, false, []int64{3, 4}},
+		{`transfer.amount > 5.5`, false, []int64{1, 2, 3, 4}},
+		{`transfer.sender = 'addr1' OR transfer.sender = 'addr4'`, false, []int64{1, 4}},
+		{`transfer.sender LIKE 'addr%' AND NOT block.height <= 3`, false, []int64{4}},
+		{`transfer.sender LIKE 'ADDR%'`, false, []int64{}},
+		{`NOT transfer.sender CONTAINS '2'`, false, []int64{1, 3, 4}},
+		{`transfer.sender = 'fee' AND transfer.amount = 30`, false, []int64{3}},
+		{`transfer.sender = 'fee' AND transfer.amount = 30`, true, []int64{}},
+		{`transfer.sender = 'fee' AND transfer.amount = 5`, true, []int64{1, 2, 3, 4}},
+		{`transfer.sender = 'addr3' AND transfer.amount = 30 AND block.height = 3`, true, []int64{3}},
+	}
+	for _, tc := range testCases {
+		heights, err := es.SearchBlockEvents(ctx, query.MustCompile(tc.q), tc.matchEvents)
+		require.NoError(t, err, tc.q)
+		assert.Equal(t, tc.want, heights, "query %q (match events: %v)", tc.q, tc.matchEvents)
+	}
+
+	_, err := es.SearchBlockEvents(ctx, query.MustCompile(`transfer.time > TIME 2021-01-01T00:00:00Z`), false)
+	assert.Error(t, err)
+}
+
+func TestSearchTxEvents(t *testing.T) {
+	ctx := context.Background()
+	es := newTestSink(t)
+
+	var txrs []*abci.TxResult
+	for h := int64(1); h <= 3; h++ {
+		require.NoError(t, es.IndexBlockEvents(types.EventDataNewBlockHeader{
+			Header: types.Header{Height: h},
+		}))
+		for i := uint32(0); i < 2; i++ {
+			txr := &abci.TxResult{
+				Height: h,
+				Index:  i,
+				Tx:     types.Tx(fmt.Sprintf("tx-%d-%d", h, i)),
+				Result: abci.ResponseDeliverTx{
+					Events: []abci.Event{transfer(fmt.Sprintf("addr%d", i), fmt.Sprint(h*10+int64(i)))},
+				},
+			}
+			txrs = append(txrs, txr)
+		}
+	}
+	require.NoError(t, es.IndexTxEvents(txrs))
+
+	testCases := []struct {
+		q    string
+		want []*abci.TxResult
+	}{
+		{`tx.height = 2`, txrs[2:4]},
+		{`tx.height >= 2 AND transfer.sender = 'addr1'`, []*abci.TxResult{txrs[3], txrs[5]}},
+		{fmt.Sprintf(`tx.hash = '%X'`, types.Tx(txrs[4].Tx).Hash()), txrs[4:5]},
+		{`transfer.amount < 21`, txrs[0:3]},
+		{`transfer.sender EXISTS`, txrs},
+		{`transfer.sender = 'addr2'`, []*abci.TxResult{}},
+	}
+	for _, tc := range testCases {
+		results, err := es.SearchTxEvents(ctx, query.MustCompile(tc.q))
+		require.NoError(t, err, tc.q)
+		assert.Equal(t, tc.want, results, "query %q", tc.q)
+	}
+}
@@ -0,0 +1,237 @@
+// Package sqlquery translates event queries into SQL queries on the blocks,
+// tx_results, events and attributes tables shared by the SQL event sinks.
+// The differences between databases are described by a Dialect.
+package sqlquery
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tendermint/tendermint/internal/pubsub/query/syntax"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	tableBlocks     = "blocks"
+	tableTxResults  = "tx_results"
+	tableEvents     = "events"
+	tableAttributes = "attributes"
+)
+
+// A Dialect describes how the SQL of a database differs from that of the
+// others, for the expressions the queries are made of.
+type Dialect struct {
+	// Name is the name of the sink, used in errors.
+	Name string
+
+	// Placeholder returns the placeholder of the nth argument of a query,
+	// counting from 1.
+	Placeholder func(n int) string
+
+	// NumericValue is an expression evaluating to the value "a.value" of an
+	// attribute as a number, or NULL if the value is not numeric, so that
+	// comparisons with non-numeric values are false rather than an error.
+	NumericValue string
+
+	// Text and Numeric return expr converted to text and to a number.
+	Text    func(expr string) string
+	Numeric func(expr string) string
+
+	// Contains returns a predicate on s containing substr.
+	Contains func(s, substr string) string
+
+	// Like returns a predicate on s matching the LIKE pattern, with no escape
+	// character and case sensitive, as in the other sinks.
+	Like func(s, pattern string) string
+}
+
+// searchQuery accumulates the arguments of a block or transaction search, and
+// describes how the searched rows relate to their height and events.
+type searchQuery struct {
+	Dialect
+	args []interface{}
+
+	heightKey    string // the meta-event key of the height
+	heightColumn string // the column holding the height
+	eventFilter  string // restricts events "e" to those of the searched row
+}
+
+// arg records v as an argument of the query and returns its placeholder.
+func (sq *searchQuery) arg(v interface{}) string {
+	sq.args = append(sq.args, v)
+	return sq.Placeholder(len(sq.args))
+}
+
+// BlockQuery constructs an SQL query selecting the heights of the blocks of
+// the given chain that match q, in ascending order, and returns it with its
+// arguments. If matchEvents is true, the event conditions of each clause of q
+// must be satisfied by a single event.
+func BlockQuery(d Dialect, chainID string, q syntax.Query, matchEvents bool) (string, []interface{}, error) {
+	sq := &searchQuery{
+		Dialect:      d,
+		heightKey:    types.BlockHeightKey,
+		heightColumn: tableBlocks + ".height",
+		eventFilter:  "e.block_id = " + tableBlocks + ".rowid AND e.tx_id IS NULL",
+	}
+	chainArg := sq.arg(chainID)
+
+	where, err := sq.where(q, matchEvents)
+	if err != nil {
+		return "", nil, err
+	}
+	return `
+SELECT height FROM ` + tableBlocks + `
+  WHERE chain_id = ` + chainArg + ` AND (` + where + `)
+  ORDER BY height;
+`, sq.args, nil
+}
+
+// TxQuery constructs an SQL query selecting the encoded results of the
+// transactions of the given chain that match q, ordered by height and index,
+// and returns it with its arguments.
+func TxQuery(d Dialect, chainID string, q syntax.Query) (string, []interface{}, error) {
+	sq := &searchQuery{
+		Dialect:      d,
+		heightKey:    types.TxHeightKey,
+		heightColumn: "b.height",
+		eventFilter:  "e.tx_id = r.rowid",
+	}
+	chainArg := sq.arg(chainID)
+
+	where, err := sq.where(q, false)
+	if err != nil {
+		return "", nil, err
+	}
+	return `
+SELECT r.tx_result FROM ` + tableTxResults + ` r JOIN ` + tableBlocks + ` b ON r.block_id = b.rowid
+  WHERE b.chain_id = ` + chainArg + ` AND (` + where + `)
+  ORDER BY b.height, r."index";
+`, sq.args, nil
+}
+
+// where returns an SQL predicate matching the rows that satisfy any clause of
+// q. An empty query matches every row.
+func (sq *searchQuery) where(q syntax.Query, matchEvents bool) (string, error) {
+	if len(q) == 0 {
+		return "TRUE", nil
+	}
+
+	clauses := make([]string, 0, len(q))
+	for _, clause := range q {
+		sql, err := sq.clause(clause, matchEvents)
+		if err != nil {
+			return "", err
+		}
+		clauses = append(clauses, "("+sql+")")
+	}
+	return strings.Join(clauses, " OR "), nil
+}
+
+// clause returns an SQL predicate matching the rows that satisfy every
+// condition of conditions.
+func (sq *searchQuery) clause(conditions syntax.Clause, matchEvents bool) (string, error) {
+	var preds, eventPreds []string
+	for _, c := range conditions {
+		if c.Tag == sq.heightKey {
+			pred, err := sq.valuePredicate(sq.Text(sq.heightColumn), sq.heightColumn, c)
+			if err != nil {
+				return "", err
+			}
+			preds = append(preds, negate(pred, c.Not))
+			continue
+		}
+
+		pred, err := sq.attributePredicate(c)
+		if err != nil {
+			return "", err
+		}
+		if matchEvents && !c.Not {
+			eventPreds = append(eventPreds, pred)
+			continue
+		}
+		preds = append(preds, negate(sq.eventExists(pred), c.Not))
+	}
+
+	// The conditions collected in eventPreds must all be satisfied by the
+	// attributes of the same event.
+	if len(eventPreds) > 0 {
+		preds = append(preds, sq.eventExists(strings.Join(eventPreds, " AND ")))
+	}
+	if len(preds) == 0 {
+		return "TRUE", nil
+	}
+	return strings.Join(preds, " AND "), nil
+}
+
+// attributePredicate returns an SQL predicate on events "e" having an indexed
+// attribute that satisfies c, without regard to its negation.
+func (sq *searchQuery) attributePredicate(c syntax.Condition) (string, error) {
+	pred, err := sq.valuePredicate("a.value", sq.NumericValue, c)
+	if err != nil {
+		return "", err
+	}
+	return `EXISTS (SELECT 1 FROM ` + tableAttributes + ` a
+  WHERE a.event_id = e.rowid AND a.composite_key = ` + sq.arg(c.Tag) + ` AND ` + pred + `)`, nil
+}
+
+// valuePredicate returns an SQL predicate on the value of the given
+// expressions satisfying the operator and argument of c, without regard to its
+// negation. Comparisons of numbers use numExpr, and all others use strExpr.
+func (sq *searchQuery) valuePredicate(strExpr, numExpr string, c syntax.Condition) (string, error) {
+	if c.Op == syntax.TExists {
+		return "TRUE", nil
+	}
+	if c.Arg == nil {
+		return "", fmt.Errorf("condition %q has no argument", c)
+	}
+
+	switch c.Arg.Type {
+	case syntax.TTime, syntax.TDate:
+		return "", fmt.Errorf("condition %q: timestamps are not supported by the %s event sink", c, sq.Name)
+
+	case syntax.TNumber:
+		var op string
+		switch c.Op {
+		case syntax.TEq:
+			op = "="
+		case syntax.TLt:
+			op = "<"
+		case syntax.TLeq:
+			op = "<="
+		case syntax.TGt:
+			op = ">"
+		case syntax.TGeq:
+			op = ">="
+		default:
+			return "", fmt.Errorf("condition %q: unsupported operator for a number", c)
+		}
+		return numExpr + " " + op + " " + sq.Numeric(sq.arg(c.Arg.Value())), nil
+	}
+
+	switch c.Op {
+	case syntax.TEq:
+		return strExpr + " = " + sq.arg(c.Arg.Value()), nil
+	case syntax.TContains:
+		return sq.Contains(strExpr, sq.arg(c.Arg.Value())), nil
+	case syntax.TLike:
+		return sq.Like(strExpr, sq.arg(c.Arg.Value())), nil
+	default:
+		return "", fmt.Errorf("condition %q: unsupported operator for a string", c)
+	}
+}
+
+// eventExists returns an SQL predicate on the searched rows having an event
+// "e" that satisfies pred.
+func (sq *searchQuery) eventExists(pred string) string {
+	return `EXISTS (SELECT 1 FROM ` + tableEvents + ` e
+  WHERE ` + sq.eventFilter + ` AND ` + pred + `)`
+}
+
+// negate returns the negation of the SQL predicate pred if not is true, and
+// otherwise pred.
+func negate(pred string, not bool) string {
+	if not {
+		return "NOT (" + pred + ")"
+	}
+	return pred
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
-	"strconv"
 	"strings"
 
 	"github.com/gogo/protobuf/proto"
@@ -128,12 +127,14 @@ func (txi *TxIndex) indexEvents(result *abci.TxResult, hash []byte, store dbm.Ba
 
 // Search performs a search using the given query.
 //
-// It breaks the query into conditions (like "tx.height > 5"). For each
-// condition, it queries the DB index. One special use cases here: (1) if
-// "tx.hash" is found, it returns tx result for it (2) for range queries it is
-// better for the client to provide both lower and upper bounds, so we are not
-// performing a full scan. Results from querying indexes are then intersected
-// and returned to the caller, in no particular order.
+// The query is a disjunction of clauses, each of which is evaluated separately
+// and the union of the results is returned. A clause is broken into conditions
+// (like "tx.height > 5"). For each condition, it queries the DB index. One
+// special use cases here: (1) if "tx.hash" is found, it returns tx result for
+// it (2) for range queries it is better for the client to provide both lower
+// and upper bounds, so we are not performing a full scan. Results from querying
+// indexes are then intersected, negated conditions are subtracted, and the
+// remainder is returned to the caller, in no particular order.
 //
 // Search will exit early and return any result fetched so far,
 // when a message is received on the context chan.
@@ -145,26 +146,58 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 	default:
 	}
 
+	matchedHashes := make(map[string][]byte)
+	for _, clause := range q.Syntax() {
+		hashes, err := txi.searchClause(ctx, clause)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range hashes {
+			matchedHashes[k] = v
+		}
+	}
+
+	results := make([]*abci.TxResult, 0, len(matchedHashes))
+hashes:
+	for _, h := range matchedHashes {
+		res, err := txi.Get(h)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Tx{%X}: %w", h, err)
+		}
+		if res == nil {
+			continue
+		}
+		results = append(results, res)
+
+		// Potentially exit early.
+		select {
+		case <-ctx.Done():
+			break hashes
+		default:
+		}
+	}
+
+	return results, nil
+}
+
+// searchClause returns the hashes of all txs matching every condition of the
+// given clause.
+func (txi *TxIndex) searchClause(ctx context.Context, conditions syntax.Clause) (map[string][]byte, error) {
 	var hashesInitialized bool
 	filteredHashes := make(map[string][]byte)
 
-	// get a list of conditions (like "tx.height > 5")
-	conditions := q.Syntax()
-
 	// if there is a hash condition, return the result immediately
 	hash, ok, err := lookForHash(conditions)
 	if err != nil {
 		return nil, fmt.Errorf("error during searching for a hash in the query: %w", err)
 	} else if ok {
 		res, err := txi.Get(hash)
-		switch {
-		case err != nil:
-			return []*abci.TxResult{}, fmt.Errorf("error while retrieving the result: %w", err)
-		case res == nil:
-			return []*abci.TxResult{}, nil
-		default:
-			return []*abci.TxResult{res}, nil
+		if err != nil {
+			return nil, fmt.Errorf("error while retrieving the result: %w", err)
+		} else if res != nil {
+			filteredHashes[string(hash)] = hash
 		}
+		return filteredHashes, nil
 	}
 
 	// conditions to skip because they're handled before "everything else"
@@ -197,10 +230,15 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 	height := lookForHeight(conditions)
 
 	// for all other conditions
+	var negated []syntax.Condition
 	for i, c := range conditions {
 		if intInSlice(i, skipIndexes) {
 			continue
 		}
+		if c.Not {
+			negated = append(negated, c)
+			continue
+		}
 
 		if !hashesInitialized {
 			filteredHashes = txi.match(ctx, c, prefixForCondition(c, height), filteredHashes, true)
@@ -216,29 +254,43 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 		}
 	}
 
-	results := make([]*abci.TxResult, 0, len(filteredHashes))
-hashes:
-	for _, h := range filteredHashes {
-		res, err := txi.Get(h)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get Tx{%X}: %w", h, err)
-		}
-		results = append(results, res)
+	if len(negated) == 0 {
+		return filteredHashes, nil
+	}
 
-		// Potentially exit early.
-		select {
-		case <-ctx.Done():
-			break hashes
-		default:
+	// A clause consisting only of negated conditions selects from the set of
+	// all indexed txs, which are always indexed by height.
+	if !hashesInitialized {
+		filteredHashes = txi.match(ctx, syntax.Condition{Tag: types.TxHeightKey, Op: syntax.TExists},
+			nil, filteredHashes, true)
+	}
+	for _, c := range negated {
+		if len(filteredHashes) == 0 {
+			break
+		}
+		for k := range txi.matchCondition(ctx, c) {
+			delete(filteredHashes, k)
 		}
 	}
 
-	return results, nil
+	return filteredHashes, nil
+}
+
+// matchCondition returns all txs by hash that satisfy c without regard to its
+// negation. It is used to evaluate negated conditions by subtraction.
+func (txi *TxIndex) matchCondition(ctx context.Context, c syntax.Condition) map[string][]byte {
+	c.Not = false
+	if indexer.IsRangeOperation(c.Op) {
+		ranges, _ := indexer.LookForRanges([]syntax.Condition{c})
+		qr := ranges[c.Tag]
+		return txi.matchRange(ctx, qr, prefixFromCompositeKey(qr.Key), make(map[string][]byte), true)
+	}
+	return txi.match(ctx, c, prefixForCondition(c, 0), make(map[string][]byte), true)
 }
 
 func lookForHash(conditions []syntax.Condition) (hash []byte, ok bool, err error) {
 	for _, c := range conditions {
-		if c.Tag == types.TxHashKey {
+		if c.Tag == types.TxHashKey && c.Op == syntax.TEq && !c.Not {
 			decoded, err := hex.DecodeString(c.Arg.Value())
 			return decoded, true, err
 		}
@@ -249,7 +301,7 @@ func lookForHash(conditions []syntax.Condition) (hash []byte, ok bool, err error
 // lookForHeight returns a height if there is an "height=X" condition.
 func lookForHeight(conditions []syntax.Condition) (height int64) {
 	for _, c := range conditions {
-		if c.Tag == types.TxHeightKey && c.Op == syntax.TEq {
+		if c.Tag == types.TxHeightKey && c.Op == syntax.TEq && !c.Not {
 			return int64(c.Arg.Number())
 		}
 	}
@@ -353,6 +405,37 @@ func (txi *TxIndex) match(
 		if err := it.Error(); err != nil {
 			panic(err)
 		}
+	case c.Op == syntax.TLike:
+		// Only keys whose value begins with the literal prefix of the pattern
+		// can match, so restrict the scan to that portion of the index.
+		pattern := c.Arg.Value()
+		it, err := dbm.IteratePrefix(txi.store, prefixFromCompositeKeyAndValuePrefix(c.Tag, syntax.LikePrefix(pattern)))
+		if err != nil {
+			panic(err)
+		}
+		defer it.Close()
+
+	iterLike:
+		for ; it.Valid(); it.Next() {
+			value, err := parseValueFromKey(it.Key())
+			if err != nil {
+				continue
+			}
+			if syntax.MatchLike(pattern, value) {
+				tmpHashes[string(it.Value())] = it.Value()
+			}
+
+			// Potentially exit early.
+			select {
+			case <-ctx.Done():
+				break iterLike
+			default:
+			}
+		}
+		if err := it.Error(); err != nil {
+			panic(err)
+		}
+
 	default:
 		panic("other operators should be handled already")
 	}
@@ -405,8 +488,6 @@ func (txi *TxIndex) matchRange(
 	}
 
 	tmpHashes := make(map[string][]byte)
-	lowerBound := qr.LowerBoundValue()
-	upperBound := qr.UpperBoundValue()
 
 	it, err := dbm.IteratePrefix(txi.store, startKey)
 	if err != nil {
@@ -420,33 +501,17 @@ iter:
 		if err != nil {
 			continue
 		}
-		if _, ok := qr.AnyBound().(int64); ok {
-			v, err := strconv.ParseInt(value, 10, 64)
-			if err != nil {
-				continue iter
-			}
-
-			include := true
-			if lowerBound != nil && v < lowerBound.(int64) {
-				include = false
-			}
-
-			if upperBound != nil && v > upperBound.(int64) {
-				include = false
-			}
-
-			if include {
-				tmpHashes[string(it.Value())] = it.Value()
-			}
-
-			// XXX: passing time in a ABCI Events is not yet implemented
-			// case time.Time:
-			// 	v := strconv.ParseInt(extractValueFromKey(it.Key()), 10, 64)
-			// 	if v == r.upperBound {
-			// 		break
-			// 	}
+		if qr.IsNumeric() && qr.MatchNumber(value) {
+			tmpHashes[string(it.Value())] = it.Value()
 		}
 
+		// XXX: passing time in a ABCI Events is not yet implemented
+		// case time.Time:
+		// 	v := strconv.ParseInt(extractValueFromKey(it.Key()), 10, 64)
+		// 	if v == r.upperBound {
+		// 		break
+		// 	}
+
 		// Potentially exit early.
 		select {
 		case <-ctx.Done():
@@ -574,6 +639,15 @@ func prefixFromCompositeKeyAndValue(compositeKey, value string) []byte {
 	return key
 }
 
+// prefixFromCompositeKeyAndValuePrefix returns a key prefix shared by all event
+// keys for compositeKey whose value begins with prefix. The string encoding
+// used by orderedcode preserves byte prefixes apart from its two-byte
+// terminator, which is dropped here.
+func prefixFromCompositeKeyAndValuePrefix(compositeKey, prefix string) []byte {
+	key := prefixFromCompositeKeyAndValue(compositeKey, prefix)
+	return key[:len(key)-2]
+}
+
 // a small utility function for getting a keys prefix based on a condition and a height
 func prefixForCondition(c syntax.Condition, height int64) []byte {
 	key := prefixFromCompositeKeyAndValue(c.Tag, c.Arg.Value())
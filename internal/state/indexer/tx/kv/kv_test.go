@@ -124,6 +124,24 @@ func TestTxSearch(t *testing.T) {
 		{"account.number = 1 AND tx.height = 3", 0},
 		// search using height only
 		{"tx.height = 1", 1},
+		// search using OR
+		{"account.owner = 'Vlad' OR account.number = 1", 1},
+		{"account.owner = 'Vlad' OR account.number = 2", 0},
+		{"account.number = 1 AND account.owner = 'Ivan' OR account.number = 1", 1},
+		// search using NOT
+		{"account.number = 1 AND NOT account.owner = 'Vlad'", 1},
+		{"account.number = 1 AND NOT account.owner = 'Ivan'", 0},
+		{"NOT account.owner = 'Vlad'", 1},
+		{"NOT account.number EXISTS", 0},
+		{"account.owner EXISTS AND NOT account.number > 5", 1},
+		{"account.owner EXISTS AND NOT account.number >= 1", 0},
+		{"NOT account.owner = 'Vlad' AND NOT account.owner = 'Ivan' OR account.number = 1", 1},
+		// search using LIKE
+		{"account.owner LIKE 'Iv%'", 1},
+		{"account.owner LIKE 'I_an'", 1},
+		{"account.owner LIKE '%van'", 1},
+		{"account.owner LIKE 'Iv'", 0},
+		{"account.owner LIKE 'Vl%'", 0},
 	}
 
 	ctx := context.Background()
@@ -315,6 +333,53 @@ func TestTxSearchMultipleTxs(t *testing.T) {
 	assert.NoError(t, err)
 
 	require.Len(t, results, 3)
+
+	results, err = indexer.Search(ctx, query.MustCompile(`account.number = 1 OR account.number = 3`))
+	assert.NoError(t, err)
+	require.Len(t, results, 2)
+
+	results, err = indexer.Search(ctx, query.MustCompile(`account.number >= 1 AND NOT account.number = 2`))
+	assert.NoError(t, err)
+	require.Len(t, results, 2)
+
+	results, err = indexer.Search(ctx, query.MustCompile(`NOT account.number <= 2`))
+	assert.NoError(t, err)
+	require.Len(t, results, 2) // Jack's account and Mike's account
+}
+
+func TestTxSearchDecimalRange(t *testing.T) {
+	indexer := NewTxIndex(dbm.NewMemDB())
+
+	for i, amount := range []string{"0.5", "1.25", "7", "12.75"} {
+		txResult := txResultWithEvents([]abci.Event{
+			{Type: "transfer", Attributes: []abci.EventAttribute{{Key: "amount", Value: amount, Index: true}}},
+		})
+		txResult.Tx = types.Tx(fmt.Sprintf("tx%d", i))
+		txResult.Index = uint32(i)
+		require.NoError(t, indexer.Index([]*abci.TxResult{txResult}))
+	}
+
+	testCases := []struct {
+		q             string
+		resultsLength int
+	}{
+		{"transfer.amount > 1", 3},
+		{"transfer.amount >= 1.25", 3},
+		{"transfer.amount > 1.25", 2},
+		{"transfer.amount > 0.5 AND transfer.amount < 12.75", 2},
+		{"transfer.amount <= 7", 3},
+		{"transfer.amount < 0.5 OR transfer.amount > 12", 1},
+	}
+
+	ctx := context.Background()
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.q, func(t *testing.T) {
+			results, err := indexer.Search(ctx, query.MustCompile(tc.q))
+			require.NoError(t, err)
+			assert.Len(t, results, tc.resultsLength)
+		})
+	}
 }
 
 func txResultWithEvents(events []abci.Event) *abci.TxResult {
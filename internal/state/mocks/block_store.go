@@ -208,3 +208,17 @@ func (_m *BlockStore) Size() int64 {
 
 	return r0
 }
+
+// Sync provides a mock function with given fields:
+func (_m *BlockStore) Sync() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
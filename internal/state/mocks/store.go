@@ -43,6 +43,20 @@ func (_m *Store) Close() error {
 	return r0
 }
 
+// IterateValidators provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Store) IterateValidators(_a0 int64, _a1 int64, _a2 func(int64, *types.ValidatorSet) bool) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int64, func(int64, *types.ValidatorSet) bool) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Load provides a mock function with given fields:
 func (_m *Store) Load() (state.State, error) {
 	ret := _m.Called()
@@ -90,6 +90,7 @@ func Rollback(bs BlockStore, ss Store) (int64, []byte, error) {
 		LastHeightConsensusParamsChanged: paramsChangeHeight,
 
 		LastResultsHash: rollbackBlock.Header.LastResultsHash,
+		LastEventsHash:  rollbackBlock.Header.LastEventsHash,
 		AppHash:         rollbackBlock.Header.AppHash,
 	}
 
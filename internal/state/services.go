@@ -25,6 +25,7 @@ type BlockStore interface {
 	LoadBlock(height int64) *types.Block
 
 	SaveBlock(block *types.Block, blockParts *types.PartSet, seenCommit *types.Commit)
+	Sync() error
 
 	PruneBlocks(height int64) (uint64, error)
 
@@ -96,6 +96,9 @@ type State struct {
 	// Merkle root of the results from executing prev block
 	LastResultsHash []byte
 
+	// Merkle root of the events from executing prev block, if committed to
+	LastEventsHash []byte
+
 	// the latest AppHash we've received from calling abci.Commit()
 	AppHash []byte
 }
@@ -123,6 +126,7 @@ func (state State) Copy() State {
 		AppHash: state.AppHash,
 
 		LastResultsHash: state.LastResultsHash,
+		LastEventsHash:  state.LastEventsHash,
 	}
 }
 
@@ -190,6 +194,7 @@ func (state *State) ToProto() (*tmstate.State, error) {
 	sm.ConsensusParams = state.ConsensusParams.ToProto()
 	sm.LastHeightConsensusParamsChanged = state.LastHeightConsensusParamsChanged
 	sm.LastResultsHash = state.LastResultsHash
+	sm.LastEventsHash = state.LastEventsHash
 	sm.AppHash = state.AppHash
 
 	return sm, nil
@@ -241,6 +246,7 @@ func FromProto(pb *tmstate.State) (*State, error) {
 	state.ConsensusParams = types.ConsensusParamsFromProto(pb.ConsensusParams)
 	state.LastHeightConsensusParamsChanged = pb.LastHeightConsensusParamsChanged
 	state.LastResultsHash = pb.LastResultsHash
+	state.LastEventsHash = pb.LastEventsHash
 	state.AppHash = pb.AppHash
 
 	return state, nil
@@ -263,24 +269,27 @@ func (state State) MakeBlock(
 	// Build base block with block data.
 	block := types.MakeBlock(height, txs, commit, evidence)
 
-	// Set time.
-	var timestamp time.Time
-	if height == state.InitialHeight {
-		timestamp = state.LastBlockTime // genesis time
-	} else {
-		timestamp = MedianTime(commit, state.LastValidators)
-	}
-
 	// Fill rest of header with state data.
 	block.Header.Populate(
 		state.Version.Consensus, state.ChainID,
-		timestamp, state.LastBlockID,
+		state.blockTime(height, commit), state.LastBlockID,
 		state.Validators.Hash(), state.NextValidators.Hash(),
 		state.ConsensusParams.HashConsensusParams(), state.AppHash, state.LastResultsHash,
 		proposerAddress,
 	)
+	block.Header.LastEventsHash = state.LastEventsHash
 
-	return block, block.MakePartSet(types.BlockPartSizeBytes)
+	return block, block.MakePartSetWithParams(state.ConsensusParams.Block)
+}
+
+// blockTime returns the time of the block at height with the given last
+// commit: the genesis time for the initial block, and the median time of the
+// commit otherwise.
+func (state State) blockTime(height int64, commit *types.Commit) time.Time {
+	if height == state.InitialHeight {
+		return state.LastBlockTime // genesis time
+	}
+	return MedianTime(commit, state.LastValidators)
 }
 
 // MedianTime computes a median time for a given Commit (based on Timestamp field of votes messages) and the
@@ -437,6 +437,55 @@ func testProposerFreq(t *testing.T, caseNum int, valSet *types.ValidatorSet) {
 }
 
 // TestProposerPriorityDoesNotGetResetToZero assert that we preserve accum when calling updateState
+func TestStateKeyRotation(t *testing.T) {
+	tearDown, _, state := setupTestCase(t)
+	defer tearDown(t)
+
+	val1PubKey := ed25519.GenPrivKey().PubKey()
+	val2PubKey := ed25519.GenPrivKey().PubKey()
+	state.Validators = types.NewValidatorSet([]*types.Validator{
+		types.NewValidator(val1PubKey, 10),
+		types.NewValidator(val2PubKey, 20),
+	})
+	state.NextValidators = state.Validators
+
+	block := statefactory.MakeBlock(state, state.LastBlockHeight+1, new(types.Commit))
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: block.MakePartSet(testPartSize).Header()}
+
+	newPubKey := ed25519.GenPrivKey().PubKey()
+	updateState := func(updates ...abci.ValidatorUpdate) (sm.State, error) {
+		abciResponses := &tmstate.ABCIResponses{
+			BeginBlock: &abci.ResponseBeginBlock{},
+			EndBlock:   &abci.ResponseEndBlock{ValidatorUpdates: updates},
+		}
+		validatorUpdates, err := types.PB2TM.ValidatorUpdates(updates)
+		require.NoError(t, err)
+		return sm.UpdateState(state, blockID, &block.Header, abciResponses, validatorUpdates)
+	}
+
+	rotation := abci.RotateValidatorKey(val1PubKey.Bytes(), newPubKey.Bytes(), 10, ed25519.KeyType)
+
+	// the power of the rotated validator must not change
+	_, err := updateState(abci.RotateValidatorKey(val1PubKey.Bytes(), newPubKey.Bytes(), 5, ed25519.KeyType))
+	assert.Error(t, err)
+
+	// the rotated validator must not be otherwise updated
+	_, err = updateState(rotation, abci.UpdateValidator(val1PubKey.Bytes(), 5, ed25519.KeyType))
+	assert.Error(t, err)
+
+	updatedState, err := updateState(rotation)
+	require.NoError(t, err)
+	assert.Equal(t, block.Height+2, updatedState.LastHeightValidatorsChanged)
+
+	// the key is rotated in the next validator set only
+	assert.True(t, updatedState.Validators.HasAddress(val1PubKey.Address()))
+	assert.False(t, updatedState.NextValidators.HasAddress(val1PubKey.Address()))
+	_, val := updatedState.NextValidators.GetByAddress(newPubKey.Address())
+	require.NotNil(t, val)
+	assert.EqualValues(t, 10, val.VotingPower)
+	assert.Equal(t, state.NextValidators.TotalVotingPower(), updatedState.NextValidators.TotalVotingPower())
+}
+
 // see https://github.com/tendermint/tendermint/issues/2718
 func TestProposerPriorityDoesNotGetResetToZero(t *testing.T) {
 	tearDown, _, state := setupTestCase(t)
@@ -78,6 +78,9 @@ type Store interface {
 	Load() (State, error)
 	// LoadValidators loads the validator set at a given height
 	LoadValidators(int64) (*types.ValidatorSet, error)
+	// IterateValidators calls the function with the validator sets at the
+	// heights of the given range, in increasing order, until it returns false
+	IterateValidators(int64, int64, func(int64, *types.ValidatorSet) bool) error
 	// LoadABCIResponses loads the abciResponse for a given height
 	LoadABCIResponses(int64) (*tmstate.ABCIResponses, error)
 	// LoadConsensusParams loads the consensus params for a given height
@@ -404,6 +407,25 @@ func ABCIResponsesResultsHash(ar *tmstate.ABCIResponses) []byte {
 	return types.NewResults(ar.DeliverTxs).Hash()
 }
 
+// ABCIResponsesEvents returns the events emitted while executing a block, in
+// the order they are committed to by the events hash (see ABCIEvents).
+func ABCIResponsesEvents(ar *tmstate.ABCIResponses) types.ABCIEvents {
+	var beginBlock, endBlock []abci.Event
+	if ar.BeginBlock != nil {
+		beginBlock = ar.BeginBlock.Events
+	}
+	if ar.EndBlock != nil {
+		endBlock = ar.EndBlock.Events
+	}
+	return types.NewEvents(beginBlock, ar.DeliverTxs, endBlock)
+}
+
+// ABCIResponsesEventsHash returns the root hash of a Merkle tree of the
+// events emitted while executing a block (see ABCIEvents.Hash)
+func ABCIResponsesEventsHash(ar *tmstate.ABCIResponses) []byte {
+	return ABCIResponsesEvents(ar).Hash()
+}
+
 // LoadABCIResponses loads the ABCIResponses for the given height from the
 // database. If not found, ErrNoABCIResponsesForHeight is returned.
 //
@@ -523,6 +545,90 @@ func (store dbStore) LoadValidators(height int64) (*types.ValidatorSet, error) {
 	return vip, nil
 }
 
+// IterateValidators calls fn with the ValidatorSet at each height from
+// fromHeight to toHeight inclusive, in increasing order, until fn returns
+// false. Unlike calling LoadValidators for each height, the stored validator
+// sets the others are derived from are only loaded once.
+// Returns ErrNoValSetForHeight if the validator set can't be found for a height
+// of the range.
+func (store dbStore) IterateValidators(
+	fromHeight, toHeight int64,
+	fn func(height int64, vals *types.ValidatorSet) bool,
+) error {
+	if fromHeight > toHeight {
+		return nil
+	}
+
+	iter, err := store.db.Iterator(validatorsKey(fromHeight), validatorsKey(toHeight+1))
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+
+	var (
+		height       = fromHeight
+		storedHeight int64
+		storedVals   *types.ValidatorSet
+	)
+	for ; iter.Valid(); iter.Next() {
+		if h, err := decodeValidatorsKey(iter.Key()); err != nil {
+			return err
+		} else if h != height {
+			return ErrNoValSetForHeight{height}
+		}
+
+		valInfo := new(tmstate.ValidatorsInfo)
+		if err := valInfo.Unmarshal(iter.Value()); err != nil {
+			// DATA HAS BEEN CORRUPTED OR THE SPEC HAS CHANGED
+			panic(fmt.Sprintf("data has been corrupted or its spec has changed: %+v", err))
+		}
+
+		var vals *types.ValidatorSet
+		if valInfo.ValidatorSet != nil {
+			if vals, err = types.ValidatorSetFromProto(valInfo.ValidatorSet); err != nil {
+				return err
+			}
+			storedHeight, storedVals = height, vals
+		} else {
+			lastStoredHeight := lastStoredHeightFor(height, valInfo.LastHeightChanged)
+			if storedVals == nil || storedHeight != lastStoredHeight {
+				if storedVals, err = store.LoadValidators(lastStoredHeight); err != nil {
+					return err
+				}
+				storedHeight = lastStoredHeight
+			}
+			vals = storedVals.CopyIncrementProposerPriority(tmmath.SafeConvertInt32(height - storedHeight))
+		}
+
+		if !fn(height, vals) {
+			return nil
+		}
+		height++
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	if height <= toHeight {
+		return ErrNoValSetForHeight{height}
+	}
+	return nil
+}
+
+func decodeValidatorsKey(key []byte) (height int64, err error) {
+	var prefix int64
+	remaining, err := orderedcode.Parse(string(key), &prefix, &height)
+	if err != nil {
+		return
+	}
+	if len(remaining) != 0 {
+		return -1, fmt.Errorf("expected complete key but got remainder: %s", remaining)
+	}
+	if prefix != prefixValidators {
+		return -1, fmt.Errorf("incorrect prefix. Expected %v, got %v", prefixValidators, prefix)
+	}
+	return
+}
+
 func lastStoredHeightFor(height, lastHeightChanged int64) int64 {
 	checkpointHeight := height - height%valSetCheckpointInterval
 	return tmmath.MaxInt64(checkpointHeight, lastHeightChanged)
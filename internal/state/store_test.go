@@ -98,6 +98,44 @@ func TestStoreLoadValidators(t *testing.T) {
 // NOTE: This isn't too indicative of validator retrieval speed as the db is always (regardless of height) only
 // performing two operations: 1) retrieve validator info at height x, which has a last validator set change of 1
 // and 2) retrieve the validator set at the aforementioned height 1.
+func TestStoreIterateValidators(t *testing.T) {
+	stateDB := dbm.NewMemDB()
+	stateStore := sm.NewStore(stateDB)
+	val, _ := factory.RandValidator(true, 10)
+	val2, _ := factory.RandValidator(true, 20)
+	vals := types.NewValidatorSet([]*types.Validator{val, val2})
+
+	for height := int64(1); height <= 5; height++ {
+		require.NoError(t, stateStore.Save(makeRandomStateFromValidatorSet(vals, height, 1)))
+	}
+
+	// the validator sets are the same as the ones loaded one by one
+	var heights []int64
+	err := stateStore.IterateValidators(1, 5, func(height int64, iterVals *types.ValidatorSet) bool {
+		heights = append(heights, height)
+		loadedVals, err := stateStore.LoadValidators(height)
+		require.NoError(t, err)
+		require.Equal(t, loadedVals, iterVals)
+		return true
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 2, 3, 4, 5}, heights)
+
+	// the iteration stops when the function returns false
+	heights = nil
+	err = stateStore.IterateValidators(2, 5, func(height int64, _ *types.ValidatorSet) bool {
+		heights = append(heights, height)
+		return height < 3
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int64{2, 3}, heights)
+
+	// heights without validator sets are reported
+	err = stateStore.IterateValidators(5, 100, func(int64, *types.ValidatorSet) bool { return true })
+	require.Error(t, err)
+	require.IsType(t, sm.ErrNoValSetForHeight{}, err)
+}
+
 func BenchmarkLoadValidators(b *testing.B) {
 	const valSetSize = 100
 
@@ -16,7 +16,12 @@ func TxPreCheck(state State) mempool.PreCheckFunc {
 }
 
 // TxPostCheck returns a function to filter transactions after processing.
-// The function limits the gas wanted by a transaction to the block's maximum total gas.
+// The function limits the gas wanted by a transaction to the block's maximum
+// total gas, and maximum total gas wanted.
 func TxPostCheck(state State) mempool.PostCheckFunc {
-	return mempool.PostCheckMaxGas(state.ConsensusParams.Block.MaxGas)
+	maxGas := state.ConsensusParams.Block.MaxGas
+	if limit := state.ConsensusParams.Block.GasWantedLimit(); limit > -1 && (maxGas == -1 || limit < maxGas) {
+		maxGas = limit
+	}
+	return mempool.PostCheckMaxGas(maxGas)
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	abci "github.com/tendermint/tendermint/abci/types"
 	sm "github.com/tendermint/tendermint/internal/state"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
 	"github.com/tendermint/tendermint/types"
@@ -22,8 +23,8 @@ func TestTxFilter(t *testing.T) {
 		tx    types.Tx
 		isErr bool
 	}{
-		{types.Tx(tmrand.Bytes(2155)), false},
-		{types.Tx(tmrand.Bytes(2156)), true},
+		{types.Tx(tmrand.Bytes(2121)), false},
+		{types.Tx(tmrand.Bytes(2122)), true},
 		{types.Tx(tmrand.Bytes(3000)), true},
 	}
 
@@ -39,3 +40,35 @@ func TestTxFilter(t *testing.T) {
 		}
 	}
 }
+
+func TestTxPostCheck(t *testing.T) {
+	testCases := []struct {
+		maxGas, maxGasWanted int64
+		gasWanted            int64
+		isErr                bool
+	}{
+		{-1, 0, 1000, false},
+		{100, 0, 100, false},
+		{100, 0, 101, true},
+		{100, 50, 50, false},
+		{100, 50, 51, true},
+		{50, 100, 51, true},
+		{-1, 50, 51, true},
+	}
+
+	for i, tc := range testCases {
+		genDoc := randomGenesisDoc()
+		genDoc.ConsensusParams.Block.MaxGas = tc.maxGas
+		genDoc.ConsensusParams.Block.MaxGasWanted = tc.maxGasWanted
+		state, err := sm.MakeGenesisState(genDoc)
+		require.NoError(t, err)
+
+		f := sm.TxPostCheck(state)
+		err = f(types.Tx("tx"), &abci.ResponseCheckTx{GasWanted: tc.gasWanted})
+		if tc.isErr {
+			assert.Error(t, err, "#%v", i)
+		} else {
+			assert.NoError(t, err, "#%v", i)
+		}
+	}
+}
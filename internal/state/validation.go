@@ -51,10 +51,11 @@ func validateBlock(state State, block *types.Block) error {
 
 	// Validate app info
 	if !bytes.Equal(block.AppHash, state.AppHash) {
-		return fmt.Errorf("wrong Block.Header.AppHash.  Expected %X, got %v",
-			state.AppHash,
-			block.AppHash,
-		)
+		return ErrAppHashMismatch{
+			Height:   block.Height,
+			Expected: state.AppHash,
+			Got:      block.AppHash,
+		}
 	}
 	hashCP := state.ConsensusParams.HashConsensusParams()
 	if !bytes.Equal(block.ConsensusHash, hashCP) {
@@ -69,6 +70,12 @@ func validateBlock(state State, block *types.Block) error {
 			block.LastResultsHash,
 		)
 	}
+	if !bytes.Equal(block.LastEventsHash, state.LastEventsHash) {
+		return fmt.Errorf("wrong Block.Header.LastEventsHash.  Expected %X, got %v",
+			state.LastEventsHash,
+			block.LastEventsHash,
+		)
+	}
 	if !bytes.Equal(block.ValidatorsHash, state.Validators.Hash()) {
 		return fmt.Errorf("wrong Block.Header.ValidatorsHash.  Expected %X, got %v",
 			state.Validators.Hash(),
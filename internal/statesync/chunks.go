@@ -35,6 +35,7 @@ type chunkQueue struct {
 	chunkSenders   map[uint32]types.NodeID    // the peer who sent the given chunk
 	chunkAllocated map[uint32]bool            // chunks that have been allocated via Allocate()
 	chunkReturned  map[uint32]bool            // chunks returned via Next()
+	chunkSkipped   map[uint32]bool            // chunks applied before a restart, see Skip()
 	waiters        map[uint32][]chan<- uint32 // signals WaitFor() waiters about chunk arrival
 }
 
@@ -56,6 +57,7 @@ func newChunkQueue(snapshot *snapshot, tempDir string) (*chunkQueue, error) {
 		chunkSenders:   make(map[uint32]types.NodeID, snapshot.Chunks),
 		chunkAllocated: make(map[uint32]bool, snapshot.Chunks),
 		chunkReturned:  make(map[uint32]bool, snapshot.Chunks),
+		chunkSkipped:   make(map[uint32]bool),
 		waiters:        make(map[uint32][]chan<- uint32),
 	}, nil
 }
@@ -171,6 +173,9 @@ func (q *chunkQueue) discard(index uint32) error {
 
 	path := q.chunkFiles[index]
 	if path == "" {
+		if q.chunkSkipped[index] {
+			q.unskip(index)
+		}
 		return nil
 	}
 
@@ -307,10 +312,59 @@ func (q *chunkQueue) Retry(index uint32) {
 }
 
 // RetryAll schedules all chunks to be retried, without refetching them.
+// Skipped chunks are fetched.
 func (q *chunkQueue) RetryAll() {
 	q.Lock()
 	defer q.Unlock()
 	q.chunkReturned = make(map[uint32]bool)
+	for index := range q.chunkSkipped {
+		q.unskip(index)
+	}
+}
+
+// Skip skips the chunks below the given index, which the app applied before a
+// restart, so that they are neither fetched nor returned via Next().
+func (q *chunkQueue) Skip(index uint32) {
+	q.Lock()
+	defer q.Unlock()
+
+	if q.snapshot == nil {
+		return
+	}
+	for i := uint32(0); i < index && i < q.snapshot.Chunks; i++ {
+		q.chunkAllocated[i] = true
+		q.chunkReturned[i] = true
+		q.chunkSkipped[i] = true
+	}
+}
+
+// unskip schedules a skipped chunk for fetching. The caller must hold the mutex
+// lock.
+func (q *chunkQueue) unskip(index uint32) {
+	delete(q.chunkSkipped, index)
+	delete(q.chunkReturned, index)
+	delete(q.chunkAllocated, index)
+}
+
+// progress returns the restore progress of the snapshot, i.e. the number of
+// chunks returned in order, or nil when closed.
+func (q *chunkQueue) progress() *restoreProgress {
+	q.Lock()
+	defer q.Unlock()
+
+	if q.snapshot == nil {
+		return nil
+	}
+	applied := uint32(0)
+	for applied < q.snapshot.Chunks && q.chunkReturned[applied] {
+		applied++
+	}
+	return &restoreProgress{
+		Height:  q.snapshot.Height,
+		Format:  q.snapshot.Format,
+		Hash:    q.snapshot.Hash,
+		Applied: applied,
+	}
 }
 
 // Size returns the total number of chunks for the snapshot and queue, or 0 when closed.
@@ -465,6 +465,40 @@ func TestChunkQueue_RetryAll(t *testing.T) {
 	assert.Equal(t, errDone, err)
 }
 
+func TestChunkQueue_Skip(t *testing.T) {
+	queue, teardown := setupChunkQueue(t)
+	defer teardown()
+
+	// Skipped chunks are neither allocated nor returned
+	queue.Skip(2)
+	require.EqualValues(t, 2, queue.progress().Applied)
+
+	index, err := queue.Allocate()
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, index)
+
+	_, err = queue.Add(&chunk{Height: 3, Format: 1, Index: 2, Chunk: []byte{2}})
+	require.NoError(t, err)
+	chunk, err := queue.Next()
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, chunk.Index)
+	assert.Equal(t, &restoreProgress{Height: 3, Format: 1, Hash: []byte{7}, Applied: 3}, queue.progress())
+
+	// Discarding a skipped chunk fetches it again
+	require.NoError(t, queue.Discard(1))
+	index, err = queue.Allocate()
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, index)
+	assert.EqualValues(t, 1, queue.progress().Applied)
+
+	// Retrying all chunks fetches all skipped chunks
+	queue.RetryAll()
+	index, err = queue.Allocate()
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, index)
+	assert.EqualValues(t, 0, queue.progress().Applied)
+}
+
 func TestChunkQueue_Size(t *testing.T) {
 	queue, teardown := setupChunkQueue(t)
 	defer teardown()
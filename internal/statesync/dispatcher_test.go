@@ -139,6 +139,40 @@ func TestDispatcherProviders(t *testing.T) {
 	}
 }
 
+func TestBlockProviderLocalLightBlocks(t *testing.T) {
+	t.Cleanup(leaktest.Check(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	chans, ch := testChannel(100)
+	d := NewDispatcher(ch)
+
+	vals, pv := factory.RandValidatorSet(3, 10)
+	_, _, localLB := mockLB(t, 10, factory.DefaultTestTime, factory.MakeBlockID(), vals, pv)
+
+	p := NewBlockProvider(factory.NodeID("a"), factory.DefaultTestChainID, d)
+	p.local = func(height int64) (*types.LightBlock, error) {
+		if height == localLB.Height {
+			return localLB, nil
+		}
+		return nil, nil
+	}
+
+	// the local light block is served without requesting it from the peer
+	lb, err := p.LightBlock(ctx, 10)
+	require.NoError(t, err)
+	require.Equal(t, localLB, lb)
+	require.Empty(t, chans.Out)
+
+	// the others are requested from the peer
+	ctx, cancelFunc := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancelFunc()
+	_, err = p.LightBlock(ctx, 11)
+	require.Equal(t, context.DeadlineExceeded, err)
+	require.Len(t, chans.Out, 1)
+}
+
 func TestPeerListBasic(t *testing.T) {
 	t.Cleanup(leaktest.Check(t))
 
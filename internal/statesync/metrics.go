@@ -19,8 +19,10 @@ type Metrics struct {
 	SnapshotHeight      metrics.Gauge
 	SnapshotChunk       metrics.Counter
 	SnapshotChunkTotal  metrics.Gauge
+	ChunkRetries        metrics.Counter
 	BackFilledBlocks    metrics.Counter
 	BackFillBlocksTotal metrics.Gauge
+	LocalLightBlocks    metrics.Counter
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -62,6 +64,12 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "snapshot_chunks_total",
 			Help:      "The total number of chunks in the current snapshot.",
 		}, labels).With(labelsAndValues...),
+		ChunkRetries: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "chunk_retries",
+			Help:      "The number of chunk requests retried after timing out.",
+		}, labels).With(labelsAndValues...),
 		BackFilledBlocks: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
@@ -74,6 +82,12 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "backfilled_blocks_total",
 			Help:      "The total number of blocks that need to be back-filled.",
 		}, labels).With(labelsAndValues...),
+		LocalLightBlocks: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "local_light_blocks",
+			Help:      "The number of light blocks sourced from the local block store rather than peers.",
+		}, labels).With(labelsAndValues...),
 	}
 }
 
@@ -85,7 +99,9 @@ func NopMetrics() *Metrics {
 		SnapshotHeight:      discard.NewGauge(),
 		SnapshotChunk:       discard.NewCounter(),
 		SnapshotChunkTotal:  discard.NewGauge(),
+		ChunkRetries:        discard.NewCounter(),
 		BackFilledBlocks:    discard.NewCounter(),
 		BackFillBlocksTotal: discard.NewGauge(),
+		LocalLightBlocks:    discard.NewCounter(),
 	}
 }
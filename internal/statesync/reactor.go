@@ -81,6 +81,7 @@ func GetChannelDescriptors() []*p2p.ChannelDescriptor {
 			SendQueueCapacity:   10,
 			RecvMessageCapacity: snapshotMsgSize,
 			RecvBufferCapacity:  128,
+			Compress:            true,
 		},
 		{
 			ID:                  ChunkChannel,
@@ -89,6 +90,7 @@ func GetChannelDescriptors() []*p2p.ChannelDescriptor {
 			SendQueueCapacity:   4,
 			RecvMessageCapacity: chunkMsgSize,
 			RecvBufferCapacity:  128,
+			Compress:            true,
 		},
 		{
 			ID:                  LightBlockChannel,
@@ -159,6 +161,28 @@ type Reactor struct {
 	metrics            *Metrics
 	backfillBlockTotal int64
 	backfilledBlocks   int64
+
+	checkpoints types.Checkpoints
+
+	// The snapshots advertised to peers once the application reports the
+	// snapshots it creates in its Commit responses, refreshed on each report.
+	// Until then, the snapshots are listed from the application on every
+	// request.
+	snapshotsMtx    sync.Mutex
+	snapshots       []*snapshot
+	snapshotsCached bool
+	snapshotRetain  uint64 // height of the oldest snapshot retained
+	pendingSnapshot uint64 // height of a reported snapshot to advertise
+	snapshotHintCh  chan struct{}
+}
+
+// ReactorOption sets an optional parameter on the Reactor.
+type ReactorOption func(*Reactor)
+
+// ReactorCheckpoints sets checkpoints of the chain, which the light blocks
+// verified by the light client of the state provider must match.
+func ReactorCheckpoints(checkpoints types.Checkpoints) ReactorOption {
+	return func(r *Reactor) { r.checkpoints = checkpoints }
 }
 
 // NewReactor returns a reference to a new state sync reactor, which implements
@@ -178,26 +202,31 @@ func NewReactor(
 	blockStore *store.BlockStore,
 	tempDir string,
 	ssMetrics *Metrics,
+	options ...ReactorOption,
 ) *Reactor {
 	r := &Reactor{
-		logger:        logger,
-		chainID:       chainID,
-		initialHeight: initialHeight,
-		cfg:           cfg,
-		conn:          conn,
-		connQuery:     connQuery,
-		snapshotCh:    snapshotCh,
-		chunkCh:       chunkCh,
-		blockCh:       blockCh,
-		paramsCh:      paramsCh,
-		peerUpdates:   peerUpdates,
-		tempDir:       tempDir,
-		stateStore:    stateStore,
-		blockStore:    blockStore,
-		peers:         newPeerList(),
-		dispatcher:    NewDispatcher(blockCh),
-		providers:     make(map[types.NodeID]*BlockProvider),
-		metrics:       ssMetrics,
+		logger:         logger,
+		chainID:        chainID,
+		initialHeight:  initialHeight,
+		cfg:            cfg,
+		conn:           conn,
+		connQuery:      connQuery,
+		snapshotCh:     snapshotCh,
+		chunkCh:        chunkCh,
+		blockCh:        blockCh,
+		paramsCh:       paramsCh,
+		peerUpdates:    peerUpdates,
+		tempDir:        tempDir,
+		stateStore:     stateStore,
+		blockStore:     blockStore,
+		peers:          newPeerList(),
+		dispatcher:     NewDispatcher(blockCh),
+		providers:      make(map[types.NodeID]*BlockProvider),
+		metrics:        ssMetrics,
+		snapshotHintCh: make(chan struct{}, 1),
+	}
+	for _, option := range options {
+		option(r)
 	}
 
 	r.BaseService = *service.NewBaseService(logger, "StateSync", r)
@@ -216,6 +245,7 @@ func (r *Reactor) OnStart(ctx context.Context) error {
 	go r.processCh(ctx, r.blockCh, "light block")
 	go r.processCh(ctx, r.paramsCh, "consensus params")
 	go r.processPeerUpdates(ctx)
+	go r.processSnapshotHints(ctx)
 
 	return nil
 }
@@ -347,6 +377,28 @@ func (r *Reactor) backfill(
 
 	queue := newBlockQueue(startHeight, stopHeight, initialHeight, stopTime, maxLightBlockRequestRetries)
 
+	// the light blocks already in the local store are verified like the ones
+	// fetched from peers, without requesting them. Those failing verification
+	// are fetched from peers instead.
+	var (
+		localMtx    sync.Mutex
+		localFailed = make(map[int64]bool)
+	)
+	localLightBlock := func(height int64) *types.LightBlock {
+		localMtx.Lock()
+		defer localMtx.Unlock()
+		if localFailed[height] {
+			return nil
+		}
+		lb, err := r.localLightBlock(chainID, height)
+		if err != nil {
+			r.logger.Debug("backfill: unusable light block in the local store", "height", height, "err", err)
+			localFailed[height] = true
+			return nil
+		}
+		return lb
+	}
+
 	// fetch light blocks across four workers. The aim with deploying concurrent
 	// workers is to equate the network messaging time with the verification
 	// time. Ideally we want the verification process to never have to be
@@ -361,6 +413,12 @@ func (r *Reactor) backfill(
 				case <-ctx.Done():
 					return
 				case height := <-queue.nextHeight():
+					if lb := localLightBlock(height); lb != nil {
+						queue.add(lightBlockResponse{block: lb})
+						r.logger.Debug("backfill: added local light block to processing queue", "height", height)
+						continue
+					}
+
 					// pop the next peer of the list to send a request to
 					peer := r.peers.Pop(ctx)
 					r.logger.Debug("fetching next block", "height", height, "peer", peer)
@@ -441,8 +499,12 @@ func (r *Reactor) backfill(
 			// checked in the `ValidateBasic`
 			if w, g := trustedBlockID.Hash, resp.block.Hash(); !bytes.Equal(w, g) {
 				r.logger.Info("received invalid light block. header hash doesn't match trusted LastBlockID",
-					"trustedHash", w, "receivedHash", g, "height", resp.block.Height)
-				if err := r.blockCh.SendError(ctx, p2p.PeerError{
+					"trustedHash", w, "receivedHash", g, "height", resp.block.Height, "peer", resp.peer)
+				if resp.peer == "" {
+					localMtx.Lock()
+					localFailed[resp.block.Height] = true
+					localMtx.Unlock()
+				} else if err := r.blockCh.SendError(ctx, p2p.PeerError{
 					NodeID: resp.peer,
 					Err:    fmt.Errorf("received invalid light block. Expected hash %v, got: %v", w, g),
 				}); err != nil {
@@ -452,8 +514,10 @@ func (r *Reactor) backfill(
 				continue
 			}
 
-			// save the signed headers
-			if err := r.blockStore.SaveSignedHeader(resp.block.SignedHeader, trustedBlockID); err != nil {
+			// save the signed headers, unless they came from the local store
+			if resp.peer == "" {
+				r.metrics.LocalLightBlocks.Add(1)
+			} else if err := r.blockStore.SaveSignedHeader(resp.block.SignedHeader, trustedBlockID); err != nil {
 				return err
 			}
 
@@ -508,7 +572,7 @@ func (r *Reactor) handleSnapshotMessage(ctx context.Context, envelope *p2p.Envel
 
 	switch msg := envelope.Message.(type) {
 	case *ssproto.SnapshotsRequest:
-		snapshots, err := r.recentSnapshots(ctx, recentSnapshots)
+		snapshots, err := r.advertisedSnapshots(ctx)
 		if err != nil {
 			logger.Error("failed to fetch snapshots", "err", err)
 			return nil
@@ -839,7 +903,7 @@ func (r *Reactor) processPeerUpdate(ctx context.Context, peerUpdate p2p.PeerUpda
 
 	switch peerUpdate.Status {
 	case p2p.PeerStatusUp:
-		newProvider := NewBlockProvider(peerUpdate.NodeID, r.chainID, r.dispatcher)
+		newProvider := r.newBlockProvider(peerUpdate.NodeID, r.chainID)
 		r.providers[peerUpdate.NodeID] = newProvider
 		err := r.syncer.AddPeer(ctx, peerUpdate.NodeID)
 		if err != nil {
@@ -913,6 +977,108 @@ func (r *Reactor) recentSnapshots(ctx context.Context, n uint32) ([]*snapshot, e
 	return snapshots, nil
 }
 
+// NotifySnapshots implements state.SnapshotNotifier. The snapshots below
+// retainHeight are no longer advertised, and the advertised snapshots are
+// refreshed from the application and the snapshot at height, if any,
+// advertised to all peers, without blocking the caller.
+func (r *Reactor) NotifySnapshots(height, retainHeight uint64) {
+	r.snapshotsMtx.Lock()
+	if retainHeight > r.snapshotRetain {
+		r.snapshotRetain = retainHeight
+		r.snapshots = retainSnapshots(r.snapshots, retainHeight)
+	}
+	if height > r.pendingSnapshot {
+		r.pendingSnapshot = height
+	}
+	r.snapshotsMtx.Unlock()
+
+	if height > 0 {
+		select {
+		case r.snapshotHintCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// processSnapshotHints refreshes the advertised snapshots when the application
+// reports a new snapshot, and advertises it to all peers.
+func (r *Reactor) processSnapshotHints(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.snapshotHintCh:
+		}
+
+		r.snapshotsMtx.Lock()
+		height := r.pendingSnapshot
+		r.pendingSnapshot = 0
+		r.snapshotsMtx.Unlock()
+
+		snapshots, err := r.recentSnapshots(ctx, recentSnapshots)
+		if err != nil {
+			r.logger.Error("failed to fetch snapshots", "err", err)
+			continue
+		}
+
+		r.snapshotsMtx.Lock()
+		snapshots = retainSnapshots(snapshots, r.snapshotRetain)
+		r.snapshots = snapshots
+		r.snapshotsCached = true
+		r.snapshotsMtx.Unlock()
+
+		for _, snapshot := range snapshots {
+			if snapshot.Height != height {
+				continue
+			}
+			r.logger.Info("advertising new snapshot", "height", snapshot.Height, "format", snapshot.Format)
+
+			if err := r.snapshotCh.Send(ctx, p2p.Envelope{
+				Broadcast: true,
+				Message: &ssproto.SnapshotsResponse{
+					Height:   snapshot.Height,
+					Format:   snapshot.Format,
+					Chunks:   snapshot.Chunks,
+					Hash:     snapshot.Hash,
+					Metadata: snapshot.Metadata,
+				},
+			}); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// advertisedSnapshots returns the snapshots to advertise to a peer: the cached
+// snapshots once the application has reported one, or else the most recent
+// snapshots of the application.
+func (r *Reactor) advertisedSnapshots(ctx context.Context) ([]*snapshot, error) {
+	r.snapshotsMtx.Lock()
+	if r.snapshotsCached {
+		defer r.snapshotsMtx.Unlock()
+		return r.snapshots, nil
+	}
+	retainHeight := r.snapshotRetain
+	r.snapshotsMtx.Unlock()
+
+	snapshots, err := r.recentSnapshots(ctx, recentSnapshots)
+	if err != nil {
+		return nil, err
+	}
+	return retainSnapshots(snapshots, retainHeight), nil
+}
+
+// retainSnapshots returns the snapshots at or above retainHeight.
+func retainSnapshots(snapshots []*snapshot, retainHeight uint64) []*snapshot {
+	retained := make([]*snapshot, 0, len(snapshots))
+	for _, s := range snapshots {
+		if s.Height >= retainHeight {
+			retained = append(retained, s)
+		}
+	}
+	return retained
+}
+
 // fetchLightBlock works out whether the node has a light block at a particular
 // height and if so returns it so it can be gossiped to peers
 func (r *Reactor) fetchLightBlock(height uint64) (*types.LightBlock, error) {
@@ -945,6 +1111,33 @@ func (r *Reactor) fetchLightBlock(height uint64) (*types.LightBlock, error) {
 	}, nil
 }
 
+// newBlockProvider returns a light block provider for the given peer, which
+// serves the light blocks in the local stores without requesting them.
+func (r *Reactor) newBlockProvider(peer types.NodeID, chainID string) *BlockProvider {
+	p := NewBlockProvider(peer, chainID, r.dispatcher)
+	p.local = func(height int64) (*types.LightBlock, error) {
+		return r.localLightBlock(chainID, height)
+	}
+	return p
+}
+
+// localLightBlock returns the light block at the given height from the local
+// block and state stores, or nil if they don't have it. An error is returned if
+// the light block is invalid.
+func (r *Reactor) localLightBlock(chainID string, height int64) (*types.LightBlock, error) {
+	if height <= 0 {
+		return nil, nil
+	}
+	lb, err := r.fetchLightBlock(uint64(height))
+	if err != nil || lb == nil {
+		return nil, err
+	}
+	if err := lb.ValidateBasic(chainID); err != nil {
+		return nil, err
+	}
+	return lb, nil
+}
+
 func (r *Reactor) waitForEnoughPeers(ctx context.Context, numPeers int) error {
 	startAt := time.Now()
 	t := time.NewTicker(100 * time.Millisecond)
@@ -992,15 +1185,17 @@ func (r *Reactor) initStateProvider(ctx context.Context, chainID string, initial
 		peers := r.peers.All()
 		providers := make([]provider.Provider, len(peers))
 		for idx, p := range peers {
-			providers[idx] = NewBlockProvider(p, chainID, r.dispatcher)
+			providers[idx] = r.newBlockProvider(p, chainID)
 		}
 
-		r.stateProvider, err = NewP2PStateProvider(ctx, chainID, initialHeight, providers, to, r.paramsCh, spLogger)
+		r.stateProvider, err = NewP2PStateProvider(ctx, chainID, initialHeight, providers, to, r.paramsCh, spLogger,
+			light.Checkpoints(r.checkpoints))
 		if err != nil {
 			return fmt.Errorf("failed to initialize P2P state provider: %w", err)
 		}
 	} else {
-		r.stateProvider, err = NewRPCStateProvider(ctx, chainID, initialHeight, r.cfg.RPCServers, to, spLogger)
+		r.stateProvider, err = NewRPCStateProvider(ctx, chainID, initialHeight, r.cfg.RPCServers, to, spLogger,
+			light.Checkpoints(r.checkpoints))
 		if err != nil {
 			return fmt.Errorf("failed to initialize RPC state provider: %w", err)
 		}
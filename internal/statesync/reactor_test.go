@@ -412,6 +412,57 @@ func TestReactor_SnapshotsRequest(t *testing.T) {
 	}
 }
 
+func TestReactor_SnapshotHints(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	conn := &proxymocks.AppConnSnapshot{}
+	conn.On("ListSnapshotsSync", mock.Anything, abci.RequestListSnapshots{}).Return(&abci.ResponseListSnapshots{
+		Snapshots: []*abci.Snapshot{
+			{Height: 1, Format: 1, Chunks: 1, Hash: []byte{1}},
+			{Height: 2, Format: 1, Chunks: 1, Hash: []byte{2}},
+			{Height: 3, Format: 1, Chunks: 1, Hash: []byte{3}},
+		},
+	}, nil)
+
+	rts := setup(ctx, t, conn, nil, nil, 100)
+
+	requestSnapshots := func() []uint64 {
+		rts.snapshotInCh <- p2p.Envelope{
+			From:    types.NodeID("aa"),
+			Message: &ssproto.SnapshotsRequest{},
+		}
+		var heights []uint64
+		for {
+			select {
+			case e := <-rts.snapshotOutCh:
+				heights = append(heights, e.Message.(*ssproto.SnapshotsResponse).Height)
+			case <-time.After(100 * time.Millisecond):
+				return heights
+			}
+		}
+	}
+
+	// the new snapshot is advertised to all peers right away
+	rts.reactor.NotifySnapshots(3, 2)
+	select {
+	case e := <-rts.snapshotOutCh:
+		require.True(t, e.Broadcast)
+		require.EqualValues(t, 3, e.Message.(*ssproto.SnapshotsResponse).Height)
+	case <-time.After(time.Second):
+		t.Fatal("new snapshot not advertised")
+	}
+
+	// requests are served from the refreshed snapshots, without those below
+	// the retain height
+	require.Equal(t, []uint64{3, 2}, requestSnapshots())
+	conn.AssertNumberOfCalls(t, "ListSnapshotsSync", 1)
+
+	rts.reactor.NotifySnapshots(0, 3)
+	require.Equal(t, []uint64{3}, requestSnapshots())
+	conn.AssertNumberOfCalls(t, "ListSnapshotsSync", 1)
+}
+
 func TestReactor_LightBlockResponse(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -671,6 +722,89 @@ func TestReactor_Backfill(t *testing.T) {
 	}
 }
 
+func TestReactor_BackfillLocalBlocks(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	t.Cleanup(leaktest.CheckTimeout(t, 1*time.Minute))
+	rts := setup(ctx, t, nil, nil, nil, 21)
+
+	var (
+		startHeight int64 = 20
+		stopHeight  int64 = 10
+		stopTime          = time.Date(2020, 1, 1, 0, 100, 0, 0, time.UTC)
+	)
+
+	for _, peer := range []string{"a", "b"} {
+		rts.peerUpdateCh <- p2p.PeerUpdate{
+			NodeID: types.NodeID(peer),
+			Status: p2p.PeerStatusUp,
+		}
+	}
+
+	chain := buildLightBlockChain(t, stopHeight-1, startHeight+1, stopTime)
+
+	// the node already has the blocks in the middle of the range
+	local := map[int64]bool{13: true, 14: true, 15: true, 16: true, 17: true}
+	for height := range local {
+		require.NoError(t, rts.blockStore.SaveSignedHeader(chain[height].SignedHeader,
+			factory.MakeBlockIDWithHash(chain[height].Header.Hash())))
+	}
+	rts.stateStore.On("LoadValidators", mock.AnythingOfType("int64")).Return(
+		func(height int64) *types.ValidatorSet { return chain[height].ValidatorSet },
+		func(height int64) error { return nil },
+	)
+	rts.stateStore.On("SaveValidatorSets", mock.AnythingOfType("int64"), mock.AnythingOfType("int64"),
+		mock.AnythingOfType("*types.ValidatorSet")).Return(nil)
+
+	var (
+		mtx       sync.Mutex
+		requested = make(map[int64]bool)
+	)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case envelope := <-rts.blockOutCh:
+				msg, ok := envelope.Message.(*ssproto.LightBlockRequest)
+				if !ok {
+					continue
+				}
+				mtx.Lock()
+				requested[int64(msg.Height)] = true
+				mtx.Unlock()
+
+				lb, err := chain[int64(msg.Height)].ToProto()
+				require.NoError(t, err)
+				rts.blockInCh <- p2p.Envelope{
+					From:    envelope.To,
+					Message: &ssproto.LightBlockResponse{LightBlock: lb},
+				}
+			}
+		}
+	}()
+
+	err := rts.reactor.backfill(
+		ctx,
+		factory.DefaultTestChainID,
+		startHeight,
+		stopHeight,
+		1,
+		factory.MakeBlockIDWithHash(chain[startHeight].Header.Hash()),
+		stopTime,
+	)
+	require.NoError(t, err)
+
+	mtx.Lock()
+	defer mtx.Unlock()
+	for height := stopHeight; height <= startHeight; height++ {
+		require.NotNil(t, rts.blockStore.LoadBlockMeta(height))
+		require.Equal(t, !local[height], requested[height], "height %d", height)
+	}
+	require.Equal(t, startHeight-stopHeight+1, rts.reactor.backfilledBlocks)
+}
+
 // retryUntil will continue to evaluate fn and will return successfully when true
 // or fail when the timeout is reached.
 func retryUntil(ctx context.Context, t *testing.T, fn func() bool, timeout time.Duration) {
@@ -0,0 +1,69 @@
+package statesync
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/tendermint/tendermint/internal/libs/tempfile"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	tmos "github.com/tendermint/tendermint/libs/os"
+)
+
+// restoreProgress is the progress of a snapshot restoration, persisted so that
+// a restoration interrupted by a restart can resume from the last applied
+// chunk.
+type restoreProgress struct {
+	Height uint64           `json:"height"`
+	Format uint32           `json:"format"`
+	Hash   tmbytes.HexBytes `json:"hash"`
+
+	// Applied is the number of chunks applied by the app, i.e. the index of
+	// the next chunk to apply. Chunks are applied in order.
+	Applied uint32 `json:"applied"`
+}
+
+// matches returns whether the progress is that of the given snapshot.
+func (p *restoreProgress) matches(s *snapshot) bool {
+	return p.Height == s.Height && p.Format == s.Format && bytes.Equal(p.Hash, s.Hash) &&
+		p.Applied < s.Chunks
+}
+
+// loadRestoreProgress loads the restore progress from the given file. It
+// returns nil if there is none.
+func loadRestoreProgress(path string) (*restoreProgress, error) {
+	bz, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	progress := &restoreProgress{}
+	if err := json.Unmarshal(bz, progress); err != nil {
+		return nil, fmt.Errorf("invalid restore progress in %v: %w", path, err)
+	}
+	return progress, nil
+}
+
+// saveRestoreProgress atomically writes the restore progress to the given file.
+func saveRestoreProgress(path string, progress *restoreProgress) error {
+	if err := tmos.EnsureDir(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	bz, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+	return tempfile.WriteFileAtomic(path, bz, 0600)
+}
+
+// removeRestoreProgress removes the restore progress file, if any.
+func removeRestoreProgress(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
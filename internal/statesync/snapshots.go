@@ -130,11 +130,26 @@ func (p *snapshotPool) Best() *snapshot {
 
 // GetPeer returns a random peer for a snapshot, if any.
 func (p *snapshotPool) GetPeer(snapshot *snapshot) types.NodeID {
+	return p.GetPeerExcept(snapshot, nil)
+}
+
+// GetPeerExcept returns a random peer for a snapshot which is not excluded, or
+// a random excluded peer if all are, if any.
+func (p *snapshotPool) GetPeerExcept(snapshot *snapshot, exclude map[types.NodeID]bool) types.NodeID {
 	peers := p.GetPeers(snapshot)
-	if len(peers) == 0 {
+	candidates := make([]types.NodeID, 0, len(peers))
+	for _, peer := range peers {
+		if !exclude[peer] {
+			candidates = append(candidates, peer)
+		}
+	}
+	if len(candidates) == 0 {
+		candidates = peers
+	}
+	if len(candidates) == 0 {
 		return ""
 	}
-	return peers[rand.Intn(len(peers))] // nolint:gosec // G404: Use of weak random number generator
+	return candidates[rand.Intn(len(candidates))] // nolint:gosec // G404: Use of weak random number generator
 }
 
 // GetPeers returns the peers for a snapshot.
@@ -100,6 +100,32 @@ func TestSnapshotPool_GetPeer(t *testing.T) {
 	require.EqualValues(t, "", peer)
 }
 
+func TestSnapshotPool_GetPeerExcept(t *testing.T) {
+	pool := newSnapshotPool()
+
+	s := &snapshot{Height: 1, Format: 1, Chunks: 1, Hash: []byte{1}}
+
+	peerAID := types.NodeID("aa")
+	peerBID := types.NodeID("bb")
+
+	_, err := pool.Add(peerAID, s)
+	require.NoError(t, err)
+
+	_, err = pool.Add(peerBID, s)
+	require.NoError(t, err)
+
+	// Excluded peers are not returned, unless all peers are excluded
+	for i := 0; i < 10; i++ {
+		require.Equal(t, peerBID, pool.GetPeerExcept(s, map[types.NodeID]bool{peerAID: true}))
+	}
+	peer := pool.GetPeerExcept(s, map[types.NodeID]bool{peerAID: true, peerBID: true})
+	require.Contains(t, []types.NodeID{peerAID, peerBID}, peer)
+
+	// GetPeerExcept should return empty for an unknown snapshot
+	peer = pool.GetPeerExcept(&snapshot{Height: 9, Format: 9}, nil)
+	require.EqualValues(t, "", peer)
+}
+
 func TestSnapshotPool_GetPeers(t *testing.T) {
 	pool := newSnapshotPool()
 
@@ -55,6 +55,7 @@ func NewRPCStateProvider(
 	servers []string,
 	trustOptions light.TrustOptions,
 	logger log.Logger,
+	lightOptions ...light.Option,
 ) (StateProvider, error) {
 	if len(servers) < 2 {
 		return nil, fmt.Errorf("at least 2 RPC servers are required, got %d", len(servers))
@@ -75,7 +76,7 @@ func NewRPCStateProvider(
 	}
 
 	lc, err := light.NewClient(ctx, chainID, trustOptions, providers[0], providers[1:],
-		lightdb.New(dbm.NewMemDB()), light.Logger(logger))
+		lightdb.New(dbm.NewMemDB()), append([]light.Option{light.Logger(logger)}, lightOptions...)...)
 	if err != nil {
 		return nil, err
 	}
@@ -164,6 +165,7 @@ func (s *stateProviderRPC) State(ctx context.Context, height uint64) (sm.State,
 	state.LastBlockID = lastLightBlock.Commit.BlockID
 	state.AppHash = currentLightBlock.AppHash
 	state.LastResultsHash = currentLightBlock.LastResultsHash
+	state.LastEventsHash = currentLightBlock.LastEventsHash
 	state.LastValidators = lastLightBlock.ValidatorSet
 	state.Validators = currentLightBlock.ValidatorSet
 	state.NextValidators = nextLightBlock.ValidatorSet
@@ -216,13 +218,14 @@ func NewP2PStateProvider(
 	trustOptions light.TrustOptions,
 	paramsSendCh *p2p.Channel,
 	logger log.Logger,
+	lightOptions ...light.Option,
 ) (StateProvider, error) {
 	if len(providers) < 2 {
 		return nil, fmt.Errorf("at least 2 peers are required, got %d", len(providers))
 	}
 
 	lc, err := light.NewClient(ctx, chainID, trustOptions, providers[0], providers[1:],
-		lightdb.New(dbm.NewMemDB()), light.Logger(logger))
+		lightdb.New(dbm.NewMemDB()), append([]light.Option{light.Logger(logger)}, lightOptions...)...)
 	if err != nil {
 		return nil, err
 	}
@@ -310,6 +313,7 @@ func (s *stateProviderP2P) State(ctx context.Context, height uint64) (sm.State,
 	state.LastBlockID = lastLightBlock.Commit.BlockID
 	state.AppHash = currentLightBlock.AppHash
 	state.LastResultsHash = currentLightBlock.LastResultsHash
+	state.LastEventsHash = currentLightBlock.LastEventsHash
 	state.LastValidators = lastLightBlock.ValidatorSet
 	state.Validators = currentLightBlock.ValidatorSet
 	state.NextValidators = nextLightBlock.ValidatorSet
@@ -62,6 +62,7 @@ type syncer struct {
 	tempDir       string
 	fetchers      int32
 	retryTimeout  time.Duration
+	progressFile  string // restore progress file, if resuming is enabled
 
 	mtx     sync.RWMutex
 	chunks  *chunkQueue
@@ -95,6 +96,7 @@ func newSyncer(
 		tempDir:       tempDir,
 		fetchers:      cfg.Fetchers,
 		retryTimeout:  cfg.ChunkRequestTimeout,
+		progressFile:  cfg.RestoreProgressFile(),
 		metrics:       metrics,
 	}
 }
@@ -183,7 +185,7 @@ func (s *syncer) SyncAny(
 	for {
 		// If not nil, we're going to retry restoration of the same snapshot.
 		if snapshot == nil {
-			snapshot = s.snapshots.Best()
+			snapshot = s.bestSnapshot()
 			chunks = nil
 		}
 		if snapshot == nil {
@@ -208,14 +210,18 @@ func (s *syncer) SyncAny(
 		newState, commit, err := s.Sync(ctx, snapshot, chunks)
 		switch {
 		case err == nil:
+			s.clearRestoreProgress()
 			s.metrics.SnapshotHeight.Set(float64(snapshot.Height))
 			s.lastSyncedSnapshotHeight = int64(snapshot.Height)
 			return newState, commit, nil
 
 		case errors.Is(err, errAbort):
+			s.clearRestoreProgress()
 			return sm.State{}, nil, err
 
 		case errors.Is(err, errRetrySnapshot):
+			// the snapshot is offered again, so the restoration starts over
+			s.clearRestoreProgress()
 			chunks.RetryAll()
 			s.logger.Info("Retrying snapshot", "height", snapshot.Height, "format", snapshot.Format,
 				"hash", snapshot.Hash)
@@ -244,9 +250,17 @@ func (s *syncer) SyncAny(
 			}
 
 		default:
+			// The restoration is resumed after a restart, unless the app
+			// could not be verified.
+			if errors.Is(err, errVerifyFailed) {
+				s.clearRestoreProgress()
+			}
 			return sm.State{}, nil, fmt.Errorf("snapshot restoration failed: %w", err)
 		}
 
+		// The snapshot is not resumed
+		s.clearRestoreProgress()
+
 		// Discard snapshot and chunks for next iteration
 		err = chunks.Close()
 		if err != nil {
@@ -295,10 +309,18 @@ func (s *syncer) Sync(ctx context.Context, snapshot *snapshot, chunks *chunkQueu
 	}
 	snapshot.trustedAppHash = appHash
 
-	// Offer snapshot to ABCI app.
-	err = s.offerSnapshot(ctx, snapshot)
-	if err != nil {
-		return sm.State{}, nil, err
+	// Resume an interrupted restoration of the snapshot, skipping the chunks
+	// the app applied, or offer the snapshot to the ABCI app.
+	if progress := s.restoreProgress(); progress != nil && progress.matches(snapshot) {
+		s.logger.Info("Resuming snapshot restoration", "height", snapshot.Height,
+			"format", snapshot.Format, "hash", snapshot.Hash, "chunk", progress.Applied)
+		chunks.Skip(progress.Applied)
+	} else {
+		err = s.offerSnapshot(ctx, snapshot)
+		if err != nil {
+			return sm.State{}, nil, err
+		}
+		s.clearRestoreProgress()
 	}
 
 	// Spawn chunk fetchers. They will terminate when the chunk queue is closed or context canceled.
@@ -442,6 +464,7 @@ func (s *syncer) applyChunks(ctx context.Context, chunks *chunkQueue, start time
 
 		switch resp.Result {
 		case abci.ResponseApplySnapshotChunk_ACCEPT:
+			s.saveRestoreProgress(chunks)
 			s.metrics.SnapshotChunk.Add(1)
 			s.avgChunkTime = time.Since(start).Nanoseconds() / int64(chunks.numChunksReturned())
 			s.metrics.ChunkProcessAvgTime.Set(float64(s.avgChunkTime))
@@ -460,11 +483,13 @@ func (s *syncer) applyChunks(ctx context.Context, chunks *chunkQueue, start time
 }
 
 // fetchChunks requests chunks from peers, receiving allocations from the chunk queue. Chunks
-// will be received from the reactor via syncer.AddChunks() to chunkQueue.Add().
+// will be received from the reactor via syncer.AddChunks() to chunkQueue.Add(). A chunk which
+// is not received in time is requested again, from a peer it was not requested from yet if any.
 func (s *syncer) fetchChunks(ctx context.Context, snapshot *snapshot, chunks *chunkQueue) {
 	var (
 		next  = true
 		index uint32
+		tried map[types.NodeID]bool // peers the chunk was requested from
 		err   error
 	)
 
@@ -485,6 +510,9 @@ func (s *syncer) fetchChunks(ctx context.Context, snapshot *snapshot, chunks *ch
 				s.logger.Error("Failed to allocate chunk from queue", "err", err)
 				return
 			}
+			tried = make(map[types.NodeID]bool)
+		} else {
+			s.metrics.ChunkRetries.Add(1)
 		}
 		s.logger.Info("Fetching snapshot chunk", "height", snapshot.Height,
 			"format", snapshot.Format, "chunk", index, "total", chunks.Size())
@@ -492,9 +520,11 @@ func (s *syncer) fetchChunks(ctx context.Context, snapshot *snapshot, chunks *ch
 		ticker := time.NewTicker(s.retryTimeout)
 		defer ticker.Stop()
 
-		if err := s.requestChunk(ctx, snapshot, index); err != nil {
+		peer, err := s.requestChunk(ctx, snapshot, index, tried)
+		if err != nil {
 			return
 		}
+		tried[peer] = true
 
 		select {
 		case <-chunks.WaitFor(index):
@@ -511,17 +541,23 @@ func (s *syncer) fetchChunks(ctx context.Context, snapshot *snapshot, chunks *ch
 	}
 }
 
-// requestChunk requests a chunk from a peer.
+// requestChunk requests a chunk from a peer, preferring peers the chunk was not
+// requested from yet, and returns the peer.
 //
 // returns nil if there are no peers for the given snapshot or the
 // request is successfully made and an error if the request cannot be
 // completed
-func (s *syncer) requestChunk(ctx context.Context, snapshot *snapshot, chunk uint32) error {
-	peer := s.snapshots.GetPeer(snapshot)
+func (s *syncer) requestChunk(
+	ctx context.Context,
+	snapshot *snapshot,
+	chunk uint32,
+	tried map[types.NodeID]bool,
+) (types.NodeID, error) {
+	peer := s.snapshots.GetPeerExcept(snapshot, tried)
 	if peer == "" {
 		s.logger.Error("No valid peers found for snapshot", "height", snapshot.Height,
 			"format", snapshot.Format, "hash", snapshot.Hash)
-		return nil
+		return "", nil
 	}
 
 	s.logger.Debug(
@@ -542,9 +578,59 @@ func (s *syncer) requestChunk(ctx context.Context, snapshot *snapshot, chunk uin
 	}
 
 	if err := s.chunkCh.Send(ctx, msg); err != nil {
-		return err
+		return "", err
+	}
+	return peer, nil
+}
+
+// bestSnapshot returns the snapshot whose restoration was interrupted, if a peer
+// still has it, or else the best snapshot.
+func (s *syncer) bestSnapshot() *snapshot {
+	if progress := s.restoreProgress(); progress != nil {
+		for _, snapshot := range s.snapshots.Ranked() {
+			if progress.matches(snapshot) {
+				return snapshot
+			}
+		}
+	}
+	return s.snapshots.Best()
+}
+
+// restoreProgress returns the persisted progress of an interrupted
+// restoration, or nil if there is none or resuming is disabled.
+func (s *syncer) restoreProgress() *restoreProgress {
+	if s.progressFile == "" {
+		return nil
+	}
+	progress, err := loadRestoreProgress(s.progressFile)
+	if err != nil {
+		s.logger.Error("Failed to load restore progress", "file", s.progressFile, "err", err)
+		return nil
+	}
+	return progress
+}
+
+// saveRestoreProgress persists the progress of the restoration, if resuming
+// is enabled.
+func (s *syncer) saveRestoreProgress(chunks *chunkQueue) {
+	progress := chunks.progress()
+	if s.progressFile == "" || progress == nil {
+		return
+	}
+	if err := saveRestoreProgress(s.progressFile, progress); err != nil {
+		s.logger.Error("Failed to save restore progress", "file", s.progressFile, "err", err)
+	}
+}
+
+// clearRestoreProgress removes the persisted restore progress, if resuming is
+// enabled.
+func (s *syncer) clearRestoreProgress() {
+	if s.progressFile == "" {
+		return
+	}
+	if err := removeRestoreProgress(s.progressFile); err != nil {
+		s.logger.Error("Failed to remove restore progress", "file", s.progressFile, "err", err)
 	}
-	return nil
 }
 
 // verifyApp verifies the sync, checking the app hash and last block height. It returns the
@@ -3,6 +3,7 @@ package statesync
 import (
 	"context"
 	"errors"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -226,6 +227,83 @@ func TestSyncer_SyncAny_noSnapshots(t *testing.T) {
 	require.Equal(t, errNoSnapshots, err)
 }
 
+func TestSyncer_SyncAny_resume(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	state := sm.State{ChainID: "chain", LastBlockHeight: 1, AppHash: []byte("app_hash")}
+	commit := &types.Commit{BlockID: types.BlockID{Hash: []byte("blockhash")}}
+
+	chunks := []*chunk{
+		{Height: 1, Format: 1, Index: 0, Chunk: []byte{1, 1, 0}},
+		{Height: 1, Format: 1, Index: 1, Chunk: []byte{1, 1, 1}},
+		{Height: 1, Format: 1, Index: 2, Chunk: []byte{1, 1, 2}},
+	}
+	s := &snapshot{Height: 1, Format: 1, Chunks: 3, Hash: []byte{1, 2, 3}}
+
+	stateProvider := &mocks.StateProvider{}
+	stateProvider.On("AppHash", mock.Anything, uint64(1)).Return(state.AppHash, nil)
+	stateProvider.On("Commit", mock.Anything, uint64(1)).Return(commit, nil)
+	stateProvider.On("State", mock.Anything, uint64(1)).Return(state, nil)
+
+	rts := setup(ctx, t, nil, nil, stateProvider, 3)
+	rts.syncer.progressFile = filepath.Join(t.TempDir(), "restore.json")
+
+	// The restoration of the snapshot was interrupted after applying chunk 0,
+	// so it is resumed even though a better snapshot is available.
+	err := saveRestoreProgress(rts.syncer.progressFile, &restoreProgress{
+		Height: s.Height, Format: s.Format, Hash: s.Hash, Applied: 1,
+	})
+	require.NoError(t, err)
+
+	peerID := types.NodeID("aa")
+	_, err = rts.syncer.AddSnapshot(peerID, s)
+	require.NoError(t, err)
+	_, err = rts.syncer.AddSnapshot(peerID, &snapshot{Height: 2, Format: 1, Chunks: 3, Hash: []byte{1}})
+	require.NoError(t, err)
+
+	go func() {
+		for e := range rts.chunkOutCh {
+			msg, ok := e.Message.(*ssproto.ChunkRequest)
+			assert.True(t, ok)
+			assert.EqualValues(t, 1, msg.Height)
+			assert.NotZero(t, msg.Index, "applied chunk should not be fetched")
+
+			_, err := rts.syncer.AddChunk(chunks[msg.Index])
+			assert.NoError(t, err)
+		}
+	}()
+
+	// The snapshot is not offered again, and only the remaining chunks are
+	// applied. The progress is saved after each applied chunk.
+	rts.conn.On("ApplySnapshotChunkSync", mock.Anything, abci.RequestApplySnapshotChunk{
+		Index: 1, Chunk: []byte{1, 1, 1},
+	}).Once().Return(&abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ACCEPT}, nil)
+	rts.conn.On("ApplySnapshotChunkSync", mock.Anything, abci.RequestApplySnapshotChunk{
+		Index: 2, Chunk: []byte{1, 1, 2},
+	}).Once().Run(func(args mock.Arguments) {
+		progress, err := loadRestoreProgress(rts.syncer.progressFile)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 2, progress.Applied)
+	}).Return(&abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ACCEPT}, nil)
+	rts.connQuery.On("InfoSync", mock.Anything, proxy.RequestInfo).Return(&abci.ResponseInfo{
+		LastBlockHeight:  1,
+		LastBlockAppHash: []byte("app_hash"),
+	}, nil)
+
+	newState, lastCommit, err := rts.syncer.SyncAny(ctx, 0, func() error { return nil })
+	require.NoError(t, err)
+	require.Equal(t, state, newState)
+	require.Equal(t, commit, lastCommit)
+
+	// The progress is removed once the restoration completes
+	progress, err := loadRestoreProgress(rts.syncer.progressFile)
+	require.NoError(t, err)
+	require.Nil(t, progress)
+
+	rts.conn.AssertExpectations(t)
+}
+
 func TestSyncer_SyncAny_abort(t *testing.T) {
 	stateProvider := &mocks.StateProvider{}
 	stateProvider.On("AppHash", mock.Anything, mock.Anything).Return([]byte("app_hash"), nil)
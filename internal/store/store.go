@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"strconv"
+	"sync"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/google/orderedcode"
@@ -27,17 +28,41 @@ the Commit data outside the Block. (TODO)
 
 The store can be assumed to contain all contiguous blocks between base and height (inclusive).
 
+Each block is written in a single atomic batch, so a crash can never leave
+a block partially saved. By default the batch is fsynced when it is written;
+with WithAsyncSync the fsync is deferred until Sync is called, which the
+block executor does together with committing the state of the block.
+
 // NOTE: BlockStore methods will panic if they encounter errors
 // deserializing loaded data, indicating probable corruption on disk.
 */
 type BlockStore struct {
 	db dbm.DB
+
+	asyncSync bool
+
+	mtx          sync.Mutex
+	unsyncedTill int64 // height of the last block not yet fsynced, or 0
+}
+
+// BlockStoreOption sets an optional parameter on the BlockStore.
+type BlockStoreOption func(*BlockStore)
+
+// WithAsyncSync makes SaveBlock write blocks without waiting for them to be
+// fsynced to disk. Callers must call Sync before relying on the durability of
+// the saved blocks, e.g. before persisting a state that refers to them.
+func WithAsyncSync() BlockStoreOption {
+	return func(bs *BlockStore) { bs.asyncSync = true }
 }
 
 // NewBlockStore returns a new BlockStore with the given DB,
 // initialized to the last height that was committed to the DB.
-func NewBlockStore(db dbm.DB) *BlockStore {
-	return &BlockStore{db}
+func NewBlockStore(db dbm.DB, options ...BlockStoreOption) *BlockStore {
+	bs := &BlockStore{db: db}
+	for _, option := range options {
+		option(bs)
+	}
+	return bs
 }
 
 // Base returns the first known contiguous block height, or 0 for empty block stores.
@@ -145,7 +170,12 @@ func (bs *BlockStore) LoadBlock(height int64) *types.Block {
 		}
 		buf = append(buf, part.Bytes...)
 	}
-	err := proto.Unmarshal(buf, pbb)
+	// the parts of erasure-coded blocks frame the block, and are followed by
+	// parity parts
+	buf, err := types.PartSetData(buf)
+	if err == nil {
+		err = proto.Unmarshal(buf, pbb)
+	}
 	if err != nil {
 		// NOTE: The existence of meta should imply the existence of the
 		// block. So, make sure meta is only saved after blocks are saved.
@@ -500,7 +530,14 @@ func (bs *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, s
 		panic(err)
 	}
 
-	if err := batch.WriteSync(); err != nil {
+	if bs.asyncSync {
+		if err := batch.Write(); err != nil {
+			panic(err)
+		}
+		bs.mtx.Lock()
+		bs.unsyncedTill = height
+		bs.mtx.Unlock()
+	} else if err := batch.WriteSync(); err != nil {
 		panic(err)
 	}
 
@@ -509,6 +546,65 @@ func (bs *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, s
 	}
 }
 
+// Sync fsyncs the blocks saved since the last call to Sync. It is a no-op
+// unless the store was created WithAsyncSync.
+func (bs *BlockStore) Sync() error {
+	bs.mtx.Lock()
+	defer bs.mtx.Unlock()
+
+	if bs.unsyncedTill == 0 {
+		return nil
+	}
+	// The DB has no way to fsync outstanding writes other than writing with
+	// sync, so record the synced height, which flushes everything before it.
+	if err := bs.db.SetSync(syncedHeightKey(), []byte(strconv.FormatInt(bs.unsyncedTill, 10))); err != nil {
+		return fmt.Errorf("unable to sync block store: %w", err)
+	}
+	bs.unsyncedTill = 0
+	return nil
+}
+
+// CheckIntegrity checks that the latest block was saved completely, returning
+// an error describing the inconsistency if it was not, e.g. following a torn
+// write caused by a crash. It is meant to be called on startup.
+func (bs *BlockStore) CheckIntegrity() error {
+	height := bs.Height()
+	if height == 0 {
+		return nil
+	}
+
+	meta := bs.LoadBlockMeta(height)
+	if meta == nil {
+		return fmt.Errorf("missing meta of latest block %d", height)
+	}
+
+	bz, err := bs.db.Get(blockHashKey(meta.BlockID.Hash))
+	if err != nil {
+		return err
+	}
+	if string(bz) != strconv.FormatInt(height, 10) {
+		return fmt.Errorf("hash %X of latest block %d is indexed at height %q",
+			meta.BlockID.Hash, height, bz)
+	}
+
+	// Headers saved by state sync come with their own commit but no parts.
+	if bs.LoadBlockCommit(height) == nil {
+		for i := 0; i < int(meta.BlockID.PartSetHeader.Total); i++ {
+			if bs.LoadBlockPart(height, i) == nil {
+				return fmt.Errorf("missing part %d of %d of latest block %d",
+					i, meta.BlockID.PartSetHeader.Total, height)
+			}
+		}
+	}
+	if height > bs.Base() && bs.LoadBlockCommit(height-1) == nil {
+		return fmt.Errorf("missing commit of block %d", height-1)
+	}
+	if seen := bs.LoadSeenCommit(); seen != nil && seen.Height > height {
+		return fmt.Errorf("seen commit is for height %d, above the latest block %d", seen.Height, height)
+	}
+	return nil
+}
+
 func (bs *BlockStore) saveBlockPart(height int64, index int, part *types.Part, batch dbm.Batch) {
 	pbp, err := part.ToProto()
 	if err != nil {
@@ -581,11 +677,12 @@ func (bs *BlockStore) Close() error {
 // key prefixes
 const (
 	// prefixes are unique across all tm db's
-	prefixBlockMeta   = int64(0)
-	prefixBlockPart   = int64(1)
-	prefixBlockCommit = int64(2)
-	prefixSeenCommit  = int64(3)
-	prefixBlockHash   = int64(4)
+	prefixBlockMeta    = int64(0)
+	prefixBlockPart    = int64(1)
+	prefixBlockCommit  = int64(2)
+	prefixSeenCommit   = int64(3)
+	prefixBlockHash    = int64(4)
+	prefixSyncedHeight = int64(14)
 )
 
 func blockMetaKey(height int64) []byte {
@@ -635,6 +732,14 @@ func seenCommitKey() []byte {
 	return key
 }
 
+func syncedHeightKey() []byte {
+	key, err := orderedcode.Append(nil, prefixSyncedHeight)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
 func blockHashKey(hash []byte) []byte {
 	key, err := orderedcode.Append(nil, prefixBlockHash, string(hash))
 	if err != nil {
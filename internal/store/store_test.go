@@ -433,6 +433,54 @@ func TestPruneBlocks(t *testing.T) {
 	assert.Nil(t, bs.LoadBlock(1501))
 }
 
+func TestBlockStoreAsyncSync(t *testing.T) {
+	db := dbm.NewMemDB()
+	bs := NewBlockStore(db, WithAsyncSync())
+
+	// Nothing to sync yet.
+	require.NoError(t, bs.Sync())
+	has, err := db.Has(syncedHeightKey())
+	require.NoError(t, err)
+	require.False(t, has)
+
+	for h := int64(1); h <= 3; h++ {
+		block := factory.MakeBlock(state, h, new(types.Commit))
+		bs.SaveBlock(block, block.MakePartSet(2), makeTestCommit(h, tmtime.Now()))
+	}
+	// The blocks are readable before they are synced.
+	require.NotNil(t, bs.LoadBlock(3))
+	has, err = db.Has(syncedHeightKey())
+	require.NoError(t, err)
+	require.False(t, has)
+
+	require.NoError(t, bs.Sync())
+	bz, err := db.Get(syncedHeightKey())
+	require.NoError(t, err)
+	require.Equal(t, "3", string(bz))
+	require.NoError(t, bs.CheckIntegrity())
+}
+
+func TestBlockStoreCheckIntegrity(t *testing.T) {
+	bs, db := freshBlockStore()
+	require.NoError(t, bs.CheckIntegrity())
+
+	for h := int64(1); h <= 3; h++ {
+		block := factory.MakeBlock(state, h, new(types.Commit))
+		bs.SaveBlock(block, block.MakePartSet(2), makeTestCommit(h, tmtime.Now()))
+	}
+	require.NoError(t, bs.CheckIntegrity())
+
+	// Simulate torn writes of the latest block.
+	for _, key := range [][]byte{blockPartKey(3, 0), blockCommitKey(2)} {
+		value, err := db.Get(key)
+		require.NoError(t, err)
+		require.NoError(t, db.Delete(key))
+		require.Error(t, bs.CheckIntegrity(), "%X", key)
+		require.NoError(t, db.Set(key, value))
+	}
+	require.NoError(t, bs.CheckIntegrity())
+}
+
 func TestLoadBlockMeta(t *testing.T) {
 	bs, db := freshBlockStore()
 	height := int64(10)
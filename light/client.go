@@ -118,6 +118,25 @@ func ProviderTimeout(d time.Duration) Option {
 	return func(c *Client) { c.providerTimeout = d }
 }
 
+// EvidenceReceivers option sets providers, usually full nodes, to which all
+// the light client attack evidence the light client forms is reported, in
+// addition to the provider that supplied the conflicting trace.
+func EvidenceReceivers(receivers ...provider.Provider) Option {
+	return func(c *Client) { c.evidenceReceivers = receivers }
+}
+
+// Checkpoints option sets checkpoints of the chain, which the light blocks the
+// light client trusts or verifies backwards must match. A light block at a
+// checkpoint height with another hash fails with a types.ErrCheckpointMismatch.
+func Checkpoints(checkpoints types.Checkpoints) Option {
+	return func(c *Client) { c.checkpoints = checkpoints }
+}
+
+// WithMetrics option sets the metrics of the light client.
+func WithMetrics(metrics *Metrics) Option {
+	return func(c *Client) { c.metrics = metrics }
+}
+
 // Client represents a light client, connected to a single chain, which gets
 // light blocks from a primary provider, verifies them either sequentially or by
 // skipping some and stores them in a trusted store (usually, a local FS).
@@ -138,6 +157,11 @@ type Client struct {
 	primary provider.Provider
 	// Providers used to "witness" new headers.
 	witnesses []provider.Provider
+	// Providers to which all evidence is reported. See EvidenceReceivers
+	evidenceReceivers []provider.Provider
+	// Divergences between the primary and witnesses detected so far, oldest
+	// first. Guarded by providerMutex.
+	divergences []Divergence
 
 	// Where trusted light blocks are stored.
 	trustedStore store.Store
@@ -147,7 +171,11 @@ type Client struct {
 	// See PruningSize option
 	pruningSize uint16
 
-	logger log.Logger
+	// See Checkpoints option
+	checkpoints types.Checkpoints
+
+	metrics *Metrics
+	logger  log.Logger
 }
 
 // NewClient returns a new light client. It returns an error if it fails to
@@ -204,6 +232,7 @@ func NewClient(
 		maxBlockLag:      defaultMaxBlockLag,
 		providerTimeout:  defaultProviderTimeout,
 		pruningSize:      defaultPruningSize,
+		metrics:          NopMetrics(),
 		logger:           log.NewNopLogger(),
 	}
 
@@ -246,6 +275,7 @@ func NewClientFromTrustedStore(
 		witnesses:        witnesses,
 		trustedStore:     trustedStore,
 		pruningSize:      defaultPruningSize,
+		metrics:          NopMetrics(),
 		logger:           log.NewNopLogger(),
 	}
 
@@ -403,6 +433,7 @@ func (c *Client) Update(ctx context.Context, now time.Time) (*types.LightBlock,
 // primary.
 //
 // It will replace the primary provider if an error from a request to the provider occurs
+// or if the primary is caught sending a conflicting header it can not back up.
 func (c *Client) VerifyLightBlockAtHeight(ctx context.Context, height int64, now time.Time) (*types.LightBlock, error) {
 	if height <= 0 {
 		return nil, errors.New("negative or zero height")
@@ -835,6 +866,14 @@ func (c *Client) Witnesses() []provider.Provider {
 	return c.witnesses
 }
 
+// Divergences returns the divergences between the primary and the witnesses
+// that have been detected, oldest first.
+func (c *Client) Divergences() []Divergence {
+	c.providerMutex.Lock()
+	defer c.providerMutex.Unlock()
+	return append([]Divergence(nil), c.divergences...)
+}
+
 // AddProvider adds a providers to the light clients set
 //
 // NOTE: The light client does not check for uniqueness
@@ -855,6 +894,11 @@ func (c *Client) Cleanup() error {
 func (c *Client) updateTrustedLightBlock(l *types.LightBlock) error {
 	c.logger.Debug("updating trusted light block", "light_block", l)
 
+	if err := c.checkpoints.Verify(l.Height, l.Hash()); err != nil {
+		c.logger.Error("light block does not match checkpoint, halting", "err", err)
+		return err
+	}
+
 	if err := c.trustedStore.SaveLightBlock(l); err != nil {
 		return fmt.Errorf("failed to save trusted header: %w", err)
 	}
@@ -891,6 +935,10 @@ func (c *Client) backwards(
 			return fmt.Errorf("failed to obtain the header at height #%d: %w", verifiedHeader.Height-1, err)
 		}
 		interimHeader = interimBlock.Header
+		if err := c.checkpoints.Verify(interimHeader.Height, interimHeader.Hash()); err != nil {
+			c.logger.Error("light block does not match checkpoint, halting", "err", err)
+			return err
+		}
 		c.logger.Debug("verify newHeader against verifiedHeader",
 			"trustedHeight", verifiedHeader.Height,
 			"trustedHash", verifiedHeader.Hash(),
@@ -1049,6 +1097,7 @@ func (c *Client) findNewPrimary(ctx context.Context, height int64, remove bool)
 			// promote respondent as the new primary
 			c.logger.Debug("found new primary", "primary", c.witnesses[response.witnessIndex])
 			c.primary = c.witnesses[response.witnessIndex]
+			c.metrics.PrimarySwitches.Add(1)
 
 			// add promoted witness to the list of witnesses to be removed
 			witnessesToRemove = append(witnessesToRemove, response.witnessIndex)
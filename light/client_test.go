@@ -552,6 +552,39 @@ func TestClient_Update(t *testing.T) {
 	mockFullNode.AssertExpectations(t)
 }
 
+func TestClient_Checkpoints(t *testing.T) {
+	mockFullNode := &provider_mocks.Provider{}
+	mockFullNode.On("LightBlock", mock.Anything, int64(1)).Return(l1, nil)
+	mockFullNode.On("LightBlock", mock.Anything, int64(3)).Return(l3, nil)
+
+	newClient := func(checkpoints types.Checkpoints) *light.Client {
+		c, err := light.NewClient(
+			ctx,
+			chainID,
+			trustOptions,
+			mockFullNode,
+			[]provider.Provider{mockFullNode},
+			dbs.New(dbm.NewMemDB()),
+			light.Logger(log.TestingLogger()),
+			light.Checkpoints(checkpoints),
+		)
+		require.NoError(t, err)
+		return c
+	}
+
+	// a light block matching its checkpoint is trusted
+	c := newClient(types.Checkpoints{3: h3.Hash()})
+	_, err := c.VerifyLightBlockAtHeight(ctx, 3, bTime.Add(2*time.Hour))
+	require.NoError(t, err)
+
+	// a light block not matching its checkpoint is not
+	c = newClient(types.Checkpoints{3: h2.Hash()})
+	_, err = c.VerifyLightBlockAtHeight(ctx, 3, bTime.Add(2*time.Hour))
+	require.ErrorAs(t, err, &types.ErrCheckpointMismatch{})
+	_, err = c.TrustedLightBlock(3)
+	require.Error(t, err)
+}
+
 func TestClient_Concurrency(t *testing.T) {
 	mockFullNode := &provider_mocks.Provider{}
 	mockFullNode.On("LightBlock", mock.Anything, int64(2)).Return(l2, nil)
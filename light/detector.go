@@ -15,13 +15,37 @@ import (
 // More info here:
 // tendermint/docs/architecture/adr-047-handling-evidence-from-light-client.md
 
+// maxDivergences is the number of divergences the light client keeps a record of.
+const maxDivergences = 100
+
+// Divergence is the record of a witness sending a header that conflicts with
+// the header verified from the primary, and of the evidence formed from it.
+type Divergence struct {
+	Height  int64     `json:"height"`
+	Time    time.Time `json:"time"` // when the divergence was detected
+	Primary string    `json:"primary"`
+	Witness string    `json:"witness"`
+
+	// The divergent headers: the one verified from the primary and the
+	// conflicting one from the witness.
+	PrimaryBlock *types.LightBlock `json:"primary_block"`
+	WitnessBlock *types.LightBlock `json:"witness_block"`
+
+	EvidenceAgainstPrimary *types.LightClientAttackEvidence `json:"evidence_against_primary"`
+	// Evidence against the witness can only be formed if the primary can back
+	// up its header. Otherwise the primary is replaced by the witness.
+	EvidenceAgainstWitness *types.LightClientAttackEvidence `json:"evidence_against_witness,omitempty"`
+	PrimaryReplaced        bool                             `json:"primary_replaced"`
+}
+
 // detectDivergence is a second wall of defense for the light client.
 //
 // It takes the target verified header and compares it with the headers of a set of
 // witness providers that the light client is connected to. If a conflicting header
 // is returned it verifies and examines the conflicting header against the verified
 // trace that was produced from the primary. If successful, it produces two sets of evidence
-// and sends them to the opposite provider before halting.
+// and sends them to the opposite provider before halting. If the primary can not back up
+// its header, it is also replaced by the witness.
 //
 // If there are no conflictinge headers, the light client deems the verified target header
 // trusted and saves it to the trusted store.
@@ -202,14 +226,42 @@ func (c *Client) compareNewHeaderWithWitness(ctx context.Context, errc chan erro
 	errc <- nil
 }
 
-// sendEvidence sends evidence to a provider on a best effort basis.
+// sendEvidence sends evidence to a provider, and to the evidence receivers,
+// on a best effort basis.
 func (c *Client) sendEvidence(ctx context.Context, ev *types.LightClientAttackEvidence, receiver provider.Provider) {
-	err := receiver.ReportEvidence(ctx, ev)
-	if err != nil {
-		c.logger.Error("failed to report evidence to provider", "ev", ev, "provider", receiver)
+	for _, p := range append([]provider.Provider{receiver}, c.evidenceReceivers...) {
+		err := p.ReportEvidence(ctx, ev)
+		if err != nil {
+			c.logger.Error("failed to report evidence to provider", "ev", ev, "provider", p, "err", err)
+			continue
+		}
+		c.metrics.EvidenceReported.Add(1)
 	}
 }
 
+// recordDivergence keeps a record of the divergence, dropping the oldest
+// record if there are too many.
+//
+// NOTE: requires a providerMutex lock
+func (c *Client) recordDivergence(d Divergence) {
+	c.metrics.Divergences.Add(1)
+	if len(c.divergences) >= maxDivergences {
+		c.divergences = c.divergences[1:]
+	}
+	c.divergences = append(c.divergences, d)
+}
+
+// replacePrimaryWithWitness promotes the witness at the given index to be the
+// primary, dropping the current primary.
+//
+// NOTE: requires a providerMutex lock
+func (c *Client) replacePrimaryWithWitness(witnessIndex int) {
+	c.primary = c.witnesses[witnessIndex]
+	c.witnesses[witnessIndex] = c.witnesses[len(c.witnesses)-1]
+	c.witnesses = c.witnesses[:len(c.witnesses)-1]
+	c.metrics.PrimarySwitches.Add(1)
+}
+
 // handleConflictingHeaders handles the primary style of attack, which is where a primary and witness have
 // two headers of the same height but with different hashes
 func (c *Client) handleConflictingHeaders(
@@ -240,6 +292,16 @@ func (c *Client) handleConflictingHeaders(
 		"primary", c.primary, "witness", supportingWitness)
 	c.sendEvidence(ctx, evidenceAgainstPrimary, supportingWitness)
 
+	divergence := Divergence{
+		Height:                 primaryBlock.Height,
+		Time:                   now,
+		Primary:                fmt.Sprint(c.primary),
+		Witness:                fmt.Sprint(supportingWitness),
+		PrimaryBlock:           primaryTrace[len(primaryTrace)-1],
+		WitnessBlock:           challendingBlock,
+		EvidenceAgainstPrimary: evidenceAgainstPrimary,
+	}
+
 	if primaryBlock.Commit.Round != witnessTrace[len(witnessTrace)-1].Commit.Round {
 		c.logger.Info("The light client has detected, and prevented, an attempted amnesia attack." +
 			" We think this attack is pretty unlikely, so if you see it, that's interesting to us." +
@@ -247,8 +309,7 @@ func (c *Client) handleConflictingHeaders(
 	}
 
 	// This may not be valid because the witness itself is at fault. So now we reverse it, examining the
-	// trace provided by the witness and holding the primary as the source of truth. Note: primary may not
-	// respond but this is okay as we will halt anyway.
+	// trace provided by the witness and holding the primary as the source of truth.
 	primaryTrace, witnessBlock, err := c.examineConflictingHeaderAgainstTrace(
 		ctx,
 		witnessTrace,
@@ -257,7 +318,14 @@ func (c *Client) handleConflictingHeaders(
 		now,
 	)
 	if err != nil {
-		c.logger.Info("Error validating primary's divergent header", "primary", c.primary, "err", err)
+		// The primary can not back up its header, whereas the witness can. We hold the primary as
+		// faulty and replace it with the witness, so that the light client can carry on with an
+		// honest primary once the attack has been reported.
+		c.logger.Error("Error validating primary's divergent header, replacing primary with witness",
+			"primary", c.primary, "witness", supportingWitness, "err", err)
+		divergence.PrimaryReplaced = true
+		c.recordDivergence(divergence)
+		c.replacePrimaryWithWitness(witnessIndex)
 		return ErrLightClientAttack
 	}
 
@@ -267,6 +335,8 @@ func (c *Client) handleConflictingHeaders(
 	c.logger.Error("Sending evidence against witness by primary", "ev", evidenceAgainstWitness,
 		"primary", c.primary, "witness", supportingWitness)
 	c.sendEvidence(ctx, evidenceAgainstWitness, c.primary)
+	divergence.EvidenceAgainstWitness = evidenceAgainstWitness
+	c.recordDivergence(divergence)
 	// We return the error and don't process anymore witnesses
 	return ErrLightClientAttack
 }
@@ -98,6 +98,16 @@ func TestLightClientAttackEvidence_Lunatic(t *testing.T) {
 		assert.Equal(t, light.ErrLightClientAttack, err)
 	}
 
+	// Both the primary and the witness can back up their header, so the primary is kept
+	assert.Same(t, mockPrimary, c.Primary())
+	divergences := c.Divergences()
+	if assert.Len(t, divergences, 1) {
+		assert.Equal(t, latestHeight, divergences[0].Height)
+		assert.NotNil(t, divergences[0].EvidenceAgainstPrimary)
+		assert.NotNil(t, divergences[0].EvidenceAgainstWitness)
+		assert.False(t, divergences[0].PrimaryReplaced)
+	}
+
 	mockWitness.AssertExpectations(t)
 	mockPrimary.AssertExpectations(t)
 }
@@ -283,6 +293,10 @@ func TestLightClientAttackEvidence_ForwardLunatic(t *testing.T) {
 		return bytes.Equal(evidence.Hash(), evAgainstPrimary.Hash())
 	})).Return(nil).Twice()
 
+	// the evidence is also reported to the configured full node
+	mockFullNode := &provider_mocks.Provider{}
+	mockFullNode.On("ReportEvidence", mock.Anything, mock.Anything).Return(nil).Twice()
+
 	// In order to perform the attack, the primary needs at least one accomplice as a witness to also
 	// send the forged block
 	accomplice := mockPrimary
@@ -301,6 +315,7 @@ func TestLightClientAttackEvidence_ForwardLunatic(t *testing.T) {
 		light.Logger(log.TestingLogger()),
 		light.MaxClockDrift(1*time.Second),
 		light.MaxBlockLag(1*time.Second),
+		light.EvidenceReceivers(mockFullNode),
 	)
 	require.NoError(t, err)
 
@@ -334,6 +349,19 @@ func TestLightClientAttackEvidence_ForwardLunatic(t *testing.T) {
 		assert.Equal(t, light.ErrLightClientAttack, err)
 	}
 
+	// The primary can not provide a header at the height of the witness's header, hence
+	// it is replaced by the witness
+	assert.Same(t, mockWitness, c.Primary())
+	assert.Equal(t, []provider.Provider{accomplice}, c.Witnesses())
+	divergences := c.Divergences()
+	if assert.Len(t, divergences, 1) {
+		assert.Equal(t, forgedHeight, divergences[0].PrimaryBlock.Height)
+		assert.Equal(t, proofHeight, divergences[0].WitnessBlock.Height)
+		assert.NotNil(t, divergences[0].EvidenceAgainstPrimary)
+		assert.Nil(t, divergences[0].EvidenceAgainstWitness)
+		assert.True(t, divergences[0].PrimaryReplaced)
+	}
+
 	// We attempt the same call but now the supporting witness has a block which should
 	// immediately conflict in time with the primary
 	_, err = c.VerifyLightBlockAtHeight(ctx, forgedHeight, bTime.Add(time.Duration(forgedHeight)*time.Minute))
@@ -341,6 +369,13 @@ func TestLightClientAttackEvidence_ForwardLunatic(t *testing.T) {
 		assert.Equal(t, light.ErrLightClientAttack, err)
 	}
 
+	// The witness doesn't have the forged header so the accomplice was promoted to primary,
+	// and replaced again by the witness
+	assert.Same(t, mockWitness, c.Primary())
+	assert.Empty(t, c.Witnesses())
+	assert.Len(t, c.Divergences(), 2)
+	mockFullNode.AssertExpectations(t)
+
 	// Lastly we test the unfortunate case where the light clients supporting witness doesn't update
 	// in enough time
 	mockLaggingWitness := mockNodeFromHeadersAndVals(witnessHeaders, witnessValidators)
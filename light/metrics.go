@@ -0,0 +1,66 @@
+package light
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "light"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Number of times the primary was replaced by a witness.
+	PrimarySwitches metrics.Counter
+
+	// Number of conflicting headers from witnesses that could be verified,
+	// i.e. divergences between the primary and a witness.
+	Divergences metrics.Counter
+
+	// Number of light client attack evidence reports sent to providers.
+	EvidenceReported metrics.Counter
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		PrimarySwitches: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "primary_switches",
+			Help:      "Number of times the primary was replaced by a witness.",
+		}, labels).With(labelsAndValues...),
+		Divergences: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "divergences",
+			Help:      "Number of verified conflicting headers between the primary and a witness.",
+		}, labels).With(labelsAndValues...),
+		EvidenceReported: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "evidence_reported",
+			Help:      "Number of light client attack evidence reports sent to providers.",
+		}, labels).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		PrimarySwitches:  discard.NewCounter(),
+		Divergences:      discard.NewCounter(),
+		EvidenceReported: discard.NewCounter(),
+	}
+}
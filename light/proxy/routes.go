@@ -1,6 +1,8 @@
 package proxy
 
 import (
+	"time"
+
 	"github.com/tendermint/tendermint/libs/bytes"
 	lrpc "github.com/tendermint/tendermint/light/rpc"
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
@@ -21,6 +23,7 @@ func RPCRoutes(c *lrpc.Client) map[string]*rpcserver.RPCFunc {
 		"health":               rpcserver.NewRPCFunc(makeHealthFunc(c), "", false),
 		"status":               rpcserver.NewRPCFunc(makeStatusFunc(c), "", false),
 		"net_info":             rpcserver.NewRPCFunc(makeNetInfoFunc(c), "", false),
+		"net_topology":         rpcserver.NewRPCFunc(makeNetTopologyFunc(c), "", false),
 		"blockchain":           rpcserver.NewRPCFunc(makeBlockchainInfoFunc(c), "minHeight,maxHeight", true),
 		"genesis":              rpcserver.NewRPCFunc(makeGenesisFunc(c), "", true),
 		"genesis_chunked":      rpcserver.NewRPCFunc(makeGenesisChunkedFunc(c), "", true),
@@ -32,18 +35,25 @@ func RPCRoutes(c *lrpc.Client) map[string]*rpcserver.RPCFunc {
 		"commit":               rpcserver.NewRPCFunc(makeCommitFunc(c), "height", true),
 		"tx":                   rpcserver.NewRPCFunc(makeTxFunc(c), "hash,prove", true),
 		"tx_search":            rpcserver.NewRPCFunc(makeTxSearchFunc(c), "query,prove,page,per_page,order_by", false),
-		"block_search":         rpcserver.NewRPCFunc(makeBlockSearchFunc(c), "query,page,per_page,order_by", false),
+		"block_search":         rpcserver.NewRPCFunc(makeBlockSearchFunc(c), "query,page,per_page,order_by,match_events", false),
 		"validators":           rpcserver.NewRPCFunc(makeValidatorsFunc(c), "height,page,per_page", true),
+		"validator_updates":    rpcserver.NewRPCFunc(makeValidatorUpdatesFunc(c), "min_height,max_height", true),
 		"dump_consensus_state": rpcserver.NewRPCFunc(makeDumpConsensusStateFunc(c), "", false),
+		"app_hash_mismatches":  rpcserver.NewRPCFunc(makeAppHashMismatchesFunc(c), "height", false),
 		"consensus_state":      rpcserver.NewRPCFunc(makeConsensusStateFunc(c), "", false),
 		"consensus_params":     rpcserver.NewRPCFunc(makeConsensusParamsFunc(c), "height", true),
+		"consensus_time_stats": rpcserver.NewRPCFunc(makeConsensusTimeStatsFunc(c), "", false),
 		"unconfirmed_txs":      rpcserver.NewRPCFunc(makeUnconfirmedTxsFunc(c), "limit", false),
 		"num_unconfirmed_txs":  rpcserver.NewRPCFunc(makeNumUnconfirmedTxsFunc(c), "", false),
+		"archived_tx":          rpcserver.NewRPCFunc(makeArchivedTxFunc(c), "hash", false),
+		"archived_txs":         rpcserver.NewRPCFunc(makeArchivedTxsFunc(c), "sender,status,page,per_page", false),
 
 		// tx broadcast API
 		"broadcast_tx_commit": rpcserver.NewRPCFunc(makeBroadcastTxCommitFunc(c), "tx", false),
-		"broadcast_tx_sync":   rpcserver.NewRPCFunc(makeBroadcastTxSyncFunc(c), "tx", false),
-		"broadcast_tx_async":  rpcserver.NewRPCFunc(makeBroadcastTxAsyncFunc(c), "tx", false),
+		"broadcast_tx_commit_proof": rpcserver.NewRPCFunc(
+			makeBroadcastTxCommitProofFunc(c), "tx,timeout", false),
+		"broadcast_tx_sync":  rpcserver.NewRPCFunc(makeBroadcastTxSyncFunc(c), "tx", false),
+		"broadcast_tx_async": rpcserver.NewRPCFunc(makeBroadcastTxAsyncFunc(c), "tx", false),
 
 		// abci API
 		"abci_query": rpcserver.NewRPCFunc(makeABCIQueryFunc(c), "path,data,height,prove", false),
@@ -51,6 +61,8 @@ func RPCRoutes(c *lrpc.Client) map[string]*rpcserver.RPCFunc {
 
 		// evidence API
 		"broadcast_evidence": rpcserver.NewRPCFunc(makeBroadcastEvidenceFunc(c), "evidence", false),
+		"evidence": rpcserver.NewRPCFunc(makeEvidenceFunc(c),
+			"committed,type,min_height,max_height,validator_address,page,per_page", false),
 	}
 }
 
@@ -79,6 +91,14 @@ func makeNetInfoFunc(c *lrpc.Client) rpcNetInfoFunc {
 	}
 }
 
+type rpcNetTopologyFunc func(ctx *rpctypes.Context) (*coretypes.ResultNetTopology, error)
+
+func makeNetTopologyFunc(c *lrpc.Client) rpcNetTopologyFunc {
+	return func(ctx *rpctypes.Context) (*coretypes.ResultNetTopology, error) {
+		return c.NetTopology(ctx.Context())
+	}
+}
+
 type rpcBlockchainInfoFunc func(ctx *rpctypes.Context, minHeight, maxHeight int64) (*coretypes.ResultBlockchainInfo, error)
 
 func makeBlockchainInfoFunc(c *lrpc.Client) rpcBlockchainInfoFunc {
@@ -182,20 +202,20 @@ func makeTxSearchFunc(c *lrpc.Client) rpcTxSearchFunc {
 type rpcBlockSearchFunc func(
 	ctx *rpctypes.Context,
 	query string,
-	prove bool,
 	page, perPage *int,
 	orderBy string,
+	matchEvents bool,
 ) (*coretypes.ResultBlockSearch, error)
 
 func makeBlockSearchFunc(c *lrpc.Client) rpcBlockSearchFunc {
 	return func(
 		ctx *rpctypes.Context,
 		query string,
-		prove bool,
 		page, perPage *int,
 		orderBy string,
+		matchEvents bool,
 	) (*coretypes.ResultBlockSearch, error) {
-		return c.BlockSearch(ctx.Context(), query, page, perPage, orderBy)
+		return c.BlockSearch(ctx.Context(), query, page, perPage, orderBy, matchEvents)
 	}
 }
 
@@ -208,6 +228,15 @@ func makeValidatorsFunc(c *lrpc.Client) rpcValidatorsFunc {
 	}
 }
 
+type rpcValidatorUpdatesFunc func(ctx *rpctypes.Context,
+	minHeight, maxHeight int64) (*coretypes.ResultValidatorUpdates, error)
+
+func makeValidatorUpdatesFunc(c *lrpc.Client) rpcValidatorUpdatesFunc {
+	return func(ctx *rpctypes.Context, minHeight, maxHeight int64) (*coretypes.ResultValidatorUpdates, error) {
+		return c.ValidatorUpdates(ctx.Context(), minHeight, maxHeight)
+	}
+}
+
 type rpcDumpConsensusStateFunc func(ctx *rpctypes.Context) (*coretypes.ResultDumpConsensusState, error)
 
 func makeDumpConsensusStateFunc(c *lrpc.Client) rpcDumpConsensusStateFunc {
@@ -216,6 +245,14 @@ func makeDumpConsensusStateFunc(c *lrpc.Client) rpcDumpConsensusStateFunc {
 	}
 }
 
+type rpcAppHashMismatchesFunc func(ctx *rpctypes.Context, height *int64) (*coretypes.ResultAppHashMismatches, error)
+
+func makeAppHashMismatchesFunc(c *lrpc.Client) rpcAppHashMismatchesFunc {
+	return func(ctx *rpctypes.Context, height *int64) (*coretypes.ResultAppHashMismatches, error) {
+		return c.AppHashMismatches(ctx.Context(), height)
+	}
+}
+
 type rpcConsensusStateFunc func(ctx *rpctypes.Context) (*coretypes.ResultConsensusState, error)
 
 func makeConsensusStateFunc(c *lrpc.Client) rpcConsensusStateFunc {
@@ -224,6 +261,14 @@ func makeConsensusStateFunc(c *lrpc.Client) rpcConsensusStateFunc {
 	}
 }
 
+type rpcConsensusTimeStatsFunc func(ctx *rpctypes.Context) (*coretypes.ResultConsensusTimeStats, error)
+
+func makeConsensusTimeStatsFunc(c *lrpc.Client) rpcConsensusTimeStatsFunc {
+	return func(ctx *rpctypes.Context) (*coretypes.ResultConsensusTimeStats, error) {
+		return c.ConsensusTimeStats(ctx.Context())
+	}
+}
+
 type rpcConsensusParamsFunc func(ctx *rpctypes.Context, height *int64) (*coretypes.ResultConsensusParams, error)
 
 func makeConsensusParamsFunc(c *lrpc.Client) rpcConsensusParamsFunc {
@@ -248,6 +293,30 @@ func makeNumUnconfirmedTxsFunc(c *lrpc.Client) rpcNumUnconfirmedTxsFunc {
 	}
 }
 
+type rpcArchivedTxFunc func(ctx *rpctypes.Context, hash bytes.HexBytes) (*coretypes.ResultArchivedTx, error)
+
+func makeArchivedTxFunc(c *lrpc.Client) rpcArchivedTxFunc {
+	return func(ctx *rpctypes.Context, hash bytes.HexBytes) (*coretypes.ResultArchivedTx, error) {
+		return c.ArchivedTx(ctx.Context(), hash)
+	}
+}
+
+type rpcArchivedTxsFunc func(
+	ctx *rpctypes.Context,
+	sender, status string,
+	page, perPage *int,
+) (*coretypes.ResultArchivedTxs, error)
+
+func makeArchivedTxsFunc(c *lrpc.Client) rpcArchivedTxsFunc {
+	return func(
+		ctx *rpctypes.Context,
+		sender, status string,
+		page, perPage *int,
+	) (*coretypes.ResultArchivedTxs, error) {
+		return c.ArchivedTxs(ctx.Context(), sender, status, page, perPage)
+	}
+}
+
 type rpcBroadcastTxCommitFunc func(ctx *rpctypes.Context, tx types.Tx) (*coretypes.ResultBroadcastTxCommit, error)
 
 func makeBroadcastTxCommitFunc(c *lrpc.Client) rpcBroadcastTxCommitFunc {
@@ -256,6 +325,22 @@ func makeBroadcastTxCommitFunc(c *lrpc.Client) rpcBroadcastTxCommitFunc {
 	}
 }
 
+type rpcBroadcastTxCommitProofFunc func(
+	ctx *rpctypes.Context,
+	tx types.Tx,
+	timeout time.Duration,
+) (*coretypes.ResultBroadcastTxCommitProof, error)
+
+func makeBroadcastTxCommitProofFunc(c *lrpc.Client) rpcBroadcastTxCommitProofFunc {
+	return func(
+		ctx *rpctypes.Context,
+		tx types.Tx,
+		timeout time.Duration,
+	) (*coretypes.ResultBroadcastTxCommitProof, error) {
+		return c.BroadcastTxCommitProof(ctx.Context(), tx, timeout)
+	}
+}
+
 type rpcBroadcastTxSyncFunc func(ctx *rpctypes.Context, tx types.Tx) (*coretypes.ResultBroadcastTx, error)
 
 func makeBroadcastTxSyncFunc(c *lrpc.Client) rpcBroadcastTxSyncFunc {
@@ -302,3 +387,25 @@ func makeBroadcastEvidenceFunc(c *lrpc.Client) rpcBroadcastEvidenceFunc {
 		return c.BroadcastEvidence(ctx.Context(), ev)
 	}
 }
+
+type rpcEvidenceFunc func(
+	ctx *rpctypes.Context,
+	committed bool,
+	evType string,
+	minHeight, maxHeight int64,
+	validatorAddress bytes.HexBytes,
+	page, perPage *int,
+) (*coretypes.ResultEvidenceList, error)
+
+func makeEvidenceFunc(c *lrpc.Client) rpcEvidenceFunc {
+	return func(
+		ctx *rpctypes.Context,
+		committed bool,
+		evType string,
+		minHeight, maxHeight int64,
+		validatorAddress bytes.HexBytes,
+		page, perPage *int,
+	) (*coretypes.ResultEvidenceList, error) {
+		return c.Evidence(ctx.Context(), committed, evType, minHeight, maxHeight, validatorAddress, page, perPage)
+	}
+}
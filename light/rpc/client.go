@@ -203,6 +203,36 @@ func (c *Client) BroadcastTxCommit(ctx context.Context, tx types.Tx) (*coretypes
 	return c.next.BroadcastTxCommit(ctx, tx)
 }
 
+// BroadcastTxCommitProof broadcasts the tx and verifies the proof of its
+// inclusion in the block.
+func (c *Client) BroadcastTxCommitProof(
+	ctx context.Context,
+	tx types.Tx,
+	timeout time.Duration,
+) (*coretypes.ResultBroadcastTxCommitProof, error) {
+	res, err := c.next.BroadcastTxCommitProof(ctx, tx, timeout)
+	if err != nil || res.CheckTx.IsErr() {
+		return res, err
+	}
+
+	// Validate res.
+	if res.Height <= 0 {
+		return nil, coretypes.ErrZeroOrNegativeHeight
+	}
+
+	// Update the light client if we're behind.
+	l, err := c.updateLightClientIfNeededTo(ctx, &res.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the proof.
+	if !bytes.Equal(res.Proof.Leaf(), tx.Hash()) {
+		return nil, fmt.Errorf("proof is for tx %X, not %X", res.Proof.Leaf(), tx.Hash())
+	}
+	return res, res.Proof.Validate(l.DataHash)
+}
+
 func (c *Client) BroadcastTxAsync(ctx context.Context, tx types.Tx) (*coretypes.ResultBroadcastTx, error) {
 	return c.next.BroadcastTxAsync(ctx, tx)
 }
@@ -227,18 +257,48 @@ func (c *Client) RemoveTx(ctx context.Context, txKey types.TxKey) error {
 	return c.next.RemoveTx(ctx, txKey)
 }
 
+// ArchivedTx returns a transaction of the archive of the node, which can't
+// be verified.
+func (c *Client) ArchivedTx(ctx context.Context, hash tmbytes.HexBytes) (*coretypes.ResultArchivedTx, error) {
+	return c.next.ArchivedTx(ctx, hash)
+}
+
+// ArchivedTxs returns archived transactions of a sender, which can't be
+// verified.
+func (c *Client) ArchivedTxs(
+	ctx context.Context,
+	sender, status string,
+	page, perPage *int,
+) (*coretypes.ResultArchivedTxs, error) {
+	return c.next.ArchivedTxs(ctx, sender, status, page, perPage)
+}
+
 func (c *Client) NetInfo(ctx context.Context) (*coretypes.ResultNetInfo, error) {
 	return c.next.NetInfo(ctx)
 }
 
+func (c *Client) NetTopology(ctx context.Context) (*coretypes.ResultNetTopology, error) {
+	return c.next.NetTopology(ctx)
+}
+
 func (c *Client) DumpConsensusState(ctx context.Context) (*coretypes.ResultDumpConsensusState, error) {
 	return c.next.DumpConsensusState(ctx)
 }
 
+// AppHashMismatches returns the forensic dumps of the node, which can't be
+// verified.
+func (c *Client) AppHashMismatches(ctx context.Context, height *int64) (*coretypes.ResultAppHashMismatches, error) {
+	return c.next.AppHashMismatches(ctx, height)
+}
+
 func (c *Client) ConsensusState(ctx context.Context) (*coretypes.ResultConsensusState, error) {
 	return c.next.ConsensusState(ctx)
 }
 
+func (c *Client) ConsensusTimeStats(ctx context.Context) (*coretypes.ResultConsensusTimeStats, error) {
+	return c.next.ConsensusTimeStats(ctx)
+}
+
 func (c *Client) ConsensusParams(ctx context.Context, height *int64) (*coretypes.ResultConsensusParams, error) {
 	res, err := c.next.ConsensusParams(ctx, height)
 	if err != nil {
@@ -450,6 +510,20 @@ func (c *Client) BlockResults(ctx context.Context, height *int64) (*coretypes.Re
 			rH, trustedBlock.LastResultsHash)
 	}
 
+	// Verify the events, if the chain commits to them.
+	if len(trustedBlock.LastEventsHash) > 0 {
+		eH := types.NewEvents(res.BeginBlockEvents, res.TxsResults, res.EndBlockEvents).Hash()
+		if !bytes.Equal(eH, trustedBlock.LastEventsHash) {
+			return nil, fmt.Errorf("events hash %X does not match with trusted last events hash %X",
+				eH, trustedBlock.LastEventsHash)
+		}
+		for i, proof := range res.EventProofs {
+			if err := proof.Verify(trustedBlock.LastEventsHash); err != nil {
+				return nil, fmt.Errorf("invalid proof of event #%d: %w", i, err)
+			}
+		}
+	}
+
 	return res, nil
 }
 
@@ -539,8 +613,9 @@ func (c *Client) BlockSearch(
 	query string,
 	page, perPage *int,
 	orderBy string,
+	matchEvents bool,
 ) (*coretypes.ResultBlockSearch, error) {
-	return c.next.BlockSearch(ctx, query, page, perPage, orderBy)
+	return c.next.BlockSearch(ctx, query, page, perPage, orderBy, matchEvents)
 }
 
 // Validators fetches and verifies validators.
@@ -575,10 +650,59 @@ func (c *Client) Validators(
 	}, nil
 }
 
+// ValidatorUpdates calls rpcclient#ValidatorUpdates and verifies every listed
+// change against the validator sets of the light blocks it changes. The
+// heights not listed are not verified to leave the validator set unchanged.
+func (c *Client) ValidatorUpdates(
+	ctx context.Context,
+	minHeight, maxHeight int64,
+) (*coretypes.ResultValidatorUpdates, error) {
+	res, err := c.next.ValidatorUpdates(ctx, minHeight, maxHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	// Replace every change with the change between the verified validator sets.
+	for i, change := range res.ValidatorSetChanges {
+		if change.Height <= 0 {
+			return nil, coretypes.ErrZeroOrNegativeHeight
+		}
+		prevHeight, nextHeight := change.Height+1, change.Height+2
+		prev, err := c.updateLightClientIfNeededTo(ctx, &prevHeight)
+		if err != nil {
+			return nil, err
+		}
+		next, err := c.updateLightClientIfNeededTo(ctx, &nextHeight)
+		if err != nil {
+			return nil, err
+		}
+
+		verified := types.NewValidatorSetChange(change.Height, prev.ValidatorSet, next.ValidatorSet)
+		if verified.IsEmpty() {
+			return nil, fmt.Errorf("validator set not changed at height %d", change.Height)
+		}
+		res.ValidatorSetChanges[i] = verified
+	}
+
+	return res, nil
+}
+
 func (c *Client) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*coretypes.ResultBroadcastEvidence, error) {
 	return c.next.BroadcastEvidence(ctx, ev)
 }
 
+// Evidence calls rpcclient#Evidence. The listed evidence is not verified.
+func (c *Client) Evidence(
+	ctx context.Context,
+	committed bool,
+	evType string,
+	minHeight, maxHeight int64,
+	validatorAddress tmbytes.HexBytes,
+	page, perPage *int,
+) (*coretypes.ResultEvidenceList, error) {
+	return c.next.Evidence(ctx, committed, evType, minHeight, maxHeight, validatorAddress, page, perPage)
+}
+
 func (c *Client) Subscribe(ctx context.Context, subscriber, query string,
 	outCapacity ...int) (out <-chan coretypes.ResultEvent, err error) {
 	return c.next.Subscribe(ctx, subscriber, query, outCapacity...)
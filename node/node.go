@@ -17,11 +17,19 @@ import (
 	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/internal/consensus"
+	"github.com/tendermint/tendermint/internal/downtime"
 	"github.com/tendermint/tendermint/internal/eventbus"
+	"github.com/tendermint/tendermint/internal/evidence"
+	"github.com/tendermint/tendermint/internal/flightrec"
+	"github.com/tendermint/tendermint/internal/invariants"
+	"github.com/tendermint/tendermint/internal/libs/profiling"
 	"github.com/tendermint/tendermint/internal/mempool"
 	"github.com/tendermint/tendermint/internal/p2p"
+	"github.com/tendermint/tendermint/internal/p2p/pex"
+	"github.com/tendermint/tendermint/internal/p2p/status"
 	"github.com/tendermint/tendermint/internal/proxy"
 	tmpubsub "github.com/tendermint/tendermint/internal/pubsub"
+	rpcauth "github.com/tendermint/tendermint/internal/rpc/auth"
 	rpccore "github.com/tendermint/tendermint/internal/rpc/core"
 	sm "github.com/tendermint/tendermint/internal/state"
 	"github.com/tendermint/tendermint/internal/state/indexer"
@@ -34,11 +42,10 @@ import (
 	tmtime "github.com/tendermint/tendermint/libs/time"
 	"github.com/tendermint/tendermint/privval"
 	tmgrpc "github.com/tendermint/tendermint/privval/grpc"
+	"github.com/tendermint/tendermint/privval/pkcs11"
 	rpcserver "github.com/tendermint/tendermint/rpc/jsonrpc/server"
 	"github.com/tendermint/tendermint/types"
 
-	_ "net/http/pprof" // nolint: gosec // securely exposed on separate, optional port
-
 	_ "github.com/lib/pq" // provide the psql db driver
 )
 
@@ -56,6 +63,7 @@ type nodeImpl struct {
 	// network
 	peerManager *p2p.PeerManager
 	router      *p2p.Router
+	natService  service.Service // for mapping the p2p port and detecting the external address, if enabled
 	nodeInfo    types.NodeInfo
 	nodeKey     types.NodeKey // our node privkey
 	isListening bool
@@ -68,16 +76,32 @@ type nodeImpl struct {
 	bcReactor        service.Service   // for block-syncing
 	mempoolReactor   service.Service   // for gossipping transactions
 	mempool          mempool.Mempool
+	rejectedTxLog    service.Service    // nil if disabled
+	txArchive        service.Service    // nil if disabled
+	downtimeTracker  service.Service    // nil if disabled
+	invariantChecker service.Service    // nil if disabled
+	flightRecorder   service.Service    // nil if disabled
 	stateSync        bool               // whether the node should state sync on startup
 	stateSyncReactor *statesync.Reactor // for hosting and restoring state sync snapshots
 	consensusReactor *consensus.Reactor // for participating in the consensus
+	consensusState   *consensus.State   // for waiting for the block being committed on shutdown
 	pexReactor       service.Service    // for exchanging peer addresses
+	statusReactor    service.Service    // for exchanging the heights of peers
 	evidenceReactor  service.Service
 	rpcListeners     []net.Listener // rpc servers
 	shutdownOps      closer
 	indexerService   service.Service
 	rpcEnv           *rpccore.Environment
 	prometheusSrv    *http.Server
+	halt             *sm.Halt            // nil if no halt is configured
+	profiler         *profiling.Profiler // nil if the pprof server is disabled
+
+	// Services started by OnStart run under contexts owned by the node rather
+	// than the caller, so that OnStop can shut them down in dependency order
+	// instead of all at once when the caller's context ends.
+	stopNetwork   context.CancelFunc // router
+	stopConsensus context.CancelFunc // consensus reactor and state
+	stopReactors  context.CancelFunc // all other reactors
 }
 
 // newDefaultNode returns a Tendermint node with default settings for the
@@ -100,11 +124,12 @@ func newDefaultNode(
 			nodeKey,
 			defaultGenesisDocProviderFunc(cfg),
 			logger,
+			nil,
 		)
 	}
 
 	var pval *privval.FilePV
-	if cfg.Mode == config.ModeValidator {
+	if cfg.Mode == config.ModeValidator && cfg.PrivValidator.PKCS11Module == "" {
 		pval, err = privval.LoadOrGenFilePV(cfg.PrivValidator.KeyFile(), cfg.PrivValidator.StateFile())
 		if err != nil {
 			return nil, err
@@ -124,6 +149,11 @@ func newDefaultNode(
 		defaultGenesisDocProviderFunc(cfg),
 		config.DefaultDBProvider,
 		logger,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 }
 
@@ -137,6 +167,11 @@ func makeNode(
 	genesisDocProvider genesisDocProvider,
 	dbProvider config.DBProvider,
 	logger log.Logger,
+	clock func() time.Time,
+	peerManager *p2p.PeerManager,
+	rejectedTxSink mempool.RejectedTxSink,
+	txSender mempool.TxSenderFunc,
+	downtimeAlertHandler downtime.AlertHandler,
 ) (service.Service, error) {
 	var cancel context.CancelFunc
 	ctx, cancel = context.WithCancel(ctx)
@@ -163,19 +198,46 @@ func makeNode(
 			makeCloser(closers))
 	}
 
+	genHash, err := genesisHash(cfg, genDoc)
+	if err != nil {
+		return nil, combineCloseError(err, makeCloser(closers))
+	}
+
 	state, err := loadStateFromDBOrGenesisDocProvider(stateStore, genDoc)
 	if err != nil {
 		return nil, combineCloseError(err, makeCloser(closers))
 	}
 
+	checkpoints, err := types.ParseCheckpoints(cfg.Checkpoints)
+	if err != nil {
+		return nil, combineCloseError(err, makeCloser(closers))
+	}
+	if err := verifyStoreCheckpoints(blockStore, checkpoints); err != nil {
+		return nil, combineCloseError(err, makeCloser(closers))
+	}
+
 	nodeMetrics := defaultMetricsProvider(cfg.Instrumentation)(genDoc.ChainID)
 
+	tracingCloser, err := initTracing(ctx, cfg.Instrumentation.Tracing, genDoc.ChainID, nodeKey.ID, cfg.Moniker)
+	if err != nil {
+		return nil, combineCloseError(err, makeCloser(closers))
+	}
+	closers = append(closers, tracingCloser)
+
 	// Create the proxyApp and establish connections to the ABCI app (consensus, mempool, query).
-	proxyApp := proxy.NewAppConns(clientCreator, logger.With("module", "proxy"), nodeMetrics.proxy)
+	proxyApp, proxyCloser := createProxyApp(cfg, clientCreator, state.LastBlockHeight, logger, nodeMetrics.proxy)
+	closers = append(closers, proxyCloser)
 	if err := proxyApp.Start(ctx); err != nil {
 		return nil, fmt.Errorf("error starting proxy app connections: %v", err)
 	}
 
+	// Optional features the application doesn't support are disabled. Lanes
+	// are never assigned by such an application, so their channels would idle.
+	if len(cfg.Mempool.Lanes) > 0 && !proxyApp.SupportsFeature(abci.FeatureMempoolLanes) {
+		logger.Info("the application doesn't support mempool lanes, disabling them", "lanes", cfg.Mempool.Lanes)
+		cfg.Mempool.Lanes = nil
+	}
+
 	// EventBus and IndexerService must be started before the handshake because
 	// we might need to index the txs of the replayed block as this might not have happened
 	// when the node stopped last time (i.e. the node stopped after it saved the block
@@ -186,15 +248,25 @@ func makeNode(
 	}
 
 	indexerService, eventSinks, err := createAndStartIndexerService(
-		ctx, cfg, dbProvider, eventBus,
+		ctx, cfg, dbProvider, eventBus, blockStore, stateStore,
 		logger, genDoc.ChainID, nodeMetrics.indexer)
 	if err != nil {
 		return nil, combineCloseError(err, makeCloser(closers))
 	}
 
 	// If an address is provided, listen on the socket for a connection from an
-	// external signing process.
-	if cfg.PrivValidator.ListenAddr != "" {
+	// external signing process. If several are, fail over between them.
+	if pvAddrs := strings.SplitAndTrimEmpty(cfg.PrivValidator.ListenAddr, ",", " "); len(pvAddrs) > 1 {
+		var pvCloser closer
+		privValidator, pvCloser, err = createAndStartPrivValidatorFailoverClient(
+			ctx, cfg, pvAddrs, genDoc.ChainID, logger, nodeMetrics.privval)
+		if err != nil {
+			return nil, combineCloseError(
+				fmt.Errorf("error with private validator failover client: %w", err),
+				makeCloser(closers))
+		}
+		closers = append(closers, pvCloser)
+	} else if cfg.PrivValidator.ListenAddr != "" {
 		protocol, _ := tmnet.ProtocolAndAddress(cfg.PrivValidator.ListenAddr)
 		// FIXME: we should start services inside OnStart
 		switch protocol {
@@ -218,6 +290,16 @@ func makeNode(
 					makeCloser(closers))
 			}
 		}
+	} else if cfg.PrivValidator.PKCS11Module != "" {
+		// If a PKCS#11 module is provided, sign with the key held on the token.
+		var pvCloser closer
+		privValidator, pvCloser, err = createPrivValidatorPKCS11(ctx, cfg, nodeMetrics.privval)
+		if err != nil {
+			return nil, combineCloseError(
+				fmt.Errorf("error with PKCS#11 private validator: %w", err),
+				makeCloser(closers))
+		}
+		closers = append(closers, pvCloser)
 	}
 	var pubKey crypto.PubKey
 	if cfg.Mode == config.ModeValidator {
@@ -247,6 +329,7 @@ func makeNode(
 		if err := consensus.NewHandshaker(
 			logger.With("module", "handshaker"),
 			stateStore, state, blockStore, eventBus, genDoc,
+			consensus.HandshakerAppStateChunkSize(cfg.GenesisAppStateChunkSize),
 		).Handshake(ctx, proxyApp); err != nil {
 			return nil, combineCloseError(err, makeCloser(closers))
 		}
@@ -262,6 +345,26 @@ func makeNode(
 		}
 	}
 
+	// Refuse to start past the halt height or time, to require the halt to be
+	// lifted once the node has been upgraded.
+	var halt *sm.Halt
+	if cfg.Consensus.HaltHeight > 0 || cfg.Consensus.HaltTime > 0 {
+		var haltTime time.Time
+		if cfg.Consensus.HaltTime > 0 {
+			haltTime = time.Unix(cfg.Consensus.HaltTime, 0)
+		}
+		halt = sm.NewHalt(cfg.Consensus.HaltHeight, haltTime, cfg.Consensus.HaltMarkerFile())
+		if halted, err := halt.Check(state); halted {
+			if err != nil {
+				logger.Error("failed to halt", "err", err)
+			}
+			return nil, combineCloseError(
+				fmt.Errorf("%w (height %d), change halt-height and halt-time to continue",
+					sm.ErrHalted, state.LastBlockHeight),
+				makeCloser(closers))
+		}
+	}
+
 	// Determine whether we should do block sync. This must happen after the handshake, since the
 	// app may modify the validator set, specifying ourself as the only validator.
 	blockSync := !onlyValidatorIsUs(state, pubKey)
@@ -270,20 +373,38 @@ func makeNode(
 
 	// TODO: Fetch and provide real options and do proper p2p bootstrapping.
 	// TODO: Use a persistent peer database.
-	nodeInfo, err := makeNodeInfo(cfg, nodeKey, eventSinks, genDoc, state)
+	nodeInfo, err := makeNodeInfo(cfg, nodeKey, eventSinks, genDoc, genHash, state)
 	if err != nil {
 		return nil, combineCloseError(err, makeCloser(closers))
 	}
 
-	peerManager, peerCloser, err := createPeerManager(cfg, dbProvider, nodeKey.ID)
-	closers = append(closers, peerCloser)
-	if err != nil {
-		return nil, combineCloseError(
-			fmt.Errorf("failed to create peer manager: %w", err),
-			makeCloser(closers))
+	// Prove to peers that this node is operated by our validator, so that they
+	// can prioritize it. The node runs without the proof if the signer can't
+	// produce one, e.g. a remote signer predating the request.
+	if cfg.Mode == config.ModeValidator {
+		signer, ok := privValidator.(types.NodeValidatorProofSigner)
+		if !ok {
+			logger.Error("private validator can't sign node validator proofs, peers won't prioritize this node",
+				"type", fmt.Sprintf("%T", privValidator))
+		} else if proof, err := signer.SignNodeValidatorProof(ctx, genDoc.ChainID, nodeKey.ID); err != nil {
+			logger.Error("failed to sign node validator proof, peers won't prioritize this node", "err", err)
+		} else {
+			nodeInfo.ValidatorProof = proof
+		}
 	}
 
-	router, err := createRouter(ctx, logger, nodeMetrics.p2p, nodeInfo, nodeKey,
+	if peerManager == nil {
+		var peerCloser closer
+		peerManager, peerCloser, err = createPeerManager(logger, cfg, dbProvider, nodeKey.ID)
+		closers = append(closers, peerCloser)
+		if err != nil {
+			return nil, combineCloseError(
+				fmt.Errorf("failed to create peer manager: %w", err),
+				makeCloser(closers))
+		}
+	}
+
+	router, natService, err := createRouter(ctx, logger, nodeMetrics.p2p, nodeInfo, nodeKey,
 		peerManager, cfg, proxyApp)
 	if err != nil {
 		return nil, combineCloseError(
@@ -291,20 +412,54 @@ func makeNode(
 			makeCloser(closers))
 	}
 
+	var rejectedTxLog service.Service
+	if rejectedTxSink == nil {
+		txLog, err := createRejectedTxLog(cfg, logger)
+		if err != nil {
+			return nil, combineCloseError(err, makeCloser(closers))
+		}
+		if txLog != nil {
+			rejectedTxSink, rejectedTxLog = txLog, txLog
+		}
+	}
+
+	txArchive, txArchiveCloser, err := createTxArchive(cfg, dbProvider, logger)
+	closers = append(closers, txArchiveCloser)
+	if err != nil {
+		return nil, combineCloseError(err, makeCloser(closers))
+	}
+
+	var downtimeTracker service.Service
+	if pubKey != nil && cfg.Instrumentation.Downtime.Window > 0 {
+		downtimeTracker = createDowntimeTracker(cfg, pubKey.Address(), eventBus, stateStore,
+			blockStore, nodeMetrics.downtime, downtimeAlertHandler, logger)
+	}
+
+	var invariantChecker service.Service
+	if cfg.Instrumentation.Invariants.CheckInterval > 0 {
+		invariantChecker = invariants.NewChecker(logger.With("module", "invariants"),
+			cfg.Instrumentation.Invariants, eventBus, stateStore, blockStore, nodeMetrics.invariants)
+	}
+
 	mpReactor, mp, err := createMempoolReactor(ctx,
-		cfg, proxyApp, state, nodeMetrics.mempool, peerManager, router, logger,
+		cfg, proxyApp, state, nodeMetrics.mempool, peerManager, router, rejectedTxSink, txArchive, txSender, logger,
 	)
 	if err != nil {
 		return nil, combineCloseError(err, makeCloser(closers))
 	}
 
 	evReactor, evPool, err := createEvidenceReactor(ctx,
-		cfg, dbProvider, stateDB, blockStore, peerManager, router, logger,
+		cfg, dbProvider, stateDB, blockStore, peerManager, router, logger, nodeMetrics.evidence,
 	)
 	if err != nil {
 		return nil, combineCloseError(err, makeCloser(closers))
 	}
 
+	var forensics *sm.Forensics
+	if cfg.Consensus.ForensicsPath != "" {
+		forensics = sm.NewForensics(cfg.Consensus.ForensicsDir())
+	}
+
 	// make block executor for consensus and blockchain reactors to execute blocks
 	blockExec := sm.NewBlockExecutor(
 		stateStore,
@@ -314,12 +469,14 @@ func makeNode(
 		evPool,
 		blockStore,
 		sm.BlockExecutorWithMetrics(nodeMetrics.state),
+		sm.BlockExecutorWithHalt(halt),
+		sm.BlockExecutorWithForensics(forensics),
 	)
 
 	csReactor, csState, err := createConsensusReactor(ctx,
-		cfg, state, blockExec, blockStore, mp, evPool,
+		cfg, state, blockExec, blockStore, stateDB, mp, evPool,
 		privValidator, nodeMetrics.consensus, stateSync || blockSync, eventBus,
-		peerManager, router, logger,
+		peerManager, router, logger, clock,
 	)
 	if err != nil {
 		return nil, combineCloseError(err, makeCloser(closers))
@@ -329,7 +486,8 @@ func makeNode(
 	// doing a state sync first.
 	bcReactor, err := createBlockchainReactor(ctx,
 		logger, state, blockExec, blockStore, csReactor,
-		peerManager, router, blockSync && !stateSync, nodeMetrics.consensus,
+		peerManager, router, blockSync && !stateSync, cfg.BlockArchiveLocation(),
+		checkpoints, nodeMetrics.consensus,
 	)
 	if err != nil {
 		return nil, combineCloseError(
@@ -377,16 +535,28 @@ func makeNode(
 		blockStore,
 		cfg.StateSync.TempDir,
 		nodeMetrics.statesync,
+		statesync.ReactorCheckpoints(checkpoints),
 	)
+	blockExec.SetSnapshotNotifier(stateSyncReactor)
+
+	statusReactor, err := createStatusReactor(ctx, logger, peerManager, router, blockStore, nodeMetrics.status)
+	if err != nil {
+		return nil, combineCloseError(err, makeCloser(closers))
+	}
 
-	var pexReactor service.Service
+	var pexReactor *pex.Reactor
 	if cfg.P2P.PexReactor {
-		pexReactor, err = createPEXReactor(ctx, logger, peerManager, router)
+		pexReactor, err = createPEXReactor(ctx, logger, cfg, nodeKey, peerManager, router)
 		if err != nil {
 			return nil, combineCloseError(err, makeCloser(closers))
 		}
 	}
 
+	profiler, err := createProfiler(cfg)
+	if err != nil {
+		return nil, combineCloseError(err, makeCloser(closers))
+	}
+
 	node := &nodeImpl{
 		config:        cfg,
 		logger:        logger,
@@ -403,16 +573,22 @@ func makeNode(
 		bcReactor:        bcReactor,
 		mempoolReactor:   mpReactor,
 		mempool:          mp,
+		rejectedTxLog:    rejectedTxLog,
+		downtimeTracker:  downtimeTracker,
+		invariantChecker: invariantChecker,
 		consensusReactor: csReactor,
+		consensusState:   csState,
 		stateSyncReactor: stateSyncReactor,
 		stateSync:        stateSync,
-		pexReactor:       pexReactor,
+		statusReactor:    statusReactor,
 		evidenceReactor:  evReactor,
 		indexerService:   indexerService,
 		eventBus:         eventBus,
 		eventSinks:       eventSinks,
 
 		shutdownOps: makeCloser(closers),
+		halt:        halt,
+		profiler:    profiler,
 
 		rpcEnv: &rpccore.Environment{
 			ProxyAppQuery:   proxyApp.Query(),
@@ -428,17 +604,47 @@ func makeNode(
 
 			PeerManager: peerManager,
 
-			GenDoc:     genDoc,
-			EventSinks: eventSinks,
-			EventBus:   eventBus,
-			Mempool:    mp,
-			Logger:     logger.With("module", "rpc"),
-			Config:     *cfg.RPC,
+			GenDoc:         genDoc,
+			EventSinks:     eventSinks,
+			IndexerService: indexerService,
+			EventBus:       eventBus,
+			Mempool:        mp,
+			Halt:           halt,
+			Forensics:      forensics,
+			TxArchive:      txArchive,
+			Profiler:       profiler,
+			Logger:         logger.With("module", "rpc"),
+			Config:         *cfg.RPC,
 		},
 	}
+	if natService != nil {
+		node.natService = natService
+	}
+	if pexReactor != nil {
+		node.pexReactor = pexReactor
+		node.rpcEnv.PexReactor = pexReactor
+	}
+	if txArchive != nil {
+		node.txArchive = txArchive
+	}
 
 	node.rpcEnv.P2PTransport = node
 
+	// The flight recorder records the node through its RPC environment, and
+	// dumps its recordings when consensus fails.
+	if cfg.Instrumentation.FlightRecorder.Interval > 0 {
+		flightRecorder := createFlightRecorder(cfg, node.rpcEnv, logger)
+		node.flightRecorder = flightRecorder
+		node.rpcEnv.FlightRecorder = flightRecorder
+		csState.SetFailureHandler(func(interface{}) {
+			go func() {
+				if _, err := flightRecorder.Dump(context.Background(), flightrec.DumpConsensusFailure); err != nil {
+					logger.Error("failed to dump the flight recorder", "err", err)
+				}
+			}()
+		})
+	}
+
 	node.BaseService = *service.NewBaseService(logger, "Node", node)
 
 	return node, nil
@@ -452,6 +658,7 @@ func makeSeedNode(
 	nodeKey types.NodeKey,
 	genesisDocProvider genesisDocProvider,
 	logger log.Logger,
+	peerManager *p2p.PeerManager,
 ) (service.Service, error) {
 	if !cfg.P2P.PexReactor {
 		return nil, errors.New("cannot run seed nodes with PEX disabled")
@@ -461,6 +668,9 @@ func makeSeedNode(
 	if err != nil {
 		return nil, err
 	}
+	if _, err := genesisHash(cfg, genDoc); err != nil {
+		return nil, err
+	}
 
 	state, err := sm.MakeGenesisState(genDoc)
 	if err != nil {
@@ -475,14 +685,18 @@ func makeSeedNode(
 	// Setup Transport and Switch.
 	p2pMetrics := p2p.PrometheusMetrics(cfg.Instrumentation.Namespace, "chain_id", genDoc.ChainID)
 
-	peerManager, closer, err := createPeerManager(cfg, dbProvider, nodeKey.ID)
-	if err != nil {
-		return nil, combineCloseError(
-			fmt.Errorf("failed to create peer manager: %w", err),
-			closer)
+	closer := func() error { return nil }
+	if peerManager == nil {
+		var err error
+		peerManager, closer, err = createPeerManager(logger, cfg, dbProvider, nodeKey.ID)
+		if err != nil {
+			return nil, combineCloseError(
+				fmt.Errorf("failed to create peer manager: %w", err),
+				closer)
+		}
 	}
 
-	router, err := createRouter(ctx, logger, p2pMetrics, nodeInfo, nodeKey,
+	router, natService, err := createRouter(ctx, logger, p2pMetrics, nodeInfo, nodeKey,
 		peerManager, cfg, nil)
 	if err != nil {
 		return nil, combineCloseError(
@@ -490,7 +704,12 @@ func makeSeedNode(
 			closer)
 	}
 
-	pexReactor, err := createPEXReactor(ctx, logger, peerManager, router)
+	pexReactor, err := createPEXReactor(ctx, logger, cfg, nodeKey, peerManager, router)
+	if err != nil {
+		return nil, combineCloseError(err, closer)
+	}
+
+	profiler, err := createProfiler(cfg)
 	if err != nil {
 		return nil, combineCloseError(err, closer)
 	}
@@ -508,6 +727,10 @@ func makeSeedNode(
 		shutdownOps: closer,
 
 		pexReactor: pexReactor,
+		profiler:   profiler,
+	}
+	if natService != nil {
+		node.natService = natService
 	}
 	node.BaseService = *service.NewBaseService(logger, "SeedNode", node)
 
@@ -515,10 +738,10 @@ func makeSeedNode(
 }
 
 // OnStart starts the Node. It implements service.Service.
-func (n *nodeImpl) OnStart(ctx context.Context) error {
+func (n *nodeImpl) OnStart(ctx context.Context) (err error) {
 	if n.config.RPC.PprofListenAddress != "" {
 		rpcCtx, rpcCancel := context.WithCancel(ctx)
-		srv := &http.Server{Addr: n.config.RPC.PprofListenAddress, Handler: nil}
+		srv := &http.Server{Addr: n.config.RPC.PprofListenAddress, Handler: n.profiler.Handler()}
 		go func() {
 			select {
 			case <-ctx.Done():
@@ -539,6 +762,14 @@ func (n *nodeImpl) OnStart(ctx context.Context) error {
 		}()
 	}
 
+	if watermark := n.config.RPC.HeapProfileWatermark; watermark > 0 {
+		go profiling.NewHeapMonitor(
+			n.logger.With("module", "profiling"),
+			uint64(watermark),
+			n.config.RPC.HeapProfileDirectory(),
+		).Run(ctx)
+	}
+
 	now := tmtime.Now()
 	genTime := n.genesisDoc.GenesisTime
 	if genTime.After(now) {
@@ -548,7 +779,7 @@ func (n *nodeImpl) OnStart(ctx context.Context) error {
 
 	// Start the RPC server before the P2P server
 	// so we can eg. receive txs for the first block
-	if n.config.RPC.ListenAddress != "" && n.config.Mode != config.ModeSeed {
+	if (n.config.RPC.ListenAddress != "" || len(n.config.RPC.Listeners) > 0) && n.config.Mode != config.ModeSeed {
 		listeners, err := n.startRPC(ctx)
 		if err != nil {
 			return err
@@ -560,44 +791,127 @@ func (n *nodeImpl) OnStart(ctx context.Context) error {
 		n.prometheusSrv = n.startPrometheusServer(ctx, n.config.Instrumentation.PrometheusListenAddr)
 	}
 
+	netCtx, stopNetwork := context.WithCancel(context.Background())
+	consensusCtx, stopConsensus := context.WithCancel(context.Background())
+	reactorCtx, stopReactors := context.WithCancel(context.Background())
+	n.stopNetwork, n.stopConsensus, n.stopReactors = stopNetwork, stopConsensus, stopReactors
+	defer func() {
+		// OnStop is not called if OnStart fails, so stop anything that has
+		// been started here.
+		if err != nil {
+			stopReactors()
+			stopConsensus()
+			stopNetwork()
+		}
+	}()
+
+	// Start recording before anything else can fail.
+	if n.flightRecorder != nil {
+		if err := n.flightRecorder.Start(reactorCtx); err != nil {
+			return err
+		}
+	}
+
 	// Start the transport.
-	if err := n.router.Start(ctx); err != nil {
+	if err := n.router.Start(netCtx); err != nil {
 		return err
 	}
 	n.isListening = true
 
+	if n.natService != nil {
+		if err := n.natService.Start(netCtx); err != nil {
+			return err
+		}
+	}
+
 	if n.config.Mode != config.ModeSeed {
-		if err := n.bcReactor.Start(ctx); err != nil {
+		if err := n.bcReactor.Start(reactorCtx); err != nil {
 			return err
 		}
 
 		// Start the real consensus reactor separately since the switch uses the shim.
-		if err := n.consensusReactor.Start(ctx); err != nil {
+		if err := n.consensusReactor.Start(consensusCtx); err != nil {
 			return err
 		}
 
 		// Start the real state sync reactor separately since the switch uses the shim.
-		if err := n.stateSyncReactor.Start(ctx); err != nil {
+		if err := n.stateSyncReactor.Start(reactorCtx); err != nil {
 			return err
 		}
 
+		if n.rejectedTxLog != nil {
+			if err := n.rejectedTxLog.Start(reactorCtx); err != nil {
+				return err
+			}
+		}
+
+		if n.txArchive != nil {
+			if err := n.txArchive.Start(reactorCtx); err != nil {
+				return err
+			}
+		}
+
+		if n.downtimeTracker != nil {
+			if err := n.downtimeTracker.Start(reactorCtx); err != nil {
+				return err
+			}
+		}
+
+		if n.invariantChecker != nil {
+			if err := n.invariantChecker.Start(reactorCtx); err != nil {
+				return err
+			}
+		}
+
 		// Start the real mempool reactor separately since the switch uses the shim.
-		if err := n.mempoolReactor.Start(ctx); err != nil {
+		if err := n.mempoolReactor.Start(reactorCtx); err != nil {
 			return err
 		}
 
 		// Start the real evidence reactor separately since the switch uses the shim.
-		if err := n.evidenceReactor.Start(ctx); err != nil {
+		if err := n.evidenceReactor.Start(reactorCtx); err != nil {
+			return err
+		}
+
+		if err := n.statusReactor.Start(reactorCtx); err != nil {
 			return err
 		}
 	}
 
 	if n.config.P2P.PexReactor {
-		if err := n.pexReactor.Start(ctx); err != nil {
+		if err := n.pexReactor.Start(reactorCtx); err != nil {
 			return err
 		}
 	}
 
+	// Stop the node once it halts.
+	if n.halt != nil {
+		go func() {
+			select {
+			case <-n.halt.Done():
+				n.logger.Info("node halted, stopping", "height", n.halt.Marker().Height)
+				if err := n.Stop(); err != nil {
+					n.logger.Error("failed to stop the node", "err", err)
+				}
+			case <-ctx.Done():
+			}
+		}()
+	}
+
+	// Stop the node once an invariant is violated, if configured to halt.
+	if checker, ok := n.invariantChecker.(*invariants.Checker); ok {
+		go func() {
+			select {
+			case <-checker.Halted():
+				n.logger.Error("invariant violated, stopping")
+				if err := n.Stop(); err != nil {
+					n.logger.Error("failed to stop the node", "err", err)
+				}
+			case <-ctx.Done():
+			}
+		}()
+	}
+
 	// Run state sync
 	// TODO: We shouldn't run state sync if we already have state that has a
 	// LastBlockHeight that is not InitialHeight
@@ -653,7 +967,7 @@ func (n *nodeImpl) OnStart(ctx context.Context) error {
 		// is running
 		// FIXME Very ugly to have these metrics bleed through here.
 		n.consensusReactor.SetBlockSyncingMetrics(1)
-		if err := bcR.SwitchToBlockSync(ctx, ssState); err != nil {
+		if err := bcR.SwitchToBlockSync(reactorCtx, ssState); err != nil {
 			n.logger.Error("failed to switch to block sync", "err", err)
 			return err
 		}
@@ -672,8 +986,70 @@ func (n *nodeImpl) OnStart(ctx context.Context) error {
 }
 
 // OnStop stops the Node. It implements service.Service.
+//
+// Shutdown proceeds in stages: the node first stops accepting new RPC
+// requests and peer connections, then waits for any in-flight block commit
+// and stops consensus (which flushes the consensus WAL), then flushes the
+// mempool's log of rejected transactions, then stops the remaining reactors
+// and the router, and finally closes its stores. All the stages share a
+// single shutdown-grace-period deadline; once it elapses, the remaining
+// stages proceed without waiting.
 func (n *nodeImpl) OnStop() {
-	n.logger.Info("Stopping Node")
+	n.logger.Info("Stopping Node", "grace_period", n.config.ShutdownGracePeriod)
+
+	ctx, cancel := context.WithTimeout(context.Background(), n.config.ShutdownGracePeriod)
+	defer cancel()
+
+	// Stop accepting new work from clients and peers.
+	n.closeRPCListeners()
+	n.router.Drain()
+
+	if n.config.Mode != config.ModeSeed {
+		// Let consensus finish the block it is committing, if any, before
+		// stopping it, so that the block, its state and the consensus WAL
+		// are consistent on disk.
+		if n.consensusState != nil && !n.consensusState.WaitForCommit(ctx) {
+			n.logger.Error("timed out waiting for the block commit to finish")
+		}
+		cancelStage(n.stopConsensus)
+		if !n.waitForServices(ctx, n.consensusReactor) {
+			n.logger.Error("timed out waiting for consensus to stop")
+		}
+
+		// No more blocks update the mempool, so flush the log of the
+		// transactions it rejected.
+		if rtl, ok := n.rejectedTxLog.(*mempool.RejectedTxLog); ok {
+			if err := rtl.FlushAndSync(); err != nil {
+				n.logger.Error("failed to flush rejected transactions log", "err", err)
+			}
+		}
+
+		cancelStage(n.stopReactors)
+		if !n.waitForServices(ctx,
+			n.bcReactor,
+			n.stateSyncReactor,
+			n.mempoolReactor,
+			n.evidenceReactor,
+			n.statusReactor,
+			n.rejectedTxLog,
+			n.txArchive,
+			n.downtimeTracker,
+			n.invariantChecker,
+			n.flightRecorder,
+		) {
+			n.logger.Error("timed out waiting for reactors to stop")
+		}
+	}
+	cancelStage(n.stopReactors)
+	if n.config.P2P.PexReactor && !n.waitForServices(ctx, n.pexReactor) {
+		n.logger.Error("timed out waiting for peer exchange to stop")
+	}
+
+	cancelStage(n.stopNetwork)
+	if !n.waitForServices(ctx, n.router, n.natService) {
+		n.logger.Error("timed out waiting for router to stop")
+	}
+	n.isListening = false
 
 	if n.eventBus != nil {
 		n.eventBus.Wait()
@@ -688,25 +1064,6 @@ func (n *nodeImpl) OnStop() {
 		}
 	}
 
-	if n.config.Mode != config.ModeSeed {
-		n.bcReactor.Wait()
-		n.consensusReactor.Wait()
-		n.stateSyncReactor.Wait()
-		n.mempoolReactor.Wait()
-		n.evidenceReactor.Wait()
-	}
-	n.pexReactor.Wait()
-	n.router.Wait()
-	n.isListening = false
-
-	// finally stop the listeners / external services
-	for _, l := range n.rpcListeners {
-		n.logger.Info("Closing rpc listener", "listener", l)
-		if err := l.Close(); err != nil {
-			n.logger.Error("Error closing listener", "listener", l, "err", err)
-		}
-	}
-
 	if pvsc, ok := n.privValidator.(service.Service); ok {
 		pvsc.Wait()
 	}
@@ -718,6 +1075,8 @@ func (n *nodeImpl) OnStop() {
 		}
 
 	}
+
+	// Close the stores last, once nothing that writes to them is running.
 	if err := n.shutdownOps(); err != nil {
 		if strings.TrimSpace(err.Error()) != "" {
 			n.logger.Error("problem shutting down additional services", "err", err)
@@ -735,6 +1094,44 @@ func (n *nodeImpl) OnStop() {
 	}
 }
 
+// closeRPCListeners closes the RPC listeners, so that no new RPC requests are
+// accepted.
+func (n *nodeImpl) closeRPCListeners() {
+	for _, l := range n.rpcListeners {
+		n.logger.Info("Closing rpc listener", "listener", l)
+		if err := l.Close(); err != nil {
+			n.logger.Error("Error closing listener", "listener", l, "err", err)
+		}
+	}
+	n.rpcListeners = nil
+}
+
+// waitForServices waits for each of the given services to stop. It reports
+// false if ctx is done before they have all stopped.
+func (n *nodeImpl) waitForServices(ctx context.Context, svcs ...service.Service) bool {
+	for _, svc := range svcs {
+		if svc == nil {
+			continue
+		}
+		done := make(chan struct{})
+		go func(svc service.Service) { svc.Wait(); close(done) }(svc)
+		select {
+		case <-done:
+		case <-ctx.Done():
+			n.logger.Error("service did not stop within the shutdown grace period", "service", svc)
+			return false
+		}
+	}
+	return true
+}
+
+// cancelStage calls cancel, if it is set.
+func cancelStage(cancel context.CancelFunc) {
+	if cancel != nil {
+		cancel()
+	}
+}
+
 func (n *nodeImpl) startRPC(ctx context.Context) ([]net.Listener, error) {
 	if n.config.Mode == config.ModeValidator {
 		pubKey, err := n.privValidator.GetPubKey(ctx)
@@ -747,13 +1144,39 @@ func (n *nodeImpl) startRPC(ctx context.Context) ([]net.Listener, error) {
 		return nil, err
 	}
 
-	listenAddrs := strings.SplitAndTrimEmpty(n.config.RPC.ListenAddress, ",", " ")
 	routes := n.rpcEnv.GetRoutes()
-
 	if n.config.RPC.Unsafe {
 		n.rpcEnv.AddUnsafe(routes)
 	}
 
+	// every address of laddr serves all the routes, while each additional
+	// listener only serves the routes in its allowlist
+	var (
+		listenAddrs    = strings.SplitAndTrimEmpty(n.config.RPC.ListenAddress, ",", " ")
+		listenerRoutes = make([]rpccore.RoutesMap, len(listenAddrs))
+	)
+	for i := range listenAddrs {
+		listenerRoutes[i] = routes
+	}
+	for name, l := range n.config.RPC.Listeners {
+		lroutes, err := selectRPCRoutes(n.rpcEnv, routes, l.Methods)
+		if err != nil {
+			return nil, fmt.Errorf("rpc listener %s: %w", name, err)
+		}
+		listenAddrs = append(listenAddrs, l.ListenAddress)
+		listenerRoutes = append(listenerRoutes, lroutes)
+	}
+
+	authenticator, err := rpcauth.Load(n.config.RPC.AuthKeysFilePath(), n.config.RPC.AuthKeys)
+	if err != nil {
+		return nil, err
+	}
+	// without API keys, calls are not authorized
+	var authorize rpcserver.AuthorizeFunc
+	if authenticator != nil {
+		authorize = authenticator.Authorize
+	}
+
 	cfg := rpcserver.DefaultConfig()
 	cfg.MaxBodyBytes = n.config.RPC.MaxBodyBytes
 	cfg.MaxHeaderBytes = n.config.RPC.MaxHeaderBytes
@@ -768,6 +1191,7 @@ func (n *nodeImpl) startRPC(ctx context.Context) ([]net.Listener, error) {
 	// we may expose the rpc over both a unix and tcp socket
 	listeners := make([]net.Listener, len(listenAddrs))
 	for i, listenAddr := range listenAddrs {
+		routes := listenerRoutes[i]
 		mux := http.NewServeMux()
 		rpcLogger := n.logger.With("module", "rpc-server")
 		wmLogger := rpcLogger.With("protocol", "websocket")
@@ -779,10 +1203,19 @@ func (n *nodeImpl) startRPC(ctx context.Context) ([]net.Listener, error) {
 				}
 			}),
 			rpcserver.ReadLimit(cfg.MaxBodyBytes),
+			rpcserver.WSAuthorizer(authorize),
 		)
 		wm.SetLogger(wmLogger)
 		mux.HandleFunc("/websocket", wm.WebsocketHandler)
-		rpcserver.RegisterRPCFuncs(mux, routes, rpcLogger)
+		// the probes are served along with the health method, without
+		// authentication, for orchestrators to call them
+		if _, ok := routes["health"]; ok {
+			mux.Handle("/health/live", rpccore.HealthProbeHandler(n.rpcEnv.LiveProbe))
+			mux.Handle("/health/ready", rpccore.HealthProbeHandler(n.rpcEnv.ReadyProbe))
+		}
+		rpcserver.RegisterRPCFuncs(mux, routes, rpcLogger,
+			rpcserver.MaxBatchConcurrency(n.config.RPC.MaxBatchConcurrency),
+			rpcserver.Authorizer(authorize))
 		listener, err := rpcserver.Listen(
 			listenAddr,
 			cfg.MaxOpenConnections,
@@ -834,6 +1267,30 @@ func (n *nodeImpl) startRPC(ctx context.Context) ([]net.Listener, error) {
 	return listeners, nil
 }
 
+// selectRPCRoutes returns the routes named in methods, where "*" stands for all
+// of the given routes. Unsafe routes can be named even if they are not part of
+// routes.
+func selectRPCRoutes(env *rpccore.Environment, routes rpccore.RoutesMap, methods []string) (rpccore.RoutesMap, error) {
+	all := env.GetRoutes()
+	env.AddUnsafe(all)
+
+	selected := make(rpccore.RoutesMap, len(methods))
+	for _, method := range methods {
+		if method == config.RPCListenerAllMethods {
+			for name, route := range routes {
+				selected[name] = route
+			}
+			continue
+		}
+		route, ok := all[method]
+		if !ok {
+			return nil, fmt.Errorf("unknown RPC method %q", method)
+		}
+		selected[method] = route
+	}
+	return selected, nil
+}
+
 // startPrometheusServer starts a Prometheus HTTP server, listening for metrics
 // collectors on addr.
 func (n *nodeImpl) startPrometheusServer(ctx context.Context, addr string) *http.Server {
@@ -911,9 +1368,16 @@ func (n *nodeImpl) IsListening() bool {
 	return n.isListening
 }
 
-// NodeInfo returns the Node's Info from the Switch.
+// PeerRTT returns the round-trip time to a connected peer, measured by the
+// router.
+func (n *nodeImpl) PeerRTT(peerID types.NodeID) (time.Duration, bool) {
+	return n.router.PeerRTT(peerID)
+}
+
+// NodeInfo returns the Node's Info from the router, whose listen address may
+// have been updated by NAT traversal.
 func (n *nodeImpl) NodeInfo() types.NodeInfo {
-	return n.nodeInfo
+	return n.router.NodeInfo()
 }
 
 // genesisDocProvider returns a GenesisDoc.
@@ -922,21 +1386,30 @@ func (n *nodeImpl) NodeInfo() types.NodeInfo {
 type genesisDocProvider func() (*types.GenesisDoc, error)
 
 // defaultGenesisDocProviderFunc returns a GenesisDocProvider that loads
-// the GenesisDoc from the config.GenesisFile() on the filesystem.
+// the GenesisDoc from the config.GenesisFile() on the filesystem, streaming
+// it if the app state is sent to the application in chunks.
 func defaultGenesisDocProviderFunc(cfg *config.Config) genesisDocProvider {
 	return func() (*types.GenesisDoc, error) {
+		if cfg.GenesisAppStateChunkSize > 0 {
+			return types.StreamGenesisDocFromFile(cfg.GenesisFile())
+		}
 		return types.GenesisDocFromFile(cfg.GenesisFile())
 	}
 }
 
 type nodeMetrics struct {
-	consensus *consensus.Metrics
-	indexer   *indexer.Metrics
-	mempool   *mempool.Metrics
-	p2p       *p2p.Metrics
-	proxy     *proxy.Metrics
-	state     *sm.Metrics
-	statesync *statesync.Metrics
+	consensus  *consensus.Metrics
+	downtime   *downtime.Metrics
+	evidence   *evidence.Metrics
+	indexer    *indexer.Metrics
+	invariants *invariants.Metrics
+	mempool    *mempool.Metrics
+	p2p        *p2p.Metrics
+	proxy      *proxy.Metrics
+	state      *sm.Metrics
+	statesync  *statesync.Metrics
+	privval    *privval.Metrics
+	status     *status.Metrics
 }
 
 // metricsProvider returns consensus, p2p, mempool, state, statesync Metrics.
@@ -948,27 +1421,53 @@ func defaultMetricsProvider(cfg *config.InstrumentationConfig) metricsProvider {
 	return func(chainID string) *nodeMetrics {
 		if cfg.Prometheus {
 			return &nodeMetrics{
-				consensus: consensus.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
-				indexer:   indexer.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
-				mempool:   mempool.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
-				p2p:       p2p.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
-				proxy:     proxy.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
-				state:     sm.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
-				statesync: statesync.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
+				consensus:  consensus.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
+				downtime:   downtime.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
+				evidence:   evidence.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
+				indexer:    indexer.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
+				invariants: invariants.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
+				mempool:    mempool.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
+				p2p:        p2p.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
+				proxy:      proxy.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
+				state:      sm.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
+				statesync:  statesync.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
+				privval:    privval.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
+				status:     status.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
 			}
 		}
 		return &nodeMetrics{
-			consensus: consensus.NopMetrics(),
-			indexer:   indexer.NopMetrics(),
-			mempool:   mempool.NopMetrics(),
-			p2p:       p2p.NopMetrics(),
-			proxy:     proxy.NopMetrics(),
-			state:     sm.NopMetrics(),
-			statesync: statesync.NopMetrics(),
+			consensus:  consensus.NopMetrics(),
+			downtime:   downtime.NopMetrics(),
+			evidence:   evidence.NopMetrics(),
+			indexer:    indexer.NopMetrics(),
+			invariants: invariants.NopMetrics(),
+			mempool:    mempool.NopMetrics(),
+			p2p:        p2p.NopMetrics(),
+			proxy:      proxy.NopMetrics(),
+			state:      sm.NopMetrics(),
+			statesync:  statesync.NopMetrics(),
+			privval:    privval.NopMetrics(),
+			status:     status.NopMetrics(),
 		}
 	}
 }
 
+// verifyStoreCheckpoints checks the blocks of the block store at checkpoint
+// heights, so that a node which synced a chain not matching its checkpoints
+// refuses to start.
+func verifyStoreCheckpoints(blockStore *store.BlockStore, checkpoints types.Checkpoints) error {
+	for height := range checkpoints {
+		meta := blockStore.LoadBlockMeta(height)
+		if meta == nil {
+			continue
+		}
+		if err := checkpoints.Verify(height, meta.BlockID.Hash); err != nil {
+			return fmt.Errorf("the block store does not match the checkpoints: %w", err)
+		}
+	}
+	return nil
+}
+
 //------------------------------------------------------------------------------
 
 // loadStateFromDBOrGenesisDocProvider attempts to load the state from the
@@ -1002,29 +1501,40 @@ func createAndStartPrivValidatorSocketClient(
 	logger log.Logger,
 ) (types.PrivValidator, error) {
 
-	pve, err := privval.NewSignerListener(listenAddr, logger)
+	pvsc, err := startPrivValidatorSocketClient(ctx, listenAddr, chainID, logger)
 	if err != nil {
-		return nil, fmt.Errorf("failed to start private validator: %w", err)
+		return nil, err
 	}
 
-	pvsc, err := privval.NewSignerClient(ctx, pve, chainID)
+	// try to get a pubkey from private validate first time
+	_, err = pvsc.GetPubKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("can't get pubkey: %w", err)
+	}
+
+	return pvsc, nil
+}
+
+func startPrivValidatorSocketClient(
+	ctx context.Context,
+	listenAddr, chainID string,
+	logger log.Logger,
+) (*privval.RetrySignerClient, error) {
+	pve, err := privval.NewSignerListener(listenAddr, logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to start private validator: %w", err)
 	}
 
-	// try to get a pubkey from private validate first time
-	_, err = pvsc.GetPubKey(ctx)
+	pvsc, err := privval.NewSignerClient(ctx, pve, chainID)
 	if err != nil {
-		return nil, fmt.Errorf("can't get pubkey: %w", err)
+		return nil, fmt.Errorf("failed to start private validator: %w", err)
 	}
 
 	const (
 		retries = 50 // 50 * 100ms = 5s total
 		timeout = 100 * time.Millisecond
 	)
-	pvscWithRetries := privval.NewRetrySignerClient(pvsc, retries, timeout)
-
-	return pvscWithRetries, nil
+	return privval.NewRetrySignerClient(pvsc, retries, timeout), nil
 }
 
 func createAndStartPrivValidatorGRPCClient(
@@ -1053,11 +1563,88 @@ func createAndStartPrivValidatorGRPCClient(
 	return pvsc, nil
 }
 
+// createAndStartPrivValidatorFailoverClient connects to the remote signers of
+// the given addresses, over gRPC or sockets as for a single one, to fail over
+// between them.
+func createAndStartPrivValidatorFailoverClient(
+	ctx context.Context,
+	cfg *config.Config,
+	listenAddrs []string,
+	chainID string,
+	logger log.Logger,
+	metrics *privval.Metrics,
+) (types.PrivValidator, closer, error) {
+	endpoints := make([]privval.FailoverEndpoint, 0, len(listenAddrs))
+	closers := make([]closer, 0, len(listenAddrs))
+
+	for _, addr := range listenAddrs {
+		var (
+			signer privval.RemoteSigner
+			err    error
+		)
+		protocol, _ := tmnet.ProtocolAndAddress(addr)
+		switch protocol {
+		case "grpc":
+			pvCfg := *cfg.PrivValidator
+			pvCfg.ListenAddr = addr
+			signer, err = tmgrpc.DialRemoteSigner(
+				ctx,
+				&pvCfg,
+				chainID,
+				logger.With("signer", addr),
+				cfg.Instrumentation.Prometheus,
+			)
+		default:
+			signer, err = startPrivValidatorSocketClient(ctx, addr, chainID, logger.With("signer", addr))
+		}
+		if err != nil {
+			return nil, nil, combineCloseError(fmt.Errorf("remote signer %s: %w", addr, err), makeCloser(closers))
+		}
+		endpoints = append(endpoints, privval.FailoverEndpoint{Addr: addr, Signer: signer})
+		closers = append(closers, signer.Close)
+	}
+
+	pvsc, err := privval.NewFailoverSignerClient(ctx, logger, endpoints, metrics)
+	if err != nil {
+		return nil, nil, combineCloseError(err, makeCloser(closers))
+	}
+
+	return pvsc, pvsc.Close, nil
+}
+
+func createPrivValidatorPKCS11(
+	ctx context.Context,
+	cfg *config.Config,
+	metrics *privval.Metrics,
+) (types.PrivValidator, closer, error) {
+	signer, err := pkcs11.NewSigner(pkcs11.Config{
+		Module:     cfg.PrivValidator.PKCS11Module,
+		TokenLabel: cfg.PrivValidator.PKCS11TokenLabel,
+		KeyLabel:   cfg.PrivValidator.PKCS11KeyLabel,
+		PIN:        cfg.PrivValidator.PKCS11PIN,
+		KeyType:    cfg.PrivValidator.PKCS11KeyType,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pv, err := privval.NewKeySignerPV(ctx, signer, cfg.PrivValidator.StateFile(), metrics)
+	if err != nil {
+		return nil, nil, combineCloseError(err, signer.Close)
+	}
+
+	return pv, signer.Close, nil
+}
+
 func getRouterConfig(conf *config.Config, proxyApp proxy.AppConns) p2p.RouterOptions {
 	opts := p2p.RouterOptions{
 		QueueType: conf.P2P.QueueType,
 	}
 
+	if conf.Mode == config.ModeSeed {
+		opts.Networks = strings.SplitAndTrimEmpty(conf.P2P.SeedNetworks, ",", " ")
+	}
+
 	if conf.FilterPeers && proxyApp != nil {
 		opts.FilterPeerByID = func(ctx context.Context, id types.NodeID) error {
 			res, err := proxyApp.Query().QuerySync(ctx, abci.RequestQuery{
@@ -1,12 +1,15 @@
 package node
 
 import (
+	"archive/zip"
 	"context"
 	"errors"
 	"fmt"
 	"math"
 	"net"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -20,11 +23,16 @@ import (
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/tendermint/tendermint/internal/consensus"
 	"github.com/tendermint/tendermint/internal/eventbus"
 	"github.com/tendermint/tendermint/internal/evidence"
+	"github.com/tendermint/tendermint/internal/flightrec"
 	"github.com/tendermint/tendermint/internal/mempool"
+	"github.com/tendermint/tendermint/internal/p2p"
+	"github.com/tendermint/tendermint/internal/p2p/pex"
 	"github.com/tendermint/tendermint/internal/proxy"
 	"github.com/tendermint/tendermint/internal/pubsub"
+	rpccore "github.com/tendermint/tendermint/internal/rpc/core"
 	sm "github.com/tendermint/tendermint/internal/state"
 	"github.com/tendermint/tendermint/internal/state/indexer"
 	"github.com/tendermint/tendermint/internal/store"
@@ -34,6 +42,7 @@ import (
 	"github.com/tendermint/tendermint/libs/service"
 	tmtime "github.com/tendermint/tendermint/libs/time"
 	"github.com/tendermint/tendermint/privval"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -79,6 +88,119 @@ func TestNodeStartStop(t *testing.T) {
 	require.False(t, n.IsRunning(), "node must shut down")
 }
 
+func TestNodeFlightRecorder(t *testing.T) {
+	cfg, err := config.ResetTestRoot("node_flight_recorder_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(cfg.RootDir)
+	cfg.Instrumentation.FlightRecorder.Interval = time.Hour
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	n := getTestNode(ctx, t, cfg, log.TestingLogger())
+	require.NoError(t, n.Start(ctx))
+
+	res, err := n.rpcEnv.UnsafeDumpFlightRecorder(&rpctypes.Context{})
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(cfg.DBDir(), flightrec.DirName), filepath.Dir(filepath.Dir(res.Path)))
+
+	zr, err := zip.OpenReader(res.Path)
+	require.NoError(t, err)
+	defer zr.Close()
+	var files []string
+	for _, file := range zr.File {
+		files = append(files, filepath.Base(file.Name))
+	}
+	require.ElementsMatch(t, []string{
+		flightrec.GoroutineFile,
+		"consensus_state.json",
+		"net_info.json",
+		"p2p_metrics.txt",
+		"status.json",
+	}, files)
+}
+
+func TestNodeNewWithOptions(t *testing.T) {
+	cfg, err := config.ResetTestRoot("node_new_with_options_test")
+	require.NoError(t, err)
+
+	defer os.RemoveAll(cfg.RootDir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	blockStoreDB := dbm.NewMemDB()
+	const offset = time.Hour
+	ns, err := NewWithOptions(ctx, cfg, Options{
+		Logger:        log.TestingLogger(),
+		ClientCreator: abciclient.NewLocalCreator(kvstore.NewApplication()),
+		DBs: map[string]dbm.DB{
+			BlockStoreDB: blockStoreDB,
+			StateDB:      dbm.NewMemDB(),
+		},
+		Clock: func() time.Time { return tmtime.Now().Add(offset) },
+	})
+	require.NoError(t, err)
+
+	n, ok := ns.(*nodeImpl)
+	require.True(t, ok)
+	t.Cleanup(func() {
+		if n.IsRunning() {
+			cancel()
+			n.Wait()
+		}
+	})
+
+	blocksSub, err := n.EventBus().SubscribeWithArgs(ctx, pubsub.SubscribeArgs{
+		ClientID: "node_test",
+		Query:    types.EventQueryNewBlock,
+	})
+	require.NoError(t, err)
+	require.NoError(t, n.Start(ctx))
+
+	// the time of the blocks after the first one comes from the votes
+	tctx, tcancel := context.WithTimeout(ctx, 10*time.Second)
+	defer tcancel()
+	for {
+		msg, err := blocksSub.Next(tctx)
+		require.NoError(t, err, "waiting for event")
+		block := msg.Data().(types.EventDataNewBlock).Block
+		if block.Height < 2 {
+			continue
+		}
+		assert.True(t, block.Time.After(tmtime.Now().Add(offset/2)),
+			"block time %v is not set by the clock", block.Time)
+		break
+	}
+
+	// the blocks are saved in the given database
+	assert.GreaterOrEqual(t, store.NewBlockStore(blockStoreDB).Height(), int64(2))
+}
+
+func TestNodeGenesisHash(t *testing.T) {
+	cfg, err := config.ResetTestRoot("node_genesis_hash_test")
+	require.NoError(t, err)
+
+	defer os.RemoveAll(cfg.RootDir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	genDoc, err := types.GenesisDocFromFile(cfg.GenesisFile())
+	require.NoError(t, err)
+	hash, err := genDoc.Hash()
+	require.NoError(t, err)
+
+	// the node refuses to run a genesis doc with a different hash
+	cfg.GenesisHash = strings.Repeat("ab", 32)
+	_, err = newDefaultNode(ctx, cfg, log.TestingLogger())
+	require.Error(t, err)
+
+	cfg.GenesisHash = fmt.Sprintf("%X", hash)
+	n := getTestNode(ctx, t, cfg, log.TestingLogger())
+	require.EqualValues(t, hash, n.NodeInfo().GenesisHash)
+}
+
 func getTestNode(ctx context.Context, t *testing.T, conf *config.Config, logger log.Logger) *nodeImpl {
 	t.Helper()
 	ctx, cancel := context.WithCancel(ctx)
@@ -316,11 +438,13 @@ func TestCreateProposalBlock(t *testing.T) {
 	)
 
 	commit := types.NewCommit(height-1, 0, types.BlockID{}, nil)
-	block, _ := blockExec.CreateProposalBlock(
+	block, _, err := blockExec.CreateProposalBlock(
+		ctx,
 		height,
 		state, commit,
 		proposerAddr,
 	)
+	require.NoError(t, err)
 
 	// check that the part set does not exceed the maximum block size
 	partSet := block.MakePartSet(partSize)
@@ -387,11 +511,13 @@ func TestMaxTxsProposalBlockSize(t *testing.T) {
 	)
 
 	commit := types.NewCommit(height-1, 0, types.BlockID{}, nil)
-	block, _ := blockExec.CreateProposalBlock(
+	block, _, err := blockExec.CreateProposalBlock(
+		ctx,
 		height,
 		state, commit,
 		proposerAddr,
 	)
+	require.NoError(t, err)
 
 	pb, err := block.ToProto()
 	require.NoError(t, err)
@@ -465,8 +591,10 @@ func TestMaxProposalBlockSize(t *testing.T) {
 	// change state in order to produce the largest accepted header
 	state.LastBlockID = blockID
 	state.LastBlockHeight = math.MaxInt64 - 1
+	state.LastValidators = state.Validators.Copy()
 	state.LastBlockTime = timestamp
 	state.LastResultsHash = tmhash.Sum([]byte("last_results_hash"))
+	state.LastEventsHash = tmhash.Sum([]byte("last_events_hash"))
 	state.AppHash = tmhash.Sum([]byte("app_hash"))
 	state.Version.Consensus.Block = math.MaxInt64
 	state.Version.Consensus.App = math.MaxInt64
@@ -494,11 +622,13 @@ func TestMaxProposalBlockSize(t *testing.T) {
 		commit.Signatures = append(commit.Signatures, cs)
 	}
 
-	block, partSet := blockExec.CreateProposalBlock(
+	block, partSet, err := blockExec.CreateProposalBlock(
+		ctx,
 		math.MaxInt64,
 		state, commit,
 		proposerAddr,
 	)
+	require.NoError(t, err)
 
 	// this ensures that the header is at max size
 	block.Header.Time = timestamp
@@ -535,6 +665,7 @@ func TestNodeNewSeedNode(t *testing.T) {
 		nodeKey,
 		defaultGenesisDocProviderFunc(cfg),
 		log.TestingLogger(),
+		nil,
 	)
 	t.Cleanup(ns.Wait)
 
@@ -571,7 +702,7 @@ func TestNodeSetEventSink(t *testing.T) {
 		require.NoError(t, err)
 
 		indexService, eventSinks, err := createAndStartIndexerService(ctx, cfg,
-			config.DefaultDBProvider, eventBus, logger, genDoc.ChainID,
+			config.DefaultDBProvider, eventBus, nil, nil, logger, genDoc.ChainID,
 			indexer.NopMetrics())
 		require.NoError(t, err)
 		t.Cleanup(indexService.Wait)
@@ -707,3 +838,72 @@ func loadStatefromGenesis(t *testing.T) sm.State {
 
 	return state
 }
+
+func TestSelectRPCRoutes(t *testing.T) {
+	env := &rpccore.Environment{}
+	routes := env.GetRoutes()
+
+	selected, err := selectRPCRoutes(env, routes, []string{"health", "status"})
+	require.NoError(t, err)
+	require.Len(t, selected, 2)
+	require.Contains(t, selected, "status")
+
+	// unsafe routes are served only if listed by name
+	selected, err = selectRPCRoutes(env, routes, []string{config.RPCListenerAllMethods})
+	require.NoError(t, err)
+	require.Equal(t, len(routes), len(selected))
+	require.NotContains(t, selected, "unsafe_flush_mempool")
+
+	selected, err = selectRPCRoutes(env, routes, []string{config.RPCListenerAllMethods, "unsafe_flush_mempool"})
+	require.NoError(t, err)
+	require.Equal(t, len(routes)+1, len(selected))
+	require.Contains(t, selected, "unsafe_flush_mempool")
+
+	_, err = selectRPCRoutes(env, routes, []string{"dial_peers"})
+	require.Error(t, err)
+}
+
+func TestMigrateLegacyAddrBook(t *testing.T) {
+	selfID := types.NodeID(strings.Repeat("a", 40))
+	peerID := types.NodeID(strings.Repeat("b", 40))
+	path := filepath.Join(t.TempDir(), "addrbook.json")
+	book := fmt.Sprintf(`{"key": "", "addrs": [
+		{"addr": {"id": %q, "ip": "1.2.3.4", "port": 26656}, "bucket_type": 2},
+		{"addr": {"id": %q, "ip": "5.6.7.8", "port": 26656}, "bucket_type": 1}
+	]}`, selfID, peerID)
+	require.NoError(t, os.WriteFile(path, []byte(book), 0600))
+
+	peerManager, err := p2p.NewPeerManager(selfID, dbm.NewMemDB(), p2p.PeerManagerOptions{})
+	require.NoError(t, err)
+
+	// the node's own address is skipped, and the address book renamed
+	require.NoError(t, migrateLegacyAddrBook(log.NewNopLogger(), path, peerManager, selfID))
+	entries := peerManager.ExportAddressBook()
+	require.Len(t, entries, 1)
+	require.Equal(t, peerID, entries[0].Address.NodeID)
+	_, err = os.Stat(path)
+	require.True(t, os.IsNotExist(err))
+	_, err = os.Stat(path + ".migrated")
+	require.NoError(t, err)
+
+	// without an address book, there is nothing to migrate
+	require.NoError(t, migrateLegacyAddrBook(log.NewNopLogger(), path, peerManager, selfID))
+}
+
+func TestMakeGossipPolicy(t *testing.T) {
+	blocksOnly := makeGossipPolicy(config.GossipPolicyBlocksOnly)
+	for _, chID := range []p2p.ChannelID{
+		pex.PexChannel,
+		mempool.MempoolChannel,
+		mempool.LaneChannel(0),
+		mempool.LaneChannel(config.MaxMempoolLanes - 1),
+		evidence.EvidenceChannel,
+	} {
+		assert.True(t, blocksOnly.BlockedChannels[chID], "channel %#x", chID)
+	}
+	assert.False(t, blocksOnly.BlockedChannels[consensus.DataChannel])
+
+	noAddresses := makeGossipPolicy(config.GossipPolicyNoAddresses)
+	assert.True(t, noAddresses.BlockedChannels[pex.PexChannel])
+	assert.False(t, noAddresses.BlockedChannels[mempool.LaneChannel(0)])
+}
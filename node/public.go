@@ -4,9 +4,16 @@ package node
 import (
 	"context"
 	"fmt"
+	"time"
+
+	dbm "github.com/tendermint/tm-db"
 
 	abciclient "github.com/tendermint/tendermint/abci/client"
 	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/downtime"
+	"github.com/tendermint/tendermint/internal/mempool"
+	"github.com/tendermint/tendermint/internal/p2p"
+	"github.com/tendermint/tendermint/internal/proxy"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/libs/service"
 	"github.com/tendermint/tendermint/privval"
@@ -38,13 +45,122 @@ func New(
 	cf abciclient.Creator,
 	gen *types.GenesisDoc,
 ) (service.Service, error) {
-	nodeKey, err := types.LoadOrGenNodeKey(conf.NodeKeyFile())
-	if err != nil {
-		return nil, fmt.Errorf("failed to load or gen node key %s: %w", conf.NodeKeyFile(), err)
+	return NewWithOptions(ctx, conf, Options{
+		Logger:        logger,
+		ClientCreator: cf,
+		GenesisDoc:    gen,
+	})
+}
+
+// The IDs of the databases of a node, for Options.DBs and the DBContext
+// passed to a config.DBProvider.
+const (
+	BlockStoreDB = "blockstore"
+	StateDB      = "state"
+	EvidenceDB   = "evidence"
+	TxIndexDB    = "tx_index"
+	PeerStoreDB  = "peerstore"
+	TxArchiveDB  = "tx_archive"
+)
+
+// Options are the dependencies of a node which projects embedding it as a
+// library can provide, instead of the node creating them from its config.
+// All of them are optional.
+type Options struct {
+	// Logger is the logger of the node. If nil, the node doesn't log.
+	Logger log.Logger
+
+	// ClientCreator creates the clients of the ABCI application, e.g. a local
+	// client of an application running in the same process. If nil, the
+	// clients connect to proxy-app.
+	ClientCreator abciclient.Creator
+
+	// GenesisDoc is the genesis document. If nil, it is read from the
+	// genesis file.
+	GenesisDoc *types.GenesisDoc
+
+	// NodeKey is the key of the node. If nil, it is read from the node key
+	// file, or generated.
+	NodeKey *types.NodeKey
+
+	// PrivValidator signs the votes and proposals of a validator. If nil, it
+	// is read from the validator key and state files, or generated. A remote
+	// signer or PKCS#11 token set up in the config takes precedence.
+	PrivValidator types.PrivValidator
+
+	// DBs are the databases of the node, by ID (BlockStoreDB, StateDB,
+	// EvidenceDB, TxIndexDB, PeerStoreDB and TxArchiveDB), which the node
+	// closes when it stops. The other databases are created by DBProvider.
+	DBs map[string]dbm.DB
+
+	// DBProvider creates the databases not in DBs. If nil,
+	// config.DefaultDBProvider.
+	DBProvider config.DBProvider
+
+	// Clock returns the current time for consensus, i.e. the timestamps of
+	// proposals and votes, and the scheduling of rounds. If nil, the system
+	// clock.
+	Clock func() time.Time
+
+	// PeerManager is a pre-built peer manager, for the node ID of NodeKey,
+	// used instead of creating one from the p2p config. The node doesn't
+	// close its database.
+	PeerManager *p2p.PeerManager
+
+	// RejectedTxSink records the transactions rejected by CheckTx, e.g. to
+	// report them to the application developers. If nil, they are logged to
+	// the mempool's rejected-txs-log-file, if any.
+	RejectedTxSink mempool.RejectedTxSink
+
+	// TxSender returns the sender the tx archive of the mempool, if enabled,
+	// indexes a checked transaction by, e.g. an account decoded from it. If
+	// nil, the sender reported by the application in CheckTx.
+	TxSender mempool.TxSenderFunc
+
+	// DowntimeAlertHandler is called with the alerts raised when the miss
+	// rate of the validator crosses the alert-threshold of the downtime
+	// config, in addition to its webhook-url, if any.
+	DowntimeAlertHandler downtime.AlertHandler
+}
+
+// dbProvider returns the provider of the databases of the node.
+func (opts Options) dbProvider() config.DBProvider {
+	provider := opts.DBProvider
+	if provider == nil {
+		provider = config.DefaultDBProvider
+	}
+	if len(opts.DBs) == 0 {
+		return provider
+	}
+	return func(ctx *config.DBContext) (dbm.DB, error) {
+		if db, ok := opts.DBs[ctx.ID]; ok {
+			return db, nil
+		}
+		return provider(ctx)
+	}
+}
+
+// NewWithOptions constructs a tendermint node as New does, using the given
+// dependencies instead of those created from the config.
+func NewWithOptions(ctx context.Context, conf *config.Config, opts Options) (service.Service, error) {
+	logger := opts.Logger
+	if logger == nil {
+		logger = log.NewNopLogger()
+	}
+
+	var nodeKey types.NodeKey
+	if opts.NodeKey != nil {
+		nodeKey = *opts.NodeKey
+	} else {
+		var err error
+		nodeKey, err = types.LoadOrGenNodeKey(conf.NodeKeyFile())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load or gen node key %s: %w", conf.NodeKeyFile(), err)
+		}
 	}
 
 	var genProvider genesisDocProvider
-	switch gen {
+	switch gen := opts.GenesisDoc; gen {
 	case nil:
 		genProvider = defaultGenesisDocProviderFunc(conf)
 	default:
@@ -53,9 +169,18 @@ func New(
 
 	switch conf.Mode {
 	case config.ModeFull, config.ModeValidator:
-		pval, err := privval.LoadOrGenFilePV(conf.PrivValidator.KeyFile(), conf.PrivValidator.StateFile())
-		if err != nil {
-			return nil, err
+		pval := opts.PrivValidator
+		if pval == nil {
+			filePV, err := privval.LoadOrGenFilePV(conf.PrivValidator.KeyFile(), conf.PrivValidator.StateFile())
+			if err != nil {
+				return nil, err
+			}
+			pval = filePV
+		}
+
+		cf := opts.ClientCreator
+		if cf == nil {
+			cf, _ = proxy.DefaultClientCreator(logger, conf.ProxyApp, conf.ABCI, conf.DBDir())
 		}
 
 		return makeNode(
@@ -65,10 +190,15 @@ func New(
 			nodeKey,
 			cf,
 			genProvider,
-			config.DefaultDBProvider,
-			logger)
+			opts.dbProvider(),
+			logger,
+			opts.Clock,
+			opts.PeerManager,
+			opts.RejectedTxSink,
+			opts.TxSender,
+			opts.DowntimeAlertHandler)
 	case config.ModeSeed:
-		return makeSeedNode(ctx, conf, config.DefaultDBProvider, nodeKey, genProvider, logger)
+		return makeSeedNode(ctx, conf, opts.dbProvider(), nodeKey, genProvider, logger, opts.PeerManager)
 	default:
 		return nil, fmt.Errorf("%q is not a valid mode", conf.Mode)
 	}
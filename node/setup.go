@@ -3,24 +3,46 @@ package node
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
 	dbm "github.com/tendermint/tm-db"
-
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+
+	abciclient "github.com/tendermint/tendermint/abci/client"
+	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/internal/blocksync"
 	"github.com/tendermint/tendermint/internal/consensus"
+	"github.com/tendermint/tendermint/internal/downtime"
 	"github.com/tendermint/tendermint/internal/eventbus"
 	"github.com/tendermint/tendermint/internal/evidence"
+	"github.com/tendermint/tendermint/internal/flightrec"
+	"github.com/tendermint/tendermint/internal/libs/autofile"
+	"github.com/tendermint/tendermint/internal/libs/profiling"
 	"github.com/tendermint/tendermint/internal/mempool"
 	"github.com/tendermint/tendermint/internal/p2p"
 	"github.com/tendermint/tendermint/internal/p2p/conn"
+	"github.com/tendermint/tendermint/internal/p2p/nat"
 	"github.com/tendermint/tendermint/internal/p2p/pex"
+	"github.com/tendermint/tendermint/internal/p2p/status"
 	"github.com/tendermint/tendermint/internal/proxy"
+	rpccore "github.com/tendermint/tendermint/internal/rpc/core"
 	sm "github.com/tendermint/tendermint/internal/state"
 	"github.com/tendermint/tendermint/internal/state/indexer"
 	"github.com/tendermint/tendermint/internal/state/indexer/sink"
@@ -29,10 +51,9 @@ import (
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/libs/service"
 	tmstrings "github.com/tendermint/tendermint/libs/strings"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 	"github.com/tendermint/tendermint/types"
 	"github.com/tendermint/tendermint/version"
-
-	_ "net/http/pprof" // nolint: gosec // securely exposed on separate, optional port
 )
 
 type closer func() error
@@ -56,6 +77,50 @@ func convertCancelCloser(cancel context.CancelFunc) closer {
 	return func() error { cancel(); return nil }
 }
 
+// initTracing registers the global tracer provider configured by cfg, which
+// exports the spans recorded by the node. The returned closer flushes any
+// pending spans and shuts the provider down.
+func initTracing(
+	ctx context.Context,
+	cfg *config.TracingConfig,
+	chainID string,
+	nodeID types.NodeID,
+	moniker string,
+) (closer, error) {
+	if cfg == nil || cfg.Exporter == config.TracingExporterNone {
+		return func() error { return nil }, nil
+	}
+
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+	if cfg.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRate))),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceNameKey.String("tendermint"),
+			semconv.ServiceInstanceIDKey.String(string(nodeID)),
+			semconv.ServiceVersionKey.String(version.TMVersion),
+			attribute.String("chain_id", chainID),
+			attribute.String("moniker", moniker),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return provider.Shutdown(ctx)
+	}, nil
+}
+
 func combineCloseError(err error, cl closer) error {
 	if err == nil {
 		return cl()
@@ -74,15 +139,21 @@ func initDBs(
 	dbProvider config.DBProvider,
 ) (*store.BlockStore, dbm.DB, closer, error) {
 
-	blockStoreDB, err := dbProvider(&config.DBContext{ID: "blockstore", Config: cfg})
+	blockStoreDB, err := dbProvider(&config.DBContext{ID: BlockStoreDB, Config: cfg})
 	if err != nil {
 		return nil, nil, func() error { return nil }, fmt.Errorf("unable to initialize blockstore: %w", err)
 	}
-	closers := []closer{}
-	blockStore := store.NewBlockStore(blockStoreDB)
-	closers = append(closers, blockStoreDB.Close)
+	closers := []closer{blockStoreDB.Close}
+	var blockStoreOptions []store.BlockStoreOption
+	if cfg.BlockStoreAsyncSync {
+		blockStoreOptions = append(blockStoreOptions, store.WithAsyncSync())
+	}
+	blockStore := store.NewBlockStore(blockStoreDB, blockStoreOptions...)
+	if err := blockStore.CheckIntegrity(); err != nil {
+		return nil, nil, makeCloser(closers), fmt.Errorf("block store is corrupted: %w", err)
+	}
 
-	stateDB, err := dbProvider(&config.DBContext{ID: "state", Config: cfg})
+	stateDB, err := dbProvider(&config.DBContext{ID: StateDB, Config: cfg})
 	if err != nil {
 		return nil, nil, makeCloser(closers), fmt.Errorf("unable to initialize statestore: %w", err)
 	}
@@ -92,11 +163,46 @@ func initDBs(
 	return blockStore, stateDB, makeCloser(closers), nil
 }
 
+// createProxyApp creates the connections to the ABCI application, switching
+// to the upgraded application at the upgrade height if one is configured.
+func createProxyApp(
+	cfg *config.Config,
+	clientCreator abciclient.Creator,
+	lastHeight int64,
+	logger log.Logger,
+	metrics *proxy.Metrics,
+) (proxy.AppConns, closer) {
+	logger = logger.With("module", "proxy")
+	options := []proxy.AppConnsOption{
+		proxy.WithMempoolConnections(cfg.MempoolConnections),
+		proxy.WithTimeouts(cfg.ABCIConsensusTimeout, cfg.ABCIMempoolTimeout, cfg.ABCIQueryTimeout),
+	}
+	if cfg.GenesisAppStateChunkSize > 0 {
+		options = append(options, proxy.WithRequiredFeatures(abci.FeatureInitChainChunks))
+	}
+	proxyApp := proxy.NewAppConns(clientCreator, logger, metrics, options...)
+	if cfg.UpgradeHeight == 0 {
+		return proxyApp, func() error { return nil }
+	}
+
+	var upgradeApp proxy.AppConns
+	upgradeCloser := func() error { return nil }
+	if cfg.UpgradeProxyApp != "" {
+		upgradeCreator, appCloser := proxy.DefaultClientCreator(logger, cfg.UpgradeProxyApp, cfg.ABCI, cfg.DBDir())
+		upgradeApp = proxy.NewAppConns(upgradeCreator, logger.With("app", "upgraded"), metrics, options...)
+		upgradeCloser = appCloser.Close
+	}
+
+	return proxy.NewUpgradeAppConns(proxyApp, upgradeApp, cfg.UpgradeHeight, lastHeight, logger), upgradeCloser
+}
+
 func createAndStartIndexerService(
 	ctx context.Context,
 	cfg *config.Config,
 	dbProvider config.DBProvider,
 	eventBus *eventbus.EventBus,
+	blockStore sm.BlockStore,
+	stateStore sm.Store,
 	logger log.Logger,
 	chainID string,
 	metrics *indexer.Metrics,
@@ -111,6 +217,11 @@ func createAndStartIndexerService(
 		EventBus: eventBus,
 		Logger:   logger.With("module", "txindex"),
 		Metrics:  metrics,
+
+		BlockStore:       blockStore,
+		StateStore:       stateStore,
+		GapCheckWindow:   cfg.TxIndex.GapCheckWindow,
+		GapCheckInterval: cfg.TxIndex.GapCheckInterval,
 	})
 
 	if err := indexerService.Start(ctx); err != nil {
@@ -173,6 +284,9 @@ func createMempoolReactor(
 	memplMetrics *mempool.Metrics,
 	peerManager *p2p.PeerManager,
 	router *p2p.Router,
+	rejectedTxSink mempool.RejectedTxSink,
+	txArchive *mempool.TxArchive,
+	txSender mempool.TxSenderFunc,
 	logger log.Logger,
 ) (service.Service, mempool.Mempool, error) {
 
@@ -183,14 +297,34 @@ func createMempoolReactor(
 		return nil, nil, err
 	}
 
+	laneChDescs, err := mempool.GetLaneChannelDescriptors(cfg.Mempool)
+	if err != nil {
+		return nil, nil, err
+	}
+	laneChs := make([]*p2p.Channel, len(laneChDescs))
+	for i, chDesc := range laneChDescs {
+		if laneChs[i], err = router.OpenChannel(ctx, chDesc); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	options := []mempool.TxMempoolOption{
+		mempool.WithMetrics(memplMetrics),
+		mempool.WithPreCheck(sm.TxPreCheck(state)),
+		mempool.WithPostCheck(sm.TxPostCheck(state)),
+	}
+	if rejectedTxSink != nil {
+		options = append(options, mempool.WithRejectedTxSink(rejectedTxSink))
+	}
+	if txArchive != nil {
+		options = append(options, mempool.WithTxArchive(txArchive, txSender))
+	}
 	mp := mempool.NewTxMempool(
 		logger,
 		cfg.Mempool,
 		proxyApp.Mempool(),
 		state.LastBlockHeight,
-		mempool.WithMetrics(memplMetrics),
-		mempool.WithPreCheck(sm.TxPreCheck(state)),
-		mempool.WithPostCheck(sm.TxPostCheck(state)),
+		options...,
 	)
 
 	reactor := mempool.NewReactor(
@@ -199,6 +333,7 @@ func createMempoolReactor(
 		peerManager,
 		mp,
 		ch,
+		laneChs,
 		peerManager.Subscribe(ctx),
 	)
 
@@ -209,6 +344,60 @@ func createMempoolReactor(
 	return reactor, mp, nil
 }
 
+// createRejectedTxLog returns the log of the transactions rejected by CheckTx,
+// or nil if it's disabled.
+func createRejectedTxLog(cfg *config.Config, logger log.Logger) (*mempool.RejectedTxLog, error) {
+	path := cfg.Mempool.RejectedTxsLogFile()
+	if path == "" {
+		return nil, nil
+	}
+	return mempool.NewRejectedTxLog(logger.With("module", "mempool"), path,
+		autofile.GroupHeadSizeLimit(10*1024*1024),   // 10MB
+		autofile.GroupTotalSizeLimit(100*1024*1024)) // 100MB
+}
+
+// createTxArchive returns the archive of the transactions checked by the
+// mempool, and the closer of its database, or nil if it's disabled.
+func createTxArchive(
+	cfg *config.Config,
+	dbProvider config.DBProvider,
+	logger log.Logger,
+) (*mempool.TxArchive, closer, error) {
+	if !cfg.Mempool.TxArchive {
+		return nil, func() error { return nil }, nil
+	}
+	db, err := dbProvider(&config.DBContext{ID: TxArchiveDB, Config: cfg})
+	if err != nil {
+		return nil, func() error { return nil }, fmt.Errorf("unable to initialize tx archive db: %w", err)
+	}
+	return mempool.NewTxArchive(logger.With("module", "mempool"), db, cfg.Mempool.TxArchiveRetention), db.Close, nil
+}
+
+// createDowntimeTracker returns the tracker of the blocks missed by the
+// validator with the given address, alerting the webhook of the config and
+// the given handler, if any.
+func createDowntimeTracker(
+	cfg *config.Config,
+	address crypto.Address,
+	eventBus *eventbus.EventBus,
+	stateStore sm.Store,
+	blockStore sm.BlockStore,
+	metrics *downtime.Metrics,
+	alertHandler downtime.AlertHandler,
+	logger log.Logger,
+) *downtime.Tracker {
+	logger = logger.With("module", "downtime")
+	var handlers []downtime.AlertHandler
+	if url := cfg.Instrumentation.Downtime.WebhookURL; url != "" {
+		handlers = append(handlers, downtime.WebhookAlertHandler(logger, url))
+	}
+	if alertHandler != nil {
+		handlers = append(handlers, alertHandler)
+	}
+	return downtime.NewTracker(logger, cfg.Instrumentation.Downtime, address,
+		eventBus, stateStore, blockStore, metrics, handlers...)
+}
+
 func createEvidenceReactor(
 	ctx context.Context,
 	cfg *config.Config,
@@ -218,15 +407,17 @@ func createEvidenceReactor(
 	peerManager *p2p.PeerManager,
 	router *p2p.Router,
 	logger log.Logger,
+	metrics *evidence.Metrics,
 ) (*evidence.Reactor, *evidence.Pool, error) {
-	evidenceDB, err := dbProvider(&config.DBContext{ID: "evidence", Config: cfg})
+	evidenceDB, err := dbProvider(&config.DBContext{ID: EvidenceDB, Config: cfg})
 	if err != nil {
 		return nil, nil, fmt.Errorf("unable to initialize evidence db: %w", err)
 	}
 
 	logger = logger.With("module", "evidence")
 
-	evidencePool, err := evidence.NewPool(logger, evidenceDB, sm.NewStore(stateDB), blockStore)
+	evidencePool, err := evidence.NewPool(logger, evidenceDB, sm.NewStore(stateDB), blockStore,
+		evidence.WithMetrics(metrics))
 	if err != nil {
 		return nil, nil, fmt.Errorf("creating evidence pool: %w", err)
 	}
@@ -256,6 +447,8 @@ func createBlockchainReactor(
 	peerManager *p2p.PeerManager,
 	router *p2p.Router,
 	blockSync bool,
+	blockArchive string,
+	checkpoints types.Checkpoints,
 	metrics *consensus.Metrics,
 ) (service.Service, error) {
 
@@ -268,10 +461,22 @@ func createBlockchainReactor(
 
 	peerUpdates := peerManager.Subscribe(ctx)
 
+	var options []blocksync.ReactorOption
+	if blockArchive != "" {
+		archive, err := blocksync.NewArchive(blockArchive)
+		if err != nil {
+			return nil, fmt.Errorf("invalid block archive: %w", err)
+		}
+		options = append(options, blocksync.ReactorArchive(archive))
+	}
+	if len(checkpoints) > 0 {
+		options = append(options, blocksync.ReactorCheckpoints(checkpoints))
+	}
+
 	reactor, err := blocksync.NewReactor(
 		logger, state.Copy(), blockExec, blockStore, csReactor,
 		ch, peerUpdates, blockSync,
-		metrics,
+		metrics, options...,
 	)
 	if err != nil {
 		return nil, err
@@ -286,6 +491,7 @@ func createConsensusReactor(
 	state sm.State,
 	blockExec *sm.BlockExecutor,
 	blockStore sm.BlockStore,
+	stateDB dbm.DB,
 	mp mempool.Mempool,
 	evidencePool *evidence.Pool,
 	privValidator types.PrivValidator,
@@ -295,9 +501,17 @@ func createConsensusReactor(
 	peerManager *p2p.PeerManager,
 	router *p2p.Router,
 	logger log.Logger,
+	clock func() time.Time,
 ) (*consensus.Reactor, *consensus.State, error) {
 	logger = logger.With("module", "consensus")
 
+	options := []consensus.StateOption{
+		consensus.StateMetrics(csMetrics),
+		consensus.StateCheckpointDB(stateDB),
+	}
+	if clock != nil {
+		options = append(options, consensus.StateClock(clock))
+	}
 	consensusState := consensus.NewState(ctx,
 		logger,
 		cfg.Consensus,
@@ -306,7 +520,7 @@ func createConsensusReactor(
 		blockStore,
 		mp,
 		evidencePool,
-		consensus.StateMetrics(csMetrics),
+		options...,
 	)
 
 	if privValidator != nil && cfg.Mode == config.ModeValidator {
@@ -335,6 +549,8 @@ func createConsensusReactor(
 		peerManager.Subscribe(ctx),
 		waitSync,
 		consensus.ReactorMetrics(csMetrics),
+		consensus.ReactorValidatorTracker(peerManager),
+		consensus.ReactorTxFetcher(mp),
 	)
 
 	// Services which will be publishing and/or subscribing for messages (events)
@@ -344,7 +560,31 @@ func createConsensusReactor(
 	return reactor, consensusState, nil
 }
 
+// makeGossipPolicy returns the gossip policy with the given name, see
+// config.P2PConfig.GossipPolicies.
+func makeGossipPolicy(name string) p2p.GossipPolicy {
+	switch name {
+	case config.GossipPolicyNoAddresses:
+		return p2p.GossipPolicy{BlockedChannels: map[p2p.ChannelID]bool{
+			pex.PexChannel: true,
+		}}
+	case config.GossipPolicyBlocksOnly:
+		blocked := map[p2p.ChannelID]bool{
+			pex.PexChannel:           true,
+			mempool.MempoolChannel:   true,
+			evidence.EvidenceChannel: true,
+		}
+		for i := 0; i < config.MaxMempoolLanes; i++ {
+			blocked[mempool.LaneChannel(i)] = true
+		}
+		return p2p.GossipPolicy{BlockedChannels: blocked}
+	default:
+		return p2p.GossipPolicy{}
+	}
+}
+
 func createPeerManager(
+	logger log.Logger,
 	cfg *config.Config,
 	dbProvider config.DBProvider,
 	nodeID types.NodeID,
@@ -355,6 +595,30 @@ func createPeerManager(
 		privatePeerIDs[types.NodeID(id)] = struct{}{}
 	}
 
+	unconditionalPeerIDs := make(map[types.NodeID]struct{})
+	for _, id := range tmstrings.SplitAndTrimEmpty(cfg.P2P.UnconditionalPeerIDs, ",", " ") {
+		unconditionalPeerIDs[types.NodeID(id)] = struct{}{}
+	}
+
+	policies, err := cfg.P2P.ParseGossipPolicies()
+	if err != nil {
+		return nil, func() error { return nil }, fmt.Errorf("invalid gossip policies: %w", err)
+	}
+	gossipPolicies := make(map[types.NodeID]p2p.GossipPolicy, len(policies))
+	var defaultGossipPolicy p2p.GossipPolicy
+	for id, name := range policies {
+		if id == config.GossipPolicyDefaultPeer {
+			defaultGossipPolicy = makeGossipPolicy(name)
+			continue
+		}
+		gossipPolicies[types.NodeID(id)] = makeGossipPolicy(name)
+	}
+
+	allowlist, err := cfg.P2P.ParseConnectionLimitAllowlist()
+	if err != nil {
+		return nil, func() error { return nil }, fmt.Errorf("invalid connection limit allowlist: %w", err)
+	}
+
 	var maxConns uint16
 
 	switch {
@@ -367,12 +631,22 @@ func createPeerManager(
 	options := p2p.PeerManagerOptions{
 		MaxConnected:           maxConns,
 		MaxConnectedUpgrade:    4,
+		MaxConnectedInbound:    cfg.P2P.MaxInboundConnections,
+		MaxConnectedOutbound:   cfg.P2P.MaxOutboundConnections,
+		MaxConnectedPerIP:      cfg.P2P.MaxConnectionsPerIP,
+		MaxConnectedPerSubnet:  cfg.P2P.MaxConnectionsPerSubnet,
 		MaxPeers:               1000,
 		MinRetryTime:           100 * time.Millisecond,
 		MaxRetryTime:           8 * time.Hour,
 		MaxRetryTimePersistent: 5 * time.Minute,
 		RetryTimeJitter:        3 * time.Second,
 		PrivatePeers:           privatePeerIDs,
+		UnconditionalPeers:     unconditionalPeerIDs,
+		GossipPolicies:         gossipPolicies,
+		DefaultGossipPolicy:    defaultGossipPolicy,
+
+		ConnectionLimitAllowlist:      allowlist,
+		PersistentPeerResolveInterval: cfg.P2P.PersistentPeersResolveInterval,
 	}
 
 	peers := []p2p.NodeAddress{}
@@ -394,7 +668,7 @@ func createPeerManager(
 		peers = append(peers, address)
 	}
 
-	peerDB, err := dbProvider(&config.DBContext{ID: "peerstore", Config: cfg})
+	peerDB, err := dbProvider(&config.DBContext{ID: PeerStoreDB, Config: cfg})
 	if err != nil {
 		return nil, func() error { return nil }, fmt.Errorf("unable to initialize peer store: %w", err)
 	}
@@ -405,14 +679,60 @@ func createPeerManager(
 	}
 
 	for _, peer := range peers {
-		if _, err := peerManager.Add(peer); err != nil {
+		if _, err := peerManager.AddFrom(peer, p2p.AddressSourceConfig); err != nil {
 			return nil, peerDB.Close, fmt.Errorf("failed to add peer %q: %w", peer, err)
 		}
 	}
 
+	if err := migrateLegacyAddrBook(logger, cfg.P2P.LegacyAddrBookFile(), peerManager, nodeID); err != nil {
+		// the node can still discover peers, and the migration is retried on
+		// the next start
+		logger.Error("failed to import the legacy address book", "err", err)
+	}
+
 	return peerManager, peerDB.Close, nil
 }
 
+// migrateLegacyAddrBook imports the addresses of the address book of the
+// legacy p2p stack at path, if it exists, into the peer store. The address
+// book is then renamed with a .migrated suffix, so that it is imported once.
+func migrateLegacyAddrBook(
+	logger log.Logger,
+	path string,
+	peerManager *p2p.PeerManager,
+	nodeID types.NodeID,
+) error {
+	bz, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	entries, skipped, err := p2p.ParseLegacyAddressBook(bz)
+	if err != nil {
+		return err
+	}
+	// the legacy address book may hold the node's own addresses
+	imported := entries[:0]
+	for _, entry := range entries {
+		if entry.Address.NodeID != nodeID {
+			imported = append(imported, entry)
+		}
+	}
+	added, err := peerManager.ImportAddressBook(imported)
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(path, path+".migrated"); err != nil {
+		return err
+	}
+
+	logger.Info("imported the legacy address book into the peer store",
+		"path", path, "added", added, "skipped", skipped)
+	return nil
+}
+
 func createRouter(
 	ctx context.Context,
 	logger log.Logger,
@@ -422,7 +742,7 @@ func createRouter(
 	peerManager *p2p.PeerManager,
 	cfg *config.Config,
 	proxyApp proxy.AppConns,
-) (*p2p.Router, error) {
+) (*p2p.Router, *nat.Service, error) {
 
 	p2pLogger := logger.With("module", "p2p")
 
@@ -431,19 +751,55 @@ func createRouter(
 	transportConf.SendRate = cfg.P2P.SendRate
 	transportConf.RecvRate = cfg.P2P.RecvRate
 	transportConf.MaxPacketMsgPayloadSize = cfg.P2P.MaxPacketMsgPayloadSize
+	networkKey, err := cfg.P2P.NetworkKeyBytes()
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid network key: %w", err)
+	}
+	// Unconditional peers are accepted beyond max-inbound-connections, so
+	// the transport leaves room for them.
+	maxAccepted := uint32(cfg.P2P.MaxConnections)
+	if cfg.P2P.MaxInboundConnections > 0 {
+		maxAccepted = uint32(cfg.P2P.MaxInboundConnections) +
+			uint32(len(tmstrings.SplitAndTrimEmpty(cfg.P2P.UnconditionalPeerIDs, ",", " ")))
+	}
+	transportOptions := p2p.MConnTransportOptions{
+		MaxAcceptedConnections: maxAccepted,
+		NoiseHandshake:         cfg.P2P.NoiseHandshake,
+		NetworkKey:             networkKey,
+		DialProxyOnly:          cfg.P2P.DialProxyOnly,
+		Metrics:                p2pMetrics,
+	}
+	proxyURL, err := cfg.P2P.DialProxyURL()
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid dial proxy: %w", err)
+	}
+	if proxyURL != nil {
+		if transportOptions.DialProxy, err = p2p.NewProxyDialer(proxyURL); err != nil {
+			return nil, nil, fmt.Errorf("invalid dial proxy: %w", err)
+		}
+		p2pLogger.Info("dialing peers through proxy", "proxy", proxyURL.Redacted(), "proxy_only", cfg.P2P.DialProxyOnly)
+	}
 	transport := p2p.NewMConnTransport(
-		p2pLogger, transportConf, []*p2p.ChannelDescriptor{},
-		p2p.MConnTransportOptions{
-			MaxAcceptedConnections: uint32(cfg.P2P.MaxConnections),
-		},
-	)
+		p2pLogger, transportConf, []*p2p.ChannelDescriptor{}, transportOptions)
 
 	ep, err := p2p.NewEndpoint(nodeKey.ID.AddressString(cfg.P2P.ListenAddress))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return p2p.NewRouter(
+	// The NAT service is only needed if the external address is not
+	// configured. It reports the addresses peers observe through the router,
+	// and updates the router's listen address.
+	var natService *nat.Service
+	routerOpts := getRouterConfig(cfg, proxyApp)
+	natEnabled := cfg.P2P.ExternalAddress == "" && (cfg.P2P.UPNP || cfg.P2P.DetectExternalAddress)
+	if natEnabled {
+		routerOpts.ObservedAddress = func(id types.NodeID, ip net.IP) {
+			natService.Observe(id, ip)
+		}
+	}
+
+	router, err := p2p.NewRouter(
 		ctx,
 		p2pLogger,
 		p2pMetrics,
@@ -452,23 +808,64 @@ func createRouter(
 		peerManager,
 		[]p2p.Transport{transport},
 		[]p2p.Endpoint{ep},
-		getRouterConfig(cfg, proxyApp),
+		routerOpts,
 	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if natEnabled {
+		natService = nat.NewService(logger.With("module", "nat"), nat.Options{
+			ListenPort:    int(ep.Port),
+			MapPort:       cfg.P2P.UPNP,
+			DetectAddress: cfg.P2P.DetectExternalAddress,
+		}, router.SetListenAddr)
+	}
+
+	return router, natService, nil
 }
 
 func createPEXReactor(
 	ctx context.Context,
 	logger log.Logger,
+	cfg *config.Config,
+	nodeKey types.NodeKey,
 	peerManager *p2p.PeerManager,
 	router *p2p.Router,
-) (service.Service, error) {
+) (*pex.Reactor, error) {
 
 	channel, err := router.OpenChannel(ctx, pex.ChannelDescriptor())
 	if err != nil {
 		return nil, err
 	}
 
-	return pex.NewReactor(logger, peerManager, channel, peerManager.Subscribe(ctx)), nil
+	// the node signs the address it advertises in its node info, which is
+	// updated once the external address of a node behind a NAT is known
+	return pex.NewReactor(logger, peerManager, channel, peerManager.Subscribe(ctx), pex.ReactorOptions{
+		PrivKey: nodeKey.PrivKey,
+		SelfAddress: func() string {
+			return nodeKey.ID.AddressString(router.NodeInfo().ListenAddr)
+		},
+		RequireSignedAddresses: cfg.P2P.PexRequireSignedAddresses,
+	}), nil
+}
+
+func createStatusReactor(
+	ctx context.Context,
+	logger log.Logger,
+	peerManager *p2p.PeerManager,
+	router *p2p.Router,
+	blockStore *store.BlockStore,
+	metrics *status.Metrics,
+) (service.Service, error) {
+
+	channel, err := router.OpenChannel(ctx, status.ChannelDescriptor())
+	if err != nil {
+		return nil, err
+	}
+
+	return status.NewReactor(logger.With("module", "status"), peerManager, channel,
+		peerManager.Subscribe(ctx), blockStore.Height, metrics), nil
 }
 
 func makeNodeInfo(
@@ -476,6 +873,7 @@ func makeNodeInfo(
 	nodeKey types.NodeKey,
 	eventSinks []indexer.EventSink,
 	genDoc *types.GenesisDoc,
+	genHash []byte,
 	state sm.State,
 ) (types.NodeInfo, error) {
 
@@ -491,9 +889,10 @@ func makeNodeInfo(
 			Block: state.Version.Consensus.Block,
 			App:   state.Version.Consensus.App,
 		},
-		NodeID:  nodeKey.ID,
-		Network: genDoc.ChainID,
-		Version: version.TMVersion,
+		NodeID:      nodeKey.ID,
+		Network:     genDoc.ChainID,
+		GenesisHash: genHash,
+		Version:     version.TMVersion,
 		Channels: []byte{
 			byte(blocksync.BlockSyncChannel),
 			byte(consensus.StateChannel),
@@ -506,6 +905,7 @@ func makeNodeInfo(
 			byte(statesync.ChunkChannel),
 			byte(statesync.LightBlockChannel),
 			byte(statesync.ParamsChannel),
+			byte(status.StatusChannel),
 		},
 		Moniker: cfg.Moniker,
 		Other: types.NodeInfoOther{
@@ -518,6 +918,20 @@ func makeNodeInfo(
 		nodeInfo.Channels = append(nodeInfo.Channels, pex.PexChannel)
 	}
 
+	compressions, err := cfg.P2P.Compressions()
+	if err != nil {
+		return nodeInfo, fmt.Errorf("invalid compression: %w", err)
+	}
+	nodeInfo.Compression = compressions
+
+	// Every node reconstructs compact blocks and handles tx announcements and
+	// vote batches, whether or not it sends them itself.
+	nodeInfo.Capabilities = types.CapabilityCompactBlocks | types.CapabilityTxAnnouncements |
+		types.CapabilityVoteBatches
+	if len(compressions) > 0 {
+		nodeInfo.Capabilities |= types.CapabilityCompression
+	}
+
 	nodeInfo.ListenAddr = cfg.P2P.ExternalAddress
 	if nodeInfo.ListenAddr == "" {
 		nodeInfo.ListenAddr = cfg.P2P.ListenAddress
@@ -526,6 +940,23 @@ func makeNodeInfo(
 	return nodeInfo, nodeInfo.Validate()
 }
 
+// genesisHash returns the hash of the genesis doc, checking it against the
+// genesis-hash of the config, if set.
+func genesisHash(cfg *config.Config, genDoc *types.GenesisDoc) ([]byte, error) {
+	hash, err := genDoc.Hash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash genesis doc: %w", err)
+	}
+	expected, err := cfg.GenesisHashBytes()
+	if err != nil {
+		return nil, fmt.Errorf("invalid genesis-hash: %w", err)
+	}
+	if expected != nil && !bytes.Equal(hash, expected) {
+		return nil, fmt.Errorf("genesis doc hash %X doesn't match genesis-hash %X", hash, expected)
+	}
+	return hash, nil
+}
+
 func makeSeedNodeInfo(
 	cfg *config.Config,
 	nodeKey types.NodeKey,
@@ -560,3 +991,72 @@ func makeSeedNodeInfo(
 
 	return nodeInfo, nodeInfo.Validate()
 }
+
+// createProfiler returns the profiler gating the profiles served by the pprof
+// server, or nil if the pprof server is disabled.
+func createProfiler(cfg *config.Config) (*profiling.Profiler, error) {
+	if cfg.RPC.PprofListenAddress == "" {
+		return nil, nil
+	}
+	profiler, err := profiling.NewProfiler(
+		cfg.RPC.PprofProfiles,
+		cfg.RPC.PprofMutexProfileFraction,
+		cfg.RPC.PprofBlockProfileRate,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create profiler: %w", err)
+	}
+	return profiler, nil
+}
+
+// createFlightRecorder returns the flight recorder of the node, recording the
+// status, consensus state and peers served by env, and the p2p metrics, to
+// the flight recorder directory in the data directory.
+func createFlightRecorder(cfg *config.Config, env *rpccore.Environment, logger log.Logger) *flightrec.Recorder {
+	p2pMetricsPrefix := cfg.Instrumentation.Namespace + "_p2p_"
+	sources := map[string]flightrec.Source{
+		"status.json": func(_ context.Context, w io.Writer) error {
+			return writeResultJSON(w)(env.Status(&rpctypes.Context{}))
+		},
+		"consensus_state.json": func(_ context.Context, w io.Writer) error {
+			return writeResultJSON(w)(env.DumpConsensusState(&rpctypes.Context{}))
+		},
+		"net_info.json": func(_ context.Context, w io.Writer) error {
+			return writeResultJSON(w)(env.NetInfo(&rpctypes.Context{}))
+		},
+		// the p2p metrics are only registered if prometheus is enabled
+		"p2p_metrics.txt": func(_ context.Context, w io.Writer) error {
+			families, err := prometheus.DefaultGatherer.Gather()
+			if err != nil {
+				return err
+			}
+			for _, family := range families {
+				if strings.HasPrefix(family.GetName(), p2pMetricsPrefix) {
+					if _, err := expfmt.MetricFamilyToText(w, family); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+	}
+	return flightrec.NewRecorder(
+		logger.With("module", "flightrec"),
+		cfg.Instrumentation.FlightRecorder,
+		filepath.Join(cfg.DBDir(), flightrec.DirName),
+		sources,
+	)
+}
+
+// writeResultJSON returns a function writing the result of an RPC endpoint
+// to w as indented JSON, or returning its error.
+func writeResultJSON(w io.Writer) func(interface{}, error) error {
+	return func(result interface{}, err error) error {
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+}
@@ -7,6 +7,13 @@ FilePV
 FilePV is the simplest implementation and developer default.
 It uses one file for the private key and another to store state.
 
+KeySignerPV
+
+KeySignerPV signs with a KeySigner, a generic interface for backends which hold
+the key themselves, such as a hardware security module (see the pkcs11
+subpackage) or a cloud key management service. Like FilePV it persists the
+last sign state to a file to prevent double signing.
+
 SignerListenerEndpoint
 
 SignerListenerEndpoint establishes a connection to an external process,
@@ -0,0 +1,373 @@
+package privval
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	// defaultHealthCheckInterval is the interval at which a
+	// FailoverSignerClient pings its remote signers.
+	defaultHealthCheckInterval = 5 * time.Second
+
+	// reconcileTimeout bounds the queries of the public key and last sign
+	// state of a remote signer.
+	reconcileTimeout = 10 * time.Second
+)
+
+// ErrDoubleSignRisk is returned by a FailoverSignerClient for a message which
+// it may have had signed by another remote signer than the one it would sign
+// it with.
+var ErrDoubleSignRisk = errors.New("message may have been signed by another remote signer")
+
+// RemoteSigner is a private validator backed by a remote signer, as the signer
+// clients of the socket and gRPC protocols are.
+type RemoteSigner interface {
+	types.PrivValidator
+	types.NodeValidatorProofSigner
+	LastSignStateGetter
+
+	// Ping checks that the remote signer is reachable.
+	Ping() error
+	Close() error
+}
+
+// FailoverEndpoint is a remote signer a FailoverSignerClient fails over to,
+// by its address.
+type FailoverEndpoint struct {
+	Addr   string
+	Signer RemoteSigner
+}
+
+// FailoverSignerClient implements PrivValidator on top of several remote
+// signers of the same key. It signs with one of them, and fails over to
+// another once it's unreachable, so that a signer crash doesn't take the
+// validator offline. The signers are pinged periodically to track their
+// health.
+//
+// Each signer protects against double signing by its own last sign state,
+// which the others don't share. The client thus never requests a signature at
+// or before the sign state of the last one it requested, from another signer
+// than the one it requested it from, as that signer may have signed it even if
+// the request failed. The message isn't signed then, which costs the validator
+// a step rather than risk a double sign. The sign state of the last signature
+// is reconciled with the last sign states of the signers on start, and with
+// that of each signer once it's failed over to.
+type FailoverSignerClient struct {
+	logger    log.Logger
+	metrics   *Metrics
+	endpoints []FailoverEndpoint
+	pubKey    crypto.PubKey
+	cancel    context.CancelFunc
+
+	mtx     sync.Mutex
+	active  int // index of the endpoint signing
+	healthy []bool
+	// sign state of the last signature requested, and the index of the
+	// endpoint it was requested from
+	lastSigned SignState
+	lastSigner int
+}
+
+var _ types.PrivValidator = (*FailoverSignerClient)(nil)
+var _ types.NodeValidatorProofSigner = (*FailoverSignerClient)(nil)
+
+// NewFailoverSignerClient returns a FailoverSignerClient failing over between
+// the given endpoints, pinging them until ctx is done or it's closed. The
+// endpoints reachable must all have the same public key, and at least one of
+// them must be reachable. The endpoint with the latest last sign state signs
+// first.
+func NewFailoverSignerClient(
+	ctx context.Context,
+	logger log.Logger,
+	endpoints []FailoverEndpoint,
+	metrics *Metrics,
+) (*FailoverSignerClient, error) {
+	if len(endpoints) == 0 {
+		return nil, errors.New("no remote signer to fail over between")
+	}
+
+	c := &FailoverSignerClient{
+		logger:    logger,
+		metrics:   metrics,
+		endpoints: endpoints,
+		healthy:   make([]bool, len(endpoints)),
+	}
+	if err := c.reconcileAll(ctx); err != nil {
+		return nil, err
+	}
+
+	ctx, c.cancel = context.WithCancel(ctx)
+	go c.checkHealthRoutine(ctx, defaultHealthCheckInterval)
+	return c, nil
+}
+
+// reconcileAll queries the public keys and last sign states of all the
+// endpoints, to sign with the latest one.
+func (c *FailoverSignerClient) reconcileAll(ctx context.Context) error {
+	type result struct {
+		pubKey crypto.PubKey
+		ss     SignState
+		err    error
+	}
+	results := make([]result, len(c.endpoints))
+	var wg sync.WaitGroup
+	for i, e := range c.endpoints {
+		wg.Add(1)
+		go func(i int, signer RemoteSigner) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, reconcileTimeout)
+			defer cancel()
+
+			r := &results[i]
+			if r.pubKey, r.err = signer.GetPubKey(ctx); r.err == nil {
+				r.ss, r.err = signer.GetLastSignState(ctx)
+			}
+		}(i, e.Signer)
+	}
+	wg.Wait()
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.active, c.lastSigner = -1, -1
+	for i, r := range results {
+		if r.err != nil {
+			c.logger.Error("failed to reach remote signer", "addr", c.endpoints[i].Addr, "err", r.err)
+			c.setHealthy(i, false)
+			continue
+		}
+		if c.pubKey == nil {
+			c.pubKey = r.pubKey
+		} else if !bytes.Equal(c.pubKey.Bytes(), r.pubKey.Bytes()) {
+			return fmt.Errorf("remote signers %s and %s have different public keys",
+				c.endpoints[c.active].Addr, c.endpoints[i].Addr)
+		}
+		if c.active < 0 || c.lastSigned.Before(r.ss) {
+			c.active, c.lastSigned, c.lastSigner = i, r.ss, i
+		}
+		c.setHealthy(i, true)
+	}
+	if c.active < 0 {
+		return errors.New("no remote signer reachable")
+	}
+
+	c.logger.Info("signing with remote signer", "addr", c.endpoints[c.active].Addr,
+		"last_sign_state", c.lastSigned)
+	return nil
+}
+
+// Close stops the health checks, and closes the connections to the remote
+// signers.
+func (c *FailoverSignerClient) Close() error {
+	c.cancel()
+
+	var errs []error
+	for _, e := range c.endpoints {
+		if err := e.Signer.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", e.Addr, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to close remote signers: %v", errs)
+	}
+	return nil
+}
+
+//--------------------------------------------------------
+// Implement PrivValidator
+
+// Ping pings the remote signer signing.
+func (c *FailoverSignerClient) Ping() error {
+	c.mtx.Lock()
+	signer := c.endpoints[c.active].Signer
+	c.mtx.Unlock()
+	return signer.Ping()
+}
+
+// GetPubKey returns the public key of the remote signers.
+func (c *FailoverSignerClient) GetPubKey(ctx context.Context) (crypto.PubKey, error) {
+	return c.pubKey, nil
+}
+
+// SignNodeValidatorProof requests the remote signer signing to sign a proof
+// that the node with the given ID is operated by the validator. The proof
+// isn't bound to a sign state, so it may be signed by any of the signers.
+func (c *FailoverSignerClient) SignNodeValidatorProof(
+	ctx context.Context,
+	chainID string,
+	nodeID types.NodeID,
+) (*types.NodeValidatorProof, error) {
+	c.mtx.Lock()
+	signer := c.endpoints[c.active].Signer
+	c.mtx.Unlock()
+	return signer.SignNodeValidatorProof(ctx, chainID, nodeID)
+}
+
+// SignVote requests a remote signer to sign a vote, failing over to another
+// if it's unreachable.
+func (c *FailoverSignerClient) SignVote(ctx context.Context, chainID string, vote *tmproto.Vote) error {
+	step, err := voteToStep(vote)
+	if err != nil {
+		return err
+	}
+	return c.sign(ctx, SignState{Height: vote.Height, Round: vote.Round, Step: step}, func(signer RemoteSigner) error {
+		return signer.SignVote(ctx, chainID, vote)
+	})
+}
+
+// SignProposal requests a remote signer to sign a proposal, failing over to
+// another if it's unreachable.
+func (c *FailoverSignerClient) SignProposal(ctx context.Context, chainID string, proposal *tmproto.Proposal) error {
+	ss := SignState{Height: proposal.Height, Round: proposal.Round, Step: stepPropose}
+	return c.sign(ctx, ss, func(signer RemoteSigner) error {
+		return signer.SignProposal(ctx, chainID, proposal)
+	})
+}
+
+// sign signs a message of the given sign state with sign, trying each
+// endpoint in turn until one is reached.
+func (c *FailoverSignerClient) sign(ctx context.Context, ss SignState, sign func(RemoteSigner) error) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	var err error
+	for attempt := 0; attempt < len(c.endpoints); attempt++ {
+		if attempt > 0 {
+			c.failover()
+		}
+		e := c.endpoints[c.active]
+
+		if c.active != c.lastSigner {
+			if err = c.reconcile(ctx, c.active); err != nil {
+				c.logger.Error("failed to reach remote signer", "addr", e.Addr, "err", err)
+				c.setHealthy(c.active, false)
+				continue
+			}
+			if c.active != c.lastSigner && !c.lastSigned.Before(ss) {
+				return fmt.Errorf("%w: signature at %v requested from %s, now signing at %v with %s",
+					ErrDoubleSignRisk, c.lastSigned, c.endpoints[c.lastSigner].Addr, ss, e.Addr)
+			}
+		}
+
+		// the signer may sign the message even if the request fails
+		if c.lastSigned.Before(ss) {
+			c.lastSigned, c.lastSigner = ss, c.active
+		}
+		if err = sign(e.Signer); err == nil || signerRefused(err) || ctx.Err() != nil {
+			return err
+		}
+		c.logger.Error("failed to sign with remote signer", "addr", e.Addr, "err", err)
+		c.setHealthy(c.active, false)
+	}
+	return err
+}
+
+// reconcile queries the last sign state of the i-th endpoint, which signed the
+// last signature if it's later than the last requested.
+func (c *FailoverSignerClient) reconcile(ctx context.Context, i int) error {
+	ctx, cancel := context.WithTimeout(ctx, reconcileTimeout)
+	defer cancel()
+
+	ss, err := c.endpoints[i].Signer.GetLastSignState(ctx)
+	if err != nil {
+		return err
+	}
+	if !ss.Before(c.lastSigned) && ss != c.lastSigned {
+		c.lastSigned, c.lastSigner = ss, i
+	}
+	return nil
+}
+
+// failover makes the next healthy endpoint sign, or the next one if none is
+// healthy.
+func (c *FailoverSignerClient) failover() {
+	from := c.active
+	next := (from + 1) % len(c.endpoints)
+	for i := next; i != from; i = (i + 1) % len(c.endpoints) {
+		if c.healthy[i] {
+			next = i
+			break
+		}
+	}
+	if next == from {
+		return
+	}
+
+	c.active = next
+	c.metrics.RemoteSignerFailovers.Add(1)
+	c.logger.Info("failing over to remote signer", "from", c.endpoints[from].Addr, "to", c.endpoints[next].Addr)
+}
+
+func (c *FailoverSignerClient) setHealthy(i int, healthy bool) {
+	if c.healthy[i] != healthy {
+		c.logger.Info("remote signer health changed", "addr", c.endpoints[i].Addr, "healthy", healthy)
+	}
+	c.healthy[i] = healthy
+
+	value := 0.0
+	if healthy {
+		value = 1
+	}
+	c.metrics.RemoteSignerHealthy.With("signer", c.endpoints[i].Addr).Set(value)
+}
+
+// checkHealthRoutine pings the endpoints every interval until ctx is done,
+// failing over from the endpoint signing once it's unhealthy.
+func (c *FailoverSignerClient) checkHealthRoutine(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		c.checkHealth()
+	}
+}
+
+func (c *FailoverSignerClient) checkHealth() {
+	healthy := make([]bool, len(c.endpoints))
+	for i, e := range c.endpoints {
+		healthy[i] = e.Signer.Ping() == nil
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	for i := range healthy {
+		c.setHealthy(i, healthy[i])
+	}
+	if !c.healthy[c.active] {
+		c.failover()
+	}
+}
+
+// signerRefused returns true if err is the error of a reachable remote signer
+// refusing a request, rather than of reaching it.
+func signerRefused(err error) bool {
+	var signerErr *RemoteSignerError
+	if errors.As(err, &signerErr) {
+		return true
+	}
+	if s, ok := status.FromError(err); ok {
+		switch s.Code() {
+		case codes.Unavailable, codes.DeadlineExceeded, codes.Canceled:
+			return false
+		}
+		return true
+	}
+	return false
+}
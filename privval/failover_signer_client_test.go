@@ -0,0 +1,247 @@
+package privval
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+var errUnreachable = errors.New("remote signer unreachable")
+
+// fakeRemoteSigner signs with a mock private validator, keeping its last sign
+// state, unless it's down.
+type fakeRemoteSigner struct {
+	types.MockPV
+
+	mtx    sync.Mutex
+	ss     SignState
+	down   bool
+	signed int
+}
+
+func (s *fakeRemoteSigner) setDown(down bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.down = down
+}
+
+func (s *fakeRemoteSigner) signCount() int {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.signed
+}
+
+func (s *fakeRemoteSigner) Ping() error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.down {
+		return errUnreachable
+	}
+	return nil
+}
+
+func (s *fakeRemoteSigner) Close() error { return nil }
+
+func (s *fakeRemoteSigner) GetPubKey(ctx context.Context) (crypto.PubKey, error) {
+	if err := s.Ping(); err != nil {
+		return nil, err
+	}
+	return s.MockPV.GetPubKey(ctx)
+}
+
+func (s *fakeRemoteSigner) GetLastSignState(ctx context.Context) (SignState, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.down {
+		return SignState{}, errUnreachable
+	}
+	return s.ss, nil
+}
+
+func (s *fakeRemoteSigner) SignVote(ctx context.Context, chainID string, vote *tmproto.Vote) error {
+	step, err := voteToStep(vote)
+	if err != nil {
+		return err
+	}
+	return s.sign(SignState{Height: vote.Height, Round: vote.Round, Step: step}, func() error {
+		return s.MockPV.SignVote(ctx, chainID, vote)
+	})
+}
+
+func (s *fakeRemoteSigner) SignProposal(ctx context.Context, chainID string, proposal *tmproto.Proposal) error {
+	return s.sign(SignState{Height: proposal.Height, Round: proposal.Round, Step: stepPropose}, func() error {
+		return s.MockPV.SignProposal(ctx, chainID, proposal)
+	})
+}
+
+func (s *fakeRemoteSigner) sign(ss SignState, sign func() error) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.down {
+		return errUnreachable
+	}
+	if !s.ss.Before(ss) {
+		return &RemoteSignerError{Code: 1, Description: "height regression"}
+	}
+	s.ss = ss
+	s.signed++
+	return sign()
+}
+
+func newFakeRemoteSigners(n int) ([]*fakeRemoteSigner, []FailoverEndpoint) {
+	pv := types.NewMockPV()
+	signers := make([]*fakeRemoteSigner, n)
+	endpoints := make([]FailoverEndpoint, n)
+	for i := range signers {
+		signers[i] = &fakeRemoteSigner{MockPV: pv}
+		endpoints[i] = FailoverEndpoint{Addr: string(rune('a' + i)), Signer: signers[i]}
+	}
+	return signers, endpoints
+}
+
+func newPrevote(height int64, round int32) *tmproto.Vote {
+	return &tmproto.Vote{Type: tmproto.PrevoteType, Height: height, Round: round}
+}
+
+func newPrecommit(height int64, round int32) *tmproto.Vote {
+	return &tmproto.Vote{Type: tmproto.PrecommitType, Height: height, Round: round}
+}
+
+func TestNewFailoverSignerClient(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	logger := log.TestingLogger()
+
+	t.Run("signs with the latest signer", func(t *testing.T) {
+		signers, endpoints := newFakeRemoteSigners(3)
+		signers[1].ss = SignState{Height: 2}
+		signers[2].ss = SignState{Height: 1}
+
+		c, err := NewFailoverSignerClient(ctx, logger, endpoints, NopMetrics())
+		require.NoError(t, err)
+		defer c.Close()
+
+		pubKey, err := c.GetPubKey(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, signers[0].PrivKey.PubKey(), pubKey)
+
+		require.NoError(t, c.SignVote(ctx, "chain", newPrevote(3, 0)))
+		assert.Equal(t, 1, signers[1].signCount())
+	})
+
+	t.Run("skips unreachable signers", func(t *testing.T) {
+		signers, endpoints := newFakeRemoteSigners(2)
+		signers[0].setDown(true)
+
+		c, err := NewFailoverSignerClient(ctx, logger, endpoints, NopMetrics())
+		require.NoError(t, err)
+		defer c.Close()
+
+		require.NoError(t, c.SignVote(ctx, "chain", newPrevote(1, 0)))
+		assert.Equal(t, 1, signers[1].signCount())
+	})
+
+	t.Run("no signer reachable", func(t *testing.T) {
+		signers, endpoints := newFakeRemoteSigners(2)
+		signers[0].setDown(true)
+		signers[1].setDown(true)
+
+		_, err := NewFailoverSignerClient(ctx, logger, endpoints, NopMetrics())
+		require.Error(t, err)
+	})
+
+	t.Run("different public keys", func(t *testing.T) {
+		signers, endpoints := newFakeRemoteSigners(2)
+		signers[1].MockPV = types.NewMockPV()
+
+		_, err := NewFailoverSignerClient(ctx, logger, endpoints, NopMetrics())
+		require.Error(t, err)
+	})
+}
+
+func TestFailoverSignerClientFailover(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signers, endpoints := newFakeRemoteSigners(2)
+	c, err := NewFailoverSignerClient(ctx, log.TestingLogger(), endpoints, NopMetrics())
+	require.NoError(t, err)
+	defer c.Close()
+
+	require.NoError(t, c.SignVote(ctx, "chain", newPrevote(1, 0)))
+	assert.Equal(t, 1, signers[0].signCount())
+
+	// the first signer is down, and may have signed the precommit: the second
+	// one doesn't sign it
+	signers[0].setDown(true)
+	err = c.SignVote(ctx, "chain", newPrecommit(1, 0))
+	require.ErrorIs(t, err, ErrDoubleSignRisk)
+	assert.Zero(t, signers[1].signCount())
+
+	// but it signs the next step
+	require.NoError(t, c.SignProposal(ctx, "chain", &tmproto.Proposal{Height: 2}))
+	require.NoError(t, c.SignVote(ctx, "chain", newPrevote(2, 0)))
+	assert.Equal(t, 2, signers[1].signCount())
+
+	// the first signer back, it's failed over to once the second one is down
+	signers[0].setDown(false)
+	signers[1].setDown(true)
+	c.checkHealth()
+	require.NoError(t, c.SignVote(ctx, "chain", newPrevote(3, 0)))
+	assert.Equal(t, 2, signers[0].signCount())
+}
+
+func TestFailoverSignerClientRefusal(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signers, endpoints := newFakeRemoteSigners(2)
+	signers[0].ss = SignState{Height: 5}
+	c, err := NewFailoverSignerClient(ctx, log.TestingLogger(), endpoints, NopMetrics())
+	require.NoError(t, err)
+	defer c.Close()
+
+	// a signer refusing to sign isn't failed over from
+	err = c.SignVote(ctx, "chain", newPrevote(4, 0))
+	var signerErr *RemoteSignerError
+	require.ErrorAs(t, err, &signerErr)
+	assert.Zero(t, signers[1].signCount())
+
+	require.NoError(t, c.SignVote(ctx, "chain", newPrevote(6, 0)))
+	assert.Equal(t, 1, signers[0].signCount())
+}
+
+func TestFailoverSignerClientReconcile(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the second signer is unreachable at start, and has signed later than the
+	// first one
+	signers, endpoints := newFakeRemoteSigners(2)
+	signers[1].ss = SignState{Height: 3, Step: stepPrevote}
+	signers[1].setDown(true)
+	c, err := NewFailoverSignerClient(ctx, log.TestingLogger(), endpoints, NopMetrics())
+	require.NoError(t, err)
+	defer c.Close()
+
+	require.NoError(t, c.SignVote(ctx, "chain", newPrevote(2, 0)))
+
+	// its last sign state is reconciled when it's failed over to
+	signers[0].setDown(true)
+	signers[1].setDown(false)
+	c.checkHealth()
+	err = c.SignVote(ctx, "chain", newPrevote(3, 0))
+	var signerErr *RemoteSignerError
+	require.ErrorAs(t, err, &signerErr)
+	require.NoError(t, c.SignVote(ctx, "chain", newPrecommit(3, 0)))
+	assert.Equal(t, 1, signers[1].signCount())
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/crypto/sr25519"
 	"github.com/tendermint/tendermint/internal/libs/protoio"
 	"github.com/tendermint/tendermint/internal/libs/tempfile"
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
@@ -51,6 +52,10 @@ type FilePVKey struct {
 	PubKey  crypto.PubKey  `json:"pub_key"`
 	PrivKey crypto.PrivKey `json:"priv_key"`
 
+	// The key the validator switches to once its key has been rotated.
+	NextPubKey  crypto.PubKey  `json:"next_pub_key,omitempty"`
+	NextPrivKey crypto.PrivKey `json:"next_priv_key,omitempty"`
+
 	filePath string
 }
 
@@ -122,6 +127,26 @@ func (lss *FilePVLastSignState) CheckHRS(height int64, round int32, step int8) (
 	return false, nil
 }
 
+// SignState returns the height, round and step of the last signature.
+func (lss *FilePVLastSignState) SignState() SignState {
+	return SignState{Height: lss.Height, Round: lss.Round, Step: lss.Step}
+}
+
+// loadFilePVLastSignState loads a FilePVLastSignState from stateFilePath.
+func loadFilePVLastSignState(stateFilePath string) (FilePVLastSignState, error) {
+	pvState := FilePVLastSignState{}
+	stateJSONBytes, err := os.ReadFile(stateFilePath)
+	if err != nil {
+		return pvState, err
+	}
+	err = tmjson.Unmarshal(stateJSONBytes, &pvState)
+	if err != nil {
+		return pvState, fmt.Errorf("error reading PrivValidator state from %v: %w", stateFilePath, err)
+	}
+	pvState.filePath = stateFilePath
+	return pvState, nil
+}
+
 // Save persists the FilePvLastSignState to its filePath.
 func (lss *FilePVLastSignState) Save() error {
 	outFile := lss.filePath
@@ -148,6 +173,9 @@ type FilePV struct {
 }
 
 var _ types.PrivValidator = (*FilePV)(nil)
+var _ types.NodeValidatorProofSigner = (*FilePV)(nil)
+var _ types.KeyRotator = (*FilePV)(nil)
+var _ LastSignStateGetter = (*FilePV)(nil)
 
 // NewFilePV generates a new validator from the given key and paths.
 func NewFilePV(privKey crypto.PrivKey, keyFilePath, stateFilePath string) *FilePV {
@@ -168,11 +196,21 @@ func NewFilePV(privKey crypto.PrivKey, keyFilePath, stateFilePath string) *FileP
 // GenFilePV generates a new validator with randomly generated private key
 // and sets the filePaths, but does not call Save().
 func GenFilePV(keyFilePath, stateFilePath, keyType string) (*FilePV, error) {
+	privKey, err := genPrivKey(keyType)
+	if err != nil {
+		return nil, err
+	}
+	return NewFilePV(privKey, keyFilePath, stateFilePath), nil
+}
+
+func genPrivKey(keyType string) (crypto.PrivKey, error) {
 	switch keyType {
 	case types.ABCIPubKeyTypeSecp256k1:
-		return NewFilePV(secp256k1.GenPrivKey(), keyFilePath, stateFilePath), nil
+		return secp256k1.GenPrivKey(), nil
+	case types.ABCIPubKeyTypeSr25519:
+		return sr25519.GenPrivKey(), nil
 	case "", types.ABCIPubKeyTypeEd25519:
-		return NewFilePV(ed25519.GenPrivKey(), keyFilePath, stateFilePath), nil
+		return ed25519.GenPrivKey(), nil
 	default:
 		return nil, fmt.Errorf("key type: %s is not supported", keyType)
 	}
@@ -206,23 +244,21 @@ func loadFilePV(keyFilePath, stateFilePath string, loadState bool) (*FilePV, err
 	// overwrite pubkey and address for convenience
 	pvKey.PubKey = pvKey.PrivKey.PubKey()
 	pvKey.Address = pvKey.PubKey.Address()
+	pvKey.NextPubKey = nil
+	if pvKey.NextPrivKey != nil {
+		pvKey.NextPubKey = pvKey.NextPrivKey.PubKey()
+	}
 	pvKey.filePath = keyFilePath
 
-	pvState := FilePVLastSignState{}
+	pvState := FilePVLastSignState{filePath: stateFilePath}
 
 	if loadState {
-		stateJSONBytes, err := os.ReadFile(stateFilePath)
+		pvState, err = loadFilePVLastSignState(stateFilePath)
 		if err != nil {
 			return nil, err
 		}
-		err = tmjson.Unmarshal(stateJSONBytes, &pvState)
-		if err != nil {
-			return nil, fmt.Errorf("error reading PrivValidator state from %v: %w", stateFilePath, err)
-		}
 	}
 
-	pvState.filePath = stateFilePath
-
 	return &FilePV{
 		Key:           pvKey,
 		LastSignState: pvState,
@@ -263,6 +299,12 @@ func (pv *FilePV) GetPubKey(ctx context.Context) (crypto.PubKey, error) {
 	return pv.Key.PubKey, nil
 }
 
+// GetLastSignState returns the height, round and step of the last signature.
+// Implements LastSignStateGetter.
+func (pv *FilePV) GetLastSignState(ctx context.Context) (SignState, error) {
+	return pv.LastSignState.SignState(), nil
+}
+
 // SignVote signs a canonical representation of the vote, along with the
 // chainID. Implements PrivValidator.
 func (pv *FilePV) SignVote(ctx context.Context, chainID string, vote *tmproto.Vote) error {
@@ -281,6 +323,64 @@ func (pv *FilePV) SignProposal(ctx context.Context, chainID string, proposal *tm
 	return nil
 }
 
+// SignNodeValidatorProof signs a proof that the node with the given ID is
+// operated by this validator. Implements types.NodeValidatorProofSigner.
+func (pv *FilePV) SignNodeValidatorProof(
+	ctx context.Context,
+	chainID string,
+	nodeID types.NodeID,
+) (*types.NodeValidatorProof, error) {
+	sig, err := pv.Key.PrivKey.Sign(types.NodeValidatorProofSignBytes(chainID, nodeID))
+	if err != nil {
+		return nil, err
+	}
+	return &types.NodeValidatorProof{PubKey: pv.Key.PubKey, Signature: sig}, nil
+}
+
+// GenNextKey generates a new key of the given type, which the validator
+// switches to once its key has been rotated, and saves it.
+func (pv *FilePV) GenNextKey(keyType string) (crypto.PubKey, error) {
+	privKey, err := genPrivKey(keyType)
+	if err != nil {
+		return nil, err
+	}
+	key := pv.Key
+	key.NextPrivKey = privKey
+	key.NextPubKey = privKey.PubKey()
+	if err := key.Save(); err != nil {
+		return nil, err
+	}
+	pv.Key = key
+	return key.NextPubKey, nil
+}
+
+// GetNextPubKey returns the next public key of the validator, or nil if there
+// is none. Implements types.KeyRotator.
+func (pv *FilePV) GetNextPubKey(ctx context.Context) (crypto.PubKey, error) {
+	return pv.Key.NextPubKey, nil
+}
+
+// RotateKey replaces the key of the validator with its next key, and saves it.
+// The last sign state is kept, so the validator does not sign at a height,
+// round and step it already signed at with the previous key.
+// Implements types.KeyRotator.
+func (pv *FilePV) RotateKey(ctx context.Context) error {
+	if pv.Key.NextPrivKey == nil {
+		return errors.New("no next key to rotate to")
+	}
+	key := pv.Key
+	key.PrivKey = key.NextPrivKey
+	key.PubKey = key.NextPubKey
+	key.Address = key.NextPubKey.Address()
+	key.NextPrivKey = nil
+	key.NextPubKey = nil
+	if err := key.Save(); err != nil {
+		return err
+	}
+	pv.Key = key
+	return nil
+}
+
 // Save persists the FilePV to disk.
 func (pv *FilePV) Save() error {
 	if err := pv.Key.Save(); err != nil {
@@ -318,6 +418,25 @@ func (pv *FilePV) String() string {
 // It may need to set the timestamp as well if the vote is otherwise the same as
 // a previously signed vote (ie. we crashed after signing but before the vote hit the WAL).
 func (pv *FilePV) signVote(chainID string, vote *tmproto.Vote) error {
+	return pv.LastSignState.signVote(chainID, vote, pv.Key.PrivKey.Sign)
+}
+
+// signProposal checks if the proposal is good to sign and sets the proposal signature.
+// It may need to set the timestamp as well if the proposal is otherwise the same as
+// a previously signed proposal ie. we crashed after signing but before the proposal hit the WAL).
+func (pv *FilePV) signProposal(chainID string, proposal *tmproto.Proposal) error {
+	return pv.LastSignState.signProposal(chainID, proposal, pv.Key.PrivKey.Sign)
+}
+
+// signVote checks if the vote is good to sign against the last sign state,
+// signs it using sign, and persists the new state. It may need to set the
+// timestamp as well if the vote is otherwise the same as a previously signed
+// vote (ie. we crashed after signing but before the vote hit the WAL).
+func (lss *FilePVLastSignState) signVote(
+	chainID string,
+	vote *tmproto.Vote,
+	sign func([]byte) ([]byte, error),
+) error {
 	step, err := voteToStep(vote)
 	if err != nil {
 		return err
@@ -325,7 +444,6 @@ func (pv *FilePV) signVote(chainID string, vote *tmproto.Vote) error {
 
 	height := vote.Height
 	round := vote.Round
-	lss := pv.LastSignState
 
 	sameHRS, err := lss.CheckHRS(height, round, step)
 	if err != nil {
@@ -358,25 +476,29 @@ func (pv *FilePV) signVote(chainID string, vote *tmproto.Vote) error {
 	}
 
 	// It passed the checks. Sign the vote
-	sig, err := pv.Key.PrivKey.Sign(signBytes)
+	sig, err := sign(signBytes)
 	if err != nil {
 		return err
 	}
-	if err := pv.saveSigned(height, round, step, signBytes, sig); err != nil {
+	if err := lss.saveSigned(height, round, step, signBytes, sig); err != nil {
 		return err
 	}
 	vote.Signature = sig
 	return nil
 }
 
-// signProposal checks if the proposal is good to sign and sets the proposal signature.
-// It may need to set the timestamp as well if the proposal is otherwise the same as
-// a previously signed proposal ie. we crashed after signing but before the proposal hit the WAL).
-func (pv *FilePV) signProposal(chainID string, proposal *tmproto.Proposal) error {
+// signProposal checks if the proposal is good to sign against the last sign
+// state, signs it using sign, and persists the new state. It may need to set
+// the timestamp as well if the proposal is otherwise the same as a previously
+// signed proposal ie. we crashed after signing but before the proposal hit the
+// WAL).
+func (lss *FilePVLastSignState) signProposal(
+	chainID string,
+	proposal *tmproto.Proposal,
+	sign func([]byte) ([]byte, error),
+) error {
 	height, round, step := proposal.Height, proposal.Round, stepPropose
 
-	lss := pv.LastSignState
-
 	sameHRS, err := lss.CheckHRS(height, round, step)
 	if err != nil {
 		return err
@@ -407,11 +529,11 @@ func (pv *FilePV) signProposal(chainID string, proposal *tmproto.Proposal) error
 	}
 
 	// It passed the checks. Sign the proposal
-	sig, err := pv.Key.PrivKey.Sign(signBytes)
+	sig, err := sign(signBytes)
 	if err != nil {
 		return err
 	}
-	if err := pv.saveSigned(height, round, step, signBytes, sig); err != nil {
+	if err := lss.saveSigned(height, round, step, signBytes, sig); err != nil {
 		return err
 	}
 	proposal.Signature = sig
@@ -419,13 +541,13 @@ func (pv *FilePV) signProposal(chainID string, proposal *tmproto.Proposal) error
 }
 
 // Persist height/round/step and signature
-func (pv *FilePV) saveSigned(height int64, round int32, step int8, signBytes []byte, sig []byte) error {
-	pv.LastSignState.Height = height
-	pv.LastSignState.Round = round
-	pv.LastSignState.Step = step
-	pv.LastSignState.Signature = sig
-	pv.LastSignState.SignBytes = signBytes
-	return pv.LastSignState.Save()
+func (lss *FilePVLastSignState) saveSigned(height int64, round int32, step int8, signBytes []byte, sig []byte) error {
+	lss.Height = height
+	lss.Round = round
+	lss.Step = step
+	lss.Signature = sig
+	lss.SignBytes = signBytes
+	return lss.Save()
 }
 
 //-----------------------------------------------------------------------------------------
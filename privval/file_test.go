@@ -42,6 +42,43 @@ func TestGenLoadValidator(t *testing.T) {
 	assert.Equal(height, privVal.LastSignState.Height, "expected privval.LastHeight to have been saved")
 }
 
+func TestGenLoadValidatorKeyTypes(t *testing.T) {
+	for _, keyType := range []string{
+		types.ABCIPubKeyTypeEd25519,
+		types.ABCIPubKeyTypeSecp256k1,
+		types.ABCIPubKeyTypeSr25519,
+	} {
+		keyType := keyType
+		t.Run(keyType, func(t *testing.T) {
+			tempKeyFile, err := os.CreateTemp("", "priv_validator_key_")
+			require.NoError(t, err)
+			tempStateFile, err := os.CreateTemp("", "priv_validator_state_")
+			require.NoError(t, err)
+
+			privVal, err := GenFilePV(tempKeyFile.Name(), tempStateFile.Name(), keyType)
+			require.NoError(t, err)
+			require.NoError(t, privVal.Save())
+			assert.Equal(t, keyType, privVal.Key.PubKey.Type())
+
+			privVal, err = LoadFilePV(tempKeyFile.Name(), tempStateFile.Name())
+			require.NoError(t, err)
+			assert.Equal(t, keyType, privVal.Key.PubKey.Type())
+
+			// the loaded key signs votes which verify against its public key
+			blockID := types.BlockID{Hash: tmrand.Bytes(tmhash.Size),
+				PartSetHeader: types.PartSetHeader{Total: 5, Hash: tmrand.Bytes(tmhash.Size)}}
+			vote := newVote(privVal.Key.Address, 0, 10, 1, tmproto.PrevoteType, blockID)
+			v := vote.ToProto()
+			require.NoError(t, privVal.SignVote(context.Background(), "mychainid", v))
+			vote.Signature = v.Signature
+			assert.NoError(t, vote.Verify("mychainid", privVal.Key.PubKey))
+		})
+	}
+
+	_, err := GenFilePV("", "", "rsa")
+	assert.Error(t, err)
+}
+
 func TestResetValidator(t *testing.T) {
 	tempKeyFile, err := os.CreateTemp("", "priv_validator_key_")
 	require.Nil(t, err)
@@ -97,6 +134,43 @@ func TestLoadOrGenValidator(t *testing.T) {
 	assert.Equal(addr, privVal.GetAddress(), "expected privval addr to be the same")
 }
 
+func TestRotateKey(t *testing.T) {
+	ctx := context.Background()
+
+	tempKeyFile, err := os.CreateTemp("", "priv_validator_key_")
+	require.Nil(t, err)
+	tempStateFile, err := os.CreateTemp("", "priv_validator_state_")
+	require.Nil(t, err)
+
+	privVal, err := GenFilePV(tempKeyFile.Name(), tempStateFile.Name(), "")
+	require.NoError(t, err)
+	require.NoError(t, privVal.Save())
+	require.Error(t, privVal.RotateKey(ctx), "expected error without next key")
+
+	nextPubKey, err := privVal.GenNextKey(types.ABCIPubKeyTypeEd25519)
+	require.NoError(t, err)
+
+	// the next key is persisted
+	privVal, err = LoadFilePV(tempKeyFile.Name(), tempStateFile.Name())
+	require.NoError(t, err)
+	pubKey, err := privVal.GetNextPubKey(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, nextPubKey, pubKey)
+
+	require.NoError(t, privVal.RotateKey(ctx))
+	assert.Equal(t, nextPubKey.Address(), privVal.GetAddress())
+
+	// the rotation is persisted
+	privVal, err = LoadFilePV(tempKeyFile.Name(), tempStateFile.Name())
+	require.NoError(t, err)
+	pubKey, err = privVal.GetPubKey(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, nextPubKey, pubKey)
+	pubKey, err = privVal.GetNextPubKey(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, pubKey)
+}
+
 func TestUnmarshalValidatorState(t *testing.T) {
 	assert, require := assert.New(t), require.New(t)
 
@@ -2,13 +2,16 @@ package grpc
 
 import (
 	"context"
+	"time"
 
+	grpc_retry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
 	grpc "google.golang.org/grpc"
 	"google.golang.org/grpc/status"
 
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/encoding"
 	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/privval"
 	privvalproto "github.com/tendermint/tendermint/proto/tendermint/privval"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/types"
@@ -24,7 +27,12 @@ type SignerClient struct {
 	chainID string
 }
 
+// pingTimeout bounds a ping, which is not retried.
+const pingTimeout = 2 * time.Second
+
 var _ types.PrivValidator = (*SignerClient)(nil)
+var _ types.NodeValidatorProofSigner = (*SignerClient)(nil)
+var _ privval.RemoteSigner = (*SignerClient)(nil)
 
 // NewSignerClient returns an instance of SignerClient.
 // it will start the endpoint (if not already started)
@@ -52,6 +60,34 @@ func (sc *SignerClient) Close() error {
 //--------------------------------------------------------
 // Implement PrivValidator
 
+// Ping sends a ping request to the remote signer
+func (sc *SignerClient) Ping() error {
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
+
+	_, err := sc.client.Ping(ctx, &privvalproto.PingRequest{}, grpc_retry.WithMax(0))
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		sc.logger.Error("SignerClient::Ping", "err", errStatus.Message())
+		return errStatus.Err()
+	}
+
+	return nil
+}
+
+// GetLastSignState retrieves the sign state of the last signature of the
+// remote signer
+func (sc *SignerClient) GetLastSignState(ctx context.Context) (privval.SignState, error) {
+	resp, err := sc.client.GetLastSignState(ctx, &privvalproto.LastSignStateRequest{ChainId: sc.chainID})
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		sc.logger.Error("SignerClient::GetLastSignState", "err", errStatus.Message())
+		return privval.SignState{}, errStatus.Err()
+	}
+
+	return privval.SignState{Height: resp.Height, Round: resp.Round, Step: int8(resp.Step)}, nil
+}
+
 // GetPubKey retrieves a public key from a remote signer
 // returns an error if client is not able to provide the key
 func (sc *SignerClient) GetPubKey(ctx context.Context) (crypto.PubKey, error) {
@@ -99,3 +135,26 @@ func (sc *SignerClient) SignProposal(ctx context.Context, chainID string, propos
 
 	return nil
 }
+
+// SignNodeValidatorProof requests a remote signer to sign a proof that the
+// node with the given ID is operated by its validator
+func (sc *SignerClient) SignNodeValidatorProof(
+	ctx context.Context,
+	chainID string,
+	nodeID types.NodeID,
+) (*types.NodeValidatorProof, error) {
+	resp, err := sc.client.SignNodeValidatorProof(
+		ctx, &privvalproto.SignNodeValidatorProofRequest{ChainId: chainID, NodeId: string(nodeID)})
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		sc.logger.Error("SignerClient::SignNodeValidatorProof", "err", errStatus.Message())
+		return nil, errStatus.Err()
+	}
+
+	pk, err := encoding.PubKeyFromProto(resp.PubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.NodeValidatorProof{PubKey: pk, Signature: resp.Signature}, nil
+}
@@ -3,11 +3,15 @@ package grpc_test
 import (
 	"context"
 	"net"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
 
 	"github.com/stretchr/testify/assert"
@@ -16,6 +20,7 @@ import (
 	"github.com/tendermint/tendermint/crypto/tmhash"
 	"github.com/tendermint/tendermint/libs/log"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
+	"github.com/tendermint/tendermint/privval"
 	tmgrpc "github.com/tendermint/tendermint/privval/grpc"
 	privvalproto "github.com/tendermint/tendermint/proto/tendermint/privval"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
@@ -65,6 +70,84 @@ func TestSignerClient_GetPubKey(t *testing.T) {
 	assert.Equal(t, mockPV.PrivKey.PubKey(), pk)
 }
 
+func TestSignerClient_GetLastSignState(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir := t.TempDir()
+	filePV, err := privval.GenFilePV(filepath.Join(dir, "key.json"), filepath.Join(dir, "state.json"), "")
+	require.NoError(t, err)
+	logger := log.TestingLogger()
+
+	testCases := []struct {
+		name string
+		pv   types.PrivValidator
+		err  bool
+	}{
+		{name: "file pv", pv: filePV},
+		{name: "last sign state not kept", pv: types.NewMockPV(), err: true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			srv, dialer := dialer(t, tc.pv, logger)
+			defer srv.Stop()
+
+			conn, err := grpc.DialContext(ctx, "",
+				grpc.WithTransportCredentials(insecure.NewCredentials()),
+				grpc.WithContextDialer(dialer),
+			)
+			require.NoError(t, err)
+			defer conn.Close()
+
+			client, err := tmgrpc.NewSignerClient(conn, chainID, logger)
+			require.NoError(t, err)
+			require.NoError(t, client.Ping())
+
+			ss, err := client.GetLastSignState(ctx)
+			if tc.err {
+				assert.Equal(t, codes.Unimplemented, status.Code(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, privval.SignState{}, ss)
+
+			vote := &tmproto.Vote{Type: tmproto.PrevoteType, Height: 3, Round: 1}
+			require.NoError(t, client.SignVote(ctx, chainID, vote))
+			ss, err = client.GetLastSignState(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, privval.SignState{Height: 3, Round: 1, Step: 2}, ss)
+		})
+	}
+}
+
+func TestSignerClient_SignNodeValidatorProof(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockPV := types.NewMockPV()
+	logger := log.TestingLogger()
+	srv, dialer := dialer(t, mockPV, logger)
+	defer srv.Stop()
+
+	conn, err := grpc.DialContext(ctx, "",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(dialer),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client, err := tmgrpc.NewSignerClient(conn, chainID, logger)
+	require.NoError(t, err)
+
+	nodeID := types.NodeID(strings.Repeat("ab", 20))
+	proof, err := client.SignNodeValidatorProof(ctx, chainID, nodeID)
+	require.NoError(t, err)
+	assert.NoError(t, proof.Verify(chainID, nodeID))
+	assert.Equal(t, mockPV.PrivKey.PubKey(), proof.PubKey)
+}
+
 func TestSignerClient_SignVote(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
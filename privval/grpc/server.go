@@ -9,6 +9,7 @@ import (
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/encoding"
 	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/privval"
 	privvalproto "github.com/tendermint/tendermint/proto/tendermint/privval"
 	"github.com/tendermint/tendermint/types"
 )
@@ -54,6 +55,29 @@ func (ss *SignerServer) GetPubKey(ctx context.Context, req *privvalproto.PubKeyR
 	return &privvalproto.PubKeyResponse{PubKey: pk}, nil
 }
 
+// Ping receives a ping request, to check that the signer is reachable
+func (ss *SignerServer) Ping(ctx context.Context, req *privvalproto.PingRequest) (
+	*privvalproto.PingResponse, error) {
+	return &privvalproto.PingResponse{}, nil
+}
+
+// GetLastSignState receives a request for the sign state of the last
+// signature, returns it on success and error on failure
+func (ss *SignerServer) GetLastSignState(ctx context.Context, req *privvalproto.LastSignStateRequest) (
+	*privvalproto.LastSignStateResponse, error) {
+	getter, ok := ss.privVal.(privval.LastSignStateGetter)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "last sign state not kept by the private validator")
+	}
+
+	lss, err := getter.GetLastSignState(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error getting last sign state: %v", err)
+	}
+
+	return &privvalproto.LastSignStateResponse{Height: lss.Height, Round: lss.Round, Step: int32(lss.Step)}, nil
+}
+
 // SignVote receives a vote sign requests, attempts to sign it
 // returns SignedVoteResponse on success and error on failure
 func (ss *SignerServer) SignVote(ctx context.Context, req *privvalproto.SignVoteRequest) (
@@ -85,3 +109,27 @@ func (ss *SignerServer) SignProposal(ctx context.Context, req *privvalproto.Sign
 
 	return &privvalproto.SignedProposalResponse{Proposal: *proposal}, nil
 }
+
+// SignNodeValidatorProof receives a request to sign a node validator proof,
+// returns the proof on success and error on failure
+func (ss *SignerServer) SignNodeValidatorProof(ctx context.Context, req *privvalproto.SignNodeValidatorProofRequest) (
+	*privvalproto.SignedNodeValidatorProofResponse, error) {
+	signer, ok := ss.privVal.(types.NodeValidatorProofSigner)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "node validator proofs not supported by the private validator")
+	}
+
+	proof, err := signer.SignNodeValidatorProof(ctx, req.ChainId, types.NodeID(req.NodeId))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "error signing node validator proof: %v", err)
+	}
+
+	pk, err := encoding.PubKeyToProto(proof.PubKey)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error transitioning pubkey to proto: %v", err)
+	}
+
+	ss.logger.Info("SignerServer: SignNodeValidatorProof Success", "node_id", req.NodeId)
+
+	return &privvalproto.SignedNodeValidatorProofResponse{PubKey: pk, Signature: proof.Signature}, nil
+}
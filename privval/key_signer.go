@@ -0,0 +1,150 @@
+package privval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/crypto"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// KeySigner is a generic signing backend which holds a single private key,
+// such as a hardware security module or a cloud key management service.
+// Implementations are not expected to protect against double signing.
+type KeySigner interface {
+	// PubKey returns the public key of the signing key.
+	PubKey(ctx context.Context) (crypto.PubKey, error)
+	// Sign signs msg with the signing key.
+	Sign(ctx context.Context, msg []byte) ([]byte, error)
+}
+
+// KeySignerPV implements PrivValidator on top of a KeySigner. The key never
+// leaves the signer; double sign protection is provided by the last sign
+// state, which is persisted to a file in the same format FilePV uses.
+type KeySignerPV struct {
+	signer  KeySigner
+	pubKey  crypto.PubKey
+	metrics *Metrics
+
+	mtx           sync.Mutex
+	lastSignState FilePVLastSignState
+}
+
+var _ types.PrivValidator = (*KeySignerPV)(nil)
+var _ types.NodeValidatorProofSigner = (*KeySignerPV)(nil)
+var _ LastSignStateGetter = (*KeySignerPV)(nil)
+
+// NewKeySignerPV returns a KeySignerPV which signs using signer and keeps the
+// last sign state in stateFilePath. If the state file does not exist, an empty
+// state is created.
+func NewKeySignerPV(
+	ctx context.Context,
+	signer KeySigner,
+	stateFilePath string,
+	metrics *Metrics,
+) (*KeySignerPV, error) {
+	pubKey, err := signer.PubKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public key from signer: %w", err)
+	}
+
+	var lastSignState FilePVLastSignState
+	if _, err := os.Stat(stateFilePath); err == nil {
+		lastSignState, err = loadFilePVLastSignState(stateFilePath)
+		if err != nil {
+			return nil, err
+		}
+	} else if errors.Is(err, os.ErrNotExist) {
+		lastSignState = FilePVLastSignState{Step: stepNone, filePath: stateFilePath}
+		if err := lastSignState.Save(); err != nil {
+			return nil, err
+		}
+	} else {
+		return nil, err
+	}
+
+	return &KeySignerPV{
+		signer:        signer,
+		pubKey:        pubKey,
+		metrics:       metrics,
+		lastSignState: lastSignState,
+	}, nil
+}
+
+// GetPubKey returns the public key of the validator.
+// Implements PrivValidator.
+func (pv *KeySignerPV) GetPubKey(ctx context.Context) (crypto.PubKey, error) {
+	return pv.pubKey, nil
+}
+
+// GetLastSignState returns the height, round and step of the last signature.
+// Implements LastSignStateGetter.
+func (pv *KeySignerPV) GetLastSignState(ctx context.Context) (SignState, error) {
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
+	return pv.lastSignState.SignState(), nil
+}
+
+// SignVote signs a canonical representation of the vote, along with the
+// chainID. Implements PrivValidator.
+func (pv *KeySignerPV) SignVote(ctx context.Context, chainID string, vote *tmproto.Vote) error {
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
+
+	msgType := "vote"
+	if err := pv.lastSignState.signVote(chainID, vote, pv.signFunc(ctx, msgType)); err != nil {
+		pv.metrics.SignErrors.With("msg_type", msgType).Add(1)
+		return fmt.Errorf("error signing vote: %w", err)
+	}
+	return nil
+}
+
+// SignProposal signs a canonical representation of the proposal, along with
+// the chainID. Implements PrivValidator.
+func (pv *KeySignerPV) SignProposal(ctx context.Context, chainID string, proposal *tmproto.Proposal) error {
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
+
+	msgType := "proposal"
+	if err := pv.lastSignState.signProposal(chainID, proposal, pv.signFunc(ctx, msgType)); err != nil {
+		pv.metrics.SignErrors.With("msg_type", msgType).Add(1)
+		return fmt.Errorf("error signing proposal: %w", err)
+	}
+	return nil
+}
+
+// SignNodeValidatorProof signs a proof that the node with the given ID is
+// operated by this validator. Implements types.NodeValidatorProofSigner.
+func (pv *KeySignerPV) SignNodeValidatorProof(
+	ctx context.Context,
+	chainID string,
+	nodeID types.NodeID,
+) (*types.NodeValidatorProof, error) {
+	sig, err := pv.signer.Sign(ctx, types.NodeValidatorProofSignBytes(chainID, nodeID))
+	if err != nil {
+		return nil, err
+	}
+	return &types.NodeValidatorProof{PubKey: pv.pubKey, Signature: sig}, nil
+}
+
+// String returns a string representation of the KeySignerPV.
+func (pv *KeySignerPV) String() string {
+	return fmt.Sprintf("KeySignerPV{%v LH:%v, LR:%v, LS:%v}",
+		pv.pubKey.Address(), pv.lastSignState.Height, pv.lastSignState.Round, pv.lastSignState.Step)
+}
+
+// signFunc returns a function which signs using the underlying signer and
+// records the latency of each request.
+func (pv *KeySignerPV) signFunc(ctx context.Context, msgType string) func([]byte) ([]byte, error) {
+	return func(msg []byte) ([]byte, error) {
+		start := time.Now()
+		sig, err := pv.signer.Sign(ctx, msg)
+		pv.metrics.SignLatency.With("msg_type", msgType).Observe(time.Since(start).Seconds())
+		return sig, err
+	}
+}
@@ -0,0 +1,130 @@
+package privval
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// memKeySigner is a KeySigner which keeps its key in memory.
+type memKeySigner struct {
+	privKey crypto.PrivKey
+	signs   int
+	err     error
+}
+
+func (s *memKeySigner) PubKey(ctx context.Context) (crypto.PubKey, error) {
+	return s.privKey.PubKey(), nil
+}
+
+func (s *memKeySigner) Sign(ctx context.Context, msg []byte) ([]byte, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.signs++
+	return s.privKey.Sign(msg)
+}
+
+func newTestKeySignerPV(t *testing.T, signer KeySigner, stateFile string) *KeySignerPV {
+	t.Helper()
+	pv, err := NewKeySignerPV(context.Background(), signer, stateFile, NopMetrics())
+	require.NoError(t, err)
+	return pv
+}
+
+func TestKeySignerPVSignVote(t *testing.T) {
+	ctx := context.Background()
+	signer := &memKeySigner{privKey: ed25519.GenPrivKey()}
+	stateFile := filepath.Join(t.TempDir(), "priv_validator_state.json")
+	pv := newTestKeySignerPV(t, signer, stateFile)
+
+	pubKey, err := pv.GetPubKey(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, signer.privKey.PubKey(), pubKey)
+
+	randbytes := tmrand.Bytes(tmhash.Size)
+	randbytes2 := tmrand.Bytes(tmhash.Size)
+	block1 := types.BlockID{Hash: randbytes,
+		PartSetHeader: types.PartSetHeader{Total: 5, Hash: randbytes}}
+	block2 := types.BlockID{Hash: randbytes2,
+		PartSetHeader: types.PartSetHeader{Total: 10, Hash: randbytes2}}
+
+	height, round := int64(10), int32(1)
+	voteType := tmproto.PrevoteType
+
+	// sign a vote for first time
+	v := newVote(pubKey.Address(), 0, height, round, voteType, block1).ToProto()
+	require.NoError(t, pv.SignVote(ctx, "mychainid", v))
+	assert.True(t, pubKey.VerifySignature(types.VoteSignBytes("mychainid", v), v.Signature))
+	assert.Equal(t, 1, signer.signs)
+
+	// try to sign the same vote again; should be fine
+	require.NoError(t, pv.SignVote(ctx, "mychainid", v))
+	signs := signer.signs
+
+	// conflicting votes are rejected without reaching the signer
+	cases := []*types.Vote{
+		newVote(pubKey.Address(), 0, height, round-1, voteType, block1), // round regression
+		newVote(pubKey.Address(), 0, height-1, round, voteType, block1), // height regression
+		newVote(pubKey.Address(), 0, height, round, voteType, block2),   // different block
+	}
+	for _, c := range cases {
+		assert.Error(t, pv.SignVote(ctx, "mychainid", c.ToProto()))
+	}
+	assert.Equal(t, signs, signer.signs)
+
+	// the last sign state survives a restart
+	pv = newTestKeySignerPV(t, signer, stateFile)
+	assert.Error(t, pv.SignVote(ctx, "mychainid", cases[2].ToProto()))
+	assert.Equal(t, height, pv.lastSignState.Height)
+}
+
+func TestKeySignerPVSignProposal(t *testing.T) {
+	ctx := context.Background()
+	signer := &memKeySigner{privKey: ed25519.GenPrivKey()}
+	pv := newTestKeySignerPV(t, signer, filepath.Join(t.TempDir(), "priv_validator_state.json"))
+
+	randbytes := tmrand.Bytes(tmhash.Size)
+	block1 := types.BlockID{Hash: randbytes,
+		PartSetHeader: types.PartSetHeader{Total: 5, Hash: randbytes}}
+	height, round := int64(10), int32(1)
+
+	proposal := newProposal(height, round, block1).ToProto()
+	require.NoError(t, pv.SignProposal(ctx, "mychainid", proposal))
+	pubKey, err := pv.GetPubKey(ctx)
+	require.NoError(t, err)
+	assert.True(t, pubKey.VerifySignature(types.ProposalSignBytes("mychainid", proposal), proposal.Signature))
+
+	// height regression
+	assert.Error(t, pv.SignProposal(ctx, "mychainid", newProposal(height-1, round, block1).ToProto()))
+}
+
+func TestKeySignerPVSignError(t *testing.T) {
+	ctx := context.Background()
+	signer := &memKeySigner{privKey: ed25519.GenPrivKey(), err: errors.New("hsm unavailable")}
+	pv := newTestKeySignerPV(t, signer, filepath.Join(t.TempDir(), "priv_validator_state.json"))
+
+	randbytes := tmrand.Bytes(tmhash.Size)
+	block1 := types.BlockID{Hash: randbytes,
+		PartSetHeader: types.PartSetHeader{Total: 5, Hash: randbytes}}
+
+	v := newVote(nil, 0, 10, 1, tmproto.PrevoteType, block1).ToProto()
+	require.Error(t, pv.SignVote(ctx, "mychainid", v))
+
+	// a failed signature must not advance the last sign state
+	assert.Equal(t, int64(0), pv.lastSignState.Height)
+	signer.err = nil
+	require.NoError(t, pv.SignVote(ctx, "mychainid", v))
+	assert.Equal(t, int64(10), pv.lastSignState.Height)
+}
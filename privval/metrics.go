@@ -0,0 +1,77 @@
+package privval
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "privval"
+)
+
+// Metrics contains the prometheus metrics exposed by the privval package.
+type Metrics struct {
+	// Time spent signing a message, labeled by message type.
+	SignLatency metrics.Histogram
+	// Number of failed sign requests, labeled by message type.
+	SignErrors metrics.Counter
+	// Whether each remote signer failed over between is healthy (1) or not
+	// (0), labeled by address.
+	RemoteSignerHealthy metrics.Gauge
+	// Number of failovers from a remote signer to another.
+	RemoteSignerFailovers metrics.Counter
+}
+
+// PrometheusMetrics constructs a Metrics instance that collects metrics samples.
+// The resulting metrics will be prefixed with namespace and labeled with the
+// defaultLabelsAndValues. defaultLabelsAndValues must be a list of string pairs
+// where the first of each pair is the label and the second is the value.
+func PrometheusMetrics(namespace string, defaultLabelsAndValues ...string) *Metrics {
+	defaultLabels := []string{}
+	for i := 0; i < len(defaultLabelsAndValues); i += 2 {
+		defaultLabels = append(defaultLabels, defaultLabelsAndValues[i])
+	}
+	return &Metrics{
+		SignLatency: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "sign_latency_seconds",
+			Help:      "Time spent signing a message, in seconds.",
+			Buckets:   []float64{.0005, .001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, append(defaultLabels, "msg_type")).With(defaultLabelsAndValues...),
+		SignErrors: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "sign_errors",
+			Help:      "Number of failed sign requests.",
+		}, append(defaultLabels, "msg_type")).With(defaultLabelsAndValues...),
+		RemoteSignerHealthy: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "remote_signer_healthy",
+			Help:      "Whether each remote signer failed over between is healthy.",
+		}, append(defaultLabels, "signer")).With(defaultLabelsAndValues...),
+		RemoteSignerFailovers: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "remote_signer_failovers",
+			Help:      "Number of failovers from a remote signer to another.",
+		}, defaultLabels).With(defaultLabelsAndValues...),
+	}
+}
+
+// NopMetrics constructs a Metrics instance that discards all samples and is suitable
+// for testing.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		SignLatency: discard.NewHistogram(),
+		SignErrors:  discard.NewCounter(),
+
+		RemoteSignerHealthy:   discard.NewGauge(),
+		RemoteSignerFailovers: discard.NewCounter(),
+	}
+}
@@ -32,6 +32,14 @@ func mustWrapMsg(pb proto.Message) privvalproto.Message {
 		msg.Sum = &privvalproto.Message_PingRequest{PingRequest: pb}
 	case *privvalproto.PingResponse:
 		msg.Sum = &privvalproto.Message_PingResponse{PingResponse: pb}
+	case *privvalproto.LastSignStateRequest:
+		msg.Sum = &privvalproto.Message_LastSignStateRequest{LastSignStateRequest: pb}
+	case *privvalproto.LastSignStateResponse:
+		msg.Sum = &privvalproto.Message_LastSignStateResponse{LastSignStateResponse: pb}
+	case *privvalproto.SignNodeValidatorProofRequest:
+		msg.Sum = &privvalproto.Message_SignNodeValidatorProofRequest{SignNodeValidatorProofRequest: pb}
+	case *privvalproto.SignedNodeValidatorProofResponse:
+		msg.Sum = &privvalproto.Message_SignedNodeValidatorProofResponse{SignedNodeValidatorProofResponse: pb}
 	default:
 		panic(fmt.Errorf("unknown message type %T", pb))
 	}
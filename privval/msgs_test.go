@@ -80,6 +80,9 @@ func TestPrivvalVectors(t *testing.T) {
 	}{
 		{"ping request", &privproto.PingRequest{}, "3a00"},
 		{"ping response", &privproto.PingResponse{}, "4200"},
+		{"last sign state request", &privproto.LastSignStateRequest{ChainId: "chain"}, "4a070a05636861696e"},
+		{"last sign state response", &privproto.LastSignStateResponse{Height: 3, Round: 2, Step: 1}, "5206080310021801"},
+		{"last sign state response with error", &privproto.LastSignStateResponse{Error: remoteError}, "521222100801120c697427732061206572726f72"},
 		{"pubKey request", &privproto.PubKeyRequest{}, "0a00"},
 		{"pubKey response", &privproto.PubKeyResponse{PubKey: ppk, Error: nil}, "12240a220a20556a436f1218d30942efe798420f51dc9b6a311b929c578257457d05c5fcf230"},
 		{"pubKey response with error", &privproto.PubKeyResponse{PubKey: cryptoproto.PublicKey{}, Error: remoteError}, "12140a0012100801120c697427732061206572726f72"},
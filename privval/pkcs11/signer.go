@@ -0,0 +1,34 @@
+// Package pkcs11 implements a privval.KeySigner backed by a PKCS#11 token,
+// such as a hardware security module.
+//
+// PKCS#11 support requires cgo and is only compiled in when building with the
+// pkcs11 build tag.
+package pkcs11
+
+import (
+	"io"
+
+	"github.com/tendermint/tendermint/privval"
+)
+
+// Config describes how to locate the signing key on a PKCS#11 token.
+type Config struct {
+	// Path to the PKCS#11 module (shared library) provided by the HSM vendor.
+	Module string
+	// Label of the token holding the key.
+	TokenLabel string
+	// Label of the key pair. Both the private and public key objects must
+	// carry this label.
+	KeyLabel string
+	// User PIN used to log in to the token.
+	PIN string
+	// Type of the key: "ed25519" or "secp256k1".
+	KeyType string
+}
+
+// Signer is a privval.KeySigner which holds an open session with a PKCS#11
+// token. It must be closed to release the session.
+type Signer interface {
+	privval.KeySigner
+	io.Closer
+}
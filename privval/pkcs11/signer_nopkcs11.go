@@ -0,0 +1,11 @@
+//go:build !pkcs11
+// +build !pkcs11
+
+package pkcs11
+
+import "errors"
+
+// NewSigner returns an error, as PKCS#11 support was not compiled in.
+func NewSigner(cfg Config) (Signer, error) {
+	return nil, errors.New("PKCS#11 support is not enabled; rebuild with the pkcs11 build tag")
+}
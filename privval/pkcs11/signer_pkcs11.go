@@ -0,0 +1,237 @@
+//go:build pkcs11
+// +build pkcs11
+
+package pkcs11
+
+import (
+	"context"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/miekg/pkcs11"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+// ckmEdDSA is the PKCS#11 v3.0 EdDSA mechanism, which is not yet defined by
+// the bindings.
+const ckmEdDSA = 0x00001057
+
+var secp256k1halfN = new(big.Int).Rsh(btcec.S256().N, 1)
+
+type signer struct {
+	keyType string
+	pubKey  crypto.PubKey
+
+	// PKCS#11 sessions must not be used concurrently.
+	mtx     sync.Mutex
+	ctx     *pkcs11.Ctx
+	session pkcs11.SessionHandle
+	privKey pkcs11.ObjectHandle
+}
+
+var _ Signer = (*signer)(nil)
+
+// NewSigner loads the PKCS#11 module, opens a session with the token labeled
+// cfg.TokenLabel and looks up the key pair labeled cfg.KeyLabel.
+func NewSigner(cfg Config) (Signer, error) {
+	switch cfg.KeyType {
+	case ed25519.KeyType, secp256k1.KeyType:
+	default:
+		return nil, fmt.Errorf("unsupported PKCS#11 key type %q", cfg.KeyType)
+	}
+
+	p := pkcs11.New(cfg.Module)
+	if p == nil {
+		return nil, fmt.Errorf("failed to load PKCS#11 module %s", cfg.Module)
+	}
+	if err := p.Initialize(); err != nil {
+		p.Destroy()
+		return nil, fmt.Errorf("failed to initialize PKCS#11 module: %w", err)
+	}
+
+	s := &signer{keyType: cfg.KeyType, ctx: p}
+	if err := s.open(cfg); err != nil {
+		p.Finalize() // nolint: errcheck
+		p.Destroy()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *signer) open(cfg Config) error {
+	slot, err := s.findSlot(cfg.TokenLabel)
+	if err != nil {
+		return err
+	}
+
+	s.session, err = s.ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION)
+	if err != nil {
+		return fmt.Errorf("failed to open PKCS#11 session: %w", err)
+	}
+	if err := s.ctx.Login(s.session, pkcs11.CKU_USER, cfg.PIN); err != nil {
+		s.ctx.CloseSession(s.session) // nolint: errcheck
+		return fmt.Errorf("failed to log in to token %q: %w", cfg.TokenLabel, err)
+	}
+
+	if err := s.loadKeys(cfg.KeyLabel); err != nil {
+		s.ctx.Logout(s.session)       // nolint: errcheck
+		s.ctx.CloseSession(s.session) // nolint: errcheck
+		return err
+	}
+	return nil
+}
+
+func (s *signer) findSlot(tokenLabel string) (uint, error) {
+	slots, err := s.ctx.GetSlotList(true)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list PKCS#11 slots: %w", err)
+	}
+	for _, slot := range slots {
+		info, err := s.ctx.GetTokenInfo(slot)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get PKCS#11 token info: %w", err)
+		}
+		if strings.TrimSpace(info.Label) == tokenLabel {
+			return slot, nil
+		}
+	}
+	return 0, fmt.Errorf("PKCS#11 token %q not found", tokenLabel)
+}
+
+func (s *signer) loadKeys(keyLabel string) error {
+	var err error
+	s.privKey, err = s.findObject(pkcs11.CKO_PRIVATE_KEY, keyLabel)
+	if err != nil {
+		return err
+	}
+	pubKey, err := s.findObject(pkcs11.CKO_PUBLIC_KEY, keyLabel)
+	if err != nil {
+		return err
+	}
+
+	attrs, err := s.ctx.GetAttributeValue(s.session, pubKey, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, nil),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read public key %q: %w", keyLabel, err)
+	}
+	point := attrs[0].Value
+	// Most tokens return the point DER-encoded as an OCTET STRING.
+	var raw []byte
+	if rest, err := asn1.Unmarshal(point, &raw); err == nil && len(rest) == 0 {
+		point = raw
+	}
+
+	switch s.keyType {
+	case ed25519.KeyType:
+		if len(point) != ed25519.PubKeySize {
+			return fmt.Errorf("invalid ed25519 public key length %d", len(point))
+		}
+		s.pubKey = ed25519.PubKey(point)
+	case secp256k1.KeyType:
+		pk, err := btcec.ParsePubKey(point, btcec.S256())
+		if err != nil {
+			return fmt.Errorf("invalid secp256k1 public key: %w", err)
+		}
+		s.pubKey = secp256k1.PubKey(pk.SerializeCompressed())
+	}
+	return nil
+}
+
+func (s *signer) findObject(class uint, label string) (pkcs11.ObjectHandle, error) {
+	template := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, class),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+	}
+	if err := s.ctx.FindObjectsInit(s.session, template); err != nil {
+		return 0, err
+	}
+	objs, _, err := s.ctx.FindObjects(s.session, 2)
+	if ferr := s.ctx.FindObjectsFinal(s.session); err == nil {
+		err = ferr
+	}
+	if err != nil {
+		return 0, err
+	}
+	switch len(objs) {
+	case 0:
+		return 0, fmt.Errorf("PKCS#11 key %q not found", label)
+	case 1:
+		return objs[0], nil
+	default:
+		return 0, fmt.Errorf("multiple PKCS#11 keys labeled %q", label)
+	}
+}
+
+// PubKey implements privval.KeySigner.
+func (s *signer) PubKey(ctx context.Context) (crypto.PubKey, error) {
+	return s.pubKey, nil
+}
+
+// Sign implements privval.KeySigner. ed25519 keys sign msg using EdDSA,
+// secp256k1 keys sign the SHA256 hash of msg using ECDSA and return the
+// signature in lower-S form.
+func (s *signer) Sign(ctx context.Context, msg []byte) ([]byte, error) {
+	mech := uint(ckmEdDSA)
+	if s.keyType == secp256k1.KeyType {
+		mech = pkcs11.CKM_ECDSA
+		msg = crypto.Sha256(msg)
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if err := s.ctx.SignInit(s.session, []*pkcs11.Mechanism{pkcs11.NewMechanism(mech, nil)}, s.privKey); err != nil {
+		return nil, fmt.Errorf("failed to initialize PKCS#11 signature: %w", err)
+	}
+	sig, err := s.ctx.Sign(s.session, msg)
+	if err != nil {
+		return nil, fmt.Errorf("PKCS#11 signature failed: %w", err)
+	}
+
+	if s.keyType == secp256k1.KeyType {
+		return normalizeECDSA(sig)
+	}
+	return sig, nil
+}
+
+// normalizeECDSA converts an R || S signature to lower-S form, which is the
+// only form accepted by secp256k1.PubKey.VerifySignature.
+func normalizeECDSA(sig []byte) ([]byte, error) {
+	if len(sig) != 64 {
+		return nil, errors.New("invalid ECDSA signature length")
+	}
+	sv := new(big.Int).SetBytes(sig[32:])
+	if sv.Cmp(secp256k1halfN) > 0 {
+		sv.Sub(btcec.S256().N, sv)
+		out := make([]byte, 64)
+		copy(out, sig[:32])
+		sv.FillBytes(out[32:])
+		return out, nil
+	}
+	return sig, nil
+}
+
+// Close logs out of the token and releases the PKCS#11 module.
+func (s *signer) Close() error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	err := s.ctx.Logout(s.session)
+	if cerr := s.ctx.CloseSession(s.session); err == nil {
+		err = cerr
+	}
+	if ferr := s.ctx.Finalize(); err == nil {
+		err = ferr
+	}
+	s.ctx.Destroy()
+	return err
+}
@@ -25,6 +25,8 @@ func NewRetrySignerClient(sc *SignerClient, retries int, timeout time.Duration)
 }
 
 var _ types.PrivValidator = (*RetrySignerClient)(nil)
+var _ types.NodeValidatorProofSigner = (*RetrySignerClient)(nil)
+var _ RemoteSigner = (*RetrySignerClient)(nil)
 
 func (sc *RetrySignerClient) Close() error {
 	return sc.next.Close()
@@ -71,6 +73,62 @@ func (sc *RetrySignerClient) GetPubKey(ctx context.Context) (crypto.PubKey, erro
 	return nil, fmt.Errorf("exhausted all attempts to get pubkey: %w", err)
 }
 
+func (sc *RetrySignerClient) GetLastSignState(ctx context.Context) (SignState, error) {
+	var (
+		ss  SignState
+		err error
+	)
+
+	t := time.NewTimer(sc.timeout)
+	for i := 0; i < sc.retries || sc.retries == 0; i++ {
+		ss, err = sc.next.GetLastSignState(ctx)
+		if err == nil {
+			return ss, nil
+		}
+		// If remote signer errors, we don't retry.
+		if _, ok := err.(*RemoteSignerError); ok {
+			return SignState{}, err
+		}
+		select {
+		case <-ctx.Done():
+			return SignState{}, ctx.Err()
+		case <-t.C:
+			t.Reset(sc.timeout)
+		}
+	}
+	return SignState{}, fmt.Errorf("exhausted all attempts to get last sign state: %w", err)
+}
+
+func (sc *RetrySignerClient) SignNodeValidatorProof(
+	ctx context.Context,
+	chainID string,
+	nodeID types.NodeID,
+) (*types.NodeValidatorProof, error) {
+	var (
+		proof *types.NodeValidatorProof
+		err   error
+	)
+
+	t := time.NewTimer(sc.timeout)
+	for i := 0; i < sc.retries || sc.retries == 0; i++ {
+		proof, err = sc.next.SignNodeValidatorProof(ctx, chainID, nodeID)
+		if err == nil {
+			return proof, nil
+		}
+		// If remote signer errors, we don't retry.
+		if _, ok := err.(*RemoteSignerError); ok {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-t.C:
+			t.Reset(sc.timeout)
+		}
+	}
+	return nil, fmt.Errorf("exhausted all attempts to sign node validator proof: %w", err)
+}
+
 func (sc *RetrySignerClient) SignVote(ctx context.Context, chainID string, vote *tmproto.Vote) error {
 	var err error
 	for i := 0; i < sc.retries || sc.retries == 0; i++ {
@@ -0,0 +1,36 @@
+package privval
+
+import (
+	"context"
+	"fmt"
+)
+
+// SignState is the height, round and step of a signature, by which private
+// validators protect against double signing.
+type SignState struct {
+	Height int64
+	Round  int32
+	Step   int8
+}
+
+// Before returns true if ss is at an earlier height, round or step than other.
+func (ss SignState) Before(other SignState) bool {
+	if ss.Height != other.Height {
+		return ss.Height < other.Height
+	}
+	if ss.Round != other.Round {
+		return ss.Round < other.Round
+	}
+	return ss.Step < other.Step
+}
+
+func (ss SignState) String() string {
+	return fmt.Sprintf("%d/%d/%d", ss.Height, ss.Round, ss.Step)
+}
+
+// LastSignStateGetter is implemented by the private validators which keep the
+// sign state of their last signature, as FilePV does, so that remote signers
+// can report it.
+type LastSignStateGetter interface {
+	GetLastSignState(ctx context.Context) (SignState, error)
+}
@@ -22,6 +22,8 @@ type SignerClient struct {
 }
 
 var _ types.PrivValidator = (*SignerClient)(nil)
+var _ types.NodeValidatorProofSigner = (*SignerClient)(nil)
+var _ RemoteSigner = (*SignerClient)(nil)
 
 // NewSignerClient returns an instance of SignerClient.
 // it will start the endpoint (if not already started)
@@ -62,17 +64,36 @@ func (sc *SignerClient) Ping() error {
 	response, err := sc.endpoint.SendRequest(mustWrapMsg(&privvalproto.PingRequest{}))
 	if err != nil {
 		sc.logger.Error("SignerClient::Ping", "err", err)
-		return nil
+		return err
 	}
 
 	pb := response.GetPingResponse()
 	if pb == nil {
-		return err
+		return ErrUnexpectedResponse
 	}
 
 	return nil
 }
 
+// GetLastSignState retrieves the sign state of the last signature of the
+// remote signer
+func (sc *SignerClient) GetLastSignState(ctx context.Context) (SignState, error) {
+	response, err := sc.endpoint.SendRequest(mustWrapMsg(&privvalproto.LastSignStateRequest{ChainId: sc.chainID}))
+	if err != nil {
+		return SignState{}, fmt.Errorf("send: %w", err)
+	}
+
+	resp := response.GetLastSignStateResponse()
+	if resp == nil {
+		return SignState{}, ErrUnexpectedResponse
+	}
+	if resp.Error != nil {
+		return SignState{}, &RemoteSignerError{Code: int(resp.Error.Code), Description: resp.Error.Description}
+	}
+
+	return SignState{Height: resp.Height, Round: resp.Round, Step: int8(resp.Step)}, nil
+}
+
 // GetPubKey retrieves a public key from a remote signer
 // returns an error if client is not able to provide the key
 func (sc *SignerClient) GetPubKey(ctx context.Context) (crypto.PubKey, error) {
@@ -138,3 +159,33 @@ func (sc *SignerClient) SignProposal(ctx context.Context, chainID string, propos
 
 	return nil
 }
+
+// SignNodeValidatorProof requests a remote signer to sign a proof that the
+// node with the given ID is operated by its validator
+func (sc *SignerClient) SignNodeValidatorProof(
+	ctx context.Context,
+	chainID string,
+	nodeID types.NodeID,
+) (*types.NodeValidatorProof, error) {
+	response, err := sc.endpoint.SendRequest(mustWrapMsg(
+		&privvalproto.SignNodeValidatorProofRequest{NodeId: string(nodeID), ChainId: chainID},
+	))
+	if err != nil {
+		return nil, fmt.Errorf("send: %w", err)
+	}
+
+	resp := response.GetSignedNodeValidatorProofResponse()
+	if resp == nil {
+		return nil, ErrUnexpectedResponse
+	}
+	if resp.Error != nil {
+		return nil, &RemoteSignerError{Code: int(resp.Error.Code), Description: resp.Error.Description}
+	}
+
+	pk, err := encoding.PubKeyFromProto(resp.PubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.NodeValidatorProof{PubKey: pk, Signature: resp.Signature}, nil
+}
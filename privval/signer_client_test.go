@@ -3,6 +3,8 @@ package privval
 import (
 	"context"
 	"fmt"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -109,6 +111,65 @@ func TestSignerGetPubKey(t *testing.T) {
 	}
 }
 
+func TestSignerGetLastSignState(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for _, tc := range getSignerTestCases(ctx, t) {
+		t.Run(tc.name, func(t *testing.T) {
+			defer tc.closer()
+
+			// the mock private validator doesn't keep its last sign state
+			_, err := tc.signerClient.GetLastSignState(ctx)
+			var signerErr *RemoteSignerError
+			require.ErrorAs(t, err, &signerErr)
+
+			dir := t.TempDir()
+			filePV, err := GenFilePV(filepath.Join(dir, "key.json"), filepath.Join(dir, "state.json"), "")
+			require.NoError(t, err)
+			tc.signerServer.SetRequestHandler(func(
+				ctx context.Context,
+				_ types.PrivValidator,
+				req privvalproto.Message,
+				chainID string,
+			) (privvalproto.Message, error) {
+				return DefaultValidationRequestHandler(ctx, filePV, req, chainID)
+			})
+
+			vote := &tmproto.Vote{Type: tmproto.PrecommitType, Height: 5, Round: 2}
+			require.NoError(t, tc.signerClient.SignVote(ctx, tc.chainID, vote))
+			ss, err := tc.signerClient.GetLastSignState(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, SignState{Height: 5, Round: 2, Step: stepPrecommit}, ss)
+		})
+	}
+}
+
+func TestSignerSignNodeValidatorProof(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for _, tc := range getSignerTestCases(ctx, t) {
+		t.Run(tc.name, func(t *testing.T) {
+			defer tc.closer()
+
+			nodeID := types.NodeID(strings.Repeat("ab", 20))
+			proof, err := tc.signerClient.SignNodeValidatorProof(ctx, tc.chainID, nodeID)
+			require.NoError(t, err)
+			assert.NoError(t, proof.Verify(tc.chainID, nodeID))
+
+			pubKey, err := tc.mockPV.GetPubKey(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, pubKey, proof.PubKey)
+
+			// the signer doesn't sign proofs for another chain
+			_, err = tc.signerClient.SignNodeValidatorProof(ctx, "other-chain", nodeID)
+			var signerErr *RemoteSignerError
+			require.ErrorAs(t, err, &signerErr)
+		})
+	}
+}
+
 func TestSignerProposal(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -88,6 +88,63 @@ func DefaultValidationRequestHandler(
 	case *privvalproto.Message_PingRequest:
 		err, res = nil, mustWrapMsg(&privvalproto.PingResponse{})
 
+	case *privvalproto.Message_LastSignStateRequest:
+		if r.LastSignStateRequest.GetChainId() != chainID {
+			res = mustWrapMsg(&privvalproto.LastSignStateResponse{
+				Error: &privvalproto.RemoteSignerError{
+					Code: 0, Description: "unable to provide last sign state"}})
+			return res, fmt.Errorf("want chainID: %s, got chainID: %s", r.LastSignStateRequest.GetChainId(), chainID)
+		}
+
+		getter, ok := privVal.(LastSignStateGetter)
+		if !ok {
+			res = mustWrapMsg(&privvalproto.LastSignStateResponse{
+				Error: &privvalproto.RemoteSignerError{
+					Code: 0, Description: "last sign state not kept by the private validator"}})
+			return res, nil
+		}
+
+		var ss SignState
+		ss, err = getter.GetLastSignState(ctx)
+		if err != nil {
+			res = mustWrapMsg(&privvalproto.LastSignStateResponse{
+				Error: &privvalproto.RemoteSignerError{Code: 0, Description: err.Error()}})
+		} else {
+			res = mustWrapMsg(&privvalproto.LastSignStateResponse{
+				Height: ss.Height, Round: ss.Round, Step: int32(ss.Step)})
+		}
+
+	case *privvalproto.Message_SignNodeValidatorProofRequest:
+		if r.SignNodeValidatorProofRequest.GetChainId() != chainID {
+			res = mustWrapMsg(&privvalproto.SignedNodeValidatorProofResponse{
+				Error: &privvalproto.RemoteSignerError{
+					Code: 0, Description: "unable to sign node validator proof"}})
+			return res, fmt.Errorf("want chainID: %s, got chainID: %s",
+				r.SignNodeValidatorProofRequest.GetChainId(), chainID)
+		}
+
+		signer, ok := privVal.(types.NodeValidatorProofSigner)
+		if !ok {
+			res = mustWrapMsg(&privvalproto.SignedNodeValidatorProofResponse{
+				Error: &privvalproto.RemoteSignerError{
+					Code: 0, Description: "node validator proofs not supported by the private validator"}})
+			return res, nil
+		}
+
+		var proof *types.NodeValidatorProof
+		nodeID := types.NodeID(r.SignNodeValidatorProofRequest.GetNodeId())
+		proof, err = signer.SignNodeValidatorProof(ctx, chainID, nodeID)
+		if err != nil {
+			res = mustWrapMsg(&privvalproto.SignedNodeValidatorProofResponse{
+				Error: &privvalproto.RemoteSignerError{Code: 0, Description: err.Error()}})
+			break
+		}
+		pk, err := encoding.PubKeyToProto(proof.PubKey)
+		if err != nil {
+			return res, err
+		}
+		res = mustWrapMsg(&privvalproto.SignedNodeValidatorProofResponse{PubKey: pk, Signature: proof.Signature})
+
 	default:
 		err = fmt.Errorf("unknown msg: %v", r)
 	}
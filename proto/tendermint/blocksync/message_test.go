@@ -125,6 +125,35 @@ func TestBlockchainMessageVectors(t *testing.T) {
 			bz, err := proto.Marshal(tc.bmsg)
 			require.NoError(t, err)
 			require.Equal(t, tc.expBytes, hex.EncodeToString(bz))
+
+			// decoding without copying yields the same message
+			var expected, actual bcproto.Message
+			require.NoError(t, proto.Unmarshal(bz, &expected))
+			require.NoError(t, actual.UnmarshalZeroCopy(bz))
+			require.Equal(t, expected, actual)
 		})
 	}
 }
+
+func TestBlockResponseUnmarshalZeroCopy(t *testing.T) {
+	block := types.MakeBlock(int64(3), []types.Tx{types.Tx("Hello World")}, nil, nil)
+	bpb, err := block.ToProto()
+	require.NoError(t, err)
+	bz, err := proto.Marshal(&bcproto.Message{Sum: &bcproto.Message_BlockResponse{
+		BlockResponse: &bcproto.BlockResponse{Block: bpb}}})
+	require.NoError(t, err)
+
+	var msg bcproto.Message
+	require.NoError(t, msg.UnmarshalZeroCopy(bz))
+	tx := msg.GetBlockResponse().Block.Data.Txs[0]
+	require.Equal(t, []byte("Hello World"), tx)
+
+	// the transactions alias the encoded message
+	for i := range bz {
+		bz[i] = 0
+	}
+	require.Equal(t, make([]byte, len(tx)), tx)
+
+	// malformed messages are rejected
+	require.Error(t, msg.UnmarshalZeroCopy([]byte{0x1a, 0x70}))
+}
@@ -0,0 +1,41 @@
+package blocksync
+
+import (
+	"github.com/tendermint/tendermint/internal/libs/protoio"
+	"github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+// UnmarshalZeroCopy unmarshals the message like proto.Unmarshal. The
+// transactions of block responses are decoded aliasing b, which must not be
+// modified afterwards.
+func (m *Message) UnmarshalZeroCopy(b []byte) error {
+	*m = Message{}
+	err := protoio.ReadWireFields(b, func(f protoio.WireField) error {
+		if f.Num != 3 || f.WireType != protoio.WireBytes {
+			return types.ErrZeroCopyField
+		}
+		msg := &BlockResponse{}
+		if err := msg.unmarshalZeroCopy(f.Bytes); err != nil {
+			return err
+		}
+		m.Sum = &Message_BlockResponse{BlockResponse: msg}
+		return nil
+	})
+	if err != nil {
+		// other messages are decoded, and malformed ones reported, by the
+		// generated decoder
+		*m = Message{}
+		return m.Unmarshal(b)
+	}
+	return nil
+}
+
+func (m *BlockResponse) unmarshalZeroCopy(b []byte) error {
+	return protoio.ReadWireFields(b, func(f protoio.WireField) error {
+		if f.Num != 1 || f.WireType != protoio.WireBytes {
+			return types.ErrZeroCopyField
+		}
+		m.Block = &types.Block{}
+		return m.Block.UnmarshalZeroCopy(f.Bytes)
+	})
+}
@@ -36,6 +36,18 @@ func (m *Message) Wrap(pb proto.Message) error {
 	case *VoteSetBits:
 		m.Sum = &Message_VoteSetBits{VoteSetBits: msg}
 
+	case *CompactBlock:
+		m.Sum = &Message_CompactBlock{CompactBlock: msg}
+
+	case *CompactBlockTxsRequest:
+		m.Sum = &Message_CompactBlockTxsRequest{CompactBlockTxsRequest: msg}
+
+	case *CompactBlockTxs:
+		m.Sum = &Message_CompactBlockTxs{CompactBlockTxs: msg}
+
+	case *VoteBatch:
+		m.Sum = &Message_VoteBatch{VoteBatch: msg}
+
 	default:
 		return fmt.Errorf("unknown message: %T", msg)
 	}
@@ -74,6 +86,18 @@ func (m *Message) Unwrap() (proto.Message, error) {
 	case *Message_VoteSetBits:
 		return m.GetVoteSetBits(), nil
 
+	case *Message_CompactBlock:
+		return m.GetCompactBlock(), nil
+
+	case *Message_CompactBlockTxsRequest:
+		return m.GetCompactBlockTxsRequest(), nil
+
+	case *Message_CompactBlockTxs:
+		return m.GetCompactBlockTxs(), nil
+
+	case *Message_VoteBatch:
+		return m.GetVoteBatch(), nil
+
 	default:
 		return nil, fmt.Errorf("unknown message: %T", msg)
 	}
@@ -4,11 +4,13 @@ import (
 	"encoding/hex"
 	"math"
 	"testing"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/require"
 
 	tmcons "github.com/tendermint/tendermint/proto/tendermint/consensus"
+	"github.com/tendermint/tendermint/proto/tendermint/crypto"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 )
 
@@ -30,3 +32,103 @@ func TestHasVoteVector(t *testing.T) {
 		require.Equal(t, tc.expBytes, hex.EncodeToString(bz), "test vector failed", i)
 	}
 }
+
+func zeroCopyTestMessages() []*tmcons.Message {
+	blockID := tmproto.BlockID{
+		Hash:          []byte("block hash"),
+		PartSetHeader: tmproto.PartSetHeader{Total: 2, Hash: []byte("parts hash")},
+	}
+	return []*tmcons.Message{
+		{Sum: &tmcons.Message_BlockPart{BlockPart: &tmcons.BlockPart{
+			Height: 10,
+			Round:  -1,
+			Part: tmproto.Part{
+				Index: 1,
+				Bytes: make([]byte, 65536),
+				Proof: crypto.Proof{Total: 2, Index: 1, LeafHash: []byte("leaf"), Aunts: [][]byte{[]byte("a"), []byte("b")}},
+			},
+		}}},
+		{Sum: &tmcons.Message_Vote{Vote: &tmcons.Vote{Vote: &tmproto.Vote{
+			Type:             tmproto.PrecommitType,
+			Height:           math.MaxInt64,
+			Round:            2,
+			BlockID:          blockID,
+			Timestamp:        time.Date(2022, 1, 2, 3, 4, 5, 6, time.UTC),
+			ValidatorAddress: []byte("validator address"),
+			ValidatorIndex:   3,
+			Signature:        []byte("signature"),
+		}}}},
+		{Sum: &tmcons.Message_Vote{Vote: &tmcons.Vote{Vote: &tmproto.Vote{}}}},
+		{Sum: &tmcons.Message_HasVote{HasVote: &tmcons.HasVote{Height: 1, Round: 2, Type: tmproto.PrevoteType, Index: 3}}},
+	}
+}
+
+func TestMessageUnmarshalZeroCopy(t *testing.T) {
+	for i, msg := range zeroCopyTestMessages() {
+		bz, err := proto.Marshal(msg)
+		require.NoError(t, err)
+
+		var expected, actual tmcons.Message
+		require.NoError(t, proto.Unmarshal(bz, &expected))
+		require.NoError(t, actual.UnmarshalZeroCopy(bz), i)
+		require.Equal(t, expected, actual, i)
+
+		// a message with a field unknown to the hand-written decoder is
+		// decoded by the generated one
+		unknown := append(append([]byte{}, bz...), 0xf8, 0x01, 0x01) // field 31
+		require.NoError(t, proto.Unmarshal(unknown, &expected))
+		require.NoError(t, actual.UnmarshalZeroCopy(unknown), i)
+		require.Equal(t, expected, actual, i)
+
+		// malformed messages are rejected
+		require.Error(t, actual.UnmarshalZeroCopy(bz[:len(bz)-1]), i)
+	}
+
+	// so is a vote with an unknown field
+	voteBz, err := proto.Marshal(zeroCopyTestMessages()[1].GetVote().Vote)
+	require.NoError(t, err)
+	voteBz = append(voteBz, 0x48, 0x01) // field 9
+	wrap := func(tag byte, bz []byte) []byte {
+		return append(append([]byte{tag}, proto.EncodeVarint(uint64(len(bz)))...), bz...)
+	}
+	bz := wrap(0x32, wrap(0x0a, voteBz))
+	var expected, actual tmcons.Message
+	require.NoError(t, proto.Unmarshal(bz, &expected))
+	require.NoError(t, actual.UnmarshalZeroCopy(bz))
+	require.Equal(t, expected, actual)
+
+	// the bytes of block parts alias the encoded message
+	bz, err = proto.Marshal(zeroCopyTestMessages()[0])
+	require.NoError(t, err)
+	var msg tmcons.Message
+	require.NoError(t, msg.UnmarshalZeroCopy(bz))
+	for i := range bz {
+		bz[i] = 0xff
+	}
+	require.Equal(t, byte(0xff), msg.GetBlockPart().Part.Bytes[0])
+}
+
+func BenchmarkMessageUnmarshal(b *testing.B) {
+	msgs := zeroCopyTestMessages()[:2]
+	encoded := make([][]byte, len(msgs))
+	for i, msg := range msgs {
+		bz, err := proto.Marshal(msg)
+		require.NoError(b, err)
+		encoded[i] = bz
+	}
+
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var msg tmcons.Message
+			_ = proto.Unmarshal(encoded[i%len(encoded)], &msg)
+		}
+	})
+	b.Run("UnmarshalZeroCopy", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var msg tmcons.Message
+			_ = msg.UnmarshalZeroCopy(encoded[i%len(encoded)])
+		}
+	})
+}
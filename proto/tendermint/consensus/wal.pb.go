@@ -10,7 +10,7 @@ import (
 	_ "github.com/gogo/protobuf/types"
 	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
 	_ "github.com/golang/protobuf/ptypes/duration"
-	types "github.com/tendermint/tendermint/proto/tendermint/types"
+	types1 "github.com/tendermint/tendermint/proto/tendermint/types"
 	io "io"
 	math "math"
 	math_bits "math/bits"
@@ -197,12 +197,83 @@ func (m *EndHeight) GetHeight() int64 {
 	return 0
 }
 
+// Checkpoint marks the point inside WAL after which messages are replayed on
+// top of the consensus state checkpoint saved at the same time.
+type Checkpoint struct {
+	Height int64     `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round  int32     `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	Step   uint32    `protobuf:"varint,3,opt,name=step,proto3" json:"step,omitempty"`
+	Time   time.Time `protobuf:"bytes,4,opt,name=time,proto3,stdtime" json:"time"`
+}
+
+func (m *Checkpoint) Reset()         { *m = Checkpoint{} }
+func (m *Checkpoint) String() string { return proto.CompactTextString(m) }
+func (*Checkpoint) ProtoMessage()    {}
+func (*Checkpoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ed0b60c2d348ab09, []int{3}
+}
+func (m *Checkpoint) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Checkpoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Checkpoint.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Checkpoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Checkpoint.Merge(m, src)
+}
+func (m *Checkpoint) XXX_Size() int {
+	return m.Size()
+}
+func (m *Checkpoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_Checkpoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Checkpoint proto.InternalMessageInfo
+
+func (m *Checkpoint) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *Checkpoint) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *Checkpoint) GetStep() uint32 {
+	if m != nil {
+		return m.Step
+	}
+	return 0
+}
+
+func (m *Checkpoint) GetTime() time.Time {
+	if m != nil {
+		return m.Time
+	}
+	return time.Time{}
+}
+
 type WALMessage struct {
 	// Types that are valid to be assigned to Sum:
 	//	*WALMessage_EventDataRoundState
 	//	*WALMessage_MsgInfo
 	//	*WALMessage_TimeoutInfo
 	//	*WALMessage_EndHeight
+	//	*WALMessage_Checkpoint
 	Sum isWALMessage_Sum `protobuf_oneof:"sum"`
 }
 
@@ -210,7 +281,7 @@ func (m *WALMessage) Reset()         { *m = WALMessage{} }
 func (m *WALMessage) String() string { return proto.CompactTextString(m) }
 func (*WALMessage) ProtoMessage()    {}
 func (*WALMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ed0b60c2d348ab09, []int{3}
+	return fileDescriptor_ed0b60c2d348ab09, []int{4}
 }
 func (m *WALMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -246,7 +317,7 @@ type isWALMessage_Sum interface {
 }
 
 type WALMessage_EventDataRoundState struct {
-	EventDataRoundState *types.EventDataRoundState `protobuf:"bytes,1,opt,name=event_data_round_state,json=eventDataRoundState,proto3,oneof" json:"event_data_round_state,omitempty"`
+	EventDataRoundState *types1.EventDataRoundState `protobuf:"bytes,1,opt,name=event_data_round_state,json=eventDataRoundState,proto3,oneof" json:"event_data_round_state,omitempty"`
 }
 type WALMessage_MsgInfo struct {
 	MsgInfo *MsgInfo `protobuf:"bytes,2,opt,name=msg_info,json=msgInfo,proto3,oneof" json:"msg_info,omitempty"`
@@ -257,11 +328,15 @@ type WALMessage_TimeoutInfo struct {
 type WALMessage_EndHeight struct {
 	EndHeight *EndHeight `protobuf:"bytes,4,opt,name=end_height,json=endHeight,proto3,oneof" json:"end_height,omitempty"`
 }
+type WALMessage_Checkpoint struct {
+	Checkpoint *Checkpoint `protobuf:"bytes,5,opt,name=checkpoint,proto3,oneof" json:"checkpoint,omitempty"`
+}
 
 func (*WALMessage_EventDataRoundState) isWALMessage_Sum() {}
 func (*WALMessage_MsgInfo) isWALMessage_Sum()             {}
 func (*WALMessage_TimeoutInfo) isWALMessage_Sum()         {}
 func (*WALMessage_EndHeight) isWALMessage_Sum()           {}
+func (*WALMessage_Checkpoint) isWALMessage_Sum()          {}
 
 func (m *WALMessage) GetSum() isWALMessage_Sum {
 	if m != nil {
@@ -270,7 +345,7 @@ func (m *WALMessage) GetSum() isWALMessage_Sum {
 	return nil
 }
 
-func (m *WALMessage) GetEventDataRoundState() *types.EventDataRoundState {
+func (m *WALMessage) GetEventDataRoundState() *types1.EventDataRoundState {
 	if x, ok := m.GetSum().(*WALMessage_EventDataRoundState); ok {
 		return x.EventDataRoundState
 	}
@@ -298,6 +373,13 @@ func (m *WALMessage) GetEndHeight() *EndHeight {
 	return nil
 }
 
+func (m *WALMessage) GetCheckpoint() *Checkpoint {
+	if x, ok := m.GetSum().(*WALMessage_Checkpoint); ok {
+		return x.Checkpoint
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*WALMessage) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -305,7 +387,102 @@ func (*WALMessage) XXX_OneofWrappers() []interface{} {
 		(*WALMessage_MsgInfo)(nil),
 		(*WALMessage_TimeoutInfo)(nil),
 		(*WALMessage_EndHeight)(nil),
+		(*WALMessage_Checkpoint)(nil),
+	}
+}
+
+// ConsensusCheckpoint is the consensus state of a height saved in the state DB
+// at a checkpoint, so crash recovery only replays the WAL after the checkpoint.
+type ConsensusCheckpoint struct {
+	Checkpoint    Checkpoint       `protobuf:"bytes,1,opt,name=checkpoint,proto3" json:"checkpoint"`
+	Proposal      *types1.Proposal `protobuf:"bytes,2,opt,name=proposal,proto3" json:"proposal,omitempty"`
+	ProposalBlock *types1.Block    `protobuf:"bytes,3,opt,name=proposal_block,json=proposalBlock,proto3" json:"proposal_block,omitempty"`
+	LockedRound   int32            `protobuf:"varint,4,opt,name=locked_round,json=lockedRound,proto3" json:"locked_round,omitempty"`
+	LockedBlock   *types1.Block    `protobuf:"bytes,5,opt,name=locked_block,json=lockedBlock,proto3" json:"locked_block,omitempty"`
+	ValidRound    int32            `protobuf:"varint,6,opt,name=valid_round,json=validRound,proto3" json:"valid_round,omitempty"`
+	ValidBlock    *types1.Block    `protobuf:"bytes,7,opt,name=valid_block,json=validBlock,proto3" json:"valid_block,omitempty"`
+}
+
+func (m *ConsensusCheckpoint) Reset()         { *m = ConsensusCheckpoint{} }
+func (m *ConsensusCheckpoint) String() string { return proto.CompactTextString(m) }
+func (*ConsensusCheckpoint) ProtoMessage()    {}
+func (*ConsensusCheckpoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ed0b60c2d348ab09, []int{5}
+}
+func (m *ConsensusCheckpoint) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ConsensusCheckpoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ConsensusCheckpoint.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ConsensusCheckpoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConsensusCheckpoint.Merge(m, src)
+}
+func (m *ConsensusCheckpoint) XXX_Size() int {
+	return m.Size()
+}
+func (m *ConsensusCheckpoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConsensusCheckpoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConsensusCheckpoint proto.InternalMessageInfo
+
+func (m *ConsensusCheckpoint) GetCheckpoint() Checkpoint {
+	if m != nil {
+		return m.Checkpoint
+	}
+	return Checkpoint{}
+}
+
+func (m *ConsensusCheckpoint) GetProposal() *types1.Proposal {
+	if m != nil {
+		return m.Proposal
+	}
+	return nil
+}
+
+func (m *ConsensusCheckpoint) GetProposalBlock() *types1.Block {
+	if m != nil {
+		return m.ProposalBlock
+	}
+	return nil
+}
+
+func (m *ConsensusCheckpoint) GetLockedRound() int32 {
+	if m != nil {
+		return m.LockedRound
+	}
+	return 0
+}
+
+func (m *ConsensusCheckpoint) GetLockedBlock() *types1.Block {
+	if m != nil {
+		return m.LockedBlock
+	}
+	return nil
+}
+
+func (m *ConsensusCheckpoint) GetValidRound() int32 {
+	if m != nil {
+		return m.ValidRound
+	}
+	return 0
+}
+
+func (m *ConsensusCheckpoint) GetValidBlock() *types1.Block {
+	if m != nil {
+		return m.ValidBlock
 	}
+	return nil
 }
 
 // TimedWALMessage wraps WALMessage and adds Time for debugging purposes.
@@ -318,7 +495,7 @@ func (m *TimedWALMessage) Reset()         { *m = TimedWALMessage{} }
 func (m *TimedWALMessage) String() string { return proto.CompactTextString(m) }
 func (*TimedWALMessage) ProtoMessage()    {}
 func (*TimedWALMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ed0b60c2d348ab09, []int{4}
+	return fileDescriptor_ed0b60c2d348ab09, []int{6}
 }
 func (m *TimedWALMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -365,48 +542,61 @@ func init() {
 	proto.RegisterType((*MsgInfo)(nil), "tendermint.consensus.MsgInfo")
 	proto.RegisterType((*TimeoutInfo)(nil), "tendermint.consensus.TimeoutInfo")
 	proto.RegisterType((*EndHeight)(nil), "tendermint.consensus.EndHeight")
+	proto.RegisterType((*Checkpoint)(nil), "tendermint.consensus.Checkpoint")
 	proto.RegisterType((*WALMessage)(nil), "tendermint.consensus.WALMessage")
+	proto.RegisterType((*ConsensusCheckpoint)(nil), "tendermint.consensus.ConsensusCheckpoint")
 	proto.RegisterType((*TimedWALMessage)(nil), "tendermint.consensus.TimedWALMessage")
 }
 
 func init() { proto.RegisterFile("tendermint/consensus/wal.proto", fileDescriptor_ed0b60c2d348ab09) }
 
 var fileDescriptor_ed0b60c2d348ab09 = []byte{
-	// 539 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x53, 0xdd, 0x8a, 0xd3, 0x40,
-	0x14, 0xce, 0x6c, 0xff, 0x4f, 0x15, 0x21, 0x96, 0xa5, 0x16, 0x36, 0x8d, 0x5d, 0x84, 0x5e, 0x25,
-	0xb0, 0x22, 0x88, 0x5e, 0xa8, 0xa5, 0x2b, 0x2d, 0xb8, 0x20, 0xe3, 0x8a, 0x20, 0x42, 0x48, 0x37,
-	0xa7, 0x69, 0x60, 0x33, 0x53, 0x32, 0x13, 0xc5, 0x2b, 0x5f, 0xa1, 0x97, 0xbe, 0x89, 0xaf, 0xb0,
-	0x97, 0x7b, 0xe9, 0xd5, 0x2a, 0xed, 0x8b, 0x48, 0x66, 0xd2, 0x36, 0xb8, 0xd9, 0xbb, 0x39, 0x73,
-	0xbe, 0x73, 0xbe, 0x73, 0xbe, 0x6f, 0x06, 0x2c, 0x89, 0x2c, 0xc0, 0x24, 0x8e, 0x98, 0x74, 0x2f,
-	0x38, 0x13, 0xc8, 0x44, 0x2a, 0xdc, 0x6f, 0xfe, 0xa5, 0xb3, 0x4c, 0xb8, 0xe4, 0x66, 0x67, 0x9f,
-	0x77, 0x76, 0xf9, 0x5e, 0x27, 0xe4, 0x21, 0x57, 0x00, 0x37, 0x3b, 0x69, 0x6c, 0xcf, 0x2e, 0xed,
-	0x25, 0xbf, 0x2f, 0x51, 0xe4, 0x88, 0xa3, 0x02, 0x42, 0xdd, 0xbb, 0xf8, 0x15, 0x99, 0xdc, 0xa6,
-	0xad, 0x90, 0xf3, 0xf0, 0x12, 0x5d, 0x15, 0xcd, 0xd2, 0xb9, 0x1b, 0xa4, 0x89, 0x2f, 0x23, 0xce,
-	0xf2, 0x7c, 0xff, 0xff, 0xbc, 0x8c, 0x62, 0x14, 0xd2, 0x8f, 0x97, 0x1a, 0x30, 0x40, 0x68, 0x9c,
-	0x89, 0x70, 0xca, 0xe6, 0xdc, 0x7c, 0x06, 0x95, 0x58, 0x84, 0x5d, 0x62, 0x93, 0x61, 0xfb, 0xe4,
-	0xc8, 0x29, 0x5b, 0xc3, 0x39, 0x43, 0x21, 0xfc, 0x10, 0x47, 0xd5, 0xab, 0x9b, 0xbe, 0x41, 0x33,
-	0xbc, 0x79, 0x0c, 0x8d, 0x25, 0x62, 0xe2, 0x45, 0x41, 0xf7, 0xc0, 0x26, 0xc3, 0xd6, 0x08, 0xd6,
-	0x37, 0xfd, 0xfa, 0x7b, 0xc4, 0x64, 0x3a, 0xa6, 0xf5, 0x2c, 0x35, 0x0d, 0x06, 0x2b, 0x02, 0xed,
-	0xf3, 0x28, 0x46, 0x9e, 0x4a, 0xc5, 0xf5, 0x0a, 0x9a, 0xdb, 0x49, 0x73, 0xc2, 0x47, 0x8e, 0x1e,
-	0xd5, 0xd9, 0x8e, 0xea, 0x8c, 0x73, 0xc0, 0xa8, 0x99, 0x91, 0xfd, 0xfc, 0xd3, 0x27, 0x74, 0x57,
-	0x64, 0x1e, 0x42, 0x7d, 0x81, 0x51, 0xb8, 0x90, 0x8a, 0xb4, 0x42, 0xf3, 0xc8, 0xec, 0x40, 0x2d,
-	0xe1, 0x29, 0x0b, 0xba, 0x15, 0x9b, 0x0c, 0x6b, 0x54, 0x07, 0xa6, 0x09, 0x55, 0x21, 0x71, 0xd9,
-	0xad, 0xda, 0x64, 0x78, 0x9f, 0xaa, 0xf3, 0xe0, 0x18, 0x5a, 0xa7, 0x2c, 0x98, 0xe8, 0xb2, 0x7d,
-	0x3b, 0x52, 0x6c, 0x37, 0xf8, 0x75, 0x00, 0xf0, 0xe9, 0xcd, 0xbb, 0x7c, 0x6d, 0xf3, 0x0b, 0x1c,
-	0x2a, 0xf9, 0xbd, 0xc0, 0x97, 0xbe, 0xa7, 0x7a, 0x7b, 0x42, 0xfa, 0x12, 0xf3, 0x25, 0x9e, 0x14,
-	0x55, 0xd3, 0x36, 0x9e, 0x66, 0xf8, 0xb1, 0x2f, 0x7d, 0x9a, 0xa1, 0x3f, 0x64, 0xe0, 0x89, 0x41,
-	0x1f, 0xe2, 0xed, 0x6b, 0xf3, 0x05, 0x34, 0x63, 0x11, 0x7a, 0x11, 0x9b, 0x73, 0xb5, 0xd5, 0xdd,
-	0x2e, 0x68, 0xc7, 0x26, 0x06, 0x6d, 0xc4, 0xb9, 0x79, 0x6f, 0xe1, 0x9e, 0xd4, 0xfa, 0xea, 0xfa,
-	0x8a, 0xaa, 0x7f, 0x5c, 0x5e, 0x5f, 0x70, 0x62, 0x62, 0xd0, 0xb6, 0x2c, 0x18, 0xf3, 0x1a, 0x00,
-	0x59, 0xe0, 0xe5, 0x62, 0x54, 0x55, 0x97, 0x7e, 0x79, 0x97, 0x9d, 0x7a, 0x13, 0x83, 0xb6, 0x70,
-	0x1b, 0x8c, 0x6a, 0x50, 0x11, 0x69, 0x3c, 0xf8, 0x01, 0x0f, 0x32, 0x9a, 0xa0, 0xa0, 0xde, 0x73,
-	0xa8, 0x66, 0x54, 0xb9, 0x56, 0xbd, 0x5b, 0x86, 0x9f, 0x6f, 0xdf, 0xa6, 0x76, 0x7c, 0x95, 0x39,
-	0xae, 0x2a, 0xcc, 0x13, 0xfd, 0x34, 0xb5, 0x28, 0x76, 0xf9, 0x38, 0x7b, 0x22, 0xf5, 0x2e, 0x47,
-	0x1f, 0xaf, 0xd6, 0x16, 0xb9, 0x5e, 0x5b, 0xe4, 0xef, 0xda, 0x22, 0xab, 0x8d, 0x65, 0x5c, 0x6f,
-	0x2c, 0xe3, 0xf7, 0xc6, 0x32, 0x3e, 0xbf, 0x0c, 0x23, 0xb9, 0x48, 0x67, 0xce, 0x05, 0x8f, 0xdd,
-	0xe2, 0xf7, 0xda, 0x1f, 0xf5, 0x47, 0x2d, 0xfb, 0x9c, 0xb3, 0xba, 0xca, 0x3d, 0xfd, 0x17, 0x00,
-	0x00, 0xff, 0xff, 0x0b, 0xad, 0x1c, 0x1b, 0x07, 0x04, 0x00, 0x00,
+	// 715 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0xcd, 0x6b, 0x13, 0x41,
+	0x14, 0xdf, 0xc9, 0x77, 0x5f, 0x5a, 0x85, 0x69, 0xa9, 0x31, 0xd8, 0x4d, 0x9a, 0x22, 0xe4, 0xb4,
+	0x81, 0x8a, 0x52, 0x2a, 0xf8, 0x91, 0x7e, 0x90, 0x82, 0x85, 0x32, 0x56, 0x04, 0x11, 0xc2, 0x26,
+	0x3b, 0xdd, 0x2c, 0xcd, 0xee, 0x2c, 0x99, 0x49, 0xc5, 0x93, 0x57, 0x6f, 0xf6, 0xe8, 0x9f, 0xd4,
+	0x63, 0x8f, 0x9e, 0xaa, 0xa4, 0x7f, 0x86, 0x17, 0xd9, 0x99, 0xd9, 0xec, 0x6a, 0x62, 0xd0, 0x53,
+	0xde, 0xcc, 0xfb, 0x7d, 0xcc, 0xbe, 0xf7, 0x66, 0x02, 0xa6, 0xa0, 0x81, 0x43, 0x47, 0xbe, 0x17,
+	0x88, 0x56, 0x9f, 0x05, 0x9c, 0x06, 0x7c, 0xcc, 0x5b, 0x1f, 0xec, 0xa1, 0x15, 0x8e, 0x98, 0x60,
+	0x78, 0x2d, 0xc9, 0x5b, 0xd3, 0x7c, 0x75, 0xcd, 0x65, 0x2e, 0x93, 0x80, 0x56, 0x14, 0x29, 0x6c,
+	0xb5, 0x3e, 0x57, 0x4b, 0x7c, 0x0c, 0x29, 0xd7, 0x88, 0x8d, 0x14, 0x42, 0xee, 0xb7, 0xe8, 0x05,
+	0x0d, 0x44, 0x9c, 0x7e, 0x30, 0x93, 0x4e, 0x93, 0x67, 0xb3, 0xbd, 0x21, 0xeb, 0x9f, 0xeb, 0xac,
+	0xe9, 0x32, 0xe6, 0x0e, 0x69, 0x4b, 0xae, 0x7a, 0xe3, 0xb3, 0x96, 0x33, 0x1e, 0xd9, 0xc2, 0x63,
+	0x81, 0xce, 0xd7, 0xfe, 0xcc, 0x0b, 0xcf, 0xa7, 0x5c, 0xd8, 0x7e, 0xa8, 0x00, 0x0d, 0x0a, 0xc5,
+	0x63, 0xee, 0x1e, 0x05, 0x67, 0x0c, 0x3f, 0x86, 0xac, 0xcf, 0xdd, 0x0a, 0xaa, 0xa3, 0x66, 0x79,
+	0x7b, 0xc3, 0x9a, 0x57, 0x02, 0xeb, 0x98, 0x72, 0x6e, 0xbb, 0xb4, 0x9d, 0xbb, 0xba, 0xa9, 0x19,
+	0x24, 0xc2, 0xe3, 0x2d, 0x28, 0x86, 0x94, 0x8e, 0xba, 0x9e, 0x53, 0xc9, 0xd4, 0x51, 0x73, 0xa9,
+	0x0d, 0x93, 0x9b, 0x5a, 0xe1, 0x84, 0xd2, 0xd1, 0xd1, 0x3e, 0x29, 0x44, 0xa9, 0x23, 0xa7, 0x71,
+	0x89, 0xa0, 0x7c, 0xea, 0xf9, 0x94, 0x8d, 0x85, 0xf4, 0x7a, 0x0e, 0xa5, 0xf8, 0xa4, 0xda, 0xf0,
+	0xbe, 0xa5, 0x8e, 0x6a, 0xc5, 0x47, 0xb5, 0xf6, 0x35, 0xa0, 0x5d, 0x8a, 0xcc, 0xbe, 0x7e, 0xaf,
+	0x21, 0x32, 0x25, 0xe1, 0x75, 0x28, 0x0c, 0xa8, 0xe7, 0x0e, 0x84, 0x34, 0xcd, 0x12, 0xbd, 0xc2,
+	0x6b, 0x90, 0x1f, 0xb1, 0x71, 0xe0, 0x54, 0xb2, 0x75, 0xd4, 0xcc, 0x13, 0xb5, 0xc0, 0x18, 0x72,
+	0x5c, 0xd0, 0xb0, 0x92, 0xab, 0xa3, 0xe6, 0x0a, 0x91, 0x71, 0x63, 0x0b, 0x96, 0x0e, 0x02, 0xa7,
+	0xa3, 0x68, 0x89, 0x1c, 0x4a, 0xcb, 0x35, 0x3e, 0x23, 0x80, 0xbd, 0x01, 0xed, 0x9f, 0x87, 0xcc,
+	0x0b, 0xfe, 0x0a, 0x4b, 0x5c, 0x33, 0xf3, 0x5c, 0xb3, 0x89, 0x2b, 0xde, 0x81, 0x5c, 0xd4, 0x02,
+	0x79, 0x92, 0xf2, 0x76, 0x75, 0xe6, 0xa3, 0x4f, 0xe3, 0xfe, 0xa8, 0xaf, 0xbe, 0x8c, 0xbe, 0x5a,
+	0x32, 0x1a, 0x3f, 0x33, 0x00, 0x6f, 0x5f, 0xbe, 0xd2, 0x1d, 0xc0, 0xef, 0x61, 0x5d, 0x4e, 0x51,
+	0xd7, 0xb1, 0x85, 0xdd, 0x95, 0x86, 0x5d, 0x2e, 0x6c, 0x41, 0x75, 0x3d, 0x1f, 0xa6, 0x1b, 0xa8,
+	0x06, 0xea, 0x20, 0xc2, 0xef, 0xdb, 0xc2, 0x26, 0x11, 0xfa, 0x75, 0x04, 0xee, 0x18, 0x64, 0x95,
+	0xce, 0x6e, 0xe3, 0x5d, 0x28, 0xf9, 0xdc, 0xed, 0x7a, 0xc1, 0x19, 0xab, 0x64, 0x16, 0x0e, 0x84,
+	0x1a, 0x9e, 0x8e, 0x41, 0x8a, 0xbe, 0x0a, 0xf1, 0x21, 0x2c, 0x0b, 0xd5, 0x6a, 0xc5, 0xcf, 0x4a,
+	0xfe, 0xe6, 0x7c, 0x7e, 0x6a, 0x28, 0x3a, 0x06, 0x29, 0x8b, 0x64, 0x89, 0x5f, 0x00, 0xd0, 0xc0,
+	0xe9, 0xea, 0x82, 0xab, 0x82, 0xd5, 0xe6, 0xab, 0x4c, 0x1b, 0xd9, 0x31, 0xc8, 0x12, 0x9d, 0x76,
+	0xb5, 0x0d, 0xd0, 0x9f, 0x36, 0xaf, 0x92, 0x97, 0x0a, 0xf5, 0xf9, 0x0a, 0x49, 0x93, 0x3b, 0x06,
+	0x49, 0xb1, 0xda, 0x79, 0xc8, 0xf2, 0xb1, 0xdf, 0xf8, 0x92, 0x85, 0xd5, 0xbd, 0x18, 0x9d, 0x9a,
+	0x88, 0xc3, 0xdf, 0x2c, 0xd0, 0xbf, 0x59, 0xe8, 0xeb, 0x93, 0x62, 0xe2, 0x27, 0x50, 0x0a, 0x47,
+	0x2c, 0x64, 0xdc, 0x1e, 0xea, 0x82, 0x57, 0x67, 0x1b, 0x78, 0xa2, 0x11, 0x64, 0x8a, 0xc5, 0xcf,
+	0xe0, 0x4e, 0x1c, 0x77, 0xe5, 0xc3, 0xa0, 0xcb, 0x7d, 0x6f, 0x96, 0xdd, 0x8e, 0xd2, 0x64, 0x25,
+	0x86, 0xcb, 0x25, 0xde, 0x84, 0xe5, 0xe8, 0x97, 0x3a, 0x6a, 0x84, 0x64, 0x99, 0xf3, 0xa4, 0xac,
+	0xf6, 0xe4, 0x40, 0xe0, 0xdd, 0x29, 0x44, 0x19, 0xe4, 0x17, 0x1b, 0x68, 0xae, 0x92, 0xaf, 0x41,
+	0xf9, 0xc2, 0x1e, 0x7a, 0xb1, 0x7a, 0x41, 0xaa, 0x83, 0xdc, 0x52, 0xe2, 0x3b, 0x31, 0x40, 0x69,
+	0x17, 0x17, 0x6b, 0x2b, 0xa6, 0x8c, 0x1b, 0x9f, 0xe0, 0x6e, 0x34, 0x3c, 0x4e, 0xea, 0x4e, 0xc4,
+	0x97, 0x0b, 0xfd, 0xef, 0xe5, 0xc2, 0xdb, 0xea, 0xed, 0xcb, 0x2c, 0xea, 0x5f, 0x62, 0x24, 0x1f,
+	0xbe, 0xf6, 0x9b, 0xab, 0x89, 0x89, 0xae, 0x27, 0x26, 0xfa, 0x31, 0x31, 0xd1, 0xe5, 0xad, 0x69,
+	0x5c, 0xdf, 0x9a, 0xc6, 0xb7, 0x5b, 0xd3, 0x78, 0xf7, 0xd4, 0xf5, 0xc4, 0x60, 0xdc, 0xb3, 0xfa,
+	0xcc, 0x6f, 0xa5, 0x9f, 0xef, 0x24, 0x54, 0xff, 0x22, 0xf3, 0xfe, 0x39, 0x7a, 0x05, 0x99, 0x7b,
+	0xf4, 0x6b, 0x00, 0x1b, 0xe5, 0xc9, 0x6d, 0xa4, 0x06, 0x00, 0x00,
 }
 
 func (m *MsgInfo) Marshal() (dAtA []byte, err error) {
@@ -523,6 +713,52 @@ func (m *EndHeight) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *Checkpoint) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Checkpoint) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Checkpoint) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	n3, err3 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
+	if err3 != nil {
+		return 0, err3
+	}
+	i -= n3
+	i = encodeVarintWal(dAtA, i, uint64(n3))
+	i--
+	dAtA[i] = 0x22
+	if m.Step != 0 {
+		i = encodeVarintWal(dAtA, i, uint64(m.Step))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Round != 0 {
+		i = encodeVarintWal(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintWal(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *WALMessage) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -639,6 +875,118 @@ func (m *WALMessage_EndHeight) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	}
 	return len(dAtA) - i, nil
 }
+func (m *WALMessage_Checkpoint) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WALMessage_Checkpoint) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Checkpoint != nil {
+		{
+			size, err := m.Checkpoint.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintWal(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *ConsensusCheckpoint) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConsensusCheckpoint) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConsensusCheckpoint) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ValidBlock != nil {
+		{
+			size, err := m.ValidBlock.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintWal(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.ValidRound != 0 {
+		i = encodeVarintWal(dAtA, i, uint64(m.ValidRound))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.LockedBlock != nil {
+		{
+			size, err := m.LockedBlock.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintWal(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.LockedRound != 0 {
+		i = encodeVarintWal(dAtA, i, uint64(m.LockedRound))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.ProposalBlock != nil {
+		{
+			size, err := m.ProposalBlock.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintWal(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Proposal != nil {
+		{
+			size, err := m.Proposal.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintWal(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	{
+		size, err := m.Checkpoint.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintWal(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
 func (m *TimedWALMessage) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -671,12 +1019,12 @@ func (m *TimedWALMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x12
 	}
-	n8, err8 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
-	if err8 != nil {
-		return 0, err8
+	n15, err15 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
+	if err15 != nil {
+		return 0, err15
 	}
-	i -= n8
-	i = encodeVarintWal(dAtA, i, uint64(n8))
+	i -= n15
+	i = encodeVarintWal(dAtA, i, uint64(n15))
 	i--
 	dAtA[i] = 0xa
 	return len(dAtA) - i, nil
@@ -740,6 +1088,26 @@ func (m *EndHeight) Size() (n int) {
 	return n
 }
 
+func (m *Checkpoint) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovWal(uint64(m.Height))
+	}
+	if m.Round != 0 {
+		n += 1 + sovWal(uint64(m.Round))
+	}
+	if m.Step != 0 {
+		n += 1 + sovWal(uint64(m.Step))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Time)
+	n += 1 + l + sovWal(uint64(l))
+	return n
+}
+
 func (m *WALMessage) Size() (n int) {
 	if m == nil {
 		return 0
@@ -800,14 +1168,59 @@ func (m *WALMessage_EndHeight) Size() (n int) {
 	}
 	return n
 }
-func (m *TimedWALMessage) Size() (n int) {
+func (m *WALMessage_Checkpoint) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Time)
-	n += 1 + l + sovWal(uint64(l))
+	if m.Checkpoint != nil {
+		l = m.Checkpoint.Size()
+		n += 1 + l + sovWal(uint64(l))
+	}
+	return n
+}
+func (m *ConsensusCheckpoint) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Checkpoint.Size()
+	n += 1 + l + sovWal(uint64(l))
+	if m.Proposal != nil {
+		l = m.Proposal.Size()
+		n += 1 + l + sovWal(uint64(l))
+	}
+	if m.ProposalBlock != nil {
+		l = m.ProposalBlock.Size()
+		n += 1 + l + sovWal(uint64(l))
+	}
+	if m.LockedRound != 0 {
+		n += 1 + sovWal(uint64(m.LockedRound))
+	}
+	if m.LockedBlock != nil {
+		l = m.LockedBlock.Size()
+		n += 1 + l + sovWal(uint64(l))
+	}
+	if m.ValidRound != 0 {
+		n += 1 + sovWal(uint64(m.ValidRound))
+	}
+	if m.ValidBlock != nil {
+		l = m.ValidBlock.Size()
+		n += 1 + l + sovWal(uint64(l))
+	}
+	return n
+}
+
+func (m *TimedWALMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Time)
+	n += 1 + l + sovWal(uint64(l))
 	if m.Msg != nil {
 		l = m.Msg.Size()
 		n += 1 + l + sovWal(uint64(l))
@@ -1145,6 +1558,146 @@ func (m *EndHeight) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *Checkpoint) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWal
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Checkpoint: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Checkpoint: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Step", wireType)
+			}
+			m.Step = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Step |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Time, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWal(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWal
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *WALMessage) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -1203,7 +1756,7 @@ func (m *WALMessage) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &types.EventDataRoundState{}
+			v := &types1.EventDataRoundState{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
@@ -1314,6 +1867,306 @@ func (m *WALMessage) Unmarshal(dAtA []byte) error {
 			}
 			m.Sum = &WALMessage_EndHeight{v}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Checkpoint", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &Checkpoint{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &WALMessage_Checkpoint{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWal(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWal
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConsensusCheckpoint) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWal
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConsensusCheckpoint: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConsensusCheckpoint: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Checkpoint", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Checkpoint.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proposal", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Proposal == nil {
+				m.Proposal = &types1.Proposal{}
+			}
+			if err := m.Proposal.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProposalBlock", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ProposalBlock == nil {
+				m.ProposalBlock = &types1.Block{}
+			}
+			if err := m.ProposalBlock.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LockedRound", wireType)
+			}
+			m.LockedRound = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LockedRound |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LockedBlock", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LockedBlock == nil {
+				m.LockedBlock = &types1.Block{}
+			}
+			if err := m.LockedBlock.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidRound", wireType)
+			}
+			m.ValidRound = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ValidRound |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidBlock", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ValidBlock == nil {
+				m.ValidBlock = &types1.Block{}
+			}
+			if err := m.ValidBlock.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipWal(dAtA[iNdEx:])
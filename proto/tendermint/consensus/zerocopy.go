@@ -0,0 +1,68 @@
+package consensus
+
+import (
+	"github.com/tendermint/tendermint/internal/libs/protoio"
+	"github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+// UnmarshalZeroCopy unmarshals the message like proto.Unmarshal. Block parts
+// and votes, the bulk of the consensus messages, are decoded with their byte
+// fields aliasing b, which must not be modified afterwards.
+func (m *Message) UnmarshalZeroCopy(b []byte) error {
+	*m = Message{}
+	err := protoio.ReadWireFields(b, func(f protoio.WireField) error {
+		if f.WireType != protoio.WireBytes {
+			return types.ErrZeroCopyField
+		}
+		switch f.Num {
+		case 5:
+			msg := &BlockPart{}
+			if err := msg.unmarshalZeroCopy(f.Bytes); err != nil {
+				return err
+			}
+			m.Sum = &Message_BlockPart{BlockPart: msg}
+		case 6:
+			msg := &Vote{}
+			if err := msg.unmarshalZeroCopy(f.Bytes); err != nil {
+				return err
+			}
+			m.Sum = &Message_Vote{Vote: msg}
+		default:
+			return types.ErrZeroCopyField
+		}
+		return nil
+	})
+	if err != nil {
+		// other messages are decoded, and malformed ones reported, by the
+		// generated decoder
+		*m = Message{}
+		return m.Unmarshal(b)
+	}
+	return nil
+}
+
+func (m *BlockPart) unmarshalZeroCopy(b []byte) error {
+	return protoio.ReadWireFields(b, func(f protoio.WireField) error {
+		switch {
+		case f.Num == 1 && f.WireType == protoio.WireVarint:
+			m.Height = int64(f.Varint)
+		case f.Num == 2 && f.WireType == protoio.WireVarint:
+			m.Round = int32(f.Varint)
+		case f.Num == 3 && f.WireType == protoio.WireBytes:
+			return m.Part.UnmarshalZeroCopy(f.Bytes)
+		default:
+			return types.ErrZeroCopyField
+		}
+		return nil
+	})
+}
+
+func (m *Vote) unmarshalZeroCopy(b []byte) error {
+	return protoio.ReadWireFields(b, func(f protoio.WireField) error {
+		if f.Num != 1 || f.WireType != protoio.WireBytes {
+			return types.ErrZeroCopyField
+		}
+		m.Vote = &types.Vote{}
+		return m.Vote.UnmarshalZeroCopy(f.Bytes)
+	})
+}
@@ -25,6 +25,10 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 type PexAddress struct {
 	URL string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// The Ed25519 public key of the node at the address, whose ID it hashes
+	// to, and its signature of the address. Empty if the address is unsigned.
+	PubKey    []byte `protobuf:"bytes,2,opt,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"`
+	Signature []byte `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
 }
 
 func (m *PexAddress) Reset()         { *m = PexAddress{} }
@@ -67,6 +71,20 @@ func (m *PexAddress) GetURL() string {
 	return ""
 }
 
+func (m *PexAddress) GetPubKey() []byte {
+	if m != nil {
+		return m.PubKey
+	}
+	return nil
+}
+
+func (m *PexAddress) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
 type PexRequest struct {
 }
 
@@ -242,27 +260,29 @@ func init() {
 func init() { proto.RegisterFile("tendermint/p2p/pex.proto", fileDescriptor_81c2f011fd13be57) }
 
 var fileDescriptor_81c2f011fd13be57 = []byte{
-	// 311 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0x28, 0x49, 0xcd, 0x4b,
-	0x49, 0x2d, 0xca, 0xcd, 0xcc, 0x2b, 0xd1, 0x2f, 0x30, 0x2a, 0xd0, 0x2f, 0x48, 0xad, 0xd0, 0x2b,
-	0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x43, 0xc8, 0xe8, 0x15, 0x18, 0x15, 0x48, 0x89, 0xa4, 0xe7,
-	0xa7, 0xe7, 0x83, 0xa5, 0xf4, 0x41, 0x2c, 0x88, 0x2a, 0x25, 0x63, 0x2e, 0xae, 0x80, 0xd4, 0x0a,
-	0xc7, 0x94, 0x94, 0xa2, 0xd4, 0xe2, 0x62, 0x21, 0x49, 0x2e, 0xe6, 0xd2, 0xa2, 0x1c, 0x09, 0x46,
-	0x05, 0x46, 0x0d, 0x4e, 0x27, 0xf6, 0x47, 0xf7, 0xe4, 0x99, 0x43, 0x83, 0x7c, 0x82, 0x40, 0x62,
-	0x5e, 0x2c, 0x1c, 0x4c, 0x02, 0xcc, 0x5e, 0x2c, 0x1c, 0xcc, 0x02, 0x2c, 0x4a, 0x3c, 0x60, 0x4d,
-	0x41, 0xa9, 0x85, 0xa5, 0xa9, 0xc5, 0x25, 0x4a, 0xbe, 0x5c, 0xdc, 0x60, 0x5e, 0x71, 0x41, 0x7e,
-	0x5e, 0x71, 0xaa, 0x90, 0x1d, 0x17, 0x67, 0x22, 0xc4, 0xb8, 0xd4, 0x62, 0x09, 0x46, 0x05, 0x66,
-	0x0d, 0x6e, 0x23, 0x29, 0x3d, 0x54, 0xb7, 0xe8, 0x21, 0xac, 0x74, 0x62, 0x39, 0x71, 0x4f, 0x9e,
-	0x21, 0x08, 0xa1, 0x45, 0x69, 0x01, 0x23, 0xd8, 0x74, 0xdf, 0xd4, 0xe2, 0xe2, 0xc4, 0xf4, 0x54,
-	0x21, 0x5b, 0x2e, 0xee, 0x82, 0xd4, 0x8a, 0xf8, 0x22, 0x88, 0x65, 0x12, 0xcc, 0x0a, 0x8c, 0x38,
-	0x0c, 0x84, 0x3a, 0xc7, 0x83, 0x21, 0x88, 0xab, 0x00, 0xce, 0x13, 0x72, 0xe0, 0xe2, 0x81, 0x68,
-	0x87, 0xb8, 0x4e, 0x82, 0x05, 0xac, 0x5f, 0x1a, 0xab, 0x7e, 0x88, 0x12, 0x0f, 0x86, 0x20, 0xee,
-	0x02, 0x04, 0xd7, 0x89, 0x95, 0x8b, 0xb9, 0xb8, 0x34, 0xd7, 0x8b, 0x85, 0x83, 0x51, 0x80, 0x09,
-	0x12, 0x0a, 0x4e, 0xfe, 0x27, 0x1e, 0xc9, 0x31, 0x5e, 0x78, 0x24, 0xc7, 0xf8, 0xe0, 0x91, 0x1c,
-	0xe3, 0x84, 0xc7, 0x72, 0x0c, 0x17, 0x1e, 0xcb, 0x31, 0xdc, 0x78, 0x2c, 0xc7, 0x10, 0x65, 0x9a,
-	0x9e, 0x59, 0x92, 0x51, 0x9a, 0xa4, 0x97, 0x9c, 0x9f, 0xab, 0x8f, 0x14, 0x33, 0xc8, 0x91, 0x04,
-	0x8e, 0x01, 0xd4, 0x58, 0x4b, 0x62, 0x03, 0x8b, 0x1a, 0x03, 0x02, 0x00, 0x00, 0xff, 0xff, 0xa7,
-	0x1d, 0xdd, 0x6f, 0xce, 0x01, 0x00, 0x00,
+	// 341 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x51, 0x4d, 0x4f, 0xc2, 0x40,
+	0x10, 0xed, 0xb2, 0x95, 0x8f, 0x2d, 0x31, 0x64, 0x63, 0x62, 0x45, 0x53, 0x48, 0x4f, 0x9c, 0xda,
+	0x04, 0xe3, 0x51, 0xa3, 0x3d, 0x11, 0x94, 0x68, 0x36, 0xf1, 0xe2, 0x85, 0x50, 0x99, 0x54, 0x54,
+	0xda, 0xb5, 0xdb, 0x4d, 0xe0, 0x5f, 0xf8, 0x13, 0xfc, 0x39, 0x1c, 0x39, 0x7a, 0x22, 0xa6, 0xfc,
+	0x11, 0xc3, 0xae, 0xb1, 0x90, 0x70, 0x9b, 0x79, 0x6f, 0xde, 0xcc, 0xdb, 0x7d, 0xc4, 0xce, 0x20,
+	0x1e, 0x43, 0x3a, 0x9d, 0xc4, 0x99, 0xcf, 0xbb, 0xdc, 0xe7, 0x30, 0xf3, 0x78, 0x9a, 0x64, 0x09,
+	0x3d, 0x2c, 0x18, 0x8f, 0x77, 0x79, 0xf3, 0x28, 0x4a, 0xa2, 0x44, 0x51, 0xfe, 0xa6, 0xd2, 0x53,
+	0xee, 0x2b, 0x21, 0x0f, 0x30, 0xbb, 0x19, 0x8f, 0x53, 0x10, 0x82, 0x9e, 0x10, 0x2c, 0xd3, 0x77,
+	0x1b, 0xb5, 0x51, 0xa7, 0x16, 0x54, 0xf2, 0x55, 0x0b, 0x3f, 0xb2, 0x3b, 0xb6, 0xc1, 0xe8, 0x31,
+	0xa9, 0x70, 0x19, 0x0e, 0xdf, 0x60, 0x6e, 0x97, 0xda, 0xa8, 0x53, 0x67, 0x65, 0x2e, 0xc3, 0x5b,
+	0x98, 0xd3, 0x33, 0x52, 0x13, 0x93, 0x28, 0x1e, 0x65, 0x32, 0x05, 0x1b, 0x2b, 0xaa, 0x00, 0xfa,
+	0x66, 0xb5, 0xd4, 0xc0, 0x7d, 0xb3, 0x8a, 0x1b, 0xa6, 0x5b, 0x57, 0xb7, 0x18, 0x7c, 0x48, 0x10,
+	0x99, 0x3b, 0x20, 0x96, 0xea, 0x04, 0x4f, 0x62, 0x01, 0xf4, 0x8a, 0xd4, 0x46, 0xda, 0x05, 0x08,
+	0x1b, 0xb5, 0x71, 0xc7, 0xea, 0x36, 0xbd, 0xdd, 0x27, 0x78, 0x85, 0xd3, 0xc0, 0x5c, 0xac, 0x5a,
+	0x06, 0x2b, 0x24, 0xee, 0x17, 0x52, 0xdb, 0x07, 0x20, 0xc4, 0x28, 0x02, 0x7a, 0x49, 0x2c, 0x0e,
+	0xb3, 0x61, 0xaa, 0x8f, 0x29, 0x5f, 0xfb, 0x17, 0xfe, 0xd9, 0xe9, 0x19, 0x8c, 0xf0, 0xff, 0x8e,
+	0x5e, 0x93, 0xba, 0x96, 0x6b, 0x77, 0xb6, 0xa9, 0xf4, 0xa7, 0x7b, 0xf5, 0x7a, 0xa4, 0x67, 0x30,
+	0x8b, 0x17, 0x6d, 0x70, 0x40, 0xb0, 0x90, 0xd3, 0xbe, 0x59, 0x45, 0x8d, 0x92, 0xfe, 0x85, 0xe0,
+	0x7e, 0x91, 0x3b, 0x68, 0x99, 0x3b, 0xe8, 0x27, 0x77, 0xd0, 0xe7, 0xda, 0x31, 0x96, 0x6b, 0xc7,
+	0xf8, 0x5e, 0x3b, 0xc6, 0xd3, 0x45, 0x34, 0xc9, 0x5e, 0x64, 0xe8, 0x3d, 0x27, 0x53, 0x7f, 0x2b,
+	0xd0, 0xad, 0x52, 0x07, 0xb7, 0x1b, 0x76, 0x58, 0x56, 0xe8, 0xf9, 0xef, 0x00, 0xb0, 0xa8, 0xbc,
+	0x1a, 0x05, 0x02, 0x00, 0x00,
 }
 
 func (m *PexAddress) Marshal() (dAtA []byte, err error) {
@@ -285,6 +305,20 @@ func (m *PexAddress) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Signature) > 0 {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintPex(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.PubKey) > 0 {
+		i -= len(m.PubKey)
+		copy(dAtA[i:], m.PubKey)
+		i = encodeVarintPex(dAtA, i, uint64(len(m.PubKey)))
+		i--
+		dAtA[i] = 0x12
+	}
 	if len(m.URL) > 0 {
 		i -= len(m.URL)
 		copy(dAtA[i:], m.URL)
@@ -450,6 +484,14 @@ func (m *PexAddress) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovPex(uint64(l))
 	}
+	l = len(m.PubKey)
+	if l > 0 {
+		n += 1 + l + sovPex(uint64(l))
+	}
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovPex(uint64(l))
+	}
 	return n
 }
 
@@ -581,6 +623,74 @@ func (m *PexAddress) Unmarshal(dAtA []byte) error {
 			}
 			m.URL = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PubKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthPex
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PubKey = append(m.PubKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.PubKey == nil {
+				m.PubKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthPex
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPex(dAtA[iNdEx:])
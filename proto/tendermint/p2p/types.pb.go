@@ -9,6 +9,7 @@ import (
 	proto "github.com/gogo/protobuf/proto"
 	_ "github.com/gogo/protobuf/types"
 	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
+	crypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
 	io "io"
 	math "math"
 	math_bits "math/bits"
@@ -96,6 +97,19 @@ type NodeInfo struct {
 	Channels        []byte          `protobuf:"bytes,6,opt,name=channels,proto3" json:"channels,omitempty"`
 	Moniker         string          `protobuf:"bytes,7,opt,name=moniker,proto3" json:"moniker,omitempty"`
 	Other           NodeInfoOther   `protobuf:"bytes,8,opt,name=other,proto3" json:"other"`
+	ValidatorProof  *ValidatorProof `protobuf:"bytes,9,opt,name=validator_proof,json=validatorProof,proto3" json:"validator_proof,omitempty"`
+	// The IP address the sender observed the receiver's connection from, so
+	// that nodes behind a NAT can learn their external address.
+	ObservedAddr string `protobuf:"bytes,10,opt,name=observed_addr,json=observedAddr,proto3" json:"observed_addr,omitempty"`
+	// The compression algorithms the sender supports for p2p messages, in
+	// order of preference.
+	Compression []string `protobuf:"bytes,11,rep,name=compression,proto3" json:"compression,omitempty"`
+	// The optional protocol extensions the sender supports, as a bitfield of
+	// capability flags. Unknown flags are ignored.
+	Capabilities uint64 `protobuf:"varint,12,opt,name=capabilities,proto3" json:"capabilities,omitempty"`
+	// The SHA-256 hash of the sender's genesis document, so that peers on a
+	// different genesis are rejected at handshake.
+	GenesisHash []byte `protobuf:"bytes,13,opt,name=genesis_hash,json=genesisHash,proto3" json:"genesis_hash,omitempty"`
 }
 
 func (m *NodeInfo) Reset()         { *m = NodeInfo{} }
@@ -187,6 +201,41 @@ func (m *NodeInfo) GetOther() NodeInfoOther {
 	return NodeInfoOther{}
 }
 
+func (m *NodeInfo) GetValidatorProof() *ValidatorProof {
+	if m != nil {
+		return m.ValidatorProof
+	}
+	return nil
+}
+
+func (m *NodeInfo) GetObservedAddr() string {
+	if m != nil {
+		return m.ObservedAddr
+	}
+	return ""
+}
+
+func (m *NodeInfo) GetCompression() []string {
+	if m != nil {
+		return m.Compression
+	}
+	return nil
+}
+
+func (m *NodeInfo) GetCapabilities() uint64 {
+	if m != nil {
+		return m.Capabilities
+	}
+	return 0
+}
+
+func (m *NodeInfo) GetGenesisHash() []byte {
+	if m != nil {
+		return m.GenesisHash
+	}
+	return nil
+}
+
 type NodeInfoOther struct {
 	TxIndex    string `protobuf:"bytes,1,opt,name=tx_index,json=txIndex,proto3" json:"tx_index,omitempty"`
 	RPCAddress string `protobuf:"bytes,2,opt,name=rpc_address,json=rpcAddress,proto3" json:"rpc_address,omitempty"`
@@ -239,17 +288,74 @@ func (m *NodeInfoOther) GetRPCAddress() string {
 	return ""
 }
 
+// ValidatorProof proves that a node is operated by the holder of a validator
+// key, by signing the chain ID and node ID with it.
+type ValidatorProof struct {
+	PubKey    crypto.PublicKey `protobuf:"bytes,1,opt,name=pub_key,json=pubKey,proto3" json:"pub_key"`
+	Signature []byte           `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *ValidatorProof) Reset()         { *m = ValidatorProof{} }
+func (m *ValidatorProof) String() string { return proto.CompactTextString(m) }
+func (*ValidatorProof) ProtoMessage()    {}
+func (*ValidatorProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c8a29e659aeca578, []int{3}
+}
+func (m *ValidatorProof) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ValidatorProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ValidatorProof.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ValidatorProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidatorProof.Merge(m, src)
+}
+func (m *ValidatorProof) XXX_Size() int {
+	return m.Size()
+}
+func (m *ValidatorProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidatorProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidatorProof proto.InternalMessageInfo
+
+func (m *ValidatorProof) GetPubKey() crypto.PublicKey {
+	if m != nil {
+		return m.PubKey
+	}
+	return crypto.PublicKey{}
+}
+
+func (m *ValidatorProof) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
 type PeerInfo struct {
 	ID            string             `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	AddressInfo   []*PeerAddressInfo `protobuf:"bytes,2,rep,name=address_info,json=addressInfo,proto3" json:"address_info,omitempty"`
 	LastConnected *time.Time         `protobuf:"bytes,3,opt,name=last_connected,json=lastConnected,proto3,stdtime" json:"last_connected,omitempty"`
+	Network       string             `protobuf:"bytes,4,opt,name=network,proto3" json:"network,omitempty"`
+	// The node info presented in the last handshake.
+	NodeInfo *NodeInfo `protobuf:"bytes,5,opt,name=node_info,json=nodeInfo,proto3" json:"node_info,omitempty"`
 }
 
 func (m *PeerInfo) Reset()         { *m = PeerInfo{} }
 func (m *PeerInfo) String() string { return proto.CompactTextString(m) }
 func (*PeerInfo) ProtoMessage()    {}
 func (*PeerInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c8a29e659aeca578, []int{3}
+	return fileDescriptor_c8a29e659aeca578, []int{4}
 }
 func (m *PeerInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -299,18 +405,33 @@ func (m *PeerInfo) GetLastConnected() *time.Time {
 	return nil
 }
 
+func (m *PeerInfo) GetNetwork() string {
+	if m != nil {
+		return m.Network
+	}
+	return ""
+}
+
+func (m *PeerInfo) GetNodeInfo() *NodeInfo {
+	if m != nil {
+		return m.NodeInfo
+	}
+	return nil
+}
+
 type PeerAddressInfo struct {
 	Address         string     `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
 	LastDialSuccess *time.Time `protobuf:"bytes,2,opt,name=last_dial_success,json=lastDialSuccess,proto3,stdtime" json:"last_dial_success,omitempty"`
 	LastDialFailure *time.Time `protobuf:"bytes,3,opt,name=last_dial_failure,json=lastDialFailure,proto3,stdtime" json:"last_dial_failure,omitempty"`
 	DialFailures    uint32     `protobuf:"varint,4,opt,name=dial_failures,json=dialFailures,proto3" json:"dial_failures,omitempty"`
+	Source          string     `protobuf:"bytes,5,opt,name=source,proto3" json:"source,omitempty"`
 }
 
 func (m *PeerAddressInfo) Reset()         { *m = PeerAddressInfo{} }
 func (m *PeerAddressInfo) String() string { return proto.CompactTextString(m) }
 func (*PeerAddressInfo) ProtoMessage()    {}
 func (*PeerAddressInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c8a29e659aeca578, []int{4}
+	return fileDescriptor_c8a29e659aeca578, []int{5}
 }
 func (m *PeerAddressInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -367,10 +488,18 @@ func (m *PeerAddressInfo) GetDialFailures() uint32 {
 	return 0
 }
 
+func (m *PeerAddressInfo) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*ProtocolVersion)(nil), "tendermint.p2p.ProtocolVersion")
 	proto.RegisterType((*NodeInfo)(nil), "tendermint.p2p.NodeInfo")
 	proto.RegisterType((*NodeInfoOther)(nil), "tendermint.p2p.NodeInfoOther")
+	proto.RegisterType((*ValidatorProof)(nil), "tendermint.p2p.ValidatorProof")
 	proto.RegisterType((*PeerInfo)(nil), "tendermint.p2p.PeerInfo")
 	proto.RegisterType((*PeerAddressInfo)(nil), "tendermint.p2p.PeerAddressInfo")
 }
@@ -378,46 +507,58 @@ func init() {
 func init() { proto.RegisterFile("tendermint/p2p/types.proto", fileDescriptor_c8a29e659aeca578) }
 
 var fileDescriptor_c8a29e659aeca578 = []byte{
-	// 610 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0xcd, 0x4e, 0x1b, 0x3d,
-	0x14, 0xcd, 0x24, 0x21, 0x09, 0x37, 0x84, 0xf0, 0x59, 0xe8, 0xd3, 0x10, 0xa9, 0x19, 0x14, 0x36,
-	0xac, 0x26, 0x52, 0xaa, 0x2e, 0xba, 0x64, 0x40, 0xad, 0x22, 0x55, 0x25, 0x9a, 0xa2, 0x2e, 0xda,
-	0xc5, 0x68, 0x32, 0x76, 0x82, 0xc5, 0xc4, 0xb6, 0x3c, 0x4e, 0x4b, 0xdf, 0x82, 0x37, 0xe9, 0x63,
-	0x94, 0x25, 0xcb, 0xae, 0xd2, 0x6a, 0xd8, 0xf6, 0x21, 0x2a, 0xdb, 0x33, 0x40, 0xa2, 0x2e, 0xd8,
-	0xf9, 0xdc, 0xe3, 0x73, 0xee, 0x8f, 0xad, 0x0b, 0x3d, 0x45, 0x18, 0x26, 0x72, 0x41, 0x99, 0x1a,
-	0x8a, 0x91, 0x18, 0xaa, 0x6f, 0x82, 0x64, 0xbe, 0x90, 0x5c, 0x71, 0xb4, 0xfb, 0xc8, 0xf9, 0x62,
-	0x24, 0x7a, 0xfb, 0x73, 0x3e, 0xe7, 0x86, 0x1a, 0xea, 0x93, 0xbd, 0xd5, 0xf3, 0xe6, 0x9c, 0xcf,
-	0x53, 0x32, 0x34, 0x68, 0xba, 0x9c, 0x0d, 0x15, 0x5d, 0x90, 0x4c, 0xc5, 0x0b, 0x61, 0x2f, 0x0c,
-	0x2e, 0xa0, 0x3b, 0xd1, 0x87, 0x84, 0xa7, 0x1f, 0x89, 0xcc, 0x28, 0x67, 0xe8, 0x00, 0x6a, 0x62,
-	0x24, 0x5c, 0xe7, 0xd0, 0x39, 0xae, 0x07, 0xcd, 0x7c, 0xe5, 0xd5, 0x26, 0xa3, 0x49, 0xa8, 0x63,
-	0x68, 0x1f, 0xb6, 0xa6, 0x29, 0x4f, 0xae, 0xdc, 0xaa, 0x26, 0x43, 0x0b, 0xd0, 0x1e, 0xd4, 0x62,
-	0x21, 0xdc, 0x9a, 0x89, 0xe9, 0xe3, 0xe0, 0x47, 0x15, 0x5a, 0xef, 0x39, 0x26, 0x63, 0x36, 0xe3,
-	0x68, 0x02, 0x7b, 0xa2, 0x48, 0x11, 0x7d, 0xb1, 0x39, 0x8c, 0x79, 0x7b, 0xe4, 0xf9, 0xeb, 0x4d,
-	0xf8, 0x1b, 0xa5, 0x04, 0xf5, 0xdb, 0x95, 0x57, 0x09, 0xbb, 0x62, 0xa3, 0xc2, 0x23, 0x68, 0x32,
-	0x8e, 0x49, 0x44, 0xb1, 0x29, 0x64, 0x3b, 0x80, 0x7c, 0xe5, 0x35, 0x4c, 0xc2, 0xb3, 0xb0, 0xa1,
-	0xa9, 0x31, 0x46, 0x1e, 0xb4, 0x53, 0x9a, 0x29, 0xc2, 0xa2, 0x18, 0x63, 0x69, 0xaa, 0xdb, 0x0e,
-	0xc1, 0x86, 0x4e, 0x30, 0x96, 0xc8, 0x85, 0x26, 0x23, 0xea, 0x2b, 0x97, 0x57, 0x6e, 0xdd, 0x90,
-	0x25, 0xd4, 0x4c, 0x59, 0xe8, 0x96, 0x65, 0x0a, 0x88, 0x7a, 0xd0, 0x4a, 0x2e, 0x63, 0xc6, 0x48,
-	0x9a, 0xb9, 0x8d, 0x43, 0xe7, 0x78, 0x27, 0x7c, 0xc0, 0x5a, 0xb5, 0xe0, 0x8c, 0x5e, 0x11, 0xe9,
-	0x36, 0xad, 0xaa, 0x80, 0xe8, 0x35, 0x6c, 0x71, 0x75, 0x49, 0xa4, 0xdb, 0x32, 0x6d, 0xbf, 0xd8,
-	0x6c, 0xbb, 0x1c, 0xd5, 0xb9, 0xbe, 0x54, 0x34, 0x6d, 0x15, 0x83, 0xcf, 0xd0, 0x59, 0x63, 0xd1,
-	0x01, 0xb4, 0xd4, 0x75, 0x44, 0x19, 0x26, 0xd7, 0x66, 0x8a, 0xdb, 0x61, 0x53, 0x5d, 0x8f, 0x35,
-	0x44, 0x43, 0x68, 0x4b, 0x91, 0x98, 0x76, 0x49, 0x96, 0x15, 0xa3, 0xd9, 0xcd, 0x57, 0x1e, 0x84,
-	0x93, 0xd3, 0x13, 0x1b, 0x0d, 0x41, 0x8a, 0xa4, 0x38, 0x0f, 0xbe, 0x3b, 0xd0, 0x9a, 0x10, 0x22,
-	0xcd, 0x33, 0xfd, 0x0f, 0x55, 0x8a, 0xad, 0x65, 0xd0, 0xc8, 0x57, 0x5e, 0x75, 0x7c, 0x16, 0x56,
-	0x29, 0x46, 0x01, 0xec, 0x14, 0x8e, 0x11, 0x65, 0x33, 0xee, 0x56, 0x0f, 0x6b, 0xff, 0x7c, 0x3a,
-	0x42, 0x64, 0xe1, 0xab, 0xed, 0xc2, 0x76, 0xfc, 0x08, 0xd0, 0x5b, 0xd8, 0x4d, 0xe3, 0x4c, 0x45,
-	0x09, 0x67, 0x8c, 0x24, 0x8a, 0x60, 0xf3, 0x1c, 0xed, 0x51, 0xcf, 0xb7, 0xff, 0xd3, 0x2f, 0xff,
-	0xa7, 0x7f, 0x51, 0xfe, 0xcf, 0xa0, 0x7e, 0xf3, 0xcb, 0x73, 0xc2, 0x8e, 0xd6, 0x9d, 0x96, 0xb2,
-	0xc1, 0x1f, 0x07, 0xba, 0x1b, 0x99, 0xf4, 0xdc, 0xcb, 0x96, 0x8b, 0x81, 0x14, 0x10, 0xbd, 0x83,
-	0xff, 0x4c, 0x5a, 0x4c, 0xe3, 0x34, 0xca, 0x96, 0x49, 0x52, 0x8e, 0xe5, 0x39, 0x99, 0xbb, 0x5a,
-	0x7a, 0x46, 0xe3, 0xf4, 0x83, 0x15, 0xae, 0xbb, 0xcd, 0x62, 0x9a, 0x2e, 0x25, 0x79, 0x76, 0x1f,
-	0x0f, 0x6e, 0x6f, 0xac, 0x10, 0x1d, 0x41, 0xe7, 0xa9, 0x51, 0x66, 0xfe, 0x60, 0x27, 0xdc, 0xc1,
-	0x8f, 0x77, 0xb2, 0xe0, 0xfc, 0x36, 0xef, 0x3b, 0x77, 0x79, 0xdf, 0xf9, 0x9d, 0xf7, 0x9d, 0x9b,
-	0xfb, 0x7e, 0xe5, 0xee, 0xbe, 0x5f, 0xf9, 0x79, 0xdf, 0xaf, 0x7c, 0x7a, 0x35, 0xa7, 0xea, 0x72,
-	0x39, 0xf5, 0x13, 0xbe, 0x18, 0x3e, 0xd9, 0x12, 0x4f, 0x17, 0x86, 0xd9, 0x05, 0xeb, 0x1b, 0x64,
-	0xda, 0x30, 0xd1, 0x97, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x0b, 0xe9, 0x56, 0xd3, 0x5a, 0x04,
-	0x00, 0x00,
+	// 807 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0xb9, 0x8e, 0x1b, 0x37,
+	0x18, 0x5e, 0x1d, 0xab, 0xe3, 0xd7, 0xe5, 0x10, 0x86, 0x31, 0x16, 0x36, 0x1a, 0x45, 0x6e, 0xb6,
+	0x1a, 0x01, 0x0a, 0x5c, 0x04, 0xa9, 0x2c, 0x2f, 0xe2, 0x2c, 0x1c, 0xc4, 0xc2, 0xc4, 0x70, 0x91,
+	0x14, 0x83, 0x99, 0x21, 0x25, 0x11, 0x1a, 0x91, 0x04, 0x49, 0x6d, 0x56, 0x5d, 0x8a, 0x3c, 0x80,
+	0x9f, 0x20, 0xcf, 0xe3, 0xd2, 0x65, 0x2a, 0x25, 0xd0, 0xbe, 0x48, 0x40, 0x0e, 0x27, 0x3a, 0x90,
+	0x04, 0x71, 0xc7, 0xef, 0x3f, 0xbf, 0xff, 0xe0, 0x0f, 0x7d, 0x4d, 0x18, 0x26, 0x72, 0x4d, 0x99,
+	0x1e, 0x8b, 0x89, 0x18, 0xeb, 0xad, 0x20, 0x2a, 0x10, 0x92, 0x6b, 0x8e, 0xba, 0x07, 0x5d, 0x20,
+	0x26, 0xa2, 0xff, 0x78, 0xc1, 0x17, 0xdc, 0xaa, 0xc6, 0xe6, 0x95, 0x5b, 0xf5, 0xfd, 0x05, 0xe7,
+	0x8b, 0x8c, 0x8c, 0x2d, 0x4a, 0x36, 0xf3, 0xb1, 0xa6, 0x6b, 0xa2, 0x74, 0xbc, 0x16, 0xce, 0xe0,
+	0xea, 0x28, 0x45, 0x2a, 0xb7, 0x42, 0xf3, 0xf1, 0x8a, 0x6c, 0x5d, 0x92, 0xd1, 0x5b, 0xe8, 0xcd,
+	0xcc, 0x23, 0xe5, 0xd9, 0x3b, 0x22, 0x15, 0xe5, 0x0c, 0x3d, 0x85, 0x8a, 0x98, 0x08, 0xaf, 0x34,
+	0x2c, 0x5d, 0x57, 0xa7, 0xf5, 0xfd, 0xce, 0xaf, 0xcc, 0x26, 0xb3, 0xd0, 0xc8, 0xd0, 0x63, 0xb8,
+	0x4c, 0x32, 0x9e, 0xae, 0xbc, 0xb2, 0x51, 0x86, 0x39, 0x40, 0x8f, 0xa0, 0x12, 0x0b, 0xe1, 0x55,
+	0xac, 0xcc, 0x3c, 0x47, 0xbf, 0x55, 0xa1, 0xf1, 0x3d, 0xc7, 0xe4, 0x96, 0xcd, 0x39, 0x9a, 0xc1,
+	0x23, 0xe1, 0x52, 0x44, 0x77, 0x79, 0x0e, 0x1b, 0xbc, 0x35, 0xf1, 0x83, 0xd3, 0x12, 0x83, 0x33,
+	0x2a, 0xd3, 0xea, 0x87, 0x9d, 0x7f, 0x11, 0xf6, 0xc4, 0x19, 0xc3, 0x67, 0x50, 0x67, 0x1c, 0x93,
+	0x88, 0x62, 0x4b, 0xa4, 0x39, 0x85, 0xfd, 0xce, 0xaf, 0xd9, 0x84, 0x37, 0x61, 0xcd, 0xa8, 0x6e,
+	0x31, 0xf2, 0xa1, 0x95, 0x51, 0xa5, 0x09, 0x8b, 0x62, 0x8c, 0xa5, 0x65, 0xd7, 0x0c, 0x21, 0x17,
+	0xbd, 0xc0, 0x58, 0x22, 0x0f, 0xea, 0x8c, 0xe8, 0x9f, 0xb9, 0x5c, 0x79, 0x55, 0xab, 0x2c, 0xa0,
+	0xd1, 0x14, 0x44, 0x2f, 0x73, 0x8d, 0x83, 0xa8, 0x0f, 0x8d, 0x74, 0x19, 0x33, 0x46, 0x32, 0xe5,
+	0xd5, 0x86, 0xa5, 0xeb, 0x76, 0xf8, 0x37, 0x36, 0x5e, 0x6b, 0xce, 0xe8, 0x8a, 0x48, 0xaf, 0x9e,
+	0x7b, 0x39, 0x88, 0xbe, 0x82, 0x4b, 0xae, 0x97, 0x44, 0x7a, 0x0d, 0x5b, 0xf6, 0xe7, 0xe7, 0x65,
+	0x17, 0xad, 0x7a, 0x63, 0x8c, 0x5c, 0xd1, 0xb9, 0x07, 0x7a, 0x05, 0xbd, 0xbb, 0x38, 0xa3, 0x38,
+	0xd6, 0x5c, 0x46, 0x42, 0x72, 0x3e, 0xf7, 0x9a, 0x36, 0xc8, 0xe0, 0x3c, 0xc8, 0xbb, 0xc2, 0x6c,
+	0x66, 0xac, 0xc2, 0xee, 0xdd, 0x09, 0x46, 0xcf, 0xa0, 0xc3, 0x13, 0x45, 0xe4, 0x1d, 0xc1, 0x79,
+	0x43, 0xc0, 0x72, 0x6c, 0x17, 0x42, 0xdb, 0x92, 0x21, 0xb4, 0x52, 0xbe, 0x16, 0x92, 0x28, 0x5b,
+	0x7c, 0x6b, 0x58, 0xb9, 0x6e, 0x86, 0xc7, 0x22, 0x34, 0x82, 0x76, 0x1a, 0x8b, 0x38, 0xa1, 0x19,
+	0xd5, 0x94, 0x28, 0xaf, 0x6d, 0x87, 0x7e, 0x22, 0x43, 0x5f, 0x40, 0x7b, 0x41, 0x18, 0x51, 0x54,
+	0x45, 0xcb, 0x58, 0x2d, 0xbd, 0x8e, 0x6d, 0x54, 0xcb, 0xc9, 0xbe, 0x8d, 0xd5, 0x72, 0xf4, 0x13,
+	0x74, 0x4e, 0x8a, 0x46, 0x4f, 0xa1, 0xa1, 0xef, 0x23, 0xca, 0x30, 0xb9, 0xb7, 0xcb, 0xd1, 0x0c,
+	0xeb, 0xfa, 0xfe, 0xd6, 0x40, 0x34, 0x86, 0x96, 0x14, 0xa9, 0x25, 0x4d, 0x94, 0x72, 0x13, 0xef,
+	0xee, 0x77, 0x3e, 0x84, 0xb3, 0x97, 0x2f, 0x72, 0x69, 0x08, 0x52, 0xa4, 0xee, 0x3d, 0x5a, 0x41,
+	0xf7, 0xb4, 0x19, 0xe8, 0x6b, 0xa8, 0x8b, 0x4d, 0x12, 0xad, 0xc8, 0xd6, 0x6d, 0xde, 0xd5, 0x71,
+	0xf7, 0xf2, 0x5f, 0x11, 0xcc, 0x36, 0x49, 0x46, 0xd3, 0xd7, 0x64, 0xeb, 0x26, 0x50, 0x13, 0x9b,
+	0xe4, 0x35, 0xd9, 0xa2, 0x2b, 0x68, 0x2a, 0xba, 0x60, 0xb1, 0xde, 0x48, 0x62, 0xb3, 0xb7, 0xc3,
+	0x83, 0x60, 0xf4, 0x4b, 0x19, 0x1a, 0x33, 0x42, 0xa4, 0x5d, 0xf5, 0x27, 0x50, 0xa6, 0x38, 0xe7,
+	0x3f, 0xad, 0xed, 0x77, 0x7e, 0xf9, 0xf6, 0x26, 0x2c, 0x53, 0x8c, 0xa6, 0xd0, 0x76, 0xf4, 0x23,
+	0xca, 0xe6, 0xdc, 0x2b, 0x0f, 0x2b, 0xff, 0xb8, 0xfe, 0x84, 0x48, 0x57, 0x84, 0x09, 0x17, 0xb6,
+	0xe2, 0x03, 0x40, 0xaf, 0xa0, 0x9b, 0xc5, 0x4a, 0x47, 0x29, 0x67, 0x8c, 0xa4, 0x9a, 0x60, 0xbb,
+	0xd2, 0xad, 0x49, 0x3f, 0xc8, 0x2f, 0x40, 0x50, 0x5c, 0x80, 0xe0, 0x6d, 0x71, 0x01, 0xa6, 0xd5,
+	0xf7, 0x7f, 0xf8, 0xa5, 0xb0, 0x63, 0xfc, 0x5e, 0x16, 0x6e, 0xff, 0xb1, 0xf7, 0xcf, 0xa1, 0x99,
+	0xff, 0x2b, 0xc3, 0xf1, 0xd2, 0x46, 0xf7, 0xfe, 0x6d, 0x57, 0xc3, 0x06, 0x73, 0xaf, 0xd1, 0xaf,
+	0x65, 0xe8, 0x9d, 0x51, 0x37, 0x49, 0x8a, 0x81, 0xb9, 0x71, 0x3a, 0x88, 0xbe, 0x83, 0xcf, 0x6c,
+	0x1d, 0x98, 0xc6, 0x59, 0xa4, 0x36, 0x69, 0x5a, 0x0c, 0xf5, 0xff, 0x94, 0xd2, 0x33, 0xae, 0x37,
+	0x34, 0xce, 0x7e, 0xc8, 0x1d, 0x4f, 0xa3, 0xcd, 0x63, 0x9a, 0x99, 0x21, 0x55, 0x3e, 0x35, 0xda,
+	0x37, 0xb9, 0xa3, 0xf9, 0x24, 0xc7, 0x81, 0x94, 0x6d, 0x50, 0x27, 0x6c, 0xe3, 0x83, 0x8d, 0x42,
+	0x4f, 0xa0, 0xa6, 0xf8, 0x46, 0xa6, 0xc4, 0x1d, 0x07, 0x87, 0xa6, 0x6f, 0x7e, 0x7c, 0xbe, 0xa0,
+	0x7a, 0xb9, 0x49, 0x82, 0x94, 0xaf, 0xc7, 0x47, 0x57, 0xf7, 0xe8, 0x99, 0x9f, 0xef, 0xd3, 0xa3,
+	0xff, 0x61, 0x3f, 0x28, 0x7d, 0xdc, 0x0f, 0x4a, 0x7f, 0xee, 0x07, 0xa5, 0xf7, 0x0f, 0x83, 0x8b,
+	0x8f, 0x0f, 0x83, 0x8b, 0xdf, 0x1f, 0x06, 0x17, 0x49, 0xcd, 0x5a, 0x7f, 0xf9, 0xd7, 0x00, 0xc4,
+	0x21, 0x19, 0xd0, 0x25, 0x06, 0x00, 0x00,
 }
 
 func (m *ProtocolVersion) Marshal() (dAtA []byte, err error) {
@@ -478,6 +619,46 @@ func (m *NodeInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.GenesisHash) > 0 {
+		i -= len(m.GenesisHash)
+		copy(dAtA[i:], m.GenesisHash)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.GenesisHash)))
+		i--
+		dAtA[i] = 0x6a
+	}
+	if m.Capabilities != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Capabilities))
+		i--
+		dAtA[i] = 0x60
+	}
+	if len(m.Compression) > 0 {
+		for iNdEx := len(m.Compression) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Compression[iNdEx])
+			copy(dAtA[i:], m.Compression[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.Compression[iNdEx])))
+			i--
+			dAtA[i] = 0x5a
+		}
+	}
+	if len(m.ObservedAddr) > 0 {
+		i -= len(m.ObservedAddr)
+		copy(dAtA[i:], m.ObservedAddr)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ObservedAddr)))
+		i--
+		dAtA[i] = 0x52
+	}
+	if m.ValidatorProof != nil {
+		{
+			size, err := m.ValidatorProof.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x4a
+	}
 	{
 		size, err := m.Other.MarshalToSizedBuffer(dAtA[:i])
 		if err != nil {
@@ -580,6 +761,46 @@ func (m *NodeInfoOther) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *ValidatorProof) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ValidatorProof) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ValidatorProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Signature) > 0 {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0x12
+	}
+	{
+		size, err := m.PubKey.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
 func (m *PeerInfo) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -600,13 +821,32 @@ func (m *PeerInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.NodeInfo != nil {
+		{
+			size, err := m.NodeInfo.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Network) > 0 {
+		i -= len(m.Network)
+		copy(dAtA[i:], m.Network)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Network)))
+		i--
+		dAtA[i] = 0x22
+	}
 	if m.LastConnected != nil {
-		n3, err3 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.LastConnected, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.LastConnected):])
-		if err3 != nil {
-			return 0, err3
+		n6, err6 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.LastConnected, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.LastConnected):])
+		if err6 != nil {
+			return 0, err6
 		}
-		i -= n3
-		i = encodeVarintTypes(dAtA, i, uint64(n3))
+		i -= n6
+		i = encodeVarintTypes(dAtA, i, uint64(n6))
 		i--
 		dAtA[i] = 0x1a
 	}
@@ -654,28 +894,35 @@ func (m *PeerAddressInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Source) > 0 {
+		i -= len(m.Source)
+		copy(dAtA[i:], m.Source)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Source)))
+		i--
+		dAtA[i] = 0x2a
+	}
 	if m.DialFailures != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.DialFailures))
 		i--
 		dAtA[i] = 0x20
 	}
 	if m.LastDialFailure != nil {
-		n4, err4 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.LastDialFailure, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.LastDialFailure):])
-		if err4 != nil {
-			return 0, err4
+		n7, err7 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.LastDialFailure, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.LastDialFailure):])
+		if err7 != nil {
+			return 0, err7
 		}
-		i -= n4
-		i = encodeVarintTypes(dAtA, i, uint64(n4))
+		i -= n7
+		i = encodeVarintTypes(dAtA, i, uint64(n7))
 		i--
 		dAtA[i] = 0x1a
 	}
 	if m.LastDialSuccess != nil {
-		n5, err5 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.LastDialSuccess, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.LastDialSuccess):])
-		if err5 != nil {
-			return 0, err5
+		n8, err8 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.LastDialSuccess, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.LastDialSuccess):])
+		if err8 != nil {
+			return 0, err8
 		}
-		i -= n5
-		i = encodeVarintTypes(dAtA, i, uint64(n5))
+		i -= n8
+		i = encodeVarintTypes(dAtA, i, uint64(n8))
 		i--
 		dAtA[i] = 0x12
 	}
@@ -752,6 +999,27 @@ func (m *NodeInfo) Size() (n int) {
 	}
 	l = m.Other.Size()
 	n += 1 + l + sovTypes(uint64(l))
+	if m.ValidatorProof != nil {
+		l = m.ValidatorProof.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.ObservedAddr)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if len(m.Compression) > 0 {
+		for _, s := range m.Compression {
+			l = len(s)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.Capabilities != 0 {
+		n += 1 + sovTypes(uint64(m.Capabilities))
+	}
+	l = len(m.GenesisHash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -772,6 +1040,21 @@ func (m *NodeInfoOther) Size() (n int) {
 	return n
 }
 
+func (m *ValidatorProof) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.PubKey.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
 func (m *PeerInfo) Size() (n int) {
 	if m == nil {
 		return 0
@@ -792,6 +1075,14 @@ func (m *PeerInfo) Size() (n int) {
 		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.LastConnected)
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	l = len(m.Network)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.NodeInfo != nil {
+		l = m.NodeInfo.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -816,6 +1107,10 @@ func (m *PeerAddressInfo) Size() (n int) {
 	if m.DialFailures != 0 {
 		n += 1 + sovTypes(uint64(m.DialFailures))
 	}
+	l = len(m.Source)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -1221,6 +1516,159 @@ func (m *NodeInfo) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidatorProof", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ValidatorProof == nil {
+				m.ValidatorProof = &ValidatorProof{}
+			}
+			if err := m.ValidatorProof.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ObservedAddr", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ObservedAddr = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Compression", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Compression = append(m.Compression, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Capabilities", wireType)
+			}
+			m.Capabilities = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Capabilities |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GenesisHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GenesisHash = append(m.GenesisHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.GenesisHash == nil {
+				m.GenesisHash = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -1356,6 +1804,123 @@ func (m *NodeInfoOther) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ValidatorProof) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ValidatorProof: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ValidatorProof: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PubKey", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.PubKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *PeerInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -1487,6 +2052,74 @@ func (m *PeerInfo) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Network", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Network = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeInfo", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.NodeInfo == nil {
+				m.NodeInfo = &NodeInfo{}
+			}
+			if err := m.NodeInfo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -1660,6 +2293,38 @@ func (m *PeerAddressInfo) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Source", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Source = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
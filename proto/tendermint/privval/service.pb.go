@@ -27,23 +27,28 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 func init() { proto.RegisterFile("tendermint/privval/service.proto", fileDescriptor_7afe74f9f46d3dc9) }
 
 var fileDescriptor_7afe74f9f46d3dc9 = []byte{
-	// 251 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x52, 0x28, 0x49, 0xcd, 0x4b,
-	0x49, 0x2d, 0xca, 0xcd, 0xcc, 0x2b, 0xd1, 0x2f, 0x28, 0xca, 0x2c, 0x2b, 0x4b, 0xcc, 0xd1, 0x2f,
-	0x4e, 0x2d, 0x2a, 0xcb, 0x4c, 0x4e, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x12, 0x42, 0xa8,
-	0xd0, 0x83, 0xaa, 0x90, 0x92, 0xc3, 0xa2, 0xab, 0xa4, 0xb2, 0x20, 0xb5, 0x18, 0xa2, 0xc7, 0x68,
-	0x09, 0x13, 0x97, 0x40, 0x40, 0x51, 0x66, 0x59, 0x58, 0x62, 0x4e, 0x66, 0x4a, 0x62, 0x49, 0x7e,
-	0x91, 0x63, 0x80, 0xa7, 0x50, 0x10, 0x17, 0xa7, 0x7b, 0x6a, 0x49, 0x40, 0x69, 0x92, 0x77, 0x6a,
-	0xa5, 0x90, 0xa2, 0x1e, 0xa6, 0xb1, 0x7a, 0x10, 0xb9, 0xa0, 0xd4, 0xc2, 0xd2, 0xd4, 0xe2, 0x12,
-	0x29, 0x25, 0x7c, 0x4a, 0x8a, 0x0b, 0xf2, 0xf3, 0x8a, 0x53, 0x85, 0xc2, 0xb9, 0x38, 0x82, 0x33,
-	0xd3, 0xf3, 0xc2, 0xf2, 0x4b, 0x52, 0x85, 0x94, 0xb1, 0xa9, 0x87, 0xc9, 0xc2, 0x0c, 0x55, 0xc3,
-	0xa5, 0x28, 0x35, 0x05, 0xa2, 0x0c, 0x6a, 0x70, 0x32, 0x17, 0x0f, 0x48, 0x34, 0xa0, 0x28, 0xbf,
-	0x20, 0xbf, 0x38, 0x31, 0x47, 0x48, 0x1d, 0x97, 0x3e, 0x98, 0x0a, 0x98, 0x05, 0x5a, 0xb8, 0x2d,
-	0x40, 0x28, 0x85, 0x58, 0xe2, 0x14, 0x7c, 0xe2, 0x91, 0x1c, 0xe3, 0x85, 0x47, 0x72, 0x8c, 0x0f,
-	0x1e, 0xc9, 0x31, 0x4e, 0x78, 0x2c, 0xc7, 0x70, 0xe1, 0xb1, 0x1c, 0xc3, 0x8d, 0xc7, 0x72, 0x0c,
-	0x51, 0x96, 0xe9, 0x99, 0x25, 0x19, 0xa5, 0x49, 0x7a, 0xc9, 0xf9, 0xb9, 0xfa, 0x48, 0x61, 0x8d,
-	0x12, 0xec, 0xf9, 0x25, 0xf9, 0xfa, 0x98, 0xf1, 0x90, 0xc4, 0x06, 0x96, 0x31, 0x06, 0x04, 0x00,
-	0x00, 0xff, 0xff, 0x42, 0x60, 0x24, 0x48, 0xda, 0x01, 0x00, 0x00,
+	// 331 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0xbd, 0x4a, 0x3b, 0x41,
+	0x14, 0xc5, 0xb3, 0x10, 0xfe, 0xfc, 0x1d, 0x2c, 0xc2, 0x14, 0x16, 0x29, 0xc6, 0xa8, 0xe0, 0x57,
+	0xb1, 0x8b, 0x1f, 0x8d, 0xa5, 0x36, 0x21, 0x28, 0xb2, 0x24, 0x10, 0xc1, 0x6e, 0x93, 0xbd, 0xae,
+	0x03, 0xc9, 0xdc, 0x75, 0xe6, 0x66, 0x21, 0xa5, 0x6f, 0xe0, 0x63, 0x59, 0xa6, 0xb4, 0xb0, 0x90,
+	0xe4, 0x45, 0x24, 0xee, 0x8e, 0x31, 0xec, 0x8e, 0xd8, 0xde, 0xfb, 0x3b, 0xe7, 0xec, 0xdd, 0x39,
+	0xac, 0x45, 0xa0, 0x62, 0xd0, 0x63, 0xa9, 0x28, 0x48, 0xb5, 0xcc, 0xb2, 0x68, 0x14, 0x18, 0xd0,
+	0x99, 0x1c, 0x82, 0x9f, 0x6a, 0x24, 0xe4, 0x7c, 0x45, 0xf8, 0x05, 0xd1, 0x14, 0x15, 0x2a, 0x9a,
+	0xa6, 0x60, 0x72, 0xcd, 0xe9, 0x7b, 0x9d, 0x35, 0x42, 0x2d, 0xb3, 0x7e, 0x34, 0x92, 0x71, 0x44,
+	0xa8, 0x2f, 0xc3, 0x0e, 0xef, 0xb2, 0x8d, 0x36, 0x50, 0x38, 0x19, 0x5c, 0xc3, 0x94, 0xef, 0xf8,
+	0x65, 0x5b, 0x3f, 0xdf, 0x75, 0xe1, 0x69, 0x02, 0x86, 0x9a, 0xbb, 0xbf, 0x21, 0x26, 0x45, 0x65,
+	0x80, 0xdf, 0xb1, 0xff, 0x3d, 0x99, 0xa8, 0x3e, 0x12, 0xf0, 0xbd, 0x2a, 0xde, 0x6e, 0xad, 0xe9,
+	0xbe, 0x0b, 0x82, 0x38, 0xc7, 0x0a, 0xe3, 0x21, 0xdb, 0x5c, 0x4e, 0x43, 0x8d, 0x29, 0x9a, 0x68,
+	0xc4, 0x0f, 0x5c, 0x3a, 0x4b, 0xd8, 0x80, 0x63, 0x77, 0xc0, 0x0a, 0x2d, 0x42, 0x3a, 0xac, 0x1e,
+	0x4a, 0x95, 0xf0, 0xed, 0xca, 0x4b, 0xa5, 0x4a, 0xac, 0x69, 0xcb, 0x0d, 0x14, 0x56, 0x09, 0x6b,
+	0xb4, 0x81, 0x6e, 0x22, 0x43, 0xcb, 0xac, 0x1e, 0x45, 0x04, 0xfc, 0xb0, 0x4a, 0xb5, 0x86, 0x58,
+	0xff, 0xa3, 0x3f, 0x90, 0x45, 0xd0, 0xb3, 0xc7, 0xb6, 0x96, 0xd3, 0x5b, 0x8c, 0xe1, 0xfb, 0x79,
+	0x43, 0x8d, 0xf8, 0xc0, 0x4f, 0x5c, 0xa7, 0x97, 0x59, 0x1b, 0x7c, 0xee, 0xfe, 0x5b, 0x55, 0xa2,
+	0xfc, 0x1b, 0xae, 0x7a, 0xaf, 0x73, 0xe1, 0xcd, 0xe6, 0xc2, 0xfb, 0x98, 0x0b, 0xef, 0x65, 0x21,
+	0x6a, 0xb3, 0x85, 0xa8, 0xbd, 0x2d, 0x44, 0xed, 0xfe, 0x22, 0x91, 0xf4, 0x38, 0x19, 0xf8, 0x43,
+	0x1c, 0x07, 0x3f, 0x3a, 0xba, 0x56, 0x57, 0x24, 0x0c, 0xca, 0xfd, 0x1d, 0xfc, 0xfb, 0xda, 0x9c,
+	0x7d, 0x0e, 0x00, 0xdd, 0x15, 0xf7, 0xf3, 0x12, 0x03, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -61,6 +66,9 @@ type PrivValidatorAPIClient interface {
 	GetPubKey(ctx context.Context, in *PubKeyRequest, opts ...grpc.CallOption) (*PubKeyResponse, error)
 	SignVote(ctx context.Context, in *SignVoteRequest, opts ...grpc.CallOption) (*SignedVoteResponse, error)
 	SignProposal(ctx context.Context, in *SignProposalRequest, opts ...grpc.CallOption) (*SignedProposalResponse, error)
+	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
+	GetLastSignState(ctx context.Context, in *LastSignStateRequest, opts ...grpc.CallOption) (*LastSignStateResponse, error)
+	SignNodeValidatorProof(ctx context.Context, in *SignNodeValidatorProofRequest, opts ...grpc.CallOption) (*SignedNodeValidatorProofResponse, error)
 }
 
 type privValidatorAPIClient struct {
@@ -98,11 +106,41 @@ func (c *privValidatorAPIClient) SignProposal(ctx context.Context, in *SignPropo
 	return out, nil
 }
 
+func (c *privValidatorAPIClient) Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error) {
+	out := new(PingResponse)
+	err := c.cc.Invoke(ctx, "/tendermint.privval.PrivValidatorAPI/Ping", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *privValidatorAPIClient) GetLastSignState(ctx context.Context, in *LastSignStateRequest, opts ...grpc.CallOption) (*LastSignStateResponse, error) {
+	out := new(LastSignStateResponse)
+	err := c.cc.Invoke(ctx, "/tendermint.privval.PrivValidatorAPI/GetLastSignState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *privValidatorAPIClient) SignNodeValidatorProof(ctx context.Context, in *SignNodeValidatorProofRequest, opts ...grpc.CallOption) (*SignedNodeValidatorProofResponse, error) {
+	out := new(SignedNodeValidatorProofResponse)
+	err := c.cc.Invoke(ctx, "/tendermint.privval.PrivValidatorAPI/SignNodeValidatorProof", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PrivValidatorAPIServer is the server API for PrivValidatorAPI service.
 type PrivValidatorAPIServer interface {
 	GetPubKey(context.Context, *PubKeyRequest) (*PubKeyResponse, error)
 	SignVote(context.Context, *SignVoteRequest) (*SignedVoteResponse, error)
 	SignProposal(context.Context, *SignProposalRequest) (*SignedProposalResponse, error)
+	Ping(context.Context, *PingRequest) (*PingResponse, error)
+	GetLastSignState(context.Context, *LastSignStateRequest) (*LastSignStateResponse, error)
+	SignNodeValidatorProof(context.Context, *SignNodeValidatorProofRequest) (*SignedNodeValidatorProofResponse, error)
 }
 
 // UnimplementedPrivValidatorAPIServer can be embedded to have forward compatible implementations.
@@ -118,6 +156,15 @@ func (*UnimplementedPrivValidatorAPIServer) SignVote(ctx context.Context, req *S
 func (*UnimplementedPrivValidatorAPIServer) SignProposal(ctx context.Context, req *SignProposalRequest) (*SignedProposalResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SignProposal not implemented")
 }
+func (*UnimplementedPrivValidatorAPIServer) Ping(ctx context.Context, req *PingRequest) (*PingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
+}
+func (*UnimplementedPrivValidatorAPIServer) GetLastSignState(ctx context.Context, req *LastSignStateRequest) (*LastSignStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLastSignState not implemented")
+}
+func (*UnimplementedPrivValidatorAPIServer) SignNodeValidatorProof(ctx context.Context, req *SignNodeValidatorProofRequest) (*SignedNodeValidatorProofResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignNodeValidatorProof not implemented")
+}
 
 func RegisterPrivValidatorAPIServer(s *grpc.Server, srv PrivValidatorAPIServer) {
 	s.RegisterService(&_PrivValidatorAPI_serviceDesc, srv)
@@ -177,6 +224,60 @@ func _PrivValidatorAPI_SignProposal_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PrivValidatorAPI_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivValidatorAPIServer).Ping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.privval.PrivValidatorAPI/Ping",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivValidatorAPIServer).Ping(ctx, req.(*PingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrivValidatorAPI_GetLastSignState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LastSignStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivValidatorAPIServer).GetLastSignState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.privval.PrivValidatorAPI/GetLastSignState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivValidatorAPIServer).GetLastSignState(ctx, req.(*LastSignStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrivValidatorAPI_SignNodeValidatorProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignNodeValidatorProofRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivValidatorAPIServer).SignNodeValidatorProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.privval.PrivValidatorAPI/SignNodeValidatorProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivValidatorAPIServer).SignNodeValidatorProof(ctx, req.(*SignNodeValidatorProofRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _PrivValidatorAPI_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "tendermint.privval.PrivValidatorAPI",
 	HandlerType: (*PrivValidatorAPIServer)(nil),
@@ -193,6 +294,18 @@ var _PrivValidatorAPI_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SignProposal",
 			Handler:    _PrivValidatorAPI_SignProposal_Handler,
 		},
+		{
+			MethodName: "Ping",
+			Handler:    _PrivValidatorAPI_Ping_Handler,
+		},
+		{
+			MethodName: "GetLastSignState",
+			Handler:    _PrivValidatorAPI_GetLastSignState_Handler,
+		},
+		{
+			MethodName: "SignNodeValidatorProof",
+			Handler:    _PrivValidatorAPI_SignNodeValidatorProof_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "tendermint/privval/service.proto",
@@ -498,6 +498,238 @@ func (m *PingResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_PingResponse proto.InternalMessageInfo
 
+// LastSignStateRequest requests the height, round and step of the last
+// signature of the remote signer.
+type LastSignStateRequest struct {
+	ChainId string `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+}
+
+func (m *LastSignStateRequest) Reset()         { *m = LastSignStateRequest{} }
+func (m *LastSignStateRequest) String() string { return proto.CompactTextString(m) }
+func (*LastSignStateRequest) ProtoMessage()    {}
+func (*LastSignStateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb4e437a5328cf9c, []int{9}
+}
+func (m *LastSignStateRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LastSignStateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LastSignStateRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LastSignStateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LastSignStateRequest.Merge(m, src)
+}
+func (m *LastSignStateRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *LastSignStateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LastSignStateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LastSignStateRequest proto.InternalMessageInfo
+
+func (m *LastSignStateRequest) GetChainId() string {
+	if m != nil {
+		return m.ChainId
+	}
+	return ""
+}
+
+// LastSignStateResponse is a response containing the height, round and step of
+// the last signature of the remote signer, or an error.
+type LastSignStateResponse struct {
+	Height int64              `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round  int32              `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	Step   int32              `protobuf:"varint,3,opt,name=step,proto3" json:"step,omitempty"`
+	Error  *RemoteSignerError `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (m *LastSignStateResponse) Reset()         { *m = LastSignStateResponse{} }
+func (m *LastSignStateResponse) String() string { return proto.CompactTextString(m) }
+func (*LastSignStateResponse) ProtoMessage()    {}
+func (*LastSignStateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb4e437a5328cf9c, []int{10}
+}
+func (m *LastSignStateResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LastSignStateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LastSignStateResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LastSignStateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LastSignStateResponse.Merge(m, src)
+}
+func (m *LastSignStateResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *LastSignStateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LastSignStateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LastSignStateResponse proto.InternalMessageInfo
+
+func (m *LastSignStateResponse) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *LastSignStateResponse) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *LastSignStateResponse) GetStep() int32 {
+	if m != nil {
+		return m.Step
+	}
+	return 0
+}
+
+func (m *LastSignStateResponse) GetError() *RemoteSignerError {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+// SignNodeValidatorProofRequest is a request to sign the proof binding the
+// node ID to the consensus key of the remote signer.
+type SignNodeValidatorProofRequest struct {
+	NodeId  string `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	ChainId string `protobuf:"bytes,2,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+}
+
+func (m *SignNodeValidatorProofRequest) Reset()         { *m = SignNodeValidatorProofRequest{} }
+func (m *SignNodeValidatorProofRequest) String() string { return proto.CompactTextString(m) }
+func (*SignNodeValidatorProofRequest) ProtoMessage()    {}
+func (*SignNodeValidatorProofRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb4e437a5328cf9c, []int{11}
+}
+func (m *SignNodeValidatorProofRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SignNodeValidatorProofRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SignNodeValidatorProofRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SignNodeValidatorProofRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignNodeValidatorProofRequest.Merge(m, src)
+}
+func (m *SignNodeValidatorProofRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SignNodeValidatorProofRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignNodeValidatorProofRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignNodeValidatorProofRequest proto.InternalMessageInfo
+
+func (m *SignNodeValidatorProofRequest) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *SignNodeValidatorProofRequest) GetChainId() string {
+	if m != nil {
+		return m.ChainId
+	}
+	return ""
+}
+
+// SignedNodeValidatorProofResponse is a response containing the consensus
+// public key and its signature over the node ID, or an error.
+type SignedNodeValidatorProofResponse struct {
+	PubKey    crypto.PublicKey   `protobuf:"bytes,1,opt,name=pub_key,json=pubKey,proto3" json:"pub_key"`
+	Signature []byte             `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	Error     *RemoteSignerError `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (m *SignedNodeValidatorProofResponse) Reset()         { *m = SignedNodeValidatorProofResponse{} }
+func (m *SignedNodeValidatorProofResponse) String() string { return proto.CompactTextString(m) }
+func (*SignedNodeValidatorProofResponse) ProtoMessage()    {}
+func (*SignedNodeValidatorProofResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb4e437a5328cf9c, []int{12}
+}
+func (m *SignedNodeValidatorProofResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SignedNodeValidatorProofResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SignedNodeValidatorProofResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SignedNodeValidatorProofResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignedNodeValidatorProofResponse.Merge(m, src)
+}
+func (m *SignedNodeValidatorProofResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *SignedNodeValidatorProofResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignedNodeValidatorProofResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignedNodeValidatorProofResponse proto.InternalMessageInfo
+
+func (m *SignedNodeValidatorProofResponse) GetPubKey() crypto.PublicKey {
+	if m != nil {
+		return m.PubKey
+	}
+	return crypto.PublicKey{}
+}
+
+func (m *SignedNodeValidatorProofResponse) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *SignedNodeValidatorProofResponse) GetError() *RemoteSignerError {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
 type Message struct {
 	// Types that are valid to be assigned to Sum:
 	//	*Message_PubKeyRequest
@@ -508,6 +740,10 @@ type Message struct {
 	//	*Message_SignedProposalResponse
 	//	*Message_PingRequest
 	//	*Message_PingResponse
+	//	*Message_LastSignStateRequest
+	//	*Message_LastSignStateResponse
+	//	*Message_SignNodeValidatorProofRequest
+	//	*Message_SignedNodeValidatorProofResponse
 	Sum isMessage_Sum `protobuf_oneof:"sum"`
 }
 
@@ -515,7 +751,7 @@ func (m *Message) Reset()         { *m = Message{} }
 func (m *Message) String() string { return proto.CompactTextString(m) }
 func (*Message) ProtoMessage()    {}
 func (*Message) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cb4e437a5328cf9c, []int{9}
+	return fileDescriptor_cb4e437a5328cf9c, []int{13}
 }
 func (m *Message) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -574,15 +810,31 @@ type Message_PingRequest struct {
 type Message_PingResponse struct {
 	PingResponse *PingResponse `protobuf:"bytes,8,opt,name=ping_response,json=pingResponse,proto3,oneof" json:"ping_response,omitempty"`
 }
+type Message_LastSignStateRequest struct {
+	LastSignStateRequest *LastSignStateRequest `protobuf:"bytes,9,opt,name=last_sign_state_request,json=lastSignStateRequest,proto3,oneof" json:"last_sign_state_request,omitempty"`
+}
+type Message_LastSignStateResponse struct {
+	LastSignStateResponse *LastSignStateResponse `protobuf:"bytes,10,opt,name=last_sign_state_response,json=lastSignStateResponse,proto3,oneof" json:"last_sign_state_response,omitempty"`
+}
+type Message_SignNodeValidatorProofRequest struct {
+	SignNodeValidatorProofRequest *SignNodeValidatorProofRequest `protobuf:"bytes,11,opt,name=sign_node_validator_proof_request,json=signNodeValidatorProofRequest,proto3,oneof" json:"sign_node_validator_proof_request,omitempty"`
+}
+type Message_SignedNodeValidatorProofResponse struct {
+	SignedNodeValidatorProofResponse *SignedNodeValidatorProofResponse `protobuf:"bytes,12,opt,name=signed_node_validator_proof_response,json=signedNodeValidatorProofResponse,proto3,oneof" json:"signed_node_validator_proof_response,omitempty"`
+}
 
-func (*Message_PubKeyRequest) isMessage_Sum()          {}
-func (*Message_PubKeyResponse) isMessage_Sum()         {}
-func (*Message_SignVoteRequest) isMessage_Sum()        {}
-func (*Message_SignedVoteResponse) isMessage_Sum()     {}
-func (*Message_SignProposalRequest) isMessage_Sum()    {}
-func (*Message_SignedProposalResponse) isMessage_Sum() {}
-func (*Message_PingRequest) isMessage_Sum()            {}
-func (*Message_PingResponse) isMessage_Sum()           {}
+func (*Message_PubKeyRequest) isMessage_Sum()                    {}
+func (*Message_PubKeyResponse) isMessage_Sum()                   {}
+func (*Message_SignVoteRequest) isMessage_Sum()                  {}
+func (*Message_SignedVoteResponse) isMessage_Sum()               {}
+func (*Message_SignProposalRequest) isMessage_Sum()              {}
+func (*Message_SignedProposalResponse) isMessage_Sum()           {}
+func (*Message_PingRequest) isMessage_Sum()                      {}
+func (*Message_PingResponse) isMessage_Sum()                     {}
+func (*Message_LastSignStateRequest) isMessage_Sum()             {}
+func (*Message_LastSignStateResponse) isMessage_Sum()            {}
+func (*Message_SignNodeValidatorProofRequest) isMessage_Sum()    {}
+func (*Message_SignedNodeValidatorProofResponse) isMessage_Sum() {}
 
 func (m *Message) GetSum() isMessage_Sum {
 	if m != nil {
@@ -647,6 +899,34 @@ func (m *Message) GetPingResponse() *PingResponse {
 	return nil
 }
 
+func (m *Message) GetLastSignStateRequest() *LastSignStateRequest {
+	if x, ok := m.GetSum().(*Message_LastSignStateRequest); ok {
+		return x.LastSignStateRequest
+	}
+	return nil
+}
+
+func (m *Message) GetLastSignStateResponse() *LastSignStateResponse {
+	if x, ok := m.GetSum().(*Message_LastSignStateResponse); ok {
+		return x.LastSignStateResponse
+	}
+	return nil
+}
+
+func (m *Message) GetSignNodeValidatorProofRequest() *SignNodeValidatorProofRequest {
+	if x, ok := m.GetSum().(*Message_SignNodeValidatorProofRequest); ok {
+		return x.SignNodeValidatorProofRequest
+	}
+	return nil
+}
+
+func (m *Message) GetSignedNodeValidatorProofResponse() *SignedNodeValidatorProofResponse {
+	if x, ok := m.GetSum().(*Message_SignedNodeValidatorProofResponse); ok {
+		return x.SignedNodeValidatorProofResponse
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Message) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -658,6 +938,10 @@ func (*Message) XXX_OneofWrappers() []interface{} {
 		(*Message_SignedProposalResponse)(nil),
 		(*Message_PingRequest)(nil),
 		(*Message_PingResponse)(nil),
+		(*Message_LastSignStateRequest)(nil),
+		(*Message_LastSignStateResponse)(nil),
+		(*Message_SignNodeValidatorProofRequest)(nil),
+		(*Message_SignedNodeValidatorProofResponse)(nil),
 	}
 }
 
@@ -673,7 +957,7 @@ func (m *AuthSigMessage) Reset()         { *m = AuthSigMessage{} }
 func (m *AuthSigMessage) String() string { return proto.CompactTextString(m) }
 func (*AuthSigMessage) ProtoMessage()    {}
 func (*AuthSigMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cb4e437a5328cf9c, []int{10}
+	return fileDescriptor_cb4e437a5328cf9c, []int{14}
 }
 func (m *AuthSigMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -727,6 +1011,10 @@ func init() {
 	proto.RegisterType((*SignedProposalResponse)(nil), "tendermint.privval.SignedProposalResponse")
 	proto.RegisterType((*PingRequest)(nil), "tendermint.privval.PingRequest")
 	proto.RegisterType((*PingResponse)(nil), "tendermint.privval.PingResponse")
+	proto.RegisterType((*LastSignStateRequest)(nil), "tendermint.privval.LastSignStateRequest")
+	proto.RegisterType((*LastSignStateResponse)(nil), "tendermint.privval.LastSignStateResponse")
+	proto.RegisterType((*SignNodeValidatorProofRequest)(nil), "tendermint.privval.SignNodeValidatorProofRequest")
+	proto.RegisterType((*SignedNodeValidatorProofResponse)(nil), "tendermint.privval.SignedNodeValidatorProofResponse")
 	proto.RegisterType((*Message)(nil), "tendermint.privval.Message")
 	proto.RegisterType((*AuthSigMessage)(nil), "tendermint.privval.AuthSigMessage")
 }
@@ -734,56 +1022,71 @@ func init() {
 func init() { proto.RegisterFile("tendermint/privval/types.proto", fileDescriptor_cb4e437a5328cf9c) }
 
 var fileDescriptor_cb4e437a5328cf9c = []byte{
-	// 779 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0x4b, 0x4f, 0xdb, 0x4a,
-	0x14, 0xb6, 0xc9, 0x0b, 0x4e, 0x1e, 0x84, 0x81, 0xcb, 0x0d, 0x11, 0xd7, 0xe4, 0xfa, 0xea, 0xb6,
-	0x28, 0x8b, 0xa4, 0xa2, 0x52, 0xa5, 0x8a, 0x6e, 0x78, 0x58, 0x4d, 0x14, 0xe1, 0xa4, 0x93, 0x50,
-	0x10, 0x52, 0x65, 0xe5, 0x31, 0x75, 0x2c, 0x88, 0xed, 0x7a, 0x1c, 0xa4, 0xac, 0xbb, 0xeb, 0xaa,
-	0x52, 0xff, 0x44, 0xd7, 0xfd, 0x15, 0x2c, 0x59, 0x76, 0x55, 0x55, 0xf0, 0x47, 0xaa, 0x8c, 0x27,
-	0x8e, 0xf3, 0x42, 0xad, 0xd8, 0xcd, 0x9c, 0x73, 0xe6, 0x3b, 0xdf, 0x37, 0xf3, 0xd9, 0x07, 0x24,
-	0x97, 0x98, 0x1d, 0xe2, 0xf4, 0x0c, 0xd3, 0x2d, 0xda, 0x8e, 0x71, 0x7d, 0xdd, 0xbc, 0x2a, 0xba,
-	0x03, 0x9b, 0xd0, 0x82, 0xed, 0x58, 0xae, 0x85, 0xd0, 0x38, 0x5f, 0xe0, 0xf9, 0xec, 0x76, 0xe0,
-	0x4c, 0xdb, 0x19, 0xd8, 0xae, 0x55, 0xbc, 0x24, 0x03, 0x7e, 0x62, 0x22, 0xcb, 0x90, 0x82, 0x78,
-	0xd9, 0x0d, 0xdd, 0xd2, 0x2d, 0xb6, 0x2c, 0x0e, 0x57, 0x5e, 0x54, 0x2e, 0xc3, 0x1a, 0x26, 0x3d,
-	0xcb, 0x25, 0x75, 0x43, 0x37, 0x89, 0xa3, 0x38, 0x8e, 0xe5, 0x20, 0x04, 0xe1, 0xb6, 0xd5, 0x21,
-	0x19, 0x31, 0x27, 0xee, 0x46, 0x30, 0x5b, 0xa3, 0x1c, 0xc4, 0x3b, 0x84, 0xb6, 0x1d, 0xc3, 0x76,
-	0x0d, 0xcb, 0xcc, 0x2c, 0xe5, 0xc4, 0xdd, 0x15, 0x1c, 0x0c, 0xc9, 0x79, 0x48, 0xd6, 0xfa, 0xad,
-	0x0a, 0x19, 0x60, 0xf2, 0xa1, 0x4f, 0xa8, 0x8b, 0xb6, 0x60, 0xb9, 0xdd, 0x6d, 0x1a, 0xa6, 0x66,
-	0x74, 0x18, 0xd4, 0x0a, 0x8e, 0xb1, 0x7d, 0xb9, 0x23, 0x7f, 0x12, 0x21, 0x35, 0x2a, 0xa6, 0xb6,
-	0x65, 0x52, 0x82, 0xf6, 0x21, 0x66, 0xf7, 0x5b, 0xda, 0x25, 0x19, 0xb0, 0xe2, 0xf8, 0xde, 0x76,
-	0x21, 0x70, 0x03, 0x9e, 0xda, 0x42, 0xad, 0xdf, 0xba, 0x32, 0xda, 0x15, 0x32, 0x38, 0x0c, 0xdf,
-	0xfc, 0xd8, 0x11, 0x70, 0xd4, 0x66, 0x20, 0x68, 0x1f, 0x22, 0x64, 0x48, 0x9d, 0xf1, 0x8a, 0xef,
-	0xfd, 0x5f, 0x98, 0xbd, 0xbc, 0xc2, 0x8c, 0x4e, 0xec, 0x9d, 0x91, 0xcf, 0x61, 0x75, 0x18, 0x7d,
-	0x6b, 0xb9, 0x64, 0x44, 0x3d, 0x0f, 0xe1, 0x6b, 0xcb, 0x25, 0x9c, 0xc9, 0x66, 0x10, 0xce, 0xbb,
-	0x53, 0x56, 0xcc, 0x6a, 0x26, 0x64, 0x2e, 0x4d, 0xca, 0xfc, 0x28, 0x02, 0x62, 0x0d, 0x3b, 0x1e,
-	0x38, 0x97, 0xfa, 0xec, 0x77, 0xd0, 0xb9, 0x42, 0xaf, 0xc7, 0xa3, 0xf4, 0x75, 0x61, 0x7d, 0x18,
-	0xad, 0x39, 0x96, 0x6d, 0xd1, 0xe6, 0xd5, 0x48, 0xe3, 0x0b, 0x58, 0xb6, 0x79, 0x88, 0x33, 0xc9,
-	0xce, 0x32, 0xf1, 0x0f, 0xf9, 0xb5, 0x0f, 0xe9, 0xfd, 0x22, 0xc2, 0xa6, 0xa7, 0x77, 0xdc, 0x8c,
-	0x6b, 0x7e, 0xf5, 0x27, 0xdd, 0xb8, 0xf6, 0x71, 0xcf, 0x47, 0xe9, 0x4f, 0x42, 0xbc, 0x66, 0x98,
-	0x3a, 0xd7, 0x2d, 0xa7, 0x20, 0xe1, 0x6d, 0x3d, 0x66, 0xf2, 0xb7, 0x08, 0xc4, 0x4e, 0x08, 0xa5,
-	0x4d, 0x9d, 0xa0, 0x0a, 0xac, 0x72, 0x13, 0x6a, 0x8e, 0x57, 0xce, 0xc9, 0xfe, 0x3b, 0xaf, 0xe3,
-	0x84, 0xdd, 0x4b, 0x02, 0x4e, 0xda, 0x13, 0xfe, 0x57, 0x21, 0x3d, 0x06, 0xf3, 0x9a, 0x71, 0xfe,
-	0xf2, 0x43, 0x68, 0x5e, 0x65, 0x49, 0xc0, 0x29, 0x7b, 0xf2, 0x0b, 0x79, 0x03, 0x6b, 0xd4, 0xd0,
-	0x4d, 0x6d, 0xe8, 0x08, 0x9f, 0x5e, 0x88, 0x01, 0xfe, 0x37, 0x0f, 0x70, 0xca, 0xd4, 0x25, 0x01,
-	0xaf, 0xd2, 0x29, 0x9f, 0x5f, 0xc0, 0x06, 0x65, 0xef, 0x35, 0x02, 0xe5, 0x34, 0xc3, 0x0c, 0xf5,
-	0xc9, 0x22, 0xd4, 0x49, 0x3f, 0x97, 0x04, 0x8c, 0xe8, 0xac, 0xcb, 0xdf, 0xc1, 0x5f, 0x8c, 0xee,
-	0xe8, 0x11, 0x7d, 0xca, 0x11, 0x06, 0xfe, 0x74, 0x11, 0xf8, 0x94, 0x4f, 0x4b, 0x02, 0x5e, 0xa7,
-	0x73, 0xec, 0xfb, 0x1e, 0x32, 0x9c, 0x7a, 0xa0, 0x01, 0xa7, 0x1f, 0x65, 0x1d, 0xf2, 0x8b, 0xe9,
-	0x4f, 0xdb, 0xb3, 0x24, 0xe0, 0x4d, 0x3a, 0xdf, 0xb8, 0xc7, 0x90, 0xb0, 0x0d, 0x53, 0xf7, 0xd9,
-	0xc7, 0x18, 0xf6, 0xce, 0xdc, 0x17, 0x1c, 0xbb, 0xac, 0x24, 0xe0, 0xb8, 0x3d, 0xde, 0xa2, 0xd7,
-	0x90, 0xe4, 0x28, 0x9c, 0xe2, 0x32, 0x83, 0xc9, 0x2d, 0x86, 0xf1, 0x89, 0x25, 0xec, 0xc0, 0xfe,
-	0x30, 0x02, 0x21, 0xda, 0xef, 0xc9, 0x1a, 0xa4, 0x0e, 0xfa, 0x6e, 0xb7, 0x6e, 0xe8, 0x23, 0xeb,
-	0x3e, 0xea, 0xff, 0x99, 0x86, 0x10, 0x35, 0x74, 0xe6, 0xce, 0x04, 0x1e, 0x2e, 0xf3, 0x5f, 0x45,
-	0x88, 0xb2, 0xaf, 0x88, 0x22, 0x04, 0x29, 0x05, 0xe3, 0x2a, 0xae, 0x6b, 0xa7, 0x6a, 0x45, 0xad,
-	0x9e, 0xa9, 0x69, 0x01, 0x49, 0x90, 0xf5, 0x63, 0xca, 0x79, 0x4d, 0x39, 0x6a, 0x28, 0xc7, 0x1a,
-	0x56, 0xea, 0xb5, 0xaa, 0x5a, 0x57, 0xd2, 0x22, 0xca, 0xc0, 0x06, 0xcf, 0xab, 0x55, 0xed, 0xa8,
-	0xaa, 0xaa, 0xca, 0x51, 0xa3, 0x5c, 0x55, 0xd3, 0x4b, 0xe8, 0x1f, 0xd8, 0xe2, 0x99, 0x71, 0x58,
-	0x6b, 0x94, 0x4f, 0x94, 0xea, 0x69, 0x23, 0x1d, 0x42, 0x7f, 0xc3, 0x3a, 0x4f, 0x63, 0xe5, 0xe0,
-	0xd8, 0x4f, 0x84, 0x03, 0x88, 0x67, 0xb8, 0xdc, 0x50, 0xfc, 0x4c, 0xe4, 0xb0, 0x7e, 0x73, 0x27,
-	0x89, 0xb7, 0x77, 0x92, 0xf8, 0xf3, 0x4e, 0x12, 0x3f, 0xdf, 0x4b, 0xc2, 0xed, 0xbd, 0x24, 0x7c,
-	0xbf, 0x97, 0x84, 0x8b, 0x97, 0xba, 0xe1, 0x76, 0xfb, 0xad, 0x42, 0xdb, 0xea, 0x15, 0x83, 0xc3,
-	0x31, 0x38, 0x79, 0x87, 0x03, 0x71, 0x76, 0x14, 0xb7, 0xa2, 0x2c, 0xf3, 0xfc, 0x57, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0x46, 0x64, 0xeb, 0xa4, 0xa7, 0x07, 0x00, 0x00,
+	// 1015 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xcb, 0x6e, 0xdb, 0x46,
+	0x14, 0x25, 0x2d, 0x4b, 0xb6, 0xaf, 0xfc, 0x50, 0xc6, 0xb2, 0xad, 0x18, 0xb6, 0xa2, 0xa8, 0x2f,
+	0xd7, 0x0b, 0xb9, 0x49, 0x8b, 0x02, 0x45, 0xba, 0x89, 0x6d, 0xa2, 0x14, 0xdc, 0x50, 0xea, 0x48,
+	0x79, 0x20, 0x40, 0x41, 0x50, 0xe2, 0x98, 0x22, 0x22, 0x73, 0x58, 0xce, 0xc8, 0x80, 0x16, 0x5d,
+	0x05, 0xe8, 0xa2, 0xab, 0x02, 0x05, 0xfa, 0x0d, 0xfd, 0x86, 0x7e, 0x41, 0x96, 0x59, 0x76, 0x55,
+	0x14, 0xf6, 0x8f, 0x14, 0x1c, 0x8e, 0x48, 0xca, 0x7a, 0x34, 0x8d, 0xbb, 0x9b, 0xb9, 0x77, 0xe6,
+	0xdc, 0x73, 0xee, 0xcc, 0x1c, 0x0c, 0x94, 0x39, 0xf1, 0x6c, 0x12, 0x5c, 0xb8, 0x1e, 0x3f, 0xf2,
+	0x03, 0xf7, 0xf2, 0xd2, 0xea, 0x1f, 0xf1, 0xa1, 0x4f, 0x58, 0xcd, 0x0f, 0x28, 0xa7, 0x08, 0x25,
+	0xf9, 0x9a, 0xcc, 0xef, 0xee, 0xa5, 0xf6, 0x74, 0x83, 0xa1, 0xcf, 0xe9, 0xd1, 0x2b, 0x32, 0x94,
+	0x3b, 0xc6, 0xb2, 0x02, 0x29, 0x8d, 0xb7, 0x5b, 0x74, 0xa8, 0x43, 0xc5, 0xf0, 0x28, 0x1c, 0x45,
+	0xd1, 0x6a, 0x1d, 0xee, 0x60, 0x72, 0x41, 0x39, 0x69, 0xb9, 0x8e, 0x47, 0x02, 0x2d, 0x08, 0x68,
+	0x80, 0x10, 0x2c, 0x76, 0xa9, 0x4d, 0x4a, 0x6a, 0x45, 0x3d, 0xc8, 0x62, 0x31, 0x46, 0x15, 0xc8,
+	0xdb, 0x84, 0x75, 0x03, 0xd7, 0xe7, 0x2e, 0xf5, 0x4a, 0x0b, 0x15, 0xf5, 0x60, 0x05, 0xa7, 0x43,
+	0xd5, 0x43, 0x58, 0x6b, 0x0e, 0x3a, 0x67, 0x64, 0x88, 0xc9, 0x0f, 0x03, 0xc2, 0x38, 0xba, 0x0b,
+	0xcb, 0xdd, 0x9e, 0xe5, 0x7a, 0xa6, 0x6b, 0x0b, 0xa8, 0x15, 0xbc, 0x24, 0xe6, 0x75, 0xbb, 0xfa,
+	0xb3, 0x0a, 0xeb, 0xa3, 0xc5, 0xcc, 0xa7, 0x1e, 0x23, 0xe8, 0x11, 0x2c, 0xf9, 0x83, 0x8e, 0xf9,
+	0x8a, 0x0c, 0xc5, 0xe2, 0xfc, 0xc3, 0xbd, 0x5a, 0xaa, 0x03, 0x91, 0xda, 0x5a, 0x73, 0xd0, 0xe9,
+	0xbb, 0xdd, 0x33, 0x32, 0x3c, 0x5e, 0x7c, 0xf3, 0xd7, 0x3d, 0x05, 0xe7, 0x7c, 0x01, 0x82, 0x1e,
+	0x41, 0x96, 0x84, 0xd4, 0x05, 0xaf, 0xfc, 0xc3, 0x8f, 0x6a, 0x93, 0xcd, 0xab, 0x4d, 0xe8, 0xc4,
+	0xd1, 0x9e, 0xea, 0x0b, 0xd8, 0x08, 0xa3, 0xcf, 0x28, 0x27, 0x23, 0xea, 0x87, 0xb0, 0x78, 0x49,
+	0x39, 0x91, 0x4c, 0xb6, 0xd3, 0x70, 0x51, 0x4f, 0xc5, 0x62, 0xb1, 0x66, 0x4c, 0xe6, 0xc2, 0xb8,
+	0xcc, 0xd7, 0x2a, 0x20, 0x51, 0xd0, 0x8e, 0xc0, 0xa5, 0xd4, 0xcf, 0xde, 0x05, 0x5d, 0x2a, 0x8c,
+	0x6a, 0xdc, 0x4a, 0x5f, 0x0f, 0x36, 0xc3, 0x68, 0x33, 0xa0, 0x3e, 0x65, 0x56, 0x7f, 0xa4, 0xf1,
+	0x4b, 0x58, 0xf6, 0x65, 0x48, 0x32, 0xd9, 0x9d, 0x64, 0x12, 0x6f, 0x8a, 0xd7, 0xce, 0xd3, 0xfb,
+	0xab, 0x0a, 0xdb, 0x91, 0xde, 0xa4, 0x98, 0xd4, 0xfc, 0xf5, 0x7f, 0xa9, 0x26, 0xb5, 0x27, 0x35,
+	0x6f, 0xa5, 0x7f, 0x0d, 0xf2, 0x4d, 0xd7, 0x73, 0xa4, 0xee, 0xea, 0x3a, 0xac, 0x46, 0xd3, 0x88,
+	0x59, 0xf5, 0x01, 0x14, 0xbf, 0xb5, 0x18, 0x0f, 0x37, 0xb6, 0xb8, 0x95, 0xdc, 0x81, 0x39, 0xd7,
+	0xf7, 0x37, 0x15, 0xb6, 0x6e, 0xec, 0x91, 0x32, 0xb7, 0x21, 0xd7, 0x23, 0xae, 0xd3, 0xe3, 0x62,
+	0x4b, 0x06, 0xcb, 0x19, 0x2a, 0x42, 0x36, 0xa0, 0x03, 0x2f, 0xea, 0x58, 0x16, 0x47, 0x93, 0xf0,
+	0xa1, 0x31, 0x4e, 0xfc, 0x52, 0x26, 0x7a, 0x68, 0xe1, 0x38, 0x91, 0xba, 0xf8, 0x1e, 0x52, 0x5b,
+	0xb0, 0x1f, 0x46, 0x0d, 0x6a, 0x93, 0x67, 0x56, 0xdf, 0xb5, 0x2d, 0x4e, 0x83, 0x66, 0x40, 0xe9,
+	0xf9, 0x48, 0xd4, 0x0e, 0x2c, 0x79, 0xd4, 0x26, 0x89, 0xa6, 0x5c, 0x38, 0xad, 0xdb, 0xf3, 0x4e,
+	0xf5, 0x0f, 0x15, 0x2a, 0xd1, 0xa9, 0x4e, 0xc3, 0xfd, 0x3f, 0x9e, 0xef, 0x1e, 0xac, 0x30, 0xd7,
+	0xf1, 0x2c, 0x3e, 0x08, 0x88, 0xa8, 0xbe, 0x8a, 0x93, 0x40, 0xd2, 0x91, 0xcc, 0x7b, 0x74, 0xe4,
+	0xf5, 0x0a, 0x2c, 0x3d, 0x21, 0x8c, 0x59, 0x0e, 0x41, 0x67, 0xb0, 0x21, 0x39, 0x9a, 0x41, 0xd4,
+	0x0f, 0xc9, 0xf5, 0xfe, 0x34, 0xc8, 0x31, 0x33, 0xd3, 0x15, 0xbc, 0xe6, 0xa7, 0x03, 0xc8, 0x80,
+	0x42, 0x02, 0x16, 0x35, 0x41, 0xde, 0xce, 0xea, 0x3c, 0xb4, 0x68, 0xa5, 0xae, 0xe0, 0x75, 0x7f,
+	0x2c, 0x82, 0xbe, 0x83, 0x3b, 0xa1, 0x64, 0x33, 0x7c, 0xef, 0x31, 0xbd, 0x48, 0xf1, 0x07, 0xd3,
+	0x00, 0x6f, 0x58, 0x96, 0xae, 0xe0, 0x0d, 0x36, 0x1e, 0x42, 0x2f, 0xa1, 0xc8, 0xc4, 0xb9, 0x8d,
+	0x40, 0x25, 0xcd, 0xe8, 0x66, 0x7d, 0x3c, 0x0b, 0x75, 0xdc, 0xad, 0x74, 0x05, 0x23, 0x36, 0x11,
+	0x45, 0xdf, 0xc3, 0x96, 0xa0, 0x3b, 0x7a, 0xa2, 0x31, 0xe5, 0xac, 0x00, 0xff, 0x64, 0x16, 0xf8,
+	0x0d, 0x17, 0xd2, 0x15, 0xbc, 0xc9, 0x26, 0xc3, 0xe8, 0x1c, 0x4a, 0x92, 0x7a, 0xaa, 0x80, 0xa4,
+	0x9f, 0x13, 0x15, 0x0e, 0x67, 0xd3, 0xbf, 0x69, 0x3e, 0xba, 0x82, 0xb7, 0xd9, 0xd4, 0x0c, 0x3a,
+	0x85, 0x55, 0xdf, 0xf5, 0x9c, 0x98, 0xfd, 0x92, 0xc0, 0xbe, 0x37, 0xf5, 0x04, 0x13, 0x0f, 0xd1,
+	0x15, 0x9c, 0xf7, 0x93, 0x29, 0xfa, 0x06, 0xd6, 0x24, 0x8a, 0xa4, 0xb8, 0x2c, 0x60, 0x2a, 0xb3,
+	0x61, 0x62, 0x62, 0xab, 0x7e, 0x6a, 0x8e, 0x2c, 0xd8, 0xe9, 0x5b, 0x8c, 0x9b, 0xa2, 0xb5, 0x8c,
+	0x5b, 0xa9, 0xab, 0xb0, 0x22, 0x20, 0x0f, 0xa6, 0x41, 0x4e, 0xb3, 0x2f, 0x5d, 0xc1, 0xc5, 0xfe,
+	0x94, 0x38, 0xb2, 0xa1, 0x34, 0x59, 0x42, 0xd2, 0x06, 0x51, 0xe3, 0xd3, 0x77, 0xa8, 0x11, 0xf3,
+	0xdf, 0xea, 0x4f, 0x4b, 0xa0, 0x1f, 0xe1, 0xbe, 0x28, 0x20, 0xcc, 0xe6, 0x72, 0x64, 0x19, 0xe1,
+	0x61, 0xd2, 0xf3, 0x58, 0x52, 0x5e, 0x94, 0x7b, 0x30, 0xeb, 0x20, 0x67, 0xba, 0x98, 0xae, 0xe0,
+	0x7d, 0x36, 0xd7, 0xe6, 0x7e, 0x52, 0xe1, 0x43, 0x79, 0x7f, 0x66, 0x30, 0x90, 0x8a, 0x57, 0x05,
+	0x85, 0x2f, 0x66, 0xdf, 0xa5, 0xd9, 0x96, 0xa7, 0x2b, 0xb8, 0xc2, 0xfe, 0x65, 0xcd, 0x71, 0x16,
+	0x32, 0x6c, 0x70, 0x51, 0x35, 0x61, 0xfd, 0xf1, 0x80, 0xf7, 0x5a, 0xae, 0x33, 0xf2, 0xa2, 0x5b,
+	0xf9, 0x65, 0x01, 0x32, 0xcc, 0x75, 0xa4, 0x53, 0x86, 0xc3, 0xc3, 0xdf, 0x55, 0xc8, 0x09, 0xdf,
+	0x63, 0x08, 0xc1, 0xba, 0x86, 0x71, 0x03, 0xb7, 0xcc, 0xa7, 0xc6, 0x99, 0xd1, 0x78, 0x6e, 0x14,
+	0x14, 0x54, 0x86, 0xdd, 0x38, 0xa6, 0xbd, 0x68, 0x6a, 0x27, 0x6d, 0xed, 0xd4, 0xc4, 0x5a, 0xab,
+	0xd9, 0x30, 0x5a, 0x5a, 0x41, 0x45, 0x25, 0x28, 0xca, 0xbc, 0xd1, 0x30, 0x4f, 0x1a, 0x86, 0xa1,
+	0x9d, 0xb4, 0xeb, 0x0d, 0xa3, 0xb0, 0x80, 0xf6, 0xe1, 0xae, 0xcc, 0x24, 0x61, 0xb3, 0x5d, 0x7f,
+	0xa2, 0x35, 0x9e, 0xb6, 0x0b, 0x19, 0xb4, 0x03, 0x9b, 0x32, 0x8d, 0xb5, 0xc7, 0xa7, 0x71, 0x62,
+	0x31, 0x85, 0xf8, 0x1c, 0xd7, 0xdb, 0x5a, 0x9c, 0xc9, 0x1e, 0xb7, 0xde, 0x5c, 0x95, 0xd5, 0xb7,
+	0x57, 0x65, 0xf5, 0xef, 0xab, 0xb2, 0xfa, 0xcb, 0x75, 0x59, 0x79, 0x7b, 0x5d, 0x56, 0xfe, 0xbc,
+	0x2e, 0x2b, 0x2f, 0xbf, 0x72, 0x5c, 0xde, 0x1b, 0x74, 0x6a, 0x5d, 0x7a, 0x71, 0x94, 0xfe, 0xcb,
+	0x26, 0xc3, 0xe8, 0xff, 0x3a, 0xf9, 0x73, 0xee, 0xe4, 0x44, 0xe6, 0xf3, 0x7f, 0x06, 0x00, 0x92,
+	0xe7, 0x5c, 0x13, 0x56, 0x0b, 0x00, 0x00,
 }
 
 func (m *RemoteSignerError) Marshal() (dAtA []byte, err error) {
@@ -1116,7 +1419,7 @@ func (m *PingResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *Message) Marshal() (dAtA []byte, err error) {
+func (m *LastSignStateRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -1126,38 +1429,49 @@ func (m *Message) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Message) MarshalTo(dAtA []byte) (int, error) {
+func (m *LastSignStateRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Message) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *LastSignStateRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Sum != nil {
-		{
-			size := m.Sum.Size()
-			i -= size
-			if _, err := m.Sum.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
-			}
-		}
+	if len(m.ChainId) > 0 {
+		i -= len(m.ChainId)
+		copy(dAtA[i:], m.ChainId)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ChainId)))
+		i--
+		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *Message_PubKeyRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *LastSignStateResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LastSignStateResponse) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Message_PubKeyRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *LastSignStateResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
-	if m.PubKeyRequest != nil {
+	_ = i
+	var l int
+	_ = l
+	if m.Error != nil {
 		{
-			size, err := m.PubKeyRequest.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Error.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -1165,39 +1479,197 @@ func (m *Message_PubKeyRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			i = encodeVarintTypes(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0xa
+		dAtA[i] = 0x22
+	}
+	if m.Step != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Step))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Round != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
-func (m *Message_PubKeyResponse) MarshalTo(dAtA []byte) (int, error) {
+
+func (m *SignNodeValidatorProofRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SignNodeValidatorProofRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Message_PubKeyResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *SignNodeValidatorProofRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
-	if m.PubKeyResponse != nil {
-		{
-			size, err := m.PubKeyResponse.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintTypes(dAtA, i, uint64(size))
-		}
+	_ = i
+	var l int
+	_ = l
+	if len(m.ChainId) > 0 {
+		i -= len(m.ChainId)
+		copy(dAtA[i:], m.ChainId)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ChainId)))
 		i--
 		dAtA[i] = 0x12
 	}
+	if len(m.NodeId) > 0 {
+		i -= len(m.NodeId)
+		copy(dAtA[i:], m.NodeId)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.NodeId)))
+		i--
+		dAtA[i] = 0xa
+	}
 	return len(dAtA) - i, nil
 }
-func (m *Message_SignVoteRequest) MarshalTo(dAtA []byte) (int, error) {
+
+func (m *SignedNodeValidatorProofResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SignedNodeValidatorProofResponse) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Message_SignVoteRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *SignedNodeValidatorProofResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
-	if m.SignVoteRequest != nil {
+	_ = i
+	var l int
+	_ = l
+	if m.Error != nil {
+		{
+			size, err := m.Error.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Signature) > 0 {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0x12
+	}
+	{
+		size, err := m.PubKey.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *Message) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Message) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Sum != nil {
+		{
+			size := m.Sum.Size()
+			i -= size
+			if _, err := m.Sum.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Message_PubKeyRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_PubKeyRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.PubKeyRequest != nil {
+		{
+			size, err := m.PubKeyRequest.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_PubKeyResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_PubKeyResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.PubKeyResponse != nil {
+		{
+			size, err := m.PubKeyResponse.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_SignVoteRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_SignVoteRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.SignVoteRequest != nil {
 		{
 			size, err := m.SignVoteRequest.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
@@ -1316,6 +1788,90 @@ func (m *Message_PingResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Message_LastSignStateRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_LastSignStateRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.LastSignStateRequest != nil {
+		{
+			size, err := m.LastSignStateRequest.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x4a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_LastSignStateResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_LastSignStateResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.LastSignStateResponse != nil {
+		{
+			size, err := m.LastSignStateResponse.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x52
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_SignNodeValidatorProofRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_SignNodeValidatorProofRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.SignNodeValidatorProofRequest != nil {
+		{
+			size, err := m.SignNodeValidatorProofRequest.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x5a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_SignedNodeValidatorProofResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_SignedNodeValidatorProofResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.SignedNodeValidatorProofResponse != nil {
+		{
+			size, err := m.SignedNodeValidatorProofResponse.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x62
+	}
+	return len(dAtA) - i, nil
+}
 func (m *AuthSigMessage) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1493,6 +2049,77 @@ func (m *PingResponse) Size() (n int) {
 	return n
 }
 
+func (m *LastSignStateRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ChainId)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *LastSignStateResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if m.Round != 0 {
+		n += 1 + sovTypes(uint64(m.Round))
+	}
+	if m.Step != 0 {
+		n += 1 + sovTypes(uint64(m.Step))
+	}
+	if m.Error != nil {
+		l = m.Error.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *SignNodeValidatorProofRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.NodeId)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.ChainId)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *SignedNodeValidatorProofResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.PubKey.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Error != nil {
+		l = m.Error.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
 func (m *Message) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1601,32 +2228,80 @@ func (m *Message_PingResponse) Size() (n int) {
 	}
 	return n
 }
-func (m *AuthSigMessage) Size() (n int) {
+func (m *Message_LastSignStateRequest) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	l = m.PubKey.Size()
-	n += 1 + l + sovTypes(uint64(l))
-	l = len(m.Sig)
-	if l > 0 {
+	if m.LastSignStateRequest != nil {
+		l = m.LastSignStateRequest.Size()
 		n += 1 + l + sovTypes(uint64(l))
 	}
 	return n
 }
-
-func sovTypes(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozTypes(x uint64) (n int) {
-	return sovTypes(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+func (m *Message_LastSignStateResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.LastSignStateResponse != nil {
+		l = m.LastSignStateResponse.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
 }
-func (m *RemoteSignerError) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
+func (m *Message_SignNodeValidatorProofRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SignNodeValidatorProofRequest != nil {
+		l = m.SignNodeValidatorProofRequest.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Message_SignedNodeValidatorProofResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SignedNodeValidatorProofResponse != nil {
+		l = m.SignedNodeValidatorProofResponse.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *AuthSigMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.PubKey.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	l = len(m.Sig)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func sovTypes(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozTypes(x uint64) (n int) {
+	return sovTypes(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *RemoteSignerError) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
 		var wire uint64
 		for shift := uint(0); ; shift += 7 {
 			if shift >= 64 {
@@ -2498,7 +3173,7 @@ func (m *PingResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Message) Unmarshal(dAtA []byte) error {
+func (m *LastSignStateRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2521,17 +3196,17 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Message: wiretype end group for non-group")
+			return fmt.Errorf("proto: LastSignStateRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Message: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LastSignStateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PubKeyRequest", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainId", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2541,32 +3216,79 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &PubKeyRequest{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.ChainId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			m.Sum = &Message_PubKeyRequest{v}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PubKeyResponse", wireType)
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
 			}
-			var msglen int
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LastSignStateResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LastSignStateResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LastSignStateResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2576,32 +3298,35 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Height |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
 			}
-			v := &PubKeyResponse{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.Sum = &Message_PubKeyResponse{v}
-			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SignVoteRequest", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Step", wireType)
 			}
-			var msglen int
+			m.Step = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2611,30 +3336,14 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Step |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			v := &SignVoteRequest{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Sum = &Message_SignVoteRequest{v}
-			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SignedVoteResponse", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2661,17 +3370,68 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &SignedVoteResponse{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Error == nil {
+				m.Error = &RemoteSignerError{}
+			}
+			if err := m.Error.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Sum = &Message_SignedVoteResponse{v}
 			iNdEx = postIndex
-		case 5:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SignNodeValidatorProofRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SignNodeValidatorProofRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SignNodeValidatorProofRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SignProposalRequest", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeId", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2681,32 +3441,29 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &SignProposalRequest{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Sum = &Message_SignProposalRequest{v}
+			m.NodeId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 6:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SignedProposalResponse", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainId", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2716,26 +3473,436 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &SignedProposalResponse{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Sum = &Message_SignedProposalResponse{v}
+			m.ChainId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SignedNodeValidatorProofResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SignedNodeValidatorProofResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SignedNodeValidatorProofResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PubKey", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.PubKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Error == nil {
+				m.Error = &RemoteSignerError{}
+			}
+			if err := m.Error.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Message) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Message: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Message: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PubKeyRequest", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &PubKeyRequest{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_PubKeyRequest{v}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PubKeyResponse", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &PubKeyResponse{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_PubKeyResponse{v}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignVoteRequest", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &SignVoteRequest{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_SignVoteRequest{v}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignedVoteResponse", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &SignedVoteResponse{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_SignedVoteResponse{v}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignProposalRequest", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &SignProposalRequest{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_SignProposalRequest{v}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignedProposalResponse", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &SignedProposalResponse{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_SignedProposalResponse{v}
 			iNdEx = postIndex
 		case 7:
 			if wireType != 2 {
@@ -2807,6 +3974,146 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 			}
 			m.Sum = &Message_PingResponse{v}
 			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastSignStateRequest", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &LastSignStateRequest{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_LastSignStateRequest{v}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastSignStateResponse", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &LastSignStateResponse{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_LastSignStateResponse{v}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignNodeValidatorProofRequest", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &SignNodeValidatorProofRequest{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_SignNodeValidatorProofRequest{v}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignedNodeValidatorProofResponse", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &SignedNodeValidatorProofResponse{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_SignedNodeValidatorProofResponse{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -276,6 +276,8 @@ type State struct {
 	LastHeightConsensusParamsChanged int64                  `protobuf:"varint,11,opt,name=last_height_consensus_params_changed,json=lastHeightConsensusParamsChanged,proto3" json:"last_height_consensus_params_changed,omitempty"`
 	// Merkle root of the results from executing prev block
 	LastResultsHash []byte `protobuf:"bytes,12,opt,name=last_results_hash,json=lastResultsHash,proto3" json:"last_results_hash,omitempty"`
+	// Merkle root of the events from executing prev block, if committed to
+	LastEventsHash []byte `protobuf:"bytes,15,opt,name=last_events_hash,json=lastEventsHash,proto3" json:"last_events_hash,omitempty"`
 	// the latest AppHash we've received from calling abci.Commit()
 	AppHash []byte `protobuf:"bytes,13,opt,name=app_hash,json=appHash,proto3" json:"app_hash,omitempty"`
 }
@@ -404,6 +406,13 @@ func (m *State) GetLastResultsHash() []byte {
 	return nil
 }
 
+func (m *State) GetLastEventsHash() []byte {
+	if m != nil {
+		return m.LastEventsHash
+	}
+	return nil
+}
+
 func (m *State) GetAppHash() []byte {
 	if m != nil {
 		return m.AppHash
@@ -422,55 +431,56 @@ func init() {
 func init() { proto.RegisterFile("tendermint/state/types.proto", fileDescriptor_ccfacf933f22bf93) }
 
 var fileDescriptor_ccfacf933f22bf93 = []byte{
-	// 763 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0xcf, 0x6f, 0xd3, 0x30,
-	0x14, 0x6e, 0xe8, 0xb6, 0xb6, 0xce, 0xda, 0x0e, 0x8f, 0x43, 0xd6, 0xb1, 0xb4, 0x2b, 0x3f, 0x34,
-	0x71, 0x48, 0xa5, 0x71, 0x40, 0x5c, 0x26, 0x2d, 0x2d, 0x62, 0x95, 0x26, 0x04, 0xd9, 0xb4, 0x03,
-	0x97, 0xc8, 0x6d, 0xbc, 0x24, 0xa2, 0x4d, 0xa2, 0xd8, 0x2d, 0xe3, 0x0f, 0xe0, 0xbe, 0x2b, 0xff,
-	0xd1, 0x8e, 0x3b, 0x22, 0x0e, 0x03, 0xba, 0x7f, 0x04, 0xd9, 0xce, 0x0f, 0xb7, 0x65, 0xd2, 0x10,
-	0x37, 0xfb, 0x7d, 0xdf, 0xfb, 0xfc, 0xf9, 0xf9, 0x3d, 0x19, 0x3c, 0xa6, 0x38, 0x70, 0x70, 0x3c,
-	0xf6, 0x03, 0xda, 0x21, 0x14, 0x51, 0xdc, 0xa1, 0x5f, 0x22, 0x4c, 0x8c, 0x28, 0x0e, 0x69, 0x08,
-	0x37, 0x72, 0xd4, 0xe0, 0x68, 0xe3, 0x91, 0x1b, 0xba, 0x21, 0x07, 0x3b, 0x6c, 0x25, 0x78, 0x8d,
-	0x6d, 0x49, 0x05, 0x0d, 0x86, 0xbe, 0x2c, 0xd2, 0x90, 0x8f, 0xe0, 0xf1, 0x39, 0xb4, 0xb5, 0x84,
-	0x4e, 0xd1, 0xc8, 0x77, 0x10, 0x0d, 0xe3, 0x84, 0xb1, 0xb3, 0xc4, 0x88, 0x50, 0x8c, 0xc6, 0xa9,
-	0x80, 0x2e, 0xc1, 0x53, 0x1c, 0x13, 0x3f, 0x0c, 0xe6, 0x0e, 0x68, 0xba, 0x61, 0xe8, 0x8e, 0x70,
-	0x87, 0xef, 0x06, 0x93, 0xf3, 0x0e, 0xf5, 0xc7, 0x98, 0x50, 0x34, 0x8e, 0x04, 0xa1, 0xfd, 0x43,
-	0x01, 0xd5, 0x43, 0xb3, 0xdb, 0xb7, 0x30, 0x89, 0xc2, 0x80, 0x60, 0x02, 0xbb, 0x40, 0x75, 0xf0,
-	0xc8, 0x9f, 0xe2, 0xd8, 0xa6, 0x17, 0x44, 0x53, 0x5a, 0xc5, 0x3d, 0x75, 0xbf, 0x6d, 0x48, 0xc5,
-	0x60, 0x97, 0x34, 0xd2, 0x84, 0x9e, 0xe0, 0x9e, 0x5e, 0x58, 0xc0, 0x49, 0x97, 0x04, 0x1e, 0x80,
-	0x0a, 0x0e, 0x1c, 0x7b, 0x30, 0x0a, 0x87, 0x9f, 0xb4, 0x07, 0x2d, 0x65, 0x4f, 0xdd, 0xdf, 0xbd,
-	0x53, 0xe2, 0x4d, 0xe0, 0x98, 0x8c, 0x68, 0x95, 0x71, 0xb2, 0x82, 0x3d, 0xa0, 0x0e, 0xb0, 0xeb,
-	0x07, 0x89, 0x42, 0x91, 0x2b, 0x3c, 0xb9, 0x53, 0xc1, 0x64, 0x5c, 0xa1, 0x01, 0x06, 0xd9, 0xba,
-	0xfd, 0x55, 0x01, 0xb5, 0xb3, 0xb4, 0xa0, 0xa4, 0x1f, 0x9c, 0x87, 0xb0, 0x0b, 0xaa, 0x59, 0x89,
-	0x6d, 0x82, 0xa9, 0xa6, 0x70, 0x69, 0x5d, 0x96, 0x16, 0x05, 0xcc, 0x12, 0x4f, 0x30, 0xb5, 0xd6,
-	0xa7, 0xd2, 0x0e, 0x1a, 0x60, 0x73, 0x84, 0x08, 0xb5, 0x3d, 0xec, 0xbb, 0x1e, 0xb5, 0x87, 0x1e,
-	0x0a, 0x5c, 0xec, 0xf0, 0x7b, 0x16, 0xad, 0x87, 0x0c, 0x3a, 0xe2, 0x48, 0x57, 0x00, 0xed, 0x6f,
-	0x0a, 0xd8, 0xec, 0x32, 0x9f, 0x01, 0x99, 0x90, 0xf7, 0xfc, 0xfd, 0xb8, 0x19, 0x0b, 0x6c, 0x0c,
-	0xd3, 0xb0, 0x2d, 0xde, 0x35, 0xf1, 0xb3, 0xbb, 0xec, 0x67, 0x41, 0xc0, 0x5c, 0xb9, 0xba, 0x69,
-	0x16, 0xac, 0xfa, 0x70, 0x3e, 0xfc, 0xcf, 0xde, 0x3c, 0x50, 0x3a, 0x13, 0x8d, 0x03, 0x0f, 0x41,
-	0x25, 0x53, 0x4b, 0x7c, 0xec, 0xc8, 0x3e, 0x92, 0x06, 0xcb, 0x9d, 0x24, 0x1e, 0xf2, 0x2c, 0xd8,
-	0x00, 0x65, 0x12, 0x9e, 0xd3, 0xcf, 0x28, 0xc6, 0xfc, 0xc8, 0x8a, 0x95, 0xed, 0xdb, 0xbf, 0xd7,
-	0xc0, 0xea, 0x09, 0x9b, 0x23, 0xf8, 0x1a, 0x94, 0x12, 0xad, 0xe4, 0x98, 0x2d, 0x63, 0x71, 0xd6,
-	0x8c, 0xc4, 0x54, 0x72, 0x44, 0xca, 0x87, 0xcf, 0x41, 0x79, 0xe8, 0x21, 0x3f, 0xb0, 0x7d, 0x71,
-	0xa7, 0x8a, 0xa9, 0xce, 0x6e, 0x9a, 0xa5, 0x2e, 0x8b, 0xf5, 0x7b, 0x56, 0x89, 0x83, 0x7d, 0x07,
-	0x3e, 0x03, 0x35, 0x3f, 0xf0, 0xa9, 0x8f, 0x46, 0x49, 0x25, 0xb4, 0x1a, 0xaf, 0x40, 0x35, 0x89,
-	0x8a, 0x22, 0xc0, 0x17, 0x80, 0x97, 0x44, 0xb4, 0x59, 0xca, 0x2c, 0x72, 0x66, 0x9d, 0x01, 0xbc,
-	0x8f, 0x12, 0xae, 0x05, 0xaa, 0x12, 0xd7, 0x77, 0xb4, 0x95, 0x65, 0xef, 0xe2, 0xa9, 0x78, 0x56,
-	0xbf, 0x67, 0x6e, 0x32, 0xef, 0xb3, 0x9b, 0xa6, 0x7a, 0x9c, 0x4a, 0xf5, 0x7b, 0x96, 0x9a, 0xe9,
-	0xf6, 0x1d, 0x78, 0x0c, 0xea, 0x92, 0x26, 0x1b, 0x4e, 0x6d, 0x95, 0xab, 0x36, 0x0c, 0x31, 0xb9,
-	0x46, 0x3a, 0xb9, 0xc6, 0x69, 0x3a, 0xb9, 0x66, 0x99, 0xc9, 0x5e, 0xfe, 0x6c, 0x2a, 0x56, 0x35,
-	0xd3, 0x62, 0x28, 0x7c, 0x0b, 0xea, 0x01, 0xbe, 0xa0, 0x76, 0xd6, 0xac, 0x44, 0x5b, 0xbb, 0x57,
-	0x7b, 0xd7, 0x58, 0x5a, 0x3e, 0x29, 0xf0, 0x00, 0x00, 0x49, 0xa3, 0x74, 0x2f, 0x0d, 0x29, 0x83,
-	0x19, 0xe1, 0xd7, 0x92, 0x44, 0xca, 0xf7, 0x33, 0xc2, 0xd2, 0x24, 0x23, 0x5d, 0xa0, 0xcb, 0xdd,
-	0x9c, 0xeb, 0x65, 0x8d, 0x5d, 0xe1, 0x8f, 0xb5, 0x9d, 0x37, 0x76, 0x9e, 0x9d, 0xb4, 0xf8, 0x5f,
-	0xc7, 0x0c, 0xfc, 0xe7, 0x98, 0xbd, 0x03, 0x4f, 0xe7, 0xc6, 0x6c, 0x41, 0x3f, 0xb3, 0xa7, 0x72,
-	0x7b, 0x2d, 0x69, 0xee, 0xe6, 0x85, 0x52, 0x8f, 0x69, 0x23, 0xc6, 0x98, 0x4c, 0x46, 0x94, 0xd8,
-	0x1e, 0x22, 0x9e, 0xb6, 0xde, 0x52, 0xf6, 0xd6, 0x45, 0x23, 0x5a, 0x22, 0x7e, 0x84, 0x88, 0x07,
-	0xb7, 0x40, 0x19, 0x45, 0x91, 0xa0, 0x54, 0x39, 0xa5, 0x84, 0xa2, 0x88, 0x41, 0xe6, 0x87, 0xab,
-	0x99, 0xae, 0x5c, 0xcf, 0x74, 0xe5, 0xd7, 0x4c, 0x57, 0x2e, 0x6f, 0xf5, 0xc2, 0xf5, 0xad, 0x5e,
-	0xf8, 0x7e, 0xab, 0x17, 0x3e, 0xbe, 0x72, 0x7d, 0xea, 0x4d, 0x06, 0xc6, 0x30, 0x1c, 0x77, 0xe4,
-	0x3f, 0x25, 0x5f, 0x8a, 0x8f, 0x6d, 0xf1, 0x4b, 0x1c, 0xac, 0xf1, 0xf8, 0xcb, 0x3f, 0x01, 0x00,
-	0x00, 0xff, 0xff, 0xa5, 0x17, 0xac, 0x23, 0x2d, 0x07, 0x00, 0x00,
+	// 780 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0x3d, 0x6f, 0xdb, 0x3a,
+	0x14, 0xb5, 0x9e, 0x93, 0xd8, 0xa6, 0x62, 0x3b, 0x8f, 0x79, 0x83, 0xe2, 0xbc, 0xc8, 0x7e, 0x7e,
+	0x1f, 0x30, 0xde, 0x20, 0x03, 0xe9, 0x50, 0x74, 0x29, 0x10, 0xd9, 0x41, 0x63, 0x20, 0x28, 0x5a,
+	0x25, 0xc8, 0xd0, 0x45, 0xa0, 0x2d, 0x46, 0x22, 0x6a, 0x4b, 0x82, 0x48, 0xbb, 0xe9, 0x0f, 0xe8,
+	0xd4, 0x25, 0x6b, 0xff, 0x51, 0xc6, 0x8c, 0x45, 0x87, 0xb4, 0x70, 0xfe, 0x48, 0x41, 0x52, 0x92,
+	0x69, 0xbb, 0x01, 0x52, 0x74, 0x23, 0xef, 0x39, 0xf7, 0xf0, 0xf0, 0xf2, 0x5e, 0x10, 0xfc, 0xc9,
+	0x70, 0xe8, 0xe1, 0x64, 0x42, 0x42, 0xd6, 0xa5, 0x0c, 0x31, 0xdc, 0x65, 0xef, 0x63, 0x4c, 0xad,
+	0x38, 0x89, 0x58, 0x04, 0x77, 0x16, 0xa8, 0x25, 0xd0, 0xc6, 0x1f, 0x7e, 0xe4, 0x47, 0x02, 0xec,
+	0xf2, 0x95, 0xe4, 0x35, 0xf6, 0x15, 0x15, 0x34, 0x1c, 0x11, 0x55, 0xa4, 0xa1, 0x1e, 0x21, 0xe2,
+	0x4b, 0x68, 0x6b, 0x0d, 0x9d, 0xa1, 0x31, 0xf1, 0x10, 0x8b, 0x92, 0x94, 0x71, 0xb0, 0xc6, 0x88,
+	0x51, 0x82, 0x26, 0x99, 0x80, 0xa9, 0xc0, 0x33, 0x9c, 0x50, 0x12, 0x85, 0x4b, 0x07, 0x34, 0xfd,
+	0x28, 0xf2, 0xc7, 0xb8, 0x2b, 0x76, 0xc3, 0xe9, 0x65, 0x97, 0x91, 0x09, 0xa6, 0x0c, 0x4d, 0x62,
+	0x49, 0x68, 0x7f, 0xd1, 0x40, 0xf5, 0xc8, 0xee, 0x0d, 0x1c, 0x4c, 0xe3, 0x28, 0xa4, 0x98, 0xc2,
+	0x1e, 0xd0, 0x3d, 0x3c, 0x26, 0x33, 0x9c, 0xb8, 0xec, 0x8a, 0x1a, 0x5a, 0xab, 0xd8, 0xd1, 0x0f,
+	0xdb, 0x96, 0x52, 0x0c, 0x7e, 0x49, 0x2b, 0x4b, 0xe8, 0x4b, 0xee, 0xf9, 0x95, 0x03, 0xbc, 0x6c,
+	0x49, 0xe1, 0x73, 0x50, 0xc1, 0xa1, 0xe7, 0x0e, 0xc7, 0xd1, 0xe8, 0xad, 0xf1, 0x5b, 0x4b, 0xeb,
+	0xe8, 0x87, 0x7f, 0x3d, 0x28, 0x71, 0x1c, 0x7a, 0x36, 0x27, 0x3a, 0x65, 0x9c, 0xae, 0x60, 0x1f,
+	0xe8, 0x43, 0xec, 0x93, 0x30, 0x55, 0x28, 0x0a, 0x85, 0xbf, 0x1f, 0x54, 0xb0, 0x39, 0x57, 0x6a,
+	0x80, 0x61, 0xbe, 0x6e, 0x7f, 0xd0, 0x40, 0xed, 0x22, 0x2b, 0x28, 0x1d, 0x84, 0x97, 0x11, 0xec,
+	0x81, 0x6a, 0x5e, 0x62, 0x97, 0x62, 0x66, 0x68, 0x42, 0xda, 0x54, 0xa5, 0x65, 0x01, 0xf3, 0xc4,
+	0x33, 0xcc, 0x9c, 0xed, 0x99, 0xb2, 0x83, 0x16, 0xd8, 0x1d, 0x23, 0xca, 0xdc, 0x00, 0x13, 0x3f,
+	0x60, 0xee, 0x28, 0x40, 0xa1, 0x8f, 0x3d, 0x71, 0xcf, 0xa2, 0xf3, 0x3b, 0x87, 0x4e, 0x04, 0xd2,
+	0x93, 0x40, 0xfb, 0x93, 0x06, 0x76, 0x7b, 0xdc, 0x67, 0x48, 0xa7, 0xf4, 0x95, 0x78, 0x3f, 0x61,
+	0xc6, 0x01, 0x3b, 0xa3, 0x2c, 0xec, 0xca, 0x77, 0x35, 0xb4, 0xf5, 0x62, 0x49, 0x3f, 0x2b, 0x02,
+	0xf6, 0xc6, 0xcd, 0x5d, 0xb3, 0xe0, 0xd4, 0x47, 0xcb, 0xe1, 0x9f, 0xf6, 0x16, 0x80, 0xd2, 0x85,
+	0x6c, 0x1c, 0x78, 0x04, 0x2a, 0xb9, 0x5a, 0xea, 0xe3, 0x40, 0xf5, 0x91, 0x36, 0xd8, 0xc2, 0x49,
+	0xea, 0x61, 0x91, 0x05, 0x1b, 0xa0, 0x4c, 0xa3, 0x4b, 0xf6, 0x0e, 0x25, 0x58, 0x1c, 0x59, 0x71,
+	0xf2, 0x7d, 0xfb, 0x63, 0x09, 0x6c, 0x9e, 0xf1, 0x39, 0x82, 0xcf, 0x40, 0x29, 0xd5, 0x4a, 0x8f,
+	0xd9, 0xb3, 0x56, 0x67, 0xcd, 0x4a, 0x4d, 0xa5, 0x47, 0x64, 0x7c, 0xf8, 0x1f, 0x28, 0x8f, 0x02,
+	0x44, 0x42, 0x97, 0xc8, 0x3b, 0x55, 0x6c, 0x7d, 0x7e, 0xd7, 0x2c, 0xf5, 0x78, 0x6c, 0xd0, 0x77,
+	0x4a, 0x02, 0x1c, 0x78, 0xf0, 0x5f, 0x50, 0x23, 0x21, 0x61, 0x04, 0x8d, 0xd3, 0x4a, 0x18, 0x35,
+	0x51, 0x81, 0x6a, 0x1a, 0x95, 0x45, 0x80, 0xff, 0x03, 0x51, 0x12, 0xd9, 0x66, 0x19, 0xb3, 0x28,
+	0x98, 0x75, 0x0e, 0x88, 0x3e, 0x4a, 0xb9, 0x0e, 0xa8, 0x2a, 0x5c, 0xe2, 0x19, 0x1b, 0xeb, 0xde,
+	0xe5, 0x53, 0x89, 0xac, 0x41, 0xdf, 0xde, 0xe5, 0xde, 0xe7, 0x77, 0x4d, 0xfd, 0x34, 0x93, 0x1a,
+	0xf4, 0x1d, 0x3d, 0xd7, 0x1d, 0x78, 0xf0, 0x14, 0xd4, 0x15, 0x4d, 0x3e, 0x9c, 0xc6, 0xa6, 0x50,
+	0x6d, 0x58, 0x72, 0x72, 0xad, 0x6c, 0x72, 0xad, 0xf3, 0x6c, 0x72, 0xed, 0x32, 0x97, 0xbd, 0xfe,
+	0xda, 0xd4, 0x9c, 0x6a, 0xae, 0xc5, 0x51, 0xf8, 0x02, 0xd4, 0x43, 0x7c, 0xc5, 0xdc, 0xbc, 0x59,
+	0xa9, 0xb1, 0xf5, 0xa8, 0xf6, 0xae, 0xf1, 0xb4, 0x3c, 0xc2, 0xc7, 0x17, 0x28, 0x1a, 0xa5, 0x47,
+	0x69, 0x28, 0x19, 0xdc, 0x88, 0xb8, 0x96, 0x22, 0x52, 0x7e, 0x9c, 0x11, 0x9e, 0xa6, 0x18, 0xe9,
+	0x01, 0x53, 0xed, 0xe6, 0x85, 0x5e, 0xde, 0xd8, 0x15, 0xf1, 0x58, 0xfb, 0x8b, 0xc6, 0x5e, 0x64,
+	0xa7, 0x2d, 0xfe, 0xc3, 0x31, 0x03, 0xbf, 0x38, 0x66, 0x2f, 0xc1, 0x3f, 0x4b, 0x63, 0xb6, 0xa2,
+	0x9f, 0xdb, 0xd3, 0x85, 0xbd, 0x96, 0x32, 0x77, 0xcb, 0x42, 0x99, 0xc7, 0xac, 0x11, 0x13, 0x4c,
+	0xa7, 0x63, 0x46, 0xdd, 0x00, 0xd1, 0xc0, 0xd8, 0x6e, 0x69, 0x9d, 0x6d, 0xd9, 0x88, 0x8e, 0x8c,
+	0x9f, 0x20, 0x1a, 0xc0, 0x0e, 0xd8, 0x11, 0x5c, 0x3c, 0xc3, 0x61, 0x46, 0xad, 0x0b, 0xaa, 0x28,
+	0xdf, 0xb1, 0x08, 0x0b, 0xe6, 0x1e, 0x28, 0xa3, 0x38, 0x96, 0x8c, 0xaa, 0x60, 0x94, 0x50, 0x1c,
+	0x73, 0xc8, 0x7e, 0x7d, 0x33, 0x37, 0xb5, 0xdb, 0xb9, 0xa9, 0x7d, 0x9b, 0x9b, 0xda, 0xf5, 0xbd,
+	0x59, 0xb8, 0xbd, 0x37, 0x0b, 0x9f, 0xef, 0xcd, 0xc2, 0x9b, 0xa7, 0x3e, 0x61, 0xc1, 0x74, 0x68,
+	0x8d, 0xa2, 0x49, 0x57, 0xfd, 0x7d, 0x16, 0x4b, 0xf9, 0x05, 0xae, 0x7e, 0x9e, 0xc3, 0x2d, 0x11,
+	0x7f, 0xf2, 0x7d, 0x00, 0x9e, 0xec, 0x7f, 0x6d, 0x57, 0x07, 0x00, 0x00,
 }
 
 func (m *ABCIResponses) Marshal() (dAtA []byte, err error) {
@@ -672,6 +682,13 @@ func (m *State) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.LastEventsHash) > 0 {
+		i -= len(m.LastEventsHash)
+		copy(dAtA[i:], m.LastEventsHash)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.LastEventsHash)))
+		i--
+		dAtA[i] = 0x7a
+	}
 	if m.InitialHeight != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.InitialHeight))
 		i--
@@ -919,6 +936,10 @@ func (m *State) Size() (n int) {
 	if m.InitialHeight != 0 {
 		n += 1 + sovTypes(uint64(m.InitialHeight))
 	}
+	l = len(m.LastEventsHash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -1851,6 +1872,40 @@ func (m *State) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastEventsHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LastEventsHash = append(m.LastEventsHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.LastEventsHash == nil {
+				m.LastEventsHash = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -308,48 +308,106 @@ func (m *CanonicalVote) GetChainID() string {
 	return ""
 }
 
+// CanonicalNodeValidatorProof is signed by a validator's consensus key to bind
+// it to the ID of the node it runs on.
+type CanonicalNodeValidatorProof struct {
+	NodeID  string `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	ChainID string `protobuf:"bytes,2,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+}
+
+func (m *CanonicalNodeValidatorProof) Reset()         { *m = CanonicalNodeValidatorProof{} }
+func (m *CanonicalNodeValidatorProof) String() string { return proto.CompactTextString(m) }
+func (*CanonicalNodeValidatorProof) ProtoMessage()    {}
+func (*CanonicalNodeValidatorProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8d1a1a84ff7267ed, []int{4}
+}
+func (m *CanonicalNodeValidatorProof) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CanonicalNodeValidatorProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CanonicalNodeValidatorProof.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CanonicalNodeValidatorProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CanonicalNodeValidatorProof.Merge(m, src)
+}
+func (m *CanonicalNodeValidatorProof) XXX_Size() int {
+	return m.Size()
+}
+func (m *CanonicalNodeValidatorProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_CanonicalNodeValidatorProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CanonicalNodeValidatorProof proto.InternalMessageInfo
+
+func (m *CanonicalNodeValidatorProof) GetNodeID() string {
+	if m != nil {
+		return m.NodeID
+	}
+	return ""
+}
+
+func (m *CanonicalNodeValidatorProof) GetChainID() string {
+	if m != nil {
+		return m.ChainID
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*CanonicalBlockID)(nil), "tendermint.types.CanonicalBlockID")
 	proto.RegisterType((*CanonicalPartSetHeader)(nil), "tendermint.types.CanonicalPartSetHeader")
 	proto.RegisterType((*CanonicalProposal)(nil), "tendermint.types.CanonicalProposal")
 	proto.RegisterType((*CanonicalVote)(nil), "tendermint.types.CanonicalVote")
+	proto.RegisterType((*CanonicalNodeValidatorProof)(nil), "tendermint.types.CanonicalNodeValidatorProof")
 }
 
 func init() { proto.RegisterFile("tendermint/types/canonical.proto", fileDescriptor_8d1a1a84ff7267ed) }
 
 var fileDescriptor_8d1a1a84ff7267ed = []byte{
-	// 487 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x53, 0x3d, 0x6f, 0xd3, 0x40,
-	0x18, 0xce, 0xa5, 0x4e, 0xe2, 0x5c, 0x1b, 0x08, 0xa7, 0xaa, 0xb2, 0x22, 0x64, 0x5b, 0x1e, 0x90,
-	0x59, 0x6c, 0xa9, 0x1d, 0xd8, 0x5d, 0x06, 0x82, 0x40, 0x94, 0x6b, 0xd5, 0x81, 0x25, 0xba, 0xd8,
-	0x87, 0x6d, 0xe1, 0xf8, 0x4e, 0xf6, 0x65, 0xe8, 0xc2, 0x6f, 0xe8, 0xef, 0xe0, 0x97, 0x74, 0xec,
-	0x08, 0x4b, 0x40, 0xce, 0x1f, 0x41, 0x77, 0x4e, 0xec, 0xa8, 0x01, 0x16, 0x10, 0xcb, 0xe9, 0xfd,
-	0x78, 0xee, 0x79, 0x1f, 0x3d, 0xaf, 0x5e, 0x68, 0x0b, 0x9a, 0x47, 0xb4, 0x58, 0xa4, 0xb9, 0xf0,
-	0xc5, 0x0d, 0xa7, 0xa5, 0x1f, 0x92, 0x9c, 0xe5, 0x69, 0x48, 0x32, 0x8f, 0x17, 0x4c, 0x30, 0x34,
-	0x6e, 0x11, 0x9e, 0x42, 0x4c, 0x8e, 0x63, 0x16, 0x33, 0xd5, 0xf4, 0x65, 0x54, 0xe3, 0x26, 0x4f,
-	0xf7, 0x98, 0xd4, 0xbb, 0xe9, 0x5a, 0x31, 0x63, 0x71, 0x46, 0x7d, 0x95, 0xcd, 0x97, 0x1f, 0x7d,
-	0x91, 0x2e, 0x68, 0x29, 0xc8, 0x82, 0xd7, 0x00, 0xe7, 0x33, 0x1c, 0x9f, 0x6f, 0x27, 0x07, 0x19,
-	0x0b, 0x3f, 0x4d, 0x5f, 0x22, 0x04, 0xb5, 0x84, 0x94, 0x89, 0x01, 0x6c, 0xe0, 0x1e, 0x61, 0x15,
-	0xa3, 0x6b, 0xf8, 0x98, 0x93, 0x42, 0xcc, 0x4a, 0x2a, 0x66, 0x09, 0x25, 0x11, 0x2d, 0x8c, 0xae,
-	0x0d, 0xdc, 0xc3, 0x53, 0xd7, 0x7b, 0x28, 0xd4, 0x6b, 0x08, 0x2f, 0x48, 0x21, 0x2e, 0xa9, 0x78,
-	0xa5, 0xf0, 0x81, 0x76, 0xb7, 0xb2, 0x3a, 0x78, 0xc4, 0x77, 0x8b, 0x4e, 0x00, 0x4f, 0x7e, 0x0d,
-	0x47, 0xc7, 0xb0, 0x27, 0x98, 0x20, 0x99, 0x92, 0x31, 0xc2, 0x75, 0xd2, 0x68, 0xeb, 0xb6, 0xda,
-	0x9c, 0x6f, 0x5d, 0xf8, 0xa4, 0x25, 0x29, 0x18, 0x67, 0x25, 0xc9, 0xd0, 0x19, 0xd4, 0xa4, 0x1c,
-	0xf5, 0xfd, 0xd1, 0xa9, 0xb5, 0x2f, 0xf3, 0x32, 0x8d, 0x73, 0x1a, 0xbd, 0x2d, 0xe3, 0xab, 0x1b,
-	0x4e, 0xb1, 0x02, 0xa3, 0x13, 0xd8, 0x4f, 0x68, 0x1a, 0x27, 0x42, 0x0d, 0x18, 0xe3, 0x4d, 0x26,
-	0xc5, 0x14, 0x6c, 0x99, 0x47, 0xc6, 0x81, 0x2a, 0xd7, 0x09, 0x7a, 0x0e, 0x87, 0x9c, 0x65, 0xb3,
-	0xba, 0xa3, 0xd9, 0xc0, 0x3d, 0x08, 0x8e, 0xaa, 0x95, 0xa5, 0x5f, 0xbc, 0x7b, 0x83, 0x65, 0x0d,
-	0xeb, 0x9c, 0x65, 0x2a, 0x42, 0xaf, 0xa1, 0x3e, 0x97, 0xf6, 0xce, 0xd2, 0xc8, 0xe8, 0x29, 0xe3,
-	0x9c, 0x3f, 0x18, 0xb7, 0xd9, 0x44, 0x70, 0x58, 0xad, 0xac, 0xc1, 0x26, 0xc1, 0x03, 0x45, 0x30,
-	0x8d, 0x50, 0x00, 0x87, 0xcd, 0x1a, 0x8d, 0xbe, 0x22, 0x9b, 0x78, 0xf5, 0xa2, 0xbd, 0xed, 0xa2,
-	0xbd, 0xab, 0x2d, 0x22, 0xd0, 0xa5, 0xef, 0xb7, 0xdf, 0x2d, 0x80, 0xdb, 0x6f, 0xe8, 0x19, 0xd4,
-	0xc3, 0x84, 0xa4, 0xb9, 0xd4, 0x33, 0xb0, 0x81, 0x3b, 0xac, 0x67, 0x9d, 0xcb, 0x9a, 0x9c, 0xa5,
-	0x9a, 0xd3, 0xc8, 0xf9, 0xd2, 0x85, 0xa3, 0x46, 0xd6, 0x35, 0x13, 0xf4, 0x7f, 0xf8, 0xba, 0x6b,
-	0x96, 0xf6, 0x2f, 0xcd, 0xea, 0xfd, 0xbd, 0x59, 0xfd, 0xdf, 0x9b, 0x15, 0xbc, 0xbf, 0xab, 0x4c,
-	0x70, 0x5f, 0x99, 0xe0, 0x47, 0x65, 0x82, 0xdb, 0xb5, 0xd9, 0xb9, 0x5f, 0x9b, 0x9d, 0xaf, 0x6b,
-	0xb3, 0xf3, 0xe1, 0x45, 0x9c, 0x8a, 0x64, 0x39, 0xf7, 0x42, 0xb6, 0xf0, 0x77, 0x0f, 0xb6, 0x0d,
-	0xeb, 0xc3, 0x7e, 0x78, 0xcc, 0xf3, 0xbe, 0xaa, 0x9f, 0xfd, 0x0c, 0x00, 0x00, 0xff, 0xff, 0x6d,
-	0xdd, 0x12, 0x5d, 0x31, 0x04, 0x00, 0x00,
+	// 531 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x54, 0xcd, 0x6e, 0xd3, 0x40,
+	0x18, 0xcc, 0xa6, 0xa9, 0x93, 0x6c, 0x1b, 0x08, 0xab, 0xaa, 0x8a, 0x02, 0xb2, 0xa3, 0x20, 0xa1,
+	0x70, 0xb1, 0xa5, 0xf6, 0xc0, 0xdd, 0xed, 0x81, 0x20, 0x7e, 0xc2, 0xb6, 0xca, 0x81, 0x4b, 0xb4,
+	0xc9, 0x6e, 0x6d, 0x83, 0xe3, 0xcf, 0xb2, 0x37, 0x87, 0x5e, 0x78, 0x86, 0x3e, 0x07, 0x4f, 0xd2,
+	0x63, 0x8f, 0x70, 0x09, 0xc8, 0x79, 0x11, 0xb4, 0xeb, 0xc4, 0x0e, 0x2d, 0xf4, 0x02, 0xe2, 0x62,
+	0x7d, 0x3f, 0xe3, 0x99, 0xd1, 0x7c, 0xd2, 0xe2, 0x9e, 0x14, 0x11, 0x17, 0xc9, 0x3c, 0x88, 0xa4,
+	0x23, 0x2f, 0x63, 0x91, 0x3a, 0x33, 0x16, 0x41, 0x14, 0xcc, 0x58, 0x68, 0xc7, 0x09, 0x48, 0x20,
+	0xed, 0x12, 0x61, 0x6b, 0x44, 0xf7, 0xc0, 0x03, 0x0f, 0xf4, 0xd2, 0x51, 0x55, 0x8e, 0xeb, 0x3e,
+	0xb9, 0xc3, 0xa4, 0xbf, 0xeb, 0xad, 0xe5, 0x01, 0x78, 0xa1, 0x70, 0x74, 0x37, 0x5d, 0x5c, 0x38,
+	0x32, 0x98, 0x8b, 0x54, 0xb2, 0x79, 0x9c, 0x03, 0xfa, 0x9f, 0x71, 0xfb, 0x64, 0xa3, 0xec, 0x86,
+	0x30, 0xfb, 0x34, 0x3c, 0x25, 0x04, 0xd7, 0x7c, 0x96, 0xfa, 0x1d, 0xd4, 0x43, 0x83, 0x7d, 0xaa,
+	0x6b, 0x32, 0xc6, 0x0f, 0x63, 0x96, 0xc8, 0x49, 0x2a, 0xe4, 0xc4, 0x17, 0x8c, 0x8b, 0xa4, 0x53,
+	0xed, 0xa1, 0xc1, 0xde, 0xd1, 0xc0, 0xbe, 0x6d, 0xd4, 0x2e, 0x08, 0x47, 0x2c, 0x91, 0x67, 0x42,
+	0xbe, 0xd4, 0x78, 0xb7, 0x76, 0xbd, 0xb4, 0x2a, 0xb4, 0x15, 0x6f, 0x0f, 0xfb, 0x2e, 0x3e, 0xfc,
+	0x3d, 0x9c, 0x1c, 0xe0, 0x5d, 0x09, 0x92, 0x85, 0xda, 0x46, 0x8b, 0xe6, 0x4d, 0xe1, 0xad, 0x5a,
+	0x7a, 0xeb, 0x7f, 0xab, 0xe2, 0x47, 0x25, 0x49, 0x02, 0x31, 0xa4, 0x2c, 0x24, 0xc7, 0xb8, 0xa6,
+	0xec, 0xe8, 0xdf, 0x1f, 0x1c, 0x59, 0x77, 0x6d, 0x9e, 0x05, 0x5e, 0x24, 0xf8, 0x9b, 0xd4, 0x3b,
+	0xbf, 0x8c, 0x05, 0xd5, 0x60, 0x72, 0x88, 0x0d, 0x5f, 0x04, 0x9e, 0x2f, 0xb5, 0x40, 0x9b, 0xae,
+	0x3b, 0x65, 0x26, 0x81, 0x45, 0xc4, 0x3b, 0x3b, 0x7a, 0x9c, 0x37, 0xe4, 0x39, 0x6e, 0xc6, 0x10,
+	0x4e, 0xf2, 0x4d, 0xad, 0x87, 0x06, 0x3b, 0xee, 0x7e, 0xb6, 0xb4, 0x1a, 0xa3, 0x77, 0xaf, 0xa9,
+	0x9a, 0xd1, 0x46, 0x0c, 0xa1, 0xae, 0xc8, 0x2b, 0xdc, 0x98, 0xaa, 0x78, 0x27, 0x01, 0xef, 0xec,
+	0xea, 0xe0, 0xfa, 0xf7, 0x04, 0xb7, 0xbe, 0x84, 0xbb, 0x97, 0x2d, 0xad, 0xfa, 0xba, 0xa1, 0x75,
+	0x4d, 0x30, 0xe4, 0xc4, 0xc5, 0xcd, 0xe2, 0x8c, 0x1d, 0x43, 0x93, 0x75, 0xed, 0xfc, 0xd0, 0xf6,
+	0xe6, 0xd0, 0xf6, 0xf9, 0x06, 0xe1, 0x36, 0x54, 0xee, 0x57, 0xdf, 0x2d, 0x44, 0xcb, 0xdf, 0xc8,
+	0x33, 0xdc, 0x98, 0xf9, 0x2c, 0x88, 0x94, 0x9f, 0x7a, 0x0f, 0x0d, 0x9a, 0xb9, 0xd6, 0x89, 0x9a,
+	0x29, 0x2d, 0xbd, 0x1c, 0xf2, 0xfe, 0x97, 0x2a, 0x6e, 0x15, 0xb6, 0xc6, 0x20, 0xc5, 0xff, 0xc8,
+	0x75, 0x3b, 0xac, 0xda, 0xbf, 0x0c, 0x6b, 0xf7, 0xef, 0xc3, 0x32, 0xee, 0x09, 0xeb, 0x23, 0x7e,
+	0x5c, 0xb8, 0x7a, 0x0b, 0x5c, 0x8c, 0x59, 0x18, 0x70, 0x26, 0x21, 0x19, 0x25, 0x00, 0x17, 0xe4,
+	0x29, 0xae, 0x47, 0xc0, 0x85, 0x62, 0x41, 0x9a, 0x05, 0x67, 0x4b, 0xcb, 0x50, 0xc0, 0xe1, 0x29,
+	0x35, 0xd4, 0x6a, 0xc8, 0x7f, 0xd1, 0xaa, 0xfe, 0x59, 0xcb, 0x7d, 0x7f, 0x9d, 0x99, 0xe8, 0x26,
+	0x33, 0xd1, 0x8f, 0xcc, 0x44, 0x57, 0x2b, 0xb3, 0x72, 0xb3, 0x32, 0x2b, 0x5f, 0x57, 0x66, 0xe5,
+	0xc3, 0x0b, 0x2f, 0x90, 0xfe, 0x62, 0x6a, 0xcf, 0x60, 0xee, 0x6c, 0x3f, 0x0e, 0x65, 0x99, 0x3f,
+	0x22, 0xb7, 0x1f, 0x8e, 0xa9, 0xa1, 0xe7, 0xc7, 0x3f, 0x07, 0x00, 0xfb, 0x7e, 0x5f, 0x70, 0x9d,
+	0x04, 0x00, 0x00,
 }
 
 func (m *CanonicalBlockID) Marshal() (dAtA []byte, err error) {
@@ -566,6 +624,43 @@ func (m *CanonicalVote) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *CanonicalNodeValidatorProof) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CanonicalNodeValidatorProof) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CanonicalNodeValidatorProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ChainID) > 0 {
+		i -= len(m.ChainID)
+		copy(dAtA[i:], m.ChainID)
+		i = encodeVarintCanonical(dAtA, i, uint64(len(m.ChainID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.NodeID) > 0 {
+		i -= len(m.NodeID)
+		copy(dAtA[i:], m.NodeID)
+		i = encodeVarintCanonical(dAtA, i, uint64(len(m.NodeID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintCanonical(dAtA []byte, offset int, v uint64) int {
 	offset -= sovCanonical(v)
 	base := offset
@@ -667,6 +762,23 @@ func (m *CanonicalVote) Size() (n int) {
 	return n
 }
 
+func (m *CanonicalNodeValidatorProof) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.NodeID)
+	if l > 0 {
+		n += 1 + l + sovCanonical(uint64(l))
+	}
+	l = len(m.ChainID)
+	if l > 0 {
+		n += 1 + l + sovCanonical(uint64(l))
+	}
+	return n
+}
+
 func sovCanonical(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -1292,6 +1404,120 @@ func (m *CanonicalVote) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *CanonicalNodeValidatorProof) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCanonical
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CanonicalNodeValidatorProof: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CanonicalNodeValidatorProof: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCanonical
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCanonical
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCanonical
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NodeID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCanonical
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCanonical
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCanonical
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChainID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCanonical(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCanonical
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipCanonical(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -34,6 +34,7 @@ type ConsensusParams struct {
 	Evidence  *EvidenceParams  `protobuf:"bytes,2,opt,name=evidence,proto3" json:"evidence,omitempty"`
 	Validator *ValidatorParams `protobuf:"bytes,3,opt,name=validator,proto3" json:"validator,omitempty"`
 	Version   *VersionParams   `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	ABCI      *ABCIParams      `protobuf:"bytes,5,opt,name=abci,proto3" json:"abci,omitempty"`
 }
 
 func (m *ConsensusParams) Reset()         { *m = ConsensusParams{} }
@@ -97,6 +98,13 @@ func (m *ConsensusParams) GetVersion() *VersionParams {
 	return nil
 }
 
+func (m *ConsensusParams) GetABCI() *ABCIParams {
+	if m != nil {
+		return m.ABCI
+	}
+	return nil
+}
+
 // BlockParams contains limits on the block size.
 type BlockParams struct {
 	// Max block size, in bytes.
@@ -105,6 +113,13 @@ type BlockParams struct {
 	// Max gas per block.
 	// Note: must be greater or equal to -1
 	MaxGas int64 `protobuf:"varint,2,opt,name=max_gas,json=maxGas,proto3" json:"max_gas,omitempty"`
+	// Size of the parts blocks are split into, in bytes. If 0, 65536 is used.
+	PartSizeBytes int64 `protobuf:"varint,4,opt,name=part_size_bytes,json=partSizeBytes,proto3" json:"part_size_bytes,omitempty"`
+	// Whether block parts are extended with erasure-coded parity parts.
+	ErasureCoding bool `protobuf:"varint,5,opt,name=erasure_coding,json=erasureCoding,proto3" json:"erasure_coding,omitempty"`
+	// Max total gas wanted by the txs of a block, as reported by CheckTx.
+	// Note: must be greater or equal to 0, where 0 means no limit
+	MaxGasWanted int64 `protobuf:"varint,6,opt,name=max_gas_wanted,json=maxGasWanted,proto3" json:"max_gas_wanted,omitempty"`
 }
 
 func (m *BlockParams) Reset()         { *m = BlockParams{} }
@@ -154,6 +169,27 @@ func (m *BlockParams) GetMaxGas() int64 {
 	return 0
 }
 
+func (m *BlockParams) GetPartSizeBytes() int64 {
+	if m != nil {
+		return m.PartSizeBytes
+	}
+	return 0
+}
+
+func (m *BlockParams) GetErasureCoding() bool {
+	if m != nil {
+		return m.ErasureCoding
+	}
+	return false
+}
+
+func (m *BlockParams) GetMaxGasWanted() int64 {
+	if m != nil {
+		return m.MaxGasWanted
+	}
+	return 0
+}
+
 // EvidenceParams determine how we handle evidence of malfeasance.
 type EvidenceParams struct {
 	// Max age of evidence, in blocks.
@@ -231,6 +267,9 @@ func (m *EvidenceParams) GetMaxBytes() int64 {
 // NOTE: uses ABCI pubkey naming, not Amino names.
 type ValidatorParams struct {
 	PubKeyTypes []string `protobuf:"bytes,1,rep,name=pub_key_types,json=pubKeyTypes,proto3" json:"pub_key_types,omitempty"`
+	// Name of the algorithm selecting the proposer of each round. If empty,
+	// the priority-based algorithm.
+	ProposerSelection string `protobuf:"bytes,2,opt,name=proposer_selection,json=proposerSelection,proto3" json:"proposer_selection,omitempty"`
 }
 
 func (m *ValidatorParams) Reset()         { *m = ValidatorParams{} }
@@ -273,6 +312,13 @@ func (m *ValidatorParams) GetPubKeyTypes() []string {
 	return nil
 }
 
+func (m *ValidatorParams) GetProposerSelection() string {
+	if m != nil {
+		return m.ProposerSelection
+	}
+	return ""
+}
+
 // VersionParams contains the ABCI application version.
 type VersionParams struct {
 	AppVersion uint64 `protobuf:"varint,1,opt,name=app_version,json=appVersion,proto3" json:"app_version,omitempty"`
@@ -373,6 +419,53 @@ func (m *HashedParams) GetBlockMaxGas() int64 {
 	return 0
 }
 
+// ABCIParams configure how the results of the application are committed to.
+type ABCIParams struct {
+	// Commit to the events of each block in the LastEventsHash of the next
+	// header.
+	HashEvents bool `protobuf:"varint,1,opt,name=hash_events,json=hashEvents,proto3" json:"hash_events,omitempty"`
+}
+
+func (m *ABCIParams) Reset()         { *m = ABCIParams{} }
+func (m *ABCIParams) String() string { return proto.CompactTextString(m) }
+func (*ABCIParams) ProtoMessage()    {}
+func (*ABCIParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e12598271a686f57, []int{6}
+}
+func (m *ABCIParams) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ABCIParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ABCIParams.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ABCIParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ABCIParams.Merge(m, src)
+}
+func (m *ABCIParams) XXX_Size() int {
+	return m.Size()
+}
+func (m *ABCIParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_ABCIParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ABCIParams proto.InternalMessageInfo
+
+func (m *ABCIParams) GetHashEvents() bool {
+	if m != nil {
+		return m.HashEvents
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*ConsensusParams)(nil), "tendermint.types.ConsensusParams")
 	proto.RegisterType((*BlockParams)(nil), "tendermint.types.BlockParams")
@@ -380,44 +473,53 @@ func init() {
 	proto.RegisterType((*ValidatorParams)(nil), "tendermint.types.ValidatorParams")
 	proto.RegisterType((*VersionParams)(nil), "tendermint.types.VersionParams")
 	proto.RegisterType((*HashedParams)(nil), "tendermint.types.HashedParams")
+	proto.RegisterType((*ABCIParams)(nil), "tendermint.types.ABCIParams")
 }
 
 func init() { proto.RegisterFile("tendermint/types/params.proto", fileDescriptor_e12598271a686f57) }
 
 var fileDescriptor_e12598271a686f57 = []byte{
-	// 498 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x93, 0xc1, 0x6a, 0xd4, 0x40,
-	0x1c, 0xc6, 0x77, 0x9a, 0xda, 0xee, 0xfe, 0xe3, 0x76, 0xcb, 0x20, 0x18, 0x2b, 0xcd, 0xae, 0x39,
-	0x48, 0x41, 0x48, 0xc4, 0x22, 0x22, 0x08, 0xe2, 0x56, 0xa9, 0x20, 0x15, 0x09, 0xea, 0xa1, 0x97,
-	0x30, 0xd9, 0x8c, 0x69, 0xe8, 0x4e, 0x66, 0xc8, 0x24, 0xcb, 0xee, 0xcd, 0x47, 0xf0, 0xe8, 0x23,
-	0xe8, 0x9b, 0xf4, 0xd8, 0xa3, 0x27, 0x95, 0xdd, 0x17, 0x91, 0x4c, 0x32, 0xa6, 0x9b, 0xf6, 0x36,
-	0x33, 0xdf, 0xef, 0x9b, 0xe1, 0xfb, 0x86, 0x3f, 0xec, 0xe7, 0x34, 0x8d, 0x68, 0xc6, 0x92, 0x34,
-	0xf7, 0xf2, 0x85, 0xa0, 0xd2, 0x13, 0x24, 0x23, 0x4c, 0xba, 0x22, 0xe3, 0x39, 0xc7, 0xbb, 0x8d,
-	0xec, 0x2a, 0x79, 0xef, 0x4e, 0xcc, 0x63, 0xae, 0x44, 0xaf, 0x5c, 0x55, 0xdc, 0x9e, 0x1d, 0x73,
-	0x1e, 0x4f, 0xa9, 0xa7, 0x76, 0x61, 0xf1, 0xc5, 0x8b, 0x8a, 0x8c, 0xe4, 0x09, 0x4f, 0x2b, 0xdd,
-	0xf9, 0xba, 0x01, 0x83, 0x23, 0x9e, 0x4a, 0x9a, 0xca, 0x42, 0x7e, 0x50, 0x2f, 0xe0, 0x43, 0xb8,
-	0x15, 0x4e, 0xf9, 0xe4, 0xdc, 0x42, 0x23, 0x74, 0x60, 0x3e, 0xd9, 0x77, 0xdb, 0x6f, 0xb9, 0xe3,
-	0x52, 0xae, 0x68, 0xbf, 0x62, 0xf1, 0x0b, 0xe8, 0xd2, 0x59, 0x12, 0xd1, 0x74, 0x42, 0xad, 0x0d,
-	0xe5, 0x1b, 0x5d, 0xf7, 0xbd, 0xa9, 0x89, 0xda, 0xfa, 0xdf, 0x81, 0x5f, 0x42, 0x6f, 0x46, 0xa6,
-	0x49, 0x44, 0x72, 0x9e, 0x59, 0x86, 0xb2, 0x3f, 0xb8, 0x6e, 0xff, 0xac, 0x91, 0xda, 0xdf, 0x78,
-	0xf0, 0x73, 0xd8, 0x9e, 0xd1, 0x4c, 0x26, 0x3c, 0xb5, 0x36, 0x95, 0x7d, 0x78, 0x83, 0xbd, 0x02,
-	0x6a, 0xb3, 0xe6, 0x9d, 0x23, 0x30, 0xaf, 0xe4, 0xc1, 0xf7, 0xa1, 0xc7, 0xc8, 0x3c, 0x08, 0x17,
-	0x39, 0x95, 0xaa, 0x01, 0xc3, 0xef, 0x32, 0x32, 0x1f, 0x97, 0x7b, 0x7c, 0x17, 0xb6, 0x4b, 0x31,
-	0x26, 0x52, 0x85, 0x34, 0xfc, 0x2d, 0x46, 0xe6, 0xc7, 0x44, 0x3a, 0x3f, 0x11, 0xec, 0xac, 0xa7,
-	0xc3, 0x8f, 0x00, 0x97, 0x2c, 0x89, 0x69, 0x90, 0x16, 0x2c, 0x50, 0x35, 0xe9, 0x1b, 0x07, 0x8c,
-	0xcc, 0x5f, 0xc5, 0xf4, 0x7d, 0xc1, 0xd4, 0xd3, 0x12, 0x9f, 0xc0, 0xae, 0x86, 0xf5, 0x0f, 0xd5,
-	0x35, 0xde, 0x73, 0xab, 0x2f, 0x74, 0xf5, 0x17, 0xba, 0xaf, 0x6b, 0x60, 0xdc, 0xbd, 0xf8, 0x3d,
-	0xec, 0x7c, 0xff, 0x33, 0x44, 0xfe, 0x4e, 0x75, 0x9f, 0x56, 0xd6, 0x43, 0x18, 0xeb, 0x21, 0x9c,
-	0xa7, 0x30, 0x68, 0x35, 0x89, 0x1d, 0xe8, 0x8b, 0x22, 0x0c, 0xce, 0xe9, 0x22, 0x50, 0x5d, 0x59,
-	0x68, 0x64, 0x1c, 0xf4, 0x7c, 0x53, 0x14, 0xe1, 0x3b, 0xba, 0xf8, 0x58, 0x1e, 0x39, 0x8f, 0xa1,
-	0xbf, 0xd6, 0x20, 0x1e, 0x82, 0x49, 0x84, 0x08, 0x74, 0xef, 0x65, 0xb2, 0x4d, 0x1f, 0x88, 0x10,
-	0x35, 0xe6, 0x9c, 0xc2, 0xed, 0xb7, 0x44, 0x9e, 0xd1, 0xa8, 0x36, 0x3c, 0x84, 0x81, 0x6a, 0x21,
-	0x68, 0x17, 0xdc, 0x57, 0xc7, 0x27, 0xba, 0x65, 0x07, 0xfa, 0x0d, 0xd7, 0x74, 0x6d, 0x6a, 0xea,
-	0x98, 0xc8, 0xf1, 0xa7, 0x1f, 0x4b, 0x1b, 0x5d, 0x2c, 0x6d, 0x74, 0xb9, 0xb4, 0xd1, 0xdf, 0xa5,
-	0x8d, 0xbe, 0xad, 0xec, 0xce, 0xe5, 0xca, 0xee, 0xfc, 0x5a, 0xd9, 0x9d, 0xd3, 0x67, 0x71, 0x92,
-	0x9f, 0x15, 0xa1, 0x3b, 0xe1, 0xcc, 0xbb, 0x3a, 0x48, 0xcd, 0xb2, 0x9a, 0x94, 0xf6, 0x90, 0x85,
-	0x5b, 0xea, 0xfc, 0xf0, 0x5f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x18, 0x54, 0x4f, 0xe1, 0x7f, 0x03,
-	0x00, 0x00,
+	// 637 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x94, 0x4f, 0x6e, 0xd3, 0x40,
+	0x14, 0xc6, 0xe3, 0x26, 0x6d, 0x93, 0x97, 0x26, 0x29, 0x23, 0x24, 0x4c, 0xa1, 0x4e, 0xb1, 0xa0,
+	0xaa, 0x84, 0xea, 0x20, 0xba, 0x40, 0x20, 0x24, 0xd4, 0x94, 0xaa, 0x20, 0x54, 0x84, 0x5c, 0xfe,
+	0x48, 0xdd, 0x58, 0xe3, 0xf8, 0xe1, 0x58, 0x8d, 0x3d, 0x96, 0xc7, 0x0e, 0x49, 0x4f, 0xc1, 0x92,
+	0x23, 0xc0, 0x0d, 0x58, 0x70, 0x80, 0x2e, 0xbb, 0x64, 0x55, 0x50, 0x7a, 0x11, 0xe4, 0xb1, 0xa7,
+	0x6e, 0x12, 0x76, 0x9e, 0xf7, 0x7d, 0xbf, 0x99, 0xf1, 0x7b, 0x9f, 0x06, 0xd6, 0x63, 0x0c, 0x1c,
+	0x8c, 0x7c, 0x2f, 0x88, 0x3b, 0xf1, 0x38, 0x44, 0xde, 0x09, 0x69, 0x44, 0x7d, 0x6e, 0x84, 0x11,
+	0x8b, 0x19, 0x59, 0x2d, 0x64, 0x43, 0xc8, 0x6b, 0x37, 0x5d, 0xe6, 0x32, 0x21, 0x76, 0xd2, 0xaf,
+	0xcc, 0xb7, 0xa6, 0xb9, 0x8c, 0xb9, 0x03, 0xec, 0x88, 0x95, 0x9d, 0x7c, 0xee, 0x38, 0x49, 0x44,
+	0x63, 0x8f, 0x05, 0x99, 0xae, 0xff, 0x5a, 0x80, 0xd6, 0x1e, 0x0b, 0x38, 0x06, 0x3c, 0xe1, 0xef,
+	0xc4, 0x09, 0x64, 0x07, 0x16, 0xed, 0x01, 0xeb, 0x9d, 0xa8, 0xca, 0x86, 0xb2, 0x55, 0x7f, 0xbc,
+	0x6e, 0xcc, 0x9e, 0x65, 0x74, 0x53, 0x39, 0x73, 0x9b, 0x99, 0x97, 0x3c, 0x87, 0x2a, 0x0e, 0x3d,
+	0x07, 0x83, 0x1e, 0xaa, 0x0b, 0x82, 0xdb, 0x98, 0xe7, 0xf6, 0x73, 0x47, 0x8e, 0x5e, 0x11, 0xe4,
+	0x05, 0xd4, 0x86, 0x74, 0xe0, 0x39, 0x34, 0x66, 0x91, 0x5a, 0x16, 0xf8, 0xbd, 0x79, 0xfc, 0xa3,
+	0xb4, 0xe4, 0x7c, 0xc1, 0x90, 0xa7, 0xb0, 0x3c, 0xc4, 0x88, 0x7b, 0x2c, 0x50, 0x2b, 0x02, 0x6f,
+	0xff, 0x07, 0xcf, 0x0c, 0x39, 0x2c, 0xfd, 0xe4, 0x19, 0x54, 0xa8, 0xdd, 0xf3, 0xd4, 0x45, 0xc1,
+	0xdd, 0x9d, 0xe7, 0x76, 0xbb, 0x7b, 0xaf, 0x33, 0xa8, 0x5b, 0x9d, 0x5c, 0xb4, 0x2b, 0xe9, 0xda,
+	0x14, 0x8c, 0xfe, 0x53, 0x81, 0xfa, 0xb5, 0x66, 0x90, 0x3b, 0x50, 0xf3, 0xe9, 0xc8, 0xb2, 0xc7,
+	0x31, 0x72, 0xd1, 0xbe, 0xb2, 0x59, 0xf5, 0xe9, 0xa8, 0x9b, 0xae, 0xc9, 0x2d, 0x58, 0x4e, 0x45,
+	0x97, 0x72, 0xd1, 0xa1, 0xb2, 0xb9, 0xe4, 0xd3, 0xd1, 0x01, 0xe5, 0x64, 0x13, 0x5a, 0x21, 0x8d,
+	0x62, 0x8b, 0x7b, 0xa7, 0x98, 0xb3, 0x15, 0x61, 0x68, 0xa4, 0xe5, 0x23, 0xef, 0x14, 0xb3, 0x0d,
+	0x1e, 0x40, 0x13, 0x23, 0xca, 0x93, 0x08, 0xad, 0x1e, 0x73, 0xbc, 0xc0, 0x15, 0x77, 0xae, 0x9a,
+	0x8d, 0xbc, 0xba, 0x27, 0x8a, 0xe4, 0x3e, 0x34, 0xf3, 0x73, 0xac, 0x2f, 0x34, 0x88, 0xd1, 0x51,
+	0x97, 0xc4, 0x6e, 0x2b, 0xd9, 0x71, 0x9f, 0x44, 0x4d, 0xff, 0xa1, 0x40, 0x73, 0x7a, 0x1e, 0xe4,
+	0x21, 0x90, 0x14, 0xa4, 0x2e, 0x5a, 0x41, 0xe2, 0x5b, 0x62, 0xb0, 0xf2, 0x37, 0x5a, 0x3e, 0x1d,
+	0xed, 0xba, 0xf8, 0x36, 0xf1, 0xc5, 0xff, 0x72, 0x72, 0x08, 0xab, 0xd2, 0x2c, 0x33, 0x95, 0x0f,
+	0xfe, 0xb6, 0x91, 0x85, 0xce, 0x90, 0xa1, 0x33, 0x5e, 0xe6, 0x86, 0x6e, 0xf5, 0xec, 0xa2, 0x5d,
+	0xfa, 0xf6, 0xa7, 0xad, 0x98, 0xcd, 0x6c, 0x3f, 0xa9, 0x4c, 0x77, 0xae, 0x3c, 0xdd, 0x39, 0xdd,
+	0x81, 0xd6, 0xcc, 0xec, 0x89, 0x0e, 0x8d, 0x30, 0xb1, 0xad, 0x13, 0x1c, 0x5b, 0x62, 0x4a, 0xaa,
+	0xb2, 0x51, 0xde, 0xaa, 0x99, 0xf5, 0x30, 0xb1, 0xdf, 0xe0, 0xf8, 0x7d, 0x5a, 0x22, 0xdb, 0x40,
+	0xc2, 0x88, 0x85, 0x8c, 0x63, 0x64, 0x71, 0x1c, 0x60, 0xef, 0xea, 0x92, 0x35, 0xf3, 0x86, 0x54,
+	0x8e, 0xa4, 0xa0, 0x3f, 0x82, 0xc6, 0x54, 0x44, 0x48, 0x1b, 0xea, 0x34, 0x0c, 0x2d, 0x19, 0xac,
+	0xb4, 0x11, 0x15, 0x13, 0x68, 0x18, 0xe6, 0x36, 0xfd, 0x18, 0x56, 0x5e, 0x51, 0xde, 0x47, 0x27,
+	0x07, 0x36, 0xa1, 0x25, 0x9a, 0x66, 0xcd, 0x86, 0xa0, 0x21, 0xca, 0x87, 0x32, 0x09, 0x3a, 0x34,
+	0x0a, 0x5f, 0x91, 0x87, 0xba, 0x74, 0x1d, 0x50, 0xae, 0x6f, 0x03, 0x14, 0xc1, 0x4b, 0xaf, 0xd2,
+	0xa7, 0xbc, 0x6f, 0xe1, 0x10, 0x83, 0x38, 0xdb, 0xb5, 0x6a, 0x42, 0x5a, 0xda, 0x17, 0x95, 0xee,
+	0x87, 0xef, 0x13, 0x4d, 0x39, 0x9b, 0x68, 0xca, 0xf9, 0x44, 0x53, 0xfe, 0x4e, 0x34, 0xe5, 0xeb,
+	0xa5, 0x56, 0x3a, 0xbf, 0xd4, 0x4a, 0xbf, 0x2f, 0xb5, 0xd2, 0xf1, 0x13, 0xd7, 0x8b, 0xfb, 0x89,
+	0x6d, 0xf4, 0x98, 0xdf, 0xb9, 0xfe, 0xb0, 0x14, 0x9f, 0xd9, 0xcb, 0x31, 0xfb, 0xe8, 0xd8, 0x4b,
+	0xa2, 0xbe, 0xf3, 0x6f, 0x00, 0x32, 0x8c, 0x55, 0xfd, 0x8f, 0x04, 0x00, 0x00,
 }
 
 func (this *ConsensusParams) Equal(that interface{}) bool {
@@ -451,6 +553,9 @@ func (this *ConsensusParams) Equal(that interface{}) bool {
 	if !this.Version.Equal(that1.Version) {
 		return false
 	}
+	if !this.ABCI.Equal(that1.ABCI) {
+		return false
+	}
 	return true
 }
 func (this *BlockParams) Equal(that interface{}) bool {
@@ -478,6 +583,15 @@ func (this *BlockParams) Equal(that interface{}) bool {
 	if this.MaxGas != that1.MaxGas {
 		return false
 	}
+	if this.PartSizeBytes != that1.PartSizeBytes {
+		return false
+	}
+	if this.ErasureCoding != that1.ErasureCoding {
+		return false
+	}
+	if this.MaxGasWanted != that1.MaxGasWanted {
+		return false
+	}
 	return true
 }
 func (this *EvidenceParams) Equal(that interface{}) bool {
@@ -537,6 +651,9 @@ func (this *ValidatorParams) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if this.ProposerSelection != that1.ProposerSelection {
+		return false
+	}
 	return true
 }
 func (this *VersionParams) Equal(that interface{}) bool {
@@ -590,6 +707,30 @@ func (this *HashedParams) Equal(that interface{}) bool {
 	}
 	return true
 }
+func (this *ABCIParams) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ABCIParams)
+	if !ok {
+		that2, ok := that.(ABCIParams)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.HashEvents != that1.HashEvents {
+		return false
+	}
+	return true
+}
 func (m *ConsensusParams) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -610,6 +751,18 @@ func (m *ConsensusParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.ABCI != nil {
+		{
+			size, err := m.ABCI.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintParams(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
 	if m.Version != nil {
 		{
 			size, err := m.Version.MarshalToSizedBuffer(dAtA[:i])
@@ -681,6 +834,26 @@ func (m *BlockParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.MaxGasWanted != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.MaxGasWanted))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.ErasureCoding {
+		i--
+		if m.ErasureCoding {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.PartSizeBytes != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.PartSizeBytes))
+		i--
+		dAtA[i] = 0x20
+	}
 	if m.MaxGas != 0 {
 		i = encodeVarintParams(dAtA, i, uint64(m.MaxGas))
 		i--
@@ -719,12 +892,12 @@ func (m *EvidenceParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x18
 	}
-	n5, err5 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.MaxAgeDuration, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.MaxAgeDuration):])
-	if err5 != nil {
-		return 0, err5
+	n6, err6 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.MaxAgeDuration, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.MaxAgeDuration):])
+	if err6 != nil {
+		return 0, err6
 	}
-	i -= n5
-	i = encodeVarintParams(dAtA, i, uint64(n5))
+	i -= n6
+	i = encodeVarintParams(dAtA, i, uint64(n6))
 	i--
 	dAtA[i] = 0x12
 	if m.MaxAgeNumBlocks != 0 {
@@ -755,6 +928,13 @@ func (m *ValidatorParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.ProposerSelection) > 0 {
+		i -= len(m.ProposerSelection)
+		copy(dAtA[i:], m.ProposerSelection)
+		i = encodeVarintParams(dAtA, i, uint64(len(m.ProposerSelection)))
+		i--
+		dAtA[i] = 0x12
+	}
 	if len(m.PubKeyTypes) > 0 {
 		for iNdEx := len(m.PubKeyTypes) - 1; iNdEx >= 0; iNdEx-- {
 			i -= len(m.PubKeyTypes[iNdEx])
@@ -828,6 +1008,39 @@ func (m *HashedParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *ABCIParams) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ABCIParams) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ABCIParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.HashEvents {
+		i--
+		if m.HashEvents {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintParams(dAtA []byte, offset int, v uint64) int {
 	offset -= sovParams(v)
 	base := offset
@@ -861,6 +1074,10 @@ func (m *ConsensusParams) Size() (n int) {
 		l = m.Version.Size()
 		n += 1 + l + sovParams(uint64(l))
 	}
+	if m.ABCI != nil {
+		l = m.ABCI.Size()
+		n += 1 + l + sovParams(uint64(l))
+	}
 	return n
 }
 
@@ -876,6 +1093,15 @@ func (m *BlockParams) Size() (n int) {
 	if m.MaxGas != 0 {
 		n += 1 + sovParams(uint64(m.MaxGas))
 	}
+	if m.PartSizeBytes != 0 {
+		n += 1 + sovParams(uint64(m.PartSizeBytes))
+	}
+	if m.ErasureCoding {
+		n += 2
+	}
+	if m.MaxGasWanted != 0 {
+		n += 1 + sovParams(uint64(m.MaxGasWanted))
+	}
 	return n
 }
 
@@ -908,6 +1134,10 @@ func (m *ValidatorParams) Size() (n int) {
 			n += 1 + l + sovParams(uint64(l))
 		}
 	}
+	l = len(m.ProposerSelection)
+	if l > 0 {
+		n += 1 + l + sovParams(uint64(l))
+	}
 	return n
 }
 
@@ -938,6 +1168,18 @@ func (m *HashedParams) Size() (n int) {
 	return n
 }
 
+func (m *ABCIParams) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.HashEvents {
+		n += 2
+	}
+	return n
+}
+
 func sovParams(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -1117,6 +1359,42 @@ func (m *ConsensusParams) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ABCI", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ABCI == nil {
+				m.ABCI = &ABCIParams{}
+			}
+			if err := m.ABCI.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipParams(dAtA[iNdEx:])
@@ -1205,6 +1483,64 @@ func (m *BlockParams) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PartSizeBytes", wireType)
+			}
+			m.PartSizeBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PartSizeBytes |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ErasureCoding", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ErasureCoding = bool(v != 0)
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxGasWanted", wireType)
+			}
+			m.MaxGasWanted = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxGasWanted |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipParams(dAtA[iNdEx:])
@@ -1408,6 +1744,38 @@ func (m *ValidatorParams) Unmarshal(dAtA []byte) error {
 			}
 			m.PubKeyTypes = append(m.PubKeyTypes, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProposerSelection", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ProposerSelection = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipParams(dAtA[iNdEx:])
@@ -1586,6 +1954,76 @@ func (m *HashedParams) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ABCIParams) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowParams
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ABCIParams: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ABCIParams: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HashEvents", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.HashEvents = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipParams(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthParams
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipParams(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
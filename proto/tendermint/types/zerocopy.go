@@ -0,0 +1,171 @@
+package types
+
+import (
+	"errors"
+
+	gogotypes "github.com/gogo/protobuf/types"
+
+	"github.com/tendermint/tendermint/internal/libs/protoio"
+	"github.com/tendermint/tendermint/proto/tendermint/crypto"
+)
+
+// ErrZeroCopyField is returned by the hand-written zero-copy decoders for a
+// field they do not know, for the message to be decoded by Unmarshal instead.
+var ErrZeroCopyField = errors.New("field not decoded without copying")
+
+// UnmarshalZeroCopy unmarshals the vote like proto.Unmarshal, but with its
+// byte fields aliasing b, which must not be modified afterwards.
+func (m *Vote) UnmarshalZeroCopy(b []byte) error {
+	*m = Vote{}
+	if err := m.unmarshalZeroCopy(b); err != nil {
+		// malformed votes are reported, and unknown fields decoded, by the
+		// generated decoder
+		*m = Vote{}
+		return m.Unmarshal(b)
+	}
+	return nil
+}
+
+func (m *Vote) unmarshalZeroCopy(b []byte) error {
+	return protoio.ReadWireFields(b, func(f protoio.WireField) error {
+		switch {
+		case f.Num == 1 && f.WireType == protoio.WireVarint:
+			m.Type = SignedMsgType(f.Varint)
+		case f.Num == 2 && f.WireType == protoio.WireVarint:
+			m.Height = int64(f.Varint)
+		case f.Num == 3 && f.WireType == protoio.WireVarint:
+			m.Round = int32(f.Varint)
+		case f.Num == 4 && f.WireType == protoio.WireBytes:
+			return m.BlockID.unmarshalZeroCopy(f.Bytes)
+		case f.Num == 5 && f.WireType == protoio.WireBytes:
+			return gogotypes.StdTimeUnmarshal(&m.Timestamp, f.Bytes)
+		case f.Num == 6 && f.WireType == protoio.WireBytes:
+			m.ValidatorAddress = f.Bytes
+		case f.Num == 7 && f.WireType == protoio.WireVarint:
+			m.ValidatorIndex = int32(f.Varint)
+		case f.Num == 8 && f.WireType == protoio.WireBytes:
+			m.Signature = f.Bytes
+		default:
+			return ErrZeroCopyField
+		}
+		return nil
+	})
+}
+
+func (m *BlockID) unmarshalZeroCopy(b []byte) error {
+	return protoio.ReadWireFields(b, func(f protoio.WireField) error {
+		switch {
+		case f.Num == 1 && f.WireType == protoio.WireBytes:
+			m.Hash = f.Bytes
+		case f.Num == 2 && f.WireType == protoio.WireBytes:
+			return m.PartSetHeader.unmarshalZeroCopy(f.Bytes)
+		default:
+			return ErrZeroCopyField
+		}
+		return nil
+	})
+}
+
+func (m *PartSetHeader) unmarshalZeroCopy(b []byte) error {
+	return protoio.ReadWireFields(b, func(f protoio.WireField) error {
+		switch {
+		case f.Num == 1 && f.WireType == protoio.WireVarint:
+			m.Total = uint32(f.Varint)
+		case f.Num == 2 && f.WireType == protoio.WireBytes:
+			m.Hash = f.Bytes
+		default:
+			return ErrZeroCopyField
+		}
+		return nil
+	})
+}
+
+// UnmarshalZeroCopy unmarshals the block part like proto.Unmarshal, but with
+// its bytes and proof aliasing b, which must not be modified afterwards.
+func (m *Part) UnmarshalZeroCopy(b []byte) error {
+	*m = Part{}
+	if err := m.unmarshalZeroCopy(b); err != nil {
+		*m = Part{}
+		return m.Unmarshal(b)
+	}
+	return nil
+}
+
+func (m *Part) unmarshalZeroCopy(b []byte) error {
+	return protoio.ReadWireFields(b, func(f protoio.WireField) error {
+		switch {
+		case f.Num == 1 && f.WireType == protoio.WireVarint:
+			m.Index = uint32(f.Varint)
+		case f.Num == 2 && f.WireType == protoio.WireBytes:
+			m.Bytes = f.Bytes
+		case f.Num == 3 && f.WireType == protoio.WireBytes:
+			return unmarshalProofZeroCopy(&m.Proof, f.Bytes)
+		default:
+			return ErrZeroCopyField
+		}
+		return nil
+	})
+}
+
+func unmarshalProofZeroCopy(m *crypto.Proof, b []byte) error {
+	return protoio.ReadWireFields(b, func(f protoio.WireField) error {
+		switch {
+		case f.Num == 1 && f.WireType == protoio.WireVarint:
+			m.Total = int64(f.Varint)
+		case f.Num == 2 && f.WireType == protoio.WireVarint:
+			m.Index = int64(f.Varint)
+		case f.Num == 3 && f.WireType == protoio.WireBytes:
+			m.LeafHash = f.Bytes
+		case f.Num == 4 && f.WireType == protoio.WireBytes:
+			m.Aunts = append(m.Aunts, f.Bytes)
+		default:
+			return ErrZeroCopyField
+		}
+		return nil
+	})
+}
+
+// UnmarshalZeroCopy unmarshals the block like proto.Unmarshal, but with its
+// transactions aliasing b, which must not be modified afterwards. The header,
+// evidence and last commit are decoded by Unmarshal.
+func (m *Block) UnmarshalZeroCopy(b []byte) error {
+	*m = Block{}
+	if err := m.unmarshalZeroCopy(b); err != nil {
+		*m = Block{}
+		return m.Unmarshal(b)
+	}
+	return nil
+}
+
+func (m *Block) unmarshalZeroCopy(b []byte) error {
+	return protoio.ReadWireFields(b, func(f protoio.WireField) error {
+		if f.WireType != protoio.WireBytes {
+			return ErrZeroCopyField
+		}
+		switch f.Num {
+		case 1:
+			return m.Header.Unmarshal(f.Bytes)
+		case 2:
+			return m.Data.unmarshalZeroCopy(f.Bytes)
+		case 3:
+			return m.Evidence.Unmarshal(f.Bytes)
+		case 4:
+			if m.LastCommit == nil {
+				m.LastCommit = &Commit{}
+			}
+			return m.LastCommit.Unmarshal(f.Bytes)
+		default:
+			return ErrZeroCopyField
+		}
+	})
+}
+
+func (m *Data) unmarshalZeroCopy(b []byte) error {
+	return protoio.ReadWireFields(b, func(f protoio.WireField) error {
+		if f.Num != 1 || f.WireType != protoio.WireBytes {
+			return ErrZeroCopyField
+		}
+		m.Txs = append(m.Txs, f.Bytes)
+		return nil
+	})
+}
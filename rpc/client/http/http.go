@@ -236,7 +236,13 @@ func (c *baseRPCClient) ABCIQueryWithOptions(
 	opts rpcclient.ABCIQueryOptions) (*coretypes.ResultABCIQuery, error) {
 	result := new(coretypes.ResultABCIQuery)
 	_, err := c.caller.Call(ctx, "abci_query",
-		map[string]interface{}{"path": path, "data": data, "height": opts.Height, "prove": opts.Prove},
+		map[string]interface{}{
+			"path":   path,
+			"data":   data,
+			"height": opts.Height,
+			"prove":  opts.Prove,
+			"verify": opts.Verify,
+		},
 		result)
 	if err != nil {
 		return nil, err
@@ -257,6 +263,20 @@ func (c *baseRPCClient) BroadcastTxCommit(
 	return result, nil
 }
 
+func (c *baseRPCClient) BroadcastTxCommitProof(
+	ctx context.Context,
+	tx types.Tx,
+	timeout time.Duration,
+) (*coretypes.ResultBroadcastTxCommitProof, error) {
+	result := new(coretypes.ResultBroadcastTxCommitProof)
+	_, err := c.caller.Call(ctx, "broadcast_tx_commit_proof",
+		map[string]interface{}{"tx": tx, "timeout": timeout}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) BroadcastTxAsync(
 	ctx context.Context,
 	tx types.Tx,
@@ -326,6 +346,38 @@ func (c *baseRPCClient) RemoveTx(ctx context.Context, txKey types.TxKey) error {
 	return nil
 }
 
+func (c *baseRPCClient) ArchivedTx(ctx context.Context, hash bytes.HexBytes) (*coretypes.ResultArchivedTx, error) {
+	result := new(coretypes.ResultArchivedTx)
+	_, err := c.caller.Call(ctx, "archived_tx", map[string]interface{}{"hash": hash}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) ArchivedTxs(
+	ctx context.Context,
+	sender, status string,
+	page, perPage *int,
+) (*coretypes.ResultArchivedTxs, error) {
+	result := new(coretypes.ResultArchivedTxs)
+	params := map[string]interface{}{
+		"sender": sender,
+		"status": status,
+	}
+	if page != nil {
+		params["page"] = page
+	}
+	if perPage != nil {
+		params["per_page"] = perPage
+	}
+	_, err := c.caller.Call(ctx, "archived_txs", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) NetInfo(ctx context.Context) (*coretypes.ResultNetInfo, error) {
 	result := new(coretypes.ResultNetInfo)
 	_, err := c.caller.Call(ctx, "net_info", map[string]interface{}{}, result)
@@ -335,6 +387,15 @@ func (c *baseRPCClient) NetInfo(ctx context.Context) (*coretypes.ResultNetInfo,
 	return result, nil
 }
 
+func (c *baseRPCClient) NetTopology(ctx context.Context) (*coretypes.ResultNetTopology, error) {
+	result := new(coretypes.ResultNetTopology)
+	_, err := c.caller.Call(ctx, "net_topology", map[string]interface{}{}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) DumpConsensusState(ctx context.Context) (*coretypes.ResultDumpConsensusState, error) {
 	result := new(coretypes.ResultDumpConsensusState)
 	_, err := c.caller.Call(ctx, "dump_consensus_state", map[string]interface{}{}, result)
@@ -344,6 +405,22 @@ func (c *baseRPCClient) DumpConsensusState(ctx context.Context) (*coretypes.Resu
 	return result, nil
 }
 
+func (c *baseRPCClient) AppHashMismatches(
+	ctx context.Context,
+	height *int64,
+) (*coretypes.ResultAppHashMismatches, error) {
+	result := new(coretypes.ResultAppHashMismatches)
+	params := make(map[string]interface{})
+	if height != nil {
+		params["height"] = height
+	}
+	_, err := c.caller.Call(ctx, "app_hash_mismatches", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) ConsensusState(ctx context.Context) (*coretypes.ResultConsensusState, error) {
 	result := new(coretypes.ResultConsensusState)
 	_, err := c.caller.Call(ctx, "consensus_state", map[string]interface{}{}, result)
@@ -353,6 +430,15 @@ func (c *baseRPCClient) ConsensusState(ctx context.Context) (*coretypes.ResultCo
 	return result, nil
 }
 
+func (c *baseRPCClient) ConsensusTimeStats(ctx context.Context) (*coretypes.ResultConsensusTimeStats, error) {
+	result := new(coretypes.ResultConsensusTimeStats)
+	_, err := c.caller.Call(ctx, "consensus_time_stats", map[string]interface{}{}, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) ConsensusParams(
 	ctx context.Context,
 	height *int64,
@@ -539,12 +625,14 @@ func (c *baseRPCClient) BlockSearch(
 	query string,
 	page, perPage *int,
 	orderBy string,
+	matchEvents bool,
 ) (*coretypes.ResultBlockSearch, error) {
 
 	result := new(coretypes.ResultBlockSearch)
 	params := map[string]interface{}{
-		"query":    query,
-		"order_by": orderBy,
+		"query":        query,
+		"order_by":     orderBy,
+		"match_events": matchEvents,
 	}
 
 	if page != nil {
@@ -586,6 +674,21 @@ func (c *baseRPCClient) Validators(
 	return result, nil
 }
 
+func (c *baseRPCClient) ValidatorUpdates(
+	ctx context.Context,
+	minHeight,
+	maxHeight int64,
+) (*coretypes.ResultValidatorUpdates, error) {
+	result := new(coretypes.ResultValidatorUpdates)
+	_, err := c.caller.Call(ctx, "validator_updates",
+		map[string]interface{}{"min_height": minHeight, "max_height": maxHeight},
+		result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) BroadcastEvidence(
 	ctx context.Context,
 	ev types.Evidence,
@@ -597,3 +700,33 @@ func (c *baseRPCClient) BroadcastEvidence(
 	}
 	return result, nil
 }
+
+func (c *baseRPCClient) Evidence(
+	ctx context.Context,
+	committed bool,
+	evType string,
+	minHeight, maxHeight int64,
+	validatorAddress bytes.HexBytes,
+	page, perPage *int,
+) (*coretypes.ResultEvidenceList, error) {
+	result := new(coretypes.ResultEvidenceList)
+	params := map[string]interface{}{
+		"committed":         committed,
+		"type":              evType,
+		"min_height":        minHeight,
+		"max_height":        maxHeight,
+		"validator_address": validatorAddress,
+	}
+	if page != nil {
+		params["page"] = page
+	}
+	if perPage != nil {
+		params["per_page"] = perPage
+	}
+
+	_, err := c.caller.Call(ctx, "evidence", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -22,6 +22,7 @@ implementation.
 
 import (
 	"context"
+	"time"
 
 	"github.com/tendermint/tendermint/libs/bytes"
 	"github.com/tendermint/tendermint/rpc/coretypes"
@@ -65,6 +66,10 @@ type ABCIClient interface {
 
 	// Writing to abci app
 	BroadcastTxCommit(context.Context, types.Tx) (*coretypes.ResultBroadcastTxCommit, error)
+	// BroadcastTxCommitProof waits for at most the given timeout, or the
+	// node's default if zero, for the tx to be committed, and returns a proof
+	// of its inclusion in the block.
+	BroadcastTxCommitProof(ctx context.Context, tx types.Tx, timeout time.Duration) (*coretypes.ResultBroadcastTxCommitProof, error)
 	BroadcastTxAsync(context.Context, types.Tx) (*coretypes.ResultBroadcastTx, error)
 	BroadcastTxSync(context.Context, types.Tx) (*coretypes.ResultBroadcastTx, error)
 }
@@ -79,6 +84,9 @@ type SignClient interface {
 	HeaderByHash(ctx context.Context, hash bytes.HexBytes) (*coretypes.ResultHeader, error)
 	Commit(ctx context.Context, height *int64) (*coretypes.ResultCommit, error)
 	Validators(ctx context.Context, height *int64, page, perPage *int) (*coretypes.ResultValidators, error)
+	// ValidatorUpdates returns the changes of the validator set made by the
+	// validator updates of the blocks minHeight <= height <= maxHeight.
+	ValidatorUpdates(ctx context.Context, minHeight, maxHeight int64) (*coretypes.ResultValidatorUpdates, error)
 	Tx(ctx context.Context, hash bytes.HexBytes, prove bool) (*coretypes.ResultTx, error)
 
 	// TxSearch defines a method to search for a paginated set of transactions by
@@ -92,12 +100,15 @@ type SignClient interface {
 	) (*coretypes.ResultTxSearch, error)
 
 	// BlockSearch defines a method to search for a paginated set of blocks by
-	// BeginBlock and EndBlock event search criteria.
+	// BeginBlock and EndBlock event search criteria. If matchEvents is true,
+	// the event conditions of each query clause must be satisfied by a single
+	// event.
 	BlockSearch(
 		ctx context.Context,
 		query string,
 		page, perPage *int,
 		orderBy string,
+		matchEvents bool,
 	) (*coretypes.ResultBlockSearch, error)
 }
 
@@ -117,8 +128,20 @@ type StatusClient interface {
 // usually.
 type NetworkClient interface {
 	NetInfo(context.Context) (*coretypes.ResultNetInfo, error)
+	// NetTopology returns the network graph as seen by the node: its
+	// connected peers, with the latencies and channels of their connections,
+	// and the nodes they reported over PEX.
+	NetTopology(context.Context) (*coretypes.ResultNetTopology, error)
 	DumpConsensusState(context.Context) (*coretypes.ResultDumpConsensusState, error)
+	// AppHashMismatches returns the forensic dumps written when the app hash
+	// of the committed block at the given height, or at the greatest height
+	// with dumps if nil, did not match the app hash of the application.
+	AppHashMismatches(ctx context.Context, height *int64) (*coretypes.ResultAppHashMismatches, error)
 	ConsensusState(context.Context) (*coretypes.ResultConsensusState, error)
+	// ConsensusTimeStats returns the average time between the latest heights
+	// committed by the node, the number of rounds they took and how often the
+	// timeouts of the consensus steps fired.
+	ConsensusTimeStats(context.Context) (*coretypes.ResultConsensusTimeStats, error)
 	ConsensusParams(ctx context.Context, height *int64) (*coretypes.ResultConsensusParams, error)
 	Health(context.Context) (*coretypes.ResultHealth, error)
 }
@@ -146,12 +169,31 @@ type MempoolClient interface {
 	NumUnconfirmedTxs(context.Context) (*coretypes.ResultUnconfirmedTxs, error)
 	CheckTx(context.Context, types.Tx) (*coretypes.ResultCheckTx, error)
 	RemoveTx(context.Context, types.TxKey) error
+	// ArchivedTx returns a transaction of the archive of the transactions
+	// checked by the mempool of the node, with its last status.
+	ArchivedTx(ctx context.Context, hash bytes.HexBytes) (*coretypes.ResultArchivedTx, error)
+	// ArchivedTxs returns a paginated set of the archived transactions of a
+	// sender, newest first, of the given status only if not empty.
+	ArchivedTxs(ctx context.Context, sender, status string, page, perPage *int) (*coretypes.ResultArchivedTxs, error)
 }
 
 // EvidenceClient is used for submitting an evidence of the malicious
 // behavior.
 type EvidenceClient interface {
 	BroadcastEvidence(context.Context, types.Evidence) (*coretypes.ResultBroadcastEvidence, error)
+
+	// Evidence lists a paginated set of pending or committed evidence,
+	// optionally filtered by type, by the range of heights of the misbehavior
+	// (where a zero max height is unbounded) and by the address of a
+	// misbehaving validator.
+	Evidence(
+		ctx context.Context,
+		committed bool,
+		evType string,
+		minHeight, maxHeight int64,
+		validatorAddress bytes.HexBytes,
+		page, perPage *int,
+	) (*coretypes.ResultEvidenceList, error)
 }
 
 // RemoteClient is a Client, which can also return the remote network address.
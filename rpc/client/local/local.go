@@ -90,13 +90,21 @@ func (c *Local) ABCIQueryWithOptions(
 	path string,
 	data bytes.HexBytes,
 	opts rpcclient.ABCIQueryOptions) (*coretypes.ResultABCIQuery, error) {
-	return c.env.ABCIQuery(c.ctx, path, data, opts.Height, opts.Prove)
+	return c.env.ABCIQuery(c.ctx, path, data, opts.Height, opts.Prove, opts.Verify)
 }
 
 func (c *Local) BroadcastTxCommit(ctx context.Context, tx types.Tx) (*coretypes.ResultBroadcastTxCommit, error) {
 	return c.env.BroadcastTxCommit(c.ctx, tx)
 }
 
+func (c *Local) BroadcastTxCommitProof(
+	ctx context.Context,
+	tx types.Tx,
+	timeout time.Duration,
+) (*coretypes.ResultBroadcastTxCommitProof, error) {
+	return c.env.BroadcastTxCommitProof(c.ctx, tx, timeout)
+}
+
 func (c *Local) BroadcastTxAsync(ctx context.Context, tx types.Tx) (*coretypes.ResultBroadcastTx, error) {
 	return c.env.BroadcastTxAsync(c.ctx, tx)
 }
@@ -121,18 +129,42 @@ func (c *Local) RemoveTx(ctx context.Context, txKey types.TxKey) error {
 	return c.env.Mempool.RemoveTxByKey(txKey)
 }
 
+func (c *Local) ArchivedTx(ctx context.Context, hash bytes.HexBytes) (*coretypes.ResultArchivedTx, error) {
+	return c.env.ArchivedTx(c.ctx, hash)
+}
+
+func (c *Local) ArchivedTxs(
+	ctx context.Context,
+	sender, status string,
+	page, perPage *int,
+) (*coretypes.ResultArchivedTxs, error) {
+	return c.env.ArchivedTxs(c.ctx, sender, status, page, perPage)
+}
+
 func (c *Local) NetInfo(ctx context.Context) (*coretypes.ResultNetInfo, error) {
 	return c.env.NetInfo(c.ctx)
 }
 
+func (c *Local) NetTopology(ctx context.Context) (*coretypes.ResultNetTopology, error) {
+	return c.env.NetTopology(c.ctx)
+}
+
 func (c *Local) DumpConsensusState(ctx context.Context) (*coretypes.ResultDumpConsensusState, error) {
 	return c.env.DumpConsensusState(c.ctx)
 }
 
+func (c *Local) AppHashMismatches(ctx context.Context, height *int64) (*coretypes.ResultAppHashMismatches, error) {
+	return c.env.AppHashMismatches(c.ctx, height)
+}
+
 func (c *Local) ConsensusState(ctx context.Context) (*coretypes.ResultConsensusState, error) {
 	return c.env.GetConsensusState(c.ctx)
 }
 
+func (c *Local) ConsensusTimeStats(ctx context.Context) (*coretypes.ResultConsensusTimeStats, error) {
+	return c.env.ConsensusTimeStats(c.ctx)
+}
+
 func (c *Local) ConsensusParams(ctx context.Context, height *int64) (*coretypes.ResultConsensusParams, error) {
 	return c.env.ConsensusParams(c.ctx, height)
 }
@@ -162,7 +194,7 @@ func (c *Local) BlockByHash(ctx context.Context, hash bytes.HexBytes) (*coretype
 }
 
 func (c *Local) BlockResults(ctx context.Context, height *int64) (*coretypes.ResultBlockResults, error) {
-	return c.env.BlockResults(c.ctx, height)
+	return c.env.BlockResults(c.ctx, height, false)
 }
 
 func (c *Local) Header(ctx context.Context, height *int64) (*coretypes.ResultHeader, error) {
@@ -178,7 +210,11 @@ func (c *Local) Commit(ctx context.Context, height *int64) (*coretypes.ResultCom
 }
 
 func (c *Local) Validators(ctx context.Context, height *int64, page, perPage *int) (*coretypes.ResultValidators, error) {
-	return c.env.Validators(c.ctx, height, page, perPage)
+	return c.env.Validators(c.ctx, height, page, perPage, "", nil)
+}
+
+func (c *Local) ValidatorUpdates(ctx context.Context, minHeight, maxHeight int64) (*coretypes.ResultValidatorUpdates, error) {
+	return c.env.ValidatorUpdates(c.ctx, minHeight, maxHeight)
 }
 
 func (c *Local) Tx(ctx context.Context, hash bytes.HexBytes, prove bool) (*coretypes.ResultTx, error) {
@@ -201,14 +237,26 @@ func (c *Local) BlockSearch(
 	queryString string,
 	page, perPage *int,
 	orderBy string,
+	matchEvents bool,
 ) (*coretypes.ResultBlockSearch, error) {
-	return c.env.BlockSearch(c.ctx, queryString, page, perPage, orderBy)
+	return c.env.BlockSearch(c.ctx, queryString, page, perPage, orderBy, matchEvents)
 }
 
 func (c *Local) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*coretypes.ResultBroadcastEvidence, error) {
 	return c.env.BroadcastEvidence(c.ctx, ev)
 }
 
+func (c *Local) Evidence(
+	ctx context.Context,
+	committed bool,
+	evType string,
+	minHeight, maxHeight int64,
+	validatorAddress bytes.HexBytes,
+	page, perPage *int,
+) (*coretypes.ResultEvidenceList, error) {
+	return c.env.Evidence(c.ctx, committed, evType, minHeight, maxHeight, validatorAddress, page, perPage)
+}
+
 func (c *Local) Subscribe(
 	ctx context.Context,
 	subscriber,
@@ -2,6 +2,7 @@ package mock
 
 import (
 	"context"
+	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/internal/proxy"
@@ -55,16 +56,37 @@ func (a ABCIApp) BroadcastTxCommit(ctx context.Context, tx types.Tx) (*coretypes
 	if res.CheckTx.IsErr() {
 		return &res, nil
 	}
-	res.DeliverTx = a.App.DeliverTx(abci.RequestDeliverTx{Tx: tx})
+	fb := a.App.FinalizeBlock(abci.RequestFinalizeBlock{Txs: [][]byte{tx}})
+	res.DeliverTx = *fb.Txs[0]
 	res.Height = -1 // TODO
 	return &res, nil
 }
 
+// NOTE: Caller should call a.App.Commit() separately,
+// this function does not actually wait for a commit.
+func (a ABCIApp) BroadcastTxCommitProof(
+	ctx context.Context,
+	tx types.Tx,
+	timeout time.Duration,
+) (*coretypes.ResultBroadcastTxCommitProof, error) {
+	res := coretypes.ResultBroadcastTxCommitProof{}
+	res.CheckTx = a.App.CheckTx(abci.RequestCheckTx{Tx: tx})
+	res.Hash = tx.Hash()
+	if res.CheckTx.IsErr() {
+		return &res, nil
+	}
+	fb := a.App.FinalizeBlock(abci.RequestFinalizeBlock{Txs: [][]byte{tx}})
+	res.DeliverTx = *fb.Txs[0]
+	res.Height = -1 // TODO
+	res.Proof = types.Txs{tx}.Proof(0)
+	return &res, nil
+}
+
 func (a ABCIApp) BroadcastTxAsync(ctx context.Context, tx types.Tx) (*coretypes.ResultBroadcastTx, error) {
 	c := a.App.CheckTx(abci.RequestCheckTx{Tx: tx})
 	// and this gets written in a background thread...
 	if !c.IsErr() {
-		go func() { a.App.DeliverTx(abci.RequestDeliverTx{Tx: tx}) }()
+		go func() { a.App.FinalizeBlock(abci.RequestFinalizeBlock{Txs: [][]byte{tx}}) }()
 	}
 	return &coretypes.ResultBroadcastTx{
 		Code:      c.Code,
@@ -79,7 +101,7 @@ func (a ABCIApp) BroadcastTxSync(ctx context.Context, tx types.Tx) (*coretypes.R
 	c := a.App.CheckTx(abci.RequestCheckTx{Tx: tx})
 	// and this gets written in a background thread...
 	if !c.IsErr() {
-		go func() { a.App.DeliverTx(abci.RequestDeliverTx{Tx: tx}) }()
+		go func() { a.App.FinalizeBlock(abci.RequestFinalizeBlock{Txs: [][]byte{tx}}) }()
 	}
 	return &coretypes.ResultBroadcastTx{
 		Code:      c.Code,
@@ -97,7 +119,9 @@ type ABCIMock struct {
 	Info            Call
 	Query           Call
 	BroadcastCommit Call
-	Broadcast       Call
+	// BroadcastCommitProof responds to BroadcastTxCommitProof
+	BroadcastCommitProof Call
+	Broadcast            Call
 }
 
 func (m ABCIMock) ABCIInfo(ctx context.Context) (*coretypes.ResultABCIInfo, error) {
@@ -133,6 +157,18 @@ func (m ABCIMock) BroadcastTxCommit(ctx context.Context, tx types.Tx) (*coretype
 	return res.(*coretypes.ResultBroadcastTxCommit), nil
 }
 
+func (m ABCIMock) BroadcastTxCommitProof(
+	ctx context.Context,
+	tx types.Tx,
+	timeout time.Duration,
+) (*coretypes.ResultBroadcastTxCommitProof, error) {
+	res, err := m.BroadcastCommitProof.GetResponse(tx)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*coretypes.ResultBroadcastTxCommitProof), nil
+}
+
 func (m ABCIMock) BroadcastTxAsync(ctx context.Context, tx types.Tx) (*coretypes.ResultBroadcastTx, error) {
 	res, err := m.Broadcast.GetResponse(tx)
 	if err != nil {
@@ -218,6 +254,21 @@ func (r *ABCIRecorder) BroadcastTxCommit(ctx context.Context, tx types.Tx) (*cor
 	return res, err
 }
 
+func (r *ABCIRecorder) BroadcastTxCommitProof(
+	ctx context.Context,
+	tx types.Tx,
+	timeout time.Duration,
+) (*coretypes.ResultBroadcastTxCommitProof, error) {
+	res, err := r.Client.BroadcastTxCommitProof(ctx, tx, timeout)
+	r.addCall(Call{
+		Name:     "broadcast_tx_commit_proof",
+		Args:     tx,
+		Response: res,
+		Error:    err,
+	})
+	return res, err
+}
+
 func (r *ABCIRecorder) BroadcastTxAsync(ctx context.Context, tx types.Tx) (*coretypes.ResultBroadcastTx, error) {
 	res, err := r.Client.BroadcastTxAsync(ctx, tx)
 	r.addCall(Call{
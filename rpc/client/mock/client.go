@@ -17,6 +17,7 @@ want to directly call a tendermint node in process, you can use the
 import (
 	"context"
 	"reflect"
+	"time"
 
 	"github.com/tendermint/tendermint/internal/rpc/core"
 	"github.com/tendermint/tendermint/libs/bytes"
@@ -92,13 +93,21 @@ func (c Client) ABCIQueryWithOptions(
 	path string,
 	data bytes.HexBytes,
 	opts client.ABCIQueryOptions) (*coretypes.ResultABCIQuery, error) {
-	return c.env.ABCIQuery(&rpctypes.Context{}, path, data, opts.Height, opts.Prove)
+	return c.env.ABCIQuery(&rpctypes.Context{}, path, data, opts.Height, opts.Prove, opts.Verify)
 }
 
 func (c Client) BroadcastTxCommit(ctx context.Context, tx types.Tx) (*coretypes.ResultBroadcastTxCommit, error) {
 	return c.env.BroadcastTxCommit(&rpctypes.Context{}, tx)
 }
 
+func (c Client) BroadcastTxCommitProof(
+	ctx context.Context,
+	tx types.Tx,
+	timeout time.Duration,
+) (*coretypes.ResultBroadcastTxCommitProof, error) {
+	return c.env.BroadcastTxCommitProof(&rpctypes.Context{}, tx, timeout)
+}
+
 func (c Client) BroadcastTxAsync(ctx context.Context, tx types.Tx) (*coretypes.ResultBroadcastTx, error) {
 	return c.env.BroadcastTxAsync(&rpctypes.Context{}, tx)
 }
@@ -115,14 +124,26 @@ func (c Client) NetInfo(ctx context.Context) (*coretypes.ResultNetInfo, error) {
 	return c.env.NetInfo(&rpctypes.Context{})
 }
 
+func (c Client) NetTopology(ctx context.Context) (*coretypes.ResultNetTopology, error) {
+	return c.env.NetTopology(&rpctypes.Context{})
+}
+
 func (c Client) ConsensusState(ctx context.Context) (*coretypes.ResultConsensusState, error) {
 	return c.env.GetConsensusState(&rpctypes.Context{})
 }
 
+func (c Client) ConsensusTimeStats(ctx context.Context) (*coretypes.ResultConsensusTimeStats, error) {
+	return c.env.ConsensusTimeStats(&rpctypes.Context{})
+}
+
 func (c Client) DumpConsensusState(ctx context.Context) (*coretypes.ResultDumpConsensusState, error) {
 	return c.env.DumpConsensusState(&rpctypes.Context{})
 }
 
+func (c Client) AppHashMismatches(ctx context.Context, height *int64) (*coretypes.ResultAppHashMismatches, error) {
+	return c.env.AppHashMismatches(&rpctypes.Context{}, height)
+}
+
 func (c Client) ConsensusParams(ctx context.Context, height *int64) (*coretypes.ResultConsensusParams, error) {
 	return c.env.ConsensusParams(&rpctypes.Context{}, height)
 }
@@ -152,9 +173,24 @@ func (c Client) Commit(ctx context.Context, height *int64) (*coretypes.ResultCom
 }
 
 func (c Client) Validators(ctx context.Context, height *int64, page, perPage *int) (*coretypes.ResultValidators, error) {
-	return c.env.Validators(&rpctypes.Context{}, height, page, perPage)
+	return c.env.Validators(&rpctypes.Context{}, height, page, perPage, "", nil)
+}
+
+func (c Client) ValidatorUpdates(ctx context.Context, minHeight, maxHeight int64) (*coretypes.ResultValidatorUpdates, error) {
+	return c.env.ValidatorUpdates(&rpctypes.Context{}, minHeight, maxHeight)
 }
 
 func (c Client) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*coretypes.ResultBroadcastEvidence, error) {
 	return c.env.BroadcastEvidence(&rpctypes.Context{}, ev)
 }
+
+func (c Client) Evidence(
+	ctx context.Context,
+	committed bool,
+	evType string,
+	minHeight, maxHeight int64,
+	validatorAddress bytes.HexBytes,
+	page, perPage *int,
+) (*coretypes.ResultEvidenceList, error) {
+	return c.env.Evidence(&rpctypes.Context{}, committed, evType, minHeight, maxHeight, validatorAddress, page, perPage)
+}
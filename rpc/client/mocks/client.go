@@ -12,6 +12,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	time "time"
+
 	types "github.com/tendermint/tendermint/types"
 )
 
@@ -89,6 +91,75 @@ func (_m *Client) ABCIQueryWithOptions(ctx context.Context, path string, data by
 	return r0, r1
 }
 
+// AppHashMismatches provides a mock function with given fields: ctx, height
+func (_m *Client) AppHashMismatches(ctx context.Context, height *int64) (*coretypes.ResultAppHashMismatches, error) {
+	ret := _m.Called(ctx, height)
+
+	var r0 *coretypes.ResultAppHashMismatches
+	if rf, ok := ret.Get(0).(func(context.Context, *int64) *coretypes.ResultAppHashMismatches); ok {
+		r0 = rf(ctx, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultAppHashMismatches)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *int64) error); ok {
+		r1 = rf(ctx, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ArchivedTx provides a mock function with given fields: ctx, hash
+func (_m *Client) ArchivedTx(ctx context.Context, hash bytes.HexBytes) (*coretypes.ResultArchivedTx, error) {
+	ret := _m.Called(ctx, hash)
+
+	var r0 *coretypes.ResultArchivedTx
+	if rf, ok := ret.Get(0).(func(context.Context, bytes.HexBytes) *coretypes.ResultArchivedTx); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultArchivedTx)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, bytes.HexBytes) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ArchivedTxs provides a mock function with given fields: ctx, sender, status, page, perPage
+func (_m *Client) ArchivedTxs(ctx context.Context, sender string, status string, page *int, perPage *int) (*coretypes.ResultArchivedTxs, error) {
+	ret := _m.Called(ctx, sender, status, page, perPage)
+
+	var r0 *coretypes.ResultArchivedTxs
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *int, *int) *coretypes.ResultArchivedTxs); ok {
+		r0 = rf(ctx, sender, status, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultArchivedTxs)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *int, *int) error); ok {
+		r1 = rf(ctx, sender, status, page, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Block provides a mock function with given fields: ctx, height
 func (_m *Client) Block(ctx context.Context, height *int64) (*coretypes.ResultBlock, error) {
 	ret := _m.Called(ctx, height)
@@ -158,13 +229,13 @@ func (_m *Client) BlockResults(ctx context.Context, height *int64) (*coretypes.R
 	return r0, r1
 }
 
-// BlockSearch provides a mock function with given fields: ctx, query, page, perPage, orderBy
-func (_m *Client) BlockSearch(ctx context.Context, query string, page *int, perPage *int, orderBy string) (*coretypes.ResultBlockSearch, error) {
-	ret := _m.Called(ctx, query, page, perPage, orderBy)
+// BlockSearch provides a mock function with given fields: ctx, query, page, perPage, orderBy, matchEvents
+func (_m *Client) BlockSearch(ctx context.Context, query string, page *int, perPage *int, orderBy string, matchEvents bool) (*coretypes.ResultBlockSearch, error) {
+	ret := _m.Called(ctx, query, page, perPage, orderBy, matchEvents)
 
 	var r0 *coretypes.ResultBlockSearch
-	if rf, ok := ret.Get(0).(func(context.Context, string, *int, *int, string) *coretypes.ResultBlockSearch); ok {
-		r0 = rf(ctx, query, page, perPage, orderBy)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *int, *int, string, bool) *coretypes.ResultBlockSearch); ok {
+		r0 = rf(ctx, query, page, perPage, orderBy, matchEvents)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*coretypes.ResultBlockSearch)
@@ -172,8 +243,8 @@ func (_m *Client) BlockSearch(ctx context.Context, query string, page *int, perP
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string, *int, *int, string) error); ok {
-		r1 = rf(ctx, query, page, perPage, orderBy)
+	if rf, ok := ret.Get(1).(func(context.Context, string, *int, *int, string, bool) error); ok {
+		r1 = rf(ctx, query, page, perPage, orderBy, matchEvents)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -273,6 +344,29 @@ func (_m *Client) BroadcastTxCommit(_a0 context.Context, _a1 types.Tx) (*coretyp
 	return r0, r1
 }
 
+// BroadcastTxCommitProof provides a mock function with given fields: ctx, tx, timeout
+func (_m *Client) BroadcastTxCommitProof(ctx context.Context, tx types.Tx, timeout time.Duration) (*coretypes.ResultBroadcastTxCommitProof, error) {
+	ret := _m.Called(ctx, tx, timeout)
+
+	var r0 *coretypes.ResultBroadcastTxCommitProof
+	if rf, ok := ret.Get(0).(func(context.Context, types.Tx, time.Duration) *coretypes.ResultBroadcastTxCommitProof); ok {
+		r0 = rf(ctx, tx, timeout)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultBroadcastTxCommitProof)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.Tx, time.Duration) error); ok {
+		r1 = rf(ctx, tx, timeout)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // BroadcastTxSync provides a mock function with given fields: _a0, _a1
 func (_m *Client) BroadcastTxSync(_a0 context.Context, _a1 types.Tx) (*coretypes.ResultBroadcastTx, error) {
 	ret := _m.Called(_a0, _a1)
@@ -388,6 +482,29 @@ func (_m *Client) ConsensusState(_a0 context.Context) (*coretypes.ResultConsensu
 	return r0, r1
 }
 
+// ConsensusTimeStats provides a mock function with given fields: _a0
+func (_m *Client) ConsensusTimeStats(_a0 context.Context) (*coretypes.ResultConsensusTimeStats, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *coretypes.ResultConsensusTimeStats
+	if rf, ok := ret.Get(0).(func(context.Context) *coretypes.ResultConsensusTimeStats); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultConsensusTimeStats)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DumpConsensusState provides a mock function with given fields: _a0
 func (_m *Client) DumpConsensusState(_a0 context.Context) (*coretypes.ResultDumpConsensusState, error) {
 	ret := _m.Called(_a0)
@@ -411,6 +528,29 @@ func (_m *Client) DumpConsensusState(_a0 context.Context) (*coretypes.ResultDump
 	return r0, r1
 }
 
+// Evidence provides a mock function with given fields: ctx, committed, evType, minHeight, maxHeight, validatorAddress, page, perPage
+func (_m *Client) Evidence(ctx context.Context, committed bool, evType string, minHeight int64, maxHeight int64, validatorAddress bytes.HexBytes, page *int, perPage *int) (*coretypes.ResultEvidenceList, error) {
+	ret := _m.Called(ctx, committed, evType, minHeight, maxHeight, validatorAddress, page, perPage)
+
+	var r0 *coretypes.ResultEvidenceList
+	if rf, ok := ret.Get(0).(func(context.Context, bool, string, int64, int64, bytes.HexBytes, *int, *int) *coretypes.ResultEvidenceList); ok {
+		r0 = rf(ctx, committed, evType, minHeight, maxHeight, validatorAddress, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultEvidenceList)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, bool, string, int64, int64, bytes.HexBytes, *int, *int) error); ok {
+		r1 = rf(ctx, committed, evType, minHeight, maxHeight, validatorAddress, page, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Genesis provides a mock function with given fields: _a0
 func (_m *Client) Genesis(_a0 context.Context) (*coretypes.ResultGenesis, error) {
 	ret := _m.Called(_a0)
@@ -563,6 +703,29 @@ func (_m *Client) NetInfo(_a0 context.Context) (*coretypes.ResultNetInfo, error)
 	return r0, r1
 }
 
+// NetTopology provides a mock function with given fields: _a0
+func (_m *Client) NetTopology(_a0 context.Context) (*coretypes.ResultNetTopology, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *coretypes.ResultNetTopology
+	if rf, ok := ret.Get(0).(func(context.Context) *coretypes.ResultNetTopology); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultNetTopology)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NumUnconfirmedTxs provides a mock function with given fields: _a0
 func (_m *Client) NumUnconfirmedTxs(_a0 context.Context) (*coretypes.ResultUnconfirmedTxs, error) {
 	ret := _m.Called(_a0)
@@ -764,6 +927,29 @@ func (_m *Client) UnsubscribeAll(ctx context.Context, subscriber string) error {
 	return r0
 }
 
+// ValidatorUpdates provides a mock function with given fields: ctx, minHeight, maxHeight
+func (_m *Client) ValidatorUpdates(ctx context.Context, minHeight int64, maxHeight int64) (*coretypes.ResultValidatorUpdates, error) {
+	ret := _m.Called(ctx, minHeight, maxHeight)
+
+	var r0 *coretypes.ResultValidatorUpdates
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) *coretypes.ResultValidatorUpdates); ok {
+		r0 = rf(ctx, minHeight, maxHeight)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultValidatorUpdates)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, minHeight, maxHeight)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Validators provides a mock function with given fields: ctx, height, page, perPage
 func (_m *Client) Validators(ctx context.Context, height *int64, page *int, perPage *int) (*coretypes.ResultValidators, error) {
 	ret := _m.Called(ctx, height, page, perPage)
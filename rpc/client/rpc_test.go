@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"math"
 	"net/http"
@@ -126,6 +127,22 @@ func TestClientOperations(t *testing.T) {
 
 		assert.Equal(t, resp.Header.Get("Access-Control-Allow-Origin"), origin)
 	})
+	t.Run("HealthProbes", func(t *testing.T) {
+		remote := strings.ReplaceAll(conf.RPC.ListenAddress, "tcp", "http")
+		for _, path := range []string{"/health/live", "/health/ready"} {
+			req, err := http.NewRequestWithContext(ctx, "GET", remote+path, nil)
+			require.NoError(t, err)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			var res coretypes.ResultHealthProbe
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+			assert.Equal(t, http.StatusOK, resp.StatusCode, "%s: %+v", path, res)
+			assert.True(t, res.Healthy)
+			assert.NotEmpty(t, res.Checks)
+		}
+	})
 	t.Run("Batching", func(t *testing.T) {
 		t.Run("JSONRPCCalls", func(t *testing.T) {
 			c := getHTTPClient(t, conf)
@@ -172,6 +189,48 @@ func TestClientOperations(t *testing.T) {
 			wg.Wait()
 		})
 	})
+	t.Run("BroadcastTxCommitProofStatus", func(t *testing.T) {
+		// the client stops once its context is canceled
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		c, err := rpcclient.NewWS(conf.RPC.ListenAddress, "/websocket")
+		require.NoError(t, err)
+		require.NoError(t, c.Start(ctx))
+		t.Cleanup(func() { _ = c.Stop() })
+
+		_, _, tx := MakeTxKV()
+		require.NoError(t, c.Call(ctx, "broadcast_tx_commit_proof", map[string]interface{}{"tx": tx}))
+
+		// the status transitions are streamed before the result
+		for _, status := range []coretypes.TxStatus{
+			coretypes.TxStatusAccepted,
+			coretypes.TxStatusIncluded,
+			coretypes.TxStatusFinalized,
+		} {
+			select {
+			case resp := <-c.ResponsesCh:
+				require.Nil(t, resp.Error)
+				res := new(coretypes.ResultBroadcastTxStatus)
+				require.NoError(t, tmjson.Unmarshal(resp.Result, res))
+				assert.Equal(t, status, res.Status)
+				assert.EqualValues(t, types.Tx(tx).Hash(), res.Hash)
+			case <-time.After(10 * time.Second):
+				require.Fail(t, "timed out waiting for tx status", status)
+			}
+		}
+
+		select {
+		case resp := <-c.ResponsesCh:
+			require.Nil(t, resp.Error)
+			res := new(coretypes.ResultBroadcastTxCommitProof)
+			require.NoError(t, tmjson.Unmarshal(resp.Result, res))
+			assert.True(t, res.DeliverTx.IsOK())
+			assert.EqualValues(t, tx, res.Proof.Data)
+		case <-time.After(10 * time.Second):
+			require.Fail(t, "timed out waiting for the result")
+		}
+	})
 	t.Run("HTTPReturnsErrorIfClientIsNotRunning", func(t *testing.T) {
 		c := getHTTPClientWithTimeout(t, conf, 100*time.Millisecond)
 
@@ -229,6 +288,15 @@ func TestClientMethodCalls(t *testing.T) {
 				assert.True(t, netinfo.Listening)
 				assert.Equal(t, 0, len(netinfo.Peers))
 			})
+			t.Run("NetTopology", func(t *testing.T) {
+				nc, ok := c.(client.NetworkClient)
+				require.True(t, ok, "%d", i)
+				topology, err := nc.NetTopology(ctx)
+				require.NoError(t, err, "%d", i)
+				require.Len(t, topology.Nodes, 1)
+				assert.Equal(t, topology.NodeID, topology.Nodes[0].ID)
+				assert.Empty(t, topology.Edges)
+			})
 			t.Run("DumpConsensusState", func(t *testing.T) {
 				// FIXME: fix server so it doesn't panic on invalid input
 				nc, ok := c.(client.NetworkClient)
@@ -238,6 +306,15 @@ func TestClientMethodCalls(t *testing.T) {
 				assert.NotEmpty(t, cons.RoundState)
 				assert.Empty(t, cons.Peers)
 			})
+			t.Run("AppHashMismatches", func(t *testing.T) {
+				nc, ok := c.(client.NetworkClient)
+				require.True(t, ok, "%d", i)
+
+				// the app hash of every block matches
+				_, err := nc.AppHashMismatches(ctx, nil)
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "no app hash mismatch")
+			})
 			t.Run("ConsensusState", func(t *testing.T) {
 				// FIXME: fix server so it doesn't panic on invalid input
 				nc, ok := c.(client.NetworkClient)
@@ -246,6 +323,13 @@ func TestClientMethodCalls(t *testing.T) {
 				require.Nil(t, err, "%d: %+v", i, err)
 				assert.NotEmpty(t, cons.RoundState)
 			})
+			t.Run("ConsensusTimeStats", func(t *testing.T) {
+				nc, ok := c.(client.NetworkClient)
+				require.True(t, ok, "%d", i)
+				stats, err := nc.ConsensusTimeStats(ctx)
+				require.NoError(t, err, "%d", i)
+				assert.Len(t, stats.Timeouts, 3)
+			})
 			t.Run("Health", func(t *testing.T) {
 				nc, ok := c.(client.NetworkClient)
 				require.True(t, ok, "%d", i)
@@ -437,6 +521,19 @@ func TestClientMethodCalls(t *testing.T) {
 				assert.Nil(t, res)
 				assert.Contains(t, err.Error(), "can't be greater than max")
 			})
+			t.Run("ValidatorUpdates", func(t *testing.T) {
+				err := client.WaitForHeight(c, 3, nil)
+				require.NoError(t, err)
+
+				// the genesis validator set is never changed
+				res, err := c.ValidatorUpdates(ctx, 0, 0)
+				require.NoError(t, err)
+				assert.True(t, res.LastHeight > 0)
+				assert.Empty(t, res.ValidatorSetChanges)
+
+				_, err = c.ValidatorUpdates(ctx, 10000, 1)
+				require.Error(t, err)
+			})
 			t.Run("BroadcastTxCommit", func(t *testing.T) {
 				_, _, tx := MakeTxKV()
 				bres, err := c.BroadcastTxCommit(ctx, tx)
@@ -446,6 +543,24 @@ func TestClientMethodCalls(t *testing.T) {
 
 				require.Equal(t, 0, pool.Size())
 			})
+			t.Run("BroadcastTxCommitProof", func(t *testing.T) {
+				_, _, tx := MakeTxKV()
+				bres, err := c.BroadcastTxCommitProof(ctx, tx, 0)
+				require.NoError(t, err)
+				require.True(t, bres.CheckTx.IsOK())
+				require.True(t, bres.DeliverTx.IsOK())
+
+				// the proof is of the inclusion of the tx in the block
+				block, err := c.Block(ctx, &bres.Height)
+				require.NoError(t, err)
+				assert.EqualValues(t, tx, bres.Proof.Data)
+				assert.NoError(t, bres.Proof.Validate(block.Block.DataHash))
+
+				// the commit of the block is canonical
+				commit, err := c.Commit(ctx, &bres.Height)
+				require.NoError(t, err)
+				assert.True(t, commit.CanonicalCommit)
+			})
 			t.Run("BroadcastTxSync", func(t *testing.T) {
 				_, _, tx := MakeTxKV()
 				initMempoolSize := pool.Size()
@@ -536,6 +651,18 @@ func TestClientMethodCalls(t *testing.T) {
 					err = client.WaitForHeight(c, status.SyncInfo.LatestBlockHeight+2, nil)
 					require.NoError(t, err)
 
+					// the evidence is committed
+					evList, err := c.Evidence(ctx, true, "duplicate_vote", 0, 0, pv.Key.Address, nil, nil)
+					require.NoError(t, err)
+					committed := false
+					for _, ev := range evList.Evidence {
+						if bytes.Equal(correct.Hash(), ev.Hash) {
+							committed = true
+							assert.Positive(t, ev.CommitHeight)
+						}
+					}
+					assert.True(t, committed, "evidence %X was not committed", correct.Hash())
+
 					ed25519pub := pv.Key.PubKey.(ed25519.PubKey)
 					rawpub := ed25519pub.Bytes()
 					result2, err := c.ABCIQuery(ctx, "/val", rawpub)
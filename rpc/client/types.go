@@ -5,6 +5,9 @@ package client
 type ABCIQueryOptions struct {
 	Height int64
 	Prove  bool
+	// Verify has the node verify the proof of the response, see
+	// coretypes.ResultABCIQuery.Verification. It implies Prove.
+	Verify bool
 }
 
 // DefaultABCIQueryOptions are latest height (0) and prove false.
@@ -71,6 +71,11 @@ type ResultBlockResults struct {
 	EndBlockEvents        []abci.Event              `json:"end_block_events"`
 	ValidatorUpdates      []abci.ValidatorUpdate    `json:"validator_updates"`
 	ConsensusParamUpdates *tmproto.ConsensusParams  `json:"consensus_param_updates"`
+
+	// Only set if the events were requested to be proven. EventsHash is
+	// committed to by the LastEventsHash of the next header.
+	EventsHash  bytes.HexBytes     `json:"events_hash,omitempty"`
+	EventProofs []types.EventProof `json:"event_proofs,omitempty"`
 }
 
 // NewResultCommit is a helper to initialize the ResultCommit with
@@ -100,6 +105,8 @@ type SyncInfo struct {
 	EarliestBlockTime   time.Time      `json:"earliest_block_time"`
 
 	MaxPeerBlockHeight int64 `json:"max_peer_block_height"`
+	// Number of blocks the node is behind the max peer block height.
+	HeightLag int64 `json:"height_lag"`
 
 	CatchingUp bool `json:"catching_up"`
 
@@ -122,11 +129,20 @@ type ValidatorInfo struct {
 	VotingPower int64          `json:"voting_power"`
 }
 
+// Info about the node's pending halt, set if a halt height or time is
+// configured
+type HaltInfo struct {
+	HaltHeight int64     `json:"halt_height"`
+	HaltTime   time.Time `json:"halt_time"`
+	Halted     bool      `json:"halted"`
+}
+
 // Node Status
 type ResultStatus struct {
 	NodeInfo      types.NodeInfo `json:"node_info"`
 	SyncInfo      SyncInfo       `json:"sync_info"`
 	ValidatorInfo ValidatorInfo  `json:"validator_info"`
+	HaltInfo      *HaltInfo      `json:"halt_info,omitempty"`
 }
 
 // Is TxIndexing enabled
@@ -143,6 +159,23 @@ type ResultNetInfo struct {
 	Listeners []string `json:"listeners"`
 	NPeers    int      `json:"n_peers"`
 	Peers     []Peer   `json:"peers"`
+
+	// Versions run by the connected peers.
+	Versions PeerVersions `json:"versions"`
+}
+
+// Versions run by the connected peers, as presented in their handshakes,
+// each ordered by decreasing number of peers.
+type PeerVersions struct {
+	Software []VersionCount `json:"software"`
+	Block    []VersionCount `json:"block"`
+	App      []VersionCount `json:"app"`
+}
+
+// Number of connected peers running a version
+type VersionCount struct {
+	Version string `json:"version"`
+	NPeers  int    `json:"n_peers"`
 }
 
 // Log from dialing seeds
@@ -159,6 +192,76 @@ type ResultDialPeers struct {
 type Peer struct {
 	ID  types.NodeID `json:"node_id"`
 	URL string       `json:"url"`
+
+	// The node info presented in the peer's last handshake, if any.
+	NodeInfo *types.NodeInfo `json:"node_info,omitempty"`
+}
+
+// Peer addresses known to the node
+type ResultAddressBook struct {
+	Addresses []AddressBookEntry `json:"addresses"`
+}
+
+// A peer address known to the node, and its dial history
+type AddressBookEntry struct {
+	Address         string    `json:"address"`
+	Source          string    `json:"source"`
+	LastConnected   time.Time `json:"last_connected"`
+	LastDialSuccess time.Time `json:"last_dial_success"`
+	LastDialFailure time.Time `json:"last_dial_failure"`
+	DialFailures    uint32    `json:"dial_failures"`
+}
+
+// Number of addresses added by an address book import
+type ResultImportAddressBook struct {
+	Added int `json:"added"`
+}
+
+// The network graph as seen by the node: the node, its connected peers and the
+// nodes they reported over PEX, with an edge from each node to the nodes it
+// is connected to or reported.
+// UNSTABLE
+type ResultNetTopology struct {
+	NodeID types.NodeID   `json:"node_id"`
+	Nodes  []TopologyNode `json:"nodes"`
+	Edges  []TopologyEdge `json:"edges"`
+}
+
+// A node of the network graph
+type TopologyNode struct {
+	ID        types.NodeID `json:"id"`
+	Moniker   string       `json:"moniker,omitempty"`
+	Connected bool         `json:"connected"`
+	// The channels of the node or a connected peer, from its node info.
+	Channels bytes.HexBytes `json:"channels,omitempty"`
+}
+
+// An edge of the network graph, from a node to a peer it's connected to or
+// reported over PEX
+type TopologyEdge struct {
+	From     types.NodeID `json:"from"`
+	To       types.NodeID `json:"to"`
+	Reported bool         `json:"reported"`
+	// The round-trip time of the connection, if it's measured.
+	Latency time.Duration `json:"latency,omitempty"`
+}
+
+// The state of a runtime profile served by the pprof server
+type ProfileStatus struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	Rate    int    `json:"rate"`
+}
+
+// The state of the runtime profiles which can be enabled and disabled
+type ResultProfiles struct {
+	Profiles []ProfileStatus `json:"profiles"`
+}
+
+// The dump of the ring of the flight recorder
+type ResultDumpFlightRecorder struct {
+	// Path of the zip file of the dump on the node.
+	Path string `json:"path"`
 }
 
 // Validators for a height.
@@ -169,6 +272,23 @@ type ResultValidators struct {
 	Count int `json:"count"`
 	// Total number of validators
 	Total int `json:"total"`
+	// Validators at the heights following BlockHeight, if a window of heights
+	// was requested
+	Window []*ResultValidators `json:"window,omitempty"`
+	// Cursor to request the validators following these ones, if any
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// Changes of the validator set
+type ResultValidatorUpdates struct {
+	LastHeight          int64                      `json:"last_height"`
+	ValidatorSetChanges []types.ValidatorSetChange `json:"validator_set_changes"`
+}
+
+// Forensic dumps of the app hash mismatches at a height
+type ResultAppHashMismatches struct {
+	Height int64             `json:"height"`
+	Dumps  []json.RawMessage `json:"dumps"`
 }
 
 // ConsensusParams for given height
@@ -182,6 +302,10 @@ type ResultConsensusParams struct {
 type ResultDumpConsensusState struct {
 	RoundState json.RawMessage `json:"round_state"`
 	Peers      []PeerStateInfo `json:"peers"`
+	// Internals of the state machine: the progress of the proposal block
+	// parts, the timeouts of the current round, the last scheduled timeout
+	// and the most recent step transitions.
+	StateMachine json.RawMessage `json:"state_machine"`
 }
 
 // UNSTABLE
@@ -195,6 +319,32 @@ type ResultConsensusState struct {
 	RoundState json.RawMessage `json:"round_state"`
 }
 
+// Timing of the latest heights committed by the node
+// UNSTABLE
+type ResultConsensusTimeStats struct {
+	FirstHeight  int64         `json:"first_height"`
+	LastHeight   int64         `json:"last_height"`
+	AvgBlockTime time.Duration `json:"avg_block_time"`
+	// number of heights committed in each number of rounds
+	Rounds   []HeightCount  `json:"rounds"`
+	Timeouts []TimeoutUsage `json:"timeouts"`
+}
+
+// HeightCount is the number of heights at which something, such as a round or
+// a timeout, happened Count times.
+type HeightCount struct {
+	Count   int `json:"count"`
+	Heights int `json:"heights"`
+}
+
+// TimeoutUsage is the number of times the timeout of a consensus step fired,
+// and its histogram by height.
+type TimeoutUsage struct {
+	Step      string        `json:"step"`
+	Fired     int           `json:"fired"`
+	Histogram []HeightCount `json:"histogram"`
+}
+
 // CheckTx result
 type ResultBroadcastTx struct {
 	Code         uint32         `json:"code"`
@@ -214,6 +364,39 @@ type ResultBroadcastTxCommit struct {
 	Height    int64                  `json:"height"`
 }
 
+// TxStatus is a stage a transaction broadcast with broadcast_tx_commit_proof
+// goes through.
+type TxStatus string
+
+const (
+	// TxStatusAccepted is the status of a transaction accepted by the mempool.
+	TxStatusAccepted TxStatus = "accepted"
+	// TxStatusIncluded is the status of a transaction included in a committed
+	// block.
+	TxStatusIncluded TxStatus = "included"
+	// TxStatusFinalized is the status of a transaction included in a block
+	// whose commit is canonical, i.e. part of the next block.
+	TxStatusFinalized TxStatus = "finalized"
+)
+
+// ResultBroadcastTxStatus is a status transition of a transaction broadcast
+// with broadcast_tx_commit_proof, streamed over WebSocket.
+type ResultBroadcastTxStatus struct {
+	Hash   bytes.HexBytes `json:"hash"`
+	Status TxStatus       `json:"status"`
+	Height int64          `json:"height,omitempty"`
+}
+
+// CheckTx and DeliverTx results, and a proof of the inclusion of the tx in
+// the block
+type ResultBroadcastTxCommitProof struct {
+	CheckTx   abci.ResponseCheckTx   `json:"check_tx"`
+	DeliverTx abci.ResponseDeliverTx `json:"deliver_tx"`
+	Hash      bytes.HexBytes         `json:"hash"`
+	Height    int64                  `json:"height"`
+	Proof     types.TxProof          `json:"proof"`
+}
+
 // ResultCheckTx wraps abci.ResponseCheckTx.
 type ResultCheckTx struct {
 	abci.ResponseCheckTx
@@ -249,6 +432,32 @@ type ResultUnconfirmedTxs struct {
 	Txs        []types.Tx `json:"txs"`
 }
 
+// List of transactions removed from the mempool
+type ResultUnconfirmedTxsRemove struct {
+	Hashes []bytes.HexBytes `json:"hashes"`
+}
+
+// A transaction of the archive of the node, with its last status
+type ResultArchivedTx struct {
+	Hash         bytes.HexBytes `json:"hash"`
+	Tx           types.Tx       `json:"tx"`
+	Sender       string         `json:"sender,omitempty"`
+	Local        bool           `json:"local,omitempty"`
+	Time         time.Time      `json:"time"`
+	Height       int64          `json:"height"`
+	Status       string         `json:"status"`
+	StatusTime   time.Time      `json:"status_time"`
+	Code         uint32         `json:"code,omitempty"`
+	Error        string         `json:"error,omitempty"`
+	CommitHeight int64          `json:"commit_height,omitempty"`
+}
+
+// List of archived transactions of a sender
+type ResultArchivedTxs struct {
+	Txs        []*ResultArchivedTx `json:"txs"`
+	TotalCount int                 `json:"total_count"`
+}
+
 // Info abci msg
 type ResultABCIInfo struct {
 	Response abci.ResponseInfo `json:"response"`
@@ -257,6 +466,19 @@ type ResultABCIInfo struct {
 // Query abci msg
 type ResultABCIQuery struct {
 	Response abci.ResponseQuery `json:"response"`
+
+	// Verification is the outcome of the verification of the proof of the
+	// response by the node, if requested.
+	Verification *ProofVerification `json:"verification,omitempty"`
+}
+
+// ProofVerification is the outcome of the verification of the proof of an
+// ABCI query response against the app hash of its height.
+type ProofVerification struct {
+	Verified bool           `json:"verified"`
+	AppHash  bytes.HexBytes `json:"app_hash,omitempty"`
+	// Error is the reason the proof couldn't be verified.
+	Error string `json:"error,omitempty"`
 }
 
 // Result of broadcasting evidence
@@ -264,6 +486,21 @@ type ResultBroadcastEvidence struct {
 	Hash []byte `json:"hash"`
 }
 
+// ResultEvidence is pending or committed evidence.
+type ResultEvidence struct {
+	Evidence types.Evidence `json:"evidence"`
+	Hash     bytes.HexBytes `json:"hash"`
+	// CommitHeight is the height of the block which committed the evidence,
+	// or 0 if it is pending.
+	CommitHeight int64 `json:"commit_height"`
+}
+
+// Result of listing evidence
+type ResultEvidenceList struct {
+	Evidence   []*ResultEvidence `json:"evidence"`
+	TotalCount int               `json:"total_count"`
+}
+
 // empty results
 type (
 	ResultUnsafeFlushMempool struct{}
@@ -273,6 +510,20 @@ type (
 	ResultHealth             struct{}
 )
 
+// Result of the /health/live or /health/ready probe, healthy if all its
+// checks are
+type ResultHealthProbe struct {
+	Healthy bool          `json:"healthy"`
+	Checks  []HealthCheck `json:"checks"`
+}
+
+// A check of a health probe, with the reason it failed, if it did
+type HealthCheck struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+	Error   string `json:"error,omitempty"`
+}
+
 // Event data from a subscription
 type ResultEvent struct {
 	SubscriptionID string            `json:"subscription_id"`
@@ -526,6 +526,14 @@ func (c *WSClient) Subscribe(ctx context.Context, query string) error {
 	return c.Call(ctx, "subscribe", params)
 }
 
+// SubscribeFromHeight subscribes to a query like Subscribe, but first has the
+// server replay the events of the past transactions matching the query, from
+// the given height on.
+func (c *WSClient) SubscribeFromHeight(ctx context.Context, query string, fromHeight int64) error {
+	params := map[string]interface{}{"query": query, "from_height": fromHeight}
+	return c.Call(ctx, "subscribe", params)
+}
+
 // Unsubscribe from a query. Note the server must have a "unsubscribe" route
 // defined.
 func (c *WSClient) Unsubscribe(ctx context.Context, query string) error {
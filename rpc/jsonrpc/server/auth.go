@@ -0,0 +1,66 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+var (
+	// ErrUnauthorized is returned by an AuthorizeFunc for the requests of
+	// clients which did not authenticate, or failed to.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrForbidden is returned by an AuthorizeFunc for the calls of methods the
+	// client is not allowed to call.
+	ErrForbidden = errors.New("forbidden")
+	// ErrRateLimited is returned by an AuthorizeFunc for the calls of clients
+	// which exceeded their rate limit.
+	ErrRateLimited = errors.New("rate limit exceeded")
+)
+
+// AuthorizeFunc decides whether the client which sent r may call method. It is
+// called before each call, including each call of a batch request and each
+// call on a websocket connection, for which r is the upgrade request. The
+// error returned should wrap ErrUnauthorized, ErrForbidden or ErrRateLimited,
+// other errors are reported as ErrForbidden.
+type AuthorizeFunc func(r *http.Request, method string) error
+
+// Authorizer sets the function authorizing the calls of the JSON-RPC and URI
+// handlers.
+func Authorizer(authorize AuthorizeFunc) JSONRPCOption {
+	return func(h *jsonrpcHandler) {
+		h.authorize = authorize
+	}
+}
+
+// WSAuthorizer sets the function authorizing the calls on a websocket
+// connection. It should be used as a WebsocketManager option.
+func WSAuthorizer(authorize AuthorizeFunc) func(*wsConnection) {
+	return func(wsc *wsConnection) {
+		wsc.authorize = authorize
+	}
+}
+
+// authorizeCall calls authorize, if any, for the call of request and returns
+// the error response if the call is not allowed.
+func authorizeCall(authorize AuthorizeFunc, r *http.Request, request rpctypes.RPCRequest) *rpctypes.RPCResponse {
+	if authorize == nil {
+		return nil
+	}
+	err := authorize(r, request.Method)
+	if err == nil {
+		return nil
+	}
+
+	var res rpctypes.RPCResponse
+	switch {
+	case errors.Is(err, ErrUnauthorized):
+		res = rpctypes.RPCUnauthorizedError(request.ID, err)
+	case errors.Is(err, ErrRateLimited):
+		res = rpctypes.RPCRateLimitedError(request.ID, err)
+	default:
+		res = rpctypes.RPCForbiddenError(request.ID, err)
+	}
+	return &res
+}
@@ -8,7 +8,9 @@ import (
 	"io"
 	"net/http"
 	"reflect"
+	"runtime/debug"
 	"sort"
+	"sync"
 
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	"github.com/tendermint/tendermint/libs/log"
@@ -18,8 +20,46 @@ import (
 
 // HTTP + JSON handler
 
+// DefaultMaxBatchConcurrency is the default maximum number of calls of a
+// batch request executed concurrently.
+const DefaultMaxBatchConcurrency = 4
+
+// JSONRPCOption sets an optional parameter on the JSON-RPC handler.
+type JSONRPCOption func(*jsonrpcHandler)
+
+// MaxBatchConcurrency sets the maximum number of calls of a batch request
+// executed concurrently. Values below 1 execute the calls one at a time.
+func MaxBatchConcurrency(n int) JSONRPCOption {
+	return func(h *jsonrpcHandler) {
+		if n < 1 {
+			n = 1
+		}
+		h.maxBatchConcurrency = n
+	}
+}
+
+type jsonrpcHandler struct {
+	funcMap             map[string]*RPCFunc
+	logger              log.Logger
+	maxBatchConcurrency int
+	authorize           AuthorizeFunc
+}
+
+func newJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger, opts ...JSONRPCOption) *jsonrpcHandler {
+	h := &jsonrpcHandler{
+		funcMap:             funcMap,
+		logger:              logger,
+		maxBatchConcurrency: DefaultMaxBatchConcurrency,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
 // jsonrpc calls grab the given method's function info and runs reflect.Call
-func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.HandlerFunc {
+func makeJSONRPCHandler(h *jsonrpcHandler) http.HandlerFunc {
+	funcMap, logger := h.funcMap, h.logger
 	return func(w http.ResponseWriter, r *http.Request) {
 		b, err := io.ReadAll(r.Body)
 		if err != nil {
@@ -39,114 +79,177 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.Han
 			return
 		}
 
-		// first try to unmarshal the incoming request as an array of RPC requests
-		var (
-			requests  []rpctypes.RPCRequest
-			responses []rpctypes.RPCResponse
-		)
-		if err := json.Unmarshal(b, &requests); err != nil {
-			// next, try to unmarshal as a single request
-			var request rpctypes.RPCRequest
-			if err := json.Unmarshal(b, &request); err != nil {
+		// A batch request is an array of requests. Its responses are written
+		// as an array in the order of the requests, even if there is only one.
+		if b = bytes.TrimSpace(b); len(b) > 0 && b[0] == '[' {
+			var batch []json.RawMessage
+			if err := json.Unmarshal(b, &batch); err != nil {
 				res := rpctypes.RPCParseError(fmt.Errorf("error unmarshaling request: %w", err))
 				if wErr := WriteRPCResponseHTTPError(w, res); wErr != nil {
 					logger.Error("failed to write response", "res", res, "err", wErr)
 				}
 				return
 			}
-			requests = []rpctypes.RPCRequest{request}
-		}
-
-		// Set the default response cache to true unless
-		// 1. Any RPC request rrror.
-		// 2. Any RPC request doesn't allow to be cached.
-		// 3. Any RPC request has the height argument and the value is 0 (the default).
-		var c = true
-		for _, request := range requests {
-			request := request
-
-			// A Notification is a Request object without an "id" member.
-			// The Server MUST NOT reply to a Notification, including those that are within a batch request.
-			if request.ID == nil {
-				logger.Debug(
-					"HTTPJSONRPC received a notification, skipping... (please send a non-empty ID if you want to call a method)",
-					"req", request,
-				)
-				continue
-			}
-			if len(r.URL.Path) > 1 {
-				responses = append(
-					responses,
-					rpctypes.RPCInvalidRequestError(request.ID, fmt.Errorf("path %s is invalid", r.URL.Path)),
-				)
-				c = false
-				continue
-			}
-			rpcFunc, ok := funcMap[request.Method]
-			if !ok || rpcFunc.ws {
-				responses = append(responses, rpctypes.RPCMethodNotFoundError(request.ID))
-				c = false
-				continue
-			}
-			ctx := &rpctypes.Context{JSONReq: &request, HTTPReq: r}
-			args := []reflect.Value{reflect.ValueOf(ctx)}
-			if len(request.Params) > 0 {
-				fnArgs, err := jsonParamsToArgs(rpcFunc, request.Params)
-				if err != nil {
-					responses = append(
-						responses,
-						rpctypes.RPCInvalidParamsError(request.ID, fmt.Errorf("error converting json params to arguments: %w", err)),
-					)
-					c = false
-					continue
+			if len(batch) == 0 {
+				res := rpctypes.RPCInvalidRequestError(nil, errors.New("empty batch request"))
+				if wErr := WriteRPCResponseHTTPError(w, res); wErr != nil {
+					logger.Error("failed to write response", "res", res, "err", wErr)
 				}
-				args = append(args, fnArgs...)
-
-			}
-
-			if hasDefaultHeight(request, args) {
-				c = false
+				return
 			}
 
-			returns := rpcFunc.f.Call(args)
-			logger.Debug("HTTPJSONRPC", "method", request.Method, "args", args, "returns", returns)
-			result, err := unreflectResult(returns)
-			switch e := err.(type) {
-			// if no error then return a success response
-			case nil:
-				responses = append(responses, rpctypes.NewRPCSuccessResponse(request.ID, result))
-
-			// if this already of type RPC error then forward that error
-			case *rpctypes.RPCError:
-				responses = append(responses, rpctypes.NewRPCErrorResponse(request.ID, e.Code, e.Message, e.Data))
-				c = false
-			default: // we need to unwrap the error and parse it accordingly
-				switch errors.Unwrap(err) {
-				// check if the error was due to an invald request
-				case coretypes.ErrZeroOrNegativeHeight, coretypes.ErrZeroOrNegativePerPage,
-					coretypes.ErrPageOutOfRange, coretypes.ErrInvalidRequest:
-					responses = append(responses, rpctypes.RPCInvalidRequestError(request.ID, err))
-					c = false
-				// lastly default all remaining errors as internal errors
-				default: // includes ctypes.ErrHeightNotAvailable and ctypes.ErrHeightExceedsChainHead
-					responses = append(responses, rpctypes.RPCInternalError(request.ID, err))
-					c = false
+			responses, c := h.handleBatch(r, batch)
+			if len(responses) > 0 {
+				if wErr := writeRPCBatchResponseHTTP(w, c, responses); wErr != nil {
+					logger.Error("failed to write responses", "err", wErr)
 				}
 			}
+			return
+		}
 
-			if c && !rpcFunc.cache {
-				c = false
+		var request rpctypes.RPCRequest
+		if err := json.Unmarshal(b, &request); err != nil {
+			res := rpctypes.RPCParseError(fmt.Errorf("error unmarshaling request: %w", err))
+			if wErr := WriteRPCResponseHTTPError(w, res); wErr != nil {
+				logger.Error("failed to write response", "res", res, "err", wErr)
 			}
+			return
 		}
 
-		if len(responses) > 0 {
-			if wErr := WriteRPCResponseHTTP(w, c, responses...); wErr != nil {
+		if res, c := h.handleRequest(r, request); res != nil {
+			if wErr := WriteRPCResponseHTTP(w, c, *res); wErr != nil {
 				logger.Error("failed to write responses", "err", wErr)
 			}
 		}
 	}
 }
 
+// handleBatch executes the calls of a batch request, at most
+// maxBatchConcurrency at a time, and returns their responses in the order of
+// the requests. The responses can be cached if all of them can.
+func (h *jsonrpcHandler) handleBatch(
+	r *http.Request,
+	batch []json.RawMessage,
+) ([]rpctypes.RPCResponse, bool) {
+	var (
+		results = make([]*rpctypes.RPCResponse, len(batch))
+		cache   = make([]bool, len(batch))
+		sem     = make(chan struct{}, h.maxBatchConcurrency)
+		wg      sync.WaitGroup
+	)
+	for i, raw := range batch {
+		var request rpctypes.RPCRequest
+		if err := json.Unmarshal(raw, &request); err != nil {
+			res := rpctypes.RPCInvalidRequestError(nil, fmt.Errorf("error unmarshaling request: %w", err))
+			results[i] = &res
+			continue
+		}
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int, request rpctypes.RPCRequest) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			// The handler only recovers from panics on the request goroutine.
+			defer func() {
+				if e := recover(); e != nil {
+					h.logger.Error("panic in RPC batch call", "method", request.Method, "err", e,
+						"stack", string(debug.Stack()))
+					res := rpctypes.RPCInternalError(request.ID, fmt.Errorf("panic: %v", e))
+					results[i], cache[i] = &res, false
+				}
+			}()
+
+			results[i], cache[i] = h.handleRequest(r, request)
+		}(i, request)
+	}
+	wg.Wait()
+
+	var (
+		responses = make([]rpctypes.RPCResponse, 0, len(batch))
+		c         = true
+	)
+	for i, res := range results {
+		if res == nil {
+			continue
+		}
+		responses = append(responses, *res)
+		c = c && cache[i]
+	}
+	return responses, c
+}
+
+// handleRequest executes the call of a request and returns its response, or
+// nil for a notification, and whether the response can be cached, i.e. unless
+// the call fails, its method doesn't allow it or it uses the default height.
+func (h *jsonrpcHandler) handleRequest(
+	r *http.Request,
+	request rpctypes.RPCRequest,
+) (*rpctypes.RPCResponse, bool) {
+	var res rpctypes.RPCResponse
+
+	// A Notification is a Request object without an "id" member.
+	// The Server MUST NOT reply to a Notification, including those that are within a batch request.
+	if request.ID == nil {
+		h.logger.Debug(
+			"HTTPJSONRPC received a notification, skipping... (please send a non-empty ID if you want to call a method)",
+			"req", request,
+		)
+		return nil, true
+	}
+	if len(r.URL.Path) > 1 {
+		res = rpctypes.RPCInvalidRequestError(request.ID, fmt.Errorf("path %s is invalid", r.URL.Path))
+		return &res, false
+	}
+	rpcFunc, ok := h.funcMap[request.Method]
+	if !ok || rpcFunc.ws {
+		res = rpctypes.RPCMethodNotFoundError(request.ID)
+		return &res, false
+	}
+	if res := authorizeCall(h.authorize, r, request); res != nil {
+		return res, false
+	}
+	ctx := &rpctypes.Context{JSONReq: &request, HTTPReq: r}
+	args := []reflect.Value{reflect.ValueOf(ctx)}
+	if len(request.Params) > 0 {
+		fnArgs, err := jsonParamsToArgs(rpcFunc, request.Params)
+		if err != nil {
+			res = rpctypes.RPCInvalidParamsError(request.ID,
+				fmt.Errorf("error converting json params to arguments: %w", err))
+			return &res, false
+		}
+		args = append(args, fnArgs...)
+	}
+
+	c := rpcFunc.cache && !hasDefaultHeight(request, args)
+
+	returns := rpcFunc.f.Call(args)
+	h.logger.Debug("HTTPJSONRPC", "method", request.Method, "args", args, "returns", returns)
+	result, err := unreflectResult(returns)
+	switch e := err.(type) {
+	// if no error then return a success response
+	case nil:
+		res = rpctypes.NewRPCSuccessResponse(request.ID, result)
+		return &res, c
+
+	// if this already of type RPC error then forward that error
+	case *rpctypes.RPCError:
+		res = rpctypes.NewRPCErrorResponse(request.ID, e.Code, e.Message, e.Data)
+	default: // we need to unwrap the error and parse it accordingly
+		switch errors.Unwrap(err) {
+		// check if the error was due to an invald request
+		case coretypes.ErrZeroOrNegativeHeight, coretypes.ErrZeroOrNegativePerPage,
+			coretypes.ErrPageOutOfRange, coretypes.ErrInvalidRequest:
+			res = rpctypes.RPCInvalidRequestError(request.ID, err)
+		// lastly default all remaining errors as internal errors
+		default: // includes ctypes.ErrHeightNotAvailable and ctypes.ErrHeightExceedsChainHead
+			res = rpctypes.RPCInternalError(request.ID, err)
+		}
+	}
+	return &res, false
+}
+
 func handleInvalidJSONRPCPaths(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Since the pattern "/" matches all paths not matched by other registered patterns,
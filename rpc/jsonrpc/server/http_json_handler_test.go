@@ -2,11 +2,14 @@ package server
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -215,6 +218,174 @@ func TestRPCNotificationInBatch(t *testing.T) {
 	}
 }
 
+func TestRPCBatch(t *testing.T) {
+	mux := testMux()
+	tests := []struct {
+		payload   string
+		expectIDs []interface{}
+		expectErr []bool
+	}{
+		// single request in a batch is responded to with an array
+		{`[{"jsonrpc": "2.0","method":"c","id":1,"params":["a","10"]}]`,
+			[]interface{}{rpctypes.JSONRPCIntID(1)}, []bool{false}},
+		// responses are in the order of the requests, including errors
+		{`[
+			{"jsonrpc": "2.0","method":"c","id":1,"params":["a","10"]},
+			{"jsonrpc": "2.0","method":"y","id":2},
+			1,
+			{"jsonrpc": "2.0","method":"block","id":"3","params":["1"]}
+		 ]`,
+			[]interface{}{rpctypes.JSONRPCIntID(1), rpctypes.JSONRPCIntID(2), nil, rpctypes.JSONRPCStringID("3")},
+			[]bool{false, true, true, false}},
+	}
+	for i, tt := range tests {
+		req, _ := http.NewRequest("POST", "http://localhost/", strings.NewReader(tt.payload))
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		res := rec.Result()
+		assert.True(t, statusOK(res.StatusCode), "#%d: should always return 2XX", i)
+		blob, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		res.Body.Close()
+
+		var responses []rpctypes.RPCResponse
+		require.NoError(t, json.Unmarshal(blob, &responses), "#%d: expected an array\nblob: %s", i, blob)
+		require.Len(t, responses, len(tt.expectIDs), "#%d", i)
+		for j, response := range responses {
+			assert.Equal(t, tt.expectIDs[j], response.ID, "#%d: response %d", i, j)
+			assert.Equal(t, tt.expectErr[j], response.Error != nil, "#%d: response %d", i, j)
+		}
+	}
+
+	// an empty batch is an invalid request
+	req, _ := http.NewRequest("POST", "http://localhost/", strings.NewReader(`[]`))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	res := rec.Result()
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+	blob, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	res.Body.Close()
+
+	recv := new(rpctypes.RPCResponse)
+	require.NoError(t, json.Unmarshal(blob, recv))
+	require.NotNil(t, recv.Error)
+	require.Equal(t, -32600, recv.Error.Code)
+}
+
+func TestRPCBatchConcurrency(t *testing.T) {
+	const maxConcurrency = 2
+
+	var (
+		mtx           sync.Mutex
+		running, peak int
+	)
+	funcMap := map[string]*RPCFunc{
+		"slow": NewRPCFunc(func(ctx *rpctypes.Context) (string, error) {
+			mtx.Lock()
+			running++
+			if running > peak {
+				peak = running
+			}
+			mtx.Unlock()
+
+			time.Sleep(20 * time.Millisecond)
+
+			mtx.Lock()
+			running--
+			mtx.Unlock()
+			return "done", nil
+		}, "", false),
+		"panic": NewRPCFunc(func(ctx *rpctypes.Context) (string, error) { panic("boom") }, "", false),
+	}
+	mux := http.NewServeMux()
+	RegisterRPCFuncs(mux, funcMap, log.NewNopLogger(), MaxBatchConcurrency(maxConcurrency))
+
+	calls := make([]string, 8)
+	for i := range calls {
+		calls[i] = fmt.Sprintf(`{"jsonrpc": "2.0","method":"slow","id":%d}`, i)
+	}
+	calls = append(calls, `{"jsonrpc": "2.0","method":"panic","id":8}`)
+	req, _ := http.NewRequest("POST", "http://localhost/", strings.NewReader("["+strings.Join(calls, ",")+"]"))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	res := rec.Result()
+	require.True(t, statusOK(res.StatusCode))
+	blob, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	res.Body.Close()
+
+	var responses []rpctypes.RPCResponse
+	require.NoError(t, json.Unmarshal(blob, &responses))
+	require.Len(t, responses, len(calls))
+	for i, response := range responses {
+		assert.Equal(t, rpctypes.JSONRPCIntID(i), response.ID)
+	}
+	// a panicking call fails without affecting the others
+	assert.Nil(t, responses[0].Error)
+	require.NotNil(t, responses[8].Error)
+	assert.Contains(t, responses[8].Error.Data, "boom")
+
+	mtx.Lock()
+	defer mtx.Unlock()
+	assert.Equal(t, maxConcurrency, peak)
+}
+
+func TestRPCAuthorizer(t *testing.T) {
+	funcMap := map[string]*RPCFunc{
+		"public":  NewRPCFunc(func(ctx *rpctypes.Context) (string, error) { return "public", nil }, "", false),
+		"private": NewRPCFunc(func(ctx *rpctypes.Context) (string, error) { return "private", nil }, "", false),
+	}
+	authorize := func(r *http.Request, method string) error {
+		switch {
+		case r.Header.Get("Authorization") == "":
+			return ErrUnauthorized
+		case r.Header.Get("Authorization") == "Bearer limited":
+			return ErrRateLimited
+		case method == "private":
+			return ErrForbidden
+		}
+		return nil
+	}
+	mux := http.NewServeMux()
+	RegisterRPCFuncs(mux, funcMap, log.NewNopLogger(), Authorizer(authorize))
+
+	tests := []struct {
+		method   string
+		auth     string
+		wantCode int
+		wantErr  int
+	}{
+		{"public", "Bearer key", http.StatusOK, 0},
+		{"private", "Bearer key", http.StatusForbidden, -32002},
+		{"public", "", http.StatusUnauthorized, -32001},
+		{"public", "Bearer limited", http.StatusTooManyRequests, -32003},
+	}
+	for _, tt := range tests {
+		// JSON-RPC calls report errors in the response body
+		body := fmt.Sprintf(`[{"jsonrpc": "2.0","method":%q,"id":0}]`, tt.method)
+		req, _ := http.NewRequest("POST", "http://localhost/", strings.NewReader(body))
+		req.Header.Set("Authorization", tt.auth)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		var responses []rpctypes.RPCResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &responses))
+		require.Len(t, responses, 1)
+		if tt.wantErr == 0 {
+			assert.Nil(t, responses[0].Error, "%+v", tt)
+		} else if assert.NotNil(t, responses[0].Error, "%+v", tt) {
+			assert.Equal(t, tt.wantErr, responses[0].Error.Code, "%+v", tt)
+		}
+
+		// URI calls also report them in the status code
+		req, _ = http.NewRequest("GET", "http://localhost/"+tt.method, nil)
+		req.Header.Set("Authorization", tt.auth)
+		rec = httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		assert.Equal(t, tt.wantCode, rec.Code, "%+v", tt)
+	}
+}
+
 func TestUnknownRPCPath(t *testing.T) {
 	mux := testMux()
 	req, _ := http.NewRequest("GET", "http://localhost/unknownrpcpath", nil)
@@ -14,6 +14,8 @@ import (
 	"strings"
 	"time"
 
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 	"golang.org/x/net/netutil"
 
 	"github.com/tendermint/tendermint/libs/log"
@@ -48,7 +50,9 @@ func DefaultConfig() *Config {
 
 // Serve creates a http.Server and calls Serve with the given listener. It
 // wraps handler with RecoverAndLogHandler and a handler, which limits the max
-// body size to config.MaxBodyBytes.
+// body size to config.MaxBodyBytes. Besides HTTP/1.1, the server accepts
+// cleartext HTTP/2 (h2c) from clients with prior knowledge or upgrading to it,
+// e.g. sidecars on the same host connecting over a unix socket.
 //
 // NOTE: This function blocks - you may want to call it in a go-routine.
 func Serve(
@@ -59,8 +63,10 @@ func Serve(
 	config *Config,
 ) error {
 	logger.Info(fmt.Sprintf("Starting RPC HTTP server on %s", listener.Addr()))
+	h2s := &http2.Server{IdleTimeout: config.ReadTimeout}
 	s := &http.Server{
-		Handler:        RecoverAndLogHandler(maxBytesHandler{h: handler, n: config.MaxBodyBytes}, logger),
+		Handler: h2c.NewHandler(
+			RecoverAndLogHandler(maxBytesHandler{h: handler, n: config.MaxBodyBytes}, logger), h2s),
 		ReadTimeout:    config.ReadTimeout,
 		WriteTimeout:   config.WriteTimeout,
 		MaxHeaderBytes: config.MaxHeaderBytes,
@@ -135,6 +141,9 @@ func ServeTLS(
 // 404	-32601	Method not found.
 // 500	-32602	Invalid params.
 // 500	-32603	Internal error.
+// 401	-32001	Unauthorized.
+// 403	-32002	Forbidden.
+// 429	-32003	Too many requests.
 // 500	-32099..-32000	Server error.
 //
 // source: https://www.jsonrpc.org/historical/json-rpc-over-http.html
@@ -157,6 +166,12 @@ func WriteRPCResponseHTTPError(
 		httpCode = http.StatusBadRequest
 	case -32601:
 		httpCode = http.StatusNotFound
+	case -32001:
+		httpCode = http.StatusUnauthorized
+	case -32002:
+		httpCode = http.StatusForbidden
+	case -32003:
+		httpCode = http.StatusTooManyRequests
 	default:
 		httpCode = http.StatusInternalServerError
 	}
@@ -176,7 +191,16 @@ func WriteRPCResponseHTTP(w http.ResponseWriter, c bool, res ...rpctypes.RPCResp
 	} else {
 		v = res
 	}
+	return writeJSONHTTP(w, c, v)
+}
+
+// writeRPCBatchResponseHTTP is like WriteRPCResponseHTTP, but writes the
+// responses of a batch request as an array even if there is only one.
+func writeRPCBatchResponseHTTP(w http.ResponseWriter, c bool, res []rpctypes.RPCResponse) error {
+	return writeJSONHTTP(w, c, res)
+}
 
+func writeJSONHTTP(w http.ResponseWriter, c bool, v interface{}) error {
 	jsonBytes, err := json.MarshalIndent(v, "", "  ")
 	if err != nil {
 		return fmt.Errorf("json marshal: %w", err)
@@ -296,6 +320,8 @@ func (h maxBytesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 // Listen starts a new net.Listener on the given address.
 // It returns an error if the address is invalid or the call to Listen() fails.
+// The socket file of a unix address is removed first if it is stale, i.e. left
+// behind by a process which is no longer listening on it.
 func Listen(addr string, maxOpenConnections int) (listener net.Listener, err error) {
 	parts := strings.SplitN(addr, "://", 2)
 	if len(parts) != 2 {
@@ -305,6 +331,11 @@ func Listen(addr string, maxOpenConnections int) (listener net.Listener, err err
 		)
 	}
 	proto, addr := parts[0], parts[1]
+	if proto == "unix" {
+		if err := removeStaleSocket(addr); err != nil {
+			return nil, fmt.Errorf("failed to remove stale socket %v: %w", addr, err)
+		}
+	}
 	listener, err = net.Listen(proto, addr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to listen on %v: %v", addr, err)
@@ -315,3 +346,23 @@ func Listen(addr string, maxOpenConnections int) (listener net.Listener, err err
 
 	return listener, nil
 }
+
+// removeStaleSocket removes the unix socket file at path if no process is
+// listening on it. Files other than sockets are left for net.Listen to fail on.
+func removeStaleSocket(path string) error {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return nil
+	}
+	conn, err := net.DialTimeout("unix", path, time.Second)
+	if err == nil {
+		// in use, net.Listen reports it
+		return conn.Close()
+	}
+	return os.Remove(path)
+}
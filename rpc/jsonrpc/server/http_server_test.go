@@ -9,6 +9,7 @@ import (
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
 
 	"github.com/tendermint/tendermint/libs/log"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
@@ -112,6 +114,60 @@ func TestServeTLS(t *testing.T) {
 	assert.Equal(t, []byte("some body"), body)
 }
 
+func TestServeUnixSocket(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// a socket file left behind by a process which is no longer listening
+	path := filepath.Join(t.TempDir(), "rpc.sock")
+	stale, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+	require.NoError(t, err)
+	stale.SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+
+	ln, err := Listen("unix://"+path, 0)
+	require.NoError(t, err)
+	defer ln.Close()
+
+	// a socket in use is not removed
+	_, err = Listen("unix://"+path, 0)
+	require.Error(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "HTTP/%d", r.ProtoMajor)
+	})
+	config := DefaultConfig()
+	config.ReadTimeout = 500 * time.Millisecond
+	go Serve(ctx, ln, mux, log.TestingLogger(), config) //nolint:errcheck // ignore for tests
+
+	dial := func(network, addr string) (net.Conn, error) {
+		return net.Dial("unix", path)
+	}
+	h1 := &http.Client{Transport: &http.Transport{Dial: dial}}
+	h2 := &http.Client{Transport: &http2.Transport{
+		AllowHTTP: true,
+		DialTLS: func(network, addr string, cfg *tls.Config) (net.Conn, error) {
+			return dial(network, addr)
+		},
+	}}
+
+	get := func(c *http.Client) string {
+		res, err := c.Get("http://localhost/")
+		require.NoError(t, err)
+		defer res.Body.Close()
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+	assert.Equal(t, "HTTP/1", get(h1))
+	assert.Equal(t, "HTTP/2", get(h2))
+
+	// HTTP/2 clients are still served after the read timeout of the first request
+	time.Sleep(2 * config.ReadTimeout)
+	assert.Equal(t, "HTTP/2", get(h2))
+}
+
 func TestWriteRPCResponseHTTP(t *testing.T) {
 	id := rpctypes.JSONRPCIntID(-1)
 
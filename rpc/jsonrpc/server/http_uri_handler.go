@@ -21,7 +21,12 @@ import (
 var reInt = regexp.MustCompile(`^-?[0-9]+$`)
 
 // convert from a function name to the http handler
-func makeHTTPHandler(rpcFunc *RPCFunc, logger log.Logger) func(http.ResponseWriter, *http.Request) {
+func makeHTTPHandler(
+	funcName string,
+	rpcFunc *RPCFunc,
+	authorize AuthorizeFunc,
+	logger log.Logger,
+) func(http.ResponseWriter, *http.Request) {
 	// Always return -1 as there's no ID here.
 	dummyID := rpctypes.JSONRPCIntID(-1) // URIClientRequestID
 
@@ -39,6 +44,13 @@ func makeHTTPHandler(rpcFunc *RPCFunc, logger log.Logger) func(http.ResponseWrit
 	return func(w http.ResponseWriter, r *http.Request) {
 		logger.Debug("HTTP HANDLER", "req", dumpHTTPRequest(r))
 
+		if res := authorizeCall(authorize, r, rpctypes.RPCRequest{ID: dummyID, Method: funcName}); res != nil {
+			if wErr := WriteRPCResponseHTTPError(w, *res); wErr != nil {
+				logger.Error("failed to write response", "res", res, "err", wErr)
+			}
+			return
+		}
+
 		ctx := &rpctypes.Context{HTTPReq: r}
 		args := []reflect.Value{reflect.ValueOf(ctx)}
 
@@ -11,15 +11,22 @@ import (
 // RegisterRPCFuncs adds a route for each function in the funcMap, as well as
 // general jsonrpc and websocket handlers for all functions. "result" is the
 // interface on which the result objects are registered, and is popualted with
-// every RPCResponse
-func RegisterRPCFuncs(mux *http.ServeMux, funcMap map[string]*RPCFunc, logger log.Logger) {
+// every RPCResponse. The options configure the JSON-RPC handler.
+func RegisterRPCFuncs(
+	mux *http.ServeMux,
+	funcMap map[string]*RPCFunc,
+	logger log.Logger,
+	opts ...JSONRPCOption,
+) {
+	h := newJSONRPCHandler(funcMap, logger, opts...)
+
 	// HTTP endpoints
 	for funcName, rpcFunc := range funcMap {
-		mux.HandleFunc("/"+funcName, makeHTTPHandler(rpcFunc, logger))
+		mux.HandleFunc("/"+funcName, makeHTTPHandler(funcName, rpcFunc, h.authorize, logger))
 	}
 
 	// JSONRPC endpoints
-	mux.HandleFunc("/", handleInvalidJSONRPCPaths(makeJSONRPCHandler(funcMap, logger)))
+	mux.HandleFunc("/", handleInvalidJSONRPCPaths(makeJSONRPCHandler(h)))
 }
 
 // Function introspection
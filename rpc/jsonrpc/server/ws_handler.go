@@ -88,6 +88,7 @@ func (wm *WebsocketManager) WebsocketHandler(w http.ResponseWriter, r *http.Requ
 	// register connection
 	logger := wm.logger.With("remote", wsConn.RemoteAddr())
 	conn := newWSConnection(wsConn, wm.funcMap, logger, wm.wsConnOptions...)
+	conn.httpReq = r
 	wm.logger.Info("New websocket connection", "remote", conn.remoteAddr)
 
 	// starting the conn is blocking
@@ -139,6 +140,10 @@ type wsConnection struct {
 	// callback which is called upon disconnect
 	onDisconnect func(remoteAddr string)
 
+	// authorizes each call, given the upgrade request httpReq
+	authorize AuthorizeFunc
+	httpReq   *http.Request
+
 	ctx    context.Context
 	cancel context.CancelFunc
 }
@@ -369,6 +374,13 @@ func (wsc *wsConnection) readRoutine(ctx context.Context) {
 				continue
 			}
 
+			if res := authorizeCall(wsc.authorize, wsc.httpReq, request); res != nil {
+				if err := wsc.WriteRPCResponse(writeCtx, *res); err != nil {
+					wsc.Logger.Error("Error writing RPC response", "err", err)
+				}
+				continue
+			}
+
 			ctx := &rpctypes.Context{JSONReq: &request, WSConn: wsc}
 			args := []reflect.Value{reflect.ValueOf(ctx)}
 			if len(request.Params) > 0 {
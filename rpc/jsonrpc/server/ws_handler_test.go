@@ -42,11 +42,47 @@ func TestWebsocketManagerHandler(t *testing.T) {
 	dialResp.Body.Close()
 }
 
-func newWSServer() *httptest.Server {
+func TestWebsocketManagerAuthorizer(t *testing.T) {
+	s := newWSServer(WSAuthorizer(func(r *http.Request, method string) error {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			return ErrUnauthorized
+		}
+		return nil
+	}))
+	defer s.Close()
+
+	for auth, wantErr := range map[string]bool{"Bearer secret": false, "Bearer wrong": true} {
+		d := websocket.Dialer{}
+		c, dialResp, err := d.Dial("ws://"+s.Listener.Addr().String()+"/websocket",
+			http.Header{"Authorization": []string{auth}})
+		require.NoError(t, err)
+		dialResp.Body.Close()
+
+		req, err := rpctypes.MapToRequest(
+			rpctypes.JSONRPCStringID("TestWebsocketManagerAuthorizer"),
+			"c",
+			map[string]interface{}{"s": "a", "i": 10},
+		)
+		require.NoError(t, err)
+		require.NoError(t, c.WriteJSON(req))
+
+		var resp rpctypes.RPCResponse
+		require.NoError(t, c.ReadJSON(&resp))
+		if wantErr {
+			require.NotNil(t, resp.Error)
+			require.Equal(t, -32001, resp.Error.Code)
+		} else {
+			require.Nil(t, resp.Error)
+		}
+		c.Close()
+	}
+}
+
+func newWSServer(opts ...func(*wsConnection)) *httptest.Server {
 	funcMap := map[string]*RPCFunc{
 		"c": NewWSRPCFunc(func(ctx *rpctypes.Context, s string, i int) (string, error) { return "foo", nil }, "s,i"),
 	}
-	wm := NewWebsocketManager(funcMap)
+	wm := NewWebsocketManager(funcMap, opts...)
 	wm.SetLogger(log.TestingLogger())
 
 	mux := http.NewServeMux()
@@ -244,6 +244,24 @@ func RPCServerError(id jsonrpcid, err error) RPCResponse {
 	return NewRPCErrorResponse(id, -32000, "Server error", err.Error())
 }
 
+// RPCUnauthorizedError is returned for the calls of clients which did not
+// authenticate, or failed to.
+func RPCUnauthorizedError(id jsonrpcid, err error) RPCResponse {
+	return NewRPCErrorResponse(id, -32001, "Unauthorized", err.Error())
+}
+
+// RPCForbiddenError is returned for the calls of methods the client is not
+// allowed to call.
+func RPCForbiddenError(id jsonrpcid, err error) RPCResponse {
+	return NewRPCErrorResponse(id, -32002, "Forbidden", err.Error())
+}
+
+// RPCRateLimitedError is returned for the calls of clients which exceeded
+// their rate limit.
+func RPCRateLimitedError(id jsonrpcid, err error) RPCResponse {
+	return NewRPCErrorResponse(id, -32003, "Too many requests", err.Error())
+}
+
 //----------------------------------------
 
 // WSRPCConnection represents a websocket connection.
@@ -27,6 +27,7 @@ type Application struct {
 	cfg             *Config
 	restoreSnapshot *abci.Snapshot
 	restoreChunks   [][]byte
+	appStateChunks  [][]byte
 }
 
 // Config allows for the setting of high level parameters for running the e2e Application
@@ -45,7 +46,7 @@ type Config struct {
 	RetainBlocks uint64 `toml:"retain_blocks"`
 
 	// KeyType sets the curve that will be used by validators.
-	// Options are ed25519 & secp256k1
+	// Options are ed25519, secp256k1 & sr25519
 	KeyType string `toml:"key_type"`
 
 	// PersistInterval specifies the height interval at which the application
@@ -106,6 +107,8 @@ func (app *Application) Info(req abci.RequestInfo) abci.ResponseInfo {
 		AppVersion:       1,
 		LastBlockHeight:  int64(app.state.Height),
 		LastBlockAppHash: app.state.Hash,
+		AbciVersion:      version.ABCIVersion,
+		Features:         []string{abci.FeatureInitChainChunks},
 	}
 }
 
@@ -113,8 +116,18 @@ func (app *Application) Info(req abci.RequestInfo) abci.ResponseInfo {
 func (app *Application) InitChain(req abci.RequestInitChain) abci.ResponseInitChain {
 	var err error
 	app.state.initialHeight = uint64(req.InitialHeight)
-	if len(req.AppStateBytes) > 0 {
-		err = app.state.Import(0, req.AppStateBytes)
+	appStateBytes := req.AppStateBytes
+	if req.AppStateChunks > 0 {
+		// Buffer the chunks of the app state until the last one is received.
+		app.appStateChunks = append(app.appStateChunks, req.AppStateBytes)
+		if req.AppStateChunk < req.AppStateChunks-1 {
+			return abci.ResponseInitChain{}
+		}
+		appStateBytes = bytes.Join(app.appStateChunks, nil)
+		app.appStateChunks = nil
+	}
+	if len(appStateBytes) > 0 {
+		err = app.state.Import(0, appStateBytes)
 		if err != nil {
 			panic(err)
 		}
@@ -145,24 +158,25 @@ func (app *Application) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
 	return abci.ResponseCheckTx{Code: code.CodeTypeOK, GasWanted: 1}
 }
 
-// DeliverTx implements ABCI.
-func (app *Application) DeliverTx(req abci.RequestDeliverTx) abci.ResponseDeliverTx {
-	key, value, err := parseTx(req.Tx)
-	if err != nil {
-		panic(err) // shouldn't happen since we verified it in CheckTx
+// FinalizeBlock implements ABCI.
+func (app *Application) FinalizeBlock(req abci.RequestFinalizeBlock) abci.ResponseFinalizeBlock {
+	txs := make([]*abci.ResponseDeliverTx, len(req.Txs))
+	for i, tx := range req.Txs {
+		key, value, err := parseTx(tx)
+		if err != nil {
+			panic(err) // shouldn't happen since we verified it in CheckTx
+		}
+		app.state.Set(key, value)
+		txs[i] = &abci.ResponseDeliverTx{Code: code.CodeTypeOK}
 	}
-	app.state.Set(key, value)
-	return abci.ResponseDeliverTx{Code: code.CodeTypeOK}
-}
 
-// EndBlock implements ABCI.
-func (app *Application) EndBlock(req abci.RequestEndBlock) abci.ResponseEndBlock {
-	valUpdates, err := app.validatorUpdates(uint64(req.Height))
+	valUpdates, err := app.validatorUpdates(uint64(req.Header.Height))
 	if err != nil {
 		panic(err)
 	}
 
-	return abci.ResponseEndBlock{
+	return abci.ResponseFinalizeBlock{
+		Txs:              txs,
 		ValidatorUpdates: valUpdates,
 		Events: []abci.Event{
 			{
@@ -174,7 +188,7 @@ func (app *Application) EndBlock(req abci.RequestEndBlock) abci.ResponseEndBlock
 					},
 					{
 						Key:   "height",
-						Value: strconv.Itoa(int(req.Height)),
+						Value: strconv.Itoa(int(req.Header.Height)),
 					},
 				},
 			},
@@ -188,6 +202,7 @@ func (app *Application) Commit() abci.ResponseCommit {
 	if err != nil {
 		panic(err)
 	}
+	var snapshotHeight, snapshotRetainHeight uint64
 	if app.cfg.SnapshotInterval > 0 && height%app.cfg.SnapshotInterval == 0 {
 		snapshot, err := app.snapshots.Create(app.state)
 		if err != nil {
@@ -198,14 +213,21 @@ func (app *Application) Commit() abci.ResponseCommit {
 		if err != nil {
 			app.logger.Error("Failed to prune snapshots", "err", err)
 		}
+		// let the node advertise the snapshot right away
+		snapshotHeight = snapshot.Height
+		if snapshots, err := app.snapshots.List(); err == nil && len(snapshots) > 0 {
+			snapshotRetainHeight = snapshots[0].Height
+		}
 	}
 	retainHeight := int64(0)
 	if app.cfg.RetainBlocks > 0 {
 		retainHeight = int64(height - app.cfg.RetainBlocks + 1)
 	}
 	return abci.ResponseCommit{
-		Data:         hash,
-		RetainHeight: retainHeight,
+		Data:                 hash,
+		RetainHeight:         retainHeight,
+		SnapshotHeight:       snapshotHeight,
+		SnapshotRetainHeight: snapshotRetainHeight,
 	}
 }
 
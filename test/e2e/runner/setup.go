@@ -203,6 +203,9 @@ func MakeGenesis(testnet *e2e.Testnet) (types.GenesisDoc, error) {
 	case "", types.ABCIPubKeyTypeEd25519, types.ABCIPubKeyTypeSecp256k1:
 		genesis.ConsensusParams.Validator.PubKeyTypes =
 			append(genesis.ConsensusParams.Validator.PubKeyTypes, types.ABCIPubKeyTypeSecp256k1)
+	case types.ABCIPubKeyTypeSr25519:
+		genesis.ConsensusParams.Validator.PubKeyTypes =
+			append(genesis.ConsensusParams.Validator.PubKeyTypes, types.ABCIPubKeyTypeSr25519)
 	default:
 		return genesis, errors.New("unsupported KeyType")
 	}
@@ -0,0 +1,89 @@
+package simulation
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Kind is the kind of a consensus message.
+type Kind string
+
+const (
+	KindProposal  Kind = "proposal"
+	KindBlockPart Kind = "block_part"
+	KindPrevote   Kind = "prevote"
+	KindPrecommit Kind = "precommit"
+)
+
+// Delivery is the fate of a message: dropped, or delivered after a delay.
+type Delivery struct {
+	Drop  bool
+	Delay time.Duration
+}
+
+// Scheduler decides the delivery of each message sent from a node to
+// another. It is given the random number generator of the simulation, seeded
+// by Config.Seed, for its decisions to be reproducible.
+type Scheduler interface {
+	Schedule(rng *rand.Rand, msg Message) Delivery
+}
+
+// SchedulerFunc is a function implementing Scheduler.
+type SchedulerFunc func(rng *rand.Rand, msg Message) Delivery
+
+// Schedule implements Scheduler.
+func (f SchedulerFunc) Schedule(rng *rand.Rand, msg Message) Delivery {
+	return f(rng, msg)
+}
+
+// FixedDelay delivers every message after delay, in the order they are sent.
+func FixedDelay(delay time.Duration) Scheduler {
+	return SchedulerFunc(func(*rand.Rand, Message) Delivery {
+		return Delivery{Delay: delay}
+	})
+}
+
+// RandomDelay delivers every message after a random delay between min and
+// max, reordering them.
+func RandomDelay(min, max time.Duration) Scheduler {
+	return SchedulerFunc(func(rng *rand.Rand, _ Message) Delivery {
+		if max <= min {
+			return Delivery{Delay: min}
+		}
+		return Delivery{Delay: min + time.Duration(rng.Int63n(int64(max-min)))}
+	})
+}
+
+// DropRate drops each message with probability p, and schedules the others
+// with next.
+func DropRate(p float64, next Scheduler) Scheduler {
+	return SchedulerFunc(func(rng *rand.Rand, msg Message) Delivery {
+		if rng.Float64() < p {
+			return Delivery{Drop: true}
+		}
+		return next.Schedule(rng, msg)
+	})
+}
+
+// Partition drops the messages sent between the given groups of nodes, by
+// index, from the time elapsed since the start of the simulation is from
+// until it is until, and schedules the others with next. Nodes in no group are
+// isolated from all the others.
+func Partition(from, until time.Duration, groups [][]int, next Scheduler) Scheduler {
+	group := make(map[int]int)
+	for g, nodes := range groups {
+		for _, node := range nodes {
+			group[node] = g
+		}
+	}
+	return SchedulerFunc(func(rng *rand.Rand, msg Message) Delivery {
+		if msg.Elapsed >= from && msg.Elapsed < until {
+			gFrom, okFrom := group[msg.From]
+			gTo, okTo := group[msg.To]
+			if !okFrom || !okTo || gFrom != gTo {
+				return Delivery{Drop: true}
+			}
+		}
+		return next.Schedule(rng, msg)
+	})
+}
@@ -0,0 +1,521 @@
+// Package simulation runs several consensus state machines in a single
+// process, against a virtual clock and a controllable message scheduler, for
+// reproducible tests of timeout and partition scenarios.
+//
+// The nodes of a simulation don't run any goroutine of their own: the
+// simulation processes one event at a time, the delivery of a message or the
+// timeout of a node, in the order of their virtual times, advancing the clock
+// to each. Every proposal, block part and vote a node sends is handed to the
+// Scheduler for each other node, which drops it or picks its delay. Given the
+// same Config, including its Seed, a simulation makes the same decisions and
+// commits the same blocks.
+//
+// Nodes exchange no other messages than their proposals, block parts and
+// votes. In place of the gossip of the reactor, every Config.GossipInterval
+// each node sends again the messages it sent at its current height, and the
+// nodes which are behind are sent the commit and block parts of the next block,
+// as block sync would.
+package simulation
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	dbm "github.com/tendermint/tm-db"
+
+	abciclient "github.com/tendermint/tendermint/abci/client"
+	"github.com/tendermint/tendermint/abci/example/kvstore"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/internal/consensus"
+	"github.com/tendermint/tendermint/internal/eventbus"
+	"github.com/tendermint/tendermint/internal/mempool"
+	sm "github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/internal/store"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// ErrTimeout is returned when a condition isn't met within the virtual time
+// allowed.
+var ErrTimeout = errors.New("simulation timed out")
+
+// Config configures a simulation.
+type Config struct {
+	// Number of validators, of equal voting power. Defaults to 4.
+	Validators int
+	// Seeds the validator keys and the random number generator of the
+	// Scheduler.
+	Seed int64
+	// Decides the delivery of the messages. Defaults to delivering them all
+	// after 10ms.
+	Scheduler Scheduler
+	// Consensus configuration of the nodes. Defaults to
+	// config.DefaultConsensusConfig, whose timeouts cost nothing on the
+	// virtual clock.
+	Consensus *config.ConsensusConfig
+	// Returns the application of a node, by index. Defaults to kvstore.
+	App func(node int) abci.Application
+	// Start of the virtual clock and genesis time. Defaults to 2020-01-01 UTC.
+	GenesisTime time.Time
+	// Interval at which the nodes send their messages again, and the nodes
+	// which are behind are sent the commits of the others. Defaults to 1s;
+	// negative disables it.
+	GossipInterval time.Duration
+	// Defaults to log.NewNopLogger.
+	Logger log.Logger
+}
+
+// Message is a consensus message sent from a node to another, by index.
+type Message struct {
+	From    int
+	To      int
+	Kind    Kind
+	Height  int64
+	Round   int32
+	Elapsed time.Duration // virtual time elapsed since the start, when sent
+
+	msg consensus.Message
+}
+
+// Simulation is a network of consensus state machines on a virtual clock.
+// It is not safe for concurrent use.
+type Simulation struct {
+	cfg        Config
+	rng        *rand.Rand
+	start      time.Time
+	now        time.Time
+	nodes      []*node
+	events     eventQueue
+	seq        uint64
+	nextGossip time.Time
+}
+
+type node struct {
+	id types.NodeID
+	// the messages the node sent at its current height
+	sent       []consensus.Message
+	sentHeight int64
+
+	sim      *consensus.SimulatedNode
+	mempool  *mempool.TxMempool
+	store    *store.BlockStore
+	eventBus *eventbus.EventBus
+}
+
+// New returns a simulation of the configured network, its nodes ready to
+// start their first round. The context bounds the event buses of the nodes.
+func New(ctx context.Context, cfg Config) (*Simulation, error) {
+	if cfg.Validators == 0 {
+		cfg.Validators = 4
+	}
+	if cfg.Scheduler == nil {
+		cfg.Scheduler = FixedDelay(10 * time.Millisecond)
+	}
+	if cfg.Consensus == nil {
+		cfg.Consensus = config.DefaultConsensusConfig()
+	}
+	if cfg.App == nil {
+		cfg.App = func(int) abci.Application { return kvstore.NewApplication() }
+	}
+	if cfg.GenesisTime.IsZero() {
+		cfg.GenesisTime = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	if cfg.GossipInterval == 0 {
+		cfg.GossipInterval = time.Second
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = log.NewNopLogger()
+	}
+
+	s := &Simulation{
+		cfg:   cfg,
+		rng:   rand.New(rand.NewSource(cfg.Seed)), // nolint:gosec // reproducibility
+		start: cfg.GenesisTime,
+		now:   cfg.GenesisTime,
+	}
+	s.nextGossip = s.now.Add(cfg.GossipInterval)
+
+	privKeys := make([]ed25519.PrivKey, cfg.Validators)
+	genDoc := &types.GenesisDoc{
+		GenesisTime:     cfg.GenesisTime,
+		ChainID:         "simulation",
+		InitialHeight:   1,
+		ConsensusParams: types.DefaultConsensusParams(),
+	}
+	for i := range privKeys {
+		privKeys[i] = ed25519.GenPrivKeyFromSecret([]byte(fmt.Sprintf("simulation/%d/%d", cfg.Seed, i)))
+		genDoc.Validators = append(genDoc.Validators, types.GenesisValidator{
+			PubKey: privKeys[i].PubKey(),
+			Power:  10,
+			Name:   fmt.Sprintf("validator%d", i),
+		})
+	}
+	if err := genDoc.ValidateAndComplete(); err != nil {
+		return nil, err
+	}
+
+	for i, privKey := range privKeys {
+		n, err := s.newNode(ctx, i, genDoc, privKey)
+		if err != nil {
+			return nil, fmt.Errorf("node %d: %w", i, err)
+		}
+		s.nodes = append(s.nodes, n)
+	}
+	for _, n := range s.nodes {
+		n.sim.Start()
+	}
+	return s, nil
+}
+
+func (s *Simulation) newNode(
+	ctx context.Context,
+	index int,
+	genDoc *types.GenesisDoc,
+	privKey ed25519.PrivKey,
+) (*node, error) {
+	logger := s.cfg.Logger.With("node", index)
+
+	state, err := sm.MakeGenesisState(genDoc)
+	if err != nil {
+		return nil, err
+	}
+
+	// one for mempool, one for consensus
+	mtx := new(sync.Mutex)
+	app := s.cfg.App(index)
+	proxyAppConnMem := abciclient.NewLocalClient(logger, mtx, app)
+	proxyAppConnCon := abciclient.NewLocalClient(logger, mtx, app)
+
+	params := genDoc.ConsensusParams.ToProto()
+	res, err := proxyAppConnCon.InitChainSync(ctx, abci.RequestInitChain{
+		Time:            genDoc.GenesisTime,
+		ChainId:         genDoc.ChainID,
+		InitialHeight:   genDoc.InitialHeight,
+		ConsensusParams: &params,
+		Validators:      types.TM2PB.ValidatorUpdates(state.Validators),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(res.AppHash) > 0 {
+		state.AppHash = res.AppHash
+	}
+
+	mp := mempool.NewTxMempool(logger.With("module", "mempool"), config.DefaultMempoolConfig(), proxyAppConnMem, 0)
+	if s.cfg.Consensus.WaitForTxs() {
+		mp.EnableTxsAvailable()
+	}
+
+	stateStore := sm.NewStore(dbm.NewMemDB())
+	if err := stateStore.Save(state); err != nil {
+		return nil, err
+	}
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	evpool := sm.EmptyEvidencePool{}
+	blockExec := sm.NewBlockExecutor(stateStore, logger, proxyAppConnCon, mp, evpool, blockStore)
+
+	eventBus := eventbus.NewDefault(logger.With("module", "events"))
+	if err := eventBus.Start(ctx); err != nil {
+		return nil, err
+	}
+
+	cs := consensus.NewState(ctx,
+		logger.With("module", "consensus"),
+		s.cfg.Consensus,
+		state,
+		blockExec,
+		blockStore,
+		mp,
+		evpool,
+		consensus.StateClock(s.Now),
+	)
+	cs.SetPrivValidator(ctx, types.NewMockPVWithParams(privKey, false, false))
+	cs.SetEventBus(eventBus)
+
+	return &node{
+		id:       types.NodeIDFromPubKey(privKey.PubKey()),
+		sim:      consensus.NewSimulatedNode(cs),
+		mempool:  mp,
+		store:    blockStore,
+		eventBus: eventBus,
+	}, nil
+}
+
+// Now returns the time of the virtual clock.
+func (s *Simulation) Now() time.Time {
+	return s.now
+}
+
+// Elapsed returns the virtual time elapsed since the start of the simulation.
+func (s *Simulation) Elapsed() time.Duration {
+	return s.now.Sub(s.start)
+}
+
+// Nodes returns the number of nodes.
+func (s *Simulation) Nodes() int {
+	return len(s.nodes)
+}
+
+// Height returns the height of the last block committed by a node.
+func (s *Simulation) Height(node int) int64 {
+	return s.nodes[node].store.Height()
+}
+
+// Block returns the block a node committed at height, nil if it didn't.
+func (s *Simulation) Block(node int, height int64) *types.Block {
+	return s.nodes[node].store.LoadBlock(height)
+}
+
+// Round returns the height and round a node is at.
+func (s *Simulation) Round(node int) (int64, int32) {
+	rs := s.nodes[node].sim.State().GetRoundState()
+	return rs.Height, rs.Round
+}
+
+// EventBus returns the event bus of a node, to subscribe to its events.
+func (s *Simulation) EventBus(node int) *eventbus.EventBus {
+	return s.nodes[node].eventBus
+}
+
+// CheckTx adds tx to the mempool of every node.
+func (s *Simulation) CheckTx(ctx context.Context, tx types.Tx) error {
+	for i, n := range s.nodes {
+		if err := n.mempool.CheckTx(ctx, tx, nil, mempool.TxInfo{}); err != nil {
+			return fmt.Errorf("node %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Step processes the next event, advancing the virtual clock to it, and
+// returns false if there is none. The deliveries of the messages come before
+// the timeouts at the same time, the timeouts of the nodes in their order,
+// and the gossip last.
+func (s *Simulation) Step(ctx context.Context) bool {
+	timeoutNode, timeoutAt := s.nextTimeout()
+	gossip := s.cfg.GossipInterval > 0
+
+	switch {
+	case len(s.events) > 0 && (timeoutNode < 0 || !s.events[0].at.After(timeoutAt)) &&
+		(!gossip || !s.events[0].at.After(s.nextGossip)):
+		e := heap.Pop(&s.events).(*event)
+		s.advance(e.at)
+		n := s.nodes[e.msg.To]
+		s.broadcast(e.msg.To, n.sim.Receive(ctx, e.msg.msg, s.nodes[e.msg.From].id))
+
+	case timeoutNode >= 0 && (!gossip || !timeoutAt.After(s.nextGossip)):
+		s.advance(timeoutAt)
+		s.broadcast(timeoutNode, s.nodes[timeoutNode].sim.FireTimeout(ctx))
+
+	case gossip:
+		s.advance(s.nextGossip)
+		s.gossip()
+		s.nextGossip = s.now.Add(s.cfg.GossipInterval)
+
+	default:
+		return false
+	}
+	return true
+}
+
+// RunFor processes the events of the next d of virtual time.
+func (s *Simulation) RunFor(ctx context.Context, d time.Duration) error {
+	end := s.now.Add(d)
+	for s.nextEventBefore(end) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		s.Step(ctx)
+	}
+	s.advance(end)
+	return nil
+}
+
+// RunUntil processes events until cond returns true, or returns ErrTimeout if
+// it doesn't within d of virtual time.
+func (s *Simulation) RunUntil(ctx context.Context, d time.Duration, cond func(*Simulation) bool) error {
+	end := s.now.Add(d)
+	for !cond(s) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !s.nextEventBefore(end) {
+			s.advance(end)
+			return fmt.Errorf("%w after %v", ErrTimeout, d)
+		}
+		s.Step(ctx)
+	}
+	return nil
+}
+
+// RunUntilHeight processes events until every node committed height, or
+// returns ErrTimeout if they don't within d of virtual time.
+func (s *Simulation) RunUntilHeight(ctx context.Context, d time.Duration, height int64) error {
+	return s.RunUntil(ctx, d, func(s *Simulation) bool {
+		for i := range s.nodes {
+			if s.Height(i) < height {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// nextEventBefore returns whether there is an event up to end.
+func (s *Simulation) nextEventBefore(end time.Time) bool {
+	if len(s.events) > 0 && !s.events[0].at.After(end) {
+		return true
+	}
+	if node, at := s.nextTimeout(); node >= 0 && !at.After(end) {
+		return true
+	}
+	return s.cfg.GossipInterval > 0 && !s.nextGossip.After(end)
+}
+
+// nextTimeout returns the node whose pending timeout fires first, -1 if none
+// has any, and the time it fires at.
+func (s *Simulation) nextTimeout() (int, time.Time) {
+	node, firesAt := -1, time.Time{}
+	for i, n := range s.nodes {
+		if at, ok := n.sim.NextTimeout(); ok && (node < 0 || at.Before(firesAt)) {
+			node, firesAt = i, at
+		}
+	}
+	return node, firesAt
+}
+
+// advance moves the virtual clock forward to t; timeouts of non-positive
+// durations may be due before the current time.
+func (s *Simulation) advance(t time.Time) {
+	if t.After(s.now) {
+		s.now = t
+	}
+}
+
+// broadcast sends the messages sent by a node to every other node.
+func (s *Simulation) broadcast(from int, msgs []consensus.Message) {
+	n := s.nodes[from]
+	if height, _ := s.Round(from); height != n.sentHeight {
+		n.sent, n.sentHeight = nil, height
+	}
+	for _, msg := range msgs {
+		if msgHeight(msg) == n.sentHeight {
+			n.sent = append(n.sent, msg)
+		}
+		for to := range s.nodes {
+			if to != from {
+				s.send(from, to, msg)
+			}
+		}
+	}
+}
+
+// send schedules the delivery of msg from a node to another.
+func (s *Simulation) send(from, to int, msg consensus.Message) {
+	m := Message{From: from, To: to, Elapsed: s.Elapsed(), msg: msg}
+	switch msg := msg.(type) {
+	case *consensus.ProposalMessage:
+		m.Kind, m.Height, m.Round = KindProposal, msg.Proposal.Height, msg.Proposal.Round
+	case *consensus.BlockPartMessage:
+		m.Kind, m.Height, m.Round = KindBlockPart, msg.Height, msg.Round
+	case *consensus.VoteMessage:
+		m.Kind, m.Height, m.Round = KindPrevote, msg.Vote.Height, msg.Vote.Round
+		if msg.Vote.Type == tmproto.PrecommitType {
+			m.Kind = KindPrecommit
+		}
+	default:
+		return
+	}
+
+	d := s.cfg.Scheduler.Schedule(s.rng, m)
+	if d.Drop {
+		return
+	}
+	if d.Delay < 0 {
+		d.Delay = 0
+	}
+	s.seq++
+	heap.Push(&s.events, &event{at: s.now.Add(d.Delay), seq: s.seq, msg: m})
+}
+
+// gossip sends again the messages each node sent at its current height, and
+// sends each node which is behind another the commit and block parts of the
+// next block, from the first node which committed it.
+func (s *Simulation) gossip() {
+	for from, n := range s.nodes {
+		if height, _ := s.Round(from); height != n.sentHeight {
+			n.sent, n.sentHeight = nil, height
+		}
+		for _, msg := range n.sent {
+			for to := range s.nodes {
+				if to != from {
+					s.send(from, to, msg)
+				}
+			}
+		}
+	}
+
+	for to, n := range s.nodes {
+		height := n.store.Height() + 1
+		for from, peer := range s.nodes {
+			if from == to || peer.store.Height() < height {
+				continue
+			}
+			for _, msg := range peer.sim.CommitMessages(height) {
+				s.send(from, to, msg)
+			}
+			break
+		}
+	}
+}
+
+// msgHeight returns the height of a proposal, block part or vote.
+func msgHeight(msg consensus.Message) int64 {
+	switch msg := msg.(type) {
+	case *consensus.ProposalMessage:
+		return msg.Proposal.Height
+	case *consensus.BlockPartMessage:
+		return msg.Height
+	case *consensus.VoteMessage:
+		return msg.Vote.Height
+	}
+	return 0
+}
+
+// event is the delivery of a message at a time. Events at the same time are
+// ordered by their sequence number, the order they were scheduled in.
+type event struct {
+	at  time.Time
+	seq uint64
+	msg Message
+}
+
+type eventQueue []*event
+
+func (q eventQueue) Len() int { return len(q) }
+
+func (q eventQueue) Less(i, j int) bool {
+	if !q[i].at.Equal(q[j].at) {
+		return q[i].at.Before(q[j].at)
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q eventQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *eventQueue) Push(x interface{}) { *q = append(*q, x.(*event)) }
+
+func (q *eventQueue) Pop() interface{} {
+	old := *q
+	e := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return e
+}
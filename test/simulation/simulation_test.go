@@ -0,0 +1,111 @@
+package simulation
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestSimulation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s, err := New(ctx, Config{Seed: 1})
+	require.NoError(t, err)
+	require.NoError(t, s.CheckTx(ctx, types.Tx("key=value")))
+
+	require.NoError(t, s.RunUntilHeight(ctx, time.Minute, 5))
+
+	// every node committed the same blocks, with the transaction
+	var txs int
+	for h := int64(1); h <= 5; h++ {
+		block := s.Block(0, h)
+		require.NotNil(t, block)
+		txs += len(block.Txs)
+		for i := 1; i < s.Nodes(); i++ {
+			assert.Equal(t, block.Hash(), s.Block(i, h).Hash(), "node %d height %d", i, h)
+		}
+	}
+	assert.Equal(t, 1, txs)
+	// on the virtual clock, without any actual wait
+	assert.Greater(t, s.Elapsed(), 5*time.Second)
+}
+
+func TestSimulationReproducible(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	run := func(seed int64) ([]types.BlockID, time.Duration) {
+		s, err := New(ctx, Config{
+			Seed:      seed,
+			Scheduler: DropRate(0.1, RandomDelay(0, 500*time.Millisecond)),
+		})
+		require.NoError(t, err)
+		require.NoError(t, s.RunUntilHeight(ctx, 10*time.Minute, 5))
+
+		var ids []types.BlockID
+		for h := int64(1); h <= 5; h++ {
+			meta := s.nodes[0].store.LoadBlockMeta(h)
+			ids = append(ids, meta.BlockID)
+		}
+		return ids, s.Elapsed()
+	}
+
+	ids, elapsed := run(7)
+	ids2, elapsed2 := run(7)
+	assert.Equal(t, ids, ids2)
+	assert.Equal(t, elapsed, elapsed2)
+}
+
+func TestSimulationPartition(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// no group has +2/3 of the voting power during the partition
+	s, err := New(ctx, Config{
+		Seed:      2,
+		Scheduler: Partition(0, time.Minute, [][]int{{0, 1}, {2, 3}}, FixedDelay(10*time.Millisecond)),
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, s.RunFor(ctx, time.Minute))
+	for i := 0; i < s.Nodes(); i++ {
+		assert.Zero(t, s.Height(i), "node %d", i)
+	}
+
+	// the network recovers once the partition heals
+	require.NoError(t, s.RunUntilHeight(ctx, 5*time.Minute, 3))
+}
+
+func TestSimulationCatchUp(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// node 3 receives nothing for a minute, while the others commit blocks
+	s, err := New(ctx, Config{
+		Seed: 3,
+		Scheduler: SchedulerFunc(func(rng *rand.Rand, msg Message) Delivery {
+			if msg.To == 3 && msg.Elapsed < time.Minute {
+				return Delivery{Drop: true}
+			}
+			return Delivery{Delay: 10 * time.Millisecond}
+		}),
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, s.RunFor(ctx, time.Minute))
+	assert.Zero(t, s.Height(3))
+	require.Greater(t, s.Height(0), int64(3))
+
+	height := s.Height(0)
+	require.NoError(t, s.RunUntilHeight(ctx, time.Minute, height))
+	for h := int64(1); h <= height; h++ {
+		assert.Equal(t, s.Block(0, h).Hash(), s.Block(3, h).Hash())
+	}
+}
@@ -25,7 +25,7 @@ const (
 	// MaxHeaderBytes is a maximum header size.
 	// NOTE: Because app hash can be of arbitrary size, the header is therefore not
 	// capped in size and thus this number should be seen as a soft max
-	MaxHeaderBytes int64 = 626
+	MaxHeaderBytes int64 = 660
 
 	// MaxOverheadForBlock - maximum overhead to encode a block (up to
 	// MaxBlockSizeBytes in size) not including it's parts except Data.
@@ -144,6 +144,30 @@ func (b *Block) MakePartSet(partSize uint32) *PartSet {
 	return NewPartSetFromData(bz, partSize)
 }
 
+// MakePartSetWithParams returns a PartSet containing parts of a serialized
+// block, split as configured by the block parameters: into parts of
+// params.PartSize() bytes, erasure-coded if params.ErasureCoding is set.
+func (b *Block) MakePartSetWithParams(params BlockParams) *PartSet {
+	if !params.ErasureCoding {
+		return b.MakePartSet(params.PartSize())
+	}
+	if b == nil {
+		return nil
+	}
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	pbb, err := b.ToProto()
+	if err != nil {
+		panic(err)
+	}
+	bz, err := proto.Marshal(pbb)
+	if err != nil {
+		panic(err)
+	}
+	return NewErasurePartSetFromData(bz, params.PartSize())
+}
+
 // HashesTo is a convenience function that checks if a block hashes to the given argument.
 // Returns false if the block is nil or the hash is empty.
 func (b *Block) HashesTo(hash []byte) bool {
@@ -356,6 +380,10 @@ type Header struct {
 	// consensus info
 	EvidenceHash    tmbytes.HexBytes `json:"evidence_hash"`    // evidence included in the block
 	ProposerAddress Address          `json:"proposer_address"` // original proposer of the block
+
+	// root hash of all events from the previous block, if committed to by the
+	// consensus params of the previous block (see EventsHash)
+	LastEventsHash tmbytes.HexBytes `json:"last_events_hash,omitempty"`
 }
 
 // Populate the Header with state-derived data.
@@ -435,13 +463,18 @@ func (h Header) ValidateBasic() error {
 	if err := ValidateHash(h.LastResultsHash); err != nil {
 		return fmt.Errorf("wrong LastResultsHash: %v", err)
 	}
+	if err := ValidateHash(h.LastEventsHash); err != nil {
+		return fmt.Errorf("wrong LastEventsHash: %v", err)
+	}
 
 	return nil
 }
 
 // Hash returns the hash of the header.
 // It computes a Merkle tree from the header fields
-// ordered as they appear in the Header.
+// ordered as they appear in the Header. The LastEventsHash is
+// only included if set, so that the hashes of headers of chains
+// not committing to events are unchanged.
 // Returns nil if ValidatorHash is missing,
 // since a Header is not valid unless there is
 // a ValidatorsHash (corresponding to the validator set).
@@ -465,7 +498,7 @@ func (h *Header) Hash() tmbytes.HexBytes {
 	if err != nil {
 		return nil
 	}
-	return merkle.HashFromByteSlices([][]byte{
+	fields := [][]byte{
 		hbz,
 		cdcEncode(h.ChainID),
 		cdcEncode(h.Height),
@@ -480,7 +513,11 @@ func (h *Header) Hash() tmbytes.HexBytes {
 		cdcEncode(h.LastResultsHash),
 		cdcEncode(h.EvidenceHash),
 		cdcEncode(h.ProposerAddress),
-	})
+	}
+	if len(h.LastEventsHash) > 0 {
+		fields = append(fields, cdcEncode(h.LastEventsHash))
+	}
+	return merkle.HashFromByteSlices(fields)
 }
 
 // StringIndented returns an indented string representation of the header.
@@ -503,6 +540,7 @@ func (h *Header) StringIndented(indent string) string {
 %s  Results:        %v
 %s  Evidence:       %v
 %s  Proposer:       %v
+%s  Events:         %v
 %s}#%v`,
 		indent, h.Version,
 		indent, h.ChainID,
@@ -518,6 +556,7 @@ func (h *Header) StringIndented(indent string) string {
 		indent, h.LastResultsHash,
 		indent, h.EvidenceHash,
 		indent, h.ProposerAddress,
+		indent, h.LastEventsHash,
 		indent, h.Hash())
 }
 
@@ -542,6 +581,7 @@ func (h *Header) ToProto() *tmproto.Header {
 		LastResultsHash:    h.LastResultsHash,
 		LastCommitHash:     h.LastCommitHash,
 		ProposerAddress:    h.ProposerAddress,
+		LastEventsHash:     h.LastEventsHash,
 	}
 }
 
@@ -574,6 +614,7 @@ func HeaderFromProto(ph *tmproto.Header) (Header, error) {
 	h.LastResultsHash = ph.LastResultsHash
 	h.LastCommitHash = ph.LastCommitHash
 	h.ProposerAddress = ph.ProposerAddress
+	h.LastEventsHash = ph.LastEventsHash
 
 	return *h, h.ValidateBasic()
 }
@@ -774,17 +815,35 @@ func NewCommit(height int64, round int32, blockID BlockID, commitSigs []CommitSi
 // Panics if signatures from the commit can't be added to the voteset.
 // Inverse of VoteSet.MakeCommit().
 func CommitToVoteSet(chainID string, commit *Commit, vals *ValidatorSet) *VoteSet {
+	voteSet, err := VoteSetFromCommit(chainID, commit, vals)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to reconstruct LastCommit: %v", err))
+	}
+	return voteSet
+}
+
+// VoteSetFromCommit constructs a VoteSet from the Commit and validator set,
+// verifying the signatures of the commit in a batch where possible. It returns
+// an error if any of the signatures can't be added to the voteset.
+func VoteSetFromCommit(chainID string, commit *Commit, vals *ValidatorSet) (*VoteSet, error) {
 	voteSet := NewVoteSet(chainID, commit.Height, commit.Round, tmproto.PrecommitType, vals)
+	votes := make([]*Vote, 0, len(commit.Signatures))
 	for idx, commitSig := range commit.Signatures {
 		if commitSig.Absent() {
 			continue // OK, some precommits can be missing.
 		}
-		added, err := voteSet.AddVote(commit.GetVote(int32(idx)))
-		if !added || err != nil {
-			panic(fmt.Sprintf("Failed to reconstruct LastCommit: %v", err))
+		votes = append(votes, commit.GetVote(int32(idx)))
+	}
+	added, errs := voteSet.AddVotes(votes)
+	for i, vote := range votes {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		if !added[i] {
+			return nil, fmt.Errorf("duplicate precommit of validator %d", vote.ValidatorIndex)
 		}
 	}
-	return voteSet
+	return voteSet, nil
 }
 
 // GetVote converts the CommitSig for the given valIdx to a Vote.
@@ -336,6 +336,23 @@ func TestHeaderHash(t *testing.T) {
 			LastResultsHash:    tmhash.Sum([]byte("last_results_hash")),
 			EvidenceHash:       tmhash.Sum([]byte("evidence_hash")),
 			ProposerAddress:    crypto.AddressHash([]byte("proposer_address")),
+			LastEventsHash:     tmhash.Sum([]byte("last_events_hash")),
+		}, hexBytesFromString("48CB6F54AAAF4296FE168635F0B7CEC29F2A0D3AE39D32FE3C80B1AF9CAF8E4C")},
+		{"Generates expected hash without LastEventsHash", &Header{
+			Version:            version.Consensus{Block: 1, App: 2},
+			ChainID:            "chainId",
+			Height:             3,
+			Time:               time.Date(2019, 10, 13, 16, 14, 44, 0, time.UTC),
+			LastBlockID:        makeBlockID(make([]byte, tmhash.Size), 6, make([]byte, tmhash.Size)),
+			LastCommitHash:     tmhash.Sum([]byte("last_commit_hash")),
+			DataHash:           tmhash.Sum([]byte("data_hash")),
+			ValidatorsHash:     tmhash.Sum([]byte("validators_hash")),
+			NextValidatorsHash: tmhash.Sum([]byte("next_validators_hash")),
+			ConsensusHash:      tmhash.Sum([]byte("consensus_hash")),
+			AppHash:            tmhash.Sum([]byte("app_hash")),
+			LastResultsHash:    tmhash.Sum([]byte("last_results_hash")),
+			EvidenceHash:       tmhash.Sum([]byte("evidence_hash")),
+			ProposerAddress:    crypto.AddressHash([]byte("proposer_address")),
 		}, hexBytesFromString("F740121F553B5418C3EFBD343C2DBFE9E007BB67B0D020A0741374BAB65242A4")},
 		{"nil header yields nil", nil, nil},
 		{"nil ValidatorsHash yields nil", &Header{
@@ -362,7 +379,7 @@ func TestHeaderHash(t *testing.T) {
 
 			// We also make sure that all fields are hashed in struct order, and that all
 			// fields in the test struct are non-zero.
-			if tc.header != nil && tc.expectHash != nil {
+			if tc.header != nil && tc.expectHash != nil && len(tc.header.LastEventsHash) > 0 {
 				byteSlices := [][]byte{}
 
 				s := reflect.ValueOf(*tc.header)
@@ -432,6 +449,7 @@ func TestMaxHeaderBytes(t *testing.T) {
 		LastResultsHash:    tmhash.Sum([]byte("last_results_hash")),
 		EvidenceHash:       tmhash.Sum([]byte("evidence_hash")),
 		ProposerAddress:    crypto.AddressHash([]byte("proposer_address")),
+		LastEventsHash:     tmhash.Sum([]byte("last_events_hash")),
 	}
 
 	bz, err := h.ToProto().Marshal()
@@ -469,11 +487,11 @@ func TestBlockMaxDataBytes(t *testing.T) {
 	}{
 		0: {-10, 1, 0, true, 0},
 		1: {10, 1, 0, true, 0},
-		2: {841, 1, 0, true, 0},
-		3: {842, 1, 0, false, 0},
-		4: {843, 1, 0, false, 1},
-		5: {954, 2, 0, false, 1},
-		6: {1053, 2, 100, false, 0},
+		2: {875, 1, 0, true, 0},
+		3: {876, 1, 0, false, 0},
+		4: {877, 1, 0, false, 1},
+		5: {988, 2, 0, false, 1},
+		6: {1087, 2, 100, false, 0},
 	}
 
 	for i, tc := range testCases {
@@ -500,9 +518,9 @@ func TestBlockMaxDataBytesNoEvidence(t *testing.T) {
 	}{
 		0: {-10, 1, true, 0},
 		1: {10, 1, true, 0},
-		2: {841, 1, true, 0},
-		3: {842, 1, false, 0},
-		4: {843, 1, false, 1},
+		2: {875, 1, true, 0},
+		3: {876, 1, false, 0},
+		4: {877, 1, false, 1},
 	}
 
 	for i, tc := range testCases {
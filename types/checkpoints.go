@@ -0,0 +1,74 @@
+package types
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+)
+
+// Checkpoints are the hashes of the blocks of a chain at given heights, known
+// out of band. Nodes syncing the chain check the blocks at these heights
+// against them, which protects them from long-range attacks by validators of
+// past validator sets whose keys have been compromised.
+type Checkpoints map[int64]tmbytes.HexBytes
+
+// ErrCheckpointMismatch is returned when the block at a checkpoint height does
+// not have the checkpoint hash.
+type ErrCheckpointMismatch struct {
+	Height   int64
+	Expected tmbytes.HexBytes
+	Actual   tmbytes.HexBytes
+}
+
+func (e ErrCheckpointMismatch) Error() string {
+	return fmt.Sprintf("block %d has hash %v, but the checkpoint at this height is %v",
+		e.Height, e.Actual, e.Expected)
+}
+
+// ParseCheckpoints parses checkpoints of the form "height:hash", where hash is
+// the hex-encoded hash of the block at height.
+func ParseCheckpoints(checkpoints []string) (Checkpoints, error) {
+	parsed := make(Checkpoints, len(checkpoints))
+	for _, checkpoint := range checkpoints {
+		checkpoint = strings.TrimSpace(checkpoint)
+		if checkpoint == "" {
+			continue
+		}
+		parts := strings.Split(checkpoint, ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid checkpoint %q, expected height:hash", checkpoint)
+		}
+		height, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil || height <= 0 {
+			return nil, fmt.Errorf("invalid checkpoint %q, height must be positive", checkpoint)
+		}
+		hash, err := hex.DecodeString(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid checkpoint %q: %w", checkpoint, err)
+		}
+		if len(hash) != tmhash.Size {
+			return nil, fmt.Errorf("invalid checkpoint %q, expected a hash of %d bytes, got %d",
+				checkpoint, tmhash.Size, len(hash))
+		}
+		if existing, ok := parsed[height]; ok && !bytes.Equal(existing, hash) {
+			return nil, fmt.Errorf("conflicting checkpoints at height %d", height)
+		}
+		parsed[height] = hash
+	}
+	return parsed, nil
+}
+
+// Verify returns an ErrCheckpointMismatch if there is a checkpoint at height
+// other than hash.
+func (c Checkpoints) Verify(height int64, hash []byte) error {
+	expected, ok := c[height]
+	if !ok || bytes.Equal(expected, hash) {
+		return nil
+	}
+	return ErrCheckpointMismatch{Height: height, Expected: expected, Actual: hash}
+}
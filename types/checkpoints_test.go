@@ -0,0 +1,55 @@
+package types
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+)
+
+func TestParseCheckpoints(t *testing.T) {
+	hash := tmhash.Sum([]byte("block"))
+	hexHash := hex.EncodeToString(hash)
+
+	checkpoints, err := ParseCheckpoints([]string{"10:" + hexHash, " 20:" + strings.ToUpper(hexHash) + " ", ""})
+	require.NoError(t, err)
+	assert.Len(t, checkpoints, 2)
+	assert.EqualValues(t, hash, checkpoints[10])
+	assert.EqualValues(t, hash, checkpoints[20])
+
+	for _, invalid := range [][]string{
+		{"10"},
+		{"10:" + hexHash + ":1"},
+		{"0:" + hexHash},
+		{"-1:" + hexHash},
+		{"a:" + hexHash},
+		{"10:zz"},
+		{"10:abcd"},
+		{"10:" + hexHash, "10:" + strings.Repeat("00", tmhash.Size)},
+	} {
+		_, err := ParseCheckpoints(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestCheckpointsVerify(t *testing.T) {
+	hash := tmhash.Sum([]byte("block"))
+	other := tmhash.Sum([]byte("other"))
+	checkpoints := Checkpoints{10: hash}
+
+	assert.NoError(t, checkpoints.Verify(10, hash))
+	assert.NoError(t, checkpoints.Verify(11, other))
+	assert.NoError(t, Checkpoints(nil).Verify(10, other))
+
+	err := checkpoints.Verify(10, other)
+	var mismatch ErrCheckpointMismatch
+	require.True(t, errors.As(err, &mismatch))
+	assert.EqualValues(t, 10, mismatch.Height)
+	assert.EqualValues(t, hash, mismatch.Expected)
+	assert.EqualValues(t, other, mismatch.Actual)
+}
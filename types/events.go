@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	tmpubsub "github.com/tendermint/tendermint/internal/pubsub"
@@ -22,12 +23,19 @@ const (
 	EventNewBlockHeaderValue      = "NewBlockHeader"
 	EventNewEvidenceValue         = "NewEvidence"
 	EventTxValue                  = "Tx"
+	EventValidatorSetChangeValue  = "ValidatorSetChange"
 	EventValidatorSetUpdatesValue = "ValidatorSetUpdates"
 
 	// Internal consensus events.
 	// These are used for testing the consensus state machine.
 	// They can also be used to build real-time consensus visualizers.
 	EventCompleteProposalValue = "CompleteProposal"
+	// The ConsensusStep, ConsensusVote and ConsensusBlockPart events are only
+	// published with the consensus event firehose enabled, for external
+	// consensus monitors. They can be matched by the consensus.* keys.
+	EventConsensusBlockPartValue = "ConsensusBlockPart"
+	EventConsensusStepValue      = "ConsensusStep"
+	EventConsensusVoteValue      = "ConsensusVote"
 	// The BlockSyncStatus event will be emitted when the node switching
 	// state sync mechanism between the consensus reactor and the blocksync reactor.
 	EventBlockSyncStatusValue = "BlockSyncStatus"
@@ -85,6 +93,16 @@ var (
 			},
 		},
 	}
+
+	EventValidatorSetChange = abci.Event{
+		Type: strings.Split(EventTypeKey, ".")[0],
+		Attributes: []abci.EventAttribute{
+			{
+				Key:   strings.Split(EventTypeKey, ".")[1],
+				Value: EventValidatorSetChangeValue,
+			},
+		},
+	}
 )
 
 // ENCODING / DECODING
@@ -104,9 +122,13 @@ func init() {
 	tmjson.RegisterType(EventDataCompleteProposal{}, "tendermint/event/CompleteProposal")
 	tmjson.RegisterType(EventDataVote{}, "tendermint/event/Vote")
 	tmjson.RegisterType(EventDataValidatorSetUpdates{}, "tendermint/event/ValidatorSetUpdates")
+	tmjson.RegisterType(EventDataValidatorSetChange{}, "tendermint/event/ValidatorSetChange")
 	tmjson.RegisterType(EventDataString(""), "tendermint/event/ProposalString")
 	tmjson.RegisterType(EventDataBlockSyncStatus{}, "tendermint/event/FastSyncStatus")
 	tmjson.RegisterType(EventDataStateSyncStatus{}, "tendermint/event/StateSyncStatus")
+	tmjson.RegisterType(EventDataConsensusStep{}, "tendermint/event/ConsensusStep")
+	tmjson.RegisterType(EventDataConsensusVote{}, "tendermint/event/ConsensusVote")
+	tmjson.RegisterType(EventDataConsensusBlockPart{}, "tendermint/event/ConsensusBlockPart")
 }
 
 // Most event messages are basic types (a block, a transaction)
@@ -177,6 +199,12 @@ type EventDataValidatorSetUpdates struct {
 	ValidatorUpdates []*Validator `json:"validator_updates"`
 }
 
+// EventDataValidatorSetChange lists the validators added, removed and whose
+// voting power is updated by the validator updates of a block.
+type EventDataValidatorSetChange struct {
+	ValidatorSetChange ValidatorSetChange `json:"validator_set_change"`
+}
+
 // EventDataBlockSyncStatus shows the fastsync status and the
 // height when the node state sync mechanism changes.
 type EventDataBlockSyncStatus struct {
@@ -191,6 +219,52 @@ type EventDataStateSyncStatus struct {
 	Height   int64 `json:"height"`
 }
 
+// EventDataConsensusStep is a transition of the consensus state machine to a
+// new step, with the time spent in the previous step.
+type EventDataConsensusStep struct {
+	Height int64     `json:"height"`
+	Round  int32     `json:"round"`
+	Step   string    `json:"step"`
+	Time   time.Time `json:"time"`
+
+	PrevHeight       int64         `json:"prev_height"`
+	PrevRound        int32         `json:"prev_round"`
+	PrevStep         string        `json:"prev_step"`
+	PrevStepDuration time.Duration `json:"prev_step_duration"`
+}
+
+// EventDataConsensusVote is a vote received by the consensus state machine,
+// from a peer or signed by the node itself (empty PeerID), and whether it was
+// added to the votes of the height.
+type EventDataConsensusVote struct {
+	Height           int64     `json:"height"`
+	Round            int32     `json:"round"`
+	Type             string    `json:"type"`
+	ValidatorAddress Address   `json:"validator_address"`
+	ValidatorIndex   int32     `json:"validator_index"`
+	BlockID          BlockID   `json:"block_id"`
+	Timestamp        time.Time `json:"timestamp"`
+	PeerID           NodeID    `json:"peer_id"`
+	Time             time.Time `json:"time"`
+	Added            bool      `json:"added"`
+	Error            string    `json:"error,omitempty"`
+}
+
+// EventDataConsensusBlockPart is a part of a proposal block received by the
+// consensus state machine, from a peer or from the node itself (empty
+// PeerID), and whether it was added to the parts of the proposal block.
+type EventDataConsensusBlockPart struct {
+	Height   int64     `json:"height"`
+	Round    int32     `json:"round"`
+	Index    uint32    `json:"index"`
+	Total    uint32    `json:"total"`
+	PeerID   NodeID    `json:"peer_id"`
+	Time     time.Time `json:"time"`
+	Added    bool      `json:"added"`
+	Complete bool      `json:"complete"`
+	Error    string    `json:"error,omitempty"`
+}
+
 // PUBSUB
 
 const (
@@ -202,6 +276,21 @@ const (
 	// TxHeightKey is a reserved key, used to specify transaction block's height.
 	// see EventBus#PublishEventTx
 	TxHeightKey = "tx.height"
+	// ValidatorAddressKey is a reserved key, used to specify the addresses of
+	// the validators changed by a validator set change.
+	// see EventBus#PublishEventValidatorSetChange
+	ValidatorAddressKey = "validator.address"
+	// ConsensusHeightKey, ConsensusRoundKey and ConsensusStepKey are reserved
+	// keys, used to specify the height, round and step of the consensus
+	// firehose events, e.g. "consensus.height EXISTS" matches all of them.
+	// see EventBus#PublishEventConsensusStep
+	ConsensusHeightKey = "consensus.height"
+	ConsensusRoundKey  = "consensus.round"
+	ConsensusStepKey   = "consensus.step"
+	// ConsensusPeerKey is a reserved key, used to specify the peer a vote or
+	// block part of the consensus firehose was received from.
+	// see EventBus#PublishEventConsensusVote
+	ConsensusPeerKey = "consensus.peer"
 
 	// BlockHeightKey is a reserved key used for indexing BeginBlock and Endblock
 	// events.
@@ -213,6 +302,9 @@ const (
 
 var (
 	EventQueryCompleteProposal    = QueryForEvent(EventCompleteProposalValue)
+	EventQueryConsensusBlockPart  = QueryForEvent(EventConsensusBlockPartValue)
+	EventQueryConsensusStep       = QueryForEvent(EventConsensusStepValue)
+	EventQueryConsensusVote       = QueryForEvent(EventConsensusVoteValue)
 	EventQueryLock                = QueryForEvent(EventLockValue)
 	EventQueryNewBlock            = QueryForEvent(EventNewBlockValue)
 	EventQueryNewBlockHeader      = QueryForEvent(EventNewBlockHeaderValue)
@@ -225,6 +317,7 @@ var (
 	EventQueryTimeoutWait         = QueryForEvent(EventTimeoutWaitValue)
 	EventQueryTx                  = QueryForEvent(EventTxValue)
 	EventQueryUnlock              = QueryForEvent(EventUnlockValue)
+	EventQueryValidatorSetChange  = QueryForEvent(EventValidatorSetChangeValue)
 	EventQueryValidatorSetUpdates = QueryForEvent(EventValidatorSetUpdatesValue)
 	EventQueryValidBlock          = QueryForEvent(EventValidBlockValue)
 	EventQueryVote                = QueryForEvent(EventVoteValue)
@@ -236,6 +329,19 @@ func EventQueryTxFor(tx Tx) tmpubsub.Query {
 	return tmquery.MustCompile(fmt.Sprintf("%s='%s' AND %s='%X'", EventTypeKey, EventTxValue, TxHashKey, tx.Hash()))
 }
 
+// EventQueryValidatorSetChangeFor returns the query of the validator set
+// changes changing the validator with the given address.
+func EventQueryValidatorSetChangeFor(address Address) tmpubsub.Query {
+	return tmquery.MustCompile(fmt.Sprintf("%s='%s' AND %s='%s'",
+		EventTypeKey, EventValidatorSetChangeValue, ValidatorAddressKey, address))
+}
+
+// EventQueryConsensusHeight returns the query of all the consensus firehose
+// events of the given height.
+func EventQueryConsensusHeight(height int64) tmpubsub.Query {
+	return tmquery.MustCompile(fmt.Sprintf("%s=%d", ConsensusHeightKey, height))
+}
+
 func QueryForEvent(eventValue string) tmpubsub.Query {
 	return tmquery.MustCompile(fmt.Sprintf("%s='%s'", EventTypeKey, eventValue))
 }
@@ -247,6 +353,7 @@ type BlockEventPublisher interface {
 	PublishEventNewEvidence(ctx context.Context, evidence EventDataNewEvidence) error
 	PublishEventTx(context.Context, EventDataTx) error
 	PublishEventValidatorSetUpdates(context.Context, EventDataValidatorSetUpdates) error
+	PublishEventValidatorSetChange(context.Context, EventDataValidatorSetChange) error
 }
 
 type TxEventPublisher interface {
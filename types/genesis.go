@@ -2,9 +2,11 @@ package types
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"time"
 
@@ -42,6 +44,12 @@ type GenesisDoc struct {
 	Validators      []GenesisValidator `json:"validators,omitempty"`
 	AppHash         tmbytes.HexBytes   `json:"app_hash"`
 	AppState        json.RawMessage    `json:"app_state,omitempty"`
+
+	// appStateFile, if set, is the file the genesis doc was streamed from, in
+	// which the app state of appStateSize bytes starts at appStateOffset.
+	appStateFile   string
+	appStateOffset int64
+	appStateSize   int64
 }
 
 // SaveAs is a utility method for saving GenensisDoc as a JSON file.
@@ -54,6 +62,54 @@ func (genDoc *GenesisDoc) SaveAs(file string) error {
 	return os.WriteFile(file, genDocBytes, 0644) // nolint:gosec
 }
 
+// AppStateReader returns a reader of the JSON app state and its size in bytes.
+// The app state of a genesis doc streamed by StreamGenesisDocFromFile is read
+// from its file, which must not have changed. The reader must be closed.
+func (genDoc *GenesisDoc) AppStateReader() (io.ReadCloser, int64, error) {
+	if genDoc.appStateFile == "" {
+		return io.NopCloser(bytes.NewReader(genDoc.AppState)), int64(len(genDoc.AppState)), nil
+	}
+	f, err := os.Open(genDoc.appStateFile)
+	if err != nil {
+		return nil, 0, fmt.Errorf("couldn't open GenesisDoc file: %w", err)
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.NewSectionReader(f, genDoc.appStateOffset, genDoc.appStateSize), f}, genDoc.appStateSize, nil
+}
+
+// SourceFile returns the file the genesis doc was streamed from by
+// StreamGenesisDocFromFile, or "" if the genesis doc holds its app state.
+func (genDoc *GenesisDoc) SourceFile() string {
+	return genDoc.appStateFile
+}
+
+// Hash returns the SHA-256 hash of the genesis doc, which identifies it e.g.
+// to peers. The app state is hashed as is, after the canonical JSON encoding
+// of the other fields, so that it can be hashed from the file of a streamed
+// genesis doc.
+func (genDoc *GenesisDoc) Hash() ([]byte, error) {
+	doc := *genDoc
+	doc.AppState = nil
+	bz, err := tmjson.Marshal(&doc)
+	if err != nil {
+		return nil, err
+	}
+	appState, _, err := genDoc.AppStateReader()
+	if err != nil {
+		return nil, err
+	}
+	defer appState.Close()
+
+	hasher := sha256.New()
+	hasher.Write(bz)
+	if _, err := io.Copy(hasher, appState); err != nil {
+		return nil, fmt.Errorf("couldn't read app state: %w", err)
+	}
+	return hasher.Sum(nil), nil
+}
+
 // ValidatorHash returns the hash of the validator set contained in the GenesisDoc
 func (genDoc *GenesisDoc) ValidatorHash() []byte {
 	vals := make([]*Validator, len(genDoc.Validators))
@@ -90,6 +146,10 @@ func (genDoc *GenesisDoc) ValidateAndComplete() error {
 		if v.Power == 0 {
 			return fmt.Errorf("the genesis file cannot contain validators with no voting power: %v", v)
 		}
+		if !genDoc.ConsensusParams.Validator.IsValidPubkeyType(v.PubKey.Type()) {
+			return fmt.Errorf("validator %v in the genesis file has a %s key, which is not one of the validator key types in the consensus params: %v",
+				v, v.PubKey.Type(), genDoc.ConsensusParams.Validator.PubKeyTypes)
+		}
 		if len(v.Address) > 0 && !bytes.Equal(v.PubKey.Address(), v.Address) {
 			return fmt.Errorf("incorrect address for validator %v in the genesis file, should be %v", v, v.PubKey.Address())
 		}
@@ -135,3 +195,126 @@ func GenesisDocFromFile(genDocFile string) (*GenesisDoc, error) {
 	}
 	return genDoc, nil
 }
+
+// StreamGenesisDocFromFile reads a GenesisDoc from a JSON file like
+// GenesisDocFromFile, but parses the file as a stream and leaves the app state
+// in the file rather than loading it into memory, so that genesis files with
+// app states larger than memory can be loaded. AppState is only set if the
+// app state is a JSON scalar; use AppStateReader to read it.
+//
+// NOTE: SaveAs of the returned genesis doc omits the app state left in the file.
+func StreamGenesisDocFromFile(genDocFile string) (*GenesisDoc, error) {
+	f, err := os.Open(genDocFile)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read GenesisDoc file: %w", err)
+	}
+	defer f.Close()
+
+	genDoc, err := streamGenesisDoc(f)
+	if err != nil {
+		return nil, fmt.Errorf("error reading GenesisDoc at %s: %w", genDocFile, err)
+	}
+	if genDoc.appStateSize > 0 {
+		genDoc.appStateFile = genDocFile
+	}
+	return genDoc, nil
+}
+
+// streamGenesisDoc decodes a GenesisDoc from r, skipping over an app_state
+// object or array and recording its offset and size.
+func streamGenesisDoc(r io.Reader) (*GenesisDoc, error) {
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+
+	var (
+		fields       = map[string]json.RawMessage{}
+		appState     json.RawMessage
+		offset, size int64
+	)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected an object key, got %v", tok)
+		}
+		if key != "app_state" {
+			var value json.RawMessage
+			if err := dec.Decode(&value); err != nil {
+				return nil, fmt.Errorf("invalid %q: %w", key, err)
+			}
+			fields[key] = value
+			continue
+		}
+
+		appState, offset, size = nil, 0, 0
+		tok, err = dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("invalid app_state: %w", err)
+		}
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			// The delimiter is the last byte read by the decoder.
+			offset = dec.InputOffset() - 1
+			if err := skipValue(dec); err != nil {
+				return nil, fmt.Errorf("invalid app_state: %w", err)
+			}
+			size = dec.InputOffset() - offset
+		case nil:
+		default:
+			if appState, err = json.Marshal(tok); err != nil {
+				return nil, fmt.Errorf("invalid app_state: %w", err)
+			}
+		}
+	}
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, err
+	}
+
+	jsonBlob, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	genDoc, err := GenesisDocFromJSON(jsonBlob)
+	if err != nil {
+		return nil, err
+	}
+	genDoc.AppState = appState
+	genDoc.appStateOffset = offset
+	genDoc.appStateSize = size
+	return genDoc, nil
+}
+
+// expectDelim reads the next token of dec, which must be the given delimiter.
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != delim {
+		return fmt.Errorf("expected %v, got %v", delim, tok)
+	}
+	return nil
+}
+
+// skipValue reads the tokens of dec up to the end of the object or array whose
+// opening delimiter was just read.
+func skipValue(dec *json.Decoder) error {
+	for depth := 1; depth > 0; {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+	}
+	return nil
+}
@@ -1,13 +1,18 @@
 package types
 
 import (
+	"io"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/crypto/sr25519"
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	tmtime "github.com/tendermint/tendermint/libs/time"
 )
@@ -120,6 +125,32 @@ func TestGenesisGood(t *testing.T) {
 	}
 }
 
+func TestGenesisValidatorKeyTypes(t *testing.T) {
+	for _, pubkey := range []crypto.PubKey{
+		ed25519.GenPrivKey().PubKey(),
+		secp256k1.GenPrivKey().PubKey(),
+		sr25519.GenPrivKey().PubKey(),
+	} {
+		genDoc := &GenesisDoc{
+			ChainID:         "abc",
+			Validators:      []GenesisValidator{{pubkey.Address(), pubkey, 10, "myval"}},
+			ConsensusParams: DefaultConsensusParams(),
+		}
+		genDoc.ConsensusParams.Validator.PubKeyTypes = []string{pubkey.Type()}
+		genDocBytes, err := tmjson.Marshal(genDoc)
+		require.NoError(t, err)
+		genDoc, err = GenesisDocFromJSON(genDocBytes)
+		require.NoError(t, err, "expected no error for %s validator", pubkey.Type())
+		assert.Equal(t, pubkey, genDoc.Validators[0].PubKey)
+
+		// validators must have one of the key types of the consensus params
+		genDoc.ConsensusParams.Validator.PubKeyTypes = []string{ABCIPubKeyTypeEd25519}
+		if pubkey.Type() != ABCIPubKeyTypeEd25519 {
+			assert.Error(t, genDoc.ValidateAndComplete(), "expected error for %s validator", pubkey.Type())
+		}
+	}
+}
+
 func TestGenesisSaveAs(t *testing.T) {
 	tmpfile, err := os.CreateTemp("", "genesis")
 	require.NoError(t, err)
@@ -146,11 +177,92 @@ func TestGenesisSaveAs(t *testing.T) {
 	assert.Equal(t, genDoc2.Validators, genDoc.Validators)
 }
 
+func TestStreamGenesisDocFromFile(t *testing.T) {
+	genDoc := randomGenesisDoc()
+	genDoc.AppState = []byte(`{"accounts": [{"owner": "Bob", "coins": [1, 2]}], "note": "}]"}`)
+	genDocBytes, err := tmjson.MarshalIndent(genDoc, "", "  ")
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "genesis.json")
+	require.NoError(t, os.WriteFile(file, genDocBytes, 0644))
+
+	streamed, err := StreamGenesisDocFromFile(file)
+	require.NoError(t, err)
+	assert.Nil(t, streamed.AppState)
+	assert.Equal(t, file, streamed.SourceFile())
+	assert.Equal(t, genDoc.ChainID, streamed.ChainID)
+	assert.Equal(t, genDoc.InitialHeight, streamed.InitialHeight)
+	assert.Equal(t, genDoc.Validators, streamed.Validators)
+	assert.Equal(t, genDoc.AppHash, streamed.AppHash)
+
+	r, size, err := streamed.AppStateReader()
+	require.NoError(t, err)
+	appState, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.EqualValues(t, len(appState), size)
+	assert.JSONEq(t, string(genDoc.AppState), string(appState))
+
+	// scalar or missing app states are held by the genesis doc
+	for appState, expect := range map[string]string{
+		`"state"`: `"state"`,
+		`null`:    ``,
+	} {
+		jsonBlob := []byte(`{"chain_id": "abc", "app_state": ` + appState + `}`)
+		require.NoError(t, os.WriteFile(file, jsonBlob, 0644))
+		streamed, err := StreamGenesisDocFromFile(file)
+		require.NoError(t, err)
+		assert.Empty(t, streamed.SourceFile())
+		assert.Equal(t, expect, string(streamed.AppState))
+	}
+
+	// invalid genesis docs are rejected
+	for _, jsonBlob := range []string{
+		`{"chain_id": "abc", "app_state": {"a": [}}`,
+		`{"chain_id": "abc"`,
+		`{"app_state": {}}`,
+		`[]`,
+	} {
+		require.NoError(t, os.WriteFile(file, []byte(jsonBlob), 0644))
+		_, err := StreamGenesisDocFromFile(file)
+		assert.Error(t, err, jsonBlob)
+	}
+}
+
 func TestGenesisValidatorHash(t *testing.T) {
 	genDoc := randomGenesisDoc()
 	assert.NotEmpty(t, genDoc.ValidatorHash())
 }
 
+func TestGenesisDocHash(t *testing.T) {
+	genDoc := randomGenesisDoc()
+	genDoc.AppState = []byte(`{"accounts": [{"owner": "Bob", "coins": [1, 2]}]}`)
+	genDocBytes, err := tmjson.MarshalIndent(genDoc, "", "  ")
+	require.NoError(t, err)
+
+	file := filepath.Join(t.TempDir(), "genesis.json")
+	require.NoError(t, os.WriteFile(file, genDocBytes, 0644))
+
+	loaded, err := GenesisDocFromFile(file)
+	require.NoError(t, err)
+	hash, err := loaded.Hash()
+	require.NoError(t, err)
+	assert.Len(t, hash, 32)
+
+	// streaming the app state doesn't change the hash
+	streamed, err := StreamGenesisDocFromFile(file)
+	require.NoError(t, err)
+	streamedHash, err := streamed.Hash()
+	require.NoError(t, err)
+	assert.Equal(t, hash, streamedHash)
+
+	loaded.ChainID = "def"
+	otherHash, err := loaded.Hash()
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, otherHash)
+}
+
 func randomGenesisDoc() *GenesisDoc {
 	pubkey := ed25519.GenPrivKey().PubKey()
 	return &GenesisDoc{
@@ -9,6 +9,10 @@ import (
 // ErrTxInCache is returned to the client if we saw tx earlier
 var ErrTxInCache = errors.New("tx already exists in cache")
 
+// ErrTxRejected is returned to the client if the tx was removed from the
+// mempool by the node operator, who asked to reject it for some time.
+var ErrTxRejected = errors.New("tx was rejected by the node operator")
+
 // TxKey is the fixed length array key used as an index.
 type TxKey [sha256.Size]byte
 
@@ -1,20 +1,29 @@
 package types
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"net"
 	"strconv"
 	"strings"
 
-	"github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/encoding"
+	"github.com/tendermint/tendermint/internal/libs/protoio"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 	tmstrings "github.com/tendermint/tendermint/libs/strings"
 	tmp2p "github.com/tendermint/tendermint/proto/tendermint/p2p"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 )
 
 const (
 	maxNodeInfoSize = 10240 // 10KB
 	maxNumChannels  = 16    // plenty of room for upgrades, for now
+
+	maxNumCompressions   = 8
+	maxCompressionLength = 32
 )
 
 // Max size of the NodeInfo struct
@@ -45,11 +54,60 @@ type NodeInfo struct {
 	Network string `json:"network"` // network/chain ID
 	Version string `json:"version"` // major.minor.revision
 	// FIXME: This should be changed to uint16 to be consistent with the updated channel type
-	Channels bytes.HexBytes `json:"channels"` // channels this node knows about
+	Channels tmbytes.HexBytes `json:"channels"` // channels this node knows about
 
 	// ASCIIText fields
 	Moniker string        `json:"moniker"` // arbitrary moniker
 	Other   NodeInfoOther `json:"other"`   // other application specific data
+
+	// Proof that the node is operated by a validator, if any.
+	ValidatorProof *NodeValidatorProof `json:"validator_proof,omitempty"`
+
+	// ObservedAddr is the IP address the sender observed the receiver's
+	// connection from. Unlike the other fields it describes the receiver, and
+	// is only set in handshakes, so that nodes behind a NAT can learn their
+	// external address from their peers.
+	ObservedAddr string `json:"observed_addr,omitempty"`
+
+	// Compression lists the compression algorithms the node supports for p2p
+	// messages, in order of preference. Names unknown to the receiver are
+	// ignored, so that algorithms can be added.
+	Compression []string `json:"compression,omitempty"`
+
+	// Capabilities are the optional protocol extensions the node supports, so
+	// that reactors can detect whether a peer supports an extension instead of
+	// relying on the P2P protocol version. Unknown flags are ignored.
+	Capabilities NodeCapabilities `json:"capabilities,omitempty"`
+
+	// GenesisHash is the hash of the node's genesis doc, see GenesisDoc.Hash.
+	// Nodes on the same network but a different genesis are incompatible.
+	// Empty for nodes which don't report it.
+	GenesisHash tmbytes.HexBytes `json:"genesis_hash,omitempty"`
+}
+
+// NodeCapabilities is a bitfield of the optional protocol extensions a node
+// supports.
+type NodeCapabilities uint64
+
+const (
+	// CapabilityCompactBlocks: the node reconstructs proposal blocks from
+	// compact blocks.
+	CapabilityCompactBlocks NodeCapabilities = 1 << iota
+	// CapabilityCompression: the node compresses p2p messages, see
+	// NodeInfo.Compression.
+	CapabilityCompression
+	// CapabilityTxAnnouncements: the node announces the keys of mempool
+	// transactions and requests those it is missing, instead of flooding the
+	// transactions.
+	CapabilityTxAnnouncements
+	// CapabilityVoteBatches: the node handles batches of votes for the same
+	// block ID, sent in place of the votes.
+	CapabilityVoteBatches
+)
+
+// Has returns whether all the given capabilities are set.
+func (c NodeCapabilities) Has(capabilities NodeCapabilities) bool {
+	return c&capabilities == capabilities
 }
 
 // NodeInfoOther is the misc. applcation specific data
@@ -58,6 +116,44 @@ type NodeInfoOther struct {
 	RPCAddress string `json:"rpc_address"`
 }
 
+// NodeValidatorProof proves that a node is operated by the holder of a
+// validator key. It is exchanged during the P2P handshake, so that peers can
+// prioritize connections to validators.
+type NodeValidatorProof struct {
+	PubKey    crypto.PubKey    `json:"pub_key"`
+	Signature tmbytes.HexBytes `json:"signature"`
+}
+
+// NodeValidatorProofSignBytes returns the bytes a validator signs to prove
+// that it operates the node with the given ID on the given chain: the
+// length-prefixed encoding of the CanonicalNodeValidatorProof.
+//
+// Panics if the marshaling fails.
+func NodeValidatorProofSignBytes(chainID string, nodeID NodeID) []byte {
+	pb := tmproto.CanonicalNodeValidatorProof{
+		NodeID:  string(nodeID),
+		ChainID: chainID,
+	}
+	bz, err := protoio.MarshalDelimited(&pb)
+	if err != nil {
+		panic(err)
+	}
+
+	return bz
+}
+
+// Verify checks that the proof is signed by its public key for the given
+// chain and node ID.
+func (p *NodeValidatorProof) Verify(chainID string, nodeID NodeID) error {
+	if p.PubKey == nil {
+		return errors.New("missing public key")
+	}
+	if !p.PubKey.VerifySignature(NodeValidatorProofSignBytes(chainID, nodeID), p.Signature) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
 // ID returns the node's peer ID.
 func (info NodeInfo) ID() NodeID {
 	return info.NodeID
@@ -120,6 +216,33 @@ func (info NodeInfo) Validate() error {
 		return fmt.Errorf("info.Other.RPCAddress=%v must be valid ASCII text without tabs", rpcAddr)
 	}
 
+	// Validate ValidatorProof.
+	if info.ValidatorProof != nil {
+		if err := info.ValidatorProof.Verify(info.Network, info.NodeID); err != nil {
+			return fmt.Errorf("info.ValidatorProof is invalid: %w", err)
+		}
+	}
+
+	// Validate ObservedAddr.
+	if info.ObservedAddr != "" && net.ParseIP(info.ObservedAddr) == nil {
+		return fmt.Errorf("info.ObservedAddr must be an IP address, but got %q", info.ObservedAddr)
+	}
+
+	// Validate Compression.
+	if len(info.Compression) > maxNumCompressions {
+		return fmt.Errorf("info.Compression is too long (%v). Max is %v", len(info.Compression), maxNumCompressions)
+	}
+	for _, c := range info.Compression {
+		if len(c) > maxCompressionLength || !tmstrings.IsASCIIText(c) {
+			return fmt.Errorf("info.Compression must contain short ASCII names, but got %q", c)
+		}
+	}
+
+	// Validate GenesisHash.
+	if len(info.GenesisHash) != 0 && len(info.GenesisHash) != sha256.Size {
+		return fmt.Errorf("info.GenesisHash must be %d bytes, but got %d", sha256.Size, len(info.GenesisHash))
+	}
+
 	return nil
 }
 
@@ -137,6 +260,12 @@ func (info NodeInfo) CompatibleWith(other NodeInfo) error {
 		return fmt.Errorf("peer is on a different network. Got %v, expected %v", other.Network, info.Network)
 	}
 
+	// and on the same genesis, if both report it
+	if len(info.GenesisHash) > 0 && len(other.GenesisHash) > 0 &&
+		!bytes.Equal(info.GenesisHash, other.GenesisHash) {
+		return fmt.Errorf("peer is on a different genesis. Got %v, expected %v", other.GenesisHash, info.GenesisHash)
+	}
+
 	// if we have no channels, we're just testing
 	if len(info.Channels) == 0 {
 		return nil
@@ -181,6 +310,11 @@ func (info NodeInfo) Copy() NodeInfo {
 		Channels:        info.Channels,
 		Moniker:         info.Moniker,
 		Other:           info.Other,
+		ValidatorProof:  info.ValidatorProof,
+		ObservedAddr:    info.ObservedAddr,
+		Compression:     info.Compression,
+		Capabilities:    info.Capabilities,
+		GenesisHash:     info.GenesisHash,
 	}
 }
 
@@ -199,11 +333,25 @@ func (info NodeInfo) ToProto() *tmp2p.NodeInfo {
 	dni.Version = info.Version
 	dni.Channels = info.Channels
 	dni.Moniker = info.Moniker
+	dni.ObservedAddr = info.ObservedAddr
+	dni.Compression = info.Compression
+	dni.Capabilities = uint64(info.Capabilities)
+	dni.GenesisHash = info.GenesisHash
 	dni.Other = tmp2p.NodeInfoOther{
 		TxIndex:    info.Other.TxIndex,
 		RPCAddress: info.Other.RPCAddress,
 	}
 
+	if info.ValidatorProof != nil {
+		pk, err := encoding.PubKeyToProto(info.ValidatorProof.PubKey)
+		if err == nil {
+			dni.ValidatorProof = &tmp2p.ValidatorProof{
+				PubKey:    pk,
+				Signature: info.ValidatorProof.Signature,
+			}
+		}
+	}
+
 	return dni
 }
 
@@ -227,6 +375,21 @@ func NodeInfoFromProto(pb *tmp2p.NodeInfo) (NodeInfo, error) {
 			TxIndex:    pb.Other.TxIndex,
 			RPCAddress: pb.Other.RPCAddress,
 		},
+		ObservedAddr: pb.ObservedAddr,
+		Compression:  pb.Compression,
+		Capabilities: NodeCapabilities(pb.Capabilities),
+		GenesisHash:  pb.GenesisHash,
+	}
+
+	if pb.ValidatorProof != nil {
+		pk, err := encoding.PubKeyFromProto(pb.ValidatorProof.PubKey)
+		if err != nil {
+			return NodeInfo{}, fmt.Errorf("invalid validator proof: %w", err)
+		}
+		dni.ValidatorProof = &NodeValidatorProof{
+			PubKey:    pk,
+			Signature: pb.ValidatorProof.Signature,
+		}
 	}
 
 	return dni, nil
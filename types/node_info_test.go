@@ -1,7 +1,10 @@
 package types
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -69,6 +72,16 @@ func TestNodeInfoValidate(t *testing.T) {
 		{"Empty space RPCAddress", func(ni *NodeInfo) { ni.Other.RPCAddress = emptySpace }, true},
 		{"Empty RPCAddress", func(ni *NodeInfo) { ni.Other.RPCAddress = "" }, false},
 		{"Good RPCAddress", func(ni *NodeInfo) { ni.Other.RPCAddress = "0.0.0.0:26657" }, false},
+
+		{"Invalid ObservedAddr", func(ni *NodeInfo) { ni.ObservedAddr = "1.2.3.4:26656" }, true},
+		{"Good ObservedAddr", func(ni *NodeInfo) { ni.ObservedAddr = "1.2.3.4" }, false},
+
+		{"Good Compression", func(ni *NodeInfo) { ni.Compression = []string{"zstd", "snappy", "unknown"} }, false},
+		{"Too Many Compressions", func(ni *NodeInfo) { ni.Compression = make([]string, maxNumCompressions+1) }, true},
+		{"Long Compression", func(ni *NodeInfo) { ni.Compression = []string{strings.Repeat("a", maxCompressionLength+1)} }, true},
+
+		{"Good GenesisHash", func(ni *NodeInfo) { ni.GenesisHash = make([]byte, 32) }, false},
+		{"Short GenesisHash", func(ni *NodeInfo) { ni.GenesisHash = make([]byte, 20) }, true},
 	}
 
 	nodeKeyID := testNodeID()
@@ -145,6 +158,10 @@ func TestNodeInfoCompatible(t *testing.T) {
 	ni2.Channels = []byte{newTestChannel, testCh}
 	assert.NoError(t, ni1.CompatibleWith(ni2))
 
+	// the genesis hash is only compared if both report it
+	ni1.GenesisHash = make([]byte, 32)
+	assert.NoError(t, ni1.CompatibleWith(ni2))
+
 	testCases := []struct {
 		testName         string
 		malleateNodeInfo func(*NodeInfo)
@@ -152,6 +169,7 @@ func TestNodeInfoCompatible(t *testing.T) {
 		{"Wrong block version", func(ni *NodeInfo) { ni.ProtocolVersion.Block++ }},
 		{"Wrong network", func(ni *NodeInfo) { ni.Network += "-wrong" }},
 		{"No common channels", func(ni *NodeInfo) { ni.Channels = []byte{newTestChannel} }},
+		{"Wrong genesis", func(ni *NodeInfo) { ni.GenesisHash = bytes.Repeat([]byte{1}, 32) }},
 	}
 
 	for _, tc := range testCases {
@@ -174,6 +192,73 @@ func TestNodeInfoAddChannel(t *testing.T) {
 	require.Contains(t, nodeInfo.Channels, byte(0x02))
 }
 
+func TestNodeInfoValidatorProof(t *testing.T) {
+	ctx := context.Background()
+	pv := NewMockPV()
+	ni := testNodeInfo(testNodeID(), "testing")
+
+	proof, err := pv.SignNodeValidatorProof(ctx, ni.Network, ni.NodeID)
+	require.NoError(t, err)
+	ni.ValidatorProof = proof
+	require.NoError(t, ni.Validate())
+
+	// the proof survives a round trip through protobuf
+	pbni, err := NodeInfoFromProto(ni.ToProto())
+	require.NoError(t, err)
+	require.Equal(t, ni, pbni)
+	require.NoError(t, pbni.Validate())
+
+	// the proof is bound to the chain and node ID
+	other := ni
+	other.Network = "other-chain"
+	assert.Error(t, other.Validate())
+	other = ni
+	other.NodeID = testNodeID()
+	assert.Error(t, other.Validate())
+
+	// the proof must be signed by its own key
+	other = ni
+	other.ValidatorProof = &NodeValidatorProof{
+		PubKey:    ed25519.GenPrivKey().PubKey(),
+		Signature: proof.Signature,
+	}
+	assert.Error(t, other.Validate())
+}
+
+func TestNodeValidatorProofSignBytes(t *testing.T) {
+	nodeID := NodeID("0123456789abcdef0123456789abcdef01234567")
+	want := append([]byte{
+		0x33, // length
+		0xa,  // (field_number << 3) | wire_type
+		0x28, // node ID length
+	}, nodeID...)
+	want = append(want,
+		0x12, // (field_number << 3) | wire_type
+		0x7,  // chain ID length
+	)
+	want = append(want, "testing"...)
+	require.Equal(t, want, NodeValidatorProofSignBytes("testing", nodeID))
+}
+
+func TestNodeInfoCapabilities(t *testing.T) {
+	ni := testNodeInfo(testNodeID(), "testing")
+	assert.False(t, ni.Capabilities.Has(CapabilityCompactBlocks))
+
+	ni.Capabilities = CapabilityCompactBlocks | CapabilityTxAnnouncements
+	assert.True(t, ni.Capabilities.Has(CapabilityCompactBlocks))
+	assert.True(t, ni.Capabilities.Has(CapabilityCompactBlocks|CapabilityTxAnnouncements))
+	assert.False(t, ni.Capabilities.Has(CapabilityCompression))
+	assert.False(t, ni.Capabilities.Has(CapabilityCompactBlocks|CapabilityCompression))
+
+	// the capabilities survive a round trip through protobuf, including flags
+	// unknown to this version
+	ni.Capabilities |= 1 << 63
+	pbni, err := NodeInfoFromProto(ni.ToProto())
+	require.NoError(t, err)
+	require.Equal(t, ni, pbni)
+	require.Equal(t, ni, ni.Copy())
+}
+
 func TestParseAddressString(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -17,11 +17,16 @@ const (
 	// MaxBlockSizeBytes is the maximum permitted size of the blocks.
 	MaxBlockSizeBytes = 104857600 // 100MB
 
-	// BlockPartSizeBytes is the size of one block part.
+	// BlockPartSizeBytes is the default size of one block part.
 	BlockPartSizeBytes uint32 = 65536 // 64kB
 
+	// MinBlockPartSizeBytes and MaxBlockPartSizeBytes bound the size of one
+	// block part.
+	MinBlockPartSizeBytes uint32 = 4096   // 4kB
+	MaxBlockPartSizeBytes uint32 = 524288 // 512kB
+
 	// MaxBlockPartsCount is the maximum number of block parts.
-	MaxBlockPartsCount = (MaxBlockSizeBytes / BlockPartSizeBytes) + 1
+	MaxBlockPartsCount = (MaxBlockSizeBytes / MinBlockPartSizeBytes) + 1
 
 	ABCIPubKeyTypeEd25519   = ed25519.KeyType
 	ABCIPubKeyTypeSecp256k1 = secp256k1.KeyType
@@ -41,6 +46,7 @@ type ConsensusParams struct {
 	Evidence  EvidenceParams  `json:"evidence"`
 	Validator ValidatorParams `json:"validator"`
 	Version   VersionParams   `json:"version"`
+	ABCI      ABCIParams      `json:"abci"`
 }
 
 // HashedParams is a subset of ConsensusParams.
@@ -51,11 +57,21 @@ type HashedParams struct {
 	BlockMaxGas   int64
 }
 
-// BlockParams define limits on the block size and gas plus minimum time
-// between blocks.
+// BlockParams define limits on the block size and gas, and how blocks are
+// split into parts.
 type BlockParams struct {
 	MaxBytes int64 `json:"max_bytes"`
 	MaxGas   int64 `json:"max_gas"`
+
+	// Size of the parts blocks are split into. If 0, BlockPartSizeBytes.
+	PartSizeBytes int64 `json:"part_size_bytes"`
+	// If true, block parts are extended with as many erasure-coded parity
+	// parts, so that a block can be reconstructed from any half of its parts.
+	ErasureCoding bool `json:"erasure_coding"`
+	// Max total gas wanted by the txs of a block, as reported by CheckTx,
+	// distinct from MaxGas for applications whose txs want more gas than they
+	// use. If 0, only MaxGas bounds it.
+	MaxGasWanted int64 `json:"max_gas_wanted"`
 }
 
 // EvidenceParams determine how we handle evidence of malfeasance.
@@ -65,16 +81,27 @@ type EvidenceParams struct {
 	MaxBytes        int64         `json:"max_bytes"`
 }
 
-// ValidatorParams restrict the public key types validators can use.
+// ValidatorParams restrict the public key types validators can use, and
+// select how the proposer of each round is chosen among them.
 // NOTE: uses ABCI pubkey naming, not Amino names.
 type ValidatorParams struct {
 	PubKeyTypes []string `json:"pub_key_types"`
+	// Name of the registered ProposerSelector choosing the proposer of each
+	// round. If empty, ProposerSelectionPriority.
+	ProposerSelection string `json:"proposer_selection"`
 }
 
 type VersionParams struct {
 	AppVersion uint64 `json:"app_version"`
 }
 
+// ABCIParams configure how the results of the application are committed to.
+type ABCIParams struct {
+	// HashEvents commits to the events of each block in the LastEventsHash of
+	// the next header, so that light clients can verify them.
+	HashEvents bool `json:"hash_events"`
+}
+
 // DefaultConsensusParams returns a default ConsensusParams.
 func DefaultConsensusParams() *ConsensusParams {
 	return &ConsensusParams{
@@ -82,15 +109,36 @@ func DefaultConsensusParams() *ConsensusParams {
 		Evidence:  DefaultEvidenceParams(),
 		Validator: DefaultValidatorParams(),
 		Version:   DefaultVersionParams(),
+		ABCI:      DefaultABCIParams(),
 	}
 }
 
 // DefaultBlockParams returns a default BlockParams.
 func DefaultBlockParams() BlockParams {
 	return BlockParams{
-		MaxBytes: 22020096, // 21MB
-		MaxGas:   -1,
+		MaxBytes:      22020096, // 21MB
+		MaxGas:        -1,
+		PartSizeBytes: int64(BlockPartSizeBytes),
+		ErasureCoding: false,
+		MaxGasWanted:  0,
+	}
+}
+
+// PartSize returns the size of the parts blocks are split into.
+func (params BlockParams) PartSize() uint32 {
+	if params.PartSizeBytes == 0 {
+		return BlockPartSizeBytes
 	}
+	return uint32(params.PartSizeBytes)
+}
+
+// GasWantedLimit returns the max total gas wanted by the txs of a block, or
+// -1 if MaxGasWanted doesn't bound it.
+func (params BlockParams) GasWantedLimit() int64 {
+	if params.MaxGasWanted == 0 {
+		return -1
+	}
+	return params.MaxGasWanted
 }
 
 // DefaultEvidenceParams returns a default EvidenceParams.
@@ -116,6 +164,14 @@ func DefaultVersionParams() VersionParams {
 	}
 }
 
+// DefaultABCIParams returns a default ABCIParams, which does not commit to
+// events.
+func DefaultABCIParams() ABCIParams {
+	return ABCIParams{
+		HashEvents: false,
+	}
+}
+
 func (val *ValidatorParams) IsValidPubkeyType(pubkeyType string) bool {
 	for i := 0; i < len(val.PubKeyTypes); i++ {
 		if val.PubKeyTypes[i] == pubkeyType {
@@ -125,6 +181,16 @@ func (val *ValidatorParams) IsValidPubkeyType(pubkeyType string) bool {
 	return false
 }
 
+// ProposerSelector returns the algorithm selecting the proposer of each
+// round, which is registered if the params are valid.
+func (val *ValidatorParams) ProposerSelector() ProposerSelector {
+	selector, ok := GetProposerSelector(val.ProposerSelection)
+	if !ok {
+		panic(fmt.Sprintf("unknown proposer selection algorithm %q", val.ProposerSelection))
+	}
+	return selector
+}
+
 // Validate validates the ConsensusParams to ensure all values are within their
 // allowed limits, and returns an error if they are not.
 func (params ConsensusParams) ValidateConsensusParams() error {
@@ -142,6 +208,23 @@ func (params ConsensusParams) ValidateConsensusParams() error {
 			params.Block.MaxGas)
 	}
 
+	if params.Block.MaxGasWanted < 0 {
+		return fmt.Errorf("block.MaxGasWanted must be greater or equal to 0. Got %d",
+			params.Block.MaxGasWanted)
+	}
+
+	if params.Block.PartSizeBytes != 0 &&
+		(params.Block.PartSizeBytes < int64(MinBlockPartSizeBytes) ||
+			params.Block.PartSizeBytes > int64(MaxBlockPartSizeBytes)) {
+		return fmt.Errorf("block.PartSizeBytes must be 0 or between %d and %d. Got %d",
+			MinBlockPartSizeBytes, MaxBlockPartSizeBytes, params.Block.PartSizeBytes)
+	}
+
+	if params.Block.ErasureCoding && params.Block.MaxBytes > MaxErasureCodedBlockBytes {
+		return fmt.Errorf("block.MaxBytes is too big for erasure coding. %d > %d",
+			params.Block.MaxBytes, MaxErasureCodedBlockBytes)
+	}
+
 	if params.Evidence.MaxAgeNumBlocks <= 0 {
 		return fmt.Errorf("evidence.MaxAgeNumBlocks must be greater than 0. Got %d",
 			params.Evidence.MaxAgeNumBlocks)
@@ -175,6 +258,11 @@ func (params ConsensusParams) ValidateConsensusParams() error {
 		}
 	}
 
+	if _, ok := GetProposerSelector(params.Validator.ProposerSelection); !ok {
+		return fmt.Errorf("params.Validator.ProposerSelection, %s, is an unknown proposer selection algorithm",
+			params.Validator.ProposerSelection)
+	}
+
 	return nil
 }
 
@@ -205,7 +293,9 @@ func (params ConsensusParams) HashConsensusParams() []byte {
 func (params *ConsensusParams) Equals(params2 *ConsensusParams) bool {
 	return params.Block == params2.Block &&
 		params.Evidence == params2.Evidence &&
-		tmstrings.StringSliceEqual(params.Validator.PubKeyTypes, params2.Validator.PubKeyTypes)
+		params.ABCI == params2.ABCI &&
+		tmstrings.StringSliceEqual(params.Validator.PubKeyTypes, params2.Validator.PubKeyTypes) &&
+		params.Validator.ProposerSelection == params2.Validator.ProposerSelection
 }
 
 // Update returns a copy of the params with updates from the non-zero fields of p2.
@@ -221,6 +311,9 @@ func (params ConsensusParams) UpdateConsensusParams(params2 *tmproto.ConsensusPa
 	if params2.Block != nil {
 		res.Block.MaxBytes = params2.Block.MaxBytes
 		res.Block.MaxGas = params2.Block.MaxGas
+		res.Block.PartSizeBytes = params2.Block.PartSizeBytes
+		res.Block.ErasureCoding = params2.Block.ErasureCoding
+		res.Block.MaxGasWanted = params2.Block.MaxGasWanted
 	}
 	if params2.Evidence != nil {
 		res.Evidence.MaxAgeNumBlocks = params2.Evidence.MaxAgeNumBlocks
@@ -231,18 +324,25 @@ func (params ConsensusParams) UpdateConsensusParams(params2 *tmproto.ConsensusPa
 		// Copy params2.Validator.PubkeyTypes, and set result's value to the copy.
 		// This avoids having to initialize the slice to 0 values, and then write to it again.
 		res.Validator.PubKeyTypes = append([]string{}, params2.Validator.PubKeyTypes...)
+		res.Validator.ProposerSelection = params2.Validator.ProposerSelection
 	}
 	if params2.Version != nil {
 		res.Version.AppVersion = params2.Version.AppVersion
 	}
+	if params2.ABCI != nil {
+		res.ABCI.HashEvents = params2.ABCI.HashEvents
+	}
 	return res
 }
 
 func (params *ConsensusParams) ToProto() tmproto.ConsensusParams {
 	return tmproto.ConsensusParams{
 		Block: &tmproto.BlockParams{
-			MaxBytes: params.Block.MaxBytes,
-			MaxGas:   params.Block.MaxGas,
+			MaxBytes:      params.Block.MaxBytes,
+			MaxGas:        params.Block.MaxGas,
+			PartSizeBytes: params.Block.PartSizeBytes,
+			ErasureCoding: params.Block.ErasureCoding,
+			MaxGasWanted:  params.Block.MaxGasWanted,
 		},
 		Evidence: &tmproto.EvidenceParams{
 			MaxAgeNumBlocks: params.Evidence.MaxAgeNumBlocks,
@@ -250,19 +350,26 @@ func (params *ConsensusParams) ToProto() tmproto.ConsensusParams {
 			MaxBytes:        params.Evidence.MaxBytes,
 		},
 		Validator: &tmproto.ValidatorParams{
-			PubKeyTypes: params.Validator.PubKeyTypes,
+			PubKeyTypes:       params.Validator.PubKeyTypes,
+			ProposerSelection: params.Validator.ProposerSelection,
 		},
 		Version: &tmproto.VersionParams{
 			AppVersion: params.Version.AppVersion,
 		},
+		ABCI: &tmproto.ABCIParams{
+			HashEvents: params.ABCI.HashEvents,
+		},
 	}
 }
 
 func ConsensusParamsFromProto(pbParams tmproto.ConsensusParams) ConsensusParams {
 	return ConsensusParams{
 		Block: BlockParams{
-			MaxBytes: pbParams.Block.MaxBytes,
-			MaxGas:   pbParams.Block.MaxGas,
+			MaxBytes:      pbParams.Block.MaxBytes,
+			MaxGas:        pbParams.Block.MaxGas,
+			PartSizeBytes: pbParams.Block.PartSizeBytes,
+			ErasureCoding: pbParams.Block.ErasureCoding,
+			MaxGasWanted:  pbParams.Block.MaxGasWanted,
 		},
 		Evidence: EvidenceParams{
 			MaxAgeNumBlocks: pbParams.Evidence.MaxAgeNumBlocks,
@@ -270,10 +377,15 @@ func ConsensusParamsFromProto(pbParams tmproto.ConsensusParams) ConsensusParams
 			MaxBytes:        pbParams.Evidence.MaxBytes,
 		},
 		Validator: ValidatorParams{
-			PubKeyTypes: pbParams.Validator.PubKeyTypes,
+			PubKeyTypes:       pbParams.Validator.PubKeyTypes,
+			ProposerSelection: pbParams.Validator.ProposerSelection,
 		},
 		Version: VersionParams{
 			AppVersion: pbParams.Version.AppVersion,
 		},
+		ABCI: ABCIParams{
+			// the params of chains which predate ABCIParams have none
+			HashEvents: pbParams.GetABCI().GetHashEvents(),
+		},
 	}
 }
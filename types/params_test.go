@@ -50,6 +50,59 @@ func TestConsensusParamsValidation(t *testing.T) {
 	}
 }
 
+func TestConsensusParamsValidation_BlockParts(t *testing.T) {
+	testCases := []struct {
+		partSize      int64
+		erasureCoding bool
+		maxBytes      int64
+		valid         bool
+	}{
+		{0, false, 100 * 1024 * 1024, true},
+		{int64(MinBlockPartSizeBytes), false, 1024, true},
+		{int64(MaxBlockPartSizeBytes), false, 1024, true},
+		{int64(MinBlockPartSizeBytes) - 1, false, 1024, false},
+		{int64(MaxBlockPartSizeBytes) + 1, false, 1024, false},
+		{0, true, MaxErasureCodedBlockBytes, true},
+		{0, true, MaxErasureCodedBlockBytes + 1, false},
+	}
+	for i, tc := range testCases {
+		params := makeParams(tc.maxBytes, 0, 2, 0, valEd25519)
+		params.Block.PartSizeBytes = tc.partSize
+		params.Block.ErasureCoding = tc.erasureCoding
+		if tc.valid {
+			assert.NoErrorf(t, params.ValidateConsensusParams(), "expected no error for valid params (#%d)", i)
+		} else {
+			assert.Errorf(t, params.ValidateConsensusParams(), "expected error for non valid params (#%d)", i)
+		}
+	}
+}
+
+func TestConsensusParamsValidation_MaxGasWanted(t *testing.T) {
+	params := makeParams(1024, 100, 2, 0, valEd25519)
+	assert.NoError(t, params.ValidateConsensusParams())
+	assert.EqualValues(t, -1, params.Block.GasWantedLimit())
+
+	params.Block.MaxGasWanted = 200
+	assert.NoError(t, params.ValidateConsensusParams())
+	assert.EqualValues(t, 200, params.Block.GasWantedLimit())
+
+	params.Block.MaxGasWanted = -1
+	assert.Error(t, params.ValidateConsensusParams())
+}
+
+func TestConsensusParamsValidation_ProposerSelection(t *testing.T) {
+	params := makeParams(1024, 100, 2, 0, valEd25519)
+	assert.NoError(t, params.ValidateConsensusParams())
+	assert.Equal(t, PriorityProposerSelector{}, params.Validator.ProposerSelector())
+
+	params.Validator.ProposerSelection = ProposerSelectionWeightedRandom
+	assert.NoError(t, params.ValidateConsensusParams())
+	assert.Equal(t, WeightedRandomProposerSelector{}, params.Validator.ProposerSelector())
+
+	params.Validator.ProposerSelection = "unregistered"
+	assert.Error(t, params.ValidateConsensusParams())
+}
+
 func makeParams(
 	blockBytes, blockGas int64,
 	evidenceAge int64,
@@ -165,6 +218,16 @@ func TestConsensusParamsUpdate_AppVersion(t *testing.T) {
 	assert.EqualValues(t, 1, updated.Version.AppVersion)
 }
 
+func TestConsensusParamsUpdate_MaxGasWanted(t *testing.T) {
+	params := makeParams(1, 2, 3, 0, valEd25519)
+
+	updated := params.UpdateConsensusParams(
+		&tmproto.ConsensusParams{Block: &tmproto.BlockParams{MaxBytes: 1, MaxGas: 2, MaxGasWanted: 4}})
+
+	assert.EqualValues(t, 4, updated.Block.MaxGasWanted)
+	assert.EqualValues(t, 0, params.Block.MaxGasWanted)
+}
+
 func TestProto(t *testing.T) {
 	params := []ConsensusParams{
 		makeParams(4, 2, 3, 1, valEd25519),
@@ -177,6 +240,9 @@ func TestProto(t *testing.T) {
 		makeParams(4, 6, 5, 1, valEd25519),
 	}
 
+	params[0].Block.MaxGasWanted = 10
+	params[1].Validator.ProposerSelection = ProposerSelectionWeightedRandom
+
 	for i := range params {
 		pbParams := params[i].ToProto()
 
@@ -2,12 +2,15 @@ package types
 
 import (
 	"bytes"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
 	"sync"
+	"testing/iotest"
 
 	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/internal/libs/erasure"
 	"github.com/tendermint/tendermint/libs/bits"
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 	tmjson "github.com/tendermint/tendermint/libs/json"
@@ -16,8 +19,24 @@ import (
 )
 
 var (
-	ErrPartSetUnexpectedIndex = errors.New("error part set unexpected index")
-	ErrPartSetInvalidProof    = errors.New("error part set invalid proof")
+	ErrPartSetUnexpectedIndex     = errors.New("error part set unexpected index")
+	ErrPartSetInvalidProof        = errors.New("error part set invalid proof")
+	ErrPartSetInvalidErasureTotal = errors.New("error part set invalid total of erasure-coded parts")
+)
+
+const (
+	// MaxErasureDataParts is the maximum number of data parts of an
+	// erasure-coded part set, which are followed by as many parity parts.
+	MaxErasureDataParts = erasure.MaxShards / 2
+
+	// MaxErasureCodedBlockBytes is the maximum size of the blocks whose parts
+	// can be erasure-coded.
+	MaxErasureCodedBlockBytes = MaxErasureDataParts*int64(MaxBlockPartSizeBytes) - erasureHeaderSize
+
+	// The data of erasure-coded part sets is framed with a marker byte and
+	// its length, as the last data part is padded.
+	erasureMagic      = 0x00
+	erasureHeaderSize = 9
 )
 
 type Part struct {
@@ -28,8 +47,8 @@ type Part struct {
 
 // ValidateBasic performs basic validation.
 func (part *Part) ValidateBasic() error {
-	if len(part.Bytes) > int(BlockPartSizeBytes) {
-		return fmt.Errorf("too big: %d bytes, max: %d", len(part.Bytes), BlockPartSizeBytes)
+	if len(part.Bytes) > int(MaxBlockPartSizeBytes) {
+		return fmt.Errorf("too big: %d bytes, max: %d", len(part.Bytes), MaxBlockPartSizeBytes)
 	}
 	if err := part.Proof.ValidateBasic(); err != nil {
 		return fmt.Errorf("wrong Proof: %w", err)
@@ -151,6 +170,11 @@ type PartSet struct {
 	total uint32
 	hash  []byte
 
+	// The number of data parts, followed by as many parity parts, if the
+	// part set is erasure-coded, or 0.
+	dataParts       uint32
+	reconstructFail bool // whether reconstructing from the data parts failed
+
 	mtx           sync.Mutex
 	parts         []*Part
 	partsBitArray *bits.BitArray
@@ -193,6 +217,54 @@ func NewPartSetFromData(data []byte, partSize uint32) *PartSet {
 	}
 }
 
+// NewErasurePartSetFromData returns an immutable, full, erasure-coded PartSet
+// from the data bytes. The data bytes are framed with their length and split
+// into data parts of partSize bytes, or more if needed to keep within
+// MaxErasureDataParts parts, the last one padded. As many parity parts follow,
+// so that the data can be reconstructed from any half of the parts.
+// CONTRACT: partSize is greater than zero.
+func NewErasurePartSetFromData(data []byte, partSize uint32) *PartSet {
+	framed := make([]byte, erasureHeaderSize+len(data))
+	framed[0] = erasureMagic
+	binary.BigEndian.PutUint64(framed[1:erasureHeaderSize], uint64(len(data)))
+	copy(framed[erasureHeaderSize:], data)
+
+	dataParts := (len(framed) + int(partSize) - 1) / int(partSize)
+	if dataParts > MaxErasureDataParts {
+		partSize = uint32((len(framed) + MaxErasureDataParts - 1) / MaxErasureDataParts)
+		dataParts = (len(framed) + int(partSize) - 1) / int(partSize)
+	}
+
+	shards := make([][]byte, 2*dataParts)
+	for i := 0; i < dataParts; i++ {
+		shards[i] = make([]byte, partSize)
+		copy(shards[i], framed[i*int(partSize):tmmath.MinInt(len(framed), (i+1)*int(partSize))])
+	}
+	parity, err := erasure.Encode(shards[:dataParts], dataParts)
+	if err != nil {
+		panic(err)
+	}
+	copy(shards[dataParts:], parity)
+
+	total := uint32(len(shards))
+	parts := make([]*Part, total)
+	partsBitArray := bits.NewBitArray(int(total))
+	root, proofs := merkle.ProofsFromByteSlices(shards)
+	for i := uint32(0); i < total; i++ {
+		parts[i] = &Part{Index: i, Bytes: shards[i], Proof: *proofs[i]}
+		partsBitArray.SetIndex(int(i), true)
+	}
+	return &PartSet{
+		total:         total,
+		hash:          root,
+		dataParts:     uint32(dataParts),
+		parts:         parts,
+		partsBitArray: partsBitArray,
+		count:         total,
+		byteSize:      int64(len(data)),
+	}
+}
+
 // Returns an empty PartSet ready to be populated.
 func NewPartSetFromHeader(header PartSetHeader) *PartSet {
 	return &PartSet{
@@ -205,6 +277,17 @@ func NewPartSetFromHeader(header PartSetHeader) *PartSet {
 	}
 }
 
+// NewPartSetFromHeaderWithParams returns an empty PartSet ready to be
+// populated with the parts of a block split as configured by the block
+// parameters (see Block.MakePartSetWithParams).
+func NewPartSetFromHeaderWithParams(header PartSetHeader, params BlockParams) *PartSet {
+	ps := NewPartSetFromHeader(header)
+	if params.ErasureCoding {
+		ps.dataParts = header.Total / 2
+	}
+	return ps
+}
+
 func (ps *PartSet) Header() PartSetHeader {
 	if ps == nil {
 		return PartSetHeader{}
@@ -274,6 +357,9 @@ func (ps *PartSet) AddPart(part *Part) (bool, error) {
 	if part.Index >= ps.total {
 		return false, ErrPartSetUnexpectedIndex
 	}
+	if ps.dataParts > 0 && (ps.total != 2*ps.dataParts || ps.dataParts > MaxErasureDataParts) {
+		return false, ErrPartSetInvalidErasureTotal
+	}
 
 	// If part already exists, return false.
 	if ps.parts[part.Index] != nil {
@@ -289,10 +375,66 @@ func (ps *PartSet) AddPart(part *Part) (bool, error) {
 	ps.parts[part.Index] = part
 	ps.partsBitArray.SetIndex(int(part.Index), true)
 	ps.count++
-	ps.byteSize += int64(len(part.Bytes))
+	if ps.dataParts == 0 {
+		ps.byteSize += int64(len(part.Bytes))
+		return true, nil
+	}
+
+	ps.byteSize = ps.erasureByteSize()
+	if ps.count == ps.dataParts && !ps.reconstructFail {
+		ps.reconstructFail = !ps.reconstruct()
+	}
 	return true, nil
 }
 
+// erasureByteSize returns the size of the data of an erasure-coded part set:
+// the length it is framed with, once the first part is known, and the size
+// of the data parts received so far otherwise, which can't exceed it.
+func (ps *PartSet) erasureByteSize() int64 {
+	if first := ps.parts[0]; first != nil {
+		if len(first.Bytes) < erasureHeaderSize {
+			return 0
+		}
+		return int64(binary.BigEndian.Uint64(first.Bytes[1:erasureHeaderSize]))
+	}
+	var size int64
+	for _, part := range ps.parts[1:ps.dataParts] {
+		if part != nil {
+			size += int64(len(part.Bytes))
+		}
+	}
+	return tmmath.MaxInt64(size-erasureHeaderSize, 0)
+}
+
+// reconstruct completes an erasure-coded part set from the parts received,
+// of which there must be as many as data parts, and returns whether it could.
+// The reconstructed parts are verified against the part set hash, so that a
+// part set which was not encoded correctly must be received in full.
+func (ps *PartSet) reconstruct() bool {
+	shards := make([][]byte, ps.total)
+	for i, part := range ps.parts {
+		if part != nil {
+			shards[i] = part.Bytes
+		}
+	}
+	if err := erasure.Reconstruct(shards, int(ps.dataParts)); err != nil {
+		return false
+	}
+	root, proofs := merkle.ProofsFromByteSlices(shards)
+	if !bytes.Equal(root, ps.hash) {
+		return false
+	}
+	for i := range ps.parts {
+		if ps.parts[i] == nil {
+			ps.parts[i] = &Part{Index: uint32(i), Bytes: shards[i], Proof: *proofs[i]}
+			ps.partsBitArray.SetIndex(i, true)
+		}
+	}
+	ps.count = ps.total
+	ps.byteSize = ps.erasureByteSize()
+	return true
+}
+
 func (ps *PartSet) GetPart(index int) *Part {
 	ps.mtx.Lock()
 	defer ps.mtx.Unlock()
@@ -307,7 +449,37 @@ func (ps *PartSet) GetReader() io.Reader {
 	if !ps.IsComplete() {
 		panic("Cannot GetReader() on incomplete PartSet")
 	}
-	return NewPartSetReader(ps.parts)
+	if ps.dataParts == 0 {
+		return NewPartSetReader(ps.parts)
+	}
+	var bz []byte
+	for _, part := range ps.parts[:ps.dataParts] {
+		bz = append(bz, part.Bytes...)
+	}
+	data, err := PartSetData(bz)
+	if err != nil {
+		return iotest.ErrReader(err)
+	}
+	return bytes.NewReader(data)
+}
+
+// PartSetData returns the data of a part set from the concatenated bytes of
+// its parts, which are framed if the part set is erasure-coded. Unframed data
+// is returned as is: the encoding of a block, as a protobuf message, can't
+// start with the byte marking framed data.
+func PartSetData(bz []byte) ([]byte, error) {
+	if len(bz) == 0 || bz[0] != erasureMagic {
+		return bz, nil
+	}
+	if len(bz) < erasureHeaderSize {
+		return nil, errors.New("erasure-coded data too short")
+	}
+	size := binary.BigEndian.Uint64(bz[1:erasureHeaderSize])
+	if size > uint64(len(bz)-erasureHeaderSize) {
+		return nil, fmt.Errorf("erasure-coded data of %d bytes framed as %d bytes",
+			len(bz)-erasureHeaderSize, size)
+	}
+	return bz[erasureHeaderSize : erasureHeaderSize+int(size)], nil
 }
 
 type PartSetReader struct {
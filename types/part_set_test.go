@@ -2,6 +2,7 @@ package types
 
 import (
 	"io"
+	"math/rand"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -63,6 +64,76 @@ func TestBasicPartSet(t *testing.T) {
 	assert.Equal(t, data, data2)
 }
 
+func TestErasurePartSet(t *testing.T) {
+	data := tmrand.Bytes(testPartSize*10 + 100)
+	partSet := NewErasurePartSetFromData(data, testPartSize)
+	assert.EqualValues(t, 22, partSet.Total())
+	assert.True(t, partSet.IsComplete())
+	assert.EqualValues(t, len(data), partSet.ByteSize())
+
+	data2, err := io.ReadAll(partSet.GetReader())
+	require.NoError(t, err)
+	assert.Equal(t, data, data2)
+
+	params := DefaultBlockParams()
+	params.ErasureCoding = true
+
+	// Any half of the parts reconstruct the others.
+	for _, indexes := range [][]int{
+		rand.Perm(22)[:11],
+		{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+		{11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21},
+	} {
+		partSet2 := NewPartSetFromHeaderWithParams(partSet.Header(), params)
+		for i, index := range indexes {
+			assert.False(t, partSet2.IsComplete())
+			added, err := partSet2.AddPart(partSet.GetPart(index))
+			require.NoError(t, err)
+			require.True(t, added, "part %d (%d)", index, i)
+		}
+		assert.True(t, partSet2.IsComplete())
+		assert.EqualValues(t, partSet.Total(), partSet2.Count())
+		assert.EqualValues(t, len(data), partSet2.ByteSize())
+		for i := 0; i < int(partSet.Total()); i++ {
+			assert.Equal(t, partSet.GetPart(i), partSet2.GetPart(i))
+		}
+
+		data2, err := io.ReadAll(partSet2.GetReader())
+		require.NoError(t, err)
+		assert.Equal(t, data, data2)
+	}
+
+	// The data is spread over larger parts to stay within the maximum.
+	partSet = NewErasurePartSetFromData(data, MinBlockPartSizeBytes)
+	assert.EqualValues(t, 2*MaxErasureDataParts, partSet.Total())
+
+	// Erasure-coded part sets have an even number of parts.
+	partSet2 := NewPartSetFromHeaderWithParams(PartSetHeader{Total: 3, Hash: partSet.Hash()}, params)
+	added, err := partSet2.AddPart(partSet.GetPart(0))
+	assert.False(t, added)
+	assert.ErrorIs(t, err, ErrPartSetInvalidErasureTotal)
+}
+
+func TestPartSetData(t *testing.T) {
+	data := tmrand.Bytes(100)
+	data[0] = 0x0a
+	data2, err := PartSetData(data)
+	require.NoError(t, err)
+	assert.Equal(t, data, data2)
+
+	var buf []byte
+	partSet := NewErasurePartSetFromData(data, MinBlockPartSizeBytes)
+	for i := 0; i < int(partSet.Total()); i++ {
+		buf = append(buf, partSet.GetPart(i).Bytes...)
+	}
+	data2, err = PartSetData(buf)
+	require.NoError(t, err)
+	assert.Equal(t, data, data2)
+
+	_, err = PartSetData(buf[:50])
+	assert.Error(t, err)
+}
+
 func TestWrongProof(t *testing.T) {
 	// Construct random data of size partSize * 100
 	data := tmrand.Bytes(testPartSize * 100)
@@ -116,7 +187,7 @@ func TestPartValidateBasic(t *testing.T) {
 		expectErr    bool
 	}{
 		{"Good Part", func(pt *Part) {}, false},
-		{"Too big part", func(pt *Part) { pt.Bytes = make([]byte, BlockPartSizeBytes+1) }, true},
+		{"Too big part", func(pt *Part) { pt.Bytes = make([]byte, MaxBlockPartSizeBytes+1) }, true},
 		{"Too big proof", func(pt *Part) {
 			pt.Proof = merkle.Proof{
 				Total:    1,
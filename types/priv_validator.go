@@ -32,6 +32,22 @@ type PrivValidator interface {
 	SignProposal(ctx context.Context, chainID string, proposal *tmproto.Proposal) error
 }
 
+// NodeValidatorProofSigner is implemented by PrivValidators which can prove
+// that a node is operated by the validator. Remote signers do not support this.
+type NodeValidatorProofSigner interface {
+	SignNodeValidatorProof(ctx context.Context, chainID string, nodeID NodeID) (*NodeValidatorProof, error)
+}
+
+// KeyRotator is implemented by PrivValidators which can hold the next key of
+// the validator, to switch to it once the application rotated the key of the
+// validator in the validator set.
+type KeyRotator interface {
+	// GetNextPubKey returns the next public key, or nil if there is none.
+	GetNextPubKey(context.Context) (crypto.PubKey, error)
+	// RotateKey replaces the key with the next key.
+	RotateKey(context.Context) error
+}
+
 type PrivValidatorsByAddress []PrivValidator
 
 func (pvs PrivValidatorsByAddress) Len() int {
@@ -114,6 +130,15 @@ func (pv MockPV) SignProposal(ctx context.Context, chainID string, proposal *tmp
 	return nil
 }
 
+// Implements NodeValidatorProofSigner.
+func (pv MockPV) SignNodeValidatorProof(ctx context.Context, chainID string, nodeID NodeID) (*NodeValidatorProof, error) {
+	sig, err := pv.PrivKey.Sign(NodeValidatorProofSignBytes(chainID, nodeID))
+	if err != nil {
+		return nil, err
+	}
+	return &NodeValidatorProof{PubKey: pv.PrivKey.PubKey(), Signature: sig}, nil
+}
+
 func (pv MockPV) ExtractIntoValidator(votingPower int64) *Validator {
 	pubKey, _ := pv.GetPubKey(context.Background())
 	return &Validator{
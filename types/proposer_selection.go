@@ -0,0 +1,109 @@
+package types
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+)
+
+// The proposer selection algorithms registered by default.
+const (
+	// ProposerSelectionPriority selects the validator of the highest proposer
+	// priority, so that validators propose in proportion to their voting power.
+	// It is selected by an empty ValidatorParams.ProposerSelection.
+	ProposerSelectionPriority = "priority"
+
+	// ProposerSelectionWeightedRandom selects a validator at random, weighted
+	// by voting power, seeded by the hash of the last block. It is meant for
+	// experimentation on devnets.
+	ProposerSelectionWeightedRandom = "weighted-random"
+)
+
+// ProposerSelector selects the proposer of each round among a validator set.
+// The selection must be deterministic, as all validators must agree on it.
+type ProposerSelector interface {
+	// Proposer returns the proposer of the given height and round among vals,
+	// whose proposer priorities have been incremented once per height and
+	// round. seed is the hash of the last block, empty at the initial height.
+	Proposer(vals *ValidatorSet, height int64, round int32, seed []byte) *Validator
+}
+
+var proposerSelectors = struct {
+	sync.RWMutex
+	byName map[string]ProposerSelector
+}{
+	byName: map[string]ProposerSelector{
+		ProposerSelectionPriority:       PriorityProposerSelector{},
+		ProposerSelectionWeightedRandom: WeightedRandomProposerSelector{},
+	},
+}
+
+// RegisterProposerSelector registers a proposer selection algorithm, to be
+// selected by the validator.proposer_selection consensus param. The nodes of a
+// chain must all register it before it's selected.
+//
+// Should only be called in init() functions, as it panics on error.
+func RegisterProposerSelector(name string, selector ProposerSelector) {
+	if name == "" || selector == nil {
+		panic("proposer selector must have a name and be non-nil")
+	}
+	proposerSelectors.Lock()
+	defer proposerSelectors.Unlock()
+	if _, ok := proposerSelectors.byName[name]; ok {
+		panic(fmt.Sprintf("proposer selector %q already registered", name))
+	}
+	proposerSelectors.byName[name] = selector
+}
+
+// GetProposerSelector returns the proposer selection algorithm of the given
+// name, the priority-based one if the name is empty, or false if no algorithm
+// of that name is registered.
+func GetProposerSelector(name string) (ProposerSelector, bool) {
+	if name == "" {
+		name = ProposerSelectionPriority
+	}
+	proposerSelectors.RLock()
+	defer proposerSelectors.RUnlock()
+	selector, ok := proposerSelectors.byName[name]
+	return selector, ok
+}
+
+// PriorityProposerSelector selects the validator of the highest proposer
+// priority, as tracked by the validator set.
+type PriorityProposerSelector struct{}
+
+// Proposer implements ProposerSelector.
+func (PriorityProposerSelector) Proposer(vals *ValidatorSet, _ int64, _ int32, _ []byte) *Validator {
+	return vals.GetProposer()
+}
+
+// WeightedRandomProposerSelector selects a validator at random with a
+// probability proportional to its voting power. The randomness is derived
+// from the seed, height and round, so that each round has a new proposer.
+type WeightedRandomProposerSelector struct{}
+
+// Proposer implements ProposerSelector.
+func (WeightedRandomProposerSelector) Proposer(vals *ValidatorSet, height int64, round int32, seed []byte) *Validator {
+	if vals.IsNilOrEmpty() {
+		return nil
+	}
+
+	input := make([]byte, len(seed)+12)
+	copy(input, seed)
+	binary.BigEndian.PutUint64(input[len(seed):], uint64(height))
+	binary.BigEndian.PutUint32(input[len(seed)+8:], uint32(round))
+	hash := tmhash.Sum(input)
+
+	// the total voting power is well below 2^63, so the bias of the modulo is
+	// negligible
+	target := int64(binary.BigEndian.Uint64(hash) % uint64(vals.TotalVotingPower()))
+	for _, val := range vals.Validators {
+		if target < val.VotingPower {
+			return val.Copy()
+		}
+		target -= val.VotingPower
+	}
+	panic("voting power of the validators does not add up to their total")
+}
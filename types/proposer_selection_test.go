@@ -0,0 +1,78 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type firstProposerSelector struct{}
+
+func (firstProposerSelector) Proposer(vals *ValidatorSet, _ int64, _ int32, _ []byte) *Validator {
+	return vals.Validators[0].Copy()
+}
+
+func TestRegisterProposerSelector(t *testing.T) {
+	_, ok := GetProposerSelector("first")
+	require.False(t, ok)
+
+	RegisterProposerSelector("first", firstProposerSelector{})
+	t.Cleanup(func() {
+		proposerSelectors.Lock()
+		delete(proposerSelectors.byName, "first")
+		proposerSelectors.Unlock()
+	})
+	selector, ok := GetProposerSelector("first")
+	require.True(t, ok)
+	assert.Equal(t, firstProposerSelector{}, selector)
+
+	assert.Panics(t, func() { RegisterProposerSelector("first", firstProposerSelector{}) })
+	assert.Panics(t, func() { RegisterProposerSelector(ProposerSelectionPriority, firstProposerSelector{}) })
+
+	selector, ok = GetProposerSelector("")
+	require.True(t, ok)
+	assert.Equal(t, PriorityProposerSelector{}, selector)
+}
+
+func TestPriorityProposerSelector(t *testing.T) {
+	vals := NewValidatorSet([]*Validator{
+		newValidator([]byte("a"), 1),
+		newValidator([]byte("b"), 2),
+	})
+	assert.Equal(t, vals.GetProposer(), PriorityProposerSelector{}.Proposer(vals, 1, 0, nil))
+
+	vals.IncrementProposerPriority(1)
+	assert.Equal(t, vals.GetProposer(), PriorityProposerSelector{}.Proposer(vals, 1, 1, nil))
+}
+
+func TestWeightedRandomProposerSelector(t *testing.T) {
+	vals := NewValidatorSet([]*Validator{
+		newValidator([]byte("a"), 1),
+		newValidator([]byte("b"), 3),
+	})
+	selector := WeightedRandomProposerSelector{}
+	seed := []byte("last block hash")
+
+	// the selection is deterministic
+	proposer := selector.Proposer(vals, 1, 0, seed)
+	assert.Equal(t, proposer, selector.Proposer(vals.Copy(), 1, 0, seed))
+
+	// and proposers are selected in proportion to their voting power
+	counts := map[string]int{}
+	for height := int64(1); height <= 1000; height++ {
+		counts[string(selector.Proposer(vals, height, 0, seed).Address)]++
+	}
+	assert.InDelta(t, 250, counts["a"], 50)
+	assert.InDelta(t, 750, counts["b"], 50)
+
+	// as they are for each round of a height
+	counts = map[string]int{}
+	for round := int32(0); round < 1000; round++ {
+		counts[string(selector.Proposer(vals, 1, round, seed).Address)]++
+	}
+	assert.InDelta(t, 250, counts["a"], 50)
+	assert.InDelta(t, 750, counts["b"], 50)
+
+	assert.Nil(t, selector.Proposer(NewValidatorSet(nil), 1, 0, seed))
+}
@@ -49,14 +49,40 @@ var PB2TM = pb2tm{}
 
 type pb2tm struct{}
 
+// ValidatorUpdates converts the validator updates which do not rotate the key
+// of a validator. See KeyRotations for the others.
 func (pb2tm) ValidatorUpdates(vals []abci.ValidatorUpdate) ([]*Validator, error) {
-	tmVals := make([]*Validator, len(vals))
-	for i, v := range vals {
+	tmVals := make([]*Validator, 0, len(vals))
+	for _, v := range vals {
+		if v.NewPubKey != nil {
+			continue
+		}
 		pub, err := encoding.PubKeyFromProto(v.PubKey)
 		if err != nil {
 			return nil, err
 		}
-		tmVals[i] = NewValidator(pub, v.Power)
+		tmVals = append(tmVals, NewValidator(pub, v.Power))
 	}
 	return tmVals, nil
 }
+
+// KeyRotations converts the validator updates which rotate the key of a
+// validator, i.e. set a new public key.
+func (pb2tm) KeyRotations(vals []abci.ValidatorUpdate) ([]KeyRotation, error) {
+	var rotations []KeyRotation
+	for _, v := range vals {
+		if v.NewPubKey == nil {
+			continue
+		}
+		pub, err := encoding.PubKeyFromProto(v.PubKey)
+		if err != nil {
+			return nil, err
+		}
+		newPub, err := encoding.PubKeyFromProto(*v.NewPubKey)
+		if err != nil {
+			return nil, err
+		}
+		rotations = append(rotations, KeyRotation{PubKey: pub, NewPubKey: newPub})
+	}
+	return rotations, nil
+}
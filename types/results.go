@@ -52,3 +52,87 @@ func deterministicResponseDeliverTx(response *abci.ResponseDeliverTx) *abci.Resp
 		GasUsed:   response.GasUsed,
 	}
 }
+
+// Sources of the events of a block which were not emitted by a transaction.
+// Events emitted by a transaction have its index in the block as source.
+const (
+	EventSourceBeginBlock int64 = -1
+	EventSourceEndBlock   int64 = -2
+)
+
+// ABCIEvents are the events emitted while executing a block, in the order
+// they are committed to by the events hash: those of BeginBlock, those of
+// each transaction and those of EndBlock.
+type ABCIEvents []EventProof
+
+// NewEvents collects the events emitted while executing a block.
+func NewEvents(
+	beginBlock []abci.Event,
+	txs []*abci.ResponseDeliverTx,
+	endBlock []abci.Event,
+) ABCIEvents {
+	var events ABCIEvents
+	for _, ev := range beginBlock {
+		events = append(events, EventProof{Source: EventSourceBeginBlock, Event: ev})
+	}
+	for i, tx := range txs {
+		if tx == nil {
+			continue
+		}
+		for _, ev := range tx.Events {
+			events = append(events, EventProof{Source: int64(i), Event: ev})
+		}
+	}
+	for _, ev := range endBlock {
+		events = append(events, EventProof{Source: EventSourceEndBlock, Event: ev})
+	}
+	return events
+}
+
+// Hash returns a merkle hash of all events.
+func (a ABCIEvents) Hash() []byte {
+	return merkle.HashFromByteSlices(a.toByteSlices())
+}
+
+// Prove returns the events along with merkle proofs of their inclusion.
+func (a ABCIEvents) Prove() []EventProof {
+	_, proofs := merkle.ProofsFromByteSlices(a.toByteSlices())
+	res := make([]EventProof, len(a))
+	for i, ev := range a {
+		res[i] = EventProof{Source: ev.Source, Event: ev.Event, Proof: *proofs[i]}
+	}
+	return res
+}
+
+func (a ABCIEvents) toByteSlices() [][]byte {
+	bzs := make([][]byte, len(a))
+	for i, ev := range a {
+		bzs[i] = ev.Bytes()
+	}
+	return bzs
+}
+
+// EventProof is an event emitted while executing a block, along with a merkle
+// proof of its inclusion in the events hash of the block.
+type EventProof struct {
+	// Index of the transaction which emitted the event, or one of the
+	// EventSource constants.
+	Source int64        `json:"source"`
+	Event  abci.Event   `json:"event"`
+	Proof  merkle.Proof `json:"proof"`
+}
+
+// Verify checks that the event is included in the given events hash.
+func (p EventProof) Verify(eventsHash []byte) error {
+	return p.Proof.Verify(eventsHash, p.Bytes())
+}
+
+// Bytes returns the canonical encoding of the event committed to by the
+// events hash: its source followed by the protobuf encoding of the event.
+func (p EventProof) Bytes() []byte {
+	bz, err := p.Event.Marshal()
+	if err != nil {
+		panic(err)
+	}
+	return append(cdcEncode(p.Source), bz...)
+}
@@ -52,3 +52,40 @@ func TestABCIResults(t *testing.T) {
 		assert.NoError(t, valid, "%d", i)
 	}
 }
+
+func TestABCIEvents(t *testing.T) {
+	event := func(typ string) abci.Event {
+		return abci.Event{Type: typ, Attributes: []abci.EventAttribute{{Key: "key", Value: typ, Index: true}}}
+	}
+	txs := []*abci.ResponseDeliverTx{
+		{Events: []abci.Event{event("tx0")}},
+		{},
+		{Events: []abci.Event{event("tx2a"), event("tx2b")}},
+	}
+	events := NewEvents([]abci.Event{event("begin")}, txs, []abci.Event{event("end")})
+	require.Len(t, events, 5)
+	assert.Equal(t, EventSourceBeginBlock, events[0].Source)
+	assert.EqualValues(t, 0, events[1].Source)
+	assert.EqualValues(t, 2, events[2].Source)
+	assert.EqualValues(t, 2, events[3].Source)
+	assert.Equal(t, EventSourceEndBlock, events[4].Source)
+
+	root := events.Hash()
+	assert.NotEmpty(t, root)
+
+	proofs := events.Prove()
+	require.Len(t, proofs, len(events))
+	for i, proof := range proofs {
+		assert.NoError(t, proof.Verify(root), "%d", i)
+	}
+
+	// An event claimed to come from another source must not verify.
+	forged := proofs[2]
+	forged.Source = 0
+	assert.Error(t, forged.Verify(root))
+
+	// Neither must a modified event.
+	forged = proofs[1]
+	forged.Event = event("forged")
+	assert.Error(t, forged.Verify(root))
+}
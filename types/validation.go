@@ -83,6 +83,32 @@ func VerifyCommitLight(chainID string, vals *ValidatorSet, blockID BlockID,
 		ignore, count, false, true)
 }
 
+// VerifyCommitVotes verifies +2/3 of the given validator set signed this
+// commit, like VerifyCommitLight, by adding all its signatures to a vote set.
+// The signatures are verified in a batch where possible.
+func VerifyCommitVotes(chainID string, vals *ValidatorSet, blockID BlockID,
+	height int64, commit *Commit) error {
+	// run a basic validation of the arguments
+	if err := verifyBasicValsAndCommit(vals, commit, height, blockID); err != nil {
+		return err
+	}
+
+	voteSet, err := VoteSetFromCommit(chainID, commit, vals)
+	if err != nil {
+		return err
+	}
+	if maj, ok := voteSet.TwoThirdsMajority(); !ok || !maj.Equals(blockID) {
+		var got int64
+		for idx, commitSig := range commit.Signatures {
+			if commitSig.ForBlock() {
+				got += vals.Validators[idx].VotingPower
+			}
+		}
+		return ErrNotEnoughVotingPowerSigned{Got: got, Needed: vals.TotalVotingPower() * 2 / 3}
+	}
+	return nil
+}
+
 // VerifyCommitLightTrusting verifies that trustLevel of the validator set signed
 // this commit.
 //
@@ -145,7 +171,8 @@ func ValidateHash(h []byte) error {
 
 // verifyCommitBatch batch verifies commits.  This routine is equivalent
 // to verifyCommitSingle in behavior, just faster iff every signature in the
-// batch is valid.
+// batch is valid. If any signature can't be batched or the batch fails, it
+// falls back to verifyCommitSingle to find the invalid signature.
 //
 // Note: The caller is responsible for checking to see if this routine is
 // usable via `shouldVerifyBatch(vals, commit)`.
@@ -164,7 +191,6 @@ func verifyCommitBatch(
 		valIdx             int32
 		talliedVotingPower int64
 		seenVals           = make(map[int32]int, len(commit.Signatures))
-		batchSigIdxs       = make([]int, 0, len(commit.Signatures))
 	)
 	// attempt to create a batch verifier
 	bv, ok := batch.CreateBatchVerifier(vals.GetProposer().PubKey)
@@ -205,11 +231,13 @@ func verifyCommitBatch(
 		// Validate signature.
 		voteSignBytes := commit.VoteSignBytes(chainID, int32(idx))
 
-		// add the key, sig and message to the verifier
+		// add the key, sig and message to the verifier. If it can't be batched
+		// (e.g. the validator set mixes key types), fallback to single
+		// verification.
 		if err := bv.Add(val.PubKey, voteSignBytes, commitSig.Signature); err != nil {
-			return err
+			return verifyCommitSingle(chainID, vals, commit, votingPowerNeeded,
+				ignoreSig, countSig, countAllSignatures, lookUpByIndex)
 		}
-		batchSigIdxs = append(batchSigIdxs, idx)
 
 		// If this signature counts then add the voting power of the validator
 		// to the tally
@@ -231,28 +259,17 @@ func verifyCommitBatch(
 	}
 
 	// attempt to verify the batch.
-	ok, validSigs := bv.Verify()
+	ok, _ = bv.Verify()
 	if ok {
 		// success
 		return nil
 	}
 
-	// one or more of the signatures is invalid, find and return the first
-	// invalid signature.
-	for i, ok := range validSigs {
-		if !ok {
-			// go back from the batch index to the commit.Signatures index
-			idx := batchSigIdxs[i]
-			sig := commit.Signatures[idx]
-			return fmt.Errorf("wrong signature (#%d): %X", idx, sig)
-		}
-	}
-
-	// execution reaching here is a bug, and one of the following has
-	// happened:
-	//  * non-zero tallied voting power, empty batch (impossible?)
-	//  * bv.Verify() returned `false, []bool{true, ..., true}` (BUG)
-	return fmt.Errorf("BUG: batch verification failed with no invalid signatures")
+	// one or more of the signatures is invalid: fallback to single
+	// verification, which checks the same signatures in the same order and
+	// thus returns the first invalid one.
+	return verifyCommitSingle(chainID, vals, commit, votingPowerNeeded,
+		ignoreSig, countSig, countAllSignatures, lookUpByIndex)
 }
 
 // Single Verification
@@ -2,18 +2,22 @@ package types
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
 	tmmath "github.com/tendermint/tendermint/libs/math"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 )
 
-// Check VerifyCommit, VerifyCommitLight and VerifyCommitLightTrusting basic
-// verification.
+// Check VerifyCommit, VerifyCommitLight, VerifyCommitVotes and
+// VerifyCommitLightTrusting basic verification.
 func TestValidatorSet_VerifyCommit_All(t *testing.T) {
 	var (
 		round  = int32(0)
@@ -115,6 +119,13 @@ func TestValidatorSet_VerifyCommit_All(t *testing.T) {
 				assert.NoError(t, err, "VerifyCommitLight")
 			}
 
+			err = VerifyCommitVotes(chainID, valSet, blockID, height, commit)
+			if tc.expErr {
+				assert.Error(t, err, "VerifyCommitVotes")
+			} else {
+				assert.NoError(t, err, "VerifyCommitVotes")
+			}
+
 			// only a subsection of the tests apply to VerifyCommitLightTrusting
 			if totalVotes != tc.valSize || !tc.blockID.Equals(blockID) || tc.height != height {
 				tc.expErr = false
@@ -157,6 +168,52 @@ func TestValidatorSet_VerifyCommit_CheckAllSignatures(t *testing.T) {
 	}
 }
 
+// Check that a commit from validators with mixed key types, which can't be
+// batch verified, falls back to single verification.
+func TestValidatorSet_VerifyCommit_MixedKeyTypes(t *testing.T) {
+	var (
+		chainID = "test_chain_id"
+		h       = int64(3)
+		blockID = makeBlockIDRandom()
+	)
+
+	// the ed25519 validator with the most voting power is the proposer, so
+	// batch verification is attempted
+	privKeys := []crypto.PrivKey{ed25519.GenPrivKey(), ed25519.GenPrivKey(), secp256k1.GenPrivKey(), ed25519.GenPrivKey()}
+	valz := make([]*Validator, len(privKeys))
+	for i, privKey := range privKeys {
+		valz[i] = NewValidator(privKey.PubKey(), int64(10+10*(len(privKeys)-i)))
+	}
+	valSet := NewValidatorSet(valz)
+	require.True(t, shouldBatchVerify(valSet, &Commit{Signatures: make([]CommitSig, len(privKeys))}))
+
+	// order the signers like the validator set
+	vals := make([]PrivValidator, len(privKeys))
+	for _, privKey := range privKeys {
+		idx, _ := valSet.GetByAddress(privKey.PubKey().Address())
+		vals[idx] = NewMockPVWithParams(privKey, false, false)
+	}
+
+	voteSet := NewVoteSet(chainID, h, 0, tmproto.PrecommitType, valSet)
+	commit, err := makeCommit(blockID, h, 0, voteSet, vals, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, valSet.VerifyCommit(chainID, blockID, h, commit))
+	require.NoError(t, valSet.VerifyCommitLight(chainID, blockID, h, commit))
+
+	// malleate the secp256k1 signature
+	idx, _ := valSet.GetByAddress(privKeys[2].PubKey().Address())
+	vote := voteSet.GetByIndex(idx)
+	v := vote.ToProto()
+	require.NoError(t, vals[idx].SignVote(context.Background(), "CentaurusA", v))
+	vote.Signature = v.Signature
+	commit.Signatures[idx] = vote.CommitSig()
+
+	err = valSet.VerifyCommit(chainID, blockID, h, commit)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), fmt.Sprintf("wrong signature (#%d)", idx))
+	}
+}
+
 func TestValidatorSet_VerifyCommitLight_ReturnsAsSoonAsMajorityOfVotingPowerSigned(t *testing.T) {
 	var (
 		chainID = "test_chain_id"
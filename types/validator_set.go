@@ -9,6 +9,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/merkle"
 	tmmath "github.com/tendermint/tendermint/libs/math"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
@@ -652,6 +653,129 @@ func (vals *ValidatorSet) UpdateWithChangeSet(changes []*Validator) error {
 	return vals.updateWithChangeSet(changes, true)
 }
 
+// KeyRotation changes the public key of a validator from PubKey to NewPubKey,
+// keeping its voting power.
+type KeyRotation struct {
+	PubKey    crypto.PubKey
+	NewPubKey crypto.PubKey
+}
+
+// RotateKeys replaces the public keys of validators in the set according to
+// 'rotations'. The rotated validators keep their voting power and proposer
+// priority, so the rotation does not change the proposer order nor the total
+// voting power. The rotated validator must be in the set and its new key must
+// not be used by a validator of the set, nor rotated to twice.
+// If an error is detected, it is returned and the validator set is not
+// changed.
+func (vals *ValidatorSet) RotateKeys(rotations []KeyRotation) error {
+	if len(rotations) == 0 {
+		return nil
+	}
+
+	validators := validatorListCopy(vals.Validators)
+	proposer := vals.Proposer
+	seen := make(map[string]bool, 2*len(rotations))
+	for _, rot := range rotations {
+		if rot.PubKey == nil || rot.NewPubKey == nil {
+			return errors.New("key rotation with nil public key")
+		}
+		addr, newAddr := rot.PubKey.Address(), rot.NewPubKey.Address()
+		if seen[string(addr)] || seen[string(newAddr)] {
+			return fmt.Errorf("duplicate key rotation of validator %v", addr)
+		}
+		seen[string(addr)], seen[string(newAddr)] = true, true
+
+		if vals.HasAddress(newAddr) {
+			return fmt.Errorf("cannot rotate validator %v to key of validator %v", addr, newAddr)
+		}
+		idx, _ := vals.GetByAddress(addr)
+		if idx < 0 {
+			return fmt.Errorf("cannot rotate key of unknown validator %v", addr)
+		}
+
+		val := validators[idx]
+		val.Address = newAddr
+		val.PubKey = rot.NewPubKey
+		if proposer != nil && bytes.Equal(proposer.Address, addr) {
+			proposer = val
+		}
+	}
+
+	sort.Sort(ValidatorsByVotingPower(validators))
+	vals.Validators = validators
+	vals.Proposer = proposer
+	return nil
+}
+
+// ValidatorPowerChange is the change of the voting power of a validator
+// between two validator sets. The previous power of an added validator, and
+// the power of a removed validator, are 0.
+type ValidatorPowerChange struct {
+	Address       Address       `json:"address"`
+	PubKey        crypto.PubKey `json:"pub_key"`
+	PreviousPower int64         `json:"previous_power"`
+	VotingPower   int64         `json:"voting_power"`
+}
+
+// ValidatorSetChange lists the validators added to, removed from and whose
+// voting power is updated in a validator set by the validator updates
+// returned at a height. The changed validator set takes effect at height+2.
+// A key rotation is listed as the removal of the validator with the old key
+// and the addition of the validator with the new key.
+type ValidatorSetChange struct {
+	Height  int64                  `json:"height"`
+	Added   []ValidatorPowerChange `json:"added"`
+	Updated []ValidatorPowerChange `json:"updated"`
+	Removed []ValidatorPowerChange `json:"removed"`
+}
+
+// NewValidatorSetChange returns the change from the validator set prev to
+// next, made by the validator updates returned at height. The added and
+// updated validators are in the order of next, and the removed validators in
+// the order of prev.
+func NewValidatorSetChange(height int64, prev, next *ValidatorSet) ValidatorSetChange {
+	change := ValidatorSetChange{Height: height}
+
+	prevPowers := make(map[string]int64, prev.Size())
+	for _, val := range prev.Validators {
+		prevPowers[string(val.Address)] = val.VotingPower
+	}
+	for _, val := range next.Validators {
+		prevPower, ok := prevPowers[string(val.Address)]
+		delete(prevPowers, string(val.Address))
+		switch {
+		case !ok:
+			change.Added = append(change.Added, ValidatorPowerChange{
+				Address:     val.Address,
+				PubKey:      val.PubKey,
+				VotingPower: val.VotingPower,
+			})
+		case prevPower != val.VotingPower:
+			change.Updated = append(change.Updated, ValidatorPowerChange{
+				Address:       val.Address,
+				PubKey:        val.PubKey,
+				PreviousPower: prevPower,
+				VotingPower:   val.VotingPower,
+			})
+		}
+	}
+	for _, val := range prev.Validators {
+		if _, ok := prevPowers[string(val.Address)]; ok {
+			change.Removed = append(change.Removed, ValidatorPowerChange{
+				Address:       val.Address,
+				PubKey:        val.PubKey,
+				PreviousPower: val.VotingPower,
+			})
+		}
+	}
+	return change
+}
+
+// IsEmpty returns true if no validator is changed.
+func (c ValidatorSetChange) IsEmpty() bool {
+	return len(c.Added) == 0 && len(c.Updated) == 0 && len(c.Removed) == 0
+}
+
 // VerifyCommit verifies +2/3 of the set had signed the given commit and all
 // other signatures are valid
 func (vals *ValidatorSet) VerifyCommit(chainID string, blockID BlockID,
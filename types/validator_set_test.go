@@ -1311,6 +1311,88 @@ func TestNewValidatorSetFromExistingValidators(t *testing.T) {
 	assert.Equal(t, valSet.CopyIncrementProposerPriority(3), existingValSet.CopyIncrementProposerPriority(3))
 }
 
+func TestValidatorSetRotateKeys(t *testing.T) {
+	pubKeys := make([]crypto.PubKey, 3)
+	vals := make([]*Validator, 3)
+	for i := range vals {
+		pubKeys[i] = ed25519.GenPrivKey().PubKey()
+		vals[i] = NewValidator(pubKeys[i], int64(i+1))
+	}
+	valSet := NewValidatorSet(vals)
+	valSet.IncrementProposerPriority(2)
+
+	newPubKey := ed25519.GenPrivKey().PubKey()
+	testCases := []struct {
+		name      string
+		rotations []KeyRotation
+	}{
+		{"unknown validator", []KeyRotation{{PubKey: newPubKey, NewPubKey: ed25519.GenPrivKey().PubKey()}}},
+		{"existing new key", []KeyRotation{{PubKey: pubKeys[0], NewPubKey: pubKeys[1]}}},
+		{"duplicate rotation", []KeyRotation{
+			{PubKey: pubKeys[0], NewPubKey: newPubKey},
+			{PubKey: pubKeys[1], NewPubKey: newPubKey},
+		}},
+		{"nil key", []KeyRotation{{PubKey: pubKeys[0]}}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vs := valSet.Copy()
+			assert.Error(t, vs.RotateKeys(tc.rotations))
+			assert.Equal(t, valSet, vs, "validator set must not change on error")
+		})
+	}
+
+	vs := valSet.Copy()
+	_, oldVal := vs.GetByAddress(pubKeys[0].Address())
+	require.NoError(t, vs.RotateKeys([]KeyRotation{{PubKey: pubKeys[0], NewPubKey: newPubKey}}))
+	assert.False(t, vs.HasAddress(pubKeys[0].Address()))
+	_, val := vs.GetByAddress(newPubKey.Address())
+	require.NotNil(t, val)
+	assert.Equal(t, newPubKey, val.PubKey)
+	assert.Equal(t, oldVal.VotingPower, val.VotingPower)
+	assert.Equal(t, oldVal.ProposerPriority, val.ProposerPriority)
+	assert.Equal(t, valSet.TotalVotingPower(), vs.TotalVotingPower())
+	assert.NoError(t, vs.ValidateBasic())
+}
+
+func TestNewValidatorSetChange(t *testing.T) {
+	pubKeys := make([]crypto.PubKey, 4)
+	for i := range pubKeys {
+		pubKeys[i] = ed25519.GenPrivKey().PubKey()
+	}
+	prev := NewValidatorSet([]*Validator{
+		NewValidator(pubKeys[0], 10),
+		NewValidator(pubKeys[1], 20),
+		NewValidator(pubKeys[2], 30),
+	})
+
+	next := prev.Copy()
+	require.NoError(t, next.UpdateWithChangeSet([]*Validator{
+		NewValidator(pubKeys[0], 0),
+		NewValidator(pubKeys[1], 25),
+		NewValidator(pubKeys[3], 5),
+	}))
+	next.IncrementProposerPriority(1)
+
+	change := NewValidatorSetChange(7, prev, next)
+	assert.False(t, change.IsEmpty())
+	assert.Equal(t, ValidatorSetChange{
+		Height: 7,
+		Added: []ValidatorPowerChange{
+			{Address: pubKeys[3].Address(), PubKey: pubKeys[3], VotingPower: 5},
+		},
+		Updated: []ValidatorPowerChange{
+			{Address: pubKeys[1].Address(), PubKey: pubKeys[1], PreviousPower: 20, VotingPower: 25},
+		},
+		Removed: []ValidatorPowerChange{
+			{Address: pubKeys[0].Address(), PubKey: pubKeys[0], PreviousPower: 10},
+		},
+	}, change)
+
+	// the proposer priorities are not a change of the validator set
+	assert.True(t, NewValidatorSetChange(7, prev, prev.CopyIncrementProposerPriority(1)).IsEmpty())
+}
+
 func TestValSetUpdateOverflowRelated(t *testing.T) {
 	testCases := []testVSetCfg{
 		{
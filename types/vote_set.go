@@ -6,6 +6,8 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/batch"
 	"github.com/tendermint/tendermint/libs/bits"
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
@@ -152,8 +154,25 @@ func (voteSet *VoteSet) AddVote(vote *Vote) (added bool, err error) {
 
 // NOTE: Validates as much as possible before attempting to verify the signature.
 func (voteSet *VoteSet) addVote(vote *Vote) (added bool, err error) {
+	val, err := voteSet.checkVote(vote)
+	if err != nil || val == nil {
+		return false, err
+	}
+
+	// Check signature.
+	if err := vote.Verify(voteSet.chainID, val.PubKey); err != nil {
+		return false, fmt.Errorf("failed to verify vote with ChainID %s and PubKey %s: %w", voteSet.chainID, val.PubKey, err)
+	}
+
+	return voteSet.addCheckedVote(vote, val)
+}
+
+// checkVote validates everything about the vote but its signature, and returns
+// the validator that cast it. A nil validator and error means the vote is a
+// duplicate of one already in the set.
+func (voteSet *VoteSet) checkVote(vote *Vote) (*Validator, error) {
 	if vote == nil {
-		return false, ErrVoteNil
+		return nil, ErrVoteNil
 	}
 	valIndex := vote.ValidatorIndex
 	valAddr := vote.ValidatorAddress
@@ -161,16 +180,16 @@ func (voteSet *VoteSet) addVote(vote *Vote) (added bool, err error) {
 
 	// Ensure that validator index was set
 	if valIndex < 0 {
-		return false, fmt.Errorf("index < 0: %w", ErrVoteInvalidValidatorIndex)
+		return nil, fmt.Errorf("index < 0: %w", ErrVoteInvalidValidatorIndex)
 	} else if len(valAddr) == 0 {
-		return false, fmt.Errorf("empty address: %w", ErrVoteInvalidValidatorAddress)
+		return nil, fmt.Errorf("empty address: %w", ErrVoteInvalidValidatorAddress)
 	}
 
 	// Make sure the step matches.
 	if (vote.Height != voteSet.height) ||
 		(vote.Round != voteSet.round) ||
 		(vote.Type != voteSet.signedMsgType) {
-		return false, fmt.Errorf("expected %d/%d/%d, but got %d/%d/%d: %w",
+		return nil, fmt.Errorf("expected %d/%d/%d, but got %d/%d/%d: %w",
 			voteSet.height, voteSet.round, voteSet.signedMsgType,
 			vote.Height, vote.Round, vote.Type, ErrVoteUnexpectedStep)
 	}
@@ -178,14 +197,14 @@ func (voteSet *VoteSet) addVote(vote *Vote) (added bool, err error) {
 	// Ensure that signer is a validator.
 	lookupAddr, val := voteSet.valSet.GetByIndex(valIndex)
 	if val == nil {
-		return false, fmt.Errorf(
+		return nil, fmt.Errorf(
 			"cannot find validator %d in valSet of size %d: %w",
 			valIndex, voteSet.valSet.Size(), ErrVoteInvalidValidatorIndex)
 	}
 
 	// Ensure that the signer has the right address.
 	if !bytes.Equal(valAddr, lookupAddr) {
-		return false, fmt.Errorf(
+		return nil, fmt.Errorf(
 			"vote.ValidatorAddress (%X) does not match address (%X) for vote.ValidatorIndex (%d)\n"+
 				"Ensure the genesis file is correct across all validators: %w",
 			valAddr, lookupAddr, valIndex, ErrVoteInvalidValidatorAddress)
@@ -194,18 +213,19 @@ func (voteSet *VoteSet) addVote(vote *Vote) (added bool, err error) {
 	// If we already know of this vote, return false.
 	if existing, ok := voteSet.getVote(valIndex, blockKey); ok {
 		if bytes.Equal(existing.Signature, vote.Signature) {
-			return false, nil // duplicate
+			return nil, nil // duplicate
 		}
-		return false, fmt.Errorf("existing vote: %v; new vote: %v: %w", existing, vote, ErrVoteNonDeterministicSignature)
+		return nil, fmt.Errorf("existing vote: %v; new vote: %v: %w", existing, vote, ErrVoteNonDeterministicSignature)
 	}
 
-	// Check signature.
-	if err := vote.Verify(voteSet.chainID, val.PubKey); err != nil {
-		return false, fmt.Errorf("failed to verify vote with ChainID %s and PubKey %s: %w", voteSet.chainID, val.PubKey, err)
-	}
+	return val, nil
+}
 
+// addCheckedVote adds a vote which passed checkVote and whose signature was
+// verified.
+func (voteSet *VoteSet) addCheckedVote(vote *Vote, val *Validator) (added bool, err error) {
 	// Add vote and get conflicting vote if any.
-	added, conflicting := voteSet.addVerifiedVote(vote, blockKey, val.VotingPower)
+	added, conflicting := voteSet.addVerifiedVote(vote, vote.BlockID.Key(), val.VotingPower)
 	if conflicting != nil {
 		return added, NewConflictingVoteError(conflicting, vote)
 	}
@@ -215,6 +235,80 @@ func (voteSet *VoteSet) addVote(vote *Vote) (added bool, err error) {
 	return added, nil
 }
 
+// AddVotes adds several votes at once, verifying their signatures in a batch
+// when the validators' key type supports it (e.g. ed25519 and sr25519, but not
+// secp256k1). If the batch fails, or for keys that don't support batching, the
+// signatures are verified individually so that only the invalid votes are
+// rejected.
+//
+// The results for each vote are those AddVote would have returned, as if the
+// votes were added in order.
+// NOTE: VoteSet must not be nil
+func (voteSet *VoteSet) AddVotes(votes []*Vote) (added []bool, errs []error) {
+	if voteSet == nil {
+		panic("AddVotes() on nil VoteSet")
+	}
+	voteSet.mtx.Lock()
+	defer voteSet.mtx.Unlock()
+
+	added = make([]bool, len(votes))
+	errs = make([]error, len(votes))
+	vals := make([]*Validator, len(votes))
+	verified := make([]bool, len(votes))
+
+	// Validate the votes before verifying any signature. Votes failing these
+	// checks are rejected regardless of the ones preceding them.
+	var (
+		bv       crypto.BatchVerifier
+		batchIdx []int
+	)
+	for i, vote := range votes {
+		vals[i], errs[i] = voteSet.checkVote(vote)
+		if vals[i] == nil {
+			continue
+		}
+		if bv == nil {
+			bv, _ = batch.CreateBatchVerifier(vals[i].PubKey)
+		}
+		if bv == nil || bv.Add(vals[i].PubKey, VoteSignBytes(voteSet.chainID, vote.ToProto()), vote.Signature) != nil {
+			// not batchable, verify on its own below
+			continue
+		}
+		batchIdx = append(batchIdx, i)
+	}
+
+	if len(batchIdx) >= batchVerifyThreshold {
+		if ok, _ := bv.Verify(); ok {
+			for _, i := range batchIdx {
+				verified[i] = true
+			}
+		}
+	}
+
+	for i, vote := range votes {
+		if vals[i] == nil {
+			continue
+		}
+		// Re-check the votes now that the preceding ones were added, since a
+		// vote may have become a duplicate.
+		val, err := voteSet.checkVote(vote)
+		if err != nil || val == nil {
+			errs[i] = err
+			continue
+		}
+		if !verified[i] {
+			if err := vote.Verify(voteSet.chainID, val.PubKey); err != nil {
+				errs[i] = fmt.Errorf("failed to verify vote with ChainID %s and PubKey %s: %w",
+					voteSet.chainID, val.PubKey, err)
+				continue
+			}
+		}
+		added[i], errs[i] = voteSet.addCheckedVote(vote, val)
+	}
+
+	return added, errs
+}
+
 // Returns (vote, true) if vote exists for valIndex and blockKey.
 func (voteSet *VoteSet) getVote(valIndex int32, blockKey string) (vote *Vote, ok bool) {
 	if existing := voteSet.votes[valIndex]; existing != nil && existing.BlockID.Key() == blockKey {
@@ -3,6 +3,7 @@ package types
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"sort"
 	"testing"
 
@@ -476,6 +477,109 @@ func TestVoteSet_MakeCommit(t *testing.T) {
 	}
 }
 
+func TestVoteSet_AddVotes(t *testing.T) {
+	height, round := int64(1), int32(0)
+	voteSet, _, privValidators := randVoteSet(height, round, tmproto.PrecommitType, 10, 1)
+	voteProto := &Vote{
+		ValidatorAddress: nil,
+		ValidatorIndex:   -1,
+		Height:           height,
+		Round:            round,
+		Timestamp:        tmtime.Now(),
+		Type:             tmproto.PrecommitType,
+		BlockID:          BlockID{crypto.CRandBytes(32), PartSetHeader{123, crypto.CRandBytes(32)}},
+	}
+
+	votes := make([]*Vote, 0, 9)
+	for i := int32(0); i < 8; i++ {
+		pv, err := privValidators[i].GetPubKey(context.Background())
+		require.NoError(t, err)
+		vote := withValidator(voteProto, pv.Address(), i)
+		v := vote.ToProto()
+		require.NoError(t, privValidators[i].SignVote(context.Background(), voteSet.ChainID(), v))
+		vote.Signature = v.Signature
+		votes = append(votes, vote)
+	}
+	// a duplicate vote is not added twice
+	votes = append(votes, votes[0])
+	// the batch fails because of an invalid signature, and only the
+	// corresponding vote is rejected
+	votes[3].Signature = votes[4].Signature
+	// a vote for another height is rejected before verification
+	votes[5] = withHeight(votes[5], height+1)
+
+	added, errs := voteSet.AddVotes(votes)
+	require.Len(t, added, len(votes))
+	require.Len(t, errs, len(votes))
+	for i := range votes {
+		switch i {
+		case 3:
+			assert.False(t, added[i])
+			assert.ErrorIs(t, errs[i], ErrVoteInvalidSignature)
+		case 5:
+			assert.False(t, added[i])
+			assert.ErrorIs(t, errs[i], ErrVoteUnexpectedStep)
+		case 8:
+			assert.False(t, added[i])
+			assert.NoError(t, errs[i])
+		default:
+			assert.True(t, added[i], i)
+			assert.NoError(t, errs[i], i)
+		}
+	}
+	assert.Equal(t, "BA{10:xxx_x_xx__}", voteSet.BitArray().String())
+	assert.False(t, voteSet.HasTwoThirdsMajority())
+}
+
+func BenchmarkVoteSet_AddVotes(b *testing.B) {
+	for _, n := range []int{8, 64, 1024} {
+		n := n
+		height, round := int64(1), int32(0)
+		voteSet, valSet, privValidators := randVoteSet(height, round, tmproto.PrecommitType, n, 10)
+		blockID := makeBlockIDRandom()
+
+		votes := make([]*Vote, n)
+		for i := range votes {
+			pubKey, err := privValidators[i].GetPubKey(context.Background())
+			require.NoError(b, err)
+			vote := &Vote{
+				ValidatorAddress: pubKey.Address(),
+				ValidatorIndex:   int32(i),
+				Height:           height,
+				Round:            round,
+				Timestamp:        tmtime.Now(),
+				Type:             tmproto.PrecommitType,
+				BlockID:          blockID,
+			}
+			v := vote.ToProto()
+			require.NoError(b, privValidators[i].SignVote(context.Background(), voteSet.ChainID(), v))
+			vote.Signature = v.Signature
+			votes[i] = vote
+		}
+
+		b.Run(fmt.Sprintf("one by one %d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				voteSet := NewVoteSet(voteSet.ChainID(), height, round, tmproto.PrecommitType, valSet)
+				for _, vote := range votes {
+					if _, err := voteSet.AddVote(vote); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("batch %d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				voteSet := NewVoteSet(voteSet.ChainID(), height, round, tmproto.PrecommitType, valSet)
+				if _, errs := voteSet.AddVotes(votes); errs[0] != nil {
+					b.Fatal(errs[0])
+				}
+			}
+		})
+	}
+}
+
 // NOTE: privValidators are in order
 func randVoteSet(
 	height int64,